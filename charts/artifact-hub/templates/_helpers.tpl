@@ -75,3 +75,23 @@ longest resource name ("db-migrator-install" = 19 chars).
 {{- end -}}
 {{- end -}}
 
+
+{{/*
+Proxy environment variables
+Environment variables used to configure the proxy used for outbound traffic.
+If no proxy has been configured, this template results in an empty string.
+*/}}
+{{- define "chart.proxyEnv" -}}
+{{- with .Values.proxy.httpProxy }}
+- name: HTTP_PROXY
+  value: {{ . | quote }}
+{{- end }}
+{{- with .Values.proxy.httpsProxy }}
+- name: HTTPS_PROXY
+  value: {{ . | quote }}
+{{- end }}
+{{- with .Values.proxy.noProxy }}
+- name: NO_PROXY
+  value: {{ . | quote }}
+{{- end }}
+{{- end -}}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,19 +11,34 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/artifacthub/hub/internal/abuse"
+	"github.com/artifacthub/hub/internal/admin"
 	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/audit"
 	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/captcha"
+	"github.com/artifacthub/hub/internal/chartmirror"
+	"github.com/artifacthub/hub/internal/comment"
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/event"
 	"github.com/artifacthub/hub/internal/handlers"
 	"github.com/artifacthub/hub/internal/hub"
-	"github.com/artifacthub/hub/internal/img/pg"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/img/objstore"
 	"github.com/artifacthub/hub/internal/notification"
 	"github.com/artifacthub/hub/internal/org"
 	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/pulls"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/review"
+	"github.com/artifacthub/hub/internal/savedsearch"
+	"github.com/artifacthub/hub/internal/scim"
+	"github.com/artifacthub/hub/internal/serviceaccount"
+	"github.com/artifacthub/hub/internal/sitemap"
 	"github.com/artifacthub/hub/internal/stats"
 	"github.com/artifacthub/hub/internal/subscription"
+	"github.com/artifacthub/hub/internal/team"
+	"github.com/artifacthub/hub/internal/tracker"
 	"github.com/artifacthub/hub/internal/user"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/artifacthub/hub/internal/webhook"
@@ -55,20 +71,89 @@ func main() {
 		log.Fatal().Err(err).Msg("authorizer setup failed")
 	}
 	hc := &http.Client{Timeout: 10 * time.Second}
+	whc := notification.NewWebhookHTTPClient(cfg)
+	is, err := util.SetupImageStore(cfg, db, hc, nil)
+	if err != nil {
+		log.Fatal().Err(err).Msg("image store setup failed")
+	}
+
+	// Setup chart mirror (optional)
+	var cm hub.ChartMirror
+	var cmBucket objstore.Bucket
+	if cfg.GetBool("chartMirror.enabled") {
+		cmBucket, err = util.SetupChartMirrorBucket(cfg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("chart mirror setup failed")
+		}
+		cmHC := &http.Client{Timeout: 1 * time.Minute}
+		cm = chartmirror.NewMirror(pkg.NewManager(db, az), repo.NewManager(cfg, db, az), cmBucket, cmHC)
+	}
+
+	// Setup users manager
+	um := user.NewManager(db, es,
+		user.WithDeletionGracePeriod(cfg.GetDuration("users.deletion.gracePeriod")),
+		user.WithLoginLockout(cfg.GetInt("users.lockout.maxFailedAttempts"), cfg.GetDuration("users.lockout.duration")),
+		user.WithPasswordPolicy(user.PasswordPolicy{
+			MinLength:     cfg.GetInt("users.passwordPolicy.minLength"),
+			CheckBreached: cfg.GetBool("users.passwordPolicy.checkBreached"),
+			HistorySize:   cfg.GetInt("users.passwordPolicy.historySize"),
+			MaxAge:        cfg.GetDuration("users.passwordPolicy.maxAge"),
+		}),
+		user.WithHTTPClient(hc),
+	)
+
+	// Setup api keys usage tracker
+	akut := apikey.NewUsageTracker(db)
+
+	// Setup captcha verifier (optional)
+	var cv hub.CaptchaVerifier
+	if cfg.GetString("server.captcha.provider") != "" {
+		v, err := captcha.NewVerifier(cfg, hc)
+		if err != nil {
+			log.Fatal().Err(err).Msg("captcha verifier setup failed")
+		}
+		cv = v
+	}
+
+	// Setup tracker dry-runner, used to validate repositories before adding
+	// them to the hub
+	trackerDryRunner := tracker.NewDryRunner(&hub.TrackerServices{
+		Cfg:                cfg,
+		Rm:                 repo.NewManager(cfg, db, az),
+		Rc:                 &repo.Cloner{},
+		Oe:                 &repo.OLMOCIExporter{},
+		Hc:                 hc,
+		SetupTrackerSource: tracker.SetupSource,
+	})
 
 	// Setup and launch http server
 	ctx, stop := context.WithCancel(context.Background())
 	hSvc := &handlers.Services{
-		OrganizationManager: org.NewManager(db, es, az),
-		UserManager:         user.NewManager(db, es),
-		RepositoryManager:   repo.NewManager(cfg, db, az),
-		PackageManager:      pkg.NewManager(db),
-		SubscriptionManager: subscription.NewManager(db),
-		WebhookManager:      webhook.NewManager(db),
-		APIKeyManager:       apikey.NewManager(db),
-		StatsManager:        stats.NewManager(db),
-		ImageStore:          pg.NewImageStore(cfg, db, hc, nil),
-		Authorizer:          az,
+		OrganizationManager:   org.NewManager(db, es, az, org.WithDeletionGracePeriod(cfg.GetDuration("organizations.deletion.gracePeriod"))),
+		UserManager:           um,
+		RepositoryManager:     repo.NewManager(cfg, db, az, repo.WithTrackerDryRunner(trackerDryRunner)),
+		PackageManager:        pkg.NewManager(db, az),
+		SubscriptionManager:   subscription.NewManager(db),
+		WebhookManager:        webhook.NewManager(db),
+		APIKeyManager:         apikey.NewManager(db),
+		ServiceAccountManager: serviceaccount.NewManager(db, az),
+		TeamManager:           team.NewManager(db, az),
+		AdminManager:          admin.NewManager(db, um),
+		APIKeyUsageTracker:    akut,
+		AuditLogManager:       audit.NewManager(db, az),
+		SCIMManager:           scim.NewManager(db),
+		StatsManager:          stats.NewManager(db),
+		SitemapManager:        sitemap.NewManager(db),
+		SavedSearchManager:    savedsearch.NewManager(db),
+		PullsManager:          pulls.NewManager(db, repo.NewManager(cfg, db, az), az),
+		ReviewManager:         review.NewManager(db),
+		CommentManager:        comment.NewManager(db),
+		AbuseReportManager:    abuse.NewManager(db),
+		ImageStore:            is,
+		ChartMirror:           cm,
+		WebhookHTTPClient:     whc,
+		Authorizer:            az,
+		CaptchaVerifier:       cv,
 	}
 	h, err := handlers.Setup(ctx, cfg, hSvc)
 	if err != nil {
@@ -118,12 +203,54 @@ func main() {
 		NotificationManager: notification.NewManager(),
 		SubscriptionManager: subscription.NewManager(db),
 		RepositoryManager:   repo.NewManager(cfg, db, az),
-		PackageManager:      pkg.NewManager(db),
+		PackageManager:      pkg.NewManager(db, az),
 	}
-	notificationsDispatcher := notification.NewDispatcher(cfg, nSvc)
+	notificationsDispatcher := notification.NewDispatcher(cfg, nSvc, notification.WithHTTPClient(whc))
 	wg.Add(1)
 	go notificationsDispatcher.Run(ctx, &wg)
 
+	// Setup and launch images garbage collector
+	if cfg.GetBool("images.gc.enabled") {
+		gcs, ok := is.(img.GCStore)
+		if !ok {
+			log.Fatal().Msg("images gc not supported by the images store configured")
+		}
+		wg.Add(1)
+		go img.NewGC(cfg, db, gcs).Run(ctx, &wg)
+	}
+
+	// Setup and launch chart mirror garbage collector
+	if cm != nil && cfg.GetBool("chartMirror.gc.enabled") {
+		wg.Add(1)
+		go chartmirror.NewGC(cfg, db, cmBucket).Run(ctx, &wg)
+	}
+
+	// Setup and launch users deleter
+	wg.Add(1)
+	go user.NewDeleter(db).Run(ctx, &wg)
+
+	// Setup and launch organizations deleter
+	wg.Add(1)
+	go org.NewDeleter(db).Run(ctx, &wg)
+
+	// Setup and launch packages pulls poller
+	if cfg.GetBool("pulls.polling.enabled") {
+		wg.Add(1)
+		go pulls.NewPoller(cfg, db, hc).Run(ctx, &wg)
+	}
+
+	// Setup and launch repositories ownership claims checker
+	wg.Add(1)
+	go repo.NewOwnershipClaimsChecker(cfg, db, hc, net.DefaultResolver).Run(ctx, &wg)
+
+	// Setup and launch saved searches evaluator
+	wg.Add(1)
+	go savedsearch.NewEvaluator(db).Run(ctx, &wg)
+
+	// Launch api keys usage tracker
+	wg.Add(1)
+	go akut.Run(ctx, &wg)
+
 	// Shutdown server gracefully when SIGINT or SIGTERM signal is received
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
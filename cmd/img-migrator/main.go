@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/artifacthub/hub/internal/img/objstore"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/rs/zerolog/log"
+)
+
+// img-migrator copies the images stored in the database to the object storage
+// bucket configured in images.store, so that the hub and the tracker can be
+// switched to use it without losing the images already registered.
+func main() {
+	// Setup configuration and logger
+	cfg, err := util.SetupConfig("img-migrator")
+	if err != nil {
+		log.Fatal().Err(err).Msg("configuration setup failed")
+	}
+	fields := map[string]interface{}{"cmd": "img-migrator"}
+	if err := util.SetupLogger(cfg, fields); err != nil {
+		log.Fatal().Err(err).Msg("logger setup failed")
+	}
+
+	// Shutdown gracefully when SIGINT or SIGTERM signal is received
+	log.Info().Int("pid", os.Getpid()).Msg("img-migrator started")
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-shutdown
+		cancel()
+		log.Info().Msg("img-migrator shutting down..")
+	}()
+
+	// Setup services
+	db, err := util.SetupDB(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("database setup failed")
+	}
+	bucket, err := util.SetupImageStoreBucket(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("image store bucket setup failed")
+	}
+
+	// Migrate images
+	migrated, err := objstore.Migrate(ctx, db, bucket)
+	if err != nil {
+		log.Fatal().Err(err).Int("migrated", migrated).Msg("error migrating images")
+	}
+	log.Info().Int("migrated", migrated).Msg("img-migrator finished")
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"os"
-	"os/exec"
 	"os/signal"
 	"sync"
 	"syscall"
@@ -39,11 +38,6 @@ func main() {
 		log.Info().Msg("scanner shutting down..")
 	}()
 
-	// Check required external tools are available
-	if _, err := exec.LookPath("trivy"); err != nil {
-		log.Fatal().Err(err).Msg("trivy not found")
-	}
-
 	// Setup services
 	db, err := util.SetupDB(cfg)
 	if err != nil {
@@ -54,24 +48,27 @@ func main() {
 		log.Fatal().Err(err).Msg("authorizer setup failed")
 	}
 	rm := repo.NewManager(cfg, db, az)
-	pm := pkg.NewManager(db)
+	pm := pkg.NewManager(db, az)
 	ec := repo.NewErrorsCollector(rm, repo.Scanner)
 
-	// Scan pending snapshots
-	trivyURL := cfg.GetString("scanner.trivyURL")
-	if trivyURL == "" {
-		log.Fatal().Err(err).Msg("trivy url not set")
-	}
-	trivyScanner := &scanner.TrivyScanner{
-		Ctx: ctx,
-		Cfg: cfg,
-		URL: trivyURL,
+	// Setup scanner backend (it checks required external tools are available)
+	cfg.SetDefault("scanner.backend", scanner.Trivy)
+	sc, err := scanner.New(ctx, cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("scanner setup failed")
 	}
+
+	// Scan pending snapshots
 	snapshots, err := pm.GetSnapshotsToScan(ctx)
 	if err != nil {
 		log.Fatal().Err(err).Msg("error getting snapshots to scan")
 	}
 	cfg.SetDefault("scanner.concurrency", 1)
+	cfg.SetDefault("scanner.alertsSeverityThreshold", "high")
+	alertsSeverityThreshold := cfg.GetString("scanner.alertsSeverityThreshold")
+	if err := scanner.ValidateSeverity(alertsSeverityThreshold); err != nil {
+		log.Fatal().Err(err).Msg("invalid alerts severity threshold")
+	}
 	limiter := make(chan struct{}, cfg.GetInt("scanner.concurrency"))
 	var wg sync.WaitGroup
 L:
@@ -89,10 +86,13 @@ L:
 
 			logger := log.With().Str("pkg", snapshot.PackageID).Str("version", snapshot.Version).Logger()
 			logger.Info().Msg("scanning snapshot")
-			report, err := scanner.ScanSnapshot(ctx, trivyScanner, snapshot, ec)
+			report, err := scanner.ScanSnapshot(ctx, sc, snapshot, ec)
 			if err != nil {
 				logger.Error().Err(err).Send()
 			}
+			if err := scanner.SetNewVulnerabilities(ctx, pm, report, alertsSeverityThreshold); err != nil {
+				logger.Error().Err(err).Send()
+			}
 			if err := pm.UpdateSnapshotSecurityReport(ctx, report); err != nil {
 				logger.Error().Err(err).Send()
 			}
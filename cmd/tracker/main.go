@@ -69,7 +69,7 @@ func main() {
 		log.Fatal().Err(err).Msg("authorizer setup failed")
 	}
 	rm := repo.NewManager(cfg, db, az)
-	pm := pkg.NewManager(db)
+	pm := pkg.NewManager(db, az)
 	hc := &http.Client{Timeout: 10 * time.Second}
 	githubMaxRequestsPerHour := githubMaxRequestsPerHourUnauthenticated
 	if cfg.GetString("creds.githubToken") != "" {
@@ -84,6 +84,10 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("image store setup failed")
 	}
+	sv, err := repo.NewCosignVerifier(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cosign verifier setup failed")
+	}
 	ec := repo.NewErrorsCollector(rm, repo.Tracker)
 	svc := &hub.TrackerServices{
 		Ctx:                ctx,
@@ -95,6 +99,7 @@ func main() {
 		Ec:                 ec,
 		Hc:                 hc,
 		Is:                 is,
+		Sv:                 sv,
 		GithubRL:           githubRL,
 		SetupTrackerSource: tracker.SetupSource,
 	}
@@ -104,6 +109,18 @@ func main() {
 	if err != nil {
 		log.Fatal().Err(err).Msg("error getting repositories")
 	}
+
+	// Register a tracking run for each of the repositories to process, so
+	// that their status and progress can be checked from the API
+	trackingRuns := make(map[string]string, len(repos))
+	for _, r := range repos {
+		trackingRunID, err := rm.RegisterTrackingRun(ctx, r.RepositoryID)
+		if err != nil {
+			log.Warn().Err(err).Str("repo", r.Name).Msg("error registering tracking run")
+			continue
+		}
+		trackingRuns[r.RepositoryID] = trackingRunID
+	}
 	cfg.SetDefault("tracker.concurrency", 1)
 	limiter := make(chan struct{}, cfg.GetInt("tracker.concurrency"))
 	var wg sync.WaitGroup
@@ -133,17 +150,27 @@ L:
 						logger.Error().Bytes("stacktrace", debug.Stack()).Interface("recover", r).Send()
 					}
 				}()
-				t := tracker.New(svc, r, logger)
+				var opts []func(t *tracker.Tracker)
+				if trackingRunID, ok := trackingRuns[r.RepositoryID]; ok {
+					opts = append(opts, tracker.WithTrackingRun(trackingRunID))
+				}
+				t := tracker.New(svc, r, logger, opts...)
 				if err := t.Run(); err != nil {
 					logger.Error().Err(err).Send()
-					svc.Ec.Append(r.RepositoryID, err.Error())
+					svc.Ec.Append(r.RepositoryID, &hub.RepositoryError{
+						Class:   hub.RepositoryErrorClassRepository,
+						Message: err.Error(),
+					})
 				}
 			}()
 			select {
 			case <-done:
 			case <-time.After(repositoryTimeout):
 				logger.Error().Err(errTimeout).Send()
-				svc.Ec.Append(r.RepositoryID, errTimeout.Error())
+				svc.Ec.Append(r.RepositoryID, &hub.RepositoryError{
+					Class:   hub.RepositoryErrorClassRepository,
+					Message: errTimeout.Error(),
+				})
 			}
 		}(r)
 	}
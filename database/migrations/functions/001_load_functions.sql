@@ -1,97 +1,244 @@
+{{ template "pagination/decode_cursor.sql" }}
+{{ template "pagination/encode_cursor.sql" }}
+{{ template "pagination/get_cursor_scope.sql" }}
+{{ template "pagination/get_cursor.sql" }}
+
 {{ template "repositories/get_repository_by_id.sql" }}
 {{ template "repositories/get_repository_summary.sql" }}
+{{ template "service_accounts/get_service_account_allowed_actions.sql" }}
+
+{{ template "abuse/add_abuse_report.sql" }}
+{{ template "abuse/get_abuse_reports.sql" }}
+{{ template "abuse/triage_abuse_report.sql" }}
+
+{{ template "admin/user_is_site_admin.sql" }}
+{{ template "admin/force_user_password_reset.sql" }}
+{{ template "admin/get_user_resources.sql" }}
+{{ template "admin/get_verified_publisher_changes.sql" }}
+{{ template "admin/revoke_user_credentials.sql" }}
+{{ template "admin/search_users.sql" }}
+{{ template "admin/update_user_disabled.sql" }}
+{{ template "admin/update_verified_publisher.sql" }}
 
 {{ template "api_keys/add_api_key.sql" }}
 {{ template "api_keys/delete_api_key.sql" }}
 {{ template "api_keys/get_api_key.sql" }}
 {{ template "api_keys/get_user_api_keys.sql" }}
+{{ template "api_keys/register_api_keys_usage.sql" }}
 {{ template "api_keys/update_api_key.sql" }}
 
+{{ template "audit/get_organization_audit_log.sql" }}
+{{ template "audit/get_user_audit_log.sql" }}
+{{ template "audit/register_audit_log_entry.sql" }}
+
+{{ template "comments/add_package_comment.sql" }}
+{{ template "comments/delete_package_comment.sql" }}
+{{ template "comments/get_package_comments.sql" }}
+{{ template "comments/set_package_comment_hidden.sql" }}
+{{ template "comments/update_package_comment.sql" }}
+
 {{ template "events/get_pending_event.sql" }}
 
+{{ template "images/delete_image.sql" }}
 {{ template "images/get_image.sql" }}
+{{ template "images/get_images_created_before.sql" }}
+{{ template "images/get_referenced_images.sql" }}
 {{ template "images/register_image.sql" }}
 
 {{ template "notifications/add_notification.sql" }}
-{{ template "notifications/get_pending_notification.sql" }}
+{{ template "notifications/defer_notification.sql" }}
+{{ template "notifications/get_pending_notifications.sql" }}
+{{ template "notifications/register_notification_delivery_failure.sql" }}
+{{ template "notifications/renew_notifications_lease.sql" }}
 {{ template "notifications/update_notification_status.sql" }}
 
 {{ template "organizations/add_organization_member.sql" }}
 {{ template "organizations/add_organization.sql" }}
+{{ template "organizations/cancel_organization_deletion.sql" }}
 {{ template "organizations/confirm_organization_membership.sql" }}
-{{ template "organizations/delete_organization.sql" }}
 {{ template "organizations/delete_organization_member.sql" }}
+{{ template "organizations/delete_scheduled_organizations.sql" }}
 {{ template "organizations/get_authorization_policies.sql" }}
 {{ template "organizations/get_authorization_policy.sql" }}
 {{ template "organizations/get_organization.sql" }}
 {{ template "organizations/get_organization_members.sql" }}
+{{ template "organizations/get_user_allowed_actions.sql" }}
 {{ template "organizations/get_user_organizations.sql" }}
+{{ template "organizations/rename_organization.sql" }}
+{{ template "organizations/schedule_organization_deletion.sql" }}
+{{ template "organizations/transfer_organization_ownership.sql" }}
 {{ template "organizations/update_authorization_policy.sql" }}
 {{ template "organizations/update_organization.sql" }}
+{{ template "organizations/update_organization_member_role.sql" }}
 {{ template "organizations/user_belongs_to_organization.sql" }}
 
+{{ template "packages/add_package_vulnerability_suppression.sql" }}
+{{ template "packages/delete_package_vulnerability_suppression.sql" }}
+{{ template "packages/generate_package_fuzzy_text.sql" }}
 {{ template "packages/generate_package_tsdoc.sql" }}
 {{ template "packages/get_harbor_replication_dump.sql" }}
 {{ template "packages/get_package.sql" }}
 {{ template "packages/get_package_changelog.sql" }}
 {{ template "packages/get_package_summary.sql" }}
 {{ template "packages/get_packages_starred_by_user.sql" }}
+{{ template "packages/get_packages_versions.sql" }}
 {{ template "packages/get_package_stars.sql" }}
 {{ template "packages/get_packages_stats.sql" }}
+{{ template "packages/get_package_vulnerability_suppressions.sql" }}
 {{ template "packages/get_random_packages.sql" }}
+{{ template "packages/get_recent_releases.sql" }}
 {{ template "packages/get_snapshots_to_scan.sql" }}
 {{ template "packages/register_package.sql" }}
+{{ template "packages/request_snapshot_security_report_rescan.sql" }}
 {{ template "packages/search_packages.sql" }}
 {{ template "packages/search_packages_monocular.sql" }}
 {{ template "packages/semver_gt.sql" }}
 {{ template "packages/semver_gte.sql" }}
 {{ template "packages/toggle_star.sql" }}
+{{ template "packages/update_package_latest_version.sql" }}
 {{ template "packages/update_snapshot_security_report.sql" }}
 {{ template "packages/unregister_package.sql" }}
+{{ template "packages/unyank_package_version.sql" }}
+{{ template "packages/yank_package_version.sql" }}
+
+{{ template "pulls/get_package_pulls.sql" }}
+{{ template "pulls/get_pulls_polling_sources.sql" }}
+{{ template "pulls/get_trending_packages.sql" }}
+{{ template "pulls/register_package_pulls.sql" }}
+{{ template "pulls/register_package_pulls_total.sql" }}
 
 {{ template "repositories/add_repository.sql" }}
 {{ template "repositories/delete_repository.sql" }}
 {{ template "repositories/get_all_repositories.sql" }}
 {{ template "repositories/get_repositories_by_kind.sql" }}
 {{ template "repositories/get_repository_by_name.sql" }}
+{{ template "repositories/get_repository_ownership_claim.sql" }}
 {{ template "repositories/get_repository_packages_digest.sql" }}
+{{ template "repositories/get_repository_tracking_status.sql" }}
 {{ template "repositories/get_org_repositories.sql" }}
+{{ template "repositories/get_pending_repository_ownership_claims.sql" }}
 {{ template "repositories/get_user_repositories.sql" }}
+{{ template "repositories/register_repository_ownership_claim_check.sql" }}
+{{ template "repositories/register_repository_tracking_run.sql" }}
+{{ template "repositories/register_verified_publisher_change.sql" }}
+{{ template "repositories/request_repository_ownership_claim.sql" }}
+{{ template "repositories/search_repositories.sql" }}
 {{ template "repositories/set_last_scanning_results.sql" }}
 {{ template "repositories/set_last_tracking_results.sql" }}
 {{ template "repositories/set_verified_publisher.sql" }}
 {{ template "repositories/transfer_repository.sql" }}
 {{ template "repositories/update_repository.sql" }}
+{{ template "repositories/update_repository_credentials.sql" }}
+{{ template "repositories/update_repository_tracking_enabled.sql" }}
+{{ template "repositories/update_repository_tracking_run.sql" }}
+{{ template "repositories/update_repository_tracking_webhook.sql" }}
+{{ template "repositories/verify_repository_ownership_claim.sql" }}
+
+{{ template "reviews/add_package_review.sql" }}
+{{ template "reviews/delete_package_review.sql" }}
+{{ template "reviews/get_package_reviews.sql" }}
+{{ template "reviews/reply_package_review.sql" }}
+{{ template "reviews/report_package_review.sql" }}
+{{ template "reviews/update_package_rating.sql" }}
+{{ template "reviews/user_is_package_publisher.sql" }}
+
+{{ template "saved_searches/add_saved_search.sql" }}
+{{ template "saved_searches/delete_saved_search.sql" }}
+{{ template "saved_searches/evaluate_saved_searches.sql" }}
+{{ template "saved_searches/get_saved_search.sql" }}
+{{ template "saved_searches/get_saved_search_matches.sql" }}
+{{ template "saved_searches/get_user_saved_searches.sql" }}
+{{ template "saved_searches/refresh_saved_search_matches.sql" }}
+{{ template "saved_searches/update_saved_search.sql" }}
+
+{{ template "scim/add_scim_group_members.sql" }}
+{{ template "scim/delete_scim_group_members.sql" }}
+{{ template "scim/get_scim_group.sql" }}
+{{ template "scim/get_scim_groups.sql" }}
+{{ template "scim/get_scim_user.sql" }}
+{{ template "scim/get_scim_users.sql" }}
+{{ template "scim/register_scim_user.sql" }}
+{{ template "scim/update_scim_user.sql" }}
+
+{{ template "service_accounts/add_service_account.sql" }}
+{{ template "service_accounts/add_service_account_api_key.sql" }}
+{{ template "service_accounts/delete_service_account.sql" }}
+{{ template "service_accounts/delete_service_account_api_key.sql" }}
+{{ template "service_accounts/get_org_service_accounts.sql" }}
+
+{{ template "sitemap/get_sitemap_entries.sql" }}
+{{ template "sitemap/get_sitemap_sections.sql" }}
 
 {{ template "stats/get_stats.sql" }}
+{{ template "stats/get_stats_series.sql" }}
 
+{{ template "subscriptions/add_bulk_subscription.sql" }}
 {{ template "subscriptions/add_opt_out.sql" }}
+{{ template "subscriptions/add_scoped_subscription.sql" }}
 {{ template "subscriptions/add_subscription.sql" }}
+{{ template "subscriptions/delete_bulk_subscription.sql" }}
 {{ template "subscriptions/delete_opt_out.sql" }}
+{{ template "subscriptions/delete_scoped_subscription.sql" }}
 {{ template "subscriptions/delete_subscription.sql" }}
+{{ template "subscriptions/export_user_subscriptions.sql" }}
 {{ template "subscriptions/get_package_subscriptors.sql" }}
 {{ template "subscriptions/get_repository_subscriptors.sql" }}
 {{ template "subscriptions/get_user_opt_out_entries.sql" }}
 {{ template "subscriptions/get_user_package_subscriptions.sql" }}
+{{ template "subscriptions/get_user_scoped_subscriptions.sql" }}
 {{ template "subscriptions/get_user_subscriptions.sql" }}
+{{ template "subscriptions/import_user_subscriptions.sql" }}
+{{ template "subscriptions/search_user_subscriptions.sql" }}
+{{ template "subscriptions/unsubscribe.sql" }}
+
+{{ template "teams/add_team.sql" }}
+{{ template "teams/add_team_member.sql" }}
+{{ template "teams/delete_team.sql" }}
+{{ template "teams/delete_team_member.sql" }}
+{{ template "teams/delete_team_repository_permissions.sql" }}
+{{ template "teams/get_org_teams.sql" }}
+{{ template "teams/get_user_teams.sql" }}
+{{ template "teams/update_team.sql" }}
+{{ template "teams/update_team_repository_permissions.sql" }}
 
+{{ template "users/change_user_email.sql" }}
 {{ template "users/check_user_alias_availability.sql" }}
+{{ template "users/delete_failed_login_attempts.sql" }}
+{{ template "users/delete_scheduled_users.sql" }}
+{{ template "users/delete_user.sql" }}
+{{ template "users/get_login_throttle.sql" }}
 {{ template "users/get_user_profile.sql" }}
+{{ template "users/register_delete_user_code.sql" }}
+{{ template "users/register_email_change_code.sql" }}
+{{ template "users/register_failed_login_attempt.sql" }}
 {{ template "users/register_password_reset_code.sql" }}
 {{ template "users/register_session.sql" }}
+{{ template "users/register_session_device.sql" }}
 {{ template "users/register_user.sql" }}
 {{ template "users/reset_user_password.sql" }}
+{{ template "users/revoke_session.sql" }}
+{{ template "users/schedule_user_deletion.sql" }}
 {{ template "users/update_user_password.sql" }}
 {{ template "users/update_user_profile.sql" }}
 {{ template "users/verify_email.sql" }}
 {{ template "users/verify_password_reset_code.sql" }}
 
 {{ template "webhooks/add_webhook.sql" }}
+{{ template "webhooks/add_webhook_delivery.sql" }}
 {{ template "webhooks/delete_webhook.sql" }}
+{{ template "webhooks/enable_webhook.sql" }}
 {{ template "webhooks/get_webhook.sql" }}
+{{ template "webhooks/get_webhook_deliveries.sql" }}
+{{ template "webhooks/get_webhook_delivery.sql" }}
+{{ template "webhooks/get_webhook_failed_deliveries.sql" }}
+{{ template "webhooks/get_webhook_failed_delivery.sql" }}
+{{ template "webhooks/get_webhook_headers.sql" }}
 {{ template "webhooks/get_org_webhooks.sql" }}
 {{ template "webhooks/get_user_webhooks.sql" }}
 {{ template "webhooks/get_webhooks_subscribed_to_package.sql" }}
+{{ template "webhooks/register_webhook_delivery.sql" }}
+{{ template "webhooks/requeue_webhook_failed_delivery.sql" }}
+{{ template "webhooks/reserve_webhook_deliveries.sql" }}
 {{ template "webhooks/update_webhook.sql" }}
 {{ template "webhooks/user_has_access_to_webhook.sql" }}
 
@@ -0,0 +1,61 @@
+-- add_abuse_report registers an abuse report on the package or repository
+-- provided. Repeated reports are throttled: reports on a target the user has
+-- already reported and is still open (or was dismissed recently) are ignored,
+-- as well as those exceeding the maximum number of reports a user can submit
+-- per day.
+create or replace function add_abuse_report(p_user_id uuid, p_report jsonb)
+returns void as $$
+declare
+    v_package_id uuid := nullif(p_report->>'package_id', '');
+    v_repository_id uuid := nullif(p_report->>'repository_id', '');
+    v_max_daily_reports int := 10;
+begin
+    -- Check the target of the report exists
+    if v_package_id is not null then
+        perform from package where package_id = v_package_id;
+    else
+        perform from repository where repository_id = v_repository_id;
+    end if;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Ignore the report if the user already reported the same target and the
+    -- report is still open or was dismissed in the last 30 days
+    perform from abuse_report
+    where user_id = p_user_id
+    and package_id is not distinct from v_package_id
+    and repository_id is not distinct from v_repository_id
+    and (
+        status in ('pending', 'investigating')
+        or (status = 'dismissed' and updated_at > current_timestamp - '30 days'::interval)
+    );
+    if found then
+        return;
+    end if;
+
+    -- Ignore the report if the user has exceeded the daily reports limit
+    if (
+        select count(*)
+        from abuse_report
+        where user_id = p_user_id
+        and created_at > current_timestamp - '1 day'::interval
+    ) >= v_max_daily_reports then
+        return;
+    end if;
+
+    insert into abuse_report (
+        package_id,
+        repository_id,
+        user_id,
+        reason,
+        description
+    ) values (
+        v_package_id,
+        v_repository_id,
+        p_user_id,
+        p_report->>'reason',
+        nullif(p_report->>'description', '')
+    );
+end
+$$ language plpgsql;
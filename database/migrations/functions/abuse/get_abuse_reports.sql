@@ -0,0 +1,78 @@
+-- get_abuse_reports returns the abuse reports moderation queue as a json
+-- object, oldest reports first, optionally filtered by status. Only site
+-- administrators are allowed to get it.
+create or replace function get_abuse_reports(p_admin_user_id uuid, p_input jsonb)
+returns setof json as $$
+declare
+    v_status text := nullif(p_input->>'status', '');
+    v_limit int := coalesce((p_input->>'limit')::int, 20);
+    v_offset int := coalesce((p_input->>'offset')::int, 0);
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with filtered_reports as (
+        select ar.*
+        from abuse_report ar
+        where
+            case when v_status is not null then
+                ar.status = v_status
+            else true end
+    )
+    select json_build_object(
+        'reports', (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'abuse_report_id', fr.abuse_report_id,
+                'reason', fr.reason,
+                'description', fr.description,
+                'status', fr.status,
+                'notes', fr.notes,
+                'reporter_alias', (select alias from "user" where user_id = fr.user_id),
+                'triaged_by_alias', (select alias from "user" where user_id = fr.triaged_by),
+                'package', (
+                    select json_build_object(
+                        'package_id', p.package_id,
+                        'name', p.name,
+                        'repository_name', r.name,
+                        'repository_kind_id', r.repository_kind_id
+                    )
+                    from package p
+                    join repository r using (repository_id)
+                    where p.package_id = fr.package_id
+                ),
+                'repository', (
+                    select json_build_object(
+                        'repository_id', r.repository_id,
+                        'name', r.name,
+                        'kind', r.repository_kind_id
+                    )
+                    from repository r
+                    where r.repository_id = fr.repository_id
+                ),
+                'open_reports_on_target', (
+                    select count(*)
+                    from abuse_report ar
+                    where ar.package_id is not distinct from fr.package_id
+                    and ar.repository_id is not distinct from fr.repository_id
+                    and ar.status in ('pending', 'investigating')
+                ),
+                'created_at', floor(extract(epoch from fr.created_at)),
+                'updated_at', floor(extract(epoch from fr.updated_at))
+            )) order by fr.created_at asc, fr.abuse_report_id asc), '[]')
+            from (
+                select * from filtered_reports
+                order by created_at asc, abuse_report_id asc
+                limit v_limit
+                offset v_offset
+            ) fr
+        ),
+        'metadata', json_build_object(
+            'limit', v_limit,
+            'offset', v_offset,
+            'total', (select count(*) from filtered_reports)
+        )
+    );
+end
+$$ language plpgsql;
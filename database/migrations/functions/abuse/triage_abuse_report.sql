@@ -0,0 +1,24 @@
+-- triage_abuse_report updates the status of the provided abuse report, as
+-- well as the notes of the site administrator who triaged it. Only site
+-- administrators are allowed to triage abuse reports.
+create or replace function triage_abuse_report(
+    p_admin_user_id uuid,
+    p_abuse_report_id uuid,
+    p_input jsonb
+) returns void as $$
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    update abuse_report set
+        status = p_input->>'status',
+        notes = nullif(p_input->>'notes', ''),
+        triaged_by = p_admin_user_id,
+        updated_at = current_timestamp
+    where abuse_report_id = p_abuse_report_id;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
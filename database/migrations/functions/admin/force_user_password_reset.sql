@@ -0,0 +1,24 @@
+-- force_user_password_reset invalidates the password and sessions of the
+-- provided user, returning its email so that a password reset code can be sent
+-- to it. Only site administrators are allowed to perform this operation.
+create or replace function force_user_password_reset(p_admin_user_id uuid, p_user_id uuid)
+returns text as $$
+declare
+    v_email text;
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    update "user" set password = null
+    where user_id = p_user_id
+    returning email into v_email;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    delete from session where user_id = p_user_id;
+
+    return v_email;
+end
+$$ language plpgsql;
@@ -0,0 +1,60 @@
+-- get_user_resources returns the resources owned by the provided user as a
+-- json object. Only site administrators are allowed to get them.
+create or replace function get_user_resources(p_admin_user_id uuid, p_user_id uuid)
+returns setof json as $$
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+    perform from "user" where user_id = p_user_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    return query
+    select json_build_object(
+        'organizations', (
+            select coalesce(json_agg(json_build_object(
+                'name', o.name,
+                'display_name', o.display_name,
+                'confirmed', uo.confirmed
+            ) order by o.name asc), '[]')
+            from organization o
+            join user__organization uo using (organization_id)
+            where uo.user_id = p_user_id
+        ),
+        'repositories', (
+            select coalesce(json_agg(json_build_object(
+                'repository_id', r.repository_id,
+                'name', r.name,
+                'display_name', r.display_name,
+                'kind', r.repository_kind_id,
+                'url', r.url
+            ) order by r.name asc), '[]')
+            from repository r
+            where r.user_id = p_user_id
+        ),
+        'webhooks', (
+            select coalesce(json_agg(json_build_object(
+                'webhook_id', w.webhook_id,
+                'name', w.name,
+                'active', w.active
+            ) order by w.name asc), '[]')
+            from webhook w
+            where w.user_id = p_user_id
+        ),
+        'api_keys', (
+            select coalesce(json_agg(json_build_object(
+                'api_key_id', ak.api_key_id,
+                'name', ak.name,
+                'created_at', floor(extract(epoch from ak.created_at))
+            ) order by ak.name asc), '[]')
+            from api_key ak
+            where ak.user_id = p_user_id
+        ),
+        'sessions', (
+            select count(*) from session where user_id = p_user_id
+        )
+    );
+end
+$$ language plpgsql;
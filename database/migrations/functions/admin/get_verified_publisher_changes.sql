@@ -0,0 +1,31 @@
+-- get_verified_publisher_changes returns the current verified publisher status
+-- of the provided repository as well as the changes registered, the most
+-- recent first. Only site administrators are allowed to perform this
+-- operation.
+create or replace function get_verified_publisher_changes(p_admin_user_id uuid, p_repository_name text)
+returns setof json as $$
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select json_build_object(
+        'verified_publisher', r.verified_publisher,
+        'changes', (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'verified', c.verified,
+                'source', c.source,
+                'evidence', c.evidence,
+                'user_alias', u.alias,
+                'created_at', floor(extract(epoch from c.created_at))
+            )) order by c.created_at desc), '[]')
+            from verified_publisher_change c
+            left join "user" u using (user_id)
+            where c.repository_id = r.repository_id
+        )
+    )
+    from repository r
+    where r.name = p_repository_name;
+end
+$$ language plpgsql;
@@ -0,0 +1,18 @@
+-- revoke_user_credentials deletes all the sessions and API keys of the
+-- provided user. Only site administrators are allowed to perform this
+-- operation.
+create or replace function revoke_user_credentials(p_admin_user_id uuid, p_user_id uuid)
+returns void as $$
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+    perform from "user" where user_id = p_user_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    delete from session where user_id = p_user_id;
+    delete from api_key where user_id = p_user_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,64 @@
+-- search_users returns the users matching the criteria provided as a json
+-- object. Only site administrators are allowed to search users.
+create or replace function search_users(p_admin_user_id uuid, p_input jsonb)
+returns setof json as $$
+declare
+    v_query text := nullif(p_input->>'query', '');
+    v_limit int := coalesce((p_input->>'limit')::int, 20);
+    v_offset int := coalesce((p_input->>'offset')::int, 0);
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with filtered_users as (
+        select
+            u.user_id,
+            u.alias,
+            u.email,
+            u.first_name,
+            u.last_name,
+            u.email_verified,
+            u.disabled,
+            u.site_admin,
+            u.deletion_scheduled_at,
+            u.created_at
+        from "user" u
+        where
+            case when v_query is not null then
+                u.alias ilike '%' || v_query || '%'
+                or u.email ilike '%' || v_query || '%'
+                or u.first_name ilike '%' || v_query || '%'
+                or u.last_name ilike '%' || v_query || '%'
+            else true end
+    )
+    select json_build_object(
+        'users', (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'user_id', user_id,
+                'alias', alias,
+                'email', email,
+                'first_name', first_name,
+                'last_name', last_name,
+                'email_verified', email_verified,
+                'disabled', disabled,
+                'site_admin', site_admin,
+                'deletion_scheduled_at', floor(extract(epoch from deletion_scheduled_at)),
+                'created_at', floor(extract(epoch from created_at))
+            ))), '[]')
+            from (
+                select * from filtered_users
+                order by alias asc
+                limit v_limit
+                offset v_offset
+            ) fu
+        ),
+        'metadata', json_build_object(
+            'limit', v_limit,
+            'offset', v_offset,
+            'total', (select count(*) from filtered_users)
+        )
+    );
+end
+$$ language plpgsql;
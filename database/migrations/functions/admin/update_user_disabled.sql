@@ -0,0 +1,26 @@
+-- update_user_disabled disables or enables the provided user. When a user is
+-- disabled, all its sessions are invalidated. Only site administrators are
+-- allowed to perform this operation.
+create or replace function update_user_disabled(
+    p_admin_user_id uuid,
+    p_user_id uuid,
+    p_disabled boolean
+) returns void as $$
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+    if p_admin_user_id = p_user_id and p_disabled then
+        raise 'site administrators cannot disable their own account';
+    end if;
+
+    update "user" set disabled = p_disabled where user_id = p_user_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    if p_disabled then
+        delete from session where user_id = p_user_id;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,27 @@
+-- update_verified_publisher sets the verified publisher status of the provided
+-- repository, recording the evidence supporting the decision. Only site
+-- administrators are allowed to perform this operation.
+create or replace function update_verified_publisher(
+    p_admin_user_id uuid,
+    p_repository_name text,
+    p_verified boolean,
+    p_evidence text
+) returns void as $$
+declare
+    v_repository_id uuid;
+begin
+    if not user_is_site_admin(p_admin_user_id) then
+        raise insufficient_privilege;
+    end if;
+
+    select repository_id into v_repository_id
+    from repository
+    where name = p_repository_name
+    for update;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    perform register_verified_publisher_change(v_repository_id, p_admin_user_id, 'admin', p_verified, p_evidence);
+end
+$$ language plpgsql;
@@ -0,0 +1,10 @@
+-- user_is_site_admin checks if the provided user is a site administrator.
+create or replace function user_is_site_admin(p_user_id uuid)
+returns boolean as $$
+    select exists (
+        select 1 from "user"
+        where user_id = p_user_id
+        and site_admin = true
+        and disabled = false
+    );
+$$ language sql;
@@ -8,11 +8,13 @@ begin
     insert into api_key (
         name,
         secret,
-        user_id
+        user_id,
+        scopes
     ) values (
         p_api_key->>'name',
         encode(sha512(v_api_key_secret::bytea), 'hex'),
-        (p_api_key->>'user_id')::uuid
+        (p_api_key->>'user_id')::uuid,
+        (select nullif(array(select jsonb_array_elements_text(nullif(p_api_key->'scopes', 'null'::jsonb))), '{}'))
     ) returning api_key_id into v_api_key_id;
 
     return query select json_build_object(
@@ -4,7 +4,16 @@ returns setof json as $$
     select json_build_object(
         'api_key_id', api_key_id,
         'name', name,
-        'created_at', floor(extract(epoch from created_at))
+        'created_at', floor(extract(epoch from created_at)),
+        'scopes', scopes,
+        'last_used_at', floor(extract(epoch from last_used_at)),
+        'last_used_ip', host(last_used_ip),
+        'requests_last_30_days', (
+            select coalesce(sum(requests), 0)
+            from api_key_usage aku
+            where aku.api_key_id = api_key.api_key_id
+            and aku.day > current_date - 30
+        )
     )
     from api_key
     where api_key_id = p_api_key_id
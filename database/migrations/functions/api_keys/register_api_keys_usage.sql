@@ -0,0 +1,29 @@
+-- register_api_keys_usage registers the usage of the api keys provided. The
+-- requests count is kept per day, and only the last 30 days are kept.
+create or replace function register_api_keys_usage(p_usage jsonb)
+returns void as $$
+declare
+    v_usage jsonb;
+begin
+    for v_usage in select * from jsonb_array_elements(p_usage)
+    loop
+        update api_key set
+            last_used_at = greatest(last_used_at, to_timestamp((v_usage->>'last_used_at')::bigint)),
+            last_used_ip = case
+                when last_used_at is null or to_timestamp((v_usage->>'last_used_at')::bigint) >= last_used_at
+                then nullif(v_usage->>'last_used_ip', '')::inet
+                else last_used_ip
+            end
+        where api_key_id = (v_usage->>'api_key_id')::uuid;
+
+        if found then
+            insert into api_key_usage (api_key_id, day, requests)
+            values ((v_usage->>'api_key_id')::uuid, current_date, (v_usage->>'requests')::bigint)
+            on conflict (api_key_id, day) do update
+            set requests = api_key_usage.requests + excluded.requests;
+        end if;
+    end loop;
+
+    delete from api_key_usage where day <= current_date - 30;
+end
+$$ language plpgsql;
@@ -0,0 +1,77 @@
+-- get_organization_audit_log returns the audit log entries of the actions
+-- performed on the organization provided as a json object. Entries can be
+-- filtered by action, user and date range using the input provided.
+create or replace function get_organization_audit_log(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_input jsonb
+) returns setof json as $$
+declare
+    v_limit int := (p_input->>'limit')::int;
+    v_offset int := (p_input->>'offset')::int;
+    v_actions text[];
+    v_user_alias text := nullif(p_input->>'user_alias', '');
+    v_from timestamptz := to_timestamp((p_input->>'from')::bigint);
+    v_to timestamptz := to_timestamp((p_input->>'to')::bigint);
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    -- Prepare filters for later use
+    select array_agg(e::text) into v_actions
+    from jsonb_array_elements_text(p_input->'actions') e;
+
+    return query
+    with filtered_entries as (
+        select
+            al.audit_log_id,
+            al.action,
+            u.alias as user_alias,
+            al.ip,
+            al.details,
+            al.created_at
+        from audit_log al
+        join organization o using (organization_id)
+        left join "user" u using (user_id)
+        where o.name = p_org_name
+        and
+            case when cardinality(v_actions) > 0 then
+            al.action = any(v_actions) else true end
+        and
+            case when v_user_alias is not null then
+            u.alias = v_user_alias else true end
+        and
+            case when v_from is not null then
+            al.created_at >= v_from else true end
+        and
+            case when v_to is not null then
+            al.created_at <= v_to else true end
+    )
+    select json_build_object(
+        'entries', (
+            select coalesce(json_agg(json_build_object(
+                'audit_log_id', audit_log_id,
+                'action', action,
+                'user_alias', user_alias,
+                'organization_name', p_org_name,
+                'ip', host(ip),
+                'details', details,
+                'created_at', floor(extract(epoch from created_at))
+            )), '[]')
+            from (
+                select *
+                from filtered_entries
+                order by created_at desc
+                limit v_limit
+                offset v_offset
+            ) entries
+        ),
+        'metadata', json_build_object(
+            'limit', v_limit,
+            'offset', v_offset,
+            'total', (select count(*) from filtered_entries)
+        )
+    );
+end
+$$ language plpgsql;
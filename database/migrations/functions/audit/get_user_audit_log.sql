@@ -0,0 +1,40 @@
+-- get_user_audit_log returns the audit log entries of the actions performed by
+-- the user provided as a json object.
+create or replace function get_user_audit_log(p_user_id uuid, p_limit int, p_offset int)
+returns setof json as $$
+    select json_build_object(
+        'entries', (
+            select coalesce(json_agg(json_build_object(
+                'audit_log_id', audit_log_id,
+                'action', action,
+                'user_alias', user_alias,
+                'organization_name', organization_name,
+                'ip', host(ip),
+                'details', details,
+                'created_at', floor(extract(epoch from created_at))
+            )), '[]')
+            from (
+                select
+                    al.audit_log_id,
+                    al.action,
+                    u.alias as user_alias,
+                    o.name as organization_name,
+                    al.ip,
+                    al.details,
+                    al.created_at
+                from audit_log al
+                join "user" u using (user_id)
+                left join organization o using (organization_id)
+                where al.user_id = p_user_id
+                order by al.created_at desc
+                limit p_limit
+                offset p_offset
+            ) entries
+        ),
+        'metadata', json_build_object(
+            'limit', p_limit,
+            'offset', p_offset,
+            'total', (select count(*) from audit_log where user_id = p_user_id)
+        )
+    );
+$$ language sql;
@@ -0,0 +1,20 @@
+-- register_audit_log_entry registers the provided entry in the audit log.
+create or replace function register_audit_log_entry(p_entry jsonb)
+returns void as $$
+    insert into audit_log (
+        action,
+        user_id,
+        organization_id,
+        ip,
+        details
+    ) values (
+        p_entry->>'action',
+        nullif(p_entry->>'user_id', '')::uuid,
+        coalesce(
+            (select organization_id from organization where name = nullif(p_entry->>'organization_name', '')),
+            (select organization_id from organization_name_alias where name = nullif(p_entry->>'organization_name', ''))
+        ),
+        nullif(p_entry->>'ip', '')::inet,
+        nullif(p_entry->'details', 'null'::jsonb)
+    );
+$$ language sql;
@@ -0,0 +1,58 @@
+-- add_package_comment adds the provided comment to the package, returning its
+-- id as a json object. Comments without a parent are questions, whereas those
+-- with a parent are answers to the question referenced. The package's
+-- publishers are notified when a new question is posted.
+create or replace function add_package_comment(p_user_id uuid, p_comment jsonb)
+returns setof json as $$
+declare
+    v_package_id uuid := p_comment->>'package_id';
+    v_parent_id uuid := nullif(p_comment->>'parent_id', '');
+    v_comment_id uuid;
+    v_repository_id uuid;
+begin
+    -- Check the package exists
+    select repository_id into v_repository_id
+    from package
+    where package_id = v_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Answers can only be posted on visible questions of the same package
+    if v_parent_id is not null then
+        perform from package_comment
+        where package_comment_id = v_parent_id
+        and package_id = v_package_id
+        and parent_id is null
+        and hidden = false;
+        if not found then
+            raise no_data_found;
+        end if;
+    end if;
+
+    -- Add comment
+    insert into package_comment (package_id, parent_id, user_id, body)
+    values (v_package_id, v_parent_id, p_user_id, p_comment->>'body')
+    returning package_comment_id into v_comment_id;
+
+    -- Register new question event, notifying the package's publishers (but
+    -- the requesting user)
+    if v_parent_id is null then
+        insert into event (package_id, event_kind_id, data)
+        select v_package_id, 10, jsonb_build_object(
+            'subscriptors', (
+                select coalesce(jsonb_agg(s), '[]')
+                from jsonb_array_elements(get_repository_subscriptors(v_repository_id, 10)::jsonb) s
+                where s->>'user_id' <> p_user_id::text
+            ),
+            'question', jsonb_build_object(
+                'comment_id', v_comment_id,
+                'body', p_comment->>'body',
+                'user_alias', (select alias from "user" where user_id = p_user_id)
+            )
+        );
+    end if;
+
+    return query select json_build_object('comment_id', v_comment_id);
+end
+$$ language plpgsql;
@@ -0,0 +1,30 @@
+-- delete_package_comment deletes the provided comment, as well as its answers
+-- when it is a question. Comments can be deleted by their authors and by the
+-- package's publishers.
+create or replace function delete_package_comment(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_comment_id uuid
+) returns void as $$
+declare
+    v_author_id uuid;
+begin
+    -- Get comment author
+    select user_id into v_author_id
+    from package_comment
+    where package_comment_id = p_comment_id
+    and package_id = p_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Check if the user doing the request is the author or the publisher
+    if v_author_id is distinct from p_user_id
+    and not user_is_package_publisher(p_user_id, p_package_id) then
+        raise insufficient_privilege;
+    end if;
+
+    -- Delete comment
+    delete from package_comment where package_comment_id = p_comment_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,69 @@
+-- get_package_comments returns the questions posted on the provided package,
+-- including their answers, as a json object using offset based pagination.
+-- Hidden comments are only returned to the package's publishers.
+create or replace function get_package_comments(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_limit int,
+    p_offset int
+) returns setof json as $$
+declare
+    v_is_publisher boolean := coalesce(user_is_package_publisher(p_user_id, p_package_id), false);
+begin
+    return query
+    with visible_comments as (
+        select
+            c.package_comment_id,
+            c.parent_id,
+            c.body,
+            c.hidden,
+            c.created_at,
+            c.updated_at,
+            u.alias as user_alias,
+            (c.user_id is not null and user_is_package_publisher(c.user_id, p_package_id)) as by_publisher
+        from package_comment c
+        left join "user" u using (user_id)
+        where c.package_id = p_package_id
+        and (c.hidden = false or v_is_publisher)
+    ), questions as (
+        select *
+        from visible_comments
+        where parent_id is null
+        order by created_at desc, package_comment_id asc
+        limit p_limit
+        offset p_offset
+    )
+    select json_build_object(
+        'questions', (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'comment_id', q.package_comment_id,
+                'body', q.body,
+                'hidden', nullif(q.hidden, false),
+                'user_alias', q.user_alias,
+                'by_publisher', nullif(q.by_publisher, false),
+                'created_at', floor(extract(epoch from q.created_at)),
+                'updated_at', floor(extract(epoch from q.updated_at)),
+                'answers', (
+                    select coalesce(json_agg(json_strip_nulls(json_build_object(
+                        'comment_id', a.package_comment_id,
+                        'body', a.body,
+                        'hidden', nullif(a.hidden, false),
+                        'user_alias', a.user_alias,
+                        'by_publisher', nullif(a.by_publisher, false),
+                        'created_at', floor(extract(epoch from a.created_at)),
+                        'updated_at', floor(extract(epoch from a.updated_at))
+                    )) order by a.created_at asc, a.package_comment_id asc), '[]')
+                    from visible_comments a
+                    where a.parent_id = q.package_comment_id
+                )
+            )) order by q.created_at desc, q.package_comment_id asc), '[]')
+            from questions q
+        ),
+        'metadata', json_build_object(
+            'limit', p_limit,
+            'offset', p_offset,
+            'total', (select count(*) from visible_comments where parent_id is null)
+        )
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,24 @@
+-- set_package_comment_hidden hides or unhides the provided comment. Hidden
+-- comments are only displayed to the package's publishers, who are the only
+-- ones allowed to moderate them.
+create or replace function set_package_comment_hidden(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_comment_id uuid,
+    p_hidden boolean
+) returns void as $$
+begin
+    -- Check if the user doing the request is the package's publisher
+    if not user_is_package_publisher(p_user_id, p_package_id) then
+        raise insufficient_privilege;
+    end if;
+
+    -- Update comment visibility
+    update package_comment set hidden = p_hidden
+    where package_comment_id = p_comment_id
+    and package_id = p_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
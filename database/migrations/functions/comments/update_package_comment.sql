@@ -0,0 +1,16 @@
+-- update_package_comment updates the body of the provided comment. Comments
+-- can only be updated by their authors.
+create or replace function update_package_comment(p_user_id uuid, p_comment jsonb)
+returns void as $$
+begin
+    update package_comment set
+        body = p_comment->>'body',
+        updated_at = current_timestamp
+    where package_comment_id = (p_comment->>'comment_id')::uuid
+    and package_id = (p_comment->>'package_id')::uuid
+    and user_id = p_user_id;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
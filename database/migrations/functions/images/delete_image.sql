@@ -0,0 +1,5 @@
+-- delete_image deletes the provided image from the database.
+create or replace function delete_image(p_image_id uuid)
+returns void as $$
+    delete from image where image_id = p_image_id;
+$$ language sql;
@@ -0,0 +1,15 @@
+-- get_images_created_before returns the images registered before the time
+-- provided, including the space they use.
+create or replace function get_images_created_before(p_created_before timestamptz)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'image_id', i.image_id,
+        'size', (
+            select coalesce(sum(length(data)), 0)
+            from image_version
+            where image_id = i.image_id
+        )
+    )), '[]')
+    from image i
+    where i.created_at < p_created_before;
+$$ language sql;
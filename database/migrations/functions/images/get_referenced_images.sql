@@ -0,0 +1,15 @@
+-- get_referenced_images returns the ids of the images referenced by any
+-- organization, user, package or package version.
+create or replace function get_referenced_images()
+returns setof json as $$
+    select coalesce(json_agg(image_id), '[]')
+    from (
+        select logo_image_id as image_id from organization where logo_image_id is not null
+        union
+        select profile_image_id from "user" where profile_image_id is not null
+        union
+        select logo_image_id from package where logo_image_id is not null
+        union
+        select logo_image_id from snapshot where logo_image_id is not null
+    ) ri;
+$$ language sql;
@@ -0,0 +1,8 @@
+-- defer_notification postpones the delivery of the provided notification until
+-- the time given. Deferred deliveries are not counted as delivery attempts.
+create or replace function defer_notification(p_notification_id uuid, p_until timestamptz)
+returns void as $$
+    update notification set
+        next_attempt_at = p_until
+    where notification_id = p_notification_id;
+$$ language sql;
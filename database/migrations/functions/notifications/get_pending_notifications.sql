@@ -0,0 +1,119 @@
+-- get_pending_notifications claims a batch of pending notifications, up to
+-- the limit provided, returning them as a json array. Claimed notifications
+-- are leased for some minutes, so that several workers can claim batches
+-- concurrently without processing the same notifications, and those whose
+-- outcome is never recorded are claimed again once the lease expires.
+-- Webhooks rate limits are honored, even when several notifications for the
+-- same webhook are pending, and notifications exceeding them do not take up
+-- any of the batch slots. Deliveries are reserved atomically in the webhooks
+-- rate limit windows when the notifications are claimed.
+create or replace function get_pending_notifications(p_limit integer)
+returns setof json as $$
+declare
+    v_claimed uuid[];
+    v_webhook record;
+    v_granted integer;
+begin
+    -- Lock the oldest pending notifications, skipping those exceeding the
+    -- deliveries still available in their webhook's rate limit window
+    select coalesce(array_agg(c.notification_id), '{}') into v_claimed
+    from (
+        select n.notification_id
+        from notification n
+        left join webhook wh using (webhook_id)
+        where n.processed = false
+        and (n.next_attempt_at is null or n.next_attempt_at <= current_timestamp)
+        and (
+            wh.rate_limit is null
+            or (
+                select count(*)
+                from notification pn
+                where pn.webhook_id = n.webhook_id
+                and pn.processed = false
+                and (pn.next_attempt_at is null or pn.next_attempt_at <= current_timestamp)
+                and (pn.created_at, pn.notification_id) < (n.created_at, n.notification_id)
+            ) < wh.rate_limit - (
+                case when wh.rate_limit_window_start > current_timestamp - interval '1 minute'
+                then wh.rate_limit_window_deliveries else 0 end
+            )
+        )
+        order by n.created_at asc
+        for update of n skip locked
+        limit p_limit
+    ) c;
+
+    -- Reserve the deliveries of the notifications for rate limited webhooks,
+    -- releasing the newest ones when not enough deliveries are granted
+    for v_webhook in
+        select n.webhook_id, count(*)::integer as deliveries
+        from notification n
+        join webhook wh using (webhook_id)
+        where n.notification_id = any(v_claimed)
+        and wh.rate_limit is not null
+        group by n.webhook_id
+        order by n.webhook_id asc
+    loop
+        v_granted := reserve_webhook_deliveries(v_webhook.webhook_id, v_webhook.deliveries);
+        if v_granted < v_webhook.deliveries then
+            v_claimed := array(
+                select unnest(v_claimed)
+                except
+                (
+                    select notification_id
+                    from notification
+                    where notification_id = any(v_claimed)
+                    and webhook_id = v_webhook.webhook_id
+                    order by created_at desc
+                    limit v_webhook.deliveries - v_granted
+                )
+            );
+        end if;
+    end loop;
+
+    -- Lease claimed notifications
+    update notification set
+        next_attempt_at = current_timestamp + interval '5 minutes'
+    where notification_id = any(v_claimed);
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'notification_id', n.notification_id,
+        'attempts', n.attempts,
+        'event', json_build_object(
+            'event_id', e.event_id,
+            'event_kind', e.event_kind_id,
+            'repository_id', e.repository_id,
+            'package_id', e.package_id,
+            'package_version', e.package_version
+        ),
+        'user', (select nullif(
+            jsonb_build_object(
+                'user_id', u.user_id,
+                'email', u.email,
+                'locale', u.locale,
+                'notifications_preferences', u.notifications_preferences
+            ),
+            '{"user_id": null, "email": null, "locale": null, "notifications_preferences": null}'::jsonb
+        )),
+        'webhook', (select nullif(
+            jsonb_build_object(
+                'webhook_id', wh.webhook_id,
+                'name', wh.name,
+                'url', wh.url,
+                'secret', wh.secret,
+                'content_type', wh.content_type,
+                'template', wh.template,
+                'format', wh.format,
+                'headers', wh.headers,
+                'batch', wh.batch
+            ),
+            '{"webhook_id": null, "name": null, "url": null, "secret": null, "content_type": null, "template": null, "format": null, "headers": null, "batch": null}'::jsonb
+        ))
+    )) order by n.created_at asc), '[]')
+    from notification n
+    join event e using (event_id)
+    left join "user" u using (user_id)
+    left join webhook wh using (webhook_id)
+    where n.notification_id = any(v_claimed);
+end
+$$ language plpgsql;
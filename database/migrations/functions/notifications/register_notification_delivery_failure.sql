@@ -0,0 +1,41 @@
+-- register_notification_delivery_failure registers a failed delivery attempt
+-- for the provided notification. The notification will be retried later unless
+-- the maximum number of attempts has been reached, in which case it will be
+-- moved to the dead-letter queue.
+create or replace function register_notification_delivery_failure(
+    p_notification_id uuid,
+    p_payload text,
+    p_error text,
+    p_max_attempts integer
+) returns void as $$
+declare
+    v_attempts integer;
+begin
+    update notification set
+        attempts = attempts + 1,
+        error = nullif(p_error, '')
+    where notification_id = p_notification_id
+    returning attempts into v_attempts;
+
+    if v_attempts < p_max_attempts then
+        -- Schedule a new attempt with a linear backoff
+        update notification set
+            next_attempt_at = current_timestamp + (interval '5 minutes' * v_attempts)
+        where notification_id = p_notification_id;
+    else
+        -- Retries exhausted, move the notification to the dead-letter queue
+        update notification set
+            processed = true,
+            processed_at = current_timestamp,
+            next_attempt_at = null
+        where notification_id = p_notification_id;
+
+        insert into notification_dead_letter (notification_id, payload, error)
+        values (p_notification_id, nullif(p_payload, ''), nullif(p_error, ''))
+        on conflict (notification_id) do update set
+            payload = excluded.payload,
+            error = excluded.error,
+            created_at = current_timestamp;
+    end if;
+end
+$$ language plpgsql;
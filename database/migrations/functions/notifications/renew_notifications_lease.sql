@@ -0,0 +1,18 @@
+-- renew_notifications_lease extends the lease of the provided notifications,
+-- using the same duration applied when they were claimed, so that it does not
+-- expire while they are still waiting to be delivered in a long batch. Only
+-- pending notifications whose lease has not expired yet are renewed, as the
+-- others may have been claimed again. It returns true when the lease of all
+-- the notifications provided was renewed.
+create or replace function renew_notifications_lease(p_notification_ids uuid[])
+returns boolean as $$
+    with renewed as (
+        update notification set
+            next_attempt_at = current_timestamp + interval '5 minutes'
+        where notification_id = any(p_notification_ids)
+        and processed = false
+        and next_attempt_at > current_timestamp
+        returning notification_id
+    )
+    select count(*) = cardinality(p_notification_ids) from renewed;
+$$ language sql;
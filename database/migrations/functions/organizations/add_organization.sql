@@ -19,8 +19,8 @@ begin
         nullif(p_org->>'logo_image_id', '')::uuid
     ) returning organization_id into v_org_id;
 
-    -- Add user who created the organization to it
-    insert into user__organization (user_id, organization_id, confirmed)
-    values (p_user_id, v_org_id, true);
+    -- Add user who created the organization to it as owner
+    insert into user__organization (user_id, organization_id, confirmed, role)
+    values (p_user_id, v_org_id, true, 'owner');
 end
 $$ language plpgsql;
@@ -0,0 +1,22 @@
+-- cancel_organization_deletion cancels the scheduled deletion of the provided
+-- organization, as well as the deletion of its service accounts.
+create or replace function cancel_organization_deletion(p_requesting_user_id uuid, p_org_name text)
+returns void as $$
+declare
+    v_org_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update organization set deletion_scheduled_at = null
+    where name = p_org_name
+    and deletion_scheduled_at is not null
+    returning organization_id into v_org_id;
+    if not found then
+        raise no_data_found;
+    end if;
+    update "user" set deletion_scheduled_at = null
+    where service_account_organization_id = v_org_id;
+end
+$$ language plpgsql;
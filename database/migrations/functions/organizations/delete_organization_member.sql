@@ -20,11 +20,42 @@ begin
         raise 'last member of an organization cannot leave it';
     end if;
 
+    -- Last owner of an organization cannot leave it
+    if not exists (
+        select 1
+        from user__organization uo
+        join organization o using (organization_id)
+        join "user" u using (user_id)
+        where o.name = p_org_name
+        and uo.role = 'owner'
+        and u.alias <> p_user_alias
+    ) and exists (
+        select 1
+        from user__organization uo
+        join organization o using (organization_id)
+        join "user" u using (user_id)
+        where o.name = p_org_name
+        and uo.role = 'owner'
+        and u.alias = p_user_alias
+    ) then
+        raise 'last owner of an organization cannot leave it';
+    end if;
+
     -- Delete member from organization
     delete from user__organization
     where user_id = (select user_id from "user" where alias = p_user_alias)
     and organization_id = (select organization_id from organization where name = p_org_name);
 
+    -- Delete member from the organization teams
+    delete from user__team
+    where user_id = (select user_id from "user" where alias = p_user_alias)
+    and team_id in (
+        select team_id
+        from team t
+        join organization o using (organization_id)
+        where o.name = p_org_name
+    );
+
     -- Delete user opt-out entries for repositories belonging to the org
     delete from opt_out
     where user_id = (select user_id from "user" where alias = p_user_alias)
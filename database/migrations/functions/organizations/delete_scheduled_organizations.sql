@@ -0,0 +1,16 @@
+-- delete_scheduled_organizations deletes the organizations whose deletion
+-- grace period has elapsed, returning the number of organizations deleted.
+-- Repositories, webhooks and service accounts (including their API keys) are
+-- deleted in cascade. The organizations logos are collected by the images gc.
+create or replace function delete_scheduled_organizations()
+returns integer as $$
+declare
+    v_orgs_deleted integer;
+begin
+    delete from organization
+    where deletion_scheduled_at <= current_timestamp;
+    get diagnostics v_orgs_deleted = row_count;
+
+    return v_orgs_deleted;
+end
+$$ language plpgsql;
@@ -1,4 +1,5 @@
--- get_organization returns the organization requested as a json object.
+-- get_organization returns the organization requested as a json object. The
+-- organization can also be requested using any of its previous names.
 create or replace function get_organization(p_org_name text)
 returns setof json as $$
     select json_strip_nulls(json_build_object(
@@ -9,5 +10,8 @@ returns setof json as $$
         'logo_image_id', o.logo_image_id
     ))
     from organization o
-    where o.name = p_org_name;
+    where o.organization_id = coalesce(
+        (select organization_id from organization where name = p_org_name),
+        (select organization_id from organization_name_alias where name = p_org_name)
+    );
 $$ language sql;
@@ -12,10 +12,11 @@ begin
         'alias', u.alias,
         'first_name', u.first_name,
         'last_name', u.last_name,
-        'confirmed', u.confirmed
+        'confirmed', u.confirmed,
+        'role', u.role
     )))
     from (
-        select u.alias, u.first_name, u.last_name, uo.confirmed
+        select u.alias, u.first_name, u.last_name, uo.confirmed, uo.role
         from "user" u
         join user__organization uo using (user_id)
         join organization o using (organization_id)
@@ -0,0 +1,19 @@
+-- get_user_allowed_actions returns the actions the user provided is allowed to
+-- perform in the organization given, based on the user's role. Service
+-- accounts are granted the actions returned by
+-- get_service_account_allowed_actions.
+create or replace function get_user_allowed_actions(p_user_id uuid, p_org_name text)
+returns text[] as $$
+    select coalesce((
+        select r.allowed_actions
+        from organization_role r
+        where r.role = (
+            select uo.role
+            from user__organization uo
+            join organization o using (organization_id)
+            where o.name = p_org_name
+            and uo.user_id = p_user_id
+            and uo.confirmed = true
+        )
+    ), get_service_account_allowed_actions(p_user_id, p_org_name));
+$$ language sql;
@@ -9,6 +9,7 @@ returns setof json as $$
         'home_url', o.home_url,
         'logo_image_id', o.logo_image_id,
         'confirmed', o.confirmed,
+        'deletion_scheduled_at', floor(extract(epoch from o.deletion_scheduled_at)),
         'members_count', (
             select count(*)
             from user__organization
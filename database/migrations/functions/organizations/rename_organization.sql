@@ -0,0 +1,15 @@
+-- rename_organization renames the provided organization if the user provided
+-- belongs to it. The previous name is kept as an alias of the organization.
+create or replace function rename_organization(p_requesting_user_id uuid, p_org_name text, p_new_name text)
+returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+    if exists (select 1 from organization where name = p_new_name) then
+        raise 'organization name not available';
+    end if;
+
+    update organization set name = p_new_name where name = p_org_name;
+end
+$$ language plpgsql;
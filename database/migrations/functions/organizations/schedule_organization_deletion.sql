@@ -0,0 +1,45 @@
+-- schedule_organization_deletion schedules the deletion of the organization
+-- provided once the grace period has elapsed. Before that, the repositories
+-- of the organization can be transferred to another organization the user
+-- belongs to or to the user doing the request. The organization's service
+-- accounts deletion is scheduled as well, so their API keys stop working
+-- immediately.
+create or replace function schedule_organization_deletion(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_input jsonb,
+    p_grace_period interval
+) returns void as $$
+declare
+    v_org_id uuid;
+    v_deletion_scheduled_at timestamptz := current_timestamp + p_grace_period;
+    v_transfer_to_org text := nullif(p_input->>'transfer_repositories_to_org', '');
+    v_transfer_to_user boolean := coalesce((p_input->>'transfer_repositories_to_user')::boolean, false);
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+    select organization_id into v_org_id from organization where name = p_org_name;
+
+    -- Transfer organization's repositories if requested
+    if v_transfer_to_org is not null then
+        if not user_belongs_to_organization(p_requesting_user_id, v_transfer_to_org) then
+            raise insufficient_privilege;
+        end if;
+        update repository set
+            organization_id = (select organization_id from organization where name = v_transfer_to_org)
+        where organization_id = v_org_id;
+    elsif v_transfer_to_user then
+        update repository set
+            user_id = p_requesting_user_id,
+            organization_id = null
+        where organization_id = v_org_id;
+    end if;
+
+    -- Schedule organization and service accounts deletion
+    update organization set deletion_scheduled_at = v_deletion_scheduled_at
+    where organization_id = v_org_id;
+    update "user" set deletion_scheduled_at = v_deletion_scheduled_at
+    where service_account_organization_id = v_org_id;
+end
+$$ language plpgsql;
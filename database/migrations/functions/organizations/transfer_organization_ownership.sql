@@ -0,0 +1,47 @@
+-- transfer_organization_ownership makes the member provided an owner of the
+-- organization, demoting the requesting user to the admin role. Only owners
+-- of the organization can transfer its ownership.
+create or replace function transfer_organization_ownership(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_user_alias text
+) returns void as $$
+declare
+    v_org_id uuid;
+    v_user_id uuid;
+begin
+    -- Check the requesting user is an owner of the organization
+    select organization_id into v_org_id
+    from organization o
+    join user__organization uo using (organization_id)
+    where o.name = p_org_name
+    and uo.user_id = p_requesting_user_id
+    and uo.confirmed = true
+    and uo.role = 'owner';
+    if not found then
+        raise insufficient_privilege;
+    end if;
+
+    -- Check the new owner is a confirmed member of the organization
+    select u.user_id into v_user_id
+    from "user" u
+    join user__organization uo using (user_id)
+    where u.alias = p_user_alias
+    and uo.organization_id = v_org_id
+    and uo.confirmed = true;
+    if not found then
+        raise no_data_found;
+    end if;
+    if v_user_id = p_requesting_user_id then
+        raise 'ownership cannot be transferred to the current owner';
+    end if;
+
+    -- Transfer ownership
+    update user__organization set role = 'owner'
+    where organization_id = v_org_id
+    and user_id = v_user_id;
+    update user__organization set role = 'admin'
+    where organization_id = v_org_id
+    and user_id = p_requesting_user_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,48 @@
+-- update_organization_member_role updates the role of a member of the provided
+-- organization.
+create or replace function update_organization_member_role(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_user_alias text,
+    p_role text
+) returns void as $$
+declare
+    v_org_id uuid;
+    v_user_id uuid;
+    v_current_role text;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    perform from organization_role where role = p_role;
+    if not found then
+        raise 'invalid role';
+    end if;
+
+    select organization_id into v_org_id from organization where name = p_org_name;
+    select user_id into v_user_id from "user" where alias = p_user_alias;
+    select role into v_current_role
+    from user__organization
+    where user_id = v_user_id
+    and organization_id = v_org_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Last owner of an organization cannot be demoted
+    if v_current_role = 'owner' and p_role <> 'owner' and not exists (
+        select 1
+        from user__organization
+        where organization_id = v_org_id
+        and role = 'owner'
+        and user_id <> v_user_id
+    ) then
+        raise 'last owner of an organization cannot be demoted';
+    end if;
+
+    update user__organization set role = p_role
+    where user_id = v_user_id
+    and organization_id = v_org_id;
+end
+$$ language plpgsql;
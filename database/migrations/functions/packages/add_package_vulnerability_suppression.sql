@@ -0,0 +1,59 @@
+-- add_package_vulnerability_suppression marks the provided vulnerability as
+-- not applicable to the package, including the justification provided. The
+-- package's snapshots already scanned are scheduled for a rescan, so that
+-- their security reports reflect the suppression. Only the users owning the
+-- package (directly or through the organization that owns its repository) can
+-- suppress vulnerabilities.
+create or replace function add_package_vulnerability_suppression(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_vulnerability_id text,
+    p_justification text
+) returns void as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the package's repository
+    select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
+    from package p
+    join repository r using (repository_id)
+    left join organization o using (organization_id)
+    where p.package_id = p_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Add or update suppression
+    insert into package_vulnerability_suppression (
+        package_id,
+        vulnerability_id,
+        justification,
+        user_id
+    ) values (
+        p_package_id,
+        p_vulnerability_id,
+        p_justification,
+        p_user_id
+    )
+    on conflict (package_id, vulnerability_id) do update set
+        justification = excluded.justification,
+        user_id = excluded.user_id,
+        created_at = current_timestamp;
+
+    -- Request rescan of the snapshots already scanned
+    update snapshot set security_report_rescan_requested_at = current_timestamp
+    where package_id = p_package_id
+    and security_report is not null;
+end
+$$ language plpgsql;
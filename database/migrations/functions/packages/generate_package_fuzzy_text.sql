@@ -0,0 +1,10 @@
+-- generate_package_fuzzy_text generates the package's text used to perform
+-- fuzzy searches, which are able to match packages even when the text
+-- provided contains typos.
+create or replace function generate_package_fuzzy_text(
+    p_name text,
+    p_display_name text,
+    p_keywords text[]
+) returns text as $$
+    select concat_ws(' ', p_name, p_display_name, array_to_string(p_keywords, ' '));
+$$ language sql immutable;
@@ -46,6 +46,7 @@ begin
                 'version', version,
                 'contains_security_updates', contains_security_updates,
                 'prerelease', prerelease,
+                'yanked', yanked,
                 'ts', floor(extract(epoch from ts))
             ))
             from snapshot
@@ -54,18 +55,31 @@ begin
         'app_version', s.app_version,
         'digest', s.digest,
         'deprecated', s.deprecated,
+        'yanked', s.yanked,
+        'yanked_reason', s.yanked_reason,
         'contains_security_updates', s.contains_security_updates,
         'prerelease', s.prerelease,
         'license', s.license,
         'signed', s.signed,
+        'signatures', s.signatures,
+        'provenance', s.provenance,
         'content_url', s.content_url,
         'containers_images', s.containers_images,
         'provider', s.provider,
         'has_values_schema', (s.values_schema is not null and s.values_schema <> '{}'),
+        'has_sbom', (s.sboms is not null and s.sboms <> '[]'),
         'has_changelog', (select exists (
             select 1 from snapshot where package_id = v_package_id and changes is not null
         )),
         'changes', s.changes,
+        'rating', p.rating,
+        'ratings', nullif(p.ratings, 0),
+        'pulls_last_month', (
+            select sum(pulls)
+            from package_pulls
+            where package_id = v_package_id
+            and day > current_date - 30
+        ),
         'ts', floor(extract(epoch from s.ts)),
         'maintainers', (
             select json_agg(json_build_object(
@@ -23,6 +23,8 @@ begin
         'name', p.name,
         'normalized_name', p.normalized_name,
         'stars', p.stars,
+        'rating', p.rating,
+        'ratings', nullif(p.ratings, 0),
         'official', p.official,
         'display_name', s.display_name,
         'description', s.description,
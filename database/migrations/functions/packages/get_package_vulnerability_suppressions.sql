@@ -0,0 +1,12 @@
+-- get_package_vulnerability_suppressions returns the vulnerabilities
+-- suppressions of the provided package as a json array.
+create or replace function get_package_vulnerability_suppressions(p_package_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'vulnerability_id', vulnerability_id,
+        'justification', justification,
+        'created_at', floor(extract(epoch from created_at))
+    ) order by vulnerability_id), '[]')
+    from package_vulnerability_suppression
+    where package_id = p_package_id;
+$$ language sql;
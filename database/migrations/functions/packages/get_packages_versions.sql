@@ -0,0 +1,12 @@
+-- get_packages_versions returns the versions available of the packages
+-- provided as a json object, where the keys are the packages ids.
+create or replace function get_packages_versions(p_packages_ids uuid[])
+returns setof json as $$
+    select coalesce(json_object_agg(package_id, versions), '{}')
+    from (
+        select package_id, json_agg(version order by version) as versions
+        from snapshot
+        where package_id = any(p_packages_ids)
+        group by package_id
+    ) pv;
+$$ language sql;
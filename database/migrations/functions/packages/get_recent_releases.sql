@@ -0,0 +1,71 @@
+-- get_recent_releases returns the most recent releases of the packages that
+-- belong to the repository or publisher provided as a json array. Yanked
+-- versions are not included. No rows are returned when the repository or the
+-- publisher provided do not exist.
+create or replace function get_recent_releases(p_input jsonb)
+returns setof json as $$
+declare
+    v_repository_name text := nullif(p_input->>'repository_name', '');
+    v_publisher text := nullif(p_input->>'publisher', '');
+    v_limit int := coalesce((p_input->>'limit')::int, 50);
+begin
+    -- Check the repository or publisher provided exist
+    if v_repository_name is not null and not exists (
+        select 1 from repository where name = v_repository_name
+    ) then
+        return;
+    end if;
+    if v_publisher is not null and not exists (
+        select 1 from "user" where alias = v_publisher
+        union all
+        select 1 from organization where name = v_publisher
+    ) then
+        return;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'package_id', rr.package_id,
+        'name', rr.name,
+        'normalized_name', rr.normalized_name,
+        'display_name', rr.display_name,
+        'description', rr.description,
+        'version', rr.version,
+        'ts', floor(extract(epoch from rr.ts)),
+        'repository', json_build_object(
+            'kind', rr.repository_kind_id,
+            'name', rr.repository_name,
+            'display_name', rr.repository_display_name,
+            'user_alias', rr.user_alias,
+            'organization_name', rr.organization_name,
+            'organization_display_name', rr.organization_display_name
+        )
+    )) order by rr.ts desc), '[]')
+    from (
+        select
+            p.package_id,
+            p.name,
+            p.normalized_name,
+            s.display_name,
+            s.description,
+            s.version,
+            s.ts,
+            r.repository_kind_id,
+            r.name as repository_name,
+            r.display_name as repository_display_name,
+            u.alias as user_alias,
+            o.name as organization_name,
+            o.display_name as organization_display_name
+        from snapshot s
+        join package p using (package_id)
+        join repository r using (repository_id)
+        left join "user" u on r.user_id = u.user_id
+        left join organization o on r.organization_id = o.organization_id
+        where s.yanked = false
+        and (v_repository_name is null or r.name = v_repository_name)
+        and (v_publisher is null or u.alias = v_publisher or o.name = v_publisher)
+        order by s.ts desc
+        limit v_limit
+    ) rr;
+end
+$$ language plpgsql;
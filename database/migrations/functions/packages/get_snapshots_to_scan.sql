@@ -1,5 +1,7 @@
 -- get_snapshots_to_scan returns the snapshots to scan for security
--- vulnerabilities as a json array.
+-- vulnerabilities as a json array. Snapshots whose rescan has been requested
+-- are returned first. The vulnerabilities suppressions of the package are
+-- included as well, so that they can be applied to the security report.
 create or replace function get_snapshots_to_scan()
 returns setof json as $$
     select coalesce(json_agg(json_build_object(
@@ -10,6 +12,14 @@ returns setof json as $$
         'containers_images', jsonb_path_query_array(
             containers_images,
             '$[*] ? (!exists(@.whitelisted) || @.whitelisted <> true)'
+        ),
+        'suppressed_vulnerabilities', (
+            select json_agg(json_build_object(
+                'vulnerability_id', pvs.vulnerability_id,
+                'justification', pvs.justification
+            ))
+            from package_vulnerability_suppression pvs
+            where pvs.package_id = s.package_id
         )
     )), '[]')
     from (
@@ -26,9 +36,10 @@ returns setof json as $$
         and r.scanner_disabled = false
         and (
             security_report is null
+            or s.security_report_rescan_requested_at is not null
             or (security_report_created_at < (current_timestamp - '1 day'::interval) and s.version = p.latest_version )
             or security_report_created_at < (current_timestamp - '1 week'::interval)
         )
-        order by s.created_at desc
+        order by s.security_report_rescan_requested_at asc nulls last, s.created_at desc
     ) s;
 $$ language sql;
@@ -2,11 +2,17 @@
 -- involves registering or updating the package entity when needed, registering
 -- a snapshot for the package version and creating/updating/deleting the
 -- package maintainers as needed depending on the ones present in the latest
--- package version.
+-- package version. Events are registered as well when a new package is added
+-- to a repository that has already been tracked, when a new release of the
+-- package is published or when a package version is marked as deprecated.
+-- Versions that have been yanked are not considered when updating the
+-- package's latest version, and they don't trigger new release events.
 create or replace function register_package(p_pkg jsonb)
 returns void as $$
 declare
     v_previous_latest_version text;
+    v_previous_deprecated boolean;
+    v_yanked boolean;
     v_package_id uuid;
     v_name text := p_pkg->>'name';
     v_display_name text := nullif(p_pkg->>'display_name', '');
@@ -22,10 +28,11 @@ declare
     v_ts_repository text[];
     v_ts_publisher text[];
     v_repository_disabled boolean;
+    v_repository_last_tracking_ts timestamptz;
 begin
     -- Get some repository information (some of it for tsdoc)
-    select r.disabled, array[r.name, r.display_name], array[u.alias, o.name, o.display_name, v_provider]
-    into v_repository_disabled, v_ts_repository, v_ts_publisher
+    select r.disabled, r.last_tracking_ts, array[r.name, r.display_name], array[u.alias, o.name, o.display_name, v_provider]
+    into v_repository_disabled, v_repository_last_tracking_ts, v_ts_repository, v_ts_publisher
     from repository r
     left join "user" u using (user_id)
     left join organization o using (organization_id)
@@ -44,11 +51,22 @@ begin
     where name = v_name
     and repository_id = v_repository_id;
 
+    -- Check if the package version being registered has been yanked
+    v_yanked := coalesce((
+        select s.yanked
+        from snapshot s
+        join package p using (package_id)
+        where p.name = v_name
+        and p.repository_id = v_repository_id
+        and s.version = v_version
+    ), false);
+
     -- Package
     insert into package (
         name,
         latest_version,
         tsdoc,
+        fuzzy_text,
         is_operator,
         channels,
         default_channel,
@@ -57,6 +75,7 @@ begin
         v_name,
         v_version,
         generate_package_tsdoc(v_name, v_display_name, v_description, v_keywords, v_ts_repository, v_ts_publisher),
+        generate_package_fuzzy_text(v_name, v_display_name, v_keywords),
         (p_pkg->>'is_operator')::boolean,
         nullif(p_pkg->'channels', 'null'),
         nullif(p_pkg->>'default_channel', ''),
@@ -67,10 +86,12 @@ begin
         name = excluded.name,
         latest_version = excluded.latest_version,
         tsdoc = generate_package_tsdoc(v_name, v_display_name, v_description, v_keywords, v_ts_repository, v_ts_publisher),
+        fuzzy_text = excluded.fuzzy_text,
         is_operator = excluded.is_operator,
         channels = excluded.channels,
         default_channel = excluded.default_channel
     where semver_gte(v_version, package.latest_version) = true
+    and v_yanked = false
     returning package_id into v_package_id;
 
     -- If package record has been created or updated
@@ -121,6 +142,12 @@ begin
         and repository_id = v_repository_id;
     end if;
 
+    -- Get package version's deprecated flag before registration, if available
+    select coalesce(deprecated, false) into v_previous_deprecated
+    from snapshot
+    where package_id = v_package_id
+    and version = v_version;
+
     -- Package snapshot
     v_ts := to_timestamp((p_pkg->>'ts')::int);
     if v_ts is null then
@@ -147,10 +174,14 @@ begin
         deprecated,
         license,
         signed,
+        signatures,
+        provenance,
         content_url,
         containers_images,
         provider,
         values_schema,
+        default_values,
+        sboms,
         changes,
         contains_security_updates,
         prerelease,
@@ -177,10 +208,14 @@ begin
         (p_pkg->>'deprecated')::boolean,
         nullif(p_pkg->>'license', ''),
         (p_pkg->>'signed')::boolean,
+        nullif(p_pkg->'signatures', 'null'),
+        nullif(p_pkg->'provenance', 'null'),
         nullif(p_pkg->>'content_url', ''),
         nullif(p_pkg->'containers_images', 'null'),
         v_provider,
         nullif(p_pkg->'values_schema', 'null'),
+        nullif(p_pkg->>'default_values', ''),
+        nullif(p_pkg->'sboms', 'null'),
         v_changes,
         (p_pkg->>'contains_security_updates')::boolean,
         (p_pkg->>'prerelease')::boolean,
@@ -207,20 +242,42 @@ begin
         deprecated = excluded.deprecated,
         license = excluded.license,
         signed = excluded.signed,
+        signatures = excluded.signatures,
+        provenance = excluded.provenance,
         content_url = excluded.content_url,
         containers_images = excluded.containers_images,
         provider = excluded.provider,
         values_schema = excluded.values_schema,
+        default_values = excluded.default_values,
+        sboms = excluded.sboms,
         changes = excluded.changes,
         contains_security_updates = excluded.contains_security_updates,
         prerelease = excluded.prerelease,
         recommendations = excluded.recommendations,
         ts = v_ts;
 
+    -- Register new package event if the package has just been added to a
+    -- repository that has already been tracked (packages indexed during the
+    -- first tracking of a repository are not considered new)
+    if v_previous_latest_version is null and v_repository_last_tracking_ts is not null then
+        insert into event (package_id, package_version, event_kind_id)
+        values (v_package_id, v_version, 7);
+    end if;
+
     -- Register new release event if package's latest version has been updated
-    if semver_gt(v_version, v_previous_latest_version) then
+    if semver_gt(v_version, v_previous_latest_version) and v_yanked = false then
         insert into event (package_id, package_version, event_kind_id)
         values (v_package_id, v_version, 0);
     end if;
+
+    -- Register package deprecated event if an existing package version has
+    -- been marked as deprecated or if the new latest version is deprecated
+    if (p_pkg->>'deprecated')::boolean = true and (
+        v_previous_deprecated = false or
+        (v_previous_deprecated is null and semver_gt(v_version, v_previous_latest_version))
+    ) then
+        insert into event (package_id, package_version, event_kind_id)
+        values (v_package_id, v_version, 5);
+    end if;
 end
 $$ language plpgsql;
@@ -0,0 +1,43 @@
+-- request_snapshot_security_report_rescan requests the provided package's
+-- snapshot to be scanned again for security vulnerabilities the next time the
+-- scanner runs. Only the users owning the package (directly or through the
+-- organization that owns its repository) can request a rescan.
+create or replace function request_snapshot_security_report_rescan(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_version text
+) returns void as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the package's repository
+    select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
+    from package p
+    join repository r using (repository_id)
+    left join organization o using (organization_id)
+    where p.package_id = p_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Request rescan
+    update snapshot set security_report_rescan_requested_at = current_timestamp
+    where package_id = p_package_id
+    and version = p_version
+    and containers_images is not null;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
@@ -13,13 +13,47 @@ declare
     v_tsquery_web tsquery := websearch_to_tsquery(p_input->>'ts_query_web');
     v_tsquery_web_with_prefix_matching tsquery;
     v_tsquery tsquery := to_tsquery(p_input->>'ts_query');
+    v_fuzzy_query text;
+    v_sort text := coalesce(nullif(p_input->>'sort', ''), 'relevance');
+    v_cursor jsonb;
+    v_cursor_rank real;
+    v_cursor_official boolean;
+    v_cursor_verified_publisher boolean;
+    v_cursor_stars int;
+    v_cursor_ts bigint;
+    v_cursor_package_id uuid;
 begin
+    -- Decode pagination cursor, if provided
+    v_cursor := get_cursor(p_input, '{rank,official,verified_publisher,stars,ts,name,package_id}');
+    if v_cursor is not null then
+        begin
+            v_cursor_rank := (v_cursor->>'rank')::real;
+            v_cursor_official := (v_cursor->>'official')::boolean;
+            v_cursor_verified_publisher := (v_cursor->>'verified_publisher')::boolean;
+            v_cursor_stars := (v_cursor->>'stars')::int;
+            v_cursor_ts := (v_cursor->>'ts')::bigint;
+            v_cursor_package_id := (v_cursor->>'package_id')::uuid;
+        exception when others then
+            raise exception 'invalid cursor' using errcode = 'invalid_parameter_value';
+        end;
+    end if;
+
+    -- Prepare fuzzy query, used to match packages whose name, display name or
+    -- keywords are similar to the text provided (i.e. containing typos) when
+    -- fuzzy search is enabled
+    if (p_input->>'fuzzy')::boolean = true and p_input->>'ts_query_web' <> '' then
+        v_fuzzy_query := lower(p_input->>'ts_query_web');
+    end if;
+
     -- Prepare filters for later use
     select array_agg(e::int) into v_repository_kinds
     from jsonb_array_elements_text(p_input->'repository_kinds') e;
     select array_agg(e::text) into v_users
     from jsonb_array_elements_text(p_input->'users') e;
-    select array_agg(e::text) into v_orgs
+    select array_agg(coalesce(
+        (select o.name from organization_name_alias a join organization o using (organization_id) where a.name = e),
+        e
+    )) into v_orgs
     from jsonb_array_elements_text(p_input->'orgs') e;
     select array_agg(e::text) into v_repositories
     from jsonb_array_elements_text(p_input->'repositories') e;
@@ -49,8 +83,12 @@ begin
             p.name,
             p.normalized_name,
             p.stars,
+            p.rating,
+            p.ratings,
             p.tsdoc,
+            p.fuzzy_text,
             p.official as package_official,
+            p.created_at,
             s.display_name,
             s.description,
             s.logo_image_id,
@@ -81,9 +119,13 @@ begin
         left join organization o using (organization_id)
         where s.version = p.latest_version
         and
-            case when v_tsquery_web is not null then
-                v_tsquery_web_with_prefix_matching @@ p.tsdoc
-            else true end
+            case
+                when v_tsquery_web is not null and v_fuzzy_query is not null then
+                    v_tsquery_web_with_prefix_matching @@ p.tsdoc or v_fuzzy_query <% p.fuzzy_text
+                when v_tsquery_web is not null then
+                    v_tsquery_web_with_prefix_matching @@ p.tsdoc
+                else true
+            end
         and
             case when v_tsquery is not null then
                 v_tsquery @@ p.tsdoc
@@ -136,6 +178,74 @@ begin
         and
             case when cardinality(v_capabilities) > 0
             then capabilities = any(v_capabilities) else true end
+    ), packages_ranked as (
+        select
+            paaf.*,
+            (case when v_tsquery_web is not null then
+                ts_rank(ts_filter(tsdoc, '{a}'), v_tsquery_web, 1) +
+                ts_rank('{0.1, 0.2, 0.2, 1.0}', ts_filter(tsdoc, '{b,c}'), v_tsquery_web)
+            else 1 end) +
+            (case when v_fuzzy_query is not null then
+                word_similarity(v_fuzzy_query, fuzzy_text)
+            else 0 end) as rank,
+            (case
+                when repository_official = true or package_official = true
+                then true else false
+            end) as official
+        from packages_applying_all_filters paaf
+    ), packages_sorted as (
+        -- Sort keys not used by the sort option requested are set to a
+        -- constant value, so that they don't affect the order
+        select
+            pr.*,
+            (case when v_sort = 'relevance' then rank else 0 end) as sort_rank,
+            (case when v_sort = 'relevance' then official else false end) as sort_official,
+            (case when v_sort = 'relevance' then verified_publisher else false end) as sort_verified_publisher,
+            (case when v_sort in ('relevance', 'stars') then stars else 0 end) as sort_stars,
+            (case
+                when v_sort = 'last_updated' then (extract(epoch from ts) * 1000000)::bigint
+                when v_sort = 'newest' then (extract(epoch from created_at) * 1000000)::bigint
+                else 0
+            end) as sort_ts
+        from packages_ranked pr
+    ), packages_page as (
+        select
+            ps.*,
+            row_number() over (
+                order by
+                    sort_rank desc,
+                    sort_official desc,
+                    sort_verified_publisher desc,
+                    sort_stars desc,
+                    sort_ts desc,
+                    name asc,
+                    package_id asc
+            ) as position,
+            count(*) over () as available
+        from packages_sorted ps
+        where
+            case when v_cursor is not null then
+                (
+                    -sort_rank,
+                    not sort_official,
+                    not sort_verified_publisher,
+                    -sort_stars,
+                    -sort_ts,
+                    name,
+                    package_id
+                ) > (
+                    -v_cursor_rank,
+                    not v_cursor_official,
+                    not v_cursor_verified_publisher,
+                    -v_cursor_stars,
+                    -v_cursor_ts,
+                    v_cursor->>'name',
+                    v_cursor_package_id
+                )
+            else true end
+        order by position asc
+        limit (p_input->>'limit')::int
+        offset (p_input->>'offset')::int
     )
     select json_strip_nulls(json_build_object(
         'data', (
@@ -147,6 +257,8 @@ begin
                         'normalized_name', normalized_name,
                         'logo_image_id', logo_image_id,
                         'stars', stars,
+                        'rating', rating,
+                        'ratings', nullif(ratings, 0),
                         'official', package_official,
                         'display_name', display_name,
                         'description', description,
@@ -169,28 +281,8 @@ begin
                             'organization_name', organization_name,
                             'organization_display_name', organization_display_name
                         )
-                    )), '[]')
-                    from (
-                        select
-                            paaf.*,
-                            (case when v_tsquery_web is not null then
-                                ts_rank(ts_filter(tsdoc, '{a}'), v_tsquery_web, 1) +
-                                ts_rank('{0.1, 0.2, 0.2, 1.0}', ts_filter(tsdoc, '{b,c}'), v_tsquery_web)
-                            else 1 end) as rank,
-                            (case
-                                when repository_official = true or package_official = true
-                                then true else false
-                            end) as official
-                        from packages_applying_all_filters paaf
-                        order by
-                            rank desc,
-                            official desc,
-                            verified_publisher desc,
-                            stars desc,
-                            name asc
-                        limit (p_input->>'limit')::int
-                        offset (p_input->>'offset')::int
-                    ) packages_applying_all_filters_paginated
+                    ) order by position asc), '[]')
+                    from packages_page
                 ),
                 'facets', case when v_facets then (
                     select json_build_array(
@@ -368,7 +460,22 @@ begin
             select json_build_object(
                 'limit', (p_input->>'limit')::int,
                 'offset', (p_input->>'offset')::int,
-                'total', (select count(*) from packages_applying_all_filters)
+                'total', (select count(*) from packages_applying_all_filters),
+                'next_cursor', (
+                    select encode_cursor(jsonb_build_object(
+                        'rank', sort_rank,
+                        'official', sort_official,
+                        'verified_publisher', sort_verified_publisher,
+                        'stars', sort_stars,
+                        'ts', sort_ts,
+                        'name', name,
+                        'package_id', package_id,
+                        'scope', get_cursor_scope(p_input)
+                    ))
+                    from packages_page
+                    where position = coalesce((p_input->>'offset')::int, 0) + (p_input->>'limit')::int
+                    and position < available
+                )
             )
         )
     ));
@@ -34,7 +34,8 @@ begin
         );
     else
         -- If the version to delete is the last version, we need to update the
-        -- package's latest version
+        -- package's latest version (yanked versions are only used as a last
+        -- resort)
         if p_pkg->>'version' = v_latest_version then
             update package set latest_version = new_latest_version
             from (
@@ -42,6 +43,7 @@ begin
                 from snapshot
                 where package_id = v_package_id and version <> v_latest_version
                 order by
+                    yanked asc,
                     (regexp_match(version, v_semver_regexp))[1:3]::int[] desc,
                     (regexp_match(version, v_semver_regexp))[4] desc nulls first
                 limit 1
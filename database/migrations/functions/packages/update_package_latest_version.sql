@@ -0,0 +1,23 @@
+-- update_package_latest_version updates the latest version of the provided
+-- package, setting it to the highest version available that has not been
+-- yanked. When all versions have been yanked, the highest one is used.
+create or replace function update_package_latest_version(p_package_id uuid)
+returns void as $$
+declare
+    v_semver_regexp text := '(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?';
+begin
+    update package set latest_version = nlv.new_latest_version
+    from (
+        select version as new_latest_version
+        from snapshot
+        where package_id = p_package_id
+        order by
+            yanked asc,
+            (regexp_match(version, v_semver_regexp))[1:3]::int[] desc,
+            (regexp_match(version, v_semver_regexp))[4] desc nulls first
+        limit 1
+    ) as nlv
+    where package_id = p_package_id
+    and latest_version <> nlv.new_latest_version;
+end
+$$ language plpgsql;
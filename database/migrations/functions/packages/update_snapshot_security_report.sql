@@ -1,11 +1,26 @@
 -- update_snapshot_security_report updates the security report of the package's
--- snapshot provides.
+-- snapshot provides. When new vulnerabilities have been found in the snapshot
+-- since the last scan, a security alert event is registered as well.
 create or replace function update_snapshot_security_report(p_report jsonb)
 returns void as $$
+begin
     update snapshot set
         security_report = p_report->'full',
         security_report_summary = p_report->'summary',
-        security_report_created_at = current_timestamp
+        security_report_created_at = current_timestamp,
+        security_report_rescan_requested_at = null
     where package_id = (p_report->>'package_id')::uuid
     and version = p_report->>'version';
-$$ language sql;
+
+    -- Register security alert event if new vulnerabilities were found
+    if found and jsonb_array_length(coalesce(nullif(p_report->'new_vulnerabilities', 'null'), '[]')) > 0 then
+        insert into event (package_id, package_version, event_kind_id, data)
+        values (
+            (p_report->>'package_id')::uuid,
+            p_report->>'version',
+            1,
+            jsonb_build_object('vulnerabilities', p_report->'new_vulnerabilities')
+        );
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,48 @@
+-- yank_package_version marks the provided package version as yanked. Yanked
+-- versions are still available, but they are not considered when resolving
+-- the package's latest version. Only the users owning the package (directly or
+-- through the organization that owns its repository) can yank its versions.
+create or replace function yank_package_version(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_version text,
+    p_reason text
+) returns void as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the package's repository
+    select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
+    from package p
+    join repository r using (repository_id)
+    left join organization o using (organization_id)
+    where p.package_id = p_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Yank package version
+    update snapshot set
+        yanked = true,
+        yanked_reason = nullif(p_reason, '')
+    where package_id = p_package_id
+    and version = p_version;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Update package's latest version if needed
+    perform update_package_latest_version(p_package_id);
+end
+$$ language plpgsql;
@@ -0,0 +1,9 @@
+-- decode_cursor returns the json object encoded in the pagination cursor
+-- provided.
+create or replace function decode_cursor(p_cursor text)
+returns jsonb as $$
+    select convert_from(decode(
+        translate(p_cursor, '-_', '+/') || repeat('=', (4 - length(p_cursor) % 4) % 4),
+        'base64'
+    ), 'UTF8')::jsonb;
+$$ language sql immutable;
@@ -0,0 +1,6 @@
+-- encode_cursor returns an opaque pagination cursor built from the json object
+-- provided. Cursors are encoded using url safe base64 without padding.
+create or replace function encode_cursor(p_cursor jsonb)
+returns text as $$
+    select rtrim(translate(encode(convert_to(p_cursor::text, 'UTF8'), 'base64'), E'+/\n', '-_'), '=');
+$$ language sql immutable;
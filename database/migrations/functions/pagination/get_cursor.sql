@@ -0,0 +1,28 @@
+-- get_cursor returns the pagination cursor included in the search input
+-- provided, if any. Cursors can only be used to continue the search that
+-- returned them, so an invalid_parameter_value error is raised when they
+-- cannot be decoded, some of the fields required are missing or they were
+-- obtained using different sort options or filters.
+create or replace function get_cursor(p_input jsonb, p_fields text[])
+returns jsonb as $$
+declare
+    v_cursor jsonb;
+begin
+    if coalesce(p_input->>'cursor', '') = '' then
+        return null;
+    end if;
+
+    begin
+        v_cursor := decode_cursor(p_input->>'cursor');
+    exception when others then
+        raise exception 'invalid cursor' using errcode = 'invalid_parameter_value';
+    end;
+    if jsonb_typeof(v_cursor) is distinct from 'object'
+    or v_cursor->>'scope' is distinct from get_cursor_scope(p_input)
+    or exists (select 1 from unnest(p_fields) f where v_cursor->>f is null) then
+        raise exception 'invalid cursor' using errcode = 'invalid_parameter_value';
+    end if;
+
+    return v_cursor;
+end
+$$ language plpgsql immutable;
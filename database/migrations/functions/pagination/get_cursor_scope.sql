@@ -0,0 +1,7 @@
+-- get_cursor_scope returns a value that identifies the sort options and
+-- filters of the search input provided. It's included in the pagination
+-- cursors, so that they cannot be used with a different search input.
+create or replace function get_cursor_scope(p_input jsonb)
+returns text as $$
+    select md5((p_input - 'cursor' - 'limit' - 'offset' - 'facets')::text);
+$$ language sql immutable;
@@ -0,0 +1,15 @@
+-- get_package_pulls returns the daily pull counts of the provided package for
+-- the number of days provided as a json object.
+create or replace function get_package_pulls(p_package_id uuid, p_days int)
+returns setof json as $$
+    select json_build_object(
+        'total', coalesce(sum(pulls), 0),
+        'daily', coalesce(json_agg(json_build_array(
+            floor(extract(epoch from day)*1000),
+            pulls
+        ) order by day asc), '[]')
+    )
+    from package_pulls
+    where package_id = p_package_id
+    and day > current_date - p_days;
+$$ language sql;
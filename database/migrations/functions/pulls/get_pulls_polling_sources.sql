@@ -0,0 +1,14 @@
+-- get_pulls_polling_sources returns the packages stored in OCI registries
+-- whose pull counts may be polled, along with their OCI reference, as a json
+-- array.
+create or replace function get_pulls_polling_sources()
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'package_id', p.package_id,
+        'ref', trim(trailing '/' from r.url)
+    ) order by p.package_id asc), '[]')
+    from package p
+    join repository r using (repository_id)
+    where r.repository_kind_id = 0
+    and r.url like 'oci://%';
+$$ language sql;
@@ -0,0 +1,35 @@
+-- get_trending_packages returns the packages with the highest number of pulls
+-- during the last week as a json array. Packages are ordered by the number of
+-- pulls and, on ties, by the growth compared to the previous week.
+create or replace function get_trending_packages(p_input jsonb)
+returns setof json as $$
+declare
+    v_kind int := (p_input->>'kind')::int;
+    v_limit int := coalesce((p_input->>'limit')::int, 10);
+begin
+    return query
+    select coalesce(json_agg(json_build_object(
+        'package', pkgJSON,
+        'pulls_last_week', tp.pulls_last_week,
+        'pulls_previous_week', tp.pulls_previous_week
+    ) order by tp.pulls_last_week desc, tp.growth desc, tp.package_id asc), '[]')
+    from (
+        select
+            pp.package_id,
+            sum(pp.pulls) filter (where pp.day > current_date - 7) as pulls_last_week,
+            coalesce(sum(pp.pulls) filter (where pp.day <= current_date - 7), 0) as pulls_previous_week,
+            sum(pp.pulls) filter (where pp.day > current_date - 7)
+                - coalesce(sum(pp.pulls) filter (where pp.day <= current_date - 7), 0) as growth
+        from package_pulls pp
+        join package p using (package_id)
+        join repository r using (repository_id)
+        where pp.day > current_date - 14
+        and (v_kind is null or r.repository_kind_id = v_kind)
+        group by pp.package_id
+        having sum(pp.pulls) filter (where pp.day > current_date - 7) > 0
+        order by pulls_last_week desc, growth desc, pp.package_id asc
+        limit v_limit
+    ) tp
+    cross join get_package_summary(jsonb_build_object('package_id', tp.package_id)) as pkgJSON;
+end
+$$ language plpgsql;
@@ -0,0 +1,45 @@
+-- register_package_pulls registers the daily pull counts provided for the
+-- packages of the repository provided. Counts already registered for a given
+-- package and day are replaced, so that the same data can be ingested more
+-- than once safely. Counts for packages not available in the repository are
+-- ignored.
+create or replace function register_package_pulls(
+    p_user_id uuid,
+    p_repository_name text,
+    p_pulls jsonb
+) returns void as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Register pull counts
+    insert into package_pulls (package_id, day, pulls)
+    select p.package_id, (e->>'day')::date, (e->>'pulls')::bigint
+    from jsonb_array_elements(p_pulls) e
+    join package p on p.normalized_name = e->>'package_name'
+    where p.repository_id = v_repository_id
+    on conflict (package_id, day) do update
+    set pulls = excluded.pulls;
+end
+$$ language plpgsql;
@@ -0,0 +1,30 @@
+-- register_package_pulls_total registers the total pull count provided for the
+-- package, as reported by the registry where it is stored. The difference with
+-- the total previously registered is added to the pull count of the current
+-- day. The first total registered is only used as a reference, so that
+-- historical pulls are not considered as pulls of the current day.
+create or replace function register_package_pulls_total(p_package_id uuid, p_total bigint)
+returns void as $$
+declare
+    v_previous_total bigint;
+begin
+    select total into v_previous_total
+    from package_pulls_total
+    where package_id = p_package_id
+    for update;
+
+    if v_previous_total is not null and p_total > v_previous_total then
+        insert into package_pulls (package_id, day, pulls)
+        values (p_package_id, current_date, p_total - v_previous_total)
+        on conflict (package_id, day) do update
+        set pulls = package_pulls.pulls + excluded.pulls;
+    end if;
+
+    insert into package_pulls_total (package_id, total)
+    values (p_package_id, p_total)
+    on conflict (package_id) do update
+    set
+        total = excluded.total,
+        updated_at = current_timestamp;
+end
+$$ language plpgsql;
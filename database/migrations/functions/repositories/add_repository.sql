@@ -9,7 +9,8 @@ declare
     v_owner_organization_id uuid;
 begin
     if p_org_name <> '' then
-        if not user_belongs_to_organization(p_user_id, p_org_name) then
+        if not user_belongs_to_organization(p_user_id, p_org_name)
+        and not 'addOrganizationRepository' = any(get_service_account_allowed_actions(p_user_id, p_org_name)) then
             raise insufficient_privilege;
         end if;
         v_owner_organization_id = (select organization_id from organization where name = p_org_name);
@@ -22,10 +23,16 @@ begin
         display_name,
         url,
         branch,
+        path,
         auth_user,
         auth_pass,
+        auth_pass_ref,
+        tls_config,
+        proxy_url,
         disabled,
         scanner_disabled,
+        tracking_schedule,
+        retention_policy,
         repository_kind_id,
         user_id,
         organization_id
@@ -34,10 +41,16 @@ begin
         nullif(p_repository->>'display_name', ''),
         p_repository->>'url',
         nullif(p_repository->>'branch', ''),
+        nullif(p_repository->>'path', ''),
         nullif(p_repository->>'auth_user', ''),
         nullif(p_repository->>'auth_pass', ''),
+        nullif(p_repository->>'auth_pass_ref', ''),
+        nullif(p_repository->>'tls_config', ''),
+        nullif(p_repository->>'proxy_url', ''),
         (p_repository->>'disabled')::boolean,
         (p_repository->>'scanner_disabled')::boolean,
+        nullif(p_repository->>'tracking_schedule', ''),
+        nullif(p_repository->'retention_policy', 'null'),
         (p_repository->>'kind')::int,
         v_owner_user_id,
         v_owner_organization_id
@@ -0,0 +1,14 @@
+-- get_pending_repository_ownership_claims returns the ownership claims whose
+-- proof has not been verified yet, the least recently checked first.
+create or replace function get_pending_repository_ownership_claims()
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'repository_ownership_claim_id', c.repository_ownership_claim_id,
+        'repository_url', r.url,
+        'method', c.method,
+        'token', c.token
+    ) order by c.checked_at asc nulls first), '[]')
+    from repository_ownership_claim c
+    join repository r using (repository_id)
+    where c.status = 'pending';
+$$ language sql;
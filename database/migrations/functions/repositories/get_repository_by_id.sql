@@ -11,18 +11,36 @@ begin
             'display_name', r.display_name,
             'url', r.url,
             'branch', r.branch,
+            'path', r.path,
             'auth_user', r.auth_user,
             'auth_pass', r.auth_pass,
+            'auth_pass_ref', r.auth_pass_ref,
+            'tls_config', r.tls_config,
+            'proxy_url', r.proxy_url,
             'kind', r.repository_kind_id,
             'verified_publisher', verified_publisher,
             'official', r.official,
             'disabled', r.disabled,
             'scanner_disabled', r.scanner_disabled,
+            'tracking_enabled', r.tracking_enabled,
+            'tracking_schedule', r.tracking_schedule,
+            'retention_policy', r.retention_policy,
+            'tracking_webhook_enabled', r.tracking_webhook_secret is not null,
             'digest', r.digest,
             'last_scanning_ts', floor(extract(epoch from last_scanning_ts)),
-            'last_scanning_errors', r.last_scanning_errors,
+            'last_scanning_errors', (
+                select string_agg(e.error->>'message', E'\n' order by e.n)
+                from jsonb_array_elements(r.last_scanning_errors) with ordinality as e(error, n)
+            ),
+            'scanning_errors', r.last_scanning_errors,
             'last_tracking_ts', floor(extract(epoch from last_tracking_ts)),
-            'last_tracking_errors', r.last_tracking_errors,
+            'last_tracking_errors', (
+                select string_agg(e.error->>'message', E'\n' order by e.n)
+                from jsonb_array_elements(r.last_tracking_errors) with ordinality as e(error, n)
+            ),
+            'tracking_errors', r.last_tracking_errors,
+            'last_successful_tracking_ts', floor(extract(epoch from last_successful_tracking_ts)),
+            'tracking_requested_ts', floor(extract(epoch from tracking_requested_at)),
             'user_alias', u.alias,
             'organization_name', o.name,
             'organization_display_name', o.display_name
@@ -39,16 +57,31 @@ begin
             'display_name', r.display_name,
             'url', r.url,
             'branch', r.branch,
+            'path', r.path,
             'kind', r.repository_kind_id,
             'verified_publisher', verified_publisher,
             'official', r.official,
             'disabled', r.disabled,
             'scanner_disabled', r.scanner_disabled,
+            'tracking_enabled', r.tracking_enabled,
+            'tracking_schedule', r.tracking_schedule,
+            'retention_policy', r.retention_policy,
+            'tracking_webhook_enabled', r.tracking_webhook_secret is not null,
             'digest', r.digest,
             'last_scanning_ts', floor(extract(epoch from last_scanning_ts)),
-            'last_scanning_errors', r.last_scanning_errors,
+            'last_scanning_errors', (
+                select string_agg(e.error->>'message', E'\n' order by e.n)
+                from jsonb_array_elements(r.last_scanning_errors) with ordinality as e(error, n)
+            ),
+            'scanning_errors', r.last_scanning_errors,
             'last_tracking_ts', floor(extract(epoch from last_tracking_ts)),
-            'last_tracking_errors', r.last_tracking_errors,
+            'last_tracking_errors', (
+                select string_agg(e.error->>'message', E'\n' order by e.n)
+                from jsonb_array_elements(r.last_tracking_errors) with ordinality as e(error, n)
+            ),
+            'tracking_errors', r.last_tracking_errors,
+            'last_successful_tracking_ts', floor(extract(epoch from last_successful_tracking_ts)),
+            'tracking_requested_ts', floor(extract(epoch from tracking_requested_at)),
             'user_alias', u.alias,
             'organization_name', o.name,
             'organization_display_name', o.display_name
@@ -0,0 +1,21 @@
+-- get_repository_ownership_claim returns the latest ownership claim requested
+-- by the user provided for the given repository.
+create or replace function get_repository_ownership_claim(p_user_id uuid, p_repository_name text)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'repository_ownership_claim_id', c.repository_ownership_claim_id,
+        'repository_url', r.url,
+        'organization_name', o.name,
+        'method', c.method,
+        'token', c.token,
+        'status', c.status,
+        'last_error', c.last_error,
+        'checked_at', floor(extract(epoch from c.checked_at)),
+        'created_at', floor(extract(epoch from c.created_at))
+    ))
+    from repository_ownership_claim c
+    join repository r using (repository_id)
+    left join organization o on o.organization_id = c.organization_id
+    where r.name = p_repository_name
+    and c.user_id = p_user_id;
+$$ language sql;
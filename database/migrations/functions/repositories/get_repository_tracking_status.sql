@@ -0,0 +1,79 @@
+-- get_repository_tracking_status returns the tracking status of the provided
+-- repository as well as a summary of its most recent tracking runs.
+create or replace function get_repository_tracking_status(p_user_id uuid, p_repository_name text)
+returns setof json as $$
+declare
+    v_repository_id uuid;
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+    if not found then
+        return;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    with runs as (
+        select
+            repository_tracking_run_id,
+            status,
+            packages_total,
+            packages_processed,
+            errors,
+            created_at,
+            started_at,
+            finished_at
+        from repository_tracking_run
+        where repository_id = v_repository_id
+    )
+    select json_strip_nulls(json_build_object(
+        'status', latest.status,
+        'packages_total', latest.packages_total,
+        'packages_processed', latest.packages_processed,
+        'last_run_duration', (
+            select floor(extract(epoch from finished_at - started_at))
+            from runs
+            where status = 'completed'
+            and started_at is not null
+            order by created_at desc
+            limit 1
+        ),
+        'runs', (
+            select coalesce(json_agg(json_build_object(
+                'tracking_run_id', repository_tracking_run_id,
+                'status', status,
+                'packages_total', packages_total,
+                'packages_processed', packages_processed,
+                'errors', errors,
+                'queued_ts', floor(extract(epoch from created_at)),
+                'started_ts', floor(extract(epoch from started_at)),
+                'finished_ts', floor(extract(epoch from finished_at)),
+                'duration', floor(extract(epoch from finished_at - started_at))
+            ) order by created_at desc), '[]')
+            from runs
+        )
+    ))
+    from (select 1) as d
+    left join lateral (
+        select status, packages_total, packages_processed
+        from runs
+        order by created_at desc
+        limit 1
+    ) as latest on true;
+end
+$$ language plpgsql;
@@ -0,0 +1,17 @@
+-- register_repository_ownership_claim_check registers the result of a failed
+-- verification of the provided ownership claim. Claims that could not be
+-- verified within a week since they were requested are expired.
+create or replace function register_repository_ownership_claim_check(
+    p_repository_ownership_claim_id uuid,
+    p_error text
+) returns void as $$
+    update repository_ownership_claim set
+        last_error = p_error,
+        checked_at = current_timestamp,
+        status = case
+            when created_at < current_timestamp - '7 days'::interval then 'expired'
+            else status
+        end
+    where repository_ownership_claim_id = p_repository_ownership_claim_id
+    and status = 'pending';
+$$ language sql;
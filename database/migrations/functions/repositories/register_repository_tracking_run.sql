@@ -0,0 +1,36 @@
+-- register_repository_tracking_run registers a new queued tracking run for the
+-- provided repository, returning its id. Runs that were not finished are
+-- marked as interrupted, and only the 10 most recent runs of each repository
+-- are kept.
+create or replace function register_repository_tracking_run(p_repository_id uuid)
+returns uuid as $$
+declare
+    v_tracking_run_id uuid;
+begin
+    -- Mark unfinished runs as interrupted
+    update repository_tracking_run set
+        status = 'completed',
+        errors = 'tracking run interrupted',
+        finished_at = current_timestamp
+    where repository_id = p_repository_id
+    and status <> 'completed';
+
+    -- Register new run
+    insert into repository_tracking_run (repository_id, status)
+    values (p_repository_id, 'queued')
+    returning repository_tracking_run_id into v_tracking_run_id;
+
+    -- Keep only the most recent runs
+    delete from repository_tracking_run
+    where repository_id = p_repository_id
+    and repository_tracking_run_id not in (
+        select repository_tracking_run_id
+        from repository_tracking_run
+        where repository_id = p_repository_id
+        order by created_at desc
+        limit 10
+    );
+
+    return v_tracking_run_id;
+end
+$$ language plpgsql;
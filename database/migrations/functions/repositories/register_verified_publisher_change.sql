@@ -0,0 +1,47 @@
+-- register_verified_publisher_change updates the verified publisher flag of
+-- the provided repository, registering the change along with its source and
+-- evidence. An event is registered when the flag changes, so that the users
+-- owning the repository are notified. Decisions made by site administrators
+-- are always registered, even if the flag does not change, as they take
+-- precedence over the repository metadata file from that moment on.
+create or replace function register_verified_publisher_change(
+    p_repository_id uuid,
+    p_user_id uuid,
+    p_source text,
+    p_verified boolean,
+    p_evidence text
+) returns void as $$
+declare
+    v_changed boolean;
+begin
+    update repository set
+        verified_publisher = p_verified
+    where repository_id = p_repository_id
+    and verified_publisher <> p_verified;
+    v_changed := found;
+
+    if v_changed or p_source = 'admin' then
+        insert into verified_publisher_change (
+            repository_id,
+            user_id,
+            source,
+            verified,
+            evidence
+        ) values (
+            p_repository_id,
+            p_user_id,
+            p_source,
+            p_verified,
+            nullif(p_evidence, '')
+        );
+    end if;
+
+    if v_changed then
+        insert into event (repository_id, event_kind_id, data)
+        values (p_repository_id, 11, jsonb_build_object(
+            'verified', p_verified,
+            'source', p_source
+        ));
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,59 @@
+-- request_repository_ownership_claim registers a request to claim the
+-- ownership of the provided repository, that will be transferred to the
+-- requesting user or the organization provided once the proof of control of
+-- the host serving the repository has been verified. Requesting a claim again
+-- replaces the previous one registered by the user for the same repository.
+create or replace function request_repository_ownership_claim(
+    p_user_id uuid,
+    p_repository_name text,
+    p_org_name text,
+    p_method text,
+    p_token text
+) returns void as $$
+declare
+    v_repository_id uuid;
+    v_organization_id uuid;
+begin
+    -- Get repository
+    select repository_id into v_repository_id
+    from repository
+    where name = p_repository_name;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- When claiming the repository for an organization, check the requesting
+    -- user belongs to it
+    if p_org_name is not null then
+        if not user_belongs_to_organization(p_user_id, p_org_name) then
+            raise insufficient_privilege;
+        end if;
+        select organization_id into v_organization_id
+        from organization
+        where name = p_org_name;
+    end if;
+
+    -- Register claim
+    insert into repository_ownership_claim (
+        repository_id,
+        user_id,
+        organization_id,
+        method,
+        token
+    ) values (
+        v_repository_id,
+        p_user_id,
+        v_organization_id,
+        p_method,
+        p_token
+    )
+    on conflict (repository_id, user_id) do update set
+        organization_id = excluded.organization_id,
+        method = excluded.method,
+        token = excluded.token,
+        status = 'pending',
+        last_error = null,
+        checked_at = null,
+        created_at = current_timestamp;
+end
+$$ language plpgsql;
@@ -0,0 +1,67 @@
+-- search_repositories returns the repositories matching the criteria provided
+-- as a json object, using offset or cursor based pagination.
+create or replace function search_repositories(p_input jsonb)
+returns setof json as $$
+declare
+    v_name text := nullif(p_input->>'name', '');
+    v_limit int := coalesce((p_input->>'limit')::int, 20);
+    v_offset int := coalesce((p_input->>'offset')::int, 0);
+    v_cursor jsonb;
+    v_kinds int[];
+begin
+    -- Prepare filters
+    select array_agg(e::int) into v_kinds
+    from jsonb_array_elements_text(p_input->'kinds') e;
+
+    -- Decode pagination cursor, if provided
+    v_cursor := get_cursor(p_input, '{name}');
+
+    return query
+    with filtered_repositories as (
+        select r.repository_id, r.name
+        from repository r
+        where
+            case when v_name is not null then
+                r.name ilike '%' || v_name || '%'
+                or r.display_name ilike '%' || v_name || '%'
+            else true end
+        and
+            case when cardinality(v_kinds) > 0 then
+            r.repository_kind_id = any(v_kinds) else true end
+    ), repositories_page as (
+        select
+            fr.*,
+            row_number() over (order by name asc) as position,
+            count(*) over () as available
+        from filtered_repositories fr
+        where
+            case when v_cursor is not null then
+                name > v_cursor->>'name'
+            else true end
+        order by position asc
+        limit v_limit
+        offset v_offset
+    )
+    select json_build_object(
+        'repositories', (
+            select coalesce(json_agg(rJSON order by position asc), '[]')
+            from repositories_page rp
+            cross join get_repository_by_id(rp.repository_id, false) as rJSON
+        ),
+        'metadata', json_strip_nulls(json_build_object(
+            'limit', v_limit,
+            'offset', v_offset,
+            'total', (select count(*) from filtered_repositories),
+            'next_cursor', (
+                select encode_cursor(jsonb_build_object(
+                    'name', name,
+                    'scope', get_cursor_scope(p_input)
+                ))
+                from repositories_page
+                where position = v_offset + v_limit
+                and position < available
+            )
+        ))
+    );
+end
+$$ language plpgsql;
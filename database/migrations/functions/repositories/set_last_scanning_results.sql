@@ -1,21 +1,27 @@
 -- set_last_scanning_results updates the timestamp and errors of the last scan.
 create or replace function set_last_scanning_results(
     p_repository_id uuid,
-    p_last_scanning_errors text,
+    p_last_scanning_errors jsonb,
     p_scanning_errors_event_enabled boolean
 )
 returns void as $$
 declare
-    v_last_scanning_errors text := nullif(p_last_scanning_errors, '');
-    v_prev_last_scanning_errors text;
+    v_last_scanning_errors jsonb := nullif(p_last_scanning_errors, '[]');
+    v_prev_last_scanning_errors jsonb;
 begin
-    -- Register repository scanning errors event if needed
+    -- Register repository scanning errors event if needed. Only the errors
+    -- messages are compared, as the rest of the fields (like the timestamp)
+    -- may differ between runs for the same error.
     if p_scanning_errors_event_enabled and v_last_scanning_errors is not null then
         select last_scanning_errors into v_prev_last_scanning_errors
         from repository
         where repository_id = p_repository_id;
 
-        if v_prev_last_scanning_errors is null or v_last_scanning_errors <> v_prev_last_scanning_errors then
+        if v_prev_last_scanning_errors is null or (
+            select jsonb_agg(e->'message') from jsonb_array_elements(v_last_scanning_errors) e
+        ) <> (
+            select jsonb_agg(e->'message') from jsonb_array_elements(v_prev_last_scanning_errors) e
+        ) then
             insert into event (repository_id, event_kind_id) values (p_repository_id, 4);
         end if;
     end if;
@@ -1,22 +1,29 @@
 -- set_last_tracking_results updates the timestamp and errors of the last
--- tracking.
+-- tracking, as well as the timestamp of the last successful one when no
+-- errors were found.
 create or replace function set_last_tracking_results(
     p_repository_id uuid,
-    p_last_tracking_errors text,
+    p_last_tracking_errors jsonb,
     p_tracking_errors_event_enabled boolean
 )
 returns void as $$
 declare
-    v_last_tracking_errors text := nullif(p_last_tracking_errors, '');
-    v_prev_last_tracking_errors text;
+    v_last_tracking_errors jsonb := nullif(p_last_tracking_errors, '[]');
+    v_prev_last_tracking_errors jsonb;
 begin
-    -- Register repository tracking errors event if needed
+    -- Register repository tracking errors event if needed. Only the errors
+    -- messages are compared, as the rest of the fields (like the timestamp)
+    -- may differ between runs for the same error.
     if p_tracking_errors_event_enabled and v_last_tracking_errors is not null then
         select last_tracking_errors into v_prev_last_tracking_errors
         from repository
         where repository_id = p_repository_id;
 
-        if v_prev_last_tracking_errors is null or v_last_tracking_errors <> v_prev_last_tracking_errors then
+        if v_prev_last_tracking_errors is null or (
+            select jsonb_agg(e->'message') from jsonb_array_elements(v_last_tracking_errors) e
+        ) <> (
+            select jsonb_agg(e->'message') from jsonb_array_elements(v_prev_last_tracking_errors) e
+        ) then
             insert into event (repository_id, event_kind_id) values (p_repository_id, 2);
         end if;
     end if;
@@ -24,7 +31,11 @@ begin
     -- Update repository with last tracking results
     update repository set
 		last_tracking_ts = current_timestamp,
-		last_tracking_errors = v_last_tracking_errors
+		last_tracking_errors = v_last_tracking_errors,
+		last_successful_tracking_ts = case
+		    when v_last_tracking_errors is null then current_timestamp
+		    else last_successful_tracking_ts
+		end
 	where repository_id = p_repository_id;
 end
 $$ language plpgsql;
@@ -1,8 +1,20 @@
 -- set_verified_publisher updates the verified publisher flag of the provided
--- repository.
+-- repository from the information in its metadata file. Repositories whose
+-- verified publisher status was last set by a site administrator are not
+-- updated, as their decision takes precedence over the metadata file.
 create or replace function set_verified_publisher(p_repository_id uuid, p_verified boolean)
 returns void as $$
-    update repository set
-        verified_publisher = p_verified
-    where repository_id = p_repository_id;
-$$ language sql;
+begin
+    if (
+        select source
+        from verified_publisher_change
+        where repository_id = p_repository_id
+        order by created_at desc
+        limit 1
+    ) = 'admin' then
+        return;
+    end if;
+
+    perform register_verified_publisher_change(p_repository_id, null, 'metadata', p_verified, null);
+end
+$$ language plpgsql;
@@ -2,7 +2,10 @@
 -- to the requesting user or an organization he belongs to. The user must own
 -- the repository transferred or belong to the organization which owns it,
 -- unless this transfer is part of an ownership claim request that has been
--- previously authorized.
+-- previously authorized. Packages (and their stats and webhooks references)
+-- belong to the repository, so they are transferred along with it, whereas
+-- the access granted to the teams of the previous owning organization is
+-- revoked.
 create or replace function transfer_repository(
     p_repository_name text,
     p_user_id uuid,
@@ -10,18 +13,25 @@ create or replace function transfer_repository(
     p_ownership_claim boolean
 ) returns void as $$
 declare
+    v_repository_id uuid;
     v_owner_user_id uuid;
+    v_owner_user_alias text;
+    v_owner_organization_id uuid;
     v_owner_organization_name text;
+    v_dst_organization_id uuid;
+    v_previous_subscriptors jsonb;
 begin
+    -- Get user or organization owning the repository
+    select r.repository_id, r.user_id, u.alias, r.organization_id, o.name
+    into v_repository_id, v_owner_user_id, v_owner_user_alias, v_owner_organization_id, v_owner_organization_name
+    from repository r
+    left join "user" u using (user_id)
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+
     -- Validate repository ownership unless this transfer is part of an
     -- ownership claim request
     if not p_ownership_claim then
-        -- Get user or organization owning the repository
-        select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
-        from repository r
-        left join organization o using (organization_id)
-        where r.name = p_repository_name;
-
         -- Check if the user doing the request is the owner or belongs to the
         -- organization which owns it
         if v_owner_organization_name is not null then
@@ -48,6 +58,8 @@ begin
             'subscriptors', get_repository_subscriptors(repository_id, 3)
         )
         from repository where name = p_repository_name;
+    else
+        v_previous_subscriptors := get_repository_subscriptors(v_repository_id, 8)::jsonb;
     end if;
 
     -- Transfer repository ownership
@@ -57,12 +69,40 @@ begin
             organization_id = null
         where name = p_repository_name;
     else
+        select organization_id into v_dst_organization_id
+        from organization where name = p_org_name;
+
         update repository set
-            organization_id = (
-                select organization_id from organization where name = p_org_name
-            ),
+            organization_id = v_dst_organization_id,
             user_id = null
         where name = p_repository_name;
     end if;
+
+    -- Revoke the access granted to the teams of the previous owner
+    if v_owner_organization_id is distinct from v_dst_organization_id then
+        delete from team__repository where repository_id = v_repository_id;
+    end if;
+
+    -- Register repository ownership transfer event if the owner has changed,
+    -- notifying the previous and new owners (but the requesting user)
+    if not p_ownership_claim and (
+        v_owner_organization_id is distinct from v_dst_organization_id or
+        (v_dst_organization_id is null and v_owner_user_id is distinct from p_user_id)
+    ) then
+        insert into event (repository_id, event_kind_id, data)
+        values (v_repository_id, 8, jsonb_build_object(
+            'subscriptors', (
+                select coalesce(jsonb_agg(distinct s), '[]')
+                from jsonb_array_elements(
+                    v_previous_subscriptors || get_repository_subscriptors(v_repository_id, 8)::jsonb
+                ) s
+                where s->>'user_id' <> p_user_id::text
+            ),
+            'previous_owner', jsonb_strip_nulls(jsonb_build_object(
+                'user_alias', v_owner_user_alias,
+                'organization_name', v_owner_organization_name
+            ))
+        ));
+    end if;
 end
 $$ language plpgsql;
@@ -20,10 +20,11 @@ begin
     left join organization o using (organization_id)
     where r.name = p_repository->>'name';
 
-    -- Check if the user doing the request is the owner or belongs to the
-    -- organization which owns it
+    -- Check if the user doing the request is the owner, belongs to the
+    -- organization which owns it or is one of its service accounts
     if v_owner_organization_name is not null then
-        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name)
+        and not 'updateOrganizationRepository' = any(get_service_account_allowed_actions(p_user_id, v_owner_organization_name)) then
             raise insufficient_privilege;
         end if;
     elsif v_owner_user_id <> p_user_id then
@@ -35,10 +36,16 @@ begin
         display_name = nullif(p_repository->>'display_name', ''),
         url = p_repository->>'url',
         branch = nullif(p_repository->>'branch', ''),
+        path = nullif(p_repository->>'path', ''),
         auth_user = nullif(p_repository->>'auth_user', ''),
         auth_pass = nullif(p_repository->>'auth_pass', ''),
+        auth_pass_ref = nullif(p_repository->>'auth_pass_ref', ''),
+        tls_config = nullif(p_repository->>'tls_config', ''),
+        proxy_url = nullif(p_repository->>'proxy_url', ''),
         disabled = (p_repository->>'disabled')::boolean,
-        scanner_disabled = (p_repository->>'scanner_disabled')::boolean
+        scanner_disabled = (p_repository->>'scanner_disabled')::boolean,
+        tracking_schedule = nullif(p_repository->>'tracking_schedule', ''),
+        retention_policy = nullif(p_repository->'retention_policy', 'null')
     where repository_id = v_repository_id;
 
     -- If the repository has been disabled, remove packages belonging to it
@@ -0,0 +1,38 @@
+-- update_repository_credentials updates the credentials of the provided
+-- repository.
+create or replace function update_repository_credentials(
+    p_user_id uuid,
+    p_repository_name text,
+    p_credentials jsonb
+) returns void as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+begin
+    -- Get user or organization owning the repository
+    select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Update repository credentials
+    update repository set
+        auth_user = nullif(p_credentials->>'auth_user', ''),
+        auth_pass = nullif(p_credentials->>'auth_pass', ''),
+        auth_pass_ref = nullif(p_credentials->>'auth_pass_ref', '')
+    where name = p_repository_name;
+end
+$$ language plpgsql;
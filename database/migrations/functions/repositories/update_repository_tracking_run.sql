@@ -0,0 +1,23 @@
+-- update_repository_tracking_run updates the status and progress of the
+-- provided repository tracking run.
+create or replace function update_repository_tracking_run(p_tracking_run jsonb)
+returns void as $$
+declare
+    v_status text := p_tracking_run->>'status';
+begin
+    update repository_tracking_run set
+        status = v_status,
+        packages_total = coalesce((p_tracking_run->>'packages_total')::integer, 0),
+        packages_processed = coalesce((p_tracking_run->>'packages_processed')::integer, 0),
+        errors = nullif(p_tracking_run->>'errors', ''),
+        started_at = case
+            when v_status <> 'queued' then coalesce(started_at, current_timestamp)
+            else started_at
+        end,
+        finished_at = case
+            when v_status = 'completed' then current_timestamp
+            else finished_at
+        end
+    where repository_tracking_run_id = (p_tracking_run->>'tracking_run_id')::uuid;
+end
+$$ language plpgsql;
@@ -0,0 +1,43 @@
+-- update_repository_tracking_webhook enables or disables the webhook that
+-- allows triggering the tracking of the provided repository. When enabled, a
+-- new secret is generated and returned, invalidating the previous one.
+create or replace function update_repository_tracking_webhook(
+    p_user_id uuid,
+    p_repository_name text,
+    p_enabled boolean
+) returns text as $$
+declare
+    v_owner_user_id uuid;
+    v_owner_organization_name text;
+    v_secret text;
+begin
+    -- Get user or organization owning the repository
+    select r.user_id, o.name into v_owner_user_id, v_owner_organization_name
+    from repository r
+    left join organization o using (organization_id)
+    where r.name = p_repository_name;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Check if the user doing the request is the owner or belongs to the
+    -- organization which owns it
+    if v_owner_organization_name is not null then
+        if not user_belongs_to_organization(p_user_id, v_owner_organization_name) then
+            raise insufficient_privilege;
+        end if;
+    elsif v_owner_user_id <> p_user_id then
+        raise insufficient_privilege;
+    end if;
+
+    -- Update repository tracking webhook secret
+    if p_enabled then
+        v_secret := encode(gen_random_bytes(32), 'hex');
+    end if;
+    update repository set
+        tracking_webhook_secret = v_secret
+    where name = p_repository_name;
+
+    return v_secret;
+end
+$$ language plpgsql;
@@ -0,0 +1,34 @@
+-- verify_repository_ownership_claim marks the provided ownership claim as
+-- verified, transferring the repository to the user or organization that
+-- requested it.
+create or replace function verify_repository_ownership_claim(p_repository_ownership_claim_id uuid)
+returns void as $$
+declare
+    v_repository_name text;
+    v_user_id uuid;
+    v_org_name text;
+begin
+    -- Get pending claim details
+    select r.name, c.user_id, o.name
+    into v_repository_name, v_user_id, v_org_name
+    from repository_ownership_claim c
+    join repository r using (repository_id)
+    left join organization o on o.organization_id = c.organization_id
+    where c.repository_ownership_claim_id = p_repository_ownership_claim_id
+    and c.status = 'pending'
+    for update of c;
+    if not found then
+        return;
+    end if;
+
+    -- Transfer repository
+    perform transfer_repository(v_repository_name, v_user_id, v_org_name, true);
+
+    -- Mark claim as verified
+    update repository_ownership_claim set
+        status = 'verified',
+        last_error = null,
+        checked_at = current_timestamp
+    where repository_ownership_claim_id = p_repository_ownership_claim_id;
+end
+$$ language plpgsql;
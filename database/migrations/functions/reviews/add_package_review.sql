@@ -0,0 +1,36 @@
+-- add_package_review adds the provided review to the database. Users can only
+-- review a package once, so when the user has already reviewed it, the
+-- existing review is replaced. Publishers cannot review their own packages.
+create or replace function add_package_review(p_user_id uuid, p_review jsonb)
+returns void as $$
+declare
+    v_package_id uuid := p_review->>'package_id';
+begin
+    -- Check the package exists and the user is not its publisher
+    perform from package where package_id = v_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+    if user_is_package_publisher(p_user_id, v_package_id) then
+        raise insufficient_privilege;
+    end if;
+
+    -- Add or replace review
+    insert into package_review (package_id, user_id, rating, title, body)
+    values (
+        v_package_id,
+        p_user_id,
+        (p_review->>'rating')::smallint,
+        nullif(p_review->>'title', ''),
+        nullif(p_review->>'body', '')
+    )
+    on conflict (package_id, user_id) do update set
+        rating = excluded.rating,
+        title = excluded.title,
+        body = excluded.body,
+        updated_at = current_timestamp;
+
+    -- Update package rating
+    perform update_package_rating(v_package_id);
+end
+$$ language plpgsql;
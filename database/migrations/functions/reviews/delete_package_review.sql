@@ -0,0 +1,16 @@
+-- delete_package_review deletes the review the provided user posted on the
+-- package provided.
+create or replace function delete_package_review(p_user_id uuid, p_package_id uuid)
+returns void as $$
+begin
+    delete from package_review
+    where package_id = p_package_id
+    and user_id = p_user_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Update package rating
+    perform update_package_rating(p_package_id);
+end
+$$ language plpgsql;
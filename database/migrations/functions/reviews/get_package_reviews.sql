@@ -0,0 +1,57 @@
+-- get_package_reviews returns the visible reviews of the provided package as a
+-- json object, using offset based pagination. The review posted by the user
+-- doing the request, if any, is included as well.
+create or replace function get_package_reviews(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_limit int,
+    p_offset int
+) returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'reviews', (
+            select coalesce(json_agg(json_build_object(
+                'review_id', package_review_id,
+                'rating', rating,
+                'title', title,
+                'body', body,
+                'reply', reply,
+                'replied_at', floor(extract(epoch from replied_at)),
+                'user_alias', user_alias,
+                'created_at', floor(extract(epoch from created_at)),
+                'updated_at', floor(extract(epoch from updated_at))
+            ) order by created_at desc, package_review_id asc), '[]')
+            from (
+                select pr.*, u.alias as user_alias
+                from package_review pr
+                join "user" u using (user_id)
+                where pr.package_id = p_package_id
+                and pr.hidden = false
+                order by pr.created_at desc, pr.package_review_id asc
+                limit p_limit
+                offset p_offset
+            ) reviews
+        ),
+        'user_review', (
+            select json_build_object(
+                'review_id', package_review_id,
+                'rating', rating,
+                'title', title,
+                'body', body,
+                'hidden', hidden
+            )
+            from package_review
+            where package_id = p_package_id
+            and user_id = p_user_id
+        ),
+        'metadata', json_build_object(
+            'limit', p_limit,
+            'offset', p_offset,
+            'total', (
+                select count(*)
+                from package_review
+                where package_id = p_package_id
+                and hidden = false
+            )
+        )
+    ));
+$$ language sql;
@@ -0,0 +1,26 @@
+-- reply_package_review sets the reply of the publisher to the provided review.
+-- Only the package's publisher can reply to its reviews, and the reply can be
+-- removed by providing an empty one.
+create or replace function reply_package_review(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_package_review_id uuid,
+    p_reply text
+) returns void as $$
+begin
+    -- Check if the user doing the request is the package's publisher
+    if not user_is_package_publisher(p_user_id, p_package_id) then
+        raise insufficient_privilege;
+    end if;
+
+    -- Set review reply
+    update package_review set
+        reply = nullif(p_reply, ''),
+        replied_at = case when nullif(p_reply, '') is null then null else current_timestamp end
+    where package_review_id = p_package_review_id
+    and package_id = p_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
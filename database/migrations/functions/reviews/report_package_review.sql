@@ -0,0 +1,40 @@
+-- report_package_review registers an abuse report on the provided review.
+-- Each user can report a given review once. Reviews reported by three or more
+-- users are hidden, so they are no longer displayed nor taken into account in
+-- the package's rating.
+create or replace function report_package_review(
+    p_user_id uuid,
+    p_package_id uuid,
+    p_package_review_id uuid,
+    p_reason text
+) returns void as $$
+declare
+    v_reports int;
+begin
+    -- Check the review exists
+    perform from package_review
+    where package_review_id = p_package_review_id
+    and package_id = p_package_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Register report
+    insert into package_review_report (package_review_id, user_id, reason)
+    values (p_package_review_id, p_user_id, p_reason)
+    on conflict do nothing;
+
+    -- Hide review if it has been reported too many times
+    select count(*) into v_reports
+    from package_review_report
+    where package_review_id = p_package_review_id;
+    if v_reports >= 3 then
+        update package_review set hidden = true
+        where package_review_id = p_package_review_id
+        and hidden = false;
+        if found then
+            perform update_package_rating(p_package_id);
+        end if;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,19 @@
+-- update_package_rating updates the rating of the provided package from the
+-- reviews it has received. Hidden reviews are not taken into account.
+create or replace function update_package_rating(p_package_id uuid)
+returns void as $$
+    update package set
+        rating = (
+            select round(avg(rating), 2)
+            from package_review
+            where package_id = p_package_id
+            and hidden = false
+        ),
+        ratings = (
+            select count(*)
+            from package_review
+            where package_id = p_package_id
+            and hidden = false
+        )
+    where package_id = p_package_id;
+$$ language sql;
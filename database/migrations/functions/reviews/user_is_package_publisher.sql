@@ -0,0 +1,17 @@
+-- user_is_package_publisher checks if the provided user is the publisher of
+-- the package provided, either because they own the package's repository or
+-- because they belong to the organization that owns it.
+create or replace function user_is_package_publisher(p_user_id uuid, p_package_id uuid)
+returns boolean as $$
+    select exists (
+        select *
+        from package p
+        join repository r using (repository_id)
+        left join organization o using (organization_id)
+        where p.package_id = p_package_id
+        and (
+            r.user_id = p_user_id
+            or (o.name is not null and user_belongs_to_organization(p_user_id, o.name))
+        )
+    );
+$$ language sql;
@@ -0,0 +1,28 @@
+-- add_saved_search adds the provided saved search to the database. The
+-- packages matching the search query at this point are registered as the
+-- baseline matches, so that only the ones matching it from now on are
+-- notified.
+create or replace function add_saved_search(p_saved_search jsonb)
+returns setof json as $$
+declare
+    v_saved_search_id uuid;
+begin
+    insert into saved_search (
+        user_id,
+        name,
+        query,
+        notifications_enabled
+    ) values (
+        (p_saved_search->>'user_id')::uuid,
+        p_saved_search->>'name',
+        p_saved_search->'query',
+        coalesce((p_saved_search->>'notifications_enabled')::boolean, false)
+    ) returning saved_search_id into v_saved_search_id;
+
+    perform refresh_saved_search_matches(v_saved_search_id, true);
+
+    return query select json_build_object(
+        'saved_search_id', v_saved_search_id
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,7 @@
+-- delete_saved_search deletes the provided saved search from the database.
+create or replace function delete_saved_search(p_user_id uuid, p_saved_search_id uuid)
+returns void as $$
+    delete from saved_search
+    where saved_search_id = p_saved_search_id
+    and user_id = p_user_id;
+$$ language sql;
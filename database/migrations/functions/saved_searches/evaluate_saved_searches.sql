@@ -0,0 +1,64 @@
+-- evaluate_saved_searches refreshes the matches of all the saved searches,
+-- registering a new matches event for the ones with notifications enabled
+-- that have new packages matching them. It returns the number of new matches
+-- found.
+create or replace function evaluate_saved_searches()
+returns integer as $$
+declare
+    v_saved_search record;
+    v_new_matches uuid[];
+    v_total_new_matches integer := 0;
+begin
+    for v_saved_search in
+        select saved_search_id, user_id, name, notifications_enabled
+        from saved_search
+        order by saved_search_id asc
+    loop
+        select coalesce(array_agg(package_id), '{}') into v_new_matches
+        from refresh_saved_search_matches(v_saved_search.saved_search_id, false) as package_id;
+        v_total_new_matches := v_total_new_matches + cardinality(v_new_matches);
+        if cardinality(v_new_matches) = 0 or not v_saved_search.notifications_enabled then
+            continue;
+        end if;
+
+        -- Register new matches event to notify the saved search owner
+        insert into event (event_kind_id, data)
+        select 9, jsonb_build_object(
+            'saved_search', jsonb_build_object(
+                'saved_search_id', v_saved_search.saved_search_id,
+                'name', v_saved_search.name
+            ),
+            'new_matches', cardinality(v_new_matches),
+            'packages', (
+                select coalesce(jsonb_agg(jsonb_build_object(
+                    'package_id', p.package_id,
+                    'name', p.name,
+                    'normalized_name', p.normalized_name,
+                    'version', p.latest_version,
+                    'repository', jsonb_build_object(
+                        'kind', r.repository_kind_id,
+                        'name', r.name
+                    )
+                ) order by p.name asc), '[]')
+                from (
+                    select p.package_id
+                    from package p
+                    where p.package_id = any(v_new_matches)
+                    order by p.name asc
+                    limit 20
+                ) nm
+                join package p using (package_id)
+                join repository r using (repository_id)
+            ),
+            'subscriptors', jsonb_build_array(jsonb_strip_nulls(jsonb_build_object(
+                'user_id', u.user_id,
+                'notifications_preferences', u.notifications_preferences
+            )))
+        )
+        from "user" u
+        where u.user_id = v_saved_search.user_id;
+    end loop;
+
+    return v_total_new_matches;
+end
+$$ language plpgsql;
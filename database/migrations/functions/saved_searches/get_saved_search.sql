@@ -0,0 +1,22 @@
+-- get_saved_search returns the saved search requested as a json object.
+create or replace function get_saved_search(p_user_id uuid, p_saved_search_id uuid)
+returns setof json as $$
+    select json_build_object(
+        'saved_search_id', saved_search_id,
+        'name', name,
+        'query', query,
+        'notifications_enabled', notifications_enabled,
+        'created_at', floor(extract(epoch from created_at)),
+        'last_evaluated_at', floor(extract(epoch from last_evaluated_at)),
+        'new_matches', (
+            select count(*)
+            from saved_search_match m
+            where m.saved_search_id = ss.saved_search_id
+            and m.baseline = false
+            and m.seen = false
+        )
+    )
+    from saved_search ss
+    where saved_search_id = p_saved_search_id
+    and user_id = p_user_id;
+$$ language sql;
@@ -0,0 +1,35 @@
+-- get_saved_search_matches returns the most recent packages that started
+-- matching the saved search provided after it was saved. The matches returned
+-- are marked as seen.
+create or replace function get_saved_search_matches(p_user_id uuid, p_saved_search_id uuid)
+returns setof json as $$
+begin
+    perform from saved_search
+    where saved_search_id = p_saved_search_id
+    and user_id = p_user_id;
+    if not found then
+        return;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_build_object(
+        'package', pkgJSON,
+        'seen', seen,
+        'matched_at', floor(extract(epoch from created_at))
+    )), '[]')
+    from (
+        select pkgJSON, m.seen, m.created_at
+        from saved_search_match m
+        cross join get_package_summary(jsonb_build_object('package_id', m.package_id)) as pkgJSON
+        where m.saved_search_id = p_saved_search_id
+        and m.baseline = false
+        order by m.created_at desc, m.package_id asc
+        limit 100
+    ) ms;
+
+    update saved_search_match set seen = true
+    where saved_search_id = p_saved_search_id
+    and baseline = false
+    and seen = false;
+end
+$$ language plpgsql;
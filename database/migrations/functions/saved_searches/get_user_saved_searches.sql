@@ -0,0 +1,13 @@
+-- get_user_saved_searches returns the saved searches that belong to the
+-- requesting user.
+create or replace function get_user_saved_searches(p_user_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(ssJSON), '[]')
+    from (
+        select ssJSON
+        from saved_search ss
+        cross join get_saved_search(p_user_id, saved_search_id) as ssJSON
+        where user_id = p_user_id
+        order by ss.name asc
+    ) sss;
+$$ language sql;
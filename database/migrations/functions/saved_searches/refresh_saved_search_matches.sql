@@ -0,0 +1,29 @@
+-- refresh_saved_search_matches registers the packages currently matching the
+-- provided saved search query that had not matched it before, returning the
+-- ids of the packages registered as new matches. Baseline matches are the ones
+-- found when the search is saved, and they are neither notified nor listed as
+-- matches.
+create or replace function refresh_saved_search_matches(p_saved_search_id uuid, p_baseline boolean)
+returns setof uuid as $$
+declare
+    v_query jsonb;
+begin
+    select query into v_query
+    from saved_search
+    where saved_search_id = p_saved_search_id;
+
+    update saved_search set last_evaluated_at = current_timestamp
+    where saved_search_id = p_saved_search_id;
+
+    return query
+    insert into saved_search_match (saved_search_id, package_id, baseline, seen)
+    select p_saved_search_id, (p->>'package_id')::uuid, p_baseline, p_baseline
+    from jsonb_array_elements((
+        select search_packages(
+            v_query - 'limit' - 'offset' - 'cursor' || '{"facets": false}'
+        )::jsonb #> '{data,packages}'
+    )) p
+    on conflict (saved_search_id, package_id) do nothing
+    returning package_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,28 @@
+-- update_saved_search updates the provided saved search in the database. When
+-- the search query changes, the saved search matches are reset.
+create or replace function update_saved_search(p_saved_search jsonb)
+returns void as $$
+declare
+    v_saved_search_id uuid := p_saved_search->>'saved_search_id';
+    v_previous_query jsonb;
+begin
+    select query into v_previous_query
+    from saved_search
+    where saved_search_id = v_saved_search_id
+    and user_id = (p_saved_search->>'user_id')::uuid;
+    if not found then
+        return;
+    end if;
+
+    update saved_search set
+        name = p_saved_search->>'name',
+        query = p_saved_search->'query',
+        notifications_enabled = coalesce((p_saved_search->>'notifications_enabled')::boolean, false)
+    where saved_search_id = v_saved_search_id;
+
+    if v_previous_query <> p_saved_search->'query' then
+        delete from saved_search_match where saved_search_id = v_saved_search_id;
+        perform refresh_saved_search_matches(v_saved_search_id, true);
+    end if;
+end
+$$ language plpgsql;
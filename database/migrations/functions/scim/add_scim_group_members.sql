@@ -0,0 +1,16 @@
+-- add_scim_group_members adds the provided users to the organization as
+-- confirmed members. Pending invitations are confirmed.
+create or replace function add_scim_group_members(p_organization_id uuid, p_user_ids uuid[])
+returns void as $$
+begin
+    if not exists (select 1 from organization where organization_id = p_organization_id) then
+        raise no_data_found;
+    end if;
+
+    insert into user__organization (user_id, organization_id, confirmed)
+    select user_id, p_organization_id, true
+    from "user"
+    where user_id = any(p_user_ids)
+    on conflict (user_id, organization_id) do update set confirmed = true;
+end
+$$ language plpgsql;
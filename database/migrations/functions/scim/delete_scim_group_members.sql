@@ -0,0 +1,30 @@
+-- delete_scim_group_members deletes the provided users from the organization.
+create or replace function delete_scim_group_members(p_organization_id uuid, p_user_ids uuid[])
+returns void as $$
+begin
+    if not exists (select 1 from organization where organization_id = p_organization_id) then
+        raise no_data_found;
+    end if;
+
+    -- Last member of an organization cannot leave it
+    if not exists (
+        select 1 from user__organization
+        where organization_id = p_organization_id
+        and user_id <> all(p_user_ids)
+    ) then
+        raise 'last member of an organization cannot leave it';
+    end if;
+
+    -- Delete members from organization
+    delete from user__organization
+    where organization_id = p_organization_id
+    and user_id = any(p_user_ids);
+
+    -- Delete users opt-out entries for repositories belonging to the org
+    delete from opt_out
+    where user_id = any(p_user_ids)
+    and repository_id in (
+        select repository_id from repository where organization_id = p_organization_id
+    );
+end
+$$ language plpgsql;
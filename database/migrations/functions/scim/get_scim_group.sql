@@ -0,0 +1,27 @@
+-- get_scim_group returns the requested organization as a json object in the
+-- format defined by the SCIM 2.0 specification for groups. Only confirmed
+-- members are included.
+create or replace function get_scim_group(p_organization_id uuid)
+returns setof json as $$
+    select json_build_object(
+        'schemas', json_build_array('urn:ietf:params:scim:schemas:core:2.0:Group'),
+        'id', o.organization_id,
+        'displayName', o.name,
+        'members', (
+            select coalesce(json_agg(json_build_object(
+                'value', u.user_id,
+                'display', u.alias
+            ) order by u.alias), '[]')
+            from user__organization uo
+            join "user" u using (user_id)
+            where uo.organization_id = o.organization_id
+            and uo.confirmed = true
+        ),
+        'meta', json_build_object(
+            'resourceType', 'Group',
+            'created', to_char(o.created_at at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+        )
+    )
+    from organization o
+    where o.organization_id = p_organization_id;
+$$ language sql;
@@ -0,0 +1,38 @@
+-- get_scim_groups returns the organizations matching the input provided as a
+-- json object in the format defined by the SCIM 2.0 specification for groups.
+create or replace function get_scim_groups(p_input jsonb)
+returns setof json as $$
+declare
+    v_name text := p_input->>'name';
+    v_limit int := coalesce((p_input->>'limit')::int, 100);
+    v_offset int := coalesce((p_input->>'offset')::int, 0);
+begin
+    return query
+    with organizations_found as (
+        select organization_id, name
+        from organization
+        where
+            case when v_name is not null then
+                name = v_name
+            else true end
+    )
+    select json_build_object(
+        'schemas', json_build_array('urn:ietf:params:scim:api:messages:2.0:ListResponse'),
+        'totalResults', (select count(*) from organizations_found),
+        'startIndex', v_offset + 1,
+        'itemsPerPage', (select count(*) from (
+            select 1 from organizations_found limit v_limit offset v_offset
+        ) p),
+        'Resources', (
+            select coalesce(json_agg(get_scim_group(organization_id)), '[]')
+            from (
+                select organization_id
+                from organizations_found
+                order by name asc
+                limit v_limit
+                offset v_offset
+            ) o
+        )
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,26 @@
+-- get_scim_user returns the requested user as a json object in the format
+-- defined by the SCIM 2.0 specification.
+create or replace function get_scim_user(p_user_id uuid)
+returns setof json as $$
+    select json_strip_nulls(json_build_object(
+        'schemas', json_build_array('urn:ietf:params:scim:schemas:core:2.0:User'),
+        'id', u.user_id,
+        'userName', u.email,
+        'displayName', u.alias,
+        'name', json_build_object(
+            'givenName', u.first_name,
+            'familyName', u.last_name
+        ),
+        'emails', json_build_array(json_build_object(
+            'value', u.email,
+            'primary', true
+        )),
+        'active', not u.disabled,
+        'meta', json_build_object(
+            'resourceType', 'User',
+            'created', to_char(u.created_at at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+        )
+    ))
+    from "user" u
+    where u.user_id = p_user_id;
+$$ language sql;
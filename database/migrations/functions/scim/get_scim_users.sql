@@ -0,0 +1,39 @@
+-- get_scim_users returns the users matching the input provided as a json
+-- object in the format defined by the SCIM 2.0 specification.
+create or replace function get_scim_users(p_input jsonb)
+returns setof json as $$
+declare
+    v_email text := p_input->>'email';
+    v_limit int := coalesce((p_input->>'limit')::int, 100);
+    v_offset int := coalesce((p_input->>'offset')::int, 0);
+begin
+    return query
+    with users_found as (
+        select user_id, email
+        from "user"
+        where service_account_organization_id is null
+        and
+            case when v_email is not null then
+                lower(email) = lower(v_email)
+            else true end
+    )
+    select json_build_object(
+        'schemas', json_build_array('urn:ietf:params:scim:api:messages:2.0:ListResponse'),
+        'totalResults', (select count(*) from users_found),
+        'startIndex', v_offset + 1,
+        'itemsPerPage', (select count(*) from (
+            select 1 from users_found limit v_limit offset v_offset
+        ) p),
+        'Resources', (
+            select coalesce(json_agg(get_scim_user(user_id)), '[]')
+            from (
+                select user_id
+                from users_found
+                order by email asc
+                limit v_limit
+                offset v_offset
+            ) uf
+        )
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,32 @@
+-- register_scim_user registers the user provided by a SCIM client, returning
+-- its id. The user alias is derived from the email, appending a random suffix
+-- to it when it's already taken.
+create or replace function register_scim_user(p_user jsonb)
+returns uuid as $$
+declare
+    v_alias text := split_part(p_user->>'email', '@', 1);
+    v_user_id uuid;
+begin
+    while exists (select 1 from "user" where alias = v_alias) loop
+        v_alias := split_part(p_user->>'email', '@', 1) || floor(random() * 1000)::text;
+    end loop;
+
+    insert into "user" (
+        alias,
+        first_name,
+        last_name,
+        email,
+        email_verified,
+        disabled
+    ) values (
+        v_alias,
+        nullif(p_user->>'first_name', ''),
+        nullif(p_user->>'last_name', ''),
+        p_user->>'email',
+        true,
+        coalesce((p_user->>'disabled')::boolean, false)
+    ) returning user_id into v_user_id;
+
+    return v_user_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,20 @@
+-- update_scim_user updates the provided user using the details received from
+-- a SCIM client. When the user is disabled, all its sessions are deleted.
+create or replace function update_scim_user(p_user_id uuid, p_user jsonb)
+returns void as $$
+begin
+    update "user" set
+        first_name = nullif(p_user->>'first_name', ''),
+        last_name = nullif(p_user->>'last_name', ''),
+        email = p_user->>'email',
+        disabled = coalesce((p_user->>'disabled')::boolean, false)
+    where user_id = p_user_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    if (p_user->>'disabled')::boolean = true then
+        delete from session where user_id = p_user_id;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,36 @@
+-- add_service_account adds a new service account to the provided organization.
+-- The service account alias is built from the organization and the name
+-- provided. Service accounts cannot log in, they can only use api keys.
+create or replace function add_service_account(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_name text
+) returns setof json as $$
+declare
+    v_alias text := p_org_name || '.' || p_name;
+    v_service_account_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+    perform from "user" where alias = v_alias;
+    if found then
+        raise 'service account name not available';
+    end if;
+
+    insert into "user" (
+        alias,
+        email,
+        service_account_organization_id
+    ) values (
+        v_alias,
+        gen_random_uuid() || '@service-accounts.invalid',
+        (select organization_id from organization where name = p_org_name)
+    ) returning user_id into v_service_account_id;
+
+    return query select json_build_object(
+        'service_account_id', v_service_account_id,
+        'alias', v_alias
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,25 @@
+-- add_service_account_api_key adds the provided api key to the service account
+-- of the organization selected.
+create or replace function add_service_account_api_key(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_service_account_id uuid,
+    p_api_key jsonb
+) returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+    perform from "user" u
+    join organization o on u.service_account_organization_id = o.organization_id
+    where o.name = p_org_name
+    and u.user_id = p_service_account_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    return query select add_api_key(
+        p_api_key || jsonb_build_object('user_id', p_service_account_id)
+    );
+end
+$$ language plpgsql;
@@ -0,0 +1,22 @@
+-- delete_service_account deletes the provided service account, as well as
+-- its api keys, from the organization it belongs to.
+create or replace function delete_service_account(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_service_account_id uuid
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from "user" u
+    using organization o
+    where u.service_account_organization_id = o.organization_id
+    and o.name = p_org_name
+    and u.user_id = p_service_account_id;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
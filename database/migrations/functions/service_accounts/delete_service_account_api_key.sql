@@ -0,0 +1,25 @@
+-- delete_service_account_api_key deletes the provided api key from the service
+-- account of the organization selected.
+create or replace function delete_service_account_api_key(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_service_account_id uuid,
+    p_api_key_id uuid
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from api_key ak
+    using "user" u, organization o
+    where ak.user_id = u.user_id
+    and u.service_account_organization_id = o.organization_id
+    and o.name = p_org_name
+    and u.user_id = p_service_account_id
+    and ak.api_key_id = p_api_key_id;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,26 @@
+-- get_org_service_accounts returns the service accounts that belong to the
+-- provided organization, including their api keys, as a json array.
+create or replace function get_org_service_accounts(p_requesting_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_build_object(
+        'service_account_id', u.user_id,
+        'alias', u.alias,
+        'created_at', floor(extract(epoch from u.created_at)),
+        'api_keys', (
+            select coalesce(json_agg(akJSON order by ak.name asc), '[]')
+            from api_key ak
+            cross join get_api_key(u.user_id, ak.api_key_id) as akJSON
+            where ak.user_id = u.user_id
+        )
+    ) order by u.alias asc), '[]')
+    from "user" u
+    join organization o on u.service_account_organization_id = o.organization_id
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
@@ -0,0 +1,15 @@
+-- get_service_account_allowed_actions returns the actions the service account
+-- provided is allowed to perform in the organization given. Service accounts
+-- do not belong to the organization that owns them like members do, they can
+-- only add and update its repositories.
+create or replace function get_service_account_allowed_actions(p_user_id uuid, p_org_name text)
+returns text[] as $$
+    select case when exists (
+        select user_id
+        from "user" u
+        join organization o on u.service_account_organization_id = o.organization_id
+        where o.name = p_org_name
+        and u.user_id = p_user_id
+    ) then '{addOrganizationRepository,updateOrganizationRepository}'::text[]
+    else '{}'::text[] end;
+$$ language sql;
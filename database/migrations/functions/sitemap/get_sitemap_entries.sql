@@ -0,0 +1,77 @@
+-- get_sitemap_entries returns the entries of the sitemap section provided as a
+-- json array. Entries are sorted by name and paginated using the limit and
+-- offset provided. The last modification time of each entry is based on the
+-- creation time of the most recent package version related to it.
+create or replace function get_sitemap_entries(p_section text, p_limit int, p_offset int)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'repository_kind_id', repository_kind_id,
+        'repository_name', repository_name,
+        'package_name', package_name,
+        'organization_name', organization_name,
+        'user_alias', user_alias,
+        'last_modified', floor(extract(epoch from last_modified))
+    ))), '[]')
+    from (
+        (
+            select
+                r.repository_kind_id,
+                r.name as repository_name,
+                p.normalized_name as package_name,
+                null::text as organization_name,
+                null::text as user_alias,
+                (
+                    select max(s.created_at)
+                    from snapshot s
+                    where s.package_id = p.package_id
+                ) as last_modified
+            from package p
+            join repository r using (repository_id)
+            where p_section = 'packages'
+            order by r.name asc, p.normalized_name asc
+            limit p_limit offset p_offset
+        )
+        union all
+        (
+            select
+                null,
+                r.name,
+                null,
+                null,
+                null,
+                greatest(r.created_at, (
+                    select max(s.created_at)
+                    from snapshot s
+                    join package p using (package_id)
+                    where p.repository_id = r.repository_id
+                ))
+            from repository r
+            where p_section = 'repositories'
+            order by r.name asc
+            limit p_limit offset p_offset
+        )
+        union all
+        (
+            select null, null, null, organization_name, user_alias, last_modified
+            from (
+                select o.name as organization_name, null as user_alias, max(s.created_at) as last_modified
+                from organization o
+                join repository r using (organization_id)
+                join package p using (repository_id)
+                join snapshot s using (package_id)
+                group by o.name
+                union all
+                select null, u.alias, max(s.created_at)
+                from "user" u
+                join repository r using (user_id)
+                join package p using (repository_id)
+                join snapshot s using (package_id)
+                where r.organization_id is null
+                group by u.alias
+            ) pub
+            where p_section = 'publishers'
+            order by coalesce(organization_name, user_alias) asc
+            limit p_limit offset p_offset
+        )
+    ) e;
+$$ language sql;
@@ -0,0 +1,32 @@
+-- get_sitemap_sections returns the sections available in the sitemap as a json
+-- array, including the number of entries in each of them and the time of
+-- their last modification.
+create or replace function get_sitemap_sections()
+returns setof json as $$
+    select json_build_array(
+        json_strip_nulls(json_build_object(
+            'name', 'packages',
+            'total', (select count(*) from package),
+            'last_modified', (select floor(extract(epoch from max(created_at))) from snapshot)
+        )),
+        json_strip_nulls(json_build_object(
+            'name', 'repositories',
+            'total', (select count(*) from repository),
+            'last_modified', (
+                select floor(extract(epoch from greatest(
+                    (select max(created_at) from repository),
+                    (select max(created_at) from snapshot)
+                )))
+            )
+        )),
+        json_strip_nulls(json_build_object(
+            'name', 'publishers',
+            'total', (
+                select count(distinct coalesce(r.organization_id, r.user_id))
+                from repository r
+                where exists (select 1 from package p where p.repository_id = r.repository_id)
+            ),
+            'last_modified', (select floor(extract(epoch from max(created_at))) from snapshot)
+        ))
+    );
+$$ language sql;
@@ -0,0 +1,81 @@
+-- get_stats_series returns the time series of the metric provided, bucketed
+-- by the interval selected, formatted as json. Buckets with no activity are
+-- included so that the series can be charted directly.
+create or replace function get_stats_series(p_input jsonb)
+returns setof json as $$
+declare
+    v_metric text := p_input->>'metric';
+    v_interval text := p_input->>'interval';
+    v_from timestamptz := date_trunc(p_input->>'interval', (p_input->>'from')::timestamptz);
+    v_to timestamptz := date_trunc(p_input->>'interval', (p_input->>'to')::timestamptz);
+    v_step interval := ('1 ' || (p_input->>'interval'))::interval;
+    v_table text;
+begin
+    -- Releases are reported per repository kind
+    if v_metric = 'releases' then
+        return query
+        select json_build_object(
+            'metric', v_metric,
+            'interval', v_interval,
+            'kinds', json_agg(json_build_object(
+                'repository_kind_id', rk.repository_kind_id,
+                'name', rk.name,
+                'created', (
+                    select json_agg(json_build_array(
+                        floor(extract(epoch from b.ts)*1000),
+                        coalesce(c.total, 0)
+                    ) order by b.ts)
+                    from generate_series(v_from, v_to, v_step) as b(ts)
+                    left join (
+                        select date_trunc(v_interval, s.created_at) as ts, count(*) as total
+                        from snapshot s
+                        join package p using (package_id)
+                        join repository r using (repository_id)
+                        where r.repository_kind_id = rk.repository_kind_id
+                        and s.created_at >= v_from
+                        and s.created_at < v_to + v_step
+                        group by 1
+                    ) c using (ts)
+                )
+            ) order by rk.repository_kind_id)
+        )
+        from repository_kind rk;
+        return;
+    end if;
+
+    -- Other metrics track the growth of the entity selected
+    v_table := case v_metric
+        when 'packages' then 'package'
+        when 'repositories' then 'repository'
+        when 'organizations' then 'organization'
+        when 'users' then 'user'
+    end;
+    if v_table is null then
+        raise 'invalid metric: %', v_metric;
+    end if;
+    return query execute format('
+        select json_build_object(
+            ''metric'', $1,
+            ''interval'', $2,
+            ''created'', json_agg(json_build_array(ts_ms, total) order by ts),
+            ''running_total'', json_agg(json_build_array(ts_ms, running_total) order by ts)
+        )
+        from (
+            select
+                b.ts,
+                floor(extract(epoch from b.ts)*1000) as ts_ms,
+                coalesce(c.total, 0) as total,
+                base.total + sum(coalesce(c.total, 0)) over (order by b.ts) as running_total
+            from generate_series($3, $4, $5) as b(ts)
+            cross join (select count(*) as total from %1$I where created_at < $3) base
+            left join (
+                select date_trunc($2, created_at) as ts, count(*) as total
+                from %1$I
+                where created_at >= $3
+                and created_at < $4 + $5
+                group by 1
+            ) c using (ts)
+        ) s', v_table)
+    using v_metric, v_interval, v_from, v_to, v_step;
+end
+$$ language plpgsql;
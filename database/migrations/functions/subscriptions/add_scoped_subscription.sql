@@ -0,0 +1,53 @@
+-- add_scoped_subscription subscribes the provided user to the event kind
+-- provided for the repository or publisher (user or organization) provided.
+-- Unlike bulk subscriptions, scoped subscriptions also cover the packages
+-- added to the repository or publisher in the future. When the subscription
+-- already exists, its minimum severity is updated.
+create or replace function add_scoped_subscription(p_user_id uuid, p_input jsonb)
+returns void as $$
+declare
+    v_event_kind_id int := (p_input->>'event_kind')::int;
+    v_min_severity text := nullif(p_input->>'min_severity', '');
+    v_repository_id uuid;
+    v_organization_id uuid;
+    v_publisher_user_id uuid;
+begin
+    if p_input ? 'repository_id' then
+        select repository_id into v_repository_id
+        from repository
+        where repository_id = (p_input->>'repository_id')::uuid;
+        if not found then
+            raise no_data_found;
+        end if;
+
+        insert into repository_subscription (user_id, repository_id, event_kind_id, min_severity)
+        values (p_user_id, v_repository_id, v_event_kind_id, v_min_severity)
+        on conflict (user_id, repository_id, event_kind_id) do update set
+            min_severity = excluded.min_severity;
+    elsif p_input ? 'organization_name' then
+        select organization_id into v_organization_id
+        from organization
+        where name = p_input->>'organization_name';
+        if not found then
+            raise no_data_found;
+        end if;
+
+        insert into publisher_subscription (user_id, organization_id, event_kind_id, min_severity)
+        values (p_user_id, v_organization_id, v_event_kind_id, v_min_severity)
+        on conflict (user_id, organization_id, event_kind_id) do update set
+            min_severity = excluded.min_severity;
+    elsif p_input ? 'user_alias' then
+        select user_id into v_publisher_user_id
+        from "user"
+        where alias = p_input->>'user_alias';
+        if not found then
+            raise no_data_found;
+        end if;
+
+        insert into publisher_subscription (user_id, publisher_user_id, event_kind_id, min_severity)
+        values (p_user_id, v_publisher_user_id, v_event_kind_id, v_min_severity)
+        on conflict (user_id, publisher_user_id, event_kind_id) do update set
+            min_severity = excluded.min_severity;
+    end if;
+end
+$$ language plpgsql;
@@ -1,13 +1,18 @@
--- add_subscription adds the provided subscription to the database.
+-- add_subscription adds the provided subscription to the database. When the
+-- subscription already exists, its minimum severity is updated.
 create or replace function add_subscription(p_subscription jsonb)
 returns void as $$
     insert into subscription (
         user_id,
         package_id,
-        event_kind_id
+        event_kind_id,
+        min_severity
     ) values (
         (p_subscription->>'user_id')::uuid,
         (p_subscription->>'package_id')::uuid,
-        (p_subscription->>'event_kind')::int
-    );
+        (p_subscription->>'event_kind')::int,
+        nullif(p_subscription->>'min_severity', '')
+    )
+    on conflict (user_id, package_id, event_kind_id) do update set
+        min_severity = excluded.min_severity;
 $$ language sql;
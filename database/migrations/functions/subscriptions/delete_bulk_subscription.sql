@@ -0,0 +1,23 @@
+-- delete_bulk_subscription unsubscribes the provided user from the event kind
+-- provided for all the packages of the repository or publisher (user or
+-- organization) provided.
+create or replace function delete_bulk_subscription(p_user_id uuid, p_input jsonb)
+returns void as $$
+    delete from subscription
+    where user_id = p_user_id
+    and event_kind_id = (p_input->>'event_kind')::int
+    and package_id in (
+        select p.package_id
+        from package p
+        join repository r using (repository_id)
+        left join organization o on o.organization_id = r.organization_id
+        left join "user" u on u.user_id = r.user_id
+        where
+            case
+                when p_input ? 'repository_id' then r.repository_id = (p_input->>'repository_id')::uuid
+                when p_input ? 'organization_name' then o.name = p_input->>'organization_name'
+                when p_input ? 'user_alias' then u.alias = p_input->>'user_alias'
+                else false
+            end
+    );
+$$ language sql;
@@ -0,0 +1,28 @@
+-- delete_scoped_subscription unsubscribes the provided user from the event
+-- kind provided for the repository or publisher (user or organization)
+-- provided.
+create or replace function delete_scoped_subscription(p_user_id uuid, p_input jsonb)
+returns void as $$
+begin
+    if p_input ? 'repository_id' then
+        delete from repository_subscription
+        where user_id = p_user_id
+        and repository_id = (p_input->>'repository_id')::uuid
+        and event_kind_id = (p_input->>'event_kind')::int;
+    elsif p_input ? 'organization_name' then
+        delete from publisher_subscription
+        where user_id = p_user_id
+        and organization_id = (
+            select organization_id from organization where name = p_input->>'organization_name'
+        )
+        and event_kind_id = (p_input->>'event_kind')::int;
+    elsif p_input ? 'user_alias' then
+        delete from publisher_subscription
+        where user_id = p_user_id
+        and publisher_user_id = (
+            select user_id from "user" where alias = p_input->>'user_alias'
+        )
+        and event_kind_id = (p_input->>'event_kind')::int;
+    end if;
+end
+$$ language plpgsql;
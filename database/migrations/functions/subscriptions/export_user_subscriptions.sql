@@ -0,0 +1,23 @@
+-- export_user_subscriptions returns all the subscriptions of the provided
+-- user as a json array. Packages are identified by their repository name and
+-- normalized name, so that the subscriptions can be imported later.
+create or replace function export_user_subscriptions(p_user_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'repository_name', repository_name,
+        'package_name', package_name,
+        'event_kinds', event_kinds
+    )), '[]')
+    from (
+        select
+            r.name as repository_name,
+            p.normalized_name as package_name,
+            json_agg(s.event_kind_id order by s.event_kind_id) as event_kinds
+        from subscription s
+        join package p using (package_id)
+        join repository r using (repository_id)
+        where s.user_id = p_user_id
+        group by r.name, p.normalized_name
+        order by r.name asc, p.normalized_name asc
+    ) es;
+$$ language sql;
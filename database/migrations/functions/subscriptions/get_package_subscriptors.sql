@@ -1,12 +1,58 @@
 -- get_package_subscriptors returns the users subscribed to the package
--- provided for the given event kind.
+-- provided for the given event kind. Users subscribed to the repository the
+-- package belongs to or to its publisher (user or organization) are also
+-- considered to be subscribed to the package, unless they have opted out of
+-- the package notifications for the given event kind. When a user has several
+-- subscriptions matching the package, the least restrictive minimum severity
+-- is returned (none if any of them does not set it).
 create or replace function get_package_subscriptors(p_package_id uuid, p_event_kind int)
 returns setof json as $$
-    select coalesce(json_agg(json_build_object(
-        'user_id', u.user_id
-    )), '[]')
-    from subscription s
-    join "user" u using (user_id)
-    where s.package_id = p_package_id
-    and s.event_kind_id = p_event_kind;
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'user_id', u.user_id,
+        'notifications_preferences', u.notifications_preferences,
+        'min_severity', subscriptors.min_severity
+    )) order by u.user_id asc), '[]')
+    from (
+        select
+            user_id,
+            case when bool_or(min_severity is null) then null else (
+                array_agg(min_severity order by array_position(
+                    array['low', 'medium', 'high', 'critical'], min_severity
+                ))
+            )[1] end as min_severity
+        from (
+            select s.user_id, s.min_severity
+            from subscription s
+            where s.package_id = p_package_id
+            and s.event_kind_id = p_event_kind
+            union all
+            select rs.user_id, rs.min_severity
+            from package p
+            join repository_subscription rs using (repository_id)
+            where p.package_id = p_package_id
+            and rs.event_kind_id = p_event_kind
+            and not exists (
+                select 1 from package_opt_out po
+                where po.user_id = rs.user_id
+                and po.package_id = p_package_id
+                and po.event_kind_id = p_event_kind
+            )
+            union all
+            select ps.user_id, ps.min_severity
+            from package p
+            join repository r using (repository_id)
+            join publisher_subscription ps on
+                ps.organization_id = r.organization_id or ps.publisher_user_id = r.user_id
+            where p.package_id = p_package_id
+            and ps.event_kind_id = p_event_kind
+            and not exists (
+                select 1 from package_opt_out po
+                where po.user_id = ps.user_id
+                and po.package_id = p_package_id
+                and po.event_kind_id = p_event_kind
+            )
+        ) s
+        group by user_id
+    ) subscriptors
+    join "user" u using (user_id);
 $$ language sql;
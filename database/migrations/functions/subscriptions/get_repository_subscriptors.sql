@@ -5,9 +5,10 @@
 -- have opted out of notifications for that repository and event.
 create or replace function get_repository_subscriptors(p_repository_id uuid, p_event_kind_id int)
 returns setof json as $$
-    select coalesce(json_agg(json_build_object(
-        'user_id', user_id
-    )), '[]')
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'user_id', u.user_id,
+        'notifications_preferences', u.notifications_preferences
+    )) order by u.user_id asc), '[]')
     from (
         select r.user_id
         from repository r
@@ -20,9 +21,9 @@ returns setof json as $$
         join user__organization uo using (organization_id)
         where repository_id = p_repository_id
         and uo.confirmed = true
-        order by user_id asc
     ) owners
-    where user_id not in (
+    join "user" u using (user_id)
+    where u.user_id not in (
         select user_id
         from opt_out
         where repository_id = p_repository_id
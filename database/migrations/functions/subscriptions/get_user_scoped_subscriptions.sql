@@ -0,0 +1,53 @@
+-- get_user_scoped_subscriptions returns all the repositories and publishers
+-- subscriptions for the provided user as a json array.
+create or replace function get_user_scoped_subscriptions(p_user_id uuid)
+returns setof json as $$
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'repository', (select get_repository_summary(ss.repository_id)),
+        'organization_name', ss.organization_name,
+        'organization_display_name', ss.organization_display_name,
+        'user_alias', ss.user_alias,
+        'event_kinds', ss.event_kinds,
+        'security_alerts_min_severity', ss.security_alerts_min_severity
+    )) order by ss.sort_key asc), '[]')
+    from (
+        select
+            rs.repository_id,
+            null as organization_name,
+            null as organization_display_name,
+            null as user_alias,
+            json_agg(rs.event_kind_id order by rs.event_kind_id asc) as event_kinds,
+            max(rs.min_severity) as security_alerts_min_severity,
+            r.name as sort_key
+        from repository_subscription rs
+        join repository r using (repository_id)
+        where rs.user_id = p_user_id
+        group by rs.repository_id, r.name
+        union all
+        select
+            null,
+            o.name,
+            o.display_name,
+            null,
+            json_agg(ps.event_kind_id order by ps.event_kind_id asc),
+            max(ps.min_severity),
+            o.name
+        from publisher_subscription ps
+        join organization o using (organization_id)
+        where ps.user_id = p_user_id
+        group by o.organization_id
+        union all
+        select
+            null,
+            null,
+            null,
+            u.alias,
+            json_agg(ps.event_kind_id order by ps.event_kind_id asc),
+            max(ps.min_severity),
+            u.alias
+        from publisher_subscription ps
+        join "user" u on u.user_id = ps.publisher_user_id
+        where ps.user_id = p_user_id
+        group by u.user_id
+    ) ss;
+$$ language sql;
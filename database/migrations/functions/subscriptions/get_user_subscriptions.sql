@@ -13,6 +13,13 @@ returns setof json as $$
             from subscription
             where package_id = sp.package_id
             and user_id = p_user_id
+        ),
+        'security_alerts_min_severity', (
+            select min_severity
+            from subscription
+            where package_id = sp.package_id
+            and user_id = p_user_id
+            and event_kind_id = 1
         )
     ))), '[]')
     from (
@@ -0,0 +1,13 @@
+-- import_user_subscriptions subscribes the provided user to the packages and
+-- event kinds provided, using the format returned by export_user_subscriptions.
+-- Existing subscriptions are kept and packages not found are ignored.
+create or replace function import_user_subscriptions(p_user_id uuid, p_subscriptions jsonb)
+returns void as $$
+    insert into subscription (user_id, package_id, event_kind_id)
+    select p_user_id, p.package_id, ek::int
+    from jsonb_array_elements(p_subscriptions) es
+    cross join jsonb_array_elements_text(es->'event_kinds') ek
+    join repository r on r.name = es->>'repository_name'
+    join package p on p.repository_id = r.repository_id and p.normalized_name = es->>'package_name'
+    on conflict do nothing;
+$$ language sql;
@@ -0,0 +1,95 @@
+-- search_user_subscriptions returns the subscriptions of the provided user
+-- that match the filters provided as a json object. Subscriptions are grouped
+-- by package and paginated, using offset or cursor based pagination.
+create or replace function search_user_subscriptions(p_user_id uuid, p_input jsonb)
+returns setof json as $$
+declare
+    v_limit int := coalesce((p_input->>'limit')::int, 20);
+    v_offset int := coalesce((p_input->>'offset')::int, 0);
+    v_cursor jsonb;
+    v_cursor_package_id uuid;
+    v_event_kinds int[];
+begin
+    -- Decode pagination cursor, if provided
+    v_cursor := get_cursor(p_input, '{normalized_name,package_id}');
+    if v_cursor is not null then
+        begin
+            v_cursor_package_id := (v_cursor->>'package_id')::uuid;
+        exception when others then
+            raise exception 'invalid cursor' using errcode = 'invalid_parameter_value';
+        end;
+    end if;
+
+    -- Prepare filters
+    select array_agg(e::int) into v_event_kinds
+    from jsonb_array_elements_text(p_input->'event_kinds') e;
+
+    return query
+    with filtered_subscriptions as (
+        select s.package_id, s.event_kind_id
+        from subscription s
+        where s.user_id = p_user_id
+        and
+            case when cardinality(v_event_kinds) > 0 then
+            s.event_kind_id = any(v_event_kinds) else true end
+    ), filtered_packages as (
+        select
+            p.package_id,
+            p.name,
+            p.normalized_name,
+            s.logo_image_id,
+            p.repository_id
+        from package p
+        join snapshot s on s.package_id = p.package_id and s.version = p.latest_version
+        where p.package_id in (select package_id from filtered_subscriptions)
+    ), packages_page as (
+        select
+            fp.*,
+            row_number() over (order by normalized_name asc, package_id asc) as position,
+            count(*) over () as available
+        from filtered_packages fp
+        where
+            case when v_cursor is not null then
+                (normalized_name, package_id) > (
+                    v_cursor->>'normalized_name',
+                    v_cursor_package_id
+                )
+            else true end
+        order by position asc
+        limit v_limit
+        offset v_offset
+    )
+    select json_build_object(
+        'subscriptions', (
+            select coalesce(json_agg(json_strip_nulls(json_build_object(
+                'package_id', package_id,
+                'name', name,
+                'normalized_name', normalized_name,
+                'logo_image_id', logo_image_id,
+                'repository', (select get_repository_summary(repository_id)),
+                'event_kinds', (
+                    select json_agg(fs.event_kind_id order by fs.event_kind_id)
+                    from filtered_subscriptions fs
+                    where fs.package_id = fp.package_id
+                )
+            )) order by position asc), '[]')
+            from packages_page fp
+        ),
+        'metadata', json_strip_nulls(json_build_object(
+            'limit', v_limit,
+            'offset', v_offset,
+            'total', (select count(*) from filtered_packages),
+            'next_cursor', (
+                select encode_cursor(jsonb_build_object(
+                    'normalized_name', normalized_name,
+                    'package_id', package_id,
+                    'scope', get_cursor_scope(p_input)
+                ))
+                from packages_page
+                where position = v_offset + v_limit
+                and position < available
+            )
+        ))
+    );
+end
+$$ language plpgsql;
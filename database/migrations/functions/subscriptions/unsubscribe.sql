@@ -0,0 +1,31 @@
+-- unsubscribe stops delivering the notifications described by the input
+-- provided to the corresponding user. Package subscriptions are removed and a
+-- package opt-out entry is added, so that notifications for the package are
+-- not delivered either because of a repository or publisher subscription. An
+-- opt-out entry is added for repositories notifications.
+create or replace function unsubscribe(p_input jsonb)
+returns void as $$
+begin
+    if p_input->>'package_id' is not null then
+        delete from subscription
+        where user_id = (p_input->>'user_id')::uuid
+        and package_id = (p_input->>'package_id')::uuid
+        and event_kind_id = (p_input->>'event_kind')::int;
+        insert into package_opt_out (user_id, package_id, event_kind_id)
+        values (
+            (p_input->>'user_id')::uuid,
+            (p_input->>'package_id')::uuid,
+            (p_input->>'event_kind')::int
+        )
+        on conflict do nothing;
+    elsif p_input->>'repository_id' is not null then
+        insert into opt_out (user_id, repository_id, event_kind_id)
+        values (
+            (p_input->>'user_id')::uuid,
+            (p_input->>'repository_id')::uuid,
+            (p_input->>'event_kind')::int
+        )
+        on conflict do nothing;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,28 @@
+-- add_team adds the provided team to the organization given.
+create or replace function add_team(p_requesting_user_id uuid, p_org_name text, p_team jsonb)
+returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+    perform from team t
+    join organization o using (organization_id)
+    where o.name = p_org_name
+    and t.name = p_team->>'name';
+    if found then
+        raise 'team name not available';
+    end if;
+
+    insert into team (
+        organization_id,
+        name,
+        display_name,
+        description
+    ) values (
+        (select organization_id from organization where name = p_org_name),
+        p_team->>'name',
+        nullif(p_team->>'display_name', ''),
+        nullif(p_team->>'description', '')
+    );
+end
+$$ language plpgsql;
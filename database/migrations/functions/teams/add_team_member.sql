@@ -0,0 +1,35 @@
+-- add_team_member adds a member of the organization to the provided team.
+create or replace function add_team_member(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_team_name text,
+    p_user_alias text
+) returns void as $$
+declare
+    v_team_id uuid;
+    v_user_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    select t.team_id into v_team_id
+    from team t
+    join organization o using (organization_id)
+    where o.name = p_org_name
+    and t.name = p_team_name;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Only members of the organization can join its teams
+    select user_id into v_user_id from "user" where alias = p_user_alias;
+    if v_user_id is null or not user_belongs_to_organization(v_user_id, p_org_name) then
+        raise 'user is not a member of the organization';
+    end if;
+
+    insert into user__team (user_id, team_id)
+    values (v_user_id, v_team_id)
+    on conflict do nothing;
+end
+$$ language plpgsql;
@@ -0,0 +1,16 @@
+-- delete_team deletes the provided team from the organization given.
+create or replace function delete_team(p_requesting_user_id uuid, p_org_name text, p_team_name text)
+returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from team
+    where organization_id = (select organization_id from organization where name = p_org_name)
+    and name = p_team_name;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,25 @@
+-- delete_team_member deletes a member from the provided team.
+create or replace function delete_team_member(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_team_name text,
+    p_user_alias text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from user__team ut
+    using team t, organization o, "user" u
+    where ut.team_id = t.team_id
+    and t.organization_id = o.organization_id
+    and ut.user_id = u.user_id
+    and o.name = p_org_name
+    and t.name = p_team_name
+    and u.alias = p_user_alias;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
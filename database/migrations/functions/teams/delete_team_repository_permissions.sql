@@ -0,0 +1,26 @@
+-- delete_team_repository_permissions revokes the permissions granted to the
+-- provided team on the repository given.
+create or replace function delete_team_repository_permissions(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_team_name text,
+    p_repository_name text
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from team__repository tr
+    using team t, organization o, repository r
+    where tr.team_id = t.team_id
+    and t.organization_id = o.organization_id
+    and tr.repository_id = r.repository_id
+    and o.name = p_org_name
+    and t.name = p_team_name
+    and r.name = p_repository_name;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
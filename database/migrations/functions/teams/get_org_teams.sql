@@ -0,0 +1,35 @@
+-- get_org_teams returns the teams that belong to the provided organization,
+-- including their members and repositories permissions, as a json array.
+create or replace function get_org_teams(p_requesting_user_id uuid, p_org_name text)
+returns setof json as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(json_agg(json_strip_nulls(json_build_object(
+        'name', t.name,
+        'display_name', t.display_name,
+        'description', t.description,
+        'members', (
+            select coalesce(json_agg(u.alias order by u.alias asc), '[]')
+            from user__team ut
+            join "user" u using (user_id)
+            where ut.team_id = t.team_id
+        ),
+        'repositories', (
+            select coalesce(json_agg(json_build_object(
+                'name', r.name,
+                'allowed_actions', tr.allowed_actions
+            ) order by r.name asc), '[]')
+            from team__repository tr
+            join repository r using (repository_id)
+            where tr.team_id = t.team_id
+        )
+    )) order by t.name asc), '[]')
+    from team t
+    join organization o using (organization_id)
+    where o.name = p_org_name;
+end
+$$ language plpgsql;
@@ -0,0 +1,20 @@
+-- get_user_teams returns the teams the provided user belongs to in the
+-- organization given, including the actions they are allowed to perform on
+-- each of their repositories, as a json array.
+create or replace function get_user_teams(p_user_id uuid, p_org_name text)
+returns setof json as $$
+    select coalesce(json_agg(json_build_object(
+        'name', t.name,
+        'repositories', (
+            select coalesce(json_object_agg(r.name, tr.allowed_actions), '{}')
+            from team__repository tr
+            join repository r using (repository_id)
+            where tr.team_id = t.team_id
+        )
+    ) order by t.name asc), '[]')
+    from team t
+    join organization o using (organization_id)
+    join user__team ut using (team_id)
+    where o.name = p_org_name
+    and ut.user_id = p_user_id;
+$$ language sql;
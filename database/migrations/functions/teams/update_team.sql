@@ -0,0 +1,22 @@
+-- update_team updates the provided team in the database.
+create or replace function update_team(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_team_name text,
+    p_team jsonb
+) returns void as $$
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    update team set
+        display_name = nullif(p_team->>'display_name', ''),
+        description = nullif(p_team->>'description', '')
+    where organization_id = (select organization_id from organization where name = p_org_name)
+    and name = p_team_name;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,42 @@
+-- update_team_repository_permissions sets the actions the members of the
+-- provided team are allowed to perform on the repository given.
+create or replace function update_team_repository_permissions(
+    p_requesting_user_id uuid,
+    p_org_name text,
+    p_team_name text,
+    p_repository_name text,
+    p_allowed_actions text[]
+) returns void as $$
+declare
+    v_team_id uuid;
+    v_repository_id uuid;
+begin
+    if not user_belongs_to_organization(p_requesting_user_id, p_org_name) then
+        raise insufficient_privilege;
+    end if;
+
+    select t.team_id into v_team_id
+    from team t
+    join organization o using (organization_id)
+    where o.name = p_org_name
+    and t.name = p_team_name;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Teams can only be granted permissions on the organization repositories
+    select r.repository_id into v_repository_id
+    from repository r
+    join organization o using (organization_id)
+    where o.name = p_org_name
+    and r.name = p_repository_name;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    insert into team__repository (team_id, repository_id, allowed_actions)
+    values (v_team_id, v_repository_id, p_allowed_actions)
+    on conflict (team_id, repository_id) do update
+    set allowed_actions = excluded.allowed_actions;
+end
+$$ language plpgsql;
@@ -0,0 +1,43 @@
+-- change_user_email updates the email of the user provided to the one
+-- associated to the code if it is still valid, returning the previous email of
+-- the user. The user sessions can optionally be invalidated.
+create or replace function change_user_email(
+    p_user_id uuid,
+    p_code bytea,
+    p_invalidate_sessions boolean
+) returns text as $$
+declare
+    v_new_email text;
+    v_old_email text;
+begin
+    -- Verify and delete the code provided
+    delete from email_change_code
+    where email_change_code_id = sha512(p_code)
+    and user_id = p_user_id
+    and created_at + '1 hour'::interval > current_timestamp
+    returning new_email into v_new_email;
+    if not found then
+        raise 'invalid email change code';
+    end if;
+
+    -- Check the new email is still available
+    perform from "user" where email = v_new_email;
+    if found then
+        raise 'email not available';
+    end if;
+
+    -- Update user email
+    select email into v_old_email from "user" where user_id = p_user_id;
+    update "user" set
+        email = v_new_email,
+        email_verified = true
+    where user_id = p_user_id;
+
+    -- Invalidate current user sessions if requested
+    if p_invalidate_sessions then
+        delete from session where user_id = p_user_id;
+    end if;
+
+    return v_old_email;
+end
+$$ language plpgsql;
@@ -0,0 +1,6 @@
+-- delete_failed_login_attempts deletes the failed login attempts registered
+-- for the email provided.
+create or replace function delete_failed_login_attempts(p_email text)
+returns void as $$
+    delete from failed_login_attempt where email = p_email;
+$$ language sql;
@@ -0,0 +1,19 @@
+-- delete_scheduled_users deletes the users whose deletion grace period has
+-- elapsed, returning the number of users deleted.
+create or replace function delete_scheduled_users()
+returns integer as $$
+declare
+    v_user_id uuid;
+    v_users_deleted integer := 0;
+begin
+    for v_user_id in
+        select user_id from "user"
+        where deletion_scheduled_at <= current_timestamp
+    loop
+        perform delete_user(v_user_id);
+        v_users_deleted := v_users_deleted + 1;
+    end loop;
+
+    return v_users_deleted;
+end
+$$ language plpgsql;
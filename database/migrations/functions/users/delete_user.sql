@@ -0,0 +1,22 @@
+-- delete_user deletes the provided user, as well as the repositories owned by
+-- the user.
+create or replace function delete_user(p_user_id uuid)
+returns void as $$
+begin
+    -- Update the stars of the packages starred by the user
+    update package set stars = stars - 1
+    where package_id in (
+        select package_id from user_starred_package where user_id = p_user_id
+    );
+
+    -- Delete repositories owned by the user (packages are deleted in cascade)
+    delete from repository where user_id = p_user_id;
+
+    -- Delete user (sessions, subscriptions, API keys, webhooks, etc are
+    -- deleted in cascade)
+    delete from "user" where user_id = p_user_id;
+    if not found then
+        raise no_data_found;
+    end if;
+end
+$$ language plpgsql;
@@ -0,0 +1,58 @@
+-- get_login_throttle returns whether the login attempts for the email and ip
+-- provided must be rejected for now, and the number of seconds to wait until
+-- the next attempt can be made. Accounts are locked when the maximum number of
+-- failed attempts provided is reached within the lockout period. Progressive
+-- delays are applied once there are 3 recent failed attempts for the email or
+-- the ip provided (1s, 2s, 4s, ... up to 60s).
+create or replace function get_login_throttle(
+    p_email text,
+    p_ip inet,
+    p_max_attempts integer,
+    p_lockout interval
+) returns table(locked boolean, retry_after bigint) as $$
+declare
+    v_attempts timestamptz[];
+    v_locked_until timestamptz;
+    v_retry_at timestamptz;
+begin
+    -- Check if the account is locked
+    select array_agg(a.created_at order by a.created_at desc) into v_attempts
+    from (
+        select created_at
+        from failed_login_attempt
+        where email = p_email
+        and created_at > current_timestamp - p_lockout * 2
+        order by created_at desc
+        limit p_max_attempts
+    ) a;
+    if coalesce(array_length(v_attempts, 1), 0) = p_max_attempts
+    and v_attempts[1] - v_attempts[p_max_attempts] <= p_lockout then
+        v_locked_until := v_attempts[1] + p_lockout;
+        if v_locked_until > current_timestamp then
+            return query select true, ceil(extract(epoch from v_locked_until - current_timestamp))::bigint;
+            return;
+        end if;
+    end if;
+
+    -- Check if a progressive delay must be applied
+    select max(a.last_attempt + least(2 ^ (a.attempts - 3), 60) * '1 second'::interval) into v_retry_at
+    from (
+        select count(*) as attempts, max(created_at) as last_attempt
+        from failed_login_attempt
+        where email = p_email
+        and created_at > current_timestamp - p_lockout
+        union all
+        select count(*), max(created_at)
+        from failed_login_attempt
+        where ip = p_ip
+        and created_at > current_timestamp - p_lockout
+    ) a
+    where a.attempts >= 3;
+    if v_retry_at > current_timestamp then
+        return query select false, ceil(extract(epoch from v_retry_at - current_timestamp))::bigint;
+        return;
+    end if;
+
+    return query select false, 0::bigint;
+end
+$$ language plpgsql;
@@ -0,0 +1,15 @@
+-- register_delete_user_code registers a code that allows the user provided to
+-- confirm the deletion of the account.
+create or replace function register_delete_user_code(p_user_id uuid)
+returns bytea as $$
+declare
+    v_code bytea := gen_random_bytes(32);
+begin
+    insert into delete_user_code (delete_user_code_id, user_id)
+    values (sha512(v_code), p_user_id)
+    on conflict (user_id) do update set
+        delete_user_code_id = sha512(v_code),
+        created_at = current_timestamp;
+    return v_code;
+end
+$$ language plpgsql;
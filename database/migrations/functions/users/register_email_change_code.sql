@@ -0,0 +1,23 @@
+-- register_email_change_code registers a code that allows the user provided to
+-- confirm the change of the account email to the new email provided.
+create or replace function register_email_change_code(p_user_id uuid, p_new_email text)
+returns bytea as $$
+declare
+    v_code bytea := gen_random_bytes(32);
+begin
+    -- Check the new email is not being used by another account
+    perform from "user" where email = p_new_email;
+    if found then
+        raise 'email not available';
+    end if;
+
+    -- Register code
+    insert into email_change_code (email_change_code_id, user_id, new_email)
+    values (sha512(v_code), p_user_id, p_new_email)
+    on conflict (user_id) do update set
+        email_change_code_id = sha512(v_code),
+        new_email = p_new_email,
+        created_at = current_timestamp;
+    return v_code;
+end
+$$ language plpgsql;
@@ -0,0 +1,26 @@
+-- register_failed_login_attempt registers a failed login attempt for the email
+-- and ip provided. It returns true when the attempt registered causes the
+-- account to be locked.
+create or replace function register_failed_login_attempt(
+    p_email text,
+    p_ip inet,
+    p_max_attempts integer,
+    p_lockout interval
+) returns boolean as $$
+declare
+    v_locked boolean;
+begin
+    -- Delete attempts no longer needed
+    delete from failed_login_attempt
+    where created_at < current_timestamp - greatest(p_lockout * 2, '1 day'::interval);
+
+    -- Register failed attempt
+    insert into failed_login_attempt (email, ip) values (p_email, p_ip);
+
+    -- Check if the account is locked after this attempt
+    select t.locked into v_locked
+    from get_login_throttle(p_email, p_ip, p_max_attempts, p_lockout) t;
+
+    return v_locked;
+end
+$$ language plpgsql;
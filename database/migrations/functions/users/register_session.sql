@@ -1,9 +1,19 @@
--- register_session registers the provided session in the database.
+-- register_session registers the provided session in the database. If the
+-- user's deletion was scheduled, it is cancelled. Disabled users cannot
+-- register new sessions.
 create or replace function register_session(p_session jsonb)
 returns bytea as $$
 declare
     v_session_id bytea := gen_random_bytes(32);
 begin
+    if exists (
+        select 1 from "user"
+        where user_id = (p_session->>'user_id')::uuid
+        and disabled = true
+    ) then
+        raise 'user disabled';
+    end if;
+
     insert into session (
         session_id,
         user_id,
@@ -15,6 +25,9 @@ begin
         nullif(p_session->>'ip', '')::inet,
         nullif(p_session->>'user_agent', '')
     );
+    update "user" set deletion_scheduled_at = null
+    where user_id = (p_session->>'user_id')::uuid
+    and deletion_scheduled_at is not null;
     return v_session_id;
 end
 $$ language plpgsql;
@@ -0,0 +1,41 @@
+-- register_session_device registers the device (ip and user agent) used to
+-- create the session provided as a known device of the session's user. When
+-- the device had not been seen before and the user has used other devices in
+-- the past, the revocation code provided is associated to the session and the
+-- user's email is returned, so that the new sign-in can be notified.
+create or replace function register_session_device(p_session_id bytea, p_revocation_code bytea)
+returns text as $$
+declare
+    v_user_id uuid;
+    v_device_hash bytea;
+    v_known_devices boolean;
+    v_email text;
+begin
+    -- Get session's user and device
+    select
+        s.user_id,
+        sha512(convert_to(coalesce(host(s.ip), '') || '|' || coalesce(s.user_agent, ''), 'utf8'))
+    into v_user_id, v_device_hash
+    from session s
+    where s.session_id = sha512(p_session_id);
+    if not found then
+        raise no_data_found;
+    end if;
+
+    -- Register device if it hasn't been seen before
+    select exists (select 1 from user_device where user_id = v_user_id) into v_known_devices;
+    insert into user_device (user_id, device_hash)
+    values (v_user_id, v_device_hash)
+    on conflict do nothing;
+    if not found or not v_known_devices then
+        return null;
+    end if;
+
+    -- Associate revocation code to the session
+    update session set revocation_code = sha512(p_revocation_code)
+    where session_id = sha512(p_session_id);
+
+    select email into v_email from "user" where user_id = v_user_id;
+    return v_email;
+end
+$$ language plpgsql;
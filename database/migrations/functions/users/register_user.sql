@@ -25,7 +25,8 @@ begin
         email,
         email_verified,
         password,
-        profile_image_id
+        profile_image_id,
+        locale
     ) values (
         p_user->>'alias',
         nullif(p_user->>'first_name', ''),
@@ -33,7 +34,8 @@ begin
         p_user->>'email',
         (p_user->>'email_verified')::boolean,
         nullif(p_user->>'password', ''),
-        nullif(p_user->>'profile_image_id', '')::uuid
+        nullif(p_user->>'profile_image_id', '')::uuid,
+        nullif(p_user->>'locale', '')
     ) returning user_id into v_user_id;
 
     -- Register email verification code if email isn't already verified
@@ -0,0 +1,24 @@
+-- revoke_session deletes the session associated to the revocation code
+-- provided. The device used to create the session is forgotten, so that new
+-- sign-ins from it are notified again.
+create or replace function revoke_session(p_code bytea)
+returns void as $$
+declare
+    v_user_id uuid;
+    v_device_hash bytea;
+begin
+    delete from session
+    where revocation_code = sha512(p_code)
+    returning
+        user_id,
+        sha512(convert_to(coalesce(host(ip), '') || '|' || coalesce(user_agent, ''), 'utf8'))
+    into v_user_id, v_device_hash;
+    if not found then
+        raise 'invalid session revocation code';
+    end if;
+
+    delete from user_device
+    where user_id = v_user_id
+    and device_hash = v_device_hash;
+end
+$$ language plpgsql;
@@ -0,0 +1,26 @@
+-- schedule_user_deletion schedules the deletion of the user provided once the
+-- grace period has elapsed, if the code provided is still valid. The user
+-- sessions are invalidated, as logging in again cancels the deletion.
+create or replace function schedule_user_deletion(
+    p_user_id uuid,
+    p_code bytea,
+    p_grace_period interval
+) returns void as $$
+begin
+    -- Verify and delete the code provided
+    delete from delete_user_code
+    where delete_user_code_id = sha512(p_code)
+    and user_id = p_user_id
+    and created_at + '15 minute'::interval > current_timestamp;
+    if not found then
+        raise 'invalid delete user code';
+    end if;
+
+    -- Schedule user deletion
+    update "user" set deletion_scheduled_at = current_timestamp + p_grace_period
+    where user_id = p_user_id;
+
+    -- Invalidate current user sessions
+    delete from session where user_id = p_user_id;
+end
+$$ language plpgsql;
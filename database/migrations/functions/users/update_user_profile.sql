@@ -6,6 +6,8 @@ returns void as $$
         alias = p_user->>'alias',
         first_name = nullif(p_user->>'first_name', ''),
         last_name = nullif(p_user->>'last_name', ''),
-        profile_image_id = nullif(p_user->>'profile_image_id', '')::uuid
+        profile_image_id = nullif(p_user->>'profile_image_id', '')::uuid,
+        locale = nullif(p_user->>'locale', ''),
+        notifications_preferences = nullif(p_user->'notifications_preferences', 'null')
     where user_id = p_requesting_user_id;
 $$ language sql;
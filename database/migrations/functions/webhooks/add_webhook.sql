@@ -18,6 +18,9 @@ begin
         v_owner_organization_id = (select organization_id from organization where name = p_org_name);
     else
         v_owner_user_id = p_user_id;
+        if (p_webhook->>'all_packages')::boolean then
+            raise 'only organization webhooks can cover all packages';
+        end if;
     end if;
 
     -- Webhook
@@ -28,7 +31,12 @@ begin
         secret,
         content_type,
         template,
+        format,
+        rate_limit,
+        headers,
         active,
+        batch,
+        all_packages,
         user_id,
         organization_id
     ) values (
@@ -38,7 +46,12 @@ begin
         nullif(p_webhook->>'secret', ''),
         nullif(p_webhook->>'content_type', ''),
         nullif(p_webhook->>'template', ''),
+        nullif(p_webhook->>'format', ''),
+        nullif((p_webhook->>'rate_limit')::integer, 0),
+        nullif(p_webhook->'headers', 'null'::jsonb),
         (p_webhook->>'active')::boolean,
+        coalesce((p_webhook->>'batch')::boolean, false),
+        coalesce((p_webhook->>'all_packages')::boolean, false),
         v_owner_user_id,
         v_owner_organization_id
     )
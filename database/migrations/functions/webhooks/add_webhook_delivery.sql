@@ -0,0 +1,14 @@
+-- add_webhook_delivery registers the provided webhook delivery attempt,
+-- performed on behalf of the user provided. Manual deliveries are taken into
+-- account in the consecutive failures counter, but they never cause the
+-- webhook to be suspended.
+create or replace function add_webhook_delivery(p_user_id uuid, p_delivery jsonb)
+returns void as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, (p_delivery->>'webhook_id')::uuid) then
+        raise insufficient_privilege;
+    end if;
+
+    perform register_webhook_delivery(p_delivery, null);
+end
+$$ language plpgsql;
@@ -0,0 +1,16 @@
+-- enable_webhook enables the provided webhook, clearing any suspension caused
+-- by repeated failed deliveries.
+create or replace function enable_webhook(p_user_id uuid, p_webhook_id uuid)
+returns void as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    update webhook set
+        active = true,
+        consecutive_failures = 0,
+        suspended_at = null
+    where webhook_id = p_webhook_id;
+end
+$$ language plpgsql;
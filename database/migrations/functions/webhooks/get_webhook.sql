@@ -15,7 +15,16 @@ begin
         'secret', wh.secret,
         'content_type', wh.content_type,
         'template', wh.template,
+        'format', wh.format,
+        'rate_limit', wh.rate_limit,
+        'headers', (
+            select json_agg(case when (h->>'secret')::boolean then h - 'value' else h end)
+            from jsonb_array_elements(wh.headers) h
+        ),
         'active', wh.active,
+        'batch', wh.batch,
+        'all_packages', wh.all_packages,
+        'suspended_at', floor(extract(epoch from wh.suspended_at)),
         'event_kinds', (
             select json_agg(event_kind_id)
             from webhook__event_kind wek
@@ -0,0 +1,30 @@
+-- get_webhook_delivery returns the requested delivery attempt of the provided
+-- webhook, including the payload delivered, as a json object.
+create or replace function get_webhook_delivery(
+    p_user_id uuid,
+    p_webhook_id uuid,
+    p_webhook_delivery_id uuid
+) returns setof json as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query select json_strip_nulls(json_build_object(
+        'webhook_delivery_id', webhook_delivery_id,
+        'webhook_id', webhook_id,
+        'notification_id', notification_id,
+        'delivery_id', delivery_id,
+        'payload', payload,
+        'content_type', content_type,
+        'latency', latency,
+        'status_code', status_code,
+        'response_body', response_body,
+        'error', error,
+        'created_at', floor(extract(epoch from created_at))
+    ))
+    from webhook_delivery
+    where webhook_id = p_webhook_id
+    and webhook_delivery_id = p_webhook_delivery_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,30 @@
+-- get_webhook_failed_delivery returns the requested failed delivery of the
+-- provided webhook, including the payload that could not be delivered, as a
+-- json object.
+create or replace function get_webhook_failed_delivery(
+    p_user_id uuid,
+    p_webhook_id uuid,
+    p_notification_id uuid
+) returns setof json as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query select json_strip_nulls(json_build_object(
+        'notification_id', n.notification_id,
+        'event_id', n.event_id,
+        'event_kind', e.event_kind_id,
+        'attempts', n.attempts,
+        'error', dl.error,
+        'payload', dl.payload,
+        'created_at', floor(extract(epoch from n.created_at)),
+        'failed_at', floor(extract(epoch from dl.created_at))
+    ))
+    from notification_dead_letter dl
+    join notification n using (notification_id)
+    join event e using (event_id)
+    where n.webhook_id = p_webhook_id
+    and n.notification_id = p_notification_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,15 @@
+-- get_webhook_headers returns the custom headers of the webhook provided as a
+-- json array, including the values of the secret ones.
+create or replace function get_webhook_headers(p_user_id uuid, p_webhook_id uuid)
+returns setof json as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    return query
+    select coalesce(headers::json, '[]')
+    from webhook
+    where webhook_id = p_webhook_id;
+end
+$$ language plpgsql;
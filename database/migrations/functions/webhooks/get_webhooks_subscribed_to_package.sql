@@ -1,13 +1,30 @@
 -- get_webhooks_subscribed_to_package returns the webhooks subscribed to the
--- event kind and package provided.
+-- event kind and package provided. Organization webhooks covering all
+-- packages are subscribed to the packages of all the organization's
+-- repositories, including the ones added after the webhook was created.
 create or replace function get_webhooks_subscribed_to_package(p_event_kind_id integer, p_package_id uuid)
 returns setof json as $$
     select coalesce(json_agg(wh), '[]')
     from webhook
     join webhook__event_kind wek using (webhook_id)
-    join webhook__package wp using (webhook_id)
     cross join get_webhook(null::uuid, webhook_id) as wh
     where wek.event_kind_id = p_event_kind_id
-    and wp.package_id = p_package_id
-    and active = true;
+    and active = true
+    and (
+        exists (
+            select 1
+            from webhook__package wp
+            where wp.webhook_id = webhook.webhook_id
+            and wp.package_id = p_package_id
+        )
+        or (
+            all_packages = true
+            and organization_id = (
+                select r.organization_id
+                from package p
+                join repository r using (repository_id)
+                where p.package_id = p_package_id
+            )
+        )
+    );
 $$ language sql;
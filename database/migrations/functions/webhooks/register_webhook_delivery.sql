@@ -0,0 +1,100 @@
+-- register_webhook_delivery registers the provided webhook delivery attempt.
+-- Webhooks are suspended automatically once the maximum number of consecutive
+-- failed deliveries provided, if any, is reached. When this happens, the
+-- notifications pending for the webhook are discarded and an event is
+-- registered so that the webhook owners are notified.
+create or replace function register_webhook_delivery(p_delivery jsonb, p_max_consecutive_failures integer)
+returns void as $$
+declare
+    v_webhook_id uuid := (p_delivery->>'webhook_id')::uuid;
+    v_error text := nullif(p_delivery->>'error', '');
+    v_consecutive_failures integer;
+begin
+    insert into webhook_delivery (
+        webhook_id,
+        notification_id,
+        delivery_id,
+        payload,
+        content_type,
+        latency,
+        status_code,
+        response_body,
+        error
+    ) values (
+        v_webhook_id,
+        nullif(p_delivery->>'notification_id', '')::uuid,
+        nullif(p_delivery->>'delivery_id', ''),
+        nullif(p_delivery->>'payload', ''),
+        nullif(p_delivery->>'content_type', ''),
+        (p_delivery->>'latency')::integer,
+        nullif((p_delivery->>'status_code')::integer, 0),
+        nullif(p_delivery->>'response_body', ''),
+        v_error
+    );
+
+    -- Successful deliveries reset the consecutive failures counter
+    if v_error is null and coalesce((p_delivery->>'status_code')::integer, 0) < 400 then
+        update webhook set consecutive_failures = 0
+        where webhook_id = v_webhook_id
+        and consecutive_failures > 0;
+        return;
+    end if;
+
+    -- Suspend webhook if the maximum number of consecutive failures is reached
+    update webhook set consecutive_failures = consecutive_failures + 1
+    where webhook_id = v_webhook_id
+    returning consecutive_failures into v_consecutive_failures;
+    if p_max_consecutive_failures is null or v_consecutive_failures < p_max_consecutive_failures then
+        return;
+    end if;
+    update webhook set
+        active = false,
+        suspended_at = current_timestamp
+    where webhook_id = v_webhook_id
+    and active = true;
+    if not found then
+        return;
+    end if;
+
+    -- Discard notifications pending for the webhook suspended
+    update notification set
+        processed = true,
+        processed_at = current_timestamp,
+        next_attempt_at = null,
+        error = 'webhook suspended'
+    where webhook_id = v_webhook_id
+    and processed = false;
+
+    -- Register webhook suspended event to notify the webhook owners
+    insert into event (event_kind_id, data)
+    select 6, jsonb_build_object(
+        'webhook', jsonb_strip_nulls(jsonb_build_object(
+            'webhook_id', wh.webhook_id,
+            'name', wh.name,
+            'url', wh.url,
+            'organization_name', o.name
+        )),
+        'consecutive_failures', v_consecutive_failures,
+        'last_error', coalesce(v_error, 'unexpected status code: ' || (p_delivery->>'status_code')),
+        'subscriptors', (
+            select coalesce(jsonb_agg(jsonb_strip_nulls(jsonb_build_object(
+                'user_id', u.user_id,
+                'notifications_preferences', u.notifications_preferences
+            ))), '[]')
+            from (
+                select wh.user_id
+                where wh.user_id is not null
+                union
+                select uo.user_id
+                from user__organization uo
+                where uo.organization_id = wh.organization_id
+                and uo.confirmed = true
+            ) owners
+            join "user" u using (user_id)
+        )
+    )
+    from webhook wh
+    left join organization o using (organization_id)
+    where wh.webhook_id = v_webhook_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,30 @@
+-- requeue_webhook_failed_delivery removes the provided notification from the
+-- dead-letter queue and enqueues it again so that it is delivered once more.
+create or replace function requeue_webhook_failed_delivery(
+    p_user_id uuid,
+    p_webhook_id uuid,
+    p_notification_id uuid
+) returns void as $$
+begin
+    if not user_has_access_to_webhook(p_user_id, p_webhook_id) then
+        raise insufficient_privilege;
+    end if;
+
+    delete from notification_dead_letter dl
+    using notification n
+    where dl.notification_id = n.notification_id
+    and n.webhook_id = p_webhook_id
+    and n.notification_id = p_notification_id;
+    if not found then
+        raise no_data_found;
+    end if;
+
+    update notification set
+        processed = false,
+        processed_at = null,
+        error = null,
+        attempts = 0,
+        next_attempt_at = null
+    where notification_id = p_notification_id;
+end
+$$ language plpgsql;
@@ -0,0 +1,38 @@
+-- reserve_webhook_deliveries reserves up to the number of deliveries provided
+-- in the current rate limit window of the given webhook, returning how many
+-- of them were granted. The webhook is locked while the reservation takes
+-- place, so concurrent reservations never exceed its rate limit. All the
+-- deliveries requested are granted to webhooks without a rate limit.
+create or replace function reserve_webhook_deliveries(p_webhook_id uuid, p_deliveries integer)
+returns integer as $$
+declare
+    v_rate_limit integer;
+    v_window_start timestamptz;
+    v_window_deliveries integer;
+    v_granted integer;
+begin
+    select rate_limit, rate_limit_window_start, rate_limit_window_deliveries
+    into v_rate_limit, v_window_start, v_window_deliveries
+    from webhook
+    where webhook_id = p_webhook_id
+    for update;
+
+    if v_rate_limit is null then
+        return p_deliveries;
+    end if;
+
+    -- Start a new window if the current one has already elapsed
+    if v_window_start is null or v_window_start <= current_timestamp - interval '1 minute' then
+        v_window_start := current_timestamp;
+        v_window_deliveries := 0;
+    end if;
+
+    v_granted := greatest(least(p_deliveries, v_rate_limit - v_window_deliveries), 0);
+    update webhook set
+        rate_limit_window_start = v_window_start,
+        rate_limit_window_deliveries = v_window_deliveries + v_granted
+    where webhook_id = p_webhook_id;
+
+    return v_granted;
+end
+$$ language plpgsql;
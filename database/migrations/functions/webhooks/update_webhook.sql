@@ -11,6 +11,11 @@ begin
     if not user_has_access_to_webhook(p_user_id, v_webhook_id) then
         raise insufficient_privilege;
     end if;
+    if (p_webhook->>'all_packages')::boolean and (
+        select organization_id from webhook where webhook_id = v_webhook_id
+    ) is null then
+        raise 'only organization webhooks can cover all packages';
+    end if;
 
     -- Webhook
     update webhook set
@@ -20,7 +25,26 @@ begin
         secret = nullif(p_webhook->>'secret', ''),
         content_type = nullif(p_webhook->>'content_type', ''),
         template = nullif(p_webhook->>'template', ''),
-        active = (p_webhook->>'active')::boolean
+        format = nullif(p_webhook->>'format', ''),
+        rate_limit = nullif((p_webhook->>'rate_limit')::integer, 0),
+        headers = (
+            -- Secret headers provided without a value keep the existing one
+            select jsonb_agg(
+                case when (h->>'secret')::boolean and coalesce(h->>'value', '') = '' then
+                    h || jsonb_build_object('value', (
+                        select eh->>'value'
+                        from jsonb_array_elements(webhook.headers) eh
+                        where lower(eh->>'name') = lower(h->>'name')
+                    ))
+                else h end
+            )
+            from jsonb_array_elements(nullif(p_webhook->'headers', 'null'::jsonb)) h
+        ),
+        active = (p_webhook->>'active')::boolean,
+        batch = coalesce((p_webhook->>'batch')::boolean, false),
+        all_packages = coalesce((p_webhook->>'all_packages')::boolean, false),
+        consecutive_failures = case when (p_webhook->>'active')::boolean then 0 else consecutive_failures end,
+        suspended_at = case when (p_webhook->>'active')::boolean then null else suspended_at end
     where webhook_id = v_webhook_id;
 
     -- Bind webhook with event kinds if needed
@@ -0,0 +1,15 @@
+alter table notification add column attempts integer not null default 0;
+alter table notification add column next_attempt_at timestamptz;
+
+create table if not exists notification_dead_letter (
+    notification_id uuid primary key references notification on delete cascade,
+    payload text check (payload <> ''),
+    error text check (error <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+---- create above / drop below ----
+
+drop table if exists notification_dead_letter;
+alter table notification drop column attempts;
+alter table notification drop column next_attempt_at;
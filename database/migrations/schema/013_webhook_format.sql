@@ -0,0 +1,5 @@
+alter table webhook add column format text check (format <> '');
+
+---- create above / drop below ----
+
+alter table webhook drop column format;
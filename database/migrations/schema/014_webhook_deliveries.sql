@@ -0,0 +1,16 @@
+create table if not exists webhook_delivery (
+    webhook_delivery_id uuid primary key default gen_random_uuid(),
+    webhook_id uuid not null references webhook on delete cascade,
+    notification_id uuid references notification on delete set null,
+    latency integer not null,
+    status_code integer,
+    response_body text check (response_body <> ''),
+    error text check (error <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+create index webhook_delivery_webhook_id_created_at_idx on webhook_delivery (webhook_id, created_at);
+
+---- create above / drop below ----
+
+drop table if exists webhook_delivery;
@@ -0,0 +1,7 @@
+alter table webhook_delivery add column payload text check (payload <> '');
+alter table webhook_delivery add column content_type text check (content_type <> '');
+
+---- create above / drop below ----
+
+alter table webhook_delivery drop column payload;
+alter table webhook_delivery drop column content_type;
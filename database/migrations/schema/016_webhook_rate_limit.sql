@@ -0,0 +1,5 @@
+alter table webhook add column rate_limit integer check (rate_limit > 0);
+
+---- create above / drop below ----
+
+alter table webhook drop column rate_limit;
@@ -0,0 +1,5 @@
+alter table webhook add column headers jsonb;
+
+---- create above / drop below ----
+
+alter table webhook drop column headers;
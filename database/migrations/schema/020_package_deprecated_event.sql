@@ -0,0 +1,5 @@
+insert into event_kind values (5, 'Package deprecated');
+
+---- create above / drop below ----
+
+delete from event_kind where event_kind_id = 5;
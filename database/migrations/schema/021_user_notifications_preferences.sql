@@ -0,0 +1,5 @@
+alter table "user" add column notifications_preferences jsonb;
+
+---- create above / drop below ----
+
+alter table "user" drop column notifications_preferences;
@@ -0,0 +1,13 @@
+alter table webhook add column consecutive_failures integer not null default 0;
+alter table webhook add column suspended_at timestamptz;
+
+insert into event_kind values (6, 'Webhook suspended');
+
+drop function if exists register_webhook_delivery(jsonb);
+
+---- create above / drop below ----
+
+delete from event_kind where event_kind_id = 6;
+
+alter table webhook drop column suspended_at;
+alter table webhook drop column consecutive_failures;
@@ -0,0 +1,5 @@
+alter table webhook add column batch boolean not null default false;
+
+---- create above / drop below ----
+
+alter table webhook drop column batch;
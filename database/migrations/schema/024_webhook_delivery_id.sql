@@ -0,0 +1,5 @@
+alter table webhook_delivery add column delivery_id text check (delivery_id <> '');
+
+---- create above / drop below ----
+
+alter table webhook_delivery drop column delivery_id;
@@ -0,0 +1,17 @@
+create or replace function notify_notifications_created()
+returns trigger as $$
+begin
+    perform pg_notify('notification_created', '');
+    return null;
+end
+$$ language plpgsql;
+
+create trigger trigger_notifications_created
+after insert on notification
+for each statement
+execute function notify_notifications_created();
+
+---- create above / drop below ----
+
+drop trigger if exists trigger_notifications_created on notification;
+drop function if exists notify_notifications_created;
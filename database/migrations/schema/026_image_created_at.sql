@@ -0,0 +1,5 @@
+alter table image add column created_at timestamptz default current_timestamp not null;
+
+---- create above / drop below ----
+
+alter table image drop column created_at;
@@ -0,0 +1,12 @@
+alter table "user" add column deletion_scheduled_at timestamptz;
+
+create table if not exists delete_user_code (
+    delete_user_code_id bytea primary key,
+    user_id uuid not null unique references "user" on delete cascade,
+    created_at timestamptz default current_timestamp not null
+);
+
+---- create above / drop below ----
+
+drop table if exists delete_user_code;
+alter table "user" drop column deletion_scheduled_at;
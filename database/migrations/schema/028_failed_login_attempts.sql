@@ -0,0 +1,13 @@
+create table if not exists failed_login_attempt (
+    failed_login_attempt_id uuid primary key default gen_random_uuid(),
+    email text not null check (email <> ''),
+    ip inet,
+    created_at timestamptz default current_timestamp not null
+);
+
+create index failed_login_attempt_email_idx on failed_login_attempt (email);
+create index failed_login_attempt_ip_idx on failed_login_attempt (ip);
+
+---- create above / drop below ----
+
+drop table if exists failed_login_attempt;
@@ -0,0 +1,5 @@
+alter table api_key add column scopes text[];
+
+---- create above / drop below ----
+
+alter table api_key drop column scopes;
@@ -0,0 +1,16 @@
+create table if not exists audit_log (
+    audit_log_id uuid primary key default gen_random_uuid(),
+    action text not null check (action <> ''),
+    user_id uuid references "user" on delete set null,
+    organization_id uuid references organization on delete cascade,
+    ip inet,
+    details jsonb,
+    created_at timestamptz default current_timestamp not null
+);
+
+create index audit_log_user_id_idx on audit_log (user_id);
+create index audit_log_organization_id_idx on audit_log (organization_id);
+
+---- create above / drop below ----
+
+drop table if exists audit_log;
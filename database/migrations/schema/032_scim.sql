@@ -0,0 +1,5 @@
+alter table "user" add column disabled boolean not null default false;
+
+---- create above / drop below ----
+
+alter table "user" drop column disabled;
@@ -0,0 +1,10 @@
+create table if not exists email_change_code (
+    email_change_code_id bytea primary key,
+    user_id uuid not null unique references "user" on delete cascade,
+    new_email text not null check (new_email <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+---- create above / drop below ----
+
+drop table if exists email_change_code;
@@ -0,0 +1,5 @@
+alter table "user" add column site_admin boolean not null default false;
+
+---- create above / drop below ----
+
+alter table "user" drop column site_admin;
@@ -0,0 +1,6 @@
+alter table "user" add column service_account_organization_id uuid references organization on delete cascade;
+create index user_service_account_organization_id_idx on "user" (service_account_organization_id);
+
+---- create above / drop below ----
+
+alter table "user" drop column service_account_organization_id;
@@ -0,0 +1,71 @@
+alter table "user" add column password_changed_at timestamptz;
+update "user" set password_changed_at = current_timestamp where password is not null;
+
+create table if not exists password_history (
+    password_history_id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references "user" on delete cascade,
+    password text not null check (password <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+create index password_history_user_id_idx on password_history (user_id);
+
+insert into password_history (user_id, password)
+select user_id, password from "user" where password is not null;
+
+-- Keep track of when the password of a user was last changed
+create or replace function set_user_password_changed_at()
+returns trigger as $$
+begin
+    if tg_op = 'INSERT' or new.password is distinct from old.password then
+        new.password_changed_at = current_timestamp;
+    end if;
+    return new;
+end
+$$ language plpgsql;
+
+create trigger trigger_user_password_changed_at
+before insert or update of password on "user"
+for each row
+execute function set_user_password_changed_at();
+
+-- Register the passwords set by users in their passwords history, keeping up
+-- to the 24 most recent ones
+create or replace function register_user_password_history()
+returns trigger as $$
+begin
+    if new.password is null then
+        return null;
+    end if;
+    if tg_op = 'UPDATE' and new.password is not distinct from old.password then
+        return null;
+    end if;
+
+    insert into password_history (user_id, password) values (new.user_id, new.password);
+    delete from password_history
+    where user_id = new.user_id
+    and password_history_id not in (
+        select password_history_id
+        from password_history
+        where user_id = new.user_id
+        order by created_at desc
+        limit 24
+    );
+
+    return null;
+end
+$$ language plpgsql;
+
+create trigger trigger_user_password_history
+after insert or update of password on "user"
+for each row
+execute function register_user_password_history();
+
+---- create above / drop below ----
+
+drop trigger if exists trigger_user_password_history on "user";
+drop function if exists register_user_password_history;
+drop trigger if exists trigger_user_password_changed_at on "user";
+drop function if exists set_user_password_changed_at;
+drop table if exists password_history;
+alter table "user" drop column password_changed_at;
@@ -0,0 +1,21 @@
+create table if not exists user_device (
+    user_id uuid not null references "user" on delete cascade,
+    device_hash bytea not null,
+    created_at timestamptz default current_timestamp not null,
+    primary key (user_id, device_hash)
+);
+
+alter table session add column revocation_code bytea unique;
+
+-- Devices used by the current sessions are considered known devices
+insert into user_device (user_id, device_hash)
+select distinct
+    user_id,
+    sha512(convert_to(coalesce(host(ip), '') || '|' || coalesce(user_agent, ''), 'utf8'))
+from session
+on conflict do nothing;
+
+---- create above / drop below ----
+
+alter table session drop column revocation_code;
+drop table if exists user_device;
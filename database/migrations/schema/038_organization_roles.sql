@@ -0,0 +1,36 @@
+create table if not exists organization_role (
+    role text primary key,
+    display_name text not null,
+    allowed_actions text[] not null default '{}'
+);
+
+insert into organization_role (role, display_name, allowed_actions) values
+    ('owner', 'Owner', '{all}'),
+    ('admin', 'Admin', '{
+        addOrganizationMember,
+        addOrganizationRepository,
+        deleteOrganizationMember,
+        deleteOrganizationRepository,
+        getAuditLog,
+        getAuthorizationPolicy,
+        transferOrganizationRepository,
+        updateOrganization,
+        updateOrganizationRepository
+    }'),
+    ('member', 'Member', '{
+        addOrganizationRepository,
+        updateOrganizationRepository
+    }'),
+    ('viewer', 'Viewer', '{}');
+
+alter table user__organization
+    add column role text not null default 'member' references organization_role on delete restrict;
+
+-- Existing members were allowed to perform all actions in the organizations
+-- they belong to, so they become owners to keep their current privileges
+update user__organization set role = 'owner';
+
+---- create above / drop below ----
+
+alter table user__organization drop column role;
+drop table if exists organization_role;
@@ -0,0 +1,40 @@
+create table if not exists team (
+    team_id uuid primary key default gen_random_uuid(),
+    organization_id uuid not null references organization on delete cascade,
+    name text not null check (name <> ''),
+    display_name text check (display_name <> ''),
+    description text check (description <> ''),
+    created_at timestamptz default current_timestamp not null,
+    unique (organization_id, name)
+);
+
+create table if not exists user__team (
+    user_id uuid not null references "user" on delete cascade,
+    team_id uuid not null references team on delete cascade,
+    primary key (user_id, team_id)
+);
+
+create index user__team_team_id_idx on user__team (team_id);
+
+create table if not exists team__repository (
+    team_id uuid not null references team on delete cascade,
+    repository_id uuid not null references repository on delete cascade,
+    allowed_actions text[] not null,
+    primary key (team_id, repository_id)
+);
+
+create index team__repository_repository_id_idx on team__repository (repository_id);
+
+update organization_role
+set allowed_actions = array_append(allowed_actions, 'manageOrganizationTeams')
+where role = 'admin';
+
+---- create above / drop below ----
+
+update organization_role
+set allowed_actions = array_remove(allowed_actions, 'manageOrganizationTeams')
+where role = 'admin';
+
+drop table if exists team__repository;
+drop table if exists user__team;
+drop table if exists team;
@@ -0,0 +1,7 @@
+drop function if exists get_organization_audit_log(uuid, text, int, int);
+
+create index audit_log_action_idx on audit_log (action);
+
+---- create above / drop below ----
+
+drop index if exists audit_log_action_idx;
@@ -0,0 +1,33 @@
+create table if not exists organization_name_alias (
+    name text primary key check (name <> ''),
+    organization_id uuid not null references organization on delete cascade,
+    created_at timestamptz default current_timestamp not null
+);
+
+create index organization_name_alias_organization_id_idx on organization_name_alias (organization_id);
+
+-- Keep track of the previous names of organizations, so that they can still
+-- be resolved after the organization has been renamed. Aliases never take
+-- precedence over the actual names of the organizations.
+create or replace function register_organization_name_alias()
+returns trigger as $$
+begin
+    delete from organization_name_alias where name = new.name;
+    if tg_op = 'UPDATE' and new.name <> old.name then
+        insert into organization_name_alias (name, organization_id)
+        values (old.name, new.organization_id);
+    end if;
+    return null;
+end
+$$ language plpgsql;
+
+create trigger trigger_organization_name_alias
+after insert or update of name on organization
+for each row
+execute function register_organization_name_alias();
+
+---- create above / drop below ----
+
+drop trigger if exists trigger_organization_name_alias on organization;
+drop function if exists register_organization_name_alias;
+drop table if exists organization_name_alias;
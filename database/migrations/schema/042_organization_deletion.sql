@@ -0,0 +1,7 @@
+alter table organization add column deletion_scheduled_at timestamptz;
+
+drop function if exists delete_organization(uuid, text);
+
+---- create above / drop below ----
+
+alter table organization drop column deletion_scheduled_at;
@@ -0,0 +1,6 @@
+alter table webhook add column all_packages boolean not null default false;
+alter table webhook add constraint webhook_all_packages_check check (all_packages = false or organization_id is not null);
+
+---- create above / drop below ----
+
+alter table webhook drop column all_packages;
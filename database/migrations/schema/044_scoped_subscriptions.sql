@@ -0,0 +1,27 @@
+create table if not exists repository_subscription (
+    user_id uuid not null references "user" on delete cascade,
+    repository_id uuid not null references repository on delete cascade,
+    event_kind_id integer not null references event_kind on delete restrict,
+    primary key (user_id, repository_id, event_kind_id)
+);
+
+create index repository_subscription_repository_id_idx on repository_subscription (repository_id);
+
+create table if not exists publisher_subscription (
+    publisher_subscription_id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references "user" on delete cascade,
+    organization_id uuid references organization on delete cascade,
+    publisher_user_id uuid references "user" on delete cascade,
+    event_kind_id integer not null references event_kind on delete restrict,
+    check ((organization_id is null) <> (publisher_user_id is null)),
+    constraint publisher_subscription_organization_key unique (user_id, organization_id, event_kind_id),
+    constraint publisher_subscription_publisher_user_key unique (user_id, publisher_user_id, event_kind_id)
+);
+
+create index publisher_subscription_organization_id_idx on publisher_subscription (organization_id);
+create index publisher_subscription_publisher_user_id_idx on publisher_subscription (publisher_user_id);
+
+---- create above / drop below ----
+
+drop table if exists publisher_subscription;
+drop table if exists repository_subscription;
@@ -0,0 +1,9 @@
+insert into event_kind values (7, 'New package');
+
+---- create above / drop below ----
+
+delete from event where event_kind_id = 7;
+delete from repository_subscription where event_kind_id = 7;
+delete from publisher_subscription where event_kind_id = 7;
+delete from webhook__event_kind where event_kind_id = 7;
+delete from event_kind where event_kind_id = 7;
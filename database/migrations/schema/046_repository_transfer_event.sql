@@ -0,0 +1,7 @@
+insert into event_kind values (8, 'Repository ownership transfer');
+
+---- create above / drop below ----
+
+delete from event where event_kind_id = 8;
+delete from opt_out where event_kind_id = 8;
+delete from event_kind where event_kind_id = 8;
@@ -0,0 +1,7 @@
+alter table repository add column tracking_enabled boolean not null default true;
+alter table repository add column last_successful_tracking_ts timestamptz;
+
+---- create above / drop below ----
+
+alter table repository drop column last_successful_tracking_ts;
+alter table repository drop column tracking_enabled;
@@ -0,0 +1,7 @@
+alter table repository add column tracking_webhook_secret text check (tracking_webhook_secret <> '');
+alter table repository add column tracking_requested_at timestamptz;
+
+---- create above / drop below ----
+
+alter table repository drop column tracking_requested_at;
+alter table repository drop column tracking_webhook_secret;
@@ -0,0 +1,17 @@
+create table if not exists repository_tracking_run (
+    repository_tracking_run_id uuid primary key default gen_random_uuid(),
+    repository_id uuid not null references repository on delete cascade,
+    status text not null check (status in ('queued', 'running', 'completed')),
+    packages_total integer not null default 0,
+    packages_processed integer not null default 0,
+    errors text check (errors <> ''),
+    created_at timestamptz default current_timestamp not null,
+    started_at timestamptz,
+    finished_at timestamptz
+);
+
+create index repository_tracking_run_repository_id_idx on repository_tracking_run (repository_id);
+
+---- create above / drop below ----
+
+drop table if exists repository_tracking_run;
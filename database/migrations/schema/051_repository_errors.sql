@@ -0,0 +1,53 @@
+drop function if exists set_last_scanning_results(uuid, text, boolean);
+drop function if exists set_last_tracking_results(uuid, text, boolean);
+
+alter table repository rename column last_scanning_errors to last_scanning_errors_text;
+alter table repository add column last_scanning_errors jsonb;
+update repository set last_scanning_errors = (
+    select jsonb_agg(jsonb_build_object(
+        'class', 'unknown',
+        'message', e.message,
+        'ts', floor(extract(epoch from last_scanning_ts))
+    ) order by e.n)
+    from regexp_split_to_table(last_scanning_errors_text, '\n') with ordinality as e(message, n)
+    where e.message <> ''
+)
+where last_scanning_errors_text is not null;
+alter table repository drop column last_scanning_errors_text;
+
+alter table repository rename column last_tracking_errors to last_tracking_errors_text;
+alter table repository add column last_tracking_errors jsonb;
+update repository set last_tracking_errors = (
+    select jsonb_agg(jsonb_build_object(
+        'class', 'unknown',
+        'message', e.message,
+        'ts', floor(extract(epoch from last_tracking_ts))
+    ) order by e.n)
+    from regexp_split_to_table(last_tracking_errors_text, '\n') with ordinality as e(message, n)
+    where e.message <> ''
+)
+where last_tracking_errors_text is not null;
+alter table repository drop column last_tracking_errors_text;
+
+---- create above / drop below ----
+
+drop function if exists set_last_scanning_results(uuid, jsonb, boolean);
+drop function if exists set_last_tracking_results(uuid, jsonb, boolean);
+
+alter table repository rename column last_scanning_errors to last_scanning_errors_json;
+alter table repository add column last_scanning_errors text;
+update repository set last_scanning_errors = (
+    select string_agg(e.error->>'message', E'\n' order by e.n)
+    from jsonb_array_elements(last_scanning_errors_json) with ordinality as e(error, n)
+)
+where last_scanning_errors_json is not null;
+alter table repository drop column last_scanning_errors_json;
+
+alter table repository rename column last_tracking_errors to last_tracking_errors_json;
+alter table repository add column last_tracking_errors text;
+update repository set last_tracking_errors = (
+    select string_agg(e.error->>'message', E'\n' order by e.n)
+    from jsonb_array_elements(last_tracking_errors_json) with ordinality as e(error, n)
+)
+where last_tracking_errors_json is not null;
+alter table repository drop column last_tracking_errors_json;
@@ -0,0 +1,5 @@
+alter table repository add column tls_config text check (tls_config <> '');
+
+---- create above / drop below ----
+
+alter table repository drop column tls_config;
@@ -0,0 +1,5 @@
+alter table repository add column auth_pass_ref text check (auth_pass_ref <> '');
+
+---- create above / drop below ----
+
+alter table repository drop column auth_pass_ref;
@@ -0,0 +1,5 @@
+alter table repository add column proxy_url text check (proxy_url <> '');
+
+---- create above / drop below ----
+
+alter table repository drop column proxy_url;
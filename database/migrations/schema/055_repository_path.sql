@@ -0,0 +1,5 @@
+alter table repository add column path text check (path <> '');
+
+---- create above / drop below ----
+
+alter table repository drop column path;
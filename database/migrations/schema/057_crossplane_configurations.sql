@@ -0,0 +1,5 @@
+insert into repository_kind values (10, 'Crossplane configurations');
+
+---- create above / drop below ----
+
+delete from repository_kind where repository_kind_id = 10;
@@ -0,0 +1,5 @@
+insert into repository_kind values (11, 'OCI artifacts');
+
+---- create above / drop below ----
+
+delete from repository_kind where repository_kind_id = 11;
@@ -0,0 +1,5 @@
+alter table snapshot add column sboms jsonb;
+
+---- create above / drop below ----
+
+alter table snapshot drop column sboms;
@@ -0,0 +1,12 @@
+create table if not exists package_vulnerability_suppression (
+    package_id uuid not null references package on delete cascade,
+    vulnerability_id text not null check (vulnerability_id <> ''),
+    justification text not null check (justification <> ''),
+    user_id uuid references "user" on delete set null,
+    created_at timestamptz default current_timestamp not null,
+    primary key (package_id, vulnerability_id)
+);
+
+---- create above / drop below ----
+
+drop table if exists package_vulnerability_suppression;
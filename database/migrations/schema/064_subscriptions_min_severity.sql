@@ -0,0 +1,15 @@
+alter table subscription add column min_severity text
+    check (min_severity in ('low', 'medium', 'high', 'critical'))
+    check (min_severity is null or event_kind_id = 1);
+alter table repository_subscription add column min_severity text
+    check (min_severity in ('low', 'medium', 'high', 'critical'))
+    check (min_severity is null or event_kind_id = 1);
+alter table publisher_subscription add column min_severity text
+    check (min_severity in ('low', 'medium', 'high', 'critical'))
+    check (min_severity is null or event_kind_id = 1);
+
+---- create above / drop below ----
+
+alter table subscription drop column min_severity;
+alter table repository_subscription drop column min_severity;
+alter table publisher_subscription drop column min_severity;
@@ -0,0 +1,5 @@
+alter table repository add column retention_policy jsonb;
+
+---- create above / drop below ----
+
+alter table repository drop column retention_policy;
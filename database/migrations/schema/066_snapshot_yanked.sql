@@ -0,0 +1,7 @@
+alter table snapshot add column yanked boolean not null default false;
+alter table snapshot add column yanked_reason text check (yanked_reason <> '');
+
+---- create above / drop below ----
+
+alter table snapshot drop column yanked_reason;
+alter table snapshot drop column yanked;
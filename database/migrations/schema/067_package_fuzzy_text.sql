@@ -0,0 +1,14 @@
+create extension if not exists pg_trgm;
+
+alter table package add column fuzzy_text text;
+update package p set fuzzy_text = concat_ws(' ', p.name, s.display_name, array_to_string(s.keywords, ' '))
+from snapshot s
+where s.package_id = p.package_id
+and s.version = p.latest_version;
+create index package_fuzzy_text_idx on package using gin (fuzzy_text gin_trgm_ops);
+
+---- create above / drop below ----
+
+drop index if exists package_fuzzy_text_idx;
+alter table package drop column fuzzy_text;
+drop extension if exists pg_trgm;
@@ -0,0 +1,31 @@
+create table if not exists saved_search (
+    saved_search_id uuid primary key default gen_random_uuid(),
+    user_id uuid not null references "user" on delete cascade,
+    name text not null check (name <> ''),
+    query jsonb not null,
+    notifications_enabled boolean not null default false,
+    created_at timestamptz default current_timestamp not null,
+    last_evaluated_at timestamptz,
+    unique (user_id, name)
+);
+
+create table if not exists saved_search_match (
+    saved_search_id uuid not null references saved_search on delete cascade,
+    package_id uuid not null references package on delete cascade,
+    baseline boolean not null default false,
+    seen boolean not null default false,
+    created_at timestamptz default current_timestamp not null,
+    primary key (saved_search_id, package_id)
+);
+
+create index saved_search_match_package_id_idx on saved_search_match (package_id);
+
+insert into event_kind values (9, 'Saved search new matches');
+
+---- create above / drop below ----
+
+delete from event where event_kind_id = 9;
+delete from event_kind where event_kind_id = 9;
+
+drop table if exists saved_search_match;
+drop table if exists saved_search;
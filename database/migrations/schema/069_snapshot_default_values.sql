@@ -0,0 +1,5 @@
+alter table snapshot add column default_values text;
+
+---- create above / drop below ----
+
+alter table snapshot drop column if exists default_values;
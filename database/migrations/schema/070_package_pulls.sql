@@ -0,0 +1,19 @@
+create table if not exists package_pulls (
+    package_id uuid not null references package on delete cascade,
+    day date not null,
+    pulls bigint not null check (pulls >= 0),
+    primary key (package_id, day)
+);
+
+create index package_pulls_day_idx on package_pulls (day);
+
+create table if not exists package_pulls_total (
+    package_id uuid primary key references package on delete cascade,
+    total bigint not null check (total >= 0),
+    updated_at timestamptz default current_timestamp not null
+);
+
+---- create above / drop below ----
+
+drop table if exists package_pulls_total;
+drop table if exists package_pulls;
@@ -0,0 +1,34 @@
+create table if not exists package_review (
+    package_review_id uuid primary key default gen_random_uuid(),
+    package_id uuid not null references package on delete cascade,
+    user_id uuid not null references "user" on delete cascade,
+    rating smallint not null check (rating between 1 and 5),
+    title text check (title <> ''),
+    body text check (body <> ''),
+    reply text check (reply <> ''),
+    replied_at timestamptz,
+    hidden boolean not null default false,
+    created_at timestamptz default current_timestamp not null,
+    updated_at timestamptz default current_timestamp not null,
+    unique (package_id, user_id)
+);
+
+create index package_review_user_id_idx on package_review (user_id);
+
+create table if not exists package_review_report (
+    package_review_id uuid not null references package_review on delete cascade,
+    user_id uuid not null references "user" on delete cascade,
+    reason text not null check (reason <> ''),
+    created_at timestamptz default current_timestamp not null,
+    primary key (package_review_id, user_id)
+);
+
+alter table package add column rating numeric(3, 2);
+alter table package add column ratings integer not null default 0;
+
+---- create above / drop below ----
+
+alter table package drop column if exists ratings;
+alter table package drop column if exists rating;
+drop table if exists package_review_report;
+drop table if exists package_review;
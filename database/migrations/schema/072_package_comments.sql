@@ -0,0 +1,24 @@
+create table if not exists package_comment (
+    package_comment_id uuid primary key default gen_random_uuid(),
+    package_id uuid not null references package on delete cascade,
+    parent_id uuid references package_comment on delete cascade,
+    user_id uuid references "user" on delete set null,
+    body text not null check (body <> ''),
+    hidden boolean not null default false,
+    created_at timestamptz default current_timestamp not null,
+    updated_at timestamptz default current_timestamp not null
+);
+
+create index package_comment_package_id_idx on package_comment (package_id, created_at);
+create index package_comment_parent_id_idx on package_comment (parent_id);
+create index package_comment_user_id_idx on package_comment (user_id);
+
+insert into event_kind values (10, 'Package new question');
+
+---- create above / drop below ----
+
+delete from event where event_kind_id = 10;
+delete from opt_out where event_kind_id = 10;
+delete from event_kind where event_kind_id = 10;
+
+drop table if exists package_comment;
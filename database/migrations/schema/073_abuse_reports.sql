@@ -0,0 +1,23 @@
+create table if not exists abuse_report (
+    abuse_report_id uuid primary key default gen_random_uuid(),
+    package_id uuid references package on delete cascade,
+    repository_id uuid references repository on delete cascade,
+    user_id uuid references "user" on delete set null,
+    reason text not null check (reason in ('spam', 'malware', 'impersonation', 'inappropriate', 'other')),
+    description text check (description <> ''),
+    status text not null default 'pending' check (status in ('pending', 'investigating', 'resolved', 'dismissed')),
+    notes text check (notes <> ''),
+    triaged_by uuid references "user" on delete set null,
+    created_at timestamptz default current_timestamp not null,
+    updated_at timestamptz default current_timestamp not null,
+    check ((package_id is null) <> (repository_id is null))
+);
+
+create index abuse_report_status_idx on abuse_report (status, created_at);
+create index abuse_report_package_id_idx on abuse_report (package_id);
+create index abuse_report_repository_id_idx on abuse_report (repository_id);
+create index abuse_report_user_id_idx on abuse_report (user_id, created_at);
+
+---- create above / drop below ----
+
+drop table if exists abuse_report;
@@ -0,0 +1,20 @@
+create table if not exists repository_ownership_claim (
+    repository_ownership_claim_id uuid primary key default gen_random_uuid(),
+    repository_id uuid not null references repository on delete cascade,
+    user_id uuid not null references "user" on delete cascade,
+    organization_id uuid references organization on delete cascade,
+    method text not null check (method in ('dns', 'http')),
+    token text not null check (token <> ''),
+    status text not null default 'pending' check (status in ('pending', 'verified', 'expired')),
+    last_error text,
+    checked_at timestamptz,
+    created_at timestamptz default current_timestamp not null,
+    unique (repository_id, user_id)
+);
+
+create index repository_ownership_claim_status_idx on repository_ownership_claim (status, checked_at);
+create index repository_ownership_claim_user_id_idx on repository_ownership_claim (user_id);
+
+---- create above / drop below ----
+
+drop table if exists repository_ownership_claim;
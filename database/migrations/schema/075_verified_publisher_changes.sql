@@ -0,0 +1,21 @@
+create table if not exists verified_publisher_change (
+    verified_publisher_change_id uuid primary key default gen_random_uuid(),
+    repository_id uuid not null references repository on delete cascade,
+    user_id uuid references "user" on delete set null,
+    source text not null check (source in ('metadata', 'admin')),
+    verified boolean not null,
+    evidence text check (evidence <> ''),
+    created_at timestamptz default current_timestamp not null
+);
+
+create index verified_publisher_change_repository_id_idx on verified_publisher_change (repository_id, created_at);
+create index verified_publisher_change_user_id_idx on verified_publisher_change (user_id);
+
+insert into event_kind values (11, 'Repository verified publisher change');
+
+---- create above / drop below ----
+
+delete from event where event_kind_id = 11;
+delete from opt_out where event_kind_id = 11;
+delete from event_kind where event_kind_id = 11;
+drop table if exists verified_publisher_change;
@@ -0,0 +1,7 @@
+alter table webhook add column rate_limit_window_start timestamptz;
+alter table webhook add column rate_limit_window_deliveries integer not null default 0;
+
+---- create above / drop below ----
+
+alter table webhook drop column rate_limit_window_start;
+alter table webhook drop column rate_limit_window_deliveries;
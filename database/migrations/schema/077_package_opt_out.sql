@@ -0,0 +1,11 @@
+create table if not exists package_opt_out (
+    user_id uuid not null references "user" on delete cascade,
+    package_id uuid not null references package on delete cascade,
+    event_kind_id integer not null references event_kind on delete restrict,
+    created_at timestamptz default current_timestamp not null,
+    primary key (user_id, package_id, event_kind_id)
+);
+
+---- create above / drop below ----
+
+drop table if exists package_opt_out;
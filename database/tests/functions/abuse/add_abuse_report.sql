@@ -0,0 +1,91 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select add_abuse_report('00000000-0000-0000-0000-000000000002', '{"package_id": "00000000-0000-0000-0000-000000000009", "reason": "spam"}') $$,
+    'P0002',
+    'no_data_found',
+    'Packages that do not exist cannot be reported'
+);
+select add_abuse_report(:'user2ID', jsonb_build_object(
+    'package_id', :'package1ID',
+    'reason', 'malware',
+    'description', 'Ships a crypto miner'
+));
+select results_eq(
+    $$
+        select package_id, repository_id, user_id, reason, description, status
+        from abuse_report
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            null::uuid,
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            'malware',
+            'Ships a crypto miner',
+            'pending'
+        )
+    $$,
+    'Package report should be registered as pending'
+);
+select add_abuse_report(:'user2ID', jsonb_build_object(
+    'package_id', :'package1ID',
+    'reason', 'spam'
+));
+select is(
+    (select count(*) from abuse_report)::int,
+    1,
+    'Repeated report on a target with an open report from the same user should be ignored'
+);
+update abuse_report set status = 'dismissed';
+select add_abuse_report(:'user2ID', jsonb_build_object(
+    'package_id', :'package1ID',
+    'reason', 'spam'
+));
+select is(
+    (select count(*) from abuse_report)::int,
+    1,
+    'Repeated report on a target recently dismissed should be ignored'
+);
+select add_abuse_report(:'user2ID', jsonb_build_object(
+    'repository_id', :'repo1ID',
+    'reason', 'impersonation'
+));
+select is(
+    (select count(*) from abuse_report where repository_id = :'repo1ID')::int,
+    1,
+    'Repository report should be registered'
+);
+insert into abuse_report (repository_id, user_id, reason, status)
+select :'repo1ID', :'user1ID', 'spam', 'resolved'
+from generate_series(1, 10);
+select add_abuse_report(:'user1ID', jsonb_build_object(
+    'package_id', :'package1ID',
+    'reason', 'spam'
+));
+select is(
+    (select count(*) from abuse_report where package_id = :'package1ID' and user_id = :'user1ID')::int,
+    0,
+    'Report should be ignored as the user has exceeded the daily reports limit'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
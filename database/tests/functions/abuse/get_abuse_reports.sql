@@ -0,0 +1,85 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set report1ID '00000000-0000-0000-0000-000000000001'
+\set report2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into abuse_report (abuse_report_id, package_id, user_id, reason, description, created_at, updated_at)
+values (:'report1ID', :'package1ID', :'user2ID', 'malware', 'Ships a crypto miner', '2021-05-01 10:00:00+00', '2021-05-01 10:00:00+00');
+insert into abuse_report (abuse_report_id, repository_id, user_id, reason, status, notes, triaged_by, created_at, updated_at)
+values (:'report2ID', :'repo1ID', :'user2ID', 'spam', 'dismissed', 'Not spam', :'user1ID', '2021-05-02 10:00:00+00', '2021-05-03 10:00:00+00');
+
+-- Run some tests
+select throws_ok(
+    $$ select get_abuse_reports('00000000-0000-0000-0000-000000000002', '{}') $$,
+    42501,
+    'insufficient_privilege',
+    'Abuse reports should not be returned because requesting user is not a site administrator'
+);
+select is(
+    get_abuse_reports(:'user1ID', '{}')::jsonb,
+    '{
+        "reports": [
+            {
+                "abuse_report_id": "00000000-0000-0000-0000-000000000001",
+                "reason": "malware",
+                "description": "Ships a crypto miner",
+                "status": "pending",
+                "reporter_alias": "user2",
+                "package": {
+                    "package_id": "00000000-0000-0000-0000-000000000001",
+                    "name": "package1",
+                    "repository_name": "repo1",
+                    "repository_kind_id": 0
+                },
+                "open_reports_on_target": 1,
+                "created_at": 1619863200,
+                "updated_at": 1619863200
+            },
+            {
+                "abuse_report_id": "00000000-0000-0000-0000-000000000002",
+                "reason": "spam",
+                "status": "dismissed",
+                "notes": "Not spam",
+                "reporter_alias": "user2",
+                "triaged_by_alias": "user1",
+                "repository": {
+                    "repository_id": "00000000-0000-0000-0000-000000000001",
+                    "name": "repo1",
+                    "kind": 0
+                },
+                "open_reports_on_target": 0,
+                "created_at": 1619949600,
+                "updated_at": 1620036000
+            }
+        ],
+        "metadata": {
+            "limit": 20,
+            "offset": 0,
+            "total": 2
+        }
+    }'::jsonb,
+    'All abuse reports should be returned, oldest first'
+);
+select is(
+    get_abuse_reports(:'user1ID', '{"status": "dismissed"}')::jsonb->'metadata'->'total',
+    '1'::jsonb,
+    'Only dismissed abuse reports should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,47 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set report1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin) values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into abuse_report (abuse_report_id, repository_id, user_id, reason)
+values (:'report1ID', :'repo1ID', :'user2ID', 'spam');
+
+-- Run some tests
+select throws_ok(
+    $$ select triage_abuse_report('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '{"status": "dismissed"}') $$,
+    42501,
+    'insufficient_privilege',
+    'Abuse report should not be triaged because requesting user is not a site administrator'
+);
+select throws_ok(
+    $$ select triage_abuse_report('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000009', '{"status": "dismissed"}') $$,
+    'P0002',
+    'no_data_found',
+    'Abuse reports that do not exist cannot be triaged'
+);
+select triage_abuse_report(:'user1ID', :'report1ID', '{"status": "investigating", "notes": "Checking"}');
+select results_eq(
+    $$
+        select status, notes, triaged_by
+        from abuse_report
+        where abuse_report_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('investigating', 'Checking', '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Abuse report status and notes should be updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,48 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email, password)
+values (:'user2ID', 'user2', 'user2@email.com', 'password');
+insert into session (session_id, user_id) values ('session2', :'user2ID');
+
+-- Force user password reset should fail in the following cases
+select throws_ok(
+    $$ select force_user_password_reset('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001') $$,
+    42501,
+    'insufficient_privilege',
+    'Password reset should fail because requesting user is not a site administrator'
+);
+select throws_ok(
+    $$ select force_user_password_reset('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003') $$,
+    'P0002',
+    'no_data_found',
+    'Password reset should fail because user does not exist'
+);
+
+-- Force user password reset
+select is(
+    force_user_password_reset(:'user1ID', :'user2ID'),
+    'user2@email.com',
+    'User email should be returned'
+);
+select is(
+    (select password from "user" where user_id = :'user2ID'),
+    null,
+    'User password should have been invalidated'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000002' $$,
+    'User sessions should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
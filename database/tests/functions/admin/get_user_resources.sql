@@ -0,0 +1,87 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name)
+values (:'org1ID', 'org1', 'Organization 1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into webhook (webhook_id, name, url, user_id, active)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user2ID', true);
+insert into api_key (api_key_id, name, secret, user_id, created_at)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', :'user2ID', '2020-06-16 11:20:34+02');
+insert into session (session_id, user_id) values ('session1', :'user2ID');
+
+-- Get user resources should fail in the following cases
+select throws_ok(
+    $$ select get_user_resources('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001') $$,
+    42501,
+    'insufficient_privilege',
+    'Get user resources should fail because requesting user is not a site administrator'
+);
+select throws_ok(
+    $$ select get_user_resources('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003') $$,
+    'P0002',
+    'no_data_found',
+    'Get user resources should fail because user does not exist'
+);
+
+-- Run some tests
+select is(
+    get_user_resources(:'user1ID', :'user2ID')::jsonb,
+    '{
+        "organizations": [{
+            "name": "org1",
+            "display_name": "Organization 1",
+            "confirmed": true
+        }],
+        "repositories": [{
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "name": "repo1",
+            "display_name": "Repo 1",
+            "kind": 0,
+            "url": "https://repo1.com"
+        }],
+        "webhooks": [{
+            "webhook_id": "00000000-0000-0000-0000-000000000001",
+            "name": "webhook1",
+            "active": true
+        }],
+        "api_keys": [{
+            "api_key_id": "00000000-0000-0000-0000-000000000001",
+            "name": "apikey1",
+            "created_at": 1592299234
+        }],
+        "sessions": 1
+    }'::jsonb,
+    'Resources owned by user2 should be returned'
+);
+select is(
+    get_user_resources(:'user1ID', :'user1ID')::jsonb,
+    '{
+        "organizations": [],
+        "repositories": [],
+        "webhooks": [],
+        "api_keys": [],
+        "sessions": 0
+    }'::jsonb,
+    'Empty resources should be returned for user1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,57 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, verified_publisher)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID', false);
+insert into verified_publisher_change (repository_id, source, verified, created_at)
+values (:'repo1ID', 'metadata', true, '2021-05-01 10:00:00+00');
+insert into verified_publisher_change (repository_id, user_id, source, verified, evidence, created_at)
+values (:'repo1ID', :'user1ID', 'admin', false, 'Impersonation report', '2021-05-02 10:00:00+00');
+
+-- Run some tests
+select throws_ok(
+    $$ select get_verified_publisher_changes('00000000-0000-0000-0000-000000000002', 'repo1') $$,
+    42501,
+    'insufficient_privilege',
+    'Get changes should fail because requesting user is not a site administrator'
+);
+select is(
+    get_verified_publisher_changes(:'user1ID', 'repo1')::jsonb,
+    '{
+        "verified_publisher": false,
+        "changes": [
+            {
+                "verified": false,
+                "source": "admin",
+                "evidence": "Impersonation report",
+                "user_alias": "user1",
+                "created_at": 1619949600
+            },
+            {
+                "verified": true,
+                "source": "metadata",
+                "created_at": 1619863200
+            }
+        ]
+    }'::jsonb,
+    'Verified publisher status and changes should be returned'
+);
+select is_empty(
+    $$ select get_verified_publisher_changes('00000000-0000-0000-0000-000000000001', 'repo2') $$,
+    'Nothing should be returned for a repository that does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,51 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into session (session_id, user_id) values ('session1', :'user1ID');
+insert into session (session_id, user_id) values ('session2', :'user2ID');
+insert into api_key (api_key_id, name, secret, user_id)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', :'user2ID');
+
+-- Revoke user credentials should fail in the following cases
+select throws_ok(
+    $$ select revoke_user_credentials('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001') $$,
+    42501,
+    'insufficient_privilege',
+    'Credentials revocation should fail because requesting user is not a site administrator'
+);
+select throws_ok(
+    $$ select revoke_user_credentials('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003') $$,
+    'P0002',
+    'no_data_found',
+    'Credentials revocation should fail because user does not exist'
+);
+
+-- Revoke user credentials
+select revoke_user_credentials(:'user1ID', :'user2ID');
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000002' $$,
+    'User sessions should have been deleted'
+);
+select is_empty(
+    $$ select * from api_key where user_id = '00000000-0000-0000-0000-000000000002' $$,
+    'User API keys should have been deleted'
+);
+select isnt_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Other users sessions should have been kept'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,96 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some users
+insert into "user" (user_id, alias, email, first_name, site_admin, created_at)
+values (:'user1ID', 'user1', 'user1@email.com', 'John', true, '2020-06-16 11:20:34+02');
+insert into "user" (user_id, alias, email, email_verified, created_at)
+values (:'user2ID', 'user2', 'user2@email.com', true, '2020-06-16 11:20:34+02');
+insert into "user" (user_id, alias, email, disabled, created_at)
+values (:'user3ID', 'user3', 'other@email.com', true, '2020-06-16 11:20:34+02');
+
+-- Search users should fail if the requesting user is not a site administrator
+select throws_ok(
+    $$ select search_users('00000000-0000-0000-0000-000000000002', '{}') $$,
+    42501,
+    'insufficient_privilege',
+    'Users search should fail because requesting user is not a site administrator'
+);
+
+-- Run some tests
+select is(
+    search_users(:'user1ID', '{"limit": 2}')::jsonb,
+    '{
+        "users": [
+            {
+                "user_id": "00000000-0000-0000-0000-000000000001",
+                "alias": "user1",
+                "email": "user1@email.com",
+                "first_name": "John",
+                "email_verified": false,
+                "disabled": false,
+                "site_admin": true,
+                "created_at": 1592299234
+            },
+            {
+                "user_id": "00000000-0000-0000-0000-000000000002",
+                "alias": "user2",
+                "email": "user2@email.com",
+                "email_verified": true,
+                "disabled": false,
+                "site_admin": false,
+                "created_at": 1592299234
+            }
+        ],
+        "metadata": {
+            "limit": 2,
+            "offset": 0,
+            "total": 3
+        }
+    }'::jsonb,
+    'First two users and total count should be returned'
+);
+select is(
+    search_users(:'user1ID', '{"query": "OTHER@"}')::jsonb,
+    '{
+        "users": [
+            {
+                "user_id": "00000000-0000-0000-0000-000000000003",
+                "alias": "user3",
+                "email": "other@email.com",
+                "email_verified": false,
+                "disabled": true,
+                "site_admin": false,
+                "created_at": 1592299234
+            }
+        ],
+        "metadata": {
+            "limit": 20,
+            "offset": 0,
+            "total": 1
+        }
+    }'::jsonb,
+    'Users matching the query should be returned'
+);
+select is(
+    search_users(:'user1ID', '{"query": "nomatch"}')::jsonb,
+    '{
+        "users": [],
+        "metadata": {
+            "limit": 20,
+            "offset": 0,
+            "total": 0
+        }
+    }'::jsonb,
+    'No users should be returned when none match the query'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,62 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into session (session_id, user_id) values ('session2', :'user2ID');
+
+-- Update user disabled should fail in the following cases
+select throws_ok(
+    $$ select update_user_disabled('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', true) $$,
+    42501,
+    'insufficient_privilege',
+    'User disable should fail because requesting user is not a site administrator'
+);
+select throws_ok(
+    $$ select update_user_disabled('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', true) $$,
+    'P0001',
+    'site administrators cannot disable their own account',
+    'User disable should fail because site administrators cannot disable themselves'
+);
+select throws_ok(
+    $$ select update_user_disabled('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', true) $$,
+    'P0002',
+    'no_data_found',
+    'User disable should fail because user does not exist'
+);
+
+-- Disable user
+select update_user_disabled(:'user1ID', :'user2ID', true);
+select is(
+    (select disabled from "user" where user_id = :'user2ID'),
+    true,
+    'User should have been disabled'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000002' $$,
+    'User sessions should have been deleted'
+);
+
+-- Enable user
+select update_user_disabled(:'user1ID', :'user2ID', false);
+select is(
+    (select disabled from "user" where user_id = :'user2ID'),
+    false,
+    'User should have been enabled'
+);
+select isnt_empty(
+    $$ select * from "user" where user_id = '00000000-0000-0000-0000-000000000002' $$,
+    'User should still exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
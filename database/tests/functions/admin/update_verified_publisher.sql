@@ -0,0 +1,72 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, site_admin)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+
+-- Update verified publisher should fail in the following cases
+select throws_ok(
+    $$ select update_verified_publisher('00000000-0000-0000-0000-000000000002', 'repo1', true, 'evidence') $$,
+    42501,
+    'insufficient_privilege',
+    'Update should fail because requesting user is not a site administrator'
+);
+select throws_ok(
+    $$ select update_verified_publisher('00000000-0000-0000-0000-000000000001', 'repo2', true, 'evidence') $$,
+    'P0002',
+    'no_data_found',
+    'Update should fail because repository does not exist'
+);
+
+-- Verify publisher
+select update_verified_publisher(:'user1ID', 'repo1', true, 'Domain ownership checked');
+select is(
+    (select verified_publisher from repository where repository_id = :'repo1ID'),
+    true,
+    'Repository should be flagged as verified publisher'
+);
+select results_eq(
+    $$
+        select user_id, source, verified, evidence
+        from verified_publisher_change
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, 'admin', true, 'Domain ownership checked')
+    $$,
+    'Change should have been registered with the evidence provided'
+);
+select results_eq(
+    $$
+        select event_kind_id, data
+        from event
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (11, '{"verified": true, "source": "admin"}'::jsonb)
+    $$,
+    'Verified publisher change event should have been registered'
+);
+
+-- Metadata file changes are ignored once a site administrator has decided
+select set_verified_publisher(:'repo1ID', false);
+select is(
+    (select verified_publisher from repository where repository_id = :'repo1ID'),
+    true,
+    'Repository should still be flagged as verified publisher'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
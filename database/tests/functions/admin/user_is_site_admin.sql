@@ -0,0 +1,42 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some users
+insert into "user" (user_id, alias, email, site_admin)
+values (:'user1ID', 'user1', 'user1@email.com', true);
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email, site_admin, disabled)
+values (:'user3ID', 'user3', 'user3@email.com', true, true);
+
+-- Run some tests
+select is(
+    user_is_site_admin(:'user1ID'),
+    true,
+    'User1 is a site administrator'
+);
+select is(
+    user_is_site_admin(:'user2ID'),
+    false,
+    'User2 is not a site administrator'
+);
+select is(
+    user_is_site_admin(:'user3ID'),
+    false,
+    'User3 is disabled so it is not considered a site administrator'
+);
+select is(
+    user_is_site_admin('00000000-0000-0000-0000-000000000004'),
+    false,
+    'Non existing user is not a site administrator'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(1);
+select plan(2);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -22,18 +22,47 @@ select results_eq(
     $$
         select
             name,
-            user_id
+            user_id,
+            scopes
         from api_key
     $$,
     $$
         values (
             'apikey1',
-            '00000000-0000-0000-0000-000000000001'::uuid
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            null::text[]
         )
     $$,
     'Api key should exist'
 );
 
+-- Add api key with some scopes
+select add_api_key('
+{
+    "name": "apikey2",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "scopes": ["read-only", "webhooks:manage"]
+}
+'::jsonb);
+
+-- Check if api_key was added successfully with the scopes provided
+select results_eq(
+    $$
+        select
+            name,
+            scopes
+        from api_key
+        where name = 'apikey2'
+    $$,
+    $$
+        values (
+            'apikey2',
+            '{read-only,webhooks:manage}'::text[]
+        )
+    $$,
+    'Api key with scopes should exist'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
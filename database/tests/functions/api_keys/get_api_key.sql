@@ -9,8 +9,11 @@ select plan(2);
 -- Seed some data
 insert into "user" (user_id, alias, email)
 values (:'user1ID', 'user1', 'user1@email.com');
-insert into api_key (api_key_id, name, secret, created_at, user_id)
-values (:'apikey1ID', 'apikey1', 'hashedSecret', '2020-05-29 13:55:00+02', :'user1ID');
+insert into api_key (api_key_id, name, secret, created_at, user_id, scopes, last_used_at, last_used_ip)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', '2020-05-29 13:55:00+02', :'user1ID', '{repos:write,webhooks:manage}', '2020-06-01 10:00:00+02', '192.168.1.1');
+insert into api_key_usage (api_key_id, day, requests) values (:'apikey1ID', current_date, 5);
+insert into api_key_usage (api_key_id, day, requests) values (:'apikey1ID', current_date - 1, 10);
+insert into api_key_usage (api_key_id, day, requests) values (:'apikey1ID', current_date - 30, 100);
 
 -- Run some tests
 select is(
@@ -21,7 +24,11 @@ select is(
     '{
         "api_key_id": "00000000-0000-0000-0000-000000000001",
         "name": "apikey1",
-        "created_at": 1590753300
+        "created_at": 1590753300,
+        "scopes": ["repos:write", "webhooks:manage"],
+        "last_used_at": 1590998400,
+        "last_used_ip": "192.168.1.1",
+        "requests_last_30_days": 15
     }'::jsonb,
     'Api key should exist'
 );
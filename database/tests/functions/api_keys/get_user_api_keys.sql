@@ -17,8 +17,8 @@ insert into "user" (user_id, alias, email)
 values (:'user2ID', 'user2', 'user2@email.com');
 insert into api_key (api_key_id, name, secret, created_at, user_id)
 values (:'apikey1ID', 'apikey1', 'hashedSecret', '2020-05-29 13:55:00+02', :'user1ID');
-insert into api_key (api_key_id, name, secret, created_at, user_id)
-values (:'apikey2ID', 'apikey2', 'hashedSecret', '2020-05-29 13:55:00+02', :'user1ID');
+insert into api_key (api_key_id, name, secret, created_at, user_id, scopes)
+values (:'apikey2ID', 'apikey2', 'hashedSecret', '2020-05-29 13:55:00+02', :'user1ID', '{read-only}');
 insert into api_key (api_key_id, name, secret, created_at, user_id)
 values (:'apikey3ID', 'apikey3', 'hashedSecret', '2020-05-29 13:55:00+02', :'user2ID');
 
@@ -29,12 +29,20 @@ select is(
         {
             "api_key_id": "00000000-0000-0000-0000-000000000001",
             "name": "apikey1",
-            "created_at": 1590753300
+            "created_at": 1590753300,
+            "scopes": null,
+            "last_used_at": null,
+            "last_used_ip": null,
+            "requests_last_30_days": 0
         },
         {
             "api_key_id": "00000000-0000-0000-0000-000000000002",
             "name": "apikey2",
-            "created_at": 1590753300
+            "created_at": 1590753300,
+            "scopes": ["read-only"],
+            "last_used_at": null,
+            "last_used_ip": null,
+            "requests_last_30_days": 0
         }
     ]'::jsonb,
     'Api keys 1 and 2 should be returned'
@@ -45,7 +53,11 @@ select is(
         {
             "api_key_id": "00000000-0000-0000-0000-000000000003",
             "name": "apikey3",
-            "created_at": 1590753300
+            "created_at": 1590753300,
+            "scopes": null,
+            "last_used_at": null,
+            "last_used_ip": null,
+            "requests_last_30_days": 0
         }
     ]'::jsonb,
     'Api key 3 should be returned'
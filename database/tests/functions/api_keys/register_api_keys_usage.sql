@@ -0,0 +1,94 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+\set apikey2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into api_key (api_key_id, name, secret, user_id, last_used_at, last_used_ip)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', :'user1ID', '2020-06-01 10:00:00+02', '192.168.1.1');
+insert into api_key (api_key_id, name, secret, user_id)
+values (:'apikey2ID', 'apikey2', 'hashedSecret', :'user1ID');
+insert into api_key_usage (api_key_id, day, requests) values (:'apikey1ID', current_date, 5);
+insert into api_key_usage (api_key_id, day, requests) values (:'apikey1ID', current_date - 30, 100);
+
+-- Register some usage
+select register_api_keys_usage('[
+    {
+        "api_key_id": "00000000-0000-0000-0000-000000000001",
+        "last_used_at": 1590994800,
+        "last_used_ip": "192.168.1.2",
+        "requests": 1
+    },
+    {
+        "api_key_id": "00000000-0000-0000-0000-000000000002",
+        "last_used_at": 1590998400,
+        "last_used_ip": "192.168.1.3",
+        "requests": 3
+    },
+    {
+        "api_key_id": "00000000-0000-0000-0000-000000000003",
+        "last_used_at": 1590998400,
+        "last_used_ip": "192.168.1.4",
+        "requests": 7
+    }
+]');
+
+-- Run some tests
+select results_eq(
+    $$
+        select floor(extract(epoch from last_used_at))::bigint, host(last_used_ip)
+        from api_key
+        where api_key_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (1590998400::bigint, '192.168.1.1') $$,
+    'Older usage should not override the last use of api key 1'
+);
+select results_eq(
+    $$
+        select floor(extract(epoch from last_used_at))::bigint, host(last_used_ip)
+        from api_key
+        where api_key_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$ values (1590998400::bigint, '192.168.1.3') $$,
+    'Last use of api key 2 should have been registered'
+);
+select results_eq(
+    $$
+        select api_key_id, requests
+        from api_key_usage
+        where day = current_date
+        order by api_key_id asc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, 6::bigint),
+            ('00000000-0000-0000-0000-000000000002'::uuid, 3::bigint)
+    $$,
+    'Requests should have been added to the counts of the current day'
+);
+select is_empty(
+    $$
+        select *
+        from api_key_usage
+        where day <= current_date - 30
+    $$,
+    'Usage older than 30 days should have been deleted'
+);
+select is_empty(
+    $$
+        select *
+        from api_key_usage
+        where api_key_id = '00000000-0000-0000-0000-000000000003'
+    $$,
+    'Usage of unknown api keys should be ignored'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,142 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set entry1ID '00000000-0000-0000-0000-000000000001'
+\set entry2ID '00000000-0000-0000-0000-000000000002'
+\set entry3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into audit_log (audit_log_id, action, user_id, organization_id, ip, details, created_at)
+values (:'entry1ID', 'user.login', :'user1ID', null, '192.168.1.1', null, '2020-06-16 11:20:33+02');
+insert into audit_log (audit_log_id, action, user_id, organization_id, ip, details, created_at)
+values (:'entry2ID', 'organization.member.add', :'user1ID', :'org1ID', '192.168.1.1', '{"params": {"userAlias": "user2"}}', '2020-06-16 11:20:34+02');
+insert into audit_log (audit_log_id, action, user_id, organization_id, ip, details, created_at)
+values (:'entry3ID', 'repository.add', null, :'org1ID', '192.168.1.2', null, '2020-06-17 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_organization_audit_log(:'user1ID', 'org1', '{"limit": 10, "offset": 0}')::jsonb,
+    '{
+        "entries": [
+            {
+                "audit_log_id": "00000000-0000-0000-0000-000000000003",
+                "action": "repository.add",
+                "user_alias": null,
+                "organization_name": "org1",
+                "ip": "192.168.1.2",
+                "details": null,
+                "created_at": 1592385634
+            },
+            {
+                "audit_log_id": "00000000-0000-0000-0000-000000000002",
+                "action": "organization.member.add",
+                "user_alias": "user1",
+                "organization_name": "org1",
+                "ip": "192.168.1.1",
+                "details": {"params": {"userAlias": "user2"}},
+                "created_at": 1592299234
+            }
+        ],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 2
+        }
+    }'::jsonb,
+    'Entries of org1 should be returned'
+);
+select is(
+    get_organization_audit_log(:'user1ID', 'org1', '{"limit": 1, "offset": 1}')::jsonb,
+    '{
+        "entries": [
+            {
+                "audit_log_id": "00000000-0000-0000-0000-000000000002",
+                "action": "organization.member.add",
+                "user_alias": "user1",
+                "organization_name": "org1",
+                "ip": "192.168.1.1",
+                "details": {"params": {"userAlias": "user2"}},
+                "created_at": 1592299234
+            }
+        ],
+        "metadata": {
+            "limit": 1,
+            "offset": 1,
+            "total": 2
+        }
+    }'::jsonb,
+    'Second page of entries of org1 should be returned'
+);
+select is(
+    get_organization_audit_log(:'user1ID', 'org1', '{
+        "limit": 10,
+        "offset": 0,
+        "actions": ["organization.member.add"],
+        "user_alias": "user1"
+    }')::jsonb,
+    '{
+        "entries": [
+            {
+                "audit_log_id": "00000000-0000-0000-0000-000000000002",
+                "action": "organization.member.add",
+                "user_alias": "user1",
+                "organization_name": "org1",
+                "ip": "192.168.1.1",
+                "details": {"params": {"userAlias": "user2"}},
+                "created_at": 1592299234
+            }
+        ],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 1
+        }
+    }'::jsonb,
+    'Entries of org1 matching the action and user provided should be returned'
+);
+select is(
+    get_organization_audit_log(:'user1ID', 'org1', '{
+        "limit": 10,
+        "offset": 0,
+        "from": 1592300000,
+        "to": 1592400000
+    }')::jsonb,
+    '{
+        "entries": [
+            {
+                "audit_log_id": "00000000-0000-0000-0000-000000000003",
+                "action": "repository.add",
+                "user_alias": null,
+                "organization_name": "org1",
+                "ip": "192.168.1.2",
+                "details": null,
+                "created_at": 1592385634
+            }
+        ],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 1
+        }
+    }'::jsonb,
+    'Entries of org1 in the date range provided should be returned'
+);
+select throws_ok(
+    $$ select get_organization_audit_log('00000000-0000-0000-0000-000000000002', 'org1', '{"limit": 10, "offset": 0}') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to get the audit log of org1'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
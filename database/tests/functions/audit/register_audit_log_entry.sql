@@ -0,0 +1,68 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+
+-- Register some entries
+select register_audit_log_entry('
+{
+    "action": "organization.member.add",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "organization_name": "org1",
+    "ip": "192.168.1.1",
+    "details": {"params": {"userAlias": "user2"}}
+}
+');
+select register_audit_log_entry('
+{
+    "action": "user.login",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "organization_name": "",
+    "ip": ""
+}
+');
+
+-- Run some tests
+select results_eq(
+    $$
+        select user_id, organization_id, host(ip), details
+        from audit_log
+        where action = 'organization.member.add'
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '192.168.1.1',
+            '{"params": {"userAlias": "user2"}}'::jsonb
+        )
+    $$,
+    'Organization entry should exist'
+);
+select results_eq(
+    $$
+        select user_id, organization_id, ip, details
+        from audit_log
+        where action = 'user.login'
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            null::uuid,
+            null::inet,
+            null::jsonb
+        )
+    $$,
+    'User entry should exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
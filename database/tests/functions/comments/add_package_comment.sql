@@ -0,0 +1,111 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email) values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select add_package_comment('00000000-0000-0000-0000-000000000002', '{"package_id": "00000000-0000-0000-0000-000000000009", "body": "Question"}') $$,
+    'P0002',
+    'no_data_found',
+    'Comments cannot be posted on packages that do not exist'
+);
+select throws_ok(
+    $$ select add_package_comment('00000000-0000-0000-0000-000000000002', '{"package_id": "00000000-0000-0000-0000-000000000001", "parent_id": "00000000-0000-0000-0000-000000000009", "body": "Answer"}') $$,
+    'P0002',
+    'no_data_found',
+    'Answers cannot be posted on questions that do not exist'
+);
+select lives_ok(
+    $$ select add_package_comment('00000000-0000-0000-0000-000000000002', '{"package_id": "00000000-0000-0000-0000-000000000002", "body": "How do I **configure** it?"}') $$,
+    'Question should be posted'
+);
+select results_eq(
+    $$
+        select package_id, parent_id, user_id, body
+        from package_comment
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            null::uuid,
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            'How do I **configure** it?'
+        )
+    $$,
+    'Question should have been added'
+);
+select results_eq(
+    $$
+        select
+            package_id,
+            event_kind_id,
+            data->'subscriptors',
+            data->'question'->>'body',
+            data->'question'->>'user_alias'
+        from event
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            10,
+            '[{"user_id": "00000000-0000-0000-0000-000000000001"}]'::jsonb,
+            'How do I **configure** it?',
+            'user2'
+        )
+    $$,
+    'New question event notifying the package publishers should have been registered'
+);
+select add_package_comment(
+    :'user1ID',
+    jsonb_build_object(
+        'package_id', :'package2ID',
+        'parent_id', (select package_comment_id from package_comment),
+        'body', 'See the docs'
+    )
+);
+select results_eq(
+    $$
+        select user_id, body
+        from package_comment
+        where parent_id is not null
+    $$,
+    $$ values ('00000000-0000-0000-0000-000000000001'::uuid, 'See the docs') $$,
+    'Answer should have been added'
+);
+select is(
+    (select count(*) from event)::int,
+    1,
+    'No events should be registered for answers'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
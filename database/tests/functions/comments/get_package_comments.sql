@@ -0,0 +1,137 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email) values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+\set comment1ID '00000000-0000-0000-0000-000000000001'
+\set comment2ID '00000000-0000-0000-0000-000000000002'
+\set comment3ID '00000000-0000-0000-0000-000000000003'
+\set comment4ID '00000000-0000-0000-0000-000000000004'
+insert into package_comment (package_comment_id, package_id, user_id, body, created_at, updated_at)
+values (:'comment1ID', :'package1ID', :'user2ID', 'Question', '2021-05-01 10:00:00+00', '2021-05-01 10:00:00+00');
+insert into package_comment (package_comment_id, package_id, parent_id, user_id, body, created_at, updated_at)
+values (:'comment4ID', :'package1ID', :'comment1ID', :'user1ID', 'Use the **values** file', '2021-05-02 10:00:00+00', '2021-05-02 10:00:00+00');
+insert into package_comment (package_comment_id, package_id, parent_id, user_id, body, hidden, created_at, updated_at)
+values (:'comment2ID', :'package1ID', :'comment1ID', :'user3ID', 'Spam', true, '2021-05-03 10:00:00+00', '2021-05-03 10:00:00+00');
+insert into package_comment (package_comment_id, package_id, user_id, body, created_at, updated_at)
+values (:'comment3ID', :'package1ID', :'user4ID', 'Is it production ready?', '2021-05-03 10:00:00+00', '2021-05-03 10:00:00+00');
+
+-- Run some tests
+select is(
+    get_package_comments(null, :'package1ID', 10, 0)::jsonb,
+    '{
+        "questions": [
+            {
+                "comment_id": "00000000-0000-0000-0000-000000000003",
+                "body": "Is it production ready?",
+                "user_alias": "user4",
+                "created_at": 1620036000,
+                "updated_at": 1620036000,
+                "answers": []
+            },
+            {
+                "comment_id": "00000000-0000-0000-0000-000000000001",
+                "body": "Question",
+                "user_alias": "user2",
+                "created_at": 1619863200,
+                "updated_at": 1619863200,
+                "answers": [
+                    {
+                        "comment_id": "00000000-0000-0000-0000-000000000004",
+                        "body": "Use the **values** file",
+                        "user_alias": "user1",
+                        "by_publisher": true,
+                        "created_at": 1619949600,
+                        "updated_at": 1619949600
+                    }
+                ]
+            }
+        ],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 2
+        }
+    }'::jsonb,
+    'Visible questions and answers should be returned'
+);
+select is(
+    get_package_comments(:'user1ID', :'package1ID', 1, 1)::jsonb,
+    '{
+        "questions": [
+            {
+                "comment_id": "00000000-0000-0000-0000-000000000001",
+                "body": "Question",
+                "user_alias": "user2",
+                "created_at": 1619863200,
+                "updated_at": 1619863200,
+                "answers": [
+                    {
+                        "comment_id": "00000000-0000-0000-0000-000000000004",
+                        "body": "Use the **values** file",
+                        "user_alias": "user1",
+                        "by_publisher": true,
+                        "created_at": 1619949600,
+                        "updated_at": 1619949600
+                    },
+                    {
+                        "comment_id": "00000000-0000-0000-0000-000000000002",
+                        "body": "Spam",
+                        "hidden": true,
+                        "user_alias": "user3",
+                        "created_at": 1620036000,
+                        "updated_at": 1620036000
+                    }
+                ]
+            }
+        ],
+        "metadata": {
+            "limit": 1,
+            "offset": 1,
+            "total": 2
+        }
+    }'::jsonb,
+    'Hidden comments should be returned to the package publisher'
+);
+select is(
+    get_package_comments(null, :'package2ID', 10, 0)::jsonb,
+    '{
+        "questions": [],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 0
+        }
+    }'::jsonb,
+    'No comments should be returned for package2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
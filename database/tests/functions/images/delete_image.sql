@@ -0,0 +1,25 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set image1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into image (image_id, original_hash) values (:'image1ID', 'image1Hash'::bytea);
+insert into image_version (image_id, version, data) values (:'image1ID', '1x', 'image11xData'::bytea);
+
+-- Delete image and check it and its versions are gone
+select delete_image(:'image1ID');
+select is_empty(
+    $$ select * from image where image_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Image should have been deleted'
+);
+select is_empty(
+    $$ select * from image_version where image_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Image versions should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,35 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set image1ID '00000000-0000-0000-0000-000000000001'
+\set image2ID '00000000-0000-0000-0000-000000000002'
+
+-- No images registered yet
+select is(
+    get_images_created_before(current_timestamp)::jsonb,
+    '[]'::jsonb,
+    'No images should be returned'
+);
+
+-- Seed some data
+insert into image (image_id, original_hash, created_at)
+values (:'image1ID', 'image1Hash'::bytea, current_timestamp - '2 days'::interval);
+insert into image_version (image_id, version, data) values (:'image1ID', '1x', '1234'::bytea);
+insert into image_version (image_id, version, data) values (:'image1ID', '2x', '12345678'::bytea);
+insert into image (image_id, original_hash) values (:'image2ID', 'image2Hash'::bytea);
+
+-- Only image1 was registered before the time provided
+select is(
+    get_images_created_before(current_timestamp - '1 day'::interval)::jsonb,
+    '[{
+        "image_id": "00000000-0000-0000-0000-000000000001",
+        "size": 12
+    }]'::jsonb,
+    'Only image1 should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
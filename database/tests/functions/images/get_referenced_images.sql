@@ -0,0 +1,55 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set image1ID '00000000-0000-0000-0000-000000000001'
+\set image2ID '00000000-0000-0000-0000-000000000002'
+\set image3ID '00000000-0000-0000-0000-000000000003'
+\set image4ID '00000000-0000-0000-0000-000000000004'
+
+-- No images referenced yet
+select is(
+    get_referenced_images()::jsonb,
+    '[]'::jsonb,
+    'No images should be returned'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email, profile_image_id)
+values (:'user1ID', 'user1', 'user1@email.com', :'image1ID');
+insert into organization (organization_id, name, display_name, logo_image_id)
+values (:'org1ID', 'org1', 'Organization 1', :'image2ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id, logo_image_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID', :'image3ID');
+insert into snapshot (package_id, version, logo_image_id)
+values (:'package1ID', '1.0.0', :'image3ID');
+insert into snapshot (package_id, version, logo_image_id)
+values (:'package1ID', '0.0.9', :'image4ID');
+
+-- Images referenced by users, organizations, packages and snapshots
+select results_eq(
+    $$
+        select image_id::uuid
+        from json_array_elements_text(get_referenced_images()) as image_id
+        order by image_id asc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid),
+            ('00000000-0000-0000-0000-000000000002'::uuid),
+            ('00000000-0000-0000-0000-000000000003'::uuid),
+            ('00000000-0000-0000-0000-000000000004'::uuid)
+    $$,
+    'All referenced images should be returned once'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
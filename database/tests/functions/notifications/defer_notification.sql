@@ -0,0 +1,40 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, user_id)
+values (:'notification1ID', :'event1ID', :'user1ID');
+
+-- Defer notification
+select defer_notification(:'notification1ID', '2021-06-16 08:00:00+00');
+
+-- Run some tests
+select results_eq(
+    $$
+        select processed, attempts, next_attempt_at from notification
+        where notification_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (false, 0, '2021-06-16 08:00:00+00'::timestamptz)
+    $$,
+    'Notification delivery should be deferred without counting an attempt'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,177 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set event2ID '00000000-0000-0000-0000-000000000002'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+\set notification3ID '00000000-0000-0000-0000-000000000003'
+\set notification4ID '00000000-0000-0000-0000-000000000004'
+
+-- No pending notifications available yet
+select is(
+    get_pending_notifications(10)::jsonb,
+    '[]'::jsonb,
+    'Should return an empty list'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email, locale, notifications_preferences)
+values (:'user1ID', 'user1', 'user1@email.com', 'es', '{"quiet_hours": {"start": "22:00", "end": "07:00", "time_zone": "Europe/Madrid"}}');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into webhook (
+    webhook_id,
+    name,
+    url,
+    secret,
+    content_type,
+    template,
+    headers,
+    active,
+    user_id
+) values (
+    :'webhook1ID',
+    'webhook1',
+    'http://webhook1.url',
+    'very',
+    'application/json',
+    'custom payload',
+    '[{"name": "Authorization", "value": "Bearer token", "secret": true}]',
+    true,
+    :'user1ID'
+);
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event2ID', '1.0.0', :'package1ID', 1);
+insert into notification (notification_id, event_id, user_id, created_at)
+values (:'notification1ID', :'event1ID', :'user1ID', '2020-06-16 11:20:34+02');
+insert into notification (notification_id, event_id, webhook_id, created_at)
+values (:'notification2ID', :'event1ID', :'webhook1ID', '2020-06-16 11:20:35+02');
+insert into notification (notification_id, event_id, webhook_id, created_at)
+values (:'notification3ID', :'event2ID', :'webhook1ID', '2020-06-16 11:20:36+02');
+
+-- Run some tests
+select is(
+    get_pending_notifications(2)::jsonb,
+    '[
+        {
+            "notification_id": "00000000-0000-0000-0000-000000000001",
+            "attempts": 0,
+            "event": {
+                "event_id": "00000000-0000-0000-0000-000000000001",
+                "event_kind": 0,
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "package_version": "1.0.0"
+            },
+            "user": {
+                "user_id": "00000000-0000-0000-0000-000000000001",
+                "email": "user1@email.com",
+                "locale": "es",
+                "notifications_preferences": {
+                    "quiet_hours": {"start": "22:00", "end": "07:00", "time_zone": "Europe/Madrid"}
+                }
+            }
+        },
+        {
+            "notification_id": "00000000-0000-0000-0000-000000000002",
+            "attempts": 0,
+            "event": {
+                "event_id": "00000000-0000-0000-0000-000000000001",
+                "event_kind": 0,
+                "package_id": "00000000-0000-0000-0000-000000000001",
+                "package_version": "1.0.0"
+            },
+            "webhook": {
+                "webhook_id": "00000000-0000-0000-0000-000000000001",
+                "name": "webhook1",
+                "url": "http://webhook1.url",
+                "secret": "very",
+                "content_type": "application/json",
+                "template": "custom payload",
+                "headers": [
+                    {"name": "Authorization", "value": "Bearer token", "secret": true}
+                ],
+                "batch": false
+            }
+        }
+    ]'::jsonb,
+    'Oldest two pending notifications should be returned'
+);
+select results_eq(
+    $$
+        select jsonb_array_elements(get_pending_notifications(10)::jsonb)->>'notification_id'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000003')
+    $$,
+    'Notifications already claimed should not be returned until their lease expires'
+);
+
+-- Expire leases and schedule next attempt of notification1 in the future
+update notification set next_attempt_at = null;
+update notification set next_attempt_at = current_timestamp + interval '10 minutes'
+where notification_id = :'notification1ID';
+select results_eq(
+    $$
+        select jsonb_array_elements(get_pending_notifications(10)::jsonb)->>'notification_id'
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002'),
+            ('00000000-0000-0000-0000-000000000003')
+    $$,
+    'Notifications scheduled for a later attempt should not be returned'
+);
+
+-- Limit webhook1 to two deliveries per minute, one of them already reserved
+update notification set next_attempt_at = null where webhook_id = :'webhook1ID';
+update webhook set
+    rate_limit = 2,
+    rate_limit_window_start = current_timestamp - interval '30 seconds',
+    rate_limit_window_deliveries = 1
+where webhook_id = :'webhook1ID';
+select results_eq(
+    $$
+        select jsonb_array_elements(get_pending_notifications(10)::jsonb)->>'notification_id'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000002')
+    $$,
+    'Only one notification for webhook1 should be returned as it is about to reach its rate limit'
+);
+
+-- Expire leases, as if the notifications were claimed by another worker
+-- before registering their deliveries
+update notification set next_attempt_at = null where webhook_id = :'webhook1ID';
+select is(
+    get_pending_notifications(10)::jsonb,
+    '[]'::jsonb,
+    'No notifications for webhook1 should be returned as the previous claim reserved its remaining deliveries'
+);
+
+-- Add a newer notification for user1
+insert into notification (notification_id, event_id, user_id, created_at)
+values (:'notification4ID', :'event2ID', :'user1ID', '2020-06-16 11:20:37+02');
+select results_eq(
+    $$
+        select jsonb_array_elements(get_pending_notifications(1)::jsonb)->>'notification_id'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000004')
+    $$,
+    'Notifications exceeding their webhook rate limit should not take up batch slots'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
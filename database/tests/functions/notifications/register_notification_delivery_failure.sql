@@ -0,0 +1,69 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, webhook_id)
+values (:'notification1ID', :'event1ID', :'webhook1ID');
+
+-- Register first failure and check a new attempt has been scheduled
+select register_notification_delivery_failure(:'notification1ID', 'payload', 'fake error', 2);
+select results_eq(
+    $$
+        select processed, attempts, error, next_attempt_at > current_timestamp
+        from notification
+        where notification_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (false, 1, 'fake error', true)
+    $$,
+    'Notification should be pending and scheduled for a new attempt'
+);
+select is_empty(
+    $$ select * from notification_dead_letter $$,
+    'Notification should not be in the dead-letter queue yet'
+);
+
+-- Register second failure and check the notification was dead-lettered
+select register_notification_delivery_failure(:'notification1ID', 'payload', 'fake error 2', 2);
+select results_eq(
+    $$
+        select processed, attempts, error, next_attempt_at
+        from notification
+        where notification_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, 2, 'fake error 2', null::timestamptz)
+    $$,
+    'Notification should have been processed after exhausting its attempts'
+);
+select results_eq(
+    $$
+        select notification_id, payload, error from notification_dead_letter
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, 'payload', 'fake error 2')
+    $$,
+    'Notification should be in the dead-letter queue'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,69 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+\set notification3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, user_id, next_attempt_at)
+values (:'notification1ID', :'event1ID', :'user1ID', current_timestamp + interval '1 minute');
+insert into notification (notification_id, event_id, user_id, next_attempt_at)
+values (:'notification2ID', :'event1ID', :'user1ID', current_timestamp + interval '1 minute');
+insert into notification (notification_id, event_id, user_id, next_attempt_at)
+values (:'notification3ID', :'event1ID', :'user1ID', current_timestamp - interval '1 minute');
+
+-- Run some tests
+select is(
+    renew_notifications_lease(array[:'notification1ID', :'notification2ID']::uuid[]),
+    true,
+    'Lease of all notifications provided should be renewed'
+);
+select results_eq(
+    $$
+        select notification_id, next_attempt_at from notification
+        where notification_id in (
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000002'
+        )
+        order by notification_id asc
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, current_timestamp + interval '5 minutes'),
+            ('00000000-0000-0000-0000-000000000002'::uuid, current_timestamp + interval '5 minutes')
+    $$,
+    'Notifications should be leased for 5 more minutes'
+);
+select is(
+    renew_notifications_lease(array[:'notification1ID', :'notification3ID']::uuid[]),
+    false,
+    'Lease should not be renewed when one of the notifications lease has expired'
+);
+select results_eq(
+    $$
+        select next_attempt_at < current_timestamp from notification
+        where notification_id = '00000000-0000-0000-0000-000000000003'
+    $$,
+    $$
+        values (true)
+    $$,
+    'Expired lease should not be renewed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
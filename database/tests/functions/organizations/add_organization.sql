@@ -44,15 +44,15 @@ select results_eq(
 );
 select results_eq(
     $$
-        select uo.user_id
+        select uo.user_id, uo.role
         from user__organization uo
         join organization o using (organization_id)
         where o.name = 'org1'
     $$,
     $$
-        values ('00000000-0000-0000-0000-000000000001'::uuid)
+        values ('00000000-0000-0000-0000-000000000001'::uuid, 'owner')
     $$,
-    'User who created the organization should have joined it'
+    'User who created the organization should have joined it as owner'
 );
 
 -- Finish tests and rollback transaction
@@ -0,0 +1,51 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set sa1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, deletion_scheduled_at)
+values (:'org1ID', 'org1', current_timestamp + '1 day'::interval);
+insert into "user" (user_id, alias, service_account_organization_id, deletion_scheduled_at)
+values (:'sa1ID', 'org1.ci', :'org1ID', current_timestamp + '1 day'::interval);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+
+-- Run some tests
+select throws_ok(
+    $$ select cancel_organization_deletion('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to organization1, so it should not be able to cancel its deletion'
+);
+select cancel_organization_deletion(:'user1ID', 'org1');
+select results_eq(
+    $$
+        select o.deletion_scheduled_at, u.deletion_scheduled_at
+        from organization o
+        join "user" u on u.service_account_organization_id = o.organization_id
+    $$,
+    $$ values (null::timestamptz, null::timestamptz) $$,
+    'Organization1 and its service account deletion should have been cancelled'
+);
+select throws_ok(
+    $$ select cancel_organization_deletion('00000000-0000-0000-0000-000000000001', 'org1') $$,
+    'P0002',
+    'no_data_found',
+    'Organization1 deletion is not scheduled anymore'
+);
+select is(
+    (select count(*)::int from organization),
+    1,
+    'Organization1 should still exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(7);
+select plan(8);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -97,6 +97,16 @@ select throws_ok(
     'User2 should not be able to delete an organization1 member'
 );
 
+-- Last owner of the organization cannot leave it while other members remain
+update user__organization set role = 'owner'
+where user_id = :'user2ID' and organization_id = :'org2ID';
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org2ID', true);
+select throws_ok(
+    $$ select delete_organization_member('00000000-0000-0000-0000-000000000002', 'org2', 'user2') $$,
+    'last owner of an organization cannot leave it',
+    'User2 should not be able to leave organization2 as it is its last owner'
+);
+
 -- Last user in the organization cannot leave it
 select throws_ok(
     $$ select delete_organization_member('00000000-0000-0000-0000-000000000001', 'org1', 'user1') $$,
@@ -0,0 +1,52 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set org3ID '00000000-0000-0000-0000-000000000003'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set sa1ID '00000000-0000-0000-0000-000000000001'
+
+-- No organizations deleted when there are no scheduled deletions
+select is(
+    delete_scheduled_organizations(),
+    0,
+    'No organizations should be deleted'
+);
+
+-- Seed some data
+insert into organization (organization_id, name, deletion_scheduled_at)
+values (:'org1ID', 'org1', current_timestamp - '1 day'::interval);
+insert into organization (organization_id, name, deletion_scheduled_at)
+values (:'org2ID', 'org2', current_timestamp + '1 day'::interval);
+insert into organization (organization_id, name)
+values (:'org3ID', 'org3');
+insert into "user" (user_id, alias, service_account_organization_id) values (:'sa1ID', 'org1.ci', :'org1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+
+-- Delete organizations whose grace period has elapsed
+select is(
+    delete_scheduled_organizations(),
+    1,
+    'One organization should be deleted'
+);
+select results_eq(
+    $$ select organization_id from organization order by name $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002'::uuid),
+            ('00000000-0000-0000-0000-000000000003'::uuid)
+    $$,
+    'Only organizations whose grace period has not elapsed yet should remain'
+);
+select is_empty(
+    $$ select * from "user" where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Service accounts of the deleted organization should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -27,12 +27,14 @@ select is(
         "alias": "user1",
         "first_name": "firstname1",
         "last_name": "lastname1",
-        "confirmed": true
+        "confirmed": true,
+        "role": "member"
     },{
         "alias": "user2",
         "first_name": "firstname2",
         "last_name": "lastname2",
-        "confirmed": false
+        "confirmed": false,
+        "role": "member"
     }]'::jsonb,
     'Organization1 members are returned as a json array of objects'
 );
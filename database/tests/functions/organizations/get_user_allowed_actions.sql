@@ -0,0 +1,59 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some users and an organization
+insert into organization (organization_id, name)
+values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'user4ID', 'org1.sa', 'sa@service-accounts.invalid', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user1ID', :'org1ID', true, 'owner');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user2ID', :'org1ID', true, 'viewer');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user3ID', :'org1ID', false, 'admin');
+
+-- Run some tests
+select is(
+    get_user_allowed_actions(:'user1ID', 'org1'),
+    '{all}'::text[],
+    'Owner should be allowed to perform all actions'
+);
+select is(
+    get_user_allowed_actions(:'user2ID', 'org1'),
+    '{}'::text[],
+    'Viewer should not be allowed to perform any action'
+);
+select is(
+    get_user_allowed_actions(:'user3ID', 'org1'),
+    '{}'::text[],
+    'Members who have not confirmed their membership yet should not be allowed to perform any action'
+);
+select is(
+    get_user_allowed_actions(:'user4ID', 'org1'),
+    '{addOrganizationRepository,updateOrganizationRepository}'::text[],
+    'Service accounts should only be allowed to add and update repositories'
+);
+select is(
+    get_user_allowed_actions(:'user1ID', 'org2'),
+    '{}'::text[],
+    'Users should not be allowed to perform any action in organizations they do not belong to'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
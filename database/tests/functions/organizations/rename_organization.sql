@@ -0,0 +1,67 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some users and organizations
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+
+-- Try some invalid renames
+select throws_ok(
+    $$ select rename_organization('00000000-0000-0000-0000-000000000002', 'org1', 'org1-new') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 should not be able to rename organization1'
+);
+select throws_ok(
+    $$ select rename_organization('00000000-0000-0000-0000-000000000001', 'org1', 'org2') $$,
+    'P0001',
+    'organization name not available',
+    'Organization1 should not be renamed to the name of an existing organization'
+);
+
+-- Rename organization and check it succeeded
+select rename_organization(:'user1ID', 'org1', 'org1-new');
+select is(
+    (select name from organization where organization_id = :'org1ID'),
+    'org1-new',
+    'Organization1 should have been renamed'
+);
+select results_eq(
+    $$ select name, organization_id from organization_name_alias $$,
+    $$ values ('org1', '00000000-0000-0000-0000-000000000001'::uuid) $$,
+    'Previous name should have been registered as an alias'
+);
+select is(
+    get_organization('org1')::jsonb,
+    '{"name": "org1-new"}'::jsonb,
+    'Organization1 should be returned when requested using its previous name'
+);
+
+-- Rename organization back to its previous name
+select rename_organization(:'user1ID', 'org1-new', 'org1');
+select results_eq(
+    $$ select name, organization_id from organization_name_alias $$,
+    $$ values ('org1-new', '00000000-0000-0000-0000-000000000001'::uuid) $$,
+    'Aliases should never shadow the actual names of the organizations'
+);
+
+-- Add a new organization using an alias name
+insert into organization (name) values ('org1-new');
+select is_empty(
+    $$ select * from organization_name_alias $$,
+    'Alias should have been removed once the name was taken by a new organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,95 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set sa1ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set org3ID '00000000-0000-0000-0000-000000000003'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into organization (organization_id, name) values (:'org2ID', 'org2');
+insert into organization (organization_id, name) values (:'org3ID', 'org3');
+insert into "user" (user_id, alias, service_account_organization_id) values (:'sa1ID', 'org1.ci', :'org1ID');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org2ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org2ID');
+
+-- Try some invalid requests
+select throws_ok(
+    $$ select schedule_organization_deletion('00000000-0000-0000-0000-000000000002', 'org1', '{}', '1 day') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 does not belong to organization1, so its deletion should not be scheduled'
+);
+select throws_ok(
+    $$
+        select schedule_organization_deletion(
+            '00000000-0000-0000-0000-000000000001', 'org1', '{"transfer_repositories_to_org": "org3"}', '1 day'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repositories should not be transferred to organizations the user does not belong to'
+);
+
+-- Schedule organization deletion transferring its repositories to org2
+select lives_ok(
+    $$
+        select schedule_organization_deletion(
+            '00000000-0000-0000-0000-000000000001', 'org1', '{"transfer_repositories_to_org": "org2"}', '1 day'
+        )
+    $$,
+    'User1 should be able to schedule the deletion of organization1'
+);
+select isnt(
+    (select deletion_scheduled_at from organization where organization_id = :'org1ID'),
+    null,
+    'Organization1 deletion should have been scheduled'
+);
+select isnt(
+    (select deletion_scheduled_at from "user" where user_id = :'sa1ID'),
+    null,
+    'Organization1 service account deletion should have been scheduled'
+);
+select results_eq(
+    $$ select organization_id from repository order by name $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002'::uuid),
+            ('00000000-0000-0000-0000-000000000002'::uuid)
+    $$,
+    'Organization1 repositories should have been transferred to organization2'
+);
+
+-- Schedule organization deletion transferring its repositories to the user
+select schedule_organization_deletion(:'user1ID', 'org2', '{"transfer_repositories_to_user": true}', '1 day');
+select results_eq(
+    $$ select user_id, organization_id from repository order by name $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, null::uuid),
+            ('00000000-0000-0000-0000-000000000001'::uuid, null::uuid)
+    $$,
+    'Organization2 repositories should have been transferred to user1'
+);
+select isnt(
+    (select deletion_scheduled_at from organization where organization_id = :'org2ID'),
+    null,
+    'Organization2 deletion should have been scheduled'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,65 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some users and an organization
+insert into organization (organization_id, name)
+values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user3ID', 'user3', 'user3@email.com');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user1ID', :'org1ID', true, 'owner');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user2ID', :'org1ID', true, 'member');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user3ID', :'org1ID', false, 'member');
+
+-- Try some invalid transfers
+select throws_ok(
+    $$ select transfer_organization_ownership('00000000-0000-0000-0000-000000000002', 'org1', 'user1') $$,
+    42501,
+    'insufficient_privilege',
+    'User2 is not an owner, so it should not be able to transfer the ownership of organization1'
+);
+select throws_ok(
+    $$ select transfer_organization_ownership('00000000-0000-0000-0000-000000000001', 'org1', 'user3') $$,
+    'P0002',
+    'no_data_found',
+    'Ownership should not be transferred to users who have not confirmed their membership'
+);
+select throws_ok(
+    $$ select transfer_organization_ownership('00000000-0000-0000-0000-000000000001', 'org1', 'user1') $$,
+    'P0001',
+    'ownership cannot be transferred to the current owner',
+    'Ownership should not be transferred to the current owner'
+);
+
+-- Transfer ownership and check it succeeded
+select lives_ok(
+    $$ select transfer_organization_ownership('00000000-0000-0000-0000-000000000001', 'org1', 'user2') $$,
+    'User1 should be able to transfer the ownership of organization1 to user2'
+);
+select is(
+    (select role from user__organization where user_id = :'user2ID'),
+    'owner',
+    'User2 should be an owner of organization1'
+);
+select is(
+    (select role from user__organization where user_id = :'user1ID'),
+    'admin',
+    'User1 should have been demoted to admin'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
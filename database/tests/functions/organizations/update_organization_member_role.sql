@@ -0,0 +1,68 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some users and an organization
+insert into organization (organization_id, name)
+values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user3ID', 'user3', 'user3@email.com');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user1ID', :'org1ID', true, 'owner');
+insert into user__organization (user_id, organization_id, confirmed, role)
+values (:'user2ID', :'org1ID', true, 'member');
+
+-- Update member role and check it succeeded
+select update_organization_member_role(:'user1ID', 'org1', 'user2', 'admin');
+select is(
+    (select role from user__organization where user_id = :'user2ID'),
+    'admin',
+    'User2 role should have been updated to admin'
+);
+
+-- Try some invalid updates
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000003', 'org1', 'user2', 'viewer') $$,
+    42501,
+    'insufficient_privilege',
+    'User3 should not be able to update roles in organization1'
+);
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000001', 'org1', 'user2', 'invalid') $$,
+    'invalid role',
+    'Role provided must exist'
+);
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000001', 'org1', 'user3', 'viewer') $$,
+    'P0002',
+    'no_data_found',
+    'User3 does not belong to organization1'
+);
+select throws_ok(
+    $$ select update_organization_member_role('00000000-0000-0000-0000-000000000001', 'org1', 'user1', 'admin') $$,
+    'last owner of an organization cannot be demoted',
+    'User1 should not be able to demote itself as it is the last owner'
+);
+
+-- Promote user2 to owner, now user1 can be demoted
+select update_organization_member_role(:'user1ID', 'org1', 'user2', 'owner');
+select update_organization_member_role(:'user1ID', 'org1', 'user1', 'viewer');
+select is(
+    (select role from user__organization where user_id = :'user1ID'),
+    'viewer',
+    'User1 should be a viewer now'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
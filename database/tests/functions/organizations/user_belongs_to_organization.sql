@@ -1,11 +1,12 @@
 -- Start transaction and plan tests
 begin;
-select plan(5);
+select plan(6);
 
 -- Declare some variables
 \set org1ID '00000000-0000-0000-0000-000000000001'
 \set org2ID '00000000-0000-0000-0000-000000000002'
 \set user1ID '00000000-0000-0000-0000-000000000001'
+\set sa1ID '00000000-0000-0000-0000-000000000011'
 
 -- Seed one user and some organizations
 insert into organization (organization_id, name, display_name, description, home_url)
@@ -14,6 +15,8 @@ insert into organization (organization_id, name, display_name, description, home
 values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
 insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
 insert into user__organization (user_id, organization_id) values(:'user1ID', :'org1ID');
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa1ID', 'org1.sa1', 'sa1@service-accounts.invalid', :'org1ID');
 
 -- User and some organizations have just been seeded
 select is(
@@ -45,6 +48,11 @@ select is(
     false,
     'User1 does not belong to non existing org'
 );
+select is(
+    user_belongs_to_organization(:'sa1ID', 'org1'),
+    false,
+    'Service account sa1 does not belong to Org1 even though Org1 owns it'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -0,0 +1,77 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, containers_images)
+values (:'package1ID', '1.0.0', '[{"image": "quay.io/org/pkg1:1.0.0"}]');
+insert into snapshot (package_id, version, containers_images, security_report)
+values (:'package1ID', '0.0.9', '[{"image": "quay.io/org/pkg1:0.0.9"}]', '{"k": "v"}');
+insert into snapshot (package_id, version, containers_images)
+values (:'package2ID', '1.0.0', '[{"image": "quay.io/org/pkg2:1.0.0"}]');
+
+-- Run some tests
+select throws_ok(
+    $$ select add_package_vulnerability_suppression('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'CVE-2021-0001', 'Not used') $$,
+    42501,
+    'insufficient_privilege',
+    'Users not owning the package should not be allowed to suppress vulnerabilities'
+);
+select throws_ok(
+    $$ select add_package_vulnerability_suppression('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', 'CVE-2021-0001', 'Not used') $$,
+    42501,
+    'insufficient_privilege',
+    'Users not belonging to the organization owning the package should not be allowed to suppress vulnerabilities'
+);
+select throws_ok(
+    $$ select add_package_vulnerability_suppression('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000009', 'CVE-2021-0001', 'Not used') $$,
+    'P0002',
+    'no_data_found',
+    'Suppressing vulnerabilities of a package that does not exist should fail'
+);
+select add_package_vulnerability_suppression(:'user1ID', :'package1ID', 'CVE-2021-0001', 'Not used');
+select add_package_vulnerability_suppression(:'user1ID', :'package1ID', 'CVE-2021-0001', 'Vulnerable code not used');
+select add_package_vulnerability_suppression(:'user1ID', :'package2ID', 'CVE-2021-0002', 'Not reachable');
+select results_eq(
+    'select package_id::text, vulnerability_id, justification, user_id::text from package_vulnerability_suppression order by package_id',
+    $$ values
+        ('00000000-0000-0000-0000-000000000001', 'CVE-2021-0001', 'Vulnerable code not used', '00000000-0000-0000-0000-000000000001'),
+        ('00000000-0000-0000-0000-000000000002', 'CVE-2021-0002', 'Not reachable', '00000000-0000-0000-0000-000000000001')
+    $$,
+    'Suppressions should have been added by the package owner and a member of the organization'
+);
+select isnt(security_report_rescan_requested_at, null, 'Scanned snapshots rescan should have been requested')
+from snapshot where package_id = :'package1ID' and version = '0.0.9';
+select is(security_report_rescan_requested_at, null, 'Snapshots not scanned yet rescan should not have been requested')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(
+    (select count(*) from package_vulnerability_suppression where package_id = :'package1ID'),
+    1::bigint,
+    'Suppressing an already suppressed vulnerability should update it'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -91,10 +91,13 @@ insert into snapshot (
     deprecated,
     license,
     signed,
+    signatures,
+    provenance,
     content_url,
     containers_images,
     provider,
     values_schema,
+    sboms,
     changes,
     contains_security_updates,
     prerelease,
@@ -122,10 +125,13 @@ insert into snapshot (
     true,
     'Apache-2.0',
     true,
+    '[{"kind": "cosign-key", "identity": "sha256:a1b2c3", "verified": true}]',
+    '{"predicate_type": "https://slsa.dev/provenance/v0.2", "builder_id": "https://github.com/slsa-framework/slsa-github-generator", "level": 3, "verified": true}',
     'https://content.url/pkg1.tgz',
     '[{"image": "quay.io/org/img:1.0.0"}]',
     'Org Inc',
     '{"key": "value"}',
+    '[{"format": "spdx", "content": "SPDXVersion: SPDX-2.2"}]',
     '{"feature 1", "fix 1"}',
     true,
     true,
@@ -266,12 +272,14 @@ select is(
                 "version": "0.0.9",
                 "contains_security_updates": false,
                 "prerelease": false,
+                "yanked": false,
                 "ts": 1592299233
             },
             {
                 "version": "1.0.0",
                 "contains_security_updates": true,
                 "prerelease": true,
+                "yanked": false,
                 "ts": 1592299234
             }
         ],
@@ -282,6 +290,19 @@ select is(
         "prerelease": true,
         "license": "Apache-2.0",
         "signed": true,
+        "signatures": [
+            {
+                "kind": "cosign-key",
+                "identity": "sha256:a1b2c3",
+                "verified": true
+            }
+        ],
+        "provenance": {
+            "predicate_type": "https://slsa.dev/provenance/v0.2",
+            "builder_id": "https://github.com/slsa-framework/slsa-github-generator",
+            "level": 3,
+            "verified": true
+        },
         "content_url": "https://content.url/pkg1.tgz",
         "containers_images": [
             {
@@ -290,11 +311,13 @@ select is(
         ],
         "provider": "Org Inc",
         "has_values_schema": true,
+        "has_sbom": true,
         "has_changelog": true,
         "changes": [
             "feature 1",
             "fix 1"
         ],
+        "yanked": false,
         "ts": 1592299234,
         "maintainers": [
             {
@@ -385,12 +408,14 @@ select is(
                 "version": "0.0.9",
                 "contains_security_updates": false,
                 "prerelease": false,
+                "yanked": false,
                 "ts": 1592299233
             },
             {
                 "version": "1.0.0",
                 "contains_security_updates": true,
                 "prerelease": true,
+                "yanked": false,
                 "ts": 1592299234
             }
         ],
@@ -401,6 +426,19 @@ select is(
         "prerelease": true,
         "license": "Apache-2.0",
         "signed": true,
+        "signatures": [
+            {
+                "kind": "cosign-key",
+                "identity": "sha256:a1b2c3",
+                "verified": true
+            }
+        ],
+        "provenance": {
+            "predicate_type": "https://slsa.dev/provenance/v0.2",
+            "builder_id": "https://github.com/slsa-framework/slsa-github-generator",
+            "level": 3,
+            "verified": true
+        },
         "content_url": "https://content.url/pkg1.tgz",
         "containers_images": [
             {
@@ -409,11 +447,13 @@ select is(
         ],
         "provider": "Org Inc",
         "has_values_schema": true,
+        "has_sbom": true,
         "has_changelog": true,
         "changes": [
             "feature 1",
             "fix 1"
         ],
+        "yanked": false,
         "ts": 1592299234,
         "maintainers": [
             {
@@ -494,12 +534,14 @@ select is(
                 "version": "0.0.9",
                 "contains_security_updates": false,
                 "prerelease": false,
+                "yanked": false,
                 "ts": 1592299233
             },
             {
                 "version": "1.0.0",
                 "contains_security_updates": true,
                 "prerelease": true,
+                "yanked": false,
                 "ts": 1592299234
             }
         ],
@@ -508,7 +550,9 @@ select is(
         "contains_security_updates": false,
         "prerelease": false,
         "has_values_schema": false,
+        "has_sbom": false,
         "has_changelog": true,
+        "yanked": false,
         "ts": 1592299233,
         "maintainers": [
             {
@@ -553,12 +597,15 @@ select is(
             "key": "value"
         },
         "has_values_schema": false,
+        "has_sbom": false,
         "has_changelog": false,
+        "yanked": false,
         "ts": 1592299234,
         "version": "1.0.0",
         "available_versions": [
             {
                 "version": "1.0.0",
+                "yanked": false,
                 "ts": 1592299234
             }
         ],
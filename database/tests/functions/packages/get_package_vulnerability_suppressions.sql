@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+
+-- Run some tests
+select is(
+    get_package_vulnerability_suppressions(:'package1ID')::jsonb,
+    '[]'::jsonb,
+    'No suppressions expected'
+);
+insert into package_vulnerability_suppression (package_id, vulnerability_id, justification, created_at)
+values (:'package1ID', 'CVE-2021-0002', 'Not reachable', '2021-06-16 11:20:38+02');
+insert into package_vulnerability_suppression (package_id, vulnerability_id, justification, created_at)
+values (:'package1ID', 'CVE-2021-0001', 'Vulnerable code not used', '2021-06-16 11:20:38+02');
+select is(
+    get_package_vulnerability_suppressions(:'package1ID')::jsonb,
+    '[
+        {
+            "vulnerability_id": "CVE-2021-0001",
+            "justification": "Vulnerable code not used",
+            "created_at": 1623835238
+        },
+        {
+            "vulnerability_id": "CVE-2021-0002",
+            "justification": "Not reachable",
+            "created_at": 1623835238
+        }
+    ]'::jsonb,
+    'Suppressions sorted by vulnerability id expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
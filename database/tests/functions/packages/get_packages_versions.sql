@@ -0,0 +1,54 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- No packages versions yet
+select is(
+    get_packages_versions(array[:'package1ID']::uuid[])::jsonb,
+    '{}'::jsonb,
+    'No versions should be returned'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version)
+values (:'package1ID', '1.0.0');
+insert into snapshot (package_id, version)
+values (:'package1ID', '0.0.9');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '2.0.0', :'repo1ID');
+insert into snapshot (package_id, version)
+values (:'package2ID', '2.0.0');
+
+-- Run some tests
+select is(
+    get_packages_versions(array[:'package1ID', :'package3ID']::uuid[])::jsonb,
+    '{
+        "00000000-0000-0000-0000-000000000001": ["0.0.9", "1.0.0"]
+    }'::jsonb,
+    'Only versions of the packages requested that exist should be returned'
+);
+select is(
+    get_packages_versions(array[:'package1ID', :'package2ID']::uuid[])::jsonb,
+    '{
+        "00000000-0000-0000-0000-000000000001": ["0.0.9", "1.0.0"],
+        "00000000-0000-0000-0000-000000000002": ["2.0.0"]
+    }'::jsonb,
+    'Versions of all the packages requested should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,105 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, description, ts)
+values (:'package1ID', '1.0.0', 'description', '2020-06-16 11:20:34+02');
+insert into snapshot (package_id, version, description, ts)
+values (:'package1ID', '0.0.9', 'description', '2020-06-16 11:20:33+02');
+insert into snapshot (package_id, version, yanked, ts)
+values (:'package1ID', '1.1.0', true, '2020-06-16 11:20:35+02');
+insert into snapshot (package_id, version, ts)
+values (:'package2ID', '1.0.0', '2020-06-16 11:20:36+02');
+
+-- Run some tests
+select is(
+    get_recent_releases('{"repository_name": "repo1"}')::jsonb,
+    '[
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "name": "package1",
+            "normalized_name": "package1",
+            "description": "description",
+            "version": "1.0.0",
+            "ts": 1592299234,
+            "repository": {
+                "kind": 0,
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "user_alias": "user1"
+            }
+        },
+        {
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "name": "package1",
+            "normalized_name": "package1",
+            "description": "description",
+            "version": "0.0.9",
+            "ts": 1592299233,
+            "repository": {
+                "kind": 0,
+                "name": "repo1",
+                "display_name": "Repo 1",
+                "user_alias": "user1"
+            }
+        }
+    ]'::jsonb,
+    'Releases of the packages in repo1 should be returned (yanked versions excluded)'
+);
+select is(
+    get_recent_releases('{"publisher": "org1"}')::jsonb,
+    '[
+        {
+            "package_id": "00000000-0000-0000-0000-000000000002",
+            "name": "package2",
+            "normalized_name": "package2",
+            "version": "1.0.0",
+            "ts": 1592299236,
+            "repository": {
+                "kind": 0,
+                "name": "repo2",
+                "display_name": "Repo 2",
+                "organization_name": "org1",
+                "organization_display_name": "Organization 1"
+            }
+        }
+    ]'::jsonb,
+    'Releases of the packages published by org1 should be returned'
+);
+select is(
+    (select count(*) from json_array_elements(get_recent_releases('{"publisher": "user1", "limit": 1}'))),
+    1::bigint,
+    'Only the number of releases requested should be returned'
+);
+select is_empty(
+    $$ select get_recent_releases('{"repository_name": "repo3"}') $$,
+    'No rows should be returned when the repository does not exist'
+);
+select is_empty(
+    $$ select get_recent_releases('{"publisher": "user2"}') $$,
+    'No rows should be returned when the publisher does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
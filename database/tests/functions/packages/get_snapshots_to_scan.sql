@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(4);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -180,7 +180,8 @@ select is(
                 {
                     "image": "quay.io/org/pkg1:1.0.0"
                 }
-            ]
+            ],
+            "suppressed_vulnerabilities": null
         },
         {
             "repository_id": "00000000-0000-0000-0000-000000000001",
@@ -191,7 +192,8 @@ select is(
                 {
                     "image": "quay.io/org/pkg1:0.0.9"
                 }
-            ]
+            ],
+            "suppressed_vulnerabilities": null
         },
         {
             "repository_id": "00000000-0000-0000-0000-000000000002",
@@ -203,7 +205,8 @@ select is(
                     "image": "quay.io/org/pkg2:1.0.0",
                     "whitelisted": false
                 }
-            ]
+            ],
+            "suppressed_vulnerabilities": null
         },
         {
             "repository_id": "00000000-0000-0000-0000-000000000002",
@@ -214,7 +217,8 @@ select is(
                 {
                     "image": "quay.io/org/pkg3:1.0.0"
                 }
-            ]
+            ],
+            "suppressed_vulnerabilities": null
         },
         {
             "repository_id": "00000000-0000-0000-0000-000000000002",
@@ -225,11 +229,120 @@ select is(
                 {
                     "image": "quay.io/org/pkg3:0.0.8"
                 }
-            ]
+            ],
+            "suppressed_vulnerabilities": null
         }
     ]'::jsonb,
     'Some snapshots to scan were expected'
 );
+update snapshot set security_report_rescan_requested_at = current_timestamp
+where package_id = :'package3ID' and version = '0.0.9';
+select is(
+    get_snapshots_to_scan()::jsonb,
+    '[
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "0.0.9",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg3:0.0.9"
+                }
+            ],
+            "suppressed_vulnerabilities": null
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_name": "package1",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg1:1.0.0"
+                }
+            ],
+            "suppressed_vulnerabilities": null
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_name": "package1",
+            "version": "0.0.9",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg1:0.0.9"
+                }
+            ],
+            "suppressed_vulnerabilities": null
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "package_id": "00000000-0000-0000-0000-000000000002",
+            "package_name": "package2",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg2:1.0.0",
+                    "whitelisted": false
+                }
+            ],
+            "suppressed_vulnerabilities": null
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg3:1.0.0"
+                }
+            ],
+            "suppressed_vulnerabilities": null
+        },
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000002",
+            "package_id": "00000000-0000-0000-0000-000000000003",
+            "package_name": "package3",
+            "version": "0.0.8",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg3:0.0.8"
+                }
+            ],
+            "suppressed_vulnerabilities": null
+        }
+    ]'::jsonb,
+    'Snapshot whose rescan was requested should be returned first'
+);
+update snapshot set security_report_rescan_requested_at = null;
+update snapshot set containers_images = null where package_id <> :'package1ID' or version <> '1.0.0';
+insert into package_vulnerability_suppression (package_id, vulnerability_id, justification)
+values (:'package1ID', 'CVE-2021-0001', 'Vulnerable code not used');
+select is(
+    get_snapshots_to_scan()::jsonb,
+    '[
+        {
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "package_id": "00000000-0000-0000-0000-000000000001",
+            "package_name": "package1",
+            "version": "1.0.0",
+            "containers_images": [
+                {
+                    "image": "quay.io/org/pkg1:1.0.0"
+                }
+            ],
+            "suppressed_vulnerabilities": [
+                {
+                    "vulnerability_id": "CVE-2021-0001",
+                    "justification": "Vulnerable code not used"
+                }
+            ]
+        }
+    ]'::jsonb,
+    'Package vulnerabilities suppressions should be included'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(14);
+select plan(20);
 
 -- Declare some variables
 \set org1ID '00000000-0000-0000-0000-000000000001'
@@ -74,6 +74,13 @@ select register_package('
     "values_schema": {
         "key": "value"
     },
+    "default_values": "key: value\n",
+    "sboms": [
+        {
+            "format": "spdx",
+            "content": "SPDXVersion: SPDX-2.2"
+        }
+    ],
     "changes": [
         "Added cool feature",
         "Fixed minor bug"
@@ -106,6 +113,7 @@ select results_eq(
         select
             name,
             latest_version,
+            fuzzy_text,
             is_operator,
             channels,
             default_channel,
@@ -117,6 +125,7 @@ select results_eq(
         values (
             'package1',
             '1.0.0',
+            'package1 Package 1 kw1 kw2',
             true,
             '[
                 {
@@ -160,6 +169,8 @@ select results_eq(
             s.containers_images,
             s.provider,
             s.values_schema,
+            s.default_values,
+            s.sboms,
             s.changes,
             s.contains_security_updates,
             s.prerelease,
@@ -195,6 +206,8 @@ select results_eq(
             '[{"image": "quay.io/org/img:1.0.0"}]'::jsonb,
             'Org Inc',
             '{"key": "value"}'::jsonb,
+            E'key: value\n',
+            '[{"format": "spdx", "content": "SPDXVersion: SPDX-2.2"}]'::jsonb,
             '{
                 "Added cool feature",
                 "Fixed minor bug"
@@ -232,7 +245,7 @@ select is_empty(
         join package p using (package_id)
         where p.name = 'package1'
     $$,
-    'No new release event should exist for first version of package1'
+    'No events should exist for first version of package1'
 );
 
 -- Register a new version of the package previously registered
@@ -252,6 +265,20 @@ select register_package('
     "digest": "digest-package1-2.0.0",
     "deprecated": true,
     "signed": true,
+    "signatures": [
+        {
+            "kind": "cosign-keyless",
+            "identity": "user1@email.com",
+            "issuer": "https://github.com/login/oauth",
+            "verified": true
+        }
+    ],
+    "provenance": {
+        "predicate_type": "https://slsa.dev/provenance/v0.2",
+        "builder_id": "https://github.com/slsa-framework/slsa-github-generator",
+        "level": 3,
+        "verified": true
+    },
     "is_operator": false,
     "capabilities": "seamless upgrades",
     "containers_images": [
@@ -300,6 +327,8 @@ select results_eq(
             s.capabilities,
             s.deprecated,
             s.signed,
+            s.signatures,
+            s.provenance,
             s.containers_images,
             s.provider,
             s.values_schema,
@@ -329,6 +358,8 @@ select results_eq(
             'seamless upgrades',
             true,
             true,
+            '[{"kind": "cosign-keyless", "identity": "user1@email.com", "issuer": "https://github.com/login/oauth", "verified": true}]'::jsonb,
+            '{"predicate_type": "https://slsa.dev/provenance/v0.2", "builder_id": "https://github.com/slsa-framework/slsa-github-generator", "level": 3, "verified": true}'::jsonb,
             '[{"image": "quay.io/org/img:2.0.0"}]'::jsonb,
             'Org Inc 2',
             null::jsonb,
@@ -371,9 +402,21 @@ select isnt_empty(
         join package p using (package_id)
         where p.name = 'package1'
         and e.package_version = '2.0.0'
+        and e.event_kind_id = 0
     $$,
     'New release event should exist for package1 version 2.0.0'
 );
+select isnt_empty(
+    $$
+        select *
+        from event e
+        join package p using (package_id)
+        where p.name = 'package1'
+        and e.package_version = '2.0.0'
+        and e.event_kind_id = 5
+    $$,
+    'Package deprecated event should exist for package1 version 2.0.0'
+);
 
 -- Register an old version of the package previously registered
 select register_package('
@@ -502,7 +545,130 @@ select is_empty(
         where p.name = 'package1'
         and e.package_version = '0.0.9'
     $$,
-    'No new release event should exist for package1 version 0.0.9'
+    'No events should exist for package1 version 0.0.9'
+);
+
+-- Register again a version of the package previously registered, now deprecated
+select register_package('
+{
+    "name": "package1",
+    "version": "1.0.0",
+    "deprecated": true,
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select results_eq(
+    $$
+        select e.event_kind_id
+        from event e
+        join package p using (package_id)
+        where p.name = 'package1'
+        and e.package_version = '1.0.0'
+    $$,
+    $$ values (5) $$,
+    'Only package deprecated event should exist for package1 version 1.0.0'
+);
+
+-- Register again a deprecated version of the package previously registered
+select register_package('
+{
+    "name": "package1",
+    "version": "1.0.0",
+    "deprecated": true,
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select results_eq(
+    $$
+        select count(*)
+        from event e
+        join package p using (package_id)
+        where p.name = 'package1'
+        and e.package_version = '1.0.0'
+    $$,
+    $$ values (1::bigint) $$,
+    'No new package deprecated event should exist for package1 version 1.0.0'
+);
+
+-- Register a new package in the repository once it has been tracked
+update repository set last_tracking_ts = current_timestamp where repository_id = :'repo1ID';
+select register_package('
+{
+    "name": "package2",
+    "display_name": "Package 2",
+    "version": "1.0.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select results_eq(
+    $$
+        select e.event_kind_id, e.package_version
+        from event e
+        join package p using (package_id)
+        where p.name = 'package2'
+    $$,
+    $$ values (7, '1.0.0') $$,
+    'New package event should exist for package2'
+);
+
+-- Register a new version of the package just added
+select register_package('
+{
+    "name": "package2",
+    "display_name": "Package 2",
+    "version": "1.1.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select results_eq(
+    $$
+        select e.event_kind_id
+        from event e
+        join package p using (package_id)
+        where p.name = 'package2'
+        and e.package_version = '1.1.0'
+    $$,
+    $$ values (0) $$,
+    'Only new release event should exist for package2 version 1.1.0'
+);
+
+-- Register again a yanked version of the package just added
+update snapshot set yanked = true
+where version = '1.1.0'
+and package_id = (select package_id from package where name = 'package2');
+update package set latest_version = '1.0.0' where name = 'package2';
+select register_package('
+{
+    "name": "package2",
+    "display_name": "Package 2",
+    "version": "1.1.0",
+    "repository": {
+        "repository_id": "00000000-0000-0000-0000-000000000001"
+    }
+}
+');
+select results_eq(
+    $$
+        select p.latest_version, s.yanked, (
+            select count(*) from event e
+            where e.package_id = p.package_id
+            and e.package_version = '1.1.0'
+        )
+        from package p
+        join snapshot s using (package_id)
+        where p.name = 'package2'
+        and s.version = '1.1.0'
+    $$,
+    $$ values ('1.0.0', true, 1::bigint) $$,
+    'Yanked versions registered again should not become the latest version nor trigger new events'
 );
 
 -- Disable repository and check that trying to register a package raises an error
@@ -511,8 +677,8 @@ select throws_ok(
     $$
         select register_package('
         {
-            "name": "package2",
-            "display_name": "Package 2",
+            "name": "package3",
+            "display_name": "Package 3",
             "version": "1.0.0",
             "repository": {
                 "repository_id": "00000000-0000-0000-0000-000000000001"
@@ -0,0 +1,65 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, containers_images)
+values (:'package1ID', '1.0.0', '[{"image": "quay.io/org/pkg1:1.0.0"}]');
+insert into snapshot (package_id, version)
+values (:'package1ID', '0.0.9');
+insert into snapshot (package_id, version, containers_images)
+values (:'package2ID', '1.0.0', '[{"image": "quay.io/org/pkg2:1.0.0"}]');
+
+-- Run some tests
+select throws_ok(
+    $$ select request_snapshot_security_report_rescan('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '1.0.0') $$,
+    42501,
+    'insufficient_privilege',
+    'Users not owning the package should not be allowed to request a rescan'
+);
+select throws_ok(
+    $$ select request_snapshot_security_report_rescan('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '1.0.0') $$,
+    42501,
+    'insufficient_privilege',
+    'Users not belonging to the organization owning the package should not be allowed to request a rescan'
+);
+select throws_ok(
+    $$ select request_snapshot_security_report_rescan('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '0.0.9') $$,
+    'P0002',
+    'no_data_found',
+    'Snapshots without containers images cannot be rescanned'
+);
+select request_snapshot_security_report_rescan(:'user1ID', :'package1ID', '1.0.0');
+select isnt(security_report_rescan_requested_at, null, 'Package1 rescan should have been requested by its owner')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select request_snapshot_security_report_rescan(:'user1ID', :'package2ID', '1.0.0');
+select isnt(security_report_rescan_requested_at, null, 'Package2 rescan should have been requested by a member of the organization')
+from snapshot where package_id = :'package2ID' and version = '1.0.0';
+select is(security_report_rescan_requested_at, null, 'Other snapshots rescan should not have been requested')
+from snapshot where package_id = :'package1ID' and version = '0.0.9';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
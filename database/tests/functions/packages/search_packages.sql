@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(27);
+select plan(39);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -48,6 +48,7 @@ insert into package (
     is_operator,
     stars,
     tsdoc,
+    fuzzy_text,
     official,
     repository_id
 ) values (
@@ -57,6 +58,7 @@ insert into package (
     true,
     10,
     generate_package_tsdoc('package1', null, 'description', '{"kw1", "kw2"}', '{"repo1"}', '{"user1"}'),
+    generate_package_fuzzy_text('package1', 'Package 1', '{"kw1", "kw2"}'),
     false,
     :'repo1ID'
 );
@@ -120,6 +122,7 @@ insert into package (
     latest_version,
     stars,
     tsdoc,
+    fuzzy_text,
     official,
     repository_id
 ) values (
@@ -128,6 +131,7 @@ insert into package (
     '1.0.0',
     11,
     generate_package_tsdoc('package2', null, 'description', '{"kw1", "kw2"}', '{"repo2"}', '{"org1"}'),
+    generate_package_fuzzy_text('package2', 'Package 2', '{"kw1", "kw2"}'),
     true,
     :'repo2ID'
 );
@@ -190,12 +194,14 @@ insert into package (
     name,
     latest_version,
     tsdoc,
+    fuzzy_text,
     repository_id
 ) values (
     :'package3ID',
     'package3',
     '1.0.0',
-    generate_package_tsdoc('package3', null, 'description', '{"kw3"}', '{"repo3"}', '{"org1"}'),
+    generate_package_tsdoc('package3', null, 'description', '{"kw3", "vulnerabilities"}', '{"repo3"}', '{"org1"}'),
+    generate_package_fuzzy_text('package3', 'Harbor Scanner', '{"kw3", "vulnerabilities"}'),
     :'repo3ID'
 );
 insert into snapshot (
@@ -211,10 +217,10 @@ insert into snapshot (
 ) values (
     :'package3ID',
     '1.0.0',
-    'Package 3',
+    'Harbor Scanner',
     'description',
     :'image3ID',
-    '{"kw3"}',
+    '{"kw3", "vulnerabilities"}',
     'readme',
     '{"high": 2, "medium": 1}',
     '2020-06-16 11:20:34+02'
@@ -281,7 +287,7 @@ select is(
                 "name": "package3",
                 "normalized_name": "package3",
                 "stars": 0,
-                "display_name": "Package 3",
+                "display_name": "Harbor Scanner",
                 "description": "description",
                 "logo_image_id": "00000000-0000-0000-0000-000000000003",
                 "version": "1.0.0",
@@ -430,7 +436,7 @@ select is(
                 "name": "package3",
                 "normalized_name": "package3",
                 "stars": 0,
-                "display_name": "Package 3",
+                "display_name": "Harbor Scanner",
                 "description": "description",
                 "logo_image_id": "00000000-0000-0000-0000-000000000003",
                 "version": "1.0.0",
@@ -873,6 +879,91 @@ select is(
     }'::jsonb,
     'TSQueryWeb: kw9 (inexistent) | No packages or facets expected'
 );
+select is(
+    search_packages('{
+        "ts_query_web": "pakage1"
+    }')::jsonb,
+    '{
+        "data": {
+            "packages": []
+        },
+        "metadata": {
+            "total": 0
+        }
+    }'::jsonb,
+    'TSQueryWeb: pakage1 (typo) Fuzzy: false | No packages expected'
+);
+select is(
+    (
+        select jsonb_agg(p->>'name')
+        from jsonb_array_elements(search_packages('{
+            "ts_query_web": "pakage1",
+            "fuzzy": true
+        }')::jsonb->'data'->'packages') p
+    ),
+    '["package1"]'::jsonb,
+    'TSQueryWeb: pakage1 (typo) Fuzzy: true | Package 1 expected'
+);
+select is(
+    (
+        select jsonb_agg(p->>'name')
+        from jsonb_array_elements(search_packages('{
+            "ts_query_web": "harbr",
+            "fuzzy": true
+        }')::jsonb->'data'->'packages') p
+    ),
+    '["package3"]'::jsonb,
+    'TSQueryWeb: harbr (display name typo) Fuzzy: true | Package 3 expected'
+);
+select is(
+    (
+        select jsonb_agg(p->>'name')
+        from jsonb_array_elements(search_packages('{
+            "ts_query_web": "vulnerabilites",
+            "fuzzy": true
+        }')::jsonb->'data'->'packages') p
+    ),
+    '["package3"]'::jsonb,
+    'TSQueryWeb: vulnerabilites (keyword typo) Fuzzy: true | Package 3 expected'
+);
+
+-- Tests with sort options
+select is(
+    (
+        select jsonb_agg(p->>'name')
+        from jsonb_array_elements(search_packages('{
+            "sort": "stars",
+            "deprecated": true
+        }')::jsonb->'data'->'packages') p
+    ),
+    '["package2", "package1", "package3"]'::jsonb,
+    'Sort: stars | Packages 2, 1 and 3 expected in that order'
+);
+select is(
+    (
+        select jsonb_agg(p->>'name')
+        from jsonb_array_elements(search_packages('{
+            "sort": "alphabetical",
+            "deprecated": true
+        }')::jsonb->'data'->'packages') p
+    ),
+    '["package1", "package2", "package3"]'::jsonb,
+    'Sort: alphabetical | Packages 1, 2 and 3 expected in that order'
+);
+select is(
+    search_packages(jsonb_build_object(
+        'limit', 1,
+        'sort', 'stars',
+        'cursor', search_packages('{
+            "limit": 1,
+            "sort": "stars",
+            "deprecated": true
+        }')::jsonb #>> '{metadata,next_cursor}',
+        'deprecated', true
+    ))::jsonb #>> '{data,packages,0,name}',
+    'package1',
+    'Limit: 1 Cursor: next Sort: stars | Package 1 expected'
+);
 
 -- Tests with kind and repositories filters
 select is(
@@ -1235,7 +1326,7 @@ select is(
                 "name": "package3",
                 "normalized_name": "package3",
                 "stars": 0,
-                "display_name": "Package 3",
+                "display_name": "Harbor Scanner",
                 "description": "description",
                 "logo_image_id": "00000000-0000-0000-0000-000000000003",
                 "version": "1.0.0",
@@ -1341,7 +1432,7 @@ select is(
                 "name": "package3",
                 "normalized_name": "package3",
                 "stars": 0,
-                "display_name": "Package 3",
+                "display_name": "Harbor Scanner",
                 "description": "description",
                 "logo_image_id": "00000000-0000-0000-0000-000000000003",
                 "version": "1.0.0",
@@ -1526,7 +1617,7 @@ select is(
         "offset": 0,
         "ts_query_web": "kw1",
         "deprecated": true
-    }')::jsonb,
+    }')::jsonb #- '{metadata,next_cursor}',
     '{
         "data": {
             "packages": [{
@@ -1562,6 +1653,86 @@ select is(
     }'::jsonb,
     'Limit: 1 Offset: 0 TSQueryWeb: kw1 | Package 1 expected'
 );
+select isnt(
+    search_packages('{
+        "limit": 1,
+        "offset": 0,
+        "ts_query_web": "kw1",
+        "deprecated": true
+    }')::jsonb #>> '{metadata,next_cursor}',
+    null,
+    'Limit: 1 Offset: 0 TSQueryWeb: kw1 | Next cursor expected'
+);
+select is(
+    search_packages(jsonb_build_object(
+        'limit', 1,
+        'cursor', search_packages('{
+            "limit": 1,
+            "ts_query_web": "kw1",
+            "deprecated": true
+        }')::jsonb #>> '{metadata,next_cursor}',
+        'ts_query_web', 'kw1',
+        'deprecated', true
+    ))::jsonb #>> '{data,packages,0,package_id}',
+    '00000000-0000-0000-0000-000000000002',
+    'Limit: 1 Cursor: next TSQueryWeb: kw1 | Package 2 expected'
+);
+select is(
+    search_packages(jsonb_build_object(
+        'limit', 1,
+        'cursor', search_packages('{
+            "limit": 1,
+            "ts_query_web": "kw1",
+            "deprecated": true
+        }')::jsonb #>> '{metadata,next_cursor}',
+        'ts_query_web', 'kw1',
+        'deprecated', true
+    ))::jsonb #>> '{metadata,next_cursor}',
+    null,
+    'Limit: 1 Cursor: next TSQueryWeb: kw1 | Next cursor not expected in the last page'
+);
+select throws_ok(
+    format(
+        'select search_packages(%L)',
+        jsonb_build_object(
+            'limit', 1,
+            'sort', 'stars',
+            'cursor', search_packages('{
+                "limit": 1,
+                "ts_query_web": "kw1",
+                "deprecated": true
+            }')::jsonb #>> '{metadata,next_cursor}',
+            'ts_query_web', 'kw1',
+            'deprecated', true
+        )
+    ),
+    '22023',
+    'invalid cursor',
+    'Limit: 1 Cursor: next Sort: stars TSQueryWeb: kw1 | Cursor obtained using a different sort rejected'
+);
+select throws_ok(
+    format(
+        'select search_packages(%L)',
+        jsonb_build_object(
+            'limit', 1,
+            'cursor', encode_cursor(jsonb_build_object(
+                'rank', 'invalid',
+                'official', false,
+                'verified_publisher', false,
+                'stars', 0,
+                'ts', 0,
+                'name', 'package1',
+                'package_id', '00000000-0000-0000-0000-000000000001',
+                'scope', get_cursor_scope('{"ts_query_web": "kw1", "deprecated": true}')
+            )),
+            'ts_query_web', 'kw1',
+            'deprecated', true
+        )
+    ),
+    '22023',
+    'invalid cursor',
+    'Limit: 1 Cursor: invalid TSQueryWeb: kw1 | Cursor containing invalid values rejected'
+);
 select is(
     search_packages('{
         "limit": 1,
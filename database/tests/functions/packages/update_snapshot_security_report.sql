@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(6);
+select plan(10);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -31,6 +31,8 @@ insert into snapshot (
     '1.0.0',
     '[{"image": "quay.io/org/pkg1:1.0.0"}]'
 );
+update snapshot set security_report_rescan_requested_at = current_timestamp
+where package_id = :'package1ID' and version = '1.0.0';
 
 -- Run some tests
 select is(security_report, null, 'Security report should be null')
@@ -67,6 +69,82 @@ select is(security_report_summary, '{
     "low": 10
 }', 'Security report summary should exist')
 from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is(security_report_rescan_requested_at, null, 'Security report rescan request should have been cleared')
+from snapshot where package_id = :'package1ID' and version = '1.0.0';
+select is_empty(
+    $$ select * from event $$,
+    'No security alert event should exist as no new vulnerabilities were found'
+);
+
+-- Update security report including some new vulnerabilities
+select update_snapshot_security_report('{
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "version": "1.0.0",
+    "summary": {
+        "critical": 3,
+        "high": 3,
+        "low": 10
+    },
+    "full": {
+        "quay.io/org/pkg1:1.0.0": [
+            {"k": "v2"}
+        ]
+    },
+    "new_vulnerabilities": [
+        {
+            "id": "CVE-2021-0001",
+            "severity": "CRITICAL",
+            "image": "quay.io/org/pkg1:1.0.0",
+            "pkg_name": "pkg",
+            "installed_version": "1.0.0"
+        }
+    ]
+}');
+select results_eq(
+    $$
+        select package_id, package_version, event_kind_id, data
+        from event
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '1.0.0',
+            1,
+            '{
+                "vulnerabilities": [
+                    {
+                        "id": "CVE-2021-0001",
+                        "severity": "CRITICAL",
+                        "image": "quay.io/org/pkg1:1.0.0",
+                        "pkg_name": "pkg",
+                        "installed_version": "1.0.0"
+                    }
+                ]
+            }'::jsonb
+        )
+    $$,
+    'Security alert event should exist'
+);
+
+-- Update security report of a snapshot that does not exist
+select update_snapshot_security_report('{
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "version": "2.0.0",
+    "new_vulnerabilities": [
+        {
+            "id": "CVE-2021-0001",
+            "severity": "CRITICAL",
+            "image": "quay.io/org/pkg1:2.0.0",
+            "pkg_name": "pkg",
+            "installed_version": "1.0.0"
+        }
+    ]
+}');
+select is(
+    (select count(*) from event),
+    1::bigint,
+    'No security alert event should be registered for snapshots that do not exist'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
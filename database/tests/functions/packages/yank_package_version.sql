@@ -0,0 +1,71 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.0');
+insert into snapshot (package_id, version) values (:'package1ID', '0.9.0');
+insert into snapshot (package_id, version) values (:'package1ID', '0.8.0');
+insert into snapshot (package_id, version) values (:'package2ID', '1.0.0');
+
+-- Run some tests
+select throws_ok(
+    $$ select yank_package_version('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '1.0.0', 'reason') $$,
+    42501,
+    'insufficient_privilege',
+    'Users not owning the package should not be allowed to yank its versions'
+);
+select throws_ok(
+    $$ select yank_package_version('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '1.0.0', 'reason') $$,
+    42501,
+    'insufficient_privilege',
+    'Users not belonging to the organization owning the package should not be allowed to yank its versions'
+);
+select throws_ok(
+    $$ select yank_package_version('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '2.0.0', 'reason') $$,
+    'P0002',
+    'no_data_found',
+    'Versions not available cannot be yanked'
+);
+select yank_package_version(:'user1ID', :'package1ID', '1.0.0', 'Broken release');
+select results_eq(
+    $$ select yanked, yanked_reason from snapshot where package_id = '00000000-0000-0000-0000-000000000001' and version = '1.0.0' $$,
+    $$ values (true, 'Broken release') $$,
+    'Package1 version 1.0.0 should have been yanked by its owner'
+);
+select is(latest_version, '0.9.0', 'Package1 latest version should be the highest one not yanked')
+from package where package_id = :'package1ID';
+select yank_package_version(:'user1ID', :'package2ID', '1.0.0', '');
+select results_eq(
+    $$ select yanked, yanked_reason from snapshot where package_id = '00000000-0000-0000-0000-000000000002' and version = '1.0.0' $$,
+    $$ values (true, null::text) $$,
+    'Package2 version 1.0.0 should have been yanked by a member of the organization'
+);
+select is(latest_version, '1.0.0', 'Package2 latest version should not change when all its versions are yanked')
+from package where package_id = :'package2ID';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
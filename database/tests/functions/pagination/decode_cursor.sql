@@ -0,0 +1,24 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Run some tests
+select is(
+    decode_cursor('eyJuYW1lIjogInJlcG8xIn0'),
+    '{"name": "repo1"}'::jsonb,
+    'Cursor without padding should be decoded'
+);
+select is(
+    decode_cursor('eyJuYW1lIjogIn5-fiJ9'),
+    '{"name": "~~~"}'::jsonb,
+    'Cursor using the url safe base64 alphabet should be decoded'
+);
+select is(
+    decode_cursor(encode_cursor('{"package_id": "00000000-0000-0000-0000-000000000002", "normalized_name": "package-2"}')),
+    '{"package_id": "00000000-0000-0000-0000-000000000002", "normalized_name": "package-2"}'::jsonb,
+    'Encoded cursors should be decoded back'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
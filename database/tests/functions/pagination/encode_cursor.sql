@@ -0,0 +1,24 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Run some tests
+select is(
+    encode_cursor('{"name": "repo1"}'),
+    'eyJuYW1lIjogInJlcG8xIn0',
+    'Cursor should be encoded using base64 without padding'
+);
+select is(
+    encode_cursor('{"name": "~~~"}'),
+    'eyJuYW1lIjogIn5-fiJ9',
+    'Cursor should be encoded using the url safe base64 alphabet'
+);
+select is(
+    encode_cursor('{"package_id": "00000000-0000-0000-0000-000000000002", "normalized_name": "package-2"}'),
+    'eyJwYWNrYWdlX2lkIjogIjAwMDAwMDAwLTAwMDAtMDAwMC0wMDAwLTAwMDAwMDAwMDAwMiIsICJub3JtYWxpemVkX25hbWUiOiAicGFja2FnZS0yIn0',
+    'Long cursors should not contain line breaks'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
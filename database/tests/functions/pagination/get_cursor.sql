@@ -0,0 +1,72 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Run some tests
+select is(
+    get_cursor('{"limit": 10}', '{name}'),
+    null,
+    'Null expected when the input does not include a cursor'
+);
+select is(
+    get_cursor('{"limit": 10, "cursor": ""}', '{name}'),
+    null,
+    'Null expected when the cursor provided is empty'
+);
+select is(
+    get_cursor(
+        jsonb_build_object(
+            'sort', 'stars',
+            'cursor', encode_cursor(jsonb_build_object('name', 'repo1', 'scope', get_cursor_scope('{"sort": "stars"}')))
+        ),
+        '{name}'
+    ),
+    jsonb_build_object('name', 'repo1', 'scope', get_cursor_scope('{"sort": "stars"}')),
+    'Cursor obtained using the same search input should be returned'
+);
+select throws_ok(
+    $$select get_cursor('{"cursor": "not a cursor"}', '{name}')$$,
+    '22023',
+    'invalid cursor',
+    'Cursor that cannot be decoded should be rejected'
+);
+select throws_ok(
+    format(
+        'select get_cursor(%L, %L)',
+        jsonb_build_object('cursor', encode_cursor('[1, 2]')),
+        '{name}'
+    ),
+    '22023',
+    'invalid cursor',
+    'Cursor not containing a json object should be rejected'
+);
+select throws_ok(
+    format(
+        'select get_cursor(%L, %L)',
+        jsonb_build_object(
+            'sort', 'newest',
+            'cursor', encode_cursor(jsonb_build_object('name', 'repo1', 'scope', get_cursor_scope('{"sort": "stars"}')))
+        ),
+        '{name}'
+    ),
+    '22023',
+    'invalid cursor',
+    'Cursor obtained using a different search input should be rejected'
+);
+select throws_ok(
+    format(
+        'select get_cursor(%L, %L)',
+        jsonb_build_object(
+            'sort', 'stars',
+            'cursor', encode_cursor(jsonb_build_object('scope', get_cursor_scope('{"sort": "stars"}')))
+        ),
+        '{name}'
+    ),
+    '22023',
+    'invalid cursor',
+    'Cursor missing some of the fields required should be rejected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
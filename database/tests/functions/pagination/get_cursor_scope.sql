@@ -0,0 +1,24 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Run some tests
+select is(
+    get_cursor_scope('{"limit": 10, "offset": 20, "cursor": "cursor", "facets": true, "sort": "stars"}'),
+    get_cursor_scope('{"sort": "stars"}'),
+    'Pagination options and facets should not be part of the scope'
+);
+select is(
+    get_cursor_scope('{"sort": "stars", "kinds": [0, 1]}'),
+    get_cursor_scope('{"kinds": [0, 1], "sort": "stars"}'),
+    'Scope should not depend on the order of the input fields'
+);
+select isnt(
+    get_cursor_scope('{"sort": "stars"}'),
+    get_cursor_scope('{"sort": "newest"}'),
+    'Inputs using different sort options should have different scopes'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- No pulls registered yet
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+select is(
+    get_package_pulls(:'package1ID', 30)::jsonb,
+    '{"total": 0, "daily": []}'::jsonb,
+    'No pulls expected'
+);
+
+-- Some pulls registered (older ones are not returned)
+insert into package_pulls (package_id, day, pulls) values
+    (:'package1ID', current_date - 40, 100),
+    (:'package1ID', current_date - 1, 10),
+    (:'package1ID', current_date, 20);
+select is(
+    get_package_pulls(:'package1ID', 30)::jsonb,
+    jsonb_build_object(
+        'total', 30,
+        'daily', jsonb_build_array(
+            jsonb_build_array(floor(extract(epoch from current_date - 1)*1000), 10),
+            jsonb_build_array(floor(extract(epoch from current_date)*1000), 20)
+        )
+    ),
+    'Pulls of the last 30 days expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,82 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- No pulls registered yet
+select is(
+    get_trending_packages('{}')::jsonb,
+    '[]'::jsonb,
+    'No trending packages expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 1, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, display_name, ts)
+values (:'package1ID', '1.0.0', 'Package 1', '2020-06-16 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, display_name, ts)
+values (:'package2ID', '1.0.0', 'Package 2', '2020-06-16 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'package3', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, display_name, ts)
+values (:'package3ID', '1.0.0', 'Package 3', '2020-06-16 11:20:34+02');
+insert into package_pulls (package_id, day, pulls) values
+    (:'package1ID', current_date - 10, 100),
+    (:'package1ID', current_date - 1, 50),
+    (:'package2ID', current_date - 2, 80),
+    (:'package3ID', current_date - 1, 60),
+    (:'package3ID', current_date - 30, 1000);
+
+-- Run some tests
+select results_eq(
+    $$
+        select e->'package'->>'name', (e->>'pulls_last_week')::bigint, (e->>'pulls_previous_week')::bigint
+        from jsonb_array_elements(get_trending_packages('{}')::jsonb) e
+    $$,
+    $$
+        values
+            ('package2', 80::bigint, 0::bigint),
+            ('package3', 60::bigint, 0::bigint),
+            ('package1', 50::bigint, 100::bigint)
+    $$,
+    'Packages expected to be ordered by pulls during the last week'
+);
+select results_eq(
+    $$
+        select e->'package'->>'name'
+        from jsonb_array_elements(get_trending_packages('{"kind": 0}')::jsonb) e
+    $$,
+    $$
+        values ('package2'), ('package1')
+    $$,
+    'Only packages of the kind provided expected'
+);
+select results_eq(
+    $$
+        select e->'package'->>'name'
+        from jsonb_array_elements(get_trending_packages('{"limit": 1}')::jsonb) e
+    $$,
+    $$
+        values ('package2')
+    $$,
+    'Only one package expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,116 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+
+-- Try to register pulls for a repository that does not exist
+select throws_ok(
+    $$
+        select register_package_pulls('00000000-0000-0000-0000-000000000001', 'repo3', '[]')
+    $$,
+    'P0002',
+    'no_data_found',
+    'Pulls registration should fail because the repository does not exist'
+);
+
+-- Try to register pulls for a repository owned by a user by other user
+select throws_ok(
+    $$
+        select register_package_pulls('00000000-0000-0000-0000-000000000002', 'repo1', '[]')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Pulls registration should fail because requesting user is not the owner'
+);
+
+-- Try to register pulls for a repository owned by organization by user not
+-- belonging to it
+select throws_ok(
+    $$
+        select register_package_pulls('00000000-0000-0000-0000-000000000002', 'repo2', '[]')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Pulls registration should fail because requesting user does not belong to owning organization'
+);
+
+-- Register pulls for a repository owned by the user (packages from other
+-- repositories are ignored)
+select register_package_pulls(:'user1ID', 'repo1', '[
+    {"package_name": "package1", "day": "2021-05-01", "pulls": 10},
+    {"package_name": "package1", "day": "2021-05-02", "pulls": 20},
+    {"package_name": "package2", "day": "2021-05-01", "pulls": 30}
+]');
+select results_eq(
+    $$
+        select package_id, day, pulls
+        from package_pulls
+        order by package_id, day
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, '2021-05-01'::date, 10::bigint),
+            ('00000000-0000-0000-0000-000000000001'::uuid, '2021-05-02'::date, 20::bigint)
+    $$,
+    'Pulls of the repository packages should have been registered'
+);
+
+-- Register pulls again for the same day (counts are replaced)
+select register_package_pulls(:'user1ID', 'repo1', '[
+    {"package_name": "package1", "day": "2021-05-02", "pulls": 25}
+]');
+select results_eq(
+    $$
+        select pulls
+        from package_pulls
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and day = '2021-05-02'
+    $$,
+    $$
+        values (25::bigint)
+    $$,
+    'Pulls of the same day should have been replaced'
+);
+
+-- Register pulls for a repository owned by an organization the user belongs to
+select register_package_pulls(:'user1ID', 'repo2', '[
+    {"package_name": "package2", "day": "2021-05-01", "pulls": 30}
+]');
+select results_eq(
+    $$
+        select package_id, day, pulls
+        from package_pulls
+        where package_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000002'::uuid, '2021-05-01'::date, 30::bigint)
+    $$,
+    'Pulls of the organization repository packages should have been registered'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
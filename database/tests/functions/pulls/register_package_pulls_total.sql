@@ -0,0 +1,48 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'oci://registry-1.docker.io/user1/package1', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+
+-- First total registered is only used as a reference
+select register_package_pulls_total(:'package1ID', 1000);
+select is_empty(
+    'select * from package_pulls',
+    'No pulls expected after registering the first total'
+);
+select results_eq(
+    'select total from package_pulls_total',
+    $$ values (1000::bigint) $$,
+    'Total should have been registered'
+);
+
+-- Difference with the previous total is added to the current day pulls
+select register_package_pulls_total(:'package1ID', 1010);
+select register_package_pulls_total(:'package1ID', 1015);
+select results_eq(
+    'select day, pulls from package_pulls',
+    $$ values (current_date, 15::bigint) $$,
+    'Current day pulls should have been registered'
+);
+
+-- Totals lower than the previous one do not register pulls
+select register_package_pulls_total(:'package1ID', 900);
+select results_eq(
+    'select pulls from package_pulls',
+    $$ values (15::bigint) $$,
+    'Current day pulls should not have changed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
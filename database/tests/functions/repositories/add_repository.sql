@@ -1,17 +1,23 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set sa1ID '00000000-0000-0000-0000-000000000011'
 
--- Seed user and organization
+-- Seed user, service account and organizations
 insert into "user" (user_id, alias, email)
 values (:'user1ID', 'user1', 'user1@email.com');
 insert into organization (organization_id, name, display_name, description, home_url)
 values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa1ID', 'org1.sa1', 'sa1@service-accounts.invalid', :'org1ID');
 
 -- Add repository owned by user
 select add_repository(:'user1ID', null, '
@@ -20,10 +26,15 @@ select add_repository(:'user1ID', null, '
     "display_name": "Repository 1",
     "url": "repo1_url",
     "branch": "main",
+    "path": "charts",
     "auth_user": "user1",
     "auth_pass": "pass1",
+    "tls_config": "tls_config",
+    "proxy_url": "http://proxy.com:3128",
     "disabled": false,
     "scanner_disabled": false,
+    "tracking_schedule": "@daily",
+    "retention_policy": {"max_versions": 10, "max_age_days": 90},
     "kind": 0
 }
 '::jsonb);
@@ -34,10 +45,16 @@ select results_eq(
             display_name,
             url,
             branch,
+            path,
             auth_user,
             auth_pass,
+            auth_pass_ref,
+            tls_config,
+            proxy_url,
             disabled,
             scanner_disabled,
+            tracking_schedule,
+            retention_policy,
             repository_kind_id,
             user_id,
             organization_id
@@ -50,10 +67,16 @@ select results_eq(
             'Repository 1',
             'repo1_url',
             'main',
+            'charts',
             'user1',
             'pass1',
+            null,
+            'tls_config',
+            'http://proxy.com:3128',
             false,
             false,
+            '@daily',
+            '{"max_versions": 10, "max_age_days": 90}'::jsonb,
             0,
             '00000000-0000-0000-0000-000000000001'::uuid,
             null::uuid
@@ -70,7 +93,7 @@ select add_repository(:'user1ID', 'org1', '
     "url": "repo2_url",
     "branch": "main",
     "auth_user": "user1",
-    "auth_pass": "pass1",
+    "auth_pass_ref": "kubernetes:repo2-creds#password",
     "disabled": true,
     "scanner_disabled": true,
     "kind": 0
@@ -83,10 +106,16 @@ select results_eq(
             display_name,
             url,
             branch,
+            path,
             auth_user,
             auth_pass,
+            auth_pass_ref,
+            tls_config,
+            proxy_url,
             disabled,
             scanner_disabled,
+            tracking_schedule,
+            retention_policy,
             repository_kind_id,
             user_id,
             organization_id
@@ -99,10 +128,16 @@ select results_eq(
             'Repository 2',
             'repo2_url',
             'main',
+            null,
             'user1',
-            'pass1',
+            null,
+            'kubernetes:repo2-creds#password',
+            null,
+            null,
             true,
             true,
+            null,
+            null::jsonb,
             0,
             null::uuid,
             '00000000-0000-0000-0000-000000000001'::uuid
@@ -128,6 +163,40 @@ select throws_ok(
     'User not belonging to organization should not be able to add repos in its name'
 );
 
+-- Add repositories using a service account
+select add_repository(:'sa1ID', 'org1', '
+{
+    "name": "repo5",
+    "display_name": "Repository 5",
+    "url": "repo5_url",
+    "branch": "main",
+    "auth_user": "user1",
+    "auth_pass": "pass1",
+    "disabled": false,
+    "scanner_disabled": false,
+    "kind": 0
+}
+'::jsonb);
+select isnt_empty(
+    $$ select * from repository where name = 'repo5' and organization_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Service account should be able to add repos to the organization that owns it'
+);
+select throws_ok(
+    $$
+        select add_repository('00000000-0000-0000-0000-000000000011', 'org2', '
+        {
+            "name": "repo6",
+            "display_name": "Repository 6",
+            "url": "repo6_url",
+            "kind": 1
+        }
+        '::jsonb)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Service account should not be able to add repos to other organizations'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
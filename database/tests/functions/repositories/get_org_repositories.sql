@@ -45,7 +45,7 @@ insert into repository (
     'Repo 1',
     'https://repo1.com',
     '1970-01-01 00:00:00 UTC',
-    'error1\nerror2\nerror3',
+    '[{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}]',
     0,
     :'org1ID'
 );
@@ -108,8 +108,11 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "last_tracking_ts": 0,
-        "last_tracking_errors": "error1\\nerror2\\nerror3",
+        "last_tracking_errors": "error1\nerror2",
+        "tracking_errors": [{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}],
         "organization_name": "org1",
         "organization_display_name": "Organization 1"
     }, {
@@ -122,6 +125,8 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "organization_name": "org1",
         "organization_display_name": "Organization 1"
     }]'::jsonb,
@@ -0,0 +1,58 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set claim1ID '00000000-0000-0000-0000-000000000001'
+\set claim2ID '00000000-0000-0000-0000-000000000002'
+\set claim3ID '00000000-0000-0000-0000-000000000003'
+
+-- No claims at this point
+select is(
+    get_pending_repository_ownership_claims()::jsonb,
+    '[]'::jsonb,
+    'No claims expected'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0);
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0);
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 0);
+insert into repository_ownership_claim (repository_ownership_claim_id, repository_id, user_id, method, token, checked_at)
+values (:'claim1ID', :'repo1ID', :'user1ID', 'dns', 'token1', current_timestamp);
+insert into repository_ownership_claim (repository_ownership_claim_id, repository_id, user_id, method, token)
+values (:'claim2ID', :'repo2ID', :'user1ID', 'http', 'token2');
+insert into repository_ownership_claim (repository_ownership_claim_id, repository_id, user_id, method, token, status)
+values (:'claim3ID', :'repo3ID', :'user1ID', 'dns', 'token3', 'verified');
+
+-- Run some tests
+select is(
+    get_pending_repository_ownership_claims()::jsonb,
+    '[
+        {
+            "repository_ownership_claim_id": "00000000-0000-0000-0000-000000000002",
+            "repository_url": "https://repo2.com",
+            "method": "http",
+            "token": "token2"
+        },
+        {
+            "repository_ownership_claim_id": "00000000-0000-0000-0000-000000000001",
+            "repository_url": "https://repo1.com",
+            "method": "dns",
+            "token": "token1"
+        }
+    ]'::jsonb,
+    'Pending claims should be returned, the least recently checked first'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
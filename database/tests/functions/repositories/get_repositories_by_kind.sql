@@ -39,6 +39,8 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "user_alias": "user1"
     }, {
         "repository_id": "00000000-0000-0000-0000-000000000002",
@@ -50,6 +52,8 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "user_alias": "user1"
     }]'::jsonb,
     'Repositories 1 and 2 are returned'
@@ -66,6 +70,8 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "user_alias": "user1"
     }]'::jsonb,
     'Repository 3 is returned'
@@ -21,8 +21,12 @@ insert into repository (
     display_name,
     url,
     branch,
+    path,
     auth_user,
     auth_pass,
+    auth_pass_ref,
+    tls_config,
+    proxy_url,
     digest,
     repository_kind_id,
     user_id,
@@ -37,15 +41,19 @@ values (
     'Repo 1',
     'https://repo1.com',
     'main',
+    'charts',
     'user1',
     'pass1',
+    'kubernetes:repo1-creds#password',
+    'tls_config',
+    'http://proxy.com:3128',
     'digest',
     0,
     :'user1ID',
     '2020-06-16 11:20:34+02',
-    'error1\nerror2\n',
+    '[{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}]',
     '2020-06-16 11:20:34+02',
-    'error1\nerror2\n'
+    '[{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}]'
 );
 
 -- One repository has just been seeded
@@ -57,16 +65,21 @@ select is(
         "display_name": "Repo 1",
         "url": "https://repo1.com",
         "branch": "main",
+        "path": "charts",
         "kind": 0,
         "verified_publisher": false,
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "digest": "digest",
         "last_scanning_ts": 1592299234,
-        "last_scanning_errors": "error1\\nerror2\\n",
+        "last_scanning_errors": "error1\nerror2",
+        "scanning_errors": [{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}],
         "last_tracking_ts": 1592299234,
-        "last_tracking_errors": "error1\\nerror2\\n",
+        "last_tracking_errors": "error1\nerror2",
+        "tracking_errors": [{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}],
         "user_alias": "user1"
     }'::jsonb,
     'Repository just seeded is returned as a json object'
@@ -79,18 +92,26 @@ select is(
         "display_name": "Repo 1",
         "url": "https://repo1.com",
         "branch": "main",
+        "path": "charts",
         "auth_user": "user1",
         "auth_pass": "pass1",
+        "auth_pass_ref": "kubernetes:repo1-creds#password",
+        "tls_config": "tls_config",
+        "proxy_url": "http://proxy.com:3128",
         "kind": 0,
         "verified_publisher": false,
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "digest": "digest",
         "last_scanning_ts": 1592299234,
-        "last_scanning_errors": "error1\\nerror2\\n",
+        "last_scanning_errors": "error1\nerror2",
+        "scanning_errors": [{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}],
         "last_tracking_ts": 1592299234,
-        "last_tracking_errors": "error1\\nerror2\\n",
+        "last_tracking_errors": "error1\nerror2",
+        "tracking_errors": [{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}],
         "user_alias": "user1"
     }'::jsonb,
     'Repository just seeded is returned as a json object which includes the credentials'
@@ -31,6 +31,8 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "user_alias": "user1"
     }'::jsonb,
     'Repository just seeded is returned as a json object'
@@ -0,0 +1,52 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set claim1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into repository_ownership_claim (
+    repository_ownership_claim_id,
+    repository_id,
+    user_id,
+    method,
+    token,
+    created_at
+) values (
+    :'claim1ID',
+    :'repo1ID',
+    :'user1ID',
+    'dns',
+    'token',
+    '2021-05-01 10:00:00+00'
+);
+
+-- Run some tests
+select is(
+    get_repository_ownership_claim(:'user1ID', 'repo1')::jsonb,
+    '{
+        "repository_ownership_claim_id": "00000000-0000-0000-0000-000000000001",
+        "repository_url": "https://repo1.com",
+        "method": "dns",
+        "token": "token",
+        "status": "pending",
+        "created_at": 1619863200
+    }'::jsonb,
+    'Claim requested by the user should be returned'
+);
+select is_empty(
+    $$ select get_repository_ownership_claim('00000000-0000-0000-0000-000000000002', 'repo1') $$,
+    'No claim should be returned for a user who has not requested one'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
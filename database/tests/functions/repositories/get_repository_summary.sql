@@ -1,11 +1,13 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
 \set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+\set repo4ID '00000000-0000-0000-0000-000000000004'
 
 -- Non existing repository
 select is_empty(
@@ -52,6 +54,42 @@ values (
     0,
     :'user1ID'
 );
+insert into repository (
+    repository_id,
+    name,
+    display_name,
+    url,
+    tls_config,
+    repository_kind_id,
+    user_id
+)
+values (
+    :'repo3ID',
+    'repo3',
+    'Repo 3',
+    'https://repo3.com',
+    'tls_config',
+    0,
+    :'user1ID'
+);
+insert into repository (
+    repository_id,
+    name,
+    display_name,
+    url,
+    auth_pass_ref,
+    repository_kind_id,
+    user_id
+)
+values (
+    :'repo4ID',
+    'repo4',
+    'Repo 4',
+    'https://repo4.com',
+    'kubernetes:repo4-creds#password',
+    0,
+    :'user1ID'
+);
 
 -- One repository has just been seeded
 select is(
@@ -84,6 +122,36 @@ select is(
     }'::jsonb,
     'Repository 2 is returned as a json object'
 );
+select is(
+    get_repository_summary('00000000-0000-0000-0000-000000000003')::jsonb,
+    '{
+        "repository_id": "00000000-0000-0000-0000-000000000003",
+        "name": "repo3",
+        "display_name": "Repo 3",
+        "url": "https://repo3.com",
+        "private": true,
+        "kind": 0,
+        "verified_publisher": false,
+        "official": false,
+        "user_alias": "user1"
+    }'::jsonb,
+    'Repository 3 is returned as a json object'
+);
+select is(
+    get_repository_summary('00000000-0000-0000-0000-000000000004')::jsonb,
+    '{
+        "repository_id": "00000000-0000-0000-0000-000000000004",
+        "name": "repo4",
+        "display_name": "Repo 4",
+        "url": "https://repo4.com",
+        "private": true,
+        "kind": 0,
+        "verified_publisher": false,
+        "official": false,
+        "user_alias": "user1"
+    }'::jsonb,
+    'Repository 4 is returned as a json object'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -0,0 +1,138 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set run1ID '00000000-0000-0000-0000-000000000001'
+\set run2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+
+-- Repository without runs
+select is(
+    get_repository_tracking_status(:'user1ID', 'repo1')::jsonb,
+    '{
+        "runs": []
+    }'::jsonb,
+    'Status of a repository without runs should only include an empty list of runs'
+);
+
+-- Repository with some runs
+insert into repository_tracking_run (
+    repository_tracking_run_id,
+    repository_id,
+    status,
+    packages_total,
+    packages_processed,
+    errors,
+    created_at,
+    started_at,
+    finished_at
+) values (
+    :'run1ID',
+    :'repo1ID',
+    'completed',
+    10,
+    10,
+    'error registering package',
+    '2021-03-10 10:00:00+00',
+    '2021-03-10 10:00:05+00',
+    '2021-03-10 10:01:05+00'
+);
+insert into repository_tracking_run (
+    repository_tracking_run_id,
+    repository_id,
+    status,
+    packages_total,
+    packages_processed,
+    created_at,
+    started_at
+) values (
+    :'run2ID',
+    :'repo1ID',
+    'running',
+    10,
+    4,
+    '2021-03-10 10:30:00+00',
+    '2021-03-10 10:30:05+00'
+);
+select is(
+    get_repository_tracking_status(:'user1ID', 'repo1')::jsonb,
+    '{
+        "status": "running",
+        "packages_total": 10,
+        "packages_processed": 4,
+        "last_run_duration": 60,
+        "runs": [
+            {
+                "tracking_run_id": "00000000-0000-0000-0000-000000000002",
+                "status": "running",
+                "packages_total": 10,
+                "packages_processed": 4,
+                "queued_ts": 1615372200,
+                "started_ts": 1615372205
+            },
+            {
+                "tracking_run_id": "00000000-0000-0000-0000-000000000001",
+                "status": "completed",
+                "packages_total": 10,
+                "packages_processed": 10,
+                "errors": "error registering package",
+                "queued_ts": 1615370400,
+                "started_ts": 1615370405,
+                "finished_ts": 1615370465,
+                "duration": 60
+            }
+        ]
+    }'::jsonb,
+    'Status should include the latest run progress and the recent runs'
+);
+
+-- Repository owned by an organization the user belongs to
+select is(
+    get_repository_tracking_status(:'user1ID', 'repo2')::jsonb,
+    '{
+        "runs": []
+    }'::jsonb,
+    'Organization members should be able to get the repository tracking status'
+);
+
+-- Repository that does not exist
+select is_empty(
+    $$ select get_repository_tracking_status('00000000-0000-0000-0000-000000000001', 'repo3') $$,
+    'No status should be returned for a repository that does not exist'
+);
+
+-- Try to get the status of repositories not owned by the requesting user
+select throws_ok(
+    $$ select get_repository_tracking_status('00000000-0000-0000-0000-000000000002', 'repo1') $$,
+    42501,
+    'insufficient_privilege',
+    'Status request should fail because requesting user is not the owner'
+);
+select throws_ok(
+    $$ select get_repository_tracking_status('00000000-0000-0000-0000-000000000002', 'repo2') $$,
+    42501,
+    'insufficient_privilege',
+    'Status request should fail because requesting user does not belong to owning organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -35,7 +35,7 @@ insert into repository (
     'Repo 1',
     'https://repo1.com',
     '1970-01-01 00:00:00 UTC',
-    'error1\nerror2\nerror3',
+    '[{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}]',
     0,
     :'user1ID'
 );
@@ -83,8 +83,11 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "last_tracking_ts": 0,
-        "last_tracking_errors": "error1\\nerror2\\nerror3",
+        "last_tracking_errors": "error1\nerror2",
+        "tracking_errors": [{"class": "package", "package_name": "pkg1", "package_version": "1.0.0", "message": "error1", "ts": 1}, {"class": "repository", "message": "error2", "ts": 1}],
         "user_alias": "user1"
     }, {
         "repository_id": "00000000-0000-0000-0000-000000000002",
@@ -96,6 +99,8 @@ select is(
         "official": false,
         "disabled": false,
         "scanner_disabled": false,
+        "tracking_enabled": true,
+        "tracking_webhook_enabled": false,
         "user_alias": "user1"
     }]'::jsonb,
     'Repositories belonging to user provided are returned as a json array of objects'
@@ -0,0 +1,47 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set claim1ID '00000000-0000-0000-0000-000000000001'
+\set claim2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0);
+insert into repository (repository_id, name, display_name, url, repository_kind_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0);
+insert into repository_ownership_claim (repository_ownership_claim_id, repository_id, user_id, method, token)
+values (:'claim1ID', :'repo1ID', :'user1ID', 'dns', 'token1');
+insert into repository_ownership_claim (repository_ownership_claim_id, repository_id, user_id, method, token, created_at)
+values (:'claim2ID', :'repo2ID', :'user1ID', 'dns', 'token2', current_timestamp - '8 days'::interval);
+
+-- Run some tests
+select register_repository_ownership_claim_check(:'claim1ID', 'record not found');
+select register_repository_ownership_claim_check(:'claim2ID', 'record not found');
+select results_eq(
+    $$
+        select status, last_error, checked_at is not null
+        from repository_ownership_claim
+        where repository_ownership_claim_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values ('pending', 'record not found', true) $$,
+    'Recent claim should remain pending with the check error registered'
+);
+select results_eq(
+    $$
+        select status
+        from repository_ownership_claim
+        where repository_ownership_claim_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$ values ('expired') $$,
+    'Claim requested more than a week ago should be expired'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,57 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set run1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository_tracking_run (repository_tracking_run_id, repository_id, status, created_at, started_at)
+values (:'run1ID', :'repo1ID', 'running', '2021-03-10 10:00:00+00', '2021-03-10 10:00:05+00');
+
+-- Register a new run while the previous one is still running
+select register_repository_tracking_run(:'repo1ID');
+select results_eq(
+    $$
+        select status, errors, finished_at is not null
+        from repository_tracking_run
+        where repository_tracking_run_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('completed', 'tracking run interrupted', true)
+    $$,
+    'Previous unfinished run should have been marked as interrupted'
+);
+select results_eq(
+    $$
+        select status, packages_total, packages_processed, started_at is null
+        from repository_tracking_run
+        where repository_id = '00000000-0000-0000-0000-000000000001'
+        and repository_tracking_run_id <> '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('queued', 0, 0, true)
+    $$,
+    'New run should have been registered as queued'
+);
+select is(count(*), 2::bigint, 'Repository should have two runs')
+from repository_tracking_run where repository_id = :'repo1ID';
+
+-- Register some more runs so that the oldest ones are removed
+select register_repository_tracking_run(:'repo1ID') from generate_series(1, 10);
+select is(count(*), 10::bigint, 'Only the 10 most recent runs should have been kept')
+from repository_tracking_run where repository_id = :'repo1ID';
+select is(count(*), 0::bigint, 'Oldest run should have been removed')
+from repository_tracking_run where repository_tracking_run_id = :'run1ID';
+select is(count(*), 1::bigint, 'Only one run should be queued')
+from repository_tracking_run where repository_id = :'repo1ID' and status = 'queued';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
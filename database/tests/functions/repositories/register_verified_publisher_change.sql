@@ -0,0 +1,45 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+
+-- Register a change from the metadata file that does not modify the flag
+select register_verified_publisher_change(:'repo1ID', null, 'metadata', false, null);
+select is_empty(
+    $$ select * from verified_publisher_change $$,
+    'No change should be registered when the flag does not change'
+);
+
+-- Register a change from the metadata file that modifies the flag
+select register_verified_publisher_change(:'repo1ID', null, 'metadata', true, null);
+select results_eq(
+    $$ select source, verified from verified_publisher_change $$,
+    $$ values ('metadata', true) $$,
+    'Change should be registered'
+);
+select results_eq(
+    $$ select event_kind_id, data from event $$,
+    $$ values (11, '{"verified": true, "source": "metadata"}'::jsonb) $$,
+    'Event should be registered'
+);
+
+-- Register a decision from a site administrator that does not modify the flag
+select register_verified_publisher_change(:'repo1ID', :'user1ID', 'admin', true, 'evidence');
+select results_eq(
+    $$ select count(*) from verified_publisher_change where source = 'admin' $$,
+    $$ values (1::bigint) $$,
+    'Site administrators decisions should always be registered'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,55 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select request_repository_ownership_claim('00000000-0000-0000-0000-000000000001', 'repo2', null, 'dns', 'token') $$,
+    'P0002',
+    'no_data_found',
+    'Claim should fail when the repository does not exist'
+);
+select throws_ok(
+    $$ select request_repository_ownership_claim('00000000-0000-0000-0000-000000000002', 'repo1', 'org1', 'dns', 'token') $$,
+    42501,
+    'insufficient_privilege',
+    'Claim should fail when the user does not belong to the destination organization'
+);
+select lives_ok(
+    $$ select request_repository_ownership_claim('00000000-0000-0000-0000-000000000001', 'repo1', null, 'dns', 'token1') $$,
+    'Claim should succeed'
+);
+update repository_ownership_claim set status = 'expired', last_error = 'error';
+select lives_ok(
+    $$ select request_repository_ownership_claim('00000000-0000-0000-0000-000000000001', 'repo1', 'org1', 'http', 'token2') $$,
+    'Claim should be requested again'
+);
+select results_eq(
+    $$
+        select organization_id, method, token, status, last_error
+        from repository_ownership_claim
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, 'http', 'token2', 'pending', null::text)
+    $$,
+    'Previous claim should have been replaced by a new pending one'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
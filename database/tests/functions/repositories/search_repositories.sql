@@ -0,0 +1,106 @@
+-- Start transaction and plan tests
+begin;
+select plan(9);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set repo3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo3ID', 'repo3', 'Repo 3', 'https://repo3.com', 1, :'user1ID');
+
+-- Run some tests
+select is(
+    search_repositories('{"limit": 1, "offset": 0}')::jsonb #- '{metadata,next_cursor}',
+    '{
+        "repositories": [{
+            "repository_id": "00000000-0000-0000-0000-000000000001",
+            "name": "repo1",
+            "display_name": "Repo 1",
+            "url": "https://repo1.com",
+            "kind": 0,
+            "verified_publisher": false,
+            "official": false,
+            "disabled": false,
+            "scanner_disabled": false,
+            "tracking_enabled": true,
+            "tracking_webhook_enabled": false,
+            "user_alias": "user1"
+        }],
+        "metadata": {
+            "limit": 1,
+            "offset": 0,
+            "total": 3
+        }
+    }'::jsonb,
+    'First page of repositories should be returned'
+);
+select is(
+    decode_cursor(search_repositories('{"limit": 1, "offset": 0}')::jsonb #>> '{metadata,next_cursor}'),
+    jsonb_build_object('name', 'repo1', 'scope', get_cursor_scope('{}')),
+    'Next cursor should point to the last repository returned'
+);
+select is(
+    search_repositories(jsonb_build_object(
+        'limit', 1,
+        'cursor', search_repositories('{"limit": 1}')::jsonb #>> '{metadata,next_cursor}'
+    ))::jsonb->'repositories'->0->'name',
+    '"repo2"'::jsonb,
+    'Page after the cursor provided should be returned'
+);
+select is(
+    search_repositories(jsonb_build_object(
+        'limit', 2,
+        'cursor', search_repositories('{"limit": 1}')::jsonb #>> '{metadata,next_cursor}'
+    ))::jsonb->'metadata',
+    '{"limit": 2, "offset": 0, "total": 3}'::jsonb,
+    'Next cursor should not be returned in the last page'
+);
+select throws_ok(
+    format(
+        'select search_repositories(%L)',
+        jsonb_build_object(
+            'limit', 1,
+            'kinds', '[1]'::jsonb,
+            'cursor', search_repositories('{"limit": 1}')::jsonb #>> '{metadata,next_cursor}'
+        )
+    ),
+    '22023',
+    'invalid cursor',
+    'Cursor obtained using different filters should be rejected'
+);
+select throws_ok(
+    $$select search_repositories('{"limit": 1, "cursor": "eyJuYW1lIjogInJlcG8xIn0"}')$$,
+    '22023',
+    'invalid cursor',
+    'Cursor not bound to any search input should be rejected'
+);
+select throws_ok(
+    $$select search_repositories('{"limit": 1, "cursor": "not a cursor"}')$$,
+    '22023',
+    'invalid cursor',
+    'Cursor that cannot be decoded should be rejected'
+);
+select is(
+    search_repositories('{"limit": 10, "kinds": [1]}')::jsonb->'metadata'->'total',
+    '1'::jsonb,
+    'Only repositories of the kinds provided should be returned'
+);
+select is(
+    search_repositories('{"limit": 10, "name": "repo 2"}')::jsonb->'repositories'->0->'name',
+    '"repo2"'::jsonb,
+    'Only repositories matching the name provided should be returned'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(15);
+select plan(19);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -17,47 +17,63 @@ select is(last_tracking_ts, null, 'Last tracking ts should have not been set yet
 from repository where name = 'repo1';
 select is(last_tracking_errors, null, 'Last tracking errors should have not been set yet')
 from repository where name = 'repo1';
+select is(last_successful_tracking_ts, null, 'Last successful tracking ts should have not been set yet')
+from repository where name = 'repo1';
 select is(count(*), 0::bigint, 'No tracking errors events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results and run some more tests
-select set_last_tracking_results(:'repo1ID', '', true);
+select set_last_tracking_results(:'repo1ID', '[]', true);
 select isnt(last_tracking_ts, null, 'Last tracking ts should have been set')
 from repository where name = 'repo1';
 select is(last_tracking_errors, null, 'Last tracking errors should have been set to null')
 from repository where name = 'repo1';
+select isnt(last_successful_tracking_ts, null, 'Last successful tracking ts should have been set')
+from repository where name = 'repo1';
 select is(count(*), 0::bigint, 'No tracking errors events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some errors', true);
-select is(last_tracking_errors, 'some errors', 'Last tracking errors should have been set to some errors')
+update repository set last_successful_tracking_ts = '2020-06-16 11:20:34+02' where name = 'repo1';
+select set_last_tracking_results(:'repo1ID', '[{"class": "package", "message": "some errors", "ts": 1}]', true);
+select is(last_tracking_errors, '[{"class": "package", "message": "some errors", "ts": 1}]'::jsonb, 'Last tracking errors should have been set to some errors')
+from repository where name = 'repo1';
+select is(
+    last_successful_tracking_ts,
+    '2020-06-16 11:20:34+02'::timestamptz,
+    'Last successful tracking ts should not have been updated'
+)
 from repository where name = 'repo1';
 select is(count(*), 1::bigint, 'One tracking error event should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with the same error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some errors', true);
+select set_last_tracking_results(:'repo1ID', '[{"class": "package", "message": "some errors", "ts": 1}]', true);
+select is(count(*), 1::bigint, 'No more tracking error events should have been registered')
+from event where repository_id=:'repo1ID' and event_kind_id = 2;
+
+-- Set last tracking results again with the same error at a different time
+select set_last_tracking_results(:'repo1ID', '[{"class": "package", "message": "some errors", "ts": 2}]', true);
 select is(count(*), 1::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with another error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some new errors', true);
-select is(last_tracking_errors, 'some new errors', 'Last tracking errors should have been set to some new errors')
+select set_last_tracking_results(:'repo1ID', '[{"class": "package", "message": "some new errors", "ts": 3}]', true);
+select is(last_tracking_errors, '[{"class": "package", "message": "some new errors", "ts": 3}]'::jsonb, 'Last tracking errors should have been set to some new errors')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'One more tracking error event should have been registered (total 2 now)')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with no errors and run some more tests
-select set_last_tracking_results(:'repo1ID', '', true);
+select set_last_tracking_results(:'repo1ID', '[]', true);
 select is(last_tracking_errors, null, 'Last tracking errors should have been set to null')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
 
 -- Set last tracking results again with another error and run some more tests
-select set_last_tracking_results(:'repo1ID', 'some new errors', false);
-select is(last_tracking_errors, 'some new errors', 'Last tracking errors should have been set to some new errors')
+select set_last_tracking_results(:'repo1ID', '[{"class": "package", "message": "some new errors", "ts": 3}]', false);
+select is(last_tracking_errors, '[{"class": "package", "message": "some new errors", "ts": 3}]'::jsonb, 'Last tracking errors should have been set to some new errors')
 from repository where name = 'repo1';
 select is(count(*), 2::bigint, 'No more tracking error events should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 2;
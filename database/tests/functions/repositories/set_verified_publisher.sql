@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(4);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -20,6 +20,18 @@ from repository where name = 'repo1';
 select set_verified_publisher(:'repo1ID', true);
 select is(verified_publisher, true, 'Verified publisher should be now true')
 from repository where name = 'repo1';
+select results_eq(
+    $$ select event_kind_id from event where repository_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (11) $$,
+    'Verified publisher change event should have been registered'
+);
+
+-- Set verified publisher when a site administrator has decided on it
+insert into verified_publisher_change (repository_id, source, verified, evidence, created_at)
+values (:'repo1ID', 'admin', true, 'evidence', current_timestamp + '1 minute'::interval);
+select set_verified_publisher(:'repo1ID', false);
+select is(verified_publisher, true, 'Verified publisher should still be true')
+from repository where name = 'repo1';
 
 -- Finish tests and rollback transaction
 select * from finish();
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(13);
+select plan(17);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -10,6 +10,7 @@ select plan(13);
 \set org3ID '00000000-0000-0000-0000-000000000003'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
 \set repo2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
 
 -- Seed some data
 insert into "user" (user_id, alias, email)
@@ -24,10 +25,14 @@ insert into organization (organization_id, name, display_name, description, home
 values (:'org3ID', 'org3', 'Organization 3', 'Description 3', 'https://org3.com');
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org3ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values(:'user2ID', :'org3ID', true);
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
 insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
 values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into team (team_id, organization_id, name) values (:'team1ID', :'org1ID', 'team1');
+insert into team__repository (team_id, repository_id, allowed_actions)
+values (:'team1ID', :'repo2ID', '{updateOrganizationRepository}');
 
 -- Transfers NOT part of an ownership claim request
 
@@ -97,6 +102,25 @@ select results_eq(
 );
 select is(count(*), 0::bigint, 'No repository ownership claim events should have been registered')
 from event where repository_id=:'repo2ID' and event_kind_id = 3;
+select is_empty(
+    $$ select * from team__repository $$,
+    'Access granted to org1 teams should have been revoked'
+);
+select results_eq(
+    $$
+        select data
+        from event
+        where repository_id = '00000000-0000-0000-0000-000000000002'
+        and event_kind_id = 8
+    $$,
+    $$
+        values ('{
+            "subscriptors": [],
+            "previous_owner": {"organization_name": "org1"}
+        }'::jsonb)
+    $$,
+    'Repository ownership transfer event should have been registered'
+);
 select transfer_repository(
     'repo2',
     '00000000-0000-0000-0000-000000000001',
@@ -124,6 +148,23 @@ select results_eq(
 );
 select is(count(*), 0::bigint, 'No repository ownership claim events should have been registered')
 from event where repository_id=:'repo2ID' and event_kind_id = 3;
+select results_eq(
+    $$
+        select data
+        from event
+        where repository_id = '00000000-0000-0000-0000-000000000002'
+        and event_kind_id = 8
+        and data->'previous_owner'->>'organization_name' = 'org1'
+        and jsonb_array_length(data->'subscriptors') > 0
+    $$,
+    $$
+        values ('{
+            "subscriptors": [{"user_id": "00000000-0000-0000-0000-000000000002"}],
+            "previous_owner": {"organization_name": "org1"}
+        }'::jsonb)
+    $$,
+    'Repository ownership transfer event should notify the members of the new owner'
+);
 
 -- Transfer user owned repository to org
 select transfer_repository(
@@ -202,6 +243,8 @@ select results_eq(
 );
 select is(count(*), 2::bigint, 'Another repository ownership claim event should have been registered')
 from event where repository_id=:'repo1ID' and event_kind_id = 3;
+select is(count(*), 1::bigint, 'Ownership claims should not register repository ownership transfer events')
+from event where repository_id=:'repo1ID' and event_kind_id = 8;
 
 -- Finish tests and rollback transaction
 select * from finish();
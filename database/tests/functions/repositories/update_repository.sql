@@ -19,8 +19,8 @@ values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com'
 insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
-insert into repository (repository_id, name, display_name, url, branch, repository_kind_id, organization_id)
-values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 'main', 0, :'org1ID');
+insert into repository (repository_id, name, display_name, url, branch, tls_config, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 'main', 'tls_config', 0, :'org1ID');
 insert into package (
     package_id,
     name,
@@ -106,20 +106,25 @@ select update_repository(:'user1ID', '
     "display_name": "Repo 1 updated",
     "url": "https://repo1.com/updated",
     "branch": "main",
+    "path": "charts",
     "auth_user": "user1",
     "auth_pass": "pass1",
+    "tls_config": "tls_config",
+    "proxy_url": "http://proxy.com:3128",
     "disabled": true,
-    "scanner_disabled": false
+    "scanner_disabled": false,
+    "tracking_schedule": "0 */6 * * *",
+    "retention_policy": {"max_versions": 5}
 }
 '::jsonb);
 select results_eq(
     $$
-        select name, display_name, url, branch, auth_user, auth_pass, disabled
+        select name, display_name, url, branch, path, auth_user, auth_pass, tls_config, proxy_url, disabled, tracking_schedule, retention_policy
         from repository
         where name = 'repo1'
     $$,
     $$
-        values ('repo1', 'Repo 1 updated', 'https://repo1.com/updated', 'main', 'user1', 'pass1', true)
+        values ('repo1', 'Repo 1 updated', 'https://repo1.com/updated', 'main', 'charts', 'user1', 'pass1', 'tls_config', 'http://proxy.com:3128', true, '0 */6 * * *', '{"max_versions": 5}'::jsonb)
     $$,
     'Repository should have been updated by user who owns it'
 );
@@ -136,19 +141,19 @@ select update_repository(:'user1ID', '
     "display_name": "Repo 2 updated",
     "url": "https://repo2.com/updated",
     "auth_user": "user1",
-    "auth_pass": "pass1",
+    "auth_pass_ref": "vault:repo2-creds#password",
     "disabled": false,
     "scanner_disabled": true
 }
 '::jsonb);
 select results_eq(
     $$
-        select name, display_name, url, branch, auth_user, auth_pass, disabled
+        select name, display_name, url, branch, auth_user, auth_pass, auth_pass_ref, tls_config, disabled
         from repository
         where name = 'repo2'
     $$,
     $$
-        values ('repo2', 'Repo 2 updated', 'https://repo2.com/updated', null, 'user1', 'pass1', false)
+        values ('repo2', 'Repo 2 updated', 'https://repo2.com/updated', null, 'user1', null, 'vault:repo2-creds#password', null, false)
     $$,
     'Repository should have been updated by user who belongs to owning organization'
 );
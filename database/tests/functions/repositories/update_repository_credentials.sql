@@ -0,0 +1,95 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, auth_user, auth_pass, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 'user1', 'pass1', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, auth_user, auth_pass_ref, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 'user1', 'kubernetes:repo2-creds#password', 0, :'org1ID');
+
+-- Try to update the credentials of a repository that does not exist
+select throws_ok(
+    $$
+        select update_repository_credentials('00000000-0000-0000-0000-000000000001', 'repo3', '{}')
+    $$,
+    'P0002',
+    'no_data_found',
+    'Repository credentials update should fail because the repository does not exist'
+);
+
+-- Try to update the credentials of a repository owned by a user by other user
+select throws_ok(
+    $$
+        select update_repository_credentials('00000000-0000-0000-0000-000000000002', 'repo1', '{"auth_pass": "pass2"}')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository credentials update should fail because requesting user is not the owner'
+);
+
+-- Try to update the credentials of a repository owned by organization by
+-- user not belonging to it
+select throws_ok(
+    $$
+        select update_repository_credentials('00000000-0000-0000-0000-000000000002', 'repo2', '{"auth_pass": "pass2"}')
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository credentials update should fail because requesting user does not belong to owning organization'
+);
+
+-- Replace the password of a repository owned by the user with a reference
+select update_repository_credentials(:'user1ID', 'repo1', '
+{
+    "auth_user": "user2",
+    "auth_pass_ref": "vault:repo1-creds#password"
+}
+');
+select results_eq(
+    $$
+        select auth_user, auth_pass, auth_pass_ref
+        from repository
+        where name = 'repo1'
+    $$,
+    $$
+        values ('user2', null, 'vault:repo1-creds#password')
+    $$,
+    'Repository credentials should have been updated by user who owns it'
+);
+
+-- Rotate the password of a repository owned by organization (requesting user
+-- belongs to organization)
+select update_repository_credentials(:'user1ID', 'repo2', '
+{
+    "auth_user": "user1",
+    "auth_pass": "pass2"
+}
+');
+select results_eq(
+    $$
+        select auth_user, auth_pass, auth_pass_ref
+        from repository
+        where name = 'repo2'
+    $$,
+    $$
+        values ('user1', 'pass2', null::text)
+    $$,
+    'Repository credentials should have been updated by user who belongs to owning organization'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,76 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+
+-- Try to pause the tracking of a repository that does not exist
+select throws_ok(
+    $$
+        select update_repository_tracking_enabled('00000000-0000-0000-0000-000000000001', 'repo3', false)
+    $$,
+    'P0002',
+    'no_data_found',
+    'Repository tracking update should fail because the repository does not exist'
+);
+
+-- Try to pause the tracking of a repository owned by a user by other user
+select throws_ok(
+    $$
+        select update_repository_tracking_enabled('00000000-0000-0000-0000-000000000002', 'repo1', false)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository tracking update should fail because requesting user is not the owner'
+);
+
+-- Try to pause the tracking of a repository owned by organization by user not
+-- belonging to it
+select throws_ok(
+    $$
+        select update_repository_tracking_enabled('00000000-0000-0000-0000-000000000002', 'repo2', false)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository tracking update should fail because requesting user does not belong to owning organization'
+);
+
+-- Pause the tracking of a repository owned by the user
+select update_repository_tracking_enabled(:'user1ID', 'repo1', false);
+select is(tracking_enabled, false, 'Repository tracking should have been paused')
+from repository where name = 'repo1';
+select is(count(*), 1::bigint, 'Repository packages should have been kept')
+from package where repository_id = :'repo1ID';
+
+-- Resume the tracking of the repository owned by the user
+select update_repository_tracking_enabled(:'user1ID', 'repo1', true);
+select is(tracking_enabled, true, 'Repository tracking should have been resumed')
+from repository where name = 'repo1';
+
+-- Pause the tracking of a repository owned by an organization the user belongs to
+select update_repository_tracking_enabled(:'user1ID', 'repo2', false);
+select is(tracking_enabled, false, 'Organization repository tracking should have been paused')
+from repository where name = 'repo2';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,76 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set run1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository_tracking_run (repository_tracking_run_id, repository_id, status)
+values (:'run1ID', :'repo1ID', 'queued');
+
+-- Start run
+select update_repository_tracking_run('{
+    "tracking_run_id": "00000000-0000-0000-0000-000000000001",
+    "status": "running"
+}');
+select results_eq(
+    $$
+        select status, packages_total, packages_processed, started_at is not null, finished_at is null
+        from repository_tracking_run
+        where repository_tracking_run_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('running', 0, 0, true, true)
+    $$,
+    'Run should have been started'
+);
+
+-- Update run progress
+select update_repository_tracking_run('{
+    "tracking_run_id": "00000000-0000-0000-0000-000000000001",
+    "status": "running",
+    "packages_total": 10,
+    "packages_processed": 4
+}');
+select results_eq(
+    $$
+        select status, packages_total, packages_processed, finished_at is null
+        from repository_tracking_run
+        where repository_tracking_run_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('running', 10, 4, true)
+    $$,
+    'Run progress should have been updated'
+);
+
+-- Complete run
+select update_repository_tracking_run('{
+    "tracking_run_id": "00000000-0000-0000-0000-000000000001",
+    "status": "completed",
+    "packages_total": 10,
+    "packages_processed": 10,
+    "errors": "error cloning repository"
+}');
+select results_eq(
+    $$
+        select status, packages_total, packages_processed, errors, finished_at is not null
+        from repository_tracking_run
+        where repository_tracking_run_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('completed', 10, 10, 'error cloning repository', true)
+    $$,
+    'Run should have been completed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,89 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+
+-- Try to enable the tracking webhook of a repository that does not exist
+select throws_ok(
+    $$
+        select update_repository_tracking_webhook('00000000-0000-0000-0000-000000000001', 'repo3', true)
+    $$,
+    'P0002',
+    'no_data_found',
+    'Repository tracking webhook update should fail because the repository does not exist'
+);
+
+-- Try to enable the tracking webhook of a repository owned by a user by other user
+select throws_ok(
+    $$
+        select update_repository_tracking_webhook('00000000-0000-0000-0000-000000000002', 'repo1', true)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository tracking webhook update should fail because requesting user is not the owner'
+);
+
+-- Try to enable the tracking webhook of a repository owned by organization by
+-- user not belonging to it
+select throws_ok(
+    $$
+        select update_repository_tracking_webhook('00000000-0000-0000-0000-000000000002', 'repo2', true)
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Repository tracking webhook update should fail because requesting user does not belong to owning organization'
+);
+
+-- Enable the tracking webhook of a repository owned by the user
+select is(
+    update_repository_tracking_webhook(:'user1ID', 'repo1', true),
+    (select tracking_webhook_secret from repository where name = 'repo1'),
+    'Tracking webhook secret generated should be returned'
+);
+select matches(
+    tracking_webhook_secret,
+    '^[0-9a-f]{64}$',
+    'Tracking webhook secret should have been generated'
+)
+from repository where name = 'repo1';
+
+-- Regenerate the tracking webhook secret
+create temporary table previous_secret as
+select tracking_webhook_secret as secret from repository where name = 'repo1';
+select update_repository_tracking_webhook(:'user1ID', 'repo1', true);
+select isnt(
+    tracking_webhook_secret,
+    (select secret from previous_secret),
+    'Tracking webhook secret should have been regenerated'
+)
+from repository where name = 'repo1';
+
+-- Disable the tracking webhook of the repository owned by the user
+select is(
+    update_repository_tracking_webhook(:'user1ID', 'repo1', false),
+    null,
+    'No secret should be returned when disabling the tracking webhook'
+);
+select is(tracking_webhook_secret, null, 'Tracking webhook secret should have been removed')
+from repository where name = 'repo1';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,47 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set claim1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into repository_ownership_claim (repository_ownership_claim_id, repository_id, user_id, organization_id, method, token)
+values (:'claim1ID', :'repo1ID', :'user1ID', :'org1ID', 'dns', 'token');
+
+-- Run some tests
+select verify_repository_ownership_claim(:'claim1ID');
+select results_eq(
+    $$ select user_id, organization_id from repository where repository_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (null::uuid, '00000000-0000-0000-0000-000000000001'::uuid) $$,
+    'Repository should have been transferred to the organization'
+);
+select results_eq(
+    $$ select status from repository_ownership_claim $$,
+    $$ values ('verified') $$,
+    'Claim should be marked as verified'
+);
+select results_eq(
+    $$ select event_kind_id from event where repository_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (3) $$,
+    'Ownership claim event should have been registered'
+);
+select lives_ok(
+    $$ select verify_repository_ownership_claim('00000000-0000-0000-0000-000000000001') $$,
+    'Verifying a claim already verified should be a no-op'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,99 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email) values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+
+-- Run some tests
+select throws_ok(
+    $$ select add_package_review('00000000-0000-0000-0000-000000000001', '{"package_id": "00000000-0000-0000-0000-000000000001", "rating": 5}') $$,
+    42501,
+    'insufficient_privilege',
+    'Package owners should not be allowed to review their own packages'
+);
+select throws_ok(
+    $$ select add_package_review('00000000-0000-0000-0000-000000000001', '{"package_id": "00000000-0000-0000-0000-000000000002", "rating": 5}') $$,
+    42501,
+    'insufficient_privilege',
+    'Members of the organization owning the package should not be allowed to review it'
+);
+select throws_ok(
+    $$ select add_package_review('00000000-0000-0000-0000-000000000002', '{"package_id": "00000000-0000-0000-0000-000000000009", "rating": 5}') $$,
+    'P0002',
+    'no_data_found',
+    'Packages that do not exist cannot be reviewed'
+);
+select add_package_review(:'user2ID', '{
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "rating": 4,
+    "title": "Great",
+    "body": "Works as expected"
+}');
+select add_package_review(:'user3ID', '{
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "rating": 1
+}');
+select results_eq(
+    $$
+        select user_id, rating, title, body
+        from package_review
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        order by user_id
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002'::uuid, 4::smallint, 'Great', 'Works as expected'),
+            ('00000000-0000-0000-0000-000000000003'::uuid, 1::smallint, null, null)
+    $$,
+    'Reviews should have been added'
+);
+select add_package_review(:'user3ID', '{
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "rating": 3,
+    "title": "Not bad"
+}');
+select results_eq(
+    $$
+        select rating, title
+        from package_review
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and user_id = '00000000-0000-0000-0000-000000000003'
+    $$,
+    $$ values (3::smallint, 'Not bad') $$,
+    'Existing review should have been replaced'
+);
+select results_eq(
+    $$ select rating, ratings from package where package_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (3.50::numeric(3, 2), 2) $$,
+    'Package rating should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
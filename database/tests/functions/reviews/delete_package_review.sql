@@ -0,0 +1,61 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email) values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+
+-- Run some tests
+insert into package_review (package_id, user_id, rating) values (:'package1ID', :'user2ID', 5);
+insert into package_review (package_id, user_id, rating) values (:'package1ID', :'user3ID', 1);
+select update_package_rating(:'package1ID');
+
+select throws_ok(
+    $$ select delete_package_review('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001') $$,
+    'P0002',
+    'no_data_found',
+    'Users can only delete their own reviews'
+);
+select delete_package_review(:'user3ID', :'package1ID');
+select is_empty(
+    $$
+        select * from package_review
+        where package_id = '00000000-0000-0000-0000-000000000001'
+        and user_id = '00000000-0000-0000-0000-000000000003'
+    $$,
+    'Review should have been deleted'
+);
+select results_eq(
+    $$ select rating, ratings from package where package_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (5.00::numeric(3, 2), 1) $$,
+    'Package rating should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,115 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email) values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+
+-- Run some tests
+insert into package_review (package_review_id, package_id, user_id, rating, title, reply, replied_at, created_at, updated_at)
+values ('00000000-0000-0000-0000-000000000001', :'package1ID', :'user2ID', 5, 'Great', 'Thanks!', '2021-05-03 10:00:00+00', '2021-05-01 10:00:00+00', '2021-05-01 10:00:00+00');
+insert into package_review (package_review_id, package_id, user_id, rating, body, created_at, updated_at)
+values ('00000000-0000-0000-0000-000000000002', :'package1ID', :'user3ID', 2, 'Missing docs', '2021-05-02 10:00:00+00', '2021-05-02 10:00:00+00');
+insert into package_review (package_review_id, package_id, user_id, rating, hidden, created_at, updated_at)
+values ('00000000-0000-0000-0000-000000000003', :'package1ID', :'user4ID', 1, true, '2021-05-03 10:00:00+00', '2021-05-03 10:00:00+00');
+
+select is(
+    get_package_reviews(null, :'package1ID', 10, 0)::jsonb,
+    '{
+        "reviews": [
+            {
+                "review_id": "00000000-0000-0000-0000-000000000002",
+                "rating": 2,
+                "body": "Missing docs",
+                "user_alias": "user3",
+                "created_at": 1619949600,
+                "updated_at": 1619949600
+            },
+            {
+                "review_id": "00000000-0000-0000-0000-000000000001",
+                "rating": 5,
+                "title": "Great",
+                "reply": "Thanks!",
+                "replied_at": 1620036000,
+                "user_alias": "user2",
+                "created_at": 1619863200,
+                "updated_at": 1619863200
+            }
+        ],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 2
+        }
+    }'::jsonb,
+    'Visible reviews should be returned, newest first'
+);
+select is(
+    get_package_reviews(:'user4ID', :'package1ID', 1, 1)::jsonb,
+    '{
+        "reviews": [
+            {
+                "review_id": "00000000-0000-0000-0000-000000000001",
+                "rating": 5,
+                "title": "Great",
+                "reply": "Thanks!",
+                "replied_at": 1620036000,
+                "user_alias": "user2",
+                "created_at": 1619863200,
+                "updated_at": 1619863200
+            }
+        ],
+        "user_review": {
+            "review_id": "00000000-0000-0000-0000-000000000003",
+            "rating": 1,
+            "hidden": true
+        },
+        "metadata": {
+            "limit": 1,
+            "offset": 1,
+            "total": 2
+        }
+    }'::jsonb,
+    'Requested page and the review of the requesting user should be returned'
+);
+select is(
+    get_package_reviews(null, :'package2ID', 10, 0)::jsonb,
+    '{
+        "reviews": [],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 0
+        }
+    }'::jsonb,
+    'No reviews should be returned for package2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,86 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email) values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email) values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+
+-- Run some tests
+\set review1ID '00000000-0000-0000-0000-000000000001'
+\set review2ID '00000000-0000-0000-0000-000000000002'
+insert into package_review (package_review_id, package_id, user_id, rating)
+values (:'review1ID', :'package1ID', :'user2ID', 2);
+insert into package_review (package_review_id, package_id, user_id, rating)
+values (:'review2ID', :'package2ID', :'user2ID', 3);
+
+select throws_ok(
+    $$ select reply_package_review('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', 'reply') $$,
+    42501,
+    'insufficient_privilege',
+    'Users not publishing the package should not be allowed to reply to its reviews'
+);
+select throws_ok(
+    $$ select reply_package_review('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', 'reply') $$,
+    'P0002',
+    'no_data_found',
+    'Reviews of other packages cannot be replied'
+);
+select reply_package_review(:'user1ID', :'package1ID', :'review1ID', 'Docs have been improved');
+select results_eq(
+    $$
+        select reply, replied_at is not null
+        from package_review
+        where package_review_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values ('Docs have been improved', true) $$,
+    'Package owner reply should have been set'
+);
+select reply_package_review(:'user1ID', :'package2ID', :'review2ID', 'Thanks');
+select results_eq(
+    $$
+        select reply
+        from package_review
+        where package_review_id = '00000000-0000-0000-0000-000000000002'
+    $$,
+    $$ values ('Thanks') $$,
+    'Organization member reply should have been set'
+);
+select reply_package_review(:'user1ID', :'package1ID', :'review1ID', '');
+select results_eq(
+    $$
+        select reply, replied_at
+        from package_review
+        where package_review_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (null::text, null::timestamptz) $$,
+    'Reply should have been removed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,40 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set savedSearch1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into saved_search (saved_search_id, user_id, name, query)
+values (:'savedSearch1ID', :'user1ID', 'search1', '{"ts_query_web": "kw1"}');
+
+-- Try to delete saved search by non owner
+select delete_saved_search(:'user2ID', :'savedSearch1ID');
+select isnt_empty(
+    $$
+        select *
+        from saved_search
+        where saved_search_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    'Saved search should still exist'
+);
+
+-- Delete saved search
+select delete_saved_search(:'user1ID', :'savedSearch1ID');
+select is_empty(
+    $$
+        select *
+        from saved_search
+        where saved_search_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    'Saved search should not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,121 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set savedSearch1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, tsdoc, repository_id)
+values (
+    :'package1ID',
+    'package1',
+    '1.0.0',
+    generate_package_tsdoc('package1', null, 'description', '{"kw1"}', '{"repo1"}', '{"user1"}'),
+    :'repo1ID'
+);
+insert into snapshot (package_id, version, display_name, ts)
+values (:'package1ID', '1.0.0', 'Package 1', '2020-06-16 11:20:34+02');
+insert into saved_search (saved_search_id, user_id, name, query, notifications_enabled)
+values (:'savedSearch1ID', :'user1ID', 'search1', '{"ts_query_web": "kw1"}', true);
+insert into saved_search_match (saved_search_id, package_id, baseline, seen)
+values (:'savedSearch1ID', :'package1ID', true, true);
+
+-- No new matches yet
+select is(
+    evaluate_saved_searches(),
+    0,
+    'No new matches expected'
+);
+
+-- Add a new package matching the saved search
+insert into package (package_id, name, latest_version, tsdoc, repository_id)
+values (
+    :'package2ID',
+    'package2',
+    '1.0.0',
+    generate_package_tsdoc('package2', null, 'description', '{"kw1"}', '{"repo1"}', '{"user1"}'),
+    :'repo1ID'
+);
+insert into snapshot (package_id, version, display_name, ts)
+values (:'package2ID', '1.0.0', 'Package 2', '2020-06-16 11:20:34+02');
+
+-- Evaluate saved searches again
+select is(
+    evaluate_saved_searches(),
+    1,
+    'One new match expected'
+);
+select results_eq(
+    $$
+        select package_id, baseline, seen
+        from saved_search_match
+        where saved_search_id = '00000000-0000-0000-0000-000000000001'
+        and baseline = false
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000002'::uuid, false, false)
+    $$,
+    'New match should have been registered'
+);
+select results_eq(
+    $$
+        select event_kind_id, data
+        from event
+    $$,
+    $$
+        values (9, '{
+            "saved_search": {
+                "saved_search_id": "00000000-0000-0000-0000-000000000001",
+                "name": "search1"
+            },
+            "new_matches": 1,
+            "packages": [{
+                "package_id": "00000000-0000-0000-0000-000000000002",
+                "name": "package2",
+                "normalized_name": "package2",
+                "version": "1.0.0",
+                "repository": {
+                    "kind": 0,
+                    "name": "repo1"
+                }
+            }],
+            "subscriptors": [{
+                "user_id": "00000000-0000-0000-0000-000000000001"
+            }]
+        }'::jsonb)
+    $$,
+    'Saved search new matches event should have been registered'
+);
+
+-- Disable notifications and add another package matching the saved search
+update saved_search set notifications_enabled = false;
+delete from event;
+insert into package (package_id, name, latest_version, tsdoc, repository_id)
+values (
+    '00000000-0000-0000-0000-000000000003',
+    'package3',
+    '1.0.0',
+    generate_package_tsdoc('package3', null, 'description', '{"kw1"}', '{"repo1"}', '{"user1"}'),
+    :'repo1ID'
+);
+insert into snapshot (package_id, version, display_name, ts)
+values ('00000000-0000-0000-0000-000000000003', '1.0.0', 'Package 3', '2020-06-16 11:20:34+02');
+select evaluate_saved_searches();
+select is_empty(
+    'select * from event',
+    'No events expected when notifications are disabled'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
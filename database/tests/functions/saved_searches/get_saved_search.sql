@@ -0,0 +1,54 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set savedSearch1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, tsdoc, repository_id)
+values (
+    :'package1ID',
+    'package1',
+    '1.0.0',
+    generate_package_tsdoc('package1', null, 'description', '{"kw1"}', '{"repo1"}', '{"user1"}'),
+    :'repo1ID'
+);
+insert into snapshot (package_id, version, display_name, ts)
+values (:'package1ID', '1.0.0', 'Package 1', '2020-06-16 11:20:34+02');
+insert into saved_search (saved_search_id, user_id, name, query, notifications_enabled, created_at)
+values (:'savedSearch1ID', :'user1ID', 'search1', '{"ts_query_web": "kw1"}', true, '2020-06-16 11:20:34+02');
+insert into saved_search_match (saved_search_id, package_id, baseline, seen)
+values (:'savedSearch1ID', :'package1ID', false, false);
+
+-- Run some tests
+select is(
+    get_saved_search(:'user1ID', :'savedSearch1ID')::jsonb,
+    '{
+        "saved_search_id": "00000000-0000-0000-0000-000000000001",
+        "name": "search1",
+        "query": {"ts_query_web": "kw1"},
+        "notifications_enabled": true,
+        "created_at": 1592299234,
+        "last_evaluated_at": null,
+        "new_matches": 1
+    }'::jsonb,
+    'Saved search should be returned to its owner'
+);
+select is_empty(
+    $$ select get_saved_search('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001') $$,
+    'Saved search should not be returned to a different user'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set savedSearch1ID '00000000-0000-0000-0000-000000000001'
+\set savedSearch2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into saved_search (saved_search_id, user_id, name, query, created_at)
+values (:'savedSearch1ID', :'user1ID', 'search2', '{"ts_query_web": "kw2"}', '2020-06-16 11:20:34+02');
+insert into saved_search (saved_search_id, user_id, name, query, created_at)
+values (:'savedSearch2ID', :'user1ID', 'search1', '{"ts_query_web": "kw1"}', '2020-06-16 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_user_saved_searches(:'user1ID')::jsonb,
+    '[{
+        "saved_search_id": "00000000-0000-0000-0000-000000000002",
+        "name": "search1",
+        "query": {"ts_query_web": "kw1"},
+        "notifications_enabled": false,
+        "created_at": 1592299234,
+        "last_evaluated_at": null,
+        "new_matches": 0
+    }, {
+        "saved_search_id": "00000000-0000-0000-0000-000000000001",
+        "name": "search2",
+        "query": {"ts_query_web": "kw2"},
+        "notifications_enabled": false,
+        "created_at": 1592299234,
+        "last_evaluated_at": null,
+        "new_matches": 0
+    }]'::jsonb,
+    'Saved searches owned by the user should be returned sorted by name'
+);
+select is(
+    get_user_saved_searches(:'user2ID')::jsonb,
+    '[]'::jsonb,
+    'No saved searches expected for a user without saved searches'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,87 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set savedSearch1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, tsdoc, repository_id)
+values (
+    :'package1ID',
+    'package1',
+    '1.0.0',
+    generate_package_tsdoc('package1', null, 'description', '{"kw1"}', '{"repo1"}', '{"user1"}'),
+    :'repo1ID'
+);
+insert into snapshot (package_id, version, display_name, ts)
+values (:'package1ID', '1.0.0', 'Package 1', '2020-06-16 11:20:34+02');
+insert into saved_search (saved_search_id, user_id, name, query)
+values (:'savedSearch1ID', :'user1ID', 'search1', '{"ts_query_web": "kw1"}');
+insert into saved_search_match (saved_search_id, package_id, baseline, seen)
+values (:'savedSearch1ID', :'package1ID', false, false);
+
+-- Try to update saved search by non owner
+select update_saved_search('
+{
+    "saved_search_id": "00000000-0000-0000-0000-000000000001",
+    "user_id": "00000000-0000-0000-0000-000000000002",
+    "name": "search1-updated",
+    "query": {"ts_query_web": "kw1"}
+}
+'::jsonb);
+select results_eq(
+    $$
+        select name from saved_search where saved_search_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('search1')
+    $$,
+    'Saved search should not have been updated by a different user'
+);
+
+-- Update saved search
+select update_saved_search('
+{
+    "saved_search_id": "00000000-0000-0000-0000-000000000001",
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "name": "search1-updated",
+    "query": {"ts_query_web": "package1"},
+    "notifications_enabled": true
+}
+'::jsonb);
+select results_eq(
+    $$
+        select name, query, notifications_enabled
+        from saved_search
+        where saved_search_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('search1-updated', '{"ts_query_web": "package1"}'::jsonb, true)
+    $$,
+    'Saved search should have been updated'
+);
+select results_eq(
+    $$
+        select package_id, baseline
+        from saved_search_match
+        where saved_search_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, true)
+    $$,
+    'Saved search matches should have been reset when the query changed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
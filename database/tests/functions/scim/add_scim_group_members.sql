@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', false);
+
+-- Run some tests
+select add_scim_group_members(:'org1ID', array[:'user1ID', :'user2ID']::uuid[]);
+select results_eq(
+    $$
+        select user_id, confirmed
+        from user__organization
+        where organization_id = '00000000-0000-0000-0000-000000000001'
+        order by user_id
+    $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, true),
+            ('00000000-0000-0000-0000-000000000002'::uuid, true)
+    $$,
+    'Users should be confirmed members of the organization'
+);
+select throws_ok(
+    $$ select add_scim_group_members('00000000-0000-0000-0000-000000000002', array['00000000-0000-0000-0000-000000000001']::uuid[]) $$,
+    'P0002',
+    'no_data_found',
+    'Adding members to an organization that does not exist should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,40 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', true);
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into opt_out (user_id, repository_id, event_kind_id) values (:'user2ID', :'repo1ID', 2);
+
+-- Run some tests
+select throws_ok(
+    $$ select delete_scim_group_members('00000000-0000-0000-0000-000000000001', array['00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002']::uuid[]) $$,
+    'last member of an organization cannot leave it',
+    'All members of an organization cannot be deleted'
+);
+select delete_scim_group_members(:'org1ID', array[:'user2ID']::uuid[]);
+select results_eq(
+    $$ select user_id from user__organization where organization_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values ('00000000-0000-0000-0000-000000000001'::uuid) $$,
+    'User2 should have been deleted from the organization'
+);
+select is_empty(
+    $$ select * from opt_out where user_id = '00000000-0000-0000-0000-000000000002' $$,
+    'User2 opt-out entries for the organization repositories should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
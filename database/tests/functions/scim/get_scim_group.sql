@@ -0,0 +1,43 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, created_at)
+values (:'org1ID', 'org1', '2020-06-01 10:00:00+00');
+insert into user__organization (user_id, organization_id, confirmed) values (:'user1ID', :'org1ID', true);
+insert into user__organization (user_id, organization_id, confirmed) values (:'user2ID', :'org1ID', false);
+
+-- Run some tests
+select is(
+    get_scim_group(:'org1ID')::jsonb,
+    '{
+        "schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+        "id": "00000000-0000-0000-0000-000000000001",
+        "displayName": "org1",
+        "members": [{
+            "value": "00000000-0000-0000-0000-000000000001",
+            "display": "user1"
+        }],
+        "meta": {
+            "resourceType": "Group",
+            "created": "2020-06-01T10:00:00Z"
+        }
+    }'::jsonb,
+    'Organization should be returned in SCIM format including only confirmed members'
+);
+select is_empty(
+    $$ select get_scim_group('00000000-0000-0000-0000-000000000002') $$,
+    'No group should be returned if the organization does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,36 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Seed some data
+insert into organization (organization_id, name)
+values ('00000000-0000-0000-0000-000000000001', 'org1');
+insert into organization (organization_id, name)
+values ('00000000-0000-0000-0000-000000000002', 'org2');
+
+-- Run some tests
+select is(
+    get_scim_groups('{}')::jsonb - 'Resources',
+    '{
+        "schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"],
+        "totalResults": 2,
+        "startIndex": 1,
+        "itemsPerPage": 2
+    }'::jsonb,
+    'All organizations should be returned'
+);
+select is(
+    get_scim_groups('{"name": "org2"}')::jsonb,
+    json_build_object(
+        'schemas', json_build_array('urn:ietf:params:scim:api:messages:2.0:ListResponse'),
+        'totalResults', 1,
+        'startIndex', 1,
+        'itemsPerPage', 1,
+        'Resources', json_build_array(get_scim_group('00000000-0000-0000-0000-000000000002'))
+    )::jsonb,
+    'Only org2 should be returned when filtering by its name'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,42 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, first_name, created_at)
+values (:'user1ID', 'user1', 'user1@email.com', 'first_name', '2020-06-01 10:00:00+00');
+
+-- Run some tests
+select is(
+    get_scim_user(:'user1ID')::jsonb,
+    '{
+        "schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+        "id": "00000000-0000-0000-0000-000000000001",
+        "userName": "user1@email.com",
+        "displayName": "user1",
+        "name": {
+            "givenName": "first_name"
+        },
+        "emails": [{
+            "value": "user1@email.com",
+            "primary": true
+        }],
+        "active": true,
+        "meta": {
+            "resourceType": "User",
+            "created": "2020-06-01T10:00:00Z"
+        }
+    }'::jsonb,
+    'User should be returned in SCIM format'
+);
+select is_empty(
+    $$ select get_scim_user('00000000-0000-0000-0000-000000000002') $$,
+    'No user should be returned if it does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
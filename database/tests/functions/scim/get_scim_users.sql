@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values ('00000000-0000-0000-0000-000000000001', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values ('00000000-0000-0000-0000-000000000002', 'user2', 'user2@email.com');
+
+-- Run some tests
+select is(
+    get_scim_users('{}')::jsonb - 'Resources',
+    '{
+        "schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"],
+        "totalResults": 2,
+        "startIndex": 1,
+        "itemsPerPage": 2
+    }'::jsonb,
+    'All users should be returned'
+);
+select is(
+    get_scim_users('{"limit": 1, "offset": 1}')::jsonb #>> '{Resources,0,id}',
+    '00000000-0000-0000-0000-000000000002',
+    'Second page should contain user2'
+);
+select is(
+    get_scim_users('{"email": "USER1@email.com"}')::jsonb,
+    json_build_object(
+        'schemas', json_build_array('urn:ietf:params:scim:api:messages:2.0:ListResponse'),
+        'totalResults', 1,
+        'startIndex', 1,
+        'itemsPerPage', 1,
+        'Resources', json_build_array(get_scim_user('00000000-0000-0000-0000-000000000001'))
+    )::jsonb,
+    'Only user1 should be returned when filtering by its email'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,39 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Seed some data
+insert into "user" (alias, email) values ('user1', 'user1@taken.com');
+
+-- Run some tests
+select register_scim_user('{
+    "email": "user1@email.com",
+    "first_name": "first_name",
+    "last_name": "last_name"
+}') as user_id \gset
+select results_eq(
+    $$
+        select first_name, last_name, email, email_verified, password, disabled
+        from "user"
+        where email = 'user1@email.com'
+    $$,
+    $$
+        values ('first_name', 'last_name', 'user1@email.com', true, null::text, false)
+    $$,
+    'User should exist'
+);
+select isnt(
+    (select alias from "user" where user_id = :'user_id'),
+    'user1',
+    'A suffix should have been appended to the taken alias'
+);
+select throws_ok(
+    $$ select register_scim_user('{"email": "user1@email.com"}') $$,
+    23505,
+    'duplicate key value violates unique constraint "user_email_key"',
+    'Users with the same email cannot be registered twice'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
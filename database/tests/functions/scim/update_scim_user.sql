@@ -0,0 +1,52 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, first_name)
+values (:'user1ID', 'user1', 'user1@email.com', 'first_name');
+insert into session (session_id, user_id) values ('\x01', :'user1ID');
+
+-- Run some tests
+select update_scim_user(:'user1ID', '{
+    "email": "user1-updated@email.com",
+    "last_name": "last_name",
+    "disabled": false
+}');
+select results_eq(
+    $$
+        select first_name, last_name, email, disabled
+        from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (null::text, 'last_name', 'user1-updated@email.com', false)
+    $$,
+    'User should have been updated'
+);
+select update_scim_user(:'user1ID', '{
+    "email": "user1-updated@email.com",
+    "disabled": true
+}');
+select results_eq(
+    $$ select disabled from "user" where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (true) $$,
+    'User should have been disabled'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'User sessions should have been deleted'
+);
+select throws_ok(
+    $$ select update_scim_user('00000000-0000-0000-0000-000000000002', '{"email": "user2@email.com"}') $$,
+    'P0002',
+    'no_data_found',
+    'Updating a user that does not exist should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,56 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Add service account should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select add_service_account('00000000-0000-0000-0000-000000000002', 'org1', 'ci') $$,
+    42501,
+    'insufficient_privilege',
+    'Service account should not be added when the requesting user does not belong to the organization'
+);
+
+-- Add service account
+select add_service_account(:'user1ID', 'org1', 'ci');
+select results_eq(
+    $$
+        select alias, service_account_organization_id, email_verified, password
+        from "user"
+        where service_account_organization_id is not null
+    $$,
+    $$
+        values ('org1.ci', '00000000-0000-0000-0000-000000000001'::uuid, false, null::text)
+    $$,
+    'Service account should exist'
+);
+select is_empty(
+    $$
+        select * from user__organization uo
+        join "user" u using (user_id)
+        where u.alias = 'org1.ci'
+    $$,
+    'Service account should not have been added as an organization member'
+);
+
+-- Add service account with the same name again
+select throws_ok(
+    $$ select add_service_account('00000000-0000-0000-0000-000000000001', 'org1', 'ci') $$,
+    'service account name not available',
+    'Service account should not be added when the name is not available'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
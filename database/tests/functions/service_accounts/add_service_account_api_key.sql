@@ -0,0 +1,80 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set sa1ID '00000000-0000-0000-0000-000000000011'
+\set sa2ID '00000000-0000-0000-0000-000000000012'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa1ID', 'org1.sa1', 'sa1@service-accounts.invalid', :'org1ID');
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa2ID', 'org2.sa2', 'sa2@service-accounts.invalid', :'org2ID');
+
+-- Add service account api key should fail in the following cases
+select throws_ok(
+    $$
+        select add_service_account_api_key(
+            '00000000-0000-0000-0000-000000000002',
+            'org1',
+            '00000000-0000-0000-0000-000000000011',
+            '{"name": "apikey1", "scopes": ["repos:write"]}'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Api key should not be added when the requesting user does not belong to the organization'
+);
+select throws_ok(
+    $$
+        select add_service_account_api_key(
+            '00000000-0000-0000-0000-000000000001',
+            'org1',
+            '00000000-0000-0000-0000-000000000012',
+            '{"name": "apikey1", "scopes": ["repos:write"]}'
+        )
+    $$,
+    'P0002',
+    'no_data_found',
+    'Api key should not be added when the service account belongs to a different organization'
+);
+
+-- Add service account api key
+select isnt_empty(
+    $$
+        select add_service_account_api_key(
+            '00000000-0000-0000-0000-000000000001',
+            'org1',
+            '00000000-0000-0000-0000-000000000011',
+            '{"name": "apikey1", "scopes": ["repos:write"], "user_id": "00000000-0000-0000-0000-000000000001"}'
+        )
+    $$,
+    'Api key should have been added'
+);
+select results_eq(
+    $$ select name, user_id, scopes from api_key $$,
+    $$
+        values (
+            'apikey1',
+            '00000000-0000-0000-0000-000000000011'::uuid,
+            array['repos:write']
+        )
+    $$,
+    'Api key should belong to the service account'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,54 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set sa1ID '00000000-0000-0000-0000-000000000011'
+\set sa2ID '00000000-0000-0000-0000-000000000012'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org2ID', 'org2', 'Organization 2', 'Description 2', 'https://org2.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa1ID', 'org1.sa1', 'sa1@service-accounts.invalid', :'org1ID');
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa2ID', 'org2.sa2', 'sa2@service-accounts.invalid', :'org2ID');
+insert into api_key (name, secret, user_id) values ('apikey1', 'hashedSecret', :'sa1ID');
+
+-- Delete service account should fail in the following cases
+select throws_ok(
+    $$ select delete_service_account('00000000-0000-0000-0000-000000000002', 'org1', '00000000-0000-0000-0000-000000000011') $$,
+    42501,
+    'insufficient_privilege',
+    'Service account should not be deleted when the requesting user does not belong to the organization'
+);
+select throws_ok(
+    $$ select delete_service_account('00000000-0000-0000-0000-000000000001', 'org1', '00000000-0000-0000-0000-000000000012') $$,
+    'P0002',
+    'no_data_found',
+    'Service account should not be deleted when it belongs to a different organization'
+);
+
+-- Delete service account
+select delete_service_account(:'user1ID', 'org1', :'sa1ID');
+select is_empty(
+    $$ select * from "user" where user_id = '00000000-0000-0000-0000-000000000011' $$,
+    'Service account should have been deleted'
+);
+select is_empty(
+    $$ select * from api_key $$,
+    'Service account api keys should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,62 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set sa1ID '00000000-0000-0000-0000-000000000011'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+\set apikey2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa1ID', 'org1.sa1', 'sa1@service-accounts.invalid', :'org1ID');
+insert into api_key (api_key_id, name, secret, user_id) values (:'apikey1ID', 'apikey1', 'hashedSecret', :'sa1ID');
+insert into api_key (api_key_id, name, secret, user_id) values (:'apikey2ID', 'apikey2', 'hashedSecret', :'user1ID');
+
+-- Delete service account api key should fail in the following cases
+select throws_ok(
+    $$
+        select delete_service_account_api_key(
+            '00000000-0000-0000-0000-000000000002',
+            'org1',
+            '00000000-0000-0000-0000-000000000011',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Api key should not be deleted when the requesting user does not belong to the organization'
+);
+select throws_ok(
+    $$
+        select delete_service_account_api_key(
+            '00000000-0000-0000-0000-000000000001',
+            'org1',
+            '00000000-0000-0000-0000-000000000011',
+            '00000000-0000-0000-0000-000000000002'
+        )
+    $$,
+    'P0002',
+    'no_data_found',
+    'Api key should not be deleted when it does not belong to the service account'
+);
+
+-- Delete service account api key
+select delete_service_account_api_key(:'user1ID', 'org1', :'sa1ID', :'apikey1ID');
+select results_eq(
+    $$ select api_key_id from api_key $$,
+    $$ values ('00000000-0000-0000-0000-000000000002'::uuid) $$,
+    'Only the service account api key should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
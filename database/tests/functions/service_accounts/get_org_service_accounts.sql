@@ -0,0 +1,51 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set sa1ID '00000000-0000-0000-0000-000000000011'
+\set apikey1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name, display_name, description, home_url)
+values (:'org1ID', 'org1', 'Organization 1', 'Description 1', 'https://org1.com');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, email, service_account_organization_id, created_at)
+values (:'sa1ID', 'org1.sa1', 'sa1@service-accounts.invalid', :'org1ID', '2021-01-01 00:00:00+00');
+insert into api_key (api_key_id, name, secret, user_id, scopes, created_at)
+values (:'apikey1ID', 'apikey1', 'hashedSecret', :'sa1ID', '{repos:write}', '2021-01-01 00:00:00+00');
+
+-- Run some tests
+select throws_ok(
+    $$ select get_org_service_accounts('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'Service accounts should not be returned when the requesting user does not belong to the organization'
+);
+select is(
+    get_org_service_accounts(:'user1ID', 'org1')::jsonb,
+    '[{
+        "service_account_id": "00000000-0000-0000-0000-000000000011",
+        "alias": "org1.sa1",
+        "created_at": 1609459200,
+        "api_keys": [{
+            "api_key_id": "00000000-0000-0000-0000-000000000001",
+            "name": "apikey1",
+            "created_at": 1609459200,
+            "scopes": ["repos:write"],
+            "last_used_at": null,
+            "last_used_ip": null,
+            "requests_last_30_days": 0
+        }]
+    }]'::jsonb,
+    'Service accounts returned as expected'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,47 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set org2ID '00000000-0000-0000-0000-000000000002'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set sa1ID '00000000-0000-0000-0000-000000000011'
+
+-- Seed some organizations, one user and one service account
+insert into organization (organization_id, name)
+values (:'org1ID', 'org1');
+insert into organization (organization_id, name)
+values (:'org2ID', 'org2');
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into user__organization (user_id, organization_id, confirmed)
+values (:'user1ID', :'org1ID', true);
+insert into "user" (user_id, alias, email, service_account_organization_id)
+values (:'sa1ID', 'org1.sa1', 'sa1@service-accounts.invalid', :'org1ID');
+
+-- Run some tests
+select is(
+    get_service_account_allowed_actions(:'sa1ID', 'org1'),
+    '{addOrganizationRepository,updateOrganizationRepository}'::text[],
+    'Service account should only be allowed to add and update repositories in the organization that owns it'
+);
+select is(
+    get_service_account_allowed_actions(:'sa1ID', 'org2'),
+    '{}'::text[],
+    'Service account should not be allowed to perform any action in other organizations'
+);
+select is(
+    get_service_account_allowed_actions(:'user1ID', 'org1'),
+    '{}'::text[],
+    'Users who are not service accounts should not be granted any service account action'
+);
+select is(
+    get_service_account_allowed_actions('00000000-0000-0000-0000-000000000009', 'org1'),
+    '{}'::text[],
+    'Non existing service account should not be allowed to perform any action'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,100 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name)
+values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, created_at)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', '2020-06-16 11:20:34+02');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id, created_at)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 1, :'org1ID', '2020-06-16 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, created_at)
+values (:'package1ID', '1.0.0', '2020-06-18 11:20:34+02');
+insert into snapshot (package_id, version, created_at)
+values (:'package1ID', '0.0.9', '2020-06-17 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version, created_at)
+values (:'package2ID', '1.0.0', '2020-06-19 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_sitemap_entries('packages', 10, 0)::jsonb,
+    '[
+        {
+            "repository_kind_id": 0,
+            "repository_name": "repo1",
+            "package_name": "package-1",
+            "last_modified": 1592472034
+        },
+        {
+            "repository_kind_id": 1,
+            "repository_name": "repo2",
+            "package_name": "package2",
+            "last_modified": 1592558434
+        }
+    ]'::jsonb,
+    'Packages entries are returned sorted by repository and package name'
+);
+select is(
+    get_sitemap_entries('packages', 1, 1)::jsonb,
+    '[
+        {
+            "repository_kind_id": 1,
+            "repository_name": "repo2",
+            "package_name": "package2",
+            "last_modified": 1592558434
+        }
+    ]'::jsonb,
+    'Second page of packages entries is returned'
+);
+select is(
+    get_sitemap_entries('repositories', 10, 0)::jsonb,
+    '[
+        {
+            "repository_name": "repo1",
+            "last_modified": 1592472034
+        },
+        {
+            "repository_name": "repo2",
+            "last_modified": 1592558434
+        }
+    ]'::jsonb,
+    'Repositories entries are returned'
+);
+select is(
+    get_sitemap_entries('publishers', 10, 0)::jsonb,
+    '[
+        {
+            "organization_name": "org1",
+            "last_modified": 1592558434
+        },
+        {
+            "user_alias": "user1",
+            "last_modified": 1592472034
+        }
+    ]'::jsonb,
+    'Publishers entries are returned'
+);
+select is(
+    get_sitemap_entries('unknown', 10, 0)::jsonb,
+    '[]'::jsonb,
+    'No entries are returned for unknown sections'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
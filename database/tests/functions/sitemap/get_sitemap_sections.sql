@@ -0,0 +1,50 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+
+-- No data seeded yet
+select is(
+    get_sitemap_sections()::jsonb,
+    '[
+        {"name": "packages", "total": 0},
+        {"name": "repositories", "total": 0},
+        {"name": "publishers", "total": 0}
+    ]'::jsonb,
+    'Empty sections are returned when there is no data'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into organization (organization_id, name)
+values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, created_at)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', '2020-06-16 11:20:34+02');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id, created_at)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 1, :'org1ID', '2020-06-20 11:20:34+02');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into snapshot (package_id, version, created_at)
+values (:'package1ID', '1.0.0', '2020-06-18 11:20:34+02');
+
+-- Run some tests
+select is(
+    get_sitemap_sections()::jsonb,
+    '[
+        {"name": "packages", "total": 1, "last_modified": 1592472034},
+        {"name": "repositories", "total": 2, "last_modified": 1592644834},
+        {"name": "publishers", "total": 1, "last_modified": 1592472034}
+    ]'::jsonb,
+    'Sections are returned including their totals and last modification time'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,166 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email, created_at)
+values (:'user1ID', 'user1', 'user1@email.com', '2020-06-16 11:20:34+02');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id, created_at)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID', '2020-06-16 11:20:34+02');
+insert into package (
+    package_id,
+    name,
+    latest_version,
+    created_at,
+    repository_id
+) values (
+    :'package1ID',
+    'package1',
+    '1.0.0',
+    '2020-06-16 11:20:34+02',
+    :'repo1ID'
+);
+insert into snapshot (
+    package_id,
+    version,
+    created_at
+) values (
+    :'package1ID',
+    '1.0.0',
+    '2020-06-16 11:20:34+02'
+);
+insert into snapshot (
+    package_id,
+    version,
+    created_at
+) values (
+    :'package1ID',
+    '0.0.9',
+    '2020-06-16 11:20:34+02'
+);
+insert into package (
+    package_id,
+    name,
+    latest_version,
+    created_at,
+    repository_id
+) values (
+    :'package2ID',
+    'package2',
+    '1.0.0',
+    '2020-06-17 11:20:34+02',
+    :'repo1ID'
+);
+insert into snapshot (
+    package_id,
+    version,
+    created_at
+) values (
+    :'package2ID',
+    '1.0.0',
+    '2020-06-17 11:20:34+02'
+);
+insert into snapshot (
+    package_id,
+    version,
+    created_at
+) values (
+    :'package2ID',
+    '0.0.9',
+    '2020-06-17 11:20:34+02'
+);
+
+-- Run some tests
+select is(
+    get_stats_series('{
+        "metric": "packages",
+        "interval": "day",
+        "from": "2020-06-15",
+        "to": "2020-06-18"
+    }')::jsonb,
+    '{
+        "metric": "packages",
+        "interval": "day",
+        "created": [
+            [1592179200000, 0],
+            [1592265600000, 1],
+            [1592352000000, 1],
+            [1592438400000, 0]
+        ],
+        "running_total": [
+            [1592179200000, 0],
+            [1592265600000, 1],
+            [1592352000000, 2],
+            [1592438400000, 2]
+        ]
+    }'::jsonb,
+    'Daily packages series including empty buckets is returned'
+);
+select is(
+    get_stats_series('{
+        "metric": "packages",
+        "interval": "day",
+        "from": "2020-06-17",
+        "to": "2020-06-17"
+    }')::jsonb->'running_total',
+    '[[1592352000000, 2]]'::jsonb,
+    'Running total includes entities created before the range requested'
+);
+select is(
+    get_stats_series('{
+        "metric": "users",
+        "interval": "month",
+        "from": "2020-05-10",
+        "to": "2020-06-10"
+    }')::jsonb,
+    '{
+        "metric": "users",
+        "interval": "month",
+        "created": [
+            [1588291200000, 0],
+            [1590969600000, 1]
+        ],
+        "running_total": [
+            [1588291200000, 0],
+            [1590969600000, 1]
+        ]
+    }'::jsonb,
+    'Monthly users series is returned'
+);
+select is(
+    (
+        select k
+        from jsonb_array_elements(get_stats_series('{
+            "metric": "releases",
+            "interval": "month",
+            "from": "2020-05-01",
+            "to": "2020-06-30"
+        }')::jsonb->'kinds') as k
+        where k->>'repository_kind_id' = '0'
+    ),
+    '{
+        "repository_kind_id": 0,
+        "name": "Helm charts",
+        "created": [
+            [1588291200000, 0],
+            [1590969600000, 4]
+        ]
+    }'::jsonb,
+    'Monthly releases series is returned per repository kind'
+);
+select throws_ok(
+    $$ select get_stats_series('{"metric": "invalid", "interval": "day", "from": "2020-06-15", "to": "2020-06-18"}') $$,
+    'P0001',
+    'invalid metric: invalid',
+    'Invalid metric should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
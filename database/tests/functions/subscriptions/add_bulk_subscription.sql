@@ -0,0 +1,62 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'Package 2', '1.0.0', :'repo2ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'Package 3', '1.0.0', :'repo2ID');
+
+-- Run some tests
+select add_bulk_subscription(:'user1ID', '{"organization_name": "org1", "event_kind": 0}');
+select results_eq(
+    $$ select package_id, event_kind_id from subscription order by package_id $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002'::uuid, 0),
+            ('00000000-0000-0000-0000-000000000003'::uuid, 0)
+    $$,
+    'User1 should be subscribed to all org1 packages'
+);
+select add_bulk_subscription(:'user1ID', '{"organization_name": "org1", "event_kind": 0}');
+select is(
+    (select count(*)::int from subscription),
+    2,
+    'Existing subscriptions should be kept when subscribing again'
+);
+select add_bulk_subscription(:'user1ID', '{"user_alias": "user2", "event_kind": 1}');
+select results_eq(
+    $$ select package_id, event_kind_id from subscription where event_kind_id = 1 $$,
+    $$ values ('00000000-0000-0000-0000-000000000001'::uuid, 1) $$,
+    'User1 should be subscribed to all user2 packages'
+);
+select add_bulk_subscription(:'user1ID', '{"repository_id": "00000000-0000-0000-0000-000000000002", "event_kind": 5}');
+select is(
+    (select count(*)::int from subscription where event_kind_id = 5),
+    2,
+    'User1 should be subscribed to all repo2 packages'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
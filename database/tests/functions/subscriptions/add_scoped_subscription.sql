@@ -0,0 +1,84 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+
+-- Run some tests
+select add_scoped_subscription(:'user1ID', '{"repository_id": "00000000-0000-0000-0000-000000000001", "event_kind": 0}');
+select add_scoped_subscription(:'user1ID', '{"repository_id": "00000000-0000-0000-0000-000000000001", "event_kind": 0}');
+select results_eq(
+    $$ select user_id, repository_id, event_kind_id from repository_subscription $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            0
+        )
+    $$,
+    'User1 should be subscribed to repo1 once'
+);
+select add_scoped_subscription(:'user1ID', '{"organization_name": "org1", "event_kind": 1}');
+select results_eq(
+    $$ select user_id, organization_id, event_kind_id from publisher_subscription where organization_id is not null $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            1
+        )
+    $$,
+    'User1 should be subscribed to org1'
+);
+select add_scoped_subscription(:'user1ID', '{"organization_name": "org1", "event_kind": 1, "min_severity": "high"}');
+select results_eq(
+    $$ select event_kind_id, min_severity from publisher_subscription where organization_id is not null $$,
+    $$ values (1, 'high') $$,
+    'User1 subscription to org1 minimum severity should have been updated'
+);
+select add_scoped_subscription(:'user1ID', '{"user_alias": "user2", "event_kind": 0}');
+select add_scoped_subscription(:'user1ID', '{"user_alias": "user2", "event_kind": 0}');
+select results_eq(
+    $$ select user_id, publisher_user_id, event_kind_id from publisher_subscription where publisher_user_id is not null $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            0
+        )
+    $$,
+    'User1 should be subscribed to user2 once'
+);
+select throws_ok(
+    $$ select add_scoped_subscription('00000000-0000-0000-0000-000000000001', '{"repository_id": "00000000-0000-0000-0000-000000000009", "event_kind": 0}') $$,
+    'P0002',
+    'no_data_found',
+    'Subscribing to a repository that does not exist should fail'
+);
+select throws_ok(
+    $$ select add_scoped_subscription('00000000-0000-0000-0000-000000000001', '{"organization_name": "org9", "event_kind": 0}') $$,
+    'P0002',
+    'no_data_found',
+    'Subscribing to an organization that does not exist should fail'
+);
+select throws_ok(
+    $$ select add_scoped_subscription('00000000-0000-0000-0000-000000000001', '{"user_alias": "user9", "event_kind": 0}') $$,
+    'P0002',
+    'no_data_found',
+    'Subscribing to a user that does not exist should fail'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
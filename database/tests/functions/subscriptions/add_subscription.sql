@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(1);
+select plan(3);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -42,6 +42,44 @@ select results_eq(
     'Subscription should exist'
 );
 
+-- Add security alerts subscription with minimum severity
+select add_subscription('
+{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "event_kind": 1,
+    "min_severity": "high"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select min_severity
+        from subscription
+        where event_kind_id = 1
+    $$,
+    $$ values ('high') $$,
+    'Security alerts subscription should have been added with the minimum severity provided'
+);
+
+-- Update subscription minimum severity
+select add_subscription('
+{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "package_id": "00000000-0000-0000-0000-000000000001",
+    "event_kind": 1,
+    "min_severity": "critical"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select min_severity
+        from subscription
+        where event_kind_id = 1
+    $$,
+    $$ values ('critical') $$,
+    'Subscription minimum severity should have been updated'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
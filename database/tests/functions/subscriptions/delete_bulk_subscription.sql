@@ -0,0 +1,58 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'Package 2', '1.0.0', :'repo2ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'Package 3', '1.0.0', :'repo2ID');
+insert into subscription (user_id, package_id, event_kind_id)
+values
+    (:'user1ID', :'package1ID', 0),
+    (:'user1ID', :'package2ID', 0),
+    (:'user1ID', :'package2ID', 1),
+    (:'user1ID', :'package3ID', 0),
+    (:'user2ID', :'package2ID', 0);
+
+-- Run some tests
+select delete_bulk_subscription(:'user1ID', '{"organization_name": "org1", "event_kind": 0}');
+select results_eq(
+    $$ select user_id, package_id, event_kind_id from subscription order by user_id, package_id $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000001'::uuid, '00000000-0000-0000-0000-000000000001'::uuid, 0),
+            ('00000000-0000-0000-0000-000000000001'::uuid, '00000000-0000-0000-0000-000000000002'::uuid, 1),
+            ('00000000-0000-0000-0000-000000000002'::uuid, '00000000-0000-0000-0000-000000000002'::uuid, 0)
+    $$,
+    'User1 org1 subscriptions for kind 0 should have been deleted'
+);
+select delete_bulk_subscription(:'user1ID', '{"repository_id": "00000000-0000-0000-0000-000000000001", "event_kind": 0}');
+select is(
+    (select count(*)::int from subscription where user_id = :'user1ID'),
+    1,
+    'User1 repo1 subscriptions for kind 0 should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
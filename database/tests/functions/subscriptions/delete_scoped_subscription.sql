@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into repository_subscription (user_id, repository_id, event_kind_id)
+values (:'user1ID', :'repo1ID', 0);
+insert into repository_subscription (user_id, repository_id, event_kind_id)
+values (:'user1ID', :'repo1ID', 1);
+insert into publisher_subscription (user_id, organization_id, event_kind_id)
+values (:'user1ID', :'org1ID', 0);
+insert into publisher_subscription (user_id, publisher_user_id, event_kind_id)
+values (:'user1ID', :'user2ID', 0);
+
+-- Run some tests
+select delete_scoped_subscription(:'user1ID', '{"repository_id": "00000000-0000-0000-0000-000000000001", "event_kind": 0}');
+select results_eq(
+    $$ select event_kind_id from repository_subscription $$,
+    $$ values (1) $$,
+    'Only the repo1 subscription for the event kind provided should be deleted'
+);
+select delete_scoped_subscription(:'user1ID', '{"organization_name": "org1", "event_kind": 0}');
+select is_empty(
+    $$ select * from publisher_subscription where organization_id is not null $$,
+    'Org1 subscription should have been deleted'
+);
+select delete_scoped_subscription(:'user1ID', '{"user_alias": "user2", "event_kind": 0}');
+select is_empty(
+    $$ select * from publisher_subscription $$,
+    'User2 subscription should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
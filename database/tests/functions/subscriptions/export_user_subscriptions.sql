@@ -0,0 +1,61 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'Package 2', '1.0.0', :'repo2ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'Package 3', '1.0.0', :'repo2ID');
+insert into subscription (user_id, package_id, event_kind_id)
+values
+    (:'user1ID', :'package1ID', 0),
+    (:'user1ID', :'package2ID', 5),
+    (:'user1ID', :'package2ID', 1),
+    (:'user2ID', :'package3ID', 0);
+
+-- Run some tests
+select is(
+    export_user_subscriptions(:'user1ID')::jsonb,
+    '[
+        {
+            "repository_name": "repo1",
+            "package_name": "package-1",
+            "event_kinds": [0]
+        },
+        {
+            "repository_name": "repo2",
+            "package_name": "package-2",
+            "event_kinds": [1, 5]
+        }
+    ]'::jsonb,
+    'User1 subscriptions should be exported'
+);
+select is(
+    export_user_subscriptions('00000000-0000-0000-0000-000000000009')::jsonb,
+    '[]'::jsonb,
+    'No subscriptions should be exported for unknown users'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
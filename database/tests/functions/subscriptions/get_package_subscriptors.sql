@@ -1,51 +1,167 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(9);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
 \set user2ID '00000000-0000-0000-0000-000000000002'
 \set user3ID '00000000-0000-0000-0000-000000000003'
+\set user4ID '00000000-0000-0000-0000-000000000004'
+\set org1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
 \set package1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
 \set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
 
 -- Seed some data
-insert into "user" (user_id, alias, email)
-values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, notifications_preferences)
+values (:'user1ID', 'user1', 'user1@email.com', '{"email_disabled_event_kinds": [5]}');
 insert into "user" (user_id, alias, email)
 values (:'user2ID', 'user2', 'user2@email.com');
 insert into "user" (user_id, alias, email)
 values (:'user3ID', 'user3', 'user3@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user4ID', 'user4', 'user4@email.com');
+insert into organization (organization_id, name)
+values (:'org1ID', 'org1');
 insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
 values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
 insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'Package 3', '1.0.0', :'repo2ID');
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user1ID', :'package1ID', 0);
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user2ID', :'package1ID', 0);
 insert into subscription (user_id, package_id, event_kind_id)
 values (:'user3ID', :'package1ID', 1);
+insert into repository_subscription (user_id, repository_id, event_kind_id)
+values (:'user2ID', :'repo1ID', 0);
+insert into repository_subscription (user_id, repository_id, event_kind_id)
+values (:'user3ID', :'repo2ID', 0);
+insert into publisher_subscription (user_id, publisher_user_id, event_kind_id)
+values (:'user4ID', :'user1ID', 0);
+insert into publisher_subscription (user_id, organization_id, event_kind_id)
+values (:'user4ID', :'org1ID', 1);
 
 -- Run some tests
 select is(
     get_package_subscriptors(:'package1ID', 0)::jsonb,
     '[
         {
-            "user_id": "00000000-0000-0000-0000-000000000001"
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "notifications_preferences": {
+                "email_disabled_event_kinds": [5]
+            }
         },
         {
             "user_id": "00000000-0000-0000-0000-000000000002"
+        },
+        {
+            "user_id": "00000000-0000-0000-0000-000000000004"
         }
     ]'::jsonb,
-    'Two subscriptors expected for package1 and kind new releases'
+    'Three subscriptors expected for package1 and kind new releases'
 );
 select is(
     get_package_subscriptors(:'package2ID', 0)::jsonb,
     '[]'::jsonb,
     'No subscriptors expected for package2 and kind new releases'
 );
+select is(
+    get_package_subscriptors(:'package3ID', 0)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000003"
+        }
+    ]'::jsonb,
+    'One subscriptor expected for package3 and kind new releases (repository subscription)'
+);
+select is(
+    get_package_subscriptors(:'package3ID', 1)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000004"
+        }
+    ]'::jsonb,
+    'One subscriptor expected for package3 and kind security alerts (publisher subscription)'
+);
+update publisher_subscription set min_severity = 'critical'
+where user_id = :'user4ID' and organization_id = :'org1ID';
+select is(
+    get_package_subscriptors(:'package3ID', 1)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000004",
+            "min_severity": "critical"
+        }
+    ]'::jsonb,
+    'Subscriptor minimum severity expected'
+);
+insert into repository_subscription (user_id, repository_id, event_kind_id, min_severity)
+values (:'user4ID', :'repo2ID', 1, 'high');
+select is(
+    get_package_subscriptors(:'package3ID', 1)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000004",
+            "min_severity": "high"
+        }
+    ]'::jsonb,
+    'Least restrictive minimum severity of the subscriptor subscriptions expected'
+);
+insert into subscription (user_id, package_id, event_kind_id)
+values (:'user4ID', :'package3ID', 1);
+select is(
+    get_package_subscriptors(:'package3ID', 1)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000004"
+        }
+    ]'::jsonb,
+    'No minimum severity expected when one of the subscriptor subscriptions does not set it'
+);
+select unsubscribe(jsonb_build_object(
+    'user_id', :'user2ID',
+    'package_id', :'package1ID',
+    'event_kind', 0
+));
+select is(
+    get_package_subscriptors(:'package1ID', 0)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "notifications_preferences": {
+                "email_disabled_event_kinds": [5]
+            }
+        },
+        {
+            "user_id": "00000000-0000-0000-0000-000000000004"
+        }
+    ]'::jsonb,
+    'User unsubscribed from package1 should not receive its notifications (repository subscription)'
+);
+select unsubscribe(jsonb_build_object(
+    'user_id', :'user4ID',
+    'package_id', :'package1ID',
+    'event_kind', 0
+));
+select is(
+    get_package_subscriptors(:'package1ID', 0)::jsonb,
+    '[
+        {
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "notifications_preferences": {
+                "email_disabled_event_kinds": [5]
+            }
+        }
+    ]'::jsonb,
+    'User unsubscribed from package1 should not receive its notifications (publisher subscription)'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
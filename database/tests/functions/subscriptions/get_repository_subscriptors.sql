@@ -13,8 +13,8 @@ select plan(2);
 \set repo2ID '00000000-0000-0000-0000-000000000002'
 
 -- Seed some data
-insert into "user" (user_id, alias, email)
-values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, notifications_preferences)
+values (:'user1ID', 'user1', 'user1@email.com', '{"email_disabled_event_kinds": [5]}');
 insert into "user" (user_id, alias, email)
 values (:'user2ID', 'user2', 'user2@email.com');
 insert into "user" (user_id, alias, email)
@@ -40,7 +40,10 @@ select is(
     get_repository_subscriptors(:'repo1ID', 2)::jsonb,
     '[
         {
-            "user_id": "00000000-0000-0000-0000-000000000001"
+            "user_id": "00000000-0000-0000-0000-000000000001",
+            "notifications_preferences": {
+                "email_disabled_event_kinds": [5]
+            }
         }
     ]'::jsonb,
     'One subscriptor expected for repo1'
@@ -0,0 +1,152 @@
+-- Start transaction and plan tests
+begin;
+select plan(8);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user2ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package2ID', 'Package 2', '1.0.0', :'repo2ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'Package 3', '1.0.0', :'repo2ID');
+insert into snapshot (package_id, version) values (:'package1ID', '1.0.0');
+insert into snapshot (package_id, version) values (:'package2ID', '1.0.0');
+insert into snapshot (package_id, version) values (:'package3ID', '1.0.0');
+insert into subscription (user_id, package_id, event_kind_id)
+values
+    (:'user1ID', :'package1ID', 0),
+    (:'user1ID', :'package2ID', 0),
+    (:'user1ID', :'package2ID', 1),
+    (:'user1ID', :'package3ID', 5);
+
+-- Run some tests
+select is(
+    search_user_subscriptions(:'user1ID', '{"limit": 1, "offset": 1}')::jsonb #- '{metadata,next_cursor}',
+    '{
+        "subscriptions": [
+            {
+                "package_id": "00000000-0000-0000-0000-000000000002",
+                "name": "Package 2",
+                "normalized_name": "package-2",
+                "repository": {
+                    "repository_id": "00000000-0000-0000-0000-000000000002",
+                    "name": "repo2",
+                    "display_name": "Repo 2",
+                    "url": "https://repo2.com",
+                    "private": false,
+                    "kind": 0,
+                    "verified_publisher": false,
+                    "official": false,
+                    "organization_name": "org1"
+                },
+                "event_kinds": [0, 1]
+            }
+        ],
+        "metadata": {
+            "limit": 1,
+            "offset": 1,
+            "total": 3
+        }
+    }'::jsonb,
+    'Second page of user1 subscriptions should be returned'
+);
+select is(
+    decode_cursor(search_user_subscriptions(:'user1ID', '{"limit": 1, "offset": 1}')::jsonb #>> '{metadata,next_cursor}'),
+    jsonb_build_object(
+        'normalized_name', 'package-2',
+        'package_id', :'package2ID'::text,
+        'scope', get_cursor_scope('{}')
+    ),
+    'Next cursor should point to the last package returned'
+);
+select is(
+    search_user_subscriptions(
+        :'user1ID',
+        jsonb_build_object(
+            'limit', 1,
+            'cursor', search_user_subscriptions(:'user1ID', '{"limit": 2}')::jsonb #>> '{metadata,next_cursor}'
+        )
+    )::jsonb->'subscriptions'->0->'package_id',
+    '"00000000-0000-0000-0000-000000000003"'::jsonb,
+    'Page after the cursor provided should be returned'
+);
+select is(
+    search_user_subscriptions(
+        :'user1ID',
+        jsonb_build_object(
+            'limit', 1,
+            'cursor', search_user_subscriptions(:'user1ID', '{"limit": 2}')::jsonb #>> '{metadata,next_cursor}'
+        )
+    )::jsonb->'metadata'->'next_cursor',
+    null,
+    'Next cursor should not be returned in the last page'
+);
+select throws_ok(
+    format(
+        'select search_user_subscriptions(%L, %L)',
+        :'user1ID'::text,
+        jsonb_build_object(
+            'limit', 1,
+            'event_kinds', '[1]'::jsonb,
+            'cursor', search_user_subscriptions(:'user1ID', '{"limit": 2}')::jsonb #>> '{metadata,next_cursor}'
+        )
+    ),
+    '22023',
+    'invalid cursor',
+    'Cursor obtained using different filters should be rejected'
+);
+select throws_ok(
+    format(
+        'select search_user_subscriptions(%L, %L)',
+        :'user1ID'::text,
+        jsonb_build_object(
+            'limit', 1,
+            'cursor', encode_cursor(jsonb_build_object(
+                'normalized_name', 'package-2',
+                'package_id', 'invalid',
+                'scope', get_cursor_scope('{}')
+            ))
+        )
+    ),
+    '22023',
+    'invalid cursor',
+    'Cursor containing invalid values should be rejected'
+);
+select is(
+    search_user_subscriptions(:'user1ID', '{"limit": 10, "offset": 0, "event_kinds": [1, 5]}')::jsonb->'metadata'->'total',
+    '2'::jsonb,
+    'Only packages with subscriptions of the event kinds provided should be returned'
+);
+select is(
+    search_user_subscriptions(:'user2ID', '{"limit": 10, "offset": 0}')::jsonb,
+    '{
+        "subscriptions": [],
+        "metadata": {
+            "limit": 10,
+            "offset": 0,
+            "total": 0
+        }
+    }'::jsonb,
+    'No subscriptions should be returned for user2'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
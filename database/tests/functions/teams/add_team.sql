@@ -0,0 +1,54 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+
+-- Add team should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select add_team('00000000-0000-0000-0000-000000000002', 'org1', '{"name": "team1"}') $$,
+    42501,
+    'insufficient_privilege',
+    'Team should not be added when the requesting user does not belong to the organization'
+);
+
+-- Add team
+select add_team(:'user1ID', 'org1', '
+{
+    "name": "team1",
+    "display_name": "Team 1",
+    "description": "Description 1"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select name, display_name, description, organization_id
+        from team
+    $$,
+    $$
+        values ('team1', 'Team 1', 'Description 1', '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'Team should exist'
+);
+
+-- Add team with the same name again
+select throws_ok(
+    $$ select add_team('00000000-0000-0000-0000-000000000001', 'org1', '{"name": "team1"}') $$,
+    'team name not available',
+    'Team should not be added when the name is not available'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,55 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into team (team_id, organization_id, name)
+values (:'team1ID', :'org1ID', 'team1');
+
+-- Add team member should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select add_team_member('00000000-0000-0000-0000-000000000002', 'org1', 'team1', 'user1') $$,
+    42501,
+    'insufficient_privilege',
+    'Team member should not be added when the requesting user does not belong to the organization'
+);
+
+-- Add team member
+select add_team_member(:'user1ID', 'org1', 'team1', 'user1');
+select results_eq(
+    $$ select user_id, team_id from user__team $$,
+    $$
+        values ('00000000-0000-0000-0000-000000000001'::uuid, '00000000-0000-0000-0000-000000000001'::uuid)
+    $$,
+    'User1 should be a member of team1'
+);
+
+-- Add user not belonging to the organization to the team
+select throws_ok(
+    $$ select add_team_member('00000000-0000-0000-0000-000000000001', 'org1', 'team1', 'user2') $$,
+    'user is not a member of the organization',
+    'User2 should not be added to team1 as it does not belong to organization1'
+);
+
+-- Add member to a team that does not exist
+select throws_ok(
+    $$ select add_team_member('00000000-0000-0000-0000-000000000001', 'org1', 'team2', 'user1') $$,
+    'P0002',
+    'no_data_found',
+    'Team member should not be added when the team does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
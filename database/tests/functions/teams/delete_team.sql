@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into team (team_id, organization_id, name)
+values (:'team1ID', :'org1ID', 'team1');
+insert into user__team (user_id, team_id) values (:'user1ID', :'team1ID');
+
+-- Delete team should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select delete_team('00000000-0000-0000-0000-000000000002', 'org1', 'team1') $$,
+    42501,
+    'insufficient_privilege',
+    'Team should not be deleted when the requesting user does not belong to the organization'
+);
+
+-- Delete team
+select delete_team(:'user1ID', 'org1', 'team1');
+select is_empty(
+    $$ select * from team $$,
+    'Team should have been deleted'
+);
+
+-- Delete team that does not exist
+select throws_ok(
+    $$ select delete_team('00000000-0000-0000-0000-000000000001', 'org1', 'team1') $$,
+    'P0002',
+    'no_data_found',
+    'Team should not be deleted when it does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into team (team_id, organization_id, name)
+values (:'team1ID', :'org1ID', 'team1');
+insert into user__team (user_id, team_id) values (:'user1ID', :'team1ID');
+
+-- Delete team member should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select delete_team_member('00000000-0000-0000-0000-000000000002', 'org1', 'team1', 'user1') $$,
+    42501,
+    'insufficient_privilege',
+    'Team member should not be deleted when the requesting user does not belong to the organization'
+);
+
+-- Delete team member
+select delete_team_member(:'user1ID', 'org1', 'team1', 'user1');
+select is_empty(
+    $$ select * from user__team $$,
+    'User1 should not be a member of team1 anymore'
+);
+
+-- Delete member that does not belong to the team
+select throws_ok(
+    $$ select delete_team_member('00000000-0000-0000-0000-000000000001', 'org1', 'team1', 'user1') $$,
+    'P0002',
+    'no_data_found',
+    'Team member should not be deleted when it does not belong to the team'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
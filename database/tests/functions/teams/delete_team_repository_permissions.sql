@@ -0,0 +1,49 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into team (team_id, organization_id, name)
+values (:'team1ID', :'org1ID', 'team1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into team__repository (team_id, repository_id, allowed_actions)
+values (:'team1ID', :'repo1ID', '{updateOrganizationRepository}');
+
+-- Delete permissions should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select delete_team_repository_permissions('00000000-0000-0000-0000-000000000002', 'org1', 'team1', 'repo1') $$,
+    42501,
+    'insufficient_privilege',
+    'Permissions should not be deleted when the requesting user does not belong to the organization'
+);
+
+-- Delete permissions
+select delete_team_repository_permissions(:'user1ID', 'org1', 'team1', 'repo1');
+select is_empty(
+    $$ select * from team__repository $$,
+    'Team1 permissions on repo1 should have been deleted'
+);
+
+-- Delete permissions that do not exist
+select throws_ok(
+    $$ select delete_team_repository_permissions('00000000-0000-0000-0000-000000000001', 'org1', 'team1', 'repo1') $$,
+    'P0002',
+    'no_data_found',
+    'Permissions should not be deleted when they do not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
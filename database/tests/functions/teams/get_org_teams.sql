@@ -0,0 +1,56 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into team (team_id, organization_id, name, display_name)
+values (:'team1ID', :'org1ID', 'team1', 'Team 1');
+insert into team (organization_id, name)
+values (:'org1ID', 'team2');
+insert into user__team (user_id, team_id) values (:'user1ID', :'team1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'org1ID');
+insert into team__repository (team_id, repository_id, allowed_actions)
+values (:'team1ID', :'repo1ID', '{updateOrganizationRepository}');
+
+-- Get organization teams should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select get_org_teams('00000000-0000-0000-0000-000000000002', 'org1') $$,
+    42501,
+    'insufficient_privilege',
+    'Teams should not be returned when the requesting user does not belong to the organization'
+);
+
+-- Get organization teams
+select is(
+    get_org_teams(:'user1ID', 'org1')::jsonb,
+    '[{
+        "name": "team1",
+        "display_name": "Team 1",
+        "members": ["user1"],
+        "repositories": [{
+            "name": "repo1",
+            "allowed_actions": ["updateOrganizationRepository"]
+        }]
+    }, {
+        "name": "team2",
+        "members": [],
+        "repositories": []
+    }]'::jsonb,
+    'Organization teams should be returned as a json array of objects'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,56 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set org1ID '00000000-0000-0000-0000-000000000001'
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set team1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into user__organization (user_id, organization_id, confirmed) values(:'user1ID', :'org1ID', true);
+insert into team (team_id, organization_id, name, display_name)
+values (:'team1ID', :'org1ID', 'team1', 'Team 1');
+
+-- Update team should fail when the requesting user does not belong to the organization
+select throws_ok(
+    $$ select update_team('00000000-0000-0000-0000-000000000002', 'org1', 'team1', '{}') $$,
+    42501,
+    'insufficient_privilege',
+    'Team should not be updated when the requesting user does not belong to the organization'
+);
+
+-- Update team
+select update_team(:'user1ID', 'org1', 'team1', '
+{
+    "display_name": "Team 1 updated",
+    "description": "Description 1 updated"
+}
+'::jsonb);
+select results_eq(
+    $$
+        select name, display_name, description
+        from team
+    $$,
+    $$
+        values ('team1', 'Team 1 updated', 'Description 1 updated')
+    $$,
+    'Team should have been updated'
+);
+
+-- Update team that does not exist
+select throws_ok(
+    $$ select update_team('00000000-0000-0000-0000-000000000001', 'org1', 'team2', '{}') $$,
+    'P0002',
+    'no_data_found',
+    'Team should not be updated when it does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
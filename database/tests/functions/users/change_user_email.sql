@@ -0,0 +1,95 @@
+-- Start transaction and plan tests
+begin;
+select plan(10);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set code1 '00000000-0000-0000-0000-000000000001'
+\set code2 '00000000-0000-0000-0000-000000000002'
+\set code3 '00000000-0000-0000-0000-000000000003'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user3ID', 'user3', 'user3@email.com');
+insert into email_change_code (email_change_code_id, user_id, new_email, created_at)
+values (sha512(:'code1'), :'user1ID', 'new1@email.com', current_timestamp - '5 minute'::interval);
+insert into email_change_code (email_change_code_id, user_id, new_email, created_at)
+values (sha512(:'code2'), :'user2ID', 'new2@email.com', current_timestamp - '2 hour'::interval);
+insert into email_change_code (email_change_code_id, user_id, new_email)
+values (sha512(:'code3'), :'user3ID', 'user1@email.com');
+insert into session (session_id, user_id) values ('session1', :'user1ID');
+insert into session (session_id, user_id) values ('session3', :'user3ID');
+
+-- Change user email should fail in the following cases
+select throws_ok(
+    $$ select change_user_email('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000004', false) $$,
+    'P0001',
+    'invalid email change code',
+    'Change user email failed because code did not exist'
+);
+select throws_ok(
+    $$ select change_user_email('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', false) $$,
+    'P0001',
+    'invalid email change code',
+    'Change user email failed because code belongs to a different user'
+);
+select throws_ok(
+    $$ select change_user_email('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', false) $$,
+    'P0001',
+    'invalid email change code',
+    'Change user email failed because code has expired'
+);
+select throws_ok(
+    $$ select change_user_email('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000003', false) $$,
+    'P0001',
+    'email not available',
+    'Change user email failed because the new email is already in use'
+);
+
+-- Change user email should succeed keeping the sessions
+select is(
+    change_user_email(:'user1ID', :'code1', false),
+    'user1@email.com',
+    'Previous email should be returned'
+);
+select results_eq(
+    $$
+        select email, email_verified
+        from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values ('new1@email.com', true) $$,
+    'User email should have been updated'
+);
+select is_empty(
+    $$ select * from email_change_code where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Email change code should have been deleted'
+);
+select isnt_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'User sessions should have been kept'
+);
+
+-- Change user email should succeed invalidating the sessions
+delete from email_change_code where user_id = :'user3ID';
+insert into email_change_code (email_change_code_id, user_id, new_email)
+values (sha512(:'code3'), :'user3ID', 'new3@email.com');
+select is(
+    change_user_email(:'user3ID', :'code3', true),
+    'user3@email.com',
+    'Previous email should be returned'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000003' $$,
+    'User sessions should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
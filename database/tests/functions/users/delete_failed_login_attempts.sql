@@ -0,0 +1,23 @@
+-- Start transaction and plan tests
+begin;
+select plan(1);
+
+-- Seed some failed login attempts
+insert into failed_login_attempt (email, ip) values
+    ('user1@email.com', '192.168.1.1'),
+    ('user1@email.com', '192.168.1.2'),
+    ('user2@email.com', '192.168.1.1');
+
+-- Delete failed login attempts for user1
+select delete_failed_login_attempts('user1@email.com');
+
+-- Check only the failed attempts of user1 were deleted
+select results_eq(
+    $$ select email from failed_login_attempt $$,
+    $$ values ('user2@email.com') $$,
+    'Only the failed attempts for the email provided should be deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,71 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set user3ID '00000000-0000-0000-0000-000000000003'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set package2ID '00000000-0000-0000-0000-000000000002'
+
+-- No users deleted when there are no scheduled deletions
+select is(
+    delete_scheduled_users(),
+    0,
+    'No users should be deleted'
+);
+
+-- Seed some data
+insert into "user" (user_id, alias, email, deletion_scheduled_at)
+values (:'user1ID', 'user1', 'user1@email.com', current_timestamp - '1 day'::interval);
+insert into "user" (user_id, alias, email, deletion_scheduled_at)
+values (:'user2ID', 'user2', 'user2@email.com', current_timestamp + '1 day'::interval);
+insert into "user" (user_id, alias, email)
+values (:'user3ID', 'user3', 'user3@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'user3ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'package1', '1.0.0', :'repo1ID');
+insert into package (package_id, name, latest_version, repository_id, stars)
+values (:'package2ID', 'package2', '1.0.0', :'repo2ID', 2);
+insert into user_starred_package (user_id, package_id) values (:'user1ID', :'package2ID');
+insert into user_starred_package (user_id, package_id) values (:'user3ID', :'package2ID');
+
+-- Delete users whose grace period has elapsed
+select is(
+    delete_scheduled_users(),
+    1,
+    'One user should be deleted'
+);
+select results_eq(
+    $$ select user_id from "user" order by alias $$,
+    $$
+        values
+            ('00000000-0000-0000-0000-000000000002'::uuid),
+            ('00000000-0000-0000-0000-000000000003'::uuid)
+    $$,
+    'Only users whose grace period has not elapsed yet should remain'
+);
+select results_eq(
+    $$ select repository_id from repository $$,
+    $$ values ('00000000-0000-0000-0000-000000000002'::uuid) $$,
+    'Repositories owned by the deleted user should have been deleted'
+);
+select is_empty(
+    $$ select * from package where package_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Packages in repositories owned by the deleted user should have been deleted'
+);
+select results_eq(
+    $$ select stars from package where package_id = '00000000-0000-0000-0000-000000000002' $$,
+    $$ values (1) $$,
+    'Packages starred by the deleted user should have been updated'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,66 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Check login attempts are not throttled when there are no failed attempts
+select results_eq(
+    $$ select * from get_login_throttle('user1@email.com', '192.168.1.1', 5, '15 minutes') $$,
+    $$ values (false, 0::bigint) $$,
+    'Login attempts should not be throttled when there are no failed attempts'
+);
+
+-- Check login attempts are not throttled after a couple of failed attempts
+insert into failed_login_attempt (email, ip, created_at) values
+    ('user1@email.com', '192.168.1.1', current_timestamp - '1 minute'::interval),
+    ('user1@email.com', '192.168.1.1', current_timestamp - '2 minutes'::interval);
+select results_eq(
+    $$ select * from get_login_throttle('user1@email.com', '192.168.1.1', 5, '15 minutes') $$,
+    $$ values (false, 0::bigint) $$,
+    'Login attempts should not be throttled after a couple of failed attempts'
+);
+
+-- Check progressive delays are applied by email
+insert into failed_login_attempt (email, ip, created_at) values
+    ('user1@email.com', '192.168.1.2', current_timestamp);
+select results_eq(
+    $$ select * from get_login_throttle('user1@email.com', '192.168.1.3', 5, '15 minutes') $$,
+    $$ values (false, 1::bigint) $$,
+    'Progressive delay should be applied after 3 failed attempts for the same email'
+);
+
+-- Check progressive delays are applied by ip
+insert into failed_login_attempt (email, ip, created_at) values
+    ('user2@email.com', '192.168.1.1', current_timestamp);
+select results_eq(
+    $$ select * from get_login_throttle('user3@email.com', '192.168.1.1', 5, '15 minutes') $$,
+    $$ values (false, 1::bigint) $$,
+    'Progressive delay should be applied after 3 failed attempts from the same ip'
+);
+
+-- Check account is locked once the maximum number of attempts is reached
+insert into failed_login_attempt (email, ip, created_at) values
+    ('user1@email.com', '192.168.1.4', current_timestamp - '3 minutes'::interval),
+    ('user1@email.com', '192.168.1.4', current_timestamp - '4 minutes'::interval);
+select results_eq(
+    $$ select * from get_login_throttle('user1@email.com', '192.168.1.5', 5, '15 minutes') $$,
+    $$ values (true, 900::bigint) $$,
+    'Account should be locked once the maximum number of failed attempts is reached'
+);
+
+-- Check account is not locked once the lockout period has elapsed
+delete from failed_login_attempt;
+insert into failed_login_attempt (email, ip, created_at) values
+    ('user1@email.com', '192.168.1.1', current_timestamp - '16 minutes'::interval),
+    ('user1@email.com', '192.168.1.1', current_timestamp - '17 minutes'::interval),
+    ('user1@email.com', '192.168.1.1', current_timestamp - '18 minutes'::interval),
+    ('user1@email.com', '192.168.1.1', current_timestamp - '19 minutes'::interval),
+    ('user1@email.com', '192.168.1.1', current_timestamp - '20 minutes'::interval);
+select results_eq(
+    $$ select * from get_login_throttle('user1@email.com', '192.168.1.1', 5, '15 minutes') $$,
+    $$ values (false, 0::bigint) $$,
+    'Account should not be locked once the lockout period has elapsed'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -13,7 +13,9 @@ insert into "user" (
     last_name,
     email,
     password,
-    profile_image_id
+    profile_image_id,
+    locale,
+    notifications_preferences
 ) values (
     :'user1ID',
     'user1',
@@ -21,7 +23,9 @@ insert into "user" (
     'lastname',
     'user1@email.com',
     'password',
-    '00000000-0000-0000-0000-000000000001'
+    '00000000-0000-0000-0000-000000000001',
+    'es',
+    '{"email_disabled_event_kinds": [0]}'
 );
 
 -- Run some tests
@@ -32,7 +36,11 @@ select is(
         "first_name": "firstname",
         "last_name": "lastname",
         "email": "user1@email.com",
-        "profile_image_id": "00000000-0000-0000-0000-000000000001"
+        "profile_image_id": "00000000-0000-0000-0000-000000000001",
+        "locale": "es",
+        "notifications_preferences": {
+            "email_disabled_event_kinds": [0]
+        }
     }
     '::jsonb,
     'User1 should exist'
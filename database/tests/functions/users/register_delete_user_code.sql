@@ -0,0 +1,41 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed user
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+
+-- Register delete user code
+select register_delete_user_code(:'user1ID') as code1 \gset
+
+-- Check if the code was registered
+select is(
+    delete_user_code_id,
+    sha512(:'code1'),
+    'Code returned should be registered'
+)
+from delete_user_code where user_id = :'user1ID';
+
+-- Register a new delete user code for the same user
+select register_delete_user_code(:'user1ID') as code2 \gset
+
+-- Check the previous code was replaced by the new one
+select is(
+    (select count(*) from delete_user_code where user_id = :'user1ID'),
+    1::bigint,
+    'Only one code should exist for the user'
+);
+select is(
+    delete_user_code_id,
+    sha512(:'code2'),
+    'New code should replace the previous one'
+)
+from delete_user_code where user_id = :'user1ID';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,61 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some users
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+
+-- Register email change code should fail if the email is not available
+select throws_ok(
+    $$ select register_email_change_code('00000000-0000-0000-0000-000000000001', 'user2@email.com') $$,
+    'P0001',
+    'email not available',
+    'Email change code should not be registered when the email is in use'
+);
+
+-- Register email change code
+select register_email_change_code(:'user1ID', 'new1@email.com') as code1 \gset
+
+-- Check if the code was registered
+select results_eq(
+    $$
+        select email_change_code_id, new_email
+        from email_change_code
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    format($$ values (sha512(%L::bytea), 'new1@email.com') $$, :'code1'),
+    'Code returned should be registered for the new email'
+);
+
+-- Register a new email change code for the same user
+select register_email_change_code(:'user1ID', 'new2@email.com') as code2 \gset
+
+-- Check the previous code was replaced by the new one
+select is(
+    (select count(*) from email_change_code where user_id = :'user1ID'),
+    1::bigint,
+    'Only one code should exist for the user'
+);
+select is(
+    email_change_code_id,
+    sha512(:'code2'),
+    'New code should replace the previous one'
+)
+from email_change_code where user_id = :'user1ID';
+select is(
+    new_email,
+    'new2@email.com',
+    'New email should replace the previous one'
+)
+from email_change_code where user_id = :'user1ID';
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
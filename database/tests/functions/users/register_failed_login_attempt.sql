@@ -0,0 +1,33 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Register some failed login attempts
+select is(
+    register_failed_login_attempt('user1@email.com', '192.168.1.1', 2, '15 minutes'),
+    false,
+    'Account should not be locked after the first failed attempt'
+);
+select results_eq(
+    $$ select email, ip from failed_login_attempt $$,
+    $$ values ('user1@email.com', '192.168.1.1'::inet) $$,
+    'Failed attempt should be registered'
+);
+select is(
+    register_failed_login_attempt('user1@email.com', '192.168.1.2', 2, '15 minutes'),
+    true,
+    'Account should be locked once the maximum number of failed attempts is reached'
+);
+
+-- Check old failed attempts are deleted
+insert into failed_login_attempt (email, ip, created_at)
+values ('user2@email.com', '192.168.1.1', current_timestamp - '2 days'::interval);
+select register_failed_login_attempt('user3@email.com', '192.168.1.3', 2, '15 minutes');
+select is_empty(
+    $$ select * from failed_login_attempt where email = 'user2@email.com' $$,
+    'Old failed attempts should be deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
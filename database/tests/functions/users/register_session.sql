@@ -1,10 +1,10 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(4);
 
 -- Seed user
-insert into "user" (user_id, alias, email)
-values ('00000000-0000-0000-0000-000000000001', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email, deletion_scheduled_at)
+values ('00000000-0000-0000-0000-000000000001', 'user1', 'user1@email.com', current_timestamp + '30 day'::interval);
 
 -- Register session
 select register_session('
@@ -41,6 +41,26 @@ select is(
 )
 from session where user_id = '00000000-0000-0000-0000-000000000001';
 
+select results_eq(
+    $$
+        select deletion_scheduled_at from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (null::timestamptz) $$,
+    'User scheduled deletion should have been cancelled'
+);
+
+-- Disabled users cannot register sessions
+insert into "user" (user_id, alias, email, disabled)
+values ('00000000-0000-0000-0000-000000000002', 'user2', 'user2@email.com', true);
+select throws_ok(
+    $$
+        select register_session('{"user_id": "00000000-0000-0000-0000-000000000002"}')
+    $$,
+    'user disabled',
+    'Disabled users should not be able to register sessions'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
@@ -0,0 +1,67 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed user
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+
+-- First device used by the user is not notified
+select register_session('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "ip": "192.168.1.100",
+    "user_agent": "Safari 13.0.5"
+}') as session1_id \gset
+select is(
+    register_session_device(:'session1_id', 'code1'),
+    null,
+    'First device used by the user should not be notified'
+);
+
+-- Known device is not notified
+select register_session('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "ip": "192.168.1.100",
+    "user_agent": "Safari 13.0.5"
+}') as session2_id \gset
+select is(
+    register_session_device(:'session2_id', 'code2'),
+    null,
+    'Known device should not be notified'
+);
+
+-- New device is notified
+select register_session('{
+    "user_id": "00000000-0000-0000-0000-000000000001",
+    "ip": "192.168.1.200",
+    "user_agent": "Firefox 85.0"
+}') as session3_id \gset
+select is(
+    register_session_device(:'session3_id', 'code3'),
+    'user1@email.com',
+    'New device should be notified returning the email of the user'
+);
+select results_eq(
+    $$ select revocation_code from session where revocation_code is not null $$,
+    $$ values (sha512('code3')) $$,
+    'Revocation code should only be associated to the session created from the new device'
+);
+select results_eq(
+    $$ select count(*) from user_device where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    $$ values (2::bigint) $$,
+    'User should have two known devices'
+);
+
+-- Session not found
+select throws_ok(
+    $$ select register_session_device('invalid', 'code') $$,
+    'P0002',
+    'query returned no rows',
+    'No_data_found error should be raised when the session does not exist'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -11,7 +11,8 @@ select register_user('
     "email": "email",
     "email_verified": false,
     "password": "password",
-    "profile_image_id": "00000000-0000-0000-0000-000000000001"
+    "profile_image_id": "00000000-0000-0000-0000-000000000001",
+    "locale": "es"
 }
 ') as code \gset
 
@@ -25,7 +26,8 @@ select results_eq(
             email,
             email_verified,
             password,
-            profile_image_id
+            profile_image_id,
+            locale
         from "user"
         where alias = 'alias'
     $$,
@@ -37,7 +39,8 @@ select results_eq(
             'email',
             false,
             'password',
-            '00000000-0000-0000-0000-000000000001'::uuid
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            'es'
         )
     $$,
     'User should exist'
@@ -0,0 +1,46 @@
+-- Start transaction and plan tests
+begin;
+select plan(4);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into session (session_id, user_id, ip, user_agent, revocation_code)
+values (sha512('session1'), :'user1ID', '192.168.1.100', 'Firefox 85.0', sha512('code'));
+insert into session (session_id, user_id, ip, user_agent)
+values (sha512('session2'), :'user1ID', '192.168.1.200', 'Safari 13.0.5');
+insert into user_device (user_id, device_hash)
+values (:'user1ID', sha512(convert_to('192.168.1.100|Firefox 85.0', 'utf8')));
+insert into user_device (user_id, device_hash)
+values (:'user1ID', sha512(convert_to('192.168.1.200|Safari 13.0.5', 'utf8')));
+
+-- Invalid code
+select throws_ok(
+    $$ select revoke_session('invalid') $$,
+    'invalid session revocation code',
+    'An error should be raised if the revocation code is not valid'
+);
+
+-- Revoke session
+select revoke_session('code');
+select results_eq(
+    $$ select session_id from session $$,
+    $$ values (sha512('session2')) $$,
+    'Only the session associated to the revocation code should have been deleted'
+);
+select results_eq(
+    $$ select device_hash from user_device $$,
+    $$ values (sha512(convert_to('192.168.1.200|Safari 13.0.5', 'utf8'))) $$,
+    'Only the device used to create the revoked session should have been forgotten'
+);
+select throws_ok(
+    $$ select revoke_session('code') $$,
+    'invalid session revocation code',
+    'Revocation codes should not be usable twice'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,64 @@
+-- Start transaction and plan tests
+begin;
+select plan(6);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set code1 '00000000-0000-0000-0000-000000000001'
+\set code2 '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email)
+values (:'user2ID', 'user2', 'user2@email.com');
+insert into delete_user_code (delete_user_code_id, user_id, created_at)
+values (sha512(:'code1'), :'user1ID', current_timestamp - '5 minute'::interval);
+insert into delete_user_code (delete_user_code_id, user_id, created_at)
+values (sha512(:'code2'), :'user2ID', current_timestamp - '30 minute'::interval);
+insert into session (session_id, user_id) values ('session1', :'user1ID');
+
+-- Schedule user deletion should fail in the following cases
+select throws_ok(
+    $$ select schedule_user_deletion('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000003', '30 day') $$,
+    'P0001',
+    'invalid delete user code',
+    'Schedule user deletion failed because code did not exist'
+);
+select throws_ok(
+    $$ select schedule_user_deletion('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', '30 day') $$,
+    'P0001',
+    'invalid delete user code',
+    'Schedule user deletion failed because code belongs to a different user'
+);
+select throws_ok(
+    $$ select schedule_user_deletion('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000002', '30 day') $$,
+    'P0001',
+    'invalid delete user code',
+    'Schedule user deletion failed because code has expired'
+);
+
+-- Schedule user deletion should succeed
+select schedule_user_deletion(:'user1ID', :'code1', '30 day');
+select results_eq(
+    $$
+        select deletion_scheduled_at = current_timestamp + '30 day'::interval
+        from "user"
+        where user_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$ values (true) $$,
+    'User deletion should be scheduled once the grace period elapses'
+);
+select is_empty(
+    $$ select * from delete_user_code where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'Delete user code should have been deleted'
+);
+select is_empty(
+    $$ select * from session where user_id = '00000000-0000-0000-0000-000000000001' $$,
+    'User sessions should have been deleted'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(2);
+select plan(4);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -17,6 +17,16 @@ select results_eq(
     $$ values ('new') $$,
     'User password should have been updated'
 );
+select results_eq(
+    $$ select password from password_history order by password $$,
+    $$ values ('new'), ('old') $$,
+    'Old and new passwords should be registered in the passwords history'
+);
+select isnt(
+    (select password_changed_at from "user" where user_id = '00000000-0000-0000-0000-000000000001'),
+    null,
+    'User password changed timestamp should be set'
+);
 
 -- Try updating user password providing incorrect old password
 select update_user_password(:'user1ID', 'incorrect', 'new2');
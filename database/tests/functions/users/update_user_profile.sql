@@ -30,7 +30,11 @@ select update_user_profile(:'user1ID', '
     "alias": "user1 updated",
     "first_name": "firstname updated",
     "last_name": "lastname updated",
-    "profile_image_id": "00000000-0000-0000-0000-000000000002"
+    "profile_image_id": "00000000-0000-0000-0000-000000000002",
+    "locale": "es",
+    "notifications_preferences": {
+        "email_disabled_event_kinds": [0, 5]
+    }
 }
 '::jsonb);
 
@@ -43,7 +47,9 @@ select results_eq(
             last_name,
             email,
             password,
-            profile_image_id
+            profile_image_id,
+            locale,
+            notifications_preferences
         from "user"
     $$,
     $$
@@ -53,10 +59,12 @@ select results_eq(
             'lastname updated',
             'user1@email.com',
             'password',
-            '00000000-0000-0000-0000-000000000002'::uuid
+            '00000000-0000-0000-0000-000000000002'::uuid,
+            'es',
+            '{"email_disabled_event_kinds": [0, 5]}'::jsonb
         )
     $$,
-    'User first and last name should have been updated'
+    'User profile should have been updated'
 );
 
 -- Finish tests and rollback transaction
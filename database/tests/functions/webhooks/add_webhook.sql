@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(5);
+select plan(6);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -28,7 +28,18 @@ select add_webhook(:'user1ID', null, '
     "secret": "very",
     "content_type": "application/json",
     "template": "custom payload",
+    "format": "slack",
+    "rate_limit": 10,
+    "headers": [
+        {
+            "name": "Authorization",
+            "value": "Bearer token",
+            "secret": true
+        }
+    ],
     "active": true,
+    "batch": true,
+    "all_packages": false,
     "event_kinds": [0],
     "packages": [
         {
@@ -46,7 +57,11 @@ select results_eq(
             secret,
             content_type,
             template,
+            format,
+            rate_limit,
+            headers,
             active,
+            batch,
             user_id,
             organization_id
         from webhook
@@ -60,6 +75,10 @@ select results_eq(
             'very',
             'application/json',
             'custom payload',
+            'slack',
+            10,
+            '[{"name": "Authorization", "value": "Bearer token", "secret": true}]'::jsonb,
+            true,
             true,
             '00000000-0000-0000-0000-000000000001'::uuid,
             null::uuid
@@ -139,6 +158,23 @@ select throws_ok(
     'User not belonging to organization should not be able to webhooks in its name'
 );
 
+-- Add webhook owned by user covering all packages
+select throws_ok(
+    $$
+        select add_webhook('00000000-0000-0000-0000-000000000001', null, '
+        {
+            "name": "webhook4",
+            "url": "http://webhook4.url",
+            "active": true,
+            "all_packages": true
+        }
+        '::jsonb)
+    $$,
+    'P0001',
+    'only organization webhooks can cover all packages',
+    'Webhooks owned by users should not be able to cover all packages'
+);
+
 -- Finish tests and rollback transaction
 select * from finish();
 rollback;
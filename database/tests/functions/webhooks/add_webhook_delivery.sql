@@ -0,0 +1,70 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into webhook (webhook_id, name, url, user_id, consecutive_failures)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID', 100);
+
+-- Run some tests
+select add_webhook_delivery(:'user1ID', '{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "payload": "payload",
+    "content_type": "application/json",
+    "latency": 50,
+    "status_code": 200
+}'::jsonb);
+select results_eq(
+    $$
+        select payload, content_type, latency, status_code
+        from webhook_delivery
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values ('payload', 'application/json', 50, 200)
+    $$,
+    'Delivery should be registered'
+);
+select add_webhook_delivery(:'user1ID', '{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "latency": 50,
+    "status_code": 500,
+    "error": "unexpected status code: 500"
+}'::jsonb);
+select results_eq(
+    $$
+        select active, consecutive_failures, suspended_at
+        from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (true, 1, null::timestamptz)
+    $$,
+    'Failed manual delivery should be counted but webhook should not be suspended'
+);
+select throws_ok(
+    $$
+        select add_webhook_delivery(
+            '00000000-0000-0000-0000-000000000002',
+            '{
+                "webhook_id": "00000000-0000-0000-0000-000000000001",
+                "latency": 50,
+                "status_code": 200
+            }'::jsonb
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Delivery should not be registered by users without access to the webhook'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,39 @@
+-- Start transaction and plan tests
+begin;
+select plan(2);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email)
+values (:'user1ID', 'user1', 'user1@email.com');
+insert into webhook (webhook_id, name, url, user_id, active, consecutive_failures, suspended_at)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID', false, 20, current_timestamp);
+
+-- Try to enable a webhook owned by a user by other user
+select throws_ok(
+    $$
+        select enable_webhook(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Webhook enable should fail because requesting user is not the owner'
+);
+
+-- Enable webhook owned by user
+select enable_webhook(:'user1ID', :'webhook1ID');
+select results_eq(
+    $$ select active, consecutive_failures, suspended_at from webhook $$,
+    $$ values (true, 0, null::timestamptz) $$,
+    'Webhook should have been enabled and its suspension cleared'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -73,6 +73,8 @@ select is(
             "content_type": "application/json",
             "template": "custom payload",
             "active": true,
+            "batch": false,
+            "all_packages": false,
             "event_kinds": [0],
             "packages": [
                 {
@@ -48,6 +48,9 @@ insert into webhook (
     secret,
     content_type,
     template,
+    format,
+    rate_limit,
+    headers,
     active,
     user_id
 ) values (
@@ -58,6 +61,12 @@ insert into webhook (
     'very',
     'application/json',
     'custom payload',
+    'slack',
+    10,
+    '[
+        {"name": "Authorization", "value": "Bearer token", "secret": true},
+        {"name": "X-Custom", "value": "custom"}
+    ]',
     true,
     :'user1ID'
 );
@@ -129,7 +138,15 @@ select is(
         "secret": "very",
         "content_type": "application/json",
         "template": "custom payload",
+        "format": "slack",
+        "rate_limit": 10,
+        "headers": [
+            {"name": "Authorization", "secret": true},
+            {"name": "X-Custom", "value": "custom"}
+        ],
         "active": true,
+        "batch": false,
+        "all_packages": false,
         "event_kinds": [0],
         "packages": [
             {
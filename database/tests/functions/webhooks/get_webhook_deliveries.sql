@@ -0,0 +1,110 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set delivery1ID '00000000-0000-0000-0000-000000000001'
+\set delivery2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, webhook_id)
+values (:'notification1ID', :'event1ID', :'webhook1ID');
+
+-- No deliveries yet
+select is(
+    get_webhook_deliveries(:'user1ID', :'webhook1ID')::jsonb,
+    '[]'::jsonb,
+    'An empty list should be returned when there are no deliveries'
+);
+
+-- Register some deliveries
+insert into webhook_delivery (
+    webhook_delivery_id,
+    webhook_id,
+    notification_id,
+    delivery_id,
+    latency,
+    status_code,
+    response_body,
+    created_at
+) values (
+    :'delivery1ID',
+    :'webhook1ID',
+    :'notification1ID',
+    '00000000-0000-0000-0000-000000000001-1',
+    120,
+    500,
+    'internal error',
+    '2020-06-16 11:20:34+02'
+);
+insert into webhook_delivery (
+    webhook_delivery_id,
+    webhook_id,
+    notification_id,
+    latency,
+    status_code,
+    created_at
+) values (
+    :'delivery2ID',
+    :'webhook1ID',
+    :'notification1ID',
+    80,
+    200,
+    '2020-06-16 11:25:34+02'
+);
+
+-- Run some tests
+select is(
+    get_webhook_deliveries(:'user1ID', :'webhook1ID')::jsonb,
+    '[
+        {
+            "webhook_delivery_id": "00000000-0000-0000-0000-000000000002",
+            "notification_id": "00000000-0000-0000-0000-000000000001",
+            "latency": 80,
+            "status_code": 200,
+            "created_at": 1592299534
+        },
+        {
+            "webhook_delivery_id": "00000000-0000-0000-0000-000000000001",
+            "notification_id": "00000000-0000-0000-0000-000000000001",
+            "delivery_id": "00000000-0000-0000-0000-000000000001-1",
+            "latency": 120,
+            "status_code": 500,
+            "response_body": "internal error",
+            "created_at": 1592299234
+        }
+    ]'::jsonb,
+    'Deliveries of webhook1 should be returned'
+);
+select throws_ok(
+    $$
+        select get_webhook_deliveries(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Deliveries should not be returned to users without access to the webhook'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,82 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set delivery1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into webhook_delivery (
+    webhook_delivery_id,
+    webhook_id,
+    delivery_id,
+    payload,
+    content_type,
+    latency,
+    status_code,
+    response_body,
+    error,
+    created_at
+) values (
+    :'delivery1ID',
+    :'webhook1ID',
+    '00000000-0000-0000-0000-000000000001-1',
+    'payload',
+    'application/json',
+    120,
+    500,
+    'internal error',
+    'unexpected status code: 500',
+    '2020-06-16 11:20:34+02'
+);
+
+-- Run some tests
+select is(
+    get_webhook_delivery(:'user1ID', :'webhook1ID', :'delivery1ID')::jsonb,
+    '{
+        "webhook_delivery_id": "00000000-0000-0000-0000-000000000001",
+        "webhook_id": "00000000-0000-0000-0000-000000000001",
+        "delivery_id": "00000000-0000-0000-0000-000000000001-1",
+        "payload": "payload",
+        "content_type": "application/json",
+        "latency": 120,
+        "status_code": 500,
+        "response_body": "internal error",
+        "error": "unexpected status code: 500",
+        "created_at": 1592299234
+    }'::jsonb,
+    'Delivery should be returned'
+);
+select is_empty(
+    $$
+        select get_webhook_delivery(
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000002'
+        )
+    $$,
+    'Nothing should be returned when the delivery does not exist'
+);
+select throws_ok(
+    $$
+        select get_webhook_delivery(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Delivery should not be returned to users without access to the webhook'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
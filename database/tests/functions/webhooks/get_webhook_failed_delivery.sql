@@ -0,0 +1,71 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, webhook_id, created_at, processed, attempts)
+values (:'notification1ID', :'event1ID', :'webhook1ID', '2020-06-16 11:20:34+02', true, 5);
+insert into notification_dead_letter (notification_id, payload, error, created_at)
+values (:'notification1ID', 'payload', 'fake error', '2020-06-16 11:30:00+02');
+
+-- Run some tests
+select is(
+    get_webhook_failed_delivery(:'user1ID', :'webhook1ID', :'notification1ID')::jsonb,
+    '{
+        "notification_id": "00000000-0000-0000-0000-000000000001",
+        "event_id": "00000000-0000-0000-0000-000000000001",
+        "event_kind": 0,
+        "attempts": 5,
+        "error": "fake error",
+        "payload": "payload",
+        "created_at": 1592299234,
+        "failed_at": 1592299800
+    }'::jsonb,
+    'Failed delivery including its payload should be returned'
+);
+select is_empty(
+    $$
+        select get_webhook_failed_delivery(
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000002'
+        )
+    $$,
+    'Nothing should be returned for a notification not in the dead-letter queue'
+);
+select throws_ok(
+    $$
+        select get_webhook_failed_delivery(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Failed delivery should not be returned to users without access to the webhook'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
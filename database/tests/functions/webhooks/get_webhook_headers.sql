@@ -0,0 +1,52 @@
+-- Start transaction and plan tests
+begin;
+select plan(3);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set webhook2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into webhook (webhook_id, name, url, headers, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', '
+[
+    {"name": "Authorization", "value": "Bearer token", "secret": true},
+    {"name": "X-Custom", "value": "custom"}
+]
+', :'user1ID');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook2ID', 'webhook2', 'http://webhook2.url', :'user1ID');
+
+-- Run some tests
+select is(
+    get_webhook_headers(:'user1ID', :'webhook1ID')::jsonb,
+    '[
+        {"name": "Authorization", "value": "Bearer token", "secret": true},
+        {"name": "X-Custom", "value": "custom"}
+    ]'::jsonb,
+    'Headers should be returned including the secret values'
+);
+select is(
+    get_webhook_headers(:'user1ID', :'webhook2ID')::jsonb,
+    '[]'::jsonb,
+    'An empty array should be returned when the webhook has no headers'
+);
+select throws_ok(
+    $$
+        select get_webhook_headers(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Headers should not be returned to users without access to the webhook'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
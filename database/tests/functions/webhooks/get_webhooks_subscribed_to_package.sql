@@ -1,15 +1,19 @@
 -- Start transaction and plan tests
 begin;
-select plan(3);
+select plan(5);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
+\set org1ID '00000000-0000-0000-0000-000000000001'
 \set repo1ID '00000000-0000-0000-0000-000000000001'
+\set repo2ID '00000000-0000-0000-0000-000000000002'
 \set package1ID '00000000-0000-0000-0000-000000000001'
 \set package2ID '00000000-0000-0000-0000-000000000002'
+\set package3ID '00000000-0000-0000-0000-000000000003'
 \set image1ID '00000000-0000-0000-0000-000000000001'
 \set webhook1ID '00000000-0000-0000-0000-000000000001'
 \set webhook2ID '00000000-0000-0000-0000-000000000002'
+\set webhook3ID '00000000-0000-0000-0000-000000000003'
 
 -- Seed some data
 insert into "user" (user_id, alias, email)
@@ -76,6 +80,27 @@ insert into webhook (
 );
 insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook2ID', 0);
 insert into webhook__package (webhook_id, package_id) values (:'webhook2ID', :'package1ID');
+insert into organization (organization_id, name) values (:'org1ID', 'org1');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, organization_id)
+values (:'repo2ID', 'repo2', 'Repo 2', 'https://repo2.com', 0, :'org1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package3ID', 'Package 3', '1.0.0', :'repo2ID');
+insert into webhook (
+    webhook_id,
+    name,
+    url,
+    active,
+    all_packages,
+    organization_id
+) values (
+    :'webhook3ID',
+    'webhook3',
+    'http://webhook3.url',
+    true,
+    true,
+    :'org1ID'
+);
+insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook3ID', 0);
 
 -- Run some tests
 select is(
@@ -90,6 +115,8 @@ select is(
             "content_type": "application/json",
             "template": "custom payload",
             "active": true,
+            "batch": false,
+            "all_packages": false,
             "event_kinds": [0],
             "packages": [
                 {
@@ -127,6 +154,26 @@ select is(
     '[]',
     'No webhooks should be returned for kind0 and package2'
 );
+select is(
+    get_webhooks_subscribed_to_package(0, :'package3ID')::jsonb,
+    '[
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000003",
+            "name": "webhook3",
+            "url": "http://webhook3.url",
+            "active": true,
+            "batch": false,
+            "all_packages": true,
+            "event_kinds": [0]
+        }
+    ]'::jsonb,
+    'Webhook3 should be returned when asking for kind0 and package3, as it covers all org1 packages'
+);
+select is(
+    get_webhooks_subscribed_to_package(1, :'package3ID')::jsonb,
+    '[]',
+    'No webhooks should be returned for kind1 and package3'
+);
 
 -- Finish tests and rollback transaction
 select * from finish();
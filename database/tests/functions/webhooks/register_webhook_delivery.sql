@@ -0,0 +1,145 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+\set notification2ID '00000000-0000-0000-0000-000000000002'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, webhook_id)
+values (:'notification1ID', :'event1ID', :'webhook1ID');
+insert into notification (notification_id, event_id, webhook_id)
+values (:'notification2ID', :'event1ID', :'webhook1ID');
+
+-- Register some deliveries
+select register_webhook_delivery('{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "delivery_id": "00000000-0000-0000-0000-000000000001-1",
+    "payload": "payload",
+    "content_type": "application/json",
+    "latency": 120,
+    "status_code": 500,
+    "response_body": "internal error"
+}'::jsonb, 3);
+select register_webhook_delivery('{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "latency": 30,
+    "error": "connection refused"
+}'::jsonb, 3);
+
+-- Run some tests
+select results_eq(
+    $$
+        select notification_id, delivery_id, payload, content_type, latency, status_code, response_body, error
+        from webhook_delivery
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+        and status_code is not null
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            '00000000-0000-0000-0000-000000000001-1',
+            'payload',
+            'application/json',
+            120,
+            500,
+            'internal error',
+            null::text
+        )
+    $$,
+    'Delivery with response should be registered'
+);
+select results_eq(
+    $$
+        select notification_id, payload, content_type, latency, status_code, response_body, error
+        from webhook_delivery
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+        and status_code is null
+    $$,
+    $$
+        values (
+            '00000000-0000-0000-0000-000000000001'::uuid,
+            null::text,
+            null::text,
+            30,
+            null::integer,
+            null::text,
+            'connection refused'
+        )
+    $$,
+    'Delivery with error should be registered'
+);
+select results_eq(
+    $$ select consecutive_failures, active from webhook $$,
+    $$ values (2, true) $$,
+    'Webhook should have two consecutive failures and still be active'
+);
+
+-- Register a successful delivery
+select register_webhook_delivery('{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "latency": 50,
+    "status_code": 200
+}'::jsonb, 3);
+select results_eq(
+    $$ select consecutive_failures, active from webhook $$,
+    $$ values (0, true) $$,
+    'Consecutive failures should have been reset after a successful delivery'
+);
+
+-- Register failed deliveries until the webhook is suspended
+select register_webhook_delivery('{
+    "webhook_id": "00000000-0000-0000-0000-000000000001",
+    "notification_id": "00000000-0000-0000-0000-000000000001",
+    "latency": 30,
+    "error": "connection refused"
+}'::jsonb, 3)
+from generate_series(1, 3);
+select results_eq(
+    $$ select consecutive_failures, active, suspended_at is not null from webhook $$,
+    $$ values (3, false, true) $$,
+    'Webhook should have been suspended'
+);
+select results_eq(
+    $$ select processed, error from notification where notification_id = '00000000-0000-0000-0000-000000000002' $$,
+    $$ values (true, 'webhook suspended') $$,
+    'Pending notifications for the webhook should have been discarded'
+);
+select is(
+    (select data from event where event_kind_id = 6),
+    '{
+        "webhook": {
+            "webhook_id": "00000000-0000-0000-0000-000000000001",
+            "name": "webhook1",
+            "url": "http://webhook1.url"
+        },
+        "consecutive_failures": 3,
+        "last_error": "connection refused",
+        "subscriptors": [
+            {"user_id": "00000000-0000-0000-0000-000000000001"}
+        ]
+    }'::jsonb,
+    'Webhook suspended event should have been registered'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
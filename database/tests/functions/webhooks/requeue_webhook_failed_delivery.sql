@@ -0,0 +1,83 @@
+-- Start transaction and plan tests
+begin;
+select plan(5);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set user2ID '00000000-0000-0000-0000-000000000002'
+\set repo1ID '00000000-0000-0000-0000-000000000001'
+\set package1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+\set event1ID '00000000-0000-0000-0000-000000000001'
+\set notification1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into "user" (user_id, alias, email) values (:'user2ID', 'user2', 'user2@email.com');
+insert into repository (repository_id, name, display_name, url, repository_kind_id, user_id)
+values (:'repo1ID', 'repo1', 'Repo 1', 'https://repo1.com', 0, :'user1ID');
+insert into package (package_id, name, latest_version, repository_id)
+values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into event (event_id, package_version, package_id, event_kind_id)
+values (:'event1ID', '1.0.0', :'package1ID', 0);
+insert into notification (notification_id, event_id, webhook_id, processed, processed_at, error, attempts)
+values (:'notification1ID', :'event1ID', :'webhook1ID', true, current_timestamp, 'fake error', 5);
+insert into notification_dead_letter (notification_id, payload, error)
+values (:'notification1ID', 'payload', 'fake error');
+
+-- Try to requeue a failed delivery by a user without access to the webhook
+select throws_ok(
+    $$
+        select requeue_webhook_failed_delivery(
+            '00000000-0000-0000-0000-000000000002',
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    42501,
+    'insufficient_privilege',
+    'Requeue should fail because requesting user does not have access to the webhook'
+);
+
+-- Requeue failed delivery
+select requeue_webhook_failed_delivery(:'user1ID', :'webhook1ID', :'notification1ID');
+select results_eq(
+    $$
+        select processed, processed_at, error, attempts, next_attempt_at
+        from notification
+        where notification_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (false, null::timestamptz, null::text, 0, null::timestamptz)
+    $$,
+    'Notification should be pending again'
+);
+select is_empty(
+    $$ select * from notification_dead_letter $$,
+    'Notification should have been removed from the dead-letter queue'
+);
+select is(
+    get_pending_notifications(1)::jsonb->0->>'notification_id',
+    '00000000-0000-0000-0000-000000000001',
+    'Requeued notification should be returned as pending'
+);
+
+-- Try to requeue it again, it is not in the dead-letter queue anymore
+select throws_ok(
+    $$
+        select requeue_webhook_failed_delivery(
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001',
+            '00000000-0000-0000-0000-000000000001'
+        )
+    $$,
+    'P0002',
+    'no_data_found',
+    'Requeue should fail because the notification is not in the dead-letter queue'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
@@ -0,0 +1,66 @@
+-- Start transaction and plan tests
+begin;
+select plan(7);
+
+-- Declare some variables
+\set user1ID '00000000-0000-0000-0000-000000000001'
+\set webhook1ID '00000000-0000-0000-0000-000000000001'
+
+-- Seed some data
+insert into "user" (user_id, alias, email) values (:'user1ID', 'user1', 'user1@email.com');
+insert into webhook (webhook_id, name, url, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+
+-- Run some tests
+select is(
+    reserve_webhook_deliveries(:'webhook1ID', 5),
+    5,
+    'All deliveries should be granted to webhooks without a rate limit'
+);
+
+-- Limit webhook1 to three deliveries per minute
+update webhook set rate_limit = 3 where webhook_id = :'webhook1ID';
+select is(
+    reserve_webhook_deliveries(:'webhook1ID', 2),
+    2,
+    'Deliveries requested should be granted as the rate limit is not reached'
+);
+select is(
+    reserve_webhook_deliveries(:'webhook1ID', 2),
+    1,
+    'Only the remaining delivery should be granted to a reservation racing with a previous one'
+);
+select is(
+    reserve_webhook_deliveries(:'webhook1ID', 1),
+    0,
+    'No deliveries should be granted once the rate limit is reached'
+);
+select results_eq(
+    $$
+        select rate_limit_window_start, rate_limit_window_deliveries
+        from webhook
+        where webhook_id = '00000000-0000-0000-0000-000000000001'
+    $$,
+    $$
+        values (current_timestamp, 3)
+    $$,
+    'Deliveries granted should be registered in the current rate limit window'
+);
+
+-- Rate limit window elapsed
+update webhook set rate_limit_window_start = current_timestamp - interval '2 minutes'
+where webhook_id = :'webhook1ID';
+select is(
+    reserve_webhook_deliveries(:'webhook1ID', 2),
+    2,
+    'Deliveries should be granted again in a new rate limit window'
+);
+select is(
+    (select rate_limit_window_deliveries from webhook where webhook_id = :'webhook1ID'),
+    2,
+    'Deliveries granted should be registered in the new rate limit window'
+);
+
+-- Finish tests and rollback transaction
+select * from finish();
+rollback;
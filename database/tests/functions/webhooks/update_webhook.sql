@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(6);
+select plan(7);
 
 -- Declare some variables
 \set user1ID '00000000-0000-0000-0000-000000000001'
@@ -24,8 +24,13 @@ insert into package (package_id, name, latest_version, repository_id)
 values (:'package1ID', 'Package 1', '1.0.0', :'repo1ID');
 insert into package (package_id, name, latest_version, repository_id)
 values (:'package2ID', 'Package 2', '1.0.0', :'repo1ID');
-insert into webhook (webhook_id, name, url, user_id)
-values (:'webhook1ID', 'webhook1', 'http://webhook1.url', :'user1ID');
+insert into webhook (webhook_id, name, url, headers, user_id)
+values (:'webhook1ID', 'webhook1', 'http://webhook1.url', '
+[
+    {"name": "Authorization", "value": "Bearer token", "secret": true},
+    {"name": "X-Old", "value": "old"}
+]
+', :'user1ID');
 insert into webhook__event_kind (webhook_id, event_kind_id) values (:'webhook1ID', 0);
 insert into webhook__package (webhook_id, package_id) values (:'webhook1ID', :'package1ID');
 insert into webhook (webhook_id, name, url, organization_id)
@@ -63,6 +68,23 @@ select throws_ok(
     'Webhook update should fail because requesting user does not belong to owning organization'
 );
 
+-- Try to update webhook owned by user to cover all packages
+select throws_ok(
+    $$
+        select update_webhook('00000000-0000-0000-0000-000000000001', '
+        {
+            "webhook_id": "00000000-0000-0000-0000-000000000001",
+            "name": "webhook1 updated",
+            "url": "http://webhook1.url",
+            "all_packages": true
+        }
+        '::jsonb)
+    $$,
+    'P0001',
+    'only organization webhooks can cover all packages',
+    'Webhooks owned by users should not be able to cover all packages'
+);
+
 -- Update webhook owned by user
 select update_webhook('00000000-0000-0000-0000-000000000001', '
 {
@@ -73,7 +95,21 @@ select update_webhook('00000000-0000-0000-0000-000000000001', '
     "secret": "very updated",
     "content_type": "text/xml",
     "template": "custom payload updated",
+    "format": "slack",
+    "rate_limit": 20,
+    "headers": [
+        {
+            "name": "Authorization",
+            "secret": true
+        },
+        {
+            "name": "X-New",
+            "value": "new"
+        }
+    ],
     "active": false,
+    "batch": true,
+    "all_packages": false,
     "event_kinds": [1],
     "packages": [
         {
@@ -91,7 +127,11 @@ select results_eq(
             secret,
             content_type,
             template,
+            format,
+            rate_limit,
+            headers,
             active,
+            batch,
             user_id,
             organization_id
         from webhook
@@ -105,7 +145,14 @@ select results_eq(
             'very updated',
             'text/xml',
             'custom payload updated',
+            'slack',
+            20,
+            '[
+                {"name": "Authorization", "value": "Bearer token", "secret": true},
+                {"name": "X-New", "value": "new"}
+            ]'::jsonb,
             false,
+            true,
             '00000000-0000-0000-0000-000000000001'::uuid,
             null::uuid
         )
@@ -143,12 +190,13 @@ select update_webhook('00000000-0000-0000-0000-000000000001', '
     "webhook_id": "00000000-0000-0000-0000-000000000002",
     "name": "webhook2 updated",
     "url": "http://webhook2.url/updated",
-    "active": false
+    "active": false,
+    "all_packages": true
 }
 '::jsonb);
 select results_eq(
     $$
-        select name, url, active
+        select name, url, active, all_packages
         from webhook
         where webhook_id = '00000000-0000-0000-0000-000000000002'
     $$,
@@ -156,7 +204,8 @@ select results_eq(
         values (
             'webhook2 updated',
             'http://webhook2.url/updated',
-            false
+            false,
+            true
         )
     $$,
     'Webhook2 owned by org1 should have been updated'
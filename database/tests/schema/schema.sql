@@ -1,6 +1,6 @@
 -- Start transaction and plan tests
 begin;
-select plan(140);
+select plan(338);
 
 -- Check default_text_search_config is correct
 select results_eq(
@@ -12,42 +12,115 @@ select results_eq(
 -- Check pgcrypto extension exist
 select has_extension('pgcrypto');
 
+-- Check pg_trgm extension exist
+select has_extension('pg_trgm');
+
 -- Check expected tables exist
 select tables_are(array[
+    'abuse_report',
     'api_key',
+    'api_key_usage',
+    'audit_log',
+    'delete_user_code',
+    'email_change_code',
     'email_verification_code',
     'event',
     'event_kind',
+    'failed_login_attempt',
     'image',
     'image_version',
     'maintainer',
     'notification',
+    'notification_dead_letter',
     'opt_out',
     'organization',
+    'organization_name_alias',
+    'organization_role',
     'package',
     'package__maintainer',
+    'package_comment',
+    'package_opt_out',
+    'package_pulls',
+    'package_pulls_total',
+    'package_review',
+    'package_review_report',
+    'package_vulnerability_suppression',
+    'password_history',
     'password_reset_code',
+    'publisher_subscription',
     'repository',
     'repository_kind',
+    'repository_ownership_claim',
+    'repository_subscription',
+    'repository_tracking_run',
+    'saved_search',
+    'saved_search_match',
     'session',
     'snapshot',
     'subscription',
+    'team',
+    'team__repository',
     'user',
+    'user_device',
     'user_starred_package',
     'user__organization',
+    'user__team',
+    'verified_publisher_change',
     'version_functions',
     'version_schema',
     'webhook',
     'webhook__event_kind',
-    'webhook__package'
+    'webhook__package',
+    'webhook_delivery'
 ]);
 
 -- Check tables have expected columns
+select columns_are('abuse_report', array[
+    'abuse_report_id',
+    'package_id',
+    'repository_id',
+    'user_id',
+    'reason',
+    'description',
+    'status',
+    'notes',
+    'triaged_by',
+    'created_at',
+    'updated_at'
+]);
 select columns_are('api_key', array[
     'api_key_id',
     'name',
     'secret',
     'user_id',
+    'created_at',
+    'scopes',
+    'last_used_at',
+    'last_used_ip'
+]);
+select columns_are('api_key_usage', array[
+    'api_key_id',
+    'day',
+    'requests'
+]);
+select columns_are('audit_log', array[
+    'audit_log_id',
+    'action',
+    'user_id',
+    'organization_id',
+    'ip',
+    'details',
+    'created_at'
+]);
+select columns_are('delete_user_code', array[
+    'delete_user_code_id',
+    'user_id',
+    'created_at'
+]);
+select columns_are('email_change_code', array[
+    'email_change_code_id',
+    'user_id',
+    'new_email',
     'created_at'
 ]);
 select columns_are('email_verification_code', array[
@@ -70,9 +143,16 @@ select columns_are('event_kind', array[
     'event_kind_id',
     'name'
 ]);
+select columns_are('failed_login_attempt', array[
+    'failed_login_attempt_id',
+    'email',
+    'ip',
+    'created_at'
+]);
 select columns_are('image', array[
     'image_id',
-    'original_hash'
+    'original_hash',
+    'created_at'
 ]);
 select columns_are('image_version', array[
     'image_id',
@@ -90,10 +170,18 @@ select columns_are('notification', array[
     'processed',
     'processed_at',
     'error',
+    'attempts',
+    'next_attempt_at',
     'event_id',
     'user_id',
     'webhook_id'
 ]);
+select columns_are('notification_dead_letter', array[
+    'notification_id',
+    'payload',
+    'error',
+    'created_at'
+]);
 select columns_are('opt_out', array[
     'opt_out_id',
     'user_id',
@@ -111,7 +199,18 @@ select columns_are('organization', array[
     'authorization_enabled',
     'predefined_policy',
     'custom_policy',
-    'policy_data'
+    'policy_data',
+    'deletion_scheduled_at'
+]);
+select columns_are('organization_name_alias', array[
+    'name',
+    'organization_id',
+    'created_at'
+]);
+select columns_are('organization_role', array[
+    'role',
+    'display_name',
+    'allowed_actions'
 ]);
 select columns_are('package', array[
     'package_id',
@@ -120,22 +219,91 @@ select columns_are('package', array[
     'latest_version',
     'stars',
     'tsdoc',
+    'fuzzy_text',
     'is_operator',
     'official',
     'channels',
     'default_channel',
     'created_at',
-    'repository_id'
+    'repository_id',
+    'rating',
+    'ratings'
 ]);
 select columns_are('package__maintainer', array[
     'package_id',
     'maintainer_id'
 ]);
+select columns_are('package_comment', array[
+    'package_comment_id',
+    'package_id',
+    'parent_id',
+    'user_id',
+    'body',
+    'hidden',
+    'created_at',
+    'updated_at'
+]);
+select columns_are('package_opt_out', array[
+    'user_id',
+    'package_id',
+    'event_kind_id',
+    'created_at'
+]);
+select columns_are('package_pulls', array[
+    'package_id',
+    'day',
+    'pulls'
+]);
+select columns_are('package_pulls_total', array[
+    'package_id',
+    'total',
+    'updated_at'
+]);
+select columns_are('package_review', array[
+    'package_review_id',
+    'package_id',
+    'user_id',
+    'rating',
+    'title',
+    'body',
+    'reply',
+    'replied_at',
+    'hidden',
+    'created_at',
+    'updated_at'
+]);
+select columns_are('package_review_report', array[
+    'package_review_id',
+    'user_id',
+    'reason',
+    'created_at'
+]);
+select columns_are('package_vulnerability_suppression', array[
+    'package_id',
+    'vulnerability_id',
+    'justification',
+    'user_id',
+    'created_at'
+]);
+select columns_are('password_history', array[
+    'password_history_id',
+    'user_id',
+    'password',
+    'created_at'
+]);
 select columns_are('password_reset_code', array[
     'password_reset_code_id',
     'user_id',
     'created_at'
 ]);
+select columns_are('publisher_subscription', array[
+    'publisher_subscription_id',
+    'user_id',
+    'organization_id',
+    'publisher_user_id',
+    'event_kind_id',
+    'min_severity'
+]);
 select columns_are('repository', array[
     'repository_id',
     'name',
@@ -146,12 +314,22 @@ select columns_are('repository', array[
     'auth_pass',
     'last_tracking_ts',
     'last_tracking_errors',
+    'last_successful_tracking_ts',
     'last_scanning_ts',
     'last_scanning_errors',
     'verified_publisher',
     'official',
     'disabled',
     'scanner_disabled',
+    'tracking_enabled',
+    'tracking_schedule',
+    'retention_policy',
+    'tracking_webhook_secret',
+    'tracking_requested_at',
+    'tls_config',
+    'auth_pass_ref',
+    'proxy_url',
+    'path',
     'digest',
     'created_at',
     'repository_kind_id',
@@ -162,12 +340,58 @@ select columns_are('repository_kind', array[
     'repository_kind_id',
     'name'
 ]);
+select columns_are('repository_ownership_claim', array[
+    'repository_ownership_claim_id',
+    'repository_id',
+    'user_id',
+    'organization_id',
+    'method',
+    'token',
+    'status',
+    'last_error',
+    'checked_at',
+    'created_at'
+]);
+select columns_are('repository_subscription', array[
+    'user_id',
+    'repository_id',
+    'event_kind_id',
+    'min_severity'
+]);
+select columns_are('repository_tracking_run', array[
+    'repository_tracking_run_id',
+    'repository_id',
+    'status',
+    'packages_total',
+    'packages_processed',
+    'errors',
+    'created_at',
+    'started_at',
+    'finished_at'
+]);
+select columns_are('saved_search', array[
+    'saved_search_id',
+    'user_id',
+    'name',
+    'query',
+    'notifications_enabled',
+    'created_at',
+    'last_evaluated_at'
+]);
+select columns_are('saved_search_match', array[
+    'saved_search_id',
+    'package_id',
+    'baseline',
+    'seen',
+    'created_at'
+]);
 select columns_are('session', array[
     'session_id',
     'user_id',
     'ip',
     'user_agent',
-    'created_at'
+    'created_at',
+    'revocation_code'
 ]);
 select columns_are('snapshot', array[
     'package_id',
@@ -187,27 +411,48 @@ select columns_are('snapshot', array[
     'crds_examples',
     'security_report',
     'security_report_created_at',
+    'security_report_rescan_requested_at',
     'security_report_summary',
     'capabilities',
     'data',
     'deprecated',
     'license',
     'signed',
+    'signatures',
+    'provenance',
     'content_url',
     'containers_images',
     'provider',
     'values_schema',
+    'default_values',
+    'sboms',
     'changes',
     'contains_security_updates',
     'prerelease',
     'ts',
     'created_at',
-    'recommendations'
+    'recommendations',
+    'yanked',
+    'yanked_reason'
 ]);
 select columns_are('subscription', array[
     'user_id',
     'package_id',
-    'event_kind_id'
+    'event_kind_id',
+    'min_severity'
+]);
+select columns_are('team', array[
+    'team_id',
+    'organization_id',
+    'name',
+    'display_name',
+    'description',
+    'created_at'
+]);
+select columns_are('team__repository', array[
+    'team_id',
+    'repository_id',
+    'allowed_actions'
 ]);
 select columns_are('user', array[
     'user_id',
@@ -218,6 +463,18 @@ select columns_are('user', array[
     'email_verified',
     'password',
     'profile_image_id',
+    'created_at',
+    'locale',
+    'notifications_preferences',
+    'deletion_scheduled_at',
+    'disabled',
+    'site_admin',
+    'service_account_organization_id',
+    'password_changed_at'
+]);
+select columns_are('user_device', array[
+    'user_id',
+    'device_hash',
     'created_at'
 ]);
 select columns_are('user_starred_package', array[
@@ -227,7 +484,21 @@ select columns_are('user_starred_package', array[
 select columns_are('user__organization', array[
     'user_id',
     'organization_id',
-    'confirmed'
+    'confirmed',
+    'role'
+]);
+select columns_are('user__team', array[
+    'user_id',
+    'team_id'
+]);
+select columns_are('verified_publisher_change', array[
+    'verified_publisher_change_id',
+    'repository_id',
+    'user_id',
+    'source',
+    'verified',
+    'evidence',
+    'created_at'
 ]);
 select columns_are('version_functions', array[
     'version'
@@ -243,11 +514,20 @@ select columns_are('webhook', array[
     'secret',
     'content_type',
     'template',
+    'format',
+    'rate_limit',
+    'headers',
     'active',
     'created_at',
     'updated_at',
     'user_id',
-    'organization_id'
+    'organization_id',
+    'consecutive_failures',
+    'suspended_at',
+    'batch',
+    'all_packages',
+    'rate_limit_window_start',
+    'rate_limit_window_deliveries'
 ]);
 select columns_are('webhook__event_kind', array[
     'webhook_id',
@@ -257,11 +537,48 @@ select columns_are('webhook__package', array[
     'webhook_id',
     'package_id'
 ]);
+select columns_are('webhook_delivery', array[
+    'webhook_delivery_id',
+    'webhook_id',
+    'notification_id',
+    'delivery_id',
+    'payload',
+    'content_type',
+    'latency',
+    'status_code',
+    'response_body',
+    'error',
+    'created_at'
+]);
 
 -- Check tables have expected indexes
+select indexes_are('abuse_report', array[
+    'abuse_report_pkey',
+    'abuse_report_status_idx',
+    'abuse_report_package_id_idx',
+    'abuse_report_repository_id_idx',
+    'abuse_report_user_id_idx'
+]);
 select indexes_are('api_key', array[
     'api_key_pkey'
 ]);
+select indexes_are('api_key_usage', array[
+    'api_key_usage_pkey'
+]);
+select indexes_are('audit_log', array[
+    'audit_log_pkey',
+    'audit_log_action_idx',
+    'audit_log_user_id_idx',
+    'audit_log_organization_id_idx'
+]);
+select indexes_are('delete_user_code', array[
+    'delete_user_code_pkey',
+    'delete_user_code_user_id_key'
+]);
+select indexes_are('email_change_code', array[
+    'email_change_code_pkey',
+    'email_change_code_user_id_key'
+]);
 select indexes_are('email_verification_code', array[
     'email_verification_code_pkey',
     'email_verification_code_user_id_key'
@@ -270,6 +587,11 @@ select indexes_are('event', array[
     'event_pkey',
     'event_not_processed_idx'
 ]);
+select indexes_are('failed_login_attempt', array[
+    'failed_login_attempt_pkey',
+    'failed_login_attempt_email_idx',
+    'failed_login_attempt_ip_idx'
+]);
 select indexes_are('image', array[
     'image_pkey',
     'image_original_hash_key'
@@ -288,6 +610,9 @@ select indexes_are('notification', array[
     'notification_event_id_webhook_id_key',
     'notification_webhook_id_created_at_idx'
 ]);
+select indexes_are('notification_dead_letter', array[
+    'notification_dead_letter_pkey'
+]);
 select indexes_are('opt_out', array[
     'opt_out_pkey',
     'opt_out_user_id_repository_id_event_kind_id_key'
@@ -296,19 +621,65 @@ select indexes_are('organization', array[
     'organization_pkey',
     'organization_name_key'
 ]);
+select indexes_are('organization_name_alias', array[
+    'organization_name_alias_pkey',
+    'organization_name_alias_organization_id_idx'
+]);
+select indexes_are('organization_role', array[
+    'organization_role_pkey'
+]);
 select indexes_are('package', array[
     'package_pkey',
     'package_tsdoc_idx',
+    'package_fuzzy_text_idx',
     'package_repository_id_idx',
     'package_repository_id_name_key'
 ]);
 select indexes_are('package__maintainer', array[
     'package__maintainer_pkey'
 ]);
+select indexes_are('package_comment', array[
+    'package_comment_pkey',
+    'package_comment_package_id_idx',
+    'package_comment_parent_id_idx',
+    'package_comment_user_id_idx'
+]);
+select indexes_are('package_opt_out', array[
+    'package_opt_out_pkey'
+]);
+select indexes_are('package_pulls', array[
+    'package_pulls_pkey',
+    'package_pulls_day_idx'
+]);
+select indexes_are('package_pulls_total', array[
+    'package_pulls_total_pkey'
+]);
+select indexes_are('package_review', array[
+    'package_review_pkey',
+    'package_review_package_id_user_id_key',
+    'package_review_user_id_idx'
+]);
+select indexes_are('package_review_report', array[
+    'package_review_report_pkey'
+]);
+select indexes_are('package_vulnerability_suppression', array[
+    'package_vulnerability_suppression_pkey'
+]);
+select indexes_are('password_history', array[
+    'password_history_pkey',
+    'password_history_user_id_idx'
+]);
 select indexes_are('password_reset_code', array[
     'password_reset_code_pkey',
     'password_reset_code_user_id_key'
 ]);
+select indexes_are('publisher_subscription', array[
+    'publisher_subscription_pkey',
+    'publisher_subscription_organization_key',
+    'publisher_subscription_publisher_user_key',
+    'publisher_subscription_organization_id_idx',
+    'publisher_subscription_publisher_user_id_idx'
+]);
 select indexes_are('repository', array[
     'repository_pkey',
     'repository_name_key',
@@ -320,8 +691,31 @@ select indexes_are('repository', array[
 select indexes_are('repository_kind', array[
     'repository_kind_pkey'
 ]);
+select indexes_are('repository_ownership_claim', array[
+    'repository_ownership_claim_pkey',
+    'repository_ownership_claim_repository_id_user_id_key',
+    'repository_ownership_claim_status_idx',
+    'repository_ownership_claim_user_id_idx'
+]);
+select indexes_are('repository_subscription', array[
+    'repository_subscription_pkey',
+    'repository_subscription_repository_id_idx'
+]);
+select indexes_are('repository_tracking_run', array[
+    'repository_tracking_run_pkey',
+    'repository_tracking_run_repository_id_idx'
+]);
+select indexes_are('saved_search', array[
+    'saved_search_pkey',
+    'saved_search_user_id_name_key'
+]);
+select indexes_are('saved_search_match', array[
+    'saved_search_match_pkey',
+    'saved_search_match_package_id_idx'
+]);
 select indexes_are('session', array[
-    'session_pkey'
+    'session_pkey',
+    'session_revocation_code_key'
 ]);
 select indexes_are('snapshot', array[
     'snapshot_pkey',
@@ -331,17 +725,38 @@ select indexes_are('snapshot', array[
 select indexes_are('subscription', array[
     'subscription_pkey'
 ]);
+select indexes_are('team', array[
+    'team_pkey',
+    'team_organization_id_name_key'
+]);
+select indexes_are('team__repository', array[
+    'team__repository_pkey',
+    'team__repository_repository_id_idx'
+]);
 select indexes_are('user', array[
     'user_pkey',
     'user_alias_key',
-    'user_email_key'
+    'user_email_key',
+    'user_service_account_organization_id_idx'
+]);
+select indexes_are('user_device', array[
+    'user_device_pkey'
 ]);
 select indexes_are('user__organization', array[
     'user__organization_pkey'
 ]);
+select indexes_are('user__team', array[
+    'user__team_pkey',
+    'user__team_team_id_idx'
+]);
 select indexes_are('user_starred_package', array[
     'user_starred_package_pkey'
 ]);
+select indexes_are('verified_publisher_change', array[
+    'verified_publisher_change_pkey',
+    'verified_publisher_change_repository_id_idx',
+    'verified_publisher_change_user_id_idx'
+]);
 select indexes_are('webhook', array[
     'webhook_pkey',
     'webhook_user_id_idx',
@@ -353,40 +768,86 @@ select indexes_are('webhook__event_kind', array[
 select indexes_are('webhook__package', array[
     'webhook__package_pkey'
 ]);
+select indexes_are('webhook_delivery', array[
+    'webhook_delivery_pkey',
+    'webhook_delivery_webhook_id_created_at_idx'
+]);
 
 -- Check expected functions exist
+-- Abuse reports
+select has_function('add_abuse_report');
+select has_function('get_abuse_reports');
+select has_function('triage_abuse_report');
+
+-- Admin
+select has_function('force_user_password_reset');
+select has_function('get_user_resources');
+select has_function('get_verified_publisher_changes');
+select has_function('revoke_user_credentials');
+select has_function('search_users');
+select has_function('update_user_disabled');
+select has_function('update_verified_publisher');
+select has_function('user_is_site_admin');
 -- API keys
 select has_function('add_api_key');
 select has_function('delete_api_key');
 select has_function('get_api_key');
 select has_function('get_user_api_keys');
+select has_function('register_api_keys_usage');
 select has_function('update_api_key');
+-- Audit
+select has_function('get_organization_audit_log');
+select has_function('get_user_audit_log');
+select has_function('register_audit_log_entry');
 -- Authz
 select has_function('notify_authorization_policies_updates');
+-- Comments
+select has_function('add_package_comment');
+select has_function('delete_package_comment');
+select has_function('get_package_comments');
+select has_function('set_package_comment_hidden');
+select has_function('update_package_comment');
 -- Events
 select has_function('get_pending_event');
 -- Images
+select has_function('delete_image');
 select has_function('get_image');
+select has_function('get_images_created_before');
+select has_function('get_referenced_images');
 select has_function('register_image');
 -- Notifications
 select has_function('add_notification');
-select has_function('get_pending_notification');
+select has_function('defer_notification');
+select has_function('get_pending_notifications');
+select has_function('notify_notifications_created');
+select has_function('register_notification_delivery_failure');
+select has_function('renew_notifications_lease');
 select has_function('update_notification_status');
 -- Organizations
 select has_function('add_organization');
 select has_function('add_organization_member');
+select has_function('cancel_organization_deletion');
 select has_function('confirm_organization_membership');
-select has_function('delete_organization');
 select has_function('delete_organization_member');
+select has_function('delete_scheduled_organizations');
 select has_function('get_authorization_policies');
 select has_function('get_authorization_policy');
 select has_function('get_organization');
 select has_function('get_organization_members');
+select has_function('get_user_allowed_actions');
 select has_function('get_user_organizations');
+select has_function('register_organization_name_alias');
+select has_function('rename_organization');
+select has_function('schedule_organization_deletion');
+select has_function('transfer_organization_ownership');
 select has_function('update_authorization_policy');
 select has_function('update_organization');
+select has_function('update_organization_member_role');
 select has_function('user_belongs_to_organization');
 -- Packages
+select has_function('add_package_vulnerability_suppression');
+select has_function('delete_package_vulnerability_suppression');
+select has_function('generate_package_fuzzy_text');
 select has_function('generate_package_tsdoc');
 select has_function('get_harbor_replication_dump');
 select has_function('get_package');
@@ -395,16 +856,42 @@ select has_function('get_package_summary');
 select has_function('get_packages_starred_by_user');
 select has_function('get_package_stars');
 select has_function('get_packages_stats');
+select has_function('get_package_vulnerability_suppressions');
+select has_function('get_packages_versions');
 select has_function('get_random_packages');
+select has_function('get_recent_releases');
 select has_function('get_snapshots_to_scan');
 select has_function('register_package');
 select has_function('search_packages');
 select has_function('search_packages_monocular');
 select has_function('semver_gt');
 select has_function('semver_gte');
+select has_function('request_snapshot_security_report_rescan');
 select has_function('toggle_star');
+select has_function('update_package_latest_version');
 select has_function('update_snapshot_security_report');
 select has_function('unregister_package');
+select has_function('unyank_package_version');
+select has_function('yank_package_version');
+-- Pagination
+select has_function('decode_cursor');
+select has_function('encode_cursor');
+select has_function('get_cursor');
+select has_function('get_cursor_scope');
+-- Pulls
+select has_function('get_package_pulls');
+select has_function('get_pulls_polling_sources');
+select has_function('get_trending_packages');
+select has_function('register_package_pulls');
+select has_function('register_package_pulls_total');
+-- Reviews
+select has_function('add_package_review');
+select has_function('delete_package_review');
+select has_function('get_package_reviews');
+select has_function('reply_package_review');
+select has_function('report_package_review');
+select has_function('update_package_rating');
+select has_function('user_is_package_publisher');
 -- Repositories
 select has_function('add_repository');
 select has_function('delete_repository');
@@ -412,45 +899,129 @@ select has_function('get_all_repositories');
 select has_function('get_repositories_by_kind');
 select has_function('get_repository_by_id');
 select has_function('get_repository_by_name');
+select has_function('get_repository_ownership_claim');
 select has_function('get_repository_packages_digest');
+select has_function('get_repository_tracking_status');
 select has_function('get_repository_summary');
 select has_function('get_org_repositories');
+select has_function('get_pending_repository_ownership_claims');
 select has_function('get_user_repositories');
+select has_function('register_repository_ownership_claim_check');
+select has_function('register_repository_tracking_run');
+select has_function('register_verified_publisher_change');
+select has_function('request_repository_ownership_claim');
+select has_function('search_repositories');
 select has_function('set_last_scanning_results');
 select has_function('set_last_tracking_results');
 select has_function('set_verified_publisher');
 select has_function('transfer_repository');
 select has_function('update_repository');
+select has_function('update_repository_credentials');
+select has_function('update_repository_tracking_enabled');
+select has_function('update_repository_tracking_run');
+select has_function('update_repository_tracking_webhook');
+select has_function('verify_repository_ownership_claim');
+-- Saved searches
+select has_function('add_saved_search');
+select has_function('delete_saved_search');
+select has_function('evaluate_saved_searches');
+select has_function('get_saved_search');
+select has_function('get_saved_search_matches');
+select has_function('get_user_saved_searches');
+select has_function('refresh_saved_search_matches');
+select has_function('update_saved_search');
+-- SCIM
+select has_function('add_scim_group_members');
+select has_function('delete_scim_group_members');
+select has_function('get_scim_group');
+select has_function('get_scim_groups');
+select has_function('get_scim_user');
+select has_function('get_scim_users');
+select has_function('register_scim_user');
+select has_function('update_scim_user');
+-- Service accounts
+select has_function('add_service_account');
+select has_function('add_service_account_api_key');
+select has_function('delete_service_account');
+select has_function('delete_service_account_api_key');
+select has_function('get_org_service_accounts');
+select has_function('get_service_account_allowed_actions');
+-- Sitemap
+select has_function('get_sitemap_entries');
+select has_function('get_sitemap_sections');
 -- Stats
 select has_function('get_stats');
+select has_function('get_stats_series');
 -- Subscriptions
+select has_function('add_bulk_subscription');
 select has_function('add_opt_out');
+select has_function('add_scoped_subscription');
 select has_function('add_subscription');
+select has_function('delete_bulk_subscription');
 select has_function('delete_opt_out');
+select has_function('delete_scoped_subscription');
 select has_function('delete_subscription');
+select has_function('export_user_subscriptions');
 select has_function('get_package_subscriptors');
 select has_function('get_repository_subscriptors');
 select has_function('get_user_opt_out_entries');
 select has_function('get_user_package_subscriptions');
+select has_function('get_user_scoped_subscriptions');
 select has_function('get_user_subscriptions');
+select has_function('import_user_subscriptions');
+select has_function('search_user_subscriptions');
+select has_function('unsubscribe');
+-- Teams
+select has_function('add_team');
+select has_function('add_team_member');
+select has_function('delete_team');
+select has_function('delete_team_member');
+select has_function('delete_team_repository_permissions');
+select has_function('get_org_teams');
+select has_function('get_user_teams');
+select has_function('update_team');
+select has_function('update_team_repository_permissions');
 -- Users
+select has_function('change_user_email');
 select has_function('check_user_alias_availability');
+select has_function('delete_failed_login_attempts');
+select has_function('delete_scheduled_users');
+select has_function('delete_user');
+select has_function('get_login_throttle');
 select has_function('get_user_profile');
+select has_function('register_delete_user_code');
+select has_function('register_email_change_code');
+select has_function('register_failed_login_attempt');
 select has_function('register_password_reset_code');
 select has_function('register_session');
+select has_function('register_session_device');
 select has_function('register_user');
+select has_function('register_user_password_history');
 select has_function('reset_user_password');
+select has_function('revoke_session');
+select has_function('schedule_user_deletion');
+select has_function('set_user_password_changed_at');
 select has_function('update_user_password');
 select has_function('update_user_profile');
 select has_function('verify_email');
 select has_function('verify_password_reset_code');
 -- Webhooks
 select has_function('add_webhook');
+select has_function('add_webhook_delivery');
 select has_function('delete_webhook');
+select has_function('enable_webhook');
 select has_function('get_webhook');
 select has_function('get_org_webhooks');
 select has_function('get_user_webhooks');
+select has_function('get_webhook_deliveries');
+select has_function('get_webhook_delivery');
+select has_function('get_webhook_failed_deliveries');
+select has_function('get_webhook_failed_delivery');
+select has_function('get_webhook_headers');
 select has_function('get_webhooks_subscribed_to_package');
+select has_function('register_webhook_delivery');
+select has_function('requeue_webhook_failed_delivery');
+select has_function('reserve_webhook_deliveries');
 select has_function('update_webhook');
 select has_function('user_has_access_to_webhook');
 
@@ -466,7 +1037,10 @@ select results_eq(
         (5, 'Krew kubectl plugins'),
         (6, 'Helm plugins'),
         (7, 'Tekton tasks'),
-        (8, 'KEDA scalers')
+        (8, 'KEDA scalers'),
+        (9, 'WASM modules'),
+        (10, 'Crossplane configurations'),
+        (11, 'OCI artifacts')
     $$,
     'Repository kinds should exist'
 );
@@ -479,7 +1053,14 @@ select results_eq(
         (1, 'Security alert'),
         (2, 'Repository tracking errors'),
         (3, 'Repository ownership claim'),
-        (4, 'Repository scanning errors')
+        (4, 'Repository scanning errors'),
+        (5, 'Package deprecated'),
+        (6, 'Webhook suspended'),
+        (7, 'New package'),
+        (8, 'Repository ownership transfer'),
+        (9, 'Saved search new matches'),
+        (10, 'Package new question'),
+        (11, 'Repository verified publisher change')
     $$,
     'Event kinds should exist'
 );
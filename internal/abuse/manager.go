@@ -0,0 +1,158 @@
+package abuse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addAbuseReportDBQ    = `select add_abuse_report($1::uuid, $2::jsonb)`
+	getAbuseReportsDBQ   = `select get_abuse_reports($1::uuid, $2::jsonb)`
+	triageAbuseReportDBQ = `select triage_abuse_report($1::uuid, $2::uuid, $3::jsonb)`
+
+	// DefaultLimit represents the default number of abuse reports returned.
+	DefaultLimit = 20
+
+	// MaxLimit represents the maximum number of abuse reports that can be
+	// requested.
+	MaxLimit = 100
+
+	// MaxDescriptionLength represents the maximum length of the description
+	// of an abuse report.
+	MaxDescriptionLength = 2000
+
+	// MaxNotesLength represents the maximum length of the notes added by a
+	// site administrator when triaging an abuse report.
+	MaxNotesLength = 2000
+)
+
+var (
+	// validReasons represents the reasons that can be used when reporting a
+	// package or a repository.
+	validReasons = map[string]struct{}{
+		"spam":          {},
+		"malware":       {},
+		"impersonation": {},
+		"inappropriate": {},
+		"other":         {},
+	}
+
+	// validStatuses represents the triage states abuse reports can be in.
+	validStatuses = map[string]struct{}{
+		"pending":       {},
+		"investigating": {},
+		"resolved":      {},
+		"dismissed":     {},
+	}
+)
+
+// Manager provides an API to manage abuse reports.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add registers the provided abuse report. Repeated reports submitted by the
+// same user are throttled in the database.
+func (m *Manager) Add(ctx context.Context, r *hub.AbuseReport) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	switch {
+	case r.PackageID != "" && r.RepositoryID != "":
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only one of package id or repository id must be provided")
+	case r.PackageID != "":
+		if _, err := uuid.FromString(r.PackageID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+		}
+	case r.RepositoryID != "":
+		if _, err := uuid.FromString(r.RepositoryID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package id or repository id not provided")
+	}
+	if _, ok := validReasons[r.Reason]; !ok {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid reason")
+	}
+	if utf8.RuneCountInString(r.Description) > MaxDescriptionLength {
+		return fmt.Errorf("%w: %s (max %d)", hub.ErrInvalidInput, "description too long", MaxDescriptionLength)
+	}
+
+	// Register abuse report in database
+	rJSON, _ := json.Marshal(r)
+	_, err := m.db.Exec(ctx, addAbuseReportDBQ, userID, rJSON)
+	if err != nil && err.Error() == util.ErrDBNotFound.Error() {
+		return hub.ErrNotFound
+	}
+	return err
+}
+
+// GetQueueJSON returns the abuse reports moderation queue as a json object.
+// Only site administrators are allowed to get it.
+func (m *Manager) GetQueueJSON(ctx context.Context, input *hub.GetAbuseReportsInput) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if input.Status != "" {
+		if _, ok := validStatuses[input.Status]; !ok {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid status")
+		}
+	}
+	if input.Limit == 0 {
+		input.Limit = DefaultLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxLimit {
+		return nil, fmt.Errorf("%w: invalid limit (0 < l <= %d)", hub.ErrInvalidInput, MaxLimit)
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
+	}
+
+	// Get abuse reports from database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, getAbuseReportsDBQ, userID, inputJSON)
+}
+
+// Triage updates the status of the provided abuse report. Only site
+// administrators are allowed to triage abuse reports.
+func (m *Manager) Triage(ctx context.Context, abuseReportID string, t *hub.AbuseReportTriage) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(abuseReportID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid abuse report id")
+	}
+	if _, ok := validStatuses[t.Status]; !ok {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid status")
+	}
+	if utf8.RuneCountInString(t.Notes) > MaxNotesLength {
+		return fmt.Errorf("%w: %s (max %d)", hub.ErrInvalidInput, "notes too long", MaxNotesLength)
+	}
+
+	// Update abuse report in database
+	tJSON, _ := json.Marshal(t)
+	_, err := m.db.Exec(ctx, triageAbuseReportDBQ, userID, abuseReportID, tJSON)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,297 @@
+package abuse
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	pkgID    = "00000000-0000-0000-0000-000000000001"
+	repoID   = "00000000-0000-0000-0000-000000000002"
+	reportID = "00000000-0000-0000-0000-000000000003"
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	r := &hub.AbuseReport{PackageID: pkgID, Reason: "spam"}
+	rJSON := []byte(`{"package_id":"` + pkgID + `","reason":"spam"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Add(context.Background(), r)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			r      *hub.AbuseReport
+		}{
+			{
+				"only one of package id or repository id must be provided",
+				&hub.AbuseReport{PackageID: pkgID, RepositoryID: repoID, Reason: "spam"},
+			},
+			{
+				"package id or repository id not provided",
+				&hub.AbuseReport{Reason: "spam"},
+			},
+			{
+				"invalid package id",
+				&hub.AbuseReport{PackageID: "pkgID", Reason: "spam"},
+			},
+			{
+				"invalid repository id",
+				&hub.AbuseReport{RepositoryID: "repoID", Reason: "spam"},
+			},
+			{
+				"invalid reason",
+				&hub.AbuseReport{PackageID: pkgID, Reason: "invalid"},
+			},
+			{
+				"description too long",
+				&hub.AbuseReport{
+					PackageID:   pkgID,
+					Reason:      "spam",
+					Description: strings.Repeat("a", MaxDescriptionLength+1),
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Add(ctx, tc.r)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addAbuseReportDBQ, "userID", rJSON).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Add(ctx, r)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("abuse report added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addAbuseReportDBQ, "userID", rJSON).Return(nil)
+		m := NewManager(db)
+
+		err := m.Add(ctx, r)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetQueueJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetQueueJSON(context.Background(), &hub.GetAbuseReportsInput{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetAbuseReportsInput
+		}{
+			{
+				"invalid status",
+				&hub.GetAbuseReportsInput{Status: "invalid"},
+			},
+			{
+				"invalid limit",
+				&hub.GetAbuseReportsInput{Limit: MaxLimit + 1},
+			},
+			{
+				"invalid offset",
+				&hub.GetAbuseReportsInput{Offset: -1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetQueueJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getAbuseReportsDBQ, "userID", []byte(`{"limit":20,"offset":0}`)).
+					Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.GetQueueJSON(ctx, &hub.GetAbuseReportsInput{})
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getAbuseReportsDBQ, "userID", []byte(`{"status":"pending","limit":10,"offset":0}`)).
+			Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetQueueJSON(ctx, &hub.GetAbuseReportsInput{Status: "pending", Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestTriage(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	tr := &hub.AbuseReportTriage{Status: "resolved", Notes: "Package removed"}
+	trJSON := []byte(`{"status":"resolved","notes":"Package removed"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Triage(context.Background(), reportID, tr)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			reportID string
+			tr       *hub.AbuseReportTriage
+		}{
+			{
+				"invalid abuse report id",
+				"reportID",
+				tr,
+			},
+			{
+				"invalid status",
+				reportID,
+				&hub.AbuseReportTriage{Status: "invalid"},
+			},
+			{
+				"notes too long",
+				reportID,
+				&hub.AbuseReportTriage{Status: "resolved", Notes: strings.Repeat("a", MaxNotesLength+1)},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Triage(ctx, tc.reportID, tc.tr)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, triageAbuseReportDBQ, "userID", reportID, trJSON).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Triage(ctx, reportID, tr)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("abuse report triaged successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, triageAbuseReportDBQ, "userID", reportID, trJSON).Return(nil)
+		m := NewManager(db)
+
+		err := m.Triage(ctx, reportID, tr)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,32 @@
+package abuse
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the AbuseReportManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the AbuseReportManager interface.
+func (m *ManagerMock) Add(ctx context.Context, r *hub.AbuseReport) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+// GetQueueJSON implements the AbuseReportManager interface.
+func (m *ManagerMock) GetQueueJSON(ctx context.Context, input *hub.GetAbuseReportsInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Triage implements the AbuseReportManager interface.
+func (m *ManagerMock) Triage(ctx context.Context, abuseReportID string, t *hub.AbuseReportTriage) error {
+	args := m.Called(ctx, abuseReportID, t)
+	return args.Error(0)
+}
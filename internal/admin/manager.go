@@ -0,0 +1,194 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	forcePasswordResetDBQ = `select force_user_password_reset($1::uuid, $2::uuid)`
+	getUserResourcesDBQ   = `select get_user_resources($1::uuid, $2::uuid)`
+	getVPChangesDBQ       = `select get_verified_publisher_changes($1::uuid, $2::text)`
+	revokeCredentialsDBQ  = `select revoke_user_credentials($1::uuid, $2::uuid)`
+	searchUsersDBQ        = `select search_users($1::uuid, $2::jsonb)`
+	updateUserDisabledDBQ = `select update_user_disabled($1::uuid, $2::uuid, $3::boolean)`
+	updateVPDBQ           = `select update_verified_publisher($1::uuid, $2::text, $3::boolean, $4::text)`
+
+	// maxLimit represents the maximum number of users that can be requested
+	// at once.
+	maxLimit = 100
+
+	// maxEvidenceLength represents the maximum length of the evidence
+	// provided when setting the verified publisher status of a repository.
+	maxEvidenceLength = 2000
+)
+
+// Manager provides an API to perform site administration operations. Only
+// users flagged as site administrators are allowed to use it.
+type Manager struct {
+	db hub.DB
+	um hub.UserManager
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, um hub.UserManager) *Manager {
+	return &Manager{
+		db: db,
+		um: um,
+	}
+}
+
+// ForcePasswordReset invalidates the password and sessions of the provided
+// user. A password reset code will be emailed to the user so that a new
+// password can be set.
+func (m *Manager) ForcePasswordReset(ctx context.Context, userID, baseURL string) error {
+	adminUserID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+
+	// Invalidate user password and sessions in database
+	var userEmail string
+	err := m.db.QueryRow(ctx, forcePasswordResetDBQ, adminUserID, userID).Scan(&userEmail)
+	if err != nil {
+		return translateDBError(err)
+	}
+
+	// Send password reset code to the user
+	return m.um.RegisterPasswordResetCode(ctx, userEmail, baseURL)
+}
+
+// GetUserResourcesJSON returns the resources owned by the provided user as a
+// json object.
+func (m *Manager) GetUserResourcesJSON(ctx context.Context, userID string) ([]byte, error) {
+	adminUserID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+
+	// Get user resources from database
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getUserResourcesDBQ, adminUserID, userID)
+	if err != nil {
+		return nil, translateDBError(err)
+	}
+	return dataJSON, nil
+}
+
+// GetVerifiedPublisherChangesJSON returns the verified publisher status of the
+// provided repository as well as the changes registered as a json object.
+func (m *Manager) GetVerifiedPublisherChangesJSON(ctx context.Context, repoName string) ([]byte, error) {
+	adminUserID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if repoName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Get verified publisher changes from database
+	return util.DBQueryJSON(ctx, m.db, getVPChangesDBQ, adminUserID, repoName)
+}
+
+// RevokeCredentials deletes all the sessions and API keys of the provided
+// user.
+func (m *Manager) RevokeCredentials(ctx context.Context, userID string) error {
+	adminUserID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+
+	// Revoke user credentials in database
+	_, err := m.db.Exec(ctx, revokeCredentialsDBQ, adminUserID, userID)
+	return translateDBError(err)
+}
+
+// SearchUsersJSON returns the users matching the criteria provided as a json
+// object.
+func (m *Manager) SearchUsersJSON(ctx context.Context, input *hub.SearchUsersInput) ([]byte, error) {
+	adminUserID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if input.Limit <= 0 || input.Limit > maxLimit {
+		return nil, fmt.Errorf("%w: invalid limit (0 < l <= %d)", hub.ErrInvalidInput, maxLimit)
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
+	}
+
+	// Search users in database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, searchUsersDBQ, adminUserID, inputJSON)
+}
+
+// UpdateUserDisabled disables or enables the provided user. Disabled users
+// cannot log in and their sessions and API keys stop working.
+func (m *Manager) UpdateUserDisabled(ctx context.Context, userID string, disabled bool) error {
+	adminUserID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+	if disabled && userID == adminUserID {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "site administrators cannot disable their own account")
+	}
+
+	// Update user in database
+	_, err := m.db.Exec(ctx, updateUserDisabledDBQ, adminUserID, userID, disabled)
+	return translateDBError(err)
+}
+
+// UpdateVerifiedPublisher sets the verified publisher status of the provided
+// repository, recording the evidence supporting the decision. Once set by a
+// site administrator, the status is not updated from the repository metadata
+// file anymore.
+func (m *Manager) UpdateVerifiedPublisher(
+	ctx context.Context,
+	repoName string,
+	input *hub.VerifiedPublisherInput,
+) error {
+	adminUserID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+	input.Evidence = strings.TrimSpace(input.Evidence)
+	if input.Evidence == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "evidence not provided")
+	}
+	if len(input.Evidence) > maxEvidenceLength {
+		return fmt.Errorf("%w: evidence too long (max %d)", hub.ErrInvalidInput, maxEvidenceLength)
+	}
+
+	// Update repository verified publisher status in database
+	_, err := m.db.Exec(ctx, updateVPDBQ, adminUserID, repoName, input.Verified, input.Evidence)
+	return translateDBError(err)
+}
+
+// translateDBError translates the errors returned by the database into the
+// errors returned by the manager.
+func translateDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err.Error() {
+	case util.ErrDBInsufficientPrivilege.Error():
+		return hub.ErrInsufficientPrivilege
+	case util.ErrDBNotFound.Error():
+		return hub.ErrNotFound
+	}
+	return err
+}
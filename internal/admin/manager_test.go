@@ -0,0 +1,560 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/user"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	adminUserID = "00000000-0000-0000-0000-000000000001"
+	userID      = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestForcePasswordReset(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, adminUserID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.ForcePasswordReset(context.Background(), userID, "baseURL")
+		})
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		err := m.ForcePasswordReset(ctx, "invalid", "baseURL")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid user id")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, forcePasswordResetDBQ, adminUserID, userID).Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.ForcePasswordReset(ctx, userID, "baseURL")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("password reset code registration", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			umErr       error
+		}{
+			{
+				"password reset code sent successfully",
+				nil,
+			},
+			{
+				"error sending password reset code",
+				tests.ErrFake,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, forcePasswordResetDBQ, adminUserID, userID).Return("user@email.com", nil)
+				um := &user.ManagerMock{}
+				um.On("RegisterPasswordResetCode", ctx, "user@email.com", "baseURL").Return(tc.umErr)
+				m := NewManager(db, um)
+
+				err := m.ForcePasswordReset(ctx, userID, "baseURL")
+				assert.Equal(t, tc.umErr, err)
+				db.AssertExpectations(t)
+				um.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetUserResourcesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, adminUserID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetUserResourcesJSON(context.Background(), userID)
+		})
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.GetUserResourcesJSON(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid user id")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getUserResourcesDBQ, adminUserID, userID).Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				dataJSON, err := m.GetUserResourcesJSON(ctx, userID)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user resources data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserResourcesDBQ, adminUserID, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetUserResourcesJSON(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetVerifiedPublisherChangesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, adminUserID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetVerifiedPublisherChangesJSON(context.Background(), "repo1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.GetVerifiedPublisherChangesJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "repository name not provided")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getVPChangesDBQ, adminUserID, "repo1").Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				dataJSON, err := m.GetVerifiedPublisherChangesJSON(ctx, "repo1")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("verified publisher changes returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getVPChangesDBQ, adminUserID, "repo1").Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetVerifiedPublisherChangesJSON(ctx, "repo1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRevokeCredentials(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, adminUserID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RevokeCredentials(context.Background(), userID)
+		})
+	})
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		err := m.RevokeCredentials(ctx, "invalid")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid user id")
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, revokeCredentialsDBQ, adminUserID, userID).Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.RevokeCredentials(ctx, userID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, revokeCredentialsDBQ, adminUserID, userID).Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.RevokeCredentials(ctx, userID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestSearchUsersJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, adminUserID)
+	input := &hub.SearchUsersInput{Query: "user", Limit: 10, Offset: 0}
+	inputJSON := []byte(`{"query":"user","limit":10,"offset":0}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.SearchUsersJSON(context.Background(), input)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.SearchUsersInput
+		}{
+			{
+				"invalid limit",
+				&hub.SearchUsersInput{Limit: 0},
+			},
+			{
+				"invalid limit",
+				&hub.SearchUsersInput{Limit: 101},
+			},
+			{
+				"invalid offset",
+				&hub.SearchUsersInput{Limit: 10, Offset: -1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				_, err := m.SearchUsersJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, searchUsersDBQ, adminUserID, inputJSON).Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				dataJSON, err := m.SearchUsersJSON(ctx, input)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("users data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchUsersDBQ, adminUserID, inputJSON).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.SearchUsersJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUserDisabled(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, adminUserID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateUserDisabled(context.Background(), userID, true)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			userID string
+		}{
+			{
+				"invalid user id",
+				"invalid",
+			},
+			{
+				"site administrators cannot disable their own account",
+				adminUserID,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.UpdateUserDisabled(ctx, tc.userID, true)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserDisabledDBQ, adminUserID, userID, true).Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.UpdateUserDisabled(ctx, userID, true)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateUserDisabledDBQ, adminUserID, userID, false).Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.UpdateUserDisabled(ctx, userID, false)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateVerifiedPublisher(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, adminUserID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateVerifiedPublisher(context.Background(), "repo1", &hub.VerifiedPublisherInput{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			repoName string
+			input    *hub.VerifiedPublisherInput
+		}{
+			{
+				"repository name not provided",
+				"",
+				&hub.VerifiedPublisherInput{Verified: true, Evidence: "evidence"},
+			},
+			{
+				"evidence not provided",
+				"repo1",
+				&hub.VerifiedPublisherInput{Verified: true, Evidence: "  "},
+			},
+			{
+				"evidence too long",
+				"repo1",
+				&hub.VerifiedPublisherInput{Verified: true, Evidence: strings.Repeat("a", maxEvidenceLength+1)},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.UpdateVerifiedPublisher(ctx, tc.repoName, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateVPDBQ, adminUserID, "repo1", true, "evidence").Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.UpdateVerifiedPublisher(ctx, "repo1", &hub.VerifiedPublisherInput{
+			Verified: true,
+			Evidence: " evidence ",
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateVPDBQ, adminUserID, "repo1", false, "evidence").Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.UpdateVerifiedPublisher(ctx, "repo1", &hub.VerifiedPublisherInput{
+					Verified: false,
+					Evidence: "evidence",
+				})
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
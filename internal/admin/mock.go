@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the AdminManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// ForcePasswordReset implements the AdminManager interface.
+func (m *ManagerMock) ForcePasswordReset(ctx context.Context, userID, baseURL string) error {
+	args := m.Called(ctx, userID, baseURL)
+	return args.Error(0)
+}
+
+// GetUserResourcesJSON implements the AdminManager interface.
+func (m *ManagerMock) GetUserResourcesJSON(ctx context.Context, userID string) ([]byte, error) {
+	args := m.Called(ctx, userID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetVerifiedPublisherChangesJSON implements the AdminManager interface.
+func (m *ManagerMock) GetVerifiedPublisherChangesJSON(ctx context.Context, repoName string) ([]byte, error) {
+	args := m.Called(ctx, repoName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// RevokeCredentials implements the AdminManager interface.
+func (m *ManagerMock) RevokeCredentials(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// SearchUsersJSON implements the AdminManager interface.
+func (m *ManagerMock) SearchUsersJSON(ctx context.Context, input *hub.SearchUsersInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// UpdateUserDisabled implements the AdminManager interface.
+func (m *ManagerMock) UpdateUserDisabled(ctx context.Context, userID string, disabled bool) error {
+	args := m.Called(ctx, userID, disabled)
+	return args.Error(0)
+}
+
+// UpdateVerifiedPublisher implements the AdminManager interface.
+func (m *ManagerMock) UpdateVerifiedPublisher(
+	ctx context.Context,
+	repoName string,
+	input *hub.VerifiedPublisherInput,
+) error {
+	args := m.Called(ctx, repoName, input)
+	return args.Error(0)
+}
@@ -39,6 +39,11 @@ func (m *Manager) Add(ctx context.Context, ak *hub.APIKey) ([]byte, error) {
 	if ak.Name == "" {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
+	for _, scope := range ak.Scopes {
+		if !isValidScope(scope) {
+			return nil, fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid scope", scope)
+		}
+	}
 
 	// Add api key to the database
 	akJSON, _ := json.Marshal(ak)
@@ -98,3 +103,13 @@ func (m *Manager) Update(ctx context.Context, ak *hub.APIKey) error {
 	_, err := m.db.Exec(ctx, updateAPIKeyDBQ, akJSON)
 	return err
 }
+
+// isValidScope checks if the scope provided is valid.
+func isValidScope(scope hub.APIKeyScope) bool {
+	for _, validScope := range hub.APIKeyScopes {
+		if scope == validScope {
+			return true
+		}
+	}
+	return false
+}
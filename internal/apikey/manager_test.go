@@ -39,6 +39,13 @@ func TestAdd(t *testing.T) {
 					Name: "",
 				},
 			},
+			{
+				"invalid scope",
+				&hub.APIKey{
+					Name:   "apikey1",
+					Scopes: []hub.APIKeyScope{hub.APIKeyScopeReadOnly, "invalid"},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -76,6 +83,7 @@ func TestAdd(t *testing.T) {
 		ak := &hub.APIKey{
 			Name:   "apikey1",
 			UserID: "userID",
+			Scopes: []hub.APIKeyScope{hub.APIKeyScopeReposWrite, hub.APIKeyScopeWebhooksManage},
 		}
 		akJSON, _ := json.Marshal(ak)
 		db := &tests.DBMock{}
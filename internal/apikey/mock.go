@@ -44,3 +44,14 @@ func (m *ManagerMock) Update(ctx context.Context, ak *hub.APIKey) error {
 	args := m.Called(ctx, ak)
 	return args.Error(0)
 }
+
+// UsageTrackerMock is a mock implementation of the APIKeyUsageTracker
+// interface.
+type UsageTrackerMock struct {
+	mock.Mock
+}
+
+// Track implements the APIKeyUsageTracker interface.
+func (m *UsageTrackerMock) Track(apiKeyID, ip string) {
+	m.Called(apiKeyID, ip)
+}
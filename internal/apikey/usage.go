@@ -0,0 +1,104 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Database queries
+	registerAPIKeysUsageDBQ = `select register_api_keys_usage($1::jsonb)`
+
+	// usageFlushInterval represents how often the usage collected by the
+	// tracker is registered in the database.
+	usageFlushInterval = 1 * time.Minute
+)
+
+// usage represents the usage of an api key collected since the last flush.
+type usage struct {
+	APIKeyID   string `json:"api_key_id"`
+	LastUsedAt int64  `json:"last_used_at"`
+	LastUsedIP string `json:"last_used_ip"`
+	Requests   int64  `json:"requests"`
+}
+
+// UsageTracker keeps track of the usage of the api keys. The usage is
+// collected in memory and registered in the database periodically, so that
+// requests authenticated using api keys don't have to wait for any write.
+type UsageTracker struct {
+	db  hub.DB
+	now func() time.Time
+
+	mu    sync.Mutex
+	usage map[string]*usage
+}
+
+// NewUsageTracker creates a new UsageTracker instance.
+func NewUsageTracker(db hub.DB) *UsageTracker {
+	return &UsageTracker{
+		db:    db,
+		now:   time.Now,
+		usage: make(map[string]*usage),
+	}
+}
+
+// Track records a request authenticated using the api key provided.
+func (t *UsageTracker) Track(apiKeyID, ip string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, ok := t.usage[apiKeyID]
+	if !ok {
+		u = &usage{APIKeyID: apiKeyID}
+		t.usage[apiKeyID] = u
+	}
+	u.LastUsedAt = t.now().Unix()
+	u.LastUsedIP = ip
+	u.Requests++
+}
+
+// Run registers periodically the usage collected until it's asked to stop via
+// the context provided. Any pending usage is registered before returning.
+func (t *UsageTracker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(usageFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := t.Flush(context.Background()); err != nil {
+				log.Error().Err(err).Msg("error registering api keys usage")
+			}
+			return
+		case <-ticker.C:
+			if err := t.Flush(ctx); err != nil {
+				log.Error().Err(err).Msg("error registering api keys usage")
+			}
+		}
+	}
+}
+
+// Flush registers in the database the usage collected since the last flush.
+func (t *UsageTracker) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	pending := t.usage
+	t.usage = make(map[string]*usage)
+	t.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	usageList := make([]*usage, 0, len(pending))
+	for _, u := range pending {
+		usageList = append(usageList, u)
+	}
+	usageJSON, _ := json.Marshal(usageList)
+	_, err := t.db.Exec(ctx, registerAPIKeysUsageDBQ, usageJSON)
+	return err
+}
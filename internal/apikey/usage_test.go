@@ -0,0 +1,59 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageTrackerFlush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nothing to flush", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		ut := NewUsageTracker(db)
+
+		err := ut.Flush(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerAPIKeysUsageDBQ, []byte(`[{"api_key_id":"keyID","last_used_at":1,"last_used_ip":"1.1.1.1","requests":1}]`)).
+			Return(tests.ErrFakeDB)
+		ut := NewUsageTracker(db)
+		ut.now = func() time.Time { return time.Unix(1, 0) }
+		ut.Track("keyID", "1.1.1.1")
+
+		err := ut.Flush(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("usage registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerAPIKeysUsageDBQ, []byte(`[{"api_key_id":"keyID","last_used_at":2,"last_used_ip":"2.2.2.2","requests":2}]`)).
+			Return(nil)
+		ut := NewUsageTracker(db)
+		ut.now = func() time.Time { return time.Unix(1, 0) }
+		ut.Track("keyID", "1.1.1.1")
+		ut.now = func() time.Time { return time.Unix(2, 0) }
+		ut.Track("keyID", "2.2.2.2")
+
+		err := ut.Flush(ctx)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+
+		// Usage is reset once it has been registered
+		err = ut.Flush(ctx)
+		assert.NoError(t, err)
+		db.AssertNumberOfCalls(t, "Exec", 1)
+	})
+}
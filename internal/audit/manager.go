@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	getOrgAuditLogDBQ  = `select get_organization_audit_log($1::uuid, $2::text, $3::jsonb)`
+	getUserAuditLogDBQ = `select get_user_audit_log($1::uuid, $2::int, $3::int)`
+	registerEntryDBQ   = `select register_audit_log_entry($1::jsonb)`
+
+	// maxLimit represents the maximum number of audit log entries that can be
+	// requested at once.
+	maxLimit = 100
+)
+
+// Manager provides an API to manage the audit log.
+type Manager struct {
+	db hub.DB
+	az hub.Authorizer
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, az hub.Authorizer) *Manager {
+	return &Manager{
+		db: db,
+		az: az,
+	}
+}
+
+// GetByOrgJSON returns the audit log entries of the actions performed on the
+// provided organization as a json object.
+func (m *Manager) GetByOrgJSON(ctx context.Context, orgName string, input *hub.GetAuditLogInput) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if err := validateGetInput(input); err != nil {
+		return nil, err
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.GetAuditLog,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Get audit log entries from database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, getOrgAuditLogDBQ, userID, orgName, inputJSON)
+}
+
+// GetByUserJSON returns the audit log entries of the actions performed by the
+// user doing the request as a json object.
+func (m *Manager) GetByUserJSON(ctx context.Context, input *hub.GetAuditLogInput) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateGetInput(input); err != nil {
+		return nil, err
+	}
+
+	// Get audit log entries from database
+	return util.DBQueryJSON(ctx, m.db, getUserAuditLogDBQ, userID, input.Limit, input.Offset)
+}
+
+// Register registers the provided entry in the audit log.
+func (m *Manager) Register(ctx context.Context, e *hub.AuditLogEntry) error {
+	// Validate input
+	if e.Action == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "action not provided")
+	}
+	if e.UserID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user id not provided")
+	}
+
+	// Register entry in database
+	eJSON, _ := json.Marshal(e)
+	_, err := m.db.Exec(ctx, registerEntryDBQ, eJSON)
+	return err
+}
+
+// validateGetInput checks the input provided to get some audit log entries is
+// valid.
+func validateGetInput(input *hub.GetAuditLogInput) error {
+	if input.Limit <= 0 || input.Limit > maxLimit {
+		return fmt.Errorf("%w: invalid limit (0 < l <= %d)", hub.ErrInvalidInput, maxLimit)
+	}
+	if input.Offset < 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
+	}
+	for _, action := range input.Actions {
+		if action == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid action")
+		}
+	}
+	if input.From < 0 || input.To < 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid date range")
+	}
+	if input.From > 0 && input.To > 0 && input.From > input.To {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid date range (from <= to)")
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	input := &hub.GetAuditLogInput{
+		Limit:     10,
+		Offset:    0,
+		Actions:   []string{hub.AuditOrganizationMemberAdd},
+		UserAlias: "user1",
+	}
+	inputJSON, _ := json.Marshal(input)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByOrgJSON(context.Background(), "org1", input)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			input   *hub.GetAuditLogInput
+		}{
+			{
+				"organization name not provided",
+				"",
+				input,
+			},
+			{
+				"invalid limit",
+				"org1",
+				&hub.GetAuditLogInput{Limit: 0},
+			},
+			{
+				"invalid limit",
+				"org1",
+				&hub.GetAuditLogInput{Limit: 101},
+			},
+			{
+				"invalid offset",
+				"org1",
+				&hub.GetAuditLogInput{Limit: 10, Offset: -1},
+			},
+			{
+				"invalid action",
+				"org1",
+				&hub.GetAuditLogInput{Limit: 10, Actions: []string{""}},
+			},
+			{
+				"invalid date range",
+				"org1",
+				&hub.GetAuditLogInput{Limit: 10, From: -1},
+			},
+			{
+				"invalid date range",
+				"org1",
+				&hub.GetAuditLogInput{Limit: 10, From: 2, To: 1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				_, err := m.GetByOrgJSON(ctx, tc.orgName, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuditLog,
+		}).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		dataJSON, err := m.GetByOrgJSON(ctx, "org1", input)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, dataJSON)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgAuditLogDBQ, "userID", "org1", inputJSON).Return([]byte("dataJSON"), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.GetAuditLog,
+		}).Return(nil)
+		m := NewManager(db, az)
+
+		dataJSON, err := m.GetByOrgJSON(ctx, "org1", input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgAuditLogDBQ, "userID", "org1", inputJSON).Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.GetAuditLog,
+				}).Return(nil)
+				m := NewManager(db, az)
+
+				dataJSON, err := m.GetByOrgJSON(ctx, "org1", input)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetByUserJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	input := &hub.GetAuditLogInput{Limit: 10, Offset: 5}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByUserJSON(context.Background(), input)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.GetByUserJSON(ctx, &hub.GetAuditLogInput{Limit: 10, Offset: -1})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAuditLogDBQ, "userID", 10, 5).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetByUserJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserAuditLogDBQ, "userID", 10, 5).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetByUserJSON(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			e      *hub.AuditLogEntry
+		}{
+			{
+				"action not provided",
+				&hub.AuditLogEntry{UserID: "userID"},
+			},
+			{
+				"user id not provided",
+				&hub.AuditLogEntry{Action: hub.AuditUserLogin},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.Register(ctx, tc.e)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	e := &hub.AuditLogEntry{
+		Action: hub.AuditUserLogin,
+		UserID: "userID",
+		IP:     "192.168.1.1",
+	}
+	eJSON := []byte(`{"action":"user.login","user_id":"userID","organization_name":"","ip":"192.168.1.1"}`)
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerEntryDBQ, eJSON).Return(tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		err := m.Register(ctx, e)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("entry registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerEntryDBQ, eJSON).Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.Register(ctx, e)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the AuditLogManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// GetByOrgJSON implements the AuditLogManager interface.
+func (m *ManagerMock) GetByOrgJSON(ctx context.Context, orgName string, input *hub.GetAuditLogInput) ([]byte, error) {
+	args := m.Called(ctx, orgName, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetByUserJSON implements the AuditLogManager interface.
+func (m *ManagerMock) GetByUserJSON(ctx context.Context, input *hub.GetAuditLogInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Register implements the AuditLogManager interface.
+func (m *ManagerMock) Register(ctx context.Context, e *hub.AuditLogEntry) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
@@ -25,8 +25,10 @@ const (
 	AllowedActionsQuery = "data.artifacthub.authz.allowed_actions"
 
 	// Database queries
-	getAuthzPoliciesDBQ = `select get_authorization_policies()`
-	getUserAliasDBQ     = `select alias from "user" where user_id = $1`
+	getAuthzPoliciesDBQ      = `select get_authorization_policies()`
+	getUserAliasDBQ          = `select alias from "user" where user_id = $1`
+	getUserAllowedActionsDBQ = `select get_user_allowed_actions($1::uuid, $2::text)`
+	getUserTeamsDBQ          = `select get_user_teams($1::uuid, $2::text)`
 
 	pauseOnError = 10 * time.Second
 )
@@ -42,8 +44,21 @@ var (
 		hub.GetAuthorizationPolicy,
 		hub.UpdateAuthorizationPolicy,
 	}
+	repositoryActions = []hub.Action{
+		hub.AddOrganizationRepository,
+		hub.DeleteOrganizationRepository,
+		hub.TransferOrganizationRepository,
+		hub.UpdateOrganizationRepository,
+	}
 )
 
+// userTeam represents a team a user belongs to, including the actions the team
+// members are allowed to perform on each of the team repositories.
+type userTeam struct {
+	Name         string                  `json:"name"`
+	Repositories map[string][]hub.Action `json:"repositories"`
+}
+
 // Authorizer is in charge of authorizing actions that users intend to perform.
 type Authorizer struct {
 	db     hub.DB
@@ -151,7 +166,20 @@ func (a *Authorizer) listenForPoliciesUpdates() {
 // for all the actions the user is allowed to perform and checks if the action
 // provided in the input is in that list.
 func (a *Authorizer) Authorize(ctx context.Context, input *hub.AuthorizeInput) error {
-	allowedActions, err := a.GetAllowedActions(ctx, input.UserID, input.OrganizationName)
+	// Requests authenticated using API keys restricted to some scopes can only
+	// perform actions on repositories, provided they have been granted the
+	// repos:write scope
+	if scopes, ok := ctx.Value(hub.APIKeyScopesKey).([]hub.APIKeyScope); ok && len(scopes) > 0 {
+		if !IsRepositoryAction(input.Action) || !hasScope(scopes, hub.APIKeyScopeReposWrite) {
+			return hub.ErrInsufficientPrivilege
+		}
+	}
+
+	var repoName string
+	if IsRepositoryAction(input.Action) {
+		repoName = input.RepositoryName
+	}
+	allowedActions, err := a.getAllowedActions(ctx, input.UserID, input.OrganizationName, repoName)
 	if err != nil {
 		return fmt.Errorf("%w: error getting allowed actions: %s", hub.ErrInsufficientPrivilege, err.Error())
 	}
@@ -163,29 +191,59 @@ func (a *Authorizer) Authorize(ctx context.Context, input *hub.AuthorizeInput) e
 
 // GetAllowedActions returns the actions a given user is allowed to perform in
 // the provided organization. We'll obtain them querying the organization
-// authorization policy.
+// authorization policy when it's enabled, or from the role the user has in the
+// organization otherwise.
 func (a *Authorizer) GetAllowedActions(ctx context.Context, userID, orgName string) ([]hub.Action, error) {
+	return a.getAllowedActions(ctx, userID, orgName, "")
+}
+
+// getAllowedActions returns the actions a given user is allowed to perform in
+// the provided organization. When a repository name is provided, the actions
+// granted on it to the teams the user belongs to are taken into account too.
+func (a *Authorizer) getAllowedActions(ctx context.Context, userID, orgName, repoName string) ([]hub.Action, error) {
 	// Get authorization policy allowed actions query
 	a.mu.RLock()
 	query, ok := a.allowedActionsQueries[orgName]
 	if !ok {
-		// If the organization hasn't defined an authorization policy yet, the
-		// user is allowed to perform all actions available in the organizations
-		// he belongs to.
+		// If the organization hasn't enabled an authorization policy, the
+		// user is allowed to perform the actions granted to the role they
+		// have in the organization, as well as the ones granted to their
+		// teams on the repository provided.
 		a.mu.RUnlock()
-		return []hub.Action{"all"}, nil
+		allowedActions, err := a.getRoleAllowedActions(ctx, userID, orgName)
+		if err != nil {
+			return nil, err
+		}
+		if repoName != "" {
+			teams, err := a.getUserTeams(ctx, userID, orgName)
+			if err != nil {
+				return nil, err
+			}
+			for _, team := range teams {
+				allowedActions = append(allowedActions, team.Repositories[repoName]...)
+			}
+		}
+		return allowedActions, nil
 	}
 	a.mu.RUnlock()
 
-	// Get user alias to provide it to the query as input
+	// Get user alias and teams to provide them to the query as input
 	userAlias, err := a.getUserAlias(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	teams, err := a.getUserTeams(ctx, userID, orgName)
+	if err != nil {
+		return nil, err
+	}
 
 	// Evaluate authorization policy allowed actions query
 	queryInput := map[string]interface{}{
-		"user": userAlias,
+		"user":  userAlias,
+		"teams": teams,
+	}
+	if repoName != "" {
+		queryInput["repository"] = repoName
 	}
 	results, err := query.Eval(ctx, rego.EvalInput(queryInput))
 	if err != nil {
@@ -273,6 +331,35 @@ func (a *Authorizer) WillUserBeLockedOut(
 	return false, nil
 }
 
+// getRoleAllowedActions is a helper function that returns the actions the user
+// provided is allowed to perform in the organization given based on their role.
+func (a *Authorizer) getRoleAllowedActions(ctx context.Context, userID, orgName string) ([]hub.Action, error) {
+	var actions []string
+	err := a.db.QueryRow(ctx, getUserAllowedActionsDBQ, userID, orgName).Scan(&actions)
+	if err != nil {
+		return nil, err
+	}
+	allowedActions := make([]hub.Action, 0, len(actions))
+	for _, action := range actions {
+		allowedActions = append(allowedActions, hub.Action(action))
+	}
+	return allowedActions, nil
+}
+
+// getUserTeams is a helper function that returns the teams the user provided
+// belongs to in the organization given.
+func (a *Authorizer) getUserTeams(ctx context.Context, userID, orgName string) ([]*userTeam, error) {
+	var teamsJSON []byte
+	if err := a.db.QueryRow(ctx, getUserTeamsDBQ, userID, orgName).Scan(&teamsJSON); err != nil {
+		return nil, err
+	}
+	teams := make([]*userTeam, 0)
+	if err := json.Unmarshal(teamsJSON, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
 // getUserAlias is a helper function that returns the alias of a user
 // identified by the ID provided.
 func (a *Authorizer) getUserAlias(ctx context.Context, userID string) (string, error) {
@@ -314,3 +401,23 @@ func AreActionsAllowed(allowedActions, actions []hub.Action) bool {
 	}
 	return true
 }
+
+// IsRepositoryAction checks if the action provided is a repository action.
+func IsRepositoryAction(action hub.Action) bool {
+	for _, a := range repositoryActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// hasScope checks if the scope provided is in the list of scopes given.
+func hasScope(scopes []hub.APIKeyScope, scope hub.APIKeyScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
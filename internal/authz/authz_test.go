@@ -12,6 +12,7 @@ import (
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,6 +29,7 @@ const (
 	org1Name   = "org1"
 	org2Name   = "org2"
 	org3Name   = "org3"
+	org4Name   = "org4"
 )
 
 var testsAuthorizationPoliciesJSON = []byte(`{
@@ -70,6 +72,11 @@ var testsAuthorizationPoliciesJSON = []byte(`{
 		"authorization_enabled": false,
 		"custom_policy": "package artifacthub.authz\ndefault allowed_actions = []\n",
 		"policy_data": {}
+	},
+	"org4": {
+		"authorization_enabled": true,
+		"custom_policy": "package artifacthub.authz\nallowed_actions[action] {\n  input.teams[_].name == \"team1\"\n  action := \"updateOrganization\"\n}\nallowed_actions[action] {\n  action := input.teams[_].repositories[input.repository][_]\n}\n",
+		"policy_data": {}
 	}
 }`)
 
@@ -118,6 +125,17 @@ func TestAuthorize(t *testing.T) {
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user2ID).Return(user2Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user3ID).Return(user3Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user5ID).Return("", tests.ErrFakeDB).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user1ID, org3Name).
+		Return([]string{"all"}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user2ID, org3Name).
+		Return([]string{"addOrganizationMember", "deleteOrganizationMember"}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user3ID, org3Name).
+		Return([]string{"addOrganizationMember"}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user4ID, org3Name).
+		Return([]string{}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user5ID, org3Name).
+		Return(nil, tests.ErrFakeDB).Maybe()
+	db.On("QueryRow", mock.Anything, getUserTeamsDBQ, mock.Anything, mock.Anything).Return([]byte("[]"), nil).Maybe()
 	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
 	az, err := NewAuthorizer(db)
 	require.NoError(t, err)
@@ -229,6 +247,29 @@ func TestAuthorize(t *testing.T) {
 				Action:           hub.AddOrganizationMember,
 			},
 			true,
+		}, {
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user3ID,
+				Action:           hub.DeleteOrganization,
+			},
+			false,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user4ID,
+				Action:           hub.AddOrganizationMember,
+			},
+			false,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user5ID,
+				Action:           hub.AddOrganizationMember,
+			},
+			false,
 		},
 	}
 	for i, tc := range testCases {
@@ -247,6 +288,148 @@ func TestAuthorize(t *testing.T) {
 	db.AssertExpectations(t)
 }
 
+func TestAuthorizeWithAPIKeyScopes(t *testing.T) {
+	db := &tests.DBMock{}
+	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
+	db.On("QueryRow", mock.Anything, getUserAliasDBQ, user1ID).Return(user1Alias, nil).Maybe()
+	db.On("QueryRow", mock.Anything, getUserTeamsDBQ, mock.Anything, mock.Anything).Return([]byte("[]"), nil).Maybe()
+	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
+	az, err := NewAuthorizer(db)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		scopes []hub.APIKeyScope
+		action hub.Action
+		allow  bool
+	}{
+		{
+			[]hub.APIKeyScope{hub.APIKeyScopeReposWrite},
+			hub.TransferOrganizationRepository,
+			true,
+		},
+		{
+			[]hub.APIKeyScope{hub.APIKeyScopeReadOnly, hub.APIKeyScopeWebhooksManage},
+			hub.TransferOrganizationRepository,
+			false,
+		},
+		{
+			[]hub.APIKeyScope{hub.APIKeyScopeReposWrite},
+			hub.AddOrganizationMember,
+			false,
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			ctx := context.WithValue(context.Background(), hub.APIKeyScopesKey, tc.scopes)
+			err := az.Authorize(ctx, &hub.AuthorizeInput{
+				OrganizationName: org1Name,
+				UserID:           user1ID,
+				Action:           tc.action,
+			})
+			if tc.allow {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, hub.ErrInsufficientPrivilege))
+			}
+		})
+	}
+}
+
+func TestAuthorizeWithTeams(t *testing.T) {
+	teamsJSON := []byte(`[{"name": "team1", "repositories": {"repo1": ["updateOrganizationRepository"]}}]`)
+	db := &tests.DBMock{}
+	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
+	db.On("QueryRow", context.Background(), getUserAliasDBQ, user4ID).Return(user4Alias, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user4ID, org3Name).
+		Return([]string{}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserTeamsDBQ, user4ID, org3Name).Return(teamsJSON, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserTeamsDBQ, user4ID, org4Name).Return(teamsJSON, nil).Maybe()
+	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
+	az, err := NewAuthorizer(db)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		input *hub.AuthorizeInput
+		allow bool
+	}{
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user4ID,
+				Action:           hub.UpdateOrganizationRepository,
+				RepositoryName:   "repo1",
+			},
+			true,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user4ID,
+				Action:           hub.UpdateOrganizationRepository,
+				RepositoryName:   "repo2",
+			},
+			false,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user4ID,
+				Action:           hub.UpdateOrganizationRepository,
+			},
+			false,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org3Name,
+				UserID:           user4ID,
+				Action:           hub.DeleteOrganizationRepository,
+				RepositoryName:   "repo1",
+			},
+			false,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org4Name,
+				UserID:           user4ID,
+				Action:           hub.UpdateOrganizationRepository,
+				RepositoryName:   "repo1",
+			},
+			true,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org4Name,
+				UserID:           user4ID,
+				Action:           hub.UpdateOrganizationRepository,
+				RepositoryName:   "repo2",
+			},
+			false,
+		},
+		{
+			&hub.AuthorizeInput{
+				OrganizationName: org4Name,
+				UserID:           user4ID,
+				Action:           hub.UpdateOrganization,
+			},
+			true,
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			err := az.Authorize(context.Background(), tc.input)
+			if tc.allow {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, hub.ErrInsufficientPrivilege))
+			}
+		})
+	}
+}
+
 func TestGetAllowedActions(t *testing.T) {
 	db := &tests.DBMock{}
 	db.On("QueryRow", context.Background(), getAuthzPoliciesDBQ).Return(testsAuthorizationPoliciesJSON, nil)
@@ -255,6 +438,15 @@ func TestGetAllowedActions(t *testing.T) {
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user3ID).Return(user3Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user4ID).Return(user4Alias, nil).Maybe()
 	db.On("QueryRow", context.Background(), getUserAliasDBQ, user5ID).Return("", tests.ErrFakeDB).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user1ID, org3Name).
+		Return([]string{"all"}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user3ID, org3Name).
+		Return([]string{"addOrganizationRepository", "updateOrganizationRepository"}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user4ID, org3Name).
+		Return([]string{}, nil).Maybe()
+	db.On("QueryRow", context.Background(), getUserAllowedActionsDBQ, user5ID, org3Name).
+		Return(nil, tests.ErrFakeDB).Maybe()
+	db.On("QueryRow", mock.Anything, getUserTeamsDBQ, mock.Anything, mock.Anything).Return([]byte("[]"), nil).Maybe()
 	db.On("Acquire", context.Background()).Return(nil, tests.ErrFakeDB).Maybe()
 	az, err := NewAuthorizer(db)
 	require.NoError(t, err)
@@ -317,9 +509,20 @@ func TestGetAllowedActions(t *testing.T) {
 			user3ID,
 			org3Name,
 			[]hub.Action{
-				hub.Action("all"),
+				hub.AddOrganizationRepository,
+				hub.UpdateOrganizationRepository,
 			},
 		},
+		{
+			user4ID,
+			org3Name,
+			[]hub.Action{},
+		},
+		{
+			user5ID,
+			org3Name,
+			nil,
+		},
 	}
 	for i, tc := range testCases {
 		tc := tc
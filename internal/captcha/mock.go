@@ -0,0 +1,18 @@
+package captcha
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// VerifierMock is a mock implementation of the CaptchaVerifier interface.
+type VerifierMock struct {
+	mock.Mock
+}
+
+// Verify implements the CaptchaVerifier interface.
+func (m *VerifierMock) Verify(ctx context.Context, response, remoteIP string) error {
+	args := m.Called(ctx, response, remoteIP)
+	return args.Error(0)
+}
@@ -0,0 +1,112 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+)
+
+// ErrInvalidResponse indicates that the CAPTCHA response provided is not
+// valid.
+var ErrInvalidResponse = errors.New("invalid captcha response")
+
+// Provider represents a CAPTCHA service provider.
+type Provider struct {
+	// VerifyURL represents the url of the endpoint used to verify the
+	// responses provided by the users.
+	VerifyURL string
+
+	// Sources represents the origins the provider's widget loads resources
+	// from, which must be allowed in the index CSP.
+	Sources []string
+}
+
+// Providers represents the CAPTCHA providers supported.
+var Providers = map[string]*Provider{
+	"hcaptcha": {
+		VerifyURL: "https://hcaptcha.com/siteverify",
+		Sources:   []string{"https://hcaptcha.com", "https://*.hcaptcha.com"},
+	},
+	"recaptcha": {
+		VerifyURL: "https://www.google.com/recaptcha/api/siteverify",
+		Sources:   []string{"https://www.google.com/recaptcha/", "https://www.gstatic.com/recaptcha/"},
+	},
+	"turnstile": {
+		VerifyURL: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+		Sources:   []string{"https://challenges.cloudflare.com"},
+	},
+}
+
+// Verifier checks the CAPTCHA responses provided by the users with the
+// configured provider.
+type Verifier struct {
+	provider  *Provider
+	secretKey string
+	hc        hub.HTTPClient
+}
+
+// NewVerifier creates a new Verifier instance.
+func NewVerifier(cfg *viper.Viper, hc hub.HTTPClient) (*Verifier, error) {
+	providerName := cfg.GetString("server.captcha.provider")
+	provider, ok := Providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("invalid captcha provider: %s", providerName)
+	}
+	secretKey := cfg.GetString("server.captcha.secretKey")
+	if secretKey == "" {
+		return nil, errors.New("captcha secret key not provided")
+	}
+	return &Verifier{
+		provider:  provider,
+		secretKey: secretKey,
+		hc:        hc,
+	}, nil
+}
+
+// Verify checks the CAPTCHA response provided with the provider. An
+// ErrInvalidResponse error is returned when the provider does not accept it.
+func (v *Verifier) Verify(ctx context.Context, response, remoteIP string) error {
+	if response == "" {
+		return ErrInvalidResponse
+	}
+
+	// Prepare verification request
+	data := url.Values{}
+	data.Set("secret", v.secretKey)
+	data.Set("response", response)
+	if remoteIP != "" {
+		data.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", v.provider.VerifyURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("error preparing verification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Send request and process the verification result
+	resp, err := v.hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending verification request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("error decoding verification result: %w", err)
+	}
+	if !result.Success {
+		return ErrInvalidResponse
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package captcha
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("invalid provider", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.captcha.provider", "invalid")
+		cfg.Set("server.captcha.secretKey", "secret")
+		v, err := NewVerifier(cfg, &tests.HTTPClientMock{})
+		assert.Error(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("secret key not provided", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.captcha.provider", "hcaptcha")
+		v, err := NewVerifier(cfg, &tests.HTTPClientMock{})
+		assert.Error(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("verifier created successfully", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.captcha.provider", "turnstile")
+		cfg.Set("server.captcha.secretKey", "secret")
+		hc := &tests.HTTPClientMock{}
+		v, err := NewVerifier(cfg, hc)
+		require.NoError(t, err)
+		assert.Equal(t, Providers["turnstile"], v.provider)
+		assert.Equal(t, "secret", v.secretKey)
+		assert.Equal(t, hc, v.hc)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	cfg.Set("server.captcha.provider", "hcaptcha")
+	cfg.Set("server.captcha.secretKey", "secret")
+
+	isVerifyRequest := mock.MatchedBy(func(req *http.Request) bool {
+		if req.URL.String() != Providers["hcaptcha"].VerifyURL {
+			return false
+		}
+		if err := req.ParseForm(); err != nil {
+			return false
+		}
+		return req.PostForm.Get("secret") == "secret" &&
+			req.PostForm.Get("response") == "response" &&
+			req.PostForm.Get("remoteip") == "1.2.3.4"
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		v, _ := NewVerifier(cfg, hc)
+
+		err := v.Verify(ctx, "", "1.2.3.4")
+		assert.True(t, errors.Is(err, ErrInvalidResponse))
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("error sending verification request", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isVerifyRequest).Return(nil, tests.ErrFake)
+		v, _ := NewVerifier(cfg, hc)
+
+		err := v.Verify(ctx, "response", "1.2.3.4")
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("unexpected status code received", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isVerifyRequest).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusInternalServerError,
+		}, nil)
+		v, _ := NewVerifier(cfg, hc)
+
+		err := v.Verify(ctx, "response", "1.2.3.4")
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrInvalidResponse))
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("response rejected by provider", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isVerifyRequest).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"success": false}`)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		v, _ := NewVerifier(cfg, hc)
+
+		err := v.Verify(ctx, "response", "1.2.3.4")
+		assert.True(t, errors.Is(err, ErrInvalidResponse))
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("response accepted by provider", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isVerifyRequest).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"success": true}`)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		v, _ := NewVerifier(cfg, hc)
+
+		err := v.Verify(ctx, "response", "1.2.3.4")
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,179 @@
+package chartmirror
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/img/objstore"
+	"github.com/jackc/pgx/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	getPackagesVersionsDBQ = `select get_packages_versions($1::uuid[])`
+
+	defaultGCInterval    = 24 * time.Hour
+	defaultGCGracePeriod = 24 * time.Hour
+)
+
+var (
+	gcDeletedArchives = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "chart_mirror_gc_deleted_total",
+		Help: "Number of orphaned charts archives deleted by the chart mirror garbage collector.",
+	}, []string{"dry_run"})
+	gcReclaimedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "chart_mirror_gc_reclaimed_bytes_total",
+		Help: "Space reclaimed by the chart mirror garbage collector, in bytes.",
+	}, []string{"dry_run"})
+)
+
+func init() {
+	prometheus.MustRegister(gcDeletedArchives, gcReclaimedBytes)
+}
+
+// DB defines the methods the database handler used by the chart mirror
+// garbage collector must provide.
+type DB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// StoredArchive represents a chart archive available in the mirror.
+type StoredArchive struct {
+	PackageID string
+	Version   string
+	Size      int64
+}
+
+// GC is in charge of deleting the charts archives stored in the mirror whose
+// packages versions are no longer available in the hub. Only archives stored
+// before the configured grace period are considered.
+type GC struct {
+	db          DB
+	bucket      objstore.Bucket
+	interval    time.Duration
+	gracePeriod time.Duration
+	dryRun      bool
+}
+
+// NewGC creates a new GC instance.
+func NewGC(cfg *viper.Viper, db DB, bucket objstore.Bucket) *GC {
+	cfg.SetDefault("chartMirror.gc.interval", defaultGCInterval)
+	cfg.SetDefault("chartMirror.gc.gracePeriod", defaultGCGracePeriod)
+	return &GC{
+		db:          db,
+		bucket:      bucket,
+		interval:    cfg.GetDuration("chartMirror.gc.interval"),
+		gracePeriod: cfg.GetDuration("chartMirror.gc.gracePeriod"),
+		dryRun:      cfg.GetBool("chartMirror.gc.dryRun"),
+	}
+}
+
+// Run runs the garbage collector periodically until it's asked to stop via the
+// context provided.
+func (gc *GC) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(gc.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := gc.Collect(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("error collecting orphaned charts archives")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect deletes the orphaned charts archives found in the mirror, returning
+// the archives collected. When running in dry-run mode, archives are only
+// reported.
+func (gc *GC) Collect(ctx context.Context) ([]*StoredArchive, error) {
+	// Get archives stored before the grace period
+	objects, err := gc.bucket.List(ctx, keyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	t := time.Now().Add(-gc.gracePeriod)
+	var archives []*StoredArchive
+	var packagesIDs []string
+	seen := make(map[string]struct{})
+	for _, o := range objects {
+		if !o.LastModified.Before(t) {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(o.Key, keyPrefix), "/")
+		if len(parts) != 2 || !strings.HasSuffix(parts[1], ".tgz") {
+			continue
+		}
+		a := &StoredArchive{
+			PackageID: parts[0],
+			Version:   strings.TrimSuffix(parts[1], ".tgz"),
+			Size:      o.Size,
+		}
+		archives = append(archives, a)
+		if _, ok := seen[a.PackageID]; !ok {
+			seen[a.PackageID] = struct{}{}
+			packagesIDs = append(packagesIDs, a.PackageID)
+		}
+	}
+	if len(archives) == 0 {
+		return nil, nil
+	}
+
+	// Get versions of the packages still available in the hub
+	var dataJSON []byte
+	if err := gc.db.QueryRow(ctx, getPackagesVersionsDBQ, packagesIDs).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	var packagesVersions map[string][]string
+	if err := json.Unmarshal(dataJSON, &packagesVersions); err != nil {
+		return nil, err
+	}
+	available := make(map[string]struct{})
+	for packageID, versions := range packagesVersions {
+		for _, version := range versions {
+			available[ArchiveKey(packageID, version)] = struct{}{}
+		}
+	}
+
+	// Delete archives of packages versions no longer available
+	dryRun := strconv.FormatBool(gc.dryRun)
+	var collected []*StoredArchive
+	for _, a := range archives {
+		key := ArchiveKey(a.PackageID, a.Version)
+		if _, ok := available[key]; ok {
+			continue
+		}
+		if ctx.Err() != nil {
+			return collected, ctx.Err()
+		}
+		logger := log.With().
+			Str("packageID", a.PackageID).
+			Str("version", a.Version).
+			Int64("size", a.Size).
+			Bool("dryRun", gc.dryRun).
+			Logger()
+		if !gc.dryRun {
+			if err := gc.bucket.Delete(ctx, key); err != nil {
+				logger.Error().Err(err).Msg("error deleting orphaned chart archive")
+				continue
+			}
+		}
+		logger.Info().Msg("orphaned chart archive collected")
+		gcDeletedArchives.WithLabelValues(dryRun).Inc()
+		gcReclaimedBytes.WithLabelValues(dryRun).Add(float64(a.Size))
+		collected = append(collected, a)
+	}
+
+	return collected, nil
+}
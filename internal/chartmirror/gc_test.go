@@ -0,0 +1,119 @@
+package chartmirror
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/img/objstore"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default configuration", func(t *testing.T) {
+		t.Parallel()
+		gc := NewGC(viper.New(), &tests.DBMock{}, &objstore.BucketMock{})
+		assert.Equal(t, defaultGCInterval, gc.interval)
+		assert.Equal(t, defaultGCGracePeriod, gc.gracePeriod)
+		assert.False(t, gc.dryRun)
+	})
+
+	t.Run("custom configuration", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("chartMirror.gc.interval", "1h")
+		cfg.Set("chartMirror.gc.gracePeriod", "2h")
+		cfg.Set("chartMirror.gc.dryRun", true)
+		gc := NewGC(cfg, &tests.DBMock{}, &objstore.BucketMock{})
+		assert.Equal(t, 1*time.Hour, gc.interval)
+		assert.Equal(t, 2*time.Hour, gc.gracePeriod)
+		assert.True(t, gc.dryRun)
+	})
+}
+
+func TestGCCollect(t *testing.T) {
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour)
+	packagesIDs := []string{pkg1ID, pkg2ID}
+	packagesVersionsJSON := []byte(`{"` + pkg1ID + `": ["1.0.0"]}`)
+	objects := []*objstore.ObjectInfo{
+		{Key: ArchiveKey(pkg1ID, "1.0.0"), Size: 10, LastModified: old},
+		{Key: ArchiveKey(pkg1ID, "0.9.0"), Size: 20, LastModified: old},
+		{Key: ArchiveKey(pkg2ID, "1.0.0"), Size: 30, LastModified: old},
+		{Key: ArchiveKey(pkg2ID, "2.0.0"), Size: 40, LastModified: time.Now()},
+		{Key: "charts/other", Size: 50, LastModified: old},
+	}
+
+	t.Run("error listing archives", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		bucket := &objstore.BucketMock{}
+		bucket.On("List", ctx, keyPrefix).Return(nil, tests.ErrFake)
+		gc := NewGC(viper.New(), db, bucket)
+
+		collected, err := gc.Collect(ctx)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, collected)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("error getting packages versions", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesVersionsDBQ, packagesIDs).Return(nil, tests.ErrFakeDB)
+		bucket := &objstore.BucketMock{}
+		bucket.On("List", ctx, keyPrefix).Return(objects, nil)
+		gc := NewGC(viper.New(), db, bucket)
+
+		collected, err := gc.Collect(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, collected)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("orphaned archives deleted", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesVersionsDBQ, packagesIDs).Return(packagesVersionsJSON, nil)
+		bucket := &objstore.BucketMock{}
+		bucket.On("List", ctx, keyPrefix).Return(objects, nil)
+		bucket.On("Delete", ctx, ArchiveKey(pkg1ID, "0.9.0")).Return(tests.ErrFake)
+		bucket.On("Delete", ctx, ArchiveKey(pkg2ID, "1.0.0")).Return(nil)
+		gc := NewGC(viper.New(), db, bucket)
+
+		collected, err := gc.Collect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*StoredArchive{
+			{PackageID: pkg2ID, Version: "1.0.0", Size: 30},
+		}, collected)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("dry run, orphaned archives only reported", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagesVersionsDBQ, packagesIDs).Return(packagesVersionsJSON, nil)
+		bucket := &objstore.BucketMock{}
+		bucket.On("List", ctx, keyPrefix).Return(objects, nil)
+		cfg := viper.New()
+		cfg.Set("chartMirror.gc.dryRun", true)
+		gc := NewGC(cfg, db, bucket)
+
+		collected, err := gc.Collect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*StoredArchive{
+			{PackageID: pkg1ID, Version: "0.9.0", Size: 20},
+			{PackageID: pkg2ID, Version: "1.0.0", Size: 30},
+		}, collected)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+}
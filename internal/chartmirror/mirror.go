@@ -0,0 +1,168 @@
+package chartmirror
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img/objstore"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/satori/uuid"
+)
+
+const (
+	// keyPrefix represents the prefix of the objects used to store the charts
+	// archives in the bucket.
+	keyPrefix = "charts/"
+
+	// maxArchiveSize represents the maximum size of the charts archives that
+	// can be mirrored.
+	maxArchiveSize = 50 << 20 // 50 MiB
+
+	// archiveContentType represents the content type of the charts archives.
+	archiveContentType = "application/gzip"
+)
+
+// Mirror is a hub.ChartMirror implementation that serves the archives of the
+// Helm charts tracked by the hub, acting as a read-through cache of the charts
+// repositories. Archives are fetched from the repository the first time they
+// are requested and stored in the bucket provided for subsequent requests.
+type Mirror struct {
+	pm      hub.PackageManager
+	rm      hub.RepositoryManager
+	bucket  objstore.Bucket
+	hc      hub.HTTPClient
+	mutexes sync.Map
+}
+
+// NewMirror creates a new Mirror instance.
+func NewMirror(
+	pm hub.PackageManager,
+	rm hub.RepositoryManager,
+	bucket objstore.Bucket,
+	hc hub.HTTPClient,
+) *Mirror {
+	return &Mirror{
+		pm:     pm,
+		rm:     rm,
+		bucket: bucket,
+		hc:     hc,
+	}
+}
+
+// GetChartArchive implements the hub.ChartMirror interface. It returns a
+// reader to the archive of the chart version provided, as well as the
+// archive's file name.
+func (m *Mirror) GetChartArchive(ctx context.Context, packageID, version string) (io.ReadCloser, string, error) {
+	// Validate input
+	if _, err := uuid.FromString(packageID); err != nil {
+		return nil, "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return nil, "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Get package version from database. This makes sure that only archives
+	// of packages versions still available in the hub are served.
+	p, err := m.pm.Get(ctx, &hub.GetPackageInput{PackageID: packageID, Version: version})
+	if err != nil {
+		return nil, "", err
+	}
+	if p.Repository.Kind != hub.Helm {
+		return nil, "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only Helm charts archives can be downloaded")
+	}
+	if strings.HasPrefix(p.Repository.URL, hub.RepositoryOCIPrefix) {
+		return nil, "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "OCI based repositories not supported yet")
+	}
+	fileName := fmt.Sprintf("%s-%s.tgz", p.Name, p.Version)
+
+	// Make sure we only fetch the same archive once at a time
+	key := ArchiveKey(p.PackageID, p.Version)
+	v, _ := m.mutexes.LoadOrStore(key, &sync.Mutex{})
+	mu := v.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Return archive from the bucket if it has already been mirrored
+	r, _, err := m.bucket.Get(ctx, key)
+	if err == nil {
+		return r, fileName, nil
+	}
+	if !errors.Is(err, hub.ErrNotFound) {
+		return nil, "", err
+	}
+
+	// Fetch archive from the repository and store it in the bucket
+	data, err := m.fetchArchive(ctx, p)
+	if err != nil {
+		return nil, "", fmt.Errorf("error fetching chart archive: %w", err)
+	}
+	if err := m.bucket.Put(ctx, key, data, archiveContentType); err != nil {
+		return nil, "", err
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), fileName, nil
+}
+
+// fetchArchive fetches the archive of the package version provided from its
+// repository, verifying its digest when available.
+func (m *Mirror) fetchArchive(ctx context.Context, p *hub.Package) ([]byte, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", p.ContentURL, nil)
+
+	// Use the repository credentials and transport options when needed
+	rp, err := m.rm.GetByID(ctx, p.Repository.RepositoryID, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.rm.ResolveCredentials(ctx, rp); err != nil {
+		return nil, err
+	}
+	if rp.AuthUser != "" || rp.AuthPass != "" {
+		req.SetBasicAuth(rp.AuthUser, rp.AuthPass)
+	}
+	hc, err := repo.HTTPClient(rp, m.hc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch archive
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxArchiveSize {
+		return nil, errors.New("chart archive too big")
+	}
+
+	// Verify archive digest
+	if p.Digest != "" {
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != p.Digest {
+			return nil, errors.New("chart archive digest mismatch")
+		}
+	}
+
+	return data, nil
+}
+
+// ArchiveKey returns the key of the object used to store the archive of the
+// package version provided.
+func ArchiveKey(packageID, version string) string {
+	return fmt.Sprintf("%s%s/%s.tgz", keyPrefix, packageID, version)
+}
@@ -0,0 +1,240 @@
+package chartmirror
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img/objstore"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	pkg1ID  = "00000000-0000-0000-0000-000000000001"
+	pkg2ID  = "00000000-0000-0000-0000-000000000002"
+	repo1ID = "00000000-0000-0000-0000-000000000001"
+)
+
+func TestGetChartArchive(t *testing.T) {
+	ctx := context.Background()
+	archive := []byte("archive data")
+	sum := sha256.Sum256(archive)
+	digest := hex.EncodeToString(sum[:])
+	input := &hub.GetPackageInput{PackageID: pkg1ID, Version: "1.0.0"}
+	key := ArchiveKey(pkg1ID, "1.0.0")
+	newPackage := func(digest string) *hub.Package {
+		return &hub.Package{
+			PackageID:  pkg1ID,
+			Name:       "pkg1",
+			Version:    "1.0.0",
+			Digest:     digest,
+			ContentURL: "https://repo1.com/pkg1-1.0.0.tgz",
+			Repository: &hub.Repository{
+				RepositoryID: repo1ID,
+				Kind:         hub.Helm,
+				URL:          "https://repo1.com",
+			},
+		}
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			packageID string
+			version   string
+			errMsg    string
+		}{
+			{"invalid", "1.0.0", "invalid package id"},
+			{pkg1ID, "", "version not provided"},
+		}
+		for _, tc := range testCases {
+			m := NewMirror(nil, nil, nil, nil)
+			r, fileName, err := m.GetChartArchive(ctx, tc.packageID, tc.version)
+			assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+			assert.Contains(t, err.Error(), tc.errMsg)
+			assert.Nil(t, r)
+			assert.Empty(t, fileName)
+		}
+	})
+
+	t.Run("error getting package", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("Get", ctx, input).Return(nil, hub.ErrNotFound)
+		m := NewMirror(pm, nil, nil, nil)
+
+		r, _, err := m.GetChartArchive(ctx, pkg1ID, "1.0.0")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, r)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("package not supported", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			r      *hub.Repository
+			errMsg string
+		}{
+			{
+				&hub.Repository{Kind: hub.OLM, URL: "https://github.com/org1/repo1"},
+				"only Helm charts archives can be downloaded",
+			},
+			{
+				&hub.Repository{Kind: hub.Helm, URL: "oci://registry.io/repo1"},
+				"OCI based repositories not supported yet",
+			},
+		}
+		for _, tc := range testCases {
+			p := newPackage("")
+			p.Repository = tc.r
+			pm := &pkg.ManagerMock{}
+			pm.On("Get", ctx, input).Return(p, nil)
+			m := NewMirror(pm, nil, nil, nil)
+
+			r, _, err := m.GetChartArchive(ctx, pkg1ID, "1.0.0")
+			assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+			assert.Contains(t, err.Error(), tc.errMsg)
+			assert.Nil(t, r)
+			pm.AssertExpectations(t)
+		}
+	})
+
+	t.Run("archive already mirrored", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("Get", ctx, input).Return(newPackage(digest), nil)
+		bucket := &objstore.BucketMock{}
+		bucket.On("Get", ctx, key).Return(ioutil.NopCloser(bytes.NewReader(archive)), archiveContentType, nil)
+		m := NewMirror(pm, nil, bucket, nil)
+
+		r, fileName, err := m.GetChartArchive(ctx, pkg1ID, "1.0.0")
+		require.NoError(t, err)
+		data, _ := ioutil.ReadAll(r)
+		assert.Equal(t, archive, data)
+		assert.Equal(t, "pkg1-1.0.0.tgz", fileName)
+		pm.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("error getting archive from bucket", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("Get", ctx, input).Return(newPackage(digest), nil)
+		bucket := &objstore.BucketMock{}
+		bucket.On("Get", ctx, key).Return(nil, "", tests.ErrFake)
+		m := NewMirror(pm, nil, bucket, nil)
+
+		r, _, err := m.GetChartArchive(ctx, pkg1ID, "1.0.0")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, r)
+		pm.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("error getting repository", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("Get", ctx, input).Return(newPackage(digest), nil)
+		rm := &repo.ManagerMock{}
+		rm.On("GetByID", ctx, repo1ID, true).Return(nil, tests.ErrFakeDB)
+		bucket := &objstore.BucketMock{}
+		bucket.On("Get", ctx, key).Return(nil, "", hub.ErrNotFound)
+		m := NewMirror(pm, rm, bucket, nil)
+
+		r, _, err := m.GetChartArchive(ctx, pkg1ID, "1.0.0")
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		assert.Nil(t, r)
+		pm.AssertExpectations(t)
+		rm.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("error fetching archive", func(t *testing.T) {
+		t.Parallel()
+		testCases := []struct {
+			digest string
+			resp   *http.Response
+			errMsg string
+		}{
+			{
+				digest,
+				&http.Response{
+					Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+					StatusCode: http.StatusNotFound,
+				},
+				"unexpected status code received: 404",
+			},
+			{
+				"invalid",
+				&http.Response{
+					Body:       ioutil.NopCloser(bytes.NewReader(archive)),
+					StatusCode: http.StatusOK,
+				},
+				"chart archive digest mismatch",
+			},
+		}
+		for _, tc := range testCases {
+			pm := &pkg.ManagerMock{}
+			pm.On("Get", ctx, input).Return(newPackage(tc.digest), nil)
+			rm := &repo.ManagerMock{}
+			rm.On("GetByID", ctx, repo1ID, true).Return(&hub.Repository{}, nil)
+			rm.On("ResolveCredentials", ctx, &hub.Repository{}).Return(nil)
+			bucket := &objstore.BucketMock{}
+			bucket.On("Get", ctx, key).Return(nil, "", hub.ErrNotFound)
+			hc := &tests.HTTPClientMock{}
+			hc.On("Do", mock.Anything).Return(tc.resp, nil)
+			m := NewMirror(pm, rm, bucket, hc)
+
+			r, _, err := m.GetChartArchive(ctx, pkg1ID, "1.0.0")
+			assert.Contains(t, err.Error(), tc.errMsg)
+			assert.Nil(t, r)
+			pm.AssertExpectations(t)
+			rm.AssertExpectations(t)
+			bucket.AssertExpectations(t)
+			hc.AssertExpectations(t)
+		}
+	})
+
+	t.Run("archive fetched and mirrored", func(t *testing.T) {
+		t.Parallel()
+		rp := &hub.Repository{AuthUser: "user1", AuthPass: "pass1"}
+		pm := &pkg.ManagerMock{}
+		pm.On("Get", ctx, input).Return(newPackage(digest), nil)
+		rm := &repo.ManagerMock{}
+		rm.On("GetByID", ctx, repo1ID, true).Return(rp, nil)
+		rm.On("ResolveCredentials", ctx, rp).Return(nil)
+		bucket := &objstore.BucketMock{}
+		bucket.On("Get", ctx, key).Return(nil, "", hub.ErrNotFound)
+		bucket.On("Put", ctx, key, archive, archiveContentType).Return(nil)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			user, pass, _ := req.BasicAuth()
+			return req.URL.String() == "https://repo1.com/pkg1-1.0.0.tgz" && user == "user1" && pass == "pass1"
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader(archive)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		m := NewMirror(pm, rm, bucket, hc)
+
+		r, fileName, err := m.GetChartArchive(ctx, pkg1ID, "1.0.0")
+		require.NoError(t, err)
+		data, _ := ioutil.ReadAll(r)
+		assert.Equal(t, archive, data)
+		assert.Equal(t, "pkg1-1.0.0.tgz", fileName)
+		pm.AssertExpectations(t)
+		rm.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,20 @@
+package chartmirror
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MirrorMock is a mock implementation of the ChartMirror interface.
+type MirrorMock struct {
+	mock.Mock
+}
+
+// GetChartArchive implements the ChartMirror interface.
+func (m *MirrorMock) GetChartArchive(ctx context.Context, packageID, version string) (io.ReadCloser, string, error) {
+	args := m.Called(ctx, packageID, version)
+	r, _ := args.Get(0).(io.ReadCloser)
+	return r, args.String(1), args.Error(2)
+}
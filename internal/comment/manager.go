@@ -0,0 +1,183 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addCommentDBQ       = `select add_package_comment($1::uuid, $2::jsonb)`
+	deleteCommentDBQ    = `select delete_package_comment($1::uuid, $2::uuid, $3::uuid)`
+	getCommentsDBQ      = `select get_package_comments($1::uuid, $2::uuid, $3::int, $4::int)`
+	setCommentHiddenDBQ = `select set_package_comment_hidden($1::uuid, $2::uuid, $3::uuid, $4::boolean)`
+	updateCommentDBQ    = `select update_package_comment($1::uuid, $2::jsonb)`
+
+	// DefaultLimit represents the default number of questions returned.
+	DefaultLimit = 20
+
+	// MaxLimit represents the maximum number of questions that can be
+	// requested.
+	MaxLimit = 60
+
+	// MaxBodyLength represents the maximum length of a comment body.
+	MaxBodyLength = 5000
+)
+
+// Manager provides an API to manage packages comments (questions and
+// answers).
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add adds the provided comment to the database, returning its id as a json
+// object. The package's publishers are notified when a new question is posted.
+func (m *Manager) Add(ctx context.Context, c *hub.Comment) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(c.PackageID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if c.ParentID != "" {
+		if _, err := uuid.FromString(c.ParentID); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid parent id")
+		}
+	}
+	if err := validateBody(c.Body); err != nil {
+		return nil, err
+	}
+
+	// Add comment to database
+	cJSON, _ := json.Marshal(c)
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, addCommentDBQ, userID, cJSON)
+	if err != nil && err.Error() == util.ErrDBNotFound.Error() {
+		return nil, hub.ErrNotFound
+	}
+	return dataJSON, err
+}
+
+// Delete deletes the provided comment from the database. Comments can be
+// deleted by their authors and by the package's publishers. Deleting a
+// question deletes its answers as well.
+func (m *Manager) Delete(ctx context.Context, packageID, commentID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateIDs(packageID, commentID); err != nil {
+		return err
+	}
+
+	// Delete comment from database
+	_, err := m.db.Exec(ctx, deleteCommentDBQ, userID, packageID, commentID)
+	return mapDBError(err)
+}
+
+// GetJSON returns the questions posted on the provided package, including
+// their answers, as a json object. Hidden comments are only returned when the
+// requesting user is one of the package's publishers.
+func (m *Manager) GetJSON(ctx context.Context, input *hub.GetCommentsInput) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(input.PackageID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if input.Limit == 0 {
+		input.Limit = DefaultLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxLimit {
+		return nil, fmt.Errorf("%w: invalid limit (0 < l <= %d)", hub.ErrInvalidInput, MaxLimit)
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
+	}
+
+	// Get comments from database
+	var userID *string
+	if v, _ := ctx.Value(hub.UserIDKey).(string); v != "" {
+		userID = &v
+	}
+	return util.DBQueryJSON(ctx, m.db, getCommentsDBQ, userID, input.PackageID, input.Limit, input.Offset)
+}
+
+// SetHidden hides or unhides the provided comment. Only the package's
+// publishers are allowed to moderate its comments.
+func (m *Manager) SetHidden(ctx context.Context, packageID, commentID string, hidden bool) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateIDs(packageID, commentID); err != nil {
+		return err
+	}
+
+	// Update comment visibility in database
+	_, err := m.db.Exec(ctx, setCommentHiddenDBQ, userID, packageID, commentID, hidden)
+	return mapDBError(err)
+}
+
+// Update updates the body of the provided comment. Comments can only be
+// updated by their authors.
+func (m *Manager) Update(ctx context.Context, c *hub.Comment) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateIDs(c.PackageID, c.CommentID); err != nil {
+		return err
+	}
+	if err := validateBody(c.Body); err != nil {
+		return err
+	}
+
+	// Update comment in database
+	cJSON, _ := json.Marshal(c)
+	_, err := m.db.Exec(ctx, updateCommentDBQ, userID, cJSON)
+	return mapDBError(err)
+}
+
+// validateIDs checks the package and comment ids provided are valid.
+func validateIDs(packageID, commentID string) error {
+	if _, err := uuid.FromString(packageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if _, err := uuid.FromString(commentID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid comment id")
+	}
+	return nil
+}
+
+// validateBody checks the comment body provided is valid.
+func validateBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "body not provided")
+	}
+	if utf8.RuneCountInString(body) > MaxBodyLength {
+		return fmt.Errorf("%w: %s (max %d)", hub.ErrInvalidInput, "body too long", MaxBodyLength)
+	}
+	return nil
+}
+
+// mapDBError maps the database errors that have a hub counterpart.
+func mapDBError(err error) error {
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,407 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	pkgID     = "00000000-0000-0000-0000-000000000001"
+	commentID = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	c := &hub.Comment{PackageID: pkgID, Body: "question"}
+	cJSON := []byte(`{"comment_id":"","package_id":"` + pkgID + `","parent_id":"","body":"question"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.Add(context.Background(), &hub.Comment{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			c      *hub.Comment
+		}{
+			{
+				"invalid package id",
+				&hub.Comment{PackageID: "pkgID", Body: "question"},
+			},
+			{
+				"invalid parent id",
+				&hub.Comment{PackageID: pkgID, ParentID: "parentID", Body: "answer"},
+			},
+			{
+				"body not provided",
+				&hub.Comment{PackageID: pkgID, Body: " "},
+			},
+			{
+				"body too long",
+				&hub.Comment{PackageID: pkgID, Body: strings.Repeat("a", MaxBodyLength+1)},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.Add(ctx, tc.c)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, addCommentDBQ, "userID", cJSON).Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.Add(ctx, c)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("comment added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addCommentDBQ, "userID", cJSON).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.Add(ctx, c)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), pkgID, commentID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			pkgID     string
+			commentID string
+		}{
+			{
+				"invalid package id",
+				"pkgID",
+				commentID,
+			},
+			{
+				"invalid comment id",
+				pkgID,
+				"commentID",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Delete(ctx, tc.pkgID, tc.commentID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteCommentDBQ, "userID", pkgID, commentID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Delete(ctx, pkgID, commentID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("comment deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteCommentDBQ, "userID", pkgID, commentID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Delete(ctx, pkgID, commentID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetCommentsInput
+		}{
+			{
+				"invalid package id",
+				&hub.GetCommentsInput{PackageID: "pkgID"},
+			},
+			{
+				"invalid limit",
+				&hub.GetCommentsInput{PackageID: pkgID, Limit: MaxLimit + 1},
+			},
+			{
+				"invalid offset",
+				&hub.GetCommentsInput{PackageID: pkgID, Offset: -1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetJSON(context.Background(), tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded (anonymous user)", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getCommentsDBQ, (*string)(nil), pkgID, DefaultLimit, 0).
+			Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, &hub.GetCommentsInput{PackageID: pkgID})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (authenticated user)", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+		userID := "userID"
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getCommentsDBQ, &userID, pkgID, 10, 10).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, &hub.GetCommentsInput{PackageID: pkgID, Limit: 10, Offset: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getCommentsDBQ, (*string)(nil), pkgID, DefaultLimit, 0).
+			Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, &hub.GetCommentsInput{PackageID: pkgID})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSetHidden(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.SetHidden(context.Background(), pkgID, commentID, true)
+		})
+	})
+
+	t.Run("invalid comment id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.SetHidden(ctx, pkgID, "commentID", true)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid comment id")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, setCommentHiddenDBQ, "userID", pkgID, commentID, true).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.SetHidden(ctx, pkgID, commentID, true)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("comment hidden successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, setCommentHiddenDBQ, "userID", pkgID, commentID, true).Return(nil)
+		m := NewManager(db)
+
+		err := m.SetHidden(ctx, pkgID, commentID, true)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	c := &hub.Comment{CommentID: commentID, PackageID: pkgID, Body: "updated"}
+	cJSON := []byte(`{"comment_id":"` + commentID + `","package_id":"` + pkgID + `","parent_id":"","body":"updated"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Update(context.Background(), c)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			c      *hub.Comment
+		}{
+			{
+				"invalid comment id",
+				&hub.Comment{CommentID: "commentID", PackageID: pkgID, Body: "updated"},
+			},
+			{
+				"body not provided",
+				&hub.Comment{CommentID: commentID, PackageID: pkgID},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Update(ctx, tc.c)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateCommentDBQ, "userID", cJSON).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Update(ctx, c)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("comment updated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateCommentDBQ, "userID", cJSON).Return(nil)
+		m := NewManager(db)
+
+		err := m.Update(ctx, c)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,45 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the CommentManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the CommentManager interface.
+func (m *ManagerMock) Add(ctx context.Context, c *hub.Comment) ([]byte, error) {
+	args := m.Called(ctx, c)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Delete implements the CommentManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, packageID, commentID string) error {
+	args := m.Called(ctx, packageID, commentID)
+	return args.Error(0)
+}
+
+// GetJSON implements the CommentManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context, input *hub.GetCommentsInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// SetHidden implements the CommentManager interface.
+func (m *ManagerMock) SetHidden(ctx context.Context, packageID, commentID string, hidden bool) error {
+	args := m.Called(ctx, packageID, commentID, hidden)
+	return args.Error(0)
+}
+
+// Update implements the CommentManager interface.
+func (m *ManagerMock) Update(ctx context.Context, c *hub.Comment) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
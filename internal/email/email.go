@@ -17,9 +17,11 @@ var ErrSenderNotAvailable = errors.New("email sender not available")
 
 // Data describes the different pieces of data used to compose an email.
 type Data struct {
-	To      string
-	Subject string
-	Body    []byte
+	To        string
+	Subject   string
+	Body      []byte
+	PlainBody []byte
+	Headers   map[string]string
 }
 
 // Sender is in charge of sending emails.
@@ -63,7 +65,9 @@ func NewSender(cfg *viper.Viper) *Sender {
 	return s
 }
 
-// SendEmail creates an email using the data provided and sends it.
+// SendEmail creates an email using the data provided and sends it. When a
+// plain text body is provided, the email is sent as a multipart/alternative
+// message including both the html and the plain text versions.
 func (s *Sender) SendEmail(d *Data) error {
 	email := mailyak.New(s.smtpAddr, s.smtpAuth)
 	email.FromName(s.fromName)
@@ -71,8 +75,16 @@ func (s *Sender) SendEmail(d *Data) error {
 	email.ReplyTo(s.replyTo)
 	email.To(d.To)
 	email.Subject(d.Subject)
+	for name, value := range d.Headers {
+		email.AddHeader(name, value)
+	}
 	if _, err := email.HTML().Write(d.Body); err != nil {
 		return err
 	}
+	if len(d.PlainBody) > 0 {
+		if _, err := email.Plain().Write(d.PlainBody); err != nil {
+			return err
+		}
+	}
 	return email.Send()
 }
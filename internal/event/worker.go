@@ -79,6 +79,9 @@ func (w *Worker) processEvent(ctx context.Context) error {
 			return err
 		}
 		for _, u := range users {
+			if !u.NotificationsPreferences.EmailEnabled(e.EventKind) {
+				continue
+			}
 			n := &hub.Notification{
 				Event: e,
 				User:  u,
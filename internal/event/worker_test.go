@@ -117,6 +117,27 @@ func TestWorker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("email notification not added when disabled in user preferences", func(t *testing.T) {
+		t.Parallel()
+		u3 := &hub.User{
+			UserID: "user3ID",
+			NotificationsPreferences: &hub.NotificationsPreferences{
+				EmailDisabledEventKinds: []hub.EventKind{hub.NewRelease},
+			},
+		}
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.em.On("GetPending", sw.ctx, sw.tx).Return(e, nil)
+		sw.sm.On("GetSubscriptors", sw.ctx, e).Return([]*hub.User{u1, u3}, nil)
+		sw.nm.On("Add", sw.ctx, sw.tx, &hub.Notification{Event: e, User: u1}).Return(nil)
+		sw.wm.On("GetSubscribedTo", sw.ctx, e).Return([]*hub.Webhook{}, nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("error adding webhook notification", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
@@ -0,0 +1,92 @@
+package abuse
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling abuse
+// reports operations.
+type Handlers struct {
+	abuseReportManager hub.AbuseReportManager
+	logger             zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(abuseReportManager hub.AbuseReportManager) *Handlers {
+	return &Handlers{
+		abuseReportManager: abuseReportManager,
+		logger:             log.With().Str("handlers", "abuse").Logger(),
+	}
+}
+
+// Add is an http handler that registers the provided abuse report.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	report := &hub.AbuseReport{}
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.abuseReportManager.Add(r.Context(), report); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// GetQueue is an http handler that returns the abuse reports moderation
+// queue.
+func (h *Handlers) GetQueue(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetAbuseReportsInput{
+		Status: r.FormValue("status"),
+	}
+	var err error
+	if v := r.FormValue("limit"); v != "" {
+		input.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+			return
+		}
+	}
+	if v := r.FormValue("offset"); v != "" {
+		input.Offset, err = strconv.Atoi(v)
+		if err != nil {
+			helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+			return
+		}
+	}
+	dataJSON, err := h.abuseReportManager.GetQueueJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetQueue").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Triage is an http handler that updates the status of the provided abuse
+// report.
+func (h *Handlers) Triage(w http.ResponseWriter, r *http.Request) {
+	t := &hub.AbuseReportTriage{}
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.logger.Error().Err(err).Str("method", "Triage").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	abuseReportID := chi.URLParam(r, "abuseReportID")
+	if err := h.abuseReportManager.Triage(r.Context(), abuseReportID, t); err != nil {
+		h.logger.Error().Err(err).Str("method", "Triage").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,279 @@
+package abuse
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/abuse"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestAdd(t *testing.T) {
+	reportJSON := `{"package_id": "pkg1", "reason": "spam"}`
+	report := &hub.AbuseReport{
+		PackageID: "pkg1",
+		Reason:    "spam",
+	}
+
+	t.Run("invalid abuse report provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid json"))
+
+		hw := newHandlersWrapper()
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error adding abuse report", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(reportJSON))
+
+				hw := newHandlersWrapper()
+				hw.am.On("Add", r.Context(), report).Return(tc.err)
+				hw.h.Add(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("abuse report added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(reportJSON))
+
+		hw := newHandlersWrapper()
+		hw.am.On("Add", r.Context(), report).Return(nil)
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestGetQueue(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []string{
+			"limit=a",
+			"offset=a",
+		}
+		for _, qs := range testCases {
+			qs := qs
+			t.Run(qs, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+qs, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.GetQueue(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error getting abuse reports", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+
+				hw := newHandlersWrapper()
+				hw.am.On("GetQueueJSON", r.Context(), &hub.GetAbuseReportsInput{}).Return(nil, tc.err)
+				hw.h.GetQueue(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get abuse reports succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?status=pending&limit=10&offset=20", nil)
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetQueueJSON", r.Context(), &hub.GetAbuseReportsInput{
+			Status: "pending",
+			Limit:  10,
+			Offset: 20,
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.GetQueue(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestTriage(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"abuseReportID"},
+			Values: []string{"report1"},
+		},
+	}
+	triageJSON := `{"status": "dismissed", "notes": "Not spam"}`
+	tr := &hub.AbuseReportTriage{
+		Status: "dismissed",
+		Notes:  "Not spam",
+	}
+
+	t.Run("invalid triage provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Triage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error triaging abuse report", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(triageJSON))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("Triage", r.Context(), "report1", tr).Return(tc.err)
+				hw.h.Triage(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("abuse report triaged successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(triageJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("Triage", r.Context(), "report1", tr).Return(nil)
+		hw.h.Triage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	am *abuse.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	am := &abuse.ManagerMock{}
+
+	return &handlersWrapper{
+		am: am,
+		h:  NewHandlers(am),
+	}
+}
@@ -0,0 +1,173 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// defaultLimit represents the number of users returned when no limit is
+	// provided.
+	defaultLimit = 20
+)
+
+// Handlers represents a group of http handlers in charge of handling site
+// administration operations.
+type Handlers struct {
+	adminManager hub.AdminManager
+	cfg          *viper.Viper
+	logger       zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(adminManager hub.AdminManager, cfg *viper.Viper) *Handlers {
+	return &Handlers{
+		adminManager: adminManager,
+		cfg:          cfg,
+		logger:       log.With().Str("handlers", "admin").Logger(),
+	}
+}
+
+// DisableUser is an http handler that disables the provided user.
+func (h *Handlers) DisableUser(w http.ResponseWriter, r *http.Request) {
+	h.updateUserDisabled(w, r, true, "DisableUser")
+}
+
+// EnableUser is an http handler that enables the provided user.
+func (h *Handlers) EnableUser(w http.ResponseWriter, r *http.Request) {
+	h.updateUserDisabled(w, r, false, "EnableUser")
+}
+
+// ForcePasswordReset is an http handler that invalidates the password and
+// sessions of the provided user, sending a password reset code to it.
+func (h *Handlers) ForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	err := h.adminManager.ForcePasswordReset(r.Context(), userID, h.cfg.GetString("server.baseURL"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ForcePasswordReset").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUserResources is an http handler that returns the resources owned by the
+// provided user.
+func (h *Handlers) GetUserResources(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	dataJSON, err := h.adminManager.GetUserResourcesJSON(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUserResources").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetVerifiedPublisherChanges is an http handler that returns the verified
+// publisher status of the provided repository as well as the changes
+// registered.
+func (h *Handlers) GetVerifiedPublisherChanges(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.adminManager.GetVerifiedPublisherChangesJSON(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetVerifiedPublisherChanges").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// RevokeCredentials is an http handler that deletes all the sessions and API
+// keys of the provided user.
+func (h *Handlers) RevokeCredentials(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	if err := h.adminManager.RevokeCredentials(r.Context(), userID); err != nil {
+		h.logger.Error().Err(err).Str("method", "RevokeCredentials").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SearchUsers is an http handler that returns the users matching the criteria
+// provided.
+func (h *Handlers) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	input, err := buildSearchUsersInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "SearchUsers").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, err := h.adminManager.SearchUsersJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "SearchUsers").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// UpdateVerifiedPublisher is an http handler that sets the verified publisher
+// status of the provided repository.
+func (h *Handlers) UpdateVerifiedPublisher(w http.ResponseWriter, r *http.Request) {
+	input := &hub.VerifiedPublisherInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateVerifiedPublisher").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.adminManager.UpdateVerifiedPublisher(r.Context(), repoName, input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateVerifiedPublisher").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateUserDisabled is a helper used to disable or enable the provided user.
+func (h *Handlers) updateUserDisabled(w http.ResponseWriter, r *http.Request, disabled bool, method string) {
+	userID := chi.URLParam(r, "userID")
+	if err := h.adminManager.UpdateUserDisabled(r.Context(), userID, disabled); err != nil {
+		h.logger.Error().Err(err).Str("method", method).Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// buildSearchUsersInput builds the input used to search users from a map of
+// query string values, validating them as they are extracted.
+func buildSearchUsersInput(qs url.Values) (*hub.SearchUsersInput, error) {
+	input := &hub.SearchUsersInput{
+		Query: qs.Get("query"),
+		Limit: defaultLimit,
+	}
+	if qs.Get("limit") != "" {
+		limit, err := strconv.Atoi(qs.Get("limit"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", qs.Get("limit"))
+		}
+		input.Limit = limit
+	}
+	if qs.Get("offset") != "" {
+		offset, err := strconv.Atoi(qs.Get("offset"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset: %s", qs.Get("offset"))
+		}
+		input.Offset = offset
+	}
+	return input, nil
+}
@@ -0,0 +1,439 @@
+package admin
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/admin"
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+var rctx = &chi.Context{
+	URLParams: chi.RouteParams{
+		Keys:   []string{"userID"},
+		Values: []string{"userID"},
+	},
+}
+
+var errorsTestCases = []struct {
+	err                error
+	expectedStatusCode int
+}{
+	{
+		hub.ErrInvalidInput,
+		http.StatusBadRequest,
+	},
+	{
+		hub.ErrInsufficientPrivilege,
+		http.StatusForbidden,
+	},
+	{
+		hub.ErrNotFound,
+		http.StatusNotFound,
+	},
+	{
+		tests.ErrFakeDB,
+		http.StatusInternalServerError,
+	},
+}
+
+func TestDisableUser(t *testing.T) {
+	t.Run("error disabling user", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("UpdateUserDisabled", r.Context(), "userID", true).Return(tc.err)
+				hw.h.DisableUser(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user disabled successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("UpdateUserDisabled", r.Context(), "userID", true).Return(nil)
+		hw.h.DisableUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestEnableUser(t *testing.T) {
+	t.Run("error enabling user", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("UpdateUserDisabled", r.Context(), "userID", false).Return(tests.ErrFakeDB)
+		hw.h.EnableUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("user enabled successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("UpdateUserDisabled", r.Context(), "userID", false).Return(nil)
+		hw.h.EnableUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestForcePasswordReset(t *testing.T) {
+	t.Run("error forcing password reset", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("ForcePasswordReset", r.Context(), "userID", "baseURL").Return(tc.err)
+				hw.h.ForcePasswordReset(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("password reset forced successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("ForcePasswordReset", r.Context(), "userID", "baseURL").Return(nil)
+		hw.h.ForcePasswordReset(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestGetUserResources(t *testing.T) {
+	t.Run("error getting user resources", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("GetUserResourcesJSON", r.Context(), "userID").Return(nil, tc.err)
+				hw.h.GetUserResources(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user resources returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetUserResourcesJSON", r.Context(), "userID").Return([]byte("dataJSON"), nil)
+		hw.h.GetUserResources(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestGetVerifiedPublisherChanges(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("error getting verified publisher changes", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("GetVerifiedPublisherChangesJSON", r.Context(), "repo1").Return(nil, tc.err)
+				hw.h.GetVerifiedPublisherChanges(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("verified publisher changes returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("GetVerifiedPublisherChangesJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetVerifiedPublisherChanges(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestRevokeCredentials(t *testing.T) {
+	t.Run("error revoking credentials", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("RevokeCredentials", r.Context(), "userID").Return(tc.err)
+				hw.h.RevokeCredentials(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("credentials revoked successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("RevokeCredentials", r.Context(), "userID").Return(nil)
+		hw.h.RevokeCredentials(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestSearchUsers(t *testing.T) {
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=a", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.SearchUsers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error searching users", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+
+				hw := newHandlersWrapper()
+				input := &hub.SearchUsersInput{Limit: defaultLimit}
+				hw.am.On("SearchUsersJSON", r.Context(), input).Return(nil, tc.err)
+				hw.h.SearchUsers(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("users returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?query=user1&limit=10&offset=20", nil)
+
+		hw := newHandlersWrapper()
+		input := &hub.SearchUsersInput{Query: "user1", Limit: 10, Offset: 20}
+		hw.am.On("SearchUsersJSON", r.Context(), input).Return([]byte("dataJSON"), nil)
+		hw.h.SearchUsers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+func TestUpdateVerifiedPublisher(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	inputJSON := `{"verified": true, "evidence": "evidence"}`
+	input := &hub.VerifiedPublisherInput{Verified: true, Evidence: "evidence"}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdateVerifiedPublisher(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+
+	t.Run("error updating verified publisher", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(inputJSON))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.am.On("UpdateVerifiedPublisher", r.Context(), "repo1", input).Return(tc.err)
+				hw.h.UpdateVerifiedPublisher(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.am.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("verified publisher updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(inputJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.am.On("UpdateVerifiedPublisher", r.Context(), "repo1", input).Return(nil)
+		hw.h.UpdateVerifiedPublisher(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.am.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	cfg *viper.Viper
+	am  *admin.ManagerMock
+	h   *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "baseURL")
+	am := &admin.ManagerMock{}
+
+	return &handlersWrapper{
+		cfg: cfg,
+		am:  am,
+		h:   NewHandlers(am, cfg),
+	}
+}
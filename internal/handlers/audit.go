@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"reflect"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// auditMaxPayloadSize represents the maximum size of the requests payloads
+	// that will be registered in the audit log.
+	auditMaxPayloadSize = 64 * 1024
+)
+
+// auditSensitiveFields represents the fields of the requests payloads that
+// must never be registered in the audit log.
+var auditSensitiveFields = []string{
+	"code",
+	"new_password",
+	"old_password",
+	"password",
+	"secret",
+}
+
+// auditor provides http middlewares that register in the audit log the
+// actions performed by the requests they process.
+type auditor struct {
+	am     hub.AuditLogManager
+	logger zerolog.Logger
+}
+
+// newAuditor creates a new auditor instance.
+func newAuditor(am hub.AuditLogManager) *auditor {
+	return &auditor{
+		am:     am,
+		logger: log.With().Str("handlers", "audit").Logger(),
+	}
+}
+
+// record returns an http middleware that registers the action provided in the
+// audit log when the request is processed successfully.
+func (a *auditor) record(action string) func(next http.Handler) http.Handler {
+	return a.recordWithDiff(action, nil)
+}
+
+// recordWithDiff works like record, but it also registers the differences
+// between the resource before processing the request, as returned by the
+// function provided, and the request payload.
+func (a *auditor) recordWithDiff(
+	action string,
+	getPrevious func(r *http.Request) ([]byte, error),
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Read request payload, making sure it can still be read by the
+			// next handler
+			var payload map[string]interface{}
+			if r.Body != nil {
+				data, err := ioutil.ReadAll(io.LimitReader(r.Body, auditMaxPayloadSize))
+				if err == nil {
+					_ = json.Unmarshal(data, &payload)
+				}
+				r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(data), r.Body))
+			}
+
+			// Get resource before processing the request when needed
+			var previous map[string]interface{}
+			if getPrevious != nil {
+				if data, err := getPrevious(r); err == nil {
+					_ = json.Unmarshal(data, &previous)
+				}
+			}
+
+			// Process request, letting handlers access the entry being built
+			ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+			e := &hub.AuditLogEntry{
+				Action:           action,
+				OrganizationName: chi.URLParam(r, "orgName"),
+				IP:               ip,
+			}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), hub.AuditLogEntryKey, e)))
+
+			// Register entry when the request succeeded and the actor is known
+			if ww.Status() >= http.StatusBadRequest {
+				return
+			}
+			if e.UserID == "" {
+				e.UserID, _ = r.Context().Value(hub.UserIDKey).(string)
+			}
+			if e.UserID == "" {
+				return
+			}
+			e.Details = buildAuditDetails(r, payload, previous)
+			if err := a.am.Register(r.Context(), e); err != nil {
+				a.logger.Error().Err(err).Str("action", action).Msg("error registering audit log entry")
+			}
+		})
+	}
+}
+
+// buildAuditDetails builds the details of an audit log entry from the request
+// url params, its payload and the resource before processing it. Sensitive
+// fields are removed from the payload before registering it.
+func buildAuditDetails(r *http.Request, payload, previous map[string]interface{}) map[string]interface{} {
+	details := make(map[string]interface{})
+
+	// Url params
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		params := make(map[string]string)
+		for i, key := range rctx.URLParams.Keys {
+			if key != "" && key != "*" {
+				params[key] = rctx.URLParams.Values[i]
+			}
+		}
+		if len(params) > 0 {
+			details["params"] = params
+		}
+	}
+
+	// Payload and differences with the previous version of the resource
+	for _, field := range auditSensitiveFields {
+		delete(payload, field)
+		delete(previous, field)
+	}
+	if len(payload) > 0 {
+		details["payload"] = payload
+		if previous != nil {
+			diff := make(map[string]interface{})
+			for field, newValue := range payload {
+				oldValue := previous[field]
+				if !reflect.DeepEqual(oldValue, newValue) {
+					diff[field] = map[string]interface{}{
+						"old": oldValue,
+						"new": newValue,
+					}
+				}
+			}
+			details["diff"] = diff
+		}
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	return details
+}
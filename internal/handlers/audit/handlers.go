@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// defaultLimit represents the number of audit log entries returned when
+	// no limit is provided.
+	defaultLimit = 20
+)
+
+// Handlers represents a group of http handlers in charge of handling audit
+// log operations.
+type Handlers struct {
+	auditLogManager hub.AuditLogManager
+	logger          zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(auditLogManager hub.AuditLogManager) *Handlers {
+	return &Handlers{
+		auditLogManager: auditLogManager,
+		logger:          log.With().Str("handlers", "audit").Logger(),
+	}
+}
+
+// GetByOrg is an http handler that returns the audit log entries of the
+// actions performed on the provided organization.
+func (h *Handlers) GetByOrg(w http.ResponseWriter, r *http.Request) {
+	input, err := buildGetInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetByOrg").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.auditLogManager.GetByOrgJSON(r.Context(), orgName, input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetByUser is an http handler that returns the audit log entries of the
+// actions performed by the user doing the request.
+func (h *Handlers) GetByUser(w http.ResponseWriter, r *http.Request) {
+	input, err := buildGetInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetByUser").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, err := h.auditLogManager.GetByUserJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetByUser").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// buildGetInput builds the input used to get some audit log entries from a
+// map of query string values, validating them as they are extracted.
+func buildGetInput(qs url.Values) (*hub.GetAuditLogInput, error) {
+	input := &hub.GetAuditLogInput{
+		Limit: defaultLimit,
+	}
+	if qs.Get("limit") != "" {
+		limit, err := strconv.Atoi(qs.Get("limit"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", qs.Get("limit"))
+		}
+		input.Limit = limit
+	}
+	if qs.Get("offset") != "" {
+		offset, err := strconv.Atoi(qs.Get("offset"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset: %s", qs.Get("offset"))
+		}
+		input.Offset = offset
+	}
+	input.Actions = qs["action"]
+	input.UserAlias = qs.Get("user")
+	if qs.Get("from") != "" {
+		from, err := strconv.ParseInt(qs.Get("from"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from: %s", qs.Get("from"))
+		}
+		input.From = from
+	}
+	if qs.Get("to") != "" {
+		to, err := strconv.ParseInt(qs.Get("to"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to: %s", qs.Get("to"))
+		}
+		input.To = to
+	}
+	return input, nil
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/audit"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditor(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(body))
+		r.RemoteAddr = "192.168.1.1:12345"
+		rctx := &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"orgName", "userAlias"},
+				Values: []string{"org1", "user2"},
+			},
+		}
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+		return r
+	}
+	withStatus := func(statusCode int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(statusCode)
+		}
+	}
+
+	t.Run("request failed, nothing registered", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r := newRequest("")
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		am := &audit.ManagerMock{}
+		newAuditor(am).record(hub.AuditOrganizationMemberAdd)(withStatus(http.StatusForbidden)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		am.AssertExpectations(t)
+	})
+
+	t.Run("unknown actor, nothing registered", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r := newRequest("")
+
+		am := &audit.ManagerMock{}
+		newAuditor(am).record(hub.AuditUserLogin)(withStatus(http.StatusNoContent)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		am.AssertExpectations(t)
+	})
+
+	t.Run("request succeeded, entry registered without sensitive fields", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r := newRequest(`{"name": "name1", "password": "pass", "secret": "secret"}`)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		am := &audit.ManagerMock{}
+		am.On("Register", mock.Anything, &hub.AuditLogEntry{
+			Action:           hub.AuditOrganizationMemberAdd,
+			UserID:           "userID",
+			OrganizationName: "org1",
+			IP:               "192.168.1.1",
+			Details: map[string]interface{}{
+				"params": map[string]string{
+					"orgName":   "org1",
+					"userAlias": "user2",
+				},
+				"payload": map[string]interface{}{
+					"name": "name1",
+				},
+			},
+		}).Return(nil)
+		newAuditor(am).record(hub.AuditOrganizationMemberAdd)(withStatus(http.StatusCreated)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		am.AssertExpectations(t)
+	})
+
+	t.Run("actor provided by the handler", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r := newRequest("")
+
+		am := &audit.ManagerMock{}
+		am.On("Register", mock.Anything, mock.MatchedBy(func(e *hub.AuditLogEntry) bool {
+			return e.Action == hub.AuditUserLogin && e.UserID == "userID"
+		})).Return(tests.ErrFakeDB)
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			e := r.Context().Value(hub.AuditLogEntryKey).(*hub.AuditLogEntry)
+			e.UserID = "userID"
+			w.WriteHeader(http.StatusNoContent)
+		})
+		newAuditor(am).record(hub.AuditUserLogin)(next).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		am.AssertExpectations(t)
+	})
+
+	t.Run("entry registered with diff", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r := newRequest(`{"authorization_enabled": true, "predefined_policy": "rbac.v1"}`)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		am := &audit.ManagerMock{}
+		am.On("Register", mock.Anything, mock.MatchedBy(func(e *hub.AuditLogEntry) bool {
+			return assert.Equal(t, map[string]interface{}{
+				"authorization_enabled": map[string]interface{}{
+					"old": false,
+					"new": true,
+				},
+			}, e.Details["diff"])
+		})).Return(nil)
+		getPrevious := func(r *http.Request) ([]byte, error) {
+			return []byte(`{"authorization_enabled": false, "predefined_policy": "rbac.v1"}`), nil
+		}
+		newAuditor(am).recordWithDiff(hub.AuditAuthorizationPolicyUpdate, getPrevious)(withStatus(http.StatusNoContent)).
+			ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		am.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,129 @@
+package comment
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling packages
+// comments (questions and answers) operations.
+type Handlers struct {
+	commentManager hub.CommentManager
+	logger         zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(commentManager hub.CommentManager) *Handlers {
+	return &Handlers{
+		commentManager: commentManager,
+		logger:         log.With().Str("handlers", "comment").Logger(),
+	}
+}
+
+// Add is an http handler that adds the provided comment to the database.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	c := &hub.Comment{}
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	c.PackageID = chi.URLParam(r, "packageID")
+	dataJSON, err := h.commentManager.Add(r.Context(), c)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// Delete is an http handler that deletes the provided comment from the
+// database.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	commentID := chi.URLParam(r, "commentID")
+	if err := h.commentManager.Delete(r.Context(), packageID, commentID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Get is an http handler that returns the questions posted on the provided
+// package, including their answers.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetCommentsInput{
+		PackageID: chi.URLParam(r, "packageID"),
+	}
+	var err error
+	if v := r.FormValue("limit"); v != "" {
+		input.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+			return
+		}
+	}
+	if v := r.FormValue("offset"); v != "" {
+		input.Offset, err = strconv.Atoi(v)
+		if err != nil {
+			helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+			return
+		}
+	}
+	dataJSON, err := h.commentManager.GetJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Hide is an http handler that hides the provided comment.
+func (h *Handlers) Hide(w http.ResponseWriter, r *http.Request) {
+	h.setHidden(w, r, true)
+}
+
+// Unhide is an http handler that makes visible again the provided comment.
+func (h *Handlers) Unhide(w http.ResponseWriter, r *http.Request) {
+	h.setHidden(w, r, false)
+}
+
+// setHidden updates the visibility of the provided comment.
+func (h *Handlers) setHidden(w http.ResponseWriter, r *http.Request, hidden bool) {
+	packageID := chi.URLParam(r, "packageID")
+	commentID := chi.URLParam(r, "commentID")
+	if err := h.commentManager.SetHidden(r.Context(), packageID, commentID, hidden); err != nil {
+		h.logger.Error().Err(err).Str("method", "SetHidden").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Update is an http handler that updates the body of the provided comment.
+func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
+	c := &hub.Comment{}
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	c.PackageID = chi.URLParam(r, "packageID")
+	c.CommentID = chi.URLParam(r, "commentID")
+	if err := h.commentManager.Update(r.Context(), c); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,20 +3,34 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"path"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/andybalholm/brotli"
+	"github.com/artifacthub/hub/internal/handlers/abuse"
+	"github.com/artifacthub/hub/internal/handlers/admin"
 	"github.com/artifacthub/hub/internal/handlers/apikey"
+	"github.com/artifacthub/hub/internal/handlers/audit"
+	"github.com/artifacthub/hub/internal/handlers/comment"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/handlers/org"
 	"github.com/artifacthub/hub/internal/handlers/pkg"
+	"github.com/artifacthub/hub/internal/handlers/pulls"
 	"github.com/artifacthub/hub/internal/handlers/repo"
+	"github.com/artifacthub/hub/internal/handlers/review"
+	"github.com/artifacthub/hub/internal/handlers/savedsearch"
+	"github.com/artifacthub/hub/internal/handlers/scim"
+	"github.com/artifacthub/hub/internal/handlers/serviceaccount"
+	"github.com/artifacthub/hub/internal/handlers/sitemap"
 	"github.com/artifacthub/hub/internal/handlers/static"
 	"github.com/artifacthub/hub/internal/handlers/stats"
 	"github.com/artifacthub/hub/internal/handlers/subscription"
+	"github.com/artifacthub/hub/internal/handlers/team"
 	"github.com/artifacthub/hub/internal/handlers/user"
 	"github.com/artifacthub/hub/internal/handlers/webhook"
 	"github.com/artifacthub/hub/internal/hub"
@@ -32,22 +46,61 @@ import (
 	"github.com/unrolled/secure"
 )
 
-const csrfHeader = "X-CSRF-Token"
+const (
+	csrfHeader = "X-CSRF-Token"
+
+	// compressionLevel represents the level used to compress responses.
+	compressionLevel = 5
+)
+
+// compressibleContentTypes represents the content types of the responses that
+// will be compressed when the client supports it.
+var compressibleContentTypes = []string{
+	"application/javascript",
+	"application/json",
+	"application/rss+xml",
+	"application/xml",
+	"image/svg+xml",
+	"text/css",
+	"text/html",
+	"text/javascript",
+	"text/plain",
+	"text/xml",
+}
 
 var xForwardedFor = http.CanonicalHeaderKey("X-Forwarded-For")
 
+// trackingWebhookPathRE is a regexp used to match the path of the
+// repositories tracking webhook endpoint.
+var trackingWebhookPathRE = regexp.MustCompile(`^/api/v1/repositories/[^/]+/tracking-webhook$`)
+
 // Services is a wrapper around several internal services used by the handlers.
 type Services struct {
-	OrganizationManager hub.OrganizationManager
-	UserManager         hub.UserManager
-	RepositoryManager   hub.RepositoryManager
-	PackageManager      hub.PackageManager
-	SubscriptionManager hub.SubscriptionManager
-	WebhookManager      hub.WebhookManager
-	APIKeyManager       hub.APIKeyManager
-	StatsManager        hub.StatsManager
-	ImageStore          img.Store
-	Authorizer          hub.Authorizer
+	OrganizationManager   hub.OrganizationManager
+	UserManager           hub.UserManager
+	RepositoryManager     hub.RepositoryManager
+	PackageManager        hub.PackageManager
+	SubscriptionManager   hub.SubscriptionManager
+	WebhookManager        hub.WebhookManager
+	APIKeyManager         hub.APIKeyManager
+	ServiceAccountManager hub.ServiceAccountManager
+	TeamManager           hub.TeamManager
+	AdminManager          hub.AdminManager
+	APIKeyUsageTracker    hub.APIKeyUsageTracker
+	AuditLogManager       hub.AuditLogManager
+	SCIMManager           hub.SCIMManager
+	StatsManager          hub.StatsManager
+	SitemapManager        hub.SitemapManager
+	SavedSearchManager    hub.SavedSearchManager
+	PullsManager          hub.PullsManager
+	ReviewManager         hub.ReviewManager
+	CommentManager        hub.CommentManager
+	AbuseReportManager    hub.AbuseReportManager
+	ImageStore            img.Store
+	ChartMirror           hub.ChartMirror
+	WebhookHTTPClient     hub.HTTPClient
+	Authorizer            hub.Authorizer
+	CaptchaVerifier       hub.CaptchaVerifier
 }
 
 // Metrics groups some metrics collected from a Handlers instance.
@@ -64,20 +117,31 @@ type Handlers struct {
 	logger  zerolog.Logger
 	Router  http.Handler
 
-	Organizations *org.Handlers
-	Users         *user.Handlers
-	Packages      *pkg.Handlers
-	Repositories  *repo.Handlers
-	Subscriptions *subscription.Handlers
-	Webhooks      *webhook.Handlers
-	APIKeys       *apikey.Handlers
-	Static        *static.Handlers
-	Stats         *stats.Handlers
+	Organizations   *org.Handlers
+	Users           *user.Handlers
+	Packages        *pkg.Handlers
+	Repositories    *repo.Handlers
+	Subscriptions   *subscription.Handlers
+	Webhooks        *webhook.Handlers
+	APIKeys         *apikey.Handlers
+	ServiceAccounts *serviceaccount.Handlers
+	Teams           *team.Handlers
+	Admin           *admin.Handlers
+	AuditLog        *audit.Handlers
+	SCIM            *scim.Handlers
+	Static          *static.Handlers
+	Stats           *stats.Handlers
+	Sitemap         *sitemap.Handlers
+	SavedSearches   *savedsearch.Handlers
+	Pulls           *pulls.Handlers
+	Reviews         *review.Handlers
+	Comments        *comment.Handlers
+	AbuseReports    *abuse.Handlers
 }
 
 // Setup creates a new Handlers instance.
 func Setup(ctx context.Context, cfg *viper.Viper, svc *Services) (*Handlers, error) {
-	userHandlers, err := user.NewHandlers(ctx, svc.UserManager, cfg)
+	userHandlers, err := user.NewHandlers(ctx, svc.UserManager, svc.APIKeyUsageTracker, svc.CaptchaVerifier, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -87,15 +151,26 @@ func Setup(ctx context.Context, cfg *viper.Viper, svc *Services) (*Handlers, err
 		metrics: setupMetrics(),
 		logger:  log.With().Str("handlers", "root").Logger(),
 
-		Organizations: org.NewHandlers(svc.OrganizationManager, svc.Authorizer, cfg),
-		Users:         userHandlers,
-		Repositories:  repo.NewHandlers(svc.RepositoryManager),
-		Packages:      pkg.NewHandlers(svc.PackageManager, svc.RepositoryManager, cfg, &http.Client{}),
-		Subscriptions: subscription.NewHandlers(svc.SubscriptionManager),
-		Webhooks:      webhook.NewHandlers(svc.WebhookManager),
-		APIKeys:       apikey.NewHandlers(svc.APIKeyManager),
-		Static:        static.NewHandlers(cfg, svc.ImageStore),
-		Stats:         stats.NewHandlers(svc.StatsManager),
+		Organizations:   org.NewHandlers(svc.OrganizationManager, svc.Authorizer, cfg),
+		Users:           userHandlers,
+		Repositories:    repo.NewHandlers(svc.RepositoryManager),
+		Packages:        pkg.NewHandlers(svc.PackageManager, svc.RepositoryManager, svc.ChartMirror, cfg, &http.Client{}),
+		Subscriptions:   subscription.NewHandlers(svc.SubscriptionManager, cfg),
+		Webhooks:        webhook.NewHandlers(svc.WebhookManager, svc.PackageManager, cfg, svc.WebhookHTTPClient),
+		APIKeys:         apikey.NewHandlers(svc.APIKeyManager),
+		ServiceAccounts: serviceaccount.NewHandlers(svc.ServiceAccountManager),
+		Teams:           team.NewHandlers(svc.TeamManager),
+		Admin:           admin.NewHandlers(svc.AdminManager, cfg),
+		AuditLog:        audit.NewHandlers(svc.AuditLogManager),
+		SCIM:            scim.NewHandlers(svc.SCIMManager, cfg),
+		Static:          static.NewHandlers(cfg, svc.ImageStore),
+		Stats:           stats.NewHandlers(svc.StatsManager),
+		Sitemap:         sitemap.NewHandlers(cfg, svc.SitemapManager),
+		SavedSearches:   savedsearch.NewHandlers(svc.SavedSearchManager),
+		Pulls:           pulls.NewHandlers(svc.PullsManager),
+		Reviews:         review.NewHandlers(svc.ReviewManager),
+		Comments:        comment.NewHandlers(svc.CommentManager),
+		AbuseReports:    abuse.NewHandlers(svc.AbuseReportManager),
 	}
 	h.setupRouter()
 	return h, nil
@@ -132,6 +207,7 @@ func (h *Handlers) setupRouter() {
 	r.Use(realIP(h.cfg.GetInt("server.xffIndex")))
 	r.Use(logger)
 	r.Use(h.MetricsCollector)
+	r.Use(compressor(compressionLevel))
 	r.Use(secure.New(secure.Options{
 		SSLProxyHeaders:      map[string]string{"X-Forwarded-Proto": "https"},
 		STSSeconds:           31536000,
@@ -151,6 +227,14 @@ func (h *Handlers) setupRouter() {
 		r.Use(h.Users.BasicAuth)
 	}
 	r.NotFound(h.Static.ServeIndex)
+	abuseReportsRL := rateLimitMiddleware(h.cfg, "abuseReports")
+	authRL := rateLimitMiddleware(h.cfg, "auth")
+	commentsRL := rateLimitMiddleware(h.cfg, "comments")
+	dryRunRL := rateLimitMiddleware(h.cfg, "dryRun")
+	imagesRL := rateLimitMiddleware(h.cfg, "images")
+	rescanRL := rateLimitMiddleware(h.cfg, "rescan")
+	searchRL := rateLimitMiddleware(h.cfg, "search")
+	auditLog := newAuditor(h.svc.AuditLogManager)
 
 	// API
 	r.Route("/api/v1", func(r chi.Router) {
@@ -169,18 +253,33 @@ func (h *Handlers) setupRouter() {
 
 		// Users
 		r.Route("/users", func(r chi.Router) {
-			r.Post("/", h.Users.RegisterUser)
-			r.Post("/login", h.Users.Login)
-			r.Post("/password-reset-code", h.Users.RegisterPasswordResetCode)
-			r.Put("/reset-password", h.Users.ResetPassword)
-			r.Post("/verify-email", h.Users.VerifyEmail)
-			r.Post("/verify-password-reset-code", h.Users.VerifyPasswordResetCode)
+			r.Group(func(r chi.Router) {
+				r.Use(authRL)
+				r.Post("/revoke-session", h.Users.RevokeSession)
+				r.Post("/verify-email", h.Users.VerifyEmail)
+				r.Group(func(r chi.Router) {
+					r.Use(h.Users.RequirePasswordAuth)
+					r.With(h.Users.RequireCaptcha).Post("/", h.Users.RegisterUser)
+					r.With(auditLog.record(hub.AuditUserLogin)).Post("/login", h.Users.Login)
+					r.With(h.Users.RequireCaptcha).Post("/password-reset-code", h.Users.RegisterPasswordResetCode)
+					r.Put("/reset-password", h.Users.ResetPassword)
+					r.Post("/verify-password-reset-code", h.Users.VerifyPasswordResetCode)
+				})
+			})
 			r.Group(func(r chi.Router) {
 				r.Use(h.Users.RequireLogin)
+				r.Delete("/", h.Users.DeleteUser)
+				r.Post("/delete-user-code", h.Users.RegisterDeleteUserCode)
+				r.With(authRL).Post("/email-change-code", h.Users.RegisterEmailChangeCode)
+				r.With(authRL, auditLog.record(hub.AuditUserEmailUpdate)).Put("/email", h.Users.ChangeEmail)
 				r.Get("/logout", h.Users.Logout)
+				if h.cfg.GetString("server.jwt.signingKey") != "" {
+					r.Post("/token", h.Users.IssueToken)
+				}
 				r.Get("/profile", h.Users.GetProfile)
 				r.Put("/profile", h.Users.UpdateProfile)
-				r.Put("/password", h.Users.UpdatePassword)
+				r.With(h.Users.RequirePasswordAuth, auditLog.record(hub.AuditUserPasswordUpdate)).
+					Put("/password", h.Users.UpdatePassword)
 			})
 		})
 
@@ -195,18 +294,57 @@ func (h *Handlers) setupRouter() {
 				r.Get("/", h.Organizations.Get)
 				r.Group(func(r chi.Router) {
 					r.Use(h.Users.RequireLogin)
-					r.Delete("/", h.Organizations.Delete)
+					r.With(auditLog.record(hub.AuditOrganizationDeletionSchedule)).Delete("/", h.Organizations.Delete)
+					r.With(auditLog.record(hub.AuditOrganizationDeletionCancel)).
+						Put("/cancel-deletion", h.Organizations.CancelDeletion)
 					r.Put("/", h.Organizations.Update)
+					r.With(auditLog.record(hub.AuditOrganizationRename)).Put("/rename", h.Organizations.Rename)
 					r.Route("/authorization-policy", func(r chi.Router) {
 						r.Get("/", h.Organizations.GetAuthorizationPolicy)
-						r.Put("/", h.Organizations.UpdateAuthorizationPolicy)
+						r.With(auditLog.recordWithDiff(hub.AuditAuthorizationPolicyUpdate, func(r *http.Request) ([]byte, error) {
+							return h.svc.OrganizationManager.GetAuthorizationPolicyJSON(r.Context(), chi.URLParam(r, "orgName"))
+						})).Put("/", h.Organizations.UpdateAuthorizationPolicy)
 					})
-					r.Get("/accept-invitation", h.Organizations.ConfirmMembership)
+					r.With(auditLog.record(hub.AuditOrganizationMembershipConfirm)).
+						Get("/accept-invitation", h.Organizations.ConfirmMembership)
 					r.Get("/members", h.Organizations.GetMembers)
 					r.Route("/member/{userAlias}", func(r chi.Router) {
-						r.Post("/", h.Organizations.AddMember)
-						r.Delete("/", h.Organizations.DeleteMember)
+						r.With(auditLog.record(hub.AuditOrganizationMemberAdd)).Post("/", h.Organizations.AddMember)
+						r.With(auditLog.record(hub.AuditOrganizationMemberDelete)).Delete("/", h.Organizations.DeleteMember)
+						r.With(auditLog.record(hub.AuditOrganizationMemberRoleUpdate)).
+							Put("/role", h.Organizations.UpdateMemberRole)
+						r.With(auditLog.record(hub.AuditOrganizationOwnershipTransfer)).
+							Put("/transfer-ownership", h.Organizations.TransferOwnership)
 					})
+					r.Route("/service-accounts", func(r chi.Router) {
+						r.Get("/", h.ServiceAccounts.GetByOrg)
+						r.With(auditLog.record(hub.AuditOrganizationServiceAccountAdd)).Post("/", h.ServiceAccounts.Add)
+						r.Route("/{serviceAccountID}", func(r chi.Router) {
+							r.With(auditLog.record(hub.AuditOrganizationServiceAccountDelete)).
+								Delete("/", h.ServiceAccounts.Delete)
+							r.With(auditLog.record(hub.AuditOrganizationServiceAccountAPIKeyAdd)).
+								Post("/api-keys", h.ServiceAccounts.AddAPIKey)
+							r.With(auditLog.record(hub.AuditOrganizationServiceAccountAPIKeyDelete)).
+								Delete("/api-keys/{apiKeyID}", h.ServiceAccounts.DeleteAPIKey)
+						})
+					})
+					r.Route("/teams", func(r chi.Router) {
+						r.Get("/", h.Teams.GetByOrg)
+						r.With(auditLog.record(hub.AuditOrganizationTeamAdd)).Post("/", h.Teams.Add)
+						r.Route("/{teamName}", func(r chi.Router) {
+							r.With(auditLog.record(hub.AuditOrganizationTeamUpdate)).Put("/", h.Teams.Update)
+							r.With(auditLog.record(hub.AuditOrganizationTeamDelete)).Delete("/", h.Teams.Delete)
+							r.With(auditLog.record(hub.AuditOrganizationTeamMemberAdd)).
+								Post("/member/{userAlias}", h.Teams.AddMember)
+							r.With(auditLog.record(hub.AuditOrganizationTeamMemberDelete)).
+								Delete("/member/{userAlias}", h.Teams.DeleteMember)
+							r.With(auditLog.record(hub.AuditOrganizationTeamRepositoryPermissionsUpdate)).
+								Put("/repository/{repoName}", h.Teams.UpdateRepositoryPermissions)
+							r.With(auditLog.record(hub.AuditOrganizationTeamRepositoryPermissionsDelete)).
+								Delete("/repository/{repoName}", h.Teams.DeleteRepositoryPermissions)
+						})
+					})
+					r.Get("/audit", h.AuditLog.GetByOrg)
 					r.Get("/user-allowed-actions", h.Organizations.GetUserAllowedActions)
 				})
 			})
@@ -214,28 +352,49 @@ func (h *Handlers) setupRouter() {
 
 		// Repositories
 		r.Route("/repositories", func(r chi.Router) {
-			r.Use(h.Users.RequireLogin)
-			r.Get("/", h.Repositories.GetAll)
-			r.Get("/{kind:^helm$|^falco$|^olm$|^opa|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler$}", h.Repositories.GetByKind)
-			r.Route("/user", func(r chi.Router) {
-				r.Get("/", h.Repositories.GetOwnedByUser)
-				r.Post("/", h.Repositories.Add)
-				r.Route("/{repoName}", func(r chi.Router) {
-					r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
-					r.Put("/transfer", h.Repositories.Transfer)
-					r.Put("/", h.Repositories.Update)
-					r.Delete("/", h.Repositories.Delete)
+			r.Post("/{repoName}/tracking-webhook", h.Repositories.RequestTracking)
+			r.Group(func(r chi.Router) {
+				r.Use(h.Users.RequireLogin)
+				r.Get("/", h.Repositories.GetAll)
+				r.Get("/search", h.Repositories.Search)
+				r.Get("/{kind:^helm$|^falco$|^olm$|^opa|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^wasm|^crossplane-configuration|^oci-artifact$}", h.Repositories.GetByKind)
+				r.With(dryRunRL).Post("/dry-run", h.Repositories.DryRun)
+				r.Route("/user", func(r chi.Router) {
+					r.Get("/", h.Repositories.GetOwnedByUser)
+					r.With(auditLog.record(hub.AuditRepositoryAdd)).Post("/", h.Repositories.Add)
+					r.Route("/{repoName}", func(r chi.Router) {
+						r.Get("/claim-ownership", h.Repositories.GetOwnershipClaim)
+						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.With(auditLog.record(hub.AuditRepositoryTransfer)).Put("/transfer", h.Repositories.Transfer)
+						r.With(auditLog.record(hub.AuditRepositoryCredentialsUpdate)).Put("/credentials", h.Repositories.UpdateCredentials)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingPause)).Put("/pause", h.Repositories.PauseTracking)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingResume)).Put("/resume", h.Repositories.ResumeTracking)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingWebhookEnable)).Put("/tracking-webhook", h.Repositories.UpdateTrackingWebhook)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingWebhookDisable)).Delete("/tracking-webhook", h.Repositories.DeleteTrackingWebhook)
+						r.Post("/pulls", h.Pulls.Register)
+						r.With(auditLog.record(hub.AuditRepositoryUpdate)).Put("/", h.Repositories.Update)
+						r.With(auditLog.record(hub.AuditRepositoryDelete)).Delete("/", h.Repositories.Delete)
+					})
 				})
-			})
-			r.Route("/org/{orgName}", func(r chi.Router) {
-				r.Get("/", h.Repositories.GetOwnedByOrg)
-				r.Post("/", h.Repositories.Add)
-				r.Route("/{repoName}", func(r chi.Router) {
-					r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
-					r.Put("/transfer", h.Repositories.Transfer)
-					r.Put("/", h.Repositories.Update)
-					r.Delete("/", h.Repositories.Delete)
+				r.Route("/org/{orgName}", func(r chi.Router) {
+					r.Get("/", h.Repositories.GetOwnedByOrg)
+					r.With(auditLog.record(hub.AuditRepositoryAdd)).Post("/", h.Repositories.Add)
+					r.Route("/{repoName}", func(r chi.Router) {
+						r.Get("/claim-ownership", h.Repositories.GetOwnershipClaim)
+						r.Put("/claim-ownership", h.Repositories.ClaimOwnership)
+						r.With(auditLog.record(hub.AuditRepositoryTransfer)).Put("/transfer", h.Repositories.Transfer)
+						r.With(auditLog.record(hub.AuditRepositoryCredentialsUpdate)).Put("/credentials", h.Repositories.UpdateCredentials)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingPause)).Put("/pause", h.Repositories.PauseTracking)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingResume)).Put("/resume", h.Repositories.ResumeTracking)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingWebhookEnable)).Put("/tracking-webhook", h.Repositories.UpdateTrackingWebhook)
+						r.With(auditLog.record(hub.AuditRepositoryTrackingWebhookDisable)).Delete("/tracking-webhook", h.Repositories.DeleteTrackingWebhook)
+						r.Post("/pulls", h.Pulls.Register)
+						r.With(auditLog.record(hub.AuditRepositoryUpdate)).Put("/", h.Repositories.Update)
+						r.With(auditLog.record(hub.AuditRepositoryDelete)).Delete("/", h.Repositories.Delete)
+					})
 				})
+				r.Get("/{repoName}/tracking-status", h.Repositories.GetTrackingStatus)
+				r.With(auditLog.record(hub.AuditRepositoryTransfer)).Put("/{repoName}/transfer", h.Repositories.Transfer)
 			})
 		})
 
@@ -243,10 +402,12 @@ func (h *Handlers) setupRouter() {
 		r.Route("/packages", func(r chi.Router) {
 			r.Get("/random", h.Packages.GetRandom)
 			r.Get("/stats", h.Packages.GetStats)
-			r.With(corsMW).Get("/search", h.Packages.Search)
+			r.Get("/trending", h.Pulls.GetTrending)
+			r.With(corsMW, searchRL).Get("/search", h.Packages.Search)
 			r.With(h.Users.RequireLogin).Get("/starred", h.Packages.GetStarredByUser)
-			r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler$}/{repoName}/{packageName}", func(r chi.Router) {
-				r.Get("/feed/rss", h.Packages.RssFeed)
+			r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^wasm|^crossplane-configuration|^oci-artifact$}/{repoName}/{packageName}", func(r chi.Router) {
+				r.Get("/feed/rss", h.Packages.PackageFeed)
+				r.With(corsMW).Get("/card", h.Packages.GetCard)
 				r.With(corsMW).Get("/summary", h.Packages.GetSummary)
 				r.Get("/{version}", h.Packages.Get)
 				r.Get("/", h.Packages.Get)
@@ -255,24 +416,82 @@ func (h *Handlers) setupRouter() {
 				r.With(h.Users.InjectUserID).Get("/", h.Packages.GetStars)
 				r.With(h.Users.RequireLogin).Put("/", h.Packages.ToggleStar)
 			})
+			r.Get("/{packageID}/{version}/sbom", h.Packages.GetSBOM)
 			r.Get("/{packageID}/{version}/security-report", h.Packages.GetSnapshotSecurityReport)
+			r.With(h.Users.RequireLogin, rescanRL).Post(
+				"/{packageID}/{version}/security-report/rescan",
+				h.Packages.RequestSnapshotSecurityReportRescan,
+			)
+			r.With(h.Users.RequireLogin).Put("/{packageID}/{version}/yank", h.Packages.YankVersion)
+			r.With(h.Users.RequireLogin).Delete("/{packageID}/{version}/yank", h.Packages.UnyankVersion)
 			r.Get("/{packageID}/{version}/values-schema", h.Packages.GetValuesSchema)
 			r.Get("/{packageID}/{version}/templates", h.Packages.GetChartTemplates)
+			if h.svc.ChartMirror != nil {
+				r.Get("/{packageID}/{version}/download", h.Packages.DownloadChartArchive)
+			}
 			r.Get("/{packageID}/changelog", h.Packages.GetChangeLog)
+			r.Get("/{packageID}/values-diff", h.Packages.GetValuesDiff)
+			r.Get("/{packageID}/pulls", h.Pulls.GetPackagePulls)
+			r.Route("/{packageID}/reviews", func(r chi.Router) {
+				r.With(h.Users.InjectUserID).Get("/", h.Reviews.Get)
+				r.With(h.Users.RequireLogin).Put("/", h.Reviews.Add)
+				r.With(h.Users.RequireLogin).Delete("/", h.Reviews.Delete)
+				r.With(h.Users.RequireLogin).Put("/{reviewID}/reply", h.Reviews.Reply)
+				r.With(h.Users.RequireLogin).Post("/{reviewID}/report", h.Reviews.Report)
+			})
+			r.Route("/{packageID}/comments", func(r chi.Router) {
+				r.With(h.Users.InjectUserID).Get("/", h.Comments.Get)
+				r.With(h.Users.RequireLogin, commentsRL).Post("/", h.Comments.Add)
+				r.With(h.Users.RequireLogin).Put("/{commentID}", h.Comments.Update)
+				r.With(h.Users.RequireLogin).Delete("/{commentID}", h.Comments.Delete)
+				r.With(h.Users.RequireLogin).Put("/{commentID}/hide", h.Comments.Hide)
+				r.With(h.Users.RequireLogin).Delete("/{commentID}/hide", h.Comments.Unhide)
+			})
+			r.Route("/{packageID}/vulnerabilities-suppressions", func(r chi.Router) {
+				r.Get("/", h.Packages.GetVulnerabilitiesSuppressions)
+				r.With(h.Users.RequireLogin).Post("/", h.Packages.AddVulnerabilitySuppression)
+				r.With(h.Users.RequireLogin).Delete("/{vulnerabilityID}", h.Packages.DeleteVulnerabilitySuppression)
+			})
+		})
+
+		// oEmbed
+		r.With(corsMW).Get("/oembed", h.Packages.OEmbed)
+
+		// Feeds
+		r.Route("/feeds", func(r chi.Router) {
+			r.Get("/packages/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^wasm|^crossplane-configuration|^oci-artifact$}/{repoName}/{packageName}/{format:^rss$|^atom$}", h.Packages.PackageFeed)
+			r.Get("/repositories/{repoName}/{format:^rss$|^atom$}", h.Packages.RepositoryFeed)
+			r.Get("/publishers/{publisher}/{format:^rss$|^atom$}", h.Packages.PublisherFeed)
 		})
 
 		// Subscriptions
 		r.Route("/subscriptions", func(r chi.Router) {
-			r.Use(h.Users.RequireLogin)
-			r.Route("/opt-out", func(r chi.Router) {
-				r.Get("/", h.Subscriptions.GetOptOutList)
-				r.Post("/", h.Subscriptions.AddOptOut)
-				r.Delete("/{optOutID}", h.Subscriptions.DeleteOptOut)
+			r.Get("/unsubscribe", h.Subscriptions.Unsubscribe)
+			r.Post("/unsubscribe", h.Subscriptions.Unsubscribe)
+			r.Group(func(r chi.Router) {
+				r.Use(h.Users.RequireLogin)
+				r.Route("/opt-out", func(r chi.Router) {
+					r.Get("/", h.Subscriptions.GetOptOutList)
+					r.Post("/", h.Subscriptions.AddOptOut)
+					r.Delete("/{optOutID}", h.Subscriptions.DeleteOptOut)
+				})
+				r.Route("/bulk", func(r chi.Router) {
+					r.Post("/", h.Subscriptions.AddBulk)
+					r.Delete("/", h.Subscriptions.DeleteBulk)
+				})
+				r.Route("/scoped", func(r chi.Router) {
+					r.Get("/", h.Subscriptions.GetScopedByUser)
+					r.Post("/", h.Subscriptions.AddScoped)
+					r.Delete("/", h.Subscriptions.DeleteScoped)
+				})
+				r.Get("/export", h.Subscriptions.Export)
+				r.Post("/import", h.Subscriptions.Import)
+				r.Get("/search", h.Subscriptions.Search)
+				r.Get("/{packageID}", h.Subscriptions.GetByPackage)
+				r.Get("/", h.Subscriptions.GetByUser)
+				r.Post("/", h.Subscriptions.Add)
+				r.Delete("/", h.Subscriptions.Delete)
 			})
-			r.Get("/{packageID}", h.Subscriptions.GetByPackage)
-			r.Get("/", h.Subscriptions.GetByUser)
-			r.Post("/", h.Subscriptions.Add)
-			r.Delete("/", h.Subscriptions.Delete)
 		})
 
 		// Webhooks
@@ -280,37 +499,108 @@ func (h *Handlers) setupRouter() {
 			r.Use(h.Users.RequireLogin)
 			r.Route("/user", func(r chi.Router) {
 				r.Get("/", h.Webhooks.GetOwnedByUser)
-				r.Post("/", h.Webhooks.Add)
+				r.With(auditLog.record(hub.AuditWebhookAdd)).Post("/", h.Webhooks.Add)
 				r.Route("/{webhookID}", func(r chi.Router) {
 					r.Get("/", h.Webhooks.Get)
-					r.Put("/", h.Webhooks.Update)
-					r.Delete("/", h.Webhooks.Delete)
+					r.With(auditLog.record(hub.AuditWebhookUpdate)).Put("/", h.Webhooks.Update)
+					r.With(auditLog.record(hub.AuditWebhookDelete)).Delete("/", h.Webhooks.Delete)
+					r.With(auditLog.record(hub.AuditWebhookUpdate)).Put("/enable", h.Webhooks.Enable)
+					r.Route("/deliveries", func(r chi.Router) {
+						r.Get("/", h.Webhooks.GetDeliveries)
+						r.Post("/{deliveryID}/redeliver", h.Webhooks.Redeliver)
+					})
+					r.Route("/failed-deliveries", func(r chi.Router) {
+						r.Get("/", h.Webhooks.GetFailedDeliveries)
+						r.Get("/{notificationID}", h.Webhooks.GetFailedDelivery)
+						r.Put("/{notificationID}/requeue", h.Webhooks.RequeueFailedDelivery)
+					})
 				})
 			})
 			r.Route("/org/{orgName}", func(r chi.Router) {
 				r.Get("/", h.Webhooks.GetOwnedByOrg)
-				r.Post("/", h.Webhooks.Add)
+				r.With(auditLog.record(hub.AuditWebhookAdd)).Post("/", h.Webhooks.Add)
 				r.Route("/{webhookID}", func(r chi.Router) {
 					r.Get("/", h.Webhooks.Get)
-					r.Put("/", h.Webhooks.Update)
-					r.Delete("/", h.Webhooks.Delete)
+					r.With(auditLog.record(hub.AuditWebhookUpdate)).Put("/", h.Webhooks.Update)
+					r.With(auditLog.record(hub.AuditWebhookDelete)).Delete("/", h.Webhooks.Delete)
+					r.With(auditLog.record(hub.AuditWebhookUpdate)).Put("/enable", h.Webhooks.Enable)
+					r.Route("/deliveries", func(r chi.Router) {
+						r.Get("/", h.Webhooks.GetDeliveries)
+						r.Post("/{deliveryID}/redeliver", h.Webhooks.Redeliver)
+					})
+					r.Route("/failed-deliveries", func(r chi.Router) {
+						r.Get("/", h.Webhooks.GetFailedDeliveries)
+						r.Get("/{notificationID}", h.Webhooks.GetFailedDelivery)
+						r.Put("/{notificationID}/requeue", h.Webhooks.RequeueFailedDelivery)
+					})
 				})
 			})
 			r.Post("/test", h.Webhooks.TriggerTest)
+			r.Post("/preview", h.Webhooks.Preview)
 		})
 
 		// API keys
 		r.Route("/api-keys", func(r chi.Router) {
 			r.Use(h.Users.RequireLogin)
 			r.Get("/", h.APIKeys.GetOwnedByUser)
-			r.Post("/", h.APIKeys.Add)
+			r.With(auditLog.record(hub.AuditAPIKeyAdd)).Post("/", h.APIKeys.Add)
 			r.Route("/{apiKeyID}", func(r chi.Router) {
 				r.Get("/", h.APIKeys.Get)
 				r.Put("/", h.APIKeys.Update)
-				r.Delete("/", h.APIKeys.Delete)
+				r.With(auditLog.record(hub.AuditAPIKeyDelete)).Delete("/", h.APIKeys.Delete)
 			})
 		})
 
+		// Saved searches
+		r.Route("/saved-searches", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Get("/", h.SavedSearches.GetOwnedByUser)
+			r.Post("/", h.SavedSearches.Add)
+			r.Route("/{savedSearchID}", func(r chi.Router) {
+				r.Get("/", h.SavedSearches.Get)
+				r.Put("/", h.SavedSearches.Update)
+				r.Delete("/", h.SavedSearches.Delete)
+				r.Get("/matches", h.SavedSearches.GetMatches)
+			})
+		})
+
+		// Abuse reports
+		r.With(h.Users.RequireLogin, abuseReportsRL).Post("/abuse-reports", h.AbuseReports.Add)
+
+		// Site administration
+		r.Route("/admin/abuse-reports", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Get("/", h.AbuseReports.GetQueue)
+			r.With(auditLog.record(hub.AuditAdminAbuseReportTriage)).
+				Put("/{abuseReportID}", h.AbuseReports.Triage)
+		})
+		r.Route("/admin/repositories/{repoName}/verified-publisher", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Get("/", h.Admin.GetVerifiedPublisherChanges)
+			r.With(auditLog.record(hub.AuditAdminRepositoryVerifiedPublisherUpdate)).
+				Put("/", h.Admin.UpdateVerifiedPublisher)
+		})
+		r.Route("/admin/users", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Get("/", h.Admin.SearchUsers)
+			r.Route("/{userID}", func(r chi.Router) {
+				r.Get("/resources", h.Admin.GetUserResources)
+				r.With(auditLog.record(hub.AuditAdminUserDisable)).Put("/disable", h.Admin.DisableUser)
+				r.With(auditLog.record(hub.AuditAdminUserEnable)).Put("/enable", h.Admin.EnableUser)
+				r.With(auditLog.record(hub.AuditAdminUserPasswordReset)).
+					Post("/password-reset", h.Admin.ForcePasswordReset)
+				r.With(auditLog.record(hub.AuditAdminUserCredentialsRevoke)).
+					Delete("/credentials", h.Admin.RevokeCredentials)
+			})
+		})
+
+		// Audit log
+		r.Route("/audit-log", func(r chi.Router) {
+			r.Use(h.Users.RequireLogin)
+			r.Get("/user", h.AuditLog.GetByUser)
+			r.Get("/org/{orgName}", h.AuditLog.GetByOrg)
+		})
+
 		// Availability checks
 		r.Route("/check-availability", func(r chi.Router) {
 			r.Head("/{resourceKind:^repositoryName$|^repositoryURL$}", h.Repositories.CheckAvailability)
@@ -319,10 +609,14 @@ func (h *Handlers) setupRouter() {
 		})
 
 		// Images
-		r.With(h.Users.RequireLogin).Post("/images", h.Static.SaveImage)
+		r.With(h.Users.RequireLogin, imagesRL).Post("/images", h.Static.SaveImage)
 
 		// Stats
 		r.Get("/stats", h.Stats.Get)
+		r.Get("/stats/series/{metric}", h.Stats.GetSeries)
+
+		// Theme
+		r.Get("/theme", h.Static.GetTheme)
 
 		// Harbor replication
 		//
@@ -342,7 +636,7 @@ func (h *Handlers) setupRouter() {
 	// from the Helm Hub to Artifact Hub, allowing the existing Helm tooling to
 	// continue working without modifications. This is a temporary solution and
 	// future Helm CLI versions should use the generic Artifact Hub search API.
-	r.Get("/api/chartsvc/v1/charts/search", h.Packages.SearchMonocular)
+	r.With(searchRL).Get("/api/chartsvc/v1/charts/search", h.Packages.SearchMonocular)
 
 	// Monocular charts url redirect endpoint
 	//
@@ -370,6 +664,33 @@ func (h *Handlers) setupRouter() {
 		})
 	})
 
+	// SCIM
+	if h.cfg.GetBool("server.scim.enabled") {
+		r.Route("/scim/v2", func(r chi.Router) {
+			r.Use(h.SCIM.RequireToken)
+			r.NotFound(h.SCIM.NotFound)
+			r.Get("/ServiceProviderConfig", h.SCIM.GetServiceProviderConfig)
+			r.Route("/Users", func(r chi.Router) {
+				r.Get("/", h.SCIM.GetUsers)
+				r.Post("/", h.SCIM.RegisterUser)
+				r.Route("/{userID}", func(r chi.Router) {
+					r.Get("/", h.SCIM.GetUser)
+					r.Put("/", h.SCIM.UpdateUser)
+					r.Patch("/", h.SCIM.PatchUser)
+					r.Delete("/", h.SCIM.DeleteUser)
+				})
+			})
+			r.Route("/Groups", func(r chi.Router) {
+				r.Get("/", h.SCIM.GetGroups)
+				r.Route("/{groupID}", func(r chi.Router) {
+					r.Get("/", h.SCIM.GetGroup)
+					r.Put("/", h.SCIM.UpdateGroup)
+					r.Patch("/", h.SCIM.PatchGroup)
+				})
+			})
+		})
+	}
+
 	// Oauth
 	providers := make([]string, 0, len(h.cfg.GetStringMap("server.oauth")))
 	for provider := range h.cfg.GetStringMap("server.oauth") {
@@ -377,19 +698,24 @@ func (h *Handlers) setupRouter() {
 	}
 	if len(providers) > 0 {
 		r.Route(fmt.Sprintf("/oauth/{provider:%s}", strings.Join(providers, "|")), func(r chi.Router) {
+			r.Use(authRL)
 			r.Get("/", h.Users.OauthRedirect)
-			r.Get("/callback", h.Users.OauthCallback)
+			r.With(auditLog.record(hub.AuditUserLogin)).Get("/callback", h.Users.OauthCallback)
 		})
 	}
 
 	// Index special entry points
 	r.Route("/packages", func(r chi.Router) {
-		r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler$}/{repoName}/{packageName}", func(r chi.Router) {
+		r.Route("/{^helm$|^falco$|^opa$|^olm|^tbaction|^krew|^helm-plugin|^tekton-task|^keda-scaler|^wasm|^crossplane-configuration|^oci-artifact$}/{repoName}/{packageName}", func(r chi.Router) {
 			r.With(h.Packages.InjectIndexMeta).Get("/{version}", h.Static.ServeIndex)
 			r.With(h.Packages.InjectIndexMeta).Get("/", h.Static.ServeIndex)
 		})
 	})
 
+	// Sitemap
+	r.Get("/sitemap.xml", h.Sitemap.Index)
+	r.Get("/sitemap/{section}/{page}.xml", h.Sitemap.Section)
+
 	// Badges
 	r.Get("/badge/repository/{repoName}", h.Repositories.Badge)
 
@@ -398,8 +724,8 @@ func (h *Handlers) setupRouter() {
 	webStaticFilesPath := path.Join(webBuildPath, "static")
 	widgetBuildPath := h.cfg.GetString("server.widgetBuildPath")
 	docsFilesPath := path.Join(webBuildPath, "docs")
-	static.FileServer(r, "/static", webStaticFilesPath, static.StaticCacheMaxAge)
-	static.FileServer(r, "/docs", docsFilesPath, static.DocsCacheMaxAge)
+	static.FileServer(r, "/static", webStaticFilesPath, static.StaticCacheMaxAge, h.Static.AssetsManifest())
+	static.FileServer(r, "/docs", docsFilesPath, static.DocsCacheMaxAge, nil)
 	r.Get("/image/{image}", h.Static.Image)
 	r.Get("/manifest.json", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(5*time.Minute))
@@ -440,12 +766,27 @@ func csrfSkipper(next http.Handler) http.Handler {
 		if r.Header.Get(user.APIKeyIDHeader) != "" && r.Header.Get(user.APIKeySecretHeader) != "" {
 			r = csrf.UnsafeSkipCheck(r)
 		}
+		// Skip checks for requests authenticated using a bearer token, as it
+		// is not sent automatically by browsers.
+		if strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			r = csrf.UnsafeSkipCheck(r)
+		}
 		// Skip checks for requests using GET or HEAD methods, except requests
 		// to /api/v1/csrf, which is the endpoint used to get the token that
 		// should be provided on subsequent POST, PUT or DELETE API requests.
 		if (r.Method == "GET" && r.URL.Path != "/api/v1/csrf") || r.Method == "HEAD" {
 			r = csrf.UnsafeSkipCheck(r)
 		}
+		// Skip checks for one-click unsubscribe requests sent by email
+		// clients, which are authorized using a signed token instead.
+		if r.Method == "POST" && r.URL.Path == "/api/v1/subscriptions/unsubscribe" {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		// Skip checks for repositories tracking webhook requests sent from CI
+		// workflows, which are authorized using a signature instead.
+		if r.Method == "POST" && trackingWebhookPathRE.MatchString(r.URL.Path) {
+			r = csrf.UnsafeSkipCheck(r)
+		}
 		next.ServeHTTP(w, r)
 	})
 }
@@ -485,6 +826,27 @@ func logger(next http.Handler) http.Handler {
 	})
 }
 
+// compressor returns an http middleware that compresses the responses whose
+// content type is compressible using brotli or gzip, depending on the encodings
+// accepted by the client (brotli is preferred). Byte-range requests are not
+// compressed, as the ranges served refer to the uncompressed content.
+func compressor(level int) func(next http.Handler) http.Handler {
+	c := middleware.NewCompressor(level, compressibleContentTypes...)
+	c.SetEncoder("br", func(w io.Writer, level int) io.Writer {
+		return brotli.NewWriterLevel(w, level)
+	})
+	return func(next http.Handler) http.Handler {
+		compressed := c.Handler(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			compressed.ServeHTTP(w, r)
+		})
+	}
+}
+
 // realIP is an http middleware that sets the request remote addr to the result
 // of extracting the IP in the requested index from the X-Forwarded-For header.
 // Positives indexes start by 0 and work like usual slice indexes. Negative
@@ -1,14 +1,104 @@
 package handlers
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/andybalholm/brotli"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestCompressor(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"key": "value"}`), 100)
+	handler := func(contentType string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = w.Write(data)
+		}
+	}
+
+	t.Run("compressible content compressed using brotli", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip, deflate, br")
+		compressor(compressionLevel)(handler("application/json")).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, "br", resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", resp.Header.Get("Vary"))
+		decompressed, err := ioutil.ReadAll(brotli.NewReader(resp.Body))
+		require.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+	})
+
+	t.Run("compressible content compressed using gzip", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		compressor(compressionLevel)(handler("text/html; charset=utf-8")).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		gr, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		decompressed, err := ioutil.ReadAll(gr)
+		require.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+	})
+
+	t.Run("non compressible content not compressed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip, br")
+		compressor(compressionLevel)(handler("image/png")).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, data, body)
+	})
+
+	t.Run("byte-range request not compressed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Encoding", "gzip, br")
+		r.Header.Set("Range", "bytes=0-9")
+		compressor(compressionLevel)(handler("application/json")).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, data, body)
+	})
+
+	t.Run("client does not accept any compression", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		compressor(compressionLevel)(handler("application/json")).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		assert.Equal(t, data, body)
+	})
+}
+
 func TestRealIP(t *testing.T) {
 	checkRemoteAddr := func(expectedRemoteAddr string) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
@@ -61,6 +61,18 @@ func (h *Handlers) AddMember(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// CancelDeletion is an http handler that cancels the scheduled deletion of
+// the provided organization.
+func (h *Handlers) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.orgManager.CancelDeletion(r.Context(), orgName); err != nil {
+		h.logger.Error().Err(err).Str("method", "CancelDeletion").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // CheckAvailability is an http handler that checks the availability of a given
 // value for the provided resource kind.
 func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
@@ -94,8 +106,14 @@ func (h *Handlers) ConfirmMembership(w http.ResponseWriter, r *http.Request) {
 
 // Delete is an http handler that deletes an organization.
 func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	input := &hub.DeleteOrganizationInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Msg("invalid input")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
 	orgName := chi.URLParam(r, "orgName")
-	if err := h.orgManager.Delete(r.Context(), orgName); err != nil {
+	if err := h.orgManager.Delete(r.Context(), orgName, input); err != nil {
 		h.logger.Error().Err(err).Str("method", "Delete").Send()
 		helpers.RenderErrorJSON(w, err)
 		return
@@ -166,6 +184,36 @@ func (h *Handlers) GetMembers(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// Rename is an http handler that renames the provided organization.
+func (h *Handlers) Rename(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Rename").Msg("invalid input")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.orgManager.Rename(r.Context(), orgName, input["name"]); err != nil {
+		h.logger.Error().Err(err).Str("method", "Rename").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TransferOwnership is an http handler that transfers the ownership of the
+// provided organization to one of its members.
+func (h *Handlers) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.orgManager.TransferOwnership(r.Context(), orgName, userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "TransferOwnership").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Update is an http handler that updates the provided organization in the
 // database.
 func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
@@ -202,6 +250,25 @@ func (h *Handlers) UpdateAuthorizationPolicy(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateMemberRole is an http handler that updates the role of a member of the
+// provided organization.
+func (h *Handlers) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateMemberRole").Msg("invalid input")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.orgManager.UpdateMemberRole(r.Context(), orgName, userAlias, input["role"]); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateMemberRole").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // GetUserAllowedActions is an http handler that returns the actions the
 // requesting user is allowed to perform in the provided organization.
 func (h *Handlers) GetUserAllowedActions(w http.ResponseWriter, r *http.Request) {
@@ -179,6 +179,63 @@ func TestAddMember(t *testing.T) {
 	}
 }
 
+func TestCancelDeletion(t *testing.T) {
+	testCases := []struct {
+		omErr              error
+		expectedStatusCode int
+	}{
+		{
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			hub.ErrNotFound,
+			http.StatusNotFound,
+		},
+		{
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		var desc string
+		if tc.omErr != nil {
+			desc = tc.omErr.Error()
+		}
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"orgName"},
+					Values: []string{"org1"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("CancelDeletion", r.Context(), "org1").Return(tc.omErr)
+			hw.h.CancelDeletion(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCheckAvailability(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -323,56 +380,78 @@ func TestConfirmMembership(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	testCases := []struct {
-		omErr              error
-		expectedStatusCode int
-	}{
-		{
-			nil,
-			http.StatusNoContent,
-		},
-		{
-			hub.ErrInvalidInput,
-			http.StatusBadRequest,
-		},
-		{
-			hub.ErrInsufficientPrivilege,
-			http.StatusForbidden,
-		},
-		{
-			tests.ErrFakeDB,
-			http.StatusInternalServerError,
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
 		},
 	}
-	for _, tc := range testCases {
-		tc := tc
-		var desc string
-		if tc.omErr != nil {
-			desc = tc.omErr.Error()
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			omErr              error
+			expectedStatusCode int
+		}{
+			{
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
 		}
-		t.Run(desc, func(t *testing.T) {
-			t.Parallel()
-			w := httptest.NewRecorder()
-			r, _ := http.NewRequest("DELETE", "/", nil)
-			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-			rctx := &chi.Context{
-				URLParams: chi.RouteParams{
-					Keys:   []string{"orgName"},
-					Values: []string{"org1"},
-				},
+		for _, tc := range testCases {
+			tc := tc
+			var desc string
+			if tc.omErr != nil {
+				desc = tc.omErr.Error()
 			}
-			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			t.Run(desc, func(t *testing.T) {
+				t.Parallel()
+				body := `{"confirmation": "org1", "transfer_repositories_to_user": true}`
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", strings.NewReader(body))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-			hw := newHandlersWrapper()
-			hw.om.On("Delete", r.Context(), "org1").Return(tc.omErr)
-			hw.h.Delete(w, r)
-			resp := w.Result()
-			defer resp.Body.Close()
+				hw := newHandlersWrapper()
+				hw.om.On("Delete", r.Context(), "org1", &hub.DeleteOrganizationInput{
+					Confirmation:               "org1",
+					TransferRepositoriesToUser: true,
+				}).Return(tc.omErr)
+				hw.h.Delete(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
 
-			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
-			hw.om.AssertExpectations(t)
-		})
-	}
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
 }
 
 func TestDeleteMember(t *testing.T) {
@@ -665,6 +744,138 @@ func TestGetMembers(t *testing.T) {
 	})
 }
 
+func TestRename(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Rename(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"organization rename succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error renaming organization (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error renaming organization (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error renaming organization (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"name": "org2"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("Rename", r.Context(), "org1", "org2").Return(tc.err)
+				hw.h.Rename(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestTransferOwnership(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "userAlias"},
+			Values: []string{"org1", "user1"},
+		},
+	}
+
+	testCases := []struct {
+		description        string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			"ownership transfer succeeded",
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			"error transferring ownership (invalid input)",
+			hub.ErrInvalidInput,
+			http.StatusBadRequest,
+		},
+		{
+			"error transferring ownership (insufficient privilege)",
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"error transferring ownership (not found)",
+			hub.ErrNotFound,
+			http.StatusNotFound,
+		},
+		{
+			"error transferring ownership (db error)",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.om.On("TransferOwnership", r.Context(), "org1", "user1").Return(tc.err)
+			hw.h.TransferOwnership(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.om.AssertExpectations(t)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -886,6 +1097,78 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 	})
 }
 
+func TestUpdateMemberRole(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName", "userAlias"},
+			Values: []string{"org1", "user1"},
+		},
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdateMemberRole(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"member role update succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error updating member role (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error updating member role (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error updating member role (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"role": "admin"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.om.On("UpdateMemberRole", r.Context(), "org1", "user1", "admin").Return(tc.err)
+				hw.h.UpdateMemberRole(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.om.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestGetUserAllowedActions(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
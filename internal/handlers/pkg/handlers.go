@@ -3,9 +3,14 @@ package pkg
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,40 +19,109 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
 	"github.com/go-chi/chi"
 	"github.com/gorilla/feeds"
+	"github.com/patrickmn/go-cache"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 	"helm.sh/helm/v3/pkg/chart/loader"
 )
 
+const (
+	// feedsCacheExpiration represents how long the feeds generated are cached.
+	feedsCacheExpiration = 5 * time.Minute
+
+	// feedsCacheCleanupInterval represents how often the expired feeds are
+	// removed from the cache.
+	feedsCacheCleanupInterval = 10 * time.Minute
+
+	// recentReleasesLimit represents the maximum number of releases included
+	// in the repositories and publishers feeds.
+	recentReleasesLimit = 50
+
+	// widgetWidth and widgetHeight represent the size of the embeddable
+	// package widget, as advertised in the oEmbed responses.
+	widgetWidth  = 350
+	widgetHeight = 185
+)
+
+// packageURLPathRE is a regexp used to extract the repository kind, repository
+// name and package name from the path of a package url.
+var packageURLPathRE = regexp.MustCompile(`^/packages/([^/]+)/([^/]+)/([^/]+)(?:/([^/]+))?/?$`)
+
+// errSBOMFormatNotAvailable indicates that the package's snapshot does not
+// have a SBOM in any of the formats requested.
+var errSBOMFormatNotAvailable = errors.New("sbom not available in the format requested")
+
+// errOEmbedFormatNotSupported indicates that the oEmbed response format
+// requested is not supported.
+var errOEmbedFormatNotSupported = errors.New("oembed format not supported")
+
 // Handlers represents a group of http handlers in charge of handling packages
 // operations.
 type Handlers struct {
 	pkgManager  hub.PackageManager
 	repoManager hub.RepositoryManager
+	chartMirror hub.ChartMirror
 	cfg         *viper.Viper
 	logger      zerolog.Logger
 	hc          hub.HTTPClient
+	feedsCache  *cache.Cache
 }
 
 // NewHandlers creates a new Handlers instance.
 func NewHandlers(
 	pkgManager hub.PackageManager,
 	repoManager hub.RepositoryManager,
+	chartMirror hub.ChartMirror,
 	cfg *viper.Viper,
 	hc hub.HTTPClient,
 ) *Handlers {
 	return &Handlers{
 		pkgManager:  pkgManager,
 		repoManager: repoManager,
+		chartMirror: chartMirror,
 		cfg:         cfg,
 		logger:      log.With().Str("handlers", "pkg").Logger(),
 		hc:          hc,
+		feedsCache:  cache.New(feedsCacheExpiration, feedsCacheCleanupInterval),
 	}
 }
 
+// AddVulnerabilitySuppression is an http handler used to mark a vulnerability
+// as not applicable to the package provided.
+func (h *Handlers) AddVulnerabilitySuppression(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	s := &hub.VulnerabilitySuppression{}
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddVulnerabilitySuppression").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.pkgManager.AddVulnerabilitySuppression(r.Context(), packageID, s); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddVulnerabilitySuppression").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// DeleteVulnerabilitySuppression is an http handler used to delete a
+// vulnerability suppression from the package provided.
+func (h *Handlers) DeleteVulnerabilitySuppression(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	vulnerabilityID := chi.URLParam(r, "vulnerabilityID")
+	if err := h.pkgManager.DeleteVulnerabilitySuppression(r.Context(), packageID, vulnerabilityID); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteVulnerabilitySuppression").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Get is an http handler used to get a package details.
 func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	input := &hub.GetPackageInput{
@@ -64,6 +138,42 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetCard is an http handler used to get the data needed to render an
+// embeddable card of a given package on external sites.
+func (h *Handlers) GetCard(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetPackageInput{
+		RepositoryName: chi.URLParam(r, "repoName"),
+		PackageName:    chi.URLParam(r, "packageName"),
+	}
+	p, err := h.getPackageSummary(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "GetCard").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(h.buildCard(p))
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// DownloadChartArchive is an http handler used to download the archive of a
+// given Helm chart package snapshot from the chart mirror.
+func (h *Handlers) DownloadChartArchive(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	archive, fileName, err := h.chartMirror.GetChartArchive(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "DownloadChartArchive").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	defer archive.Close()
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(24*time.Hour))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, archive)
+}
+
 // GetChangeLog is an http handler used to get a package's changelog.
 func (h *Handlers) GetChangeLog(w http.ResponseWriter, r *http.Request) {
 	packageID := chi.URLParam(r, "packageID")
@@ -101,17 +211,35 @@ func (h *Handlers) GetChartTemplates(w http.ResponseWriter, r *http.Request) {
 	// Download chart package from remote source
 	req, _ := http.NewRequest("GET", p.ContentURL, nil)
 	req = req.WithContext(r.Context())
+	hc := h.hc
 	if p.Repository.Private {
 		// Get credentials and set them in request if the repository is private
-		repo, err := h.repoManager.GetByID(r.Context(), p.Repository.RepositoryID, true)
+		rp, err := h.repoManager.GetByID(r.Context(), p.Repository.RepositoryID, true)
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "GetChartTemplates").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		if rp.AuthPassRef != "" {
+			if err := h.repoManager.ResolveCredentials(r.Context(), rp); err != nil {
+				h.logger.Error().Err(err).Str("method", "GetChartTemplates").Send()
+				helpers.RenderErrorJSON(w, err)
+				return
+			}
+		}
+		if rp.AuthUser != "" || rp.AuthPass != "" {
+			req.SetBasicAuth(rp.AuthUser, rp.AuthPass)
+		}
+
+		// Use the repository TLS options when present
+		hc, err = repo.HTTPClient(rp, h.hc)
 		if err != nil {
 			h.logger.Error().Err(err).Str("method", "GetChartTemplates").Send()
 			helpers.RenderErrorJSON(w, err)
 			return
 		}
-		req.SetBasicAuth(repo.AuthUser, repo.AuthPass)
 	}
-	resp, err := h.hc.Do(req)
+	resp, err := hc.Do(req)
 	if err != nil {
 		h.logger.Error().Err(err).Str("method", "GetChartTemplates").Send()
 		helpers.RenderErrorJSON(w, err)
@@ -165,6 +293,30 @@ func (h *Handlers) GetRandom(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetSBOM is an http handler used to get the SBOM of a package's snapshot.
+// When SBOMs are available in multiple formats, the one returned is selected
+// using the format query parameter or, when not provided, the Accept header.
+func (h *Handlers) GetSBOM(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	sboms, err := h.pkgManager.GetSBOMs(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSBOMs").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	sbom := selectSBOM(sboms, r.FormValue("format"), r.Header.Get("Accept"))
+	if sbom == nil {
+		helpers.RenderErrorWithCodeJSON(w, errSBOMFormatNotAvailable, http.StatusNotAcceptable)
+		return
+	}
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge))
+	w.Header().Set("Content-Type", pkg.SBOMMediaType(sbom.Format))
+	w.Header().Set("Vary", "Accept")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(sbom.Content))
+}
+
 // GetSnapshotSecurityReport is an http handler used to get the security report
 // of a package's snapshot.
 func (h *Handlers) GetSnapshotSecurityReport(w http.ResponseWriter, r *http.Request) {
@@ -231,6 +383,21 @@ func (h *Handlers) GetSummary(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetValuesDiff is an http handler used to get the differences between the
+// default values of two versions of a package.
+func (h *Handlers) GetValuesDiff(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	from := r.FormValue("from")
+	to := r.FormValue("to")
+	dataJSON, err := h.pkgManager.GetValuesDiffJSON(r.Context(), packageID, from, to)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetValuesDiffJSON").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // GetValuesSchema is an http handler used to get the values schema of a
 // package's snapshot.
 func (h *Handlers) GetValuesSchema(w http.ResponseWriter, r *http.Request) {
@@ -245,6 +412,19 @@ func (h *Handlers) GetValuesSchema(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
 }
 
+// GetVulnerabilitiesSuppressions is an http handler used to get the
+// vulnerabilities suppressions of the package provided.
+func (h *Handlers) GetVulnerabilitiesSuppressions(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	dataJSON, err := h.pkgManager.GetVulnerabilitiesSuppressionsJSON(r.Context(), packageID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetVulnerabilitiesSuppressions").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // InjectIndexMeta is a middleware that injects the some index metadata related
 // to a given package,
 func (h *Handlers) InjectIndexMeta(next http.Handler) http.Handler {
@@ -275,66 +455,303 @@ func (h *Handlers) InjectIndexMeta(next http.Handler) http.Handler {
 		// Inject index metadata in context and call next handler
 		ctx := context.WithValue(r.Context(), hub.IndexMetaTitleKey, title)
 		ctx = context.WithValue(ctx, hub.IndexMetaDescriptionKey, description)
+		ctx = context.WithValue(ctx, hub.IndexMetaOEmbedURLKey, buildOEmbedURL(h.cfg.GetString("server.baseURL"), r))
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// RssFeed is an http handler used to get the RSS feed of a given package.
-func (h *Handlers) RssFeed(w http.ResponseWriter, r *http.Request) {
-	// Get package details
-	input := &hub.GetPackageInput{
-		PackageName: chi.URLParam(r, "packageName"),
+// OEmbed is an http handler that implements the oEmbed protocol, allowing
+// consumers to get the html code needed to embed the widget of the package
+// identified by the url provided. Only the json format is supported.
+func (h *Handlers) OEmbed(w http.ResponseWriter, r *http.Request) {
+	qs := r.URL.Query()
+
+	// Validate request
+	if format := qs.Get("format"); format != "" && format != "json" {
+		helpers.RenderErrorWithCodeJSON(w, errOEmbedFormatNotSupported, http.StatusNotImplemented)
+		return
 	}
-	repoName := chi.URLParam(r, "repoName")
-	if repoName != "" {
-		input.RepositoryName = repoName
+	if qs.Get("url") == "" {
+		err := fmt.Errorf("%w: %s", hub.ErrInvalidInput, "url not provided")
+		helpers.RenderErrorJSON(w, err)
+		return
 	}
-	p, err := h.pkgManager.Get(r.Context(), input)
-	if err != nil {
-		h.logger.Error().Err(err).Interface("input", input).Str("method", "RssFeed").Send()
+	for _, param := range []string{"maxwidth", "maxheight"} {
+		if v := qs.Get(param); v != "" {
+			max, err := strconv.Atoi(v)
+			if err != nil || max <= 0 {
+				err := fmt.Errorf("%w: invalid %s", hub.ErrInvalidInput, param)
+				helpers.RenderErrorJSON(w, err)
+				return
+			}
+			if (param == "maxwidth" && max < widgetWidth) || (param == "maxheight" && max < widgetHeight) {
+				// The widget cannot be embedded in the space available
+				helpers.RenderErrorJSON(w, hub.ErrNotFound)
+				return
+			}
+		}
+	}
+	theme := qs.Get("theme")
+	if theme == "" {
+		theme = "light"
+	}
+	if theme != "light" && theme != "dark" {
+		err := fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid theme")
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
 
-	// Build RSS feed
+	// Get package details from the url provided
 	baseURL := h.cfg.GetString("server.baseURL")
-	publisher := p.Repository.OrganizationName
-	if publisher == "" {
-		publisher = p.Repository.UserAlias
+	input, ok := parsePackageURL(baseURL, qs.Get("url"))
+	if !ok {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	p, err := h.getPackageSummary(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Interface("input", input).Str("method", "OEmbed").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	// Prepare oEmbed response
+	c := h.buildCard(p)
+	pkgURL := html.EscapeString(c.URL)
+	desc := html.EscapeString(c.Description)
+	if desc != "" {
+		desc = ": " + desc
+	}
+	widgetHTML := fmt.Sprintf(`<div class="artifacthub-widget" data-url="%s" data-theme="%s" data-header="true" data-responsive="false">`+
+		`<blockquote><p lang="en" dir="ltr"><b>%s</b>%s</p>&mdash; Open in <a href="%s">Artifact Hub</a></blockquote></div>`+
+		`<script async src="%s/artifacthub-widget.js"></script>`,
+		pkgURL, theme, html.EscapeString(c.Name), desc, pkgURL, html.EscapeString(baseURL),
+	)
+	dataJSON, _ := json.Marshal(&oEmbedResponse{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        c.Name,
+		AuthorName:   c.Publisher,
+		ProviderName: "Artifact Hub",
+		ProviderURL:  baseURL,
+		CacheAge:     int(helpers.DefaultAPICacheMaxAge.Seconds()),
+		ThumbnailURL: c.LogoURL,
+		HTML:         widgetHTML,
+		Width:        widgetWidth,
+		Height:       widgetHeight,
+	})
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// oEmbedResponse represents the response returned by the oEmbed endpoint.
+type oEmbedResponse struct {
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name"`
+	ProviderName string `json:"provider_name"`
+	ProviderURL  string `json:"provider_url"`
+	CacheAge     int    `json:"cache_age"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+// RequestSnapshotSecurityReportRescan is an http handler used to request a
+// package's snapshot to be scanned again for security vulnerabilities.
+func (h *Handlers) RequestSnapshotSecurityReportRescan(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	err := h.pkgManager.RequestSnapshotSecurityReportRescan(r.Context(), packageID, version)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "RequestSnapshotSecurityReportRescan").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
 	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// PackageFeed is an http handler used to get the RSS or Atom feed of a given
+// package.
+func (h *Handlers) PackageFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, "PackageFeed", func() (*feeds.Feed, error) {
+		// Get package details
+		input := &hub.GetPackageInput{
+			RepositoryName: chi.URLParam(r, "repoName"),
+			PackageName:    chi.URLParam(r, "packageName"),
+		}
+		p, err := h.pkgManager.Get(r.Context(), input)
+		if err != nil {
+			return nil, err
+		}
+
+		// Build feed
+		baseURL := h.cfg.GetString("server.baseURL")
+		publisher := p.Repository.OrganizationName
+		if publisher == "" {
+			publisher = p.Repository.UserAlias
+		}
+		feed := &feeds.Feed{
+			Title:       fmt.Sprintf("%s/%s (Artifact Hub)", publisher, p.NormalizedName),
+			Description: p.Description,
+			Link:        &feeds.Link{Href: baseURL},
+			Image: &feeds.Image{
+				Title: "logo",
+				Url:   fmt.Sprintf("%s/image/%s@4x", baseURL, p.LogoImageID),
+				Link:  baseURL,
+			},
+		}
+		if len(p.Maintainers) > 0 {
+			feed.Author = &feeds.Author{
+				Name:  p.Maintainers[0].Name,
+				Email: p.Maintainers[0].Email,
+			}
+		}
+		for _, s := range p.AvailableVersions {
+			if s.Yanked {
+				continue
+			}
+			feed.Items = append(feed.Items, &feeds.Item{
+				Id:          fmt.Sprintf("%s#%s", p.PackageID, s.Version),
+				Title:       s.Version,
+				Description: fmt.Sprintf("%s %s", p.NormalizedName, s.Version),
+				Created:     time.Unix(s.TS, 0),
+				Link:        &feeds.Link{Href: BuildURL(baseURL, p, s.Version)},
+			})
+		}
+		sort.Slice(feed.Items, func(i, j int) bool {
+			vi, _ := semver.NewVersion(feed.Items[i].Title)
+			vj, _ := semver.NewVersion(feed.Items[j].Title)
+			return vj.LessThan(vi)
+		})
+		return feed, nil
+	})
+}
+
+// PublisherFeed is an http handler used to get the RSS or Atom feed of the
+// most recent releases of the packages from a given publisher (user or
+// organization).
+func (h *Handlers) PublisherFeed(w http.ResponseWriter, r *http.Request) {
+	publisher := chi.URLParam(r, "publisher")
+	h.serveFeed(w, r, "PublisherFeed", func() (*feeds.Feed, error) {
+		input := &hub.GetRecentReleasesInput{
+			Publisher: publisher,
+			Limit:     recentReleasesLimit,
+		}
+		releases, err := h.pkgManager.GetRecentReleases(r.Context(), input)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildReleasesFeed(
+			fmt.Sprintf("%s (Artifact Hub)", publisher),
+			fmt.Sprintf("Recent releases of the packages published by %s", publisher),
+			releases,
+		), nil
+	})
+}
+
+// RepositoryFeed is an http handler used to get the RSS or Atom feed of the
+// most recent releases of the packages in a given repository.
+func (h *Handlers) RepositoryFeed(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	h.serveFeed(w, r, "RepositoryFeed", func() (*feeds.Feed, error) {
+		input := &hub.GetRecentReleasesInput{
+			RepositoryName: repoName,
+			Limit:          recentReleasesLimit,
+		}
+		releases, err := h.pkgManager.GetRecentReleases(r.Context(), input)
+		if err != nil {
+			return nil, err
+		}
+		return h.buildReleasesFeed(
+			fmt.Sprintf("%s (Artifact Hub)", repoName),
+			fmt.Sprintf("Recent releases of the packages in the %s repository", repoName),
+			releases,
+		), nil
+	})
+}
+
+// buildReleasesFeed builds a feed from the list of releases provided. Releases
+// are expected to be sorted by release date, most recent first.
+func (h *Handlers) buildReleasesFeed(title, description string, releases []*hub.Package) *feeds.Feed {
+	baseURL := h.cfg.GetString("server.baseURL")
 	feed := &feeds.Feed{
-		Title:       fmt.Sprintf("%s/%s (Artifact Hub)", publisher, p.NormalizedName),
-		Description: p.Description,
+		Title:       title,
+		Description: description,
 		Link:        &feeds.Link{Href: baseURL},
-		Image: &feeds.Image{
-			Title: "logo",
-			Url:   fmt.Sprintf("%s/image/%s@4x", baseURL, p.LogoImageID),
-			Link:  baseURL,
-		},
-	}
-	if len(p.Maintainers) > 0 {
-		feed.Author = &feeds.Author{
-			Name:  p.Maintainers[0].Name,
-			Email: p.Maintainers[0].Email,
-		}
 	}
-	for _, s := range p.AvailableVersions {
+	for _, p := range releases {
 		feed.Items = append(feed.Items, &feeds.Item{
-			Id:          fmt.Sprintf("%s#%s", p.PackageID, s.Version),
-			Title:       s.Version,
-			Description: fmt.Sprintf("%s %s", p.NormalizedName, s.Version),
-			Created:     time.Unix(s.TS, 0),
-			Link:        &feeds.Link{Href: BuildURL(baseURL, p, s.Version)},
+			Id:          fmt.Sprintf("%s#%s", p.PackageID, p.Version),
+			Title:       fmt.Sprintf("%s %s", p.NormalizedName, p.Version),
+			Description: p.Description,
+			Created:     time.Unix(p.TS, 0),
+			Link:        &feeds.Link{Href: BuildURL(baseURL, p, p.Version)},
 		})
 	}
-	sort.Slice(feed.Items, func(i, j int) bool {
-		vi, _ := semver.NewVersion(feed.Items[i].Title)
-		vj, _ := semver.NewVersion(feed.Items[j].Title)
-		return vj.LessThan(vi)
-	})
+	return feed
+}
+
+// serveFeed writes to the response writer the feed returned by the build
+// function provided, in the format requested (rss by default). Feeds are
+// cached server-side by request path, so that they are only built once per
+// cache expiration period regardless of the number of subscribers.
+func (h *Handlers) serveFeed(
+	w http.ResponseWriter,
+	r *http.Request,
+	method string,
+	build func() (*feeds.Feed, error),
+) {
+	format := chi.URLParam(r, "format")
+	if format == "" {
+		format = "rss"
+	}
+	var contentType string
+	switch format {
+	case "rss":
+		contentType = "text/xml; charset=utf-8"
+	case "atom":
+		contentType = "application/atom+xml; charset=utf-8"
+	default:
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid feed format"))
+		return
+	}
 
+	// Use cached feed when available
+	key := r.URL.Path
+	if data, ok := h.feedsCache.Get(key); ok {
+		writeFeed(w, contentType, data.([]byte))
+		return
+	}
+
+	// Build and render feed
+	feed, err := build()
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", method).Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	var data string
+	if format == "atom" {
+		data, err = feed.ToAtom()
+	} else {
+		data, err = feed.ToRss()
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", method).Msg("error rendering feed")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	h.feedsCache.SetDefault(key, []byte(data))
+	writeFeed(w, contentType, []byte(data))
+}
+
+// writeFeed writes the feed data provided to the response writer.
+func writeFeed(w http.ResponseWriter, contentType string, data []byte) {
 	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge))
-	_ = feed.WriteRss(w)
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
 }
 
 // Search is an http handler used to search for packages in the hub database.
@@ -346,6 +763,7 @@ func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
+	input.Fuzzy = h.cfg.GetBool("server.search.fuzzy")
 	dataJSON, err := h.pkgManager.SearchJSON(r.Context(), input)
 	if err != nil {
 		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
@@ -381,6 +799,135 @@ func (h *Handlers) ToggleStar(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UnyankVersion is an http handler used to revert the yanking of a package
+// version.
+func (h *Handlers) UnyankVersion(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	if err := h.pkgManager.UnyankVersion(r.Context(), packageID, version); err != nil {
+		h.logger.Error().Err(err).Str("method", "UnyankVersion").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// YankVersion is an http handler used to yank a package version. The reason
+// why the version has been yanked can optionally be provided in the body.
+func (h *Handlers) YankVersion(w http.ResponseWriter, r *http.Request) {
+	input := &struct {
+		Reason string `json:"reason"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error().Err(err).Str("method", "YankVersion").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	packageID := chi.URLParam(r, "packageID")
+	version := chi.URLParam(r, "version")
+	if err := h.pkgManager.YankVersion(r.Context(), packageID, version, input.Reason); err != nil {
+		h.logger.Error().Err(err).Str("method", "YankVersion").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// card represents the data needed to render an embeddable package card.
+type card struct {
+	PackageID         string `json:"package_id"`
+	Name              string `json:"name"`
+	DisplayName       string `json:"display_name,omitempty"`
+	Description       string `json:"description,omitempty"`
+	Version           string `json:"version"`
+	AppVersion        string `json:"app_version,omitempty"`
+	Kind              string `json:"kind"`
+	RepositoryName    string `json:"repository_name"`
+	Publisher         string `json:"publisher"`
+	URL               string `json:"url"`
+	LogoURL           string `json:"logo_url,omitempty"`
+	Stars             int    `json:"stars"`
+	Official          bool   `json:"official"`
+	VerifiedPublisher bool   `json:"verified_publisher"`
+	Signed            bool   `json:"signed"`
+	TS                int64  `json:"ts"`
+}
+
+// buildCard builds the card of the package provided.
+func (h *Handlers) buildCard(p *hub.Package) *card {
+	baseURL := h.cfg.GetString("server.baseURL")
+	c := &card{
+		PackageID:         p.PackageID,
+		Name:              p.NormalizedName,
+		DisplayName:       p.DisplayName,
+		Description:       p.Description,
+		Version:           p.Version,
+		AppVersion:        p.AppVersion,
+		Kind:              hub.GetKindName(p.Repository.Kind),
+		RepositoryName:    p.Repository.Name,
+		Publisher:         p.Repository.OrganizationName,
+		URL:               BuildURL(baseURL, p, ""),
+		Stars:             p.Stars,
+		Official:          p.Official || p.Repository.Official,
+		VerifiedPublisher: p.Repository.VerifiedPublisher,
+		Signed:            p.Signed,
+		TS:                p.TS,
+	}
+	if c.Publisher == "" {
+		c.Publisher = p.Repository.UserAlias
+	}
+	if p.LogoImageID != "" {
+		c.LogoURL = fmt.Sprintf("%s/image/%s@2x", baseURL, p.LogoImageID)
+	}
+	return c
+}
+
+// getPackageSummary returns the summary of the package identified by the input
+// provided.
+func (h *Handlers) getPackageSummary(ctx context.Context, input *hub.GetPackageInput) (*hub.Package, error) {
+	dataJSON, err := h.pkgManager.GetSummaryJSON(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	p := &hub.Package{}
+	if err := json.Unmarshal(dataJSON, &p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parsePackageURL extracts from the package url provided the information
+// needed to get the package. The url must belong to the hub instance
+// identified by the base url provided.
+func parsePackageURL(baseURL, rawURL string) (*hub.GetPackageInput, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, false
+	}
+	b, err := url.Parse(baseURL)
+	if err != nil || !strings.EqualFold(u.Host, b.Host) {
+		return nil, false
+	}
+	path := strings.TrimPrefix(u.Path, strings.TrimSuffix(b.Path, "/"))
+	m := packageURLPathRE.FindStringSubmatch(path)
+	if m == nil {
+		return nil, false
+	}
+	if _, err := hub.GetKindFromName(m[1]); err != nil {
+		return nil, false
+	}
+	return &hub.GetPackageInput{
+		RepositoryName: m[2],
+		PackageName:    m[3],
+	}, true
+}
+
+// buildOEmbedURL returns the oEmbed discovery url for the package page
+// requested.
+func buildOEmbedURL(baseURL string, r *http.Request) string {
+	return fmt.Sprintf("%s/api/v1/oembed?format=json&url=%s", baseURL, url.QueryEscape(baseURL+r.URL.Path))
+}
+
 // buildSearchInput builds a packages search query from a map of query string
 // values, validating them as they are extracted.
 func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
@@ -467,6 +1014,7 @@ func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
 	return &hub.SearchPackageInput{
 		Limit:             limit,
 		Offset:            offset,
+		Cursor:            qs.Get("cursor"),
 		Facets:            facets,
 		TSQueryWeb:        qs.Get("ts_query_web"),
 		TSQuery:           qs.Get("ts_query"),
@@ -480,6 +1028,7 @@ func buildSearchInput(qs url.Values) (*hub.SearchPackageInput, error) {
 		Deprecated:        deprecated,
 		Licenses:          qs["license"],
 		Capabilities:      qs["capabilities"],
+		Sort:              qs.Get("sort"),
 	}, nil
 }
 
@@ -495,3 +1044,46 @@ func BuildURL(baseURL string, p *hub.Package, version string) string {
 	}
 	return baseURL + pkgPath
 }
+
+// selectSBOM selects the SBOM that should be returned from the list provided
+// using the format requested or the media types in the accept header value.
+// The first media type of the accept header that can be satisfied wins, and a
+// nil SBOM is returned when none of them can be satisfied.
+func selectSBOM(sboms []*hub.SBOM, format, accept string) *hub.SBOM {
+	getSBOM := func(match func(format string) bool) *hub.SBOM {
+		for _, sbom := range sboms {
+			if match(sbom.Format) {
+				return sbom
+			}
+		}
+		return nil
+	}
+
+	if format != "" {
+		return getSBOM(func(f string) bool { return f == format })
+	}
+	if strings.TrimSpace(accept) == "" {
+		return sboms[0]
+	}
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || params["q"] == "0" {
+			continue
+		}
+		var sbom *hub.SBOM
+		switch mediaType {
+		case "*/*":
+			sbom = sboms[0]
+		case "application/json":
+			sbom = getSBOM(func(f string) bool { return strings.HasSuffix(f, "-json") })
+		default:
+			if f := pkg.SBOMFormatFromMediaType(mediaType); f != "" {
+				sbom = getSBOM(func(f2 string) bool { return f2 == f })
+			}
+		}
+		if sbom != nil {
+			return sbom
+		}
+	}
+	return nil
+}
@@ -6,12 +6,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/artifacthub/hub/internal/chartmirror"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
@@ -29,6 +31,165 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func TestAddVulnerabilitySuppression(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID"},
+			Values: []string{"packageID"},
+		},
+	}
+	sJSON := `{"vulnerability_id": "CVE-2021-0001", "justification": "Vulnerable code not used"}`
+	s := &hub.VulnerabilitySuppression{
+		VulnerabilityID: "CVE-2021-0001",
+		Justification:   "Vulnerable code not used",
+	}
+
+	t.Run("invalid suppression provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.AddVulnerabilitySuppression(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error adding suppression", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(sJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("AddVulnerabilitySuppression", r.Context(), "packageID", s).Return(tc.err)
+				hw.h.AddVulnerabilitySuppression(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("suppression added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(sJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("AddVulnerabilitySuppression", r.Context(), "packageID", s).Return(nil)
+		hw.h.AddVulnerabilitySuppression(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestDeleteVulnerabilitySuppression(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "vulnerabilityID"},
+			Values: []string{"packageID", "CVE-2021-0001"},
+		},
+	}
+
+	t.Run("error deleting suppression", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("DeleteVulnerabilitySuppression", r.Context(), "packageID", "CVE-2021-0001").Return(tc.err)
+				hw.h.DeleteVulnerabilitySuppression(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("suppression deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("DeleteVulnerabilitySuppression", r.Context(), "packageID", "CVE-2021-0001").Return(nil)
+		hw.h.DeleteVulnerabilitySuppression(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
 func TestGet(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -102,6 +263,175 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestDownloadChartArchive(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("error getting chart archive", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFake,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.cm.On("GetChartArchive", r.Context(), "pkg1", "1.0.0").Return(nil, "", tc.err)
+				hw.h.DownloadChartArchive(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("chart archive downloaded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		archive := ioutil.NopCloser(strings.NewReader("archive"))
+		hw.cm.On("GetChartArchive", r.Context(), "pkg1", "1.0.0").Return(archive, "pkg1-1.0.0.tgz", nil)
+		hw.h.DownloadChartArchive(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/gzip", h.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="pkg1-1.0.0.tgz"`, h.Get("Content-Disposition"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(24*time.Hour), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("archive"), data)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestGetCard(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "packageName"},
+			Values: []string{"repo1", "pkg1"},
+		},
+	}
+	input := &hub.GetPackageInput{
+		RepositoryName: "repo1",
+		PackageName:    "pkg1",
+	}
+
+	t.Run("get package summary failed", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSummaryJSON", r.Context(), input).Return(nil, tc.pmErr)
+				hw.h.GetCard(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get package card succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetSummaryJSON", r.Context(), input).Return([]byte(`{
+			"package_id": "0001",
+			"name": "pkg1",
+			"normalized_name": "pkg1",
+			"stars": 10,
+			"description": "description",
+			"logo_image_id": "0001",
+			"version": "1.0.0",
+			"signed": true,
+			"ts": 1592299234,
+			"repository": {
+				"name": "repo1",
+				"kind": 0,
+				"verified_publisher": true,
+				"organization_name": "org1"
+			}
+		}`), nil)
+		hw.h.GetCard(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{
+			"package_id": "0001",
+			"name": "pkg1",
+			"description": "description",
+			"version": "1.0.0",
+			"kind": "helm",
+			"repository_name": "repo1",
+			"publisher": "org1",
+			"url": "baseURL/packages/helm/repo1/pkg1",
+			"logo_url": "baseURL/image/0001@2x",
+			"stars": 10,
+			"official": false,
+			"verified_publisher": true,
+			"signed": true,
+			"ts": 1592299234
+		}`, string(data))
+		hw.assertExpectations(t)
+	})
+}
+
 func TestGetChangeLog(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -450,21 +780,131 @@ func TestGetRandom(t *testing.T) {
 	})
 }
 
-func TestGetSnapshotSecurityReport(t *testing.T) {
+func TestGetSBOM(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
 			Keys:   []string{"packageID", "version"},
 			Values: []string{"pkg1", "1.0.0"},
 		},
 	}
+	sboms := []*hub.SBOM{
+		{Format: pkg.SBOMFormatSPDX, Content: "SPDXVersion: SPDX-2.2"},
+		{Format: pkg.SBOMFormatCycloneDXJSON, Content: `{"bomFormat": "CycloneDX"}`},
+	}
 
-	t.Run("get snapshot security report succeeded", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
-
-		hw := newHandlersWrapper()
+	t.Run("error getting sboms", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{hub.ErrNotFound, http.StatusNotFound},
+			{tests.ErrFakeDB, http.StatusInternalServerError},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSBOMs", r.Context(), "pkg1", "1.0.0").Return(nil, tc.err)
+				hw.h.GetSBOM(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("sbom not available in the format requested", func(t *testing.T) {
+		testCases := []struct {
+			target string
+			accept string
+		}{
+			{"/?format=spdx-json", ""},
+			{"/", "application/vnd.cyclonedx+xml"},
+			{"/", "text/html, application/spdx+json"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.target+" "+tc.accept, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", tc.target, nil)
+				r.Header.Set("Accept", tc.accept)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSBOMs", r.Context(), "pkg1", "1.0.0").Return(sboms, nil)
+				hw.h.GetSBOM(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get sbom succeeded", func(t *testing.T) {
+		testCases := []struct {
+			target              string
+			accept              string
+			expectedContentType string
+			expectedContent     string
+		}{
+			{"/", "", "text/spdx", "SPDXVersion: SPDX-2.2"},
+			{"/", "*/*", "text/spdx", "SPDXVersion: SPDX-2.2"},
+			{"/", "application/json", "application/vnd.cyclonedx+json", `{"bomFormat": "CycloneDX"}`},
+			{"/", "text/html, application/vnd.cyclonedx+json;q=0.9", "application/vnd.cyclonedx+json", `{"bomFormat": "CycloneDX"}`},
+			{"/?format=cyclonedx-json", "text/spdx", "application/vnd.cyclonedx+json", `{"bomFormat": "CycloneDX"}`},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.target+" "+tc.accept, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", tc.target, nil)
+				r.Header.Set("Accept", tc.accept)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetSBOMs", r.Context(), "pkg1", "1.0.0").Return(sboms, nil)
+				hw.h.GetSBOM(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, tc.expectedContentType, h.Get("Content-Type"))
+				assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+				assert.Equal(t, tc.expectedContent, string(data))
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetSnapshotSecurityReport(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"pkg1", "1.0.0"},
+		},
+	}
+
+	t.Run("get snapshot security report succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
 		hw.pm.On("GetSnapshotSecurityReportJSON", r.Context(), "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
 		hw.h.GetSnapshotSecurityReport(w, r)
 		resp := w.Result()
@@ -710,6 +1150,74 @@ func TestGetSummary(t *testing.T) {
 	})
 }
 
+func TestGetValuesDiff(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID"},
+			Values: []string{"pkg1"},
+		},
+	}
+
+	t.Run("get values diff succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?from=1.0.0&to=2.0.0", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetValuesDiffJSON", r.Context(), "pkg1", "1.0.0", "2.0.0").Return([]byte("dataJSON"), nil)
+		hw.h.GetValuesDiff(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting values diff", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?from=1.0.0", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetValuesDiffJSON", r.Context(), "pkg1", "1.0.0", "").Return(nil, tc.err)
+				hw.h.GetValuesDiff(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestGetValuesSchema(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -756,13 +1264,65 @@ func TestGetValuesSchema(t *testing.T) {
 	})
 }
 
+func TestGetVulnerabilitiesSuppressions(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID"},
+			Values: []string{"packageID"},
+		},
+	}
+
+	t.Run("get vulnerabilities suppressions succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetVulnerabilitiesSuppressionsJSON", r.Context(), "packageID").Return([]byte("dataJSON"), nil)
+		hw.h.GetVulnerabilitiesSuppressions(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error getting vulnerabilities suppressions", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetVulnerabilitiesSuppressionsJSON", r.Context(), "packageID").Return(nil, tests.ErrFakeDB)
+		hw.h.GetVulnerabilitiesSuppressions(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
 func TestInjectIndexMeta(t *testing.T) {
 	checkIndexMeta := func(expectedTitle, expectedDescription interface{}) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
 			title, _ := r.Context().Value(hub.IndexMetaTitleKey).(string)
 			description, _ := r.Context().Value(hub.IndexMetaDescriptionKey).(string)
+			oEmbedURL, _ := r.Context().Value(hub.IndexMetaOEmbedURLKey).(string)
 			assert.Equal(t, expectedTitle, title)
 			assert.Equal(t, expectedDescription, description)
+			if expectedTitle != "" {
+				assert.Equal(t, "baseURL/api/v1/oembed?format=json&url=baseURL%2Fpackages%2Fhelm%2Frepo1%2Fpkg1", oEmbedURL)
+			} else {
+				assert.Empty(t, oEmbedURL)
+			}
 		}
 	}
 	testCases := []struct {
@@ -810,7 +1370,7 @@ func TestInjectIndexMeta(t *testing.T) {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
 			t.Parallel()
 			w := httptest.NewRecorder()
-			r, _ := http.NewRequest("GET", "/", nil)
+			r, _ := http.NewRequest("GET", "/packages/helm/repo1/pkg1", nil)
 
 			hw := newHandlersWrapper()
 			if tc.err == nil {
@@ -828,18 +1388,138 @@ func TestInjectIndexMeta(t *testing.T) {
 	}
 }
 
-func TestRssFeed(t *testing.T) {
-	os.Setenv("TZ", "")
+func TestOEmbed(t *testing.T) {
+	pkgURL := url.QueryEscape("https://artifacthub.io/packages/helm/repo1/pkg1")
 
-	t.Run("error getting rss feed package", func(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
 		testCases := []struct {
-			pmErr              error
+			desc               string
+			qs                 string
 			expectedStatusCode int
+		}{
+			{"format not supported", "format=xml&url=" + pkgURL, http.StatusNotImplemented},
+			{"url not provided", "format=json", http.StatusBadRequest},
+			{"invalid maxwidth", "maxwidth=z&url=" + pkgURL, http.StatusBadRequest},
+			{"maxwidth too small", "maxwidth=100&url=" + pkgURL, http.StatusNotFound},
+			{"maxheight too small", "maxheight=100&url=" + pkgURL, http.StatusNotFound},
+			{"invalid theme", "theme=blue&url=" + pkgURL, http.StatusBadRequest},
+			{"url from another site", "url=" + url.QueryEscape("https://example.com/packages/helm/repo1/pkg1"), http.StatusNotFound},
+			{"url not a package", "url=" + url.QueryEscape("https://artifacthub.io/stats"), http.StatusNotFound},
+			{"invalid kind", "url=" + url.QueryEscape("https://artifacthub.io/packages/unknown/repo1/pkg1"), http.StatusNotFound},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.desc, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+tc.qs, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.cfg.Set("server.baseURL", "https://artifacthub.io")
+				hw.h.OEmbed(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get package summary failed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?url="+pkgURL, nil)
+
+		hw := newHandlersWrapper()
+		hw.h.cfg.Set("server.baseURL", "https://artifacthub.io")
+		hw.pm.On("GetSummaryJSON", r.Context(), &hub.GetPackageInput{
+			RepositoryName: "repo1",
+			PackageName:    "pkg1",
+		}).Return(nil, hub.ErrNotFound)
+		hw.h.OEmbed(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("oembed response returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		versionURL := url.QueryEscape("https://artifacthub.io/packages/helm/repo1/pkg1/1.0.0")
+		r, _ := http.NewRequest("GET", "/?format=json&maxwidth=500&theme=dark&url="+versionURL, nil)
+
+		hw := newHandlersWrapper()
+		hw.h.cfg.Set("server.baseURL", "https://artifacthub.io")
+		hw.pm.On("GetSummaryJSON", r.Context(), &hub.GetPackageInput{
+			RepositoryName: "repo1",
+			PackageName:    "pkg1",
+		}).Return([]byte(`{
+			"package_id": "0001",
+			"name": "pkg1",
+			"normalized_name": "pkg1",
+			"stars": 10,
+			"description": "description",
+			"logo_image_id": "0001",
+			"version": "1.0.0",
+			"signed": true,
+			"ts": 1592299234,
+			"repository": {
+				"name": "repo1",
+				"kind": 0,
+				"verified_publisher": true,
+				"organization_name": "org1"
+			}
+		}`), nil)
+		hw.h.OEmbed(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.JSONEq(t, `{
+			"type": "rich",
+			"version": "1.0",
+			"title": "pkg1",
+			"author_name": "org1",
+			"provider_name": "Artifact Hub",
+			"provider_url": "https://artifacthub.io",
+			"cache_age": 300,
+			"thumbnail_url": "https://artifacthub.io/image/0001@2x",
+			"html": "<div class=\"artifacthub-widget\" data-url=\"https://artifacthub.io/packages/helm/repo1/pkg1\" data-theme=\"dark\" data-header=\"true\" data-responsive=\"false\"><blockquote><p lang=\"en\" dir=\"ltr\"><b>pkg1</b>: description</p>&mdash; Open in <a href=\"https://artifacthub.io/packages/helm/repo1/pkg1\">Artifact Hub</a></blockquote></div><script async src=\"https://artifacthub.io/artifacthub-widget.js\"></script>",
+			"width": 350,
+			"height": 185
+		}`, string(data))
+		hw.assertExpectations(t)
+	})
+}
+
+func TestRequestSnapshotSecurityReportRescan(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"packageID", "1.0.0"},
+		},
+	}
+
+	t.Run("error requesting rescan", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
 		}{
 			{
 				hub.ErrInvalidInput,
 				http.StatusBadRequest,
 			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
 			{
 				hub.ErrNotFound,
 				http.StatusNotFound,
@@ -851,36 +1531,96 @@ func TestRssFeed(t *testing.T) {
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+			t.Run(tc.err.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/", nil)
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.pm.On("Get", r.Context(), mock.Anything).Return(nil, tc.pmErr)
-				hw.h.RssFeed(w, r)
+				hw.pm.On("RequestSnapshotSecurityReportRescan", r.Context(), "packageID", "1.0.0").Return(tc.err)
+				hw.h.RequestSnapshotSecurityReportRescan(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
-				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
 				hw.assertExpectations(t)
 			})
 		}
 	})
 
-	t.Run("rss feed built successfully", func(t *testing.T) {
-		testCases := []struct {
-			p                   *hub.Package
-			expectedRssFeedData []byte
-		}{
-			{
-				&hub.Package{
-					PackageID:      "0001",
-					NormalizedName: "pkg1",
-					Description:    "description",
-					Version:        "1.0.0",
-					LogoImageID:    "0001",
-					TS:             1592299234,
+	t.Run("rescan requested successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("RequestSnapshotSecurityReportRescan", r.Context(), "packageID", "1.0.0").Return(nil)
+		hw.h.RequestSnapshotSecurityReportRescan(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestPackageFeed(t *testing.T) {
+	os.Setenv("TZ", "")
+
+	t.Run("error getting feed package", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+
+				hw := newHandlersWrapper()
+				hw.pm.On("Get", r.Context(), mock.Anything).Return(nil, tc.pmErr)
+				hw.h.PackageFeed(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("rss feed built successfully", func(t *testing.T) {
+		testCases := []struct {
+			p                   *hub.Package
+			expectedRssFeedData []byte
+		}{
+			{
+				&hub.Package{
+					PackageID:      "0001",
+					NormalizedName: "pkg1",
+					Description:    "description",
+					Version:        "1.0.0",
+					LogoImageID:    "0001",
+					TS:             1592299234,
 					AvailableVersions: []*hub.Version{
 						{
 							Version: "1.0.0",
@@ -890,6 +1630,11 @@ func TestRssFeed(t *testing.T) {
 							Version: "0.0.9",
 							TS:      1592299233,
 						},
+						{
+							Version: "1.1.0",
+							TS:      1592299235,
+							Yanked:  true,
+						},
 					},
 					Maintainers: []*hub.Maintainer{
 						{
@@ -940,7 +1685,7 @@ func TestRssFeed(t *testing.T) {
 
 				hw := newHandlersWrapper()
 				hw.pm.On("Get", r.Context(), mock.Anything).Return(tc.p, nil)
-				hw.h.RssFeed(w, r)
+				hw.h.PackageFeed(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 				h := resp.Header
@@ -954,6 +1699,249 @@ func TestRssFeed(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("atom feed built successfully", func(t *testing.T) {
+		t.Parallel()
+		rctx := &chi.Context{
+			URLParams: chi.RouteParams{
+				Keys:   []string{"format"},
+				Values: []string{"atom"},
+			},
+		}
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), mock.Anything).Return(&hub.Package{
+			PackageID:      "0001",
+			NormalizedName: "pkg1",
+			AvailableVersions: []*hub.Version{
+				{
+					Version: "1.0.0",
+					TS:      1592299234,
+				},
+			},
+			Repository: &hub.Repository{
+				Name:      "repo1",
+				UserAlias: "user1",
+			},
+		}, nil)
+		hw.h.PackageFeed(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Contains(t, string(data), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+		assert.Contains(t, string(data), `<title>user1/pkg1 (Artifact Hub)</title>`)
+		assert.Contains(t, string(data), `<id>0001#1.0.0</id>`)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("cached feed is returned", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", mock.Anything, mock.Anything).Return(&hub.Package{
+			PackageID:      "0001",
+			NormalizedName: "pkg1",
+			Repository: &hub.Repository{
+				Name:      "repo1",
+				UserAlias: "user1",
+			},
+		}, nil).Once()
+
+		var feeds [][]byte
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/feed/rss", nil)
+			hw.h.PackageFeed(w, r)
+			resp := w.Result()
+			data, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			feeds = append(feeds, data)
+		}
+		assert.Equal(t, feeds[0], feeds[1])
+		hw.assertExpectations(t)
+	})
+}
+
+func TestPublisherFeed(t *testing.T) {
+	os.Setenv("TZ", "")
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"publisher", "format"},
+			Values: []string{"org1", "rss"},
+		},
+	}
+	input := &hub.GetRecentReleasesInput{
+		Publisher: "org1",
+		Limit:     recentReleasesLimit,
+	}
+
+	t.Run("error getting recent releases", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetRecentReleases", r.Context(), input).Return(nil, tc.pmErr)
+				hw.h.PublisherFeed(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("feed built successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRecentReleases", r.Context(), input).Return([]*hub.Package{
+			{
+				PackageID:      "0001",
+				NormalizedName: "pkg1",
+				Description:    "description",
+				Version:        "1.0.0",
+				TS:             1592299234,
+				Repository: &hub.Repository{
+					Name:             "repo1",
+					OrganizationName: "org1",
+				},
+			},
+		}, nil)
+		hw.h.PublisherFeed(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte(`<?xml version="1.0" encoding="UTF-8"?><rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>org1 (Artifact Hub)</title>
+    <link>baseURL</link>
+    <description>Recent releases of the packages published by org1</description>
+    <item>
+      <title>pkg1 1.0.0</title>
+      <link>baseURL/packages/helm/repo1/pkg1/1.0.0</link>
+      <description>description</description>
+      <guid>0001#1.0.0</guid>
+      <pubDate>Tue, 16 Jun 2020 09:20:34 +0000</pubDate>
+    </item>
+  </channel>
+</rss>`), data)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestRepositoryFeed(t *testing.T) {
+	os.Setenv("TZ", "")
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName", "format"},
+			Values: []string{"repo1", "rss"},
+		},
+	}
+	input := &hub.GetRecentReleasesInput{
+		RepositoryName: "repo1",
+		Limit:          recentReleasesLimit,
+	}
+
+	t.Run("error getting recent releases", func(t *testing.T) {
+		testCases := []struct {
+			pmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.pmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("GetRecentReleases", r.Context(), input).Return(nil, tc.pmErr)
+				hw.h.RepositoryFeed(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("feed built successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("GetRecentReleases", r.Context(), input).Return([]*hub.Package{
+			{
+				PackageID:      "0001",
+				NormalizedName: "pkg1",
+				Version:        "1.0.0",
+				TS:             1592299234,
+				Repository: &hub.Repository{
+					Name:      "repo1",
+					UserAlias: "user1",
+				},
+			},
+		}, nil)
+		hw.h.RepositoryFeed(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Contains(t, string(data), `<title>repo1 (Artifact Hub)</title>`)
+		assert.Contains(t, string(data), `<description>Recent releases of the packages in the repo1 repository</description>`)
+		assert.Contains(t, string(data), `<link>baseURL/packages/helm/repo1/pkg1/1.0.0</link>`)
+		hw.assertExpectations(t)
+	})
 }
 
 func TestSearch(t *testing.T) {
@@ -1003,6 +1991,26 @@ func TestSearch(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("fuzzy search enabled", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?ts_query_web=prometeus", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.cfg.Set("server.search.fuzzy", true)
+		hw.pm.On("SearchJSON", r.Context(), &hub.SearchPackageInput{
+			TSQueryWeb:      "prometeus",
+			RepositoryKinds: []hub.RepositoryKind{},
+			Fuzzy:           true,
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
 	t.Run("valid request, search succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
@@ -1137,6 +2145,173 @@ func TestToggleStar(t *testing.T) {
 	})
 }
 
+func TestUnyankVersion(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"packageID", "1.0.0"},
+		},
+	}
+
+	t.Run("error unyanking version", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("UnyankVersion", r.Context(), "packageID", "1.0.0").Return(tc.err)
+				hw.h.UnyankVersion(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("version unyanked successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("UnyankVersion", r.Context(), "packageID", "1.0.0").Return(nil)
+		hw.h.UnyankVersion(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+}
+
+func TestYankVersion(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"packageID", "version"},
+			Values: []string{"packageID", "1.0.0"},
+		},
+	}
+
+	t.Run("invalid input provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.YankVersion(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.assertExpectations(t)
+	})
+
+	t.Run("error yanking version", func(t *testing.T) {
+		testCases := []struct {
+			err            error
+			expectedStatus int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"reason": "Broken release"}`))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("YankVersion", r.Context(), "packageID", "1.0.0", "Broken release").Return(tc.err)
+				hw.h.YankVersion(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("version yanked successfully", func(t *testing.T) {
+		testCases := []struct {
+			desc           string
+			body           string
+			expectedReason string
+		}{
+			{"reason provided", `{"reason": "Broken release"}`, "Broken release"},
+			{"no body provided", "", ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.desc, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.body))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.pm.On("YankVersion", r.Context(), "packageID", "1.0.0", tc.expectedReason).Return(nil)
+				hw.h.YankVersion(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+				hw.assertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestBuildPackageURL(t *testing.T) {
 	baseURL := "http://localhost:8000"
 	testCases := []struct {
@@ -1254,6 +2429,39 @@ func TestBuildPackageURL(t *testing.T) {
 			"2.0.0",
 			baseURL + "/packages/keda-scaler/repo1/pkg1/2.0.0",
 		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.Wasm,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/wasm/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.CrossplaneConfiguration,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/crossplane-configuration/repo1/pkg1/2.0.0",
+		},
+		{
+			&hub.Package{
+				NormalizedName: "pkg1",
+				Repository: &hub.Repository{
+					Kind: hub.OCIArtifact,
+					Name: "repo1",
+				},
+			},
+			"2.0.0",
+			baseURL + "/packages/oci-artifact/repo1/pkg1/2.0.0",
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -1267,6 +2475,7 @@ func TestBuildPackageURL(t *testing.T) {
 type handlersWrapper struct {
 	pm *pkg.ManagerMock
 	rm *repo.ManagerMock
+	cm *chartmirror.MirrorMock
 	hc *tests.HTTPClientMock
 	h  *Handlers
 }
@@ -1276,18 +2485,21 @@ func newHandlersWrapper() *handlersWrapper {
 	cfg.Set("server.baseURL", "baseURL")
 	pm := &pkg.ManagerMock{}
 	rm := &repo.ManagerMock{}
+	cm := &chartmirror.MirrorMock{}
 	hc := &tests.HTTPClientMock{}
 
 	return &handlersWrapper{
 		pm: pm,
 		rm: rm,
+		cm: cm,
 		hc: hc,
-		h:  NewHandlers(pm, rm, cfg, hc),
+		h:  NewHandlers(pm, rm, cm, cfg, hc),
 	}
 }
 
 func (hw *handlersWrapper) assertExpectations(t *testing.T) {
 	hw.pm.AssertExpectations(t)
 	hw.rm.AssertExpectations(t)
+	hw.cm.AssertExpectations(t)
 	hw.hc.AssertExpectations(t)
 }
@@ -0,0 +1,103 @@
+package pulls
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pulls"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling packages
+// pulls statistics operations.
+type Handlers struct {
+	pullsManager hub.PullsManager
+	logger       zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(pullsManager hub.PullsManager) *Handlers {
+	return &Handlers{
+		pullsManager: pullsManager,
+		logger:       log.With().Str("handlers", "pulls").Logger(),
+	}
+}
+
+// GetPackagePulls is an http handler that returns the daily pull counts of
+// the provided package.
+func (h *Handlers) GetPackagePulls(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	days := pulls.DefaultDays
+	if v := r.FormValue("days"); v != "" {
+		var err error
+		days, err = strconv.Atoi(v)
+		if err != nil {
+			err = fmt.Errorf("%w: invalid days: %s", hub.ErrInvalidInput, v)
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+	}
+	dataJSON, err := h.pullsManager.GetPackagePullsJSON(r.Context(), packageID, days)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetPackagePulls").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 1*time.Hour, http.StatusOK)
+}
+
+// GetTrending is an http handler that returns the packages with the highest
+// number of pulls during the last week.
+func (h *Handlers) GetTrending(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetTrendingPackagesInput{}
+	if v := r.FormValue("kind"); v != "" {
+		kind, err := hub.GetKindFromName(v)
+		if err != nil {
+			err = fmt.Errorf("%w: invalid kind: %s", hub.ErrInvalidInput, v)
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		input.Kind = &kind
+	}
+	if v := r.FormValue("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			err = fmt.Errorf("%w: invalid limit: %s", hub.ErrInvalidInput, v)
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		input.Limit = limit
+	}
+	dataJSON, err := h.pullsManager.GetTrendingPackagesJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTrending").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 1*time.Hour, http.StatusOK)
+}
+
+// Register is an http handler that registers the daily pull counts provided
+// for the packages of the repository provided.
+func (h *Handlers) Register(w http.ResponseWriter, r *http.Request) {
+	var p []*hub.PackagePulls
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		h.logger.Error().Err(err).Str("method", "Register").Msg("invalid pulls")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.pullsManager.Register(r.Context(), repoName, p); err != nil {
+		h.logger.Error().Err(err).Str("method", "Register").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
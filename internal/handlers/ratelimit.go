@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/handlers/user"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimiterIdleTTL represents the time after which the limiter of a
+	// client that hasn't sent any request is discarded.
+	rateLimiterIdleTTL = 10 * time.Minute
+)
+
+// defaultRateLimits represents the default limits used by each of the rate
+// limiters, expressed as the number of requests allowed in a given period.
+var defaultRateLimits = map[string]struct {
+	requests int
+	period   time.Duration
+}{
+	"abuseReports": {requests: 5, period: time.Hour},
+	"auth":         {requests: 10, period: time.Minute},
+	"comments":     {requests: 10, period: time.Hour},
+	"dryRun":       {requests: 5, period: time.Minute},
+	"images":       {requests: 20, period: time.Minute},
+	"rescan":       {requests: 10, period: time.Hour},
+	"search":       {requests: 120, period: time.Minute},
+}
+
+// errRateLimited represents the error returned to the clients that have
+// exceeded the rate allowed.
+var errRateLimited = errors.New("too many requests, please try again later")
+
+var rateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_rate_limited_requests_total",
+	Help: "Number of http requests rejected by the rate limiters.",
+}, []string{"limiter"})
+
+func init() {
+	prometheus.MustRegister(rateLimitedRequests)
+}
+
+// rateLimiter is an http middleware that limits the rate of the requests
+// processed from each client using a token bucket. Clients are identified by
+// the API key used, when available, or by their IP address.
+type rateLimiter struct {
+	name   string
+	limit  rate.Limit
+	burst  int
+	now    func() time.Time
+	denied prometheus.Counter
+
+	mu          sync.Mutex
+	clients     map[string]*rateLimiterClient
+	lastCleanup time.Time
+}
+
+// rateLimiterClient represents the limiter of a given client.
+type rateLimiterClient struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// newRateLimiter creates a new rateLimiter instance that allows up to the
+// number of requests provided per period, per client.
+func newRateLimiter(name string, requests int, period time.Duration) *rateLimiter {
+	return &rateLimiter{
+		name:    name,
+		limit:   rate.Every(period / time.Duration(requests)),
+		burst:   requests,
+		now:     time.Now,
+		denied:  rateLimitedRequests.WithLabelValues(name),
+		clients: make(map[string]*rateLimiterClient),
+	}
+}
+
+// rateLimitMiddleware returns a rate limiting http middleware for the limiter
+// provided, setup using the configuration given. When rate limiting is not
+// enabled, the middleware returned does nothing.
+func rateLimitMiddleware(cfg *viper.Viper, name string) func(next http.Handler) http.Handler {
+	if !cfg.GetBool("server.rateLimit.enabled") {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	defaults := defaultRateLimits[name]
+	cfg.SetDefault("server.rateLimit."+name+".requests", defaults.requests)
+	cfg.SetDefault("server.rateLimit."+name+".period", defaults.period)
+	requests := cfg.GetInt("server.rateLimit." + name + ".requests")
+	period := cfg.GetDuration("server.rateLimit." + name + ".period")
+	if requests <= 0 || period <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return newRateLimiter(name, requests, period).Handler
+}
+
+// Handler is an http middleware that rejects the requests of the clients that
+// have exceeded the rate allowed, letting them know when they can try again.
+func (rl *rateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if delay := rl.reserve(clientKey(r)); delay > 0 {
+			rl.denied.Inc()
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			helpers.RenderErrorWithCodeJSON(w, errRateLimited, http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// reserve tries to take a token from the bucket of the client provided. When
+// no tokens are available, the time the client has to wait until the next one
+// will be available is returned.
+func (rl *rateLimiter) reserve(key string) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	rl.cleanup(now)
+	c, ok := rl.clients[key]
+	if !ok {
+		c = &rateLimiterClient{limiter: rate.NewLimiter(rl.limit, rl.burst)}
+		rl.clients[key] = c
+	}
+	c.lastSeen = now
+	reservation := c.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// cleanup discards the limiters of the clients that have been idle for a
+// while. It must be called with the mutex held.
+func (rl *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(rl.lastCleanup) < rateLimiterIdleTTL {
+		return
+	}
+	for key, c := range rl.clients {
+		if now.Sub(c.lastSeen) >= rateLimiterIdleTTL {
+			delete(rl.clients, key)
+		}
+	}
+	rl.lastCleanup = now
+}
+
+// clientKey returns the key used to identify the client that sent the request
+// provided: the API key id when the request has been authenticated using an API
+// key, or the client IP address otherwise. API keys ids are only used once they
+// have been checked, as otherwise they could be forged to bypass the limits.
+func clientKey(r *http.Request) string {
+	apiKeyID := r.Header.Get(user.APIKeyIDHeader)
+	if _, authenticated := r.Context().Value(hub.UserIDKey).(string); authenticated && apiKeyID != "" {
+		return "apikey:" + apiKeyID
+	}
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return "ip:" + ip
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/handlers/user"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	send := func(rl *rateLimiter, r *http.Request) *http.Response {
+		w := httptest.NewRecorder()
+		rl.Handler(next).ServeHTTP(w, r)
+		return w.Result()
+	}
+	newRequest := func(remoteAddr string) *http.Request {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.RemoteAddr = remoteAddr
+		return r
+	}
+
+	t.Run("requests exceeding the rate allowed are rejected", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		rl := newRateLimiter("test1", 2, time.Minute)
+		rl.now = func() time.Time { return now }
+
+		for i := 0; i < 2; i++ {
+			resp := send(rl, newRequest("1.1.1.1:1234"))
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+		resp := send(rl, newRequest("1.1.1.1:1234"))
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+
+		// Other clients are not affected
+		resp = send(rl, newRequest("2.2.2.2:1234"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		// Tokens are refilled over time
+		now = now.Add(30 * time.Second)
+		resp = send(rl, newRequest("1.1.1.1:1234"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("authenticated requests using api keys are limited per api key", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		rl := newRateLimiter("test2", 1, time.Minute)
+		rl.now = func() time.Time { return now }
+		newAPIKeyRequest := func(apiKeyID string) *http.Request {
+			r := newRequest("1.1.1.1:1234")
+			r.Header.Set(user.APIKeyIDHeader, apiKeyID)
+			return r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		}
+
+		resp := send(rl, newAPIKeyRequest("key1"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = send(rl, newAPIKeyRequest("key1"))
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		resp = send(rl, newAPIKeyRequest("key2"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("unauthenticated requests with api keys are limited per ip", func(t *testing.T) {
+		t.Parallel()
+		rl := newRateLimiter("test3", 1, time.Minute)
+		newAPIKeyRequest := func(apiKeyID string) *http.Request {
+			r := newRequest("1.1.1.1:1234")
+			r.Header.Set(user.APIKeyIDHeader, apiKeyID)
+			return r
+		}
+
+		resp := send(rl, newAPIKeyRequest("key1"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = send(rl, newAPIKeyRequest("key2"))
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	t.Run("idle clients limiters are discarded", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		rl := newRateLimiter("test4", 1, time.Minute)
+		rl.now = func() time.Time { return now }
+
+		send(rl, newRequest("1.1.1.1:1234"))
+		now = now.Add(rateLimiterIdleTTL)
+		send(rl, newRequest("2.2.2.2:1234"))
+		assert.Len(t, rl.clients, 1)
+		assert.Contains(t, rl.clients, "ip:2.2.2.2")
+	})
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("rate limiting disabled", func(t *testing.T) {
+		t.Parallel()
+		mw := rateLimitMiddleware(viper.New(), "auth")
+		for i := 0; i < defaultRateLimits["auth"].requests+1; i++ {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			mw(next).ServeHTTP(w, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+	})
+
+	t.Run("rate limiting enabled", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.rateLimit.enabled", true)
+		cfg.Set("server.rateLimit.auth.requests", 1)
+		mw := rateLimitMiddleware(cfg, "auth")
+		codes := make([]int, 0, 2)
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.RemoteAddr = "1.1.1.1:1234"
+			mw(next).ServeHTTP(w, r)
+			codes = append(codes, w.Code)
+		}
+		assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+}
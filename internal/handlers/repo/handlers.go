@@ -2,7 +2,12 @@ package repo
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
@@ -12,6 +17,14 @@ import (
 )
 
 const (
+	// trackingWebhookMaxPayloadSize represents the maximum size of the
+	// payload accepted by the repository tracking webhook.
+	trackingWebhookMaxPayloadSize = 1 << 20
+
+	// defaultSearchLimit represents the default number of repositories
+	// returned per page when searching.
+	defaultSearchLimit = 20
+
 	logoSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="14" height="14" viewBox="0 0 24 24" fill="none" stroke="#ffffff" stroke-width="2" stroke-linecap="round" stroke-linejoin="round" class="feather feather-hexagon"><path d="M21 16V8a2 2 0 0 0-1-1.73l-7-4a2 2 0 0 0-2 0l-7 4A2 2 0 0 0 3 8v8a2 2 0 0 0 1 1.73l7 4a2 2 0 0 0 2 0l7-4A2 2 0 0 0 21 16z"></path></svg>`
 )
 
@@ -90,10 +103,27 @@ func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
 
 // ClaimOwnership is an http handler used to claim the ownership of a given
 // repository, transferring it to the selected entity if the requesting user
-// has permissions to do so.
+// has permissions to do so. When a dns or http proof method is requested, the
+// claim is registered to be verified asynchronously and the details of the
+// proof expected are returned.
 func (h *Handlers) ClaimOwnership(w http.ResponseWriter, r *http.Request) {
 	repoName := chi.URLParam(r, "repoName")
 	orgName := r.FormValue("org")
+	if method := r.FormValue("method"); method != "" && method != "email" {
+		dataJSON, err := h.repoManager.RequestOwnershipClaim(
+			r.Context(),
+			repoName,
+			orgName,
+			hub.OwnershipProofMethod(method),
+		)
+		if err != nil {
+			h.logger.Error().Err(err).Str("method", "ClaimOwnership").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		helpers.RenderJSON(w, dataJSON, 0, http.StatusAccepted)
+		return
+	}
 	if err := h.repoManager.ClaimOwnership(r.Context(), repoName, orgName); err != nil {
 		h.logger.Error().Err(err).Str("method", "ClaimOwnership").Send()
 		helpers.RenderErrorJSON(w, err)
@@ -114,6 +144,38 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeleteTrackingWebhook is an http handler that disables the tracking webhook
+// of the provided repository.
+func (h *Handlers) DeleteTrackingWebhook(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	if _, err := h.repoManager.UpdateTrackingWebhook(r.Context(), repoName, false); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteTrackingWebhook").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DryRun is an http handler that processes the provided repository using the
+// tracker in dry-run mode, returning the packages that would be registered and
+// the errors found. Nothing is persisted.
+func (h *Handlers) DryRun(w http.ResponseWriter, r *http.Request) {
+	repo := &hub.Repository{}
+	if err := json.NewDecoder(r.Body).Decode(&repo); err != nil {
+		h.logger.Error().Err(err).Str("method", "DryRun").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	result, err := h.repoManager.DryRun(r.Context(), repo)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "DryRun").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(result)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetAll is an http handler that returns all the repositories available.
 func (h *Handlers) GetAll(w http.ResponseWriter, r *http.Request) {
 	dataJSON, err := h.repoManager.GetAllJSON(r.Context(), false)
@@ -169,6 +231,93 @@ func (h *Handlers) GetOwnedByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// GetOwnershipClaim is an http handler that returns the ownership claim
+// requested by the user doing the request for the provided repository.
+func (h *Handlers) GetOwnershipClaim(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.repoManager.GetOwnershipClaimJSON(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetOwnershipClaim").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetTrackingStatus is an http handler that returns the tracking status of the
+// provided repository, including a summary of its most recent tracking runs.
+func (h *Handlers) GetTrackingStatus(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	dataJSON, err := h.repoManager.GetTrackingStatusJSON(r.Context(), repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetTrackingStatus").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// PauseTracking is an http handler that pauses the tracking of the provided
+// repository.
+func (h *Handlers) PauseTracking(w http.ResponseWriter, r *http.Request) {
+	h.updateTrackingEnabled(w, r, false, "PauseTracking")
+}
+
+// RequestTracking is an http handler that schedules the tracking of the
+// provided repository. It's meant to be called from CI workflows after
+// publishing new content, and requests must include the HMAC-SHA256 signature
+// of the payload in the X-ArtifactHub-Signature header (X-Hub-Signature-256 is
+// supported as well, so that payloads signed by GitHub can be forwarded).
+func (h *Handlers) RequestTracking(w http.ResponseWriter, r *http.Request) {
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, trackingWebhookMaxPayloadSize+1))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "RequestTracking").Msg("error reading body data")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if len(payload) > trackingWebhookMaxPayloadSize {
+		err := fmt.Errorf("payload exceeds the maximum size allowed (%d bytes)", trackingWebhookMaxPayloadSize)
+		helpers.RenderErrorWithCodeJSON(w, err, http.StatusRequestEntityTooLarge)
+		return
+	}
+	signature := r.Header.Get("X-ArtifactHub-Signature")
+	if signature == "" {
+		signature = r.Header.Get("X-Hub-Signature-256")
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.RequestTracking(r.Context(), repoName, payload, signature); err != nil {
+		h.logger.Error().Err(err).Str("method", "RequestTracking").Str("repoName", repoName).Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResumeTracking is an http handler that resumes the tracking of the provided
+// repository.
+func (h *Handlers) ResumeTracking(w http.ResponseWriter, r *http.Request) {
+	h.updateTrackingEnabled(w, r, true, "ResumeTracking")
+}
+
+// Search is an http handler used to search for repositories. Results can be
+// paginated using an offset or the cursor returned in the previous page.
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
+	input, err := buildSearchInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, err := h.repoManager.SearchJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
 // Transfer is an http handler that transfers the provided repository to a
 // different owner.
 func (h *Handlers) Transfer(w http.ResponseWriter, r *http.Request) {
@@ -199,3 +348,79 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// UpdateCredentials is an http handler that updates the credentials of the
+// provided repository in the database.
+func (h *Handlers) UpdateCredentials(w http.ResponseWriter, r *http.Request) {
+	c := &hub.RepositoryCredentials{}
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateCredentials").Msg("invalid credentials")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.UpdateCredentials(r.Context(), repoName, c); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateCredentials").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateTrackingWebhook is an http handler that enables the tracking webhook
+// of the provided repository, returning its url and a newly generated secret.
+func (h *Handlers) UpdateTrackingWebhook(w http.ResponseWriter, r *http.Request) {
+	repoName := chi.URLParam(r, "repoName")
+	wh, err := h.repoManager.UpdateTrackingWebhook(r.Context(), repoName, true)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateTrackingWebhook").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, _ := json.Marshal(wh)
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// updateTrackingEnabled is a helper used to pause or resume the tracking of
+// the provided repository.
+func (h *Handlers) updateTrackingEnabled(w http.ResponseWriter, r *http.Request, enabled bool, method string) {
+	repoName := chi.URLParam(r, "repoName")
+	if err := h.repoManager.UpdateTrackingEnabled(r.Context(), repoName, enabled); err != nil {
+		h.logger.Error().Err(err).Str("method", method).Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// buildSearchInput builds a repositories search input from the query string
+// provided.
+func buildSearchInput(qs url.Values) (*hub.SearchRepositoryInput, error) {
+	input := &hub.SearchRepositoryInput{
+		Limit:  defaultSearchLimit,
+		Cursor: qs.Get("cursor"),
+		Name:   qs.Get("name"),
+	}
+	if qs.Get("limit") != "" {
+		limit, err := strconv.Atoi(qs.Get("limit"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", qs.Get("limit"))
+		}
+		input.Limit = limit
+	}
+	if qs.Get("offset") != "" {
+		offset, err := strconv.Atoi(qs.Get("offset"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset: %s", qs.Get("offset"))
+		}
+		input.Offset = offset
+	}
+	for _, v := range qs["kind"] {
+		kind, err := hub.GetKindFromName(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kind: %s", v)
+		}
+		input.Kinds = append(input.Kinds, kind)
+	}
+	return input, nil
+}
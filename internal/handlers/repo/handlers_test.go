@@ -324,6 +324,64 @@ func TestClaimOwnership(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("ownership claim using dns proof", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"ownership claim requested successfully",
+				nil,
+				http.StatusAccepted,
+			},
+			{
+				"error requesting ownership claim (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error requesting ownership claim (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/?org=org1&method=dns", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName"},
+						Values: []string{"repo1"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				var dataJSON []byte
+				if tc.err == nil {
+					dataJSON = []byte("dataJSON")
+				}
+				hw.rm.On("RequestOwnershipClaim", r.Context(), "repo1", "org1", hub.OwnershipProofDNS).
+					Return(dataJSON, tc.err)
+				hw.h.ClaimOwnership(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				if tc.err == nil {
+					assert.Equal(t, []byte("dataJSON"), data)
+				}
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -391,6 +449,161 @@ func TestDelete(t *testing.T) {
 	})
 }
 
+func TestDeleteTrackingWebhook(t *testing.T) {
+	testCases := []struct {
+		description        string
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			"repository tracking webhook disabled",
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			"error disabling repository tracking webhook (insufficient privilege)",
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"error disabling repository tracking webhook (db error)",
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("DELETE", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"repoName"},
+					Values: []string{"repo1"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.rm.On("UpdateTrackingWebhook", r.Context(), "repo1", false).Return(nil, tc.err)
+			hw.h.DeleteTrackingWebhook(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.rm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	t.Run("invalid repository provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			repoJSON    string
+			rmErr       error
+		}{
+			{
+				"no repository provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid url",
+				`{"kind": 0, "url": "https://repo1.url"}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.repoJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				if tc.rmErr != nil {
+					hw.rm.On("DryRun", r.Context(), mock.Anything).Return(nil, tc.rmErr)
+				}
+				hw.h.DryRun(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error processing repository in dry-run mode", func(t *testing.T) {
+		t.Parallel()
+		repoJSON := `{"kind": 0, "url": "https://repo1.url"}`
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(repoJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("DryRun", r.Context(), mock.Anything).Return(nil, tests.ErrFake)
+		hw.h.DryRun(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("repository processed in dry-run mode successfully", func(t *testing.T) {
+		t.Parallel()
+		repoJSON := `{"kind": 0, "url": "https://repo1.url"}`
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(repoJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("DryRun", r.Context(), &hub.Repository{
+			Kind: hub.Helm,
+			URL:  "https://repo1.url",
+		}).Return(&hub.TrackerDryRunResult{
+			Packages: []*hub.TrackerDryRunPackage{
+				{
+					Name:    "pkg1",
+					Version: "1.0.0",
+				},
+			},
+			Errors: []*hub.RepositoryError{
+				{
+					PackageName:    "pkg2",
+					PackageVersion: "1.0.0",
+					Class:          hub.RepositoryErrorClassPackage,
+					Message:        "error",
+					TS:             1,
+				},
+			},
+		}, nil)
+		hw.h.DryRun(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"packages": [{"name": "pkg1", "version": "1.0.0"}],
+			"errors": [{"package_name": "pkg2", "package_version": "1.0.0", "class": "package", "message": "error", "ts": 1}]
+		}`, string(data))
+		hw.rm.AssertExpectations(t)
+	})
+}
+
 func TestGetAll(t *testing.T) {
 	t.Run("get all repositories succeeded", func(t *testing.T) {
 		t.Parallel()
@@ -598,63 +811,62 @@ func TestGetOwnedByUser(t *testing.T) {
 	})
 }
 
-func TestTransfer(t *testing.T) {
-	t.Run("invalid input - missing repo name", func(t *testing.T) {
+func TestGetOwnershipClaim(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("get ownership claim succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("PUT", "/", nil)
+		r, _ := http.NewRequest("GET", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.rm.On("Transfer", r.Context(), "", "", false).Return(hub.ErrInvalidInput)
-		hw.h.Transfer(w, r)
+		hw.rm.On("GetOwnershipClaimJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetOwnershipClaim(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
 		hw.rm.AssertExpectations(t)
 	})
 
-	t.Run("valid input", func(t *testing.T) {
+	t.Run("error getting ownership claim", func(t *testing.T) {
 		testCases := []struct {
-			description        string
-			err                error
+			rmErr              error
 			expectedStatusCode int
 		}{
 			{
-				"repository transfer succeeded",
-				nil,
-				http.StatusNoContent,
-			},
-			{
-				"error transferring repository (insufficient privilege)",
-				hub.ErrInsufficientPrivilege,
-				http.StatusForbidden,
+				hub.ErrNotFound,
+				http.StatusNotFound,
 			},
 			{
-				"error transferring repository (db error)",
 				tests.ErrFakeDB,
 				http.StatusInternalServerError,
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.description, func(t *testing.T) {
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/?org=org1", nil)
+				r, _ := http.NewRequest("GET", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-				rctx := &chi.Context{
-					URLParams: chi.RouteParams{
-						Keys:   []string{"repoName"},
-						Values: []string{"repo1"},
-					},
-				}
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.rm.On("Transfer", r.Context(), "repo1", "org1", false).Return(tc.err)
-				hw.h.Transfer(w, r)
+				hw.rm.On("GetOwnershipClaimJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetOwnershipClaim(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -665,62 +877,461 @@ func TestTransfer(t *testing.T) {
 	})
 }
 
-func TestUpdate(t *testing.T) {
-	t.Run("invalid input", func(t *testing.T) {
+func TestGetTrackingStatus(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("get repository tracking status succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("GetTrackingStatusJSON", r.Context(), "repo1").Return([]byte("dataJSON"), nil)
+		hw.h.GetTrackingStatus(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error getting repository tracking status", func(t *testing.T) {
 		testCases := []struct {
-			description string
-			repoJSON    string
-			rmErr       error
+			rmErr              error
+			expectedStatusCode int
 		}{
 			{
-				"no repository provided",
-				"",
-				nil,
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
 			},
 			{
-				"invalid json",
-				"-",
-				nil,
+				hub.ErrNotFound,
+				http.StatusNotFound,
 			},
 			{
-				"missing name",
-				`{"url": "https://repo1.url"}`,
-				hub.ErrInvalidInput,
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.description, func(t *testing.T) {
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.repoJSON))
+				r, _ := http.NewRequest("GET", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				if tc.rmErr != nil {
-					hw.rm.On("Update", r.Context(), mock.Anything).Return(tc.rmErr)
-				}
-				hw.h.Update(w, r)
+				hw.rm.On("GetTrackingStatusJSON", r.Context(), "repo1").Return(nil, tc.rmErr)
+				hw.h.GetTrackingStatus(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
-				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
 				hw.rm.AssertExpectations(t)
 			})
 		}
 	})
+}
 
-	t.Run("valid repository provided", func(t *testing.T) {
-		repoJSON := `
-		{
-			"display_name": "Repository 1 updated",
-			"url": "https://repo1.url/updated"
-		}
-		`
-		repo := &hub.Repository{}
-		_ = json.Unmarshal([]byte(repoJSON), &repo)
-
-		testCases := []struct {
+func TestRequestTracking(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+	payload := `{"ref": "refs/heads/main"}`
+
+	t.Run("payload too large", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.Repeat("a", trackingWebhookMaxPayloadSize+1)
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.RequestTracking(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("signature headers", func(t *testing.T) {
+		testCases := []struct {
+			header            string
+			signature         string
+			expectedSignature string
+		}{
+			{
+				"X-ArtifactHub-Signature",
+				"signature",
+				"signature",
+			},
+			{
+				"X-Hub-Signature-256",
+				"sha256=signature",
+				"sha256=signature",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.header, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+				r.Header.Set(tc.header, tc.signature)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RequestTracking", r.Context(), "repo1", []byte(payload), tc.expectedSignature).Return(nil)
+				hw.h.RequestTracking(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error requesting repository tracking", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(payload))
+				r.Header.Set("X-ArtifactHub-Signature", "signature")
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("RequestTracking", r.Context(), "repo1", []byte(payload), "signature").Return(tc.rmErr)
+				hw.h.RequestTracking(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateTrackingEnabled(t *testing.T) {
+	testCases := []struct {
+		description        string
+		enabled            bool
+		err                error
+		expectedStatusCode int
+	}{
+		{
+			"repository tracking paused",
+			false,
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			"repository tracking resumed",
+			true,
+			nil,
+			http.StatusNoContent,
+		},
+		{
+			"error pausing repository tracking (insufficient privilege)",
+			false,
+			hub.ErrInsufficientPrivilege,
+			http.StatusForbidden,
+		},
+		{
+			"error resuming repository tracking (not found)",
+			true,
+			hub.ErrNotFound,
+			http.StatusNotFound,
+		},
+		{
+			"error pausing repository tracking (db error)",
+			false,
+			tests.ErrFakeDB,
+			http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("PUT", "/", nil)
+			r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+			rctx := &chi.Context{
+				URLParams: chi.RouteParams{
+					Keys:   []string{"repoName"},
+					Values: []string{"repo1"},
+				},
+			}
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			hw := newHandlersWrapper()
+			hw.rm.On("UpdateTrackingEnabled", r.Context(), "repo1", tc.enabled).Return(tc.err)
+			if tc.enabled {
+				hw.h.ResumeTracking(w, r)
+			} else {
+				hw.h.PauseTracking(w, r)
+			}
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+			hw.rm.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("invalid request params", func(t *testing.T) {
+		testCases := []struct {
+			desc   string
+			params string
+		}{
+			{"invalid limit", "limit=z"},
+			{"invalid offset", "offset=z"},
+			{"invalid kind", "kind=z"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.desc, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+tc.params, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.Search(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("error searching repositories", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+
+				hw := newHandlersWrapper()
+				hw.rm.On("SearchJSON", r.Context(), mock.Anything).Return(nil, tc.rmErr)
+				hw.h.Search(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("search repositories succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&cursor=eyJuYW1lIjogInJlcG8xIn0&name=repo&kind=helm&kind=falco", nil)
+
+		hw := newHandlersWrapper()
+		hw.rm.On("SearchJSON", r.Context(), &hub.SearchRepositoryInput{
+			Limit:  10,
+			Cursor: "eyJuYW1lIjogInJlcG8xIn0",
+			Name:   "repo",
+			Kinds:  []hub.RepositoryKind{hub.Helm, hub.Falco},
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.rm.AssertExpectations(t)
+	})
+}
+
+func TestTransfer(t *testing.T) {
+	t.Run("invalid input - missing repo name", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("Transfer", r.Context(), "", "", false).Return(hub.ErrInvalidInput)
+		hw.h.Transfer(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("valid input", func(t *testing.T) {
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"repository transfer succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error transferring repository (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error transferring repository (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/?org=org1", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName"},
+						Values: []string{"repo1"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("Transfer", r.Context(), "repo1", "org1", false).Return(tc.err)
+				hw.h.Transfer(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			repoJSON    string
+			rmErr       error
+		}{
+			{
+				"no repository provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"missing name",
+				`{"url": "https://repo1.url"}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.repoJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				if tc.rmErr != nil {
+					hw.rm.On("Update", r.Context(), mock.Anything).Return(tc.rmErr)
+				}
+				hw.h.Update(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid repository provided", func(t *testing.T) {
+		repoJSON := `
+		{
+			"display_name": "Repository 1 updated",
+			"url": "https://repo1.url/updated"
+		}
+		`
+		repo := &hub.Repository{}
+		_ = json.Unmarshal([]byte(repoJSON), &repo)
+
+		testCases := []struct {
 			description        string
 			err                error
 			expectedStatusCode int
@@ -762,6 +1373,181 @@ func TestUpdate(t *testing.T) {
 	})
 }
 
+func TestUpdateCredentials(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			credsJSON   string
+			rmErr       error
+		}{
+			{
+				"no credentials provided",
+				"",
+				nil,
+			},
+			{
+				"invalid json",
+				"-",
+				nil,
+			},
+			{
+				"invalid secret reference",
+				`{"auth_pass_ref": "invalid"}`,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(tc.credsJSON))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				if tc.rmErr != nil {
+					hw.rm.On("UpdateCredentials", r.Context(), mock.Anything, mock.Anything).Return(tc.rmErr)
+				}
+				hw.h.UpdateCredentials(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("valid credentials provided", func(t *testing.T) {
+		credsJSON := `
+		{
+			"auth_user": "user1",
+			"auth_pass_ref": "kubernetes:repo1-creds#password"
+		}
+		`
+		c := &hub.RepositoryCredentials{}
+		_ = json.Unmarshal([]byte(credsJSON), &c)
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"repository credentials update succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error updating repository credentials (insufficient privilege)",
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				"error updating repository credentials (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(credsJSON))
+				rctx := &chi.Context{
+					URLParams: chi.RouteParams{
+						Keys:   []string{"repoName"},
+						Values: []string{"repo1"},
+					},
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("UpdateCredentials", r.Context(), "repo1", c).Return(tc.err)
+				hw.h.UpdateCredentials(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestUpdateTrackingWebhook(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"repoName"},
+			Values: []string{"repo1"},
+		},
+	}
+
+	t.Run("repository tracking webhook enabled", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.rm.On("UpdateTrackingWebhook", r.Context(), "repo1", true).Return(&hub.TrackingWebhook{
+			URL:    "https://artifacthub.io/api/v1/repositories/repo1/tracking-webhook",
+			Secret: "secret",
+		}, nil)
+		hw.h.UpdateTrackingWebhook(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"url": "https://artifacthub.io/api/v1/repositories/repo1/tracking-webhook",
+			"secret": "secret"
+		}`, string(data))
+		hw.rm.AssertExpectations(t)
+	})
+
+	t.Run("error enabling repository tracking webhook", func(t *testing.T) {
+		testCases := []struct {
+			rmErr              error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.rmErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.rm.On("UpdateTrackingWebhook", r.Context(), "repo1", true).Return(nil, tc.rmErr)
+				hw.h.UpdateTrackingWebhook(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.rm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 type handlersWrapper struct {
 	rm *repo.ManagerMock
 	h  *Handlers
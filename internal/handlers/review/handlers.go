@@ -0,0 +1,131 @@
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling packages
+// reviews operations.
+type Handlers struct {
+	reviewManager hub.ReviewManager
+	logger        zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(reviewManager hub.ReviewManager) *Handlers {
+	return &Handlers{
+		reviewManager: reviewManager,
+		logger:        log.With().Str("handlers", "review").Logger(),
+	}
+}
+
+// Add is an http handler that adds the provided review to the database. When
+// the user has already reviewed the package, the existing review is replaced.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	review := &hub.Review{}
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	review.PackageID = chi.URLParam(r, "packageID")
+	if err := h.reviewManager.Add(r.Context(), review); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete is an http handler that deletes the review the requesting user
+// posted on the provided package.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	packageID := chi.URLParam(r, "packageID")
+	if err := h.reviewManager.Delete(r.Context(), packageID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Get is an http handler that returns the reviews of the provided package.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetReviewsInput{
+		PackageID: chi.URLParam(r, "packageID"),
+	}
+	var err error
+	if v := r.FormValue("limit"); v != "" {
+		input.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+			return
+		}
+	}
+	if v := r.FormValue("offset"); v != "" {
+		input.Offset, err = strconv.Atoi(v)
+		if err != nil {
+			helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+			return
+		}
+	}
+	dataJSON, err := h.reviewManager.GetJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Reply is an http handler that sets the reply of the package's publisher to
+// the provided review.
+func (h *Handlers) Reply(w http.ResponseWriter, r *http.Request) {
+	input := &struct {
+		Reply string `json:"reply"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Error().Err(err).Str("method", "Reply").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	packageID := chi.URLParam(r, "packageID")
+	reviewID := chi.URLParam(r, "reviewID")
+	if err := h.reviewManager.Reply(r.Context(), packageID, reviewID, input.Reply); err != nil {
+		h.logger.Error().Err(err).Str("method", "Reply").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Report is an http handler that registers an abuse report on the provided
+// review.
+func (h *Handlers) Report(w http.ResponseWriter, r *http.Request) {
+	input := &struct {
+		Reason string `json:"reason"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Report").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	packageID := chi.URLParam(r, "packageID")
+	reviewID := chi.URLParam(r, "reviewID")
+	if err := h.reviewManager.Report(r.Context(), packageID, reviewID, input.Reason); err != nil {
+		h.logger.Error().Err(err).Str("method", "Report").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,111 @@
+package savedsearch
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling saved
+// searches operations.
+type Handlers struct {
+	savedSearchManager hub.SavedSearchManager
+	logger             zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(savedSearchManager hub.SavedSearchManager) *Handlers {
+	return &Handlers{
+		savedSearchManager: savedSearchManager,
+		logger:             log.With().Str("handlers", "savedsearch").Logger(),
+	}
+}
+
+// Add is an http handler that adds the provided saved search to the database.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	s := &hub.SavedSearch{}
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	dataJSON, err := h.savedSearchManager.Add(r.Context(), s)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
+// Delete is an http handler that deletes the provided saved search from the
+// database.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	savedSearchID := chi.URLParam(r, "savedSearchID")
+	if err := h.savedSearchManager.Delete(r.Context(), savedSearchID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Get is an http handler that returns the requested saved search.
+func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
+	savedSearchID := chi.URLParam(r, "savedSearchID")
+	dataJSON, err := h.savedSearchManager.GetJSON(r.Context(), savedSearchID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Get").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetMatches is an http handler that returns the packages that started
+// matching the requested saved search after it was saved.
+func (h *Handlers) GetMatches(w http.ResponseWriter, r *http.Request) {
+	savedSearchID := chi.URLParam(r, "savedSearchID")
+	dataJSON, err := h.savedSearchManager.GetMatchesJSON(r.Context(), savedSearchID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetMatches").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetOwnedByUser is an http handler that returns the saved searches owned by
+// the user doing the request.
+func (h *Handlers) GetOwnedByUser(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.savedSearchManager.GetOwnedByUserJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetOwnedByUser").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Update is an http handler that updates the provided saved search in the
+// database.
+func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
+	s := &hub.SavedSearch{}
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	s.SavedSearchID = chi.URLParam(r, "savedSearchID")
+	if err := h.savedSearchManager.Update(r.Context(), s); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
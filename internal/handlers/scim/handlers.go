@@ -0,0 +1,319 @@
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/scim"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// contentType represents the content type of the SCIM responses.
+	contentType = "application/scim+json"
+
+	// defaultCount represents the number of resources returned when no count
+	// is provided.
+	defaultCount = 100
+
+	// errorSchema represents the schema of the SCIM error responses.
+	errorSchema = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// serviceProviderConfig represents the SCIM features supported.
+var serviceProviderConfig = []byte(`{
+	"schemas": ["urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"],
+	"patch": {"supported": true},
+	"bulk": {"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+	"filter": {"supported": true, "maxResults": 100},
+	"changePassword": {"supported": false},
+	"sort": {"supported": false},
+	"etag": {"supported": false},
+	"authenticationSchemes": [{
+		"type": "oauthbearertoken",
+		"name": "OAuth Bearer Token",
+		"description": "Authentication scheme using a bearer token"
+	}]
+}`)
+
+// Handlers represents a group of http handlers in charge of handling SCIM
+// operations.
+type Handlers struct {
+	scimManager hub.SCIMManager
+	cfg         *viper.Viper
+	logger      zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(scimManager hub.SCIMManager, cfg *viper.Viper) *Handlers {
+	return &Handlers{
+		scimManager: scimManager,
+		cfg:         cfg,
+		logger:      log.With().Str("handlers", "scim").Logger(),
+	}
+}
+
+// DeleteUser is an http handler that deletes the provided user.
+func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	if err := h.scimManager.DeleteUser(r.Context(), userID); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteUser").Send()
+		renderError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetGroup is an http handler that returns the requested group.
+func (h *Handlers) GetGroup(w http.ResponseWriter, r *http.Request) {
+	groupID := chi.URLParam(r, "groupID")
+	dataJSON, err := h.scimManager.GetGroupJSON(r.Context(), groupID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetGroup").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// GetGroups is an http handler that returns the groups matching the filter
+// provided.
+func (h *Handlers) GetGroups(w http.ResponseWriter, r *http.Request) {
+	input, err := buildListInput(r.URL.Query())
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetGroups").Msg("invalid query")
+		renderError(w, err)
+		return
+	}
+	dataJSON, err := h.scimManager.GetGroupsJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetGroups").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// GetServiceProviderConfig is an http handler that returns the SCIM features
+// supported.
+func (h *Handlers) GetServiceProviderConfig(w http.ResponseWriter, r *http.Request) {
+	render(w, serviceProviderConfig, http.StatusOK)
+}
+
+// GetUser is an http handler that returns the requested user.
+func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "userID")
+	dataJSON, err := h.scimManager.GetUserJSON(r.Context(), userID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUser").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// GetUsers is an http handler that returns the users matching the filter
+// provided.
+func (h *Handlers) GetUsers(w http.ResponseWriter, r *http.Request) {
+	input, err := buildListInput(r.URL.Query())
+	if err != nil {
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "GetUsers").Msg("invalid query")
+		renderError(w, err)
+		return
+	}
+	dataJSON, err := h.scimManager.GetUsersJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetUsers").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// NotFound is an http handler that renders a SCIM not found error.
+func (h *Handlers) NotFound(w http.ResponseWriter, r *http.Request) {
+	renderError(w, hub.ErrNotFound)
+}
+
+// PatchGroup is an http handler that applies the patch operations provided to
+// the group.
+func (h *Handlers) PatchGroup(w http.ResponseWriter, r *http.Request) {
+	op := &hub.SCIMPatchOp{}
+	if err := json.NewDecoder(r.Body).Decode(&op); err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchGroup").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, hub.ErrInvalidInput)
+		return
+	}
+	groupID := chi.URLParam(r, "groupID")
+	dataJSON, err := h.scimManager.PatchGroup(r.Context(), groupID, op)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchGroup").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// PatchUser is an http handler that applies the patch operations provided to
+// the user.
+func (h *Handlers) PatchUser(w http.ResponseWriter, r *http.Request) {
+	op := &hub.SCIMPatchOp{}
+	if err := json.NewDecoder(r.Body).Decode(&op); err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchUser").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, hub.ErrInvalidInput)
+		return
+	}
+	userID := chi.URLParam(r, "userID")
+	dataJSON, err := h.scimManager.PatchUser(r.Context(), userID, op)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "PatchUser").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// RegisterUser is an http handler that registers the provided user.
+func (h *Handlers) RegisterUser(w http.ResponseWriter, r *http.Request) {
+	u := &hub.SCIMUser{}
+	if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterUser").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, hub.ErrInvalidInput)
+		return
+	}
+	dataJSON, err := h.scimManager.RegisterUser(r.Context(), u)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterUser").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusCreated)
+}
+
+// RequireToken is a middleware that verifies that the request includes the
+// bearer token configured for the SCIM clients.
+func (h *Handlers) RequireToken(next http.Handler) http.Handler {
+	validToken := []byte(h.cfg.GetString("server.scim.token"))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if len(validToken) == 0 || subtle.ConstantTimeCompare([]byte(token), validToken) != 1 {
+			renderErrorWithCode(w, "", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UpdateGroup is an http handler that replaces the group members with the
+// ones provided.
+func (h *Handlers) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	g := &hub.SCIMGroup{}
+	if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateGroup").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, hub.ErrInvalidInput)
+		return
+	}
+	groupID := chi.URLParam(r, "groupID")
+	dataJSON, err := h.scimManager.UpdateGroup(r.Context(), groupID, g)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateGroup").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// UpdateUser is an http handler that replaces the user details with the ones
+// provided.
+func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	u := &hub.SCIMUser{}
+	if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateUser").Msg(hub.ErrInvalidInput.Error())
+		renderError(w, hub.ErrInvalidInput)
+		return
+	}
+	userID := chi.URLParam(r, "userID")
+	dataJSON, err := h.scimManager.UpdateUser(r.Context(), userID, u)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateUser").Send()
+		renderError(w, err)
+		return
+	}
+	render(w, dataJSON, http.StatusOK)
+}
+
+// buildListInput builds the input used to list SCIM resources from a map of
+// query string values, validating them as they are extracted.
+func buildListInput(qs url.Values) (*hub.SCIMListInput, error) {
+	input := &hub.SCIMListInput{
+		Filter:     qs.Get("filter"),
+		StartIndex: 1,
+		Count:      defaultCount,
+	}
+	if qs.Get("startIndex") != "" {
+		startIndex, err := strconv.Atoi(qs.Get("startIndex"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid startIndex")
+		}
+		input.StartIndex = startIndex
+	}
+	if qs.Get("count") != "" {
+		count, err := strconv.Atoi(qs.Get("count"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid count")
+		}
+		input.Count = count
+	}
+	return input, nil
+}
+
+// render writes the SCIM json data provided to the given http response
+// writer, setting the appropriate content type and status code.
+func render(w http.ResponseWriter, dataJSON []byte, code int) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(dataJSON)
+}
+
+// renderError writes the error provided to the given http response writer
+// using the format defined by the SCIM specification.
+func renderError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, hub.ErrInvalidInput):
+		renderErrorWithCode(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, hub.ErrNotFound):
+		renderErrorWithCode(w, "resource not found", http.StatusNotFound)
+	case errors.Is(err, scim.ErrUniqueness):
+		renderErrorWithCode(w, err.Error(), http.StatusConflict)
+	default:
+		renderErrorWithCode(w, "", http.StatusInternalServerError)
+	}
+}
+
+// renderErrorWithCode writes a SCIM error with the details and status code
+// provided to the given http response writer.
+func renderErrorWithCode(w http.ResponseWriter, detail string, code int) {
+	data := map[string]interface{}{
+		"schemas": []string{errorSchema},
+		"status":  strconv.Itoa(code),
+	}
+	if detail != "" {
+		data["detail"] = detail
+	}
+	if code == http.StatusConflict {
+		data["scimType"] = "uniqueness"
+	}
+	dataJSON, _ := json.Marshal(data)
+	render(w, dataJSON, code)
+}
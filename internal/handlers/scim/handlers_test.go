@@ -0,0 +1,370 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/scim"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	userID  = "00000000-0000-0000-0000-000000000001"
+	groupID = "00000000-0000-0000-0000-000000000001"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestDeleteUser(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+
+	t.Run("error deleting user", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("DeleteUser", r.Context(), userID).Return(tc.err)
+				hw.h.DeleteUser(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+				assert.Contains(t, string(data), errorSchema)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("DeleteUser", r.Context(), userID).Return(nil)
+		hw.h.DeleteUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestGetGroups(t *testing.T) {
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?count=a", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.GetGroups(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("groups returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", `/?filter=displayName+eq+"org1"&startIndex=2&count=5`, nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetGroupsJSON", r.Context(), &hub.SCIMListInput{
+			Filter:     `displayName eq "org1"`,
+			StartIndex: 2,
+			Count:      5,
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.GetGroups(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestGetUsers(t *testing.T) {
+	t.Run("unsupported filter", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetUsersJSON", r.Context(), &hub.SCIMListInput{StartIndex: 1, Count: defaultCount}).
+			Return(nil, hub.ErrInvalidInput)
+		hw.h.GetUsers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("users returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetUsersJSON", r.Context(), &hub.SCIMListInput{StartIndex: 1, Count: defaultCount}).
+			Return([]byte("dataJSON"), nil)
+		hw.h.GetUsers(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestPatchGroup(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"groupID"},
+			Values: []string{groupID},
+		},
+	}
+	opJSON := `{"Operations": [{"op": "add", "path": "members", "value": [{"value": "` + userID + `"}]}]}`
+	op := &hub.SCIMPatchOp{}
+	_ = json.Unmarshal([]byte(opJSON), &op)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PATCH", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.PatchGroup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("group patched successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PATCH", "/", strings.NewReader(opJSON))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("PatchGroup", r.Context(), groupID, op).Return([]byte("dataJSON"), nil)
+		hw.h.PatchGroup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestRegisterUser(t *testing.T) {
+	userJSON := `{"userName": "user1@email.com", "name": {"givenName": "first_name"}}`
+	u := &hub.SCIMUser{}
+	_ = json.Unmarshal([]byte(userJSON), &u)
+
+	t.Run("user already exists", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(userJSON))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("RegisterUser", r.Context(), u).Return(nil, scim.ErrUniqueness)
+		hw.h.RegisterUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Contains(t, string(data), `"scimType":"uniqueness"`)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("user registered successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(userJSON))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("RegisterUser", r.Context(), u).Return([]byte("dataJSON"), nil)
+		hw.h.RegisterUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestRequireToken(t *testing.T) {
+	testCases := []struct {
+		description        string
+		token              string
+		authHeader         string
+		expectedStatusCode int
+	}{
+		{
+			"token not configured",
+			"",
+			"Bearer ",
+			http.StatusUnauthorized,
+		},
+		{
+			"token not provided",
+			"token",
+			"",
+			http.StatusUnauthorized,
+		},
+		{
+			"invalid token",
+			"token",
+			"Bearer invalid",
+			http.StatusUnauthorized,
+		},
+		{
+			"valid token",
+			"token",
+			"Bearer token",
+			http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Set("Authorization", tc.authHeader)
+
+			cfg := viper.New()
+			cfg.Set("server.scim.token", tc.token)
+			h := NewHandlers(&scim.ManagerMock{}, cfg)
+			h.RequireToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestUpdateGroup(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"groupID"},
+			Values: []string{groupID},
+		},
+	}
+
+	t.Run("last member cannot leave the organization", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"displayName": "org1", "members": []}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("UpdateGroup", r.Context(), groupID, mock.Anything).Return(nil, hub.ErrInvalidInput)
+		hw.h.UpdateGroup(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"userID"},
+			Values: []string{userID},
+		},
+	}
+
+	t.Run("user updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"userName": "user1@email.com", "active": false}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("UpdateUser", r.Context(), userID, mock.MatchedBy(func(u *hub.SCIMUser) bool {
+			return u.UserName == "user1@email.com" && u.Active != nil && !*u.Active
+		})).Return([]byte("dataJSON"), nil)
+		hw.h.UpdateUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	sm *scim.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	sm := &scim.ManagerMock{}
+
+	return &handlersWrapper{
+		sm: sm,
+		h:  NewHandlers(sm, viper.New()),
+	}
+}
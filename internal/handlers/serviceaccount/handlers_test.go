@@ -0,0 +1,314 @@
+package serviceaccount
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/serviceaccount"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+var rctx = &chi.Context{
+	URLParams: chi.RouteParams{
+		Keys:   []string{"orgName", "serviceAccountID", "apiKeyID"},
+		Values: []string{"org1", "saID", "apiKeyID"},
+	},
+}
+
+var errorsTestCases = []struct {
+	err                error
+	expectedStatusCode int
+}{
+	{
+		hub.ErrInvalidInput,
+		http.StatusBadRequest,
+	},
+	{
+		hub.ErrInsufficientPrivilege,
+		http.StatusForbidden,
+	},
+	{
+		hub.ErrNotFound,
+		http.StatusNotFound,
+	},
+	{
+		tests.ErrFakeDB,
+		http.StatusInternalServerError,
+	},
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("invalid service account provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error adding service account", func(t *testing.T) {
+		testCases := append(errorsTestCases, struct {
+			err                error
+			expectedStatusCode int
+		}{
+			serviceaccount.ErrNameNotAvailable,
+			http.StatusBadRequest,
+		})
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"name": "ci"}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("Add", r.Context(), "org1", mock.Anything).Return(nil, tc.err)
+				hw.h.Add(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("service account added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"name": "ci"}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("Add", r.Context(), "org1", &hub.ServiceAccount{Name: "ci"}).Return([]byte("dataJSON"), nil)
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestAddAPIKey(t *testing.T) {
+	t.Run("invalid api key provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.AddAPIKey(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error adding api key", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"name": "apikey1", "scopes": ["repos:write"]}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("AddAPIKey", r.Context(), "org1", "saID", mock.Anything).Return(nil, tc.err)
+				hw.h.AddAPIKey(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("api key added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"name": "apikey1", "scopes": ["repos:write"]}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("AddAPIKey", r.Context(), "org1", "saID", &hub.APIKey{
+			Name:   "apikey1",
+			Scopes: []hub.APIKeyScope{hub.APIKeyScopeReposWrite},
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.AddAPIKey(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("error deleting service account", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("Delete", r.Context(), "org1", "saID").Return(tc.err)
+				hw.h.Delete(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("service account deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("Delete", r.Context(), "org1", "saID").Return(nil)
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	t.Run("error deleting api key", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("DeleteAPIKey", r.Context(), "org1", "saID", "apiKeyID").Return(tc.err)
+				hw.h.DeleteAPIKey(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("api key deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("DeleteAPIKey", r.Context(), "org1", "saID", "apiKeyID").Return(nil)
+		hw.h.DeleteAPIKey(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrg(t *testing.T) {
+	t.Run("error getting service accounts", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sam.On("GetByOrgJSON", r.Context(), "org1").Return(nil, tc.err)
+				hw.h.GetByOrg(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sam.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("service accounts returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sam.On("GetByOrgJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sam.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	sam *serviceaccount.ManagerMock
+	h   *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	sam := &serviceaccount.ManagerMock{}
+
+	return &handlersWrapper{
+		sam: sam,
+		h:   NewHandlers(sam),
+	}
+}
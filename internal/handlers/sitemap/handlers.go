@@ -0,0 +1,155 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// entriesPerSitemap represents the maximum number of entries included in
+	// each of the sitemaps of a section.
+	entriesPerSitemap = 10000
+
+	// sitemapCacheMaxAge is the cache max age used when serving sitemaps.
+	sitemapCacheMaxAge = 6 * time.Hour
+
+	// sitemapNS represents the namespace of the sitemaps XML documents.
+	sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+// sitemapIndex represents a sitemap index XML document.
+type sitemapIndex struct {
+	XMLName  xml.Name      `xml:"sitemapindex"`
+	XMLNS    string        `xml:"xmlns,attr"`
+	Sitemaps []*sitemapRef `xml:"sitemap"`
+}
+
+// sitemapRef represents a reference to a sitemap in a sitemap index.
+type sitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// urlSet represents a sitemap XML document.
+type urlSet struct {
+	XMLName xml.Name  `xml:"urlset"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	URLs    []*urlRef `xml:"url"`
+}
+
+// urlRef represents an url in a sitemap.
+type urlRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Handlers represents a group of http handlers in charge of handling sitemap
+// operations.
+type Handlers struct {
+	cfg            *viper.Viper
+	sitemapManager hub.SitemapManager
+	logger         zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(cfg *viper.Viper, sitemapManager hub.SitemapManager) *Handlers {
+	return &Handlers{
+		cfg:            cfg,
+		sitemapManager: sitemapManager,
+		logger:         log.With().Str("handlers", "sitemap").Logger(),
+	}
+}
+
+// Index is an http handler that serves the sitemap index, which references
+// all the sitemaps available for each of the sections.
+func (h *Handlers) Index(w http.ResponseWriter, r *http.Request) {
+	sections, err := h.sitemapManager.GetSections(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Index").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	baseURL := h.cfg.GetString("server.baseURL")
+	index := &sitemapIndex{XMLNS: sitemapNS}
+	for _, s := range sections {
+		pages := (s.Total + entriesPerSitemap - 1) / entriesPerSitemap
+		for page := 1; page <= pages; page++ {
+			index.Sitemaps = append(index.Sitemaps, &sitemapRef{
+				Loc:     fmt.Sprintf("%s/sitemap/%s/%d.xml", baseURL, s.Name, page),
+				LastMod: formatLastMod(s.LastModified),
+			})
+		}
+	}
+	renderXML(w, index)
+}
+
+// Section is an http handler that serves the sitemap with the entries of the
+// page requested of a given section.
+func (h *Handlers) Section(w http.ResponseWriter, r *http.Request) {
+	section := chi.URLParam(r, "section")
+	page, err := strconv.Atoi(chi.URLParam(r, "page"))
+	if err != nil || page < 1 {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+	offset := (page - 1) * entriesPerSitemap
+	entries, err := h.sitemapManager.GetEntries(r.Context(), section, entriesPerSitemap, offset)
+	if err != nil {
+		if errors.Is(err, hub.ErrInvalidInput) {
+			err = hub.ErrNotFound
+		} else {
+			h.logger.Error().Err(err).Str("method", "Section").Str("section", section).Send()
+		}
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if len(entries) == 0 && page > 1 {
+		helpers.RenderErrorJSON(w, hub.ErrNotFound)
+		return
+	}
+
+	baseURL := h.cfg.GetString("server.baseURL")
+	set := &urlSet{XMLNS: sitemapNS}
+	for _, e := range entries {
+		set.URLs = append(set.URLs, &urlRef{
+			Loc:     baseURL + e.Path,
+			LastMod: formatLastMod(e.LastModified),
+		})
+	}
+	renderXML(w, set)
+}
+
+// formatLastMod formats the unix timestamp provided using the W3C datetime
+// format expected by the lastmod sitemap field.
+func formatLastMod(ts int64) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
+
+// renderXML writes the XML document provided to the given http response
+// writer setting the appropriate headers.
+func renderXML(w http.ResponseWriter, v interface{}) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(sitemapCacheMaxAge))
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,191 @@
+package sitemap
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/sitemap"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+func TestIndex(t *testing.T) {
+	t.Run("error getting sections", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetSections", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.Index(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("sitemap index returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetSections", r.Context()).Return([]*hub.SitemapSection{
+			{Name: "packages", Total: entriesPerSitemap + 1, LastModified: 1592472034},
+			{Name: "repositories", Total: 1},
+			{Name: "publishers", Total: 0},
+		}, nil)
+		hw.h.Index(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(sitemapCacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+			`<sitemap><loc>https://hub.test/sitemap/packages/1.xml</loc><lastmod>2020-06-18T09:20:34Z</lastmod></sitemap>`+
+			`<sitemap><loc>https://hub.test/sitemap/packages/2.xml</loc><lastmod>2020-06-18T09:20:34Z</lastmod></sitemap>`+
+			`<sitemap><loc>https://hub.test/sitemap/repositories/1.xml</loc></sitemap>`+
+			`</sitemapindex>`, string(data))
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestSection(t *testing.T) {
+	t.Run("invalid page", func(t *testing.T) {
+		for _, page := range []string{"0", "-1", "page"} {
+			page := page
+			t.Run(page, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = withURLParams(r, "packages", page)
+
+				hw := newHandlersWrapper()
+				hw.h.Section(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("invalid section", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = withURLParams(r, "unknown", "1")
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetEntries", r.Context(), "unknown", entriesPerSitemap, 0).Return(nil, hub.ErrInvalidInput)
+		hw.h.Section(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("error getting entries", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = withURLParams(r, "packages", "1")
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetEntries", r.Context(), "packages", entriesPerSitemap, 0).Return(nil, tests.ErrFakeDB)
+		hw.h.Section(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("page requested out of range", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = withURLParams(r, "packages", "3")
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetEntries", r.Context(), "packages", entriesPerSitemap, 2*entriesPerSitemap).Return([]*hub.SitemapEntry{}, nil)
+		hw.h.Section(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("sitemap returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = withURLParams(r, "publishers", "2")
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetEntries", r.Context(), "publishers", entriesPerSitemap, entriesPerSitemap).Return([]*hub.SitemapEntry{
+			{Path: "/packages/search?org=org1&x=y", LastModified: 1592472034},
+			{Path: "/packages/search?user=user1"},
+		}, nil)
+		hw.h.Section(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/xml; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(sitemapCacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+			`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+
+			`<url><loc>https://hub.test/packages/search?org=org1&amp;x=y</loc><lastmod>2020-06-18T09:20:34Z</lastmod></url>`+
+			`<url><loc>https://hub.test/packages/search?user=user1</loc></url>`+
+			`</urlset>`, string(data))
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func withURLParams(r *http.Request, section, page string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("section", section)
+	rctx.URLParams.Add("page", page)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+type handlersWrapper struct {
+	sm *sitemap.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "https://hub.test")
+	sm := &sitemap.ManagerMock{}
+
+	return &handlersWrapper{
+		sm: sm,
+		h:  NewHandlers(cfg, sm),
+	}
+}
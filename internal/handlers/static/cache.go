@@ -0,0 +1,142 @@
+package static
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultImagesCacheMaxBytes   = 64 * 1024 * 1024
+	defaultImagesCacheMaxEntries = 5000
+	defaultImagesCacheTTL        = 24 * time.Hour
+)
+
+var (
+	imagesCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "images_cache_hits_total",
+		Help: "Number of images served from the images cache.",
+	})
+	imagesCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "images_cache_misses_total",
+		Help: "Number of images not found in the images cache.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(imagesCacheHits, imagesCacheMisses)
+}
+
+// imagesCache is an LRU cache used to keep the most requested images data in
+// memory. It's bounded by the total size of the data stored and the number of
+// entries, and entries expire once their TTL is reached.
+type imagesCache struct {
+	maxBytes   int
+	maxEntries int
+	ttl        time.Duration
+	hits       prometheus.Counter
+	misses     prometheus.Counter
+
+	mu      sync.Mutex
+	size    int
+	entries *list.List
+	items   map[string]*list.Element
+}
+
+// imagesCacheEntry represents an entry in the images cache.
+type imagesCacheEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// newImagesCache creates a new imagesCache instance. A zero ttl means entries
+// never expire.
+func newImagesCache(
+	maxBytes,
+	maxEntries int,
+	ttl time.Duration,
+	hits,
+	misses prometheus.Counter,
+) *imagesCache {
+	return &imagesCache{
+		maxBytes:   maxBytes,
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		hits:       hits,
+		misses:     misses,
+		entries:    list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// newImagesCacheFromConfig creates a new imagesCache instance using the
+// configuration provided.
+func newImagesCacheFromConfig(cfg *viper.Viper) *imagesCache {
+	cfg.SetDefault("server.imagesCache.maxBytes", defaultImagesCacheMaxBytes)
+	cfg.SetDefault("server.imagesCache.maxEntries", defaultImagesCacheMaxEntries)
+	cfg.SetDefault("server.imagesCache.ttl", defaultImagesCacheTTL)
+	return newImagesCache(
+		cfg.GetInt("server.imagesCache.maxBytes"),
+		cfg.GetInt("server.imagesCache.maxEntries"),
+		cfg.GetDuration("server.imagesCache.ttl"),
+		imagesCacheHits,
+		imagesCacheMisses,
+	)
+}
+
+// Get returns the data of the image identified by the key provided, if it's
+// available in the cache and hasn't expired.
+func (c *imagesCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		entry := e.Value.(*imagesCacheEntry)
+		if c.ttl == 0 || time.Now().Before(entry.expiresAt) {
+			c.entries.MoveToFront(e)
+			c.hits.Inc()
+			return entry.data, true
+		}
+		c.remove(e)
+	}
+	c.misses.Inc()
+	return nil, false
+}
+
+// Add adds the image data provided to the cache, evicting the least recently
+// used entries when needed. Images larger than the cache max size are not
+// cached.
+func (c *imagesCache) Add(key string, data []byte) {
+	if len(data) > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		c.remove(e)
+	}
+	entry := &imagesCacheEntry{
+		key:       key,
+		data:      data,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	c.items[key] = c.entries.PushFront(entry)
+	c.size += len(data)
+	for c.size > c.maxBytes || c.entries.Len() > c.maxEntries {
+		c.remove(c.entries.Back())
+	}
+}
+
+// remove removes the element provided from the cache.
+func (c *imagesCache) remove(e *list.Element) {
+	entry := e.Value.(*imagesCacheEntry)
+	c.entries.Remove(e)
+	delete(c.items, entry.key)
+	c.size -= len(entry.data)
+}
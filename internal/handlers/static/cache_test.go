@@ -0,0 +1,117 @@
+package static
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImagesCache(t *testing.T) {
+	t.Run("get and add entries, tracking hits and misses", func(t *testing.T) {
+		t.Parallel()
+		c, hits, misses := newTestImagesCache(10, 10, 0)
+
+		_, ok := c.Get("key1")
+		assert.False(t, ok)
+		c.Add("key1", []byte("data1"))
+		data, ok := c.Get("key1")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("data1"), data)
+		assert.Equal(t, float64(1), testutil.ToFloat64(hits))
+		assert.Equal(t, float64(1), testutil.ToFloat64(misses))
+	})
+
+	t.Run("least recently used entries are evicted when max bytes is reached", func(t *testing.T) {
+		t.Parallel()
+		c, _, _ := newTestImagesCache(10, 10, 0)
+
+		c.Add("key1", []byte("data1"))
+		c.Add("key2", []byte("data2"))
+		_, _ = c.Get("key1")
+		c.Add("key3", []byte("data3"))
+		_, ok := c.Get("key2")
+		assert.False(t, ok)
+		_, ok = c.Get("key1")
+		assert.True(t, ok)
+		_, ok = c.Get("key3")
+		assert.True(t, ok)
+		assert.Equal(t, 10, c.size)
+	})
+
+	t.Run("oldest entries are evicted when max entries is reached", func(t *testing.T) {
+		t.Parallel()
+		c, _, _ := newTestImagesCache(100, 2, 0)
+
+		c.Add("key1", []byte("data1"))
+		c.Add("key2", []byte("data2"))
+		c.Add("key3", []byte("data3"))
+		_, ok := c.Get("key1")
+		assert.False(t, ok)
+		assert.Equal(t, 2, c.entries.Len())
+	})
+
+	t.Run("entries larger than max bytes are not cached", func(t *testing.T) {
+		t.Parallel()
+		c, _, _ := newTestImagesCache(4, 10, 0)
+
+		c.Add("key1", []byte("data1"))
+		_, ok := c.Get("key1")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.size)
+	})
+
+	t.Run("replacing an entry updates the cache size", func(t *testing.T) {
+		t.Parallel()
+		c, _, _ := newTestImagesCache(100, 10, 0)
+
+		c.Add("key1", []byte("data1"))
+		c.Add("key1", []byte("data1-updated"))
+		data, ok := c.Get("key1")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("data1-updated"), data)
+		assert.Equal(t, 13, c.size)
+	})
+
+	t.Run("expired entries are not returned", func(t *testing.T) {
+		t.Parallel()
+		c, hits, misses := newTestImagesCache(100, 10, time.Nanosecond)
+
+		c.Add("key1", []byte("data1"))
+		time.Sleep(time.Millisecond)
+		_, ok := c.Get("key1")
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.size)
+		assert.Equal(t, float64(0), testutil.ToFloat64(hits))
+		assert.Equal(t, float64(1), testutil.ToFloat64(misses))
+	})
+}
+
+func TestNewImagesCacheFromConfig(t *testing.T) {
+	t.Parallel()
+
+	// Defaults
+	c := newImagesCacheFromConfig(viper.New())
+	assert.Equal(t, defaultImagesCacheMaxBytes, c.maxBytes)
+	assert.Equal(t, defaultImagesCacheMaxEntries, c.maxEntries)
+	assert.Equal(t, defaultImagesCacheTTL, c.ttl)
+
+	// Values provided in config
+	cfg := viper.New()
+	cfg.Set("server.imagesCache.maxBytes", 1024)
+	cfg.Set("server.imagesCache.maxEntries", 10)
+	cfg.Set("server.imagesCache.ttl", "1h")
+	c = newImagesCacheFromConfig(cfg)
+	assert.Equal(t, 1024, c.maxBytes)
+	assert.Equal(t, 10, c.maxEntries)
+	assert.Equal(t, time.Hour, c.ttl)
+}
+
+func newTestImagesCache(maxBytes, maxEntries int, ttl time.Duration) (*imagesCache, prometheus.Counter, prometheus.Counter) {
+	hits := prometheus.NewCounter(prometheus.CounterOpts{Name: "hits"})
+	misses := prometheus.NewCounter(prometheus.CounterOpts{Name: "misses"})
+	return newImagesCache(maxBytes, maxEntries, ttl, hits, misses), hits, misses
+}
@@ -1,17 +1,27 @@
 package static
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/artifacthub/hub/internal/captcha"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
@@ -30,43 +40,129 @@ const (
 
 	// StaticCacheMaxAge is the cache max age used when serving static assets.
 	StaticCacheMaxAge = 365 * 24 * time.Hour
+
+	// maxResizeDimension represents the maximum width or height that can be
+	// requested when resizing images, which matches the size of the largest
+	// image version stored.
+	maxResizeDimension = 320
+
+	// resizeStep represents the step used to round up the width and height
+	// requested when resizing images.
+	resizeStep = 10
+
+	// defaultMaxUploadSize represents the default maximum size of the images
+	// uploaded.
+	defaultMaxUploadSize = 2 * 1024 * 1024
+
+	// resizeSourceVersion represents the image version used as the source
+	// when resizing images.
+	resizeSourceVersion = "4x"
+
+	// cspNoncePlaceholder represents the placeholder that will be replaced by
+	// the nonce generated for each request in the index CSP.
+	cspNoncePlaceholder = "{{nonce}}"
+
+	// defaultIndexCSP represents the default Content-Security-Policy used when
+	// serving the index.html file.
+	defaultIndexCSP = "default-src 'none'; " +
+		"base-uri 'self'; " +
+		"connect-src 'self' https://play.openpolicyagent.org https://www.google-analytics.com https://kubernetesjsonschema.dev; " +
+		"font-src 'self'; " +
+		"form-action 'self'; " +
+		"frame-ancestors 'none'; " +
+		"img-src 'self' https:; " +
+		"manifest-src 'self'; " +
+		"object-src 'none'; " +
+		"script-src 'self' 'nonce-" + cspNoncePlaceholder + "' https://www.google-analytics.com; " +
+		"style-src 'self' 'unsafe-inline'"
 )
 
+// defaultAllowedUploadFormats represents the image formats allowed by default
+// when uploading images. They match the formats the image stores can process.
+var defaultAllowedUploadFormats = []string{"gif", "jpeg", "png", "svg"}
+
 // Handlers represents a group of http handlers in charge of handling
 // static files operations.
 type Handlers struct {
 	cfg        *viper.Viper
 	imageStore img.Store
+	transcoder img.Transcoder
 	logger     zerolog.Logger
 	indexTmpl  *template.Template
+	indexCSP   string
+	theme      *theme
+	themeJSON  []byte
+	linksJSON  string
+	assets     *AssetsManifest
 
-	mu          sync.RWMutex
-	imagesCache map[string][]byte
+	maxUploadSize        int64
+	allowedUploadFormats map[string]bool
+
+	imagesCache *imagesCache
 }
 
 // NewHandlers creates a new Handlers instance.
 func NewHandlers(cfg *viper.Viper, imageStore img.Store) *Handlers {
+	cfg.SetDefault("images.upload.maxSize", defaultMaxUploadSize)
+	cfg.SetDefault("images.upload.allowedFormats", defaultAllowedUploadFormats)
+	indexCSP := defaultIndexCSP
+	if p, ok := captcha.Providers[cfg.GetString("server.captcha.provider")]; ok {
+		indexCSP = captchaIndexCSP(p)
+	}
+	cfg.SetDefault("server.csp.indexPolicy", indexCSP)
+	allowedUploadFormats := make(map[string]bool)
+	for _, format := range cfg.GetStringSlice("images.upload.allowedFormats") {
+		allowedUploadFormats[format] = true
+	}
 	h := &Handlers{
-		cfg:         cfg,
-		imageStore:  imageStore,
-		imagesCache: make(map[string][]byte),
-		logger:      log.With().Str("handlers", "static").Logger(),
+		cfg:                  cfg,
+		imageStore:           imageStore,
+		indexCSP:             cfg.GetString("server.csp.indexPolicy"),
+		transcoder:           img.NewExecTranscoder(),
+		maxUploadSize:        cfg.GetInt64("images.upload.maxSize"),
+		allowedUploadFormats: allowedUploadFormats,
+		imagesCache:          newImagesCacheFromConfig(cfg),
+		logger:               log.With().Str("handlers", "static").Logger(),
+	}
+	h.theme = newThemeFromConfig(cfg)
+	h.themeJSON, _ = json.Marshal(h.theme)
+	linksJSON, _ := json.Marshal(h.theme.FooterLinks)
+	h.linksJSON = string(linksJSON)
+	if cfg.GetBool("server.assets.fingerprint") {
+		staticFilesPath := path.Join(cfg.GetString("server.webBuildPath"), "static")
+		assets, err := NewAssetsManifest("/static", staticFilesPath)
+		if err != nil {
+			log.Panic().Err(err).Msg("error setting up static assets manifest")
+		}
+		h.assets = assets
 	}
 	h.setupIndexTemplate()
 	return h
 }
 
-// setupIndexTemplate parses the index.html template for later use.
+// AssetsManifest returns the manifest of the fingerprinted static assets. It
+// returns nil when assets fingerprinting is disabled.
+func (h *Handlers) AssetsManifest() *AssetsManifest {
+	return h.assets
+}
+
+// setupIndexTemplate parses the index.html template for later use. When assets
+// fingerprinting is enabled, the references to the static assets in the index
+// are replaced by their fingerprinted version.
 func (h *Handlers) setupIndexTemplate() {
 	path := path.Join(h.cfg.GetString("server.webBuildPath"), "index.html")
 	text, err := ioutil.ReadFile(path)
 	if err != nil {
 		log.Panic().Err(err).Msg("error reading index.html template")
 	}
+	if h.assets != nil {
+		text = h.assets.Rewrite(text)
+	}
 	h.indexTmpl = template.Must(template.New("").Parse(string(text)))
 }
 
-// Image is an http handler that serves images stored in the database.
+// Image is an http handler that serves images stored in the image store. A
+// specific size can be requested using the width and height query parameters.
 func (h *Handlers) Image(w http.ResponseWriter, r *http.Request) {
 	// Extract image id and version
 	image := chi.URLParam(r, "image")
@@ -79,48 +175,263 @@ func (h *Handlers) Image(w http.ResponseWriter, r *http.Request) {
 		imageID = image
 	}
 
+	// Negotiate the format the image will be served in
+	format := h.negotiateFormat(w, r)
+
+	// Resize image on the fly when a specific size has been requested
+	width, height, err := getRequestedSize(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if width > 0 || height > 0 {
+		h.serveResizedImage(w, r, imageID, width, height, format)
+		return
+	}
+
+	// Stream image data when the store supports it and it doesn't need to be
+	// transcoded, instead of loading it fully in memory
+	if ss, ok := h.imageStore.(img.StreamStore); ok && format == "" {
+		// Images are immutable, so the image id and version identify the
+		// content served, which allows us to avoid hitting the store when the
+		// client already has it
+		etag := contentETag([]byte(image))
+		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(StaticCacheMaxAge))
+		w.Header().Set("ETag", etag)
+		if etagMatches(r, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rc, contentType, err := ss.GetImageReader(r.Context(), imageID, version)
+		if err != nil {
+			w.Header().Del("Cache-Control")
+			w.Header().Del("ETag")
+			h.handleImageError(w, imageID, err)
+			return
+		}
+		defer rc.Close()
+		w.Header().Set("Content-Type", contentType)
+		if _, err := io.Copy(w, rc); err != nil {
+			h.logger.Error().Err(err).Str("method", "Image").Str("imageID", imageID).Msg("error streaming image")
+		}
+		return
+	}
+
 	// Check if image version data is cached
-	h.mu.RLock()
-	data, ok := h.imagesCache[image]
-	h.mu.RUnlock()
+	data, ok := h.imagesCache.Get(image)
 	if !ok {
 		// Get image data from database
 		var err error
 		data, err = h.imageStore.GetImage(r.Context(), imageID, version)
 		if err != nil {
-			if errors.Is(err, hub.ErrNotFound) {
-				w.WriteHeader(http.StatusNotFound)
-			} else {
-				h.logger.Error().Err(err).Str("method", "Image").Str("imageID", imageID).Send()
+			h.handleImageError(w, imageID, err)
+			return
+		}
+
+		// Save image data in cache
+		h.imagesCache.Add(image, data)
+	}
+
+	h.serveImage(w, r, image, data, format)
+}
+
+// serveResizedImage serves a version of the image provided resized to fit
+// within the width and height requested. Resized images are cached, and svg
+// images are served as is as they don't need to be resized.
+func (h *Handlers) serveResizedImage(
+	w http.ResponseWriter,
+	r *http.Request,
+	imageID string,
+	width,
+	height int,
+	format img.Format,
+) {
+	// Check if resized image data is cached
+	key := fmt.Sprintf("%s?%dx%d", imageID, width, height)
+	data, ok := h.imagesCache.Get(key)
+	if !ok {
+		// Get the largest version of the image available and resize it
+		var err error
+		data, err = h.imageStore.GetImage(r.Context(), imageID, resizeSourceVersion)
+		if err != nil {
+			h.handleImageError(w, imageID, err)
+			return
+		}
+		if !svg.Is(data) {
+			data, err = img.Resize(data, width, height)
+			if err != nil {
+				h.logger.Error().Err(err).Str("method", "Image").Str("imageID", imageID).Msg("error resizing image")
 				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		// Save resized image data in cache
+		h.imagesCache.Add(key, data)
+	}
+
+	h.serveImage(w, r, key, data, format)
+}
+
+// serveImage writes the image data provided to the response writer,
+// transcoding it to the format requested when possible. Transcoded images are
+// cached using the key provided. If the image cannot be transcoded, it's served
+// in its original format.
+func (h *Handlers) serveImage(
+	w http.ResponseWriter,
+	r *http.Request,
+	key string,
+	data []byte,
+	format img.Format,
+) {
+	if format != "" && isTranscodable(data) {
+		// Check if transcoded image data is cached
+		key = key + "." + string(format)
+		transcodedData, ok := h.imagesCache.Get(key)
+		if !ok {
+			var err error
+			transcodedData, err = h.transcoder.Transcode(r.Context(), data, format)
+			if err != nil {
+				h.logger.Error().Err(err).Str("method", "Image").Str("key", key).Msg("error transcoding image")
+			} else {
+				// Save transcoded image data in cache
+				h.imagesCache.Add(key, transcodedData)
+				ok = true
 			}
+		}
+		if ok {
+			writeImage(w, r, transcodedData, format.ContentType())
 			return
 		}
+	}
+	writeImage(w, r, data, "")
+}
 
-		// Save image data in cache
-		h.mu.Lock()
-		h.imagesCache[image] = data
-		h.mu.Unlock()
+// negotiateFormat returns the preferred format supported by the transcoder
+// that has been explicitly accepted by the client in the Accept header. An
+// empty format is returned when the image should be served as is.
+func (h *Handlers) negotiateFormat(w http.ResponseWriter, r *http.Request) img.Format {
+	formats := h.transcoder.Formats()
+	if len(formats) == 0 {
+		return ""
 	}
+	w.Header().Add("Vary", "Accept")
 
-	// Set headers and write image data to response writer
+	accepted := make(map[string]bool)
+	for _, mediaRange := range strings.Split(r.Header.Get("Accept"), ",") {
+		parts := strings.Split(mediaRange, ";")
+		q := 1.0
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q > 0 {
+			accepted[strings.ToLower(strings.TrimSpace(parts[0]))] = true
+		}
+	}
+	for _, f := range formats {
+		if accepted[f.ContentType()] {
+			return f
+		}
+	}
+	return ""
+}
+
+// isTranscodable checks if the image data provided can be transcoded.
+func isTranscodable(data []byte) bool {
+	switch http.DetectContentType(data) {
+	case "image/png", "image/jpeg":
+		return true
+	default:
+		return false
+	}
+}
+
+// getRequestedSize returns the image width and height requested, if any. The
+// values provided are rounded up to a multiple of resizeStep and capped to
+// maxResizeDimension to limit the number of different versions of an image
+// that can be generated.
+func getRequestedSize(r *http.Request) (width, height int, err error) {
+	parse := func(param string) (int, error) {
+		v := r.URL.Query().Get(param)
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid %s", param)
+		}
+		n = ((n + resizeStep - 1) / resizeStep) * resizeStep
+		if n > maxResizeDimension {
+			n = maxResizeDimension
+		}
+		return n, nil
+	}
+	if width, err = parse("width"); err != nil {
+		return 0, 0, err
+	}
+	if height, err = parse("height"); err != nil {
+		return 0, 0, err
+	}
+	return width, height, nil
+}
+
+// writeImage sets the appropriate headers and writes the image data provided
+// to the response writer. When no content type is provided, it'll be detected
+// from the image data. Conditional requests are handled using an ETag based on
+// the image data hash.
+func writeImage(w http.ResponseWriter, r *http.Request, data []byte, contentType string) {
 	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(StaticCacheMaxAge))
-	if svg.Is(data) {
+	w.Header().Set("ETag", contentETag(data))
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	} else if svg.Is(data) {
 		w.Header().Set("Content-Type", "image/svg+xml")
 	} else {
 		w.Header().Set("Content-Type", http.DetectContentType(data))
 	}
-	_, _ = w.Write(data)
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+}
+
+// handleImageError writes the appropriate response for the error provided,
+// which occurred while getting an image from the store.
+func (h *Handlers) handleImageError(w http.ResponseWriter, imageID string, err error) {
+	if errors.Is(err, hub.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+	} else {
+		h.logger.Error().Err(err).Str("method", "Image").Str("imageID", imageID).Send()
+		w.WriteHeader(http.StatusInternalServerError)
+	}
 }
 
 // SaveImage is an http handler that stores the provided image returning its id.
+// Only images in one of the allowed formats and not exceeding the maximum size
+// configured are accepted.
 func (h *Handlers) SaveImage(w http.ResponseWriter, r *http.Request) {
-	data, err := ioutil.ReadAll(r.Body)
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, h.maxUploadSize+1))
 	if err != nil {
 		h.logger.Error().Err(err).Str("method", "SaveImage").Msg("error reading body data")
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
+	if int64(len(data)) > h.maxUploadSize {
+		err := fmt.Errorf("image exceeds the maximum size allowed (%d bytes)", h.maxUploadSize)
+		helpers.RenderErrorWithCodeJSON(w, err, http.StatusRequestEntityTooLarge)
+		return
+	}
+	format := img.DetectFormat(data)
+	if format == "" {
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "data provided is not an image"))
+		return
+	}
+	if !h.allowedUploadFormats[format] {
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: image format not allowed: %s", hub.ErrInvalidInput, format))
+		return
+	}
 	imageID, err := h.imageStore.SaveImage(r.Context(), data)
 	if err != nil {
 		h.logger.Error().Err(err).Str("method", "SaveImage").Send()
@@ -131,21 +442,47 @@ func (h *Handlers) SaveImage(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
-// ServeIndex is an http handler that serves the index.html file.
+// GetTheme is an http handler that returns the theme configuration used to
+// customize the web application.
+func (h *Handlers) GetTheme(w http.ResponseWriter, r *http.Request) {
+	helpers.RenderJSON(w, h.themeJSON, helpers.DefaultAPICacheMaxAge, http.StatusOK)
+}
+
+// ServeIndex is an http handler that serves the index.html file. A new nonce
+// is generated for each request, which is injected into the index template so
+// that it can be used in the inline scripts allowed by the CSP.
 func (h *Handlers) ServeIndex(w http.ResponseWriter, r *http.Request) {
+	nonce, err := generateNonce()
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ServeIndex").Msg("error generating nonce")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(indexCacheMaxAge))
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if h.indexCSP != "" {
+		cspHeader := "Content-Security-Policy"
+		if h.cfg.GetBool("server.csp.reportOnly") {
+			cspHeader = "Content-Security-Policy-Report-Only"
+			w.Header().Del("Content-Security-Policy")
+		}
+		w.Header().Set(cspHeader, strings.ReplaceAll(h.indexCSP, cspNoncePlaceholder, nonce))
+	}
 
 	// Execute index template
 	title, _ := r.Context().Value(hub.IndexMetaTitleKey).(string)
 	if title == "" {
-		title = "Artifact Hub"
+		title = h.theme.SiteName
 	}
 	description, _ := r.Context().Value(hub.IndexMetaDescriptionKey).(string)
 	if description == "" {
 		description = "Find, install and publish Kubernetes packages"
 	}
+	oEmbedURL, _ := r.Context().Value(hub.IndexMetaOEmbedURLKey).(string)
 	data := map[string]interface{}{
 		"baseURL":                  h.cfg.GetString("server.baseURL"),
+		"captchaProvider":          h.cfg.GetString("server.captcha.provider"),
+		"captchaSiteKey":           h.cfg.GetString("server.captcha.siteKey"),
 		"title":                    title,
 		"description":              description,
 		"gaTrackingID":             h.cfg.GetString("analytics.gaTrackingID"),
@@ -153,34 +490,231 @@ func (h *Handlers) ServeIndex(w http.ResponseWriter, r *http.Request) {
 		"githubAuth":               h.cfg.IsSet("server.oauth.github"),
 		"googleAuth":               h.cfg.IsSet("server.oauth.google"),
 		"oidcAuth":                 h.cfg.IsSet("server.oauth.oidc"),
+		"passwordAuth":             !h.cfg.GetBool("server.disablePasswordAuth"),
 		"motd":                     h.cfg.GetString("server.motd"),
+		"oEmbedURL":                oEmbedURL,
 		"motdSeverity":             h.cfg.GetString("server.motdSeverity"),
+		"nonce":                    nonce,
+		"siteName":                 h.theme.SiteName,
+		"themePrimaryColor":        h.theme.PrimaryColor,
+		"themeSecondaryColor":      h.theme.SecondaryColor,
+		"themeImages":              h.theme.Images,
+		"themeFooterLinks":         h.linksJSON,
 	}
 	if err := h.indexTmpl.Execute(w, data); err != nil {
 		h.logger.Error().Err(err).Msg("Error executing index template")
 	}
 }
 
-// FileServer sets up a http.FileServer handler to serve static files.
-func FileServer(r chi.Router, public, static string, cacheMaxAge time.Duration) {
+// captchaIndexCSP returns the default index CSP extended to allow loading
+// the widget of the CAPTCHA provider provided.
+func captchaIndexCSP(p *captcha.Provider) string {
+	sources := strings.Join(p.Sources, " ")
+	csp := defaultIndexCSP
+	for _, directive := range []string{"connect-src", "script-src", "style-src"} {
+		csp = strings.Replace(csp, directive+" 'self'", directive+" 'self' "+sources, 1)
+	}
+	return csp + "; frame-src " + sources
+}
+
+// generateNonce generates a random nonce suitable to be used in a CSP.
+func generateNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// FileServer sets up a http.FileServer handler to serve static files. Files are
+// served with an ETag based on their content, and conditional requests using
+// If-None-Match or If-Modified-Since are handled by the http.FileServer, as
+// well as HEAD and byte-range requests (so that clients can resume downloads
+// of large files). When a pre-compressed version of the file requested (.br or
+// .gz) is available and the client accepts its encoding, it'll be served
+// instead.
+//
+// When an assets manifest is provided, the fingerprinted version of the files
+// can also be requested. They are served with an immutable Cache-Control
+// header, as a change in their content results in a different name.
+func FileServer(
+	r chi.Router,
+	public,
+	static string,
+	cacheMaxAge time.Duration,
+	manifest *AssetsManifest,
+) {
 	if strings.ContainsAny(public, "{}*") {
 		panic("FileServer does not permit URL parameters")
 	}
 
 	if public != "/" && public[len(public)-1] != '/' {
-		r.Get(public, http.RedirectHandler(public+"/", http.StatusMovedPermanently).ServeHTTP)
+		redirect := http.RedirectHandler(public+"/", http.StatusMovedPermanently).ServeHTTP
+		r.Get(public, redirect)
+		r.Head(public, redirect)
 		public += "/"
 	}
 
 	fsHandler := http.StripPrefix(public, http.FileServer(http.Dir(static)))
+	etags := &filesETags{}
 
-	r.Get(public+"*", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		file := strings.Replace(r.RequestURI, public, "/", 1)
-		if _, err := os.Stat(static + file); os.IsNotExist(err) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file := path.Clean("/" + strings.TrimPrefix(r.URL.Path, public))
+		cacheControl := helpers.BuildCacheControlHeader(cacheMaxAge)
+		if manifest != nil {
+			if original, ok := manifest.Resolve(file); ok {
+				file = original
+				cacheControl = ImmutableCacheControl
+				r = withPath(r, public+strings.TrimPrefix(original, "/"))
+			}
+		}
+		fi, err := os.Stat(static + file)
+		if os.IsNotExist(err) {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
-		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(cacheMaxAge))
+		w.Header().Set("Cache-Control", cacheControl)
+		if err == nil && !fi.IsDir() {
+			if servePrecompressedFile(w, r, static+file, etags) {
+				return
+			}
+			if etag, err := etags.get(static+file, fi); err == nil {
+				w.Header().Set("ETag", etag)
+			}
+		}
 		fsHandler.ServeHTTP(w, r)
-	}))
+	})
+	r.Get(public+"*", handler)
+	r.Head(public+"*", handler)
+}
+
+// withPath returns a shallow copy of the request provided using the given URL
+// path.
+func withPath(r *http.Request, p string) *http.Request {
+	r2 := new(http.Request)
+	*r2 = *r
+	r2.URL = new(url.URL)
+	*r2.URL = *r.URL
+	r2.URL.Path = p
+	r2.URL.RawPath = ""
+	return r2
+}
+
+// precompressedEncodings represents the encodings of the pre-compressed
+// files supported, sorted by preference, and the extension used by them.
+var precompressedEncodings = []struct {
+	encoding string
+	ext      string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// servePrecompressedFile serves a pre-compressed version of the file provided
+// if it's available and its encoding is accepted by the client. It returns
+// true when the file has been served.
+func servePrecompressedFile(w http.ResponseWriter, r *http.Request, file string, etags *filesETags) bool {
+	for _, pe := range precompressedEncodings {
+		if !acceptsEncoding(r, pe.encoding) {
+			continue
+		}
+		f, err := os.Open(file + pe.ext)
+		if err != nil {
+			continue
+		}
+		fi, err := f.Stat()
+		if err != nil || fi.IsDir() {
+			f.Close()
+			continue
+		}
+		defer f.Close()
+		if ct := mime.TypeByExtension(path.Ext(file)); ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+		if etag, err := etags.get(file+pe.ext, fi); err == nil {
+			w.Header().Set("ETag", etag)
+		}
+		w.Header().Set("Content-Encoding", pe.encoding)
+		w.Header().Add("Vary", "Accept-Encoding")
+		http.ServeContent(w, r, file, fi.ModTime(), f)
+		return true
+	}
+	return false
+}
+
+// acceptsEncoding checks if the encoding provided is accepted by the client,
+// according to the Accept-Encoding header of the request.
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, v := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		parts := strings.Split(v, ";")
+		if !strings.EqualFold(strings.TrimSpace(parts[0]), encoding) {
+			continue
+		}
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if q, err := strconv.ParseFloat(p[2:], 64); err == nil && q == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// contentETag returns an ETag for the content provided based on its hash.
+func contentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf(`"%x"`, sum[:16])
+}
+
+// etagMatches checks if any of the ETags in the If-None-Match header of the
+// request provided matches the ETag given.
+func etagMatches(r *http.Request, etag string) bool {
+	inm := r.Header.Get("If-None-Match")
+	if inm == "" {
+		return false
+	}
+	for _, v := range strings.Split(inm, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// filesETags keeps track of the ETags of the files served, so that they only
+// need to be computed again when the files are modified.
+type filesETags struct {
+	cache sync.Map
+}
+
+// fileETag represents the ETag of a file at a given point in time.
+type fileETag struct {
+	modTime time.Time
+	size    int64
+	etag    string
+}
+
+// get returns the ETag of the file provided.
+func (e *filesETags) get(path string, fi os.FileInfo) (string, error) {
+	if v, ok := e.cache.Load(path); ok {
+		fe := v.(*fileETag)
+		if fe.modTime.Equal(fi.ModTime()) && fe.size == fi.Size() {
+			return fe.etag, nil
+		}
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	etag := contentETag(content)
+	e.cache.Store(path, &fileETag{
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+		etag:    etag,
+	})
+	return etag, nil
 }
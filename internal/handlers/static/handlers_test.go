@@ -1,6 +1,7 @@
 package static
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,16 +10,20 @@ import (
 	"net/http/httptest"
 	"os"
 	"path"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/disintegration/imaging"
 	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,6 +47,7 @@ func TestImage(t *testing.T) {
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
 		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(nil, hub.ErrNotFound)
 		hw.h.Image(w, r)
 		resp := w.Result()
@@ -58,6 +64,7 @@ func TestImage(t *testing.T) {
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
 		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(nil, errors.New("internal error"))
 		hw.h.Image(w, r)
 		resp := w.Result()
@@ -87,6 +94,7 @@ func TestImage(t *testing.T) {
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
+				hw.tr.On("Formats").Return(nil)
 				hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(imgData, nil)
 				hw.h.Image(w, r)
 				resp := w.Result()
@@ -97,23 +105,445 @@ func TestImage(t *testing.T) {
 				assert.Equal(t, http.StatusOK, resp.StatusCode)
 				assert.Equal(t, tc.expectedContentType, h.Get("Content-Type"))
 				assert.Equal(t, helpers.BuildCacheControlHeader(StaticCacheMaxAge), h.Get("Cache-Control"))
+				assert.Equal(t, contentETag(imgData), h.Get("ETag"))
 				assert.Equal(t, imgData, data)
 				hw.is.AssertExpectations(t)
 			})
 		}
 	})
+
+	t.Run("image not modified", func(t *testing.T) {
+		t.Parallel()
+		imgData, err := ioutil.ReadFile("testdata/image.png")
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("If-None-Match", contentETag(imgData))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(imgData, nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		assert.Equal(t, contentETag(imgData), resp.Header.Get("ETag"))
+		assert.Empty(t, data)
+		hw.is.AssertExpectations(t)
+	})
+}
+
+func TestImageResize(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"image"},
+			Values: []string{"imageID"},
+		},
+	}
+
+	t.Run("invalid size requested", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?width=invalid", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("non existing image", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?width=50", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		hw.is.On("GetImage", r.Context(), "imageID", resizeSourceVersion).Return(nil, hub.ErrNotFound)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("png image is resized and cached", func(t *testing.T) {
+		t.Parallel()
+		imgData, err := ioutil.ReadFile("testdata/image.png")
+		require.NoError(t, err)
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		hw.is.On("GetImage", mock.Anything, "imageID", resizeSourceVersion).Return(imgData, nil).Once()
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/?width=45&height=45", nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			hw.h.Image(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+			assert.Equal(t, helpers.BuildCacheControlHeader(StaticCacheMaxAge), resp.Header.Get("Cache-Control"))
+			resizedImg, err := imaging.Decode(bytes.NewReader(data))
+			require.NoError(t, err)
+			assert.LessOrEqual(t, resizedImg.Bounds().Dx(), 50)
+			assert.LessOrEqual(t, resizedImg.Bounds().Dy(), 50)
+		}
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("svg image is served as is", func(t *testing.T) {
+		t.Parallel()
+		imgData, err := ioutil.ReadFile("testdata/image.svg")
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?height=50", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		hw.is.On("GetImage", r.Context(), "imageID", resizeSourceVersion).Return(imgData, nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+		assert.Equal(t, imgData, data)
+		hw.is.AssertExpectations(t)
+	})
+}
+
+func TestEtagMatches(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		{"", false},
+		{`"other"`, false},
+		{`"etag"`, true},
+		{`W/"etag"`, true},
+		{`"other", "etag"`, true},
+		{"*", true},
+	}
+	for _, tc := range testCases {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("If-None-Match", tc.ifNoneMatch)
+		assert.Equal(t, tc.expected, etagMatches(r, `"etag"`), tc.ifNoneMatch)
+	}
+}
+
+func TestGetRequestedSize(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		query          string
+		expectedWidth  int
+		expectedHeight int
+		expectedError  bool
+	}{
+		{"", 0, 0, false},
+		{"width=50", 50, 0, false},
+		{"height=41", 0, 50, false},
+		{"width=1&height=1", 10, 10, false},
+		{"width=10000", maxResizeDimension, 0, false},
+		{"width=0", 0, 0, true},
+		{"height=-10", 0, 0, true},
+		{"width=abc", 0, 0, true},
+	}
+	for _, tc := range testCases {
+		r, _ := http.NewRequest("GET", "/?"+tc.query, nil)
+		width, height, err := getRequestedSize(r)
+		if tc.expectedError {
+			assert.Error(t, err, tc.query)
+		} else {
+			assert.NoError(t, err, tc.query)
+			assert.Equal(t, tc.expectedWidth, width, tc.query)
+			assert.Equal(t, tc.expectedHeight, height, tc.query)
+		}
+	}
+}
+
+func TestImageTranscoding(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"image"},
+			Values: []string{"imageID@2x"},
+		},
+	}
+	pngImgData, err := ioutil.ReadFile("testdata/image.png")
+	require.NoError(t, err)
+	svgImgData, err := ioutil.ReadFile("testdata/image.svg")
+	require.NoError(t, err)
+
+	t.Run("image transcoded to the preferred format accepted and cached", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return([]img.Format{img.AVIF, img.WebP})
+		hw.is.On("GetImage", mock.Anything, "imageID", "2x").Return(pngImgData, nil).Once()
+		hw.tr.On("Transcode", mock.Anything, pngImgData, img.AVIF).Return([]byte("avifData"), nil).Once()
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept", "image/webp,image/avif,*/*")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			hw.h.Image(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "image/avif", resp.Header.Get("Content-Type"))
+			assert.Equal(t, "Accept", resp.Header.Get("Vary"))
+			assert.Equal(t, []byte("avifData"), data)
+		}
+		hw.is.AssertExpectations(t)
+		hw.tr.AssertExpectations(t)
+	})
+
+	t.Run("formats explicitly not accepted are skipped", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", "image/avif;q=0, image/webp;q=0.8")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return([]img.Format{img.AVIF, img.WebP})
+		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(pngImgData, nil)
+		hw.tr.On("Transcode", r.Context(), pngImgData, img.WebP).Return([]byte("webpData"), nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/webp", resp.Header.Get("Content-Type"))
+		assert.Equal(t, []byte("webpData"), data)
+		hw.is.AssertExpectations(t)
+		hw.tr.AssertExpectations(t)
+	})
+
+	t.Run("no supported format accepted, original image served", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", "image/png,*/*")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return([]img.Format{img.AVIF, img.WebP})
+		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(pngImgData, nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "Accept", resp.Header.Get("Vary"))
+		assert.Equal(t, pngImgData, data)
+		hw.is.AssertExpectations(t)
+		hw.tr.AssertExpectations(t)
+	})
+
+	t.Run("svg images are not transcoded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", "image/avif,image/webp")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return([]img.Format{img.AVIF, img.WebP})
+		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(svgImgData, nil)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+		assert.Equal(t, svgImgData, data)
+		hw.is.AssertExpectations(t)
+		hw.tr.AssertExpectations(t)
+	})
+
+	t.Run("transcoding failed, original image served", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept", "image/webp")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return([]img.Format{img.WebP})
+		hw.is.On("GetImage", r.Context(), "imageID", "2x").Return(pngImgData, nil)
+		hw.tr.On("Transcode", r.Context(), pngImgData, img.WebP).Return(nil, tests.ErrFake)
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, pngImgData, data)
+		hw.is.AssertExpectations(t)
+		hw.tr.AssertExpectations(t)
+	})
+}
+
+func TestImageStreamStore(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"image"},
+			Values: []string{"imageID@2x"},
+		},
+	}
+
+	t.Run("non existing image", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		is := &img.StreamStoreMock{}
+		is.On("GetImageReader", r.Context(), "imageID", "2x").Return(nil, "", hub.ErrNotFound)
+		hw.h.imageStore = is
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		is.AssertExpectations(t)
+	})
+
+	t.Run("existing image is streamed", func(t *testing.T) {
+		t.Parallel()
+		imgData, err := ioutil.ReadFile("testdata/image.png")
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		is := &img.StreamStoreMock{}
+		rc := ioutil.NopCloser(bytes.NewReader(imgData))
+		is.On("GetImageReader", r.Context(), "imageID", "2x").Return(rc, "image/png", nil)
+		hw.h.imageStore = is
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(StaticCacheMaxAge), resp.Header.Get("Cache-Control"))
+		assert.Equal(t, imgData, data)
+		is.AssertExpectations(t)
+	})
+
+	t.Run("image not modified, store not hit", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("If-None-Match", contentETag([]byte("imageID@2x")))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tr.On("Formats").Return(nil)
+		is := &img.StreamStoreMock{}
+		hw.h.imageStore = is
+		hw.h.Image(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		is.AssertExpectations(t)
+	})
 }
 
 func TestSaveImage(t *testing.T) {
 	fakeSaveImageError := errors.New("fake save image error")
+	pngImgData, err := ioutil.ReadFile("testdata/image.png")
+	require.NoError(t, err)
 
-	t.Run("imageStore.SaveImage failed", func(t *testing.T) {
+	t.Run("image exceeds the maximum size allowed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(pngImgData))
+
+		hw := newHandlersWrapper()
+		hw.h.maxUploadSize = int64(len(pngImgData) - 1)
+		hw.h.SaveImage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		assert.Contains(t, string(data), "image exceeds the maximum size allowed")
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("data provided is not an image", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
 		r, _ := http.NewRequest("POST", "/", strings.NewReader("imageData"))
 
 		hw := newHandlersWrapper()
-		hw.is.On("SaveImage", r.Context(), []byte("imageData")).Return("", fakeSaveImageError)
+		hw.h.SaveImage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, string(data), "data provided is not an image")
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("image format not allowed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(pngImgData))
+
+		hw := newHandlersWrapper()
+		hw.h.allowedUploadFormats = map[string]bool{"svg": true}
+		hw.h.SaveImage(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, string(data), "image format not allowed: png")
+		hw.is.AssertExpectations(t)
+	})
+
+	t.Run("imageStore.SaveImage failed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(pngImgData))
+
+		hw := newHandlersWrapper()
+		hw.is.On("SaveImage", r.Context(), pngImgData).Return("", fakeSaveImageError)
 		hw.h.SaveImage(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
@@ -125,10 +555,10 @@ func TestSaveImage(t *testing.T) {
 	t.Run("imageStore.SaveImage succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("POST", "/", strings.NewReader("imageData"))
+		r, _ := http.NewRequest("POST", "/", bytes.NewReader(pngImgData))
 
 		hw := newHandlersWrapper()
-		hw.is.On("SaveImage", r.Context(), []byte("imageData")).Return("imageID", nil)
+		hw.is.On("SaveImage", r.Context(), pngImgData).Return("imageID", nil)
 		hw.h.SaveImage(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
@@ -143,28 +573,146 @@ func TestSaveImage(t *testing.T) {
 	})
 }
 
-func TestServeIndex(t *testing.T) {
+func TestGetTheme(t *testing.T) {
 	t.Parallel()
 	w := httptest.NewRecorder()
 	r, _ := http.NewRequest("GET", "/", nil)
 
 	hw := newHandlersWrapper()
-	hw.h.ServeIndex(w, r)
+	hw.h.GetTheme(w, r)
 	resp := w.Result()
 	defer resp.Body.Close()
 	h := resp.Header
 	data, _ := ioutil.ReadAll(resp.Body)
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
-	assert.Equal(t, helpers.BuildCacheControlHeader(indexCacheMaxAge), h.Get("Cache-Control"))
-	assert.Equal(t, []byte("title:Artifact Hub\ndescription:Find, install and publish Kubernetes packages\ngaTrackingID:1234\n"), data)
+	assert.Equal(t, "application/json", h.Get("Content-Type"))
+	assert.Equal(t, helpers.BuildCacheControlHeader(helpers.DefaultAPICacheMaxAge), h.Get("Cache-Control"))
+	assert.JSONEq(t, `{
+		"site_name": "Artifact Hub",
+		"images": {
+			"apple_touch_icon_192": "/static/media/logo192.png",
+			"apple_touch_icon_512": "/static/media/logo512.png",
+			"open_graph_image": "/static/media/artifactHub.png",
+			"shortcut_icon": "/static/media/logo.png"
+		},
+		"footer_links": []
+	}`, string(data))
+}
+
+func TestServeIndex(t *testing.T) {
+	t.Run("index served with a new nonce in the CSP", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+
+		nonces := make(map[string]bool)
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			hw.h.ServeIndex(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			h := resp.Header
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, helpers.BuildCacheControlHeader(indexCacheMaxAge), h.Get("Cache-Control"))
+			assert.Equal(t, "text/html; charset=utf-8", h.Get("Content-Type"))
+			matches := regexp.MustCompile(`(?s)^title:Artifact Hub
+description:Find, install and publish Kubernetes packages
+gaTrackingID:1234
+nonce:([\w-]{22})
+stylesheet:/static/test.css
+$`).FindSubmatch(data)
+			require.Len(t, matches, 2)
+			nonce := string(matches[1])
+			expectedCSP := strings.ReplaceAll(defaultIndexCSP, cspNoncePlaceholder, nonce)
+			assert.Equal(t, expectedCSP, h.Get("Content-Security-Policy"))
+			assert.Empty(t, h.Get("Content-Security-Policy-Report-Only"))
+			assert.False(t, nonces[nonce])
+			nonces[nonce] = true
+		}
+	})
+
+	t.Run("site name used as default title", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.h.theme = &theme{SiteName: "Custom Hub", Images: &themeImages{}}
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		hw.h.ServeIndex(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(string(data), "title:Custom Hub\n"))
+	})
+
+	t.Run("references to fingerprinted assets rewritten", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.cfg.Set("server.assets.fingerprint", true)
+		hw.h = NewHandlers(hw.cfg, hw.is)
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		hw.h.ServeIndex(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(data), "stylesheet:/static/test.df633ad2.css\n")
+	})
+
+	t.Run("default CSP allows captcha provider sources when enabled", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.cfg.Set("server.captcha.provider", "turnstile")
+		hw.h = NewHandlers(hw.cfg, hw.is)
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		hw.h.ServeIndex(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		csp := resp.Header.Get("Content-Security-Policy")
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, csp, "script-src 'self' https://challenges.cloudflare.com 'nonce-")
+		assert.Contains(t, csp, "connect-src 'self' https://challenges.cloudflare.com ")
+		assert.Contains(t, csp, "style-src 'self' https://challenges.cloudflare.com 'unsafe-inline'")
+		assert.True(t, strings.HasSuffix(csp, "; frame-src https://challenges.cloudflare.com"))
+	})
+
+	t.Run("custom CSP in report only mode", func(t *testing.T) {
+		t.Parallel()
+		hw := newHandlersWrapper()
+		hw.cfg.Set("server.csp.reportOnly", true)
+		hw.h.indexCSP = "script-src 'nonce-{{nonce}}'"
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		hw.h.ServeIndex(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, h.Get("Content-Security-Policy"))
+		assert.Regexp(t, `^script-src 'nonce-[\w-]{22}'$`, h.Get("Content-Security-Policy-Report-Only"))
+	})
 }
 
 func TestServeStaticFile(t *testing.T) {
 	hw := newHandlersWrapper()
 	r := chi.NewRouter()
 	staticFilesPath := path.Join(hw.h.cfg.GetString("server.webBuildPath"), "static")
-	FileServer(r, "/static", staticFilesPath, StaticCacheMaxAge)
+	manifest, err := NewAssetsManifest("/static", staticFilesPath)
+	require.NoError(t, err)
+	FileServer(r, "/static", staticFilesPath, StaticCacheMaxAge, manifest)
 	s := httptest.NewServer(r)
 	defer s.Close()
 
@@ -187,13 +735,182 @@ func TestServeStaticFile(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		assert.Equal(t, helpers.BuildCacheControlHeader(StaticCacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, contentETag([]byte("testCssData\n")), h.Get("ETag"))
+		assert.Equal(t, []byte("testCssData\n"), data)
+	})
+
+	t.Run("existing static file not modified", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", s.URL+"/static/test.css", nil)
+		req.Header.Set("If-None-Match", contentETag([]byte("testCssData\n")))
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		assert.Empty(t, data)
+	})
+
+	t.Run("existing static file requested with query string", func(t *testing.T) {
+		resp, err := http.Get(s.URL + "/static/test.css?v=1")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []byte("testCssData\n"), data)
+	})
+
+	t.Run("existing static file head request", func(t *testing.T) {
+		resp, err := http.Head(s.URL + "/static/test.css")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, helpers.BuildCacheControlHeader(StaticCacheMaxAge), h.Get("Cache-Control"))
+		assert.Equal(t, contentETag([]byte("testCssData\n")), h.Get("ETag"))
+		assert.Equal(t, "bytes", h.Get("Accept-Ranges"))
+		assert.Equal(t, "12", h.Get("Content-Length"))
+		assert.Empty(t, data)
+	})
+
+	t.Run("existing static file range request", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", s.URL+"/static/test.css", nil)
+		req.Header.Set("Range", "bytes=4-6")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "bytes 4-6/12", h.Get("Content-Range"))
+		assert.Equal(t, []byte("Css"), data)
+	})
+
+	t.Run("existing static file range request with outdated if-range", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", s.URL+"/static/test.css", nil)
+		req.Header.Set("Range", "bytes=4-6")
+		req.Header.Set("If-Range", `"outdated"`)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
 		assert.Equal(t, []byte("testCssData\n"), data)
 	})
+
+	t.Run("existing static file invalid range request", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", s.URL+"/static/test.css", nil)
+		req.Header.Set("Range", "bytes=100-200")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+	})
+
+	t.Run("fingerprinted static file", func(t *testing.T) {
+		resp, err := http.Get(s.URL + "/static/test.df633ad2.css")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, ImmutableCacheControl, h.Get("Cache-Control"))
+		assert.Equal(t, "text/css; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, contentETag([]byte("testCssData\n")), h.Get("ETag"))
+		assert.Equal(t, []byte("testCssData\n"), data)
+	})
+
+	t.Run("fingerprinted static file with outdated hash", func(t *testing.T) {
+		resp, err := http.Get(s.URL + "/static/test.00000000.css")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("fingerprinted precompressed static file", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", s.URL+"/static/precompressed.823d3b70.css", nil)
+		req.Header.Set("Accept-Encoding", "br")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+		expectedData, err := ioutil.ReadFile(path.Join(staticFilesPath, "precompressed.css.br"))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, ImmutableCacheControl, h.Get("Cache-Control"))
+		assert.Equal(t, "br", h.Get("Content-Encoding"))
+		assert.Equal(t, expectedData, data)
+	})
+
+	t.Run("precompressed static file", func(t *testing.T) {
+		testCases := []struct {
+			acceptEncoding   string
+			expectedEncoding string
+			expectedFile     string
+		}{
+			{"gzip, deflate, br", "br", "precompressed.css.br"},
+			{"gzip", "gzip", "precompressed.css.gz"},
+			{"gzip, br;q=0", "gzip", "precompressed.css.gz"},
+			{"identity", "", "precompressed.css"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.acceptEncoding, func(t *testing.T) {
+				req, _ := http.NewRequest("GET", s.URL+"/static/precompressed.css", nil)
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+				expectedData, err := ioutil.ReadFile(path.Join(staticFilesPath, tc.expectedFile))
+				require.NoError(t, err)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, tc.expectedEncoding, h.Get("Content-Encoding"))
+				assert.Equal(t, "text/css; charset=utf-8", h.Get("Content-Type"))
+				assert.Equal(t, contentETag(expectedData), h.Get("ETag"))
+				assert.Equal(t, expectedData, data)
+			})
+		}
+	})
+}
+
+func TestAcceptsEncoding(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		acceptEncoding string
+		expected       bool
+	}{
+		{"", false},
+		{"gzip", false},
+		{"br", true},
+		{"gzip, BR", true},
+		{"gzip, br;q=0.5", true},
+		{"gzip, br;q=0", false},
+	}
+	for _, tc := range testCases {
+		r, _ := http.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		assert.Equal(t, tc.expected, acceptsEncoding(r, "br"), tc.acceptEncoding)
+	}
 }
 
 type handlersWrapper struct {
 	cfg *viper.Viper
 	is  *img.StoreMock
+	tr  *img.TranscoderMock
 	h   *Handlers
 }
 
@@ -202,10 +919,14 @@ func newHandlersWrapper() *handlersWrapper {
 	cfg.Set("server.webBuildPath", "testdata")
 	cfg.Set("analytics.gaTrackingID", "1234")
 	is := &img.StoreMock{}
+	tr := &img.TranscoderMock{}
+	h := NewHandlers(cfg, is)
+	h.transcoder = tr
 
 	return &handlersWrapper{
 		cfg: cfg,
 		is:  is,
-		h:   NewHandlers(cfg, is),
+		tr:  tr,
+		h:   h,
 	}
 }
@@ -0,0 +1,108 @@
+package static
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// fingerprintLength represents the number of hex characters of the file
+	// content hash included in the fingerprinted file names.
+	fingerprintLength = 8
+
+	// ImmutableCacheControl is the Cache-Control header value used when
+	// serving fingerprinted static assets, as their content never changes.
+	ImmutableCacheControl = "public, max-age=31536000, immutable"
+)
+
+// AssetsManifest keeps track of the fingerprinted names of the static assets
+// available in a directory. Fingerprinted names include a hash of the file
+// content (i.e. js/main.js -> js/main.1a2b3c4d.js), so that they can be cached
+// forever by clients, as any change in the content will produce a new name.
+type AssetsManifest struct {
+	public string
+	refRE  *regexp.Regexp
+
+	// fingerprinted maps the original files paths to their fingerprinted
+	// version, and files does the opposite.
+	fingerprinted map[string]string
+	files         map[string]string
+}
+
+// NewAssetsManifest creates a new AssetsManifest instance for the files in the
+// static directory provided, which are expected to be served from the public
+// path given. Pre-compressed files (.br and .gz) are not fingerprinted, as they
+// are served transparently in place of the original ones.
+func NewAssetsManifest(public, static string) (*AssetsManifest, error) {
+	public = strings.TrimSuffix(public, "/")
+	m := &AssetsManifest{
+		public:        public,
+		refRE:         regexp.MustCompile(regexp.QuoteMeta(public) + `/[\w\-./@~]+`),
+		fingerprinted: make(map[string]string),
+		files:         make(map[string]string),
+	}
+	err := filepath.Walk(static, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		for _, pe := range precompressedEncodings {
+			if strings.HasSuffix(p, pe.ext) {
+				return nil
+			}
+		}
+		content, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(static, p)
+		if err != nil {
+			return err
+		}
+		file := "/" + filepath.ToSlash(rel)
+		fp := fingerprint(file, content)
+		m.fingerprinted[file] = fp
+		m.files[fp] = file
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Resolve returns the original path of the fingerprinted file provided. The
+// path is expected to be relative to the static directory (i.e. /js/main.js).
+func (m *AssetsManifest) Resolve(fingerprintedFile string) (string, bool) {
+	file, ok := m.files[fingerprintedFile]
+	return file, ok
+}
+
+// Rewrite replaces the references to the static assets found in the content
+// provided with their fingerprinted version. References to files not found in
+// the manifest are left untouched.
+func (m *AssetsManifest) Rewrite(content []byte) []byte {
+	return m.refRE.ReplaceAllFunc(content, func(ref []byte) []byte {
+		if fp, ok := m.fingerprinted[strings.TrimPrefix(string(ref), m.public)]; ok {
+			return []byte(m.public + fp)
+		}
+		return ref
+	})
+}
+
+// fingerprint returns the fingerprinted version of the file path provided,
+// which includes a hash of its content before the file extension.
+func fingerprint(file string, content []byte) string {
+	sum := sha256.Sum256(content)
+	hash := fmt.Sprintf("%x", sum)[:fingerprintLength]
+	ext := path.Ext(file)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(file, ext), hash, ext)
+}
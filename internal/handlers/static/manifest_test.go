@@ -0,0 +1,40 @@
+package static
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetsManifest(t *testing.T) {
+	t.Parallel()
+
+	m, err := NewAssetsManifest("/static/", "testdata/static")
+	require.NoError(t, err)
+
+	t.Run("precompressed files are not fingerprinted", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, map[string]string{
+			"/precompressed.css": "/precompressed.823d3b70.css",
+			"/test.css":          "/test.df633ad2.css",
+		}, m.fingerprinted)
+	})
+
+	t.Run("resolve", func(t *testing.T) {
+		t.Parallel()
+		file, ok := m.Resolve("/test.df633ad2.css")
+		assert.True(t, ok)
+		assert.Equal(t, "/test.css", file)
+
+		_, ok = m.Resolve("/test.css")
+		assert.False(t, ok)
+	})
+
+	t.Run("rewrite", func(t *testing.T) {
+		t.Parallel()
+		content := []byte(`<link href="/static/test.css" /><script src="/static/js/unknown.js"></script>`)
+		expected := []byte(`<link href="/static/test.df633ad2.css" /><script src="/static/js/unknown.js"></script>`)
+		assert.Equal(t, expected, m.Rewrite(content))
+	})
+}
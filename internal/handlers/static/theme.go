@@ -0,0 +1,80 @@
+package static
+
+import (
+	"regexp"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const defaultSiteName = "Artifact Hub"
+
+// colorRE is a regexp used to validate the theme colors provided.
+var colorRE = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// theme represents the configuration used to customize the look of the web
+// application, so that operators can rebrand their deployments without having
+// to rebuild it.
+type theme struct {
+	SiteName       string       `json:"site_name"`
+	PrimaryColor   string       `json:"primary_color,omitempty"`
+	SecondaryColor string       `json:"secondary_color,omitempty"`
+	Images         *themeImages `json:"images"`
+	FooterLinks    []*themeLink `json:"footer_links"`
+}
+
+// themeImages represents the images used by the web application that can be
+// customized.
+type themeImages struct {
+	AppleTouchIcon192 string `json:"apple_touch_icon_192"`
+	AppleTouchIcon512 string `json:"apple_touch_icon_512"`
+	OpenGraphImage    string `json:"open_graph_image"`
+	ShortcutIcon      string `json:"shortcut_icon"`
+	WebsiteLogo       string `json:"website_logo,omitempty"`
+}
+
+// themeLink represents a link displayed in the web application footer.
+type themeLink struct {
+	Title string `json:"title" mapstructure:"title"`
+	URL   string `json:"url" mapstructure:"url"`
+}
+
+// newThemeFromConfig creates a new theme instance from the configuration
+// provided. Images not provided default to the ones shipped with the web
+// application, and invalid colors are ignored.
+func newThemeFromConfig(cfg *viper.Viper) *theme {
+	baseURL := cfg.GetString("server.baseURL")
+	valueOrDefault := func(key, defaultValue string) string {
+		if v := cfg.GetString(key); v != "" {
+			return v
+		}
+		return defaultValue
+	}
+	color := func(key string) string {
+		v := cfg.GetString(key)
+		if v != "" && !colorRE.MatchString(v) {
+			log.Error().Str("key", key).Str("color", v).Msg("invalid theme color, ignoring it")
+			return ""
+		}
+		return v
+	}
+
+	t := &theme{
+		SiteName:       valueOrDefault("theme.siteName", defaultSiteName),
+		PrimaryColor:   color("theme.colors.primary"),
+		SecondaryColor: color("theme.colors.secondary"),
+		Images: &themeImages{
+			AppleTouchIcon192: valueOrDefault("theme.images.appleTouchIcon192", baseURL+"/static/media/logo192.png"),
+			AppleTouchIcon512: valueOrDefault("theme.images.appleTouchIcon512", baseURL+"/static/media/logo512.png"),
+			OpenGraphImage:    valueOrDefault("theme.images.openGraphImage", baseURL+"/static/media/artifactHub.png"),
+			ShortcutIcon:      valueOrDefault("theme.images.shortcutIcon", baseURL+"/static/media/logo.png"),
+			WebsiteLogo:       cfg.GetString("theme.images.websiteLogo"),
+		},
+		FooterLinks: []*themeLink{},
+	}
+	if err := cfg.UnmarshalKey("theme.footerLinks", &t.FooterLinks); err != nil {
+		log.Error().Err(err).Msg("invalid theme footer links, ignoring them")
+		t.FooterLinks = []*themeLink{}
+	}
+	return t
+}
@@ -0,0 +1,71 @@
+package static
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewThemeFromConfig(t *testing.T) {
+	t.Run("default theme", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.baseURL", "https://hub.test")
+
+		assert.Equal(t, &theme{
+			SiteName: defaultSiteName,
+			Images: &themeImages{
+				AppleTouchIcon192: "https://hub.test/static/media/logo192.png",
+				AppleTouchIcon512: "https://hub.test/static/media/logo512.png",
+				OpenGraphImage:    "https://hub.test/static/media/artifactHub.png",
+				ShortcutIcon:      "https://hub.test/static/media/logo.png",
+			},
+			FooterLinks: []*themeLink{},
+		}, newThemeFromConfig(cfg))
+	})
+
+	t.Run("custom theme", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.baseURL", "https://hub.test")
+		cfg.Set("theme.siteName", "Custom Hub")
+		cfg.Set("theme.colors.primary", "#417598")
+		cfg.Set("theme.colors.secondary", "#fff")
+		cfg.Set("theme.images.appleTouchIcon192", "https://img.test/192.png")
+		cfg.Set("theme.images.appleTouchIcon512", "https://img.test/512.png")
+		cfg.Set("theme.images.openGraphImage", "https://img.test/og.png")
+		cfg.Set("theme.images.shortcutIcon", "https://img.test/icon.png")
+		cfg.Set("theme.images.websiteLogo", "https://img.test/logo.svg")
+		cfg.Set("theme.footerLinks", []map[string]interface{}{
+			{"title": "Terms", "url": "https://hub.test/terms"},
+		})
+
+		assert.Equal(t, &theme{
+			SiteName:       "Custom Hub",
+			PrimaryColor:   "#417598",
+			SecondaryColor: "#fff",
+			Images: &themeImages{
+				AppleTouchIcon192: "https://img.test/192.png",
+				AppleTouchIcon512: "https://img.test/512.png",
+				OpenGraphImage:    "https://img.test/og.png",
+				ShortcutIcon:      "https://img.test/icon.png",
+				WebsiteLogo:       "https://img.test/logo.svg",
+			},
+			FooterLinks: []*themeLink{
+				{Title: "Terms", URL: "https://hub.test/terms"},
+			},
+		}, newThemeFromConfig(cfg))
+	})
+
+	t.Run("invalid colors are ignored", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("theme.colors.primary", "red; background: url(x)")
+		cfg.Set("theme.colors.secondary", "#12345")
+
+		th := newThemeFromConfig(cfg)
+		assert.Empty(t, th.PrimaryColor)
+		assert.Empty(t, th.SecondaryColor)
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -35,3 +36,21 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	}
 	helpers.RenderJSON(w, dataJSON, 6*time.Hour, http.StatusOK)
 }
+
+// GetSeries is an http handler that returns the time series of the metric
+// provided.
+func (h *Handlers) GetSeries(w http.ResponseWriter, r *http.Request) {
+	input := &hub.GetStatsSeriesInput{
+		Metric:   chi.URLParam(r, "metric"),
+		Interval: r.FormValue("interval"),
+		From:     r.FormValue("from"),
+		To:       r.FormValue("to"),
+	}
+	dataJSON, err := h.statsManager.GetSeriesJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetSeries").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 6*time.Hour, http.StatusOK)
+}
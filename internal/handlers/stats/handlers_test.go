@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -9,8 +10,10 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/stats"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
@@ -57,6 +60,76 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetSeries(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"metric"},
+			Values: []string{"packages"},
+		},
+	}
+	input := &hub.GetStatsSeriesInput{
+		Metric:   "packages",
+		Interval: "day",
+		From:     "2021-04-01",
+		To:       "2021-05-01",
+	}
+
+	t.Run("error getting stats series", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?interval=day&from=2021-04-01&to=2021-05-01", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("GetSeriesJSON", r.Context(), input).Return(nil, tc.err)
+				hw.h.GetSeries(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("get stats series succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?interval=day&from=2021-04-01&to=2021-05-01", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetSeriesJSON", r.Context(), input).Return([]byte("dataJSON"), nil)
+		hw.h.GetSeries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(6*time.Hour), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
 type handlersWrapper struct {
 	sm *stats.ManagerMock
 	h  *Handlers
@@ -3,27 +3,57 @@ package subscription
 import (
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/subscription"
 	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
 )
 
+const (
+	// defaultLimit represents the number of subscriptions returned when no
+	// limit is provided.
+	defaultLimit = 20
+)
+
+// unsubscribeTmpl represents the template of the page rendered when following
+// an unsubscribe link, asking the user to confirm the request.
+var unsubscribeTmpl = template.Must(template.New("").Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <title>Unsubscribe</title>
+  </head>
+  <body>
+    <p>Please confirm you no longer want to receive these notifications.</p>
+    <form method="post">
+      <input type="hidden" name="token" value="{{ .Token }}">
+      <button type="submit">Unsubscribe</button>
+    </form>
+  </body>
+</html>
+`))
+
 // Handlers represents a group of http handlers in charge of handling
 // subscriptions operations.
 type Handlers struct {
 	subscriptionManager hub.SubscriptionManager
+	cfg                 *viper.Viper
 	logger              zerolog.Logger
 }
 
 // NewHandlers creates a new Handlers instance.
-func NewHandlers(subscriptionManager hub.SubscriptionManager) *Handlers {
+func NewHandlers(subscriptionManager hub.SubscriptionManager, cfg *viper.Viper) *Handlers {
 	return &Handlers{
 		subscriptionManager: subscriptionManager,
+		cfg:                 cfg,
 		logger:              log.With().Str("handlers", "subscription").Logger(),
 	}
 }
@@ -44,6 +74,23 @@ func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// AddBulk is an http handler that subscribes the user doing the request to
+// all the packages of the repository or publisher provided.
+func (h *Handlers) AddBulk(w http.ResponseWriter, r *http.Request) {
+	input := &hub.BulkSubscriptionInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddBulk").Msg("invalid bulk subscription input")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.subscriptionManager.AddBulk(r.Context(), input); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddBulk").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
 // AddOptOut is an http handler that adds the provided opt-out to the database.
 func (h *Handlers) AddOptOut(w http.ResponseWriter, r *http.Request) {
 	o := &hub.OptOut{}
@@ -60,6 +107,23 @@ func (h *Handlers) AddOptOut(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// AddScoped is an http handler that subscribes the user doing the request to
+// the repository or publisher provided.
+func (h *Handlers) AddScoped(w http.ResponseWriter, r *http.Request) {
+	s := &hub.ScopedSubscription{}
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddScoped").Msg("invalid scoped subscription")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.subscriptionManager.AddScoped(r.Context(), s); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddScoped").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
 // Delete is an http handler that removes the provided subscription from the
 // database.
 func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
@@ -82,6 +146,30 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeleteBulk is an http handler that unsubscribes the user doing the request
+// from all the packages of the repository or publisher provided.
+func (h *Handlers) DeleteBulk(w http.ResponseWriter, r *http.Request) {
+	eventKind, err := strconv.Atoi(r.FormValue("event_kind"))
+	if err != nil {
+		errMsg := "invalid event kind"
+		h.logger.Error().Err(err).Str("method", "DeleteBulk").Msg(errMsg)
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, errMsg))
+		return
+	}
+	input := &hub.BulkSubscriptionInput{
+		RepositoryID:     r.FormValue("repository_id"),
+		OrganizationName: r.FormValue("organization_name"),
+		UserAlias:        r.FormValue("user_alias"),
+		EventKind:        hub.EventKind(eventKind),
+	}
+	if err := h.subscriptionManager.DeleteBulk(r.Context(), input); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteBulk").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // DeleteOptOut is an http handler that removes the provided opt-out from the
 // database.
 func (h *Handlers) DeleteOptOut(w http.ResponseWriter, r *http.Request) {
@@ -94,6 +182,42 @@ func (h *Handlers) DeleteOptOut(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeleteScoped is an http handler that removes the provided repository or
+// publisher subscription from the database.
+func (h *Handlers) DeleteScoped(w http.ResponseWriter, r *http.Request) {
+	eventKind, err := strconv.Atoi(r.FormValue("event_kind"))
+	if err != nil {
+		errMsg := "invalid event kind"
+		h.logger.Error().Err(err).Str("method", "DeleteScoped").Msg(errMsg)
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, errMsg))
+		return
+	}
+	s := &hub.ScopedSubscription{
+		RepositoryID:     r.FormValue("repository_id"),
+		OrganizationName: r.FormValue("organization_name"),
+		UserAlias:        r.FormValue("user_alias"),
+		EventKind:        hub.EventKind(eventKind),
+	}
+	if err := h.subscriptionManager.DeleteScoped(r.Context(), s); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteScoped").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Export is an http handler that returns all the subscriptions of the user
+// doing the request in a format that can be imported later.
+func (h *Handlers) Export(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.subscriptionManager.ExportJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Export").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetByPackage is an http handler that returns the subscriptions a user has
 // for a given package.
 func (h *Handlers) GetByPackage(w http.ResponseWriter, r *http.Request) {
@@ -130,3 +254,117 @@ func (h *Handlers) GetOptOutList(w http.ResponseWriter, r *http.Request) {
 	}
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
+
+// GetScopedByUser is an http handler that returns the repositories and
+// publishers subscriptions of the user doing the request.
+func (h *Handlers) GetScopedByUser(w http.ResponseWriter, r *http.Request) {
+	dataJSON, err := h.subscriptionManager.GetScopedByUserJSON(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetScopedByUser").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Import is an http handler that subscribes the user doing the request to the
+// packages and event kinds provided, using the format returned by Export.
+func (h *Handlers) Import(w http.ResponseWriter, r *http.Request) {
+	var entries []*hub.SubscriptionsExportEntry
+	if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+		h.logger.Error().Err(err).Str("method", "Import").Msg("invalid subscriptions")
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.subscriptionManager.Import(r.Context(), entries); err != nil {
+		h.logger.Error().Err(err).Str("method", "Import").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Search is an http handler that returns the subscriptions of the user doing
+// the request that match the query parameters provided.
+func (h *Handlers) Search(w http.ResponseWriter, r *http.Request) {
+	input, err := buildSearchInput(r.URL.Query())
+	if err != nil {
+		err = fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		h.logger.Error().Err(err).Str("query", r.URL.RawQuery).Str("method", "Search").Msg("invalid query")
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	dataJSON, err := h.subscriptionManager.SearchJSON(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Search").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Unsubscribe is an http handler that stops delivering the notifications
+// described by the unsubscribe token provided to the corresponding user. It
+// does not require the user to be logged in, as the token is signed. Links in
+// notifications emails use GET requests, which only render a page asking the
+// user to confirm, as they may be followed by link scanners. Confirmations
+// are redirected to the home page once processed, whereas one-click
+// unsubscribe requests (RFC 8058) from email clients get an empty response.
+func (h *Handlers) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	token := r.FormValue("token")
+	input, err := subscription.ParseUnsubscribeToken(h.cfg.GetString("notifications.unsubscribeKey"), token)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Unsubscribe").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if r.Method == http.MethodGet {
+		w.Header().Set("Cache-Control", helpers.BuildCacheControlHeader(0))
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := unsubscribeTmpl.Execute(w, map[string]string{"Token": token}); err != nil {
+			h.logger.Error().Err(err).Str("method", "Unsubscribe").Send()
+		}
+		return
+	}
+	if err := h.subscriptionManager.Unsubscribe(r.Context(), input); err != nil {
+		h.logger.Error().Err(err).Str("method", "Unsubscribe").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if r.PostFormValue("List-Unsubscribe") == "One-Click" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, h.cfg.GetString("server.baseURL"), http.StatusSeeOther)
+}
+
+// buildSearchInput builds a search subscriptions input from the query string
+// provided.
+func buildSearchInput(qs url.Values) (*hub.SearchSubscriptionsInput, error) {
+	input := &hub.SearchSubscriptionsInput{
+		Limit: defaultLimit,
+	}
+	if qs.Get("limit") != "" {
+		limit, err := strconv.Atoi(qs.Get("limit"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit: %s", qs.Get("limit"))
+		}
+		input.Limit = limit
+	}
+	if qs.Get("offset") != "" {
+		offset, err := strconv.Atoi(qs.Get("offset"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset: %s", qs.Get("offset"))
+		}
+		input.Offset = offset
+	}
+	input.Cursor = qs.Get("cursor")
+	for _, v := range qs["kind"] {
+		kind, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kind: %s", v)
+		}
+		input.EventKinds = append(input.EventKinds, hub.EventKind(kind))
+	}
+	return input, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -117,6 +118,70 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestAddBulk(t *testing.T) {
+	t.Run("invalid input provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.AddBulk(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid input provided", func(t *testing.T) {
+		input := &hub.BulkSubscriptionInput{
+			OrganizationName: "org1",
+			EventKind:        hub.NewRelease,
+		}
+		inputJSON, _ := json.Marshal(input)
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"add bulk subscription succeeded",
+				nil,
+				http.StatusCreated,
+			},
+			{
+				"error adding bulk subscription (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error adding bulk subscription (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(string(inputJSON)))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("AddBulk", r.Context(), input).Return(tc.err)
+				hw.h.AddBulk(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestAddOptOut(t *testing.T) {
 	t.Run("invalid opt-out entry provided", func(t *testing.T) {
 		testCases := []struct {
@@ -209,6 +274,75 @@ func TestAddOptOut(t *testing.T) {
 	})
 }
 
+func TestAddScoped(t *testing.T) {
+	t.Run("invalid input provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("-"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.AddScoped(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid input provided", func(t *testing.T) {
+		s := &hub.ScopedSubscription{
+			UserAlias: "user1",
+			EventKind: hub.NewRelease,
+		}
+		sJSON, _ := json.Marshal(s)
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"add scoped subscription succeeded",
+				nil,
+				http.StatusCreated,
+			},
+			{
+				"error adding scoped subscription (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error adding scoped subscription (not found)",
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				"error adding scoped subscription (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(string(sJSON)))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("AddScoped", r.Context(), s).Return(tc.err)
+				hw.h.AddScoped(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Run("invalid subscription provided", func(t *testing.T) {
 		testCases := []struct {
@@ -308,6 +442,70 @@ func TestDelete(t *testing.T) {
 	})
 }
 
+func TestDeleteBulk(t *testing.T) {
+	t.Run("invalid event kind provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/?organization_name=org1&event_kind=invalid", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.DeleteBulk(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid input provided", func(t *testing.T) {
+		input := &hub.BulkSubscriptionInput{
+			RepositoryID: "00000000-0000-0000-0000-000000000001",
+			EventKind:    hub.SecurityAlert,
+		}
+		qs := "repository_id=00000000-0000-0000-0000-000000000001&event_kind=1"
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"delete bulk subscription succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error deleting bulk subscription (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error deleting bulk subscription (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/?"+qs, nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("DeleteBulk", r.Context(), input).Return(tc.err)
+				hw.h.DeleteBulk(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestDeleteOptOut(t *testing.T) {
 	optOutID := "00000000-0000-0000-0000-000000000001"
 	rctx := &chi.Context{
@@ -354,6 +552,109 @@ func TestDeleteOptOut(t *testing.T) {
 	}
 }
 
+func TestDeleteScoped(t *testing.T) {
+	t.Run("invalid event kind provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/?user_alias=user1&event_kind=invalid", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.DeleteScoped(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid input provided", func(t *testing.T) {
+		s := &hub.ScopedSubscription{
+			OrganizationName: "org1",
+			EventKind:        hub.PackageDeprecated,
+		}
+		qs := "organization_name=org1&event_kind=5"
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"delete scoped subscription succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error deleting scoped subscription (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error deleting scoped subscription (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/?"+qs, nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("DeleteScoped", r.Context(), s).Return(tc.err)
+				hw.h.DeleteScoped(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestExport(t *testing.T) {
+	t.Run("error exporting user subscriptions", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("ExportJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.Export(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("export user subscriptions succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("ExportJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.Export(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
 func TestGetByPackage(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
@@ -498,16 +799,329 @@ func TestGetOptOutList(t *testing.T) {
 	})
 }
 
+func TestGetScopedByUser(t *testing.T) {
+	t.Run("error getting user scoped subscriptions", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetScopedByUserJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetScopedByUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("get user scoped subscriptions succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("GetScopedByUserJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetScopedByUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestImport(t *testing.T) {
+	t.Run("invalid subscriptions provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{}"))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.Import(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("valid subscriptions provided", func(t *testing.T) {
+		entries := []*hub.SubscriptionsExportEntry{
+			{
+				RepositoryName: "repo1",
+				PackageName:    "pkg1",
+				EventKinds:     []hub.EventKind{hub.NewRelease},
+			},
+		}
+		entriesJSON, _ := json.Marshal(entries)
+
+		testCases := []struct {
+			description        string
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				"import subscriptions succeeded",
+				nil,
+				http.StatusNoContent,
+			},
+			{
+				"error importing subscriptions (invalid input)",
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				"error importing subscriptions (db error)",
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(string(entriesJSON)))
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.sm.On("Import", r.Context(), entries).Return(tc.err)
+				hw.h.Import(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.sm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestSearch(t *testing.T) {
+	t.Run("invalid query provided", func(t *testing.T) {
+		testCases := []string{
+			"limit=a",
+			"offset=b",
+			"kind=c",
+		}
+		for _, qs := range testCases {
+			qs := qs
+			t.Run(qs, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?"+qs, nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+				hw := newHandlersWrapper()
+				hw.h.Search(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("error searching user subscriptions", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("SearchJSON", r.Context(), &hub.SearchSubscriptionsInput{
+			Limit: defaultLimit,
+		}).Return(nil, tests.ErrFakeDB)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("search user subscriptions succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&offset=5&kind=0&kind=5", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("SearchJSON", r.Context(), &hub.SearchSubscriptionsInput{
+			Limit:      10,
+			Offset:     5,
+			EventKinds: []hub.EventKind{hub.NewRelease, hub.PackageDeprecated},
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("search user subscriptions using cursor succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?limit=10&cursor=eyJuYW1lIjogInBrZzEifQ", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.sm.On("SearchJSON", r.Context(), &hub.SearchSubscriptionsInput{
+			Limit:  10,
+			Cursor: "eyJuYW1lIjogInBrZzEifQ",
+		}).Return([]byte("dataJSON"), nil)
+		hw.h.Search(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
+func TestUnsubscribe(t *testing.T) {
+	input := &hub.UnsubscribeInput{
+		UserID:    "00000000-0000-0000-0000-000000000001",
+		EventKind: hub.NewRelease,
+		PackageID: "00000000-0000-0000-0000-000000000001",
+	}
+	validToken := subscription.NewUnsubscribeToken("key", input)
+
+	t.Run("invalid token provided", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			token       string
+		}{
+			{
+				"no token provided",
+				"",
+			},
+			{
+				"malformed token",
+				"invalid",
+			},
+			{
+				"token signed with a different key",
+				subscription.NewUnsubscribeToken("other-key", input),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/?token="+tc.token, nil)
+
+				hw := newHandlersWrapper()
+				hw.h.Unsubscribe(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("confirmation page rendered when following link", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/?token="+validToken, nil)
+
+		hw := newHandlersWrapper()
+		hw.h.Unsubscribe(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/html; charset=utf-8", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Contains(t, string(data), `<form method="post">`)
+		assert.Contains(t, string(data), validToken)
+		hw.sm.AssertNotCalled(t, "Unsubscribe", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error unsubscribing", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader("token=" + validToken)
+		r, _ := http.NewRequest("POST", "/", body)
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		hw := newHandlersWrapper()
+		hw.sm.On("Unsubscribe", r.Context(), input).Return(tests.ErrFakeDB)
+		hw.h.Unsubscribe(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("unsubscribed confirming request", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader("token=" + validToken)
+		r, _ := http.NewRequest("POST", "/?token="+validToken, body)
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		hw := newHandlersWrapper()
+		hw.sm.On("Unsubscribe", r.Context(), input).Return(nil)
+		hw.h.Unsubscribe(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusSeeOther, resp.StatusCode)
+		assert.Equal(t, "http://baseURL", resp.Header.Get("Location"))
+		hw.sm.AssertExpectations(t)
+	})
+
+	t.Run("unsubscribed using one-click request", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader("List-Unsubscribe=One-Click")
+		r, _ := http.NewRequest("POST", "/?token="+validToken, body)
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		hw := newHandlersWrapper()
+		hw.sm.On("Unsubscribe", r.Context(), input).Return(nil)
+		hw.h.Unsubscribe(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.sm.AssertExpectations(t)
+	})
+}
+
 type handlersWrapper struct {
 	sm *subscription.ManagerMock
 	h  *Handlers
 }
 
 func newHandlersWrapper() *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "http://baseURL")
+	cfg.Set("notifications.unsubscribeKey", "key")
 	sm := &subscription.ManagerMock{}
 
 	return &handlersWrapper{
 		sm: sm,
-		h:  NewHandlers(sm),
+		h:  NewHandlers(sm, cfg),
 	}
 }
@@ -0,0 +1,162 @@
+package team
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/team"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Handlers represents a group of http handlers in charge of handling teams
+// operations.
+type Handlers struct {
+	teamManager hub.TeamManager
+	logger      zerolog.Logger
+}
+
+// NewHandlers creates a new Handlers instance.
+func NewHandlers(teamManager hub.TeamManager) *Handlers {
+	return &Handlers{
+		teamManager: teamManager,
+		logger:      log.With().Str("handlers", "team").Logger(),
+	}
+}
+
+// Add is an http handler that adds the provided team to the organization.
+func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
+	t := &hub.Team{}
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	if err := h.teamManager.Add(r.Context(), orgName, t); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		if errors.Is(err, team.ErrNameNotAvailable) {
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// AddMember is an http handler that adds a member to the provided team.
+func (h *Handlers) AddMember(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	teamName := chi.URLParam(r, "teamName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.teamManager.AddMember(r.Context(), orgName, teamName, userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "AddMember").Send()
+		if errors.Is(err, team.ErrUserNotMember) {
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// Delete is an http handler that deletes the provided team from the
+// organization.
+func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	teamName := chi.URLParam(r, "teamName")
+	if err := h.teamManager.Delete(r.Context(), orgName, teamName); err != nil {
+		h.logger.Error().Err(err).Str("method", "Delete").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteMember is an http handler that removes a member from the provided
+// team.
+func (h *Handlers) DeleteMember(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	teamName := chi.URLParam(r, "teamName")
+	userAlias := chi.URLParam(r, "userAlias")
+	if err := h.teamManager.DeleteMember(r.Context(), orgName, teamName, userAlias); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteMember").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteRepositoryPermissions is an http handler that revokes the permissions
+// the team has been granted on the provided repository.
+func (h *Handlers) DeleteRepositoryPermissions(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	teamName := chi.URLParam(r, "teamName")
+	repoName := chi.URLParam(r, "repoName")
+	err := h.teamManager.DeleteRepositoryPermissions(r.Context(), orgName, teamName, repoName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteRepositoryPermissions").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetByOrg is an http handler that returns the teams of the organization.
+func (h *Handlers) GetByOrg(w http.ResponseWriter, r *http.Request) {
+	orgName := chi.URLParam(r, "orgName")
+	dataJSON, err := h.teamManager.GetByOrgJSON(r.Context(), orgName)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetByOrg").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Update is an http handler that updates the provided team.
+func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
+	t := &hub.Team{}
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	teamName := chi.URLParam(r, "teamName")
+	if err := h.teamManager.Update(r.Context(), orgName, teamName, t); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateRepositoryPermissions is an http handler that sets the actions the
+// team is allowed to perform on the provided repository.
+func (h *Handlers) UpdateRepositoryPermissions(w http.ResponseWriter, r *http.Request) {
+	input := &struct {
+		AllowedActions []hub.Action `json:"allowed_actions"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateRepositoryPermissions").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	orgName := chi.URLParam(r, "orgName")
+	teamName := chi.URLParam(r, "teamName")
+	repoName := chi.URLParam(r, "repoName")
+	err := h.teamManager.UpdateRepositoryPermissions(r.Context(), orgName, teamName, repoName, input.AllowedActions)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "UpdateRepositoryPermissions").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,445 @@
+package team
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/handlers/helpers"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/team"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMain(m *testing.M) {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	os.Exit(m.Run())
+}
+
+var rctx = &chi.Context{
+	URLParams: chi.RouteParams{
+		Keys:   []string{"orgName", "teamName", "userAlias", "repoName"},
+		Values: []string{"org1", "team1", "user1", "repo1"},
+	},
+}
+
+var errorsTestCases = []struct {
+	err                error
+	expectedStatusCode int
+}{
+	{
+		hub.ErrInvalidInput,
+		http.StatusBadRequest,
+	},
+	{
+		hub.ErrInsufficientPrivilege,
+		http.StatusForbidden,
+	},
+	{
+		hub.ErrNotFound,
+		http.StatusNotFound,
+	},
+	{
+		tests.ErrFakeDB,
+		http.StatusInternalServerError,
+	},
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("invalid team provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error adding team", func(t *testing.T) {
+		testCases := append(errorsTestCases, struct {
+			err                error
+			expectedStatusCode int
+		}{
+			team.ErrNameNotAvailable,
+			http.StatusBadRequest,
+		})
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"name": "team1"}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("Add", r.Context(), "org1", mock.Anything).Return(tc.err)
+				hw.h.Add(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("team added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(`{"name": "team1"}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("Add", r.Context(), "org1", &hub.Team{Name: "team1"}).Return(nil)
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+func TestAddMember(t *testing.T) {
+	t.Run("error adding member", func(t *testing.T) {
+		testCases := append(errorsTestCases, struct {
+			err                error
+			expectedStatusCode int
+		}{
+			team.ErrUserNotMember,
+			http.StatusBadRequest,
+		})
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("AddMember", r.Context(), "org1", "team1", "user1").Return(tc.err)
+				hw.h.AddMember(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("member added successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("AddMember", r.Context(), "org1", "team1", "user1").Return(nil)
+		hw.h.AddMember(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("error deleting team", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("Delete", r.Context(), "org1", "team1").Return(tc.err)
+				hw.h.Delete(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("team deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("Delete", r.Context(), "org1", "team1").Return(nil)
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+func TestDeleteMember(t *testing.T) {
+	t.Run("error deleting member", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("DeleteMember", r.Context(), "org1", "team1", "user1").Return(tc.err)
+				hw.h.DeleteMember(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("member deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("DeleteMember", r.Context(), "org1", "team1", "user1").Return(nil)
+		hw.h.DeleteMember(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+func TestDeleteRepositoryPermissions(t *testing.T) {
+	t.Run("error deleting repository permissions", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("DeleteRepositoryPermissions", r.Context(), "org1", "team1", "repo1").Return(tc.err)
+				hw.h.DeleteRepositoryPermissions(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("repository permissions deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("DeleteRepositoryPermissions", r.Context(), "org1", "team1", "repo1").Return(nil)
+		hw.h.DeleteRepositoryPermissions(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrg(t *testing.T) {
+	t.Run("error getting teams", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("GetByOrgJSON", r.Context(), "org1").Return(nil, tc.err)
+				hw.h.GetByOrg(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("teams returned successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("GetByOrgJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("invalid team provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.Update(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error updating team", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"display_name": "Team 1"}`))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("Update", r.Context(), "org1", "team1", mock.Anything).Return(tc.err)
+				hw.h.Update(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("team updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(`{"display_name": "Team 1"}`))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("Update", r.Context(), "org1", "team1", &hub.Team{DisplayName: "Team 1"}).Return(nil)
+		hw.h.Update(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+func TestUpdateRepositoryPermissions(t *testing.T) {
+	body := `{"allowed_actions": ["updateOrganizationRepository"]}`
+	actions := []hub.Action{hub.UpdateOrganizationRepository}
+
+	t.Run("invalid input provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader("{invalid json"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.UpdateRepositoryPermissions(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error updating repository permissions", func(t *testing.T) {
+		for _, tc := range errorsTestCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", strings.NewReader(body))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.tm.On("UpdateRepositoryPermissions", r.Context(), "org1", "team1", "repo1", actions).
+					Return(tc.err)
+				hw.h.UpdateRepositoryPermissions(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.tm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("repository permissions updated successfully", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(body))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.tm.On("UpdateRepositoryPermissions", r.Context(), "org1", "team1", "repo1", actions).Return(nil)
+		hw.h.UpdateRepositoryPermissions(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.tm.AssertExpectations(t)
+	})
+}
+
+type handlersWrapper struct {
+	tm *team.ManagerMock
+	h  *Handlers
+}
+
+func newHandlersWrapper() *handlersWrapper {
+	tm := &team.ManagerMock{}
+
+	return &handlersWrapper{
+		tm: tm,
+		h:  NewHandlers(tm),
+	}
+}
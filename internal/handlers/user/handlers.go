@@ -3,12 +3,14 @@ package user
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha512"
 	"crypto/subtle"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"net"
 	"net/http"
@@ -16,10 +18,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/artifacthub/hub/internal/captcha"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/user"
 	"github.com/coreos/go-oidc"
+	"github.com/form3tech-oss/jwt-go"
 	"github.com/go-chi/chi"
 	"github.com/google/go-github/github"
 	"github.com/gorilla/securecookie"
@@ -42,33 +46,113 @@ const (
 	// secret.
 	APIKeySecretHeader = "X-API-KEY-SECRET" // #nosec
 
+	// CaptchaResponseHeader represents the header used to provide the
+	// response of the CAPTCHA challenge solved by the user.
+	CaptchaResponseHeader = "X-Captcha-Response"
+
 	sessionCookieName    = "sid"
 	oauthStateCookieName = "oas"
 	sessionDuration      = 30 * 24 * time.Hour
 	oauthFailedURL       = "/oauth-failed"
+
+	// bearerPrefix represents the prefix of the authorization header value
+	// used to provide a token.
+	bearerPrefix = "Bearer "
+
+	// defaultTokenDuration represents the validity of the tokens issued when
+	// no duration has been configured.
+	defaultTokenDuration = 15 * time.Minute
+
+	// tokenIssuer represents the issuer of the tokens.
+	tokenIssuer = "artifacthub"
+
+	// tokenPath represents the path of the endpoint used to issue tokens.
+	tokenPath = "/api/v1/users/token"
+
+	// oauthDomainNotAllowedURL represents the url users are redirected to
+	// when the domain of their email is not allowed to sign in.
+	oauthDomainNotAllowedURL = oauthFailedURL + "?reason=domain_not_allowed"
 )
 
 var (
+	// errAccountLocked error indicates that the account has been temporarily
+	// locked due to too many failed login attempts.
+	errAccountLocked = errors.New("account temporarily locked due to too many failed login attempts")
+
+	// errInsufficientAPIKeyScope error indicates that the scopes granted to the
+	// API key provided do not allow performing the request.
+	errInsufficientAPIKeyScope = errors.New("api key scopes do not allow this request")
+
+	// errEmailDomainNotAllowed error indicates that the domain of the email
+	// of the user trying to sign in is not allowed.
+	errEmailDomainNotAllowed = errors.New("email domain not allowed")
+
 	// errInvalidAPIKey error indicates that the API key provided is not valid.
 	errInvalidAPIKey = errors.New("invalid api key")
 
 	// errInvalidSession error indicates that the session provided is not valid.
 	errInvalidSession = errors.New("invalid session")
+
+	// errInvalidToken error indicates that the token provided is not valid.
+	errInvalidToken = errors.New("invalid token")
+
+	// errLoginThrottled error indicates that the login attempt was rejected
+	// because it was made too soon after some previous failed ones.
+	errLoginThrottled = errors.New("too many failed login attempts, please try again later")
+
+	// errPasswordAuthDisabled error indicates that password based registration
+	// and authentication have been disabled in this deployment.
+	errPasswordAuthDisabled = errors.New("password authentication is disabled, please sign in using one of the available providers")
+
+	// errPasswordExpired error indicates that the password of the user has
+	// expired and it must be reset before logging in again.
+	errPasswordExpired = errors.New("password expired, please reset it using the forgot password option")
+
+	// errTokenExchangeNotAllowed error indicates that a token cannot be used
+	// to get a new one.
+	errTokenExchangeNotAllowed = errors.New("tokens cannot be exchanged for new tokens, please use an api key or a session")
 )
 
+// tokenAuthKey represents the key used to flag in the request context that
+// the user was authenticated using a token.
+type tokenAuthKey struct{}
+
+// tokenBindingKey represents the key used for the credential the user was
+// authenticated with inside the request context, which tokens issued will be
+// bound to.
+type tokenBindingKey struct{}
+
+// tokenClaims represents the claims included in the tokens issued. Tokens
+// obtained using API keys restricted to some scopes are restricted to the
+// same scopes. Tokens are bound to the credential used to obtain them.
+type tokenClaims struct {
+	Scopes []hub.APIKeyScope `json:"scopes,omitempty"`
+	hub.TokenBinding
+	jwt.StandardClaims
+}
+
 // Handlers represents a group of http handlers in charge of handling
 // users operations.
 type Handlers struct {
 	userManager  hub.UserManager
+	akut         hub.APIKeyUsageTracker
+	cv           hub.CaptchaVerifier
 	cfg          *viper.Viper
 	sc           *securecookie.SecureCookie
 	oauthConfig  map[string]*oauth2.Config
 	oidcProvider *oidc.Provider
+	tokenKeys    map[string][]byte
 	logger       zerolog.Logger
 }
 
 // NewHandlers creates a new Handlers instance.
-func NewHandlers(ctx context.Context, userManager hub.UserManager, cfg *viper.Viper) (*Handlers, error) {
+func NewHandlers(
+	ctx context.Context,
+	userManager hub.UserManager,
+	akut hub.APIKeyUsageTracker,
+	cv hub.CaptchaVerifier,
+	cfg *viper.Viper,
+) (*Handlers, error) {
 	// Setup secure cookie instance
 	sc := securecookie.New([]byte(cfg.GetString("server.cookie.hashKey")), nil)
 	sc.MaxAge(int(sessionDuration.Seconds()))
@@ -104,12 +188,32 @@ func NewHandlers(ctx context.Context, userManager hub.UserManager, cfg *viper.Vi
 		}
 	}
 
+	// Setup keys used to sign and verify tokens. All the keys provided are
+	// used to verify tokens, so that keys can be rotated without invalidating
+	// the tokens already issued.
+	tokenKeys := make(map[string][]byte)
+	for keyID, secret := range cfg.GetStringMapString("server.jwt.keys") {
+		if secret == "" {
+			return nil, fmt.Errorf("invalid jwt key %s: secret not provided", keyID)
+		}
+		tokenKeys[keyID] = []byte(secret)
+	}
+	signingKeyID := strings.ToLower(cfg.GetString("server.jwt.signingKey"))
+	if len(tokenKeys) > 0 || signingKeyID != "" {
+		if _, ok := tokenKeys[signingKeyID]; !ok {
+			return nil, errors.New("jwt signing key not found in the keys provided")
+		}
+	}
+
 	return &Handlers{
 		userManager:  userManager,
+		akut:         akut,
+		cv:           cv,
 		cfg:          cfg,
 		sc:           sc,
 		oauthConfig:  oauthConfig,
 		oidcProvider: oidcProvider,
+		tokenKeys:    tokenKeys,
 		logger:       log.With().Str("handlers", "user").Logger(),
 	}, nil
 }
@@ -142,6 +246,43 @@ func (h *Handlers) BasicAuth(next http.Handler) http.Handler {
 	})
 }
 
+// ChangeEmail is an http handler used to update the email of the user doing
+// the request, once the new address has been verified using the code emailed
+// to it. When the user sessions are invalidated, the session cookie is deleted
+// as well.
+func (h *Handlers) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Code               string `json:"code"`
+		InvalidateSessions bool   `json:"invalidate_sessions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "ChangeEmail").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	err := h.userManager.ChangeEmail(r.Context(), input.Code, input.InvalidateSessions)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "ChangeEmail").Send()
+		if errors.Is(err, user.ErrInvalidEmailChangeCode) || errors.Is(err, user.ErrEmailNotAvailable) {
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+
+	// Request browser to delete session cookie if sessions were invalidated
+	if input.InvalidateSessions {
+		cookie := &http.Cookie{
+			Name:    sessionCookieName,
+			Path:    "/",
+			Expires: time.Now().Add(-24 * time.Hour),
+		}
+		http.SetCookie(w, cookie)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // CheckAvailability is an http handler that checks the availability of a given
 // value for the provided resource kind.
 func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
@@ -161,6 +302,37 @@ func (h *Handlers) CheckAvailability(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// DeleteUser is an http handler used to schedule the deletion of the user
+// doing the request. The user sessions are invalidated, so the session cookie
+// is deleted as well.
+func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteUser").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	err := h.userManager.DeleteUser(r.Context(), input["code"], h.cfg.GetString("server.baseURL"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "DeleteUser").Send()
+		if errors.Is(err, user.ErrInvalidDeleteUserCode) {
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+
+	// Request browser to delete session cookie
+	cookie := &http.Cookie{
+		Name:    sessionCookieName,
+		Path:    "/",
+		Expires: time.Now().Add(-24 * time.Hour),
+	}
+	http.SetCookie(w, cookie)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // GetProfile is an http handler used to get a logged in user profile.
 func (h *Handlers) GetProfile(w http.ResponseWriter, r *http.Request) {
 	dataJSON, err := h.userManager.GetProfileJSON(r.Context())
@@ -211,6 +383,59 @@ func (h *Handlers) InjectUserID(next http.Handler) http.Handler {
 	})
 }
 
+// IssueToken is an http handler that issues a signed token that can be used
+// to authenticate requests to the API until it expires. Tokens can only be
+// obtained using an API key or a session, and they are only valid while the
+// credential used to obtain them is.
+func (h *Handlers) IssueToken(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value(tokenAuthKey{}).(bool); ok {
+		helpers.RenderErrorWithCodeJSON(w, errTokenExchangeNotAllowed, http.StatusForbidden)
+		return
+	}
+	binding, ok := r.Context().Value(tokenBindingKey{}).(*hub.TokenBinding)
+	if !ok {
+		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+		return
+	}
+
+	// Prepare token claims
+	duration := h.cfg.GetDuration("server.jwt.tokenDuration")
+	if duration <= 0 {
+		duration = defaultTokenDuration
+	}
+	now := time.Now()
+	claims := &tokenClaims{
+		TokenBinding: *binding,
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.NewV4().String(),
+			Issuer:    tokenIssuer,
+			Subject:   r.Context().Value(hub.UserIDKey).(string),
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(duration).Unix(),
+		},
+	}
+	if scopes, ok := r.Context().Value(hub.APIKeyScopesKey).([]hub.APIKeyScope); ok {
+		claims.Scopes = scopes
+	}
+
+	// Sign token using the current signing key
+	signingKeyID := strings.ToLower(h.cfg.GetString("server.jwt.signingKey"))
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = signingKeyID
+	signedToken, err := token.SignedString(h.tokenKeys[signingKeyID])
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "IssueToken").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+
+	dataJSON, _ := json.Marshal(map[string]interface{}{
+		"token":      signedToken,
+		"expires_at": claims.ExpiresAt,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusCreated)
+}
+
 // Login is an http handler used to log a user in.
 func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	// Extract credentials from request
@@ -222,25 +447,45 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if the credentials provided are valid
-	checkCredentialsOutput, err := h.userManager.CheckCredentials(r.Context(), input["email"], input["password"])
+	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+	checkCredentialsOutput, err := h.userManager.CheckCredentials(
+		r.Context(),
+		input["email"],
+		input["password"],
+		ip,
+	)
 	if err != nil {
 		h.logger.Error().Err(err).Str("method", "Login").Msg("checkCredentials failed")
 		helpers.RenderErrorJSON(w, err)
 		return
 	}
 	if !checkCredentialsOutput.Valid {
-		helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+		if checkCredentialsOutput.RetryAfter > 0 {
+			retryAfter := int(math.Ceil(checkCredentialsOutput.RetryAfter.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		}
+		switch {
+		case checkCredentialsOutput.Locked:
+			helpers.RenderErrorWithCodeJSON(w, errAccountLocked, http.StatusLocked)
+		case checkCredentialsOutput.RetryAfter > 0:
+			helpers.RenderErrorWithCodeJSON(w, errLoginThrottled, http.StatusTooManyRequests)
+		default:
+			helpers.RenderErrorWithCodeJSON(w, nil, http.StatusUnauthorized)
+		}
+		return
+	}
+	if checkCredentialsOutput.PasswordExpired {
+		helpers.RenderErrorWithCodeJSON(w, errPasswordExpired, http.StatusForbidden)
 		return
 	}
 
 	// Register user session
-	ip, _, _ := net.SplitHostPort(r.RemoteAddr)
 	session := &hub.Session{
 		UserID:    checkCredentialsOutput.UserID,
 		IP:        ip,
 		UserAgent: r.UserAgent(),
 	}
-	sessionID, err := h.userManager.RegisterSession(r.Context(), session)
+	sessionID, err := h.userManager.RegisterSession(r.Context(), session, h.cfg.GetString("server.baseURL"))
 	if err != nil {
 		h.logger.Error().Err(err).Str("method", "Login").Msg("registerSession failed")
 		helpers.RenderErrorJSON(w, err)
@@ -266,6 +511,7 @@ func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
 		cookie.Secure = true
 	}
 	http.SetCookie(w, cookie)
+	setAuditLogActor(r, checkCredentialsOutput.UserID)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -342,6 +588,10 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 	userID, err := h.registerUserWithOauth(r.Context(), provider, providerConfig, oauthToken)
 	if err != nil {
 		logger.Error().Err(err).Msg("oauth code exchange failed")
+		if errors.Is(err, errEmailDomainNotAllowed) {
+			http.Redirect(w, r, oauthDomainNotAllowedURL, http.StatusSeeOther)
+			return
+		}
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
 		return
 	}
@@ -353,7 +603,7 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 		IP:        ip,
 		UserAgent: r.UserAgent(),
 	}
-	sessionID, err := h.userManager.RegisterSession(r.Context(), session)
+	sessionID, err := h.userManager.RegisterSession(r.Context(), session, h.cfg.GetString("server.baseURL"))
 	if err != nil {
 		logger.Error().Err(err).Msg("registerSession failed")
 		http.Redirect(w, r, oauthFailedURL, http.StatusSeeOther)
@@ -377,6 +627,7 @@ func (h *Handlers) OauthCallback(w http.ResponseWriter, r *http.Request) {
 		sessionCookie.Secure = true
 	}
 	http.SetCookie(w, sessionCookie)
+	setAuditLogActor(r, userID)
 	http.Redirect(w, r, state.RedirectURL, http.StatusSeeOther)
 }
 
@@ -414,6 +665,42 @@ func (h *Handlers) OauthRedirect(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, authCodeURL, http.StatusSeeOther)
 }
 
+// RegisterDeleteUserCode is an http handler used to register a code to confirm
+// the deletion of the user doing the request. The code will be emailed to the
+// user.
+func (h *Handlers) RegisterDeleteUserCode(w http.ResponseWriter, r *http.Request) {
+	err := h.userManager.RegisterDeleteUserCode(r.Context(), h.cfg.GetString("server.baseURL"))
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterDeleteUserCode").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// RegisterEmailChangeCode is an http handler used to register a code to
+// confirm the change of the email of the user doing the request. The code will
+// be emailed to the new address provided.
+func (h *Handlers) RegisterEmailChangeCode(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterEmailChangeCode").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	err := h.userManager.RegisterEmailChangeCode(r.Context(), input["email"])
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "RegisterEmailChangeCode").Send()
+		if errors.Is(err, user.ErrEmailNotAvailable) {
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
 // RegisterPasswordResetCode is an http handler used to register a code to
 // reset the password. The code will be emailed to the address provided.
 func (h *Handlers) RegisterPasswordResetCode(w http.ResponseWriter, r *http.Request) {
@@ -482,6 +769,11 @@ func (h *Handlers) registerUserWithOauth(
 		return "", err
 	}
 
+	// Check the user's email domain is allowed to sign in
+	if !isEmailDomainAllowed(u.Email, h.cfg.GetStringSlice("server.oauthAllowedDomains")) {
+		return "", fmt.Errorf("%w: %s", errEmailDomainNotAllowed, u.Email)
+	}
+
 	// Check user alias availability and append suffix to it if needed
 	available, err := h.userManager.CheckAvailability(ctx, "userAlias", u.Alias)
 	if err != nil {
@@ -641,17 +933,97 @@ func (h *Handlers) newUserFromOIDProfile(
 	}, nil
 }
 
+// RequirePasswordAuth is a middleware that verifies that password based
+// registration and authentication have not been disabled.
+func (h *Handlers) RequirePasswordAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.cfg.GetBool("server.disablePasswordAuth") {
+			helpers.RenderErrorWithCodeJSON(w, errPasswordAuthDisabled, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireCaptcha is a middleware that verifies the CAPTCHA response provided
+// in the request when a CAPTCHA provider has been configured.
+func (h *Handlers) RequireCaptcha(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.cv == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		remoteIP, _, _ := net.SplitHostPort(r.RemoteAddr)
+		err := h.cv.Verify(r.Context(), r.Header.Get(CaptchaResponseHeader), remoteIP)
+		if err != nil {
+			if errors.Is(err, captcha.ErrInvalidResponse) {
+				helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+				return
+			}
+			h.logger.Error().Err(err).Str("method", "RequireCaptcha").Send()
+			helpers.RenderErrorJSON(w, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // RequireLogin is a middleware that verifies if a user is logged in.
 func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var userID string
+		var scopes []hub.APIKeyScope
+		var tokenAuth bool
+		var binding *hub.TokenBinding
 
 		// Extract API key id and secret from header
 		apiKeyID := r.Header.Get(APIKeyIDHeader)
 		apiKeySecret := r.Header.Get(APIKeySecretHeader)
 
-		// Use API key based authentication if API key is provided
-		if apiKeyID != "" && apiKeySecret != "" {
+		// Use token based authentication if a token is provided and tokens
+		// have been enabled, otherwise use API key based authentication if an
+		// API key is provided
+		authHeader := r.Header.Get("Authorization")
+		if len(h.tokenKeys) > 0 && strings.HasPrefix(authHeader, bearerPrefix) {
+			// Check the token provided is valid
+			claims, err := h.parseToken(strings.TrimPrefix(authHeader, bearerPrefix))
+			if err != nil {
+				h.logger.Debug().Err(err).Str("method", "RequireLogin").Msg("invalid token")
+				helpers.RenderErrorWithCodeJSON(w, errInvalidToken, http.StatusUnauthorized)
+				return
+			}
+
+			// Check the token scopes allow performing this request
+			if len(claims.Scopes) > 0 {
+				if !apiKeyScopesAllowRequest(claims.Scopes, r) {
+					helpers.RenderErrorWithCodeJSON(w, errInsufficientAPIKeyScope, http.StatusForbidden)
+					return
+				}
+				scopes = claims.Scopes
+			}
+
+			// Check the credential the token is bound to is still valid
+			valid, err := h.userManager.CheckTokenBinding(r.Context(), claims.Subject, &claims.TokenBinding, sessionDuration)
+			if err != nil {
+				h.logger.Error().Err(err).Str("method", "RequireLogin").Msg("checkTokenBinding failed")
+				helpers.RenderErrorWithCodeJSON(w, nil, http.StatusInternalServerError)
+				return
+			}
+			if !valid {
+				helpers.RenderErrorWithCodeJSON(w, errInvalidToken, http.StatusUnauthorized)
+				return
+			}
+
+			// Track usage of the API key the token is bound to (registered
+			// asynchronously)
+			if claims.TokenBinding.APIKeyID != "" {
+				ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+				h.akut.Track(claims.TokenBinding.APIKeyID, ip)
+			}
+
+			userID = claims.Subject
+			tokenAuth = true
+		} else if apiKeyID != "" && apiKeySecret != "" {
 			// Check the API key provided is valid
 			checkAPIKeyOutput, err := h.userManager.CheckAPIKey(r.Context(), apiKeyID, apiKeySecret)
 			if err != nil {
@@ -664,7 +1036,21 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 				return
 			}
 
+			// Check the API key scopes allow performing this request
+			if len(checkAPIKeyOutput.Scopes) > 0 {
+				if !apiKeyScopesAllowRequest(checkAPIKeyOutput.Scopes, r) {
+					helpers.RenderErrorWithCodeJSON(w, errInsufficientAPIKeyScope, http.StatusForbidden)
+					return
+				}
+				scopes = checkAPIKeyOutput.Scopes
+			}
+
+			// Track API key usage (registered asynchronously)
+			ip, _, _ := net.SplitHostPort(r.RemoteAddr)
+			h.akut.Track(apiKeyID, ip)
+
 			userID = checkAPIKeyOutput.UserID
+			binding = &hub.TokenBinding{APIKeyID: apiKeyID}
 		} else {
 			// Use cookie based authentication
 			cookie, err := r.Cookie(sessionCookieName)
@@ -690,6 +1076,7 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 				}
 
 				userID = checkSessionOutput.UserID
+				binding = &hub.TokenBinding{SessionIDHash: fmt.Sprintf("%x", sha512.Sum512(sessionID))}
 			}
 		}
 
@@ -699,8 +1086,18 @@ func (h *Handlers) RequireLogin(next http.Handler) http.Handler {
 			return
 		}
 
-		// Inject userID in context and call next handler
+		// Inject userID (and API key scopes when restricted) in context and
+		// call next handler
 		ctx := context.WithValue(r.Context(), hub.UserIDKey, userID)
+		if len(scopes) > 0 {
+			ctx = context.WithValue(ctx, hub.APIKeyScopesKey, scopes)
+		}
+		if tokenAuth {
+			ctx = context.WithValue(ctx, tokenAuthKey{}, true)
+		}
+		if binding != nil {
+			ctx = context.WithValue(ctx, tokenBindingKey{}, binding)
+		}
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -731,6 +1128,27 @@ func (h *Handlers) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// RevokeSession is an http handler used to revoke a session using the code
+// sent to the user when signing in from a new device.
+func (h *Handlers) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	var input map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.Error().Err(err).Str("method", "RevokeSession").Msg(hub.ErrInvalidInput.Error())
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+	if err := h.userManager.RevokeSession(r.Context(), input["code"]); err != nil {
+		h.logger.Error().Err(err).Str("method", "RevokeSession").Send()
+		if errors.Is(err, user.ErrInvalidSessionRevocationCode) {
+			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		} else {
+			helpers.RenderErrorJSON(w, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // UpdatePassword is an http handler used to update the password in the hub
 // database.
 func (h *Handlers) UpdatePassword(w http.ResponseWriter, r *http.Request) {
@@ -851,3 +1269,100 @@ func getRandomSuffix() (string, error) {
 	}
 	return strconv.FormatInt(nBig.Int64(), 10), nil
 }
+
+// apiKeyScopesAllowRequest checks if the scopes granted to an API key allow
+// performing the request provided.
+func apiKeyScopesAllowRequest(scopes []hub.APIKeyScope, r *http.Request) bool {
+	// Tokens issued for restricted API keys inherit their scopes, so they
+	// can always be requested
+	if r.Method == http.MethodPost && r.URL.Path == tokenPath {
+		return true
+	}
+
+	readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead
+	for _, scope := range scopes {
+		switch scope {
+		case hub.APIKeyScopeReadOnly:
+			if readOnly {
+				return true
+			}
+		case hub.APIKeyScopePackagesRead:
+			if readOnly && hasPathPrefix(r.URL.Path, "/api/v1/packages") {
+				return true
+			}
+		case hub.APIKeyScopeReposWrite:
+			if hasPathPrefix(r.URL.Path, "/api/v1/repositories") {
+				return true
+			}
+		case hub.APIKeyScopeWebhooksManage:
+			if hasPathPrefix(r.URL.Path, "/api/v1/webhooks") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseToken parses and validates the token provided, returning its claims.
+// The key used to verify the token signature is selected using the key id
+// included in the token header.
+func (h *Handlers) parseToken(tokenString string) (*tokenClaims, error) {
+	claims := &tokenClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %s", t.Header["alg"])
+		}
+		keyID, _ := t.Header["kid"].(string)
+		key, ok := h.tokenKeys[strings.ToLower(keyID)]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id: %s", keyID)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !claims.VerifyIssuer(tokenIssuer, true) {
+		return nil, errors.New("invalid issuer")
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("expiration not provided")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("subject not provided")
+	}
+	if claims.APIKeyID == "" && claims.SessionIDHash == "" {
+		return nil, errors.New("token binding not provided")
+	}
+	return claims, nil
+}
+
+// hasPathPrefix checks if the path provided is the prefix given or any of the
+// paths below it.
+func hasPathPrefix(p, prefix string) bool {
+	return p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// isEmailDomainAllowed checks if the domain of the email provided is in the
+// list of allowed domains. All domains are allowed when the list is empty.
+func isEmailDomainAllowed(email string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+	parts := strings.Split(email, "@")
+	domain := parts[len(parts)-1]
+	for _, allowedDomain := range allowedDomains {
+		if strings.EqualFold(domain, allowedDomain) {
+			return true
+		}
+	}
+	return false
+}
+
+// setAuditLogActor sets the actor of the audit log entry of the request
+// provided, when the request is being audited.
+func setAuditLogActor(r *http.Request, userID string) {
+	if e, ok := r.Context().Value(hub.AuditLogEntryKey).(*hub.AuditLogEntry); ok {
+		e.UserID = userID
+	}
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"crypto/sha512"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -13,10 +14,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/artifacthub/hub/internal/apikey"
+	"github.com/artifacthub/hub/internal/captcha"
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/user"
+	"github.com/form3tech-oss/jwt-go"
 	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
@@ -60,6 +64,103 @@ func TestBasicAuth(t *testing.T) {
 	})
 }
 
+func TestChangeEmail(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`code`)
+		r, _ := http.NewRequest("PUT", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.h.ChangeEmail(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("change email failed", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				user.ErrInvalidEmailChangeCode,
+				http.StatusBadRequest,
+			},
+			{
+				user.ErrEmailNotAvailable,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				body := strings.NewReader(`{"code": "code", "invalidate_sessions": true}`)
+				r, _ := http.NewRequest("PUT", "/", body)
+
+				hw := newHandlersWrapper()
+				hw.um.On("ChangeEmail", r.Context(), "code", true).Return(tc.err)
+				hw.h.ChangeEmail(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Len(t, resp.Cookies(), 0)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("change email succeeded keeping sessions", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code"}`)
+		r, _ := http.NewRequest("PUT", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("ChangeEmail", r.Context(), "code", false).Return(nil)
+		hw.h.ChangeEmail(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Len(t, resp.Cookies(), 0)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("change email succeeded invalidating sessions", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code", "invalidate_sessions": true}`)
+		r, _ := http.NewRequest("PUT", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("ChangeEmail", r.Context(), "code", true).Return(nil)
+		hw.h.ChangeEmail(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 1)
+		cookie := resp.Cookies()[0]
+		assert.Equal(t, sessionCookieName, cookie.Name)
+		assert.True(t, cookie.Expires.Before(time.Now().Add(-24*time.Hour)))
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestCheckAvailability(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -153,6 +254,82 @@ func TestCheckAvailability(t *testing.T) {
 	})
 }
 
+func TestDeleteUser(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`code`)
+		r, _ := http.NewRequest("DELETE", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.h.DeleteUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("delete user failed", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				user.ErrInvalidDeleteUserCode,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				body := strings.NewReader(`{"code": "code"}`)
+				r, _ := http.NewRequest("DELETE", "/", body)
+
+				hw := newHandlersWrapper()
+				hw.um.On("DeleteUser", r.Context(), "code", "baseURL").Return(tc.err)
+				hw.h.DeleteUser(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Len(t, resp.Cookies(), 0)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("delete user succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code"}`)
+		r, _ := http.NewRequest("DELETE", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("DeleteUser", r.Context(), "code", "baseURL").Return(nil)
+		hw.h.DeleteUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Len(t, resp.Cookies(), 1)
+		cookie := resp.Cookies()[0]
+		assert.Equal(t, sessionCookieName, cookie.Name)
+		assert.True(t, cookie.Expires.Before(time.Now().Add(-24*time.Hour)))
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestGetProfile(t *testing.T) {
 	t.Run("error getting profile", func(t *testing.T) {
 		t.Parallel()
@@ -298,6 +475,120 @@ func TestInjectUserID(t *testing.T) {
 	})
 }
 
+func TestIssueToken(t *testing.T) {
+	t.Run("token exchange not allowed", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		ctx := context.WithValue(r.Context(), hub.UserIDKey, "userID")
+		ctx = context.WithValue(ctx, tokenAuthKey{}, true)
+		r = r.WithContext(ctx)
+
+		hw := newHandlersWrapper()
+		hw.h.IssueToken(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, buildError(errTokenExchangeNotAllowed.Error()), data)
+	})
+
+	t.Run("credential used to authenticate not available", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.IssueToken(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("token issued successfully", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			scopes      []hub.APIKeyScope
+			binding     *hub.TokenBinding
+		}{
+			{
+				"without scopes",
+				nil,
+				&hub.TokenBinding{SessionIDHash: "sessionIDHash"},
+			},
+			{
+				"with the scopes of the api key used",
+				[]hub.APIKeyScope{hub.APIKeyScopeReposWrite},
+				&hub.TokenBinding{APIKeyID: "apiKeyID"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", nil)
+				ctx := context.WithValue(r.Context(), hub.UserIDKey, "userID")
+				if tc.scopes != nil {
+					ctx = context.WithValue(ctx, hub.APIKeyScopesKey, tc.scopes)
+				}
+				ctx = context.WithValue(ctx, tokenBindingKey{}, tc.binding)
+				r = r.WithContext(ctx)
+
+				hw := newHandlersWrapper()
+				hw.cfg.Set("server.jwt.tokenDuration", "5m")
+				hw.h.IssueToken(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+				h := resp.Header
+				data, _ := ioutil.ReadAll(resp.Body)
+
+				assert.Equal(t, http.StatusCreated, resp.StatusCode)
+				assert.Equal(t, "application/json", h.Get("Content-Type"))
+				assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+				var output struct {
+					Token     string `json:"token"`
+					ExpiresAt int64  `json:"expires_at"`
+				}
+				require.NoError(t, json.Unmarshal(data, &output))
+				claims, err := hw.h.parseToken(output.Token)
+				require.NoError(t, err)
+				assert.Equal(t, "userID", claims.Subject)
+				assert.Equal(t, tc.scopes, claims.Scopes)
+				assert.Equal(t, *tc.binding, claims.TokenBinding)
+				assert.Equal(t, output.ExpiresAt, claims.ExpiresAt)
+				assert.InDelta(t, time.Now().Add(5*time.Minute).Unix(), claims.ExpiresAt, 5)
+				token, _, _ := new(jwt.Parser).ParseUnverified(output.Token, &tokenClaims{})
+				assert.Equal(t, "key2", token.Header["kid"])
+			})
+		}
+	})
+}
+
+func TestIsEmailDomainAllowed(t *testing.T) {
+	testCases := []struct {
+		email          string
+		allowedDomains []string
+		expectedResult bool
+	}{
+		{"user@email.com", nil, true},
+		{"user@email.com", []string{"email.com"}, true},
+		{"user@EMAIL.com", []string{"other.com", "email.com"}, true},
+		{"user@email.com", []string{"other.com"}, false},
+		{"user@sub.email.com", []string{"email.com"}, false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.email, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedResult, isEmailDomainAllowed(tc.email, tc.allowedDomains))
+		})
+	}
+}
+
 func TestLogin(t *testing.T) {
 	t.Run("invalid", func(t *testing.T) {
 		t.Parallel()
@@ -320,7 +611,7 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
-		hw.um.On("CheckCredentials", r.Context(), "", "").Return(nil, hub.ErrInvalidInput)
+		hw.um.On("CheckCredentials", r.Context(), "", "", "").Return(nil, hub.ErrInvalidInput)
 		hw.h.Login(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
@@ -335,7 +626,7 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
-		hw.um.On("CheckCredentials", r.Context(), "email", "pass").Return(nil, tests.ErrFakeDB)
+		hw.um.On("CheckCredentials", r.Context(), "email", "pass", "").Return(nil, tests.ErrFakeDB)
 		hw.h.Login(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
@@ -351,7 +642,7 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
-		hw.um.On("CheckCredentials", r.Context(), "email", "pass2").
+		hw.um.On("CheckCredentials", r.Context(), "email", "pass2", "").
 			Return(&hub.CheckCredentialsOutput{Valid: false, UserID: ""}, nil)
 		hw.h.Login(w, r)
 		resp := w.Result()
@@ -361,6 +652,66 @@ func TestLogin(t *testing.T) {
 		hw.um.AssertExpectations(t)
 	})
 
+	t.Run("login attempt throttled", func(t *testing.T) {
+		testCases := []struct {
+			output             *hub.CheckCredentialsOutput
+			expectedStatusCode int
+			expectedRetryAfter string
+		}{
+			{
+				&hub.CheckCredentialsOutput{RetryAfter: 1500 * time.Millisecond},
+				http.StatusTooManyRequests,
+				"2",
+			},
+			{
+				&hub.CheckCredentialsOutput{Locked: true, RetryAfter: 15 * time.Minute},
+				http.StatusLocked,
+				"900",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(strconv.Itoa(tc.expectedStatusCode), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				body := strings.NewReader(`{"email": "email", "password": "pass"}`)
+				r, _ := http.NewRequest("POST", "/", body)
+				r.RemoteAddr = "192.168.1.1:12345"
+
+				hw := newHandlersWrapper()
+				hw.um.On("CheckCredentials", r.Context(), "email", "pass", "192.168.1.1").Return(tc.output, nil)
+				hw.h.Login(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				assert.Equal(t, tc.expectedRetryAfter, resp.Header.Get("Retry-After"))
+				assert.Len(t, resp.Cookies(), 0)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("password expired", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"email": "email", "password": "pass"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("CheckCredentials", r.Context(), "email", "pass", "").
+			Return(&hub.CheckCredentialsOutput{Valid: true, UserID: "userID", PasswordExpired: true}, nil)
+		hw.h.Login(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, buildError(errPasswordExpired.Error()), data)
+		assert.Len(t, resp.Cookies(), 0)
+		hw.um.AssertExpectations(t)
+	})
+
 	t.Run("error registering session", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
@@ -368,9 +719,9 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
-		hw.um.On("CheckCredentials", r.Context(), "email", "pass").
+		hw.um.On("CheckCredentials", r.Context(), "email", "pass", "").
 			Return(&hub.CheckCredentialsOutput{Valid: true, UserID: "userID"}, nil)
-		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}).
+		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}, "baseURL").
 			Return(nil, tests.ErrFakeDB)
 		hw.h.Login(w, r)
 		resp := w.Result()
@@ -387,9 +738,9 @@ func TestLogin(t *testing.T) {
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
-		hw.um.On("CheckCredentials", r.Context(), "email", "pass").
+		hw.um.On("CheckCredentials", r.Context(), "email", "pass", "").
 			Return(&hub.CheckCredentialsOutput{Valid: true, UserID: "userID"}, nil)
-		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}).
+		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}, "baseURL").
 			Return([]byte("sessionID"), nil)
 		hw.h.Login(w, r)
 		resp := w.Result()
@@ -408,6 +759,28 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, []byte("sessionID"), sessionID)
 		hw.um.AssertExpectations(t)
 	})
+
+	t.Run("login succeeded sets audit log entry actor", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"email": "email", "password": "pass"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+		e := &hub.AuditLogEntry{Action: hub.AuditUserLogin}
+		r = r.WithContext(context.WithValue(r.Context(), hub.AuditLogEntryKey, e))
+
+		hw := newHandlersWrapper()
+		hw.um.On("CheckCredentials", r.Context(), "email", "pass", "").
+			Return(&hub.CheckCredentialsOutput{Valid: true, UserID: "userID"}, nil)
+		hw.um.On("RegisterSession", r.Context(), &hub.Session{UserID: "userID"}, "baseURL").
+			Return([]byte("sessionID"), nil)
+		hw.h.Login(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "userID", e.UserID)
+		hw.um.AssertExpectations(t)
+	})
 }
 
 func TestLogout(t *testing.T) {
@@ -496,6 +869,41 @@ func TestLogout(t *testing.T) {
 	})
 }
 
+func TestNewHandlers(t *testing.T) {
+	t.Run("jwt signing key not found in the keys provided", func(t *testing.T) {
+		testCases := []map[string]string{
+			nil,
+			{"key1": "secret1"},
+		}
+		for _, keys := range testCases {
+			keys := keys
+			t.Run(fmt.Sprintf("%v", keys), func(t *testing.T) {
+				t.Parallel()
+				cfg := viper.New()
+				cfg.Set("server.jwt.keys", keys)
+				cfg.Set("server.jwt.signingKey", "key2")
+				_, err := NewHandlers(context.Background(), &user.ManagerMock{}, nil, nil, cfg)
+				assert.EqualError(t, err, "jwt signing key not found in the keys provided")
+			})
+		}
+	})
+
+	t.Run("jwt key secret not provided", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("server.jwt.keys", map[string]string{"key1": ""})
+		cfg.Set("server.jwt.signingKey", "key1")
+		_, err := NewHandlers(context.Background(), &user.ManagerMock{}, nil, nil, cfg)
+		assert.Error(t, err)
+	})
+
+	t.Run("handlers created without jwt keys", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewHandlers(context.Background(), &user.ManagerMock{}, nil, nil, viper.New())
+		assert.NoError(t, err)
+	})
+}
+
 func TestOauthCallback(t *testing.T) {
 	t.Run("invalid oauth code or state", func(t *testing.T) {
 		state := &OauthState{
@@ -590,11 +998,114 @@ func TestOauthRedirect(t *testing.T) {
 	assert.Equal(t, expectedRedirectURL, redirectURL.String())
 }
 
-func TestRegisterPasswordResetCode(t *testing.T) {
-	t.Run("invalid input", func(t *testing.T) {
+func TestRegisterDeleteUserCode(t *testing.T) {
+	t.Run("register delete user code failed", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		body := strings.NewReader(`email`)
+		r, _ := http.NewRequest("POST", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterDeleteUserCode", r.Context(), "baseURL").Return(tests.ErrFakeDB)
+		hw.h.RegisterDeleteUserCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("register delete user code succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterDeleteUserCode", r.Context(), "baseURL").Return(nil)
+		hw.h.RegisterDeleteUserCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestRegisterEmailChangeCode(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`email`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.h.RegisterEmailChangeCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("register email change code failed", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				user.ErrEmailNotAvailable,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				body := strings.NewReader(`{"email": "new@email.com"}`)
+				r, _ := http.NewRequest("POST", "/", body)
+
+				hw := newHandlersWrapper()
+				hw.um.On("RegisterEmailChangeCode", r.Context(), "new@email.com").Return(tc.err)
+				hw.h.RegisterEmailChangeCode(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.um.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("register email change code succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"email": "new@email.com"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RegisterEmailChangeCode", r.Context(), "new@email.com").Return(nil)
+		hw.h.RegisterEmailChangeCode(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
+func TestRegisterPasswordResetCode(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`email`)
 		r, _ := http.NewRequest("POST", "/", body)
 
 		hw := newHandlersWrapper()
@@ -843,16 +1354,124 @@ func TestRequireLogin(t *testing.T) {
 			r, _ := http.NewRequest("GET", "/", nil)
 			r.Header.Add(APIKeyIDHeader, apiKeyID)
 			r.Header.Add(APIKeySecretHeader, apiKeySecret)
+			r.RemoteAddr = "192.168.1.1:12345"
 
 			hw := newHandlersWrapper()
 			hw.um.On("CheckAPIKey", r.Context(), apiKeyID, apiKeySecret).
 				Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true}, nil)
-			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			hw.akut.On("Track", apiKeyID, "192.168.1.1")
+			var binding *hub.TokenBinding
+			hw.h.RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				binding, _ = r.Context().Value(tokenBindingKey{}).(*hub.TokenBinding)
+			})).ServeHTTP(w, r)
 			resp := w.Result()
 			defer resp.Body.Close()
 
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, &hub.TokenBinding{APIKeyID: apiKeyID}, binding)
 			hw.um.AssertExpectations(t)
+			hw.akut.AssertExpectations(t)
+		})
+
+		t.Run("api key with scopes", func(t *testing.T) {
+			testCases := []struct {
+				scopes             []hub.APIKeyScope
+				method             string
+				path               string
+				expectedStatusCode int
+			}{
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopeReadOnly},
+					"GET",
+					"/api/v1/webhooks/user",
+					http.StatusOK,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopeReadOnly},
+					"DELETE",
+					"/api/v1/repositories/user/repo1",
+					http.StatusForbidden,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopePackagesRead},
+					"GET",
+					"/api/v1/packages/starred",
+					http.StatusOK,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopePackagesRead},
+					"PUT",
+					"/api/v1/packages/pkg1/stars",
+					http.StatusForbidden,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopePackagesRead},
+					"GET",
+					"/api/v1/repositories/user",
+					http.StatusForbidden,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopeReposWrite},
+					"POST",
+					"/api/v1/repositories/org/org1",
+					http.StatusOK,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopeReposWrite},
+					"POST",
+					"/api/v1/api-keys",
+					http.StatusForbidden,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopeReadOnly, hub.APIKeyScopeWebhooksManage},
+					"PUT",
+					"/api/v1/webhooks/user/webhookID",
+					http.StatusOK,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopeWebhooksManage},
+					"PUT",
+					"/api/v1/webhooksx",
+					http.StatusForbidden,
+				},
+				{
+					[]hub.APIKeyScope{hub.APIKeyScopeReadOnly},
+					"POST",
+					"/api/v1/users/token",
+					http.StatusOK,
+				},
+			}
+			for _, tc := range testCases {
+				tc := tc
+				t.Run(fmt.Sprintf("%v %s %s", tc.scopes, tc.method, tc.path), func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest(tc.method, tc.path, nil)
+					r.Header.Add(APIKeyIDHeader, apiKeyID)
+					r.Header.Add(APIKeySecretHeader, apiKeySecret)
+
+					hw := newHandlersWrapper()
+					hw.um.On("CheckAPIKey", r.Context(), apiKeyID, apiKeySecret).
+						Return(&hub.CheckAPIKeyOutput{UserID: "userID", Valid: true, Scopes: tc.scopes}, nil)
+					if tc.expectedStatusCode == http.StatusOK {
+						hw.akut.On("Track", apiKeyID, "")
+					}
+					var scopesInCtx []hub.APIKeyScope
+					hw.h.RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						scopesInCtx, _ = r.Context().Value(hub.APIKeyScopesKey).([]hub.APIKeyScope)
+						w.WriteHeader(http.StatusOK)
+					})).ServeHTTP(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+
+					assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+					if tc.expectedStatusCode == http.StatusOK {
+						assert.Equal(t, tc.scopes, scopesInCtx)
+					}
+					hw.um.AssertExpectations(t)
+					hw.akut.AssertExpectations(t)
+				})
+			}
 		})
 	})
 
@@ -941,12 +1560,245 @@ func TestRequireLogin(t *testing.T) {
 				Name:  sessionCookieName,
 				Value: encodedSessionID,
 			})
+			var binding *hub.TokenBinding
+			hw.h.RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				binding, _ = r.Context().Value(tokenBindingKey{}).(*hub.TokenBinding)
+			})).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, &hub.TokenBinding{SessionIDHash: fmt.Sprintf("%x", sha512.Sum512(sessionID))}, binding)
+			hw.um.AssertExpectations(t)
+		})
+	})
+
+	t.Run("token based authentication", func(t *testing.T) {
+		t.Run("invalid token provided", func(t *testing.T) {
+			testCases := []struct {
+				description string
+				token       string
+			}{
+				{
+					"malformed token",
+					"invalid",
+				},
+				{
+					"expired token",
+					buildToken("key2", "secret2", jwt.SigningMethodHS256, newTokenClaims(nil, time.Now().Add(-time.Minute))),
+				},
+				{
+					"unknown key id",
+					buildToken("key3", "secret2", jwt.SigningMethodHS256, newTokenClaims(nil, time.Now().Add(time.Minute))),
+				},
+				{
+					"invalid signature",
+					buildToken("key2", "secret1", jwt.SigningMethodHS256, newTokenClaims(nil, time.Now().Add(time.Minute))),
+				},
+				{
+					"unexpected signing method",
+					buildToken("key2", "secret2", jwt.SigningMethodHS512, newTokenClaims(nil, time.Now().Add(time.Minute))),
+				},
+				{
+					"invalid issuer",
+					buildToken("key2", "secret2", jwt.SigningMethodHS256, &tokenClaims{
+						StandardClaims: jwt.StandardClaims{
+							Issuer:    "other",
+							Subject:   "userID",
+							ExpiresAt: time.Now().Add(time.Minute).Unix(),
+						},
+					}),
+				},
+				{
+					"expiration not provided",
+					buildToken("key2", "secret2", jwt.SigningMethodHS256, &tokenClaims{
+						TokenBinding: tokenBinding,
+						StandardClaims: jwt.StandardClaims{
+							Issuer:  tokenIssuer,
+							Subject: "userID",
+						},
+					}),
+				},
+				{
+					"token binding not provided",
+					buildToken("key2", "secret2", jwt.SigningMethodHS256, &tokenClaims{
+						StandardClaims: jwt.StandardClaims{
+							Issuer:    tokenIssuer,
+							Subject:   "userID",
+							ExpiresAt: time.Now().Add(time.Minute).Unix(),
+						},
+					}),
+				},
+			}
+			for _, tc := range testCases {
+				tc := tc
+				t.Run(tc.description, func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest("GET", "/", nil)
+					r.Header.Set("Authorization", "Bearer "+tc.token)
+
+					hw := newHandlersWrapper()
+					hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+					data, _ := ioutil.ReadAll(resp.Body)
+
+					assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+					assert.Equal(t, buildError(errInvalidToken.Error()), data)
+				})
+			}
+		})
+
+		t.Run("error checking token binding", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			token := buildToken("key2", "secret2", jwt.SigningMethodHS256, newTokenClaims(nil, time.Now().Add(time.Minute)))
+			r.Header.Set("Authorization", "Bearer "+token)
+
+			hw := newHandlersWrapper()
+			hw.um.On("CheckTokenBinding", r.Context(), "userID", &tokenBinding, sessionDuration).Return(false, tests.ErrFakeDB)
+			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+			hw.um.AssertExpectations(t)
+		})
+
+		t.Run("token bound to a credential no longer valid", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			token := buildToken("key2", "secret2", jwt.SigningMethodHS256, newTokenClaims(nil, time.Now().Add(time.Minute)))
+			r.Header.Set("Authorization", "Bearer "+token)
+
+			hw := newHandlersWrapper()
+			hw.um.On("CheckTokenBinding", r.Context(), "userID", &tokenBinding, sessionDuration).Return(false, nil)
+			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+			resp := w.Result()
+			defer resp.Body.Close()
+			data, _ := ioutil.ReadAll(resp.Body)
+
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			assert.Equal(t, buildError(errInvalidToken.Error()), data)
+			hw.um.AssertExpectations(t)
+		})
+
+		t.Run("token based authentication succeeded", func(t *testing.T) {
+			testCases := []struct {
+				description string
+				keyID       string
+				secret      string
+			}{
+				{
+					"token signed with the current signing key",
+					"key2",
+					"secret2",
+				},
+				{
+					"token signed with a previous signing key",
+					"key1",
+					"secret1",
+				},
+			}
+			for _, tc := range testCases {
+				tc := tc
+				t.Run(tc.description, func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest("GET", "/", nil)
+					token := buildToken(tc.keyID, tc.secret, jwt.SigningMethodHS256, newTokenClaims(nil, time.Now().Add(time.Minute)))
+					r.Header.Set("Authorization", "Bearer "+token)
+
+					hw := newHandlersWrapper()
+					hw.um.On("CheckTokenBinding", r.Context(), "userID", &tokenBinding, sessionDuration).Return(true, nil)
+					hw.akut.On("Track", tokenBinding.APIKeyID, "")
+					var userID string
+					var tokenAuth bool
+					hw.h.RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						userID, _ = r.Context().Value(hub.UserIDKey).(string)
+						tokenAuth, _ = r.Context().Value(tokenAuthKey{}).(bool)
+					})).ServeHTTP(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+
+					assert.Equal(t, http.StatusOK, resp.StatusCode)
+					assert.Equal(t, "userID", userID)
+					assert.True(t, tokenAuth)
+					hw.um.AssertExpectations(t)
+					hw.akut.AssertExpectations(t)
+				})
+			}
+		})
+
+		t.Run("token bound to a session does not track api key usage", func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			r, _ := http.NewRequest("GET", "/", nil)
+			claims := newTokenClaims(nil, time.Now().Add(time.Minute))
+			claims.TokenBinding = hub.TokenBinding{SessionIDHash: "sessionIDHash"}
+			token := buildToken("key2", "secret2", jwt.SigningMethodHS256, claims)
+			r.Header.Set("Authorization", "Bearer "+token)
+
+			hw := newHandlersWrapper()
+			hw.um.On("CheckTokenBinding", r.Context(), "userID", &claims.TokenBinding, sessionDuration).Return(true, nil)
 			hw.h.RequireLogin(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
 			resp := w.Result()
 			defer resp.Body.Close()
 
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
 			hw.um.AssertExpectations(t)
+			hw.akut.AssertNotCalled(t, "Track", mock.Anything, mock.Anything)
+		})
+
+		t.Run("token with scopes", func(t *testing.T) {
+			testCases := []struct {
+				method             string
+				path               string
+				expectedStatusCode int
+			}{
+				{
+					"POST",
+					"/api/v1/repositories/org/org1",
+					http.StatusOK,
+				},
+				{
+					"POST",
+					"/api/v1/api-keys",
+					http.StatusForbidden,
+				},
+			}
+			for _, tc := range testCases {
+				tc := tc
+				t.Run(fmt.Sprintf("%s %s", tc.method, tc.path), func(t *testing.T) {
+					t.Parallel()
+					w := httptest.NewRecorder()
+					r, _ := http.NewRequest(tc.method, tc.path, nil)
+					scopes := []hub.APIKeyScope{hub.APIKeyScopeReposWrite}
+					token := buildToken("key2", "secret2", jwt.SigningMethodHS256, newTokenClaims(scopes, time.Now().Add(time.Minute)))
+					r.Header.Set("Authorization", "Bearer "+token)
+
+					hw := newHandlersWrapper()
+					if tc.expectedStatusCode == http.StatusOK {
+						hw.um.On("CheckTokenBinding", r.Context(), "userID", &tokenBinding, sessionDuration).Return(true, nil)
+						hw.akut.On("Track", tokenBinding.APIKeyID, "")
+					}
+					var scopesInCtx []hub.APIKeyScope
+					hw.h.RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						scopesInCtx, _ = r.Context().Value(hub.APIKeyScopesKey).([]hub.APIKeyScope)
+					})).ServeHTTP(w, r)
+					resp := w.Result()
+					defer resp.Body.Close()
+
+					assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+					if tc.expectedStatusCode == http.StatusOK {
+						assert.Equal(t, scopes, scopesInCtx)
+					}
+					hw.um.AssertExpectations(t)
+				})
+			}
 		})
 	})
 
@@ -968,6 +1820,106 @@ func TestRequireLogin(t *testing.T) {
 	})
 }
 
+func TestRequireCaptcha(t *testing.T) {
+	t.Run("captcha verification disabled", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.cv = nil
+		hw.h.RequireCaptcha(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("invalid captcha response", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "1.2.3.4:5678"
+		r.Header.Set(CaptchaResponseHeader, "response")
+
+		hw := newHandlersWrapper()
+		hw.cv.On("Verify", r.Context(), "response", "1.2.3.4").Return(captcha.ErrInvalidResponse)
+		hw.h.RequireCaptcha(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, buildError(captcha.ErrInvalidResponse.Error()), data)
+		hw.cv.AssertExpectations(t)
+	})
+
+	t.Run("error verifying captcha response", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "1.2.3.4:5678"
+		r.Header.Set(CaptchaResponseHeader, "response")
+
+		hw := newHandlersWrapper()
+		hw.cv.On("Verify", r.Context(), "response", "1.2.3.4").Return(tests.ErrFake)
+		hw.h.RequireCaptcha(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.cv.AssertExpectations(t)
+	})
+
+	t.Run("valid captcha response", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r.RemoteAddr = "1.2.3.4:5678"
+		r.Header.Set(CaptchaResponseHeader, "response")
+
+		hw := newHandlersWrapper()
+		hw.cv.On("Verify", r.Context(), "response", "1.2.3.4").Return(nil)
+		hw.h.RequireCaptcha(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		hw.cv.AssertExpectations(t)
+	})
+}
+
+func TestRequirePasswordAuth(t *testing.T) {
+	t.Run("password auth disabled", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.cfg.Set("server.disablePasswordAuth", true)
+		hw.h.RequirePasswordAuth(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, buildError(errPasswordAuthDisabled.Error()), data)
+	})
+
+	t.Run("password auth enabled", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+
+		hw := newHandlersWrapper()
+		hw.h.RequirePasswordAuth(http.HandlerFunc(testsOK)).ServeHTTP(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
 func TestResetPassword(t *testing.T) {
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
@@ -1035,6 +1987,71 @@ func TestResetPassword(t *testing.T) {
 	})
 }
 
+func TestRevokeSession(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`code`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.h.RevokeSession(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("session revocation failed (invalid code)", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RevokeSession", r.Context(), "code").Return(user.ErrInvalidSessionRevocationCode)
+		hw.h.RevokeSession(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("session revocation failed (db error)", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RevokeSession", r.Context(), "code").Return(tests.ErrFakeDB)
+		hw.h.RevokeSession(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+
+	t.Run("session revocation succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := strings.NewReader(`{"code": "code"}`)
+		r, _ := http.NewRequest("POST", "/", body)
+
+		hw := newHandlersWrapper()
+		hw.um.On("RevokeSession", r.Context(), "code").Return(nil)
+		hw.h.RevokeSession(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.um.AssertExpectations(t)
+	})
+}
+
 func TestUpdatePassword(t *testing.T) {
 	t.Run("no old password provided", func(t *testing.T) {
 		t.Parallel()
@@ -1309,23 +2326,53 @@ func TestVerifyPasswordResetCode(t *testing.T) {
 
 func testsOK(w http.ResponseWriter, r *http.Request) {}
 
+func buildToken(keyID, secret string, method jwt.SigningMethod, claims *tokenClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = keyID
+	signedToken, _ := token.SignedString([]byte(secret))
+	return signedToken
+}
+
+var tokenBinding = hub.TokenBinding{APIKeyID: "apiKeyID"}
+
+func newTokenClaims(scopes []hub.APIKeyScope, expiresAt time.Time) *tokenClaims {
+	return &tokenClaims{
+		Scopes:       scopes,
+		TokenBinding: tokenBinding,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    tokenIssuer,
+			Subject:   "userID",
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+}
+
 type handlersWrapper struct {
-	cfg *viper.Viper
-	um  *user.ManagerMock
-	h   *Handlers
+	cfg  *viper.Viper
+	um   *user.ManagerMock
+	akut *apikey.UsageTrackerMock
+	cv   *captcha.VerifierMock
+	h    *Handlers
 }
 
 func newHandlersWrapper() *handlersWrapper {
 	cfg := viper.New()
 	cfg.Set("server.baseURL", "baseURL")
 	cfg.Set("server.oauth.github", map[string]string{})
+	cfg.Set("server.jwt.keys", map[string]string{"key1": "secret1", "key2": "secret2"})
+	cfg.Set("server.jwt.signingKey", "key2")
 	um := &user.ManagerMock{}
-	h, _ := NewHandlers(context.Background(), um, cfg)
+	akut := &apikey.UsageTrackerMock{}
+	cv := &captcha.VerifierMock{}
+	h, _ := NewHandlers(context.Background(), um, akut, cv, cfg)
 
 	return &handlersWrapper{
-		cfg: cfg,
-		um:  um,
-		h:   h,
+		cfg:  cfg,
+		um:   um,
+		akut: akut,
+		cv:   cv,
+		h:    h,
 	}
 }
 
@@ -1,11 +1,10 @@
 package webhook
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"text/template"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
@@ -13,19 +12,33 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
 )
 
 // Handlers represents a group of http handlers in charge of handling webhooks
 // operations.
 type Handlers struct {
 	webhookManager hub.WebhookManager
+	pkgManager     hub.PackageManager
+	cfg            *viper.Viper
+	hc             hub.HTTPClient
+	payloadLimits  *notification.PayloadLimits
 	logger         zerolog.Logger
 }
 
 // NewHandlers creates a new Handlers instance.
-func NewHandlers(webhookManager hub.WebhookManager) *Handlers {
+func NewHandlers(
+	webhookManager hub.WebhookManager,
+	pkgManager hub.PackageManager,
+	cfg *viper.Viper,
+	hc hub.HTTPClient,
+) *Handlers {
 	return &Handlers{
 		webhookManager: webhookManager,
+		pkgManager:     pkgManager,
+		cfg:            cfg,
+		hc:             hc,
+		payloadLimits:  notification.NewPayloadLimits(cfg),
 		logger:         log.With().Str("handlers", "webhook").Logger(),
 	}
 }
@@ -39,6 +52,11 @@ func (h *Handlers) Add(w http.ResponseWriter, r *http.Request) {
 		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
 		return
 	}
+	if err := h.checkPayloadLimits(wh); err != nil {
+		h.logger.Error().Err(err).Str("method", "Add").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
 	if err := h.webhookManager.Add(r.Context(), orgName, wh); err != nil {
 		h.logger.Error().Err(err).Str("method", "Add").Send()
 		helpers.RenderErrorJSON(w, err)
@@ -58,6 +76,18 @@ func (h *Handlers) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Enable is an http handler that enables the provided webhook, resuming its
+// deliveries if it had been suspended.
+func (h *Handlers) Enable(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	if err := h.webhookManager.Enable(r.Context(), webhookID); err != nil {
+		h.logger.Error().Err(err).Str("method", "Enable").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Get is an http handler that returns the requested webhook.
 func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	webhookID := chi.URLParam(r, "webhookID")
@@ -70,6 +100,46 @@ func (h *Handlers) Get(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
+// GetDeliveries is an http handler that returns the latest delivery attempts
+// of the provided webhook.
+func (h *Handlers) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	dataJSON, err := h.webhookManager.GetDeliveriesJSON(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetDeliveries").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetFailedDeliveries is an http handler that returns the notifications of the
+// provided webhook that could not be delivered.
+func (h *Handlers) GetFailedDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	dataJSON, err := h.webhookManager.GetFailedDeliveriesJSON(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetFailedDeliveries").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// GetFailedDelivery is an http handler that returns the requested failed
+// delivery of the provided webhook, including its payload.
+func (h *Handlers) GetFailedDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	notificationID := chi.URLParam(r, "notificationID")
+	dataJSON, err := h.webhookManager.GetFailedDeliveryJSON(r.Context(), webhookID, notificationID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "GetFailedDelivery").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
 // GetOwnedByOrg is an http handler that returns the webhooks owned by the
 // organization provided. The user doing the request must belong to the
 // organization.
@@ -96,60 +166,184 @@ func (h *Handlers) GetOwnedByUser(w http.ResponseWriter, r *http.Request) {
 	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
 }
 
-// TriggerTest is an http handler used to test a webhook before adding or
-// updating it.
-func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
-	// Read webhook from request body
-	wh := &hub.Webhook{}
-	if err := json.NewDecoder(r.Body).Decode(&wh); err != nil {
+// Preview is an http handler that renders the payload of the webhook provided
+// for a given event kind, so that templates can be debugged without having to
+// wait for real notifications. Sample data is used to render the payload,
+// unless a package is provided, in which case its latest version is used.
+func (h *Handlers) Preview(w http.ResponseWriter, r *http.Request) {
+	input := &webhookPreviewInput{}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Webhook == nil {
+		h.logger.Error().Err(err).Str("method", "Preview").Msg(hub.ErrInvalidInput.Error())
 		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
 		return
 	}
 
-	// Prepare payload
-	var tmpl *template.Template
-	if wh.Template != "" {
-		var err error
-		tmpl, err = template.New("").Parse(wh.Template)
+	// Prepare template data
+	var tmplData *hub.PackageNotificationTemplateData
+	switch input.EventKind {
+	case hub.NewRelease:
+		tmplData = webhookTestTemplateData
+	case hub.NewPackage:
+		tmplData = webhookTestNewPackageTemplateData
+	case hub.PackageDeprecated:
+		tmplData = webhookTestDeprecationTemplateData
+	case hub.SecurityAlert:
+		tmplData = webhookTestSecurityAlertTemplateData
+	default:
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "event kind not supported"))
+		return
+	}
+	if input.PackageID != "" {
+		p, err := h.pkgManager.Get(r.Context(), &hub.GetPackageInput{PackageID: input.PackageID})
 		if err != nil {
-			err = fmt.Errorf("error parsing template: %w", err)
-			helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+			h.logger.Error().Err(err).Str("method", "Preview").Send()
+			helpers.RenderErrorJSON(w, err)
 			return
 		}
-	} else {
-		tmpl = notification.DefaultWebhookPayloadTmpl
+		e := &hub.Event{
+			EventID:        webhookPreviewEventID,
+			EventKind:      input.EventKind,
+			PackageID:      p.PackageID,
+			PackageVersion: p.Version,
+		}
+		if input.EventKind == hub.SecurityAlert {
+			e.Data = map[string]interface{}{
+				"vulnerabilities": webhookTestVulnerabilities,
+			}
+		}
+		tmplData = notification.NewPackageNotificationTemplateData(h.cfg.GetString("server.baseURL"), e, p)
 	}
-	var payload bytes.Buffer
-	if err := tmpl.Execute(&payload, webhookTestTemplateData); err != nil {
-		err = fmt.Errorf("error executing template: %w", err)
+
+	// Render payload
+	payload, contentType, err := h.prepareWebhookPayload(input.Webhook, tmplData)
+	if err != nil {
 		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
 		return
 	}
+	dataJSON, _ := json.Marshal(map[string]string{
+		"content_type": contentType,
+		"payload":      string(payload),
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// Redeliver is an http handler that delivers again the payload of a previous
+// delivery of the provided webhook. The payload stored is reused, but it is
+// sent to the webhook's current endpoint. The new delivery attempt is
+// registered as well.
+func (h *Handlers) Redeliver(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	deliveryID := chi.URLParam(r, "deliveryID")
 
-	// Call webhook endpoint
-	req, _ := http.NewRequest("POST", wh.URL, &payload)
-	contentType := wh.ContentType
-	if contentType == "" {
-		contentType = notification.DefaultPayloadContentType
+	// Get webhook and delivery to redeliver
+	whJSON, err := h.webhookManager.GetJSON(r.Context(), webhookID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Redeliver").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	wh := &hub.Webhook{}
+	if err := json.Unmarshal(whJSON, &wh); err != nil {
+		h.logger.Error().Err(err).Str("method", "Redeliver").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
 	}
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("X-ArtifactHub-Secret", wh.Secret)
-	resp, err := http.DefaultClient.Do(req)
+	wh.Headers, err = h.webhookManager.GetHeaders(r.Context(), webhookID)
 	if err != nil {
-		err = fmt.Errorf("error doing request: %w", err)
-		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		h.logger.Error().Err(err).Str("method", "Redeliver").Send()
+		helpers.RenderErrorJSON(w, err)
 		return
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		err := fmt.Errorf("received unexpected status code: %d", resp.StatusCode)
-		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+	dJSON, err := h.webhookManager.GetDeliveryJSON(r.Context(), webhookID, deliveryID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("method", "Redeliver").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	d := &hub.WebhookDelivery{}
+	if err := json.Unmarshal(dJSON, &d); err != nil {
+		h.logger.Error().Err(err).Str("method", "Redeliver").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if d.Payload == "" {
+		helpers.RenderErrorJSON(w, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "delivery payload not available"))
 		return
 	}
 
+	// Deliver stored payload again and register the new delivery attempt. The
+	// delivery id is kept, so that the endpoint can detect the redelivery.
+	newD, deliveryErr := notification.SendWebhookPayload(
+		h.hc,
+		wh,
+		d.DeliveryID,
+		[]byte(d.Payload),
+		d.ContentType,
+	)
+	newD.NotificationID = d.NotificationID
+	if err := h.webhookManager.AddDelivery(r.Context(), newD); err != nil {
+		h.logger.Error().Err(err).Str("method", "Redeliver").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
+	if deliveryErr != nil {
+		helpers.RenderErrorWithCodeJSON(w, deliveryErr, http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequeueFailedDelivery is an http handler that enqueues again a notification
+// of the provided webhook that could not be delivered.
+func (h *Handlers) RequeueFailedDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookID := chi.URLParam(r, "webhookID")
+	notificationID := chi.URLParam(r, "notificationID")
+	if err := h.webhookManager.RequeueFailedDelivery(r.Context(), webhookID, notificationID); err != nil {
+		h.logger.Error().Err(err).Str("method", "RequeueFailedDelivery").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// TriggerTest is an http handler used to test a webhook before adding or
+// updating it. A delivery using some sample data is sent to the webhook's
+// endpoint and the response received is returned.
+func (h *Handlers) TriggerTest(w http.ResponseWriter, r *http.Request) {
+	// Read webhook from request body
+	wh := &hub.Webhook{}
+	if err := json.NewDecoder(r.Body).Decode(&wh); err != nil {
+		helpers.RenderErrorJSON(w, hub.ErrInvalidInput)
+		return
+	}
+
+	// Prepare payload
+	payload, contentType, err := h.prepareWebhookPayload(wh, webhookTestTemplateData)
+	if err != nil {
+		helpers.RenderErrorWithCodeJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	// Call webhook endpoint and return the details of the delivery attempt
+	d, _ := notification.SendWebhookPayload(h.hc, wh, "", payload, contentType)
+	dataJSON, _ := json.Marshal(&webhookTestResult{
+		StatusCode:   d.StatusCode,
+		Latency:      d.Latency,
+		ResponseBody: d.ResponseBody,
+		Error:        d.Error,
+	})
+	helpers.RenderJSON(w, dataJSON, 0, http.StatusOK)
+}
+
+// webhookTestResult represents the outcome of a webhook test delivery.
+type webhookTestResult struct {
+	StatusCode   int    `json:"status_code"`
+	Latency      int64  `json:"latency"`
+	ResponseBody string `json:"response_body"`
+	Error        string `json:"error,omitempty"`
+}
+
 // Update is an http handler that updates the provided webhook in the database.
 func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	wh := &hub.Webhook{}
@@ -159,6 +353,11 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	wh.WebhookID = chi.URLParam(r, "webhookID")
+	if err := h.checkPayloadLimits(wh); err != nil {
+		h.logger.Error().Err(err).Str("method", "Update").Send()
+		helpers.RenderErrorJSON(w, err)
+		return
+	}
 	if err := h.webhookManager.Update(r.Context(), wh); err != nil {
 		h.logger.Error().Err(err).Str("method", "Update").Send()
 		helpers.RenderErrorJSON(w, err)
@@ -167,6 +366,48 @@ func (h *Handlers) Update(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// checkPayloadLimits renders the custom template of the webhook provided using
+// some sample data, checking that the payload produced does not exceed the
+// configured limits.
+func (h *Handlers) checkPayloadLimits(wh *hub.Webhook) error {
+	if wh.Template == "" {
+		return nil
+	}
+	_, _, err := notification.PrepareWebhookPayload(wh, webhookTestTemplateData, h.payloadLimits)
+	if errors.Is(err, notification.ErrPayloadTooLarge) || errors.Is(err, notification.ErrTemplateExecutionTimeout) {
+		return fmt.Errorf("%w: %v", hub.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// prepareWebhookPayload prepares the payload corresponding to the template
+// data provided as it would be delivered to the given webhook. Webhooks that
+// receive batches get a batch containing just this notification.
+func (h *Handlers) prepareWebhookPayload(
+	wh *hub.Webhook,
+	tmplData *hub.PackageNotificationTemplateData,
+) ([]byte, string, error) {
+	if wh.Batch {
+		return notification.PrepareWebhookBatchPayload(
+			wh,
+			[]*hub.PackageNotificationTemplateData{tmplData},
+			h.payloadLimits,
+		)
+	}
+	return notification.PrepareWebhookPayload(wh, tmplData, h.payloadLimits)
+}
+
+// webhookPreviewInput represents the input expected by the Preview handler.
+type webhookPreviewInput struct {
+	Webhook   *hub.Webhook  `json:"webhook"`
+	EventKind hub.EventKind `json:"event_kind"`
+	PackageID string        `json:"package_id"`
+}
+
+// webhookPreviewEventID represents the event id used when rendering previews
+// of webhooks payloads.
+const webhookPreviewEventID = "00000000-0000-0000-0000-000000000001"
+
 // webhookTestTemplateData represents the notification template data used by
 // TriggerTest handler.
 var webhookTestTemplateData = &hub.PackageNotificationTemplateData{
@@ -185,6 +426,107 @@ var webhookTestTemplateData = &hub.PackageNotificationTemplateData{
 		},
 		"containsSecurityUpdates": true,
 		"prerelease":              true,
+		"deprecated":              false,
+		"repository": map[string]interface{}{
+			"kind":      "helm",
+			"name":      "repo1",
+			"publisher": "org1",
+		},
+	},
+}
+
+// webhookTestNewPackageTemplateData represents the notification template data
+// used by the Preview handler for new package events when no package is
+// provided.
+var webhookTestNewPackageTemplateData = &hub.PackageNotificationTemplateData{
+	BaseURL: "https://artifacthub.io",
+	Event: map[string]interface{}{
+		"id":   "00000000-0000-0000-0000-000000000001",
+		"kind": "package.new",
+	},
+	Package: map[string]interface{}{
+		"name":                    "sample-package",
+		"version":                 "1.0.0",
+		"url":                     "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
+		"changes":                 []string{},
+		"containsSecurityUpdates": false,
+		"prerelease":              false,
+		"deprecated":              false,
+		"repository": map[string]interface{}{
+			"kind":      "helm",
+			"name":      "repo1",
+			"publisher": "org1",
+		},
+	},
+}
+
+// webhookTestDeprecationTemplateData represents the notification template
+// data used by the Preview handler for package deprecated events when no
+// package is provided.
+var webhookTestDeprecationTemplateData = &hub.PackageNotificationTemplateData{
+	BaseURL: "https://artifacthub.io",
+	Event: map[string]interface{}{
+		"id":   "00000000-0000-0000-0000-000000000001",
+		"kind": "package.deprecated",
+	},
+	Package: map[string]interface{}{
+		"name":                    "sample-package",
+		"version":                 "1.0.0",
+		"url":                     "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
+		"changes":                 []string{},
+		"containsSecurityUpdates": false,
+		"prerelease":              false,
+		"deprecated":              true,
+		"repository": map[string]interface{}{
+			"kind":      "helm",
+			"name":      "repo1",
+			"publisher": "org1",
+		},
+	},
+}
+
+// webhookTestVulnerabilities represents the sample vulnerabilities used by the
+// Preview handler when rendering security alert events.
+var webhookTestVulnerabilities = []*hub.Vulnerability{
+	{
+		ID:               "CVE-2021-1234",
+		Severity:         "HIGH",
+		Image:            "artifacthub/sample-image:1.0.0",
+		PkgName:          "openssl",
+		InstalledVersion: "1.1.1g-r0",
+		FixedVersion:     "1.1.1i-r0",
+		Title:            "openssl: sample vulnerability",
+	},
+}
+
+// webhookTestSecurityAlertTemplateData represents the notification template
+// data used by the Preview handler for security alert events when no package
+// is provided.
+var webhookTestSecurityAlertTemplateData = &hub.PackageNotificationTemplateData{
+	BaseURL: "https://artifacthub.io",
+	Event: map[string]interface{}{
+		"id":   "00000000-0000-0000-0000-000000000001",
+		"kind": "package.security-alert",
+		"vulnerabilities": []map[string]interface{}{
+			{
+				"id":               "CVE-2021-1234",
+				"severity":         "HIGH",
+				"image":            "artifacthub/sample-image:1.0.0",
+				"pkgName":          "openssl",
+				"installedVersion": "1.1.1g-r0",
+				"fixedVersion":     "1.1.1i-r0",
+				"title":            "openssl: sample vulnerability",
+			},
+		},
+	},
+	Package: map[string]interface{}{
+		"name":                    "sample-package",
+		"version":                 "1.0.0",
+		"url":                     "https://artifacthub.io/packages/helm/artifacthub/sample-package/1.0.0",
+		"changes":                 []string{},
+		"containsSecurityUpdates": false,
+		"prerelease":              false,
+		"deprecated":              false,
 		"repository": map[string]interface{}{
 			"kind":      "helm",
 			"name":      "repo1",
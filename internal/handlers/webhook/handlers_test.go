@@ -10,14 +10,17 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/handlers/helpers"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/notification"
+	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/artifacthub/hub/internal/webhook"
 	"github.com/go-chi/chi"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -81,6 +84,26 @@ func TestAdd(t *testing.T) {
 		}
 	})
 
+	t.Run("template payload exceeds the maximum size allowed", func(t *testing.T) {
+		t.Parallel()
+		webhookJSON := `{"name": "webhook1", "url": "http://webhook1.url", "template": "{{ .Event.id }}"}`
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(webhookJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.h.payloadLimits = &notification.PayloadLimits{MaxSize: 10}
+		hw.h.Add(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, getErrorMessage(t, data), notification.ErrPayloadTooLarge.Error())
+		hw.wm.AssertExpectations(t)
+	})
+
 	t.Run("valid webhook provided", func(t *testing.T) {
 		webhookJSON := `
 		{
@@ -131,116 +154,914 @@ func TestAdd(t *testing.T) {
 				resp := w.Result()
 				defer resp.Body.Close()
 
-				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
-				hw.wm.AssertExpectations(t)
-			})
-		}
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestDelete(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("error deleting webhook", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("DELETE", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("Delete", r.Context(), "000000001").Return(tc.err)
+				hw.h.Delete(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("delete webhook succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("DELETE", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("Delete", r.Context(), "000000001").Return(nil)
+		hw.h.Delete(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
+func TestEnable(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("error enabling webhook", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("PUT", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("Enable", r.Context(), "000000001").Return(tc.err)
+				hw.h.Enable(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("enable webhook succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("Enable", r.Context(), "000000001").Return(nil)
+		hw.h.Enable(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
+func TestGet(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("error getting webhook", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetJSON", r.Context(), "000000001").Return(nil, tc.err)
+				hw.h.Get(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook get succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return([]byte("dataJSON"), nil)
+		hw.h.Get(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
+func TestGetDeliveries(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("get deliveries succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetDeliveriesJSON", r.Context(), "000000001").Return([]byte("dataJSON"), nil)
+		hw.h.GetDeliveries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("error getting deliveries", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetDeliveriesJSON", r.Context(), "000000001").Return(nil, tc.err)
+				hw.h.GetDeliveries(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetFailedDeliveries(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID"},
+			Values: []string{"000000001"},
+		},
+	}
+
+	t.Run("get failed deliveries succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetFailedDeliveriesJSON", r.Context(), "000000001").Return([]byte("dataJSON"), nil)
+		hw.h.GetFailedDeliveries(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("error getting failed deliveries", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetFailedDeliveriesJSON", r.Context(), "000000001").Return(nil, tc.err)
+				hw.h.GetFailedDeliveries(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetFailedDelivery(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"webhookID", "notificationID"},
+			Values: []string{"000000001", "000000002"},
+		},
+	}
+
+	t.Run("get failed delivery succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetFailedDeliveryJSON", r.Context(), "000000001", "000000002").Return([]byte("dataJSON"), nil)
+		hw.h.GetFailedDelivery(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("error getting failed delivery", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetFailedDeliveryJSON", r.Context(), "000000001", "000000002").Return(nil, tc.err)
+				hw.h.GetFailedDelivery(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetOwnedByOrg(t *testing.T) {
+	rctx := &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"orgName"},
+			Values: []string{"org1"},
+		},
+	}
+
+	t.Run("get webhooks owned by organization succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetOwnedByOrgJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
+		hw.h.GetOwnedByOrg(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("error getting webhooks owned by organization", func(t *testing.T) {
+		testCases := []struct {
+			err                error
+			expectedStatusCode int
+		}{
+			{
+				hub.ErrInvalidInput,
+				http.StatusBadRequest,
+			},
+			{
+				tests.ErrFakeDB,
+				http.StatusInternalServerError,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.err.Error(), func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("GET", "/", nil)
+				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+				hw := newHandlersWrapper()
+				hw.wm.On("GetOwnedByOrgJSON", r.Context(), "org1").Return(nil, tc.err)
+				hw.h.GetOwnedByOrg(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
+				hw.wm.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestGetOwnedByUser(t *testing.T) {
+	t.Run("error getting webhooks owned by user", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetOwnedByUserJSON", r.Context()).Return(nil, tests.ErrFakeDB)
+		hw.h.GetOwnedByUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("get webhook owned by user succeeded", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("GET", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetOwnedByUserJSON", r.Context()).Return([]byte("dataJSON"), nil)
+		hw.h.GetOwnedByUser(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
+		assert.Equal(t, []byte("dataJSON"), data)
+		hw.wm.AssertExpectations(t)
+	})
+}
+
+func TestPreview(t *testing.T) {
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			description string
+			inputJSON   string
+		}{
+			{
+				"no input provided",
+				"",
+			},
+			{
+				"invalid json",
+				"-",
+			},
+			{
+				"no webhook provided",
+				`{"event_kind": 0}`,
+			},
+			{
+				"event kind not supported",
+				`{"webhook": {"url": "http://url"}, "event_kind": 2}`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				w := httptest.NewRecorder()
+				r, _ := http.NewRequest("POST", "/", strings.NewReader(tc.inputJSON))
+
+				hw := newHandlersWrapper()
+				hw.h.Preview(w, r)
+				resp := w.Result()
+				defer resp.Body.Close()
+
+				assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			})
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"webhook": {"url": "http://url", "template": "{{ .Package.Name }"}, "event_kind": 0}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("error getting package", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"webhook": {"url": "http://url"}, "event_kind": 0, "package_id": "pkg1"}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), &hub.GetPackageInput{PackageID: "pkg1"}).Return(nil, hub.ErrNotFound)
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.pm.AssertExpectations(t)
+	})
+
+	t.Run("payload rendered using sample data", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"webhook": {"url": "http://url", "template": "{{ .Package.name }}"}, "event_kind": 0}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		h := resp.Header
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+		assert.JSONEq(t, `{"content_type": "application/cloudevents+json", "payload": "sample-package"}`, string(data))
+	})
+
+	t.Run("package deprecated payload rendered using sample data", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"webhook": {"url": "http://url", "content_type": "text/plain", "template": "{{ .Event.kind }} {{ .Package.deprecated }}"}, "event_kind": 5}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"content_type": "text/plain", "payload": "package.deprecated true"}`, string(data))
+	})
+
+	t.Run("new package payload rendered using sample data", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"webhook": {"url": "http://url", "content_type": "text/plain", "template": "{{ .Event.kind }} {{ .Package.name }}"}, "event_kind": 7}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"content_type": "text/plain", "payload": "package.new sample-package"}`, string(data))
+	})
+
+	t.Run("batch payload rendered using sample data", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"webhook": {"url": "http://url", "batch": true}, "event_kind": 0}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var output map[string]string
+		require.NoError(t, json.Unmarshal(data, &output))
+		assert.Equal(t, notification.BatchPayloadContentType, output["content_type"])
+		var events []map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(output["payload"]), &events))
+		require.Len(t, events, 1)
+		assert.Equal(t, "io.artifacthub.package.new-release", events[0]["type"])
+	})
+
+	t.Run("security alert payload rendered using sample data", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{"webhook": {"url": "http://url", "content_type": "text/plain", "template": "{{ .Event.kind }}{{ range .Event.vulnerabilities }} {{ .id }}{{ end }}"}, "event_kind": 1}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"content_type": "text/plain", "payload": "package.security-alert CVE-2021-1234"}`, string(data))
+	})
+
+	t.Run("payload rendered using package provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		body := `{
+			"webhook": {"url": "http://url", "content_type": "text/plain", "template": "{{ .Package.name }} {{ .Package.version }} {{ .Package.url }}"},
+			"event_kind": 0,
+			"package_id": "pkg1"
+		}`
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(body))
+
+		hw := newHandlersWrapper()
+		hw.pm.On("Get", r.Context(), &hub.GetPackageInput{PackageID: "pkg1"}).Return(&hub.Package{
+			PackageID:      "pkg1",
+			Name:           "package1",
+			NormalizedName: "package1",
+			Version:        "1.2.0",
+			Repository: &hub.Repository{
+				Kind: hub.Helm,
+				Name: "repo1",
+			},
+		}, nil)
+		hw.h.Preview(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{
+			"content_type": "text/plain",
+			"payload": "package1 1.2.0 baseURL/packages/helm/repo1/package1/1.2.0"
+		}`, string(data))
+		hw.pm.AssertExpectations(t)
 	})
 }
 
-func TestDelete(t *testing.T) {
+func TestRedeliver(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
-			Keys:   []string{"webhookID"},
-			Values: []string{"000000001"},
+			Keys:   []string{"webhookID", "deliveryID"},
+			Values: []string{"000000001", "000000002"},
 		},
 	}
 
-	t.Run("error deleting webhook", func(t *testing.T) {
-		testCases := []struct {
-			err                error
-			expectedStatusCode int
-		}{
-			{
-				hub.ErrInvalidInput,
-				http.StatusBadRequest,
-			},
-			{
-				hub.ErrInsufficientPrivilege,
-				http.StatusForbidden,
-			},
-			{
-				tests.ErrFakeDB,
-				http.StatusInternalServerError,
-			},
-		}
-		for _, tc := range testCases {
-			tc := tc
-			t.Run(tc.err.Error(), func(t *testing.T) {
-				t.Parallel()
-				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("DELETE", "/", nil)
-				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	t.Run("error getting webhook", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-				hw := newHandlersWrapper()
-				hw.wm.On("Delete", r.Context(), "000000001").Return(tc.err)
-				hw.h.Delete(w, r)
-				resp := w.Result()
-				defer resp.Body.Close()
+		hw := newHandlersWrapper()
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return(nil, hub.ErrInsufficientPrivilege)
+		hw.h.Redeliver(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
 
-				assert.Equal(t, tc.expectedStatusCode, resp.StatusCode)
-				hw.wm.AssertExpectations(t)
-			})
-		}
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
 	})
 
-	t.Run("delete webhook succeeded", func(t *testing.T) {
+	t.Run("error getting webhook headers", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("DELETE", "/", nil)
+		r, _ := http.NewRequest("POST", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.wm.On("Delete", r.Context(), "000000001").Return(nil)
-		hw.h.Delete(w, r)
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return([]byte(`{"url": "http://url"}`), nil)
+		hw.wm.On("GetHeaders", r.Context(), "000000001").Return(nil, tests.ErrFakeDB)
+		hw.h.Redeliver(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 		hw.wm.AssertExpectations(t)
 	})
-}
 
-func TestGet(t *testing.T) {
-	rctx := &chi.Context{
-		URLParams: chi.RouteParams{
-			Keys:   []string{"webhookID"},
-			Values: []string{"000000001"},
-		},
-	}
+	t.Run("error getting delivery", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
-	t.Run("error getting webhook", func(t *testing.T) {
+		hw := newHandlersWrapper()
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return([]byte(`{"url": "http://url"}`), nil)
+		hw.wm.On("GetHeaders", r.Context(), "000000001").Return(nil, nil)
+		hw.wm.On("GetDeliveryJSON", r.Context(), "000000001", "000000002").Return(nil, hub.ErrNotFound)
+		hw.h.Redeliver(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("delivery payload not available", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return([]byte(`{"url": "http://url"}`), nil)
+		hw.wm.On("GetHeaders", r.Context(), "000000001").Return(nil, nil)
+		hw.wm.On("GetDeliveryJSON", r.Context(), "000000001", "000000002").Return([]byte(`{}`), nil)
+		hw.h.Redeliver(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+	})
+
+	t.Run("redelivery sent using the http client provided", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", nil)
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		hw := newHandlersWrapper()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "http://url"
+		})).Return(nil, tests.ErrFake)
+		hw.h.hc = hc
+		hw.wm.On("GetJSON", r.Context(), "000000001").Return([]byte(`{"url": "http://url"}`), nil)
+		hw.wm.On("GetHeaders", r.Context(), "000000001").Return(nil, nil)
+		hw.wm.On("GetDeliveryJSON", r.Context(), "000000001", "000000002").
+			Return([]byte(`{"payload": "stored payload", "content_type": "application/json"}`), nil)
+		hw.wm.On("AddDelivery", r.Context(), mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(1).(*hub.WebhookDelivery)
+				assert.Equal(t, tests.ErrFake.Error(), d.Error)
+			}).
+			Return(nil)
+		hw.h.Redeliver(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		hw.wm.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("redelivery attempted", func(t *testing.T) {
 		testCases := []struct {
-			err                error
+			id                 string
+			endpointStatusCode int
+			addDeliveryErr     error
 			expectedStatusCode int
 		}{
 			{
-				hub.ErrInvalidInput,
-				http.StatusBadRequest,
+				"1",
+				http.StatusOK,
+				nil,
+				http.StatusNoContent,
 			},
 			{
-				hub.ErrInsufficientPrivilege,
-				http.StatusForbidden,
+				"2",
+				http.StatusInternalServerError,
+				nil,
+				http.StatusBadRequest,
 			},
 			{
+				"3",
+				http.StatusOK,
 				tests.ErrFakeDB,
 				http.StatusInternalServerError,
 			},
 		}
 		for _, tc := range testCases {
 			tc := tc
-			t.Run(tc.err.Error(), func(t *testing.T) {
+			t.Run(tc.id, func(t *testing.T) {
 				t.Parallel()
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+					assert.Equal(t, "very", r.Header.Get("X-ArtifactHub-Secret"))
+					assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+					assert.Equal(t, "000000003-1", r.Header.Get(notification.DeliveryIDHeader))
+					payload, _ := ioutil.ReadAll(r.Body)
+					assert.Equal(t, []byte("stored payload"), payload)
+					w.WriteHeader(tc.endpointStatusCode)
+				}))
+				defer ts.Close()
+
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/", nil)
+				r, _ := http.NewRequest("POST", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.wm.On("GetJSON", r.Context(), "000000001").Return(nil, tc.err)
-				hw.h.Get(w, r)
+				whJSON, _ := json.Marshal(&hub.Webhook{
+					WebhookID: "000000001",
+					URL:       ts.URL,
+					Secret:    "very",
+				})
+				hw.wm.On("GetJSON", r.Context(), "000000001").Return(whJSON, nil)
+				hw.wm.On("GetHeaders", r.Context(), "000000001").Return([]*hub.WebhookHeader{
+					{Name: "Authorization", Value: "Bearer token", Secret: true},
+				}, nil)
+				dJSON, _ := json.Marshal(&hub.WebhookDelivery{
+					WebhookDeliveryID: "000000002",
+					WebhookID:         "000000001",
+					NotificationID:    "000000003",
+					DeliveryID:        "000000003-1",
+					Payload:           "stored payload",
+					ContentType:       "application/json",
+				})
+				hw.wm.On("GetDeliveryJSON", r.Context(), "000000001", "000000002").Return(dJSON, nil)
+				hw.wm.On("AddDelivery", r.Context(), mock.Anything).
+					Run(func(args mock.Arguments) {
+						d := args.Get(1).(*hub.WebhookDelivery)
+						assert.Equal(t, "000000001", d.WebhookID)
+						assert.Equal(t, "000000003", d.NotificationID)
+						assert.Equal(t, "000000003-1", d.DeliveryID)
+						assert.Equal(t, "stored payload", d.Payload)
+						assert.Equal(t, tc.endpointStatusCode, d.StatusCode)
+					}).
+					Return(tc.addDeliveryErr)
+				hw.h.Redeliver(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -249,61 +1070,17 @@ func TestGet(t *testing.T) {
 			})
 		}
 	})
-
-	t.Run("webhook get succeeded", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
-
-		hw := newHandlersWrapper()
-		hw.wm.On("GetJSON", r.Context(), "000000001").Return([]byte("dataJSON"), nil)
-		hw.h.Get(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
-		hw.wm.AssertExpectations(t)
-	})
 }
 
-func TestGetOwnedByOrg(t *testing.T) {
+func TestRequeueFailedDelivery(t *testing.T) {
 	rctx := &chi.Context{
 		URLParams: chi.RouteParams{
-			Keys:   []string{"orgName"},
-			Values: []string{"org1"},
+			Keys:   []string{"webhookID", "notificationID"},
+			Values: []string{"000000001", "000000002"},
 		},
 	}
 
-	t.Run("get webhooks owned by organization succeeded", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
-
-		hw := newHandlersWrapper()
-		hw.wm.On("GetOwnedByOrgJSON", r.Context(), "org1").Return([]byte("dataJSON"), nil)
-		hw.h.GetOwnedByOrg(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
-
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
-		hw.wm.AssertExpectations(t)
-	})
-
-	t.Run("error getting webhooks owned by organization", func(t *testing.T) {
+	t.Run("error requeueing failed delivery", func(t *testing.T) {
 		testCases := []struct {
 			err                error
 			expectedStatusCode int
@@ -312,6 +1089,14 @@ func TestGetOwnedByOrg(t *testing.T) {
 				hub.ErrInvalidInput,
 				http.StatusBadRequest,
 			},
+			{
+				hub.ErrInsufficientPrivilege,
+				http.StatusForbidden,
+			},
+			{
+				hub.ErrNotFound,
+				http.StatusNotFound,
+			},
 			{
 				tests.ErrFakeDB,
 				http.StatusInternalServerError,
@@ -322,13 +1107,13 @@ func TestGetOwnedByOrg(t *testing.T) {
 			t.Run(tc.err.Error(), func(t *testing.T) {
 				t.Parallel()
 				w := httptest.NewRecorder()
-				r, _ := http.NewRequest("GET", "/", nil)
+				r, _ := http.NewRequest("PUT", "/", nil)
 				r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
 				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 				hw := newHandlersWrapper()
-				hw.wm.On("GetOwnedByOrgJSON", r.Context(), "org1").Return(nil, tc.err)
-				hw.h.GetOwnedByOrg(w, r)
+				hw.wm.On("RequeueFailedDelivery", r.Context(), "000000001", "000000002").Return(tc.err)
+				hw.h.RequeueFailedDelivery(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
 
@@ -337,43 +1122,21 @@ func TestGetOwnedByOrg(t *testing.T) {
 			})
 		}
 	})
-}
-
-func TestGetOwnedByUser(t *testing.T) {
-	t.Run("error getting webhooks owned by user", func(t *testing.T) {
-		t.Parallel()
-		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
-		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
-
-		hw := newHandlersWrapper()
-		hw.wm.On("GetOwnedByUserJSON", r.Context()).Return(nil, tests.ErrFakeDB)
-		hw.h.GetOwnedByUser(w, r)
-		resp := w.Result()
-		defer resp.Body.Close()
-
-		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
-		hw.wm.AssertExpectations(t)
-	})
 
-	t.Run("get webhook owned by user succeeded", func(t *testing.T) {
+	t.Run("requeue failed delivery succeeded", func(t *testing.T) {
 		t.Parallel()
 		w := httptest.NewRecorder()
-		r, _ := http.NewRequest("GET", "/", nil)
+		r, _ := http.NewRequest("PUT", "/", nil)
 		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
 
 		hw := newHandlersWrapper()
-		hw.wm.On("GetOwnedByUserJSON", r.Context()).Return([]byte("dataJSON"), nil)
-		hw.h.GetOwnedByUser(w, r)
+		hw.wm.On("RequeueFailedDelivery", r.Context(), "000000001", "000000002").Return(nil)
+		hw.h.RequeueFailedDelivery(w, r)
 		resp := w.Result()
 		defer resp.Body.Close()
-		h := resp.Header
-		data, _ := ioutil.ReadAll(resp.Body)
 
-		assert.Equal(t, http.StatusOK, resp.StatusCode)
-		assert.Equal(t, "application/json", h.Get("Content-Type"))
-		assert.Equal(t, helpers.BuildCacheControlHeader(0), h.Get("Cache-Control"))
-		assert.Equal(t, []byte("dataJSON"), data)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 		hw.wm.AssertExpectations(t)
 	})
 }
@@ -470,15 +1233,53 @@ func TestTriggerTest(t *testing.T) {
 		resp := w.Result()
 		defer resp.Body.Close()
 		data, _ := ioutil.ReadAll(resp.Body)
+		var result *webhookTestResult
+		err := json.Unmarshal(data, &result)
+		require.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
-		assert.True(t, strings.HasPrefix(getErrorMessage(t, data), "error doing request:"))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Equal(t, 0, result.StatusCode)
+		assert.NotEmpty(t, result.Error)
+	})
+
+	t.Run("test sent using the http client provided", func(t *testing.T) {
+		t.Parallel()
+		webhookJSON := `
+		{
+			"name": "webhook1",
+			"url": "http://webhook1.url"
+		}
+		`
+
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("POST", "/", strings.NewReader(webhookJSON))
+
+		hw := newHandlersWrapper()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "http://webhook1.url"
+		})).Return(nil, tests.ErrFake)
+		hw.h.hc = hc
+		hw.h.TriggerTest(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+		var result *webhookTestResult
+		err := json.Unmarshal(data, &result)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 0, result.StatusCode)
+		assert.Equal(t, tests.ErrFake.Error(), result.Error)
+		hc.AssertExpectations(t)
 	})
 
 	t.Run("received unexpected status code", func(t *testing.T) {
 		t.Parallel()
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
 		}))
 		defer ts.Close()
 
@@ -493,9 +1294,14 @@ func TestTriggerTest(t *testing.T) {
 		resp := w.Result()
 		defer resp.Body.Close()
 		data, _ := ioutil.ReadAll(resp.Body)
+		var result *webhookTestResult
+		err := json.Unmarshal(data, &result)
+		require.NoError(t, err)
 
-		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
-		assert.Equal(t, "received unexpected status code: 404", getErrorMessage(t, data))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, http.StatusNotFound, result.StatusCode)
+		assert.Equal(t, "not found", result.ResponseBody)
+		assert.Equal(t, "unexpected status code: 404", result.Error)
 	})
 
 	t.Run("webhook endpoint call succeeded", func(t *testing.T) {
@@ -558,6 +1364,7 @@ func TestTriggerTest(t *testing.T) {
 					assert.Equal(t, tc.secret, r.Header.Get("X-ArtifactHub-Secret"))
 					payload, _ := ioutil.ReadAll(r.Body)
 					assert.Equal(t, tc.expectedPayload, payload)
+					_, _ = w.Write([]byte("ok"))
 				}))
 				defer ts.Close()
 
@@ -576,8 +1383,15 @@ func TestTriggerTest(t *testing.T) {
 				hw.h.TriggerTest(w, r)
 				resp := w.Result()
 				defer resp.Body.Close()
-
-				assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+				data, _ := ioutil.ReadAll(resp.Body)
+				var result *webhookTestResult
+				err := json.Unmarshal(data, &result)
+				require.NoError(t, err)
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, http.StatusOK, result.StatusCode)
+				assert.Equal(t, "ok", result.ResponseBody)
+				assert.Empty(t, result.Error)
 			})
 		}
 	})
@@ -628,6 +1442,25 @@ func TestUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("template payload exceeds the maximum size allowed", func(t *testing.T) {
+		t.Parallel()
+		webhookJSON := `{"name": "webhook1", "url": "http://webhook1.url", "template": "{{ .Event.id }}"}`
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest("PUT", "/", strings.NewReader(webhookJSON))
+		r = r.WithContext(context.WithValue(r.Context(), hub.UserIDKey, "userID"))
+
+		hw := newHandlersWrapper()
+		hw.h.payloadLimits = &notification.PayloadLimits{MaxSize: 10}
+		hw.h.Update(w, r)
+		resp := w.Result()
+		defer resp.Body.Close()
+		data, _ := ioutil.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, getErrorMessage(t, data), notification.ErrPayloadTooLarge.Error())
+		hw.wm.AssertExpectations(t)
+	})
+
 	t.Run("valid webhook provided", func(t *testing.T) {
 		webhookJSON := `
 		{
@@ -694,15 +1527,20 @@ func TestUpdate(t *testing.T) {
 
 type handlersWrapper struct {
 	wm *webhook.ManagerMock
+	pm *pkg.ManagerMock
 	h  *Handlers
 }
 
 func newHandlersWrapper() *handlersWrapper {
+	cfg := viper.New()
+	cfg.Set("server.baseURL", "baseURL")
 	wm := &webhook.ManagerMock{}
+	pm := &pkg.ManagerMock{}
 
 	return &handlersWrapper{
 		wm: wm,
-		h:  NewHandlers(wm),
+		pm: pm,
+		h:  NewHandlers(wm, pm, cfg, &http.Client{Timeout: 5 * time.Second}),
 	}
 }
 
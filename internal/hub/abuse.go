@@ -0,0 +1,35 @@
+package hub
+
+import "context"
+
+// AbuseReport represents a report submitted by a user about a package or a
+// repository containing malicious or spam content.
+type AbuseReport struct {
+	PackageID    string `json:"package_id,omitempty"`
+	RepositoryID string `json:"repository_id,omitempty"`
+	Reason       string `json:"reason"`
+	Description  string `json:"description,omitempty"`
+}
+
+// AbuseReportTriage represents the information provided by a site
+// administrator when triaging an abuse report.
+type AbuseReportTriage struct {
+	Status string `json:"status"`
+	Notes  string `json:"notes,omitempty"`
+}
+
+// GetAbuseReportsInput represents the input used to get the abuse reports
+// moderation queue.
+type GetAbuseReportsInput struct {
+	Status string `json:"status,omitempty"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// AbuseReportManager describes the methods an AbuseReportManager
+// implementation must provide.
+type AbuseReportManager interface {
+	Add(ctx context.Context, r *AbuseReport) error
+	GetQueueJSON(ctx context.Context, input *GetAbuseReportsInput) ([]byte, error)
+	Triage(ctx context.Context, abuseReportID string, t *AbuseReportTriage) error
+}
@@ -0,0 +1,29 @@
+package hub
+
+import "context"
+
+// SearchUsersInput represents the input used to search users.
+type SearchUsersInput struct {
+	Query  string `json:"query,omitempty"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// VerifiedPublisherInput represents the input used by site administrators to
+// set the verified publisher status of a repository.
+type VerifiedPublisherInput struct {
+	Verified bool   `json:"verified"`
+	Evidence string `json:"evidence"`
+}
+
+// AdminManager describes the methods an AdminManager implementation must
+// provide.
+type AdminManager interface {
+	ForcePasswordReset(ctx context.Context, userID, baseURL string) error
+	GetUserResourcesJSON(ctx context.Context, userID string) ([]byte, error)
+	GetVerifiedPublisherChangesJSON(ctx context.Context, repoName string) ([]byte, error)
+	RevokeCredentials(ctx context.Context, userID string) error
+	SearchUsersJSON(ctx context.Context, input *SearchUsersInput) ([]byte, error)
+	UpdateUserDisabled(ctx context.Context, userID string, disabled bool) error
+	UpdateVerifiedPublisher(ctx context.Context, repoName string, input *VerifiedPublisherInput) error
+}
@@ -2,12 +2,53 @@ package hub
 
 import "context"
 
-// APIKey represents a key used to interact with the HTTP API.
+// APIKeyScope represents a permission that can be granted to an API key.
+type APIKeyScope string
+
+const (
+	// APIKeyScopeReadOnly allows performing read-only requests on any of the
+	// resources available to the user owning the API key.
+	APIKeyScopeReadOnly APIKeyScope = "read-only"
+
+	// APIKeyScopePackagesRead allows performing read-only requests on the
+	// packages resources.
+	APIKeyScopePackagesRead APIKeyScope = "packages:read"
+
+	// APIKeyScopeReposWrite allows performing any request on the repositories
+	// resources, including adding, updating or deleting repositories.
+	APIKeyScopeReposWrite APIKeyScope = "repos:write"
+
+	// APIKeyScopeWebhooksManage allows performing any request on the webhooks
+	// resources.
+	APIKeyScopeWebhooksManage APIKeyScope = "webhooks:manage"
+)
+
+// APIKeyScopes represents the list of valid API key scopes.
+var APIKeyScopes = []APIKeyScope{
+	APIKeyScopeReadOnly,
+	APIKeyScopePackagesRead,
+	APIKeyScopeReposWrite,
+	APIKeyScopeWebhooksManage,
+}
+
+type apiKeyScopesKey struct{}
+
+// APIKeyScopesKey represents the key used for the scopes of the API key used
+// to authenticate a request inside a context. It's only set when the API key
+// has been restricted to some scopes.
+var APIKeyScopesKey = apiKeyScopesKey{}
+
+// APIKey represents a key used to interact with the HTTP API. API keys with no
+// scopes have full access to the resources of the user owning them.
 type APIKey struct {
-	APIKeyID  string `json:"api_key_id"`
-	Name      string `json:"name"`
-	CreatedAt int64  `json:"created_at"`
-	UserID    string `json:"user_id"`
+	APIKeyID           string        `json:"api_key_id"`
+	Name               string        `json:"name"`
+	CreatedAt          int64         `json:"created_at"`
+	UserID             string        `json:"user_id"`
+	Scopes             []APIKeyScope `json:"scopes,omitempty"`
+	LastUsedAt         int64         `json:"last_used_at,omitempty"`
+	LastUsedIP         string        `json:"last_used_ip,omitempty"`
+	RequestsLast30Days int64         `json:"requests_last_30_days,omitempty"`
 }
 
 // APIKeyManager describes the methods an APIKeyManager implementation must
@@ -19,3 +60,9 @@ type APIKeyManager interface {
 	GetOwnedByUserJSON(ctx context.Context) ([]byte, error)
 	Update(ctx context.Context, ak *APIKey) error
 }
+
+// APIKeyUsageTracker describes the methods an APIKeyUsageTracker
+// implementation must provide.
+type APIKeyUsageTracker interface {
+	Track(apiKeyID, ip string)
+}
@@ -0,0 +1,202 @@
+package hub
+
+import "context"
+
+const (
+	// AuditAdminAbuseReportTriage represents the action of triaging an abuse
+	// report as a site administrator.
+	AuditAdminAbuseReportTriage = "admin.abuse_report.triage"
+
+	// AuditAdminRepositoryVerifiedPublisherUpdate represents the action of
+	// setting the verified publisher status of a repository as a site
+	// administrator.
+	AuditAdminRepositoryVerifiedPublisherUpdate = "admin.repository.verified_publisher.update"
+
+	// AuditAdminUserCredentialsRevoke represents the action of revoking all
+	// the sessions and API keys of a user as a site administrator.
+	AuditAdminUserCredentialsRevoke = "admin.user.credentials.revoke"
+
+	// AuditAdminUserDisable represents the action of disabling a user as a
+	// site administrator.
+	AuditAdminUserDisable = "admin.user.disable"
+
+	// AuditAdminUserEnable represents the action of enabling a user as a site
+	// administrator.
+	AuditAdminUserEnable = "admin.user.enable"
+
+	// AuditAdminUserPasswordReset represents the action of forcing the
+	// password reset of a user as a site administrator.
+	AuditAdminUserPasswordReset = "admin.user.password_reset"
+
+	// AuditAPIKeyAdd represents the action of adding an API key.
+	AuditAPIKeyAdd = "api_key.add"
+
+	// AuditAPIKeyDelete represents the action of deleting an API key.
+	AuditAPIKeyDelete = "api_key.delete"
+
+	// AuditAuthorizationPolicyUpdate represents the action of updating an
+	// organization authorization policy.
+	AuditAuthorizationPolicyUpdate = "organization.authorization_policy.update"
+
+	// AuditOrganizationDeletionCancel represents the action of cancelling the
+	// scheduled deletion of an organization.
+	AuditOrganizationDeletionCancel = "organization.deletion.cancel"
+
+	// AuditOrganizationDeletionSchedule represents the action of scheduling
+	// the deletion of an organization.
+	AuditOrganizationDeletionSchedule = "organization.deletion.schedule"
+
+	// AuditOrganizationMemberAdd represents the action of adding a member to
+	// an organization.
+	AuditOrganizationMemberAdd = "organization.member.add"
+
+	// AuditOrganizationMemberDelete represents the action of deleting a member
+	// from an organization.
+	AuditOrganizationMemberDelete = "organization.member.delete"
+
+	// AuditOrganizationMemberRoleUpdate represents the action of updating the
+	// role of a member of an organization.
+	AuditOrganizationMemberRoleUpdate = "organization.member.role.update"
+
+	// AuditOrganizationMembershipConfirm represents the action of accepting
+	// an invitation to join an organization.
+	AuditOrganizationMembershipConfirm = "organization.membership.confirm"
+
+	// AuditOrganizationOwnershipTransfer represents the action of
+	// transferring the ownership of an organization to one of its members.
+	AuditOrganizationOwnershipTransfer = "organization.ownership.transfer"
+
+	// AuditOrganizationRename represents the action of renaming an
+	// organization.
+	AuditOrganizationRename = "organization.rename"
+
+	// AuditOrganizationServiceAccountAdd represents the action of adding a
+	// service account to an organization.
+	AuditOrganizationServiceAccountAdd = "organization.service_account.add"
+
+	// AuditOrganizationServiceAccountAPIKeyAdd represents the action of adding
+	// an api key to a service account.
+	AuditOrganizationServiceAccountAPIKeyAdd = "organization.service_account.api_key.add"
+
+	// AuditOrganizationServiceAccountAPIKeyDelete represents the action of
+	// deleting an api key from a service account.
+	AuditOrganizationServiceAccountAPIKeyDelete = "organization.service_account.api_key.delete"
+
+	// AuditOrganizationServiceAccountDelete represents the action of deleting
+	// a service account from an organization.
+	AuditOrganizationServiceAccountDelete = "organization.service_account.delete"
+
+	// AuditOrganizationTeamAdd represents the action of adding a team to an
+	// organization.
+	AuditOrganizationTeamAdd = "organization.team.add"
+
+	// AuditOrganizationTeamDelete represents the action of deleting a team
+	// from an organization.
+	AuditOrganizationTeamDelete = "organization.team.delete"
+
+	// AuditOrganizationTeamMemberAdd represents the action of adding a member
+	// to a team.
+	AuditOrganizationTeamMemberAdd = "organization.team.member.add"
+
+	// AuditOrganizationTeamMemberDelete represents the action of deleting a
+	// member from a team.
+	AuditOrganizationTeamMemberDelete = "organization.team.member.delete"
+
+	// AuditOrganizationTeamRepositoryPermissionsDelete represents the action
+	// of revoking the permissions granted to a team on a repository.
+	AuditOrganizationTeamRepositoryPermissionsDelete = "organization.team.repository_permissions.delete"
+
+	// AuditOrganizationTeamRepositoryPermissionsUpdate represents the action
+	// of updating the permissions granted to a team on a repository.
+	AuditOrganizationTeamRepositoryPermissionsUpdate = "organization.team.repository_permissions.update"
+
+	// AuditOrganizationTeamUpdate represents the action of updating a team.
+	AuditOrganizationTeamUpdate = "organization.team.update"
+
+	// AuditRepositoryAdd represents the action of adding a repository.
+	AuditRepositoryAdd = "repository.add"
+
+	// AuditRepositoryCredentialsUpdate represents the action of updating the
+	// credentials of a repository.
+	AuditRepositoryCredentialsUpdate = "repository.credentials.update"
+
+	// AuditRepositoryDelete represents the action of deleting a repository.
+	AuditRepositoryDelete = "repository.delete"
+
+	// AuditRepositoryTrackingPause represents the action of pausing the
+	// tracking of a repository.
+	AuditRepositoryTrackingPause = "repository.tracking.pause"
+
+	// AuditRepositoryTrackingResume represents the action of resuming the
+	// tracking of a repository.
+	AuditRepositoryTrackingResume = "repository.tracking.resume"
+
+	// AuditRepositoryTrackingWebhookDisable represents the action of
+	// disabling the tracking webhook of a repository.
+	AuditRepositoryTrackingWebhookDisable = "repository.tracking-webhook.disable"
+
+	// AuditRepositoryTrackingWebhookEnable represents the action of enabling
+	// the tracking webhook of a repository (or regenerating its secret).
+	AuditRepositoryTrackingWebhookEnable = "repository.tracking-webhook.enable"
+
+	// AuditRepositoryTransfer represents the action of transferring a
+	// repository to a different user or organization.
+	AuditRepositoryTransfer = "repository.transfer"
+
+	// AuditRepositoryUpdate represents the action of updating a repository.
+	AuditRepositoryUpdate = "repository.update"
+
+	// AuditUserEmailUpdate represents the action of updating the email.
+	AuditUserEmailUpdate = "user.email.update"
+
+	// AuditUserLogin represents the action of logging in.
+	AuditUserLogin = "user.login"
+
+	// AuditUserPasswordUpdate represents the action of updating the password.
+	AuditUserPasswordUpdate = "user.password.update"
+
+	// AuditWebhookAdd represents the action of adding a webhook.
+	AuditWebhookAdd = "webhook.add"
+
+	// AuditWebhookDelete represents the action of deleting a webhook.
+	AuditWebhookDelete = "webhook.delete"
+
+	// AuditWebhookUpdate represents the action of updating a webhook.
+	AuditWebhookUpdate = "webhook.update"
+)
+
+type auditLogEntryKey struct{}
+
+// AuditLogEntryKey represents the key used for the audit log entry of the
+// request being processed inside a context. Handlers can use it to provide
+// details not available otherwise, like the actor of a login request.
+var AuditLogEntryKey = auditLogEntryKey{}
+
+// AuditLogEntry represents an entry of the audit log, which keeps track of
+// the security relevant actions performed by the users.
+type AuditLogEntry struct {
+	Action           string                 `json:"action"`
+	UserID           string                 `json:"user_id"`
+	OrganizationName string                 `json:"organization_name"`
+	IP               string                 `json:"ip"`
+	Details          map[string]interface{} `json:"details,omitempty"`
+}
+
+// GetAuditLogInput represents the input used to get some audit log entries.
+// Filters are only applied when getting the audit log of an organization.
+type GetAuditLogInput struct {
+	Limit     int      `json:"limit"`
+	Offset    int      `json:"offset"`
+	Actions   []string `json:"actions,omitempty"`
+	UserAlias string   `json:"user_alias,omitempty"`
+	From      int64    `json:"from,omitempty"`
+	To        int64    `json:"to,omitempty"`
+}
+
+// AuditLogManager describes the methods an AuditLogManager implementation
+// must provide.
+type AuditLogManager interface {
+	GetByOrgJSON(ctx context.Context, orgName string, input *GetAuditLogInput) ([]byte, error)
+	GetByUserJSON(ctx context.Context, input *GetAuditLogInput) ([]byte, error)
+	Register(ctx context.Context, e *AuditLogEntry) error
+}
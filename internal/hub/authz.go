@@ -28,10 +28,17 @@ const (
 	// repository from an organization.
 	DeleteOrganizationRepository Action = "deleteOrganizationRepository"
 
+	// GetAuditLog represents the action of getting an organization audit log.
+	GetAuditLog Action = "getAuditLog"
+
 	// GetAuthorizationPolicy represents the action of getting an organization
 	// authorization policy.
 	GetAuthorizationPolicy Action = "getAuthorizationPolicy"
 
+	// ManageOrganizationTeams represents the action of managing the teams of
+	// an organization, including their members and repositories permissions.
+	ManageOrganizationTeams Action = "manageOrganizationTeams"
+
 	// TransferOrganizationRepository represents the action of transferring a
 	// repository that belongs to an organization.
 	TransferOrganizationRepository Action = "transferOrganizationRepository"
@@ -44,6 +51,10 @@ const (
 	// organization.
 	UpdateOrganization Action = "updateOrganization"
 
+	// UpdateOrganizationMemberRole represents the action of updating the role
+	// of a member of an organization.
+	UpdateOrganizationMemberRole Action = "updateOrganizationMemberRole"
+
 	// UpdateOrganizationRepository represents the action of updating a
 	// repository that belongs to an organization.
 	UpdateOrganizationRepository Action = "updateOrganizationRepository"
@@ -76,4 +87,10 @@ type AuthorizeInput struct {
 
 	// Action represents the action to perform.
 	Action Action
+
+	// RepositoryName represents the name of the repository affected by the
+	// action, when it is a repository action. It's optional and allows
+	// taking into consideration the permissions granted to teams on the
+	// repository.
+	RepositoryName string
 }
@@ -0,0 +1,9 @@
+package hub
+
+import "context"
+
+// CaptchaVerifier describes the methods a CaptchaVerifier implementation must
+// provide.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, response, remoteIP string) error
+}
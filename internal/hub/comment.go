@@ -0,0 +1,31 @@
+package hub
+
+import "context"
+
+// Comment represents a comment posted on a package. Comments without a parent
+// are questions, whereas comments with a parent are answers to the question
+// they reference.
+type Comment struct {
+	CommentID string `json:"comment_id"`
+	PackageID string `json:"package_id"`
+	ParentID  string `json:"parent_id"`
+	Body      string `json:"body"`
+}
+
+// GetCommentsInput represents the input used to get the comments of a
+// package.
+type GetCommentsInput struct {
+	PackageID string `json:"package_id"`
+	Limit     int    `json:"limit"`
+	Offset    int    `json:"offset"`
+}
+
+// CommentManager describes the methods a CommentManager implementation must
+// provide.
+type CommentManager interface {
+	Add(ctx context.Context, c *Comment) ([]byte, error)
+	Delete(ctx context.Context, packageID, commentID string) error
+	GetJSON(ctx context.Context, input *GetCommentsInput) ([]byte, error)
+	SetHidden(ctx context.Context, packageID, commentID string, hidden bool) error
+	Update(ctx context.Context, c *Comment) error
+}
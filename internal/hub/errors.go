@@ -17,7 +17,47 @@ var (
 // ErrorsCollector interface defines the methods that an errors collector
 // implementation should provide.
 type ErrorsCollector interface {
-	Append(repositoryID string, err string)
+	Append(repositoryID string, err *RepositoryError)
 	Flush()
 	Init(repositoryID string)
 }
+
+// RepositoryErrorClass represents the class of an error collected while
+// processing a repository.
+type RepositoryErrorClass string
+
+const (
+	// RepositoryErrorClassRepository represents an error that affects the
+	// repository as a whole (e.g. the tracking of the repository timed out).
+	RepositoryErrorClassRepository RepositoryErrorClass = "repository"
+
+	// RepositoryErrorClassPackage represents an error preparing a package
+	// from its metadata.
+	RepositoryErrorClassPackage RepositoryErrorClass = "package"
+
+	// RepositoryErrorClassImage represents an error processing an image, like
+	// a package's logo.
+	RepositoryErrorClassImage RepositoryErrorClass = "image"
+
+	// RepositoryErrorClassRegistration represents an error registering or
+	// unregistering a package in the database.
+	RepositoryErrorClassRegistration RepositoryErrorClass = "registration"
+
+	// RepositoryErrorClassScanning represents an error scanning a package's
+	// containers images for security vulnerabilities.
+	RepositoryErrorClassScanning RepositoryErrorClass = "scanning"
+
+	// RepositoryErrorClassUnknown represents an error whose class is unknown.
+	// Errors collected before they were classified belong to this class.
+	RepositoryErrorClassUnknown RepositoryErrorClass = "unknown"
+)
+
+// RepositoryError represents an error collected while tracking or scanning a
+// repository.
+type RepositoryError struct {
+	PackageName    string               `json:"package_name,omitempty"`
+	PackageVersion string               `json:"package_version,omitempty"`
+	Class          RepositoryErrorClass `json:"class"`
+	Message        string               `json:"message"`
+	TS             int64                `json:"ts,omitempty"`
+}
@@ -37,6 +37,34 @@ const (
 	// RepositoryScanningErrors represents an event for errors that occur while
 	// a repository is being scanned.
 	RepositoryScanningErrors EventKind = 4
+
+	// PackageDeprecated represents an event for a package version that has
+	// been marked as deprecated.
+	PackageDeprecated EventKind = 5
+
+	// WebhookSuspended represents an event for a webhook that has been
+	// suspended after too many consecutive failed deliveries.
+	WebhookSuspended EventKind = 6
+
+	// NewPackage represents an event for a package that has just been added
+	// to a repository.
+	NewPackage EventKind = 7
+
+	// RepositoryOwnershipTransfer represents an event for a repository that
+	// has been transferred to a different owner.
+	RepositoryOwnershipTransfer EventKind = 8
+
+	// SavedSearchNewMatches represents an event for a saved search that has
+	// new packages matching it.
+	SavedSearchNewMatches EventKind = 9
+
+	// PackageNewQuestion represents an event for a new question posted on a
+	// package.
+	PackageNewQuestion EventKind = 10
+
+	// RepositoryVerifiedPublisherChange represents an event for a repository
+	// whose verified publisher status has changed.
+	RepositoryVerifiedPublisherChange EventKind = 11
 )
 
 // EventManager describes the methods an EventManager implementation must
@@ -10,3 +10,9 @@ type indexMetaDescriptionKey struct{}
 // IndexMetaDescriptionKey represents the key used for the description in the
 // index metadata.
 var IndexMetaDescriptionKey = indexMetaDescriptionKey{}
+
+type indexMetaOEmbedURLKey struct{}
+
+// IndexMetaOEmbedURLKey represents the key used for the oEmbed discovery url
+// in the index metadata.
+var IndexMetaOEmbedURLKey = indexMetaOEmbedURLKey{}
@@ -2,6 +2,7 @@ package hub
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 )
@@ -9,6 +10,7 @@ import (
 // Notification represents the details of a notification pending to be delivered.
 type Notification struct {
 	NotificationID string   `json:"notification_id"`
+	Attempts       int      `json:"attempts"`
 	Event          *Event   `json:"event"`
 	User           *User    `json:"user"`
 	Webhook        *Webhook `json:"webhook"`
@@ -18,7 +20,17 @@ type Notification struct {
 // implementation must provide.
 type NotificationManager interface {
 	Add(ctx context.Context, tx pgx.Tx, n *Notification) error
-	GetPending(ctx context.Context, tx pgx.Tx) (*Notification, error)
+	Defer(ctx context.Context, tx pgx.Tx, notificationID string, until time.Time) error
+	GetPending(ctx context.Context, tx pgx.Tx, limit int) ([]*Notification, error)
+	RegisterDeliveryFailure(
+		ctx context.Context,
+		tx pgx.Tx,
+		notificationID string,
+		payload []byte,
+		deliveryErr error,
+	) error
+	RegisterWebhookDelivery(ctx context.Context, tx pgx.Tx, d *WebhookDelivery) error
+	RenewLease(ctx context.Context, tx pgx.Tx, notificationIDs []string) (bool, error)
 	UpdateStatus(
 		ctx context.Context,
 		tx pgx.Tx,
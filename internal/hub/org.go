@@ -15,19 +15,33 @@ type Organization struct {
 	LogoImageID    string `json:"logo_image_id"`
 }
 
+// DeleteOrganizationInput represents the input used to request the deletion
+// of an organization. The repositories owned by the organization can be
+// transferred to another organization or to the user doing the request
+// before it is deleted.
+type DeleteOrganizationInput struct {
+	Confirmation               string `json:"confirmation"`
+	TransferRepositoriesToOrg  string `json:"transfer_repositories_to_org,omitempty"`
+	TransferRepositoriesToUser bool   `json:"transfer_repositories_to_user,omitempty"`
+}
+
 // OrganizationManager describes the methods an OrganizationManager
 // implementation must provide.
 type OrganizationManager interface {
 	Add(ctx context.Context, org *Organization) error
 	AddMember(ctx context.Context, orgName, userAlias, baseURL string) error
+	CancelDeletion(ctx context.Context, orgName string) error
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	ConfirmMembership(ctx context.Context, orgName string) error
-	Delete(ctx context.Context, orgName string) error
+	Delete(ctx context.Context, orgName string, input *DeleteOrganizationInput) error
 	DeleteMember(ctx context.Context, orgName, userAlias string) error
 	GetJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetByUserJSON(ctx context.Context) ([]byte, error)
 	GetAuthorizationPolicyJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetMembersJSON(ctx context.Context, orgName string) ([]byte, error)
+	Rename(ctx context.Context, orgName, newName string) error
+	TransferOwnership(ctx context.Context, orgName, userAlias string) error
 	Update(ctx context.Context, orgName string, org *Organization) error
 	UpdateAuthorizationPolicy(ctx context.Context, orgName string, policy *AuthorizationPolicy) error
+	UpdateMemberRole(ctx context.Context, orgName, userAlias, role string) error
 }
@@ -3,6 +3,8 @@ package hub
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"strings"
 )
 
 const (
@@ -11,6 +13,12 @@ const (
 	PackageMetadataFile = "artifacthub-pkg"
 )
 
+// ChartMirror describes the methods a ChartMirror implementation must
+// provide.
+type ChartMirror interface {
+	GetChartArchive(ctx context.Context, packageID, version string) (io.ReadCloser, string, error)
+}
+
 // Channel represents a package's channel.
 type Channel struct {
 	Name    string `json:"name"`
@@ -25,6 +33,14 @@ type GetPackageInput struct {
 	Version        string `json:"version"`
 }
 
+// GetRecentReleasesInput represents the input used to get the most recent
+// releases of the packages in a repository or from a given publisher.
+type GetRecentReleasesInput struct {
+	RepositoryName string `json:"repository_name,omitempty"`
+	Publisher      string `json:"publisher,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+}
+
 // Link represents a url associated with a package.
 type Link struct {
 	Name string `json:"name" yaml:"name"`
@@ -54,6 +70,9 @@ type Package struct {
 	LogoImageID             string                 `json:"logo_image_id"`
 	IsOperator              bool                   `json:"is_operator"`
 	Official                bool                   `json:"official"`
+	Stars                   int                    `json:"stars,omitempty"`
+	Rating                  float64                `json:"rating,omitempty"`
+	Ratings                 int                    `json:"ratings,omitempty"`
 	Channels                []*Channel             `json:"channels"`
 	DefaultChannel          string                 `json:"default_channel"`
 	DisplayName             string                 `json:"display_name"`
@@ -74,15 +93,23 @@ type Package struct {
 	AppVersion              string                 `json:"app_version"`
 	Digest                  string                 `json:"digest"`
 	Deprecated              bool                   `json:"deprecated"`
+	Yanked                  bool                   `json:"yanked"`
+	YankedReason            string                 `json:"yanked_reason,omitempty"`
 	License                 string                 `json:"license"`
 	Signed                  bool                   `json:"signed"`
+	Signatures              []*Signature           `json:"signatures"`
+	Provenance              *Provenance            `json:"provenance"`
 	ContentURL              string                 `json:"content_url"`
 	ContainersImages        []*ContainerImage      `json:"containers_images"`
 	Provider                string                 `json:"provider"`
 	HasValuesSchema         bool                   `json:"has_values_schema"`
 	ValuesSchema            json.RawMessage        `json:"values_schema,omitempty"`
+	DefaultValues           string                 `json:"default_values,omitempty"`
+	HasSBOM                 bool                   `json:"has_sbom"`
+	SBOMs                   []*SBOM                `json:"sboms,omitempty"`
 	HasChangeLog            bool                   `json:"has_changelog"`
 	Changes                 []string               `json:"changes"`
+	PullsLastMonth          int64                  `json:"pulls_last_month,omitempty"`
 	ContainsSecurityUpdates bool                   `json:"contains_security_updates"`
 	Prerelease              bool                   `json:"prerelease"`
 	Maintainers             []*Maintainer          `json:"maintainers"`
@@ -94,24 +121,33 @@ type Package struct {
 // PackageManager describes the methods a PackageManager implementation must
 // provide.
 type PackageManager interface {
+	AddVulnerabilitySuppression(ctx context.Context, pkgID string, s *VulnerabilitySuppression) error
+	DeleteVulnerabilitySuppression(ctx context.Context, pkgID, vulnerabilityID string) error
 	Get(ctx context.Context, input *GetPackageInput) (*Package, error)
 	GetChangeLogJSON(ctx context.Context, pkgID string) ([]byte, error)
 	GetHarborReplicationDumpJSON(ctx context.Context) ([]byte, error)
 	GetJSON(ctx context.Context, input *GetPackageInput) ([]byte, error)
 	GetRandomJSON(ctx context.Context) ([]byte, error)
+	GetRecentReleases(ctx context.Context, input *GetRecentReleasesInput) ([]*Package, error)
+	GetSBOMs(ctx context.Context, pkgID, version string) ([]*SBOM, error)
 	GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error)
 	GetSnapshotsToScan(ctx context.Context) ([]*SnapshotToScan, error)
 	GetStarredByUserJSON(ctx context.Context) ([]byte, error)
 	GetStarsJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetStatsJSON(ctx context.Context) ([]byte, error)
 	GetSummaryJSON(ctx context.Context, input *GetPackageInput) ([]byte, error)
+	GetValuesDiffJSON(ctx context.Context, pkgID, from, to string) ([]byte, error)
 	GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error)
+	GetVulnerabilitiesSuppressionsJSON(ctx context.Context, pkgID string) ([]byte, error)
 	Register(ctx context.Context, pkg *Package) error
+	RequestSnapshotSecurityReportRescan(ctx context.Context, pkgID, version string) error
 	SearchJSON(ctx context.Context, input *SearchPackageInput) ([]byte, error)
 	SearchMonocularJSON(ctx context.Context, baseURL, tsQueryWeb string) ([]byte, error)
 	ToggleStar(ctx context.Context, packageID string) error
 	UpdateSnapshotSecurityReport(ctx context.Context, r *SnapshotSecurityReport) error
 	Unregister(ctx context.Context, pkg *Package) error
+	UnyankVersion(ctx context.Context, pkgID, version string) error
+	YankVersion(ctx context.Context, pkgID, version, reason string) error
 }
 
 // PackageMetadata represents some metadata about a given package. It's usually
@@ -144,6 +180,18 @@ type PackageMetadata struct {
 	Provider                *Provider         `yaml:"provider"`
 	Ignore                  []string          `yaml:"ignore"`
 	Recommendations         []*Recommendation `yaml:"recommendations"`
+	SBOMPath                string            `yaml:"sbomPath"`
+	SBOMURL                 string            `yaml:"sbomURL"`
+}
+
+// Provenance represents some information about the SLSA provenance
+// attestation of a package version.
+type Provenance struct {
+	PredicateType string `json:"predicate_type"`
+	BuilderID     string `json:"builder_id"`
+	Level         int    `json:"level"`
+	Identity      string `json:"identity,omitempty"`
+	Verified      bool   `json:"verified"`
 }
 
 // Recommendation represents some information about a recommended package.
@@ -151,13 +199,68 @@ type Recommendation struct {
 	URL string `json:"url" yaml:"url"`
 }
 
+// SBOM represents a software bill of materials of a package version.
+type SBOM struct {
+	Format  string `json:"format"`
+	Content string `json:"content"`
+}
+
+// Signature represents a signature of a package version, like the ones
+// created with cosign for the artifacts stored in OCI registries.
+type Signature struct {
+	Kind     string `json:"kind"`
+	Identity string `json:"identity,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+	Verified bool   `json:"verified"`
+}
+
 // SnapshotSecurityReport represents some information about the security
 // vulnerabilities the images used by a given package's snapshot may have.
 type SnapshotSecurityReport struct {
-	PackageID string                   `json:"package_id"`
-	Version   string                   `json:"version"`
-	Summary   *SecurityReportSummary   `json:"summary"`
-	Full      map[string][]interface{} `json:"full"`
+	PackageID          string                   `json:"package_id"`
+	Version            string                   `json:"version"`
+	Summary            *SecurityReportSummary   `json:"summary"`
+	Full               map[string][]interface{} `json:"full"`
+	NewVulnerabilities []*Vulnerability         `json:"new_vulnerabilities,omitempty"`
+}
+
+// severityLevels represents the severity levels of the vulnerabilities, used
+// to compare them against the severity thresholds.
+var severityLevels = map[string]int{
+	"UNKNOWN":  0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// SeverityLevel returns the level of the vulnerability severity provided, so
+// that severities can be compared. The severity is case insensitive. False is
+// returned when the severity provided is not valid.
+func SeverityLevel(severity string) (int, bool) {
+	level, ok := severityLevels[strings.ToUpper(severity)]
+	return level, ok
+}
+
+// Vulnerability represents some details about a security vulnerability found
+// in one of the images used by a package's snapshot.
+type Vulnerability struct {
+	ID               string `json:"id"`
+	Severity         string `json:"severity"`
+	Image            string `json:"image"`
+	PkgName          string `json:"pkg_name"`
+	InstalledVersion string `json:"installed_version"`
+	FixedVersion     string `json:"fixed_version,omitempty"`
+	Title            string `json:"title,omitempty"`
+}
+
+// VulnerabilitySuppression represents a vulnerability marked by the package's
+// publisher as not applicable to the package. Suppressed vulnerabilities are
+// still visible in the security reports, but they are not taken into account
+// in the summaries or the vulnerabilities alerts.
+type VulnerabilitySuppression struct {
+	VulnerabilityID string `json:"vulnerability_id"`
+	Justification   string `json:"justification"`
 }
 
 // SecurityReportSummary represents a summary of the security report.
@@ -172,11 +275,12 @@ type SecurityReportSummary struct {
 // SnapshotToScan represents some information about a package's snapshot that
 // needs to be scanned for security vulnerabilities.
 type SnapshotToScan struct {
-	RepositoryID     string            `json:"repository_id"`
-	PackageID        string            `json:"package_id"`
-	PackageName      string            `json:"package_name"`
-	Version          string            `json:"version"`
-	ContainersImages []*ContainerImage `json:"containers_images"`
+	RepositoryID              string                      `json:"repository_id"`
+	PackageID                 string                      `json:"package_id"`
+	PackageName               string                      `json:"package_name"`
+	Version                   string                      `json:"version"`
+	ContainersImages          []*ContainerImage           `json:"containers_images"`
+	SuppressedVulnerabilities []*VulnerabilitySuppression `json:"suppressed_vulnerabilities"`
 }
 
 // Provider represents a package's provider.
@@ -188,6 +292,7 @@ type Provider struct {
 type SearchPackageInput struct {
 	Limit             int              `json:"limit,omitempty"`
 	Offset            int              `json:"offset,omitempty"`
+	Cursor            string           `json:"cursor,omitempty"`
 	Facets            bool             `json:"facets"`
 	TSQueryWeb        string           `json:"ts_query_web,omitempty"`
 	TSQuery           string           `json:"ts_query,omitempty"`
@@ -201,10 +306,13 @@ type SearchPackageInput struct {
 	Deprecated        bool             `json:"deprecated"`
 	Licenses          []string         `json:"licenses,omitempty"`
 	Capabilities      []string         `json:"capabilities,omitempty"`
+	Sort              string           `json:"sort,omitempty"`
+	Fuzzy             bool             `json:"fuzzy,omitempty"`
 }
 
 // Version represents a package's version.
 type Version struct {
 	Version string `json:"version"`
 	TS      int64  `json:"ts"`
+	Yanked  bool   `json:"yanked,omitempty"`
 }
@@ -0,0 +1,33 @@
+package hub
+
+import "context"
+
+// PackagePulls represents the number of times a package was pulled on a given
+// day (formatted as YYYY-MM-DD).
+type PackagePulls struct {
+	PackageName string `json:"package_name"`
+	Day         string `json:"day"`
+	Pulls       int64  `json:"pulls"`
+}
+
+// PullsPollingSource represents a package whose pull count may be polled from
+// the OCI registry where it is stored.
+type PullsPollingSource struct {
+	PackageID string `json:"package_id"`
+	Ref       string `json:"ref"`
+}
+
+// GetTrendingPackagesInput represents the input used to get the trending
+// packages.
+type GetTrendingPackagesInput struct {
+	Kind  *RepositoryKind `json:"kind,omitempty"`
+	Limit int             `json:"limit,omitempty"`
+}
+
+// PullsManager describes the methods a PullsManager implementation must
+// provide.
+type PullsManager interface {
+	GetPackagePullsJSON(ctx context.Context, packageID string, days int) ([]byte, error)
+	GetTrendingPackagesJSON(ctx context.Context, input *GetTrendingPackagesInput) ([]byte, error)
+	Register(ctx context.Context, repoName string, pulls []*PackagePulls) error
+}
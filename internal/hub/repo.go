@@ -48,11 +48,53 @@ const (
 
 	// KedaScaler represents a repository with KEDA scalers.
 	KedaScaler RepositoryKind = 8
+
+	// Wasm represents a repository with WASM modules (like wasmCloud actors)
+	// stored in a OCI registry.
+	Wasm RepositoryKind = 9
+
+	// CrossplaneConfiguration represents a repository with Crossplane
+	// configuration packages stored in a OCI registry.
+	CrossplaneConfiguration RepositoryKind = 10
+
+	// OCIArtifact represents a repository with a generic artifact stored in a
+	// OCI registry, whose metadata is provided using annotations.
+	OCIArtifact RepositoryKind = 11
+)
+
+// TrackingRunStatus represents the status of a repository tracking run.
+type TrackingRunStatus string
+
+const (
+	// TrackingRunQueued represents a tracking run waiting to be processed.
+	TrackingRunQueued TrackingRunStatus = "queued"
+
+	// TrackingRunRunning represents a tracking run being processed.
+	TrackingRunRunning TrackingRunStatus = "running"
+
+	// TrackingRunCompleted represents a tracking run that has finished, with
+	// or without errors.
+	TrackingRunCompleted TrackingRunStatus = "completed"
+)
+
+// OwnershipProofMethod represents the method used to prove the control of the
+// host serving a repository when claiming its ownership.
+type OwnershipProofMethod string
+
+const (
+	// OwnershipProofDNS represents a proof provided using a DNS TXT record.
+	OwnershipProofDNS OwnershipProofMethod = "dns"
+
+	// OwnershipProofHTTP represents a proof provided using a file served
+	// from a well-known URL.
+	OwnershipProofHTTP OwnershipProofMethod = "http"
 )
 
 // GetKindName returns the name of the provided repository kind.
 func GetKindName(kind RepositoryKind) string {
 	switch kind {
+	case CrossplaneConfiguration:
+		return "crossplane-configuration"
 	case Falco:
 		return "falco"
 	case Helm:
@@ -65,12 +107,16 @@ func GetKindName(kind RepositoryKind) string {
 		return "krew"
 	case OLM:
 		return "olm"
+	case OCIArtifact:
+		return "oci-artifact"
 	case OPA:
 		return "opa"
 	case TBAction:
 		return "tbaction"
 	case TektonTask:
 		return "tekton-task"
+	case Wasm:
+		return "wasm"
 	default:
 		return ""
 	}
@@ -80,6 +126,8 @@ func GetKindName(kind RepositoryKind) string {
 // provided.
 func GetKindFromName(kind string) (RepositoryKind, error) {
 	switch kind {
+	case "crossplane-configuration":
+		return CrossplaneConfiguration, nil
 	case "falco":
 		return Falco, nil
 	case "helm":
@@ -92,12 +140,16 @@ func GetKindFromName(kind string) (RepositoryKind, error) {
 		return Krew, nil
 	case "olm":
 		return OLM, nil
+	case "oci-artifact":
+		return OCIArtifact, nil
 	case "opa":
 		return OPA, nil
 	case "tbaction":
 		return TBAction, nil
 	case "tekton-task":
 		return TektonTask, nil
+	case "wasm":
+		return Wasm, nil
 	default:
 		return -1, errors.New("invalid kind name")
 	}
@@ -115,6 +167,35 @@ type OCITagsGetter interface {
 	Tags(ctx context.Context, r *Repository) ([]string, error)
 }
 
+// OCIFilesGetter is the interface that wraps the Files method, used to get the
+// files in the filesystem of a given version (tag) of a repository in a OCI
+// registry. Only the files matched by the filter provided are returned.
+type OCIFilesGetter interface {
+	Files(ctx context.Context, r *Repository, version string, filter func(name string) bool) (map[string][]byte, error)
+}
+
+// OCIManifestGetter is the interface that wraps the Manifest method, used to
+// get the raw manifest (and its digest) of a given version (tag) of a
+// repository in a OCI registry.
+type OCIManifestGetter interface {
+	Manifest(ctx context.Context, r *Repository, version string) ([]byte, string, error)
+}
+
+// OCISBOMGetter is the interface that wraps the SBOMs method, used to get the
+// SBOMs attached to a given version (tag) of a repository in a OCI registry.
+type OCISBOMGetter interface {
+	SBOMs(ctx context.Context, r *Repository, version string) ([]*SBOM, error)
+}
+
+// OCISignatureVerifier describes the methods an OCISignatureVerifier
+// implementation must provide, used to verify the signatures and the SLSA
+// provenance attestations of a given version (tag) of a repository in a OCI
+// registry.
+type OCISignatureVerifier interface {
+	Verify(ctx context.Context, r *Repository, version string) ([]*Signature, error)
+	VerifyProvenance(ctx context.Context, r *Repository, version string) (*Provenance, error)
+}
+
 // OLMOCIExporter describes the methods an OLMOCIExporter implementation must
 // must provide.
 type OLMOCIExporter interface {
@@ -127,29 +208,92 @@ type Owner struct {
 	Email string `yaml:"email"`
 }
 
+// OwnershipClaim represents a request to claim the ownership of a repository
+// proving the control of the host serving it, which is verified periodically
+// by the ownership claims checker.
+type OwnershipClaim struct {
+	OwnershipClaimID string               `json:"repository_ownership_claim_id"`
+	RepositoryURL    string               `json:"repository_url"`
+	OrganizationName string               `json:"organization_name,omitempty"`
+	Method           OwnershipProofMethod `json:"method"`
+	Token            string               `json:"token"`
+	Status           string               `json:"status,omitempty"`
+	Proof            *OwnershipProof      `json:"proof,omitempty"`
+	LastError        string               `json:"last_error,omitempty"`
+	CheckedAt        int64                `json:"checked_at,omitempty"`
+	CreatedAt        int64                `json:"created_at,omitempty"`
+}
+
+// OwnershipProof represents the details of the proof expected to verify an
+// ownership claim. The target is the name of the DNS TXT record or the URL
+// that must contain the value provided.
+type OwnershipProof struct {
+	Target string `json:"target"`
+	Value  string `json:"value"`
+}
+
 // Repository represents a packages repository.
 type Repository struct {
-	RepositoryID            string         `json:"repository_id"`
-	Name                    string         `json:"name"`
-	DisplayName             string         `json:"display_name"`
-	URL                     string         `json:"url"`
-	Branch                  string         `json:"branch"`
-	Private                 bool           `json:"private"`
-	AuthUser                string         `json:"auth_user"`
-	AuthPass                string         `json:"auth_pass"`
-	Digest                  string         `json:"digest"`
-	Kind                    RepositoryKind `json:"kind"`
-	UserID                  string         `json:"user_id"`
-	UserAlias               string         `json:"user_alias"`
-	OrganizationID          string         `json:"organization_id"`
-	OrganizationName        string         `json:"organization_name"`
-	OrganizationDisplayName string         `json:"organization_display_name"`
-	LastScanningErrors      string         `json:"last_scanning_errors"`
-	LastTrackingErrors      string         `json:"last_tracking_errors"`
-	VerifiedPublisher       bool           `json:"verified_publisher"`
-	Official                bool           `json:"official"`
-	Disabled                bool           `json:"disabled"`
-	ScannerDisabled         bool           `json:"scanner_disabled"`
+	RepositoryID            string               `json:"repository_id"`
+	Name                    string               `json:"name"`
+	DisplayName             string               `json:"display_name"`
+	URL                     string               `json:"url"`
+	Branch                  string               `json:"branch"`
+	Path                    string               `json:"path"`
+	Private                 bool                 `json:"private"`
+	AuthUser                string               `json:"auth_user"`
+	AuthPass                string               `json:"auth_pass"`
+	AuthPassRef             string               `json:"auth_pass_ref"`
+	TLS                     *RepositoryTLSConfig `json:"tls,omitempty"`
+	ProxyURL                string               `json:"proxy_url"`
+	Digest                  string               `json:"digest"`
+	Kind                    RepositoryKind       `json:"kind"`
+	UserID                  string               `json:"user_id"`
+	UserAlias               string               `json:"user_alias"`
+	OrganizationID          string               `json:"organization_id"`
+	OrganizationName        string               `json:"organization_name"`
+	OrganizationDisplayName string               `json:"organization_display_name"`
+	LastScanningErrors      string               `json:"last_scanning_errors"`
+	ScanningErrors          []*RepositoryError   `json:"scanning_errors"`
+	LastTrackingTS          int64                `json:"last_tracking_ts"`
+	LastTrackingErrors      string               `json:"last_tracking_errors"`
+	TrackingErrors          []*RepositoryError   `json:"tracking_errors"`
+	VerifiedPublisher       bool                 `json:"verified_publisher"`
+	Official                bool                 `json:"official"`
+	Disabled                bool                 `json:"disabled"`
+	ScannerDisabled         bool                 `json:"scanner_disabled"`
+	TrackingEnabled         bool                 `json:"tracking_enabled"`
+	TrackingSchedule        string               `json:"tracking_schedule"`
+	RetentionPolicy         *RetentionPolicy     `json:"retention_policy,omitempty"`
+	TrackingRequestedTS     int64                `json:"tracking_requested_ts"`
+	TrackingWebhookEnabled  bool                 `json:"tracking_webhook_enabled"`
+}
+
+// RepositoryCredentials represents the credentials used to access a private
+// repository. The password can be provided directly or as a reference to a
+// secret stored in an external secret store, which will be resolved when
+// needed.
+type RepositoryCredentials struct {
+	AuthUser    string `json:"auth_user"`
+	AuthPass    string `json:"auth_pass"`
+	AuthPassRef string `json:"auth_pass_ref"`
+}
+
+// RetentionPolicy represents the policy used to prune old versions of the
+// packages in a repository during tracking. Versions exceeding the maximum
+// number of versions allowed or older than the maximum age (in days) are not
+// indexed. The latest version of each package is always kept.
+type RetentionPolicy struct {
+	MaxVersions int `json:"max_versions,omitempty"`
+	MaxAgeDays  int `json:"max_age_days,omitempty"`
+}
+
+// RepositoryTLSConfig represents the TLS options used when connecting to a
+// repository. Certificates and keys are expected to be PEM encoded.
+type RepositoryTLSConfig struct {
+	CACert     string `json:"ca_cert,omitempty"`
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
 }
 
 // RepositoryCloner describes the methods a RepositoryCloner implementation
@@ -169,6 +313,7 @@ type RepositoryManager interface {
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
 	ClaimOwnership(ctx context.Context, name, orgName string) error
 	Delete(ctx context.Context, name string) error
+	DryRun(ctx context.Context, r *Repository) (*TrackerDryRunResult, error)
 	GetAll(ctx context.Context, includeCredentials bool) ([]*Repository, error)
 	GetAllJSON(ctx context.Context, includeCredentials bool) ([]byte, error)
 	GetByID(ctx context.Context, repositorID string, includeCredentials bool) (*Repository, error)
@@ -179,13 +324,34 @@ type RepositoryManager interface {
 	GetPackagesDigest(ctx context.Context, repositoryID string) (map[string]string, error)
 	GetOwnedByOrgJSON(ctx context.Context, orgName string, includeCredentials bool) ([]byte, error)
 	GetOwnedByUserJSON(ctx context.Context, includeCredentials bool) ([]byte, error)
+	GetOwnershipClaimJSON(ctx context.Context, name string) ([]byte, error)
 	GetRemoteDigest(ctx context.Context, r *Repository) (string, error)
-	SetLastScanningResults(ctx context.Context, repositoryID, errs string) error
-	SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error
+	GetTrackingStatusJSON(ctx context.Context, name string) ([]byte, error)
+	RegisterTrackingRun(ctx context.Context, repositoryID string) (string, error)
+	RequestOwnershipClaim(ctx context.Context, name, orgName string, method OwnershipProofMethod) ([]byte, error)
+	RequestTracking(ctx context.Context, name string, payload []byte, signature string) error
+	ResolveCredentials(ctx context.Context, r *Repository) error
+	SearchJSON(ctx context.Context, input *SearchRepositoryInput) ([]byte, error)
+	SetLastScanningResults(ctx context.Context, repositoryID string, errs []*RepositoryError) error
+	SetLastTrackingResults(ctx context.Context, repositoryID string, errs []*RepositoryError) error
 	SetVerifiedPublisher(ctx context.Context, repositorID string, verified bool) error
 	Transfer(ctx context.Context, name, orgName string, ownershipClaim bool) error
 	Update(ctx context.Context, r *Repository) error
+	UpdateCredentials(ctx context.Context, name string, c *RepositoryCredentials) error
 	UpdateDigest(ctx context.Context, repositorID, digest string) error
+	UpdateTrackingEnabled(ctx context.Context, name string, enabled bool) error
+	UpdateTrackingRun(ctx context.Context, run *TrackingRun) error
+	UpdateTrackingWebhook(ctx context.Context, name string, enabled bool) (*TrackingWebhook, error)
+}
+
+// SearchRepositoryInput represents the query input when searching for
+// repositories.
+type SearchRepositoryInput struct {
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+	Cursor string           `json:"cursor,omitempty"`
+	Name   string           `json:"name,omitempty"`
+	Kinds  []RepositoryKind `json:"kinds,omitempty"`
 }
 
 // RepositoryMetadata represents some metadata about a given repository. It's
@@ -205,3 +371,20 @@ type RepositoryIgnoreEntry struct {
 	Name    string `yaml:"name"`
 	Version string `yaml:"version"`
 }
+
+// TrackingRun represents a repository tracking run, including its progress.
+type TrackingRun struct {
+	TrackingRunID     string            `json:"tracking_run_id"`
+	Status            TrackingRunStatus `json:"status"`
+	PackagesTotal     int               `json:"packages_total"`
+	PackagesProcessed int               `json:"packages_processed"`
+	Errors            string            `json:"errors"`
+}
+
+// TrackingWebhook represents the details of the webhook that allows
+// triggering the tracking of a repository, usually from a CI workflow after
+// publishing new content. Requests must be signed using the secret provided.
+type TrackingWebhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,29 @@
+package hub
+
+import "context"
+
+// Review represents a package review posted by a user. Reviews include a star
+// rating and optionally a short title and body.
+type Review struct {
+	PackageID string `json:"package_id"`
+	Rating    int    `json:"rating"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+}
+
+// GetReviewsInput represents the input used to get the reviews of a package.
+type GetReviewsInput struct {
+	PackageID string `json:"package_id"`
+	Limit     int    `json:"limit"`
+	Offset    int    `json:"offset"`
+}
+
+// ReviewManager describes the methods a ReviewManager implementation must
+// provide.
+type ReviewManager interface {
+	Add(ctx context.Context, r *Review) error
+	Delete(ctx context.Context, packageID string) error
+	GetJSON(ctx context.Context, input *GetReviewsInput) ([]byte, error)
+	Reply(ctx context.Context, packageID, reviewID, reply string) error
+	Report(ctx context.Context, packageID, reviewID, reason string) error
+}
@@ -0,0 +1,24 @@
+package hub
+
+import "context"
+
+// SavedSearch represents a packages search query saved by a user. Users can
+// optionally be notified when new packages start matching it.
+type SavedSearch struct {
+	SavedSearchID        string              `json:"saved_search_id"`
+	UserID               string              `json:"user_id"`
+	Name                 string              `json:"name"`
+	Query                *SearchPackageInput `json:"query"`
+	NotificationsEnabled bool                `json:"notifications_enabled"`
+}
+
+// SavedSearchManager describes the methods a SavedSearchManager
+// implementation must provide.
+type SavedSearchManager interface {
+	Add(ctx context.Context, s *SavedSearch) ([]byte, error)
+	Delete(ctx context.Context, savedSearchID string) error
+	GetJSON(ctx context.Context, savedSearchID string) ([]byte, error)
+	GetMatchesJSON(ctx context.Context, savedSearchID string) ([]byte, error)
+	GetOwnedByUserJSON(ctx context.Context) ([]byte, error)
+	Update(ctx context.Context, s *SavedSearch) error
+}
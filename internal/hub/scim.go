@@ -0,0 +1,72 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SCIMUser represents a user provided by a SCIM client.
+type SCIMUser struct {
+	UserName string       `json:"userName"`
+	Name     *SCIMName    `json:"name,omitempty"`
+	Emails   []*SCIMEmail `json:"emails,omitempty"`
+	Active   *bool        `json:"active,omitempty"`
+}
+
+// SCIMName represents the name of a SCIM user.
+type SCIMName struct {
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// SCIMEmail represents an email of a SCIM user.
+type SCIMEmail struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMGroup represents a group provided by a SCIM client. Groups are mapped
+// to organizations.
+type SCIMGroup struct {
+	DisplayName string             `json:"displayName"`
+	Members     []*SCIMGroupMember `json:"members"`
+}
+
+// SCIMGroupMember represents a member of a SCIM group.
+type SCIMGroupMember struct {
+	Value string `json:"value"`
+}
+
+// SCIMPatchOp represents a SCIM patch request.
+type SCIMPatchOp struct {
+	Operations []*SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation represents an operation of a SCIM patch request.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// SCIMListInput represents the input used to list SCIM resources.
+type SCIMListInput struct {
+	Filter     string
+	StartIndex int
+	Count      int
+}
+
+// SCIMManager describes the methods a SCIMManager implementation must
+// provide.
+type SCIMManager interface {
+	DeleteUser(ctx context.Context, userID string) error
+	GetGroupJSON(ctx context.Context, groupID string) ([]byte, error)
+	GetGroupsJSON(ctx context.Context, input *SCIMListInput) ([]byte, error)
+	GetUserJSON(ctx context.Context, userID string) ([]byte, error)
+	GetUsersJSON(ctx context.Context, input *SCIMListInput) ([]byte, error)
+	PatchGroup(ctx context.Context, groupID string, op *SCIMPatchOp) ([]byte, error)
+	PatchUser(ctx context.Context, userID string, op *SCIMPatchOp) ([]byte, error)
+	RegisterUser(ctx context.Context, u *SCIMUser) ([]byte, error)
+	UpdateGroup(ctx context.Context, groupID string, g *SCIMGroup) ([]byte, error)
+	UpdateUser(ctx context.Context, userID string, u *SCIMUser) ([]byte, error)
+}
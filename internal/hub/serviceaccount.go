@@ -0,0 +1,21 @@
+package hub
+
+import "context"
+
+// ServiceAccount represents a non-human account owned by an organization. It
+// cannot log in and can only interact with the HTTP API using its API keys.
+type ServiceAccount struct {
+	ServiceAccountID string `json:"service_account_id"`
+	Name             string `json:"name"`
+	Alias            string `json:"alias"`
+}
+
+// ServiceAccountManager describes the methods a ServiceAccountManager
+// implementation must provide.
+type ServiceAccountManager interface {
+	Add(ctx context.Context, orgName string, sa *ServiceAccount) ([]byte, error)
+	AddAPIKey(ctx context.Context, orgName, serviceAccountID string, ak *APIKey) ([]byte, error)
+	Delete(ctx context.Context, orgName, serviceAccountID string) error
+	DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error
+	GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error)
+}
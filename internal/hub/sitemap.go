@@ -0,0 +1,23 @@
+package hub
+
+import "context"
+
+// SitemapEntry represents an entry in the sitemap.
+type SitemapEntry struct {
+	Path         string `json:"path"`
+	LastModified int64  `json:"last_modified"`
+}
+
+// SitemapSection represents a section of the sitemap.
+type SitemapSection struct {
+	Name         string `json:"name"`
+	Total        int    `json:"total"`
+	LastModified int64  `json:"last_modified"`
+}
+
+// SitemapManager describes the methods a SitemapManager implementation must
+// provide.
+type SitemapManager interface {
+	GetEntries(ctx context.Context, section string, limit, offset int) ([]*SitemapEntry, error)
+	GetSections(ctx context.Context) ([]*SitemapSection, error)
+}
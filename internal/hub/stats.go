@@ -2,8 +2,17 @@ package hub
 
 import "context"
 
+// GetStatsSeriesInput represents the input used to get a stats time series.
+type GetStatsSeriesInput struct {
+	Metric   string `json:"metric"`
+	Interval string `json:"interval"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
 // StatsManager describes the methods an StatsManager implementation must
 // provide.
 type StatsManager interface {
 	GetJSON(ctx context.Context) ([]byte, error)
+	GetSeriesJSON(ctx context.Context, input *GetStatsSeriesInput) ([]byte, error)
 }
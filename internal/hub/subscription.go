@@ -2,6 +2,17 @@ package hub
 
 import "context"
 
+// BulkSubscriptionInput represents the information needed to subscribe or
+// unsubscribe a user to/from all the packages of a repository or publisher
+// (user or organization) at once. Only one of the repository id, organization
+// name or user alias must be provided.
+type BulkSubscriptionInput struct {
+	RepositoryID     string    `json:"repository_id,omitempty"`
+	OrganizationName string    `json:"organization_name,omitempty"`
+	UserAlias        string    `json:"user_alias,omitempty"`
+	EventKind        EventKind `json:"event_kind"`
+}
+
 // OptOut represents a user's opt-out entry to stop receiving notifications
 // about a given repository and event kind.
 type OptOut struct {
@@ -12,22 +23,74 @@ type OptOut struct {
 }
 
 // Subscription represents a user's subscription to receive notifications about
-// a given package and event kind.
+// a given package and event kind. Security alerts subscriptions can optionally
+// set the minimum severity the vulnerabilities must have to be notified.
 type Subscription struct {
-	UserID    string    `json:"user_id"`
-	PackageID string    `json:"package_id"`
-	EventKind EventKind `json:"event_kind"`
+	UserID      string    `json:"user_id"`
+	PackageID   string    `json:"package_id"`
+	EventKind   EventKind `json:"event_kind"`
+	MinSeverity string    `json:"min_severity,omitempty"`
+}
+
+// ScopedSubscription represents a user's subscription to receive notifications
+// about all the packages of a given repository or publisher (user or
+// organization), including the ones added in the future. Only one of the
+// repository id, organization name or user alias must be provided.
+type ScopedSubscription struct {
+	RepositoryID     string    `json:"repository_id,omitempty"`
+	OrganizationName string    `json:"organization_name,omitempty"`
+	UserAlias        string    `json:"user_alias,omitempty"`
+	EventKind        EventKind `json:"event_kind"`
+	MinSeverity      string    `json:"min_severity,omitempty"`
+}
+
+// SearchSubscriptionsInput represents the input used to search the
+// subscriptions of a user.
+type SearchSubscriptionsInput struct {
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+	Cursor     string      `json:"cursor,omitempty"`
+	EventKinds []EventKind `json:"event_kinds,omitempty"`
+}
+
+// SubscriptionsExportEntry represents the subscriptions of a user to a given
+// package, as they are exported and imported. Packages are identified by
+// their repository and normalized names, so that the entries are meaningful
+// regardless of the packages ids.
+type SubscriptionsExportEntry struct {
+	RepositoryName string      `json:"repository_name"`
+	PackageName    string      `json:"package_name"`
+	EventKinds     []EventKind `json:"event_kinds"`
+}
+
+// UnsubscribeInput represents the information needed to stop delivering a
+// given kind of notifications to a user. It is encoded in the unsubscribe
+// tokens included in the notifications emails.
+type UnsubscribeInput struct {
+	UserID       string    `json:"user_id"`
+	EventKind    EventKind `json:"event_kind"`
+	PackageID    string    `json:"package_id,omitempty"`
+	RepositoryID string    `json:"repository_id,omitempty"`
 }
 
 // SubscriptionManager describes the methods a SubscriptionManager
 // implementation must provide.
 type SubscriptionManager interface {
 	Add(ctx context.Context, s *Subscription) error
+	AddBulk(ctx context.Context, input *BulkSubscriptionInput) error
 	AddOptOut(ctx context.Context, o *OptOut) error
+	AddScoped(ctx context.Context, s *ScopedSubscription) error
 	Delete(ctx context.Context, s *Subscription) error
+	DeleteBulk(ctx context.Context, input *BulkSubscriptionInput) error
 	DeleteOptOut(ctx context.Context, optOutID string) error
+	DeleteScoped(ctx context.Context, s *ScopedSubscription) error
+	ExportJSON(ctx context.Context) ([]byte, error)
 	GetByPackageJSON(ctx context.Context, packageID string) ([]byte, error)
 	GetByUserJSON(ctx context.Context) ([]byte, error)
 	GetOptOutListJSON(ctx context.Context) ([]byte, error)
+	GetScopedByUserJSON(ctx context.Context) ([]byte, error)
 	GetSubscriptors(ctx context.Context, e *Event) ([]*User, error)
+	Import(ctx context.Context, entries []*SubscriptionsExportEntry) error
+	SearchJSON(ctx context.Context, input *SearchSubscriptionsInput) ([]byte, error)
+	Unsubscribe(ctx context.Context, input *UnsubscribeInput) error
 }
@@ -0,0 +1,23 @@
+package hub
+
+import "context"
+
+// Team represents a group of members of an organization. Teams can be granted
+// permissions on the repositories of the organization they belong to.
+type Team struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description"`
+}
+
+// TeamManager describes the methods a TeamManager implementation must provide.
+type TeamManager interface {
+	Add(ctx context.Context, orgName string, t *Team) error
+	AddMember(ctx context.Context, orgName, teamName, userAlias string) error
+	Delete(ctx context.Context, orgName, teamName string) error
+	DeleteMember(ctx context.Context, orgName, teamName, userAlias string) error
+	DeleteRepositoryPermissions(ctx context.Context, orgName, teamName, repoName string) error
+	GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error)
+	Update(ctx context.Context, orgName, teamName string, t *Team) error
+	UpdateRepositoryPermissions(ctx context.Context, orgName, teamName, repoName string, actions []Action) error
+}
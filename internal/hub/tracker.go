@@ -9,6 +9,29 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// TrackerDryRunner describes the methods a TrackerDryRunner implementation
+// must provide.
+type TrackerDryRunner interface {
+	DryRun(ctx context.Context, r *Repository) (*TrackerDryRunResult, error)
+}
+
+// TrackerDryRunPackage represents a package that would be registered if the
+// repository processed in a tracker dry-run was added to the hub.
+type TrackerDryRunPackage struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TrackerDryRunResult represents the result of processing a repository with
+// the tracker in dry-run mode, which includes the packages available in the
+// repository and the errors found while processing it.
+type TrackerDryRunResult struct {
+	Packages []*TrackerDryRunPackage `json:"packages"`
+	Errors   []*RepositoryError      `json:"errors"`
+}
+
 // TrackerServices represents a set of services that must be provided to a
 // Tracker instance so that it can perform its tasks.
 type TrackerServices struct {
@@ -21,6 +44,7 @@ type TrackerServices struct {
 	Ec                 ErrorsCollector
 	Hc                 HTTPClient
 	Is                 img.Store
+	Sv                 OCISignatureVerifier
 	GithubRL           *rate.Limiter
 	SetupTrackerSource TrackerSourceLoader
 }
@@ -58,6 +82,7 @@ type TrackerSourceServices struct {
 	Ec       ErrorsCollector
 	Hc       HTTPClient
 	Is       img.Store
+	Sv       OCISignatureVerifier
 	Logger   zerolog.Logger
 	GithubRL *rate.Limiter
 }
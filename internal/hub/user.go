@@ -7,8 +7,9 @@ import (
 
 // CheckAPIKeyOutput represents the output returned by the CheckApiKey method.
 type CheckAPIKeyOutput struct {
-	Valid  bool   `json:"valid"`
-	UserID string `json:"user_id"`
+	Valid  bool          `json:"valid"`
+	UserID string        `json:"user_id"`
+	Scopes []APIKeyScope `json:"scopes"`
 }
 
 // CheckCredentialsOutput represents the output returned by the
@@ -16,6 +17,17 @@ type CheckAPIKeyOutput struct {
 type CheckCredentialsOutput struct {
 	Valid  bool   `json:"valid"`
 	UserID string `json:"user_id"`
+
+	// Locked indicates that the account has been temporarily locked due to
+	// too many failed login attempts. RetryAfter represents the time to wait
+	// until the next login attempt is allowed, and it's only set when the
+	// login attempt has been rejected without checking the credentials.
+	Locked     bool          `json:"locked"`
+	RetryAfter time.Duration `json:"retry_after"`
+
+	// PasswordExpired indicates that the credentials are valid, but the
+	// password has expired and must be reset before logging in again.
+	PasswordExpired bool `json:"password_expired"`
 }
 
 // CheckSessionOutput represents the output returned by the CheckSession method.
@@ -24,6 +36,37 @@ type CheckSessionOutput struct {
 	UserID string `json:"user_id"`
 }
 
+// NotificationsPreferences represents the preferences of a user about how they
+// would like to be notified of the events they are subscribed to.
+type NotificationsPreferences struct {
+	EmailDisabledEventKinds []EventKind `json:"email_disabled_event_kinds,omitempty"`
+	QuietHours              *QuietHours `json:"quiet_hours,omitempty"`
+}
+
+// QuietHours represents a daily time window during which a user does not want
+// to receive email notifications. Start and end times use the HH:MM format and
+// are evaluated in the time zone provided. The window may span midnight.
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"time_zone"`
+}
+
+// EmailEnabled checks if the user would like to receive email notifications
+// for the provided event kind. Events whose email notifications have been
+// disabled are only available from the Hub itself.
+func (p *NotificationsPreferences) EmailEnabled(kind EventKind) bool {
+	if p == nil {
+		return true
+	}
+	for _, k := range p.EmailDisabledEventKinds {
+		if k == kind {
+			return false
+		}
+	}
+	return true
+}
+
 // Session represents some information about a user session.
 type Session struct {
 	SessionID string `json:"session_id"`
@@ -32,6 +75,15 @@ type Session struct {
 	UserAgent string `json:"user_agent"`
 }
 
+// TokenBinding represents the credential used to obtain a token, which can
+// be either an API key or a session (identified by the hash of its id).
+// Tokens are only accepted while the credential they are bound to remains
+// valid, so they can be revoked by deleting it.
+type TokenBinding struct {
+	APIKeyID      string `json:"akid,omitempty"`
+	SessionIDHash string `json:"sidh,omitempty"`
+}
+
 // User represents a Hub user.
 type User struct {
 	UserID         string `json:"user_id"`
@@ -42,6 +94,9 @@ type User struct {
 	EmailVerified  bool   `json:"email_verified"`
 	Password       string `json:"password"`
 	ProfileImageID string `json:"profile_image_id"`
+	Locale         string `json:"locale"`
+
+	NotificationsPreferences *NotificationsPreferences `json:"notifications_preferences,omitempty"`
 }
 
 type userIDKey struct{}
@@ -51,18 +106,24 @@ var UserIDKey = userIDKey{}
 
 // UserManager describes the methods a UserManager implementation must provide.
 type UserManager interface {
+	ChangeEmail(ctx context.Context, code string, invalidateSessions bool) error
 	CheckAPIKey(ctx context.Context, apiKeyID, apiKeySecret string) (*CheckAPIKeyOutput, error)
 	CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error)
-	CheckCredentials(ctx context.Context, email, password string) (*CheckCredentialsOutput, error)
+	CheckCredentials(ctx context.Context, email, password, ip string) (*CheckCredentialsOutput, error)
 	CheckSession(ctx context.Context, sessionID []byte, duration time.Duration) (*CheckSessionOutput, error)
+	CheckTokenBinding(ctx context.Context, userID string, b *TokenBinding, sessionDuration time.Duration) (bool, error)
 	DeleteSession(ctx context.Context, sessionID []byte) error
+	DeleteUser(ctx context.Context, code, baseURL string) error
 	GetProfile(ctx context.Context) (*User, error)
 	GetProfileJSON(ctx context.Context) ([]byte, error)
 	GetUserID(ctx context.Context, email string) (string, error)
+	RegisterDeleteUserCode(ctx context.Context, baseURL string) error
+	RegisterEmailChangeCode(ctx context.Context, newEmail string) error
 	RegisterPasswordResetCode(ctx context.Context, userEmail, baseURL string) error
-	RegisterSession(ctx context.Context, session *Session) ([]byte, error)
+	RegisterSession(ctx context.Context, session *Session, baseURL string) ([]byte, error)
 	RegisterUser(ctx context.Context, user *User, baseURL string) error
 	ResetPassword(ctx context.Context, code, newPassword, baseURL string) error
+	RevokeSession(ctx context.Context, code string) error
 	UpdatePassword(ctx context.Context, old, new string) error
 	UpdateProfile(ctx context.Context, user *User) error
 	VerifyEmail(ctx context.Context, code string) (bool, error)
@@ -2,29 +2,82 @@ package hub
 
 import "context"
 
+const (
+	// WebhookFormatSlack represents the format used to deliver notifications
+	// to Slack incoming webhooks, rendering them as Block Kit messages.
+	WebhookFormatSlack = "slack"
+
+	// WebhookFormatTeams represents the format used to deliver notifications
+	// to Microsoft Teams incoming webhooks, rendering them as message cards.
+	WebhookFormatTeams = "teams"
+
+	// WebhookFormatDiscord represents the format used to deliver
+	// notifications to Discord webhooks, rendering them as embeds.
+	WebhookFormatDiscord = "discord"
+)
+
 // Webhook represents the configuration of a webhook where notifications will
 // be posted to.
 type Webhook struct {
-	WebhookID   string      `json:"webhook_id"`
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	URL         string      `json:"url"`
-	Secret      string      `json:"secret"`
-	ContentType string      `json:"content_type"`
-	Template    string      `json:"template"`
-	Active      bool        `json:"active"`
-	EventKinds  []EventKind `json:"event_kinds"`
-	Packages    []*Package  `json:"packages"`
+	WebhookID   string           `json:"webhook_id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	URL         string           `json:"url"`
+	Secret      string           `json:"secret"`
+	ContentType string           `json:"content_type"`
+	Template    string           `json:"template"`
+	Format      string           `json:"format"`
+	RateLimit   int              `json:"rate_limit"`
+	Headers     []*WebhookHeader `json:"headers"`
+	Active      bool             `json:"active"`
+	SuspendedAt int64            `json:"suspended_at,omitempty"`
+	Batch       bool             `json:"batch"`
+	AllPackages bool             `json:"all_packages"`
+	EventKinds  []EventKind      `json:"event_kinds"`
+	Packages    []*Package       `json:"packages"`
+}
+
+// WebhookHeader represents a custom http header that will be set when
+// delivering notifications to a webhook. The values of secret headers are not
+// included when the webhook is returned by the API.
+type WebhookHeader struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret"`
+}
+
+// WebhookDelivery represents an attempt to deliver a notification to a
+// webhook.
+type WebhookDelivery struct {
+	WebhookDeliveryID string `json:"webhook_delivery_id"`
+	WebhookID         string `json:"webhook_id"`
+	NotificationID    string `json:"notification_id"`
+	DeliveryID        string `json:"delivery_id"`
+	Payload           string `json:"payload"`
+	ContentType       string `json:"content_type"`
+	Latency           int64  `json:"latency"`
+	StatusCode        int    `json:"status_code"`
+	ResponseBody      string `json:"response_body"`
+	Error             string `json:"error"`
+	CreatedAt         int64  `json:"created_at"`
 }
 
 // WebhookManager describes the methods a WebhookManager implementation must
 // provide.
 type WebhookManager interface {
 	Add(ctx context.Context, orgName string, wh *Webhook) error
+	AddDelivery(ctx context.Context, d *WebhookDelivery) error
 	Delete(ctx context.Context, webhookID string) error
+	Enable(ctx context.Context, webhookID string) error
+	GetDeliveriesJSON(ctx context.Context, webhookID string) ([]byte, error)
+	GetDeliveryJSON(ctx context.Context, webhookID, deliveryID string) ([]byte, error)
+	GetFailedDeliveriesJSON(ctx context.Context, webhookID string) ([]byte, error)
+	GetFailedDeliveryJSON(ctx context.Context, webhookID, notificationID string) ([]byte, error)
+	GetHeaders(ctx context.Context, webhookID string) ([]*WebhookHeader, error)
 	GetJSON(ctx context.Context, webhookID string) ([]byte, error)
 	GetOwnedByOrgJSON(ctx context.Context, orgName string) ([]byte, error)
 	GetOwnedByUserJSON(ctx context.Context) ([]byte, error)
 	GetSubscribedTo(ctx context.Context, e *Event) ([]*Webhook, error)
+	RequeueFailedDelivery(ctx context.Context, webhookID, notificationID string) error
 	Update(ctx context.Context, wh *Webhook) error
 }
@@ -0,0 +1,134 @@
+package img
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	getReferencedImagesDBQ = `select get_referenced_images()`
+
+	defaultGCInterval    = 24 * time.Hour
+	defaultGCGracePeriod = 24 * time.Hour
+)
+
+var (
+	gcDeletedImages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "images_gc_deleted_total",
+		Help: "Number of orphaned images deleted by the images garbage collector.",
+	}, []string{"dry_run"})
+	gcReclaimedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "images_gc_reclaimed_bytes_total",
+		Help: "Space reclaimed by the images garbage collector, in bytes.",
+	}, []string{"dry_run"})
+)
+
+func init() {
+	prometheus.MustRegister(gcDeletedImages, gcReclaimedBytes)
+}
+
+// DB defines the methods the database handler used by the images garbage
+// collector must provide.
+type DB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// GC is in charge of deleting the images stored that are no longer referenced
+// by any user, organization or package version. Only images stored before the
+// configured grace period are considered, so that images that have just been
+// uploaded but haven't been referenced yet aren't deleted.
+type GC struct {
+	db          DB
+	store       GCStore
+	interval    time.Duration
+	gracePeriod time.Duration
+	dryRun      bool
+}
+
+// NewGC creates a new GC instance.
+func NewGC(cfg *viper.Viper, db DB, store GCStore) *GC {
+	cfg.SetDefault("images.gc.interval", defaultGCInterval)
+	cfg.SetDefault("images.gc.gracePeriod", defaultGCGracePeriod)
+	return &GC{
+		db:          db,
+		store:       store,
+		interval:    cfg.GetDuration("images.gc.interval"),
+		gracePeriod: cfg.GetDuration("images.gc.gracePeriod"),
+		dryRun:      cfg.GetBool("images.gc.dryRun"),
+	}
+}
+
+// Run runs the garbage collector periodically until it's asked to stop via the
+// context provided.
+func (gc *GC) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(gc.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := gc.Collect(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("error collecting orphaned images")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect deletes the orphaned images found in the store, returning the
+// images collected. When running in dry-run mode, images are only reported.
+func (gc *GC) Collect(ctx context.Context) ([]*StoredImage, error) {
+	// Get images currently referenced
+	var dataJSON []byte
+	if err := gc.db.QueryRow(ctx, getReferencedImagesDBQ).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	var referencedImages []string
+	if err := json.Unmarshal(dataJSON, &referencedImages); err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]struct{}, len(referencedImages))
+	for _, imageID := range referencedImages {
+		referenced[imageID] = struct{}{}
+	}
+
+	// Delete images stored before the grace period that are not referenced
+	images, err := gc.store.GetImagesCreatedBefore(ctx, time.Now().Add(-gc.gracePeriod))
+	if err != nil {
+		return nil, err
+	}
+	dryRun := strconv.FormatBool(gc.dryRun)
+	var collected []*StoredImage
+	for _, i := range images {
+		if _, ok := referenced[i.ImageID]; ok {
+			continue
+		}
+		if ctx.Err() != nil {
+			return collected, ctx.Err()
+		}
+		logger := log.With().Str("imageID", i.ImageID).Int64("size", i.Size).Bool("dryRun", gc.dryRun).Logger()
+		if !gc.dryRun {
+			if err := gc.store.DeleteImage(ctx, i.ImageID); err != nil {
+				logger.Error().Err(err).Msg("error deleting orphaned image")
+				continue
+			}
+		}
+		logger.Info().Msg("orphaned image collected")
+		gcDeletedImages.WithLabelValues(dryRun).Inc()
+		gcReclaimedBytes.WithLabelValues(dryRun).Add(float64(i.Size))
+		collected = append(collected, i)
+	}
+
+	return collected, nil
+}
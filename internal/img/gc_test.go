@@ -0,0 +1,116 @@
+package img
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default configuration", func(t *testing.T) {
+		t.Parallel()
+		gc := NewGC(viper.New(), &tests.DBMock{}, &GCStoreMock{})
+		assert.Equal(t, defaultGCInterval, gc.interval)
+		assert.Equal(t, defaultGCGracePeriod, gc.gracePeriod)
+		assert.False(t, gc.dryRun)
+	})
+
+	t.Run("custom configuration", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("images.gc.interval", "1h")
+		cfg.Set("images.gc.gracePeriod", "2h")
+		cfg.Set("images.gc.dryRun", true)
+		gc := NewGC(cfg, &tests.DBMock{}, &GCStoreMock{})
+		assert.Equal(t, 1*time.Hour, gc.interval)
+		assert.Equal(t, 2*time.Hour, gc.gracePeriod)
+		assert.True(t, gc.dryRun)
+	})
+}
+
+func TestGCCollect(t *testing.T) {
+	ctx := context.Background()
+	referencedImagesJSON := []byte(`["image1", "image2"]`)
+	storedImages := []*StoredImage{
+		{ImageID: "image1", Size: 10},
+		{ImageID: "image2", Size: 20},
+		{ImageID: "image3", Size: 30},
+		{ImageID: "image4", Size: 40},
+	}
+
+	t.Run("error getting referenced images", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getReferencedImagesDBQ).Return(nil, tests.ErrFakeDB)
+		s := &GCStoreMock{}
+		gc := NewGC(viper.New(), db, s)
+
+		collected, err := gc.Collect(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, collected)
+		db.AssertExpectations(t)
+		s.AssertExpectations(t)
+	})
+
+	t.Run("error getting stored images", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getReferencedImagesDBQ).Return(referencedImagesJSON, nil)
+		s := &GCStoreMock{}
+		s.On("GetImagesCreatedBefore", ctx, mock.Anything).Return(nil, tests.ErrFake)
+		gc := NewGC(viper.New(), db, s)
+
+		collected, err := gc.Collect(ctx)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, collected)
+		db.AssertExpectations(t)
+		s.AssertExpectations(t)
+	})
+
+	t.Run("orphaned images deleted", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getReferencedImagesDBQ).Return(referencedImagesJSON, nil)
+		s := &GCStoreMock{}
+		s.On("GetImagesCreatedBefore", ctx, mock.MatchedBy(func(ts time.Time) bool {
+			return ts.Before(time.Now().Add(-defaultGCGracePeriod).Add(time.Minute))
+		})).Return(storedImages, nil)
+		s.On("DeleteImage", ctx, "image3").Return(tests.ErrFake)
+		s.On("DeleteImage", ctx, "image4").Return(nil)
+		gc := NewGC(viper.New(), db, s)
+
+		collected, err := gc.Collect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*StoredImage{{ImageID: "image4", Size: 40}}, collected)
+		db.AssertExpectations(t)
+		s.AssertExpectations(t)
+	})
+
+	t.Run("orphaned images reported in dry-run mode", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getReferencedImagesDBQ).Return(referencedImagesJSON, nil)
+		s := &GCStoreMock{}
+		s.On("GetImagesCreatedBefore", ctx, mock.Anything).Return(storedImages, nil)
+		cfg := viper.New()
+		cfg.Set("images.gc.dryRun", true)
+		gc := NewGC(cfg, db, s)
+
+		collected, err := gc.Collect(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*StoredImage{
+			{ImageID: "image3", Size: 30},
+			{ImageID: "image4", Size: 40},
+		}, collected)
+		db.AssertExpectations(t)
+		s.AssertExpectations(t)
+	})
+}
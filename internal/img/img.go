@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/disintegration/imaging"
+	svg "github.com/h2non/go-is-svg"
 	"github.com/vincent-petithory/dataurl"
 	"golang.org/x/time/rate"
 )
@@ -32,6 +35,59 @@ type Store interface {
 	SaveImage(ctx context.Context, data []byte) (imageID string, err error)
 }
 
+// GCStore describes the methods an image.Store implementation must provide to
+// support the garbage collection of images no longer referenced.
+type GCStore interface {
+	Store
+
+	// DeleteImage deletes all versions of the image identified by the ID
+	// provided.
+	DeleteImage(ctx context.Context, imageID string) error
+
+	// GetImagesCreatedBefore returns the images stored before the time
+	// provided.
+	GetImagesCreatedBefore(ctx context.Context, t time.Time) ([]*StoredImage, error)
+}
+
+// StoredImage represents an image available in the store.
+type StoredImage struct {
+	ImageID string `json:"image_id"`
+	Size    int64  `json:"size"`
+}
+
+// StreamStore describes the methods an image.Store implementation able to
+// stream images, instead of loading them fully in memory, must provide.
+type StreamStore interface {
+	Store
+
+	// GetImageReader returns a reader to the image identified by the ID and
+	// version provided, as well as its content type.
+	GetImageReader(ctx context.Context, imageID, version string) (r io.ReadCloser, contentType string, err error)
+}
+
+// DetectFormat detects the format of the image provided by inspecting its
+// content. An empty string is returned when the data provided doesn't look
+// like an image in any of the formats known.
+func DetectFormat(data []byte) string {
+	if svg.Is(data) {
+		return "svg"
+	}
+	switch http.DetectContentType(data) {
+	case "image/bmp":
+		return "bmp"
+	case "image/gif":
+		return "gif"
+	case "image/jpeg":
+		return "jpeg"
+	case "image/png":
+		return "png"
+	case "image/webp":
+		return "webp"
+	default:
+		return ""
+	}
+}
+
 // Version represents a specific size version of an image.
 type Version struct {
 	Version string
@@ -119,3 +175,29 @@ func GenerateVersions(data []byte) ([]*Version, error) {
 
 	return imgVersions, nil
 }
+
+// Resize resizes the image provided to fit within the bounds specified,
+// preserving its aspect ratio. A zero width or height means that dimension is
+// not constrained. Images are never upscaled.
+func Resize(data []byte, width, height int) ([]byte, error) {
+	// Decode original image data
+	img, err := imaging.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// Resize image to fit within the requested bounds
+	bounds := img.Bounds()
+	if width <= 0 {
+		width = bounds.Dx()
+	}
+	if height <= 0 {
+		height = bounds.Dy()
+	}
+	resizedImg := imaging.Fit(img, width, height, imaging.Lanczos)
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, resizedImg, imaging.PNG); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
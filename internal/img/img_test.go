@@ -1,6 +1,7 @@
 package img
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"flag"
@@ -11,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/disintegration/imaging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,6 +51,61 @@ func TestGenerateVersions(t *testing.T) {
 	}
 }
 
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	validImgData, err := ioutil.ReadFile("testdata/valid.png")
+	require.NoError(t, err)
+	testCases := []struct {
+		data           []byte
+		expectedFormat string
+	}{
+		{validImgData, "png"},
+		{[]byte("\xff\xd8\xff\xe0"), "jpeg"},
+		{[]byte("GIF89a"), "gif"},
+		{[]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), "svg"},
+		{[]byte("not an image"), ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedFormat, DetectFormat(tc.data))
+	}
+}
+
+func TestResize(t *testing.T) {
+	t.Parallel()
+
+	// Read sample images
+	validImgData, err := ioutil.ReadFile("testdata/valid.png")
+	require.NoError(t, err)
+	invalidImgData, err := ioutil.ReadFile("testdata/invalid.png")
+	require.NoError(t, err)
+
+	// Check the data provided must be a valid image
+	_, err = Resize(invalidImgData, 50, 50)
+	require.Error(t, err)
+
+	// Check image is resized to fit within the bounds provided
+	testCases := []struct {
+		width          int
+		height         int
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{50, 50, 50, 50},
+		{50, 0, 50, 50},
+		{0, 40, 40, 40},
+		{10000, 10000, 500, 500},
+	}
+	for _, tc := range testCases {
+		data, err := Resize(validImgData, tc.width, tc.height)
+		require.NoError(t, err)
+		resizedImg, err := imaging.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, tc.expectedWidth, resizedImg.Bounds().Dx())
+		assert.Equal(t, tc.expectedHeight, resizedImg.Bounds().Dy())
+	}
+}
+
 func TestDownload(t *testing.T) {
 	ctx := context.Background()
 	imageURL := "https://raw.githubusercontent.com/image1.png"
@@ -2,10 +2,30 @@ package img
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
 
+// GCStoreMock is a mock implementation of the img.GCStore interface.
+type GCStoreMock struct {
+	StoreMock
+}
+
+// DeleteImage implements the img.GCStore interface.
+func (m *GCStoreMock) DeleteImage(ctx context.Context, imageID string) error {
+	args := m.Called(ctx, imageID)
+	return args.Error(0)
+}
+
+// GetImagesCreatedBefore implements the img.GCStore interface.
+func (m *GCStoreMock) GetImagesCreatedBefore(ctx context.Context, t time.Time) ([]*StoredImage, error) {
+	args := m.Called(ctx, t)
+	images, _ := args.Get(0).([]*StoredImage)
+	return images, args.Error(1)
+}
+
 // StoreMock is a mock implementation of the img.Store interface.
 type StoreMock struct {
 	mock.Mock
@@ -29,3 +49,34 @@ func (m *StoreMock) SaveImage(ctx context.Context, data []byte) (string, error)
 	args := m.Called(ctx, data)
 	return args.String(0), args.Error(1)
 }
+
+// StreamStoreMock is a mock implementation of the img.StreamStore interface.
+type StreamStoreMock struct {
+	StoreMock
+}
+
+// GetImageReader implements the img.StreamStore interface.
+func (m *StreamStoreMock) GetImageReader(ctx context.Context, imageID, version string) (io.ReadCloser, string, error) {
+	args := m.Called(ctx, imageID, version)
+	r, _ := args.Get(0).(io.ReadCloser)
+	return r, args.String(1), args.Error(2)
+}
+
+// TranscoderMock is a mock implementation of the img.Transcoder interface.
+type TranscoderMock struct {
+	mock.Mock
+}
+
+// Formats implements the img.Transcoder interface.
+func (m *TranscoderMock) Formats() []Format {
+	args := m.Called()
+	formats, _ := args.Get(0).([]Format)
+	return formats
+}
+
+// Transcode implements the img.Transcoder interface.
+func (m *TranscoderMock) Transcode(ctx context.Context, data []byte, f Format) ([]byte, error) {
+	args := m.Called(ctx, data, f)
+	out, _ := args.Get(0).([]byte)
+	return out, args.Error(1)
+}
@@ -0,0 +1,136 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+)
+
+// AzureBucket is a Bucket implementation backed by an Azure Blob Storage
+// container.
+type AzureBucket struct {
+	container azblob.ContainerURL
+}
+
+// NewAzureBucket creates a new AzureBucket instance using the configuration
+// provided.
+func NewAzureBucket(cfg *viper.Viper) (*AzureBucket, error) {
+	accountName := cfg.GetString("images.azure.accountName")
+	accountKey := cfg.GetString("images.azure.accountKey")
+	container := cfg.GetString("images.azure.container")
+	if accountName == "" || container == "" {
+		return nil, errors.New("azure account name or container not provided")
+	}
+	cred, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net/%s", accountName, container))
+	if err != nil {
+		return nil, err
+	}
+	p := azblob.NewPipeline(cred, azblob.PipelineOptions{})
+	return &AzureBucket{
+		container: azblob.NewContainerURL(*u, p),
+	}, nil
+}
+
+// Delete implements the Bucket interface.
+func (b *AzureBucket) Delete(ctx context.Context, key string) error {
+	blob := b.container.NewBlobURL(key)
+	_, err := blob.Delete(ctx, azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{})
+	if err != nil && !isAzureNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Exists implements the Bucket interface.
+func (b *AzureBucket) Exists(ctx context.Context, key string) (bool, error) {
+	blob := b.container.NewBlobURL(key)
+	_, err := blob.GetProperties(ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		if isAzureNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Get implements the Bucket interface.
+func (b *AzureBucket) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	blob := b.container.NewBlobURL(key)
+	resp, err := blob.Download(
+		ctx,
+		0,
+		azblob.CountToEnd,
+		azblob.BlobAccessConditions{},
+		false,
+		azblob.ClientProvidedKeyOptions{},
+	)
+	if err != nil {
+		if isAzureNotFound(err) {
+			return nil, "", hub.ErrNotFound
+		}
+		return nil, "", err
+	}
+	return resp.Body(azblob.RetryReaderOptions{}), resp.ContentType(), nil
+}
+
+// List implements the Bucket interface.
+func (b *AzureBucket) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	var objects []*ObjectInfo
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		resp, err := b.container.ListBlobsFlatSegment(ctx, marker, azblob.ListBlobsSegmentOptions{
+			Prefix: prefix,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range resp.Segment.BlobItems {
+			var size int64
+			if item.Properties.ContentLength != nil {
+				size = *item.Properties.ContentLength
+			}
+			objects = append(objects, &ObjectInfo{
+				Key:          item.Name,
+				Size:         size,
+				LastModified: item.Properties.LastModified,
+			})
+		}
+		marker = resp.NextMarker
+	}
+	return objects, nil
+}
+
+// Put implements the Bucket interface.
+func (b *AzureBucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	blob := b.container.NewBlockBlobURL(key)
+	_, err := azblob.UploadBufferToBlockBlob(ctx, data, blob, azblob.UploadToBlockBlobOptions{
+		BlobHTTPHeaders: azblob.BlobHTTPHeaders{ContentType: contentType},
+	})
+	return err
+}
+
+// isAzureNotFound checks if the error provided was caused by the blob not
+// being found.
+func isAzureNotFound(err error) bool {
+	var stgErr azblob.StorageError
+	if errors.As(err, &stgErr) {
+		if stgErr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+			return true
+		}
+		if resp := stgErr.Response(); resp != nil && resp.StatusCode == http.StatusNotFound {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// fsTmpFilePrefix represents the prefix used by the temporary files created
+// while objects are being written.
+const fsTmpFilePrefix = ".tmp-"
+
+// FSBucket is a Bucket implementation backed by a local directory, like a
+// persistent volume mounted in the container.
+type FSBucket struct {
+	dir string
+}
+
+// NewFSBucket creates a new FSBucket instance that stores objects in the
+// directory provided, creating it if it doesn't exist.
+func NewFSBucket(dir string) (*FSBucket, error) {
+	if dir == "" {
+		return nil, errors.New("directory not provided")
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FSBucket{dir: dir}, nil
+}
+
+// Delete implements the Bucket interface.
+func (b *FSBucket) Delete(ctx context.Context, key string) error {
+	p, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Exists implements the Bucket interface.
+func (b *FSBucket) Exists(ctx context.Context, key string) (bool, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Get implements the Bucket interface. As the content type of the objects is
+// not stored, it's detected from their content.
+func (b *FSBucket) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", hub.ErrNotFound
+		}
+		return nil, "", err
+	}
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		f.Close()
+		return nil, "", err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, "", err
+	}
+	return f, http.DetectContentType(buf[:n]), nil
+}
+
+// List implements the Bucket interface.
+func (b *FSBucket) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	var objects []*ObjectInfo
+	err := filepath.Walk(b.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), fsTmpFilePrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(b.dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		objects = append(objects, &ObjectInfo{
+			Key:          key,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// Put implements the Bucket interface. Objects are written to a temporary
+// file first, so that partially written objects are never visible.
+func (b *FSBucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	p, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(p), fsTmpFilePrefix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), p)
+}
+
+// path returns the path of the file used to store the object identified by
+// the key provided, making sure it's located within the bucket directory.
+func (b *FSBucket) path(key string) (string, error) {
+	p := filepath.Join(b.dir, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, b.dir+string(os.PathSeparator)) {
+		return "", errors.New("invalid object key")
+	}
+	return p, nil
+}
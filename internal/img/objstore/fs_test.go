@@ -0,0 +1,84 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSBucket(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("directory not provided", func(t *testing.T) {
+		t.Parallel()
+		b, err := NewFSBucket("")
+		assert.Error(t, err)
+		assert.Nil(t, b)
+	})
+
+	t.Run("invalid object key", func(t *testing.T) {
+		t.Parallel()
+		b, err := NewFSBucket(t.TempDir())
+		require.NoError(t, err)
+		for _, key := range []string{"", "../key", "charts/../../key"} {
+			err := b.Put(ctx, key, []byte("data"), "text/plain")
+			assert.EqualError(t, err, "invalid object key", key)
+		}
+	})
+
+	t.Run("object not found", func(t *testing.T) {
+		t.Parallel()
+		b, err := NewFSBucket(t.TempDir())
+		require.NoError(t, err)
+		exists, err := b.Exists(ctx, "key")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		_, _, err = b.Get(ctx, "key")
+		assert.True(t, errors.Is(err, hub.ErrNotFound))
+		assert.NoError(t, b.Delete(ctx, "key"))
+	})
+
+	t.Run("put, get, list and delete objects", func(t *testing.T) {
+		t.Parallel()
+		b, err := NewFSBucket(t.TempDir())
+		require.NoError(t, err)
+
+		// Put
+		require.NoError(t, b.Put(ctx, "charts/pkg1/1.0.0.tgz", []byte("data1"), "application/gzip"))
+		require.NoError(t, b.Put(ctx, "charts/pkg1/1.1.0.tgz", []byte("data11"), "application/gzip"))
+		require.NoError(t, b.Put(ctx, "images/image1/1x", []byte("image1"), "image/png"))
+		exists, err := b.Exists(ctx, "charts/pkg1/1.0.0.tgz")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		// Get
+		r, contentType, err := b.Get(ctx, "charts/pkg1/1.0.0.tgz")
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data1"), data)
+		assert.Equal(t, "text/plain; charset=utf-8", contentType)
+
+		// List
+		objects, err := b.List(ctx, "charts/")
+		require.NoError(t, err)
+		require.Len(t, objects, 2)
+		assert.Equal(t, "charts/pkg1/1.0.0.tgz", objects[0].Key)
+		assert.Equal(t, int64(5), objects[0].Size)
+		assert.Equal(t, "charts/pkg1/1.1.0.tgz", objects[1].Key)
+		assert.Equal(t, int64(6), objects[1].Size)
+
+		// Delete
+		require.NoError(t, b.Delete(ctx, "charts/pkg1/1.0.0.tgz"))
+		objects, err = b.List(ctx, "charts/")
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+		assert.Equal(t, "charts/pkg1/1.1.0.tgz", objects[0].Key)
+	})
+}
@@ -0,0 +1,104 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// GCSBucket is a Bucket implementation backed by Google Cloud Storage.
+type GCSBucket struct {
+	bucket *storage.BucketHandle
+}
+
+// NewGCSBucket creates a new GCSBucket instance using the configuration
+// provided. When no credentials file is provided, the application default
+// credentials will be used.
+func NewGCSBucket(ctx context.Context, cfg *viper.Viper) (*GCSBucket, error) {
+	bucket := cfg.GetString("images.gcs.bucket")
+	if bucket == "" {
+		return nil, errors.New("gcs bucket not provided")
+	}
+	var opts []option.ClientOption
+	if credentialsFile := cfg.GetString("images.gcs.credentialsFile"); credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GCSBucket{
+		bucket: client.Bucket(bucket),
+	}, nil
+}
+
+// Delete implements the Bucket interface.
+func (b *GCSBucket) Delete(ctx context.Context, key string) error {
+	err := b.bucket.Object(key).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Exists implements the Bucket interface.
+func (b *GCSBucket) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := b.bucket.Object(key).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Get implements the Bucket interface.
+func (b *GCSBucket) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	r, err := b.bucket.Object(key).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, "", hub.ErrNotFound
+		}
+		return nil, "", err
+	}
+	return r, r.Attrs.ContentType, nil
+}
+
+// List implements the Bucket interface.
+func (b *GCSBucket) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	var objects []*ObjectInfo
+	it := b.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, &ObjectInfo{
+			Key:          attrs.Name,
+			Size:         attrs.Size,
+			LastModified: attrs.Updated,
+		})
+	}
+	return objects, nil
+}
+
+// Put implements the Bucket interface.
+func (b *GCSBucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	w := b.bucket.Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,109 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	svg "github.com/h2non/go-is-svg"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// Database queries
+	getNextImageDBQ = `
+	select image_id, original_hash
+	from image
+	where image_id > $1::uuid
+	order by image_id asc
+	limit 1
+	`
+	getImageVersionsDBQ = `
+	select coalesce(array_agg(version order by version), '{}')
+	from image_version
+	where image_id = $1::uuid
+	`
+	getImageVersionDataDBQ = `
+	select data
+	from image_version
+	where image_id = $1::uuid
+	and version = $2
+	`
+
+	// firstImageID represents the lowest image id possible, used as the
+	// starting point when iterating through the images in the database.
+	firstImageID = "00000000-0000-0000-0000-000000000000"
+)
+
+// DB defines the methods the database handler must provide.
+type DB interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Migrate copies the images stored in the database to the bucket provided,
+// preserving their ids so that existing references to them remain valid.
+// Images already available in the bucket are skipped, so it's safe to run it
+// multiple times. It returns the number of images migrated.
+func Migrate(ctx context.Context, db DB, bucket Bucket) (int, error) {
+	var migrated int
+	lastImageID := firstImageID
+	for {
+		select {
+		case <-ctx.Done():
+			return migrated, ctx.Err()
+		default:
+		}
+
+		// Get next image to migrate
+		var imageID string
+		var hash []byte
+		err := db.QueryRow(ctx, getNextImageDBQ, lastImageID).Scan(&imageID, &hash)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return migrated, nil
+			}
+			return migrated, err
+		}
+		lastImageID = imageID
+
+		// Skip it if it has already been migrated
+		exists, err := bucket.Exists(ctx, HashKey(hash))
+		if err != nil {
+			return migrated, err
+		}
+		if exists {
+			continue
+		}
+
+		// Copy all image versions to the bucket and register its hash
+		if err := migrateImage(ctx, db, bucket, imageID); err != nil {
+			return migrated, err
+		}
+		if err := RegisterHash(ctx, bucket, hash, imageID); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+}
+
+// migrateImage copies all versions of the image provided to the bucket.
+func migrateImage(ctx context.Context, db DB, bucket Bucket, imageID string) error {
+	var versions []string
+	if err := db.QueryRow(ctx, getImageVersionsDBQ, imageID).Scan(&versions); err != nil {
+		return err
+	}
+	for _, version := range versions {
+		var data []byte
+		if err := db.QueryRow(ctx, getImageVersionDataDBQ, imageID, version).Scan(&data); err != nil {
+			return err
+		}
+		contentType := http.DetectContentType(data)
+		if svg.Is(data) {
+			contentType = svgContentType
+		}
+		if err := bucket.Put(ctx, ImageKey(imageID, version), data, contentType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package objstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	hash := []byte("hash")
+
+	t.Run("image migrated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getNextImageDBQ, firstImageID).Return([]interface{}{imageID, hash}, nil)
+		db.On("QueryRow", ctx, getImageVersionsDBQ, imageID).Return([]string{"svg"}, nil)
+		db.On("QueryRow", ctx, getImageVersionDataDBQ, imageID, "svg").Return([]byte("<svg></svg>"), nil)
+		db.On("QueryRow", ctx, getNextImageDBQ, imageID).Return(nil, pgx.ErrNoRows)
+		bucket := &BucketMock{}
+		bucket.On("Exists", ctx, HashKey(hash)).Return(false, nil)
+		bucket.On("Put", ctx, ImageKey(imageID, "svg"), []byte("<svg></svg>"), svgContentType).Return(nil)
+		bucket.On("Put", ctx, HashKey(hash), []byte(imageID), "text/plain").Return(nil)
+
+		migrated, err := Migrate(ctx, db, bucket)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, migrated)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("image already migrated is skipped", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getNextImageDBQ, firstImageID).Return([]interface{}{imageID, hash}, nil)
+		db.On("QueryRow", ctx, getNextImageDBQ, imageID).Return(nil, pgx.ErrNoRows)
+		bucket := &BucketMock{}
+		bucket.On("Exists", ctx, HashKey(hash)).Return(true, nil)
+
+		migrated, err := Migrate(ctx, db, bucket)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, migrated)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getNextImageDBQ, firstImageID).Return(nil, tests.ErrFakeDB)
+		bucket := &BucketMock{}
+
+		migrated, err := Migrate(ctx, db, bucket)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Equal(t, 0, migrated)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("bucket error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getNextImageDBQ, firstImageID).Return([]interface{}{imageID, hash}, nil)
+		db.On("QueryRow", ctx, getImageVersionsDBQ, imageID).Return([]string{"svg"}, nil)
+		db.On("QueryRow", ctx, getImageVersionDataDBQ, imageID, "svg").Return([]byte("<svg></svg>"), nil)
+		bucket := &BucketMock{}
+		bucket.On("Exists", ctx, HashKey(hash)).Return(false, nil)
+		bucket.On("Put", ctx, ImageKey(imageID, "svg"), []byte("<svg></svg>"), svgContentType).Return(tests.ErrFake)
+
+		migrated, err := Migrate(ctx, db, bucket)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Equal(t, 0, migrated)
+		db.AssertExpectations(t)
+		bucket.AssertExpectations(t)
+	})
+}
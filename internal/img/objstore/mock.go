@@ -0,0 +1,45 @@
+package objstore
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// BucketMock is a mock implementation of the Bucket interface.
+type BucketMock struct {
+	mock.Mock
+}
+
+// Delete implements the Bucket interface.
+func (m *BucketMock) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// Exists implements the Bucket interface.
+func (m *BucketMock) Exists(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+// Get implements the Bucket interface.
+func (m *BucketMock) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	args := m.Called(ctx, key)
+	r, _ := args.Get(0).(io.ReadCloser)
+	return r, args.String(1), args.Error(2)
+}
+
+// List implements the Bucket interface.
+func (m *BucketMock) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	args := m.Called(ctx, prefix)
+	objects, _ := args.Get(0).([]*ObjectInfo)
+	return objects, args.Error(1)
+}
+
+// Put implements the Bucket interface.
+func (m *BucketMock) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	args := m.Called(ctx, key, data, contentType)
+	return args.Error(0)
+}
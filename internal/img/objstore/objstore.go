@@ -0,0 +1,330 @@
+package objstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	svg "github.com/h2non/go-is-svg"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/satori/uuid"
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Cache
+	cacheSize = 250
+
+	// svgVersion represents the version used to store svg images, as they
+	// don't need size specific versions.
+	svgVersion = "svg"
+
+	// svgContentType represents the content type of svg images.
+	svgContentType = "image/svg+xml"
+)
+
+// fallbackVersions represents the versions that will be tried, in order, when
+// the requested image version is not available.
+var fallbackVersions = []string{"1x", svgVersion}
+
+// versionRE is a regexp used to validate the image versions requested.
+var versionRE = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// Bucket defines the methods an object storage bucket implementation must
+// provide.
+type Bucket interface {
+	// Delete deletes the object identified by the key provided. Deleting an
+	// object that does not exist is not considered an error.
+	Delete(ctx context.Context, key string) error
+
+	// Exists checks if an object with the key provided exists in the bucket.
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Get returns a reader to the object identified by the key provided, as
+	// well as its content type. When the object does not exist, the error
+	// returned will be hub.ErrNotFound.
+	Get(ctx context.Context, key string) (r io.ReadCloser, contentType string, err error)
+
+	// List returns the objects in the bucket whose key starts with the prefix
+	// provided.
+	List(ctx context.Context, prefix string) ([]*ObjectInfo, error)
+
+	// Put stores the data provided in the bucket using the key provided.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+// ObjectInfo represents some information about an object stored in a bucket.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// ImageStore is an image.Store implementation that uses an object storage
+// bucket (S3 compatible, GCS or Azure Blob) as the underlying storage.
+//
+// Each image version is stored as a separate object (images/ID/VERSION). An
+// additional object (hashes/HASH) that maps the image original hash to its ID
+// is written once all versions have been stored, so that the same image is
+// only stored once.
+type ImageStore struct {
+	cfg         *viper.Viper
+	bucket      Bucket
+	hc          img.HTTPClient
+	githubRL    *rate.Limiter
+	imagesCache *lru.Cache
+	errorsCache *lru.Cache
+	mutexes     sync.Map
+}
+
+// NewImageStore creates a new ImageStore instance.
+func NewImageStore(
+	cfg *viper.Viper,
+	bucket Bucket,
+	hc img.HTTPClient,
+	githubRL *rate.Limiter,
+) *ImageStore {
+	imagesCache, _ := lru.New(cacheSize)
+	errorsCache, _ := lru.New(cacheSize)
+	return &ImageStore{
+		cfg:         cfg,
+		bucket:      bucket,
+		hc:          hc,
+		githubRL:    githubRL,
+		imagesCache: imagesCache,
+		errorsCache: errorsCache,
+	}
+}
+
+// DownloadAndSaveImage implements the image.Store interface.
+func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string) (string, error) {
+	// Make sure we only process the same image once at a time
+	cachedImage, _ := s.mutexes.LoadOrStore(imageURL, &sync.Mutex{})
+	imageMu := cachedImage.(*sync.Mutex)
+	imageMu.Lock()
+	defer imageMu.Unlock()
+
+	// Try to get image data from the cache to avoid hitting the source
+	var data []byte
+	var err error
+	cachedImage, ok := s.imagesCache.Get(imageURL)
+	if ok {
+		data = cachedImage.([]byte)
+	} else {
+		// Image not found in the cache. Check if we've tried downloading it
+		// already, returning the cached error if available.
+		cachedError, ok := s.errorsCache.Get(imageURL)
+		if ok {
+			return "", cachedError.(error)
+		}
+
+		// Download it from source and store it in the cache.
+		githubToken := s.cfg.GetString("creds.githubToken")
+		data, err = img.Download(ctx, s.hc, githubToken, s.githubRL, imageURL)
+		if err != nil {
+			s.errorsCache.Add(imageURL, err)
+			return "", err
+		}
+		s.imagesCache.Add(imageURL, data)
+	}
+
+	// Store image in the bucket
+	return s.SaveImage(ctx, data)
+}
+
+// DeleteImage implements the image.GCStore interface.
+func (s *ImageStore) DeleteImage(ctx context.Context, imageID string) error {
+	if _, err := uuid.FromString(imageID); err != nil {
+		return fmt.Errorf("%w: invalid image id", hub.ErrInvalidInput)
+	}
+	objects, err := s.bucket.List(ctx, imagePrefix(imageID))
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		if err := s.bucket.Delete(ctx, o.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetImage implements the image.Store interface.
+func (s *ImageStore) GetImage(ctx context.Context, imageID, version string) ([]byte, error) {
+	r, _, err := s.GetImageReader(ctx, imageID, version)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// GetImageReader implements the image.StreamStore interface. When the version
+// requested is not available, the smallest version of the image is returned.
+func (s *ImageStore) GetImageReader(
+	ctx context.Context,
+	imageID,
+	version string,
+) (io.ReadCloser, string, error) {
+	if _, err := uuid.FromString(imageID); err != nil {
+		return nil, "", hub.ErrNotFound
+	}
+
+	versions := make([]string, 0, len(fallbackVersions)+1)
+	if versionRE.MatchString(version) {
+		versions = append(versions, version)
+	}
+	versions = append(versions, fallbackVersions...)
+	for _, v := range versions {
+		r, contentType, err := s.bucket.Get(ctx, ImageKey(imageID, v))
+		if err == nil {
+			return r, contentType, nil
+		}
+		if !errors.Is(err, hub.ErrNotFound) {
+			return nil, "", err
+		}
+	}
+	return nil, "", hub.ErrNotFound
+}
+
+// GetImagesCreatedBefore implements the image.GCStore interface. An image is
+// considered to be created before the time provided when none of its versions
+// have been modified after it.
+func (s *ImageStore) GetImagesCreatedBefore(ctx context.Context, t time.Time) ([]*img.StoredImage, error) {
+	objects, err := s.bucket.List(ctx, "images/")
+	if err != nil {
+		return nil, err
+	}
+	var images []*img.StoredImage
+	imagesByID := make(map[string]*img.StoredImage)
+	recent := make(map[string]bool)
+	for _, o := range objects {
+		parts := strings.Split(strings.TrimPrefix(o.Key, "images/"), "/")
+		if len(parts) != 2 {
+			continue
+		}
+		imageID := parts[0]
+		if !o.LastModified.Before(t) {
+			recent[imageID] = true
+		}
+		image, ok := imagesByID[imageID]
+		if !ok {
+			image = &img.StoredImage{ImageID: imageID}
+			imagesByID[imageID] = image
+			images = append(images, image)
+		}
+		image.Size += o.Size
+	}
+	result := make([]*img.StoredImage, 0, len(images))
+	for _, image := range images {
+		if !recent[image.ImageID] {
+			result = append(result, image)
+		}
+	}
+	return result, nil
+}
+
+// SaveImage implements the image.Store interface.
+func (s *ImageStore) SaveImage(ctx context.Context, data []byte) (string, error) {
+	// Compute image hash using sha256
+	sum := sha256.Sum256(data)
+	originalHash := sum[:]
+
+	// If image is already stored we just return its id
+	imageID, err := s.getImageID(ctx, originalHash)
+	if err != nil {
+		return "", err
+	}
+	if imageID != "" {
+		return imageID, nil
+	}
+	imageID = uuid.NewV4().String()
+
+	// If image format is svg store it as is, as this format doesn't require
+	// to store additional size specific versions
+	if svg.Is(data) {
+		if err := s.bucket.Put(ctx, ImageKey(imageID, svgVersion), data, svgContentType); err != nil {
+			return "", err
+		}
+	} else {
+		// Generate image versions of different sizes and store them
+		imageVersions, err := img.GenerateVersions(data)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range imageVersions {
+			key := ImageKey(imageID, v.Version)
+			if err := s.bucket.Put(ctx, key, v.Data, http.DetectContentType(v.Data)); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	// Register image hash once all versions have been stored
+	if err := RegisterHash(ctx, s.bucket, originalHash, imageID); err != nil {
+		return "", err
+	}
+
+	return imageID, nil
+}
+
+// getImageID checks if the bucket contains an image with the hash provided,
+// returning its id when found. Hashes pointing to images that have been
+// deleted are ignored.
+func (s *ImageStore) getImageID(ctx context.Context, hash []byte) (string, error) {
+	r, _, err := s.bucket.Get(ctx, HashKey(hash))
+	if err != nil {
+		if errors.Is(err, hub.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer r.Close()
+	imageID, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	objects, err := s.bucket.List(ctx, imagePrefix(string(imageID)))
+	if err != nil {
+		return "", err
+	}
+	if len(objects) == 0 {
+		return "", nil
+	}
+	return string(imageID), nil
+}
+
+// RegisterHash stores an object that maps the image hash provided to its id.
+func RegisterHash(ctx context.Context, bucket Bucket, hash []byte, imageID string) error {
+	return bucket.Put(ctx, HashKey(hash), []byte(imageID), "text/plain")
+}
+
+// ImageKey returns the key of the object used to store the image version
+// provided.
+func ImageKey(imageID, version string) string {
+	return fmt.Sprintf("images/%s/%s", imageID, version)
+}
+
+// imagePrefix returns the prefix shared by all the objects used to store the
+// versions of the image provided.
+func imagePrefix(imageID string) string {
+	return fmt.Sprintf("images/%s/", imageID)
+}
+
+// HashKey returns the key of the object used to map the image hash provided to
+// its id.
+func HashKey(hash []byte) string {
+	return fmt.Sprintf("hashes/%s", hex.EncodeToString(hash))
+}
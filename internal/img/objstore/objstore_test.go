@@ -0,0 +1,316 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const imageID = "00000000-0000-0000-0000-000000000001"
+
+func TestNewImageStore(t *testing.T) {
+	t.Parallel()
+	bucket := &BucketMock{}
+	hc := &tests.HTTPClientMock{}
+	s := NewImageStore(nil, bucket, hc, nil)
+
+	assert.IsType(t, &ImageStore{}, s)
+	assert.Equal(t, bucket, s.bucket)
+	assert.Equal(t, hc, s.hc)
+}
+
+func TestDeleteImage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid image id", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		err := s.DeleteImage(ctx, "../imageID")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("image deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("List", ctx, "images/"+imageID+"/").Return([]*ObjectInfo{
+			{Key: ImageKey(imageID, "1x")},
+			{Key: ImageKey(imageID, "2x")},
+		}, nil)
+		bucket.On("Delete", ctx, ImageKey(imageID, "1x")).Return(nil)
+		bucket.On("Delete", ctx, ImageKey(imageID, "2x")).Return(nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		err := s.DeleteImage(ctx, imageID)
+		assert.NoError(t, err)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("bucket error listing image versions", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("List", ctx, "images/"+imageID+"/").Return(nil, tests.ErrFake)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		err := s.DeleteImage(ctx, imageID)
+		assert.Equal(t, tests.ErrFake, err)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("bucket error deleting image version", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("List", ctx, "images/"+imageID+"/").Return([]*ObjectInfo{
+			{Key: ImageKey(imageID, "1x")},
+		}, nil)
+		bucket.On("Delete", ctx, ImageKey(imageID, "1x")).Return(tests.ErrFake)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		err := s.DeleteImage(ctx, imageID)
+		assert.Equal(t, tests.ErrFake, err)
+		bucket.AssertExpectations(t)
+	})
+}
+
+func TestGetImage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid image id", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		data, err := s.GetImage(ctx, "../imageID", "2x")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("existing image version", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, ImageKey(imageID, "2x")).Return(newReader("image2xData"), "image/png", nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		data, err := s.GetImage(ctx, imageID, "2x")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte("image2xData"), data)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("version not found, smallest version returned", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, ImageKey(imageID, "2x")).Return(nil, "", hub.ErrNotFound)
+		bucket.On("Get", ctx, ImageKey(imageID, "1x")).Return(newReader("image1xData"), "image/png", nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		data, err := s.GetImage(ctx, imageID, "2x")
+		assert.Equal(t, nil, err)
+		assert.Equal(t, []byte("image1xData"), data)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("svg image", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, ImageKey(imageID, "1x")).Return(nil, "", hub.ErrNotFound)
+		bucket.On("Get", ctx, ImageKey(imageID, "svg")).Return(newReader("svgData"), svgContentType, nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		r, contentType, err := s.GetImageReader(ctx, imageID, "")
+		require.NoError(t, err)
+		data, _ := ioutil.ReadAll(r)
+		assert.Equal(t, []byte("svgData"), data)
+		assert.Equal(t, svgContentType, contentType)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("image not found", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, mock.Anything).Return(nil, "", hub.ErrNotFound)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		data, err := s.GetImage(ctx, imageID, "2x")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, data)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("bucket error", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, ImageKey(imageID, "2x")).Return(nil, "", tests.ErrFake)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		data, err := s.GetImage(ctx, imageID, "2x")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, data)
+		bucket.AssertExpectations(t)
+	})
+}
+
+func TestGetImagesCreatedBefore(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Date(2021, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	t.Run("images returned successfully", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("List", ctx, "images/").Return([]*ObjectInfo{
+			{Key: ImageKey("id1", "1x"), Size: 10, LastModified: ts.Add(-time.Hour)},
+			{Key: ImageKey("id1", "2x"), Size: 20, LastModified: ts.Add(-time.Hour)},
+			{Key: ImageKey("id2", "svg"), Size: 5, LastModified: ts.Add(-time.Hour)},
+			{Key: ImageKey("id3", "1x"), Size: 10, LastModified: ts.Add(-time.Hour)},
+			{Key: ImageKey("id3", "2x"), Size: 20, LastModified: ts.Add(time.Hour)},
+			{Key: "images/unexpected", Size: 1},
+		}, nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		images, err := s.GetImagesCreatedBefore(ctx, ts)
+		require.NoError(t, err)
+		assert.Equal(t, []*img.StoredImage{
+			{ImageID: "id1", Size: 30},
+			{ImageID: "id2", Size: 5},
+		}, images)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("bucket error listing images", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("List", ctx, "images/").Return(nil, tests.ErrFake)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		images, err := s.GetImagesCreatedBefore(ctx, ts)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Nil(t, images)
+		bucket.AssertExpectations(t)
+	})
+}
+
+func TestSaveImage(t *testing.T) {
+	pngImgData, err := ioutil.ReadFile("testdata/image.png")
+	require.NoError(t, err)
+	sumPngImg := sha256.Sum256(pngImgData)
+	pngImgHash := sumPngImg[:]
+	svgImgData, err := ioutil.ReadFile("testdata/image.svg")
+	require.NoError(t, err)
+	sumSvgImg := sha256.Sum256(svgImgData)
+	svgImgHash := sumSvgImg[:]
+	ctx := context.Background()
+
+	t.Run("successful png image registration", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, HashKey(pngImgHash)).Return(nil, "", hub.ErrNotFound)
+		for _, version := range []string{"1x", "2x", "3x", "4x"} {
+			version := version
+			keyMatcher := mock.MatchedBy(func(key string) bool {
+				return strings.HasSuffix(key, "/"+version)
+			})
+			bucket.On("Put", ctx, keyMatcher, mock.Anything, "image/png").Return(nil)
+		}
+		bucket.On("Put", ctx, HashKey(pngImgHash), mock.Anything, "text/plain").Return(nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, pngImgData)
+		require.NoError(t, err)
+		assert.NotEmpty(t, imageID)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("successful svg image registration", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, HashKey(svgImgHash)).Return(nil, "", hub.ErrNotFound)
+		bucket.On("Put", ctx, mock.Anything, svgImgData, svgContentType).Return(nil)
+		bucket.On("Put", ctx, HashKey(svgImgHash), mock.Anything, "text/plain").Return(nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		imageID, err := s.SaveImage(ctx, svgImgData)
+		require.NoError(t, err)
+		assert.NotEmpty(t, imageID)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("try to register existing png image", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, HashKey(pngImgHash)).Return(newReader(imageID), "text/plain", nil)
+		bucket.On("List", ctx, "images/"+imageID+"/").Return([]*ObjectInfo{
+			{Key: ImageKey(imageID, "1x")},
+		}, nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		id, err := s.SaveImage(ctx, pngImgData)
+		require.NoError(t, err)
+		assert.Equal(t, imageID, id)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("register png image whose previous copy was deleted", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, HashKey(pngImgHash)).Return(newReader(imageID), "text/plain", nil)
+		bucket.On("List", ctx, "images/"+imageID+"/").Return(nil, nil)
+		bucket.On("Put", ctx, mock.Anything, mock.Anything, "image/png").Return(nil)
+		bucket.On("Put", ctx, HashKey(pngImgHash), mock.Anything, "text/plain").Return(nil)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		id, err := s.SaveImage(ctx, pngImgData)
+		require.NoError(t, err)
+		assert.NotEqual(t, imageID, id)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("bucket error getting image id", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, HashKey(pngImgHash)).Return(nil, "", tests.ErrFake)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		id, err := s.SaveImage(ctx, pngImgData)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Empty(t, id)
+		bucket.AssertExpectations(t)
+	})
+
+	t.Run("bucket error storing image version", func(t *testing.T) {
+		t.Parallel()
+		bucket := &BucketMock{}
+		bucket.On("Get", ctx, HashKey(pngImgHash)).Return(nil, "", hub.ErrNotFound)
+		bucket.On("Put", ctx, mock.Anything, mock.Anything, "image/png").Return(tests.ErrFake)
+		s := NewImageStore(nil, bucket, nil, nil)
+
+		id, err := s.SaveImage(ctx, pngImgData)
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Empty(t, id)
+		bucket.AssertExpectations(t)
+	})
+}
+
+func newReader(data string) *readCloser {
+	return &readCloser{bytes.NewReader([]byte(data))}
+}
+
+type readCloser struct {
+	*bytes.Reader
+}
+
+func (r *readCloser) Close() error { return nil }
@@ -0,0 +1,128 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/viper"
+)
+
+// S3Bucket is a Bucket implementation backed by an S3 compatible object
+// storage service.
+type S3Bucket struct {
+	client *s3.S3
+	bucket string
+}
+
+// NewS3Bucket creates a new S3Bucket instance using the configuration
+// provided. When no static credentials are provided, the default AWS
+// credentials chain will be used.
+func NewS3Bucket(cfg *viper.Viper) (*S3Bucket, error) {
+	bucket := cfg.GetString("images.s3.bucket")
+	if bucket == "" {
+		return nil, errors.New("s3 bucket not provided")
+	}
+	awsCfg := &aws.Config{
+		Region:           aws.String(cfg.GetString("images.s3.region")),
+		S3ForcePathStyle: aws.Bool(cfg.GetBool("images.s3.forcePathStyle")),
+	}
+	if endpoint := cfg.GetString("images.s3.endpoint"); endpoint != "" {
+		awsCfg.Endpoint = aws.String(endpoint)
+	}
+	if accessKeyID := cfg.GetString("images.s3.accessKeyID"); accessKeyID != "" {
+		secretAccessKey := cfg.GetString("images.s3.secretAccessKey")
+		awsCfg.Credentials = credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &S3Bucket{
+		client: s3.New(sess),
+		bucket: bucket,
+	}, nil
+}
+
+// Delete implements the Bucket interface.
+func (b *S3Bucket) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// Exists implements the Bucket interface.
+func (b *S3Bucket) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := b.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var reqErr awserr.RequestFailure
+		if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Get implements the Bucket interface.
+func (b *S3Bucket) Get(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	out, err := b.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, "", hub.ErrNotFound
+		}
+		return nil, "", err
+	}
+	return out.Body, aws.StringValue(out.ContentType), nil
+}
+
+// List implements the Bucket interface.
+func (b *S3Bucket) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	var objects []*ObjectInfo
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(b.bucket),
+		Prefix: aws.String(prefix),
+	}
+	err := b.client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			objects = append(objects, &ObjectInfo{
+				Key:          aws.StringValue(o.Key),
+				Size:         aws.Int64Value(o.Size),
+				LastModified: aws.TimeValue(o.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// Put implements the Bucket interface.
+func (b *S3Bucket) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
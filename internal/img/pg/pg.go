@@ -3,13 +3,16 @@ package pg
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/img"
 	svg "github.com/h2non/go-is-svg"
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
 	"golang.org/x/time/rate"
@@ -17,9 +20,11 @@ import (
 
 const (
 	// Database queries
-	getImageDBQ      = `select get_image($1::uuid, $2::text)`
-	getImageIDDBQ    = `select image_id from image where original_hash = $1`
-	registerImageDBQ = `select register_image($1::bytea, $2::text, $3::bytea)`
+	deleteImageDBQ            = `select delete_image($1::uuid)`
+	getImageDBQ               = `select get_image($1::uuid, $2::text)`
+	getImageIDDBQ             = `select image_id from image where original_hash = $1`
+	getImagesCreatedBeforeDBQ = `select get_images_created_before($1::timestamptz)`
+	registerImageDBQ          = `select register_image($1::bytea, $2::text, $3::bytea)`
 
 	// Cache
 	cacheSize = 250
@@ -27,6 +32,7 @@ const (
 
 // DB defines the methods the database handler must provide.
 type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
@@ -97,6 +103,12 @@ func (s *ImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string)
 	return s.SaveImage(ctx, data)
 }
 
+// DeleteImage implements the image.GCStore interface.
+func (s *ImageStore) DeleteImage(ctx context.Context, imageID string) error {
+	_, err := s.db.Exec(ctx, deleteImageDBQ, imageID)
+	return err
+}
+
 // GetImage returns an image stored in the database.
 func (s *ImageStore) GetImage(ctx context.Context, imageID, version string) ([]byte, error) {
 	var data []byte
@@ -110,6 +122,19 @@ func (s *ImageStore) GetImage(ctx context.Context, imageID, version string) ([]b
 	return data, nil
 }
 
+// GetImagesCreatedBefore implements the image.GCStore interface.
+func (s *ImageStore) GetImagesCreatedBefore(ctx context.Context, t time.Time) ([]*img.StoredImage, error) {
+	var dataJSON []byte
+	if err := s.db.QueryRow(ctx, getImagesCreatedBeforeDBQ, t).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	var images []*img.StoredImage
+	if err := json.Unmarshal(dataJSON, &images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
 // SaveImage implements the image.Store interface.
 func (s *ImageStore) SaveImage(ctx context.Context, data []byte) (string, error) {
 	// Compute image hash using sha256
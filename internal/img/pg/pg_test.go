@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/artifacthub/hub/internal/img"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/viper"
@@ -141,6 +143,32 @@ func TestDownloadAndSaveImage(t *testing.T) {
 	})
 }
 
+func TestDeleteImage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("image deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteImageDBQ, "imageID").Return(nil)
+		s := NewImageStore(nil, db, nil, nil)
+
+		err := s.DeleteImage(ctx, "imageID")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteImageDBQ, "imageID").Return(tests.ErrFakeDB)
+		s := NewImageStore(nil, db, nil, nil)
+
+		err := s.DeleteImage(ctx, "imageID")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetImage(t *testing.T) {
 	ctx := context.Background()
 
@@ -169,6 +197,40 @@ func TestGetImage(t *testing.T) {
 	})
 }
 
+func TestGetImagesCreatedBefore(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Date(2021, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	t.Run("images returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImagesCreatedBeforeDBQ, ts).Return([]byte(`
+		[{
+			"image_id": "imageID",
+			"size": 1024
+		}]
+		`), nil)
+		s := NewImageStore(nil, db, nil, nil)
+
+		images, err := s.GetImagesCreatedBefore(ctx, ts)
+		require.NoError(t, err)
+		assert.Equal(t, []*img.StoredImage{{ImageID: "imageID", Size: 1024}}, images)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getImagesCreatedBeforeDBQ, ts).Return(nil, tests.ErrFakeDB)
+		s := NewImageStore(nil, db, nil, nil)
+
+		images, err := s.GetImagesCreatedBefore(ctx, ts)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, images)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestSaveImage(t *testing.T) {
 	pngImgData, err := ioutil.ReadFile("testdata/image.png")
 	require.NoError(t, err)
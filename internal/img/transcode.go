@@ -0,0 +1,130 @@
+package img
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Format represents an image format images can be transcoded to.
+type Format string
+
+const (
+	// AVIF represents the AVIF image format.
+	AVIF Format = "avif"
+
+	// WebP represents the WebP image format.
+	WebP Format = "webp"
+)
+
+// ContentType returns the content type of the format.
+func (f Format) ContentType() string {
+	return "image/" + string(f)
+}
+
+// Transcoder describes the methods a Transcoder implementation must provide.
+type Transcoder interface {
+	// Formats returns the formats supported by the transcoder, sorted by
+	// preference.
+	Formats() []Format
+
+	// Transcode transcodes the image provided to the format requested.
+	Transcode(ctx context.Context, data []byte, f Format) ([]byte, error)
+}
+
+// transcodingTool represents an external tool used to transcode images to a
+// given format.
+type transcodingTool struct {
+	format Format
+	name   string
+	args   func(input, output string) []string
+}
+
+// transcodingTools represents the tools supported by the ExecTranscoder,
+// sorted by preference.
+var transcodingTools = []transcodingTool{
+	{
+		format: AVIF,
+		name:   "avifenc",
+		args: func(input, output string) []string {
+			return []string{input, output}
+		},
+	},
+	{
+		format: WebP,
+		name:   "cwebp",
+		args: func(input, output string) []string {
+			return []string{"-quiet", input, "-o", output}
+		},
+	},
+}
+
+// ExecTranscoder is a Transcoder implementation that relies on some external
+// tools (avifenc and cwebp) to transcode images. Only the formats whose tool is
+// available in the PATH are supported.
+type ExecTranscoder struct {
+	formats []Format
+	tools   map[Format]transcodingTool
+}
+
+// NewExecTranscoder creates a new ExecTranscoder instance.
+func NewExecTranscoder() *ExecTranscoder {
+	t := &ExecTranscoder{
+		tools: make(map[Format]transcodingTool),
+	}
+	for _, tool := range transcodingTools {
+		if _, err := exec.LookPath(tool.name); err != nil {
+			continue
+		}
+		t.formats = append(t.formats, tool.format)
+		t.tools[tool.format] = tool
+	}
+	return t
+}
+
+// Formats implements the Transcoder interface.
+func (t *ExecTranscoder) Formats() []Format {
+	return t.formats
+}
+
+// Transcode implements the Transcoder interface.
+func (t *ExecTranscoder) Transcode(ctx context.Context, data []byte, f Format) ([]byte, error) {
+	tool, ok := t.tools[f]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s", f)
+	}
+
+	// Prepare input and output files (some tools rely on the input file
+	// extension to detect its format)
+	var ext string
+	switch http.DetectContentType(data) {
+	case "image/png":
+		ext = ".png"
+	case "image/jpeg":
+		ext = ".jpg"
+	default:
+		return nil, errors.New("only png and jpeg images can be transcoded")
+	}
+	tmpDir, err := ioutil.TempDir("", "artifacthub-img")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	input := filepath.Join(tmpDir, "input"+ext)
+	output := filepath.Join(tmpDir, "output."+string(f))
+	if err := ioutil.WriteFile(input, data, 0600); err != nil {
+		return nil, err
+	}
+
+	// Run transcoding tool
+	cmd := exec.CommandContext(ctx, tool.name, tool.args(input, output)...) // #nosec
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("error running %s: %w: %s", tool.name, err, out)
+	}
+	return ioutil.ReadFile(output)
+}
@@ -0,0 +1,44 @@
+package img
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecTranscoder(t *testing.T) {
+	// Setup a fake cwebp tool that copies the input file to the output one
+	binDir, err := ioutil.TempDir("", "artifacthub-img-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(binDir)
+	script := "#!/bin/sh\n/bin/cp \"$2\" \"$4\"\n"
+	err = ioutil.WriteFile(filepath.Join(binDir, "cwebp"), []byte(script), 0700) // #nosec
+	require.NoError(t, err)
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	os.Setenv("PATH", binDir)
+
+	ctx := context.Background()
+	validImgData, err := ioutil.ReadFile("testdata/valid.png")
+	require.NoError(t, err)
+	tr := NewExecTranscoder()
+
+	// Check only the formats whose tool is available are supported
+	assert.Equal(t, []Format{WebP}, tr.Formats())
+	_, err = tr.Transcode(ctx, validImgData, AVIF)
+	assert.Error(t, err)
+
+	// Check only png and jpeg images can be transcoded
+	_, err = tr.Transcode(ctx, []byte("<svg></svg>"), WebP)
+	assert.Error(t, err)
+
+	// Check image is transcoded using the tool available
+	data, err := tr.Transcode(ctx, validImgData, WebP)
+	require.NoError(t, err)
+	assert.Equal(t, validImgData, data)
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// discordEmbedColor represents the accent color used in the Discord embeds.
+const discordEmbedColor = 0x417598
+
+// prepareDiscordPayload prepares a Discord message containing an embed for
+// the package notification template data provided.
+func prepareDiscordPayload(tmplData *hub.PackageNotificationTemplateData) ([]byte, error) {
+	i := getReleaseInfo(tmplData)
+
+	// Highlights and changes
+	var description strings.Builder
+	if i.deprecated {
+		description.WriteString(":warning: This version has been deprecated\n")
+	}
+	if i.containsSecurityUpdates {
+		description.WriteString(":shield: This version contains security updates\n")
+	}
+	if i.prerelease {
+		description.WriteString(":construction: This version is a pre-release\n")
+	}
+	if len(i.vulnerabilities) > 0 {
+		if description.Len() > 0 {
+			description.WriteString("\n")
+		}
+		description.WriteString("**Vulnerabilities**\n")
+		for _, d := range i.vulnerabilitiesDescriptions() {
+			description.WriteString(fmt.Sprintf("• %s\n", d))
+		}
+	}
+	if len(i.changes) > 0 {
+		if description.Len() > 0 {
+			description.WriteString("\n")
+		}
+		description.WriteString("**Changes**\n")
+		for _, change := range i.changes {
+			description.WriteString(fmt.Sprintf("• %s\n", change))
+		}
+	}
+
+	embed := map[string]interface{}{
+		"title": i.title(),
+		"url":   i.url,
+		"color": discordEmbedColor,
+		"fields": []map[string]interface{}{
+			{"name": "Repository", "value": fmt.Sprintf("%s (%s)", i.repoName, i.repoKind), "inline": true},
+			{"name": "Publisher", "value": i.publisher, "inline": true},
+		},
+	}
+	if description.Len() > 0 {
+		embed["description"] = strings.TrimSpace(description.String())
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"embeds": []map[string]interface{}{embed},
+	})
+}
@@ -3,18 +3,26 @@ package notification
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/patrickmn/go-cache"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
+	"golang.org/x/net/http/httpproxy"
 )
 
 const (
 	defaultNumWorkers      = 2
 	cacheDefaultExpiration = 5 * time.Minute
 	cacheCleanupInterval   = 10 * time.Minute
+	webhookTimeout         = 10 * time.Second
+
+	// notificationCreatedChannel represents the database channel where the
+	// notifications created are announced.
+	notificationCreatedChannel = "notification_created"
 )
 
 // Services is a wrapper around several internal services used to handle
@@ -30,15 +38,32 @@ type Services struct {
 
 // Dispatcher handles a group of workers in charge of delivering notifications.
 type Dispatcher struct {
-	numWorkers int
-	workers    []*Worker
+	db              hub.DB
+	numWorkers      int
+	batchSize       int
+	pollingInterval time.Duration
+	httpClient      HTTPClient
+	workers         []*Worker
 }
 
 // NewDispatcher creates a new Dispatcher instance.
 func NewDispatcher(cfg *viper.Viper, svc *Services, opts ...func(d *Dispatcher)) *Dispatcher {
 	// Setup dispatcher
 	d := &Dispatcher{
-		numWorkers: defaultNumWorkers,
+		db:              svc.DB,
+		numWorkers:      defaultNumWorkers,
+		batchSize:       defaultBatchSize,
+		pollingInterval: defaultPollingInterval,
+		httpClient:      NewWebhookHTTPClient(cfg),
+	}
+	if cfg.IsSet("notifications.workers") {
+		d.numWorkers = cfg.GetInt("notifications.workers")
+	}
+	if cfg.IsSet("notifications.batchSize") {
+		d.batchSize = cfg.GetInt("notifications.batchSize")
+	}
+	if cfg.IsSet("notifications.pollingInterval") {
+		d.pollingInterval = cfg.GetDuration("notifications.pollingInterval")
 	}
 	for _, o := range opts {
 		o(d)
@@ -47,10 +72,18 @@ func NewDispatcher(cfg *viper.Viper, svc *Services, opts ...func(d *Dispatcher))
 	// Setup and launch workers
 	c := cache.New(cacheDefaultExpiration, cacheCleanupInterval)
 	baseURL := cfg.GetString("server.baseURL")
-	httpClient := &http.Client{Timeout: 10 * time.Second}
 	d.workers = make([]*Worker, 0, d.numWorkers)
 	for i := 0; i < d.numWorkers; i++ {
-		d.workers = append(d.workers, NewWorker(svc, c, baseURL, httpClient))
+		d.workers = append(d.workers, NewWorker(
+			svc,
+			c,
+			baseURL,
+			d.httpClient,
+			WithBatchSize(d.batchSize),
+			WithPollingInterval(d.pollingInterval),
+			WithUnsubscribeKey(cfg.GetString("notifications.unsubscribeKey")),
+			WithPayloadLimits(NewPayloadLimits(cfg)),
+		))
 	}
 
 	return d
@@ -64,8 +97,18 @@ func WithNumWorkers(n int) func(d *Dispatcher) {
 	}
 }
 
+// WithHTTPClient allows providing a specific http client to be used by the
+// workers of a Dispatcher instance to deliver webhook notifications.
+func WithHTTPClient(hc HTTPClient) func(d *Dispatcher) {
+	return func(d *Dispatcher) {
+		d.httpClient = hc
+	}
+}
+
 // Run starts the workers and lets them run until the dispatcher is asked to
-// stop via the context provided.
+// stop via the context provided. Workers are woken up as soon as new
+// notifications are created, falling back to polling when the database
+// notifications are not available.
 func (d *Dispatcher) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -76,9 +119,82 @@ func (d *Dispatcher) Run(ctx context.Context, wg *sync.WaitGroup) {
 		wwg.Add(1)
 		go w.Run(wctx, wwg)
 	}
+	if len(d.workers) > 0 {
+		wwg.Add(1)
+		go d.listenForNotifications(wctx, wwg)
+	}
 
 	// Stop workers when dispatcher is asked to stop
 	<-ctx.Done()
 	stopWorkers()
 	wwg.Wait()
 }
+
+// listenForNotifications listens for the database notifications sent when new
+// notifications are created, waking up the workers so that they are delivered
+// right away.
+func (d *Dispatcher) listenForNotifications(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		if err := d.waitForNotifications(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("listenForNotifications: error waiting for notifications")
+		}
+		select {
+		case <-time.After(pauseOnError):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// waitForNotifications wakes up the workers every time a notification is
+// received on the notification created channel, until an error occurs or the
+// context provided is done.
+func (d *Dispatcher) waitForNotifications(ctx context.Context) error {
+	conn, err := d.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, "listen "+notificationCreatedChannel); err != nil {
+		return err
+	}
+	for {
+		if _, err := conn.Conn().WaitForNotification(ctx); err != nil {
+			return err
+		}
+		for _, w := range d.workers {
+			w.wakeUp()
+		}
+	}
+}
+
+// NewWebhookHTTPClient creates a new http client ready to deliver webhook
+// notifications, using the proxy configured for notifications deliveries when
+// available. Requests that take longer than webhookTimeout are cancelled.
+func NewWebhookHTTPClient(cfg *viper.Viper) *http.Client {
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: setupTransport(cfg),
+	}
+}
+
+// setupTransport creates the http transport used by the workers to deliver
+// webhook notifications. When a proxy has been configured for notifications
+// deliveries it takes precedence over the one set in the environment.
+func setupTransport(cfg *viper.Viper) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	proxyCfg := &httpproxy.Config{
+		HTTPProxy:  cfg.GetString("notifications.proxy.httpProxy"),
+		HTTPSProxy: cfg.GetString("notifications.proxy.httpsProxy"),
+		NoProxy:    cfg.GetString("notifications.proxy.noProxy"),
+	}
+	if proxyCfg.HTTPProxy != "" || proxyCfg.HTTPSProxy != "" {
+		proxyFunc := proxyCfg.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+	return transport
+}
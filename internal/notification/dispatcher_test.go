@@ -2,12 +2,16 @@ package notification
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/artifacthub/hub/internal/tests"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDispatcher(t *testing.T) {
@@ -31,3 +35,112 @@ func TestDispatcher(t *testing.T) {
 		return true
 	}, 2*time.Second, 100*time.Millisecond)
 }
+
+func TestDispatcherConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := viper.New()
+	cfg.Set("notifications.workers", 3)
+	cfg.Set("notifications.batchSize", 5)
+	cfg.Set("notifications.pollingInterval", "1m")
+	d := NewDispatcher(cfg, &Services{})
+
+	assert.Len(t, d.workers, 3)
+	for _, w := range d.workers {
+		assert.Equal(t, 5, w.batchSize)
+		assert.Equal(t, 1*time.Minute, w.pollingInterval)
+	}
+}
+
+func TestDispatcherListenerUnavailable(t *testing.T) {
+	t.Parallel()
+
+	// Setup dispatcher with a database that cannot provide a connection to
+	// listen for notifications, so workers fall back to polling
+	db := &tests.DBMock{}
+	acquireCalled := make(chan struct{}, 1)
+	db.On("Acquire", mock.Anything).
+		Run(func(args mock.Arguments) {
+			select {
+			case acquireCalled <- struct{}{}:
+			default:
+			}
+		}).
+		Return(nil, tests.ErrFakeDB)
+	db.On("Begin", mock.Anything).Return(nil, tests.ErrFakeDB).Maybe()
+	cfg := viper.New()
+	d := NewDispatcher(cfg, &Services{DB: db}, WithNumWorkers(1))
+
+	// Run it
+	ctx, stopDispatcher := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go d.Run(ctx, &wg)
+
+	// Check it tried to listen and stops as expected when asked to do so
+	select {
+	case <-acquireCalled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("dispatcher did not try to listen for notifications")
+	}
+	stopDispatcher()
+	assert.Eventually(t, func() bool {
+		wg.Wait()
+		return true
+	}, 2*time.Second, 100*time.Millisecond)
+}
+
+func TestDispatcherProxyConfig(t *testing.T) {
+	t.Parallel()
+
+	cfg := viper.New()
+	cfg.Set("notifications.proxy.httpProxy", "http://proxy.corp:3128")
+	cfg.Set("notifications.proxy.httpsProxy", "http://secure-proxy.corp:3128")
+	cfg.Set("notifications.proxy.noProxy", "internal.corp")
+	d := NewDispatcher(cfg, &Services{}, WithNumWorkers(1))
+	transport := d.workers[0].httpClient.(*http.Client).Transport.(*http.Transport)
+
+	testCases := []struct {
+		url           string
+		expectedProxy string
+	}{
+		{"http://hooks.example.com/webhook", "http://proxy.corp:3128"},
+		{"https://hooks.example.com/webhook", "http://secure-proxy.corp:3128"},
+		{"https://hooks.internal.corp/webhook", ""},
+	}
+	for _, tc := range testCases {
+		req, _ := http.NewRequest("POST", tc.url, nil)
+		proxyURL, err := transport.Proxy(req)
+		require.NoError(t, err)
+		if tc.expectedProxy == "" {
+			assert.Nil(t, proxyURL)
+		} else {
+			assert.Equal(t, tc.expectedProxy, proxyURL.String())
+		}
+	}
+}
+
+func TestDispatcherHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	hc := &tests.HTTPClientMock{}
+	d := NewDispatcher(viper.New(), &Services{}, WithNumWorkers(2), WithHTTPClient(hc))
+
+	for _, w := range d.workers {
+		assert.Equal(t, hc, w.httpClient)
+	}
+}
+
+func TestNewWebhookHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	cfg := viper.New()
+	cfg.Set("notifications.proxy.httpsProxy", "http://secure-proxy.corp:3128")
+	hc := NewWebhookHTTPClient(cfg)
+
+	assert.Equal(t, webhookTimeout, hc.Timeout)
+	req, _ := http.NewRequest("POST", "https://hooks.example.com/webhook", nil)
+	proxyURL, err := hc.Transport.(*http.Transport).Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "http://secure-proxy.corp:3128", proxyURL.String())
+}
@@ -0,0 +1,167 @@
+package notification
+
+import (
+	htmlTemplate "html/template"
+	"text/template"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"golang.org/x/text/language"
+)
+
+// defaultLocale represents the locale used for notifications emails when no
+// template set matches the preferred locale of the recipient.
+var defaultLocale = language.English
+
+// emailTemplateSets contains the emails templates sets available, indexed by
+// the locale they are written in. The set corresponding to the default locale
+// must be complete, as it is used to fill the gaps in the other ones.
+var emailTemplateSets = map[language.Tag]*emailTemplateSet{
+	language.English: {
+		subject: map[hub.EventKind]*template.Template{
+			hub.NewRelease:                        newReleaseEmailSubjectTmpl,
+			hub.NewPackage:                        newPackageEmailSubjectTmpl,
+			hub.PackageDeprecated:                 packageDeprecatedEmailSubjectTmpl,
+			hub.SecurityAlert:                     securityAlertEmailSubjectTmpl,
+			hub.RepositoryOwnershipClaim:          ownershipClaimEmailSubjectTmpl,
+			hub.RepositoryOwnershipTransfer:       ownershipTransferEmailSubjectTmpl,
+			hub.RepositoryScanningErrors:          scanningErrorsEmailSubjectTmpl,
+			hub.RepositoryTrackingErrors:          trackingErrorsEmailSubjectTmpl,
+			hub.WebhookSuspended:                  webhookSuspendedEmailSubjectTmpl,
+			hub.SavedSearchNewMatches:             savedSearchNewMatchesEmailSubjectTmpl,
+			hub.PackageNewQuestion:                packageNewQuestionEmailSubjectTmpl,
+			hub.RepositoryVerifiedPublisherChange: verifiedPublisherChangeEmailSubjectTmpl,
+		},
+		html: map[hub.EventKind]*htmlTemplate.Template{
+			hub.NewRelease:                        newReleaseEmailTmpl,
+			hub.NewPackage:                        newPackageEmailTmpl,
+			hub.PackageDeprecated:                 packageDeprecatedEmailTmpl,
+			hub.SecurityAlert:                     securityAlertEmailTmpl,
+			hub.RepositoryOwnershipClaim:          ownershipClaimEmailTmpl,
+			hub.RepositoryOwnershipTransfer:       ownershipTransferEmailTmpl,
+			hub.RepositoryScanningErrors:          scanningErrorsEmailTmpl,
+			hub.RepositoryTrackingErrors:          trackingErrorsEmailTmpl,
+			hub.WebhookSuspended:                  webhookSuspendedEmailTmpl,
+			hub.SavedSearchNewMatches:             savedSearchNewMatchesEmailTmpl,
+			hub.PackageNewQuestion:                packageNewQuestionEmailTmpl,
+			hub.RepositoryVerifiedPublisherChange: verifiedPublisherChangeEmailTmpl,
+		},
+		text: map[hub.EventKind]*template.Template{
+			hub.NewRelease:                        newReleaseEmailTextTmpl,
+			hub.NewPackage:                        newPackageEmailTextTmpl,
+			hub.PackageDeprecated:                 packageDeprecatedEmailTextTmpl,
+			hub.SecurityAlert:                     securityAlertEmailTextTmpl,
+			hub.RepositoryOwnershipClaim:          ownershipClaimEmailTextTmpl,
+			hub.RepositoryOwnershipTransfer:       ownershipTransferEmailTextTmpl,
+			hub.RepositoryScanningErrors:          scanningErrorsEmailTextTmpl,
+			hub.RepositoryTrackingErrors:          trackingErrorsEmailTextTmpl,
+			hub.WebhookSuspended:                  webhookSuspendedEmailTextTmpl,
+			hub.SavedSearchNewMatches:             savedSearchNewMatchesEmailTextTmpl,
+			hub.PackageNewQuestion:                packageNewQuestionEmailTextTmpl,
+			hub.RepositoryVerifiedPublisherChange: verifiedPublisherChangeEmailTextTmpl,
+		},
+	},
+}
+
+var (
+	newReleaseEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`{{ .Package.name }} version {{ .Package.version }} released`,
+	))
+	newPackageEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`New package {{ .Package.name }} published in repository {{ .Package.repository.name }}`,
+	))
+	packageDeprecatedEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`{{ .Package.name }} version {{ .Package.version }} deprecated`,
+	))
+	securityAlertEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`{{ .Package.name }} version {{ .Package.version }} has new security vulnerabilities`,
+	))
+	ownershipClaimEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`{{ .Repository.name }} repository ownership has been claimed`,
+	))
+	ownershipTransferEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`{{ .Repository.name }} repository ownership has been transferred`,
+	))
+	scanningErrorsEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`Something went wrong scanning repository {{ .Repository.name }}`,
+	))
+	trackingErrorsEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`Something went wrong tracking repository {{ .Repository.name }}`,
+	))
+	webhookSuspendedEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`Webhook {{ .Webhook.name }} has been suspended`,
+	))
+	savedSearchNewMatchesEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`New packages matching saved search {{ .SavedSearch.name }}`,
+	))
+	packageNewQuestionEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`New question about {{ .Package.name }}`,
+	))
+	verifiedPublisherChangeEmailSubjectTmpl = template.Must(template.New("").Parse(
+		`{{ .Repository.name }} repository verified publisher status has changed`,
+	))
+)
+
+// emailTemplateSet represents the set of templates used to compose the
+// notifications emails in a given locale.
+type emailTemplateSet struct {
+	subject map[hub.EventKind]*template.Template
+	html    map[hub.EventKind]*htmlTemplate.Template
+	text    map[hub.EventKind]*template.Template
+}
+
+// emailTemplates provides the emails templates set that better suits the
+// preferred locale of a given recipient.
+type emailTemplates struct {
+	sets    []*emailTemplateSet
+	matcher language.Matcher
+}
+
+// newEmailTemplates loads the emails templates sets provided, completing the
+// templates missing in any of them with the ones from the default locale set.
+func newEmailTemplates(sets map[language.Tag]*emailTemplateSet) *emailTemplates {
+	defaultSet := sets[defaultLocale]
+	tags := []language.Tag{defaultLocale}
+	t := &emailTemplates{
+		sets: []*emailTemplateSet{defaultSet},
+	}
+	for tag, set := range sets {
+		if tag == defaultLocale {
+			continue
+		}
+		s := &emailTemplateSet{
+			subject: make(map[hub.EventKind]*template.Template),
+			html:    make(map[hub.EventKind]*htmlTemplate.Template),
+			text:    make(map[hub.EventKind]*template.Template),
+		}
+		for kind, tmpl := range defaultSet.subject {
+			s.subject[kind] = tmpl
+			if localized, ok := set.subject[kind]; ok {
+				s.subject[kind] = localized
+			}
+		}
+		for kind, tmpl := range defaultSet.html {
+			s.html[kind] = tmpl
+			if localized, ok := set.html[kind]; ok {
+				s.html[kind] = localized
+			}
+		}
+		for kind, tmpl := range defaultSet.text {
+			s.text[kind] = tmpl
+			if localized, ok := set.text[kind]; ok {
+				s.text[kind] = localized
+			}
+		}
+		tags = append(tags, tag)
+		t.sets = append(t.sets, s)
+	}
+	t.matcher = language.NewMatcher(tags)
+	return t
+}
+
+// get returns the emails templates set that better matches the locale
+// provided. The default locale set is returned when the locale provided is
+// empty or there is no suitable match for it.
+func (t *emailTemplates) get(locale string) *emailTemplateSet {
+	_, i := language.MatchStrings(t.matcher, locale)
+	return t.sets[i]
+}
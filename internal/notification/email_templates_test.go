@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestEmailTemplates(t *testing.T) {
+	esNewReleaseSubjectTmpl := template.Must(template.New("").Parse(
+		`Publicada la versión {{ .Package.version }} de {{ .Package.name }}`,
+	))
+	tmpls := newEmailTemplates(map[language.Tag]*emailTemplateSet{
+		language.English: emailTemplateSets[language.English],
+		language.Spanish: {
+			subject: map[hub.EventKind]*template.Template{
+				hub.NewRelease: esNewReleaseSubjectTmpl,
+			},
+		},
+	})
+	enSet := emailTemplateSets[language.English]
+
+	t.Run("default locale templates set is complete", func(t *testing.T) {
+		t.Parallel()
+		for _, kind := range []hub.EventKind{
+			hub.NewRelease,
+			hub.NewPackage,
+			hub.RepositoryOwnershipClaim,
+			hub.RepositoryOwnershipTransfer,
+			hub.RepositoryScanningErrors,
+			hub.RepositoryTrackingErrors,
+			hub.WebhookSuspended,
+			hub.SavedSearchNewMatches,
+			hub.PackageNewQuestion,
+			hub.RepositoryVerifiedPublisherChange,
+		} {
+			assert.NotNil(t, enSet.subject[kind])
+			assert.NotNil(t, enSet.html[kind])
+			assert.NotNil(t, enSet.text[kind])
+		}
+	})
+
+	t.Run("default locale templates set is used when no match is found", func(t *testing.T) {
+		t.Parallel()
+		for _, locale := range []string{"", "en", "en-US", "fr", "invalid_locale"} {
+			assert.Equal(t, enSet, tmpls.get(locale), locale)
+		}
+	})
+
+	t.Run("localized templates set is used when a match is found", func(t *testing.T) {
+		t.Parallel()
+		for _, locale := range []string{"es", "es-ES", "es-419", "fr;q=0.9, es;q=0.8"} {
+			set := tmpls.get(locale)
+			assert.Equal(t, esNewReleaseSubjectTmpl, set.subject[hub.NewRelease], locale)
+			assert.Equal(t, enSet.html[hub.NewRelease], set.html[hub.NewRelease], locale)
+			assert.Equal(t, enSet.subject[hub.RepositoryTrackingErrors], set.subject[hub.RepositoryTrackingErrors], locale)
+		}
+	})
+
+	t.Run("subject rendered using localized template", func(t *testing.T) {
+		t.Parallel()
+		var subject bytes.Buffer
+		err := tmpls.get("es").subject[hub.NewRelease].Execute(&subject, &hub.PackageNotificationTemplateData{
+			Package: map[string]interface{}{
+				"name":    "package1",
+				"version": "1.0.0",
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "Publicada la versión 1.0.0 de package1", subject.String())
+	})
+}
@@ -0,0 +1,105 @@
+package notification
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultMaxPayloadSize           = 256 * 1024
+	defaultMaxTemplateExecutionTime = 2 * time.Second
+)
+
+var (
+	// ErrPayloadTooLarge indicates that the webhook payload rendered exceeds
+	// the maximum size allowed.
+	ErrPayloadTooLarge = errors.New("payload too large")
+
+	// ErrTemplateExecutionTimeout indicates that the webhook template took
+	// longer than allowed to be rendered.
+	ErrTemplateExecutionTimeout = errors.New("template execution timed out")
+)
+
+// PayloadLimits represents the limits enforced when rendering the payloads
+// delivered to webhooks. A zero value disables the corresponding limit.
+type PayloadLimits struct {
+	MaxSize          int
+	MaxExecutionTime time.Duration
+}
+
+// DefaultPayloadLimits represents the limits used when none are configured.
+var DefaultPayloadLimits = &PayloadLimits{
+	MaxSize:          defaultMaxPayloadSize,
+	MaxExecutionTime: defaultMaxTemplateExecutionTime,
+}
+
+// NewPayloadLimits creates a new PayloadLimits instance from the configuration
+// provided, using the default values for the limits not set.
+func NewPayloadLimits(cfg *viper.Viper) *PayloadLimits {
+	l := &PayloadLimits{
+		MaxSize:          defaultMaxPayloadSize,
+		MaxExecutionTime: defaultMaxTemplateExecutionTime,
+	}
+	if cfg.IsSet("notifications.webhooks.maxPayloadSize") {
+		l.MaxSize = cfg.GetInt("notifications.webhooks.maxPayloadSize")
+	}
+	if cfg.IsSet("notifications.webhooks.maxTemplateExecutionTime") {
+		l.MaxExecutionTime = cfg.GetDuration("notifications.webhooks.maxTemplateExecutionTime")
+	}
+	return l
+}
+
+// checkSize verifies that the payload provided does not exceed the maximum
+// size allowed.
+func (l *PayloadLimits) checkSize(payload []byte) error {
+	if l.MaxSize > 0 && len(payload) > l.MaxSize {
+		return fmt.Errorf("%w: %d bytes (max %d)", ErrPayloadTooLarge, len(payload), l.MaxSize)
+	}
+	return nil
+}
+
+// executeTemplate renders the template provided using the given data. The
+// execution is aborted as soon as the output exceeds the maximum size allowed
+// or it takes longer than permitted.
+func (l *PayloadLimits) executeTemplate(tmpl *template.Template, data interface{}) ([]byte, error) {
+	buf := &limitedBuffer{max: l.MaxSize}
+	errC := make(chan error, 1)
+	go func() {
+		errC <- tmpl.Execute(buf, data)
+	}()
+	var timeout <-chan time.Time
+	if l.MaxExecutionTime > 0 {
+		timer := time.NewTimer(l.MaxExecutionTime)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case err := <-errC:
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case <-timeout:
+		return nil, fmt.Errorf("%w (max %s)", ErrTemplateExecutionTimeout, l.MaxExecutionTime)
+	}
+}
+
+// limitedBuffer is a bytes buffer that refuses writes once the maximum size
+// provided is exceeded.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+// Write implements the io.Writer interface.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.max > 0 && b.Len()+len(p) > b.max {
+		return 0, fmt.Errorf("%w (max %d bytes)", ErrPayloadTooLarge, b.max)
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPayloadLimits(t *testing.T) {
+	t.Run("default limits used when none are configured", func(t *testing.T) {
+		t.Parallel()
+		l := NewPayloadLimits(viper.New())
+		assert.Equal(t, DefaultPayloadLimits, l)
+	})
+
+	t.Run("configured limits used when provided", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("notifications.webhooks.maxPayloadSize", 1024)
+		cfg.Set("notifications.webhooks.maxTemplateExecutionTime", "500ms")
+		l := NewPayloadLimits(cfg)
+		assert.Equal(t, 1024, l.MaxSize)
+		assert.Equal(t, 500*time.Millisecond, l.MaxExecutionTime)
+	})
+}
+
+func TestPayloadLimits(t *testing.T) {
+	t.Run("payload within limits", func(t *testing.T) {
+		t.Parallel()
+		l := &PayloadLimits{MaxSize: 10, MaxExecutionTime: time.Second}
+		tmpl := template.Must(template.New("").Parse("{{ .Name }}"))
+		payload, err := l.executeTemplate(tmpl, map[string]string{"Name": "test"})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("test"), payload)
+		assert.NoError(t, l.checkSize(payload))
+	})
+
+	t.Run("template output exceeds the maximum size allowed", func(t *testing.T) {
+		t.Parallel()
+		l := &PayloadLimits{MaxSize: 10}
+		tmpl := template.Must(template.New("").Parse(`{{ range .Items }}{{ . }}{{ end }}`))
+		_, err := l.executeTemplate(tmpl, map[string][]string{"Items": {"0123456789", "0123456789"}})
+		assert.True(t, errors.Is(err, ErrPayloadTooLarge))
+	})
+
+	t.Run("payload exceeds the maximum size allowed", func(t *testing.T) {
+		t.Parallel()
+		l := &PayloadLimits{MaxSize: 10}
+		err := l.checkSize([]byte("01234567890"))
+		assert.True(t, errors.Is(err, ErrPayloadTooLarge))
+	})
+
+	t.Run("template execution takes longer than allowed", func(t *testing.T) {
+		t.Parallel()
+		l := &PayloadLimits{MaxExecutionTime: 10 * time.Millisecond}
+		tmpl := template.Must(template.New("").Funcs(template.FuncMap{
+			"sleep": func() string {
+				time.Sleep(100 * time.Millisecond)
+				return ""
+			},
+		}).Parse("{{ sleep }}"))
+		_, err := l.executeTemplate(tmpl, nil)
+		assert.True(t, errors.Is(err, ErrTemplateExecutionTimeout))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/jackc/pgx/v4"
@@ -11,9 +12,22 @@ import (
 )
 
 const (
+	// MaxDeliveryAttempts represents the maximum number of times the delivery
+	// of a notification will be attempted before moving it to the dead-letter
+	// queue.
+	MaxDeliveryAttempts = 5
+
+	// MaxWebhookConsecutiveFailures represents the number of consecutive
+	// failed deliveries after which a webhook will be suspended.
+	MaxWebhookConsecutiveFailures = 20
+
 	// Database queries
 	addNotificationDBQ          = `select add_notification($1::jsonb)`
-	getPendingNotificationDBQ   = `select get_pending_notification()`
+	deferNotificationDBQ        = `select defer_notification($1::uuid, $2::timestamptz)`
+	getPendingNotificationsDBQ  = `select get_pending_notifications($1::int)`
+	registerDeliveryFailureDBQ  = `select register_notification_delivery_failure($1::uuid, $2::text, $3::text, $4::int)`
+	registerWebhookDeliveryDBQ  = `select register_webhook_delivery($1::jsonb, $2::int)`
+	renewNotificationsLeaseDBQ  = `select renew_notifications_lease($1::uuid[])`
 	updateNotificationStatusDBQ = `select update_notification_status($1::uuid, $2::boolean, $3::text)`
 )
 
@@ -51,17 +65,93 @@ func (m *Manager) Add(ctx context.Context, tx pgx.Tx, n *hub.Notification) error
 	return err
 }
 
-// GetPending returns a pending notification to be delivered if available.
-func (m *Manager) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notification, error) {
+// Defer postpones the delivery of the provided notification until the time
+// given, without counting it as a delivery attempt.
+func (m *Manager) Defer(ctx context.Context, tx pgx.Tx, notificationID string, until time.Time) error {
+	if _, err := uuid.FromString(notificationID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+	}
+	_, err := tx.Exec(ctx, deferNotificationDBQ, notificationID, until)
+	return err
+}
+
+// GetPending claims a batch of pending notifications to be delivered, up to
+// the limit provided. The notifications returned are leased for some minutes,
+// so they won't be claimed again in the meantime unless their delivery
+// outcome is recorded and they are still pending.
+func (m *Manager) GetPending(ctx context.Context, tx pgx.Tx, limit int) ([]*hub.Notification, error) {
 	var dataJSON []byte
-	if err := tx.QueryRow(ctx, getPendingNotificationDBQ).Scan(&dataJSON); err != nil {
+	if err := tx.QueryRow(ctx, getPendingNotificationsDBQ, limit).Scan(&dataJSON); err != nil {
 		return nil, err
 	}
-	var n *hub.Notification
-	if err := json.Unmarshal(dataJSON, &n); err != nil {
+	var notifications []*hub.Notification
+	if err := json.Unmarshal(dataJSON, &notifications); err != nil {
 		return nil, err
 	}
-	return n, nil
+	return notifications, nil
+}
+
+// RegisterDeliveryFailure registers a failed delivery attempt for the provided
+// notification. Once the maximum number of attempts is reached, the
+// notification is moved to the dead-letter queue along with the payload that
+// could not be delivered.
+func (m *Manager) RegisterDeliveryFailure(
+	ctx context.Context,
+	tx pgx.Tx,
+	notificationID string,
+	payload []byte,
+	deliveryErr error,
+) error {
+	if _, err := uuid.FromString(notificationID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+	}
+	var deliveryErrStr string
+	if deliveryErr != nil {
+		deliveryErrStr = deliveryErr.Error()
+	}
+	_, err := tx.Exec(
+		ctx,
+		registerDeliveryFailureDBQ,
+		notificationID,
+		string(payload),
+		deliveryErrStr,
+		MaxDeliveryAttempts,
+	)
+	return err
+}
+
+// RegisterWebhookDelivery registers the provided webhook delivery attempt.
+// Webhooks that reach the maximum number of consecutive failed deliveries are
+// suspended, and their owners are notified about it.
+func (m *Manager) RegisterWebhookDelivery(ctx context.Context, tx pgx.Tx, d *hub.WebhookDelivery) error {
+	if _, err := uuid.FromString(d.WebhookID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if d.NotificationID != "" {
+		if _, err := uuid.FromString(d.NotificationID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+		}
+	}
+	dJSON, _ := json.Marshal(d)
+	_, err := tx.Exec(ctx, registerWebhookDeliveryDBQ, dJSON, MaxWebhookConsecutiveFailures)
+	return err
+}
+
+// RenewLease extends the lease of the provided notifications, so that they
+// are not claimed again while they are still waiting to be delivered. It
+// returns false when the lease of any of them could not be renewed because it
+// had already expired.
+func (m *Manager) RenewLease(ctx context.Context, tx pgx.Tx, notificationIDs []string) (bool, error) {
+	for _, notificationID := range notificationIDs {
+		if _, err := uuid.FromString(notificationID); err != nil {
+			return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+		}
+	}
+	var renewed bool
+	if err := tx.QueryRow(ctx, renewNotificationsLeaseDBQ, notificationIDs).Scan(&renewed); err != nil {
+		return false, err
+	}
+	return renewed, nil
 }
 
 // UpdateStatus the provided notification status in the database.
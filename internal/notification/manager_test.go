@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
@@ -104,16 +105,52 @@ func TestAdd(t *testing.T) {
 	})
 }
 
+func TestDefer(t *testing.T) {
+	ctx := context.Background()
+	notificationID := "00000000-0000-0000-0000-000000000001"
+	until := time.Date(2021, 6, 16, 8, 0, 0, 0, time.UTC)
+
+	t.Run("invalid notification id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager()
+		err := m.Defer(ctx, nil, "invalid", until)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid notification id")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, deferNotificationDBQ, notificationID, until).Return(tests.ErrFakeDB)
+		m := NewManager()
+
+		err := m.Defer(ctx, tx, notificationID, until)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, deferNotificationDBQ, notificationID, until).Return(nil)
+		m := NewManager()
+
+		err := m.Defer(ctx, tx, notificationID, until)
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+	})
+}
+
 func TestGetPending(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		tx := &tests.TXMock{}
-		tx.On("QueryRow", ctx, getPendingNotificationDBQ).Return(nil, tests.ErrFakeDB)
+		tx.On("QueryRow", ctx, getPendingNotificationsDBQ, 10).Return(nil, tests.ErrFakeDB)
 		m := NewManager()
 
-		dataJSON, err := m.GetPending(ctx, tx)
+		dataJSON, err := m.GetPending(ctx, tx, 10)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		tx.AssertExpectations(t)
@@ -121,37 +158,206 @@ func TestGetPending(t *testing.T) {
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
-		expectedNotification := &hub.Notification{
-			NotificationID: "notificationID",
-			Event: &hub.Event{
-				EventKind:      hub.NewRelease,
-				PackageID:      "packageID",
-				PackageVersion: "1.0.0",
+		expectedNotifications := []*hub.Notification{
+			{
+				NotificationID: "notificationID1",
+				Event: &hub.Event{
+					EventKind:      hub.NewRelease,
+					PackageID:      "packageID",
+					PackageVersion: "1.0.0",
+				},
+				User: &hub.User{
+					Email: "user1@email.com",
+				},
 			},
-			User: &hub.User{
-				Email: "user1@email.com",
+			{
+				NotificationID: "notificationID2",
+				Event: &hub.Event{
+					EventKind:      hub.NewRelease,
+					PackageID:      "packageID",
+					PackageVersion: "1.0.0",
+				},
+				Webhook: &hub.Webhook{
+					WebhookID: "webhookID",
+					URL:       "http://webhook1.url",
+				},
 			},
 		}
 
 		tx := &tests.TXMock{}
-		tx.On("QueryRow", ctx, getPendingNotificationDBQ).Return([]byte(`
-		{
-			"notification_id": "notificationID",
-			"event": {
-				"event_kind": 0,
-				"package_id": "packageID",
-				"package_version": "1.0.0"
+		tx.On("QueryRow", ctx, getPendingNotificationsDBQ, 10).Return([]byte(`
+		[
+			{
+				"notification_id": "notificationID1",
+				"event": {
+					"event_kind": 0,
+					"package_id": "packageID",
+					"package_version": "1.0.0"
+				},
+				"user": {
+					"email": "user1@email.com"
+				}
 			},
-			"user": {
-				"email": "user1@email.com"
+			{
+				"notification_id": "notificationID2",
+				"event": {
+					"event_kind": 0,
+					"package_id": "packageID",
+					"package_version": "1.0.0"
+				},
+				"webhook": {
+					"webhook_id": "webhookID",
+					"url": "http://webhook1.url"
+				}
 			}
-		}
+		]
 		`), nil)
 		m := NewManager()
 
-		n, err := m.GetPending(ctx, tx)
+		notifications, err := m.GetPending(ctx, tx, 10)
 		require.NoError(t, err)
-		assert.Equal(t, expectedNotification, n)
+		assert.Equal(t, expectedNotifications, notifications)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestRegisterDeliveryFailure(t *testing.T) {
+	ctx := context.Background()
+	notificationID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager()
+		err := m.RegisterDeliveryFailure(ctx, nil, "invalidNotificationID", nil, nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid notification id")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, registerDeliveryFailureDBQ, notificationID, "payload", tests.ErrFake.Error(), MaxDeliveryAttempts).
+			Return(tests.ErrFakeDB)
+		m := NewManager()
+
+		err := m.RegisterDeliveryFailure(ctx, tx, notificationID, []byte("payload"), tests.ErrFake)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, registerDeliveryFailureDBQ, notificationID, "payload", tests.ErrFake.Error(), MaxDeliveryAttempts).
+			Return(nil)
+		m := NewManager()
+
+		err := m.RegisterDeliveryFailure(ctx, tx, notificationID, []byte("payload"), tests.ErrFake)
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestRegisterWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	d := &hub.WebhookDelivery{
+		WebhookID:      "00000000-0000-0000-0000-000000000001",
+		NotificationID: "00000000-0000-0000-0000-000000000001",
+		Latency:        100,
+		StatusCode:     200,
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			d      *hub.WebhookDelivery
+		}{
+			{
+				"invalid webhook id",
+				&hub.WebhookDelivery{
+					WebhookID: "invalid",
+				},
+			},
+			{
+				"invalid notification id",
+				&hub.WebhookDelivery{
+					WebhookID:      "00000000-0000-0000-0000-000000000001",
+					NotificationID: "invalid",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager()
+				err := m.RegisterWebhookDelivery(ctx, nil, tc.d)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, registerWebhookDeliveryDBQ, mock.Anything, MaxWebhookConsecutiveFailures).Return(tests.ErrFakeDB)
+		m := NewManager()
+
+		err := m.RegisterWebhookDelivery(ctx, tx, d)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("Exec", ctx, registerWebhookDeliveryDBQ, mock.Anything, MaxWebhookConsecutiveFailures).Return(nil)
+		m := NewManager()
+
+		err := m.RegisterWebhookDelivery(ctx, tx, d)
+		assert.NoError(t, err)
+		tx.AssertExpectations(t)
+	})
+}
+
+func TestRenewLease(t *testing.T) {
+	ctx := context.Background()
+	notificationIDs := []string{
+		"00000000-0000-0000-0000-000000000001",
+		"00000000-0000-0000-0000-000000000002",
+	}
+
+	t.Run("invalid notification id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager()
+		renewed, err := m.RenewLease(ctx, nil, []string{"invalid"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid notification id")
+		assert.False(t, renewed)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("QueryRow", ctx, renewNotificationsLeaseDBQ, notificationIDs).Return(nil, tests.ErrFakeDB)
+		m := NewManager()
+
+		renewed, err := m.RenewLease(ctx, tx, notificationIDs)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.False(t, renewed)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		tx := &tests.TXMock{}
+		tx.On("QueryRow", ctx, renewNotificationsLeaseDBQ, notificationIDs).Return(true, nil)
+		m := NewManager()
+
+		renewed, err := m.RenewLease(ctx, tx, notificationIDs)
+		assert.NoError(t, err)
+		assert.True(t, renewed)
 		tx.AssertExpectations(t)
 	})
 }
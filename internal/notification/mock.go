@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/jackc/pgx/v4"
@@ -19,13 +20,43 @@ func (m *ManagerMock) Add(ctx context.Context, tx pgx.Tx, n *hub.Notification) e
 	return args.Error(0)
 }
 
+// Defer implements the NotificationManager interface.
+func (m *ManagerMock) Defer(ctx context.Context, tx pgx.Tx, notificationID string, until time.Time) error {
+	args := m.Called(ctx, tx, notificationID, until)
+	return args.Error(0)
+}
+
 // GetPending implements the NotificationManager interface.
-func (m *ManagerMock) GetPending(ctx context.Context, tx pgx.Tx) (*hub.Notification, error) {
-	args := m.Called(ctx, tx)
-	data, _ := args.Get(0).(*hub.Notification)
+func (m *ManagerMock) GetPending(ctx context.Context, tx pgx.Tx, limit int) ([]*hub.Notification, error) {
+	args := m.Called(ctx, tx, limit)
+	data, _ := args.Get(0).([]*hub.Notification)
 	return data, args.Error(1)
 }
 
+// RegisterDeliveryFailure implements the NotificationManager interface.
+func (m *ManagerMock) RegisterDeliveryFailure(
+	ctx context.Context,
+	tx pgx.Tx,
+	notificationID string,
+	payload []byte,
+	deliveryErr error,
+) error {
+	args := m.Called(ctx, tx, notificationID, payload, deliveryErr)
+	return args.Error(0)
+}
+
+// RegisterWebhookDelivery implements the NotificationManager interface.
+func (m *ManagerMock) RegisterWebhookDelivery(ctx context.Context, tx pgx.Tx, d *hub.WebhookDelivery) error {
+	args := m.Called(ctx, tx, d)
+	return args.Error(0)
+}
+
+// RenewLease implements the NotificationManager interface.
+func (m *ManagerMock) RenewLease(ctx context.Context, tx pgx.Tx, notificationIDs []string) (bool, error) {
+	args := m.Called(ctx, tx, notificationIDs)
+	return args.Bool(0), args.Error(1)
+}
+
 // UpdateStatus implements the NotificationManager interface.
 func (m *ManagerMock) UpdateStatus(
 	ctx context.Context,
@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// quietHoursEnd checks if the time provided falls inside the quiet hours
+// window set in the notifications preferences given. When it does, the time
+// at which the window ends is returned, so that deliveries can be deferred
+// until then. Invalid or empty windows are ignored.
+func quietHoursEnd(p *hub.NotificationsPreferences, now time.Time) (time.Time, bool) {
+	if p == nil || p.QuietHours == nil {
+		return time.Time{}, false
+	}
+	loc, err := time.LoadLocation(p.QuietHours.TimeZone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	start, err := time.Parse("15:04", p.QuietHours.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := time.Parse("15:04", p.QuietHours.End)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	t := now.In(loc)
+	windowStart := time.Date(t.Year(), t.Month(), t.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+	windowEnd := time.Date(t.Year(), t.Month(), t.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+	switch {
+	case windowStart.Equal(windowEnd):
+		return time.Time{}, false
+	case windowStart.Before(windowEnd):
+		// Window within the same day (i.e. 13:00 - 15:00)
+		if !t.Before(windowStart) && t.Before(windowEnd) {
+			return windowEnd, true
+		}
+	default:
+		// Window spanning midnight (i.e. 22:00 - 07:00)
+		if !t.Before(windowStart) {
+			return windowEnd.AddDate(0, 0, 1), true
+		}
+		if t.Before(windowEnd) {
+			return windowEnd, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,135 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuietHoursEnd(t *testing.T) {
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+
+	testCases := []struct {
+		description   string
+		prefs         *hub.NotificationsPreferences
+		now           time.Time
+		expectedEnd   time.Time
+		expectedQuiet bool
+	}{
+		{
+			"no preferences",
+			nil,
+			time.Date(2021, 6, 16, 23, 0, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"no quiet hours",
+			&hub.NotificationsPreferences{},
+			time.Date(2021, 6, 16, 23, 0, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"invalid time zone",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Invalid/Zone"},
+			},
+			time.Date(2021, 6, 16, 23, 0, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"invalid start time",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "25:00", End: "07:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 16, 23, 0, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"empty window",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "07:00", End: "07:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 16, 7, 0, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"same day window, before it starts",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "13:00", End: "15:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 16, 12, 59, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"same day window, inside it",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "13:00", End: "15:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 16, 13, 0, 0, 0, time.UTC),
+			time.Date(2021, 6, 16, 15, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"same day window, once it has ended",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "13:00", End: "15:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 16, 15, 0, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"window spanning midnight, before midnight",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "22:00", End: "07:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 16, 23, 0, 0, 0, time.UTC),
+			time.Date(2021, 6, 17, 7, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"window spanning midnight, after midnight",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "22:00", End: "07:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 17, 6, 30, 0, 0, time.UTC),
+			time.Date(2021, 6, 17, 7, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			"window spanning midnight, outside it",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "22:00", End: "07:00", TimeZone: "UTC"},
+			},
+			time.Date(2021, 6, 17, 12, 0, 0, 0, time.UTC),
+			time.Time{},
+			false,
+		},
+		{
+			"window evaluated in the time zone provided",
+			&hub.NotificationsPreferences{
+				QuietHours: &hub.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Madrid"},
+			},
+			time.Date(2021, 6, 16, 21, 0, 0, 0, time.UTC),
+			time.Date(2021, 6, 17, 7, 0, 0, 0, madrid),
+			true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+			end, quiet := quietHoursEnd(tc.prefs, tc.now)
+			assert.Equal(t, tc.expectedQuiet, quiet)
+			assert.True(t, tc.expectedEnd.Equal(end))
+		})
+	}
+}
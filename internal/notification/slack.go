@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// slackEscaper escapes the control characters that Slack uses in its mrkdwn
+// text format.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// prepareSlackPayload prepares a Slack Block Kit message for the package
+// notification template data provided.
+func prepareSlackPayload(tmplData *hub.PackageNotificationTemplateData) ([]byte, error) {
+	i := getReleaseInfo(tmplData)
+
+	// Header
+	blocks := []map[string]interface{}{
+		{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*<%s|%s>* version *%s* %s",
+					i.url,
+					slackEscaper.Replace(i.name),
+					slackEscaper.Replace(i.version),
+					i.action(),
+				),
+			},
+		},
+	}
+
+	// Highlights
+	var highlights []string
+	if i.deprecated {
+		highlights = append(highlights, ":warning: This version has been deprecated")
+	}
+	if i.containsSecurityUpdates {
+		highlights = append(highlights, ":shield: This version contains security updates")
+	}
+	if i.prerelease {
+		highlights = append(highlights, ":construction: This version is a pre-release")
+	}
+	if len(highlights) > 0 {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "context",
+			"elements": []map[string]interface{}{
+				{
+					"type": "mrkdwn",
+					"text": strings.Join(highlights, "\n"),
+				},
+			},
+		})
+	}
+
+	// Vulnerabilities
+	if len(i.vulnerabilities) > 0 {
+		var text strings.Builder
+		text.WriteString("*Vulnerabilities*")
+		for _, d := range i.vulnerabilitiesDescriptions() {
+			text.WriteString("\n• " + slackEscaper.Replace(d))
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": text.String(),
+			},
+		})
+	}
+
+	// Changes
+	if len(i.changes) > 0 {
+		var text strings.Builder
+		text.WriteString("*Changes*")
+		for _, change := range i.changes {
+			text.WriteString("\n• " + slackEscaper.Replace(change))
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type": "section",
+			"text": map[string]interface{}{
+				"type": "mrkdwn",
+				"text": text.String(),
+			},
+		})
+	}
+
+	// Repository
+	blocks = append(blocks, map[string]interface{}{
+		"type": "context",
+		"elements": []map[string]interface{}{
+			{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("Repository: *%s* (%s) | Publisher: *%s*",
+					slackEscaper.Replace(i.repoName),
+					slackEscaper.Replace(i.repoKind),
+					slackEscaper.Replace(i.publisher),
+				),
+			},
+		},
+	})
+
+	return json.Marshal(map[string]interface{}{
+		"text":   i.title(),
+		"blocks": blocks,
+	})
+}
@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// teamsThemeColor represents the accent color used in the Teams cards.
+const teamsThemeColor = "417598"
+
+// prepareTeamsPayload prepares a Microsoft Teams message card for the package
+// notification template data provided.
+func prepareTeamsPayload(tmplData *hub.PackageNotificationTemplateData) ([]byte, error) {
+	i := getReleaseInfo(tmplData)
+
+	// Highlights and changes
+	var text strings.Builder
+	if i.deprecated {
+		text.WriteString("**This version has been deprecated**\n\n")
+	}
+	if i.containsSecurityUpdates {
+		text.WriteString("**This version contains security updates**\n\n")
+	}
+	if i.prerelease {
+		text.WriteString("**This version is a pre-release**\n\n")
+	}
+	if len(i.vulnerabilities) > 0 {
+		text.WriteString("**Vulnerabilities**\n\n")
+		for _, d := range i.vulnerabilitiesDescriptions() {
+			text.WriteString(fmt.Sprintf("- %s\n", d))
+		}
+		text.WriteString("\n")
+	}
+	if len(i.changes) > 0 {
+		text.WriteString("**Changes**\n\n")
+		for _, change := range i.changes {
+			text.WriteString(fmt.Sprintf("- %s\n", change))
+		}
+	}
+
+	section := map[string]interface{}{
+		"activityTitle": i.title(),
+		"facts": []map[string]interface{}{
+			{"name": "Repository", "value": fmt.Sprintf("%s (%s)", i.repoName, i.repoKind)},
+			{"name": "Publisher", "value": i.publisher},
+		},
+		"markdown": true,
+	}
+	if text.Len() > 0 {
+		section["text"] = strings.TrimSpace(text.String())
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"themeColor": teamsThemeColor,
+		"summary":    i.title(),
+		"sections":   []map[string]interface{}{section},
+		"potentialAction": []map[string]interface{}{
+			{
+				"@type": "OpenUri",
+				"name":  "View package",
+				"targets": []map[string]interface{}{
+					{"os": "default", "uri": i.url},
+				},
+			},
+		},
+	})
+}
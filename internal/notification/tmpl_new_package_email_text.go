@@ -0,0 +1,15 @@
+package notification
+
+import "text/template"
+
+var newPackageEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Package.name }} ({{ .Package.repository.publisher }})
+
+New package published in repository {{ .Package.repository.name }} (version {{ .Package.version }}).
+
+View in Artifact Hub: {{ .Package.url }}
+
+--
+Didn't subscribe to Artifact Hub notifications for new packages in {{ .Package.repository.name }} repository? You can manage your subscriptions here: {{ .BaseURL }}/control-panel/settings/subscriptions
+
+© Artifact Hub - {{ .BaseURL }}
+`))
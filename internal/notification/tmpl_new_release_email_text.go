@@ -0,0 +1,22 @@
+package notification
+
+import "text/template"
+
+var newReleaseEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Package.name }} ({{ .Package.repository.publisher }})
+
+Version {{ .Package.version }} has been released.
+{{ if .Package.prerelease }}
+This package version is a pre-release and it is not ready for production use.
+{{ end }}{{ if .Package.containsSecurityUpdates }}
+This package version contains security updates.
+{{ end }}{{ if .Package.changes }}
+CHANGES:{{ range $change := .Package.changes }}
+- {{ $change }}{{ end }}
+{{ end }}
+View in Artifact Hub: {{ .Package.url }}
+
+--
+Didn't subscribe to Artifact Hub notifications for {{ .Package.name }} package? You can unsubscribe here: {{ if .UnsubscribeURL }}{{ .UnsubscribeURL }}{{ else }}{{ .BaseURL }}/control-panel/settings/subscriptions{{ end }}
+
+© Artifact Hub - {{ .BaseURL }}
+`))
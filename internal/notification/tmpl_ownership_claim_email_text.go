@@ -0,0 +1,11 @@
+package notification
+
+import "text/template"
+
+var ownershipClaimEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Repository.name }} repository has been transferred to {{ if .Repository.userAlias }}user {{ .Repository.userAlias }}{{ else }}organization {{ .Repository.organizationName }}{{ end }}
+
+{{ if .Repository.userAlias }}User {{ .Repository.userAlias }}{{ else }}Organization {{ .Repository.organizationName }}{{ end }} claimed the ownership of the {{ .Repository.name }} repository. After successfully verifying that the claiming entity owns it, we have proceeded with the transfer.
+
+--
+© Artifact Hub - {{ .BaseURL }}
+`))
@@ -0,0 +1,11 @@
+package notification
+
+import "text/template"
+
+var ownershipTransferEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Repository.name }} repository has been transferred to {{ if .Repository.userAlias }}user {{ .Repository.userAlias }}{{ else }}organization {{ .Repository.organizationName }}{{ end }}
+
+The ownership of the {{ .Repository.name }} repository has been transferred{{ with .Event.previousOwner }} from {{ if .userAlias }}user {{ .userAlias }}{{ else }}organization {{ .organizationName }}{{ end }}{{ end }} to {{ if .Repository.userAlias }}user {{ .Repository.userAlias }}{{ else }}organization {{ .Repository.organizationName }}{{ end }}. The repository packages, as well as their stats and webhooks references, have been moved along with it.
+
+--
+© Artifact Hub - {{ .BaseURL }}
+`))
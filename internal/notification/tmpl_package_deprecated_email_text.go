@@ -0,0 +1,17 @@
+package notification
+
+import "text/template"
+
+var packageDeprecatedEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Package.name }} ({{ .Package.repository.publisher }})
+
+Version {{ .Package.version }} has been deprecated.
+
+This package version has been deprecated by its publisher and its use is no longer recommended.
+
+View in Artifact Hub: {{ .Package.url }}
+
+--
+Didn't subscribe to Artifact Hub notifications for {{ .Package.name }} package? You can unsubscribe here: {{ if .UnsubscribeURL }}{{ .UnsubscribeURL }}{{ else }}{{ .BaseURL }}/control-panel/settings/subscriptions{{ end }}
+
+© Artifact Hub - {{ .BaseURL }}
+`))
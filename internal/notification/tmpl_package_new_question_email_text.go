@@ -0,0 +1,17 @@
+package notification
+
+import "text/template"
+
+var packageNewQuestionEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Package.name }} ({{ .Package.repository.publisher }})
+
+New question posted by {{ .Event.question.userAlias }}:
+
+{{ .Event.question.body }}
+
+Answer in Artifact Hub: {{ .Package.url }}?modal=questions
+
+--
+You are receiving this notification because you publish the {{ .Package.name }} package. You can opt out here: {{ .BaseURL }}/control-panel/settings/subscriptions
+
+© Artifact Hub - {{ .BaseURL }}
+`))
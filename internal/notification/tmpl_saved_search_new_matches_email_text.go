@@ -0,0 +1,15 @@
+package notification
+
+import "text/template"
+
+var savedSearchNewMatchesEmailTextTmpl = template.Must(template.New("").Parse(`{{ .SavedSearch.newMatches }} new package(s) started matching your saved search {{ .SavedSearch.name }}.
+{{ range .Packages }}
+- {{ .name }} {{ .version }}{{ with .repository }} ({{ .name }}){{ end }}: {{ .url }}{{ end }}
+
+View in Artifact Hub: {{ .BaseURL }}/control-panel/saved-searches
+
+--
+Don't want to receive notifications for this saved search anymore? You can disable them here: {{ .BaseURL }}/control-panel/saved-searches
+
+© Artifact Hub - {{ .BaseURL }}
+`))
@@ -131,9 +131,9 @@ var scanningErrorsEmailTmpl = template.Must(template.New("").Parse(`
                                 <code style="overflow-x: auto;">
                                   {{ range $index, $scanningError := .Repository.lastScanningErrors }}
                                     {{ if $index }}
-                                      <p style="font-family: 'Courier New', Courier, monospace; color: #C5C8C6 !important; border-top: 1px solid #333; padding-top: 15px; font-size: 13px; ">{{ $scanningError }}</p>
+                                      <p style="font-family: 'Courier New', Courier, monospace; color: #C5C8C6 !important; border-top: 1px solid #333; padding-top: 15px; font-size: 13px; "><span style="color: #F0C674 !important;">[{{ $scanningError.class }}]{{ with $scanningError.packageName }} {{ . }}{{ end }}{{ with $scanningError.packageVersion }} {{ . }}{{ end }}</span><br />{{ $scanningError.message }}</p>
                                     {{ else }}
-                                      <p style="font-family: 'Courier New', Courier, monospace; color: #C5C8C6 !important;font-size: 13px;">{{ $scanningError }}</p>
+                                      <p style="font-family: 'Courier New', Courier, monospace; color: #C5C8C6 !important;font-size: 13px;"><span style="color: #F0C674 !important;">[{{ $scanningError.class }}]{{ with $scanningError.packageName }} {{ . }}{{ end }}{{ with $scanningError.packageVersion }} {{ . }}{{ end }}</span><br />{{ $scanningError.message }}</p>
                                     {{end}}
                                   {{ end }}
                                 </code>
@@ -179,6 +179,13 @@ var scanningErrorsEmailTmpl = template.Must(template.New("").Parse(`
             <!-- START FOOTER -->
             <div class="footer" style="clear: both; Margin-top: 10px; text-align: center; width: 100%;">
               <table border="0" cellpadding="0" cellspacing="0" style="border-collapse: separate; mso-table-lspace: 0pt; mso-table-rspace: 0pt; width: 100%;">
+                {{ if .UnsubscribeURL }}
+                <tr>
+                  <td class="content-block powered-by" style="font-family: sans-serif; vertical-align: top; padding-bottom: 10px; padding-top: 10px; font-size: 10px; color: #545454; text-align: center;">
+                    <p style="color: #545454; font-size: 10px; text-align: center; text-decoration: none;">Don't want to receive notifications about scanning errors in {{ .Repository.name }} repository? You can unsubscribe <a href="{{ .UnsubscribeURL }}" target="_blank" style="text-decoration: underline; color: #545454;">here</a>.</p>
+                  </td>
+                </tr>
+                {{ end }}
                 <tr>
                   <td class="content-block powered-by" style="font-family: sans-serif; vertical-align: top; padding-bottom: 10px; padding-top: 10px; font-size: 12px; color: #39596C; text-align: center;">
                     <a href="{{ .BaseURL }}" style="color: #39596C; font-size: 12px; text-align: center; text-decoration: none;">© Artifact Hub</a>
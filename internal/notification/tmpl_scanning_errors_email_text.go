@@ -0,0 +1,18 @@
+package notification
+
+import "text/template"
+
+var scanningErrorsEmailTextTmpl = template.Must(template.New("").Parse(`We encountered some errors while scanning the packages in repository {{ .Repository.name }} for security vulnerabilities.
+
+If you find something in them that doesn't make sense, or there is anything you need help with, please file an issue here: https://github.com/artifacthub/hub/issues
+
+ERRORS LOG:{{ range $scanningError := .Repository.lastScanningErrors }}
+[{{ $scanningError.class }}]{{ with $scanningError.packageName }} {{ . }}{{ end }}{{ with $scanningError.packageVersion }} {{ . }}{{ end }} {{ $scanningError.message }}{{ end }}
+
+View in Artifact Hub: {{ .BaseURL }}/control-panel/repositories?modal=scanning&user-alias={{ with .Repository.userAlias }}{{ . }}{{ end }}&org-name={{ with .Repository.organizationName }}{{ . }}{{ end }}&repo-name={{ .Repository.name }}
+
+--
+{{ if .UnsubscribeURL }}Don't want to receive notifications about scanning errors in {{ .Repository.name }} repository? You can unsubscribe here: {{ .UnsubscribeURL }}
+
+{{ end }}© Artifact Hub - {{ .BaseURL }}
+`))
@@ -0,0 +1,18 @@
+package notification
+
+import "text/template"
+
+var securityAlertEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Package.name }} ({{ .Package.repository.publisher }})
+
+New security vulnerabilities have been found in version {{ .Package.version }}.
+
+VULNERABILITIES:{{ range $vulnerability := .Event.vulnerabilities }}
+- {{ $vulnerability.id }} ({{ $vulnerability.severity }}) in {{ $vulnerability.pkgName }} {{ $vulnerability.installedVersion }}{{ if $vulnerability.fixedVersion }}, fixed in {{ $vulnerability.fixedVersion }}{{ end }} [{{ $vulnerability.image }}]{{ end }}
+
+View in Artifact Hub: {{ .Package.url }}
+
+--
+Didn't subscribe to Artifact Hub notifications for {{ .Package.name }} package? You can unsubscribe here: {{ if .UnsubscribeURL }}{{ .UnsubscribeURL }}{{ else }}{{ .BaseURL }}/control-panel/settings/subscriptions{{ end }}
+
+© Artifact Hub - {{ .BaseURL }}
+`))
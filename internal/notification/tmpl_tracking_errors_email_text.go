@@ -0,0 +1,20 @@
+package notification
+
+import "text/template"
+
+var trackingErrorsEmailTextTmpl = template.Must(template.New("").Parse(`We encountered some errors while tracking repository {{ .Repository.name }}.
+
+Some or all of these errors may be just warnings, and it's possible that your packages have been still indexed properly. However, it'd be great if you can take a look at them just in case there is something missing or failing in your repository that may affect how your content is displayed on Artifact Hub.
+
+If you find something in them that doesn't make sense, or there is anything you need help with, please file an issue here: https://github.com/artifacthub/hub/issues
+
+ERRORS LOG:{{ range $trackingError := .Repository.lastTrackingErrors }}
+[{{ $trackingError.class }}]{{ with $trackingError.packageName }} {{ . }}{{ end }}{{ with $trackingError.packageVersion }} {{ . }}{{ end }} {{ $trackingError.message }}{{ end }}
+
+View in Artifact Hub: {{ .BaseURL }}/control-panel/repositories?modal=tracking&user-alias={{ with .Repository.userAlias }}{{ . }}{{ end }}&org-name={{ with .Repository.organizationName }}{{ . }}{{ end }}&repo-name={{ .Repository.name }}
+
+--
+{{ if .UnsubscribeURL }}Don't want to receive notifications about tracking errors in {{ .Repository.name }} repository? You can unsubscribe here: {{ .UnsubscribeURL }}
+
+{{ end }}© Artifact Hub - {{ .BaseURL }}
+`))
@@ -0,0 +1,11 @@
+package notification
+
+import "text/template"
+
+var verifiedPublisherChangeEmailTextTmpl = template.Must(template.New("").Parse(`{{ .Repository.name }} repository is {{ if not .Event.verified }}no longer {{ end }}a verified publisher
+
+The verified publisher status of the {{ .Repository.name }} repository has been {{ if .Event.verified }}granted{{ else }}revoked{{ end }}. The verified publisher badge is displayed on the repository packages only while this status is granted.
+
+--
+© Artifact Hub - {{ .BaseURL }}
+`))
@@ -0,0 +1,16 @@
+package notification
+
+import "text/template"
+
+var webhookSuspendedEmailTextTmpl = template.Must(template.New("").Parse(`Webhook {{ .Webhook.name }}{{ with .Webhook.organizationName }} from organization {{ . }}{{ end }} has been suspended after {{ .Webhook.consecutiveFailures }} consecutive failed deliveries to {{ .Webhook.url }}.
+
+No more notifications will be delivered to this webhook until it is enabled again. Please check that the endpoint is reachable and working as expected, and enable the webhook from the control panel once the problem has been solved.
+
+LAST ERROR:
+{{ .Webhook.lastError }}
+
+View in Artifact Hub: {{ .BaseURL }}/control-panel/settings/webhooks
+
+--
+© Artifact Hub - {{ .BaseURL }}
+`))
@@ -3,10 +3,12 @@ package notification
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
-	"strings"
 	"sync"
 	"text/template"
 	"time"
@@ -14,6 +16,7 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/handlers/pkg"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/subscription"
 	"github.com/artifacthub/hub/internal/util"
 	"github.com/jackc/pgx/v4"
 	"github.com/patrickmn/go-cache"
@@ -21,18 +24,38 @@ import (
 )
 
 const (
-	pauseOnEmptyQueue = 30 * time.Second
-	pauseOnError      = 10 * time.Second
+	defaultPollingInterval = 30 * time.Second
+	pauseOnError           = 10 * time.Second
+	defaultBatchSize       = 10
+
+	// maxDeliveryResponseBodySize represents the maximum number of bytes of
+	// the webhook endpoint response body that will be kept when registering a
+	// delivery.
+	maxDeliveryResponseBodySize = 1024
 
 	// DefaultPayloadContentType represents the default content type used for
 	// webhooks notifications.
 	DefaultPayloadContentType = "application/cloudevents+json"
+
+	// BatchPayloadContentType represents the content type used for webhooks
+	// notifications delivered in batches.
+	BatchPayloadContentType = "application/cloudevents-batch+json"
+
+	// DeliveryIDHeader represents the header used to send the delivery id to
+	// webhooks endpoints, so that they can deduplicate retried deliveries.
+	DeliveryIDHeader = "X-ArtifactHub-Delivery"
 )
 
 var (
 	// ErrRetryable is meant to be used as a wrapper for other errors to
 	// indicate the error is not final and the operation should be retried.
 	ErrRetryable = errors.New("retryable error")
+
+	// ErrDeliveryFailed is meant to be used as a wrapper for other errors to
+	// indicate that the notification could not be delivered to the recipient.
+	// The delivery will be attempted again later until the maximum number of
+	// attempts is reached, and then the notification will be dead-lettered.
+	ErrDeliveryFailed = errors.New("delivery failed")
 )
 
 // HTTPClient defines the methods an HTTPClient implementation must provide.
@@ -42,10 +65,16 @@ type HTTPClient interface {
 
 // Worker is in charge of delivering notifications to their intended recipients.
 type Worker struct {
-	svc        *Services
-	cache      *cache.Cache
-	baseURL    string
-	httpClient HTTPClient
+	svc             *Services
+	cache           *cache.Cache
+	baseURL         string
+	httpClient      HTTPClient
+	batchSize       int
+	pollingInterval time.Duration
+	unsubscribeKey  string
+	emailTmpls      *emailTemplates
+	payloadLimits   *PayloadLimits
+	wakeUpC         chan struct{}
 }
 
 // NewWorker creates a new Worker instance.
@@ -54,22 +83,66 @@ func NewWorker(
 	c *cache.Cache,
 	baseURL string,
 	httpClient HTTPClient,
+	opts ...func(w *Worker),
 ) *Worker {
-	return &Worker{
-		svc:        svc,
-		cache:      c,
-		baseURL:    baseURL,
-		httpClient: httpClient,
+	w := &Worker{
+		svc:             svc,
+		cache:           c,
+		baseURL:         baseURL,
+		httpClient:      httpClient,
+		batchSize:       defaultBatchSize,
+		pollingInterval: defaultPollingInterval,
+		emailTmpls:      newEmailTemplates(emailTemplateSets),
+		payloadLimits:   DefaultPayloadLimits,
+		wakeUpC:         make(chan struct{}, 1),
+	}
+	for _, o := range opts {
+		o(w)
+	}
+	return w
+}
+
+// WithBatchSize allows providing the maximum number of notifications a Worker
+// instance will claim at once.
+func WithBatchSize(n int) func(w *Worker) {
+	return func(w *Worker) {
+		w.batchSize = n
+	}
+}
+
+// WithPollingInterval allows providing how often a Worker instance will check
+// for pending notifications when the queue is empty and it is not woken up.
+func WithPollingInterval(interval time.Duration) func(w *Worker) {
+	return func(w *Worker) {
+		w.pollingInterval = interval
+	}
+}
+
+// WithPayloadLimits allows providing the limits a Worker instance will enforce
+// when rendering the payloads delivered to webhooks.
+func WithPayloadLimits(limits *PayloadLimits) func(w *Worker) {
+	return func(w *Worker) {
+		w.payloadLimits = limits
+	}
+}
+
+// WithUnsubscribeKey allows providing the key a Worker instance will use to
+// sign the unsubscribe tokens included in the notifications emails.
+func WithUnsubscribeKey(key string) func(w *Worker) {
+	return func(w *Worker) {
+		w.unsubscribeKey = key
 	}
 }
 
-// Run is the main loop of the worker. It calls processNotification periodically
-// until it's asked to stop via the context provided.
+// Run is the main loop of the worker. It calls processNotifications
+// periodically until it's asked to stop via the context provided. When the
+// queue is empty, the worker waits until it's woken up or the polling
+// interval elapses, whatever happens first.
 func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for {
-		err := w.processNotification(ctx)
+		err := w.processNotifications(ctx)
 		switch {
 		case err == nil:
 			select {
@@ -79,7 +152,8 @@ func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 			}
 		case errors.Is(err, pgx.ErrNoRows):
 			select {
-			case <-time.After(pauseOnEmptyQueue):
+			case <-w.wakeUpC:
+			case <-time.After(w.pollingInterval):
 			case <-ctx.Done():
 				return
 			}
@@ -93,157 +167,592 @@ func (w *Worker) Run(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
-// processNotification gets a pending notification from the database and
-// delivers it.
-func (w *Worker) processNotification(ctx context.Context) error {
-	return util.DBTransact(ctx, w.svc.DB, func(tx pgx.Tx) error {
-		// Get pending notification to process
-		n, err := w.svc.NotificationManager.GetPending(ctx, tx)
+// wakeUp notifies the worker that there may be new pending notifications, so
+// that it does not wait until the polling interval elapses to process them.
+func (w *Worker) wakeUp() {
+	select {
+	case w.wakeUpC <- struct{}{}:
+	default:
+	}
+}
+
+// processNotifications claims a batch of pending notifications from the
+// database and delivers them. Notifications are claimed in a short
+// transaction, so that no locks are held while they are being delivered, and
+// the outcome of each of them is recorded separately once done. Notifications
+// that could not be processed due to a retryable error are left pending, so
+// they will be claimed again once their lease expires.
+func (w *Worker) processNotifications(ctx context.Context) error {
+	// Claim batch of pending notifications to process
+	var notifications []*hub.Notification
+	err := util.DBTransact(ctx, w.svc.DB, func(tx pgx.Tx) error {
+		var err error
+		notifications, err = w.svc.NotificationManager.GetPending(ctx, tx, w.batchSize)
 		if err != nil {
-			if !errors.Is(err, pgx.ErrNoRows) {
-				log.Error().Err(err).Msg("processNotification: error getting pending notification")
-			}
+			log.Error().Err(err).Msg("processNotifications: error getting pending notifications")
 			return err
 		}
+		if len(notifications) == 0 {
+			return pgx.ErrNoRows
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-		// Process notification
-		switch {
-		case n.User != nil:
-			if w.svc.ES != nil {
-				err = w.deliverEmailNotification(ctx, n)
-			} else {
-				err = email.ErrSenderNotAvailable
+	// Process notifications. Notifications for webhooks that receive batches
+	// are grouped by webhook and delivered together.
+	var retryableErr error
+	batches := make(map[string][]*hub.Notification)
+	for _, n := range notifications {
+		if n.Webhook != nil && n.Webhook.Batch {
+			batches[n.Webhook.WebhookID] = append(batches[n.Webhook.WebhookID], n)
+			continue
+		}
+		if err := w.processNotification(ctx, n); err != nil {
+			retryableErr = err
+		}
+	}
+	for _, batch := range batches {
+		if err := w.processWebhookBatch(ctx, batch); err != nil {
+			retryableErr = err
+		}
+	}
+	return retryableErr
+}
+
+// processNotification delivers the provided notification, updating its
+// status once done. Email notifications falling inside the recipient's quiet
+// hours are deferred until they end. Only retryable errors are returned.
+func (w *Worker) processNotification(ctx context.Context, n *hub.Notification) error {
+	// Defer email notification if the recipient is in quiet hours
+	if n.User != nil {
+		if until, ok := quietHoursEnd(n.User.NotificationsPreferences, time.Now()); ok {
+			err := w.record(ctx, func(tx pgx.Tx) error {
+				return w.svc.NotificationManager.Defer(ctx, tx, n.NotificationID, until)
+			})
+			if err != nil {
+				log.Error().Err(err).Msg("processNotification: error deferring notification")
 			}
-		case n.Webhook != nil:
-			err = w.deliverWebhookNotification(ctx, n)
+			return nil
 		}
-		if errors.Is(err, ErrRetryable) {
-			log.Error().Err(err).Msg("processNotification: error delivering notification")
-			return err
+	}
+
+	// Renew notification lease before delivering it
+	if err := w.renewLease(ctx, n); err != nil {
+		log.Error().Err(err).Msg("processNotification: error renewing notification lease")
+		return err
+	}
+
+	// Deliver notification
+	var payload []byte
+	var deliveryErr error
+	switch {
+	case n.User != nil:
+		if w.svc.ES != nil {
+			deliveryErr = w.deliverEmailNotification(ctx, n)
+		} else {
+			deliveryErr = email.ErrSenderNotAvailable
 		}
+	case n.Webhook != nil:
+		payload, deliveryErr = w.deliverWebhookNotification(ctx, n)
+	}
+	if errors.Is(deliveryErr, ErrRetryable) {
+		log.Error().Err(deliveryErr).Msg("processNotification: error delivering notification")
+		return deliveryErr
+	}
 
-		// Update notification status
-		err = w.svc.NotificationManager.UpdateStatus(ctx, tx, n.NotificationID, true, err)
+	// Register delivery failure so that it's retried or dead-lettered
+	if errors.Is(deliveryErr, ErrDeliveryFailed) {
+		err := w.record(ctx, func(tx pgx.Tx) error {
+			return w.svc.NotificationManager.RegisterDeliveryFailure(ctx, tx, n.NotificationID, payload, deliveryErr)
+		})
 		if err != nil {
-			log.Error().Err(err).Msg("processNotification: error updating notification status")
+			log.Error().Err(err).Msg("processNotification: error registering delivery failure")
 		}
 		return nil
+	}
+
+	// Update notification status
+	err := w.record(ctx, func(tx pgx.Tx) error {
+		return w.svc.NotificationManager.UpdateStatus(ctx, tx, n.NotificationID, true, deliveryErr)
 	})
+	if err != nil {
+		log.Error().Err(err).Msg("processNotification: error updating notification status")
+	}
+	return nil
+}
+
+// processWebhookBatch delivers the provided notifications, all of them for the
+// same webhook, in a single request, updating their status once done. Only
+// retryable errors are returned.
+func (w *Worker) processWebhookBatch(ctx context.Context, batch []*hub.Notification) error {
+	// Renew notifications lease before delivering them
+	if err := w.renewLease(ctx, batch...); err != nil {
+		log.Error().Err(err).Msg("processWebhookBatch: error renewing notifications lease")
+		return err
+	}
+
+	// Deliver notifications
+	payload, deliveryErr := w.deliverWebhookBatch(ctx, batch)
+	if errors.Is(deliveryErr, ErrRetryable) {
+		log.Error().Err(deliveryErr).Msg("processWebhookBatch: error delivering notifications")
+		return deliveryErr
+	}
+
+	// Update notifications status, registering the delivery failure if needed
+	for _, n := range batch {
+		n := n
+		if errors.Is(deliveryErr, ErrDeliveryFailed) {
+			err := w.record(ctx, func(tx pgx.Tx) error {
+				return w.svc.NotificationManager.RegisterDeliveryFailure(ctx, tx, n.NotificationID, payload, deliveryErr)
+			})
+			if err != nil {
+				log.Error().Err(err).Msg("processWebhookBatch: error registering delivery failure")
+			}
+			continue
+		}
+		err := w.record(ctx, func(tx pgx.Tx) error {
+			return w.svc.NotificationManager.UpdateStatus(ctx, tx, n.NotificationID, true, deliveryErr)
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("processWebhookBatch: error updating notification status")
+		}
+	}
+	return nil
+}
+
+// renewLease extends the lease of the provided notifications, so that it does
+// not expire while the notifications claimed before them are being delivered,
+// which would allow other workers to claim and deliver them again. When the
+// lease cannot be renewed the notifications must not be delivered, so a
+// retryable error is returned and they are left pending.
+func (w *Worker) renewLease(ctx context.Context, notifications ...*hub.Notification) error {
+	notificationIDs := make([]string, 0, len(notifications))
+	for _, n := range notifications {
+		notificationIDs = append(notificationIDs, n.NotificationID)
+	}
+	var renewed bool
+	err := w.record(ctx, func(tx pgx.Tx) error {
+		var err error
+		renewed, err = w.svc.NotificationManager.RenewLease(ctx, tx, notificationIDs)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRetryable, err)
+	}
+	if !renewed {
+		return fmt.Errorf("%w: notifications lease expired", ErrRetryable)
+	}
+	return nil
+}
+
+// record runs the function provided, used to record the outcome of a
+// delivery, in its own short transaction.
+func (w *Worker) record(ctx context.Context, recordFn func(tx pgx.Tx) error) error {
+	return util.DBTransact(ctx, w.svc.DB, recordFn)
 }
 
 // deliverEmailNotification delivers the provided notification via email.
 func (w *Worker) deliverEmailNotification(ctx context.Context, n *hub.Notification) error {
 	// Prepare email data
-	var emailData email.Data
-	cKey := "emailData.%" + n.Event.EventID
-	cValue, ok := w.cache.Get(cKey)
-	if ok {
-		emailData = cValue.(email.Data)
-	} else {
-		var err error
-		emailData, err = w.prepareEmailData(ctx, n.Event)
-		if err != nil {
-			return fmt.Errorf("%w: error preparing email data: %v", ErrRetryable, err)
-		}
-		w.cache.SetDefault(cKey, emailData)
+	emailData, err := w.prepareEmailData(ctx, n)
+	if err != nil {
+		return fmt.Errorf("%w: error preparing email data: %v", ErrRetryable, err)
 	}
 	emailData.To = n.User.Email
 
 	// Send email
-	return w.svc.ES.SendEmail(&emailData)
+	if err := w.svc.ES.SendEmail(&emailData); err != nil {
+		return fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+	return nil
 }
 
 // deliverWebhookNotification delivers the provided notification via webhook.
-func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notification) error {
+// The payload built for the notification is returned so that it can be kept
+// in case the delivery fails. Every delivery attempt is registered, including
+// the response received from the webhook endpoint.
+func (w *Worker) deliverWebhookNotification(ctx context.Context, n *hub.Notification) ([]byte, error) {
 	// Get template data
 	tmplData, err := w.preparePkgNotificationTemplateData(ctx, n.Event)
 	if err != nil {
-		return fmt.Errorf("%w: %v", ErrRetryable, err)
+		return nil, fmt.Errorf("%w: %v", ErrRetryable, err)
+	}
+
+	// Prepare payload
+	payload, contentType, err := PrepareWebhookPayload(n.Webhook, tmplData, w.payloadLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call webhook endpoint
+	d, err := SendWebhookPayload(w.httpClient, n.Webhook, DeliveryID(n), payload, contentType)
+	d.NotificationID = n.NotificationID
+	if err := w.record(ctx, func(tx pgx.Tx) error {
+		return w.svc.NotificationManager.RegisterWebhookDelivery(ctx, tx, d)
+	}); err != nil {
+		log.Error().Err(err).Msg("deliverWebhookNotification: error registering webhook delivery")
+	}
+	return payload, err
+}
+
+// deliverWebhookBatch delivers the provided notifications, all of them for the
+// same webhook, in a single request. The batch payload is returned so that it
+// can be kept in case the delivery fails. The delivery attempt is registered
+// once for the whole batch, using the delivery id of its first notification.
+func (w *Worker) deliverWebhookBatch(ctx context.Context, batch []*hub.Notification) ([]byte, error) {
+	// Get template data
+	tmplData := make([]*hub.PackageNotificationTemplateData, 0, len(batch))
+	for _, n := range batch {
+		data, err := w.preparePkgNotificationTemplateData(ctx, n.Event)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrRetryable, err)
+		}
+		tmplData = append(tmplData, data)
 	}
 
 	// Prepare payload
+	wh := batch[0].Webhook
+	payload, contentType, err := PrepareWebhookBatchPayload(wh, tmplData, w.payloadLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call webhook endpoint
+	d, err := SendWebhookPayload(w.httpClient, wh, DeliveryID(batch[0]), payload, contentType)
+	if err := w.record(ctx, func(tx pgx.Tx) error {
+		return w.svc.NotificationManager.RegisterWebhookDelivery(ctx, tx, d)
+	}); err != nil {
+		log.Error().Err(err).Msg("deliverWebhookBatch: error registering webhook delivery")
+	}
+	return payload, err
+}
+
+// DeliveryID returns the id of the current delivery attempt of the provided
+// notification. It is derived from the notification id and the attempt
+// number, so it remains stable across redeliveries of the same attempt.
+func DeliveryID(n *hub.Notification) string {
+	return fmt.Sprintf("%s-%d", n.NotificationID, n.Attempts+1)
+}
+
+// SendWebhookPayload posts the payload provided to the webhook's endpoint.
+// The details of the delivery attempt, including the response received, are
+// returned so that they can be registered. An error wrapping ErrDeliveryFailed
+// is returned when the endpoint could not be reached or it responded with an
+// unexpected status code. Custom headers without a value are not sent, and
+// neither is the delivery id header when no delivery id is provided.
+func SendWebhookPayload(
+	hc HTTPClient,
+	wh *hub.Webhook,
+	deliveryID string,
+	payload []byte,
+	contentType string,
+) (*hub.WebhookDelivery, error) {
+	d := &hub.WebhookDelivery{
+		WebhookID:   wh.WebhookID,
+		DeliveryID:  deliveryID,
+		Payload:     string(payload),
+		ContentType: contentType,
+	}
+	req, _ := http.NewRequest("POST", wh.URL, bytes.NewReader(payload))
+	for _, h := range wh.Headers {
+		if h.Value != "" {
+			req.Header.Set(h.Name, h.Value)
+		}
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-ArtifactHub-Secret", wh.Secret)
+	if deliveryID != "" {
+		req.Header.Set(DeliveryIDHeader, deliveryID)
+	}
+	start := time.Now()
+	resp, err := hc.Do(req)
+	d.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		d.Error = err.Error()
+		return d, fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+	defer resp.Body.Close()
+	d.StatusCode = resp.StatusCode
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxDeliveryResponseBodySize))
+	d.ResponseBody = string(respBody)
+	if resp.StatusCode >= 400 {
+		err := fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		d.Error = err.Error()
+		return d, fmt.Errorf("%w: %v", ErrDeliveryFailed, err)
+	}
+	return d, nil
+}
+
+// PrepareWebhookPayload prepares the payload that will be sent to the webhook
+// provided from the notification template data given. It returns the payload
+// and the content type that should be used when delivering it. An error is
+// returned if the payload exceeds the limits provided.
+func PrepareWebhookPayload(
+	wh *hub.Webhook,
+	tmplData *hub.PackageNotificationTemplateData,
+	limits *PayloadLimits,
+) ([]byte, string, error) {
+	if limits == nil {
+		limits = DefaultPayloadLimits
+	}
+
+	// Prepare payload using the built-in format selected, if any
+	var payload []byte
+	var err error
+	switch wh.Format {
+	case "":
+	case hub.WebhookFormatSlack:
+		payload, err = prepareSlackPayload(tmplData)
+	case hub.WebhookFormatTeams:
+		payload, err = prepareTeamsPayload(tmplData)
+	case hub.WebhookFormatDiscord:
+		payload, err = prepareDiscordPayload(tmplData)
+	default:
+		return nil, "", fmt.Errorf("invalid format: %s", wh.Format)
+	}
+	if wh.Format != "" {
+		if err != nil {
+			return nil, "", err
+		}
+		if err := limits.checkSize(payload); err != nil {
+			return nil, "", err
+		}
+		return payload, "application/json", nil
+	}
+
+	// Prepare payload using the webhook's template (or the default one)
 	var tmpl *template.Template
-	if n.Webhook.Template != "" {
+	if wh.Template != "" {
 		var err error
-		tmpl, err = template.New("").Parse(n.Webhook.Template)
+		tmpl, err = template.New("").Parse(wh.Template)
 		if err != nil {
-			return err
+			return nil, "", fmt.Errorf("error parsing template: %w", err)
 		}
 	} else {
 		tmpl = DefaultWebhookPayloadTmpl
 	}
-	var payload bytes.Buffer
-	if err := tmpl.Execute(&payload, tmplData); err != nil {
-		return err
+	payload, err = limits.executeTemplate(tmpl, tmplData)
+	if err != nil {
+		return nil, "", fmt.Errorf("error executing template: %w", err)
 	}
-	contentType := n.Webhook.ContentType
+	contentType := wh.ContentType
 	if contentType == "" {
 		contentType = DefaultPayloadContentType
 	}
+	return payload, contentType, nil
+}
 
-	// Call webhook endpoint
-	req, _ := http.NewRequest("POST", n.Webhook.URL, &payload)
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("X-ArtifactHub-Secret", n.Webhook.Secret)
-	resp, err := w.httpClient.Do(req)
+// PrepareWebhookBatchPayload prepares the payload that will be sent to the
+// webhook provided when delivering several notifications at once. Each of the
+// notifications is rendered using the default payload template, and all of
+// them are delivered together as a CloudEvents batch. The limits provided
+// apply to the whole batch.
+func PrepareWebhookBatchPayload(
+	wh *hub.Webhook,
+	tmplData []*hub.PackageNotificationTemplateData,
+	limits *PayloadLimits,
+) ([]byte, string, error) {
+	if limits == nil {
+		limits = DefaultPayloadLimits
+	}
+	if wh.Format != "" || wh.Template != "" {
+		return nil, "", errors.New("batch deliveries require the default payload")
+	}
+	events := make([]json.RawMessage, 0, len(tmplData))
+	for _, data := range tmplData {
+		event, err := limits.executeTemplate(DefaultWebhookPayloadTmpl, data)
+		if err != nil {
+			return nil, "", fmt.Errorf("error executing template: %w", err)
+		}
+		events = append(events, event)
+	}
+	payload, err := json.Marshal(events)
 	if err != nil {
-		return err
+		return nil, "", fmt.Errorf("error preparing batch: %w", err)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if err := limits.checkSize(payload); err != nil {
+		return nil, "", err
 	}
-	return nil
+	return payload, BatchPayloadContentType, nil
 }
 
-// prepareEmailData prepares the email data corresponding to the event provided.
-func (w *Worker) prepareEmailData(ctx context.Context, e *hub.Event) (email.Data, error) {
-	var subject string
-	var emailBody bytes.Buffer
+// releaseInfo represents some details about a package release, extracted
+// from the notification template data, used by the built-in payload formats.
+// The release may have been just published, be the first one of a new package,
+// marked as deprecated or found to have new security vulnerabilities.
+type releaseInfo struct {
+	newPackage              bool
+	deprecated              bool
+	securityAlert           bool
+	vulnerabilities         []map[string]interface{}
+	name                    string
+	version                 string
+	url                     string
+	changes                 []string
+	containsSecurityUpdates bool
+	prerelease              bool
+	repoName                string
+	repoKind                string
+	publisher               string
+}
 
-	switch e.EventKind {
-	case hub.NewRelease:
-		tmplData, err := w.preparePkgNotificationTemplateData(ctx, e)
-		if err != nil {
-			return email.Data{}, err
-		}
-		subject = fmt.Sprintf("%s version %s released", tmplData.Package["name"], tmplData.Package["version"])
-		if err := newReleaseEmailTmpl.Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+// getReleaseInfo extracts the release details from the package
+// notification template data provided.
+func getReleaseInfo(tmplData *hub.PackageNotificationTemplateData) *releaseInfo {
+	i := &releaseInfo{}
+	i.newPackage = tmplData.Event["kind"] == "package.new"
+	i.deprecated = tmplData.Event["kind"] == "package.deprecated"
+	i.securityAlert = tmplData.Event["kind"] == "package.security-alert"
+	i.vulnerabilities, _ = tmplData.Event["vulnerabilities"].([]map[string]interface{})
+	i.name, _ = tmplData.Package["name"].(string)
+	i.version, _ = tmplData.Package["version"].(string)
+	i.url, _ = tmplData.Package["url"].(string)
+	i.changes, _ = tmplData.Package["changes"].([]string)
+	i.containsSecurityUpdates, _ = tmplData.Package["containsSecurityUpdates"].(bool)
+	i.prerelease, _ = tmplData.Package["prerelease"].(bool)
+	if repository, ok := tmplData.Package["repository"].(map[string]interface{}); ok {
+		i.repoName, _ = repository["name"].(string)
+		i.repoKind, _ = repository["kind"].(string)
+		i.publisher, _ = repository["publisher"].(string)
+	}
+	return i
+}
+
+// title returns a short title describing the release.
+func (i *releaseInfo) title() string {
+	return fmt.Sprintf("%s version %s %s", i.name, i.version, i.action())
+}
+
+// action returns the action performed on the release that triggered the
+// notification.
+func (i *releaseInfo) action() string {
+	switch {
+	case i.newPackage:
+		return "published as a new package"
+	case i.deprecated:
+		return "deprecated"
+	case i.securityAlert:
+		return "has new security vulnerabilities"
+	default:
+		return "released"
+	}
+}
+
+// vulnerabilitiesDescriptions returns a short description of each of the
+// vulnerabilities included in the release details.
+func (i *releaseInfo) vulnerabilitiesDescriptions() []string {
+	descriptions := make([]string, 0, len(i.vulnerabilities))
+	for _, v := range i.vulnerabilities {
+		d := fmt.Sprintf("%s (%s) in %s %s", v["id"], v["severity"], v["pkgName"], v["installedVersion"])
+		if fixedVersion, _ := v["fixedVersion"].(string); fixedVersion != "" {
+			d += ", fixed in " + fixedVersion
 		}
-	case hub.RepositoryScanningErrors:
-		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
+		descriptions = append(descriptions, d)
+	}
+	return descriptions
+}
+
+// prepareEmailData prepares the email data corresponding to the notification
+// provided. Emails are composed using the templates set that better matches
+// the preferred locale of the recipient, and include both an html and a plain
+// text version of the body, as well as a link the recipient can use to
+// unsubscribe from the notifications received, when possible.
+func (w *Worker) prepareEmailData(ctx context.Context, n *hub.Notification) (email.Data, error) {
+	var tmplData interface{}
+	e := n.Event
+	unsubscribeURL := w.prepareUnsubscribeURL(n)
+
+	// Prepare template data
+	switch e.EventKind {
+	case hub.NewRelease, hub.NewPackage, hub.PackageDeprecated, hub.SecurityAlert, hub.PackageNewQuestion:
+		pkgTmplData, err := w.preparePkgNotificationTemplateData(ctx, e)
 		if err != nil {
 			return email.Data{}, err
 		}
-		subject = fmt.Sprintf("Something went wrong scanning repository %s", tmplData.Repository["name"])
-		if err := scanningErrorsEmailTmpl.Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		tmplData = &pkgEmailTemplateData{
+			PackageNotificationTemplateData: pkgTmplData,
+			UnsubscribeURL:                  unsubscribeURL,
 		}
-	case hub.RepositoryTrackingErrors:
-		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
+	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors:
+		repoTmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
 		if err != nil {
 			return email.Data{}, err
 		}
-		subject = fmt.Sprintf("Something went wrong tracking repository %s", tmplData.Repository["name"])
-		if err := trackingErrorsEmailTmpl.Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		tmplData = &repoEmailTemplateData{
+			RepositoryNotificationTemplateData: repoTmplData,
+			UnsubscribeURL:                     unsubscribeURL,
 		}
-	case hub.RepositoryOwnershipClaim:
-		tmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
+	case hub.RepositoryOwnershipClaim, hub.RepositoryOwnershipTransfer, hub.RepositoryVerifiedPublisherChange:
+		repoTmplData, err := w.prepareRepoNotificationTemplateData(ctx, e)
 		if err != nil {
 			return email.Data{}, err
 		}
-		subject = fmt.Sprintf("%s repository ownership has been claimed", tmplData.Repository["name"])
-		if err := ownershipClaimEmailTmpl.Execute(&emailBody, tmplData); err != nil {
-			return email.Data{}, err
+		tmplData = repoTmplData
+	case hub.WebhookSuspended:
+		tmplData = w.prepareWebhookNotificationTemplateData(e)
+	case hub.SavedSearchNewMatches:
+		tmplData = w.prepareSavedSearchNotificationTemplateData(e)
+	}
+
+	// Render email using the templates corresponding to the user's locale
+	var locale string
+	if n.User != nil {
+		locale = n.User.Locale
+	}
+	tmpls := w.emailTmpls.get(locale)
+	subjectTmpl, ok := tmpls.subject[e.EventKind]
+	if !ok {
+		return email.Data{}, fmt.Errorf("unsupported event kind: %d", e.EventKind)
+	}
+	var subject, emailBody, emailTextBody bytes.Buffer
+	if err := subjectTmpl.Execute(&subject, tmplData); err != nil {
+		return email.Data{}, err
+	}
+	if err := tmpls.html[e.EventKind].Execute(&emailBody, tmplData); err != nil {
+		return email.Data{}, err
+	}
+	if err := tmpls.text[e.EventKind].Execute(&emailTextBody, tmplData); err != nil {
+		return email.Data{}, err
+	}
+	emailData := email.Data{
+		Subject:   subject.String(),
+		Body:      emailBody.Bytes(),
+		PlainBody: emailTextBody.Bytes(),
+	}
+	if unsubscribeURL != "" {
+		emailData.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + unsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
 		}
 	}
+	return emailData, nil
+}
 
-	return email.Data{
-		Subject: subject,
-		Body:    emailBody.Bytes(),
-	}, nil
+// prepareUnsubscribeURL returns the url the recipient of the notification
+// provided can use to unsubscribe from it without having to log in. An empty
+// string is returned when the notification does not support unsubscribing or
+// no key to sign unsubscribe tokens has been configured.
+func (w *Worker) prepareUnsubscribeURL(n *hub.Notification) string {
+	if w.unsubscribeKey == "" || n.User == nil || n.User.UserID == "" {
+		return ""
+	}
+	input := &hub.UnsubscribeInput{
+		UserID:    n.User.UserID,
+		EventKind: n.Event.EventKind,
+	}
+	switch n.Event.EventKind {
+	case hub.NewRelease, hub.PackageDeprecated, hub.SecurityAlert:
+		input.PackageID = n.Event.PackageID
+	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors:
+		input.RepositoryID = n.Event.RepositoryID
+	default:
+		return ""
+	}
+	token := subscription.NewUnsubscribeToken(w.unsubscribeKey, input)
+	return fmt.Sprintf("%s/api/v1/subscriptions/unsubscribe?token=%s", w.baseURL, token)
 }
 
 // preparePkgNotificationTemplateData prepares the data available to packages
@@ -270,38 +779,89 @@ func (w *Worker) preparePkgNotificationTemplateData(
 		w.cache.SetDefault(cKey, p)
 	}
 
-	// Prepare template data
+	return NewPackageNotificationTemplateData(w.baseURL, e, p), nil
+}
+
+// NewPackageNotificationTemplateData prepares the data available to packages
+// notifications templates for the event and package provided.
+func NewPackageNotificationTemplateData(
+	baseURL string,
+	e *hub.Event,
+	p *hub.Package,
+) *hub.PackageNotificationTemplateData {
 	var eventKindStr string
 	switch e.EventKind {
 	case hub.NewRelease:
 		eventKindStr = "package.new-release"
+	case hub.NewPackage:
+		eventKindStr = "package.new"
+	case hub.PackageDeprecated:
+		eventKindStr = "package.deprecated"
+	case hub.SecurityAlert:
+		eventKindStr = "package.security-alert"
+	case hub.PackageNewQuestion:
+		eventKindStr = "package.new-question"
 	}
 	publisher := p.Repository.OrganizationName
 	if publisher == "" {
 		publisher = p.Repository.UserAlias
 	}
+	event := map[string]interface{}{
+		"id":   e.EventID,
+		"kind": eventKindStr,
+	}
+	if e.EventKind == hub.SecurityAlert {
+		event["vulnerabilities"] = getVulnerabilitiesTemplateData(e)
+	}
+	if question, ok := e.Data["question"].(map[string]interface{}); ok {
+		event["question"] = map[string]interface{}{
+			"id":        question["comment_id"],
+			"body":      question["body"],
+			"userAlias": question["user_alias"],
+		}
+	}
 
 	return &hub.PackageNotificationTemplateData{
-		BaseURL: w.baseURL,
-		Event: map[string]interface{}{
-			"id":   e.EventID,
-			"kind": eventKindStr,
-		},
+		BaseURL: baseURL,
+		Event:   event,
 		Package: map[string]interface{}{
 			"name":                    p.Name,
 			"version":                 p.Version,
 			"logoImageID":             p.LogoImageID,
-			"url":                     pkg.BuildURL(w.baseURL, p, e.PackageVersion),
+			"url":                     pkg.BuildURL(baseURL, p, e.PackageVersion),
 			"changes":                 p.Changes,
 			"containsSecurityUpdates": p.ContainsSecurityUpdates,
 			"prerelease":              p.Prerelease,
+			"deprecated":              p.Deprecated,
 			"repository": map[string]interface{}{
 				"kind":      hub.GetKindName(p.Repository.Kind),
 				"name":      p.Repository.Name,
 				"publisher": publisher,
 			},
 		},
-	}, nil
+	}
+}
+
+// getVulnerabilitiesTemplateData extracts the vulnerabilities included in the
+// data of the security alert event provided, preparing them to be exposed to
+// the notifications templates.
+func getVulnerabilitiesTemplateData(e *hub.Event) []map[string]interface{} {
+	var vulnerabilities []*hub.Vulnerability
+	dataJSON, _ := json.Marshal(e.Data["vulnerabilities"])
+	_ = json.Unmarshal(dataJSON, &vulnerabilities)
+	data := make([]map[string]interface{}, 0, len(vulnerabilities))
+	for _, v := range vulnerabilities {
+		data = append(data, map[string]interface{}{
+			"id":               v.ID,
+			"severity":         v.Severity,
+			"image":            v.Image,
+			"pkgName":          v.PkgName,
+			"installedVersion": v.InstalledVersion,
+			"fixedVersion":     v.FixedVersion,
+			"title":            v.Title,
+		})
+	}
+	return data
 }
 
 // prepareRepoNotificationTemplateData prepares the data available to
@@ -334,25 +894,138 @@ func (w *Worker) prepareRepoNotificationTemplateData(
 		eventKindStr = "repository.tracking-errors"
 	case hub.RepositoryOwnershipClaim:
 		eventKindStr = "repository.ownership-claim"
+	case hub.RepositoryOwnershipTransfer:
+		eventKindStr = "repository.ownership-transfer"
+	case hub.RepositoryVerifiedPublisherChange:
+		eventKindStr = "repository.verified-publisher-change"
+	}
+	event := map[string]interface{}{
+		"id":   e.EventID,
+		"kind": eventKindStr,
+	}
+	if previousOwner, ok := e.Data["previous_owner"].(map[string]interface{}); ok {
+		event["previousOwner"] = map[string]interface{}{
+			"userAlias":        previousOwner["user_alias"],
+			"organizationName": previousOwner["organization_name"],
+		}
+	}
+	if verified, ok := e.Data["verified"].(bool); ok {
+		event["verified"] = verified
 	}
 
 	return &hub.RepositoryNotificationTemplateData{
 		BaseURL: w.baseURL,
-		Event: map[string]interface{}{
-			"id":   e.EventID,
-			"kind": eventKindStr,
-		},
+		Event:   event,
 		Repository: map[string]interface{}{
 			"kind":               hub.GetKindName(r.Kind),
 			"name":               r.Name,
 			"userAlias":          r.UserAlias,
 			"organizationName":   r.OrganizationName,
-			"lastScanningErrors": strings.Split(r.LastScanningErrors, "\n"),
-			"lastTrackingErrors": strings.Split(r.LastTrackingErrors, "\n"),
+			"lastScanningErrors": getRepositoryErrorsTemplateData(r.ScanningErrors),
+			"lastTrackingErrors": getRepositoryErrorsTemplateData(r.TrackingErrors),
 		},
 	}, nil
 }
 
+// getRepositoryErrorsTemplateData prepares the repository errors provided to
+// be exposed to the notifications templates.
+func getRepositoryErrorsTemplateData(errs []*hub.RepositoryError) []map[string]interface{} {
+	data := make([]map[string]interface{}, 0, len(errs))
+	for _, e := range errs {
+		data = append(data, map[string]interface{}{
+			"packageName":    e.PackageName,
+			"packageVersion": e.PackageVersion,
+			"class":          string(e.Class),
+			"message":        e.Message,
+			"ts":             e.TS,
+		})
+	}
+	return data
+}
+
+// prepareWebhookNotificationTemplateData prepares the data available to
+// webhooks notifications templates. All the webhook details needed are
+// included in the event data.
+func (w *Worker) prepareWebhookNotificationTemplateData(e *hub.Event) *webhookEmailTemplateData {
+	wh, _ := e.Data["webhook"].(map[string]interface{})
+	return &webhookEmailTemplateData{
+		BaseURL: w.baseURL,
+		Webhook: map[string]interface{}{
+			"name":                wh["name"],
+			"url":                 wh["url"],
+			"organizationName":    wh["organization_name"],
+			"consecutiveFailures": e.Data["consecutive_failures"],
+			"lastError":           e.Data["last_error"],
+		},
+	}
+}
+
+// prepareSavedSearchNotificationTemplateData prepares the data available to
+// saved searches notifications templates. The saved search details and the
+// new packages matching it are included in the event data.
+func (w *Worker) prepareSavedSearchNotificationTemplateData(e *hub.Event) *savedSearchEmailTemplateData {
+	ss, _ := e.Data["saved_search"].(map[string]interface{})
+	var packages []*hub.Package
+	dataJSON, _ := json.Marshal(e.Data["packages"])
+	_ = json.Unmarshal(dataJSON, &packages)
+	packagesData := make([]map[string]interface{}, 0, len(packages))
+	for _, p := range packages {
+		var repository map[string]interface{}
+		if p.Repository != nil {
+			repository = map[string]interface{}{
+				"kind": hub.GetKindName(p.Repository.Kind),
+				"name": p.Repository.Name,
+			}
+		} else {
+			p.Repository = &hub.Repository{}
+		}
+		packagesData = append(packagesData, map[string]interface{}{
+			"name":       p.Name,
+			"version":    p.Version,
+			"url":        pkg.BuildURL(w.baseURL, p, ""),
+			"repository": repository,
+		})
+	}
+	return &savedSearchEmailTemplateData{
+		BaseURL: w.baseURL,
+		SavedSearch: map[string]interface{}{
+			"id":         ss["saved_search_id"],
+			"name":       ss["name"],
+			"newMatches": e.Data["new_matches"],
+		},
+		Packages: packagesData,
+	}
+}
+
+// pkgEmailTemplateData represents the data available to packages
+// notifications emails templates.
+type pkgEmailTemplateData struct {
+	*hub.PackageNotificationTemplateData
+	UnsubscribeURL string
+}
+
+// repoEmailTemplateData represents the data available to repositories
+// notifications emails templates.
+type repoEmailTemplateData struct {
+	*hub.RepositoryNotificationTemplateData
+	UnsubscribeURL string
+}
+
+// webhookEmailTemplateData represents the data available to webhooks
+// notifications emails templates.
+type webhookEmailTemplateData struct {
+	BaseURL string
+	Webhook map[string]interface{}
+}
+
+// savedSearchEmailTemplateData represents the data available to saved searches
+// notifications emails templates.
+type savedSearchEmailTemplateData struct {
+	BaseURL     string
+	SavedSearch map[string]interface{}
+	Packages    []map[string]interface{}
+}
+
 // DefaultWebhookPayloadTmpl is the template used for the webhook payload when
 // the webhook uses the default template.
 var DefaultWebhookPayloadTmpl = template.Must(template.New("").Parse(`
@@ -375,7 +1048,15 @@ var DefaultWebhookPayloadTmpl = template.Must(template.New("").Parse(`
 				"name": "{{ .Package.repository.name }}",
 				"publisher": "{{ .Package.repository.publisher }}"
 			}
-		}
+		}{{ if .Event.vulnerabilities }},
+		"vulnerabilities": [{{ range $i, $v := .Event.vulnerabilities }}{{ if $i }}, {{ end }}{
+			"id": "{{ $v.id }}",
+			"severity": "{{ $v.severity }}",
+			"image": "{{ $v.image }}",
+			"pkgName": "{{ $v.pkgName }}",
+			"installedVersion": "{{ $v.installedVersion }}",
+			"fixedVersion": "{{ $v.fixedVersion }}"
+		}{{ end }}]{{ end }}
 	}
 }
 `))
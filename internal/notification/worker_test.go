@@ -2,6 +2,8 @@ package notification
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -33,6 +35,37 @@ func TestWorker(t *testing.T) {
 		EventKind:    hub.RepositoryTrackingErrors,
 		RepositoryID: "repositoryID",
 	}
+	e3 := &hub.Event{
+		EventID:        "eventID",
+		EventKind:      hub.PackageDeprecated,
+		PackageID:      "packageID",
+		PackageVersion: "1.0.0",
+	}
+	e4 := &hub.Event{
+		EventID:        "eventID",
+		EventKind:      hub.SecurityAlert,
+		PackageID:      "packageID",
+		PackageVersion: "1.0.0",
+		Data: map[string]interface{}{
+			"vulnerabilities": []interface{}{
+				map[string]interface{}{
+					"id":                "CVE-2021-1234",
+					"severity":          "HIGH",
+					"image":             "image1:1.0.0",
+					"pkg_name":          "openssl",
+					"installed_version": "1.1.1g-r0",
+					"fixed_version":     "1.1.1i-r0",
+					"title":             "openssl: sample vulnerability",
+				},
+			},
+		},
+	}
+	e5 := &hub.Event{
+		EventID:        "eventID",
+		EventKind:      hub.NewPackage,
+		PackageID:      "packageID",
+		PackageVersion: "1.0.0",
+	}
 	u := &hub.User{
 		Email: "user1@email.com",
 	}
@@ -79,13 +112,22 @@ func TestWorker(t *testing.T) {
 		Kind:             hub.Helm,
 		Name:             "repo1",
 		OrganizationName: "org1",
+		TrackingErrors: []*hub.RepositoryError{
+			{
+				PackageName:    "pkg1",
+				PackageVersion: "1.0.0",
+				Class:          hub.RepositoryErrorClassPackage,
+				Message:        "error preparing package",
+				TS:             1,
+			},
+		},
 	}
 
 	t.Run("error getting pending notification", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(nil, tests.ErrFake)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return(nil, tests.ErrFake)
 		sw.tx.On("Rollback", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
@@ -93,13 +135,54 @@ func TestWorker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("no pending notifications", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{}, nil)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("worker woken up while waiting for new notifications", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{}, nil).Once()
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n3}, nil).Once()
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.rm.On("GetByID", sw.ctx, e2.RepositoryID, false).Return(r, nil)
+		sw.es.On("SendEmail", mock.Anything).Return(nil)
+		emailSent := make(chan struct{})
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, nil).
+			Run(func(args mock.Arguments) { close(emailSent) }).
+			Return(nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{}, nil)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc, WithPollingInterval(time.Hour))
+		go w.Run(sw.ctx, sw.wg)
+		w.wakeUp()
+		select {
+		case <-emailSent:
+		case <-time.After(2 * time.Second):
+			t.Fatal("notification not delivered after waking up the worker")
+		}
+		sw.assertExpectations(t)
+	})
+
 	t.Run("error getting package preparing email data", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n1, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n1}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
-		sw.tx.On("Rollback", sw.ctx).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
 		go w.Run(sw.ctx, sw.wg)
@@ -110,23 +193,60 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n3, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n3}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(nil, tests.ErrFake)
-		sw.tx.On("Rollback", sw.ctx).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("retryable error leaves notification pending while the others are processed", func(t *testing.T) {
+		t.Parallel()
+		pkgNotification := &hub.Notification{
+			NotificationID: "notificationID1",
+			Event:          e1,
+			User:           u,
+		}
+		repoNotification := &hub.Notification{
+			NotificationID: "notificationID2",
+			Event:          e2,
+			User:           u,
+		}
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).
+			Return([]*hub.Notification{pkgNotification, repoNotification}, nil).Once()
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
+		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
+		sw.es.On("SendEmail", mock.Anything).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, repoNotification.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
 		go w.Run(sw.ctx, sw.wg)
 		sw.assertExpectations(t)
+		sw.nm.AssertNotCalled(t, "UpdateStatus", sw.ctx, sw.tx, pkgNotification.NotificationID, mock.Anything, mock.Anything)
+		sw.nm.AssertNotCalled(t, "RegisterDeliveryFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		sw.nm.AssertNotCalled(t, "Defer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("error sending package notification email", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n1, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n1}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.es.On("SendEmail", mock.Anything).Return(tests.ErrFake)
-		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, tests.ErrFake).Return(nil)
+		sw.nm.On("RegisterDeliveryFailure", sw.ctx, sw.tx, n1.NotificationID, []byte(nil), mock.Anything).
+			Run(func(args mock.Arguments) {
+				assert.True(t, errors.Is(args.Error(4), ErrDeliveryFailed))
+			}).
+			Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
@@ -138,10 +258,12 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n3, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n3}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
 		sw.es.On("SendEmail", mock.Anything).Return(tests.ErrFake)
-		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, tests.ErrFake).Return(nil)
+		sw.nm.On("RegisterDeliveryFailure", sw.ctx, sw.tx, n3.NotificationID, []byte(nil), mock.Anything).
+			Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
@@ -153,9 +275,148 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n1, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n1}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
-		sw.es.On("SendEmail", mock.Anything).Return(nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				assert.Equal(t, "package1 version 1.0.0 released", d.Subject)
+				assert.Contains(t, string(d.Body), "<html")
+				assert.Contains(t, string(d.PlainBody), "Version 1.0.0 has been released.")
+				assert.Contains(t, string(d.PlainBody), "This package version contains security updates.")
+				assert.Contains(t, string(d.PlainBody), "- Cool feature")
+				assert.NotContains(t, string(d.PlainBody), "<")
+			}).
+			Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("package deprecated email notification delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{
+				NotificationID: "notificationID",
+				Event:          e3,
+				User:           u,
+			},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				assert.Equal(t, "package1 version 1.0.0 deprecated", d.Subject)
+				assert.Contains(t, string(d.Body), "has been deprecated")
+				assert.Contains(t, string(d.PlainBody), "Version 1.0.0 has been deprecated.")
+			}).
+			Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("new package email notification delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{
+				NotificationID: "notificationID",
+				Event:          e5,
+				User:           u,
+			},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				assert.Equal(t, "New package package1 published in repository repo1", d.Subject)
+				assert.Contains(t, string(d.Body), "New package published in repository <b>repo1</b>")
+				assert.Contains(t, string(d.PlainBody), "New package published in repository repo1 (version 1.0.0).")
+			}).
+			Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("security alert email notification delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{
+				NotificationID: "notificationID",
+				Event:          e4,
+				User:           u,
+			},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				assert.Equal(t, "package1 version 1.0.0 has new security vulnerabilities", d.Subject)
+				assert.Contains(t, string(d.Body), "CVE-2021-1234")
+				assert.Contains(t, string(d.PlainBody), "CVE-2021-1234")
+				assert.Contains(t, string(d.PlainBody), "openssl")
+			}).
+			Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("webhook suspended email notification delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{
+				NotificationID: "notificationID",
+				Event: &hub.Event{
+					EventID:   "eventID",
+					EventKind: hub.WebhookSuspended,
+					Data: map[string]interface{}{
+						"webhook": map[string]interface{}{
+							"webhook_id": "webhookID",
+							"name":       "webhook1",
+							"url":        "http://webhook1.url",
+						},
+						"consecutive_failures": float64(20),
+						"last_error":           "connection refused",
+					},
+				},
+				User: u,
+			},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				assert.Equal(t, "Webhook webhook1 has been suspended", d.Subject)
+				assert.Contains(t, string(d.Body), "connection refused")
+				assert.Contains(t, string(d.PlainBody), "has been suspended after 20 consecutive failed deliveries to http://webhook1.url.")
+			}).
+			Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
@@ -168,7 +429,8 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n3, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n3}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
 		sw.es.On("SendEmail", mock.Anything).Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, nil).Return(nil)
@@ -179,13 +441,215 @@ func TestWorker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("repository ownership transfer email notification delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{
+				NotificationID: "notificationID",
+				Event: &hub.Event{
+					EventID:      "eventID",
+					EventKind:    hub.RepositoryOwnershipTransfer,
+					RepositoryID: "repositoryID",
+					Data: map[string]interface{}{
+						"previous_owner": map[string]interface{}{
+							"user_alias": "user1",
+						},
+					},
+				},
+				User: u,
+			},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				assert.Equal(t, "repo1 repository ownership has been transferred", d.Subject)
+				assert.Contains(t, string(d.PlainBody), "has been transferred from user user1 to organization org1.")
+			}).
+			Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("repository verified publisher change email notification delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{
+				NotificationID: "notificationID",
+				Event: &hub.Event{
+					EventID:      "eventID",
+					EventKind:    hub.RepositoryVerifiedPublisherChange,
+					RepositoryID: "repositoryID",
+					Data: map[string]interface{}{
+						"verified": false,
+						"source":   "admin",
+					},
+				},
+				User: u,
+			},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				assert.Equal(t, "repo1 repository verified publisher status has changed", d.Subject)
+				assert.Contains(t, string(d.PlainBody), "repo1 repository is no longer a verified publisher")
+				assert.Contains(t, string(d.PlainBody), "has been revoked.")
+			}).
+			Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("email notification deferred during recipient quiet hours", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now().UTC()
+		n := &hub.Notification{
+			NotificationID: "notificationID",
+			Event:          e1,
+			User: &hub.User{
+				Email: "user1@email.com",
+				NotificationsPreferences: &hub.NotificationsPreferences{
+					QuietHours: &hub.QuietHours{
+						Start:    now.Add(-1 * time.Hour).Format("15:04"),
+						End:      now.Add(1 * time.Hour).Format("15:04"),
+						TimeZone: "UTC",
+					},
+				},
+			},
+		}
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n}, nil)
+		sw.nm.On("Defer", sw.ctx, sw.tx, n.NotificationID, mock.Anything).
+			Run(func(args mock.Arguments) {
+				until := args.Get(3).(time.Time)
+				assert.WithinDuration(t, now.Add(1*time.Hour), until, 1*time.Minute)
+			}).
+			Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("email notification delivered with unsubscribe link", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{
+				NotificationID: "notificationID",
+				Event:          e2,
+				User: &hub.User{
+					UserID: "userID",
+					Email:  "user1@email.com",
+				},
+			},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.rm.On("GetByID", sw.ctx, "repositoryID", false).Return(r, nil)
+		sw.es.On("SendEmail", mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(0).(*email.Data)
+				token := subscription.NewUnsubscribeToken("key", &hub.UnsubscribeInput{
+					UserID:       "userID",
+					EventKind:    hub.RepositoryTrackingErrors,
+					RepositoryID: "repositoryID",
+				})
+				unsubscribeURL := "http://baseURL/api/v1/subscriptions/unsubscribe?token=" + token
+				assert.Equal(t, "user1@email.com", d.To)
+				assert.Equal(t, "<"+unsubscribeURL+">", d.Headers["List-Unsubscribe"])
+				assert.Equal(t, "List-Unsubscribe=One-Click", d.Headers["List-Unsubscribe-Post"])
+				assert.Contains(t, string(d.Body), unsubscribeURL)
+				assert.Contains(t, string(d.PlainBody), "You can unsubscribe here: "+unsubscribeURL)
+				assert.Contains(t, string(d.Body), "[package] pkg1 1.0.0</span><br />error preparing package")
+				assert.Contains(t, string(d.PlainBody), "[package] pkg1 1.0.0 error preparing package")
+			}).
+			Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n3.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "http://baseURL", sw.hc, WithUnsubscribeKey("key"))
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("batch of notifications delivered successfully", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, 5).Return([]*hub.Notification{n1, n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.es.On("SendEmail", mock.Anything).Return(nil)
+		sw.hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n1.NotificationID, true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc, WithBatchSize(5))
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("notification not delivered when its lease could not be renewed", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, []string{n2.NotificationID}).Return(false, nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+		sw.hc.AssertNotCalled(t, "Do", mock.Anything)
+		sw.nm.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("error renewing notification lease", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, []string{n2.NotificationID}).Return(false, tests.ErrFakeDB)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+		sw.tx.On("Rollback", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+		sw.hc.AssertNotCalled(t, "Do", mock.Anything)
+		sw.nm.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("error getting package preparing webhook payload", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(nil, tests.ErrFake)
-		sw.tx.On("Rollback", sw.ctx).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
 		go w.Run(sw.ctx, sw.wg)
@@ -196,10 +660,24 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
-		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, tests.ErrFake).Return(nil)
+		sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(2).(*hub.WebhookDelivery)
+				assert.Equal(t, n2.NotificationID, d.NotificationID)
+				assert.Equal(t, 0, d.StatusCode)
+				assert.Equal(t, tests.ErrFake.Error(), d.Error)
+			}).
+			Return(nil)
+		sw.nm.On("RegisterDeliveryFailure", sw.ctx, sw.tx, n2.NotificationID, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				assert.NotEmpty(t, args.Get(3))
+				assert.True(t, errors.Is(args.Error(4), ErrDeliveryFailed))
+			}).
+			Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
@@ -211,13 +689,23 @@ func TestWorker(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.hc.On("Do", mock.Anything).Return(&http.Response{
-			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Body:       ioutil.NopCloser(strings.NewReader("not found")),
 			StatusCode: http.StatusNotFound,
 		}, nil)
-		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, mock.Anything).Return(nil)
+		sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(2).(*hub.WebhookDelivery)
+				assert.Equal(t, http.StatusNotFound, d.StatusCode)
+				assert.Equal(t, "not found", d.ResponseBody)
+				assert.Equal(t, "unexpected status code: 404", d.Error)
+			}).
+			Return(nil)
+		sw.nm.On("RegisterDeliveryFailure", sw.ctx, sw.tx, n2.NotificationID, mock.Anything, mock.Anything).
+			Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
 		w := NewWorker(sw.svc, sw.cache, "", sw.hc)
@@ -225,16 +713,46 @@ func TestWorker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("webhook payload exceeds the maximum size allowed", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, mock.Anything).
+			Run(func(args mock.Arguments) {
+				assert.True(t, errors.Is(args.Error(4), ErrPayloadTooLarge))
+			}).
+			Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "", sw.hc, WithPayloadLimits(&PayloadLimits{MaxSize: 10}))
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("webhook notification delivered successfully", func(t *testing.T) {
 		t.Parallel()
 		sw := newServicesWrapper()
 		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-		sw.nm.On("GetPending", sw.ctx, sw.tx).Return(n2, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{n2}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
 		sw.hc.On("Do", mock.Anything).Return(&http.Response{
-			Body:       ioutil.NopCloser(strings.NewReader("")),
+			Body:       ioutil.NopCloser(strings.NewReader(strings.Repeat("a", 2048))),
 			StatusCode: http.StatusOK,
 		}, nil)
+		sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).
+			Run(func(args mock.Arguments) {
+				d := args.Get(2).(*hub.WebhookDelivery)
+				assert.NotEmpty(t, d.Payload)
+				assert.Equal(t, DefaultPayloadContentType, d.ContentType)
+				assert.Equal(t, http.StatusOK, d.StatusCode)
+				assert.Len(t, d.ResponseBody, maxDeliveryResponseBodySize)
+				assert.Empty(t, d.Error)
+			}).
+			Return(nil)
 		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 		sw.tx.On("Commit", sw.ctx).Return(nil)
 
@@ -248,6 +766,7 @@ func TestWorker(t *testing.T) {
 			id              string
 			contentType     string
 			template        string
+			format          string
 			secret          string
 			expectedPayload []byte
 		}{
@@ -256,6 +775,7 @@ func TestWorker(t *testing.T) {
 				"",
 				"",
 				"",
+				"",
 				[]byte(`
 {
 	"specversion" : "1.0",
@@ -285,9 +805,34 @@ func TestWorker(t *testing.T) {
 				"2",
 				"custom/type",
 				"Package {{ .Package.name }} {{ .Package.version}} updated!",
+				"",
 				"very",
 				[]byte("Package package1 1.0.0 updated!"),
 			},
+			{
+				"3",
+				"application/json",
+				"",
+				hub.WebhookFormatSlack,
+				"",
+				[]byte(`{"blocks":[{"text":{"text":"*\u003chttp://baseURL/packages/helm/repo1/package1/1.0.0|package1\u003e* version *1.0.0* released","type":"mrkdwn"},"type":"section"},{"elements":[{"text":":shield: This version contains security updates\n:construction: This version is a pre-release","type":"mrkdwn"}],"type":"context"},{"text":{"text":"*Changes*\n• Cool feature\n• Bug fixed","type":"mrkdwn"},"type":"section"},{"elements":[{"text":"Repository: *repo1* (helm) | Publisher: *org1*","type":"mrkdwn"}],"type":"context"}],"text":"package1 version 1.0.0 released"}`),
+			},
+			{
+				"4",
+				"application/json",
+				"",
+				hub.WebhookFormatTeams,
+				"",
+				[]byte(`{"@context":"https://schema.org/extensions","@type":"MessageCard","potentialAction":[{"@type":"OpenUri","name":"View package","targets":[{"os":"default","uri":"http://baseURL/packages/helm/repo1/package1/1.0.0"}]}],"sections":[{"activityTitle":"package1 version 1.0.0 released","facts":[{"name":"Repository","value":"repo1 (helm)"},{"name":"Publisher","value":"org1"}],"markdown":true,"text":"**This version contains security updates**\n\n**This version is a pre-release**\n\n**Changes**\n\n- Cool feature\n- Bug fixed"}],"summary":"package1 version 1.0.0 released","themeColor":"417598"}`),
+			},
+			{
+				"5",
+				"application/json",
+				"",
+				hub.WebhookFormatDiscord,
+				"",
+				[]byte(`{"embeds":[{"color":4289944,"description":":shield: This version contains security updates\n:construction: This version is a pre-release\n\n**Changes**\n• Cool feature\n• Bug fixed","fields":[{"inline":true,"name":"Repository","value":"repo1 (helm)"},{"inline":true,"name":"Publisher","value":"org1"}],"title":"package1 version 1.0.0 released","url":"http://baseURL/packages/helm/repo1/package1/1.0.0"}]}`),
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -301,6 +846,8 @@ func TestWorker(t *testing.T) {
 					assert.Equal(t, "POST", r.Method)
 					assert.Equal(t, contentType, r.Header.Get("Content-Type"))
 					assert.Equal(t, tc.secret, r.Header.Get("X-ArtifactHub-Secret"))
+					assert.Equal(t, "custom", r.Header.Get("X-Custom"))
+					assert.Equal(t, "notificationID-3", r.Header.Get(DeliveryIDHeader))
 					payload, _ := ioutil.ReadAll(r.Body)
 					assert.Equal(t, tc.expectedPayload, payload)
 				}))
@@ -308,17 +855,278 @@ func TestWorker(t *testing.T) {
 
 				sw := newServicesWrapper()
 				sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
-				sw.nm.On("GetPending", sw.ctx, sw.tx).Return(&hub.Notification{
-					NotificationID: "notificationID",
-					Event:          e1,
-					Webhook: &hub.Webhook{
-						URL:         ts.URL,
-						ContentType: tc.contentType,
-						Template:    tc.template,
-						Secret:      tc.secret,
+				sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+					{
+						NotificationID: "notificationID",
+						Attempts:       2,
+						Event:          e1,
+						Webhook: &hub.Webhook{
+							URL:         ts.URL,
+							ContentType: tc.contentType,
+							Template:    tc.template,
+							Format:      tc.format,
+							Secret:      tc.secret,
+							Headers: []*hub.WebhookHeader{
+								{Name: "X-Custom", Value: "custom"},
+							},
+						},
+					},
+				}, nil)
+				sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+				sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+				sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).
+					Run(func(args mock.Arguments) {
+						d := args.Get(2).(*hub.WebhookDelivery)
+						assert.Equal(t, "notificationID-3", d.DeliveryID)
+					}).
+					Return(nil)
+				sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
+				sw.tx.On("Commit", sw.ctx).Return(nil)
+
+				w := NewWorker(sw.svc, sw.cache, "http://baseURL", http.DefaultClient)
+				go w.Run(sw.ctx, sw.wg)
+				sw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("webhook notifications batch delivered successfully (real http server)", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, BatchPayloadContentType, r.Header.Get("Content-Type"))
+			assert.Equal(t, "notification1ID-1", r.Header.Get(DeliveryIDHeader))
+			var events []map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&events)
+			assert.NoError(t, err)
+			if assert.Len(t, events, 2) {
+				types := []interface{}{events[0]["type"], events[1]["type"]}
+				assert.ElementsMatch(t, []interface{}{
+					"io.artifacthub.package.new-release",
+					"io.artifacthub.package.deprecated",
+				}, types)
+			}
+		}))
+		defer ts.Close()
+
+		wh := &hub.Webhook{
+			WebhookID: "webhookID",
+			URL:       ts.URL,
+			Batch:     true,
+		}
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{NotificationID: "notification1ID", Event: e1, Webhook: wh},
+			{NotificationID: "notification2ID", Event: &hub.Event{
+				EventID:        "event2ID",
+				EventKind:      hub.PackageDeprecated,
+				PackageID:      "packageID",
+				PackageVersion: "1.0.0",
+			}, Webhook: wh},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil).Once()
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, "notification1ID", true, nil).Return(nil)
+		sw.nm.On("UpdateStatus", sw.ctx, sw.tx, "notification2ID", true, nil).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "http://baseURL", http.DefaultClient)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("webhook notifications batch delivery failed (real http server)", func(t *testing.T) {
+		t.Parallel()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		wh := &hub.Webhook{
+			WebhookID: "webhookID",
+			URL:       ts.URL,
+			Batch:     true,
+		}
+		sw := newServicesWrapper()
+		sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+		sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+			{NotificationID: "notification1ID", Event: e1, Webhook: wh},
+			{NotificationID: "notification2ID", Event: e1, Webhook: wh},
+		}, nil)
+		sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+		sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+		sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil).Once()
+		sw.nm.On("RegisterDeliveryFailure", sw.ctx, sw.tx, "notification1ID", mock.Anything, mock.Anything).Return(nil)
+		sw.nm.On("RegisterDeliveryFailure", sw.ctx, sw.tx, "notification2ID", mock.Anything, mock.Anything).Return(nil)
+		sw.tx.On("Commit", sw.ctx).Return(nil)
+
+		w := NewWorker(sw.svc, sw.cache, "http://baseURL", http.DefaultClient)
+		go w.Run(sw.ctx, sw.wg)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("package deprecated webhook notification delivered successfully (real http server)", func(t *testing.T) {
+		testCases := []struct {
+			format          string
+			expectedPayload string
+		}{
+			{
+				"",
+				`"type" : "io.artifacthub.package.deprecated"`,
+			},
+			{
+				hub.WebhookFormatSlack,
+				`"text":"package1 version 1.0.0 deprecated"`,
+			},
+			{
+				hub.WebhookFormatTeams,
+				`"text":"**This version has been deprecated**`,
+			},
+			{
+				hub.WebhookFormatDiscord,
+				`"title":"package1 version 1.0.0 deprecated"`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.format, func(t *testing.T) {
+				t.Parallel()
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					payload, _ := ioutil.ReadAll(r.Body)
+					assert.Contains(t, string(payload), tc.expectedPayload)
+				}))
+				defer ts.Close()
+
+				sw := newServicesWrapper()
+				sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+				sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+					{
+						NotificationID: "notificationID",
+						Event:          e3,
+						Webhook: &hub.Webhook{
+							URL:    ts.URL,
+							Format: tc.format,
+						},
+					},
+				}, nil)
+				sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+				sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+				sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil)
+				sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
+				sw.tx.On("Commit", sw.ctx).Return(nil)
+
+				w := NewWorker(sw.svc, sw.cache, "http://baseURL", http.DefaultClient)
+				go w.Run(sw.ctx, sw.wg)
+				sw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("security alert webhook notification delivered successfully (real http server)", func(t *testing.T) {
+		testCases := []struct {
+			format          string
+			expectedPayload string
+		}{
+			{
+				"",
+				`"id": "CVE-2021-1234"`,
+			},
+			{
+				hub.WebhookFormatSlack,
+				`CVE-2021-1234 (HIGH) in openssl 1.1.1g-r0, fixed in 1.1.1i-r0`,
+			},
+			{
+				hub.WebhookFormatTeams,
+				`**Vulnerabilities**`,
+			},
+			{
+				hub.WebhookFormatDiscord,
+				`"title":"package1 version 1.0.0 has new security vulnerabilities"`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.format, func(t *testing.T) {
+				t.Parallel()
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					payload, _ := ioutil.ReadAll(r.Body)
+					assert.Contains(t, string(payload), tc.expectedPayload)
+				}))
+				defer ts.Close()
+
+				sw := newServicesWrapper()
+				sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+				sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+					{
+						NotificationID: "notificationID",
+						Event:          e4,
+						Webhook: &hub.Webhook{
+							URL:    ts.URL,
+							Format: tc.format,
+						},
+					},
+				}, nil)
+				sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
+				sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+				sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil)
+				sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
+				sw.tx.On("Commit", sw.ctx).Return(nil)
+
+				w := NewWorker(sw.svc, sw.cache, "http://baseURL", http.DefaultClient)
+				go w.Run(sw.ctx, sw.wg)
+				sw.assertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("new package webhook notification delivered successfully (real http server)", func(t *testing.T) {
+		testCases := []struct {
+			format          string
+			expectedPayload string
+		}{
+			{
+				"",
+				`"type" : "io.artifacthub.package.new"`,
+			},
+			{
+				hub.WebhookFormatSlack,
+				`version *1.0.0* published as a new package`,
+			},
+			{
+				hub.WebhookFormatTeams,
+				`"activityTitle":"package1 version 1.0.0 published as a new package"`,
+			},
+			{
+				hub.WebhookFormatDiscord,
+				`"title":"package1 version 1.0.0 published as a new package"`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.format, func(t *testing.T) {
+				t.Parallel()
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					payload, _ := ioutil.ReadAll(r.Body)
+					assert.Contains(t, string(payload), tc.expectedPayload)
+				}))
+				defer ts.Close()
+
+				sw := newServicesWrapper()
+				sw.db.On("Begin", sw.ctx).Return(sw.tx, nil)
+				sw.nm.On("GetPending", sw.ctx, sw.tx, defaultBatchSize).Return([]*hub.Notification{
+					{
+						NotificationID: "notificationID",
+						Event:          e5,
+						Webhook: &hub.Webhook{
+							URL:    ts.URL,
+							Format: tc.format,
+						},
 					},
 				}, nil)
+				sw.nm.On("RenewLease", sw.ctx, sw.tx, mock.Anything).Return(true, nil)
 				sw.pm.On("Get", sw.ctx, gpi).Return(p, nil)
+				sw.nm.On("RegisterWebhookDelivery", sw.ctx, sw.tx, mock.Anything).Return(nil)
 				sw.nm.On("UpdateStatus", sw.ctx, sw.tx, n2.NotificationID, true, nil).Return(nil)
 				sw.tx.On("Commit", sw.ctx).Return(nil)
 
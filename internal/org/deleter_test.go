@@ -0,0 +1,36 @@
+package org
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleterDeleteScheduled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, deleteScheduledOrgsDBQ).Return(nil, tests.ErrFakeDB)
+		d := NewDeleter(db)
+
+		_, err := d.DeleteScheduled(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("scheduled organizations deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, deleteScheduledOrgsDBQ).Return(int64(2), nil)
+		d := NewDeleter(db)
+
+		n, err := d.DeleteScheduled(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), n)
+		db.AssertExpectations(t)
+	})
+}
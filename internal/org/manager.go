@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/email"
@@ -21,9 +23,9 @@ const (
 	// Database queries
 	addOrgDBQ            = `select add_organization($1::uuid, $2::jsonb)`
 	addOrgMemberDBQ      = `select add_organization_member($1::uuid, $2::text, $3::text)`
+	cancelOrgDeletionDBQ = `select cancel_organization_deletion($1::uuid, $2::text)`
 	checkOrgNameAvailDBQ = `select organization_id from organization where name = $1`
 	confirmMembershipDBQ = `select confirm_organization_membership($1::uuid, $2::text)`
-	deleteOrgDBQ         = `select delete_organization($1::uuid, $2::text)`
 	deleteOrgMemberDBQ   = `select delete_organization_member($1::uuid, $2::text, $3::text)`
 	getAuthzPolicyDBQ    = `select get_authorization_policy($1::uuid, $2::text)`
 	getOrgDBQ            = `select get_organization($1::text)`
@@ -31,28 +33,71 @@ const (
 	getUserAliasDBQ      = `select alias from "user" where user_id = $1`
 	getUserEmailDBQ      = `select email from "user" where alias = $1`
 	getUserOrgsDBQ       = `select get_user_organizations($1::uuid)`
+	renameOrgDBQ         = `select rename_organization($1::uuid, $2::text, $3::text)`
+	scheduleOrgDelDBQ    = `select schedule_organization_deletion($1::uuid, $2::text, $3::jsonb, $4::interval)`
+	transferOwnershipDBQ = `select transfer_organization_ownership($1::uuid, $2::text, $3::text)`
 	updateAuthzPolicyDBQ = `select update_authorization_policy($1::uuid, $2::text, $3::jsonb)`
+	updateMemberRoleDBQ  = `select update_organization_member_role($1::uuid, $2::text, $3::text, $4::text)`
 	updateOrgDBQ         = `select update_organization($1::uuid, $2::text, $3::jsonb)`
+
+	// DefaultDeletionGracePeriod represents the default period of time that
+	// must elapse since the deletion of an organization is requested until it
+	// is actually deleted.
+	DefaultDeletionGracePeriod = 7 * 24 * time.Hour
 )
 
 var (
+	// errInvalidRoleDB represents the error returned from the database when
+	// the role provided does not exist.
+	errInvalidRoleDB = errors.New("ERROR: invalid role (SQLSTATE P0001)")
+
+	// errLastOwnerDemotedDB represents the error returned from the database
+	// when the last owner of an organization is about to be demoted.
+	errLastOwnerDemotedDB = errors.New("ERROR: last owner of an organization cannot be demoted (SQLSTATE P0001)")
+
+	// errNameNotAvailableDB represents the error returned from the database
+	// when the new name of an organization is already in use.
+	errNameNotAvailableDB = errors.New("ERROR: organization name not available (SQLSTATE P0001)")
+
+	// errTransferToOwnerDB represents the error returned from the database
+	// when the ownership of an organization is transferred to the user who is
+	// requesting it.
+	errTransferToOwnerDB = errors.New("ERROR: ownership cannot be transferred to the current owner (SQLSTATE P0001)")
+
 	// organizationNameRE is a regexp used to validate an organization name.
 	organizationNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
 )
 
 // Manager provides an API to manage organizations.
 type Manager struct {
-	db hub.DB
-	es hub.EmailSender
-	az hub.Authorizer
+	db                  hub.DB
+	es                  hub.EmailSender
+	az                  hub.Authorizer
+	deletionGracePeriod time.Duration
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB, es hub.EmailSender, az hub.Authorizer) *Manager {
-	return &Manager{
-		db: db,
-		es: es,
-		az: az,
+func NewManager(db hub.DB, es hub.EmailSender, az hub.Authorizer, opts ...func(m *Manager)) *Manager {
+	m := &Manager{
+		db:                  db,
+		es:                  es,
+		az:                  az,
+		deletionGracePeriod: DefaultDeletionGracePeriod,
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithDeletionGracePeriod allows providing the period of time that must
+// elapse since the deletion of an organization is requested until it is
+// actually deleted.
+func WithDeletionGracePeriod(d time.Duration) func(m *Manager) {
+	return func(m *Manager) {
+		if d > 0 {
+			m.deletionGracePeriod = d
+		}
 	}
 }
 
@@ -138,6 +183,37 @@ func (m *Manager) AddMember(ctx context.Context, orgName, userAlias, baseURL str
 	return nil
 }
 
+// CancelDeletion cancels the scheduled deletion of the provided organization.
+func (m *Manager) CancelDeletion(ctx context.Context, orgName string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.DeleteOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Cancel organization deletion in database
+	_, err := m.db.Exec(ctx, cancelOrgDeletionDBQ, userID, orgName)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
 // CheckAvailability checks the availability of a given value for the provided
 // resource kind.
 func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
@@ -188,14 +264,25 @@ func (m *Manager) ConfirmMembership(ctx context.Context, orgName string) error {
 	return err
 }
 
-// Delete deletes the provided organization from the database.
-func (m *Manager) Delete(ctx context.Context, orgName string) error {
+// Delete schedules the deletion of the provided organization once the
+// deletion grace period has elapsed. The repositories owned by the
+// organization are transferred right away if requested.
+func (m *Manager) Delete(ctx context.Context, orgName string, input *hub.DeleteOrganizationInput) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
 
 	// Validate input
 	if orgName == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
 	}
+	if input == nil || input.Confirmation != orgName {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name confirmation does not match")
+	}
+	if input.TransferRepositoriesToOrg != "" && input.TransferRepositoriesToUser {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repositories can only be transferred to one destination")
+	}
+	if input.TransferRepositoriesToOrg == orgName {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repositories cannot be transferred to the organization being deleted")
+	}
 
 	// Authorize action
 	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
@@ -206,8 +293,10 @@ func (m *Manager) Delete(ctx context.Context, orgName string) error {
 		return err
 	}
 
-	// Delete organization from database
-	_, err := m.db.Exec(ctx, deleteOrgDBQ, userID, orgName)
+	// Schedule organization deletion in database
+	inputJSON, _ := json.Marshal(input)
+	gracePeriod := fmt.Sprintf("%d seconds", int64(m.deletionGracePeriod.Seconds()))
+	_, err := m.db.Exec(ctx, scheduleOrgDelDBQ, userID, orgName, inputJSON, gracePeriod)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
 		return hub.ErrInsufficientPrivilege
 	}
@@ -305,6 +394,73 @@ func (m *Manager) GetMembersJSON(ctx context.Context, orgName string) ([]byte, e
 	return util.DBQueryJSON(ctx, m.db, getOrgMembersDBQ, userID, orgName)
 }
 
+// Rename renames the provided organization. The previous name is kept as an
+// alias, so that it can still be used to get the organization.
+func (m *Manager) Rename(ctx context.Context, orgName, newName string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if newName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "new name not provided")
+	}
+	if !organizationNameRE.MatchString(newName) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid new name")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganization,
+	}); err != nil {
+		return err
+	}
+
+	// Rename organization in database
+	_, err := m.db.Exec(ctx, renameOrgDBQ, userID, orgName, newName)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errNameNotAvailableDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not available")
+		}
+	}
+	return err
+}
+
+// TransferOwnership makes the member provided an owner of the organization,
+// demoting the user doing the request to admin. Only owners of the
+// organization are allowed to transfer its ownership.
+func (m *Manager) TransferOwnership(ctx context.Context, orgName, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Transfer ownership in database
+	_, err := m.db.Exec(ctx, transferOwnershipDBQ, userID, orgName, userAlias)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		case errTransferToOwnerDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "ownership cannot be transferred to the current owner")
+		}
+	}
+	return err
+}
+
 // Update updates the provided organization in the database.
 func (m *Manager) Update(ctx context.Context, orgName string, org *hub.Organization) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -399,6 +555,49 @@ func (m *Manager) UpdateAuthorizationPolicy(
 	return err
 }
 
+// UpdateMemberRole updates the role of a member of the provided organization.
+// Roles determine the actions members are allowed to perform when the
+// organization has not enabled a custom authorization policy.
+func (m *Manager) UpdateMemberRole(ctx context.Context, orgName, userAlias, role string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+	if role == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "role not provided")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganizationMemberRole,
+	}); err != nil {
+		return err
+	}
+
+	// Update member role in database
+	_, err := m.db.Exec(ctx, updateMemberRoleDBQ, userID, orgName, userAlias, role)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		case errInvalidRoleDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid role")
+		case errLastOwnerDemotedDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "last owner of an organization cannot be demoted")
+		}
+	}
+	return err
+}
+
 // validateOrg checks if the organization provided is valid.
 func validateOrg(org *hub.Organization) error {
 	if org.Name == "" {
@@ -2,9 +2,11 @@ package org
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/email"
@@ -239,6 +241,97 @@ func TestAddMember(t *testing.T) {
 	})
 }
 
+func TestCancelDeletion(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.CancelDeletion(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		err := m.CancelDeletion(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "name not provided")
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(nil, nil, az)
+
+		err := m.CancelDeletion(ctx, "org1")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, cancelOrgDeletionDBQ, "userID", "org1").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.DeleteOrganization,
+				}).Return(nil)
+				m := NewManager(db, nil, az)
+
+				err := m.CancelDeletion(ctx, "org1")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, cancelOrgDeletionDBQ, "userID", "org1").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.DeleteOrganization,
+		}).Return(nil)
+		m := NewManager(db, nil, az)
+
+		err := m.CancelDeletion(ctx, "org1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestCheckAvailability(t *testing.T) {
 	ctx := context.Background()
 
@@ -362,16 +455,59 @@ func TestDelete(t *testing.T) {
 		t.Parallel()
 		m := NewManager(nil, nil, nil)
 		assert.Panics(t, func() {
-			_ = m.Add(context.Background(), &hub.Organization{})
+			_ = m.Delete(context.Background(), "org1", &hub.DeleteOrganizationInput{})
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(nil, nil, nil)
-		err := m.Delete(ctx, "")
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
-		assert.Contains(t, err.Error(), "name not provided")
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			input   *hub.DeleteOrganizationInput
+		}{
+			{
+				"name not provided",
+				"",
+				&hub.DeleteOrganizationInput{},
+			},
+			{
+				"confirmation does not match",
+				"org1",
+				nil,
+			},
+			{
+				"confirmation does not match",
+				"org1",
+				&hub.DeleteOrganizationInput{Confirmation: "org2"},
+			},
+			{
+				"only be transferred to one destination",
+				"org1",
+				&hub.DeleteOrganizationInput{
+					Confirmation:               "org1",
+					TransferRepositoriesToOrg:  "org2",
+					TransferRepositoriesToUser: true,
+				},
+			},
+			{
+				"cannot be transferred to the organization being deleted",
+				"org1",
+				&hub.DeleteOrganizationInput{
+					Confirmation:              "org1",
+					TransferRepositoriesToOrg: "org1",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				err := m.Delete(ctx, tc.orgName, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
 	})
 
 	t.Run("authorization failed", func(t *testing.T) {
@@ -385,43 +521,68 @@ func TestDelete(t *testing.T) {
 		}).Return(tests.ErrFake)
 		m := NewManager(db, nil, az)
 
-		err := m.Delete(ctx, "org1")
+		err := m.Delete(ctx, "org1", &hub.DeleteOrganizationInput{Confirmation: "org1"})
 		assert.Equal(t, tests.ErrFake, err)
 		az.AssertExpectations(t)
 	})
 
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
+		input := &hub.DeleteOrganizationInput{
+			Confirmation:              "org1",
+			TransferRepositoriesToOrg: "org2",
+		}
+		inputJSON, _ := json.Marshal(input)
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, deleteOrgDBQ, "userID", "org1").Return(nil)
+		db.On("Exec", ctx, scheduleOrgDelDBQ, "userID", "org1", inputJSON, "86400 seconds").Return(nil)
 		az := &authz.AuthorizerMock{}
 		az.On("Authorize", ctx, &hub.AuthorizeInput{
 			OrganizationName: "org1",
 			UserID:           "userID",
 			Action:           hub.DeleteOrganization,
 		}).Return(nil)
-		m := NewManager(db, nil, az)
+		m := NewManager(db, nil, az, WithDeletionGracePeriod(24*time.Hour))
 
-		err := m.Delete(ctx, "org1")
+		err := m.Delete(ctx, "org1", input)
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
 
 	t.Run("database error", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("Exec", ctx, deleteOrgDBQ, "userID", "org1").Return(tests.ErrFakeDB)
-		az := &authz.AuthorizerMock{}
-		az.On("Authorize", ctx, &hub.AuthorizeInput{
-			OrganizationName: "org1",
-			UserID:           "userID",
-			Action:           hub.DeleteOrganization,
-		}).Return(nil)
-		m := NewManager(db, nil, az)
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				input := &hub.DeleteOrganizationInput{Confirmation: "org1"}
+				inputJSON, _ := json.Marshal(input)
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, scheduleOrgDelDBQ, "userID", "org1", inputJSON, "604800 seconds").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.DeleteOrganization,
+				}).Return(nil)
+				m := NewManager(db, nil, az)
 
-		err := m.Delete(ctx, "org1")
-		assert.Equal(t, tests.ErrFakeDB, err)
-		db.AssertExpectations(t)
+				err := m.Delete(ctx, "org1", input)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
 	})
 }
 
@@ -785,6 +946,214 @@ func TestGetMembersJSON(t *testing.T) {
 	})
 }
 
+func TestRename(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Rename(context.Background(), "org1", "org2")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			newName string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"org2",
+			},
+			{
+				"new name not provided",
+				"org1",
+				"",
+			},
+			{
+				"invalid new name",
+				"org1",
+				"_org2",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				err := m.Rename(ctx, tc.orgName, tc.newName)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(tests.ErrFake)
+		m := NewManager(nil, nil, az)
+
+		err := m.Rename(ctx, "org1", "org2")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, renameOrgDBQ, "userID", "org1", "org2").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganization,
+		}).Return(nil)
+		m := NewManager(db, nil, az)
+
+		err := m.Rename(ctx, "org1", "org2")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errNameNotAvailableDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, renameOrgDBQ, "userID", "org1", "org2").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.UpdateOrganization,
+				}).Return(nil)
+				m := NewManager(db, nil, az)
+
+				err := m.Rename(ctx, "org1", "org2")
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestTransferOwnership(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.TransferOwnership(context.Background(), "org1", "user1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			userAlias string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"user1",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				err := m.TransferOwnership(ctx, tc.orgName, tc.userAlias)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, transferOwnershipDBQ, "userID", "org1", "user1").Return(nil)
+		m := NewManager(db, nil, nil)
+
+		err := m.TransferOwnership(ctx, "org1", "user1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				errTransferToOwnerDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, transferOwnershipDBQ, "userID", "org1", "user1").Return(tc.dbErr)
+				m := NewManager(db, nil, nil)
+
+				err := m.TransferOwnership(ctx, "org1", "user1")
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
@@ -1112,3 +1481,134 @@ func TestUpdateAuthorizationPolicy(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateMemberRole(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateMemberRole(context.Background(), "org1", "user1", "admin")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			userAlias string
+			role      string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"user1",
+				"admin",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"",
+				"admin",
+			},
+			{
+				"role not provided",
+				"org1",
+				"user1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				err := m.UpdateMemberRole(ctx, tc.orgName, tc.userAlias, tc.role)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationMemberRole,
+		}).Return(tests.ErrFake)
+		m := NewManager(nil, nil, az)
+
+		err := m.UpdateMemberRole(ctx, "org1", "user1", "admin")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateMemberRoleDBQ, "userID", "org1", "user1", "admin").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationMemberRole,
+		}).Return(nil)
+		m := NewManager(db, nil, az)
+
+		err := m.UpdateMemberRole(ctx, "org1", "user1", "admin")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				errInvalidRoleDB,
+				hub.ErrInvalidInput,
+			},
+			{
+				errLastOwnerDemotedDB,
+				hub.ErrInvalidInput,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, updateMemberRoleDBQ, "userID", "org1", "user1", "admin").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, &hub.AuthorizeInput{
+					OrganizationName: "org1",
+					UserID:           "userID",
+					Action:           hub.UpdateOrganizationMemberRole,
+				}).Return(nil)
+				m := NewManager(db, nil, az)
+
+				err := m.UpdateMemberRole(ctx, "org1", "user1", "admin")
+				assert.True(t, errors.Is(err, tc.expectedError))
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+}
@@ -24,6 +24,12 @@ func (m *ManagerMock) AddMember(ctx context.Context, orgName, userAlias, baseURL
 	return args.Error(0)
 }
 
+// CancelDeletion implements the OrganizationManager interface.
+func (m *ManagerMock) CancelDeletion(ctx context.Context, orgName string) error {
+	args := m.Called(ctx, orgName)
+	return args.Error(0)
+}
+
 // CheckAvailability implements the OrganizationManager interface.
 func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value string) (bool, error) {
 	args := m.Called(ctx, resourceKind, value)
@@ -37,8 +43,8 @@ func (m *ManagerMock) ConfirmMembership(ctx context.Context, orgName string) err
 }
 
 // Delete implements the OrganizationManager interface.
-func (m *ManagerMock) Delete(ctx context.Context, orgName string) error {
-	args := m.Called(ctx, orgName)
+func (m *ManagerMock) Delete(ctx context.Context, orgName string, input *hub.DeleteOrganizationInput) error {
+	args := m.Called(ctx, orgName, input)
 	return args.Error(0)
 }
 
@@ -76,6 +82,18 @@ func (m *ManagerMock) GetMembersJSON(ctx context.Context, orgName string) ([]byt
 	return data, args.Error(1)
 }
 
+// Rename implements the OrganizationManager interface.
+func (m *ManagerMock) Rename(ctx context.Context, orgName, newName string) error {
+	args := m.Called(ctx, orgName, newName)
+	return args.Error(0)
+}
+
+// TransferOwnership implements the OrganizationManager interface.
+func (m *ManagerMock) TransferOwnership(ctx context.Context, orgName, userAlias string) error {
+	args := m.Called(ctx, orgName, userAlias)
+	return args.Error(0)
+}
+
 // Update implements the OrganizationManager interface.
 func (m *ManagerMock) Update(ctx context.Context, orgName string, org *hub.Organization) error {
 	args := m.Called(ctx, orgName, org)
@@ -91,3 +109,9 @@ func (m *ManagerMock) UpdateAuthorizationPolicy(
 	args := m.Called(ctx, orgName, policy)
 	return args.Error(0)
 }
+
+// UpdateMemberRole implements the OrganizationManager interface.
+func (m *ManagerMock) UpdateMemberRole(ctx context.Context, orgName, userAlias, role string) error {
+	args := m.Called(ctx, orgName, userAlias, role)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package pkg
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -10,28 +11,39 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
 )
 
 const (
 	// Database queries
-	getHarborReplicationDumpDBQ     = `select get_harbor_replication_dump()`
-	getPkgDBQ                       = `select get_package($1::jsonb)`
-	getPkgChangeLogDBQ              = `select get_package_changelog($1::uuid)`
-	getPkgStarsDBQ                  = `select get_package_stars($1::uuid, $2::uuid)`
-	getPkgSummaryDBQ                = `select get_package_summary($1::jsonb)`
-	getPkgsStarredByUserDBQ         = `select get_packages_starred_by_user($1::uuid)`
-	getPkgsStatsDBQ                 = `select get_packages_stats()`
-	getSnapshotSecurityReportDBQ    = `select security_report from snapshot where package_id = $1 and version = $2`
-	getSnapshotsToScanDBQ           = `select get_snapshots_to_scan()`
-	getRandomPkgsDBQ                = `select get_random_packages()`
-	getValuesSchemaDBQ              = `select values_schema from snapshot where package_id = $1 and version = $2`
-	registerPkgDBQ                  = `select register_package($1::jsonb)`
-	searchPkgsDBQ                   = `select search_packages($1::jsonb)`
-	searchPkgsMonocularDBQ          = `select search_packages_monocular($1::text, $2::text)`
-	togglePkgStarDBQ                = `select toggle_star($1::uuid, $2::uuid)`
-	updateSnapshotSecurityReportDBQ = `select update_snapshot_security_report($1::jsonb)`
-	unregisterPkgDBQ                = `select unregister_package($1::jsonb)`
+	addVulnerabilitySuppressionDBQ    = `select add_package_vulnerability_suppression($1::uuid, $2::uuid, $3::text, $4::text)`
+	deleteVulnerabilitySuppressionDBQ = `select delete_package_vulnerability_suppression($1::uuid, $2::uuid, $3::text)`
+	getHarborReplicationDumpDBQ       = `select get_harbor_replication_dump()`
+	getPkgDBQ                         = `select get_package($1::jsonb)`
+	getPkgChangeLogDBQ                = `select get_package_changelog($1::uuid)`
+	getPkgRepositoryDBQ               = `select r.name, coalesce(o.name, '') from package p join repository r using (repository_id) left join organization o on o.organization_id = r.organization_id where p.package_id = $1`
+	getPkgStarsDBQ                    = `select get_package_stars($1::uuid, $2::uuid)`
+	getPkgSummaryDBQ                  = `select get_package_summary($1::jsonb)`
+	getPkgsStarredByUserDBQ           = `select get_packages_starred_by_user($1::uuid)`
+	getPkgsStatsDBQ                   = `select get_packages_stats()`
+	getSnapshotSecurityReportDBQ      = `select security_report from snapshot where package_id = $1 and version = $2`
+	getSnapshotsToScanDBQ             = `select get_snapshots_to_scan()`
+	getRandomPkgsDBQ                  = `select get_random_packages()`
+	getRecentReleasesDBQ              = `select get_recent_releases($1::jsonb)`
+	getSBOMsDBQ                       = `select coalesce(sboms, '[]') from snapshot where package_id = $1 and version = $2`
+	getDefaultValuesDBQ               = `select coalesce(default_values, '') from snapshot where package_id = $1 and version = $2`
+	getValuesSchemaDBQ                = `select values_schema from snapshot where package_id = $1 and version = $2`
+	getVulnerabilitiesSuppressionsDBQ = `select get_package_vulnerability_suppressions($1::uuid)`
+	registerPkgDBQ                    = `select register_package($1::jsonb)`
+	requestRescanDBQ                  = `select request_snapshot_security_report_rescan($1::uuid, $2::uuid, $3::text)`
+	searchPkgsDBQ                     = `select search_packages($1::jsonb)`
+	searchPkgsMonocularDBQ            = `select search_packages_monocular($1::text, $2::text)`
+	togglePkgStarDBQ                  = `select toggle_star($1::uuid, $2::uuid)`
+	updateSnapshotSecurityReportDBQ   = `select update_snapshot_security_report($1::jsonb)`
+	unregisterPkgDBQ                  = `select unregister_package($1::jsonb)`
+	unyankVersionDBQ                  = `select unyank_package_version($1::uuid, $2::uuid, $3::text)`
+	yankVersionDBQ                    = `select yank_package_version($1::uuid, $2::uuid, $3::text, $4::text)`
 )
 
 var (
@@ -42,18 +54,99 @@ var (
 		"deep insights",
 		"auto pilot",
 	}
+
+	validSortOptions = []string{
+		"relevance",
+		"stars",
+		"last_updated",
+		"newest",
+		"alphabetical",
+	}
 )
 
 // Manager provides an API to manage packages.
 type Manager struct {
 	db hub.DB
+	az hub.Authorizer
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB) *Manager {
+func NewManager(db hub.DB, az hub.Authorizer) *Manager {
 	return &Manager{
 		db: db,
+		az: az,
+	}
+}
+
+// AddVulnerabilitySuppression marks the provided vulnerability as not
+// applicable to the package identified by the id provided. Suppressed
+// vulnerabilities are not taken into account in the security reports
+// summaries or the vulnerabilities alerts once the package is rescanned.
+func (m *Manager) AddVulnerabilitySuppression(
+	ctx context.Context,
+	pkgID string,
+	s *hub.VulnerabilitySuppression,
+) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if s == nil || strings.TrimSpace(s.VulnerabilityID) == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "vulnerability id not provided")
 	}
+	if strings.TrimSpace(s.Justification) == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "justification not provided")
+	}
+
+	// Authorize action
+	if err := m.authorizeRepositoryAction(ctx, userID, pkgID); err != nil {
+		return err
+	}
+
+	// Add vulnerability suppression to database
+	_, err := m.db.Exec(ctx, addVulnerabilitySuppressionDBQ, userID, pkgID, s.VulnerabilityID, s.Justification)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
+// DeleteVulnerabilitySuppression deletes the provided vulnerability suppression
+// from the package identified by the id provided.
+func (m *Manager) DeleteVulnerabilitySuppression(ctx context.Context, pkgID, vulnerabilityID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if vulnerabilityID == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "vulnerability id not provided")
+	}
+
+	// Authorize action
+	if err := m.authorizeRepositoryAction(ctx, userID, pkgID); err != nil {
+		return err
+	}
+
+	// Delete vulnerability suppression from database
+	_, err := m.db.Exec(ctx, deleteVulnerabilitySuppressionDBQ, userID, pkgID, vulnerabilityID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
 }
 
 // Get returns the package identified by the input provided.
@@ -100,6 +193,42 @@ func (m *Manager) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getRandomPkgsDBQ)
 }
 
+// GetRecentReleases returns the most recent releases of the packages in the
+// repository or from the publisher provided. Yanked versions are not included.
+func (m *Manager) GetRecentReleases(
+	ctx context.Context,
+	input *hub.GetRecentReleasesInput,
+) ([]*hub.Package, error) {
+	// Validate input
+	if input.RepositoryName == "" && input.Publisher == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name or publisher not provided")
+	}
+	if input.Limit < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid limit")
+	}
+
+	// Get recent releases from database
+	inputJSON, _ := json.Marshal(input)
+	var releases []*hub.Package
+	if err := util.DBQueryUnmarshal(ctx, m.db, &releases, getRecentReleasesDBQ, inputJSON); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// GetSBOMs returns the SBOMs of the package's snapshot identified by the
+// package id and version provided.
+func (m *Manager) GetSBOMs(ctx context.Context, pkgID, version string) ([]*hub.SBOM, error) {
+	var sboms []*hub.SBOM
+	if err := util.DBQueryUnmarshal(ctx, m.db, &sboms, getSBOMsDBQ, pkgID, version); err != nil {
+		return nil, err
+	}
+	if len(sboms) == 0 {
+		return nil, hub.ErrNotFound
+	}
+	return sboms, nil
+}
+
 // GetSnapshotSecurityReportJSON returns the security report of the package's
 // snapshot identified by the package id and version provided.
 func (m *Manager) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
@@ -156,12 +285,71 @@ func (m *Manager) GetSummaryJSON(ctx context.Context, input *hub.GetPackageInput
 	return util.DBQueryJSON(ctx, m.db, getPkgSummaryDBQ, inputJSON)
 }
 
+// GetValuesDiffJSON returns the differences between the default values of the
+// package's snapshots identified by the package id and versions provided as a
+// json object.
+func (m *Manager) GetValuesDiffJSON(ctx context.Context, pkgID, from, to string) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if from == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "from version not provided")
+	}
+	if to == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "to version not provided")
+	}
+
+	// Get default values of both versions from database
+	fromValues, err := m.getDefaultValues(ctx, pkgID, from)
+	if err != nil {
+		return nil, err
+	}
+	toValues, err := m.getDefaultValues(ctx, pkgID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute values diff
+	diff, err := DiffValues(from, fromValues, to, toValues)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(diff)
+}
+
+// getDefaultValues returns the default values of the package's snapshot
+// identified by the package id and version provided.
+func (m *Manager) getDefaultValues(ctx context.Context, pkgID, version string) (string, error) {
+	var values string
+	if err := m.db.QueryRow(ctx, getDefaultValuesDBQ, pkgID, version).Scan(&values); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", hub.ErrNotFound
+		}
+		return "", err
+	}
+	return values, nil
+}
+
 // GetValuesSchemaJSON returns the values schema of the package's snapshot
 // identified by the package id and version provided.
 func (m *Manager) GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getValuesSchemaDBQ, pkgID, version)
 }
 
+// GetVulnerabilitiesSuppressionsJSON returns the vulnerabilities suppressions
+// of the package identified by the id provided as a json array. The json array
+// is built by the database.
+func (m *Manager) GetVulnerabilitiesSuppressionsJSON(ctx context.Context, pkgID string) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+
+	// Get vulnerabilities suppressions from database
+	return util.DBQueryJSON(ctx, m.db, getVulnerabilitiesSuppressionsDBQ, pkgID)
+}
+
 // Register registers the package provided in the database.
 func (m *Manager) Register(ctx context.Context, pkg *hub.Package) error {
 	// Validate input
@@ -229,6 +417,38 @@ func (m *Manager) Register(ctx context.Context, pkg *hub.Package) error {
 	return err
 }
 
+// RequestSnapshotSecurityReportRescan requests the provided package's snapshot
+// to be scanned again for security vulnerabilities the next time the scanner
+// runs. Only the package owners are allowed to request a rescan.
+func (m *Manager) RequestSnapshotSecurityReportRescan(ctx context.Context, pkgID, version string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Authorize action
+	if err := m.authorizeRepositoryAction(ctx, userID, pkgID); err != nil {
+		return err
+	}
+
+	// Request rescan in database
+	_, err := m.db.Exec(ctx, requestRescanDBQ, userID, pkgID, version)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
 // SearchJSON returns a json object with the search results produced by the
 // input provided. The json object is built by the database.
 func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput) ([]byte, error) {
@@ -239,6 +459,14 @@ func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput)
 	if input.Offset < 0 {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
 	}
+	if input.Cursor != "" {
+		if input.Offset > 0 {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "offset and cursor cannot be used together")
+		}
+		if err := util.ValidateCursor(input.Cursor); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		}
+	}
 	for _, alias := range input.Users {
 		if alias == "" {
 			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user alias")
@@ -254,6 +482,9 @@ func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchPackageInput)
 			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository name")
 		}
 	}
+	if input.Sort != "" && !isValidSortOption(input.Sort) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid sort option")
+	}
 
 	// Search packages in database
 	inputJSON, _ := json.Marshal(input)
@@ -311,6 +542,91 @@ func (m *Manager) Unregister(ctx context.Context, pkg *hub.Package) error {
 	return err
 }
 
+// UnyankVersion reverts the yanking of the provided package version.
+func (m *Manager) UnyankVersion(ctx context.Context, pkgID, version string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Authorize action
+	if err := m.authorizeRepositoryAction(ctx, userID, pkgID); err != nil {
+		return err
+	}
+
+	// Unyank package version in database
+	_, err := m.db.Exec(ctx, unyankVersionDBQ, userID, pkgID, version)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
+// YankVersion marks the provided package version as yanked. Yanked versions
+// are still available, but they are not considered when resolving the
+// package's latest version and they don't trigger new releases notifications.
+// Only the package owners are allowed to yank its versions.
+func (m *Manager) YankVersion(ctx context.Context, pkgID, version, reason string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(pkgID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if version == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "version not provided")
+	}
+
+	// Authorize action
+	if err := m.authorizeRepositoryAction(ctx, userID, pkgID); err != nil {
+		return err
+	}
+
+	// Yank package version in database
+	_, err := m.db.Exec(ctx, yankVersionDBQ, userID, pkgID, version, strings.TrimSpace(reason))
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
+// authorizeRepositoryAction checks if the user provided is allowed to update
+// the repository the package belongs to, when it is owned by an organization.
+// The ownership of repositories owned by users is checked in the database.
+func (m *Manager) authorizeRepositoryAction(ctx context.Context, userID, pkgID string) error {
+	var repoName, orgName string
+	if err := m.db.QueryRow(ctx, getPkgRepositoryDBQ, pkgID).Scan(&repoName, &orgName); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+	if orgName == "" {
+		return nil
+	}
+	return m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.UpdateOrganizationRepository,
+		RepositoryName:   repoName,
+	})
+}
+
 // BuildKey returns a key that identifies a concrete package version.
 func BuildKey(p *hub.Package) string {
 	return p.Name + "@" + p.Version
@@ -342,3 +658,14 @@ func areValidCapabilities(capabilities string) bool {
 	}
 	return false
 }
+
+// isValidSortOption checks if the provided packages search sort option is
+// valid.
+func isValidSortOption(sort string) bool {
+	for _, validOption := range validSortOptions {
+		if sort == validOption {
+			return true
+		}
+	}
+	return false
+}
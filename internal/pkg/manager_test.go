@@ -6,13 +6,239 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/authz"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+func TestAddVulnerabilitySuppression(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+	s := &hub.VulnerabilitySuppression{
+		VulnerabilityID: "CVE-2021-0001",
+		Justification:   "Vulnerable code not used",
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.AddVulnerabilitySuppression(context.Background(), pkgID, s)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			s         *hub.VulnerabilitySuppression
+		}{
+			{"invalid package id", "pkgID", s},
+			{"vulnerability id not provided", pkgID, nil},
+			{"vulnerability id not provided", pkgID, &hub.VulnerabilitySuppression{Justification: "Not used"}},
+			{"justification not provided", pkgID, &hub.VulnerabilitySuppression{VulnerabilityID: "CVE-2021-0001"}},
+			{"justification not provided", pkgID, &hub.VulnerabilitySuppression{VulnerabilityID: "CVE-2021-0001", Justification: " "}},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.AddVulnerabilitySuppression(ctx, tc.packageID, tc.s)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("user not authorized to update the organization repository", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(db, az)
+
+		err := m.AddVulnerabilitySuppression(ctx, pkgID, s)
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		db.On("Exec", ctx, addVulnerabilitySuppressionDBQ, "userID", pkgID, s.VulnerabilityID, s.Justification).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.AddVulnerabilitySuppression(ctx, pkgID, s)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", ""}, nil)
+				db.On("Exec", ctx, addVulnerabilitySuppressionDBQ, "userID", pkgID, s.VulnerabilityID, s.Justification).
+					Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.AddVulnerabilitySuppression(ctx, pkgID, s)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestDeleteVulnerabilitySuppression(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteVulnerabilitySuppression(context.Background(), pkgID, "CVE-2021-0001")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg          string
+			packageID       string
+			vulnerabilityID string
+		}{
+			{"invalid package id", "pkgID", "CVE-2021-0001"},
+			{"vulnerability id not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.DeleteVulnerabilitySuppression(ctx, tc.packageID, tc.vulnerabilityID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("user not authorized to update the organization repository", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(db, az)
+
+		err := m.DeleteVulnerabilitySuppression(ctx, pkgID, "CVE-2021-0001")
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		db.On("Exec", ctx, deleteVulnerabilitySuppressionDBQ, "userID", pkgID, "CVE-2021-0001").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.DeleteVulnerabilitySuppression(ctx, pkgID, "CVE-2021-0001")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", ""}, nil)
+				db.On("Exec", ctx, deleteVulnerabilitySuppressionDBQ, "userID", pkgID, "CVE-2021-0001").Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.DeleteVulnerabilitySuppression(ctx, pkgID, "CVE-2021-0001")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestGet(t *testing.T) {
 	ctx := context.Background()
 	input := &hub.GetPackageInput{
@@ -23,7 +249,7 @@ func TestGet(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(nil, nil)
 		_, err := m.Get(ctx, &hub.GetPackageInput{})
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
@@ -32,7 +258,7 @@ func TestGet(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		p, err := m.Get(ctx, input)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -228,7 +454,7 @@ func TestGet(t *testing.T) {
 			}
 		}
 		`), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		p, err := m.Get(ctx, input)
 		assert.NoError(t, err)
@@ -244,7 +470,7 @@ func TestGetChangeLogJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgChangeLogDBQ, "pkg1").Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetChangeLogJSON(ctx, "pkg1")
 		assert.NoError(t, err)
@@ -256,7 +482,7 @@ func TestGetChangeLogJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgChangeLogDBQ, "pkg1").Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetChangeLogJSON(ctx, "pkg1")
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -272,7 +498,7 @@ func TestGetHarborReplicationDumpJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getHarborReplicationDumpDBQ).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetHarborReplicationDumpJSON(ctx)
 		assert.NoError(t, err)
@@ -284,7 +510,7 @@ func TestGetHarborReplicationDumpJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getHarborReplicationDumpDBQ).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetHarborReplicationDumpJSON(ctx)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -303,7 +529,7 @@ func TestGetJSON(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(nil, nil)
 		_, err := m.GetJSON(ctx, &hub.GetPackageInput{})
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
@@ -312,7 +538,7 @@ func TestGetJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetJSON(ctx, input)
 		assert.NoError(t, err)
@@ -324,7 +550,7 @@ func TestGetJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetJSON(ctx, input)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -340,7 +566,7 @@ func TestGetRandomJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getRandomPkgsDBQ).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetRandomJSON(ctx)
 		assert.NoError(t, err)
@@ -352,7 +578,7 @@ func TestGetRandomJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getRandomPkgsDBQ).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetRandomJSON(ctx)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -361,6 +587,128 @@ func TestGetRandomJSON(t *testing.T) {
 	})
 }
 
+func TestGetRecentReleases(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetRecentReleasesInput
+		}{
+			{
+				"repository name or publisher not provided",
+				&hub.GetRecentReleasesInput{},
+			},
+			{
+				"invalid limit",
+				&hub.GetRecentReleasesInput{Publisher: "org1", Limit: -1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				_, err := m.GetRecentReleases(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		input := &hub.GetRecentReleasesInput{RepositoryName: "repo1"}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRecentReleasesDBQ, []byte(`{"repository_name":"repo1"}`)).Return([]byte(`
+		[{
+			"package_id": "00000000-0000-0000-0000-000000000001",
+			"name": "pkg1",
+			"normalized_name": "pkg1",
+			"version": "1.0.0",
+			"ts": 1592299234,
+			"repository": {
+				"kind": 0,
+				"name": "repo1",
+				"user_alias": "user1"
+			}
+		}]
+		`), nil)
+		m := NewManager(db, nil)
+
+		releases, err := m.GetRecentReleases(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.Package{
+			{
+				PackageID:      "00000000-0000-0000-0000-000000000001",
+				Name:           "pkg1",
+				NormalizedName: "pkg1",
+				Version:        "1.0.0",
+				TS:             1592299234,
+				Repository: &hub.Repository{
+					Kind:      hub.Helm,
+					Name:      "repo1",
+					UserAlias: "user1",
+				},
+			},
+		}, releases)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		input := &hub.GetRecentReleasesInput{Publisher: "org1"}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRecentReleasesDBQ, []byte(`{"publisher":"org1"}`)).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		releases, err := m.GetRecentReleases(ctx, input)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, releases)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetSBOMs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSBOMsDBQ, "pkg1", "1.0.0").Return([]byte(`[{"format": "spdx", "content": "SPDXVersion: SPDX-2.2"}]`), nil)
+		m := NewManager(db, nil)
+
+		sboms, err := m.GetSBOMs(ctx, "pkg1", "1.0.0")
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.SBOM{{Format: "spdx", Content: "SPDXVersion: SPDX-2.2"}}, sboms)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("snapshot has no sboms", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSBOMsDBQ, "pkg1", "1.0.0").Return([]byte(`[]`), nil)
+		m := NewManager(db, nil)
+
+		sboms, err := m.GetSBOMs(ctx, "pkg1", "1.0.0")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, sboms)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSBOMsDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		sboms, err := m.GetSBOMs(ctx, "pkg1", "1.0.0")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, sboms)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -368,7 +716,7 @@ func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
 		assert.NoError(t, err)
@@ -380,7 +728,7 @@ func TestGetSnapshotSecurityReportJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getSnapshotSecurityReportDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetSnapshotSecurityReportJSON(ctx, "pkg1", "1.0.0")
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -396,7 +744,7 @@ func TestGetSnapshotsToScan(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getSnapshotsToScanDBQ).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		s, err := m.GetSnapshotsToScan(ctx)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -421,7 +769,7 @@ func TestGetSnapshotsToScan(t *testing.T) {
 			}
 		]
 		`), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		s, err := m.GetSnapshotsToScan(ctx)
 		assert.NoError(t, err)
@@ -440,7 +788,7 @@ func TestGetStarredByUserJSON(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(nil, nil)
 		assert.Panics(t, func() {
 			_, _ = m.GetStarredByUserJSON(context.Background())
 		})
@@ -450,7 +798,7 @@ func TestGetStarredByUserJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgsStarredByUserDBQ, "userID").Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetStarredByUserJSON(ctx)
 		assert.NoError(t, err)
@@ -462,7 +810,7 @@ func TestGetStarredByUserJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgsStarredByUserDBQ, "userID").Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetStarredByUserJSON(ctx)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -487,7 +835,7 @@ func TestGetStarsJSON(t *testing.T) {
 		for _, tc := range testCases {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
-				m := NewManager(nil)
+				m := NewManager(nil, nil)
 				_, err := m.GetStarsJSON(ctx, tc.packageID)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
@@ -499,7 +847,7 @@ func TestGetStarsJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgStarsDBQ, mock.Anything, pkgID).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		_, err := m.GetStarsJSON(ctx, pkgID)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -510,7 +858,7 @@ func TestGetStarsJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgStarsDBQ, mock.Anything, pkgID).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetStarsJSON(ctx, pkgID)
 		assert.NoError(t, err)
@@ -526,7 +874,7 @@ func TestGetStatsJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgsStatsDBQ).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetStatsJSON(ctx)
 		assert.NoError(t, err)
@@ -538,7 +886,7 @@ func TestGetStatsJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgsStatsDBQ).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetStatsJSON(ctx)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -557,7 +905,7 @@ func TestGetSummaryJSON(t *testing.T) {
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(nil, nil)
 		_, err := m.GetJSON(ctx, &hub.GetPackageInput{})
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 	})
@@ -566,7 +914,7 @@ func TestGetSummaryJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgSummaryDBQ, inputJSON).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetSummaryJSON(ctx, input)
 		assert.NoError(t, err)
@@ -578,7 +926,7 @@ func TestGetSummaryJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getPkgSummaryDBQ, inputJSON).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetSummaryJSON(ctx, input)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -587,6 +935,91 @@ func TestGetSummaryJSON(t *testing.T) {
 	})
 }
 
+func TestGetValuesDiffJSON(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			pkgID  string
+			from   string
+			to     string
+		}{
+			{
+				"invalid package id",
+				"pkgID",
+				"1.0.0",
+				"2.0.0",
+			},
+			{
+				"from version not provided",
+				pkgID,
+				"",
+				"2.0.0",
+			},
+			{
+				"to version not provided",
+				pkgID,
+				"1.0.0",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				_, err := m.GetValuesDiffJSON(ctx, tc.pkgID, tc.from, tc.to)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDefaultValuesDBQ, pkgID, "1.0.0").Return(nil, pgx.ErrNoRows)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetValuesDiffJSON(ctx, pkgID, "1.0.0", "2.0.0")
+		assert.Equal(t, hub.ErrNotFound, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDefaultValuesDBQ, pkgID, "1.0.0").Return("key: value\n", nil)
+		db.On("QueryRow", ctx, getDefaultValuesDBQ, pkgID, "2.0.0").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetValuesDiffJSON(ctx, pkgID, "1.0.0", "2.0.0")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("values diff returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDefaultValuesDBQ, pkgID, "1.0.0").Return("key: value1\n", nil)
+		db.On("QueryRow", ctx, getDefaultValuesDBQ, pkgID, "2.0.0").Return("key: value2\n", nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetValuesDiffJSON(ctx, pkgID, "1.0.0", "2.0.0")
+		assert.NoError(t, err)
+		var diff *ValuesDiff
+		require.NoError(t, json.Unmarshal(dataJSON, &diff))
+		assert.Equal(t, []*ValuesChange{
+			{Path: "key", Kind: ValuesChangeModified, From: "value1", To: "value2"},
+		}, diff.Changes)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetValuesSchemaJSON(t *testing.T) {
 	ctx := context.Background()
 
@@ -594,7 +1027,7 @@ func TestGetValuesSchemaJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getValuesSchemaDBQ, "pkg1", "1.0.0").Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetValuesSchemaJSON(ctx, "pkg1", "1.0.0")
 		assert.NoError(t, err)
@@ -606,7 +1039,7 @@ func TestGetValuesSchemaJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, getValuesSchemaDBQ, "pkg1", "1.0.0").Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.GetValuesSchemaJSON(ctx, "pkg1", "1.0.0")
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -615,6 +1048,42 @@ func TestGetValuesSchemaJSON(t *testing.T) {
 	})
 }
 
+func TestGetVulnerabilitiesSuppressionsJSON(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid package id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.GetVulnerabilitiesSuppressionsJSON(ctx, "pkgID")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getVulnerabilitiesSuppressionsDBQ, pkgID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetVulnerabilitiesSuppressionsJSON(ctx, pkgID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getVulnerabilitiesSuppressionsDBQ, pkgID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetVulnerabilitiesSuppressionsJSON(ctx, pkgID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestRegister(t *testing.T) {
 	ctx := context.Background()
 
@@ -779,7 +1248,7 @@ func TestRegister(t *testing.T) {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(nil, nil)
 				err := m.Register(ctx, tc.p)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
@@ -791,7 +1260,7 @@ func TestRegister(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, registerPkgDBQ, mock.Anything).Return(nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.Register(ctx, newTestPkg())
 		assert.NoError(t, err)
@@ -802,7 +1271,7 @@ func TestRegister(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, registerPkgDBQ, mock.Anything).Return(tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.Register(ctx, newTestPkg())
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -810,6 +1279,154 @@ func TestRegister(t *testing.T) {
 	})
 }
 
+func TestRequestSnapshotSecurityReportRescan(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RequestSnapshotSecurityReportRescan(context.Background(), pkgID, "1.0.0")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+		}{
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.RequestSnapshotSecurityReportRescan(ctx, tc.packageID, tc.version)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("error getting package repository", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				pgx.ErrNoRows,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.RequestSnapshotSecurityReportRescan(ctx, pkgID, "1.0.0")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user not authorized to update the organization repository", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(db, az)
+
+		err := m.RequestSnapshotSecurityReportRescan(ctx, pkgID, "1.0.0")
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		db.On("Exec", ctx, requestRescanDBQ, "userID", pkgID, "1.0.0").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.RequestSnapshotSecurityReportRescan(ctx, pkgID, "1.0.0")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (repository owned by a user)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", ""}, nil)
+		db.On("Exec", ctx, requestRescanDBQ, "userID", pkgID, "1.0.0").Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.RequestSnapshotSecurityReportRescan(ctx, pkgID, "1.0.0")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", ""}, nil)
+				db.On("Exec", ctx, requestRescanDBQ, "userID", pkgID, "1.0.0").Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.RequestSnapshotSecurityReportRescan(ctx, pkgID, "1.0.0")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestSearchJSON(t *testing.T) {
 	ctx := context.Background()
 	input := &hub.SearchPackageInput{
@@ -847,6 +1464,21 @@ func TestSearchJSON(t *testing.T) {
 					Offset: -1,
 				},
 			},
+			{
+				"offset and cursor cannot be used together",
+				&hub.SearchPackageInput{
+					Limit:  10,
+					Offset: 10,
+					Cursor: "eyJuYW1lIjogInBrZzEifQ",
+				},
+			},
+			{
+				"invalid cursor",
+				&hub.SearchPackageInput{
+					Limit:  10,
+					Cursor: "invalid",
+				},
+			},
 			{
 				"invalid user alias",
 				&hub.SearchPackageInput{
@@ -868,12 +1500,19 @@ func TestSearchJSON(t *testing.T) {
 					Repositories: []string{""},
 				},
 			},
+			{
+				"invalid sort option",
+				&hub.SearchPackageInput{
+					Limit: 10,
+					Sort:  "invalid",
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(nil, nil)
 				dataJSON, err := m.SearchJSON(ctx, tc.input)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
@@ -886,7 +1525,7 @@ func TestSearchJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, searchPkgsDBQ, mock.Anything).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.SearchJSON(ctx, input)
 		assert.NoError(t, err)
@@ -898,13 +1537,27 @@ func TestSearchJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, searchPkgsDBQ, mock.Anything).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.SearchJSON(ctx, input)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, dataJSON)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("cursor rejected by the database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchPkgsDBQ, mock.Anything).
+			Return(nil, &pgconn.PgError{Code: "22023", Message: "invalid cursor"})
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.SearchJSON(ctx, input)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid cursor")
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestSearchMonocularJSON(t *testing.T) {
@@ -916,7 +1569,7 @@ func TestSearchMonocularJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, searchPkgsMonocularDBQ, baseURL, searchTerm).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.SearchMonocularJSON(ctx, baseURL, searchTerm)
 		assert.NoError(t, err)
@@ -928,7 +1581,7 @@ func TestSearchMonocularJSON(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("QueryRow", ctx, searchPkgsMonocularDBQ, baseURL, searchTerm).Return(nil, tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		dataJSON, err := m.SearchMonocularJSON(ctx, baseURL, searchTerm)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -943,7 +1596,7 @@ func TestToggleStar(t *testing.T) {
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
-		m := NewManager(nil)
+		m := NewManager(nil, nil)
 		assert.Panics(t, func() {
 			_ = m.ToggleStar(context.Background(), "pkgID")
 		})
@@ -961,7 +1614,7 @@ func TestToggleStar(t *testing.T) {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(nil, nil)
 				err := m.ToggleStar(ctx, tc.packageID)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
@@ -973,7 +1626,7 @@ func TestToggleStar(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, togglePkgStarDBQ, "userID", pkgID).Return(nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.ToggleStar(ctx, pkgID)
 		assert.NoError(t, err)
@@ -984,7 +1637,7 @@ func TestToggleStar(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, togglePkgStarDBQ, "userID", pkgID).Return(tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.ToggleStar(ctx, pkgID)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -1016,7 +1669,7 @@ func TestUpdateSnapshotSecurityReport(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, updateSnapshotSecurityReportDBQ, sJSON).Return(tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.UpdateSnapshotSecurityReport(ctx, s)
 		assert.Equal(t, tests.ErrFakeDB, err)
@@ -1027,7 +1680,7 @@ func TestUpdateSnapshotSecurityReport(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, updateSnapshotSecurityReportDBQ, sJSON).Return(nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.UpdateSnapshotSecurityReport(ctx, s)
 		assert.NoError(t, err)
@@ -1073,7 +1726,7 @@ func TestUnregister(t *testing.T) {
 			tc := tc
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
-				m := NewManager(nil)
+				m := NewManager(nil, nil)
 				err := m.Unregister(ctx, tc.p)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
@@ -1085,7 +1738,7 @@ func TestUnregister(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, unregisterPkgDBQ, mock.Anything).Return(nil)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.Unregister(ctx, p)
 		assert.NoError(t, err)
@@ -1096,10 +1749,224 @@ func TestUnregister(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		db.On("Exec", ctx, unregisterPkgDBQ, mock.Anything).Return(tests.ErrFakeDB)
-		m := NewManager(db)
+		m := NewManager(db, nil)
 
 		err := m.Unregister(ctx, p)
 		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
 }
+
+func TestUnyankVersion(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UnyankVersion(context.Background(), pkgID, "1.0.0")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+		}{
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.UnyankVersion(ctx, tc.packageID, tc.version)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("user not authorized to update the organization repository", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(db, az)
+
+		err := m.UnyankVersion(ctx, pkgID, "1.0.0")
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		db.On("Exec", ctx, unyankVersionDBQ, "userID", pkgID, "1.0.0").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.UnyankVersion(ctx, pkgID, "1.0.0")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", ""}, nil)
+				db.On("Exec", ctx, unyankVersionDBQ, "userID", pkgID, "1.0.0").Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.UnyankVersion(ctx, pkgID, "1.0.0")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestYankVersion(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.YankVersion(context.Background(), pkgID, "1.0.0", "reason")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			version   string
+		}{
+			{"invalid package id", "pkgID", "1.0.0"},
+			{"version not provided", pkgID, ""},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.YankVersion(ctx, tc.packageID, tc.version, "reason")
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("user not authorized to update the organization repository", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(hub.ErrInsufficientPrivilege)
+		m := NewManager(db, az)
+
+		err := m.YankVersion(ctx, pkgID, "1.0.0", "reason")
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", "org1"}, nil)
+		db.On("Exec", ctx, yankVersionDBQ, "userID", pkgID, "1.0.0", "reason").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.YankVersion(ctx, pkgID, "1.0.0", "reason")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getPkgRepositoryDBQ, pkgID).Return([]interface{}{"repo1", ""}, nil)
+				db.On("Exec", ctx, yankVersionDBQ, "userID", pkgID, "1.0.0", "reason").Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.YankVersion(ctx, pkgID, "1.0.0", "reason")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
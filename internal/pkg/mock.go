@@ -12,6 +12,22 @@ type ManagerMock struct {
 	mock.Mock
 }
 
+// AddVulnerabilitySuppression implements the PackageManager interface.
+func (m *ManagerMock) AddVulnerabilitySuppression(
+	ctx context.Context,
+	pkgID string,
+	s *hub.VulnerabilitySuppression,
+) error {
+	args := m.Called(ctx, pkgID, s)
+	return args.Error(0)
+}
+
+// DeleteVulnerabilitySuppression implements the PackageManager interface.
+func (m *ManagerMock) DeleteVulnerabilitySuppression(ctx context.Context, pkgID, vulnerabilityID string) error {
+	args := m.Called(ctx, pkgID, vulnerabilityID)
+	return args.Error(0)
+}
+
 // Get implements the PackageManager interface.
 func (m *ManagerMock) Get(ctx context.Context, input *hub.GetPackageInput) (*hub.Package, error) {
 	args := m.Called(ctx, input)
@@ -47,6 +63,23 @@ func (m *ManagerMock) GetRandomJSON(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// GetRecentReleases implements the PackageManager interface.
+func (m *ManagerMock) GetRecentReleases(
+	ctx context.Context,
+	input *hub.GetRecentReleasesInput,
+) ([]*hub.Package, error) {
+	args := m.Called(ctx, input)
+	releases, _ := args.Get(0).([]*hub.Package)
+	return releases, args.Error(1)
+}
+
+// GetSBOMs implements the PackageManager interface.
+func (m *ManagerMock) GetSBOMs(ctx context.Context, pkgID, version string) ([]*hub.SBOM, error) {
+	args := m.Called(ctx, pkgID, version)
+	sboms, _ := args.Get(0).([]*hub.SBOM)
+	return sboms, args.Error(1)
+}
+
 // GetSnapshotSecurityReportJSON implements the PackageManager interface.
 func (m *ManagerMock) GetSnapshotSecurityReportJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	args := m.Called(ctx, pkgID, version)
@@ -89,6 +122,13 @@ func (m *ManagerMock) GetSummaryJSON(ctx context.Context, input *hub.GetPackageI
 	return data, args.Error(1)
 }
 
+// GetValuesDiffJSON implements the PackageManager interface.
+func (m *ManagerMock) GetValuesDiffJSON(ctx context.Context, pkgID, from, to string) ([]byte, error) {
+	args := m.Called(ctx, pkgID, from, to)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetValuesSchemaJSON implements the PackageManager interface.
 func (m *ManagerMock) GetValuesSchemaJSON(ctx context.Context, pkgID, version string) ([]byte, error) {
 	args := m.Called(ctx, pkgID, version)
@@ -96,12 +136,25 @@ func (m *ManagerMock) GetValuesSchemaJSON(ctx context.Context, pkgID, version st
 	return data, args.Error(1)
 }
 
+// GetVulnerabilitiesSuppressionsJSON implements the PackageManager interface.
+func (m *ManagerMock) GetVulnerabilitiesSuppressionsJSON(ctx context.Context, pkgID string) ([]byte, error) {
+	args := m.Called(ctx, pkgID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // Register implements the PackageManager interface.
 func (m *ManagerMock) Register(ctx context.Context, pkg *hub.Package) error {
 	args := m.Called(ctx, pkg)
 	return args.Error(0)
 }
 
+// RequestSnapshotSecurityReportRescan implements the PackageManager interface.
+func (m *ManagerMock) RequestSnapshotSecurityReportRescan(ctx context.Context, pkgID, version string) error {
+	args := m.Called(ctx, pkgID, version)
+	return args.Error(0)
+}
+
 // SearchJSON implements the PackageManager interface.
 func (m *ManagerMock) SearchJSON(ctx context.Context, input *hub.SearchPackageInput) ([]byte, error) {
 	args := m.Called(ctx, input)
@@ -133,3 +186,15 @@ func (m *ManagerMock) Unregister(ctx context.Context, pkg *hub.Package) error {
 	args := m.Called(ctx, pkg)
 	return args.Error(0)
 }
+
+// UnyankVersion implements the PackageManager interface.
+func (m *ManagerMock) UnyankVersion(ctx context.Context, pkgID, version string) error {
+	args := m.Called(ctx, pkgID, version)
+	return args.Error(0)
+}
+
+// YankVersion implements the PackageManager interface.
+func (m *ManagerMock) YankVersion(ctx context.Context, pkgID, version, reason string) error {
+	args := m.Called(ctx, pkgID, version, reason)
+	return args.Error(0)
+}
@@ -0,0 +1,120 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// SBOM formats supported.
+const (
+	SBOMFormatCycloneDXJSON = "cyclonedx-json"
+	SBOMFormatCycloneDXXML  = "cyclonedx-xml"
+	SBOMFormatSPDX          = "spdx"
+	SBOMFormatSPDXJSON      = "spdx-json"
+)
+
+// MaxSBOMSize represents the maximum size of the SBOMs that will be ingested.
+const MaxSBOMSize = 10 << 20 // 10 MiB
+
+var (
+	// ErrUnsupportedSBOMFormat indicates that the format of the SBOM provided
+	// is not supported.
+	ErrUnsupportedSBOMFormat = errors.New("unsupported sbom format")
+
+	// sbomFormatsMediaTypes represents the media types used to serve the SBOMs
+	// of each of the formats supported.
+	sbomFormatsMediaTypes = map[string]string{
+		SBOMFormatCycloneDXJSON: "application/vnd.cyclonedx+json",
+		SBOMFormatCycloneDXXML:  "application/vnd.cyclonedx+xml",
+		SBOMFormatSPDX:          "text/spdx",
+		SBOMFormatSPDXJSON:      "application/spdx+json",
+	}
+
+	// sbomMediaTypesFormats represents the media types (including some
+	// aliases used by the tools that attach SBOMs to OCI artifacts) that can
+	// be used to identify the format of a SBOM.
+	sbomMediaTypesFormats = map[string]string{
+		"application/vnd.cyclonedx+json": SBOMFormatCycloneDXJSON,
+		"application/vnd.cyclonedx+xml":  SBOMFormatCycloneDXXML,
+		"text/spdx":                      SBOMFormatSPDX,
+		"application/spdx+json":          SBOMFormatSPDXJSON,
+		"text/spdx+json":                 SBOMFormatSPDXJSON,
+		"spdx+json":                      SBOMFormatSPDXJSON,
+	}
+)
+
+// ParseSBOM prepares a SBOM from the data provided. The SBOM format is
+// detected from the media type provided when possible, or from the content
+// otherwise.
+func ParseSBOM(data []byte, mediaType string) (*hub.SBOM, error) {
+	if len(data) > MaxSBOMSize {
+		return nil, errors.New("sbom too big")
+	}
+	format := SBOMFormatFromMediaType(mediaType)
+	if format == "" {
+		format = detectSBOMFormat(data)
+	}
+	switch format {
+	case "":
+		return nil, ErrUnsupportedSBOMFormat
+	case SBOMFormatCycloneDXJSON, SBOMFormatSPDXJSON:
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("invalid %s sbom", format)
+		}
+	}
+	return &hub.SBOM{
+		Format:  format,
+		Content: string(data),
+	}, nil
+}
+
+// SBOMFormatFromMediaType returns the SBOM format identified by the media type
+// provided, or an empty string if it's not a SBOM media type.
+func SBOMFormatFromMediaType(mediaType string) string {
+	mediaType, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return ""
+	}
+	return sbomMediaTypesFormats[mediaType]
+}
+
+// SBOMMediaType returns the media type used to serve the SBOMs of the format
+// provided.
+func SBOMMediaType(format string) string {
+	return sbomFormatsMediaTypes[format]
+}
+
+// detectSBOMFormat tries to detect the format of the SBOM provided from its
+// content, returning an empty string if it couldn't be detected.
+func detectSBOMFormat(data []byte) string {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("{")):
+		var doc struct {
+			BOMFormat   string `json:"bomFormat"`
+			SPDXVersion string `json:"spdxVersion"`
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return ""
+		}
+		switch {
+		case doc.BOMFormat == "CycloneDX":
+			return SBOMFormatCycloneDXJSON
+		case doc.SPDXVersion != "":
+			return SBOMFormatSPDXJSON
+		}
+	case bytes.HasPrefix(data, []byte("<")):
+		if bytes.Contains(data, []byte("<bom")) && bytes.Contains(data, []byte("cyclonedx.org/schema/bom")) {
+			return SBOMFormatCycloneDXXML
+		}
+	case strings.HasPrefix(string(data), "SPDXVersion:"):
+		return SBOMFormatSPDX
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSBOM(t *testing.T) {
+	t.Run("valid sboms", func(t *testing.T) {
+		testCases := []struct {
+			data           string
+			mediaType      string
+			expectedFormat string
+		}{
+			{`{"bomFormat": "CycloneDX", "specVersion": "1.3"}`, "", SBOMFormatCycloneDXJSON},
+			{`{"bomFormat": "CycloneDX", "specVersion": "1.3"}`, "application/vnd.cyclonedx+json", SBOMFormatCycloneDXJSON},
+			{`<?xml version="1.0"?><bom xmlns="http://cyclonedx.org/schema/bom/1.3"></bom>`, "", SBOMFormatCycloneDXXML},
+			{`{"spdxVersion": "SPDX-2.2"}`, "", SBOMFormatSPDXJSON},
+			{`{"spdxVersion": "SPDX-2.2"}`, "text/spdx+json; charset=utf-8", SBOMFormatSPDXJSON},
+			{"SPDXVersion: SPDX-2.2\nDataLicense: CC0-1.0", "", SBOMFormatSPDX},
+			{"SPDXVersion: SPDX-2.2\nDataLicense: CC0-1.0", "text/spdx", SBOMFormatSPDX},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedFormat, func(t *testing.T) {
+				t.Parallel()
+				sbom, err := ParseSBOM([]byte(tc.data), tc.mediaType)
+				assert.NoError(t, err)
+				assert.Equal(t, &hub.SBOM{Format: tc.expectedFormat, Content: tc.data}, sbom)
+			})
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+		sbom, err := ParseSBOM([]byte(`{"key": "value"}`), "application/json")
+		assert.Equal(t, ErrUnsupportedSBOMFormat, err)
+		assert.Nil(t, sbom)
+	})
+
+	t.Run("invalid json sbom", func(t *testing.T) {
+		t.Parallel()
+		sbom, err := ParseSBOM([]byte(`{`), "application/spdx+json")
+		assert.EqualError(t, err, "invalid spdx-json sbom")
+		assert.Nil(t, sbom)
+	})
+}
+
+func TestSBOMMediaType(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "application/vnd.cyclonedx+json", SBOMMediaType(SBOMFormatCycloneDXJSON))
+	assert.Equal(t, "text/spdx", SBOMMediaType(SBOMFormatSPDX))
+	assert.Equal(t, "", SBOMMediaType("unknown"))
+}
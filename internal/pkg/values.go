@@ -0,0 +1,130 @@
+package pkg
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of changes in the values diff.
+const (
+	ValuesChangeAdded    = "added"
+	ValuesChangeRemoved  = "removed"
+	ValuesChangeModified = "modified"
+)
+
+// valuesDiffContextLines represents the number of context lines included in
+// the values unified diff.
+const valuesDiffContextLines = 3
+
+// ValuesDiff represents the differences between the default values of two
+// versions of a Helm chart.
+type ValuesDiff struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Changes []*ValuesChange `json:"changes"`
+	Unified string          `json:"unified"`
+}
+
+// ValuesChange represents a change in a value between two versions of a Helm
+// chart. Values are identified by their path (i.e. image.tag).
+type ValuesChange struct {
+	Path string      `json:"path"`
+	Kind string      `json:"kind"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// DiffValues returns the differences between the default values (values.yaml
+// content) of the two versions provided. Both a structured list of changes,
+// where values are identified by their path, and a unified diff of the raw
+// content are returned.
+func DiffValues(fromVersion, fromValues, toVersion, toValues string) (*ValuesDiff, error) {
+	// Structured diff
+	var from, to interface{}
+	if err := yaml.Unmarshal([]byte(fromValues), &from); err != nil {
+		return nil, fmt.Errorf("error parsing values of version %s: %w", fromVersion, err)
+	}
+	if err := yaml.Unmarshal([]byte(toValues), &to); err != nil {
+		return nil, fmt.Errorf("error parsing values of version %s: %w", toVersion, err)
+	}
+	fromLeaves := make(map[string]interface{})
+	flattenValues("", from, fromLeaves)
+	toLeaves := make(map[string]interface{})
+	flattenValues("", to, toLeaves)
+	changes := make([]*ValuesChange, 0)
+	for path, fromValue := range fromLeaves {
+		toValue, ok := toLeaves[path]
+		switch {
+		case !ok:
+			changes = append(changes, &ValuesChange{Path: path, Kind: ValuesChangeRemoved, From: fromValue})
+		case !reflect.DeepEqual(fromValue, toValue):
+			changes = append(changes, &ValuesChange{
+				Path: path,
+				Kind: ValuesChangeModified,
+				From: fromValue,
+				To:   toValue,
+			})
+		}
+	}
+	for path, toValue := range toLeaves {
+		if _, ok := fromLeaves[path]; !ok {
+			changes = append(changes, &ValuesChange{Path: path, Kind: ValuesChangeAdded, To: toValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	// Unified diff
+	unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(fromValues),
+		B:        difflib.SplitLines(toValues),
+		FromFile: fromVersion + "/values.yaml",
+		ToFile:   toVersion + "/values.yaml",
+		Context:  valuesDiffContextLines,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValuesDiff{
+		From:    fromVersion,
+		To:      toVersion,
+		Changes: changes,
+		Unified: unified,
+	}, nil
+}
+
+// flattenValues registers in the leaves map provided the leaf values found in
+// the value provided, using their path as key. Lists are considered leaf
+// values, as their items cannot be identified reliably between versions.
+func flattenValues(path string, value interface{}, leaves map[string]interface{}) {
+	m, ok := value.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		if path != "" {
+			leaves[path] = value
+		}
+		return
+	}
+	for k, v := range m {
+		flattenValues(joinValuesPath(path, k), v, leaves)
+	}
+}
+
+// joinValuesPath appends the key provided to the values path. Keys containing
+// dots are quoted so that paths remain unambiguous.
+func joinValuesPath(path, key string) string {
+	if strings.Contains(key, ".") {
+		key = strconv.Quote(key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,80 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffValues(t *testing.T) {
+	t.Run("invalid values", func(t *testing.T) {
+		t.Parallel()
+		diff, err := DiffValues("1.0.0", "key: [", "2.0.0", "key: value")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error parsing values of version 1.0.0")
+		assert.Nil(t, diff)
+	})
+
+	t.Run("no differences", func(t *testing.T) {
+		t.Parallel()
+		values := "image:\n  tag: 1.0.0\n"
+		diff, err := DiffValues("1.0.0", values, "2.0.0", values)
+		require.NoError(t, err)
+		assert.Equal(t, &ValuesDiff{
+			From:    "1.0.0",
+			To:      "2.0.0",
+			Changes: []*ValuesChange{},
+			Unified: "",
+		}, diff)
+	})
+
+	t.Run("values added, removed and modified", func(t *testing.T) {
+		t.Parallel()
+		fromValues := `image:
+  repository: org/img
+  tag: 1.0.0
+replicas: 1
+annotations:
+  example.com/key: value
+`
+		toValues := `image:
+  repository: org/img
+  tag: 2.0.0
+  pullPolicy: IfNotPresent
+annotations:
+  example.com/key: value
+ports: [80, 443]
+`
+		diff, err := DiffValues("1.0.0", fromValues, "2.0.0", toValues)
+		require.NoError(t, err)
+		assert.Equal(t, []*ValuesChange{
+			{Path: "image.pullPolicy", Kind: ValuesChangeAdded, To: "IfNotPresent"},
+			{Path: "image.tag", Kind: ValuesChangeModified, From: "1.0.0", To: "2.0.0"},
+			{Path: "ports", Kind: ValuesChangeAdded, To: []interface{}{80, 443}},
+			{Path: "replicas", Kind: ValuesChangeRemoved, From: 1},
+		}, diff.Changes)
+		assert.Equal(t, `--- 1.0.0/values.yaml
++++ 2.0.0/values.yaml
+@@ -1,6 +1,7 @@
+ image:
+   repository: org/img
+-  tag: 1.0.0
+-replicas: 1
++  tag: 2.0.0
++  pullPolicy: IfNotPresent
+ annotations:
+   example.com/key: value
++ports: [80, 443]
+`, diff.Unified)
+	})
+
+	t.Run("keys containing dots are quoted", func(t *testing.T) {
+		t.Parallel()
+		diff, err := DiffValues("1.0.0", "annotations:\n  example.com/key: v1\n", "2.0.0", "annotations:\n  example.com/key: v2\n")
+		require.NoError(t, err)
+		assert.Equal(t, []*ValuesChange{
+			{Path: `annotations."example.com/key"`, Kind: ValuesChangeModified, From: "v1", To: "v2"},
+		}, diff.Changes)
+	})
+}
@@ -0,0 +1,144 @@
+package pulls
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	getPackagePullsDBQ      = `select get_package_pulls($1::uuid, $2::int)`
+	getTrendingPackagesDBQ  = `select get_trending_packages($1::jsonb)`
+	registerPackagePullsDBQ = `select register_package_pulls($1::uuid, $2::text, $3::jsonb)`
+
+	// DefaultDays represents the default number of days of pulls returned.
+	DefaultDays = 30
+
+	// MaxDays represents the maximum number of days of pulls that can be
+	// requested.
+	MaxDays = 365
+
+	// DefaultTrendingLimit represents the default number of trending packages
+	// returned.
+	DefaultTrendingLimit = 10
+
+	// MaxTrendingLimit represents the maximum number of trending packages
+	// that can be requested.
+	MaxTrendingLimit = 50
+
+	// MaxPullsPerRequest represents the maximum number of daily pull counts
+	// that can be registered in a single request.
+	MaxPullsPerRequest = 1000
+
+	dayLayout = "2006-01-02"
+)
+
+// Manager provides an API to manage packages pulls statistics.
+type Manager struct {
+	db hub.DB
+	rm hub.RepositoryManager
+	az hub.Authorizer
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, rm hub.RepositoryManager, az hub.Authorizer) *Manager {
+	return &Manager{
+		db: db,
+		rm: rm,
+		az: az,
+	}
+}
+
+// GetPackagePullsJSON returns the daily pull counts of the provided package
+// for the number of days provided as a json object.
+func (m *Manager) GetPackagePullsJSON(ctx context.Context, packageID string, days int) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(packageID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if days < 1 || days > MaxDays {
+		return nil, fmt.Errorf("%w: %s (1-%d)", hub.ErrInvalidInput, "invalid days", MaxDays)
+	}
+
+	// Get package pulls from database
+	return util.DBQueryJSON(ctx, m.db, getPackagePullsDBQ, packageID, days)
+}
+
+// GetTrendingPackagesJSON returns the packages with the highest number of
+// pulls during the last week as a json array.
+func (m *Manager) GetTrendingPackagesJSON(ctx context.Context, input *hub.GetTrendingPackagesInput) ([]byte, error) {
+	// Validate input
+	if input.Limit < 0 || input.Limit > MaxTrendingLimit {
+		return nil, fmt.Errorf("%w: %s (0 < l <= %d)", hub.ErrInvalidInput, "invalid limit", MaxTrendingLimit)
+	}
+	if input.Limit == 0 {
+		input.Limit = DefaultTrendingLimit
+	}
+
+	// Get trending packages from database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, getTrendingPackagesDBQ, inputJSON)
+}
+
+// Register registers the daily pull counts provided for the packages of the
+// repository provided. This allows publishers to ingest the pull counts
+// obtained from their Helm repositories logs or OCI registries.
+func (m *Manager) Register(ctx context.Context, repoName string, pulls []*hub.PackagePulls) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+	if len(pulls) == 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "pulls not provided")
+	}
+	if len(pulls) > MaxPullsPerRequest {
+		return fmt.Errorf("%w: %s (max: %d)", hub.ErrInvalidInput, "too many pulls entries", MaxPullsPerRequest)
+	}
+	for _, p := range pulls {
+		if p.PackageName == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package name not provided")
+		}
+		day, err := time.Parse(dayLayout, p.Day)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid day", p.Day)
+		}
+		if day.After(time.Now()) {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "day in the future", p.Day)
+		}
+		if p.Pulls < 0 {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid pulls count")
+		}
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.rm.GetByName(ctx, repoName, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   repoName,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Register pulls in database
+	pullsJSON, _ := json.Marshal(pulls)
+	_, err = m.db.Exec(ctx, registerPackagePullsDBQ, userID, repoName, pullsJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
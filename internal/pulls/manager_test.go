@@ -0,0 +1,275 @@
+package pulls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPackagePullsJSON(t *testing.T) {
+	ctx := context.Background()
+	pkgID := "00000000-0000-0000-0000-000000000001"
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			pkgID  string
+			days   int
+		}{
+			{
+				"invalid package id",
+				"pkgID",
+				30,
+			},
+			{
+				"invalid days",
+				pkgID,
+				0,
+			},
+			{
+				"invalid days",
+				pkgID,
+				MaxDays + 1,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				_, err := m.GetPackagePullsJSON(ctx, tc.pkgID, tc.days)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagePullsDBQ, pkgID, 30).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil, nil)
+
+		dataJSON, err := m.GetPackagePullsJSON(ctx, pkgID, 30)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPackagePullsDBQ, pkgID, 30).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil, nil)
+
+		dataJSON, err := m.GetPackagePullsJSON(ctx, pkgID, 30)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetTrendingPackagesJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid limit", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		_, err := m.GetTrendingPackagesJSON(ctx, &hub.GetTrendingPackagesInput{Limit: MaxTrendingLimit + 1})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid limit")
+	})
+
+	t.Run("database query succeeded (default limit)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getTrendingPackagesDBQ, []byte(`{"limit":10}`)).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil, nil)
+
+		dataJSON, err := m.GetTrendingPackagesJSON(ctx, &hub.GetTrendingPackagesInput{})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (kind provided)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getTrendingPackagesDBQ, []byte(`{"kind":0,"limit":5}`)).Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil, nil)
+
+		kind := hub.Helm
+		dataJSON, err := m.GetTrendingPackagesJSON(ctx, &hub.GetTrendingPackagesInput{Kind: &kind, Limit: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getTrendingPackagesDBQ, []byte(`{"limit":10}`)).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil, nil)
+
+		dataJSON, err := m.GetTrendingPackagesJSON(ctx, &hub.GetTrendingPackagesInput{})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegister(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	pulls := []*hub.PackagePulls{
+		{PackageName: "pkg1", Day: "2021-05-01", Pulls: 10},
+	}
+	pullsJSON := []byte(`[{"package_name":"pkg1","day":"2021-05-01","pulls":10}]`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Register(context.Background(), "repo1", pulls)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			repoName string
+			pulls    []*hub.PackagePulls
+		}{
+			{
+				"repository name not provided",
+				"",
+				pulls,
+			},
+			{
+				"pulls not provided",
+				"repo1",
+				nil,
+			},
+			{
+				"too many pulls entries",
+				"repo1",
+				make([]*hub.PackagePulls, MaxPullsPerRequest+1),
+			},
+			{
+				"package name not provided",
+				"repo1",
+				[]*hub.PackagePulls{{Day: "2021-05-01", Pulls: 10}},
+			},
+			{
+				"invalid day",
+				"repo1",
+				[]*hub.PackagePulls{{PackageName: "pkg1", Day: "01/05/2021", Pulls: 10}},
+			},
+			{
+				"day in the future",
+				"repo1",
+				[]*hub.PackagePulls{{PackageName: "pkg1", Day: "2999-01-01", Pulls: 10}},
+			},
+			{
+				"invalid pulls count",
+				"repo1",
+				[]*hub.PackagePulls{{PackageName: "pkg1", Day: "2021-05-01", Pulls: -1}},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil, nil)
+				err := m.Register(ctx, tc.repoName, tc.pulls)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("error getting repository", func(t *testing.T) {
+		t.Parallel()
+		rm := &repo.ManagerMock{}
+		rm.On("GetByName", ctx, "repo1", false).Return(nil, tests.ErrFakeDB)
+		m := NewManager(nil, rm, nil)
+
+		err := m.Register(ctx, "repo1", pulls)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		rm := &repo.ManagerMock{}
+		rm.On("GetByName", ctx, "repo1", false).Return(&hub.Repository{
+			Name:             "repo1",
+			OrganizationName: "org1",
+		}, nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, &hub.AuthorizeInput{
+			OrganizationName: "org1",
+			UserID:           "userID",
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   "repo1",
+		}).Return(tests.ErrFake)
+		m := NewManager(nil, rm, az)
+
+		err := m.Register(ctx, "repo1", pulls)
+		assert.Equal(t, tests.ErrFake, err)
+		rm.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				rm := &repo.ManagerMock{}
+				rm.On("GetByName", ctx, "repo1", false).Return(&hub.Repository{Name: "repo1"}, nil)
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, registerPackagePullsDBQ, "userID", "repo1", pullsJSON).Return(tc.dbErr)
+				m := NewManager(db, rm, nil)
+
+				err := m.Register(ctx, "repo1", pulls)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				rm.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("pulls registered successfully", func(t *testing.T) {
+		t.Parallel()
+		rm := &repo.ManagerMock{}
+		rm.On("GetByName", ctx, "repo1", false).Return(&hub.Repository{Name: "repo1"}, nil)
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, registerPackagePullsDBQ, "userID", "repo1", pullsJSON).Return(nil)
+		m := NewManager(db, rm, nil)
+
+		err := m.Register(ctx, "repo1", pulls)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		rm.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,36 @@
+package pulls
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the PullsManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// GetPackagePullsJSON implements the PullsManager interface.
+func (m *ManagerMock) GetPackagePullsJSON(ctx context.Context, packageID string, days int) ([]byte, error) {
+	args := m.Called(ctx, packageID, days)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetTrendingPackagesJSON implements the PullsManager interface.
+func (m *ManagerMock) GetTrendingPackagesJSON(
+	ctx context.Context,
+	input *hub.GetTrendingPackagesInput,
+) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Register implements the PullsManager interface.
+func (m *ManagerMock) Register(ctx context.Context, repoName string, pulls []*hub.PackagePulls) error {
+	args := m.Called(ctx, repoName, pulls)
+	return args.Error(0)
+}
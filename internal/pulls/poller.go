@@ -0,0 +1,150 @@
+package pulls
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	getPullsPollingSourcesDBQ    = `select get_pulls_polling_sources()`
+	registerPackagePullsTotalDBQ = `select register_package_pulls_total($1::uuid, $2::bigint)`
+
+	defaultPollingInterval = 24 * time.Hour
+
+	// dockerHubAPIURL represents the url of the Docker Hub API, used to get
+	// the pull count of the repositories hosted there.
+	dockerHubAPIURL = "https://hub.docker.com/v2/repositories"
+)
+
+// dockerHubHosts represents the hosts that can be used to reference the
+// artifacts stored in Docker Hub.
+var dockerHubHosts = map[string]struct{}{
+	"docker.io":               {},
+	"index.docker.io":         {},
+	"registry-1.docker.io":    {},
+	"registry.hub.docker.com": {},
+}
+
+// Poller is in charge of polling periodically the pull counts of the packages
+// stored in OCI registries that expose them. At the moment only Docker Hub is
+// supported, as the OCI distribution spec does not define a way to get them.
+type Poller struct {
+	db       hub.DB
+	hc       hub.HTTPClient
+	interval time.Duration
+	apiURL   string
+}
+
+// NewPoller creates a new Poller instance.
+func NewPoller(cfg *viper.Viper, db hub.DB, hc hub.HTTPClient) *Poller {
+	cfg.SetDefault("pulls.polling.interval", defaultPollingInterval)
+	return &Poller{
+		db:       db,
+		hc:       hc,
+		interval: cfg.GetDuration("pulls.polling.interval"),
+		apiURL:   dockerHubAPIURL,
+	}
+}
+
+// Run runs the poller periodically until it's asked to stop via the context
+// provided.
+func (p *Poller) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.Poll(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("error polling packages pulls")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll registers the current pull count of the packages stored in supported
+// registries, returning the number of packages whose pull count was polled
+// successfully.
+func (p *Poller) Poll(ctx context.Context) (int, error) {
+	var sources []*hub.PullsPollingSource
+	if err := util.DBQueryUnmarshal(ctx, p.db, &sources, getPullsPollingSourcesDBQ); err != nil {
+		return 0, err
+	}
+	var polled int
+	for _, s := range sources {
+		if ctx.Err() != nil {
+			return polled, ctx.Err()
+		}
+		repository, ok := getDockerHubRepository(s.Ref)
+		if !ok {
+			continue
+		}
+		logger := log.With().Str("packageID", s.PackageID).Str("ref", s.Ref).Logger()
+		total, err := p.getDockerHubPullCount(ctx, repository)
+		if err != nil {
+			logger.Warn().Err(err).Msg("error getting pull count")
+			continue
+		}
+		if _, err := p.db.Exec(ctx, registerPackagePullsTotalDBQ, s.PackageID, total); err != nil {
+			logger.Error().Err(err).Msg("error registering pull count")
+			continue
+		}
+		polled++
+	}
+	return polled, nil
+}
+
+// getDockerHubPullCount returns the pull count of the provided Docker Hub
+// repository.
+func (p *Poller) getDockerHubPullCount(ctx context.Context, repository string) (int64, error) {
+	req, _ := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s/", p.apiURL, repository), nil)
+	resp, err := p.hc.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	var data struct {
+		PullCount *int64 `json:"pull_count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, err
+	}
+	if data.PullCount == nil {
+		return 0, fmt.Errorf("pull count not found")
+	}
+	return *data.PullCount, nil
+}
+
+// getDockerHubRepository returns the Docker Hub repository (namespace/name)
+// referenced by the OCI reference provided, when it points to Docker Hub.
+func getDockerHubRepository(ref string) (string, bool) {
+	ref = strings.TrimPrefix(ref, hub.RepositoryOCIPrefix)
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 {
+		return "", false
+	}
+	if _, ok := dockerHubHosts[parts[0]]; !ok {
+		return "", false
+	}
+	if parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1] + "/" + parts[2], true
+}
@@ -0,0 +1,88 @@
+package pulls
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPollerPoll(t *testing.T) {
+	ctx := context.Background()
+	sourcesJSON := []byte(`[
+		{"package_id": "00000000-0000-0000-0000-000000000001", "ref": "oci://registry-1.docker.io/org/pkg1"},
+		{"package_id": "00000000-0000-0000-0000-000000000002", "ref": "oci://ghcr.io/org/pkg2"},
+		{"package_id": "00000000-0000-0000-0000-000000000003", "ref": "oci://docker.io/org/pkg3"}
+	]`)
+
+	t.Run("error getting polling sources", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPullsPollingSourcesDBQ).Return(nil, tests.ErrFakeDB)
+		p := NewPoller(viper.New(), db, nil)
+
+		polled, err := p.Poll(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Zero(t, polled)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("pull counts polled from supported registries", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPullsPollingSourcesDBQ).Return(sourcesJSON, nil)
+		db.On("Exec", ctx, registerPackagePullsTotalDBQ, "00000000-0000-0000-0000-000000000001", int64(1000)).
+			Return(nil)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == dockerHubAPIURL+"/org/pkg1/"
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(`{"pull_count": 1000}`)),
+			StatusCode: http.StatusOK,
+		}, nil)
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == dockerHubAPIURL+"/org/pkg3/"
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		p := NewPoller(viper.New(), db, hc)
+
+		polled, err := p.Poll(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, polled)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+	})
+}
+
+func TestGetDockerHubRepository(t *testing.T) {
+	testCases := []struct {
+		ref                string
+		expectedRepository string
+		expectedOK         bool
+	}{
+		{"oci://registry-1.docker.io/org/pkg", "org/pkg", true},
+		{"oci://docker.io/org/pkg", "org/pkg", true},
+		{"oci://index.docker.io/org/pkg", "org/pkg", true},
+		{"oci://ghcr.io/org/pkg", "", false},
+		{"oci://docker.io/pkg", "", false},
+		{"oci://docker.io/org/subpath/pkg", "", false},
+		{"oci://docker.io//pkg", "", false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.ref, func(t *testing.T) {
+			t.Parallel()
+			repository, ok := getDockerHubRepository(tc.ref)
+			assert.Equal(t, tc.expectedRepository, repository)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
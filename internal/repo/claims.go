@@ -0,0 +1,196 @@
+package repo
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+)
+
+const (
+	// Database queries
+	getPendingOwnershipClaimsDBQ   = `select get_pending_repository_ownership_claims()`
+	registerOwnershipClaimCheckDBQ = `select register_repository_ownership_claim_check($1::uuid, $2::text)`
+	verifyOwnershipClaimDBQ        = `select verify_repository_ownership_claim($1::uuid)`
+
+	defaultClaimsCheckInterval = 15 * time.Minute
+
+	// ownershipProofDNSRecordPrefix represents the prefix added to the host
+	// serving the repository to build the name of the DNS TXT record that
+	// must contain the ownership proof.
+	ownershipProofDNSRecordPrefix = "_artifacthub-challenge."
+
+	// ownershipProofDNSValuePrefix represents the prefix of the value of the
+	// DNS TXT record expected, followed by the claim token.
+	ownershipProofDNSValuePrefix = "artifacthub-claim="
+
+	// ownershipProofHTTPPath represents the well-known path where the file
+	// containing the ownership proof must be served from. The file may
+	// contain multiple tokens, one per line.
+	ownershipProofHTTPPath = "/.well-known/artifacthub-claims.txt"
+
+	// ownershipProofHTTPMaxSize represents the maximum number of bytes read
+	// from the file containing the ownership proof.
+	ownershipProofHTTPMaxSize = 64 * 1024
+)
+
+// errOwnershipProofNotFound indicates that the ownership proof expected was
+// not found.
+var errOwnershipProofNotFound = errors.New("ownership proof not found")
+
+// TXTResolver defines the methods a TXTResolver implementation must provide.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// OwnershipClaimsChecker is in charge of verifying periodically the proofs of
+// the pending repository ownership claims, transferring the repositories once
+// their proofs are found.
+type OwnershipClaimsChecker struct {
+	db       hub.DB
+	hc       hub.HTTPClient
+	resolver TXTResolver
+	interval time.Duration
+}
+
+// NewOwnershipClaimsChecker creates a new OwnershipClaimsChecker instance.
+func NewOwnershipClaimsChecker(
+	cfg *viper.Viper,
+	db hub.DB,
+	hc hub.HTTPClient,
+	resolver TXTResolver,
+) *OwnershipClaimsChecker {
+	cfg.SetDefault("repositories.ownershipClaims.checkInterval", defaultClaimsCheckInterval)
+	return &OwnershipClaimsChecker{
+		db:       db,
+		hc:       hc,
+		resolver: resolver,
+		interval: cfg.GetDuration("repositories.ownershipClaims.checkInterval"),
+	}
+}
+
+// Run runs the checker periodically until it's asked to stop via the context
+// provided.
+func (c *OwnershipClaimsChecker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.Check(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("error checking repositories ownership claims")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check verifies the proofs of the pending ownership claims, returning the
+// number of claims verified successfully.
+func (c *OwnershipClaimsChecker) Check(ctx context.Context) (int, error) {
+	var claims []*hub.OwnershipClaim
+	if err := util.DBQueryUnmarshal(ctx, c.db, &claims, getPendingOwnershipClaimsDBQ); err != nil {
+		return 0, err
+	}
+	var verified int
+	for _, claim := range claims {
+		if ctx.Err() != nil {
+			return verified, ctx.Err()
+		}
+		if err := c.checkProof(ctx, claim); err != nil {
+			c.registerCheckError(ctx, claim, err)
+			continue
+		}
+		if _, err := c.db.Exec(ctx, verifyOwnershipClaimDBQ, claim.OwnershipClaimID); err != nil {
+			c.registerCheckError(ctx, claim, err)
+			continue
+		}
+		verified++
+	}
+	return verified, nil
+}
+
+// registerCheckError registers the error that prevented the verification of
+// the ownership claim provided.
+func (c *OwnershipClaimsChecker) registerCheckError(ctx context.Context, claim *hub.OwnershipClaim, checkErr error) {
+	_, err := c.db.Exec(ctx, registerOwnershipClaimCheckDBQ, claim.OwnershipClaimID, checkErr.Error())
+	if err != nil {
+		log.Error().Err(err).Str("claimID", claim.OwnershipClaimID).Msg("error registering ownership claim check")
+	}
+}
+
+// checkProof checks if the proof expected for the ownership claim provided
+// can be found.
+func (c *OwnershipClaimsChecker) checkProof(ctx context.Context, claim *hub.OwnershipClaim) error {
+	proof, err := getOwnershipProof(claim.Method, claim.RepositoryURL, claim.Token)
+	if err != nil {
+		return err
+	}
+	switch claim.Method {
+	case hub.OwnershipProofDNS:
+		records, err := c.resolver.LookupTXT(ctx, proof.Target)
+		if err != nil {
+			return fmt.Errorf("error looking up dns txt record: %w", err)
+		}
+		for _, record := range records {
+			if record == proof.Value {
+				return nil
+			}
+		}
+	case hub.OwnershipProofHTTP:
+		req, _ := http.NewRequestWithContext(ctx, "GET", proof.Target, nil)
+		resp, err := c.hc.Do(req)
+		if err != nil {
+			return fmt.Errorf("error getting proof file: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+		}
+		scanner := bufio.NewScanner(io.LimitReader(resp.Body, ownershipProofHTTPMaxSize))
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == proof.Value {
+				return nil
+			}
+		}
+	}
+	return errOwnershipProofNotFound
+}
+
+// getOwnershipProof returns the details of the proof expected to verify an
+// ownership claim of a repository served from the url provided, using the
+// given method and token.
+func getOwnershipProof(method hub.OwnershipProofMethod, repoURL, token string) (*hub.OwnershipProof, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Hostname() == "" {
+		return nil, errors.New("invalid repository url")
+	}
+	switch method {
+	case hub.OwnershipProofDNS:
+		return &hub.OwnershipProof{
+			Target: ownershipProofDNSRecordPrefix + u.Hostname(),
+			Value:  ownershipProofDNSValuePrefix + token,
+		}, nil
+	case hub.OwnershipProofHTTP:
+		return &hub.OwnershipProof{
+			Target: "https://" + u.Host + ownershipProofHTTPPath,
+			Value:  token,
+		}, nil
+	default:
+		return nil, errors.New("invalid proof method")
+	}
+}
@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOwnershipClaimsCheckerCheck(t *testing.T) {
+	ctx := context.Background()
+	claim1ID := "00000000-0000-0000-0000-000000000001"
+	claim2ID := "00000000-0000-0000-0000-000000000002"
+	claimsJSON := []byte(`[
+		{
+			"repository_ownership_claim_id": "00000000-0000-0000-0000-000000000001",
+			"repository_url": "https://repo1.com/charts",
+			"method": "dns",
+			"token": "token1"
+		},
+		{
+			"repository_ownership_claim_id": "00000000-0000-0000-0000-000000000002",
+			"repository_url": "oci://registry.repo2.com/charts/pkg",
+			"method": "http",
+			"token": "token2"
+		}
+	]`)
+
+	t.Run("error getting pending claims", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPendingOwnershipClaimsDBQ).Return(nil, tests.ErrFakeDB)
+		c := NewOwnershipClaimsChecker(viper.New(), db, nil, nil)
+
+		verified, err := c.Check(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Zero(t, verified)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("proofs not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPendingOwnershipClaimsDBQ).Return(claimsJSON, nil)
+		db.On("Exec", ctx, registerOwnershipClaimCheckDBQ, claim1ID, errOwnershipProofNotFound.Error()).Return(nil)
+		db.On("Exec", ctx, registerOwnershipClaimCheckDBQ, claim2ID, "unexpected status code received: 404").
+			Return(nil)
+		r := &txtResolverMock{}
+		r.On("LookupTXT", ctx, "_artifacthub-challenge.repo1.com").Return([]string{"other"}, nil)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://registry.repo2.com/.well-known/artifacthub-claims.txt"
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+			StatusCode: http.StatusNotFound,
+		}, nil)
+		c := NewOwnershipClaimsChecker(viper.New(), db, hc, r)
+
+		verified, err := c.Check(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, verified)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+		r.AssertExpectations(t)
+	})
+
+	t.Run("proofs found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPendingOwnershipClaimsDBQ).Return(claimsJSON, nil)
+		db.On("Exec", ctx, verifyOwnershipClaimDBQ, claim1ID).Return(nil)
+		db.On("Exec", ctx, verifyOwnershipClaimDBQ, claim2ID).Return(tests.ErrFakeDB)
+		db.On("Exec", ctx, registerOwnershipClaimCheckDBQ, claim2ID, tests.ErrFakeDB.Error()).Return(nil)
+		r := &txtResolverMock{}
+		r.On("LookupTXT", ctx, "_artifacthub-challenge.repo1.com").
+			Return([]string{"other", "artifacthub-claim=token1"}, nil)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", mock.Anything).Return(&http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader("token0\n  token2  \n")),
+			StatusCode: http.StatusOK,
+		}, nil)
+		c := NewOwnershipClaimsChecker(viper.New(), db, hc, r)
+
+		verified, err := c.Check(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, verified)
+		db.AssertExpectations(t)
+		hc.AssertExpectations(t)
+		r.AssertExpectations(t)
+	})
+}
+
+func TestGetOwnershipProof(t *testing.T) {
+	testCases := []struct {
+		method        hub.OwnershipProofMethod
+		repoURL       string
+		expectedProof *hub.OwnershipProof
+		expectedError string
+	}{
+		{
+			hub.OwnershipProofDNS,
+			"https://charts.repo1.com:8443/stable",
+			&hub.OwnershipProof{Target: "_artifacthub-challenge.charts.repo1.com", Value: "artifacthub-claim=token"},
+			"",
+		},
+		{
+			hub.OwnershipProofHTTP,
+			"https://charts.repo1.com:8443/stable",
+			&hub.OwnershipProof{Target: "https://charts.repo1.com:8443/.well-known/artifacthub-claims.txt", Value: "token"},
+			"",
+		},
+		{
+			hub.OwnershipProofHTTP,
+			"oci://registry.repo1.com/charts/pkg",
+			&hub.OwnershipProof{Target: "https://registry.repo1.com/.well-known/artifacthub-claims.txt", Value: "token"},
+			"",
+		},
+		{
+			hub.OwnershipProofDNS,
+			"repo1",
+			nil,
+			"invalid repository url",
+		},
+		{
+			hub.OwnershipProofMethod("invalid"),
+			"https://repo1.com",
+			nil,
+			"invalid proof method",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(string(tc.method)+" "+tc.repoURL, func(t *testing.T) {
+			t.Parallel()
+			proof, err := getOwnershipProof(tc.method, tc.repoURL, "token")
+			assert.Equal(t, tc.expectedProof, proof)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type txtResolverMock struct {
+	mock.Mock
+}
+
+func (m *txtResolverMock) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	args := m.Called(ctx, name)
+	records, _ := args.Get(0).([]string)
+	return records, args.Error(1)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strings"
 
 	"github.com/artifacthub/hub/internal/hub"
@@ -25,7 +26,7 @@ type Cloner struct{}
 // CloneRepository implements the hub.RepositoryCloner interface.
 func (c *Cloner) CloneRepository(ctx context.Context, r *hub.Repository) (string, string, error) {
 	// Parse repository url
-	var repoBaseURL, packagesPath string
+	var repoBaseURL string
 	switch r.Kind {
 	case hub.Falco, hub.HelmPlugin, hub.Krew, hub.OLM, hub.OPA, hub.TBAction, hub.TektonTask, hub.KedaScaler:
 		matches := GitRepoURLRE.FindStringSubmatch(r.URL)
@@ -35,21 +36,46 @@ func (c *Cloner) CloneRepository(ctx context.Context, r *hub.Repository) (string
 		if len(matches) >= 3 {
 			repoBaseURL = matches[1]
 		}
-		if len(matches) == 4 {
-			packagesPath = strings.TrimSuffix(matches[3], "/")
-		}
 	default:
 		return "", "", errors.New("repository kind not supported")
 	}
 
-	// Clone git repository
+	// Clone git repository. The reference configured in the repository can be
+	// a branch or a tag, so when no branch matches it we try again as a tag.
+	var tmpDir string
+	var err error
+	ref := GetBranch(r)
+	for _, refName := range []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(ref),
+		plumbing.NewTagReferenceName(ref),
+	} {
+		tmpDir, err = c.clone(ctx, r, repoBaseURL, refName)
+		if !errors.Is(err, git.NoMatchingRefSpecError{}) {
+			break
+		}
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return tmpDir, GetPackagesPath(r), nil
+}
+
+// clone clones the git repository located at the url provided, checking out
+// the given reference, into a new temporary directory.
+func (c *Cloner) clone(
+	ctx context.Context,
+	r *hub.Repository,
+	repoURL string,
+	refName plumbing.ReferenceName,
+) (string, error) {
 	tmpDir, err := ioutil.TempDir("", "artifact-hub")
 	if err != nil {
-		return "", "", fmt.Errorf("error creating temp dir: %w", err)
+		return "", fmt.Errorf("error creating temp dir: %w", err)
 	}
 	cloneOptions := &git.CloneOptions{
-		URL:           repoBaseURL,
-		ReferenceName: plumbing.NewBranchReferenceName(GetBranch(r)),
+		URL:           repoURL,
+		ReferenceName: refName,
 		SingleBranch:  true,
 		Depth:         1,
 	}
@@ -61,14 +87,14 @@ func (c *Cloner) CloneRepository(ctx context.Context, r *hub.Repository) (string
 	}
 	_, err = git.PlainCloneContext(ctx, tmpDir, false, cloneOptions)
 	if err != nil {
-		return "", "", err
+		os.RemoveAll(tmpDir)
+		return "", err
 	}
-
-	return tmpDir, packagesPath, nil
+	return tmpDir, nil
 }
 
-// GetBranch returns the branch configured in the repository or the default one
-// if none was provided.
+// GetBranch returns the branch (or tag) configured in the repository or the
+// default one if none was provided.
 func GetBranch(r *hub.Repository) string {
 	branch := r.Branch
 	if branch == "" {
@@ -76,3 +102,17 @@ func GetBranch(r *hub.Repository) string {
 	}
 	return branch
 }
+
+// GetPackagesPath returns the path where the packages are located in a git
+// based repository. This is the path configured in the repository or, when
+// none was provided, the one included in the repository url (if any).
+func GetPackagesPath(r *hub.Repository) string {
+	if r.Path != "" {
+		return strings.Trim(r.Path, "/")
+	}
+	matches := GitRepoURLRE.FindStringSubmatch(r.URL)
+	if len(matches) == 4 {
+		return strings.TrimSuffix(matches[3], "/")
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBranch(t *testing.T) {
+	assert.Equal(t, DefaultBranch, GetBranch(&hub.Repository{}))
+	assert.Equal(t, "v1.0.0", GetBranch(&hub.Repository{Branch: "v1.0.0"}))
+}
+
+func TestGetPackagesPath(t *testing.T) {
+	testCases := []struct {
+		r            *hub.Repository
+		expectedPath string
+	}{
+		{&hub.Repository{URL: "https://github.com/org1/repo1"}, ""},
+		{&hub.Repository{URL: "https://github.com/org1/repo1/path/"}, "path"},
+		{&hub.Repository{URL: "https://github.com/org1/repo1", Path: "/path/to/pkgs/"}, "path/to/pkgs"},
+		{&hub.Repository{URL: "https://gitlab.com/org1/repo1", Path: "path"}, "path"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.r.URL+" "+tc.r.Path, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedPath, GetPackagesPath(tc.r))
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package repo
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/artifacthub/hub/internal/hub"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/spf13/viper"
+)
+
+const (
+	// CosignKeySignature represents a cosign signature created with a key.
+	CosignKeySignature = "cosign-key"
+
+	// CosignKeylessSignature represents a cosign signature created using the
+	// keyless mode (ephemeral keys certified by Fulcio).
+	CosignKeylessSignature = "cosign-keyless"
+
+	cosignPayloadMediaType      = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation   = "dev.cosignproject.cosign/signature"
+	cosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	cosignChainAnnotation       = "dev.sigstore.cosign/chain"
+	cosignSignatureTagSuffix    = "sig"
+
+	// maxCosignPayloadSize represents the maximum size of the signatures
+	// payloads that will be read.
+	maxCosignPayloadSize = 1 << 20 // 1 MiB
+)
+
+// fulcioIssuerOID represents the OID of the certificates extension used by
+// Fulcio to store the OIDC issuer of the identity certified.
+var fulcioIssuerOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+
+// CosignVerifier provides a mechanism to verify the cosign signatures of the
+// artifacts stored in OCI registries. Signatures created with a key are
+// verified using the public keys provided, whereas keyless signatures are
+// verified checking that the signing certificate was issued by one of the
+// Fulcio roots provided. Transparency log (Rekor) inclusion is not verified.
+type CosignVerifier struct {
+	keys            []crypto.PublicKey
+	roots           *x509.CertPool
+	trustedBuilders map[string]struct{}
+}
+
+// NewCosignVerifier creates a new CosignVerifier instance using the PEM
+// encoded public keys (tracker.cosign.keys) and Fulcio root certificates
+// (tracker.cosign.fulcioRoots) available in the configuration provided. The
+// SLSA builders trusted (tracker.slsa.trustedBuilders) are used to compute
+// the level of the provenance attestations verified.
+func NewCosignVerifier(cfg *viper.Viper) (*CosignVerifier, error) {
+	v := &CosignVerifier{
+		trustedBuilders: make(map[string]struct{}),
+	}
+	for _, keyPEM := range cfg.GetStringSlice("tracker.cosign.keys") {
+		block, _ := pem.Decode([]byte(keyPEM))
+		if block == nil {
+			return nil, errors.New("invalid cosign public key: pem block not found")
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cosign public key: %w", err)
+		}
+		v.keys = append(v.keys, key)
+	}
+	if roots := cfg.GetStringSlice("tracker.cosign.fulcioRoots"); len(roots) > 0 {
+		v.roots = x509.NewCertPool()
+		for _, rootPEM := range roots {
+			if !v.roots.AppendCertsFromPEM([]byte(rootPEM)) {
+				return nil, errors.New("invalid fulcio root certificate")
+			}
+		}
+	}
+	for _, builderID := range cfg.GetStringSlice("tracker.slsa.trustedBuilders") {
+		v.trustedBuilders[builderID] = struct{}{}
+	}
+	return v, nil
+}
+
+// Verify returns the cosign signatures found for the provided repository
+// version, indicating for each of them if it could be verified or not.
+func (v *CosignVerifier) Verify(ctx context.Context, r *hub.Repository, version string) ([]*hub.Signature, error) {
+	sigImg, digest, err := getAttachedImage(ctx, r, version, cosignSignatureTagSuffix)
+	if err != nil || sigImg == nil {
+		return nil, err
+	}
+	sigManifest, err := sigImg.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify signatures
+	var signatures []*hub.Signature
+	for _, layer := range sigManifest.Layers {
+		if string(layer.MediaType) != cosignPayloadMediaType {
+			continue
+		}
+		payload, err := readCosignLayer(sigImg, layer)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, v.verifySignature(payload, layer.Annotations, digest))
+	}
+	return signatures, nil
+}
+
+// readCosignLayer reads the content of the layer provided, which is expected to
+// be small as it contains a signature or attestation payload.
+func readCosignLayer(img v1.Image, layer v1.Descriptor) ([]byte, error) {
+	l, err := img.LayerByDigest(layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := l.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return ioutil.ReadAll(io.LimitReader(rc, maxCosignPayloadSize))
+}
+
+// verifySignature verifies the signature of the payload provided, which must
+// reference the artifact digest provided. The signature, as well as the
+// signing certificate when the keyless mode was used, are read from the
+// annotations of the signature layer.
+func (v *CosignVerifier) verifySignature(payload []byte, annotations map[string]string, digest string) *hub.Signature {
+	sig, _ := base64.StdEncoding.DecodeString(annotations[cosignSignatureAnnotation])
+	s := v.checkSignature(payload, sig, annotations)
+
+	// Check the payload references the artifact
+	var p struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil || p.Critical.Image.DockerManifestDigest != digest {
+		s.Verified = false
+	}
+
+	return s
+}
+
+// checkSignature checks if the signature provided is a valid signature of the
+// data provided. When the layer annotations contain a signing certificate
+// (keyless mode), it must have been issued by one of the Fulcio roots
+// configured. Otherwise, the signature must be valid for one of the keys
+// configured.
+func (v *CosignVerifier) checkSignature(data, sig []byte, annotations map[string]string) *hub.Signature {
+	s := &hub.Signature{Kind: CosignKeySignature}
+
+	// Parse signing certificate when available (keyless mode)
+	var cert *x509.Certificate
+	if certPEM := annotations[cosignCertificateAnnotation]; certPEM != "" {
+		s.Kind = CosignKeylessSignature
+		block, _ := pem.Decode([]byte(certPEM))
+		if block == nil {
+			return s
+		}
+		var err error
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return s
+		}
+		s.Identity, s.Issuer = getCertificateIdentity(cert)
+	}
+
+	// Verify signature
+	if len(sig) == 0 {
+		return s
+	}
+	if cert != nil {
+		if v.roots == nil {
+			return s
+		}
+		intermediates := x509.NewCertPool()
+		intermediates.AppendCertsFromPEM([]byte(annotations[cosignChainAnnotation]))
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:         v.roots,
+			Intermediates: intermediates,
+			CurrentTime:   cert.NotBefore,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		})
+		if err != nil {
+			return s
+		}
+		s.Verified = verifyWithKey(cert.PublicKey, data, sig)
+		return s
+	}
+	for _, key := range v.keys {
+		if verifyWithKey(key, data, sig) {
+			s.Verified = true
+			s.Identity = getKeyFingerprint(key)
+			break
+		}
+	}
+	return s
+}
+
+// getCertificateIdentity returns the identity (email or uri) and the issuer
+// certified by the Fulcio certificate provided.
+func getCertificateIdentity(cert *x509.Certificate) (identity, issuer string) {
+	switch {
+	case len(cert.EmailAddresses) > 0:
+		identity = cert.EmailAddresses[0]
+	case len(cert.URIs) > 0:
+		identity = cert.URIs[0].String()
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(fulcioIssuerOID) {
+			issuer = string(ext.Value)
+			break
+		}
+	}
+	return
+}
+
+// getKeyFingerprint returns the sha256 fingerprint of the public key provided.
+func getKeyFingerprint(key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// verifyWithKey checks if the signature provided is a valid signature of the
+// payload for the public key provided.
+func verifyWithKey(key crypto.PublicKey, payload, sig []byte) bool {
+	h := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, h[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, h[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	default:
+		return false
+	}
+}
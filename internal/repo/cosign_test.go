@@ -0,0 +1,202 @@
+package repo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cosignTestsDigest = "sha256:8f5ac5f8e4b3d6ad1fe6cb4bd1b2b2a4d1cfb93e3a85c6e2b5e1b5e5a9a3d8c2"
+
+var cosignTestsPayload = []byte(`{"critical":{"identity":{"docker-reference":"registry.io/org/pkg"},"image":{"docker-manifest-digest":"` + cosignTestsDigest + `"},"type":"cosign container image signature"},"optional":null}`)
+
+func TestNewCosignVerifier(t *testing.T) {
+	t.Run("invalid public key", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("tracker.cosign.keys", []string{"invalid"})
+		_, err := NewCosignVerifier(cfg)
+		assert.EqualError(t, err, "invalid cosign public key: pem block not found")
+	})
+
+	t.Run("invalid fulcio root", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("tracker.cosign.fulcioRoots", []string{"invalid"})
+		_, err := NewCosignVerifier(cfg)
+		assert.EqualError(t, err, "invalid fulcio root certificate")
+	})
+
+	t.Run("valid configuration", func(t *testing.T) {
+		t.Parallel()
+		key := generateTestsKey(t)
+		cfg := viper.New()
+		cfg.Set("tracker.cosign.keys", []string{encodeTestsPublicKey(t, key.Public())})
+		cfg.Set("tracker.slsa.trustedBuilders", []string{"https://builder.id"})
+		v, err := NewCosignVerifier(cfg)
+		require.NoError(t, err)
+		assert.Len(t, v.keys, 1)
+		assert.Nil(t, v.roots)
+		assert.Contains(t, v.trustedBuilders, "https://builder.id")
+	})
+}
+
+func TestCosignVerifierVerifySignature(t *testing.T) {
+	key := generateTestsKey(t)
+	sig := signTestsPayload(t, key, cosignTestsPayload)
+
+	t.Run("key: signature verified", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{keys: []crypto.PublicKey{key.Public()}}
+		s := v.verifySignature(cosignTestsPayload, map[string]string{
+			cosignSignatureAnnotation: sig,
+		}, cosignTestsDigest)
+		assert.Equal(t, &hub.Signature{
+			Kind:     CosignKeySignature,
+			Identity: getKeyFingerprint(key.Public()),
+			Verified: true,
+		}, s)
+	})
+
+	t.Run("key: signature created with a different key", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{keys: []crypto.PublicKey{generateTestsKey(t).Public()}}
+		s := v.verifySignature(cosignTestsPayload, map[string]string{
+			cosignSignatureAnnotation: sig,
+		}, cosignTestsDigest)
+		assert.Equal(t, &hub.Signature{Kind: CosignKeySignature}, s)
+	})
+
+	t.Run("key: payload referencing a different digest", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{keys: []crypto.PublicKey{key.Public()}}
+		s := v.verifySignature(cosignTestsPayload, map[string]string{
+			cosignSignatureAnnotation: sig,
+		}, "sha256:other")
+		assert.False(t, s.Verified)
+	})
+
+	t.Run("keyless: signature verified", func(t *testing.T) {
+		t.Parallel()
+		rootPEM, certPEM, signingKey := generateTestsFulcioCerts(t)
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM([]byte(rootPEM))
+		v := &CosignVerifier{roots: roots}
+		s := v.verifySignature(cosignTestsPayload, map[string]string{
+			cosignSignatureAnnotation:   signTestsPayload(t, signingKey, cosignTestsPayload),
+			cosignCertificateAnnotation: certPEM,
+		}, cosignTestsDigest)
+		assert.Equal(t, &hub.Signature{
+			Kind:     CosignKeylessSignature,
+			Identity: "jane@email.com",
+			Issuer:   "https://github.com/login/oauth",
+			Verified: true,
+		}, s)
+	})
+
+	t.Run("keyless: certificate not issued by the roots configured", func(t *testing.T) {
+		t.Parallel()
+		_, certPEM, signingKey := generateTestsFulcioCerts(t)
+		otherRootPEM, _, _ := generateTestsFulcioCerts(t)
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM([]byte(otherRootPEM))
+		v := &CosignVerifier{roots: roots}
+		s := v.verifySignature(cosignTestsPayload, map[string]string{
+			cosignSignatureAnnotation:   signTestsPayload(t, signingKey, cosignTestsPayload),
+			cosignCertificateAnnotation: certPEM,
+		}, cosignTestsDigest)
+		assert.Equal(t, &hub.Signature{
+			Kind:     CosignKeylessSignature,
+			Identity: "jane@email.com",
+			Issuer:   "https://github.com/login/oauth",
+		}, s)
+	})
+
+	t.Run("keyless: no roots configured", func(t *testing.T) {
+		t.Parallel()
+		_, certPEM, signingKey := generateTestsFulcioCerts(t)
+		v := &CosignVerifier{}
+		s := v.verifySignature(cosignTestsPayload, map[string]string{
+			cosignSignatureAnnotation:   signTestsPayload(t, signingKey, cosignTestsPayload),
+			cosignCertificateAnnotation: certPEM,
+		}, cosignTestsDigest)
+		assert.False(t, s.Verified)
+	})
+}
+
+func generateTestsKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func encodeTestsPublicKey(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func signTestsPayload(t *testing.T, key *ecdsa.PrivateKey, payload []byte) string {
+	t.Helper()
+	h := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, h[:])
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+// generateTestsFulcioCerts generates a root certificate and a short lived
+// signing certificate issued by it, like the ones issued by Fulcio.
+func generateTestsFulcioCerts(t *testing.T) (rootPEM, certPEM string, signingKey *ecdsa.PrivateKey) {
+	t.Helper()
+	now := time.Now()
+
+	rootKey := generateTestsKey(t)
+	rootTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             now.Add(-1 * time.Hour),
+		NotAfter:              now.Add(1 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTmpl, rootTmpl, rootKey.Public(), rootKey)
+	require.NoError(t, err)
+	root, err := x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	signingKey = generateTestsKey(t)
+	certTmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		NotBefore:      now.Add(-10 * time.Minute),
+		NotAfter:       now.Add(-5 * time.Minute), // Expired, verified at issuance time
+		EmailAddresses: []string{"jane@email.com"},
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{
+			{Id: fulcioIssuerOID, Value: []byte("https://github.com/login/oauth")},
+		},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, certTmpl, root, signingKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	rootPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER}))
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	return rootPEM, certPEM, signingKey
+}
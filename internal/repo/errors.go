@@ -3,8 +3,8 @@ package repo
 import (
 	"context"
 	"sort"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/rs/zerolog/log"
@@ -35,7 +35,7 @@ type ErrorsCollector struct {
 	kind ErrorsCollectorKind
 
 	mu     sync.Mutex
-	errors map[string][]string // K: repository id
+	errors map[string][]*hub.RepositoryError // K: repository id
 }
 
 // NewErrorsCollector creates a new ErrorsCollector instance.
@@ -43,47 +43,51 @@ func NewErrorsCollector(repoManager hub.RepositoryManager, kind ErrorsCollectorK
 	return &ErrorsCollector{
 		rm:     repoManager,
 		kind:   kind,
-		errors: make(map[string][]string),
+		errors: make(map[string][]*hub.RepositoryError),
 	}
 }
 
-// Append adds the error provided to the repository's list of errors.
-func (c *ErrorsCollector) Append(repositoryID string, err string) {
+// Append adds the error provided to the repository's list of errors. When the
+// error does not have a timestamp set, the current time will be used.
+func (c *ErrorsCollector) Append(repositoryID string, err *hub.RepositoryError) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if len(c.errors[repositoryID]) < maxErrorsPerRepository {
+		if err.TS == 0 {
+			err.TS = time.Now().Unix()
+		}
 		c.errors[repositoryID] = append(c.errors[repositoryID], err)
 	}
 }
 
-// Flush aggregates all errors collected per repository as a single text and
-// stores it in the database.
+// Flush stores in the database the errors collected per repository.
 func (c *ErrorsCollector) Flush() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	for repositoryID, errors := range c.errors {
-		// Sort error lines before flushing them. Packages can be processed in
-		// a repository concurrently, and the order the errors are produced is
+		// Sort errors before flushing them. Packages can be processed in a
+		// repository concurrently, and the order the errors are produced is
 		// not guaranteed. In order to be able to notify users when something
 		// goes wrong during repositories tracking or scanning, we need to be
 		// able to compare the errors produced among executions.
-		sort.Strings(errors)
-
-		var allErrors strings.Builder
-		for i, err := range errors {
-			allErrors.WriteString(err)
-			if i < len(errors)-1 {
-				allErrors.WriteString("\n")
+		sort.SliceStable(errors, func(i, j int) bool {
+			if errors[i].Message != errors[j].Message {
+				return errors[i].Message < errors[j].Message
 			}
-		}
+			if errors[i].PackageName != errors[j].PackageName {
+				return errors[i].PackageName < errors[j].PackageName
+			}
+			return errors[i].PackageVersion < errors[j].PackageVersion
+		})
+
 		var err error
 		switch c.kind {
 		case Scanner:
-			err = c.rm.SetLastScanningResults(context.Background(), repositoryID, allErrors.String())
+			err = c.rm.SetLastScanningResults(context.Background(), repositoryID, errors)
 		case Tracker:
-			err = c.rm.SetLastTrackingResults(context.Background(), repositoryID, allErrors.String())
+			err = c.rm.SetLastTrackingResults(context.Background(), repositoryID, errors)
 		}
 		if err != nil {
 			log.Error().Err(err).Str("repoID", repositoryID).Send()
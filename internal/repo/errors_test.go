@@ -3,6 +3,9 @@ package repo
 import (
 	"context"
 	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCollector(t *testing.T) {
@@ -32,14 +35,28 @@ func TestCollector(t *testing.T) {
 			ec.Init("repo1")
 
 			// Append some errors for both repositories
-			ec.Append("repo1", "error1")
-			ec.Append("repo1", "error2")
-			ec.Append("repo2", "error2")
-			ec.Append("repo2", "error1")
+			err1 := &hub.RepositoryError{
+				PackageName:    "pkg1",
+				PackageVersion: "1.0.0",
+				Class:          hub.RepositoryErrorClassPackage,
+				Message:        "error1",
+			}
+			err2 := &hub.RepositoryError{
+				Class:   hub.RepositoryErrorClassRepository,
+				Message: "error2",
+				TS:      1,
+			}
+			ec.Append("repo1", err1)
+			ec.Append("repo1", err2)
+			ec.Append("repo2", err2)
+			ec.Append("repo2", err1)
+			assert.NotZero(t, err1.TS)
+			assert.Equal(t, int64(1), err2.TS)
 
 			// Flush errors and check the results were set as expected
-			rm.On(tc.expectedCall, context.Background(), "repo1", "error1\nerror2").Return(nil)
-			rm.On(tc.expectedCall, context.Background(), "repo2", "error1\nerror2").Return(nil)
+			expectedErrs := []*hub.RepositoryError{err1, err2}
+			rm.On(tc.expectedCall, context.Background(), "repo1", expectedErrs).Return(nil)
+			rm.On(tc.expectedCall, context.Background(), "repo2", expectedErrs).Return(nil)
 			ec.Flush()
 			rm.AssertExpectations(t)
 		})
@@ -1,9 +1,13 @@
 package repo
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"path"
 	"time"
@@ -38,6 +42,22 @@ func (l *HelmIndexLoader) LoadIndex(r *hub.Repository) (*helmrepo.IndexFile, str
 			},
 		},
 	}
+	transport, err := HTTPTransport(r)
+	if err != nil {
+		return nil, "", err
+	}
+	if transport != nil {
+		getters[0].New = func(options ...getter.Option) (getter.Getter, error) {
+			return &transportHTTPGetter{
+				hc: &http.Client{
+					Timeout:   10 * time.Second,
+					Transport: transport,
+				},
+				username: r.AuthUser,
+				password: r.AuthPass,
+			}, nil
+		}
+	}
 	chartRepository, err := helmrepo.NewChartRepository(repoConfig, getters)
 	if err != nil {
 		return nil, "", err
@@ -89,3 +109,35 @@ func loadIndexFile(indexBytes []byte) (*helmrepo.IndexFile, error) {
 	indexFile.SortEntries()
 	return indexFile, nil
 }
+
+// transportHTTPGetter is a getter.Getter implementation used to download the
+// index file of repositories with TLS or proxy options. Helm's http getter
+// only supports reading certificates and keys from files, and we don't want
+// to write them to disk.
+type transportHTTPGetter struct {
+	hc       *http.Client
+	username string
+	password string
+}
+
+// Get implements the getter.Getter interface.
+func (g *transportHTTPGetter) Get(u string, options ...getter.Option) (*bytes.Buffer, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if g.username != "" || g.password != "" {
+		req.SetBasicAuth(g.username, g.password)
+	}
+	resp, err := g.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s : %s", u, resp.Status)
+	}
+	buf := bytes.NewBuffer(nil)
+	_, err = io.Copy(buf, resp.Body)
+	return buf, err
+}
@@ -2,6 +2,10 @@ package repo
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,6 +27,7 @@ import (
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/jackc/pgx/v4"
 	"github.com/satori/uuid"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v2"
@@ -36,18 +41,33 @@ const (
 	deleteRepoDBQ             = `select delete_repository($1::uuid, $2::text)`
 	getAllReposDBQ            = `select get_all_repositories($1::boolean)`
 	getOrgReposDBQ            = `select get_org_repositories($1::uuid, $2::text, $3::boolean)`
+	getOwnershipClaimDBQ      = `select get_repository_ownership_claim($1::uuid, $2::text)`
 	getRepoByIDDBQ            = `select get_repository_by_id($1::uuid, $2::boolean)`
 	getRepoByNameDBQ          = `select get_repository_by_name($1::text, $2::boolean)`
 	getRepoPkgsDigestDBQ      = `select get_repository_packages_digest($1::uuid)`
+	getRepoTrackingStatusDBQ  = `select get_repository_tracking_status($1::uuid, $2::text)`
+	getRepoTrackingWebhookDBQ = `select repository_id, tracking_webhook_secret from repository where name = $1`
 	getReposByKindDBQ         = `select get_repositories_by_kind($1::int, $2::boolean)`
 	getUserReposDBQ           = `select get_user_repositories($1::uuid, $2::boolean)`
 	getUserEmailDBQ           = `select email from "user" where user_id = $1`
-	setLastScanningResultsDBQ = `select set_last_scanning_results($1::uuid, $2::text, $3::boolean)`
-	setLastTrackingResultsDBQ = `select set_last_tracking_results($1::uuid, $2::text, $3::boolean)`
+	registerTrackingRunDBQ    = `select register_repository_tracking_run($1::uuid)`
+	requestOwnershipClaimDBQ  = `select request_repository_ownership_claim($1::uuid, $2::text, $3::text, $4::text, $5::text)`
+	requestRepoTrackingDBQ    = `update repository set tracking_requested_at = current_timestamp where repository_id = $1`
+	searchReposDBQ            = `select search_repositories($1::jsonb)`
+	setLastScanningResultsDBQ = `select set_last_scanning_results($1::uuid, $2::jsonb, $3::boolean)`
+	setLastTrackingResultsDBQ = `select set_last_tracking_results($1::uuid, $2::jsonb, $3::boolean)`
 	setVerifiedPublisherDBQ   = `select set_verified_publisher($1::uuid, $2::boolean)`
 	transferRepoDBQ           = `select transfer_repository($1::text, $2::uuid, $3::text, $4::boolean)`
 	updateRepoDBQ             = `select update_repository($1::uuid, $2::jsonb)`
+	updateRepoCredsDBQ        = `select update_repository_credentials($1::uuid, $2::text, $3::jsonb)`
 	updateRepoDigestDBQ       = `update repository set digest = $2 where repository_id = $1`
+	updateRepoTrackingDBQ     = `select update_repository_tracking_enabled($1::uuid, $2::text, $3::boolean)`
+	updateRepoTrackingWHDBQ   = `select update_repository_tracking_webhook($1::uuid, $2::text, $3::boolean)`
+	updateTrackingRunDBQ      = `select update_repository_tracking_run($1::jsonb)`
+
+	// maxSearchLimit represents the maximum number of repositories that can
+	// be requested at once when searching.
+	maxSearchLimit = 100
 )
 
 var (
@@ -57,6 +77,10 @@ var (
 	// ErrInvalidMetadata indicates that the repository metadata is not valid.
 	ErrInvalidMetadata = errors.New("invalid metadata")
 
+	// ErrDryRunNotAvailable indicates that the tracker dry-run mode is not
+	// available, as no tracker dry-runner has been provided to the manager.
+	ErrDryRunNotAvailable = errors.New("tracker dry-run not available")
+
 	// ErrSchemeNotSupported error indicates that the scheme used in the
 	// repository url is not supported.
 	ErrSchemeNotSupported = errors.New("scheme not supported")
@@ -64,8 +88,23 @@ var (
 	// GitRepoURLRE is a regexp used to validate and parse a git based
 	// repository URL.
 	GitRepoURLRE = regexp.MustCompile(`^(https:\/\/(github|gitlab)\.com\/[A-Za-z0-9_.-]+\/[A-Za-z0-9_.-]+)\/?(.*)$`)
+
+	// gitRefRE is a regexp used to validate the branch or tag configured in a
+	// git based repository.
+	gitRefRE = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*(\/[A-Za-z0-9_][A-Za-z0-9._-]*)*$`)
 )
 
+// repositoryDB represents a repository as it is sent to or received from the
+// database, where its TLS options are stored encrypted.
+type repositoryDB struct {
+	*hub.Repository
+
+	// TLS hides the plain text TLS options of the embedded repository, so
+	// that they are never sent to the database.
+	TLS       *hub.RepositoryTLSConfig `json:"tls,omitempty"`
+	TLSConfig string                   `json:"tls_config,omitempty"`
+}
+
 // HTTPGetter defines the methods an HTTPGetter implementation must provide.
 type HTTPGetter interface {
 	Get(url string) (*http.Response, error)
@@ -73,12 +112,13 @@ type HTTPGetter interface {
 
 // Manager provides an API to manage repositories.
 type Manager struct {
-	cfg             *viper.Viper
-	db              hub.DB
-	hg              HTTPGetter
-	rc              hub.RepositoryCloner
-	helmIndexLoader hub.HelmIndexLoader
-	az              hub.Authorizer
+	cfg              *viper.Viper
+	db               hub.DB
+	hg               HTTPGetter
+	rc               hub.RepositoryCloner
+	helmIndexLoader  hub.HelmIndexLoader
+	trackerDryRunner hub.TrackerDryRunner
+	az               hub.Authorizer
 }
 
 // NewManager creates a new Manager instance.
@@ -115,6 +155,14 @@ func WithHelmIndexLoader(l hub.HelmIndexLoader) func(m *Manager) {
 	}
 }
 
+// WithTrackerDryRunner allows providing a hub.TrackerDryRunner implementation
+// for a Manager instance, used to process repositories in dry-run mode.
+func WithTrackerDryRunner(dr hub.TrackerDryRunner) func(m *Manager) {
+	return func(m *Manager) {
+		m.trackerDryRunner = dr
+	}
+}
+
 // Add adds the provided repository to the database.
 func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -129,12 +177,34 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 	if !repositoryNameRE.MatchString(r.Name) {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid name")
 	}
-	if err := m.validateURL(r); err != nil {
+	if err := m.validateTLSConfig(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateProxy(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateGitOptions(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateSecretRef(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	rResolved, err := m.withResolvedCredentials(ctx, r)
+	if err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateURL(rResolved); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
 	}
 	if err := m.validateCredentials(r); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
 	}
+	if err := m.validateSchedule(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := validateRetentionPolicy(r.RetentionPolicy); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
 
 	// Authorize action if the repository will be added to an organization
 	if orgName != "" {
@@ -148,8 +218,11 @@ func (m *Manager) Add(ctx context.Context, orgName string, r *hub.Repository) er
 	}
 
 	// Add repository to the database
-	rJSON, _ := json.Marshal(r)
-	_, err := m.db.Exec(ctx, addRepoDBQ, userID, orgName, rJSON)
+	rJSON, err := m.marshalForDB(r)
+	if err != nil {
+		return err
+	}
+	_, err = m.db.Exec(ctx, addRepoDBQ, userID, orgName, rJSON)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
 		return hub.ErrInsufficientPrivilege
 	}
@@ -268,6 +341,7 @@ func (m *Manager) Delete(ctx context.Context, name string) error {
 			OrganizationName: r.OrganizationName,
 			UserID:           userID,
 			Action:           hub.DeleteOrganizationRepository,
+			RepositoryName:   name,
 		}); err != nil {
 			return err
 		}
@@ -281,11 +355,52 @@ func (m *Manager) Delete(ctx context.Context, name string) error {
 	return err
 }
 
+// DryRun processes the repository provided using the tracker in dry-run mode,
+// returning the packages it would register and the errors found. Nothing is
+// persisted, so it can be used to validate a repository before adding it.
+func (m *Manager) DryRun(ctx context.Context, r *hub.Repository) (*hub.TrackerDryRunResult, error) {
+	if m.trackerDryRunner == nil {
+		return nil, ErrDryRunNotAvailable
+	}
+
+	// Validate input
+	if !isValidKind(r.Kind) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid kind")
+	}
+	if err := m.validateTLSConfig(r); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateProxy(r); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateGitOptions(r); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateSecretRef(r); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	rResolved, err := m.withResolvedCredentials(ctx, r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateURL(rResolved); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateCredentials(r); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+
+	// Process repository in dry-run mode
+	return m.trackerDryRunner.DryRun(ctx, rResolved)
+}
+
 // GetAll returns all available repositories.
 func (m *Manager) GetAll(ctx context.Context, includeCredentials bool) ([]*hub.Repository, error) {
-	var r []*hub.Repository
-	err := util.DBQueryUnmarshal(ctx, m.db, &r, getAllReposDBQ, includeCredentials)
-	return r, err
+	var r []*repositoryDB
+	if err := util.DBQueryUnmarshal(ctx, m.db, &r, getAllReposDBQ, includeCredentials); err != nil {
+		return nil, err
+	}
+	return m.unmarshalFromDB(r)
 }
 
 // GetAllJSON returns all available repositories as a json array, which is
@@ -309,9 +424,11 @@ func (m *Manager) GetByID(
 	}
 
 	// Get repository from database
-	var r *hub.Repository
-	err := util.DBQueryUnmarshal(ctx, m.db, &r, getRepoByIDDBQ, repositoryID, includeCredentials)
-	return r, err
+	var r *repositoryDB
+	if err := util.DBQueryUnmarshal(ctx, m.db, &r, getRepoByIDDBQ, repositoryID, includeCredentials); err != nil {
+		return nil, err
+	}
+	return m.unmarshalOneFromDB(r)
 }
 
 // GetByKind returns all available repositories of the provided kind.
@@ -320,9 +437,11 @@ func (m *Manager) GetByKind(
 	kind hub.RepositoryKind,
 	includeCredentials bool,
 ) ([]*hub.Repository, error) {
-	var r []*hub.Repository
-	err := util.DBQueryUnmarshal(ctx, m.db, &r, getReposByKindDBQ, kind, includeCredentials)
-	return r, err
+	var r []*repositoryDB
+	if err := util.DBQueryUnmarshal(ctx, m.db, &r, getReposByKindDBQ, kind, includeCredentials); err != nil {
+		return nil, err
+	}
+	return m.unmarshalFromDB(r)
 }
 
 // GetByKindJSON returns all available repositories of the provided kind as a
@@ -347,9 +466,11 @@ func (m *Manager) GetByName(
 	}
 
 	// Get repository from database
-	var r *hub.Repository
-	err := util.DBQueryUnmarshal(ctx, m.db, &r, getRepoByNameDBQ, name, includeCredentials)
-	return r, err
+	var r *repositoryDB
+	if err := util.DBQueryUnmarshal(ctx, m.db, &r, getRepoByNameDBQ, name, includeCredentials); err != nil {
+		return nil, err
+	}
+	return m.unmarshalOneFromDB(r)
 }
 
 // GetMetadata reads and parses the repository metadata file provided, which
@@ -450,6 +571,30 @@ func (m *Manager) GetOwnedByUserJSON(ctx context.Context, includeCredentials boo
 	return util.DBQueryJSON(ctx, m.db, getUserReposDBQ, userID, includeCredentials)
 }
 
+// GetOwnershipClaimJSON returns the ownership claim requested by the user
+// doing the request for the provided repository, including the details of the
+// proof expected to verify it.
+func (m *Manager) GetOwnershipClaimJSON(ctx context.Context, repoName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if repoName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Get ownership claim from database and add proof details
+	var c *hub.OwnershipClaim
+	if err := util.DBQueryUnmarshal(ctx, m.db, &c, getOwnershipClaimDBQ, userID, repoName); err != nil {
+		return nil, err
+	}
+	proof, err := getOwnershipProof(c.Method, c.RepositoryURL, c.Token)
+	if err != nil {
+		return nil, err
+	}
+	c.Proof = proof
+	return json.Marshal(c)
+}
+
 // GetRemoteDigest gets the repository's digest available in the remote.
 func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (string, error) {
 	var digest string
@@ -497,7 +642,14 @@ func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (strin
 		}
 		branch := GetBranch(r)
 		for _, ref := range refs {
-			if ref.Name().IsBranch() && ref.Name().Short() == branch {
+			if ref.Name().Short() != branch {
+				continue
+			}
+			if ref.Name().IsBranch() {
+				digest = ref.Hash().String()
+				break
+			}
+			if ref.Name().IsTag() {
 				digest = ref.Hash().String()
 			}
 		}
@@ -506,9 +658,167 @@ func (m *Manager) GetRemoteDigest(ctx context.Context, r *hub.Repository) (strin
 	return digest, nil
 }
 
+// GetTrackingStatusJSON returns the tracking status of the provided
+// repository, including the progress of the current run and a summary of the
+// most recent ones, as a json object.
+func (m *Manager) GetTrackingStatusJSON(ctx context.Context, name string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Get repository tracking status from database
+	return util.DBQueryJSON(ctx, m.db, getRepoTrackingStatusDBQ, userID, name)
+}
+
+// RegisterTrackingRun registers a new queued tracking run for the provided
+// repository, returning its id.
+func (m *Manager) RegisterTrackingRun(ctx context.Context, repositoryID string) (string, error) {
+	// Validate input
+	if _, err := uuid.FromString(repositoryID); err != nil {
+		return "", fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+	}
+
+	// Register tracking run in database
+	var trackingRunID string
+	err := m.db.QueryRow(ctx, registerTrackingRunDBQ, repositoryID).Scan(&trackingRunID)
+	return trackingRunID, err
+}
+
+// RequestOwnershipClaim registers a request to claim the ownership of the
+// provided repository proving the control of the host serving it using the
+// method provided. The repository will be transferred to the destination
+// entity requested once the proof has been verified by the ownership claims
+// checker. The ownership claim registered is returned, including the details
+// of the proof expected.
+func (m *Manager) RequestOwnershipClaim(
+	ctx context.Context,
+	repoName string,
+	orgName string,
+	method hub.OwnershipProofMethod,
+) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if repoName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+	if method != hub.OwnershipProofDNS && method != hub.OwnershipProofHTTP {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid proof method")
+	}
+	r, err := m.GetByName(ctx, repoName, false)
+	if err != nil {
+		return nil, err
+	}
+	if GitRepoURLRE.MatchString(r.URL) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "proof not available for repositories hosted on github or gitlab")
+	}
+	if _, err := getOwnershipProof(method, r.URL, ""); err != nil {
+		return nil, fmt.Errorf("%w: %v", hub.ErrInvalidInput, err)
+	}
+
+	// Register ownership claim in database
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+	var orgNameP *string
+	if orgName != "" {
+		orgNameP = &orgName
+	}
+	_, err = m.db.Exec(ctx, requestOwnershipClaimDBQ, userID, repoName, orgNameP, method, hex.EncodeToString(token))
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return nil, hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return nil, hub.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return m.GetOwnershipClaimJSON(ctx, repoName)
+}
+
+// RequestTracking schedules the tracking of the provided repository, so that
+// it's processed on the next tracker run. Requests are usually sent from CI
+// workflows after publishing new content, and the payload must be signed
+// (HMAC-SHA256) using the repository tracking webhook secret.
+func (m *Manager) RequestTracking(ctx context.Context, name string, payload []byte, signature string) error {
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	signature = strings.TrimPrefix(signature, "sha256=")
+	if signature == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "signature not provided")
+	}
+
+	// Get repository tracking webhook secret from database
+	var repositoryID string
+	var secret *string
+	err := m.db.QueryRow(ctx, getRepoTrackingWebhookDBQ, name).Scan(&repositoryID, &secret)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return hub.ErrNotFound
+		}
+		return err
+	}
+
+	// Check signature
+	if secret == nil {
+		return hub.ErrInsufficientPrivilege
+	}
+	mac := hmac.New(sha256.New, []byte(*secret))
+	_, _ = mac.Write(payload)
+	if !hmac.Equal([]byte(signature), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+		return hub.ErrInsufficientPrivilege
+	}
+
+	// Schedule repository tracking
+	_, err = m.db.Exec(ctx, requestRepoTrackingDBQ, repositoryID)
+	return err
+}
+
+// SearchJSON returns a json object with the repositories matching the input
+// provided. The json object is built by the database.
+func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchRepositoryInput) ([]byte, error) {
+	// Validate input
+	if input.Limit <= 0 || input.Limit > maxSearchLimit {
+		return nil, fmt.Errorf("%w: invalid limit (0 < l <= %d)", hub.ErrInvalidInput, maxSearchLimit)
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
+	}
+	if input.Cursor != "" {
+		if input.Offset > 0 {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "offset and cursor cannot be used together")
+		}
+		if err := util.ValidateCursor(input.Cursor); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		}
+	}
+	for _, kind := range input.Kinds {
+		if !isValidKind(kind) {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid kind")
+		}
+	}
+
+	// Search repositories in database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, searchReposDBQ, inputJSON)
+}
+
 // SetLastScanningResults updates the timestamp and errors of the last scanning
 // of the provided repository in the database.
-func (m *Manager) SetLastScanningResults(ctx context.Context, repositoryID, errs string) error {
+func (m *Manager) SetLastScanningResults(
+	ctx context.Context,
+	repositoryID string,
+	errs []*hub.RepositoryError,
+) error {
 	// Validate input
 	if _, err := uuid.FromString(repositoryID); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
@@ -516,13 +826,21 @@ func (m *Manager) SetLastScanningResults(ctx context.Context, repositoryID, errs
 
 	// Update last scanning results in database
 	scanningErrorsEventsEnabled := m.cfg.GetBool("events.scanningErrors")
-	_, err := m.db.Exec(ctx, setLastScanningResultsDBQ, repositoryID, errs, scanningErrorsEventsEnabled)
+	if errs == nil {
+		errs = []*hub.RepositoryError{}
+	}
+	errsJSON, _ := json.Marshal(errs)
+	_, err := m.db.Exec(ctx, setLastScanningResultsDBQ, repositoryID, errsJSON, scanningErrorsEventsEnabled)
 	return err
 }
 
 // SetLastTrackingResults updates the timestamp and errors of the last tracking
 // of the provided repository in the database.
-func (m *Manager) SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error {
+func (m *Manager) SetLastTrackingResults(
+	ctx context.Context,
+	repositoryID string,
+	errs []*hub.RepositoryError,
+) error {
 	// Validate input
 	if _, err := uuid.FromString(repositoryID); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
@@ -530,7 +848,11 @@ func (m *Manager) SetLastTrackingResults(ctx context.Context, repositoryID, errs
 
 	// Update last tracking results in database
 	trackingErrorsEventsEnabled := m.cfg.GetBool("events.trackingErrors")
-	_, err := m.db.Exec(ctx, setLastTrackingResultsDBQ, repositoryID, errs, trackingErrorsEventsEnabled)
+	if errs == nil {
+		errs = []*hub.RepositoryError{}
+	}
+	errsJSON, _ := json.Marshal(errs)
+	_, err := m.db.Exec(ctx, setLastTrackingResultsDBQ, repositoryID, errsJSON, trackingErrorsEventsEnabled)
 	return err
 }
 
@@ -580,6 +902,7 @@ func (m *Manager) Transfer(ctx context.Context, repoName, orgName string, owners
 				OrganizationName: r.OrganizationName,
 				UserID:           userID,
 				Action:           hub.TransferOrganizationRepository,
+				RepositoryName:   repoName,
 			}); err != nil {
 				return err
 			}
@@ -602,30 +925,61 @@ func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	if r.Name == "" {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
 	}
-	if err := m.validateURL(r); err != nil {
+	rBefore, err := m.GetByName(ctx, r.Name, true)
+	if err != nil {
+		return err
+	}
+	if r.TLS == nil {
+		// Keep current TLS options when no new ones are provided, as they
+		// are never returned to clients
+		r.TLS = rBefore.TLS
+	}
+	if err := m.validateTLSConfig(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateProxy(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateGitOptions(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateSecretRef(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	rResolved, err := m.withResolvedCredentials(ctx, r)
+	if err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateURL(rResolved); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
 	}
 	if err := m.validateCredentials(r); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
 	}
+	if err := m.validateSchedule(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := validateRetentionPolicy(r.RetentionPolicy); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
 
 	// Authorize action if the repository is owned by an organization
-	rBefore, err := m.GetByName(ctx, r.Name, false)
-	if err != nil {
-		return err
-	}
 	if rBefore.OrganizationName != "" {
 		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
 			OrganizationName: rBefore.OrganizationName,
 			UserID:           userID,
 			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   r.Name,
 		}); err != nil {
 			return err
 		}
 	}
 
 	// Update repository in database
-	rJSON, _ := json.Marshal(r)
+	rJSON, err := m.marshalForDB(r)
+	if err != nil {
+		return err
+	}
 	_, err = m.db.Exec(ctx, updateRepoDBQ, userID, rJSON)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
 		return hub.ErrInsufficientPrivilege
@@ -633,12 +987,167 @@ func (m *Manager) Update(ctx context.Context, r *hub.Repository) error {
 	return err
 }
 
+// UpdateCredentials updates the credentials of the provided repository. This
+// allows rotating them without having to update the rest of the repository
+// details. The new credentials are verified against the repository before
+// being stored.
+func (m *Manager) UpdateCredentials(ctx context.Context, name string, c *hub.RepositoryCredentials) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if c == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "credentials not provided")
+	}
+	r, err := m.GetByName(ctx, name, true)
+	if err != nil {
+		return err
+	}
+
+	// Authorize action if the repository is owned by an organization
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   name,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Check the new credentials work with the repository
+	r.AuthUser = c.AuthUser
+	r.AuthPass = c.AuthPass
+	r.AuthPassRef = c.AuthPassRef
+	if err := m.validateSecretRef(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateCredentials(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.ResolveCredentials(ctx, r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+	if err := m.validateURL(r); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+	}
+
+	// Update repository credentials in database
+	cJSON, _ := json.Marshal(c)
+	_, err = m.db.Exec(ctx, updateRepoCredsDBQ, userID, name, cJSON)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
 // UpdateDigest updates the digest of the provided repository in the database.
 func (m *Manager) UpdateDigest(ctx context.Context, repositoryID, digest string) error {
 	_, err := m.db.Exec(ctx, updateRepoDigestDBQ, repositoryID, digest)
 	return err
 }
 
+// UpdateTrackingEnabled pauses or resumes the tracking of the provided
+// repository. The tracker skips repositories whose tracking has been paused,
+// but the packages already indexed are kept.
+func (m *Manager) UpdateTrackingEnabled(ctx context.Context, name string, enabled bool) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   name,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Update repository tracking enabled flag in database
+	_, err = m.db.Exec(ctx, updateRepoTrackingDBQ, userID, name, enabled)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// UpdateTrackingRun updates the status and progress of the provided tracking
+// run in the database.
+func (m *Manager) UpdateTrackingRun(ctx context.Context, run *hub.TrackingRun) error {
+	// Validate input
+	if _, err := uuid.FromString(run.TrackingRunID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid tracking run id")
+	}
+
+	// Update tracking run in database
+	runJSON, _ := json.Marshal(run)
+	_, err := m.db.Exec(ctx, updateTrackingRunDBQ, runJSON)
+	return err
+}
+
+// UpdateTrackingWebhook enables or disables the tracking webhook of the
+// provided repository. When enabled, a new secret is generated (invalidating
+// the previous one, if any) and returned along with the webhook url.
+func (m *Manager) UpdateTrackingWebhook(
+	ctx context.Context,
+	name string,
+	enabled bool,
+) (*hub.TrackingWebhook, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+
+	// Authorize action if the repository is owned by an organization
+	r, err := m.GetByName(ctx, name, false)
+	if err != nil {
+		return nil, err
+	}
+	if r.OrganizationName != "" {
+		if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+			OrganizationName: r.OrganizationName,
+			UserID:           userID,
+			Action:           hub.UpdateOrganizationRepository,
+			RepositoryName:   name,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update repository tracking webhook in database
+	var secret *string
+	err = m.db.QueryRow(ctx, updateRepoTrackingWHDBQ, userID, name, enabled).Scan(&secret)
+	if err != nil {
+		if err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+			return nil, hub.ErrInsufficientPrivilege
+		}
+		return nil, err
+	}
+	if !enabled || secret == nil {
+		return nil, nil
+	}
+	return &hub.TrackingWebhook{
+		URL:    m.cfg.GetString("server.baseURL") + "/api/v1/repositories/" + name + "/tracking-webhook",
+		Secret: *secret,
+	}, nil
+}
+
 // validateURL validates the url of the repository provided.
 func (m *Manager) validateURL(r *hub.Repository) error {
 	if r.URL == "" {
@@ -665,6 +1174,18 @@ func (m *Manager) validateURL(r *hub.Repository) error {
 		if SchemeIsHTTP(u) && !GitRepoURLRE.MatchString(r.URL) {
 			return errors.New("invalid url format")
 		}
+	case hub.Wasm:
+		if u.Scheme != "oci" {
+			return errors.New("invalid url format: WASM repositories must be stored in OCI registries")
+		}
+	case hub.CrossplaneConfiguration:
+		if u.Scheme != "oci" {
+			return errors.New("invalid url format: Crossplane repositories must be stored in OCI registries")
+		}
+	case hub.OCIArtifact:
+		if u.Scheme != "oci" {
+			return errors.New("invalid url format: OCI artifacts repositories must be stored in OCI registries")
+		}
 	}
 	return nil
 }
@@ -672,12 +1193,154 @@ func (m *Manager) validateURL(r *hub.Repository) error {
 // validateCredentials validates the credentials of the repository provided.
 func (m *Manager) validateCredentials(r *hub.Repository) error {
 	allowPrivateRepos := m.cfg.GetBool("server.allowPrivateRepositories")
-	if !allowPrivateRepos && (r.AuthUser != "" || r.AuthPass != "") {
+	if !allowPrivateRepos && (r.AuthUser != "" || r.AuthPass != "" || r.AuthPassRef != "" || hasTLSConfig(r)) {
 		return errors.New("private repositories not allowed")
 	}
 	return nil
 }
 
+// validateTLSConfig validates the TLS options of the repository provided.
+func (m *Manager) validateTLSConfig(r *hub.Repository) error {
+	if !hasTLSConfig(r) {
+		return nil
+	}
+	if m.cfg.GetString("creds.encryptionKey") == "" {
+		return errors.New("TLS options not available: encryption key not configured")
+	}
+	if r.Kind != hub.Helm {
+		return errors.New("TLS options are only supported in Helm repositories")
+	}
+	if (r.TLS.ClientCert == "") != (r.TLS.ClientKey == "") {
+		return errors.New("client certificate and key must be provided together")
+	}
+	_, err := TLSClientConfig(r)
+	return err
+}
+
+// validateProxy validates the proxy options of the repository provided.
+func (m *Manager) validateProxy(r *hub.Repository) error {
+	if r.ProxyURL == "" {
+		return nil
+	}
+	if r.Kind != hub.Helm {
+		return errors.New("proxy is only supported in Helm repositories")
+	}
+	_, err := ProxyURL(r)
+	return err
+}
+
+// validateGitOptions validates the branch (or tag) and the packages path of
+// the repository provided, which are only supported in git based repositories.
+func (m *Manager) validateGitOptions(r *hub.Repository) error {
+	if r.Branch == "" && r.Path == "" {
+		return nil
+	}
+	switch r.Kind {
+	case hub.Falco, hub.HelmPlugin, hub.Krew, hub.OLM, hub.OPA, hub.TBAction, hub.TektonTask, hub.KedaScaler:
+		if strings.HasPrefix(r.URL, hub.RepositoryOCIPrefix) {
+			return errors.New("branch and path are only supported in git based repositories")
+		}
+	default:
+		return errors.New("branch and path are only supported in git based repositories")
+	}
+	if r.Branch != "" {
+		if !gitRefRE.MatchString(r.Branch) || strings.Contains(r.Branch, "..") || strings.HasSuffix(r.Branch, ".lock") {
+			return errors.New("invalid branch or tag")
+		}
+	}
+	if r.Path != "" {
+		cleanPath := path.Clean(r.Path)
+		if path.IsAbs(r.Path) || cleanPath == "." || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+			return errors.New("invalid path: it must be a relative path within the repository")
+		}
+		if matches := GitRepoURLRE.FindStringSubmatch(r.URL); len(matches) == 4 && strings.Trim(matches[3], "/") != "" {
+			return errors.New("path cannot be provided both in the url and in the path field")
+		}
+	}
+	return nil
+}
+
+// validateSchedule validates the tracking schedule of the repository
+// provided, making sure it's within the bounds allowed in this deployment.
+func (m *Manager) validateSchedule(r *hub.Repository) error {
+	if r.TrackingSchedule == "" {
+		return nil
+	}
+	minInterval := m.cfg.GetDuration("repositories.trackingSchedule.minInterval")
+	if minInterval <= 0 {
+		minInterval = defaultTrackingScheduleMinInterval
+	}
+	maxInterval := m.cfg.GetDuration("repositories.trackingSchedule.maxInterval")
+	if maxInterval <= 0 {
+		maxInterval = defaultTrackingScheduleMaxInterval
+	}
+	return validateTrackingSchedule(r.TrackingSchedule, minInterval, maxInterval)
+}
+
+// validateRetentionPolicy validates the retention policy provided.
+func validateRetentionPolicy(p *hub.RetentionPolicy) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxVersions < 0 {
+		return errors.New("retention policy max versions cannot be negative")
+	}
+	if p.MaxAgeDays < 0 {
+		return errors.New("retention policy max age cannot be negative")
+	}
+	return nil
+}
+
+// marshalForDB marshals the repository provided so that it can be sent to the
+// database, encrypting its TLS options when present. Passwords obtained from
+// a secret reference are never stored.
+func (m *Manager) marshalForDB(r *hub.Repository) ([]byte, error) {
+	if r.AuthPassRef != "" && r.AuthPass != "" {
+		rCopy := *r
+		rCopy.AuthPass = ""
+		r = &rCopy
+	}
+	rDB := &repositoryDB{Repository: r}
+	if hasTLSConfig(r) {
+		tlsConfig, err := encryptTLSConfig(m.cfg.GetString("creds.encryptionKey"), r.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting TLS options: %w", err)
+		}
+		rDB.TLSConfig = tlsConfig
+	}
+	return json.Marshal(rDB)
+}
+
+// unmarshalFromDB returns the repositories provided as obtained from the
+// database, decrypting their TLS options when present.
+func (m *Manager) unmarshalFromDB(rDB []*repositoryDB) ([]*hub.Repository, error) {
+	repos := make([]*hub.Repository, 0, len(rDB))
+	for _, r := range rDB {
+		repo, err := m.unmarshalOneFromDB(r)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, repo)
+	}
+	return repos, nil
+}
+
+// unmarshalOneFromDB returns the repository provided as obtained from the
+// database, decrypting its TLS options when present.
+func (m *Manager) unmarshalOneFromDB(rDB *repositoryDB) (*hub.Repository, error) {
+	if rDB == nil {
+		return nil, nil
+	}
+	if rDB.TLSConfig != "" {
+		tlsConfig, err := decryptTLSConfig(m.cfg.GetString("creds.encryptionKey"), rDB.TLSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting TLS options of repository %s: %w", rDB.Name, err)
+		}
+		rDB.Repository.TLS = tlsConfig
+	}
+	return rDB.Repository, nil
+}
+
 // SchemeIsHTTP is a helper that checks if the scheme of the url provided is
 // http or https.
 func SchemeIsHTTP(u *url.URL) bool {
@@ -707,6 +1370,9 @@ func isValidKind(kind hub.RepositoryKind) bool {
 		hub.TBAction,
 		hub.TektonTask,
 		hub.KedaScaler,
+		hub.Wasm,
+		hub.CrossplaneConfiguration,
+		hub.OCIArtifact,
 	} {
 		if kind == validKind {
 			return true
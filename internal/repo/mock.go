@@ -25,7 +25,7 @@ type ErrorsCollectorMock struct {
 }
 
 // Append implements the ErrorsCollector interface.
-func (m *ErrorsCollectorMock) Append(repositoryID string, err string) {
+func (m *ErrorsCollectorMock) Append(repositoryID string, err *hub.RepositoryError) {
 	m.Called(repositoryID, err)
 }
 
@@ -52,6 +52,19 @@ func (m *HelmIndexLoaderMock) LoadIndex(r *hub.Repository) (*repo.IndexFile, str
 	return indexFile, args.String(1), args.Error(2)
 }
 
+// TrackerDryRunnerMock is a mock implementation of the TrackerDryRunner
+// interface.
+type TrackerDryRunnerMock struct {
+	mock.Mock
+}
+
+// DryRun implements the TrackerDryRunner interface.
+func (m *TrackerDryRunnerMock) DryRun(ctx context.Context, r *hub.Repository) (*hub.TrackerDryRunResult, error) {
+	args := m.Called(ctx, r)
+	data, _ := args.Get(0).(*hub.TrackerDryRunResult)
+	return data, args.Error(1)
+}
+
 // ManagerMock is a mock implementation of the RepositoryManager interface.
 type ManagerMock struct {
 	mock.Mock
@@ -81,6 +94,13 @@ func (m *ManagerMock) Delete(ctx context.Context, name string) error {
 	return args.Error(0)
 }
 
+// DryRun implements the RepositoryManager interface.
+func (m *ManagerMock) DryRun(ctx context.Context, r *hub.Repository) (*hub.TrackerDryRunResult, error) {
+	args := m.Called(ctx, r)
+	data, _ := args.Get(0).(*hub.TrackerDryRunResult)
+	return data, args.Error(1)
+}
+
 // GetAll implements the RepositoryManager interface.
 func (m *ManagerMock) GetAll(ctx context.Context, includeCredentials bool) ([]*hub.Repository, error) {
 	args := m.Called(ctx, includeCredentials)
@@ -180,14 +200,65 @@ func (m *ManagerMock) GetOwnedByUserJSON(ctx context.Context, includeCredentials
 	return data, args.Error(1)
 }
 
+// GetOwnershipClaimJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetOwnershipClaimJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetTrackingStatusJSON implements the RepositoryManager interface.
+func (m *ManagerMock) GetTrackingStatusJSON(ctx context.Context, name string) ([]byte, error) {
+	args := m.Called(ctx, name)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// RegisterTrackingRun implements the RepositoryManager interface.
+func (m *ManagerMock) RegisterTrackingRun(ctx context.Context, repositoryID string) (string, error) {
+	args := m.Called(ctx, repositoryID)
+	return args.String(0), args.Error(1)
+}
+
+// RequestOwnershipClaim implements the RepositoryManager interface.
+func (m *ManagerMock) RequestOwnershipClaim(
+	ctx context.Context,
+	name string,
+	orgName string,
+	method hub.OwnershipProofMethod,
+) ([]byte, error) {
+	args := m.Called(ctx, name, orgName, method)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// RequestTracking implements the RepositoryManager interface.
+func (m *ManagerMock) RequestTracking(ctx context.Context, name string, payload []byte, signature string) error {
+	args := m.Called(ctx, name, payload, signature)
+	return args.Error(0)
+}
+
+// ResolveCredentials implements the RepositoryManager interface.
+func (m *ManagerMock) ResolveCredentials(ctx context.Context, r *hub.Repository) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+// SearchJSON implements the RepositoryManager interface.
+func (m *ManagerMock) SearchJSON(ctx context.Context, input *hub.SearchRepositoryInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // SetLastScanningResults implements the RepositoryManager interface.
-func (m *ManagerMock) SetLastScanningResults(ctx context.Context, repositoryID, errs string) error {
+func (m *ManagerMock) SetLastScanningResults(ctx context.Context, repositoryID string, errs []*hub.RepositoryError) error {
 	args := m.Called(ctx, repositoryID, errs)
 	return args.Error(0)
 }
 
 // SetLastTrackingResults implements the RepositoryManager interface.
-func (m *ManagerMock) SetLastTrackingResults(ctx context.Context, repositoryID, errs string) error {
+func (m *ManagerMock) SetLastTrackingResults(ctx context.Context, repositoryID string, errs []*hub.RepositoryError) error {
 	args := m.Called(ctx, repositoryID, errs)
 	return args.Error(0)
 }
@@ -210,12 +281,41 @@ func (m *ManagerMock) Update(ctx context.Context, r *hub.Repository) error {
 	return args.Error(0)
 }
 
+// UpdateCredentials implements the RepositoryManager interface.
+func (m *ManagerMock) UpdateCredentials(ctx context.Context, name string, c *hub.RepositoryCredentials) error {
+	args := m.Called(ctx, name, c)
+	return args.Error(0)
+}
+
 // UpdateDigest implements the RepositoryManager interface.
 func (m *ManagerMock) UpdateDigest(ctx context.Context, repositoryID, digest string) error {
 	args := m.Called(ctx, repositoryID, digest)
 	return args.Error(0)
 }
 
+// UpdateTrackingEnabled implements the RepositoryManager interface.
+func (m *ManagerMock) UpdateTrackingEnabled(ctx context.Context, name string, enabled bool) error {
+	args := m.Called(ctx, name, enabled)
+	return args.Error(0)
+}
+
+// UpdateTrackingRun implements the RepositoryManager interface.
+func (m *ManagerMock) UpdateTrackingRun(ctx context.Context, run *hub.TrackingRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+// UpdateTrackingWebhook implements the RepositoryManager interface.
+func (m *ManagerMock) UpdateTrackingWebhook(
+	ctx context.Context,
+	name string,
+	enabled bool,
+) (*hub.TrackingWebhook, error) {
+	args := m.Called(ctx, name, enabled)
+	data, _ := args.Get(0).(*hub.TrackingWebhook)
+	return data, args.Error(1)
+}
+
 // OCITagsGetterMock is a mock implementation of the OCITagsGetter interface.
 type OCITagsGetterMock struct {
 	mock.Mock
@@ -228,6 +328,68 @@ func (m *OCITagsGetterMock) Tags(ctx context.Context, r *hub.Repository) ([]stri
 	return tags, args.Error(1)
 }
 
+// OCIFilesGetterMock is a mock implementation of the OCIFilesGetter interface.
+type OCIFilesGetterMock struct {
+	mock.Mock
+}
+
+// Files implements the OCIFilesGetter interface.
+func (m *OCIFilesGetterMock) Files(
+	ctx context.Context,
+	r *hub.Repository,
+	version string,
+	filter func(name string) bool,
+) (map[string][]byte, error) {
+	args := m.Called(ctx, r, version)
+	files, _ := args.Get(0).(map[string][]byte)
+	return files, args.Error(1)
+}
+
+// OCIManifestGetterMock is a mock implementation of the OCIManifestGetter
+// interface.
+type OCIManifestGetterMock struct {
+	mock.Mock
+}
+
+// Manifest implements the OCIManifestGetter interface.
+func (m *OCIManifestGetterMock) Manifest(ctx context.Context, r *hub.Repository, version string) ([]byte, string, error) {
+	args := m.Called(ctx, r, version)
+	manifest, _ := args.Get(0).([]byte)
+	return manifest, args.String(1), args.Error(2)
+}
+
+// OCISBOMGetterMock is a mock implementation of the OCISBOMGetter interface.
+type OCISBOMGetterMock struct {
+	mock.Mock
+}
+
+// SBOMs implements the OCISBOMGetter interface.
+func (m *OCISBOMGetterMock) SBOMs(ctx context.Context, r *hub.Repository, version string) ([]*hub.SBOM, error) {
+	args := m.Called(ctx, r, version)
+	sboms, _ := args.Get(0).([]*hub.SBOM)
+	return sboms, args.Error(1)
+}
+
+// OCISignatureVerifierMock is a mock implementation of the
+// OCISignatureVerifier interface.
+type OCISignatureVerifierMock struct {
+	mock.Mock
+}
+
+// Verify implements the OCISignatureVerifier interface.
+func (m *OCISignatureVerifierMock) Verify(ctx context.Context, r *hub.Repository, version string) ([]*hub.Signature, error) {
+	args := m.Called(ctx, r, version)
+	signatures, _ := args.Get(0).([]*hub.Signature)
+	return signatures, args.Error(1)
+}
+
+// VerifyProvenance implements the OCISignatureVerifier interface.
+func (m *OCISignatureVerifierMock) VerifyProvenance(ctx context.Context, r *hub.Repository, version string) (*hub.Provenance, error) {
+	args := m.Called(ctx, r, version)
+	provenance, _ := args.Get(0).(*hub.Provenance)
+	return provenance, args.Error(1)
+}
+
 // OLMOCIExporterMock is a mock implementation of the OLMOCIExporter interface.
 type OLMOCIExporterMock struct {
 	mock.Mock
@@ -1,17 +1,89 @@
 package repo
 
 import (
+	"archive/tar"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
 	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 )
 
+// maxOCIFileSize represents the maximum size of the files that can be read
+// from the filesystem of an OCI image.
+const maxOCIFileSize = 10 << 20 // 10 MiB
+
+// OCIFilesGetter provides a mechanism to get the files in the filesystem of a
+// given version (tag) of a repository in a OCI registry.
+type OCIFilesGetter struct{}
+
+// Files returns the content of the files matched by the filter provided in
+// the flattened filesystem of the provided repository version.
+func (fg *OCIFilesGetter) Files(
+	ctx context.Context,
+	r *hub.Repository,
+	version string,
+	filter func(name string) bool,
+) (map[string][]byte, error) {
+	u := strings.TrimPrefix(r.URL, hub.RepositoryOCIPrefix)
+	ref, err := name.ParseReference(u + ":" + version)
+	if err != nil {
+		return nil, err
+	}
+	options, err := remoteOptions(r)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, remote.WithContext(ctx))
+	img, err := remote.Image(ref, options...)
+	if err != nil {
+		return nil, err
+	}
+	rc := mutate.Extract(img)
+	defer rc.Close()
+	files := make(map[string][]byte)
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		if !filter(name) {
+			continue
+		}
+		if hdr.Size > maxOCIFileSize {
+			return nil, fmt.Errorf("file %s too big", name)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
 // OCITagsGetter provides a mechanism to get all the version tags available for
 // a given repository in a OCI registry. Tags that aren't valid semver versions
 // will be filtered out.
@@ -24,14 +96,9 @@ func (tg *OCITagsGetter) Tags(ctx context.Context, r *hub.Repository) ([]string,
 	if err != nil {
 		return nil, err
 	}
-	var options []remote.Option
-	if r.AuthUser != "" || r.AuthPass != "" {
-		options = []remote.Option{
-			remote.WithAuth(&authn.Basic{
-				Username: r.AuthUser,
-				Password: r.AuthPass,
-			}),
-		}
+	options, err := remoteOptions(r)
+	if err != nil {
+		return nil, err
 	}
 	tags, err := remote.ListWithContext(ctx, ociRepo, options...)
 	if err != nil {
@@ -50,3 +117,123 @@ func (tg *OCITagsGetter) Tags(ctx context.Context, r *hub.Repository) ([]string,
 	})
 	return tagsFiltered, nil
 }
+
+// OCIManifestGetter provides a mechanism to get the manifest of a given
+// version (tag) of a repository in a OCI registry.
+type OCIManifestGetter struct{}
+
+// Manifest returns the raw manifest of the provided repository version, as
+// well as its digest.
+func (mg *OCIManifestGetter) Manifest(ctx context.Context, r *hub.Repository, version string) ([]byte, string, error) {
+	u := strings.TrimPrefix(r.URL, hub.RepositoryOCIPrefix)
+	ref, err := name.ParseReference(u + ":" + version)
+	if err != nil {
+		return nil, "", err
+	}
+	options, err := remoteOptions(r)
+	if err != nil {
+		return nil, "", err
+	}
+	options = append(options, remote.WithContext(ctx))
+	desc, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	return desc.Manifest, desc.Digest.String(), nil
+}
+
+// OCISBOMGetter provides a mechanism to get the SBOMs attached to a given
+// version (tag) of a repository in a OCI registry.
+type OCISBOMGetter struct{}
+
+// SBOMs returns the SBOMs attached to the provided repository version using
+// the cosign conventions (sha256-<digest>.sbom tag). Layers whose format is
+// not supported are ignored.
+func (sg *OCISBOMGetter) SBOMs(ctx context.Context, r *hub.Repository, version string) ([]*hub.SBOM, error) {
+	img, _, err := getAttachedImage(ctx, r, version, "sbom")
+	if err != nil || img == nil {
+		return nil, err
+	}
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	var sboms []*hub.SBOM
+	for _, layer := range manifest.Layers {
+		l, err := img.LayerByDigest(layer.Digest)
+		if err != nil {
+			return nil, err
+		}
+		rc, err := l.Compressed()
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(rc, pkg.MaxSBOMSize+1))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		sbom, err := pkg.ParseSBOM(data, string(layer.MediaType))
+		if err != nil {
+			if errors.Is(err, pkg.ErrUnsupportedSBOMFormat) {
+				continue
+			}
+			return nil, err
+		}
+		sboms = append(sboms, sbom)
+	}
+	return sboms, nil
+}
+
+// getAttachedImage returns the image attached to the provided repository
+// version using the cosign tag based conventions (sha256-<digest>.<suffix>),
+// as well as the digest of the version. When nothing has been attached to the
+// version a nil image is returned.
+func getAttachedImage(ctx context.Context, r *hub.Repository, version, suffix string) (v1.Image, string, error) {
+	u := strings.TrimPrefix(r.URL, hub.RepositoryOCIPrefix)
+	ref, err := name.ParseReference(u + ":" + version)
+	if err != nil {
+		return nil, "", err
+	}
+	options, err := remoteOptions(r)
+	if err != nil {
+		return nil, "", err
+	}
+	options = append(options, remote.WithContext(ctx))
+	desc, err := remote.Head(ref, options...)
+	if err != nil {
+		return nil, "", err
+	}
+	digest := desc.Digest.String()
+	tag := strings.Replace(digest, ":", "-", 1) + "." + suffix
+	img, err := remote.Image(ref.Context().Tag(tag), options...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, digest, nil
+		}
+		return nil, "", err
+	}
+	return img, digest, nil
+}
+
+// remoteOptions returns the options that should be used to interact with the
+// OCI registry of the repository provided, honoring its credentials and its
+// transport options when present.
+func remoteOptions(r *hub.Repository) ([]remote.Option, error) {
+	var options []remote.Option
+	if r.AuthUser != "" || r.AuthPass != "" {
+		options = append(options, remote.WithAuth(&authn.Basic{
+			Username: r.AuthUser,
+			Password: r.AuthPass,
+		}))
+	}
+	transport, err := HTTPTransport(r)
+	if err != nil {
+		return nil, err
+	}
+	if transport != nil {
+		options = append(options, remote.WithTransport(transport))
+	}
+	return options, nil
+}
@@ -0,0 +1,177 @@
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	cosignAttestationTagSuffix = "att"
+	dsseEnvelopeMediaType      = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType          = "application/vnd.in-toto+json"
+	slsaPredicateTypePrefix    = "https://slsa.dev/provenance/"
+)
+
+// SLSA levels assigned to the provenance attestations.
+const (
+	// SLSALevelUnverified is assigned to the provenance attestations whose
+	// signature could not be verified.
+	SLSALevelUnverified = 1
+
+	// SLSALevelSigned is assigned to the provenance attestations signed by one
+	// of the keys or identities trusted.
+	SLSALevelSigned = 2
+
+	// SLSALevelTrustedBuilder is assigned to the signed provenance
+	// attestations generated by one of the builders trusted.
+	SLSALevelTrustedBuilder = 3
+)
+
+// dsseEnvelope represents a DSSE envelope, used by cosign to wrap the in-toto
+// attestations attached to the artifacts.
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		Sig string `json:"sig"`
+	} `json:"signatures"`
+}
+
+// inTotoStatement represents an in-toto statement with a SLSA provenance
+// predicate (only the fields we are interested in are included). Builder is
+// used by the v0.x predicates, whereas RunDetails is used by v1.
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	Predicate struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+	} `json:"predicate"`
+}
+
+// VerifyProvenance returns the SLSA provenance attestation found for the
+// provided repository version. When several attestations are available, the
+// one with the highest level is returned. Nil is returned if the version does
+// not have any SLSA provenance attestation attached.
+func (v *CosignVerifier) VerifyProvenance(
+	ctx context.Context,
+	r *hub.Repository,
+	version string,
+) (*hub.Provenance, error) {
+	attImg, digest, err := getAttachedImage(ctx, r, version, cosignAttestationTagSuffix)
+	if err != nil || attImg == nil {
+		return nil, err
+	}
+	attManifest, err := attImg.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify provenance attestations
+	var provenance *hub.Provenance
+	for _, layer := range attManifest.Layers {
+		if string(layer.MediaType) != dsseEnvelopeMediaType {
+			continue
+		}
+		data, err := readCosignLayer(attImg, layer)
+		if err != nil {
+			return nil, err
+		}
+		p := v.verifyProvenance(data, layer.Annotations, digest)
+		if p != nil && (provenance == nil || p.Level > provenance.Level) {
+			provenance = p
+		}
+	}
+	return provenance, nil
+}
+
+// verifyProvenance verifies the DSSE envelope provided, returning the SLSA
+// provenance it contains. Nil is returned when the envelope does not contain
+// a SLSA provenance statement about the artifact digest provided.
+func (v *CosignVerifier) verifyProvenance(data []byte, annotations map[string]string, digest string) *hub.Provenance {
+	// Extract statement from envelope
+	var env dsseEnvelope
+	if err := json.Unmarshal(data, &env); err != nil || env.PayloadType != inTotoPayloadType {
+		return nil
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil
+	}
+	var st inTotoStatement
+	if err := json.Unmarshal(payload, &st); err != nil {
+		return nil
+	}
+	if !strings.HasPrefix(st.PredicateType, slsaPredicateTypePrefix) {
+		return nil
+	}
+
+	// Check the statement is about the artifact
+	var subjectFound bool
+	for _, subject := range st.Subject {
+		for alg, hex := range subject.Digest {
+			if alg+":"+hex == digest {
+				subjectFound = true
+			}
+		}
+	}
+	if !subjectFound {
+		return nil
+	}
+
+	// Prepare provenance
+	p := &hub.Provenance{
+		PredicateType: st.PredicateType,
+		BuilderID:     st.Predicate.Builder.ID,
+		Level:         SLSALevelUnverified,
+	}
+	if p.BuilderID == "" {
+		p.BuilderID = st.Predicate.RunDetails.Builder.ID
+	}
+
+	// Verify envelope signatures
+	pae := dssePAE(env.PayloadType, payload)
+	for _, envSig := range env.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(envSig.Sig)
+		if err != nil {
+			continue
+		}
+		s := v.checkSignature(pae, sig, annotations)
+		if s.Identity != "" && p.Identity == "" {
+			p.Identity = s.Identity
+		}
+		if s.Verified {
+			p.Verified = true
+			p.Identity = s.Identity
+			break
+		}
+	}
+	if p.Verified {
+		p.Level = SLSALevelSigned
+		if _, ok := v.trustedBuilders[p.BuilderID]; ok {
+			p.Level = SLSALevelTrustedBuilder
+		}
+	}
+
+	return p
+}
+
+// dssePAE returns the pre-authentication encoding of the payload provided, as
+// defined in the DSSE specification. This is the data signed in DSSE
+// envelopes.
+func dssePAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
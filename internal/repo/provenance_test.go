@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const slsaTestsBuilderID = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.2.0"
+
+func TestCosignVerifierVerifyProvenance(t *testing.T) {
+	key := generateTestsKey(t)
+	statementV02 := `{
+		"_type": "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"subject": [{"name": "registry.io/org/pkg", "digest": {"sha256": "` + cosignTestsDigest[7:] + `"}}],
+		"predicate": {"builder": {"id": "` + slsaTestsBuilderID + `"}}
+	}`
+
+	t.Run("signed by trusted builder", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{
+			keys:            []crypto.PublicKey{key.Public()},
+			trustedBuilders: map[string]struct{}{slsaTestsBuilderID: {}},
+		}
+		p := v.verifyProvenance(prepareTestsEnvelope(t, key, statementV02), nil, cosignTestsDigest)
+		assert.Equal(t, &hub.Provenance{
+			PredicateType: "https://slsa.dev/provenance/v0.2",
+			BuilderID:     slsaTestsBuilderID,
+			Level:         SLSALevelTrustedBuilder,
+			Identity:      getKeyFingerprint(key.Public()),
+			Verified:      true,
+		}, p)
+	})
+
+	t.Run("signed by untrusted builder", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{keys: []crypto.PublicKey{key.Public()}}
+		p := v.verifyProvenance(prepareTestsEnvelope(t, key, statementV02), nil, cosignTestsDigest)
+		assert.Equal(t, SLSALevelSigned, p.Level)
+		assert.True(t, p.Verified)
+	})
+
+	t.Run("signature not verified", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{
+			keys:            []crypto.PublicKey{generateTestsKey(t).Public()},
+			trustedBuilders: map[string]struct{}{slsaTestsBuilderID: {}},
+		}
+		p := v.verifyProvenance(prepareTestsEnvelope(t, key, statementV02), nil, cosignTestsDigest)
+		assert.Equal(t, &hub.Provenance{
+			PredicateType: "https://slsa.dev/provenance/v0.2",
+			BuilderID:     slsaTestsBuilderID,
+			Level:         SLSALevelUnverified,
+		}, p)
+	})
+
+	t.Run("v1 predicate", func(t *testing.T) {
+		t.Parallel()
+		statementV1 := `{
+			"predicateType": "https://slsa.dev/provenance/v1",
+			"subject": [{"digest": {"sha256": "` + cosignTestsDigest[7:] + `"}}],
+			"predicate": {"runDetails": {"builder": {"id": "` + slsaTestsBuilderID + `"}}}
+		}`
+		v := &CosignVerifier{keys: []crypto.PublicKey{key.Public()}}
+		p := v.verifyProvenance(prepareTestsEnvelope(t, key, statementV1), nil, cosignTestsDigest)
+		assert.Equal(t, slsaTestsBuilderID, p.BuilderID)
+		assert.Equal(t, "https://slsa.dev/provenance/v1", p.PredicateType)
+	})
+
+	t.Run("statement about a different artifact", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{keys: []crypto.PublicKey{key.Public()}}
+		p := v.verifyProvenance(prepareTestsEnvelope(t, key, statementV02), nil, "sha256:other")
+		assert.Nil(t, p)
+	})
+
+	t.Run("not a slsa provenance statement", func(t *testing.T) {
+		t.Parallel()
+		statement := `{
+			"predicateType": "https://cyclonedx.org/bom",
+			"subject": [{"digest": {"sha256": "` + cosignTestsDigest[7:] + `"}}]
+		}`
+		v := &CosignVerifier{keys: []crypto.PublicKey{key.Public()}}
+		p := v.verifyProvenance(prepareTestsEnvelope(t, key, statement), nil, cosignTestsDigest)
+		assert.Nil(t, p)
+	})
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		t.Parallel()
+		v := &CosignVerifier{}
+		p := v.verifyProvenance([]byte("{"), nil, cosignTestsDigest)
+		assert.Nil(t, p)
+	})
+}
+
+// prepareTestsEnvelope returns a DSSE envelope wrapping the in-toto statement
+// provided, signed with the key given.
+func prepareTestsEnvelope(t *testing.T, key *ecdsa.PrivateKey, statement string) []byte {
+	t.Helper()
+	sig := signTestsPayload(t, key, dssePAE(inTotoPayloadType, []byte(statement)))
+	data, err := json.Marshal(map[string]interface{}{
+		"payloadType": inTotoPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+		"signatures":  []map[string]string{{"sig": sig}},
+	})
+	require.NoError(t, err)
+	return data
+}
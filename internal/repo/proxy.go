@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// ProxyURL returns the url of the proxy configured in the repository provided,
+// used to reach it instead of the one set at the deployment level (if any).
+// When the repository has no proxy configured, nil is returned.
+func ProxyURL(r *hub.Repository) (*url.URL, error) {
+	if r.ProxyURL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(r.ProxyURL)
+	if err != nil {
+		return nil, errors.New("invalid proxy url")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.New("invalid proxy url: scheme not supported")
+	}
+	if u.Host == "" {
+		return nil, errors.New("invalid proxy url: host not provided")
+	}
+	return u, nil
+}
@@ -0,0 +1,274 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// defaultTrackingScheduleMinInterval represents the default minimum
+	// interval allowed between two consecutive trackings of a repository when
+	// using a custom tracking schedule.
+	defaultTrackingScheduleMinInterval = 30 * time.Minute
+
+	// defaultTrackingScheduleMaxInterval represents the default maximum
+	// interval allowed between two consecutive trackings of a repository when
+	// using a custom tracking schedule.
+	defaultTrackingScheduleMaxInterval = 7 * 24 * time.Hour
+
+	// trackingScheduleSlack represents the margin applied when checking if
+	// the tracking of a repository is due. The tracker runs periodically and
+	// it takes some time to process each repository, so without it schedules
+	// would end up drifting one tracker run.
+	trackingScheduleSlack = 5 * time.Minute
+
+	// trackingScheduleSamples represents the number of activations checked
+	// when validating the intervals of a cron based tracking schedule.
+	trackingScheduleSamples = 100
+)
+
+// trackingScheduleDescriptors contains the predefined tracking schedules
+// supported and the cron expressions they are equivalent to.
+var trackingScheduleDescriptors = map[string]string{
+	"@hourly": "0 * * * *",
+	"@daily":  "0 0 * * *",
+	"@weekly": "0 0 * * 0",
+}
+
+// trackingSchedule represents a repository tracking schedule.
+type trackingSchedule interface {
+	// next returns the next activation time after the time provided.
+	next(t time.Time) time.Time
+}
+
+// parseTrackingSchedule parses the tracking schedule provided. Schedules can
+// be defined using a fixed interval (e.g. @every 30m), one of the predefined
+// descriptors (@hourly, @daily or @weekly) or a standard cron expression with
+// five fields (minute, hour, day of month, month and day of week).
+func parseTrackingSchedule(s string) (trackingSchedule, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(s, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval: %w", err)
+		}
+		if d < time.Minute {
+			return nil, errors.New("interval must be at least one minute")
+		}
+		return intervalSchedule(d), nil
+	}
+	if expr, ok := trackingScheduleDescriptors[s]; ok {
+		s = expr
+	}
+	return parseCronSchedule(s)
+}
+
+// validateTrackingSchedule checks that the tracking schedule provided is valid
+// and that the interval between two consecutive activations is within the
+// bounds provided.
+func validateTrackingSchedule(s string, minInterval, maxInterval time.Duration) error {
+	schedule, err := parseTrackingSchedule(s)
+	if err != nil {
+		return fmt.Errorf("invalid tracking schedule: %w", err)
+	}
+
+	// Check intervals between activations
+	var shortest, longest time.Duration
+	switch v := schedule.(type) {
+	case intervalSchedule:
+		shortest, longest = time.Duration(v), time.Duration(v)
+	default:
+		t := schedule.next(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+		for i := 0; i < trackingScheduleSamples; i++ {
+			next := schedule.next(t)
+			if next.IsZero() {
+				return errors.New("invalid tracking schedule: it never triggers")
+			}
+			interval := next.Sub(t)
+			if shortest == 0 || interval < shortest {
+				shortest = interval
+			}
+			if interval > longest {
+				longest = interval
+			}
+			t = next
+		}
+	}
+	if shortest < minInterval {
+		return fmt.Errorf("tracking schedule interval cannot be shorter than %s", minInterval)
+	}
+	if longest > maxInterval {
+		return fmt.Errorf("tracking schedule interval cannot be longer than %s", maxInterval)
+	}
+	return nil
+}
+
+// IsTrackingDue checks if the tracking of the provided repository is due at
+// the given time. Repositories without a custom tracking schedule, that have
+// never been tracked or that have a pending tracking request (e.g. triggered
+// from the tracking webhook) are processed on the next tracker run.
+func IsTrackingDue(r *hub.Repository, now time.Time) bool {
+	if r.TrackingSchedule == "" || r.LastTrackingTS == 0 {
+		return true
+	}
+	if r.TrackingRequestedTS > r.LastTrackingTS {
+		return true
+	}
+	schedule, err := parseTrackingSchedule(r.TrackingSchedule)
+	if err != nil {
+		return true
+	}
+	next := schedule.next(time.Unix(r.LastTrackingTS, 0).UTC())
+	return !next.IsZero() && !next.After(now.Add(trackingScheduleSlack))
+}
+
+// intervalSchedule represents a tracking schedule that triggers periodically
+// at a fixed interval.
+type intervalSchedule time.Duration
+
+// next implements the trackingSchedule interface.
+func (s intervalSchedule) next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+// cronSchedule represents a tracking schedule defined using a cron expression.
+// Each field is represented as a bitset with the values that match it.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+}
+
+// cronField represents the bounds of a cron expression field.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField = cronField{"minute", 0, 59}
+	hourField   = cronField{"hour", 0, 23}
+	domField    = cronField{"day of month", 1, 31}
+	monthField  = cronField{"month", 1, 12}
+	dowField    = cronField{"day of week", 0, 7}
+)
+
+// parseCronSchedule parses the cron expression provided.
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.New("cron expressions must have five fields")
+	}
+	s := &cronSchedule{}
+	var err error
+	if s.minute, err = parseCronField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseCronField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseCronField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseCronField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseCronField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 // Both 0 and 7 represent Sunday
+	}
+	s.domRestricted = fields[2] != "*"
+	s.dowRestricted = fields[4] != "*"
+	return s, nil
+}
+
+// parseCronField parses a cron expression field, returning a bitset with the
+// values that match it. Fields are made of a comma separated list of items,
+// where each item can be a wildcard, a single value or a range, optionally
+// followed by a step (e.g. */15 or 1-10/2).
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangeExpr, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			rangeExpr = item[:i]
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field: %s", f.name, item)
+			}
+		}
+		start, end := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			parts := strings.SplitN(rangeExpr, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(parts[0])
+			end, err2 = strconv.Atoi(parts[1])
+			if err1 != nil || err2 != nil || start > end {
+				return 0, fmt.Errorf("invalid range in %s field: %s", f.name, item)
+			}
+		default:
+			var err error
+			start, err = strconv.Atoi(rangeExpr)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value in %s field: %s", f.name, item)
+			}
+			if step == 1 {
+				end = start
+			}
+		}
+		if start < f.min || end > f.max {
+			return 0, fmt.Errorf("value out of range in %s field: %s", f.name, item)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// next implements the trackingSchedule interface.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.UTC().Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches checks if the day of the time provided matches the schedule. As
+// in the standard cron implementation, when both the day of month and the day
+// of week fields are restricted, matching any of them is enough.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
@@ -0,0 +1,187 @@
+package repo
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrackingSchedule(t *testing.T) {
+	t.Run("invalid schedules", func(t *testing.T) {
+		testCases := []struct {
+			schedule string
+			errMsg   string
+		}{
+			{"", "cron expressions must have five fields"},
+			{"@every", "cron expressions must have five fields"},
+			{"@every 1x", "invalid interval"},
+			{"@every 30s", "interval must be at least one minute"},
+			{"@monthly", "cron expressions must have five fields"},
+			{"* * * *", "cron expressions must have five fields"},
+			{"60 * * * *", "value out of range in minute field: 60"},
+			{"* 24 * * *", "value out of range in hour field: 24"},
+			{"* * 0 * *", "value out of range in day of month field: 0"},
+			{"* * * 13 *", "value out of range in month field: 13"},
+			{"* * * * 8", "value out of range in day of week field: 8"},
+			{"*/0 * * * *", "invalid step in minute field: */0"},
+			{"5-1 * * * *", "invalid range in minute field: 5-1"},
+			{"a * * * *", "invalid value in minute field: a"},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.schedule, func(t *testing.T) {
+				t.Parallel()
+				_, err := parseTrackingSchedule(tc.schedule)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("valid schedules", func(t *testing.T) {
+		from := time.Date(2021, 3, 10, 10, 15, 30, 0, time.UTC) // Wednesday
+		testCases := []struct {
+			schedule     string
+			expectedNext time.Time
+		}{
+			{"@every 45m", time.Date(2021, 3, 10, 11, 0, 30, 0, time.UTC)},
+			{"@hourly", time.Date(2021, 3, 10, 11, 0, 0, 0, time.UTC)},
+			{"@daily", time.Date(2021, 3, 11, 0, 0, 0, 0, time.UTC)},
+			{"@weekly", time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC)},
+			{"*/30 * * * *", time.Date(2021, 3, 10, 10, 30, 0, 0, time.UTC)},
+			{"0 8-18/4 * * *", time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)},
+			{"0 6,22 * * *", time.Date(2021, 3, 10, 22, 0, 0, 0, time.UTC)},
+			{"0 0 1 * *", time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)},
+			{"0 0 * * 7", time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC)},
+			{"0 0 15 * 1", time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)},
+			{"30 2 29 2 *", time.Date(2024, 2, 29, 2, 30, 0, 0, time.UTC)},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.schedule, func(t *testing.T) {
+				t.Parallel()
+				s, err := parseTrackingSchedule(tc.schedule)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedNext, s.next(from))
+			})
+		}
+	})
+}
+
+func TestValidateTrackingSchedule(t *testing.T) {
+	minInterval := 30 * time.Minute
+	maxInterval := 7 * 24 * time.Hour
+
+	testCases := []struct {
+		schedule string
+		errMsg   string
+	}{
+		{"* * * * *", "tracking schedule interval cannot be shorter than 30m0s"},
+		{"@every 10m", "tracking schedule interval cannot be shorter than 30m0s"},
+		{"0,15 * * * *", "tracking schedule interval cannot be shorter than 30m0s"},
+		{"@every 200h", "tracking schedule interval cannot be longer than 168h0m0s"},
+		{"0 0 1 * *", "tracking schedule interval cannot be longer than 168h0m0s"},
+		{"0 0 31 2 *", "invalid tracking schedule: it never triggers"},
+		{"invalid", "invalid tracking schedule"},
+		{"@every 30m", ""},
+		{"@hourly", ""},
+		{"@daily", ""},
+		{"@weekly", ""},
+		{"0,30 * * * *", ""},
+		{"0 9 * * 1-5", ""},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.schedule, func(t *testing.T) {
+			t.Parallel()
+			err := validateTrackingSchedule(tc.schedule, minInterval, maxInterval)
+			if tc.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestIsTrackingDue(t *testing.T) {
+	now := time.Date(2021, 3, 10, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		r           *hub.Repository
+		expectedDue bool
+	}{
+		{
+			&hub.Repository{},
+			true,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule: "@daily",
+			},
+			true,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule: "invalid",
+				LastTrackingTS:   now.Unix(),
+			},
+			true,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule: "@every 1h",
+				LastTrackingTS:   now.Add(-30 * time.Minute).Unix(),
+			},
+			false,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule: "@every 1h",
+				LastTrackingTS:   now.Add(-58 * time.Minute).Unix(),
+			},
+			true,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule: "@daily",
+				LastTrackingTS:   now.Add(-2 * time.Hour).Unix(),
+			},
+			false,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule: "0 9 * * *",
+				LastTrackingTS:   now.Add(-2 * time.Hour).Unix(),
+			},
+			true,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule:    "@daily",
+				LastTrackingTS:      now.Add(-2 * time.Hour).Unix(),
+				TrackingRequestedTS: now.Add(-1 * time.Hour).Unix(),
+			},
+			true,
+		},
+		{
+			&hub.Repository{
+				TrackingSchedule:    "@daily",
+				LastTrackingTS:      now.Add(-2 * time.Hour).Unix(),
+				TrackingRequestedTS: now.Add(-3 * time.Hour).Unix(),
+			},
+			false,
+		},
+	}
+	for i, tc := range testCases {
+		tc := tc
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedDue, IsTrackingDue(tc.r, now))
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package repo
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+const (
+	// secretRefKubernetes represents the prefix used in references to
+	// secrets stored in Kubernetes.
+	secretRefKubernetes = "kubernetes"
+
+	// secretRefVault represents the prefix used in references to secrets
+	// stored in Vault.
+	secretRefVault = "vault"
+
+	// secretRepositoryLabel represents the label Kubernetes secrets must have
+	// to be used by a repository. Its value must match the repository name.
+	secretRepositoryLabel = "artifacthub.io/repository"
+
+	// Kubernetes defaults used when running in cluster.
+	defaultKubernetesAPIServerURL = "https://kubernetes.default.svc"
+	defaultKubernetesCAFile       = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	defaultKubernetesTokenFile    = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	// defaultVaultMount represents the mount path of the Vault KV secrets
+	// engine (version 2) used by default.
+	defaultVaultMount = "secret"
+)
+
+var (
+	// secretRefRE is a regexp used to validate and parse a secret reference.
+	// References have the format <store>:<name>#<key>.
+	secretRefRE = regexp.MustCompile(`^(kubernetes|vault):([a-z0-9]([-a-z0-9.]*[a-z0-9])?)#([-._a-zA-Z0-9]+)$`)
+
+	// errSecretNotFound indicates that the secret referenced was not found.
+	errSecretNotFound = errors.New("secret not found")
+)
+
+// secretRef represents a reference to a secret stored in an external store.
+type secretRef struct {
+	store string
+	name  string
+	key   string
+}
+
+// parseSecretRef parses the secret reference provided.
+func parseSecretRef(ref string) (*secretRef, error) {
+	matches := secretRefRE.FindStringSubmatch(ref)
+	if matches == nil {
+		return nil, errors.New("invalid secret reference: expected format is <kubernetes|vault>:<name>#<key>")
+	}
+	return &secretRef{
+		store: matches[1],
+		name:  matches[2],
+		key:   matches[4],
+	}, nil
+}
+
+// ResolveCredentials resolves the credentials of the repository provided
+// when they reference a secret stored in an external store, setting the
+// password obtained in the repository.
+func (m *Manager) ResolveCredentials(ctx context.Context, r *hub.Repository) error {
+	if r.AuthPassRef == "" {
+		return nil
+	}
+	ref, err := parseSecretRef(r.AuthPassRef)
+	if err != nil {
+		return err
+	}
+	var secret string
+	switch ref.store {
+	case secretRefKubernetes:
+		secret, err = m.getKubernetesSecret(ctx, r.Name, ref)
+	case secretRefVault:
+		secret, err = m.getVaultSecret(ctx, r.Name, ref)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting secret %s: %w", r.AuthPassRef, err)
+	}
+	r.AuthPass = secret
+	return nil
+}
+
+// withResolvedCredentials returns a copy of the repository provided with its
+// credentials resolved when they reference a secret stored in an external
+// store. Otherwise the repository provided is returned as is.
+func (m *Manager) withResolvedCredentials(ctx context.Context, r *hub.Repository) (*hub.Repository, error) {
+	if r.AuthPassRef == "" {
+		return r, nil
+	}
+	rCopy := *r
+	if err := m.ResolveCredentials(ctx, &rCopy); err != nil {
+		return nil, err
+	}
+	return &rCopy, nil
+}
+
+// validateSecretRef validates the secret reference of the repository
+// provided, making sure the store referenced is enabled.
+func (m *Manager) validateSecretRef(r *hub.Repository) error {
+	if r.AuthPassRef == "" {
+		return nil
+	}
+	if r.AuthPass != "" {
+		return errors.New("password and password reference cannot be provided together")
+	}
+	ref, err := parseSecretRef(r.AuthPassRef)
+	if err != nil {
+		return err
+	}
+	switch ref.store {
+	case secretRefKubernetes:
+		if m.cfg.GetString("creds.secretRefs.kubernetes.namespace") == "" {
+			return errors.New("kubernetes secrets references not enabled")
+		}
+	case secretRefVault:
+		if m.cfg.GetString("creds.secretRefs.vault.addr") == "" {
+			return errors.New("vault secrets references not enabled")
+		}
+	}
+	return nil
+}
+
+// getKubernetesSecret returns the value of the referenced key in a
+// Kubernetes secret. Secrets are read from the namespace configured and they
+// must be labeled with the name of the repository using them.
+func (m *Manager) getKubernetesSecret(ctx context.Context, repoName string, ref *secretRef) (string, error) {
+	namespace := m.cfg.GetString("creds.secretRefs.kubernetes.namespace")
+	if namespace == "" {
+		return "", errors.New("kubernetes secrets references not enabled")
+	}
+	apiServerURL := m.cfg.GetString("creds.secretRefs.kubernetes.apiServerURL")
+	if apiServerURL == "" {
+		apiServerURL = defaultKubernetesAPIServerURL
+	}
+	tokenFile := m.cfg.GetString("creds.secretRefs.kubernetes.tokenFile")
+	if tokenFile == "" {
+		tokenFile = defaultKubernetesTokenFile
+	}
+	token, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("error reading service account token: %w", err)
+	}
+
+	// Get secret from Kubernetes API server
+	hc, err := m.kubernetesHTTPClient(apiServerURL)
+	if err != nil {
+		return "", err
+	}
+	u := fmt.Sprintf("%s/api/v1/namespaces/%s/secrets/%s", strings.TrimSuffix(apiServerURL, "/"), namespace, ref.name)
+	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	var secret struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Data map[string]string `json:"data"`
+	}
+	if err := getSecretJSON(hc, req, &secret); err != nil {
+		return "", err
+	}
+
+	// Check the secret can be used by the repository and extract the key
+	if secret.Metadata.Labels[secretRepositoryLabel] != repoName {
+		return "", fmt.Errorf("secret not labeled for this repository (%s=%s)", secretRepositoryLabel, repoName)
+	}
+	value, ok := secret.Data[ref.key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret", ref.key)
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("error decoding secret key %s: %w", ref.key, err)
+	}
+	return string(data), nil
+}
+
+// kubernetesHTTPClient returns an http client that can be used to make
+// requests to the Kubernetes API server provided. When the API server uses
+// https, the service account CA is trusted.
+func (m *Manager) kubernetesHTTPClient(apiServerURL string) (*http.Client, error) {
+	u, err := url.Parse(apiServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubernetes api server url: %w", err)
+	}
+	hc := &http.Client{Timeout: 10 * time.Second}
+	if u.Scheme == "https" {
+		caFile := m.cfg.GetString("creds.secretRefs.kubernetes.caFile")
+		if caFile == "" {
+			caFile = defaultKubernetesCAFile
+		}
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading service account CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("invalid service account CA")
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig.RootCAs = pool
+		hc.Transport = transport
+	}
+	return hc, nil
+}
+
+// getVaultSecret returns the value of the referenced key in a secret stored
+// in the Vault KV secrets engine (version 2). Secrets are read from a path
+// made of the prefix configured and the name of the repository using them.
+func (m *Manager) getVaultSecret(ctx context.Context, repoName string, ref *secretRef) (string, error) {
+	addr := m.cfg.GetString("creds.secretRefs.vault.addr")
+	if addr == "" {
+		return "", errors.New("vault secrets references not enabled")
+	}
+	mount := m.cfg.GetString("creds.secretRefs.vault.mount")
+	if mount == "" {
+		mount = defaultVaultMount
+	}
+	pathPrefix := m.cfg.GetString("creds.secretRefs.vault.pathPrefix")
+
+	// Get secret from Vault
+	hc := &http.Client{Timeout: 10 * time.Second}
+	secretPath := path.Join(mount, "data", pathPrefix, repoName, ref.name)
+	u := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(addr, "/"), secretPath)
+	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+	req.Header.Set("X-Vault-Token", m.cfg.GetString("creds.secretRefs.vault.token"))
+	var secret struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := getSecretJSON(hc, req, &secret); err != nil {
+		return "", err
+	}
+
+	// Extract the key requested
+	value, ok := secret.Data.Data[ref.key].(string)
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret", ref.key)
+	}
+	return value, nil
+}
+
+// getSecretJSON is a helper that performs the request provided and decodes
+// the json response received into the value provided.
+func getSecretJSON(hc hub.HTTPClient, req *http.Request, v interface{}) error {
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errSecretNotFound
+	default:
+		return fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
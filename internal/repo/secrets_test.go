@@ -0,0 +1,209 @@
+package repo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	t.Run("invalid references", func(t *testing.T) {
+		t.Parallel()
+		for _, ref := range []string{
+			"",
+			"secret#key",
+			"other:secret#key",
+			"kubernetes:secret",
+			"kubernetes:#key",
+			"kubernetes:Secret#key",
+			"vault:../secret#key",
+			"vault:path/secret#key",
+		} {
+			_, err := parseSecretRef(ref)
+			assert.Error(t, err, ref)
+		}
+	})
+
+	t.Run("valid references", func(t *testing.T) {
+		t.Parallel()
+		ref, err := parseSecretRef("kubernetes:repo1-creds#password")
+		require.NoError(t, err)
+		assert.Equal(t, &secretRef{store: "kubernetes", name: "repo1-creds", key: "password"}, ref)
+		ref, err = parseSecretRef("vault:repo1.creds#auth_pass")
+		require.NoError(t, err)
+		assert.Equal(t, &secretRef{store: "vault", name: "repo1.creds", key: "auth_pass"}, ref)
+	})
+}
+
+func TestResolveCredentials(t *testing.T) {
+	ctx := context.Background()
+	tokenFile := writeTempFile(t, "token")
+
+	t.Run("no secret reference, nothing to resolve", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(viper.New(), nil, nil)
+		r := &hub.Repository{Name: "repo1", AuthPass: "pass1"}
+		err := m.ResolveCredentials(ctx, r)
+		assert.NoError(t, err)
+		assert.Equal(t, "pass1", r.AuthPass)
+	})
+
+	t.Run("kubernetes secrets references not enabled", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(viper.New(), nil, nil)
+		r := &hub.Repository{Name: "repo1", AuthPassRef: "kubernetes:repo1-creds#password"}
+		err := m.ResolveCredentials(ctx, r)
+		assert.Contains(t, err.Error(), "kubernetes secrets references not enabled")
+	})
+
+	t.Run("kubernetes secret", func(t *testing.T) {
+		k8s := newKubernetesSecretsServer(t, "repo1", "repo1-creds", "password", "pass1")
+		defer k8s.Close()
+		cfg := viper.New()
+		cfg.Set("creds.secretRefs.kubernetes.namespace", "hub")
+		cfg.Set("creds.secretRefs.kubernetes.apiServerURL", k8s.URL)
+		cfg.Set("creds.secretRefs.kubernetes.tokenFile", tokenFile)
+		m := NewManager(cfg, nil, nil)
+
+		testCases := []struct {
+			r              *hub.Repository
+			expectedErrMsg string
+		}{
+			{
+				&hub.Repository{Name: "repo1", AuthPassRef: "kubernetes:repo2-creds#password"},
+				errSecretNotFound.Error(),
+			},
+			{
+				&hub.Repository{Name: "repo2", AuthPassRef: "kubernetes:repo1-creds#password"},
+				"secret not labeled for this repository",
+			},
+			{
+				&hub.Repository{Name: "repo1", AuthPassRef: "kubernetes:repo1-creds#other"},
+				"key other not found in secret",
+			},
+			{
+				&hub.Repository{Name: "repo1", AuthPassRef: "kubernetes:repo1-creds#password"},
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.r.Name+" "+tc.r.AuthPassRef, func(t *testing.T) {
+				err := m.ResolveCredentials(ctx, tc.r)
+				if tc.expectedErrMsg != "" {
+					assert.Contains(t, err.Error(), tc.expectedErrMsg)
+					assert.Empty(t, tc.r.AuthPass)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, "pass1", tc.r.AuthPass)
+				}
+			})
+		}
+	})
+
+	t.Run("vault secret", func(t *testing.T) {
+		vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if r.URL.Path != "/v1/secret/data/hub/repo1/repo1-creds" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"data": map[string]interface{}{
+						"password": "pass1",
+					},
+				},
+			})
+		}))
+		defer vault.Close()
+		cfg := viper.New()
+		cfg.Set("creds.secretRefs.vault.addr", vault.URL)
+		cfg.Set("creds.secretRefs.vault.token", "token")
+		cfg.Set("creds.secretRefs.vault.pathPrefix", "hub")
+		m := NewManager(cfg, nil, nil)
+
+		testCases := []struct {
+			r              *hub.Repository
+			expectedErrMsg string
+		}{
+			{
+				&hub.Repository{Name: "repo2", AuthPassRef: "vault:repo1-creds#password"},
+				errSecretNotFound.Error(),
+			},
+			{
+				&hub.Repository{Name: "repo1", AuthPassRef: "vault:repo1-creds#other"},
+				"key other not found in secret",
+			},
+			{
+				&hub.Repository{Name: "repo1", AuthPassRef: "vault:repo1-creds#password"},
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.r.Name+" "+tc.r.AuthPassRef, func(t *testing.T) {
+				err := m.ResolveCredentials(ctx, tc.r)
+				if tc.expectedErrMsg != "" {
+					assert.Contains(t, err.Error(), tc.expectedErrMsg)
+					assert.Empty(t, tc.r.AuthPass)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, "pass1", tc.r.AuthPass)
+				}
+			})
+		}
+	})
+}
+
+// newKubernetesSecretsServer returns a test server that mimics the Kubernetes
+// API server, serving a secret labeled for the repository provided.
+func newKubernetesSecretsServer(t *testing.T, repoName, name, key, value string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v1/namespaces/hub/secrets/"+name {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]string{
+					secretRepositoryLabel: repoName,
+				},
+			},
+			"data": map[string]string{
+				key: base64.StdEncoding.EncodeToString([]byte(value)),
+			},
+		})
+	}))
+}
+
+// writeTempFile writes the content provided to a temporary file that is
+// removed when the test finishes, returning its path.
+func writeTempFile(t *testing.T, content string) string {
+	t.Helper()
+	f, err := ioutil.TempFile("", "artifacthub-test")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.Remove(f.Name()) })
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return f.Name()
+}
@@ -0,0 +1,162 @@
+package repo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+var (
+	// errEncryptionKeyNotSet indicates that the key used to encrypt the
+	// repositories TLS options has not been configured.
+	errEncryptionKeyNotSet = errors.New("encryption key not configured")
+
+	// errInvalidCiphertext indicates that the encrypted TLS options provided
+	// are not valid.
+	errInvalidCiphertext = errors.New("invalid ciphertext")
+)
+
+// TLSClientConfig returns a tls.Config built from the TLS options of the
+// repository provided. When the repository has no TLS options, nil is
+// returned. The CA certificates provided are added to the system ones.
+func TLSClientConfig(r *hub.Repository) (*tls.Config, error) {
+	if !hasTLSConfig(r) {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if r.TLS.CACert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(r.TLS.CACert)) {
+			return nil, errors.New("invalid CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if r.TLS.ClientCert != "" || r.TLS.ClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(r.TLS.ClientCert), []byte(r.TLS.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// HTTPTransport returns an http transport that honors the TLS and proxy
+// options of the repository provided. When the repository has none of them
+// set, nil is returned.
+func HTTPTransport(r *hub.Repository) (*http.Transport, error) {
+	tlsConfig, err := TLSClientConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	proxyURL, err := ProxyURL(r)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil && proxyURL == nil {
+		return nil, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return transport, nil
+}
+
+// HTTPClient returns an http client that honors the TLS and proxy options of
+// the repository provided. When the repository has none of them set, the
+// client provided is returned.
+func HTTPClient(r *hub.Repository, hc hub.HTTPClient) (hub.HTTPClient, error) {
+	transport, err := HTTPTransport(r)
+	if err != nil {
+		return nil, err
+	}
+	if transport == nil {
+		return hc, nil
+	}
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+	}, nil
+}
+
+// hasTLSConfig checks if the repository provided has some TLS options set.
+func hasTLSConfig(r *hub.Repository) bool {
+	return r.TLS != nil && (r.TLS.CACert != "" || r.TLS.ClientCert != "" || r.TLS.ClientKey != "")
+}
+
+// encryptTLSConfig encrypts the TLS options provided using AES-GCM with a key
+// derived from the one provided. The nonce is prepended to the ciphertext and
+// the result is returned base64 encoded.
+func encryptTLSConfig(key string, c *hub.RepositoryTLSConfig) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	ciphertext := aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decryptTLSConfig decrypts the TLS options provided, which must have been
+// encrypted using encryptTLSConfig and the same key.
+func decryptTLSConfig(key, data string) (*hub.RepositoryTLSConfig, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(ciphertext) < aead.NonceSize() {
+		return nil, errInvalidCiphertext
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting TLS options: %w", err)
+	}
+	var c *hub.RepositoryTLSConfig
+	if err := json.Unmarshal(plaintext, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// newAEAD creates a new AES-GCM cipher using a 256 bits key derived from the
+// key provided.
+func newAEAD(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, errEncryptionKeyNotSet
+	}
+	k := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSClientConfig(t *testing.T) {
+	cert, key := readTLSTestData(t)
+
+	t.Run("no TLS options", func(t *testing.T) {
+		t.Parallel()
+		for _, r := range []*hub.Repository{
+			{},
+			{TLS: &hub.RepositoryTLSConfig{}},
+		} {
+			tlsConfig, err := TLSClientConfig(r)
+			assert.NoError(t, err)
+			assert.Nil(t, tlsConfig)
+		}
+	})
+
+	t.Run("invalid CA certificate", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{TLS: &hub.RepositoryTLSConfig{CACert: "invalid"}}
+		tlsConfig, err := TLSClientConfig(r)
+		assert.EqualError(t, err, "invalid CA certificate")
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("invalid client certificate or key", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{TLS: &hub.RepositoryTLSConfig{ClientCert: cert, ClientKey: "invalid"}}
+		tlsConfig, err := TLSClientConfig(r)
+		assert.Contains(t, err.Error(), "invalid client certificate or key")
+		assert.Nil(t, tlsConfig)
+	})
+
+	t.Run("valid TLS options", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{TLS: &hub.RepositoryTLSConfig{
+			CACert:     cert,
+			ClientCert: cert,
+			ClientKey:  key,
+		}}
+		tlsConfig, err := TLSClientConfig(r)
+		require.NoError(t, err)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.Len(t, tlsConfig.Certificates, 1)
+	})
+}
+
+func TestHTTPClient(t *testing.T) {
+	cert, _ := readTLSTestData(t)
+	hc := &http.Client{}
+
+	t.Run("no TLS options, client provided returned", func(t *testing.T) {
+		t.Parallel()
+		c, err := HTTPClient(&hub.Repository{}, hc)
+		require.NoError(t, err)
+		assert.Same(t, hc, c)
+	})
+
+	t.Run("invalid TLS options", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{TLS: &hub.RepositoryTLSConfig{CACert: "invalid"}}
+		c, err := HTTPClient(r, hc)
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("TLS options set, new client returned", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{TLS: &hub.RepositoryTLSConfig{CACert: cert}}
+		c, err := HTTPClient(r, hc)
+		require.NoError(t, err)
+		assert.NotSame(t, hc, c)
+		transport := c.(*http.Client).Transport.(*http.Transport)
+		assert.NotNil(t, transport.TLSClientConfig.RootCAs)
+	})
+
+	t.Run("invalid proxy url", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{ProxyURL: "ftp://proxy.com"}
+		c, err := HTTPClient(r, hc)
+		assert.Error(t, err)
+		assert.Nil(t, c)
+	})
+
+	t.Run("proxy set, new client returned", func(t *testing.T) {
+		t.Parallel()
+		r := &hub.Repository{ProxyURL: "http://proxy.com:3128"}
+		c, err := HTTPClient(r, hc)
+		require.NoError(t, err)
+		assert.NotSame(t, hc, c)
+		transport := c.(*http.Client).Transport.(*http.Transport)
+		req, _ := http.NewRequest("GET", "https://repo1.com/index.yaml", nil)
+		proxyURL, err := transport.Proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, "http://proxy.com:3128", proxyURL.String())
+	})
+}
+
+func TestEncryptDecryptTLSConfig(t *testing.T) {
+	tlsConfig := &hub.RepositoryTLSConfig{
+		CACert:     "caCert",
+		ClientCert: "clientCert",
+		ClientKey:  "clientKey",
+	}
+
+	t.Run("encryption key not set", func(t *testing.T) {
+		t.Parallel()
+		_, err := encryptTLSConfig("", tlsConfig)
+		assert.Equal(t, errEncryptionKeyNotSet, err)
+		_, err = decryptTLSConfig("", "data")
+		assert.Equal(t, errEncryptionKeyNotSet, err)
+	})
+
+	t.Run("invalid ciphertext", func(t *testing.T) {
+		t.Parallel()
+		for _, data := range []string{"invalid", "aW52YWxpZA=="} {
+			_, err := decryptTLSConfig("key", data)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("decrypting with a different key fails", func(t *testing.T) {
+		t.Parallel()
+		data, err := encryptTLSConfig("key1", tlsConfig)
+		require.NoError(t, err)
+		_, err = decryptTLSConfig("key2", data)
+		assert.Error(t, err)
+	})
+
+	t.Run("encrypt and decrypt succeeded", func(t *testing.T) {
+		t.Parallel()
+		data, err := encryptTLSConfig("key", tlsConfig)
+		require.NoError(t, err)
+		assert.NotContains(t, data, "clientKey")
+		decryptedTLSConfig, err := decryptTLSConfig("key", data)
+		require.NoError(t, err)
+		assert.Equal(t, tlsConfig, decryptedTLSConfig)
+	})
+}
+
+func readTLSTestData(t *testing.T) (cert, key string) {
+	certData, err := ioutil.ReadFile("testdata/tls.crt")
+	require.NoError(t, err)
+	keyData, err := ioutil.ReadFile("testdata/tls.key")
+	require.NoError(t, err)
+	return string(certData), string(keyData)
+}
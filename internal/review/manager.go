@@ -0,0 +1,183 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addReviewDBQ    = `select add_package_review($1::uuid, $2::jsonb)`
+	deleteReviewDBQ = `select delete_package_review($1::uuid, $2::uuid)`
+	getReviewsDBQ   = `select get_package_reviews($1::uuid, $2::uuid, $3::int, $4::int)`
+	replyReviewDBQ  = `select reply_package_review($1::uuid, $2::uuid, $3::uuid, $4::text)`
+	reportReviewDBQ = `select report_package_review($1::uuid, $2::uuid, $3::uuid, $4::text)`
+
+	// DefaultLimit represents the default number of reviews returned.
+	DefaultLimit = 20
+
+	// MaxLimit represents the maximum number of reviews that can be requested.
+	MaxLimit = 60
+
+	// MaxTitleLength represents the maximum length of a review title.
+	MaxTitleLength = 100
+
+	// MaxBodyLength represents the maximum length of a review body, as well as
+	// the maximum length of the publisher's reply.
+	MaxBodyLength = 2000
+
+	// MaxReasonLength represents the maximum length of the reason provided when
+	// reporting a review.
+	MaxReasonLength = 500
+)
+
+// Manager provides an API to manage packages reviews.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add adds the provided review to the database. When the user has already
+// reviewed the package, the existing review is replaced.
+func (m *Manager) Add(ctx context.Context, r *hub.Review) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(r.PackageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if r.Rating < 1 || r.Rating > 5 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid rating (1-5)")
+	}
+	if utf8.RuneCountInString(r.Title) > MaxTitleLength {
+		return fmt.Errorf("%w: %s (max %d)", hub.ErrInvalidInput, "title too long", MaxTitleLength)
+	}
+	if utf8.RuneCountInString(r.Body) > MaxBodyLength {
+		return fmt.Errorf("%w: %s (max %d)", hub.ErrInvalidInput, "body too long", MaxBodyLength)
+	}
+
+	// Add review to database
+	rJSON, _ := json.Marshal(r)
+	_, err := m.db.Exec(ctx, addReviewDBQ, userID, rJSON)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
+// Delete deletes the review the requesting user posted on the provided
+// package.
+func (m *Manager) Delete(ctx context.Context, packageID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(packageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+
+	// Delete review from database
+	_, err := m.db.Exec(ctx, deleteReviewDBQ, userID, packageID)
+	if err != nil && err.Error() == util.ErrDBNotFound.Error() {
+		return hub.ErrNotFound
+	}
+	return err
+}
+
+// GetJSON returns the visible reviews of the provided package as a json
+// object. When the request is authenticated, the review posted by the
+// requesting user is included as well.
+func (m *Manager) GetJSON(ctx context.Context, input *hub.GetReviewsInput) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(input.PackageID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if input.Limit == 0 {
+		input.Limit = DefaultLimit
+	}
+	if input.Limit < 0 || input.Limit > MaxLimit {
+		return nil, fmt.Errorf("%w: invalid limit (0 < l <= %d)", hub.ErrInvalidInput, MaxLimit)
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
+	}
+
+	// Get reviews from database
+	var userID *string
+	if v, _ := ctx.Value(hub.UserIDKey).(string); v != "" {
+		userID = &v
+	}
+	return util.DBQueryJSON(ctx, m.db, getReviewsDBQ, userID, input.PackageID, input.Limit, input.Offset)
+}
+
+// Reply sets the reply of the package's publisher to the provided review. An
+// empty reply removes the existing one.
+func (m *Manager) Reply(ctx context.Context, packageID, reviewID, reply string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(packageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if _, err := uuid.FromString(reviewID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid review id")
+	}
+	if utf8.RuneCountInString(reply) > MaxBodyLength {
+		return fmt.Errorf("%w: %s (max %d)", hub.ErrInvalidInput, "reply too long", MaxBodyLength)
+	}
+
+	// Set review reply in database
+	_, err := m.db.Exec(ctx, replyReviewDBQ, userID, packageID, reviewID, reply)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
+// Report registers an abuse report on the provided review. Reviews reported
+// by several users are hidden automatically.
+func (m *Manager) Report(ctx context.Context, packageID, reviewID, reason string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(packageID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+	}
+	if _, err := uuid.FromString(reviewID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid review id")
+	}
+	if reason == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "reason not provided")
+	}
+	if utf8.RuneCountInString(reason) > MaxReasonLength {
+		return fmt.Errorf("%w: %s (max %d)", hub.ErrInvalidInput, "reason too long", MaxReasonLength)
+	}
+
+	// Register report in database
+	_, err := m.db.Exec(ctx, reportReviewDBQ, userID, packageID, reviewID, reason)
+	if err != nil && err.Error() == util.ErrDBNotFound.Error() {
+		return hub.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,437 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	pkgID    = "00000000-0000-0000-0000-000000000001"
+	reviewID = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Add(context.Background(), &hub.Review{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			r      *hub.Review
+		}{
+			{
+				"invalid package id",
+				&hub.Review{PackageID: "pkgID", Rating: 5},
+			},
+			{
+				"invalid rating",
+				&hub.Review{PackageID: pkgID, Rating: 0},
+			},
+			{
+				"invalid rating",
+				&hub.Review{PackageID: pkgID, Rating: 6},
+			},
+			{
+				"title too long",
+				&hub.Review{PackageID: pkgID, Rating: 5, Title: strings.Repeat("a", MaxTitleLength+1)},
+			},
+			{
+				"body too long",
+				&hub.Review{PackageID: pkgID, Rating: 5, Body: strings.Repeat("a", MaxBodyLength+1)},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Add(ctx, tc.r)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addReviewDBQ, "userID", []byte(`{"package_id":"`+pkgID+`","rating":4,"title":"","body":""}`)).
+					Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Add(ctx, &hub.Review{PackageID: pkgID, Rating: 4})
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("review added successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addReviewDBQ, "userID", []byte(`{"package_id":"`+pkgID+`","rating":4,"title":"Great","body":"Works fine"}`)).
+			Return(nil)
+		m := NewManager(db)
+
+		err := m.Add(ctx, &hub.Review{PackageID: pkgID, Rating: 4, Title: "Great", Body: "Works fine"})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), pkgID)
+		})
+	})
+
+	t.Run("invalid package id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Delete(ctx, "pkgID")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteReviewDBQ, "userID", pkgID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Delete(ctx, pkgID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("review deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteReviewDBQ, "userID", pkgID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Delete(ctx, pkgID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetReviewsInput
+		}{
+			{
+				"invalid package id",
+				&hub.GetReviewsInput{PackageID: "pkgID"},
+			},
+			{
+				"invalid limit",
+				&hub.GetReviewsInput{PackageID: pkgID, Limit: MaxLimit + 1},
+			},
+			{
+				"invalid offset",
+				&hub.GetReviewsInput{PackageID: pkgID, Offset: -1},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded (anonymous user)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		var userID *string
+		db.On("QueryRow", ctx, getReviewsDBQ, userID, pkgID, DefaultLimit, 0).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, &hub.GetReviewsInput{PackageID: pkgID})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (signed in user)", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+		db := &tests.DBMock{}
+		userID := "userID"
+		db.On("QueryRow", ctx, getReviewsDBQ, &userID, pkgID, 10, 20).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, &hub.GetReviewsInput{PackageID: pkgID, Limit: 10, Offset: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		var userID *string
+		db.On("QueryRow", ctx, getReviewsDBQ, userID, pkgID, DefaultLimit, 0).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, &hub.GetReviewsInput{PackageID: pkgID})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestReply(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Reply(context.Background(), pkgID, reviewID, "reply")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			reviewID  string
+			reply     string
+		}{
+			{
+				"invalid package id",
+				"pkgID",
+				reviewID,
+				"reply",
+			},
+			{
+				"invalid review id",
+				pkgID,
+				"reviewID",
+				"reply",
+			},
+			{
+				"reply too long",
+				pkgID,
+				reviewID,
+				strings.Repeat("a", MaxBodyLength+1),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Reply(ctx, tc.packageID, tc.reviewID, tc.reply)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, replyReviewDBQ, "userID", pkgID, reviewID, "reply").Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Reply(ctx, pkgID, reviewID, "reply")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("reply set successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, replyReviewDBQ, "userID", pkgID, reviewID, "reply").Return(nil)
+		m := NewManager(db)
+
+		err := m.Reply(ctx, pkgID, reviewID, "reply")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestReport(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Report(context.Background(), pkgID, reviewID, "spam")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			packageID string
+			reviewID  string
+			reason    string
+		}{
+			{
+				"invalid package id",
+				"pkgID",
+				reviewID,
+				"spam",
+			},
+			{
+				"invalid review id",
+				pkgID,
+				"reviewID",
+				"spam",
+			},
+			{
+				"reason not provided",
+				pkgID,
+				reviewID,
+				"",
+			},
+			{
+				"reason too long",
+				pkgID,
+				reviewID,
+				strings.Repeat("a", MaxReasonLength+1),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Report(ctx, tc.packageID, tc.reviewID, tc.reason)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, reportReviewDBQ, "userID", pkgID, reviewID, "spam").Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Report(ctx, pkgID, reviewID, "spam")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("review reported successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, reportReviewDBQ, "userID", pkgID, reviewID, "spam").Return(nil)
+		m := NewManager(db)
+
+		err := m.Report(ctx, pkgID, reviewID, "spam")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,44 @@
+package review
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the ReviewManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the ReviewManager interface.
+func (m *ManagerMock) Add(ctx context.Context, r *hub.Review) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+// Delete implements the ReviewManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, packageID string) error {
+	args := m.Called(ctx, packageID)
+	return args.Error(0)
+}
+
+// GetJSON implements the ReviewManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context, input *hub.GetReviewsInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Reply implements the ReviewManager interface.
+func (m *ManagerMock) Reply(ctx context.Context, packageID, reviewID, reply string) error {
+	args := m.Called(ctx, packageID, reviewID, reply)
+	return args.Error(0)
+}
+
+// Report implements the ReviewManager interface.
+func (m *ManagerMock) Report(ctx context.Context, packageID, reviewID, reason string) error {
+	args := m.Called(ctx, packageID, reviewID, reason)
+	return args.Error(0)
+}
@@ -0,0 +1,64 @@
+package savedsearch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Database queries
+	evaluateSavedSearchesDBQ = `select evaluate_saved_searches()`
+
+	// evaluatorInterval represents how often the evaluator checks if there
+	// are new packages matching the saved searches.
+	evaluatorInterval = 1 * time.Hour
+)
+
+// Evaluator is in charge of evaluating periodically the saved searches,
+// registering the new packages matching them and notifying their owners when
+// they have enabled notifications.
+type Evaluator struct {
+	db hub.DB
+}
+
+// NewEvaluator creates a new Evaluator instance.
+func NewEvaluator(db hub.DB) *Evaluator {
+	return &Evaluator{
+		db: db,
+	}
+}
+
+// Run runs the evaluator periodically until it's asked to stop via the
+// context provided.
+func (e *Evaluator) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(evaluatorInterval)
+	defer ticker.Stop()
+	for {
+		if n, err := e.Evaluate(ctx); err != nil {
+			if ctx.Err() == nil {
+				log.Error().Err(err).Msg("error evaluating saved searches")
+			}
+		} else if n > 0 {
+			log.Info().Int64("matches", n).Msg("saved searches new matches registered")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evaluate evaluates all the saved searches, returning the number of new
+// matches found.
+func (e *Evaluator) Evaluate(ctx context.Context) (int64, error) {
+	var n int64
+	err := e.db.QueryRow(ctx, evaluateSavedSearchesDBQ).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,36 @@
+package savedsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluatorEvaluate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, evaluateSavedSearchesDBQ).Return(nil, tests.ErrFakeDB)
+		e := NewEvaluator(db)
+
+		_, err := e.Evaluate(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("saved searches evaluated successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, evaluateSavedSearchesDBQ).Return(int64(3), nil)
+		e := NewEvaluator(db)
+
+		n, err := e.Evaluate(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,149 @@
+package savedsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addSavedSearchDBQ        = `select add_saved_search($1::jsonb)`
+	deleteSavedSearchDBQ     = `select delete_saved_search($1::uuid, $2::uuid)`
+	getSavedSearchDBQ        = `select get_saved_search($1::uuid, $2::uuid)`
+	getSavedSearchMatchesDBQ = `select get_saved_search_matches($1::uuid, $2::uuid)`
+	getUserSavedSearchesDBQ  = `select get_user_saved_searches($1::uuid)`
+	updateSavedSearchDBQ     = `select update_saved_search($1::jsonb)`
+)
+
+// Manager provides an API to manage saved searches.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// Add adds the provided saved search to the database.
+func (m *Manager) Add(ctx context.Context, s *hub.SavedSearch) ([]byte, error) {
+	s.UserID = ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateSavedSearch(s); err != nil {
+		return nil, err
+	}
+
+	// Add saved search to the database
+	sJSON, _ := json.Marshal(s)
+	return util.DBQueryJSON(ctx, m.db, addSavedSearchDBQ, sJSON)
+}
+
+// Delete deletes the provided saved search from the database.
+func (m *Manager) Delete(ctx context.Context, savedSearchID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(savedSearchID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid saved search id")
+	}
+
+	// Delete saved search from database
+	_, err := m.db.Exec(ctx, deleteSavedSearchDBQ, userID, savedSearchID)
+	return err
+}
+
+// GetJSON returns the requested saved search as a json object.
+func (m *Manager) GetJSON(ctx context.Context, savedSearchID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(savedSearchID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid saved search id")
+	}
+
+	// Get saved search from database
+	return util.DBQueryJSON(ctx, m.db, getSavedSearchDBQ, userID, savedSearchID)
+}
+
+// GetMatchesJSON returns the packages that started matching the requested
+// saved search after it was saved as a json array. The matches returned are
+// marked as seen.
+func (m *Manager) GetMatchesJSON(ctx context.Context, savedSearchID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(savedSearchID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid saved search id")
+	}
+
+	// Get saved search matches from database
+	return util.DBQueryJSON(ctx, m.db, getSavedSearchMatchesDBQ, userID, savedSearchID)
+}
+
+// GetOwnedByUserJSON returns the saved searches belonging to the requesting
+// user as a json array.
+func (m *Manager) GetOwnedByUserJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Get saved searches from database
+	return util.DBQueryJSON(ctx, m.db, getUserSavedSearchesDBQ, userID)
+}
+
+// Update updates the provided saved search in the database.
+func (m *Manager) Update(ctx context.Context, s *hub.SavedSearch) error {
+	s.UserID = ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(s.SavedSearchID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid saved search id")
+	}
+	if err := validateSavedSearch(s); err != nil {
+		return err
+	}
+
+	// Update saved search in database
+	sJSON, _ := json.Marshal(s)
+	_, err := m.db.Exec(ctx, updateSavedSearchDBQ, sJSON)
+	return err
+}
+
+// validateSavedSearch checks if the provided saved search is valid. The
+// pagination related fields of the search query are cleared, as all the
+// packages matching it are taken into account when it is evaluated.
+func validateSavedSearch(s *hub.SavedSearch) error {
+	if s.Name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if s.Query == nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "query not provided")
+	}
+	for _, alias := range s.Query.Users {
+		if alias == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user alias")
+		}
+	}
+	for _, name := range s.Query.Orgs {
+		if name == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid organization name")
+		}
+	}
+	for _, name := range s.Query.Repositories {
+		if name == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository name")
+		}
+	}
+	s.Query.Limit = 0
+	s.Query.Offset = 0
+	s.Query.Cursor = ""
+	s.Query.Facets = false
+	s.Query.Sort = ""
+	return nil
+}
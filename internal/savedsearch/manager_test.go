@@ -0,0 +1,385 @@
+package savedsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+)
+
+const savedSearchID = "00000000-0000-0000-0000-000000000001"
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			s := &hub.SavedSearch{
+				Name:  "search1",
+				Query: &hub.SearchPackageInput{TSQueryWeb: "kw1"},
+			}
+			_, _ = m.Add(context.Background(), s)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			s      *hub.SavedSearch
+		}{
+			{
+				"name not provided",
+				&hub.SavedSearch{
+					Name: "",
+				},
+			},
+			{
+				"query not provided",
+				&hub.SavedSearch{
+					Name: "search1",
+				},
+			},
+			{
+				"invalid user alias",
+				&hub.SavedSearch{
+					Name:  "search1",
+					Query: &hub.SearchPackageInput{Users: []string{""}},
+				},
+			},
+			{
+				"invalid organization name",
+				&hub.SavedSearch{
+					Name:  "search1",
+					Query: &hub.SearchPackageInput{Orgs: []string{""}},
+				},
+			},
+			{
+				"invalid repository name",
+				&hub.SavedSearch{
+					Name:  "search1",
+					Query: &hub.SearchPackageInput{Repositories: []string{""}},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+
+				dataJSON, err := m.Add(ctx, tc.s)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Empty(t, dataJSON)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		s := &hub.SavedSearch{
+			Name:   "search1",
+			UserID: "userID",
+			Query:  &hub.SearchPackageInput{TSQueryWeb: "kw1"},
+		}
+		sJSON, _ := json.Marshal(s)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addSavedSearchDBQ, sJSON).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.Add(ctx, s)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("add saved search succeeded", func(t *testing.T) {
+		t.Parallel()
+		s := &hub.SavedSearch{
+			Name:   "search1",
+			UserID: "userID",
+			Query: &hub.SearchPackageInput{
+				Limit:      20,
+				Offset:     20,
+				Facets:     true,
+				TSQueryWeb: "kw1",
+			},
+			NotificationsEnabled: true,
+		}
+		expectedSJSON, _ := json.Marshal(&hub.SavedSearch{
+			Name:                 "search1",
+			UserID:               "userID",
+			Query:                &hub.SearchPackageInput{TSQueryWeb: "kw1"},
+			NotificationsEnabled: true,
+		})
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addSavedSearchDBQ, expectedSJSON).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.Add(ctx, s)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), savedSearchID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Delete(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteSavedSearchDBQ, "userID", savedSearchID).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.Delete(ctx, savedSearchID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("delete saved search succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteSavedSearchDBQ, "userID", savedSearchID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Delete(ctx, savedSearchID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetJSON(context.Background(), savedSearchID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSavedSearchDBQ, "userID", savedSearchID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, savedSearchID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("saved search data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSavedSearchDBQ, "userID", savedSearchID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetJSON(ctx, savedSearchID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetMatchesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetMatchesJSON(context.Background(), savedSearchID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetMatchesJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSavedSearchMatchesDBQ, "userID", savedSearchID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetMatchesJSON(ctx, savedSearchID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("saved search matches returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSavedSearchMatchesDBQ, "userID", savedSearchID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetMatchesJSON(ctx, savedSearchID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetOwnedByUserJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetOwnedByUserJSON(context.Background())
+		})
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserSavedSearchesDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetOwnedByUserJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user saved searches data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserSavedSearchesDBQ, "userID").Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetOwnedByUserJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			s := &hub.SavedSearch{
+				SavedSearchID: savedSearchID,
+				Name:          "search1-updated",
+				Query:         &hub.SearchPackageInput{TSQueryWeb: "kw1"},
+			}
+			_ = m.Update(context.Background(), s)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			s      *hub.SavedSearch
+		}{
+			{
+				"invalid saved search id",
+				&hub.SavedSearch{
+					SavedSearchID: "",
+				},
+			},
+			{
+				"name not provided",
+				&hub.SavedSearch{
+					SavedSearchID: savedSearchID,
+					Name:          "",
+				},
+			},
+			{
+				"query not provided",
+				&hub.SavedSearch{
+					SavedSearchID: savedSearchID,
+					Name:          "search1-updated",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+
+				err := m.Update(ctx, tc.s)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		s := &hub.SavedSearch{
+			SavedSearchID: savedSearchID,
+			Name:          "search1-updated",
+			UserID:        "userID",
+			Query:         &hub.SearchPackageInput{TSQueryWeb: "kw1"},
+		}
+		sJSON, _ := json.Marshal(s)
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateSavedSearchDBQ, sJSON).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.Update(ctx, s)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("update saved search succeeded", func(t *testing.T) {
+		t.Parallel()
+		s := &hub.SavedSearch{
+			SavedSearchID: savedSearchID,
+			Name:          "search1-updated",
+			UserID:        "userID",
+			Query:         &hub.SearchPackageInput{TSQueryWeb: "kw1"},
+		}
+		sJSON, _ := json.Marshal(s)
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateSavedSearchDBQ, sJSON).Return(nil)
+		m := NewManager(db)
+
+		err := m.Update(ctx, s)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
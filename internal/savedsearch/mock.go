@@ -0,0 +1,53 @@
+package savedsearch
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the SavedSearchManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the SavedSearchManager interface.
+func (m *ManagerMock) Add(ctx context.Context, s *hub.SavedSearch) ([]byte, error) {
+	args := m.Called(ctx, s)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Delete implements the SavedSearchManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, savedSearchID string) error {
+	args := m.Called(ctx, savedSearchID)
+	return args.Error(0)
+}
+
+// GetJSON implements the SavedSearchManager interface.
+func (m *ManagerMock) GetJSON(ctx context.Context, savedSearchID string) ([]byte, error) {
+	args := m.Called(ctx, savedSearchID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetMatchesJSON implements the SavedSearchManager interface.
+func (m *ManagerMock) GetMatchesJSON(ctx context.Context, savedSearchID string) ([]byte, error) {
+	args := m.Called(ctx, savedSearchID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetOwnedByUserJSON implements the SavedSearchManager interface.
+func (m *ManagerMock) GetOwnedByUserJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Update implements the SavedSearchManager interface.
+func (m *ManagerMock) Update(ctx context.Context, s *hub.SavedSearch) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
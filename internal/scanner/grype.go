@@ -0,0 +1,194 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// grypeOSPackagesTypes represents the types of the packages installed using
+// the operating system package manager.
+var grypeOSPackagesTypes = map[string]struct{}{
+	"alpm":    {},
+	"apk":     {},
+	"deb":     {},
+	"portage": {},
+	"rpm":     {},
+}
+
+// GrypeScanner is an implementation of the Scanner interface that uses Grype.
+type GrypeScanner struct {
+	Ctx context.Context
+	Cfg *viper.Viper
+}
+
+// Scan implements the Scanner interface.
+func (s *GrypeScanner) Scan(image string) ([]byte, error) {
+	// Setup grype command (images are pulled directly from the registry)
+	cmd := exec.CommandContext(s.Ctx, "grype", "--quiet", "-o", "json", "registry:"+image) // #nosec
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = commandEnv("GRYPE_DB_CACHE_DIR")
+
+	// If the registry is the Docker Hub, include credentials (when provided)
+	// to avoid rate limiting issues
+	isDockerHub, err := isDockerHubImage(image)
+	if err != nil {
+		return nil, err
+	}
+	if isDockerHub && s.Cfg.GetString("creds.dockerUsername") != "" {
+		cmd.Env = append(cmd.Env,
+			"GRYPE_REGISTRY_AUTH_AUTHORITY=index.docker.io",
+			"GRYPE_REGISTRY_AUTH_USERNAME="+s.Cfg.GetString("creds.dockerUsername"),
+			"GRYPE_REGISTRY_AUTH_PASSWORD="+s.Cfg.GetString("creds.dockerPassword"),
+		)
+	}
+
+	// Run grype command
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "MANIFEST_UNKNOWN") || strings.Contains(stderr.String(), "NAME_UNKNOWN") {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("error running grype on image %s: %w: %s", image, err, stderr.String())
+	}
+	return normalizeGrypeReport(image, stdout.Bytes())
+}
+
+// grypeReport represents the report generated by Grype (only the fields we
+// are interested in are included).
+type grypeReport struct {
+	Matches []*grypeMatch `json:"matches"`
+	Distro  struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"distro"`
+}
+
+// grypeMatch represents a vulnerability found by Grype in a given artifact.
+type grypeMatch struct {
+	Vulnerability          *grypeVulnerability   `json:"vulnerability"`
+	RelatedVulnerabilities []*grypeVulnerability `json:"relatedVulnerabilities"`
+	Artifact               struct {
+		Name      string `json:"name"`
+		Version   string `json:"version"`
+		Type      string `json:"type"`
+		Locations []struct {
+			Path string `json:"path"`
+		} `json:"locations"`
+	} `json:"artifact"`
+}
+
+// grypeVulnerability represents some details about a vulnerability provided
+// by one of the Grype data sources.
+type grypeVulnerability struct {
+	ID          string   `json:"id"`
+	DataSource  string   `json:"dataSource"`
+	Namespace   string   `json:"namespace"`
+	Severity    string   `json:"severity"`
+	Description string   `json:"description"`
+	URLs        []string `json:"urls"`
+	CVSS        []struct {
+		Version string `json:"version"`
+		Vector  string `json:"vector"`
+		Metrics struct {
+			BaseScore float64 `json:"baseScore"`
+		} `json:"metrics"`
+	} `json:"cvss"`
+	Fix struct {
+		Versions []string `json:"versions"`
+	} `json:"fix"`
+}
+
+// normalizeGrypeReport converts the Grype report provided into a security
+// report that follows the schema of the reports generated by Trivy. The
+// vulnerabilities found in the operating system packages are grouped in a
+// target for the image, whereas the ones found in the applications
+// dependencies are grouped by the file where they were found.
+func normalizeGrypeReport(image string, data []byte) ([]byte, error) {
+	var report *grypeReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error unmarshalling grype report: %w", err)
+	}
+
+	// Operating system target is always included, even when no
+	// vulnerabilities were found
+	osTarget := &Target{
+		Target: image,
+		Type:   report.Distro.Name,
+	}
+	if report.Distro.Name != "" {
+		osTarget.Target = fmt.Sprintf("%s (%s %s)", image, report.Distro.Name, report.Distro.Version)
+	}
+	targets := []*Target{osTarget}
+	appsTargets := make(map[string]*Target)
+
+	for _, m := range report.Matches {
+		if m.Vulnerability == nil {
+			continue
+		}
+		target := osTarget
+		if _, ok := grypeOSPackagesTypes[m.Artifact.Type]; !ok {
+			var path string
+			if len(m.Artifact.Locations) > 0 {
+				path = strings.TrimPrefix(m.Artifact.Locations[0].Path, "/")
+			}
+			key := m.Artifact.Type + "#" + path
+			target = appsTargets[key]
+			if target == nil {
+				target = &Target{Target: path, Type: m.Artifact.Type}
+				appsTargets[key] = target
+				targets = append(targets, target)
+			}
+		}
+		target.Vulnerabilities = append(target.Vulnerabilities, m.toVulnerability())
+	}
+
+	return json.Marshal(targets)
+}
+
+// toVulnerability returns a security report vulnerability from the match.
+// Data missing in the vulnerability matched (like the description, usually
+// only available in the NVD entry) is taken from the related vulnerabilities.
+func (m *grypeMatch) toVulnerability() *Vulnerability {
+	v := &Vulnerability{
+		VulnerabilityID:  m.Vulnerability.ID,
+		PkgName:          m.Artifact.Name,
+		InstalledVersion: m.Artifact.Version,
+		FixedVersion:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+		Description:      m.Vulnerability.Description,
+		Severity:         normalizeSeverity(m.Vulnerability.Severity),
+		PrimaryURL:       m.Vulnerability.DataSource,
+		References:       m.Vulnerability.URLs,
+	}
+	for _, gv := range append([]*grypeVulnerability{m.Vulnerability}, m.RelatedVulnerabilities...) {
+		if gv == nil {
+			continue
+		}
+		if v.Description == "" {
+			v.Description = gv.Description
+		}
+		for _, c := range gv.CVSS {
+			source := gv.Namespace
+			if v.CVSS == nil {
+				v.CVSS = make(map[string]*CVSS)
+			}
+			if v.CVSS[source] == nil {
+				v.CVSS[source] = &CVSS{}
+			}
+			if strings.HasPrefix(c.Version, "3") {
+				v.CVSS[source].V3Vector = c.Vector
+				v.CVSS[source].V3Score = c.Metrics.BaseScore
+			} else {
+				v.CVSS[source].V2Vector = c.Vector
+				v.CVSS[source].V2Score = c.Metrics.BaseScore
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,125 @@
+package scanner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeGrypeReport(t *testing.T) {
+	image := "repo/image:tag"
+
+	t.Run("invalid report", func(t *testing.T) {
+		t.Parallel()
+		_, err := normalizeGrypeReport(image, []byte("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("report without vulnerabilities", func(t *testing.T) {
+		t.Parallel()
+		data, err := normalizeGrypeReport(image, []byte(`{"matches": [], "distro": {"name": "alpine", "version": "3.13.5"}}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"Target": "repo/image:tag (alpine 3.13.5)", "Type": "alpine", "Vulnerabilities": null}]`, string(data))
+	})
+
+	t.Run("report with vulnerabilities", func(t *testing.T) {
+		t.Parallel()
+		data, err := normalizeGrypeReport(image, sampleGrypeReportData)
+		require.NoError(t, err)
+		var targets []*Target
+		require.NoError(t, json.Unmarshal(data, &targets))
+		assert.Equal(t, []*Target{
+			{
+				Target: "repo/image:tag (alpine 3.13.5)",
+				Type:   "alpine",
+				Vulnerabilities: []*Vulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-3711",
+						PkgName:          "libssl1.1",
+						InstalledVersion: "1.1.1k-r0",
+						FixedVersion:     "1.1.1l-r0",
+						Description:      "SM2 decryption buffer overflow",
+						Severity:         "CRITICAL",
+						PrimaryURL:       "https://security.alpinelinux.org/vuln/CVE-2021-3711",
+						References:       []string{"https://www.openssl.org/news/secadv/20210824.txt"},
+						CVSS: map[string]*CVSS{
+							"nvd": {V3Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", V3Score: 9.8},
+						},
+					},
+				},
+			},
+			{
+				Target: "usr/local/bin/app",
+				Type:   "go-module",
+				Vulnerabilities: []*Vulnerability{
+					{
+						VulnerabilityID:  "GHSA-h86h-8ppg-mxmh",
+						PkgName:          "github.com/gogo/protobuf",
+						InstalledVersion: "v1.3.1",
+						FixedVersion:     "1.3.2",
+						Severity:         "HIGH",
+						PrimaryURL:       "https://github.com/advisories/GHSA-h86h-8ppg-mxmh",
+					},
+				},
+			},
+		}, targets)
+	})
+}
+
+var sampleGrypeReportData = []byte(`
+{
+  "matches": [
+    {
+      "vulnerability": {
+        "id": "CVE-2021-3711",
+        "dataSource": "https://security.alpinelinux.org/vuln/CVE-2021-3711",
+        "namespace": "alpine:3.13",
+        "severity": "Critical",
+        "urls": ["https://www.openssl.org/news/secadv/20210824.txt"],
+        "cvss": [],
+        "fix": {"versions": ["1.1.1l-r0"], "state": "fixed"}
+      },
+      "relatedVulnerabilities": [
+        {
+          "id": "CVE-2021-3711",
+          "dataSource": "https://nvd.nist.gov/vuln/detail/CVE-2021-3711",
+          "namespace": "nvd",
+          "severity": "Critical",
+          "description": "SM2 decryption buffer overflow",
+          "cvss": [
+            {
+              "version": "3.1",
+              "vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+              "metrics": {"baseScore": 9.8}
+            }
+          ]
+        }
+      ],
+      "artifact": {
+        "name": "libssl1.1",
+        "version": "1.1.1k-r0",
+        "type": "apk",
+        "locations": [{"path": "/lib/apk/db/installed"}]
+      }
+    },
+    {
+      "vulnerability": {
+        "id": "GHSA-h86h-8ppg-mxmh",
+        "dataSource": "https://github.com/advisories/GHSA-h86h-8ppg-mxmh",
+        "namespace": "github:go",
+        "severity": "High",
+        "fix": {"versions": ["1.3.2"], "state": "fixed"}
+      },
+      "artifact": {
+        "name": "github.com/gogo/protobuf",
+        "version": "v1.3.1",
+        "type": "go-module",
+        "locations": [{"path": "/usr/local/bin/app"}]
+      }
+    }
+  ],
+  "distro": {"name": "alpine", "version": "3.13.5"}
+}
+`)
@@ -0,0 +1,219 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// osvExitCodeVulnerabilitiesFound and osvExitCodeNoPackagesFound represent
+// the exit codes used by osv-scanner when the scan was successful but some
+// vulnerabilities or no packages were found, respectively.
+const (
+	osvExitCodeVulnerabilitiesFound = 1
+	osvExitCodeNoPackagesFound      = 128
+)
+
+// OSVScanner is an implementation of the Scanner interface that uses
+// OSV-Scanner. Images are pulled using the Docker CLI, so it must be available
+// and able to reach a Docker daemon.
+type OSVScanner struct {
+	Ctx context.Context
+}
+
+// Scan implements the Scanner interface.
+func (s *OSVScanner) Scan(image string) ([]byte, error) {
+	// Setup osv-scanner command
+	cmd := exec.CommandContext(s.Ctx, "osv-scanner", "--format", "json", "--docker", image) // #nosec
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = commandEnv("DOCKER_HOST", "DOCKER_CONFIG")
+
+	// Run osv-scanner command
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("error running osv-scanner on image %s: %w", image, err)
+		}
+		switch exitErr.ExitCode() {
+		case osvExitCodeVulnerabilitiesFound:
+			// Report generated successfully
+		case osvExitCodeNoPackagesFound:
+			return normalizeOSVReport(image, nil)
+		default:
+			if strings.Contains(stderr.String(), "manifest unknown") || strings.Contains(stderr.String(), "pull access denied") {
+				return nil, ErrImageNotFound
+			}
+			return nil, fmt.Errorf("error running osv-scanner on image %s: %w: %s", image, err, stderr.String())
+		}
+	}
+	return normalizeOSVReport(image, stdout.Bytes())
+}
+
+// osvReport represents the report generated by OSV-Scanner (only the fields
+// we are interested in are included).
+type osvReport struct {
+	Results []struct {
+		Packages []*osvPackage `json:"packages"`
+	} `json:"results"`
+}
+
+// osvPackage represents a package with vulnerabilities found by OSV-Scanner.
+type osvPackage struct {
+	Package struct {
+		Name      string `json:"name"`
+		Version   string `json:"version"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+	Vulnerabilities []*osvVulnerability `json:"vulnerabilities"`
+	Groups          []struct {
+		IDs         []string `json:"ids"`
+		MaxSeverity string   `json:"max_severity"`
+	} `json:"groups"`
+}
+
+// osvVulnerability represents a vulnerability in the OSV format.
+type osvVulnerability struct {
+	ID       string `json:"id"`
+	Summary  string `json:"summary"`
+	Details  string `json:"details"`
+	Affected []struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Events []map[string]string `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific"`
+	References       []struct {
+		URL string `json:"url"`
+	} `json:"references"`
+}
+
+// normalizeOSVReport converts the OSV-Scanner report provided into a security
+// report that follows the schema of the reports generated by Trivy. The
+// vulnerabilities are grouped by the ecosystem of the affected packages.
+// Vulnerabilities that are aliases of each other are reported once.
+func normalizeOSVReport(image string, data []byte) ([]byte, error) {
+	var report osvReport
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("error unmarshalling osv-scanner report: %w", err)
+		}
+	}
+
+	var targets []*Target
+	targetsByEcosystem := make(map[string]*Target)
+	for _, r := range report.Results {
+		for _, p := range r.Packages {
+			target := targetsByEcosystem[p.Package.Ecosystem]
+			if target == nil {
+				target = &Target{
+					Target: fmt.Sprintf("%s (%s)", image, p.Package.Ecosystem),
+					Type:   strings.ToLower(strings.SplitN(p.Package.Ecosystem, ":", 2)[0]),
+				}
+				targetsByEcosystem[p.Package.Ecosystem] = target
+				targets = append(targets, target)
+			}
+			target.Vulnerabilities = append(target.Vulnerabilities, p.toVulnerabilities()...)
+		}
+	}
+	if len(targets) == 0 {
+		targets = []*Target{{Target: image}}
+	}
+
+	return json.Marshal(targets)
+}
+
+// toVulnerabilities returns the security report vulnerabilities found in the
+// package, one per group of vulnerabilities that are aliases of each other.
+func (p *osvPackage) toVulnerabilities() []*Vulnerability {
+	vulnerabilitiesByID := make(map[string]*osvVulnerability, len(p.Vulnerabilities))
+	for _, v := range p.Vulnerabilities {
+		vulnerabilitiesByID[v.ID] = v
+	}
+
+	var vulnerabilities []*Vulnerability
+	processed := make(map[string]struct{})
+	for _, g := range p.Groups {
+		var primary *osvVulnerability
+		for _, id := range g.IDs {
+			processed[id] = struct{}{}
+			v, ok := vulnerabilitiesByID[id]
+			if !ok {
+				continue
+			}
+			if primary == nil || (strings.HasPrefix(id, "CVE-") && !strings.HasPrefix(primary.ID, "CVE-")) {
+				primary = v
+			}
+		}
+		if primary == nil {
+			continue
+		}
+		vulnerabilities = append(vulnerabilities, p.toVulnerability(primary, g.MaxSeverity))
+	}
+	for _, v := range p.Vulnerabilities {
+		if _, ok := processed[v.ID]; !ok {
+			vulnerabilities = append(vulnerabilities, p.toVulnerability(v, ""))
+		}
+	}
+	return vulnerabilities
+}
+
+// toVulnerability returns a security report vulnerability from the OSV
+// vulnerability provided. The severity is obtained from the group's maximum
+// CVSS score when available, or from the database specific severity
+// otherwise.
+func (p *osvPackage) toVulnerability(ov *osvVulnerability, maxSeverity string) *Vulnerability {
+	v := &Vulnerability{
+		VulnerabilityID:  ov.ID,
+		PkgName:          p.Package.Name,
+		InstalledVersion: p.Package.Version,
+		Title:            ov.Summary,
+		Description:      ov.Details,
+		Severity:         "UNKNOWN",
+		PrimaryURL:       "https://osv.dev/vulnerability/" + ov.ID,
+	}
+	if score, err := strconv.ParseFloat(maxSeverity, 64); err == nil {
+		v.Severity = severityFromCVSSScore(score)
+	} else if severity, ok := ov.DatabaseSpecific["severity"].(string); ok {
+		v.Severity = normalizeSeverity(severity)
+	}
+	for _, a := range ov.Affected {
+		if a.Package.Name != p.Package.Name {
+			continue
+		}
+		for _, r := range a.Ranges {
+			for _, e := range r.Events {
+				if fixed, ok := e["fixed"]; ok && v.FixedVersion == "" {
+					v.FixedVersion = fixed
+				}
+			}
+		}
+	}
+	for _, s := range ov.Severity {
+		switch s.Type {
+		case "CVSS_V3":
+			v.CVSS = map[string]*CVSS{"osv": {V3Vector: s.Score}}
+		case "CVSS_V2":
+			if v.CVSS == nil {
+				v.CVSS = map[string]*CVSS{"osv": {V2Vector: s.Score}}
+			}
+		}
+	}
+	for _, r := range ov.References {
+		v.References = append(v.References, r.URL)
+	}
+	return v
+}
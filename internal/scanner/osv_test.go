@@ -0,0 +1,120 @@
+package scanner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeOSVReport(t *testing.T) {
+	image := "repo/image:tag"
+
+	t.Run("invalid report", func(t *testing.T) {
+		t.Parallel()
+		_, err := normalizeOSVReport(image, []byte("{"))
+		assert.Error(t, err)
+	})
+
+	t.Run("no packages found", func(t *testing.T) {
+		t.Parallel()
+		data, err := normalizeOSVReport(image, nil)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"Target": "repo/image:tag", "Vulnerabilities": null}]`, string(data))
+	})
+
+	t.Run("report with vulnerabilities", func(t *testing.T) {
+		t.Parallel()
+		data, err := normalizeOSVReport(image, sampleOSVReportData)
+		require.NoError(t, err)
+		var targets []*Target
+		require.NoError(t, json.Unmarshal(data, &targets))
+		assert.Equal(t, []*Target{
+			{
+				Target: "repo/image:tag (Alpine:v3.13)",
+				Type:   "alpine",
+				Vulnerabilities: []*Vulnerability{
+					{
+						VulnerabilityID:  "CVE-2021-3711",
+						PkgName:          "openssl",
+						InstalledVersion: "1.1.1k-r0",
+						FixedVersion:     "1.1.1l-r0",
+						Description:      "SM2 decryption buffer overflow",
+						Severity:         "CRITICAL",
+						PrimaryURL:       "https://osv.dev/vulnerability/CVE-2021-3711",
+						CVSS: map[string]*CVSS{
+							"osv": {V3Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+						},
+					},
+				},
+			},
+			{
+				Target: "repo/image:tag (Go)",
+				Type:   "go",
+				Vulnerabilities: []*Vulnerability{
+					{
+						VulnerabilityID:  "GHSA-h86h-8ppg-mxmh",
+						PkgName:          "github.com/gogo/protobuf",
+						InstalledVersion: "1.3.1",
+						FixedVersion:     "1.3.2",
+						Title:            "Improper Input Validation in GoGo Protobuf",
+						Severity:         "HIGH",
+						PrimaryURL:       "https://osv.dev/vulnerability/GHSA-h86h-8ppg-mxmh",
+						References:       []string{"https://github.com/gogo/protobuf/commit/b03c65ea87cdc3521ede29f62fe3ce239267c1bc"},
+					},
+				},
+			},
+		}, targets)
+	})
+}
+
+var sampleOSVReportData = []byte(`
+{
+  "results": [
+    {
+      "source": {"path": "repo/image:tag", "type": "docker"},
+      "packages": [
+        {
+          "package": {"name": "openssl", "version": "1.1.1k-r0", "ecosystem": "Alpine:v3.13"},
+          "vulnerabilities": [
+            {
+              "id": "ALPINE-CVE-2021-3711",
+              "details": "SM2 decryption buffer overflow"
+            },
+            {
+              "id": "CVE-2021-3711",
+              "details": "SM2 decryption buffer overflow",
+              "affected": [
+                {
+                  "package": {"name": "openssl"},
+                  "ranges": [{"events": [{"introduced": "0"}, {"fixed": "1.1.1l-r0"}]}]
+                }
+              ],
+              "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}]
+            }
+          ],
+          "groups": [{"ids": ["ALPINE-CVE-2021-3711", "CVE-2021-3711"], "max_severity": "9.8"}]
+        },
+        {
+          "package": {"name": "github.com/gogo/protobuf", "version": "1.3.1", "ecosystem": "Go"},
+          "vulnerabilities": [
+            {
+              "id": "GHSA-h86h-8ppg-mxmh",
+              "summary": "Improper Input Validation in GoGo Protobuf",
+              "affected": [
+                {
+                  "package": {"name": "github.com/gogo/protobuf"},
+                  "ranges": [{"events": [{"introduced": "0"}, {"fixed": "1.3.2"}]}]
+                }
+              ],
+              "database_specific": {"severity": "HIGH"},
+              "references": [{"url": "https://github.com/gogo/protobuf/commit/b03c65ea87cdc3521ede29f62fe3ce239267c1bc"}]
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}
+`)
@@ -5,15 +5,61 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/spf13/viper"
 )
 
-// Scanner describes the methods a Scanner implementation must provide.
+// ErrInvalidSeverity indicates that the severity provided is not valid.
+var ErrInvalidSeverity = errors.New("invalid severity")
+
+// Scanners backends supported.
+const (
+	Trivy = "trivy"
+	Grype = "grype"
+	OSV   = "osv"
+)
+
+// Scanner describes the methods a Scanner implementation must provide. Scan
+// returns the security report of the image provided, which must follow the
+// schema of the reports generated by Trivy (a list of targets).
 type Scanner interface {
 	Scan(image string) ([]byte, error)
 }
 
+// New creates a new Scanner instance for the backend selected in the
+// configuration provided (scanner.backend), checking that the external tool
+// it relies on is available.
+func New(ctx context.Context, cfg *viper.Viper) (Scanner, error) {
+	backend := cfg.GetString("scanner.backend")
+	if backend == "" {
+		backend = Trivy
+	}
+	var s Scanner
+	var tool string
+	switch backend {
+	case Trivy:
+		trivyURL := cfg.GetString("scanner.trivyURL")
+		if trivyURL == "" {
+			return nil, errors.New("trivy url not set")
+		}
+		s, tool = &TrivyScanner{Ctx: ctx, Cfg: cfg, URL: trivyURL}, "trivy"
+	case Grype:
+		s, tool = &GrypeScanner{Ctx: ctx, Cfg: cfg}, "grype"
+	case OSV:
+		s, tool = &OSVScanner{Ctx: ctx}, "osv-scanner"
+	default:
+		return nil, fmt.Errorf("invalid scanner backend: %s", backend)
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil, fmt.Errorf("%s not found: %w", tool, err)
+	}
+	return s, nil
+}
+
 // ScanSnapshot scans the provided package's snapshot for security
 // vulnerabilities returning a report with the results.
 func ScanSnapshot(
@@ -29,12 +75,21 @@ func ScanSnapshot(
 		reportData, err := scanner.Scan(image.Image)
 		if err != nil {
 			if errors.Is(err, ErrImageNotFound) {
-				err := fmt.Sprintf("%s: %s (package %s:%s)", err.Error(), image.Image, s.PackageName, s.Version)
-				ec.Append(s.RepositoryID, err)
+				ec.Append(s.RepositoryID, &hub.RepositoryError{
+					PackageName:    s.PackageName,
+					PackageVersion: s.Version,
+					Class:          hub.RepositoryErrorClassScanning,
+					Message:        fmt.Sprintf("%s: %s (package %s:%s)", err.Error(), image.Image, s.PackageName, s.Version),
+				})
 				continue
 			}
 			err := fmt.Errorf("error scanning image %s: %w (package %s:%s)", image.Image, err, s.PackageName, s.Version)
-			ec.Append(s.RepositoryID, err.Error())
+			ec.Append(s.RepositoryID, &hub.RepositoryError{
+				PackageName:    s.PackageName,
+				PackageVersion: s.Version,
+				Class:          hub.RepositoryErrorClassScanning,
+				Message:        err.Error(),
+			})
 			return nil, err
 		}
 		var imageFullReport []interface{}
@@ -47,6 +102,7 @@ func ScanSnapshot(
 	}
 	var summary *hub.SecurityReportSummary
 	if len(full) > 0 {
+		suppressVulnerabilities(full, s.SuppressedVulnerabilities)
 		summary = generateSummary(full)
 	} else {
 		full = nil
@@ -60,8 +116,42 @@ func ScanSnapshot(
 	}, nil
 }
 
+// suppressVulnerabilities marks the vulnerabilities in the full security
+// report provided that have been suppressed by the package's publisher. They
+// are kept in the report, along with the justification provided, but they are
+// not taken into account in the summary or the vulnerabilities alerts.
+func suppressVulnerabilities(full map[string][]interface{}, suppressions []*hub.VulnerabilitySuppression) {
+	if len(suppressions) == 0 {
+		return
+	}
+	justifications := make(map[string]string, len(suppressions))
+	for _, s := range suppressions {
+		justifications[s.VulnerabilityID] = s.Justification
+	}
+	for _, targets := range full {
+		for _, entry := range targets {
+			target, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			vulnerabilities, _ := target["Vulnerabilities"].([]interface{})
+			for _, entry := range vulnerabilities {
+				v, ok := entry.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				id, _ := v["VulnerabilityID"].(string)
+				if justification, ok := justifications[id]; ok {
+					v["Suppressed"] = true
+					v["SuppressionJustification"] = justification
+				}
+			}
+		}
+	}
+}
+
 // generateSummary generates a summary of the security report from the full
-// report
+// report. Suppressed vulnerabilities are not included in the summary.
 func generateSummary(full map[string][]interface{}) *hub.SecurityReportSummary {
 	summary := &hub.SecurityReportSummary{}
 	for _, targets := range full {
@@ -75,6 +165,9 @@ func generateSummary(full map[string][]interface{}) *hub.SecurityReportSummary {
 				continue
 			}
 			for _, vulnerability := range target.Vulnerabilities {
+				if vulnerability.Suppressed {
+					continue
+				}
 				switch vulnerability.Severity {
 				case "CRITICAL":
 					summary.Critical++
@@ -93,12 +186,175 @@ func generateSummary(full map[string][]interface{}) *hub.SecurityReportSummary {
 	return summary
 }
 
-// Target represents a target in a security report.
+// ValidateSeverity checks if the severity provided is valid.
+func ValidateSeverity(severity string) error {
+	if _, ok := hub.SeverityLevel(severity); !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidSeverity, severity)
+	}
+	return nil
+}
+
+// normalizeSeverity returns the severity provided using one of the severity
+// levels of the security reports.
+func normalizeSeverity(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return "CRITICAL"
+	case "HIGH", "IMPORTANT":
+		return "HIGH"
+	case "MEDIUM", "MODERATE":
+		return "MEDIUM"
+	case "LOW", "NEGLIGIBLE":
+		return "LOW"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// severityFromCVSSScore returns the severity level that corresponds to the
+// CVSS base score provided.
+func severityFromCVSSScore(score float64) string {
+	switch {
+	case score >= 9:
+		return "CRITICAL"
+	case score >= 7:
+		return "HIGH"
+	case score >= 4:
+		return "MEDIUM"
+	case score > 0:
+		return "LOW"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SetNewVulnerabilities compares the report provided with the previous
+// security report of the same package's snapshot, setting in the report the
+// vulnerabilities that were not present in the previous one and whose severity
+// is equal or greater than the threshold provided. Suppressed vulnerabilities
+// are never considered new. Nothing is set when the
+// snapshot had not been scanned before, as the first report is used as the
+// baseline to detect new vulnerabilities in subsequent scans.
+func SetNewVulnerabilities(
+	ctx context.Context,
+	pm hub.PackageManager,
+	r *hub.SnapshotSecurityReport,
+	threshold string,
+) error {
+	if err := ValidateSeverity(threshold); err != nil {
+		return err
+	}
+	minLevel, _ := hub.SeverityLevel(threshold)
+	if r == nil || len(r.Full) == 0 {
+		return nil
+	}
+	previousJSON, err := pm.GetSnapshotSecurityReportJSON(ctx, r.PackageID, r.Version)
+	if err != nil {
+		return fmt.Errorf("error getting previous security report: %w", err)
+	}
+	if len(previousJSON) == 0 || string(previousJSON) == "null" {
+		return nil
+	}
+	var previous map[string][]interface{}
+	if err := json.Unmarshal(previousJSON, &previous); err != nil {
+		return fmt.Errorf("error unmarshalling previous security report: %w", err)
+	}
+
+	known := make(map[string]struct{})
+	for _, v := range getVulnerabilities(previous) {
+		known[v.key()] = struct{}{}
+	}
+	r.NewVulnerabilities = nil
+	for _, v := range getVulnerabilities(r.Full) {
+		if level, _ := hub.SeverityLevel(v.Severity); v.Suppressed || level < minLevel {
+			continue
+		}
+		if _, ok := known[v.key()]; ok {
+			continue
+		}
+		known[v.key()] = struct{}{}
+		r.NewVulnerabilities = append(r.NewVulnerabilities, &hub.Vulnerability{
+			ID:               v.VulnerabilityID,
+			Severity:         v.Severity,
+			Image:            v.image,
+			PkgName:          v.PkgName,
+			InstalledVersion: v.InstalledVersion,
+			FixedVersion:     v.FixedVersion,
+			Title:            v.Title,
+		})
+	}
+	return nil
+}
+
+// getVulnerabilities returns all the vulnerabilities in the full security
+// report provided, sorted by image.
+func getVulnerabilities(full map[string][]interface{}) []*Vulnerability {
+	images := make([]string, 0, len(full))
+	for image := range full {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+
+	var vulnerabilities []*Vulnerability
+	for _, image := range images {
+		for _, entry := range full[image] {
+			var target *Target
+			entryJSON, err := json.Marshal(entry)
+			if err != nil {
+				continue
+			}
+			if err := json.Unmarshal(entryJSON, &target); err != nil || target == nil {
+				continue
+			}
+			for _, v := range target.Vulnerabilities {
+				v.image = image
+				vulnerabilities = append(vulnerabilities, v)
+			}
+		}
+	}
+	return vulnerabilities
+}
+
+// Target represents a target in a security report. The security reports
+// follow the schema of the reports generated by Trivy, so the reports of other
+// scanners backends are normalized into it.
 type Target struct {
+	Target          string           `json:"Target,omitempty"`
+	Type            string           `json:"Type,omitempty"`
 	Vulnerabilities []*Vulnerability `json:"Vulnerabilities"`
 }
 
 // Vulnerability represents a vulnerability in a security report target.
 type Vulnerability struct {
-	Severity string `json:"Severity"`
+	VulnerabilityID  string           `json:"VulnerabilityID"`
+	PkgName          string           `json:"PkgName"`
+	InstalledVersion string           `json:"InstalledVersion"`
+	FixedVersion     string           `json:"FixedVersion,omitempty"`
+	Title            string           `json:"Title,omitempty"`
+	Description      string           `json:"Description,omitempty"`
+	Severity         string           `json:"Severity"`
+	PrimaryURL       string           `json:"PrimaryURL,omitempty"`
+	References       []string         `json:"References,omitempty"`
+	CVSS             map[string]*CVSS `json:"CVSS,omitempty"`
+
+	// Suppressed vulnerabilities have been marked by the package's publisher
+	// as not applicable to the package.
+	Suppressed               bool   `json:"Suppressed,omitempty"`
+	SuppressionJustification string `json:"SuppressionJustification,omitempty"`
+
+	image string
+}
+
+// CVSS represents the CVSS vectors and scores of a vulnerability provided by
+// a given source.
+type CVSS struct {
+	V2Vector string  `json:"V2Vector,omitempty"`
+	V3Vector string  `json:"V3Vector,omitempty"`
+	V2Score  float64 `json:"V2Score,omitempty"`
+	V3Score  float64 `json:"V3Score,omitempty"`
+}
+
+// key returns a key that identifies the vulnerability in a given image.
+func (v *Vulnerability) key() string {
+	return v.image + "#" + v.PkgName + "#" + v.VulnerabilityID
 }
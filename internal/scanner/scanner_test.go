@@ -7,12 +7,34 @@ import (
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid backend", func(t *testing.T) {
+		t.Parallel()
+		cfg := viper.New()
+		cfg.Set("scanner.backend", "invalid")
+		s, err := New(ctx, cfg)
+		assert.EqualError(t, err, "invalid scanner backend: invalid")
+		assert.Nil(t, s)
+	})
+
+	t.Run("trivy url not set", func(t *testing.T) {
+		t.Parallel()
+		s, err := New(ctx, viper.New())
+		assert.EqualError(t, err, "trivy url not set")
+		assert.Nil(t, s)
+	})
+}
+
 func TestScanSnapshot(t *testing.T) {
 	ctx := context.Background()
 	repositoryID := "00000000-0000-0000-0000-000000000001"
@@ -27,7 +49,12 @@ func TestScanSnapshot(t *testing.T) {
 		scannerMock.On("Scan", image).Return(nil, tests.ErrFake)
 		ecMock := &repo.ErrorsCollectorMock{}
 		ecMock.On("Init", repositoryID)
-		ecMock.On("Append", repositoryID, "error scanning image repo/image:tag: fake error for tests (package pkg1:1.0.0)")
+		ecMock.On("Append", repositoryID, &hub.RepositoryError{
+			PackageName:    packageName,
+			PackageVersion: version,
+			Class:          hub.RepositoryErrorClassScanning,
+			Message:        "error scanning image repo/image:tag: fake error for tests (package pkg1:1.0.0)",
+		})
 
 		snapshot := &hub.SnapshotToScan{
 			RepositoryID: repositoryID,
@@ -53,7 +80,12 @@ func TestScanSnapshot(t *testing.T) {
 		scannerMock.On("Scan", image).Return(nil, ErrImageNotFound)
 		ecMock := &repo.ErrorsCollectorMock{}
 		ecMock.On("Init", repositoryID)
-		ecMock.On("Append", repositoryID, "image not found: repo/image:tag (package pkg1:1.0.0)")
+		ecMock.On("Append", repositoryID, &hub.RepositoryError{
+			PackageName:    packageName,
+			PackageVersion: version,
+			Class:          hub.RepositoryErrorClassScanning,
+			Message:        "image not found: repo/image:tag (package pkg1:1.0.0)",
+		})
 
 		snapshot := &hub.SnapshotToScan{
 			RepositoryID: repositoryID,
@@ -140,6 +172,188 @@ func TestScanSnapshot(t *testing.T) {
 		scannerMock.AssertExpectations(t)
 		ecMock.AssertExpectations(t)
 	})
+
+	t.Run("suppressed vulnerabilities excluded from summary", func(t *testing.T) {
+		t.Parallel()
+		scannerMock := &Mock{}
+		scannerMock.On("Scan", image).Return(sampleReportData, nil)
+		ecMock := &repo.ErrorsCollectorMock{}
+		ecMock.On("Init", repositoryID)
+
+		snapshot := &hub.SnapshotToScan{
+			RepositoryID: repositoryID,
+			PackageID:    packageID,
+			PackageName:  packageName,
+			Version:      version,
+			ContainersImages: []*hub.ContainerImage{
+				{
+					Image: image,
+				},
+			},
+			SuppressedVulnerabilities: []*hub.VulnerabilitySuppression{
+				{
+					VulnerabilityID: "CVE-2020-13822",
+					Justification:   "Vulnerable code not used",
+				},
+			},
+		}
+		report, err := ScanSnapshot(ctx, scannerMock, snapshot, ecMock)
+		require.Nil(t, err)
+		assert.Equal(t, &hub.SecurityReportSummary{
+			High:   7,
+			Medium: 1,
+		}, report.Summary)
+		vulnerabilities := getVulnerabilities(report.Full)
+		require.Len(t, vulnerabilities, 9)
+		assert.Equal(t, "CVE-2020-13822", vulnerabilities[0].VulnerabilityID)
+		assert.True(t, vulnerabilities[0].Suppressed)
+		assert.Equal(t, "Vulnerable code not used", vulnerabilities[0].SuppressionJustification)
+		for _, v := range vulnerabilities[1:] {
+			assert.False(t, v.Suppressed)
+		}
+		scannerMock.AssertExpectations(t)
+		ecMock.AssertExpectations(t)
+	})
+}
+
+func TestSetNewVulnerabilities(t *testing.T) {
+	ctx := context.Background()
+	packageID := "00000000-0000-0000-0000-000000000001"
+	version := "1.0.0"
+	image := "repo/image:tag"
+	var imageFullReport []interface{}
+	require.NoError(t, json.Unmarshal(sampleReportData, &imageFullReport))
+	newReport := func() *hub.SnapshotSecurityReport {
+		return &hub.SnapshotSecurityReport{
+			PackageID: packageID,
+			Version:   version,
+			Full: map[string][]interface{}{
+				image: imageFullReport,
+			},
+		}
+	}
+
+	t.Run("invalid severity threshold", func(t *testing.T) {
+		t.Parallel()
+		err := SetNewVulnerabilities(ctx, nil, newReport(), "invalid")
+		assert.True(t, errors.Is(err, ErrInvalidSeverity))
+	})
+
+	t.Run("report without vulnerabilities", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		r := &hub.SnapshotSecurityReport{PackageID: packageID, Version: version}
+
+		err := SetNewVulnerabilities(ctx, pm, r, "high")
+		assert.NoError(t, err)
+		assert.Nil(t, r.NewVulnerabilities)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("error getting previous report", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotSecurityReportJSON", ctx, packageID, version).Return(nil, tests.ErrFakeDB)
+		r := newReport()
+
+		err := SetNewVulnerabilities(ctx, pm, r, "high")
+		assert.True(t, errors.Is(err, tests.ErrFakeDB))
+		assert.Nil(t, r.NewVulnerabilities)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("snapshot not scanned before", func(t *testing.T) {
+		t.Parallel()
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotSecurityReportJSON", ctx, packageID, version).Return(nil, nil)
+		r := newReport()
+
+		err := SetNewVulnerabilities(ctx, pm, r, "high")
+		assert.NoError(t, err)
+		assert.Nil(t, r.NewVulnerabilities)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("no new vulnerabilities found", func(t *testing.T) {
+		t.Parallel()
+		previousJSON, _ := json.Marshal(newReport().Full)
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotSecurityReportJSON", ctx, packageID, version).Return(previousJSON, nil)
+		r := newReport()
+
+		err := SetNewVulnerabilities(ctx, pm, r, "low")
+		assert.NoError(t, err)
+		assert.Nil(t, r.NewVulnerabilities)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("new vulnerabilities above threshold found", func(t *testing.T) {
+		t.Parallel()
+		previousJSON := []byte(`{
+			"repo/image:tag": [{
+				"Target": "home/hub/web/yarn.lock",
+				"Vulnerabilities": [
+					{"VulnerabilityID": "CVE-2020-13822", "PkgName": "elliptic", "Severity": "HIGH"},
+					{"VulnerabilityID": "GHSA-6x33-pw7p-hmpq", "PkgName": "http-proxy", "Severity": "HIGH"},
+					{"VulnerabilityID": "CVE-2020-8203", "PkgName": "lodash", "Severity": "HIGH"},
+					{"VulnerabilityID": "NSWG-ECO-516", "PkgName": "lodash", "Severity": "HIGH"},
+					{"VulnerabilityID": "CVE-2020-7720", "PkgName": "node-forge", "Severity": "HIGH"},
+					{"VulnerabilityID": "CVE-2020-15256", "PkgName": "object-path", "Severity": "HIGH"}
+				]
+			}]
+		}`)
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotSecurityReportJSON", ctx, packageID, version).Return(previousJSON, nil)
+		r := newReport()
+
+		err := SetNewVulnerabilities(ctx, pm, r, "high")
+		assert.NoError(t, err)
+		require.Len(t, r.NewVulnerabilities, 2)
+		assert.Equal(t, "CVE-2020-7660", r.NewVulnerabilities[0].ID)
+		assert.Equal(t, "HIGH", r.NewVulnerabilities[0].Severity)
+		assert.Equal(t, image, r.NewVulnerabilities[0].Image)
+		assert.Equal(t, "serialize-javascript", r.NewVulnerabilities[0].PkgName)
+		assert.Equal(t, "CVE-2020-7662", r.NewVulnerabilities[1].ID)
+		assert.Equal(t, "websocket-extensions", r.NewVulnerabilities[1].PkgName)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("suppressed vulnerabilities are not considered new", func(t *testing.T) {
+		t.Parallel()
+		previousJSON := []byte(`{"repo/image:tag": []}`)
+		pm := &pkg.ManagerMock{}
+		pm.On("GetSnapshotSecurityReportJSON", ctx, packageID, version).Return(previousJSON, nil)
+		r := &hub.SnapshotSecurityReport{
+			PackageID: packageID,
+			Version:   version,
+			Full: map[string][]interface{}{
+				image: {
+					map[string]interface{}{
+						"Target": "home/hub/web/yarn.lock",
+						"Vulnerabilities": []interface{}{
+							map[string]interface{}{
+								"VulnerabilityID": "CVE-2020-13822",
+								"PkgName":         "elliptic",
+								"Severity":        "HIGH",
+								"Suppressed":      true,
+							},
+							map[string]interface{}{
+								"VulnerabilityID": "CVE-2020-7660",
+								"PkgName":         "serialize-javascript",
+								"Severity":        "HIGH",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		err := SetNewVulnerabilities(ctx, pm, r, "high")
+		assert.NoError(t, err)
+		require.Len(t, r.NewVulnerabilities, 1)
+		assert.Equal(t, "CVE-2020-7660", r.NewVulnerabilities[0].ID)
+		pm.AssertExpectations(t)
+	})
 }
 
 var sampleReportData = []byte(`
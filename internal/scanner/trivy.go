@@ -13,9 +13,22 @@ import (
 	"github.com/spf13/viper"
 )
 
-// ErrImageNotFound represents that the image provided was not found in the
-// repository.
-var ErrImageNotFound = errors.New("image not found")
+var (
+	// ErrImageNotFound represents that the image provided was not found in the
+	// repository.
+	ErrImageNotFound = errors.New("image not found")
+
+	// proxyEnvVars represents the environment variables used to configure the
+	// proxy used by trivy when pulling images.
+	proxyEnvVars = []string{
+		"HTTP_PROXY",
+		"HTTPS_PROXY",
+		"NO_PROXY",
+		"http_proxy",
+		"https_proxy",
+		"no_proxy",
+	}
+)
 
 // TrivyScanner is an implementation of the Scanner interface that uses Trivy.
 type TrivyScanner struct {
@@ -31,22 +44,15 @@ func (s *TrivyScanner) Scan(image string) ([]byte, error) {
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
-	// clean environment
-	cmd.Env = []string{
-		"PATH=" + os.Getenv("PATH"),
-		"USER=" + os.Getenv("USER"),
-		"HOME=" + os.Getenv("HOME"),
-		"TRIVY_CACHE_DIR=" + os.Getenv("TRIVY_CACHE_DIR"),
-	}
+	cmd.Env = commandEnv("TRIVY_CACHE_DIR")
 
 	// If the registry is the Docker Hub, include credentials to avoid rate
-	// limiting issues. Empty registry names will also match this check as the
-	// registry name will be set to index.docker.io when parsing the reference.
-	ref, err := name.ParseReference(image)
+	// limiting issues
+	isDockerHub, err := isDockerHubImage(image)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing image %s ref: %w", image, err)
+		return nil, err
 	}
-	if strings.HasSuffix(ref.Context().Registry.Name(), "docker.io") {
+	if isDockerHub {
 		cmd.Env = append(cmd.Env,
 			"TRIVY_USERNAME="+s.Cfg.GetString("creds.dockerUsername"),
 			"TRIVY_PASSWORD="+s.Cfg.GetString("creds.dockerPassword"),
@@ -62,3 +68,32 @@ func (s *TrivyScanner) Scan(image string) ([]byte, error) {
 	}
 	return stdout.Bytes(), nil
 }
+
+// commandEnv returns a clean environment to run the scanners commands. Only
+// the basic variables, the proxy settings of the deployment (if any), as
+// images may have to be pulled through a proxy, and the extra variables
+// provided (when set) are kept.
+func commandEnv(extraVars ...string) []string {
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"USER=" + os.Getenv("USER"),
+		"HOME=" + os.Getenv("HOME"),
+	}
+	for _, envVar := range append(proxyEnvVars, extraVars...) {
+		if value, ok := os.LookupEnv(envVar); ok {
+			env = append(env, envVar+"="+value)
+		}
+	}
+	return env
+}
+
+// isDockerHubImage checks if the image provided is hosted in the Docker Hub.
+// Empty registry names will also match this check as the registry name will
+// be set to index.docker.io when parsing the reference.
+func isDockerHubImage(image string) (bool, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return false, fmt.Errorf("error parsing image %s ref: %w", image, err)
+	}
+	return strings.HasSuffix(ref.Context().Registry.Name(), "docker.io"), nil
+}
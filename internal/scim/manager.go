@@ -0,0 +1,549 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgconn"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addGroupMembersDBQ    = `select add_scim_group_members($1::uuid, $2::uuid[])`
+	deleteGroupMembersDBQ = `select delete_scim_group_members($1::uuid, $2::uuid[])`
+	deleteUserDBQ         = `select delete_user($1::uuid)`
+	getGroupDBQ           = `select get_scim_group($1::uuid)`
+	getGroupsDBQ          = `select get_scim_groups($1::jsonb)`
+	getUserDBQ            = `select get_scim_user($1::uuid)`
+	getUsersDBQ           = `select get_scim_users($1::jsonb)`
+	registerUserDBQ       = `select register_scim_user($1::jsonb)`
+	updateUserDBQ         = `select update_scim_user($1::uuid, $2::jsonb)`
+
+	// maxCount represents the maximum number of resources that can be
+	// requested at once.
+	maxCount = 100
+
+	// uniqueViolationCode represents the error code returned by the database
+	// when a unique constraint is violated.
+	uniqueViolationCode = "23505"
+)
+
+var (
+	// ErrUniqueness indicates that the resource provided conflicts with an
+	// existing one.
+	ErrUniqueness = errors.New("resource already exists")
+
+	// errLastMemberDB represents the error returned from the database when
+	// all members of an organization are about to be deleted.
+	errLastMemberDB = errors.New("ERROR: last member of an organization cannot leave it (SQLSTATE P0001)")
+
+	// filterRE represents a regular expression used to parse the filters
+	// supported (attribute eq "value").
+	filterRE = regexp.MustCompile(`(?i)^\s*([a-z.]+)\s+eq\s+"([^"]*)"\s*$`)
+
+	// memberPathRE represents a regular expression used to extract the id of
+	// the member referenced in a patch operation path.
+	memberPathRE = regexp.MustCompile(`(?i)^members\[value\s+eq\s+"([^"]*)"\]$`)
+)
+
+// Manager provides an API to manage the users and organizations memberships
+// provisioned by SCIM clients (identity providers).
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// DeleteUser deletes the provided user from the database.
+func (m *Manager) DeleteUser(ctx context.Context, userID string) error {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return hub.ErrNotFound
+	}
+
+	// Delete user from database
+	_, err := m.db.Exec(ctx, deleteUserDBQ, userID)
+	return translateDBError(err)
+}
+
+// GetGroupJSON returns the requested group as a json object.
+func (m *Manager) GetGroupJSON(ctx context.Context, groupID string) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(groupID); err != nil {
+		return nil, hub.ErrNotFound
+	}
+
+	// Get group from database
+	return util.DBQueryJSON(ctx, m.db, getGroupDBQ, groupID)
+}
+
+// GetGroupsJSON returns the groups matching the input provided as a json
+// object.
+func (m *Manager) GetGroupsJSON(ctx context.Context, input *hub.SCIMListInput) ([]byte, error) {
+	// Validate input
+	query, err := buildListQuery(input, map[string]string{
+		"displayname": "name",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get groups from database
+	queryJSON, _ := json.Marshal(query)
+	return util.DBQueryJSON(ctx, m.db, getGroupsDBQ, queryJSON)
+}
+
+// GetUserJSON returns the requested user as a json object.
+func (m *Manager) GetUserJSON(ctx context.Context, userID string) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return nil, hub.ErrNotFound
+	}
+
+	// Get user from database
+	return util.DBQueryJSON(ctx, m.db, getUserDBQ, userID)
+}
+
+// GetUsersJSON returns the users matching the input provided as a json object.
+func (m *Manager) GetUsersJSON(ctx context.Context, input *hub.SCIMListInput) ([]byte, error) {
+	// Validate input
+	query, err := buildListQuery(input, map[string]string{
+		"username":     "email",
+		"emails.value": "email",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get users from database
+	queryJSON, _ := json.Marshal(query)
+	return util.DBQueryJSON(ctx, m.db, getUsersDBQ, queryJSON)
+}
+
+// PatchGroup applies the patch operations provided to the group members,
+// returning the updated group as a json object.
+func (m *Manager) PatchGroup(ctx context.Context, groupID string, op *hub.SCIMPatchOp) ([]byte, error) {
+	// Get current group members
+	current, err := m.getGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply patch operations to the current members list
+	members := make([]string, len(current))
+	copy(members, current)
+	for _, o := range op.Operations {
+		members, err = applyGroupPatchOperation(members, o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Update group members in database
+	return m.updateGroupMembers(ctx, groupID, current, members)
+}
+
+// PatchUser applies the patch operations provided to the user, returning the
+// updated user as a json object.
+func (m *Manager) PatchUser(ctx context.Context, userID string, op *hub.SCIMPatchOp) ([]byte, error) {
+	// Get current user
+	userJSON, err := m.GetUserJSON(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	u := &hub.SCIMUser{}
+	if err := json.Unmarshal(userJSON, &u); err != nil {
+		return nil, err
+	}
+
+	// Apply patch operations to the current user
+	for _, o := range op.Operations {
+		if err := applyUserPatchOperation(u, o); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update user in database
+	return m.UpdateUser(ctx, userID, u)
+}
+
+// RegisterUser registers the user provided in the database, returning the
+// registered user as a json object.
+func (m *Manager) RegisterUser(ctx context.Context, u *hub.SCIMUser) ([]byte, error) {
+	// Validate input
+	info, err := buildUserInfo(u)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register user in database
+	infoJSON, _ := json.Marshal(info)
+	var userID string
+	if err := m.db.QueryRow(ctx, registerUserDBQ, infoJSON).Scan(&userID); err != nil {
+		return nil, translateDBError(err)
+	}
+	return m.GetUserJSON(ctx, userID)
+}
+
+// UpdateGroup replaces the group members with the ones provided, returning
+// the updated group as a json object. Groups names are managed by Artifact
+// Hub, so the display name provided is ignored.
+func (m *Manager) UpdateGroup(ctx context.Context, groupID string, g *hub.SCIMGroup) ([]byte, error) {
+	// Get current group members
+	current, err := m.getGroupMembers(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update group members in database
+	members := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		members = appendUnique(members, member.Value)
+	}
+	return m.updateGroupMembers(ctx, groupID, current, members)
+}
+
+// UpdateUser updates the user provided in the database, returning the updated
+// user as a json object.
+func (m *Manager) UpdateUser(ctx context.Context, userID string, u *hub.SCIMUser) ([]byte, error) {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return nil, hub.ErrNotFound
+	}
+	info, err := buildUserInfo(u)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update user in database
+	infoJSON, _ := json.Marshal(info)
+	if _, err := m.db.Exec(ctx, updateUserDBQ, userID, infoJSON); err != nil {
+		return nil, translateDBError(err)
+	}
+	return m.GetUserJSON(ctx, userID)
+}
+
+// getGroupMembers returns the ids of the members of the provided group.
+func (m *Manager) getGroupMembers(ctx context.Context, groupID string) ([]string, error) {
+	groupJSON, err := m.GetGroupJSON(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	g := &hub.SCIMGroup{}
+	if err := json.Unmarshal(groupJSON, &g); err != nil {
+		return nil, err
+	}
+	members := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		members = append(members, member.Value)
+	}
+	return members, nil
+}
+
+// updateGroupMembers updates the members of the provided group in the
+// database so that they match the members list provided. New members are
+// added before deleting the old ones, so that the organization is never left
+// without members.
+func (m *Manager) updateGroupMembers(ctx context.Context, groupID string, current, members []string) ([]byte, error) {
+	// Validate input
+	for _, member := range members {
+		if _, err := uuid.FromString(member); err != nil {
+			return nil, fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid member", member)
+		}
+	}
+
+	// Add new members and delete the ones not in the list anymore
+	membersToAdd := difference(members, current)
+	membersToDelete := difference(current, members)
+	if len(membersToAdd) > 0 {
+		if _, err := m.db.Exec(ctx, addGroupMembersDBQ, groupID, membersToAdd); err != nil {
+			return nil, translateDBError(err)
+		}
+	}
+	if len(membersToDelete) > 0 {
+		if _, err := m.db.Exec(ctx, deleteGroupMembersDBQ, groupID, membersToDelete); err != nil {
+			return nil, translateDBError(err)
+		}
+	}
+
+	return m.GetGroupJSON(ctx, groupID)
+}
+
+// listQuery represents the query used to list users or groups from the
+// database.
+type listQuery struct {
+	Email  string `json:"email,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// buildListQuery builds a list query from the input provided. The attributes
+// that can be used in filters are provided as a map, where the values are
+// the fields of the list query they are mapped to.
+func buildListQuery(input *hub.SCIMListInput, filterAttrs map[string]string) (*listQuery, error) {
+	q := &listQuery{
+		Limit:  input.Count,
+		Offset: input.StartIndex - 1,
+	}
+	if q.Limit < 0 {
+		q.Limit = 0
+	}
+	if q.Limit > maxCount {
+		q.Limit = maxCount
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	if input.Filter != "" {
+		matches := filterRE.FindStringSubmatch(input.Filter)
+		if matches == nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "unsupported filter")
+		}
+		switch filterAttrs[strings.ToLower(matches[1])] {
+		case "email":
+			q.Email = matches[2]
+		case "name":
+			q.Name = matches[2]
+		default:
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "unsupported filter attribute")
+		}
+	}
+	return q, nil
+}
+
+// userInfo represents the details of a user stored in the database.
+type userInfo struct {
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Disabled  bool   `json:"disabled"`
+}
+
+// buildUserInfo builds the user details to store in the database from the
+// SCIM user provided. The user name is used as email when it looks like one,
+// otherwise the primary email is used.
+func buildUserInfo(u *hub.SCIMUser) (*userInfo, error) {
+	info := &userInfo{}
+	if strings.Contains(u.UserName, "@") {
+		info.Email = u.UserName
+	} else {
+		for _, e := range u.Emails {
+			if info.Email == "" || e.Primary {
+				info.Email = e.Value
+			}
+		}
+	}
+	if info.Email == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "email not provided")
+	}
+	if u.Name != nil {
+		info.FirstName = u.Name.GivenName
+		info.LastName = u.Name.FamilyName
+	}
+	if u.Active != nil {
+		info.Disabled = !*u.Active
+	}
+	return info, nil
+}
+
+// applyUserPatchOperation applies the patch operation provided to the user.
+// Operations on attributes not supported are ignored.
+func applyUserPatchOperation(u *hub.SCIMUser, o *hub.SCIMPatchOperation) error {
+	switch strings.ToLower(o.Op) {
+	case "add", "replace":
+		if o.Path != "" {
+			return setUserAttribute(u, o.Path, o.Value)
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(o.Value, &values); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid operation value")
+		}
+		for path, value := range values {
+			if err := setUserAttribute(u, path, value); err != nil {
+				return err
+			}
+		}
+	case "remove":
+		switch strings.ToLower(o.Path) {
+		case "name":
+			u.Name = nil
+		case "name.givenname":
+			if u.Name != nil {
+				u.Name.GivenName = ""
+			}
+		case "name.familyname":
+			if u.Name != nil {
+				u.Name.FamilyName = ""
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid operation", o.Op)
+	}
+	return nil
+}
+
+// setUserAttribute sets the value of the user attribute at the path provided.
+func setUserAttribute(u *hub.SCIMUser, path string, value json.RawMessage) error {
+	var err error
+	path = strings.ToLower(path)
+	switch {
+	case path == "username":
+		err = json.Unmarshal(value, &u.UserName)
+	case path == "active":
+		var active bool
+		active, err = parseBool(value)
+		u.Active = &active
+	case path == "name":
+		err = json.Unmarshal(value, &u.Name)
+	case path == "name.givenname", path == "name.familyname":
+		if u.Name == nil {
+			u.Name = &hub.SCIMName{}
+		}
+		if path == "name.givenname" {
+			err = json.Unmarshal(value, &u.Name.GivenName)
+		} else {
+			err = json.Unmarshal(value, &u.Name.FamilyName)
+		}
+	case strings.HasPrefix(path, "emails"):
+		var email string
+		if json.Unmarshal(value, &email) == nil {
+			u.Emails = []*hub.SCIMEmail{{Value: email, Primary: true}}
+		} else {
+			err = json.Unmarshal(value, &u.Emails)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid value for attribute", path)
+	}
+	return nil
+}
+
+// parseBool parses the boolean value provided. Some SCIM clients send boolean
+// values as strings, so both representations are supported.
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(strings.ToLower(s))
+}
+
+// applyGroupPatchOperation applies the patch operation provided to the group
+// members list, returning the resulting list. Operations on attributes other
+// than members are ignored.
+func applyGroupPatchOperation(members []string, o *hub.SCIMPatchOperation) ([]string, error) {
+	// Extract members provided in the operation value
+	var value struct {
+		Members []*hub.SCIMGroupMember `json:"members"`
+	}
+	path := strings.ToLower(o.Path)
+	switch {
+	case path == "members":
+		if len(o.Value) > 0 && string(o.Value) != "null" {
+			if err := json.Unmarshal(o.Value, &value.Members); err != nil {
+				return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid operation value")
+			}
+		}
+	case path == "":
+		if err := json.Unmarshal(o.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid operation value")
+		}
+		if value.Members == nil {
+			return members, nil
+		}
+	case memberPathRE.MatchString(o.Path):
+		value.Members = []*hub.SCIMGroupMember{{Value: memberPathRE.FindStringSubmatch(o.Path)[1]}}
+	default:
+		return members, nil
+	}
+	ids := make([]string, 0, len(value.Members))
+	for _, member := range value.Members {
+		ids = append(ids, member.Value)
+	}
+
+	// Apply operation
+	switch strings.ToLower(o.Op) {
+	case "add":
+		for _, id := range ids {
+			members = appendUnique(members, id)
+		}
+	case "remove":
+		if path == "members" && len(ids) == 0 {
+			return []string{}, nil
+		}
+		members = difference(members, ids)
+	case "replace":
+		members = make([]string, 0, len(ids))
+		for _, id := range ids {
+			members = appendUnique(members, id)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid operation", o.Op)
+	}
+	return members, nil
+}
+
+// appendUnique appends the value provided to the list if it's not already in
+// it.
+func appendUnique(list []string, value string) []string {
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// difference returns the elements in a that are not in b.
+func difference(a, b []string) []string {
+	result := make([]string, 0, len(a))
+	for _, v := range a {
+		found := false
+		for _, w := range b {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// translateDBError translates the errors returned by the database into the
+// errors returned by the manager.
+func translateDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode:
+		return ErrUniqueness
+	case err.Error() == util.ErrDBNotFound.Error():
+		return hub.ErrNotFound
+	case err.Error() == errLastMemberDB.Error():
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "last member of an organization cannot leave it")
+	}
+	return err
+}
@@ -0,0 +1,389 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userID  = "00000000-0000-0000-0000-000000000001"
+	user2ID = "00000000-0000-0000-0000-000000000002"
+	user3ID = "00000000-0000-0000-0000-000000000003"
+	groupID = "00000000-0000-0000-0000-000000000001"
+)
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.DeleteUser(ctx, "invalid")
+		assert.Equal(t, hub.ErrNotFound, err)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteUserDBQ, userID).Return(util.ErrDBNotFound)
+		m := NewManager(db)
+
+		err := m.DeleteUser(ctx, userID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user deleted successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteUserDBQ, userID).Return(nil)
+		m := NewManager(db)
+
+		err := m.DeleteUser(ctx, userID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetGroupsJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			filter string
+		}{
+			{
+				"unsupported filter",
+				`displayName co "org"`,
+			},
+			{
+				"unsupported filter attribute",
+				`userName eq "org1"`,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetGroupsJSON(ctx, &hub.SCIMListInput{Filter: tc.filter, StartIndex: 1, Count: 10})
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("groups data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupsDBQ, []byte(`{"name":"org1","limit":100,"offset":1}`)).
+			Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetGroupsJSON(ctx, &hub.SCIMListInput{
+			Filter:     `displayName eq "org1"`,
+			StartIndex: 2,
+			Count:      1000,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetUserJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetUserJSON(ctx, "invalid")
+		assert.Equal(t, hub.ErrNotFound, err)
+	})
+
+	t.Run("user data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetUserJSON(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetUsersJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetUsersJSON(ctx, &hub.SCIMListInput{Filter: `userName sw "user"`})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUsersDBQ, []byte(`{"limit":10,"offset":0}`)).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetUsersJSON(ctx, &hub.SCIMListInput{StartIndex: 1, Count: 10})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("users data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUsersDBQ, []byte(`{"email":"user1@email.com","limit":10,"offset":0}`)).
+			Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetUsersJSON(ctx, &hub.SCIMListInput{
+			Filter:     `userName eq "user1@email.com"`,
+			StartIndex: 1,
+			Count:      10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestPatchGroup(t *testing.T) {
+	ctx := context.Background()
+	groupJSON := []byte(`{"id": "` + groupID + `", "members": [{"value": "` + userID + `"}, {"value": "` + user2ID + `"}]}`)
+
+	t.Run("group not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(nil, util.ErrDBNotFound)
+		m := NewManager(db)
+
+		_, err := m.PatchGroup(ctx, groupID, &hub.SCIMPatchOp{})
+		assert.Error(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		m := NewManager(db)
+
+		_, err := m.PatchGroup(ctx, groupID, &hub.SCIMPatchOp{
+			Operations: []*hub.SCIMPatchOperation{
+				{Op: "move", Path: "members", Value: json.RawMessage(`[]`)},
+			},
+		})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("last member cannot leave the organization", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		db.On("Exec", ctx, deleteGroupMembersDBQ, groupID, []string{userID, user2ID}).Return(errLastMemberDB)
+		m := NewManager(db)
+
+		_, err := m.PatchGroup(ctx, groupID, &hub.SCIMPatchOp{
+			Operations: []*hub.SCIMPatchOperation{
+				{Op: "remove", Path: "members"},
+			},
+		})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("group members patched successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		db.On("Exec", ctx, addGroupMembersDBQ, groupID, []string{user3ID}).Return(nil)
+		db.On("Exec", ctx, deleteGroupMembersDBQ, groupID, []string{user2ID}).Return(nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.PatchGroup(ctx, groupID, &hub.SCIMPatchOp{
+			Operations: []*hub.SCIMPatchOperation{
+				{Op: "Add", Path: "members", Value: json.RawMessage(`[{"value": "` + user3ID + `"}]`)},
+				{Op: "Remove", Path: `members[value eq "` + user2ID + `"]`},
+				{Op: "Replace", Value: json.RawMessage(`{"displayName": "org1"}`)},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, groupJSON, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestPatchUser(t *testing.T) {
+	ctx := context.Background()
+	userJSON := []byte(`{
+		"id": "` + userID + `",
+		"userName": "user1@email.com",
+		"name": {"givenName": "first_name"},
+		"emails": [{"value": "user1@email.com", "primary": true}],
+		"active": true
+	}`)
+
+	t.Run("user not found", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(nil, util.ErrDBNotFound)
+		m := NewManager(db)
+
+		_, err := m.PatchUser(ctx, userID, &hub.SCIMPatchOp{})
+		assert.Error(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid attribute value", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		m := NewManager(db)
+
+		_, err := m.PatchUser(ctx, userID, &hub.SCIMPatchOp{
+			Operations: []*hub.SCIMPatchOperation{
+				{Op: "replace", Path: "active", Value: json.RawMessage(`"maybe"`)},
+			},
+		})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user patched successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return(userJSON, nil)
+		db.On("Exec", ctx, updateUserDBQ, userID, []byte(`{"email":"user1@email.com","first_name":"","last_name":"last_name","disabled":true}`)).
+			Return(nil)
+		m := NewManager(db)
+
+		_, err := m.PatchUser(ctx, userID, &hub.SCIMPatchOp{
+			Operations: []*hub.SCIMPatchOperation{
+				{Op: "Replace", Path: "active", Value: json.RawMessage(`"False"`)},
+				{Op: "Add", Value: json.RawMessage(`{"name.familyName": "last_name"}`)},
+				{Op: "Remove", Path: "name.givenName"},
+			},
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegisterUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("email not provided", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.RegisterUser(ctx, &hub.SCIMUser{UserName: "user1"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("user already exists", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerUserDBQ, []byte(`{"email":"user1@email.com","first_name":"","last_name":"","disabled":false}`)).
+			Return(nil, &pgconn.PgError{Code: uniqueViolationCode})
+		m := NewManager(db)
+
+		_, err := m.RegisterUser(ctx, &hub.SCIMUser{UserName: "user1@email.com"})
+		assert.Equal(t, ErrUniqueness, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("user registered successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerUserDBQ, []byte(`{"email":"user1@email.com","first_name":"first_name","last_name":"","disabled":true}`)).
+			Return(userID, nil)
+		db.On("QueryRow", ctx, getUserDBQ, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		active := false
+		dataJSON, err := m.RegisterUser(ctx, &hub.SCIMUser{
+			UserName: "user1",
+			Name:     &hub.SCIMName{GivenName: "first_name"},
+			Emails: []*hub.SCIMEmail{
+				{Value: "other@email.com"},
+				{Value: "user1@email.com", Primary: true},
+			},
+			Active: &active,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdateGroup(t *testing.T) {
+	ctx := context.Background()
+	groupJSON := []byte(`{"id": "` + groupID + `", "members": [{"value": "` + userID + `"}]}`)
+
+	t.Run("invalid member", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		m := NewManager(db)
+
+		_, err := m.UpdateGroup(ctx, groupID, &hub.SCIMGroup{
+			Members: []*hub.SCIMGroupMember{{Value: "invalid"}},
+		})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
+	t.Run("group members replaced successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getGroupDBQ, groupID).Return(groupJSON, nil)
+		db.On("Exec", ctx, addGroupMembersDBQ, groupID, []string{user2ID}).Return(nil)
+		db.On("Exec", ctx, deleteGroupMembersDBQ, groupID, []string{userID}).Return(nil)
+		m := NewManager(db)
+
+		_, err := m.UpdateGroup(ctx, groupID, &hub.SCIMGroup{
+			Members: []*hub.SCIMGroupMember{{Value: user2ID}},
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid user id", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.UpdateUser(ctx, "invalid", &hub.SCIMUser{UserName: "user1@email.com"})
+		assert.Equal(t, hub.ErrNotFound, err)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserDBQ, userID, []byte(`{"email":"user1@email.com","first_name":"","last_name":"","disabled":false}`)).
+			Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		_, err := m.UpdateUser(ctx, userID, &hub.SCIMUser{UserName: "user1@email.com"})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,82 @@
+package scim
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the SCIMManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// DeleteUser implements the SCIMManager interface.
+func (m *ManagerMock) DeleteUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// GetGroupJSON implements the SCIMManager interface.
+func (m *ManagerMock) GetGroupJSON(ctx context.Context, groupID string) ([]byte, error) {
+	args := m.Called(ctx, groupID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetGroupsJSON implements the SCIMManager interface.
+func (m *ManagerMock) GetGroupsJSON(ctx context.Context, input *hub.SCIMListInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetUserJSON implements the SCIMManager interface.
+func (m *ManagerMock) GetUserJSON(ctx context.Context, userID string) ([]byte, error) {
+	args := m.Called(ctx, userID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetUsersJSON implements the SCIMManager interface.
+func (m *ManagerMock) GetUsersJSON(ctx context.Context, input *hub.SCIMListInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// PatchGroup implements the SCIMManager interface.
+func (m *ManagerMock) PatchGroup(ctx context.Context, groupID string, op *hub.SCIMPatchOp) ([]byte, error) {
+	args := m.Called(ctx, groupID, op)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// PatchUser implements the SCIMManager interface.
+func (m *ManagerMock) PatchUser(ctx context.Context, userID string, op *hub.SCIMPatchOp) ([]byte, error) {
+	args := m.Called(ctx, userID, op)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// RegisterUser implements the SCIMManager interface.
+func (m *ManagerMock) RegisterUser(ctx context.Context, u *hub.SCIMUser) ([]byte, error) {
+	args := m.Called(ctx, u)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// UpdateGroup implements the SCIMManager interface.
+func (m *ManagerMock) UpdateGroup(ctx context.Context, groupID string, g *hub.SCIMGroup) ([]byte, error) {
+	args := m.Called(ctx, groupID, g)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// UpdateUser implements the SCIMManager interface.
+func (m *ManagerMock) UpdateUser(ctx context.Context, userID string, u *hub.SCIMUser) ([]byte, error) {
+	args := m.Called(ctx, userID, u)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
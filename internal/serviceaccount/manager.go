@@ -0,0 +1,227 @@
+package serviceaccount
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/satori/uuid"
+)
+
+const (
+	// Database queries
+	addServiceAccountDBQ     = `select add_service_account($1::uuid, $2::text, $3::text)`
+	addAPIKeyDBQ             = `select add_service_account_api_key($1::uuid, $2::text, $3::uuid, $4::jsonb)`
+	deleteServiceAccountDBQ  = `select delete_service_account($1::uuid, $2::text, $3::uuid)`
+	deleteAPIKeyDBQ          = `select delete_service_account_api_key($1::uuid, $2::text, $3::uuid, $4::uuid)`
+	getOrgServiceAccountsDBQ = `select get_org_service_accounts($1::uuid, $2::text)`
+)
+
+var (
+	// ErrNameNotAvailable indicates that the service account name provided is
+	// already being used.
+	ErrNameNotAvailable = errors.New("service account name not available")
+
+	// errNameNotAvailableDB represents the error returned from the database
+	// when the service account name provided is not available.
+	errNameNotAvailableDB = errors.New("ERROR: service account name not available (SQLSTATE P0001)")
+
+	// serviceAccountNameRE is a regexp used to validate a service account name.
+	serviceAccountNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+)
+
+// Manager provides an API to manage the service accounts of an organization.
+type Manager struct {
+	db hub.DB
+	az hub.Authorizer
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, az hub.Authorizer) *Manager {
+	return &Manager{
+		db: db,
+		az: az,
+	}
+}
+
+// Add adds a new service account to the provided organization. The user doing
+// the request must be allowed to add members to the organization.
+func (m *Manager) Add(ctx context.Context, orgName string, sa *hub.ServiceAccount) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if sa.Name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if !serviceAccountNameRE.MatchString(sa.Name) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid name")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationMember,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Add service account to database
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, addServiceAccountDBQ, userID, orgName, sa.Name)
+	if err != nil {
+		return nil, translateDBError(err)
+	}
+	return dataJSON, nil
+}
+
+// AddAPIKey adds an api key to the provided service account. Service accounts
+// api keys must be restricted to some scopes.
+func (m *Manager) AddAPIKey(
+	ctx context.Context,
+	orgName,
+	serviceAccountID string,
+	ak *hub.APIKey,
+) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if _, err := uuid.FromString(serviceAccountID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid service account id")
+	}
+	if ak.Name == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if len(ak.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "scopes not provided")
+	}
+	for _, scope := range ak.Scopes {
+		if !isValidScope(scope) {
+			return nil, fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid scope", scope)
+		}
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.AddOrganizationMember,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Add service account api key to database
+	akJSON, _ := json.Marshal(ak)
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, addAPIKeyDBQ, userID, orgName, serviceAccountID, akJSON)
+	if err != nil {
+		return nil, translateDBError(err)
+	}
+	return dataJSON, nil
+}
+
+// Delete deletes the provided service account, as well as its api keys. The
+// user doing the request must be allowed to delete members from the
+// organization.
+func (m *Manager) Delete(ctx context.Context, orgName, serviceAccountID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if _, err := uuid.FromString(serviceAccountID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid service account id")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.DeleteOrganizationMember,
+	}); err != nil {
+		return err
+	}
+
+	// Delete service account from database
+	_, err := m.db.Exec(ctx, deleteServiceAccountDBQ, userID, orgName, serviceAccountID)
+	return translateDBError(err)
+}
+
+// DeleteAPIKey deletes the provided api key from the service account.
+func (m *Manager) DeleteAPIKey(ctx context.Context, orgName, serviceAccountID, apiKeyID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if _, err := uuid.FromString(serviceAccountID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid service account id")
+	}
+	if _, err := uuid.FromString(apiKeyID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid api key id")
+	}
+
+	// Authorize action
+	if err := m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           userID,
+		Action:           hub.DeleteOrganizationMember,
+	}); err != nil {
+		return err
+	}
+
+	// Delete service account api key from database
+	_, err := m.db.Exec(ctx, deleteAPIKeyDBQ, userID, orgName, serviceAccountID, apiKeyID)
+	return translateDBError(err)
+}
+
+// GetByOrgJSON returns the service accounts of the provided organization as a
+// json array. The user doing the request must belong to the organization.
+func (m *Manager) GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization service accounts from database
+	return util.DBQueryJSON(ctx, m.db, getOrgServiceAccountsDBQ, userID, orgName)
+}
+
+// isValidScope checks if the provided api key scope is valid.
+func isValidScope(scope hub.APIKeyScope) bool {
+	for _, validScope := range hub.APIKeyScopes {
+		if scope == validScope {
+			return true
+		}
+	}
+	return false
+}
+
+// translateDBError translates the errors returned by the database into the
+// errors returned by the manager.
+func translateDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err.Error() {
+	case util.ErrDBInsufficientPrivilege.Error():
+		return hub.ErrInsufficientPrivilege
+	case util.ErrDBNotFound.Error():
+		return hub.ErrNotFound
+	case errNameNotAvailableDB.Error():
+		return ErrNameNotAvailable
+	}
+	return err
+}
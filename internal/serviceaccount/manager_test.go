@@ -0,0 +1,530 @@
+package serviceaccount
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	saID     = "00000000-0000-0000-0000-000000000001"
+	apiKeyID = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	azInput := &hub.AuthorizeInput{
+		OrganizationName: "org1",
+		UserID:           "userID",
+		Action:           hub.AddOrganizationMember,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.Add(context.Background(), "org1", &hub.ServiceAccount{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			sa      *hub.ServiceAccount
+		}{
+			{
+				"organization name not provided",
+				"",
+				&hub.ServiceAccount{Name: "ci"},
+			},
+			{
+				"name not provided",
+				"org1",
+				&hub.ServiceAccount{},
+			},
+			{
+				"invalid name",
+				"org1",
+				&hub.ServiceAccount{Name: "_invalid.name"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				_, err := m.Add(ctx, tc.orgName, tc.sa)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		_, err := m.Add(ctx, "org1", &hub.ServiceAccount{Name: "ci"})
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errNameNotAvailableDB,
+				ErrNameNotAvailable,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, addServiceAccountDBQ, "userID", "org1", "ci").Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, azInput).Return(nil)
+				m := NewManager(db, az)
+
+				dataJSON, err := m.Add(ctx, "org1", &hub.ServiceAccount{Name: "ci"})
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addServiceAccountDBQ, "userID", "org1", "ci").Return([]byte("dataJSON"), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		dataJSON, err := m.Add(ctx, "org1", &hub.ServiceAccount{Name: "ci"})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestAddAPIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	azInput := &hub.AuthorizeInput{
+		OrganizationName: "org1",
+		UserID:           "userID",
+		Action:           hub.AddOrganizationMember,
+	}
+	ak := &hub.APIKey{
+		Name:   "apikey1",
+		Scopes: []hub.APIKeyScope{hub.APIKeyScopeReposWrite},
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.AddAPIKey(context.Background(), "org1", saID, ak)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg           string
+			orgName          string
+			serviceAccountID string
+			ak               *hub.APIKey
+		}{
+			{
+				"organization name not provided",
+				"",
+				saID,
+				ak,
+			},
+			{
+				"invalid service account id",
+				"org1",
+				"invalid",
+				ak,
+			},
+			{
+				"name not provided",
+				"org1",
+				saID,
+				&hub.APIKey{Scopes: []hub.APIKeyScope{hub.APIKeyScopeReposWrite}},
+			},
+			{
+				"scopes not provided",
+				"org1",
+				saID,
+				&hub.APIKey{Name: "apikey1"},
+			},
+			{
+				"invalid scope",
+				"org1",
+				saID,
+				&hub.APIKey{Name: "apikey1", Scopes: []hub.APIKeyScope{"invalid"}},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				_, err := m.AddAPIKey(ctx, tc.orgName, tc.serviceAccountID, tc.ak)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		_, err := m.AddAPIKey(ctx, "org1", saID, ak)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, addAPIKeyDBQ, "userID", "org1", saID, []byte(`{"api_key_id":"","name":"apikey1","created_at":0,"user_id":"","scopes":["repos:write"]}`)).
+					Return(nil, tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, azInput).Return(nil)
+				m := NewManager(db, az)
+
+				dataJSON, err := m.AddAPIKey(ctx, "org1", saID, ak)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, addAPIKeyDBQ, "userID", "org1", saID, []byte(`{"api_key_id":"","name":"apikey1","created_at":0,"user_id":"","scopes":["repos:write"]}`)).
+			Return([]byte("dataJSON"), nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		dataJSON, err := m.AddAPIKey(ctx, "org1", saID, ak)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	azInput := &hub.AuthorizeInput{
+		OrganizationName: "org1",
+		UserID:           "userID",
+		Action:           hub.DeleteOrganizationMember,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), "org1", saID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg           string
+			orgName          string
+			serviceAccountID string
+		}{
+			{
+				"organization name not provided",
+				"",
+				saID,
+			},
+			{
+				"invalid service account id",
+				"org1",
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.Delete(ctx, tc.orgName, tc.serviceAccountID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.Delete(ctx, "org1", saID)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteServiceAccountDBQ, "userID", "org1", saID).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, azInput).Return(nil)
+				m := NewManager(db, az)
+
+				err := m.Delete(ctx, "org1", saID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteServiceAccountDBQ, "userID", "org1", saID).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.Delete(ctx, "org1", saID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	azInput := &hub.AuthorizeInput{
+		OrganizationName: "org1",
+		UserID:           "userID",
+		Action:           hub.DeleteOrganizationMember,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteAPIKey(context.Background(), "org1", saID, apiKeyID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg           string
+			orgName          string
+			serviceAccountID string
+			apiKeyID         string
+		}{
+			{
+				"organization name not provided",
+				"",
+				saID,
+				apiKeyID,
+			},
+			{
+				"invalid service account id",
+				"org1",
+				"invalid",
+				apiKeyID,
+			},
+			{
+				"invalid api key id",
+				"org1",
+				saID,
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.DeleteAPIKey(ctx, tc.orgName, tc.serviceAccountID, tc.apiKeyID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.DeleteAPIKey(ctx, "org1", saID, apiKeyID)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteAPIKeyDBQ, "userID", "org1", saID, apiKeyID).Return(util.ErrDBNotFound)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.DeleteAPIKey(ctx, "org1", saID, apiKeyID)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteAPIKeyDBQ, "userID", "org1", saID, apiKeyID).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.DeleteAPIKey(ctx, "org1", saID, apiKeyID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByOrgJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.GetByOrgJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getOrgServiceAccountsDBQ, "userID", "org1").Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				dataJSON, err := m.GetByOrgJSON(ctx, "org1")
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgServiceAccountsDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetByOrgJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,32 @@
+package sitemap
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the SitemapManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// GetEntries implements the SitemapManager interface.
+func (m *ManagerMock) GetEntries(
+	ctx context.Context,
+	section string,
+	limit,
+	offset int,
+) ([]*hub.SitemapEntry, error) {
+	args := m.Called(ctx, section, limit, offset)
+	entries, _ := args.Get(0).([]*hub.SitemapEntry)
+	return entries, args.Error(1)
+}
+
+// GetSections implements the SitemapManager interface.
+func (m *ManagerMock) GetSections(ctx context.Context) ([]*hub.SitemapSection, error) {
+	args := m.Called(ctx)
+	sections, _ := args.Get(0).([]*hub.SitemapSection)
+	return sections, args.Error(1)
+}
@@ -0,0 +1,125 @@
+package sitemap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	getSitemapEntriesDBQ  = `select get_sitemap_entries($1::text, $2::int, $3::int)`
+	getSitemapSectionsDBQ = `select get_sitemap_sections()`
+
+	// Sections
+	packagesSection     = "packages"
+	publishersSection   = "publishers"
+	repositoriesSection = "repositories"
+)
+
+// entry represents a sitemap entry as returned by the database.
+type entry struct {
+	RepositoryKindID *hub.RepositoryKind `json:"repository_kind_id"`
+	RepositoryName   string              `json:"repository_name"`
+	PackageName      string              `json:"package_name"`
+	OrganizationName string              `json:"organization_name"`
+	UserAlias        string              `json:"user_alias"`
+	LastModified     int64               `json:"last_modified"`
+}
+
+// Manager provides an API to get the information needed to build the sitemap.
+type Manager struct {
+	db hub.DB
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB) *Manager {
+	return &Manager{
+		db: db,
+	}
+}
+
+// GetEntries returns the entries of the sitemap section provided, paginated
+// using the limit and offset provided.
+func (m *Manager) GetEntries(
+	ctx context.Context,
+	section string,
+	limit,
+	offset int,
+) ([]*hub.SitemapEntry, error) {
+	// Validate input
+	switch section {
+	case packagesSection, publishersSection, repositoriesSection:
+	default:
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid section")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid limit")
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset")
+	}
+
+	// Get entries from database
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getSitemapEntriesDBQ, section, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	var dbEntries []*entry
+	if err := json.Unmarshal(dataJSON, &dbEntries); err != nil {
+		return nil, err
+	}
+
+	// Build sitemap entries
+	entries := make([]*hub.SitemapEntry, 0, len(dbEntries))
+	for _, e := range dbEntries {
+		entries = append(entries, &hub.SitemapEntry{
+			Path:         buildPath(section, e),
+			LastModified: e.LastModified,
+		})
+	}
+	return entries, nil
+}
+
+// GetSections returns the sections available in the sitemap.
+func (m *Manager) GetSections(ctx context.Context) ([]*hub.SitemapSection, error) {
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getSitemapSectionsDBQ)
+	if err != nil {
+		return nil, err
+	}
+	var sections []*hub.SitemapSection
+	if err := json.Unmarshal(dataJSON, &sections); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// buildPath builds the path of the web page related to the sitemap entry
+// provided.
+func buildPath(section string, e *entry) string {
+	switch section {
+	case packagesSection:
+		var kind hub.RepositoryKind
+		if e.RepositoryKindID != nil {
+			kind = *e.RepositoryKindID
+		}
+		return fmt.Sprintf("/packages/%s/%s/%s",
+			hub.GetKindName(kind),
+			url.PathEscape(e.RepositoryName),
+			url.PathEscape(e.PackageName),
+		)
+	case repositoriesSection:
+		return "/packages/search?repo=" + url.QueryEscape(e.RepositoryName)
+	case publishersSection:
+		if e.OrganizationName != "" {
+			return "/packages/search?org=" + url.QueryEscape(e.OrganizationName)
+		}
+		return "/packages/search?user=" + url.QueryEscape(e.UserAlias)
+	default:
+		return ""
+	}
+}
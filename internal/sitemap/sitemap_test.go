@@ -0,0 +1,129 @@
+package sitemap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEntries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			section string
+			limit   int
+			offset  int
+		}{
+			{"invalid section", "unknown", 10, 0},
+			{"invalid limit", packagesSection, 0, 0},
+			{"invalid offset", packagesSection, 10, -1},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				entries, err := m.GetEntries(ctx, tc.section, tc.limit, tc.offset)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+				assert.Nil(t, entries)
+			})
+		}
+	})
+
+	t.Run("entries returned successfully", func(t *testing.T) {
+		testCases := []struct {
+			section         string
+			dataJSON        string
+			expectedEntries []*hub.SitemapEntry
+		}{
+			{
+				packagesSection,
+				`[{"repository_kind_id": 0, "repository_name": "repo1", "package_name": "pkg1", "last_modified": 1592472034}]`,
+				[]*hub.SitemapEntry{{Path: "/packages/helm/repo1/pkg1", LastModified: 1592472034}},
+			},
+			{
+				repositoriesSection,
+				`[{"repository_name": "repo1", "last_modified": 1592472034}]`,
+				[]*hub.SitemapEntry{{Path: "/packages/search?repo=repo1", LastModified: 1592472034}},
+			},
+			{
+				publishersSection,
+				`[{"organization_name": "org1", "last_modified": 1592472034}, {"user_alias": "user1"}]`,
+				[]*hub.SitemapEntry{
+					{Path: "/packages/search?org=org1", LastModified: 1592472034},
+					{Path: "/packages/search?user=user1"},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.section, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getSitemapEntriesDBQ, tc.section, 10, 20).Return([]byte(tc.dataJSON), nil)
+				m := NewManager(db)
+
+				entries, err := m.GetEntries(ctx, tc.section, 10, 20)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedEntries, entries)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSitemapEntriesDBQ, packagesSection, 10, 0).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		entries, err := m.GetEntries(ctx, packagesSection, 10, 0)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, entries)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetSections(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSitemapSectionsDBQ).Return([]byte(`
+		[
+			{"name": "packages", "total": 2, "last_modified": 1592472034},
+			{"name": "repositories", "total": 0}
+		]
+		`), nil)
+		m := NewManager(db)
+
+		sections, err := m.GetSections(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.SitemapSection{
+			{Name: "packages", Total: 2, LastModified: 1592472034},
+			{Name: "repositories", Total: 0},
+		}, sections)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getSitemapSectionsDBQ).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		sections, err := m.GetSections(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, sections)
+		db.AssertExpectations(t)
+	})
+}
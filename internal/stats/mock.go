@@ -3,6 +3,7 @@ package stats
 import (
 	"context"
 
+	"github.com/artifacthub/hub/internal/hub"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -17,3 +18,10 @@ func (m *ManagerMock) GetJSON(ctx context.Context) ([]byte, error) {
 	data, _ := args.Get(0).([]byte)
 	return data, args.Error(1)
 }
+
+// GetSeriesJSON implements the StatsManager interface.
+func (m *ManagerMock) GetSeriesJSON(ctx context.Context, input *hub.GetStatsSeriesInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
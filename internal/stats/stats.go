@@ -2,6 +2,9 @@ package stats
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
@@ -9,9 +12,33 @@ import (
 
 const (
 	// Database queries
-	getStatsDBQ = `select get_stats()`
+	getStatsDBQ       = `select get_stats()`
+	getStatsSeriesDBQ = `select get_stats_series($1::jsonb)`
+
+	// DefaultSeriesInterval represents the interval used to bucket the time
+	// series when none is provided.
+	DefaultSeriesInterval = "month"
+
+	dateLayout = "2006-01-02"
 )
 
+// validMetrics represents the metrics available as a time series.
+var validMetrics = map[string]struct{}{
+	"packages":      {},
+	"repositories":  {},
+	"organizations": {},
+	"users":         {},
+	"releases":      {},
+}
+
+// maxSeriesRange represents the maximum range that can be requested for each
+// of the intervals supported, to keep the number of data points bounded.
+var maxSeriesRange = map[string]time.Duration{
+	"day":   366 * 24 * time.Hour,
+	"week":  5 * 366 * 24 * time.Hour,
+	"month": 20 * 366 * 24 * time.Hour,
+}
+
 // Manager provides an API to manage stats.
 type Manager struct {
 	db hub.DB
@@ -28,3 +55,48 @@ func NewManager(db hub.DB) *Manager {
 func (m *Manager) GetJSON(ctx context.Context) ([]byte, error) {
 	return util.DBQueryJSON(ctx, m.db, getStatsDBQ)
 }
+
+// GetSeriesJSON returns the time series of the metric provided as a json
+// object built by the database. When no range is provided, the last year is
+// returned.
+func (m *Manager) GetSeriesJSON(ctx context.Context, input *hub.GetStatsSeriesInput) ([]byte, error) {
+	// Validate input
+	if _, ok := validMetrics[input.Metric]; !ok {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid metric")
+	}
+	if input.Interval == "" {
+		input.Interval = DefaultSeriesInterval
+	}
+	maxRange, ok := maxSeriesRange[input.Interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid interval")
+	}
+	to := time.Now().UTC()
+	if input.To != "" {
+		var err error
+		to, err = time.Parse(dateLayout, input.To)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid to date")
+		}
+	}
+	from := to.AddDate(-1, 0, 0)
+	if input.From != "" {
+		var err error
+		from, err = time.Parse(dateLayout, input.From)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid from date")
+		}
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "from date must not be after to date")
+	}
+	if to.Sub(from) > maxRange {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "range too large for the interval selected")
+	}
+	input.From = from.Format(dateLayout)
+	input.To = to.Format(dateLayout)
+
+	// Get stats series from database
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, getStatsSeriesDBQ, inputJSON)
+}
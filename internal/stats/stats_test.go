@@ -2,8 +2,10 @@ package stats
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,3 +37,101 @@ func TestGetJSON(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 }
+
+func TestGetSeriesJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.GetStatsSeriesInput
+		}{
+			{
+				"invalid metric",
+				&hub.GetStatsSeriesInput{Metric: "invalid"},
+			},
+			{
+				"invalid interval",
+				&hub.GetStatsSeriesInput{Metric: "packages", Interval: "hour"},
+			},
+			{
+				"invalid to date",
+				&hub.GetStatsSeriesInput{Metric: "packages", To: "01/05/2021"},
+			},
+			{
+				"invalid from date",
+				&hub.GetStatsSeriesInput{Metric: "packages", From: "01/05/2021"},
+			},
+			{
+				"from date must not be after to date",
+				&hub.GetStatsSeriesInput{Metric: "packages", From: "2021-05-02", To: "2021-05-01"},
+			},
+			{
+				"range too large for the interval selected",
+				&hub.GetStatsSeriesInput{Metric: "packages", Interval: "day", From: "2019-01-01", To: "2021-05-01"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetSeriesJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded (default interval)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getStatsSeriesDBQ, []byte(`{"metric":"releases","interval":"month","from":"2020-05-01","to":"2021-05-01"}`)).
+			Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSeriesJSON(ctx, &hub.GetStatsSeriesInput{
+			Metric: "releases",
+			From:   "2020-05-01",
+			To:     "2021-05-01",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (default range)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getStatsSeriesDBQ, []byte(`{"metric":"users","interval":"week","from":"2020-05-01","to":"2021-05-01"}`)).
+			Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSeriesJSON(ctx, &hub.GetStatsSeriesInput{
+			Metric:   "users",
+			Interval: "week",
+			To:       "2021-05-01",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getStatsSeriesDBQ, []byte(`{"metric":"packages","interval":"day","from":"2021-04-01","to":"2021-05-01"}`)).
+			Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetSeriesJSON(ctx, &hub.GetStatsSeriesInput{
+			Metric:   "packages",
+			Interval: "day",
+			From:     "2021-04-01",
+			To:       "2021-05-01",
+		})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
@@ -6,20 +6,38 @@ import (
 	"fmt"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
 	"github.com/satori/uuid"
 )
 
 const (
 	// Database queries
-	addOptOutDBQ               = `select add_opt_out($1::jsonb)`
-	addSubscriptionDBQ         = `select add_subscription($1::jsonb)`
-	deleteOptOutDBQ            = `select delete_opt_out($1::uuid, $2::uuid)`
-	deleteSubscriptionDBQ      = `select delete_subscription($1::jsonb)`
-	getPkgSubscriptorsDBQ      = `select get_package_subscriptors($1::uuid, $2::integer)`
-	getRepoSubscriptorsDBQ     = `select get_repository_subscriptors($1::uuid, $2::integer)`
-	getUserOptOutEntriesDBQ    = `select get_user_opt_out_entries($1::uuid)`
-	getUserPkgSubscriptionsDBQ = `select get_user_package_subscriptions($1::uuid, $2::uuid)`
-	getUserSubscriptionsDBQ    = `select get_user_subscriptions($1::uuid)`
+	addBulkSubscriptionDBQ        = `select add_bulk_subscription($1::uuid, $2::jsonb)`
+	addOptOutDBQ                  = `select add_opt_out($1::jsonb)`
+	addScopedSubscriptionDBQ      = `select add_scoped_subscription($1::uuid, $2::jsonb)`
+	addSubscriptionDBQ            = `select add_subscription($1::jsonb)`
+	deleteBulkSubscriptionDBQ     = `select delete_bulk_subscription($1::uuid, $2::jsonb)`
+	deleteOptOutDBQ               = `select delete_opt_out($1::uuid, $2::uuid)`
+	deleteScopedSubscriptionDBQ   = `select delete_scoped_subscription($1::uuid, $2::jsonb)`
+	deleteSubscriptionDBQ         = `select delete_subscription($1::jsonb)`
+	exportSubscriptionsDBQ        = `select export_user_subscriptions($1::uuid)`
+	getPkgSubscriptorsDBQ         = `select get_package_subscriptors($1::uuid, $2::integer)`
+	getRepoSubscriptorsDBQ        = `select get_repository_subscriptors($1::uuid, $2::integer)`
+	getUserOptOutEntriesDBQ       = `select get_user_opt_out_entries($1::uuid)`
+	getUserPkgSubscriptionsDBQ    = `select get_user_package_subscriptions($1::uuid, $2::uuid)`
+	getUserScopedSubscriptionsDBQ = `select get_user_scoped_subscriptions($1::uuid)`
+	getUserSubscriptionsDBQ       = `select get_user_subscriptions($1::uuid)`
+	importSubscriptionsDBQ        = `select import_user_subscriptions($1::uuid, $2::jsonb)`
+	searchSubscriptionsDBQ        = `select search_user_subscriptions($1::uuid, $2::jsonb)`
+	unsubscribeDBQ                = `select unsubscribe($1::jsonb)`
+
+	// maxLimit represents the maximum number of subscriptions that can be
+	// requested at once.
+	maxLimit = 100
+
+	// maxImportEntries represents the maximum number of entries that can be
+	// imported at once.
+	maxImportEntries = 1000
 )
 
 // Manager provides an API to manage subscriptions.
@@ -46,6 +64,18 @@ func (m *Manager) Add(ctx context.Context, s *hub.Subscription) error {
 	return err
 }
 
+// AddBulk subscribes the user doing the request to all the packages of the
+// repository or publisher provided.
+func (m *Manager) AddBulk(ctx context.Context, input *hub.BulkSubscriptionInput) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	if err := validateBulkSubscriptionInput(input); err != nil {
+		return err
+	}
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, addBulkSubscriptionDBQ, userID, inputJSON)
+	return err
+}
+
 // AddOptOut adds an opt-out entry to the database.
 func (m *Manager) AddOptOut(ctx context.Context, o *hub.OptOut) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -58,6 +88,27 @@ func (m *Manager) AddOptOut(ctx context.Context, o *hub.OptOut) error {
 	return err
 }
 
+// AddScoped subscribes the user doing the request to all the packages of the
+// repository or publisher provided, including the ones added in the future.
+func (m *Manager) AddScoped(ctx context.Context, s *hub.ScopedSubscription) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	if err := validateScope(s.RepositoryID, s.OrganizationName, s.UserAlias); err != nil {
+		return err
+	}
+	if err := validateScopedEventKind(s.EventKind); err != nil {
+		return err
+	}
+	if err := validateMinSeverity(s.EventKind, s.MinSeverity); err != nil {
+		return err
+	}
+	sJSON, _ := json.Marshal(s)
+	_, err := m.db.Exec(ctx, addScopedSubscriptionDBQ, userID, sJSON)
+	if err != nil && err.Error() == util.ErrDBNotFound.Error() {
+		return hub.ErrNotFound
+	}
+	return err
+}
+
 // Delete removes a subscription from the database.
 func (m *Manager) Delete(ctx context.Context, s *hub.Subscription) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -70,6 +121,18 @@ func (m *Manager) Delete(ctx context.Context, s *hub.Subscription) error {
 	return err
 }
 
+// DeleteBulk unsubscribes the user doing the request from all the packages of
+// the repository or publisher provided.
+func (m *Manager) DeleteBulk(ctx context.Context, input *hub.BulkSubscriptionInput) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	if err := validateBulkSubscriptionInput(input); err != nil {
+		return err
+	}
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, deleteBulkSubscriptionDBQ, userID, inputJSON)
+	return err
+}
+
 // DeleteOptOut deletes an opt-out entry from the database.
 func (m *Manager) DeleteOptOut(ctx context.Context, optOutID string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -80,6 +143,32 @@ func (m *Manager) DeleteOptOut(ctx context.Context, optOutID string) error {
 	return err
 }
 
+// DeleteScoped removes a repository or publisher subscription from the
+// database.
+func (m *Manager) DeleteScoped(ctx context.Context, s *hub.ScopedSubscription) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	if err := validateScope(s.RepositoryID, s.OrganizationName, s.UserAlias); err != nil {
+		return err
+	}
+	if err := validateScopedEventKind(s.EventKind); err != nil {
+		return err
+	}
+	sJSON, _ := json.Marshal(s)
+	_, err := m.db.Exec(ctx, deleteScopedSubscriptionDBQ, userID, sJSON)
+	return err
+}
+
+// ExportJSON returns all the subscriptions of the user doing the request as a
+// json array of objects that can be imported later.
+func (m *Manager) ExportJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	var dataJSON []byte
+	if err := m.db.QueryRow(ctx, exportSubscriptionsDBQ, userID).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	return dataJSON, nil
+}
+
 // GetByPackageJSON returns the subscriptions the user has for a given package
 // as json array of objects.
 func (m *Manager) GetByPackageJSON(ctx context.Context, packageID string) ([]byte, error) {
@@ -117,17 +206,34 @@ func (m *Manager) GetOptOutListJSON(ctx context.Context) ([]byte, error) {
 	return dataJSON, nil
 }
 
+// GetScopedByUserJSON returns all the repositories and publishers
+// subscriptions of the user doing the request as a json array of objects.
+func (m *Manager) GetScopedByUserJSON(ctx context.Context) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	var dataJSON []byte
+	if err := m.db.QueryRow(ctx, getUserScopedSubscriptionsDBQ, userID).Scan(&dataJSON); err != nil {
+		return nil, err
+	}
+	return dataJSON, nil
+}
+
 // GetSubscriptors returns the users subscribed to receive notifications for
-// certain kind of events.
+// certain kind of events. Users whose security alerts subscriptions require a
+// minimum severity not reached by any of the vulnerabilities in the event are
+// not included.
 func (m *Manager) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.User, error) {
 	var dataJSON []byte
 	var err error
 	switch e.EventKind {
-	case hub.NewRelease:
+	case hub.NewRelease, hub.NewPackage, hub.PackageDeprecated, hub.SecurityAlert:
 		err = m.db.QueryRow(ctx, getPkgSubscriptorsDBQ, e.PackageID, e.EventKind).Scan(&dataJSON)
-	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors:
+	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors, hub.RepositoryVerifiedPublisherChange:
 		err = m.db.QueryRow(ctx, getRepoSubscriptorsDBQ, e.RepositoryID, e.EventKind).Scan(&dataJSON)
-	case hub.RepositoryOwnershipClaim:
+	case hub.RepositoryOwnershipClaim,
+		hub.RepositoryOwnershipTransfer,
+		hub.WebhookSuspended,
+		hub.SavedSearchNewMatches,
+		hub.PackageNewQuestion:
 		dataJSON, _ = json.Marshal(e.Data["subscriptors"])
 	default:
 		return nil, nil
@@ -139,21 +245,221 @@ func (m *Manager) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.Use
 	if err := json.Unmarshal(dataJSON, &subscriptors); err != nil {
 		return nil, err
 	}
+	if e.EventKind == hub.SecurityAlert {
+		return filterSubscriptorsBySeverity(dataJSON, subscriptors, e)
+	}
 	return subscriptors, nil
 }
 
+// filterSubscriptorsBySeverity returns the subscriptors provided whose minimum
+// severity is reached by at least one of the vulnerabilities in the security
+// alert event given. Subscriptors that did not set a minimum severity are
+// always included, as well as all of them when the event does not include the
+// vulnerabilities found.
+func filterSubscriptorsBySeverity(
+	dataJSON []byte,
+	subscriptors []*hub.User,
+	e *hub.Event,
+) ([]*hub.User, error) {
+	// Get the highest severity level of the event vulnerabilities
+	eventLevel := -1
+	vulnerabilities, _ := e.Data["vulnerabilities"].([]interface{})
+	for _, entry := range vulnerabilities {
+		v, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		severity, _ := v["severity"].(string)
+		if level, ok := hub.SeverityLevel(severity); ok && level > eventLevel {
+			eventLevel = level
+		}
+	}
+	if eventLevel == -1 {
+		return subscriptors, nil
+	}
+
+	// Filter out subscriptors whose minimum severity is not reached
+	var thresholds []struct {
+		MinSeverity string `json:"min_severity"`
+	}
+	if err := json.Unmarshal(dataJSON, &thresholds); err != nil {
+		return nil, err
+	}
+	filtered := make([]*hub.User, 0, len(subscriptors))
+	for i, u := range subscriptors {
+		if minLevel, ok := hub.SeverityLevel(thresholds[i].MinSeverity); ok && minLevel > eventLevel {
+			continue
+		}
+		filtered = append(filtered, u)
+	}
+	return filtered, nil
+}
+
+// Import subscribes the user doing the request to the packages and event kinds
+// provided. Existing subscriptions are kept and entries referring to packages
+// that cannot be found are ignored.
+func (m *Manager) Import(ctx context.Context, entries []*hub.SubscriptionsExportEntry) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no subscriptions provided")
+	}
+	if len(entries) > maxImportEntries {
+		return fmt.Errorf("%w: too many subscriptions (max %d)", hub.ErrInvalidInput, maxImportEntries)
+	}
+	for _, e := range entries {
+		if e.RepositoryName == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+		}
+		if e.PackageName == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "package name not provided")
+		}
+		if len(e.EventKinds) == 0 {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
+		}
+		for _, kind := range e.EventKinds {
+			if err := validatePackageEventKind(kind); err != nil {
+				return err
+			}
+		}
+	}
+	entriesJSON, _ := json.Marshal(entries)
+	_, err := m.db.Exec(ctx, importSubscriptionsDBQ, userID, entriesJSON)
+	return err
+}
+
+// SearchJSON returns the subscriptions of the user doing the request that
+// match the input provided as a json object.
+func (m *Manager) SearchJSON(ctx context.Context, input *hub.SearchSubscriptionsInput) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+	if input.Limit <= 0 || input.Limit > maxLimit {
+		return nil, fmt.Errorf("%w: invalid limit (0 < l <= %d)", hub.ErrInvalidInput, maxLimit)
+	}
+	if input.Offset < 0 {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid offset (o >= 0)")
+	}
+	if input.Cursor != "" {
+		if input.Offset > 0 {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "offset and cursor cannot be used together")
+		}
+		if err := util.ValidateCursor(input.Cursor); err != nil {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, err.Error())
+		}
+	}
+	for _, kind := range input.EventKinds {
+		if err := validatePackageEventKind(kind); err != nil {
+			return nil, err
+		}
+	}
+	inputJSON, _ := json.Marshal(input)
+	return util.DBQueryJSON(ctx, m.db, searchSubscriptionsDBQ, userID, inputJSON)
+}
+
+// Unsubscribe stops delivering the notifications described by the input
+// provided to the corresponding user. Package subscriptions are removed,
+// whereas repositories notifications are opted out.
+func (m *Manager) Unsubscribe(ctx context.Context, input *hub.UnsubscribeInput) error {
+	if _, err := uuid.FromString(input.UserID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+	switch input.EventKind {
+	case hub.NewRelease, hub.PackageDeprecated, hub.SecurityAlert:
+		if _, err := uuid.FromString(input.PackageID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
+		}
+	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors:
+		if _, err := uuid.FromString(input.RepositoryID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event kind")
+	}
+	inputJSON, _ := json.Marshal(input)
+	_, err := m.db.Exec(ctx, unsubscribeDBQ, inputJSON)
+	return err
+}
+
 // validateSubscription checks if the subscription provided is valid to be used
 // as input for some database functions calls.
 func validateSubscription(s *hub.Subscription) error {
 	if _, err := uuid.FromString(s.PackageID); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
 	}
-	if s.EventKind != hub.NewRelease {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event kind")
+	if err := validatePackageEventKind(s.EventKind); err != nil {
+		return err
+	}
+	return validateMinSeverity(s.EventKind, s.MinSeverity)
+}
+
+// validateBulkSubscriptionInput checks if the bulk subscription input provided
+// is valid to be used as input for some database functions calls.
+func validateBulkSubscriptionInput(input *hub.BulkSubscriptionInput) error {
+	if err := validateScope(input.RepositoryID, input.OrganizationName, input.UserAlias); err != nil {
+		return err
+	}
+	return validatePackageEventKind(input.EventKind)
+}
+
+// validateScope checks that exactly one of the repository id, organization
+// name or user alias provided has been set, and that it is valid.
+func validateScope(repositoryID, organizationName, userAlias string) error {
+	var targets int
+	if repositoryID != "" {
+		if _, err := uuid.FromString(repositoryID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
+		}
+		targets++
+	}
+	if organizationName != "" {
+		targets++
+	}
+	if userAlias != "" {
+		targets++
+	}
+	if targets != 1 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "one of repository id, organization name or user alias must be provided")
 	}
 	return nil
 }
 
+// validatePackageEventKind checks if the event kind provided can be used in
+// packages subscriptions.
+func validatePackageEventKind(kind hub.EventKind) error {
+	switch kind {
+	case hub.NewRelease, hub.PackageDeprecated, hub.SecurityAlert:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event kind")
+	}
+}
+
+// validateScopedEventKind checks if the event kind provided can be used in
+// repositories and publishers subscriptions. In addition to the packages event
+// kinds, they can also be used to get notified about new packages.
+func validateScopedEventKind(kind hub.EventKind) error {
+	if kind == hub.NewPackage {
+		return nil
+	}
+	return validatePackageEventKind(kind)
+}
+
+// validateMinSeverity checks if the minimum severity provided can be used in
+// subscriptions to the event kind given. Only security alerts subscriptions
+// can set a minimum severity.
+func validateMinSeverity(kind hub.EventKind, minSeverity string) error {
+	if minSeverity == "" {
+		return nil
+	}
+	if kind != hub.SecurityAlert {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "minimum severity only allowed in security alerts subscriptions")
+	}
+	switch minSeverity {
+	case "low", "medium", "high", "critical":
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid minimum severity")
+	}
+}
+
 // validateOptOut checks if the opt-out information provided is valid to be
 // used as input for some database functions calls.
 func validateOptOut(o *hub.OptOut) error {
@@ -161,7 +467,8 @@ func validateOptOut(o *hub.OptOut) error {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid repository id")
 	}
 	switch o.EventKind {
-	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors:
+	case hub.RepositoryScanningErrors, hub.RepositoryTrackingErrors, hub.PackageNewQuestion,
+		hub.RepositoryVerifiedPublisherChange:
 	default:
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event kind")
 	}
@@ -2,11 +2,14 @@ package subscription
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/jackc/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -44,7 +47,30 @@ func TestAdd(t *testing.T) {
 				"invalid event kind",
 				&hub.Subscription{
 					PackageID: packageID,
-					EventKind: hub.EventKind(5),
+					EventKind: hub.RepositoryTrackingErrors,
+				},
+			},
+			{
+				"invalid event kind",
+				&hub.Subscription{
+					PackageID: packageID,
+					EventKind: hub.NewPackage,
+				},
+			},
+			{
+				"minimum severity only allowed in security alerts subscriptions",
+				&hub.Subscription{
+					PackageID:   packageID,
+					EventKind:   hub.NewRelease,
+					MinSeverity: "high",
+				},
+			},
+			{
+				"invalid minimum severity",
+				&hub.Subscription{
+					PackageID:   packageID,
+					EventKind:   hub.SecurityAlert,
+					MinSeverity: "unknown",
 				},
 			},
 		}
@@ -89,6 +115,107 @@ func TestAdd(t *testing.T) {
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("database query succeeded (package deprecated event)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addSubscriptionDBQ, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		s := &hub.Subscription{
+			PackageID: packageID,
+			EventKind: hub.PackageDeprecated,
+		}
+		err := m.Add(ctx, s)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestAddBulk(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.AddBulk(context.Background(), &hub.BulkSubscriptionInput{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.BulkSubscriptionInput
+		}{
+			{
+				"one of repository id, organization name or user alias must be provided",
+				&hub.BulkSubscriptionInput{
+					EventKind: hub.NewRelease,
+				},
+			},
+			{
+				"one of repository id, organization name or user alias must be provided",
+				&hub.BulkSubscriptionInput{
+					OrganizationName: "org1",
+					UserAlias:        "user1",
+					EventKind:        hub.NewRelease,
+				},
+			},
+			{
+				"invalid repository id",
+				&hub.BulkSubscriptionInput{
+					RepositoryID: "invalid",
+					EventKind:    hub.NewRelease,
+				},
+			},
+			{
+				"invalid event kind",
+				&hub.BulkSubscriptionInput{
+					OrganizationName: "org1",
+					EventKind:        hub.RepositoryTrackingErrors,
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.AddBulk(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addBulkSubscriptionDBQ, userID, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.AddBulk(ctx, &hub.BulkSubscriptionInput{
+			RepositoryID: repositoryID,
+			EventKind:    hub.NewRelease,
+		})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addBulkSubscriptionDBQ, userID, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.AddBulk(ctx, &hub.BulkSubscriptionInput{
+			UserAlias: "user1",
+			EventKind: hub.SecurityAlert,
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestAddOptOut(t *testing.T) {
@@ -165,6 +292,126 @@ func TestAddOptOut(t *testing.T) {
 	})
 }
 
+func TestAddScoped(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.AddScoped(context.Background(), &hub.ScopedSubscription{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			s      *hub.ScopedSubscription
+		}{
+			{
+				"one of repository id, organization name or user alias must be provided",
+				&hub.ScopedSubscription{
+					EventKind: hub.NewRelease,
+				},
+			},
+			{
+				"one of repository id, organization name or user alias must be provided",
+				&hub.ScopedSubscription{
+					RepositoryID: repositoryID,
+					UserAlias:    "user1",
+					EventKind:    hub.NewRelease,
+				},
+			},
+			{
+				"invalid repository id",
+				&hub.ScopedSubscription{
+					RepositoryID: "invalid",
+					EventKind:    hub.NewRelease,
+				},
+			},
+			{
+				"invalid event kind",
+				&hub.ScopedSubscription{
+					UserAlias: "user1",
+					EventKind: hub.RepositoryScanningErrors,
+				},
+			},
+			{
+				"minimum severity only allowed in security alerts subscriptions",
+				&hub.ScopedSubscription{
+					UserAlias:   "user1",
+					EventKind:   hub.NewPackage,
+					MinSeverity: "critical",
+				},
+			},
+			{
+				"invalid minimum severity",
+				&hub.ScopedSubscription{
+					UserAlias:   "user1",
+					EventKind:   hub.SecurityAlert,
+					MinSeverity: "CRITICAL",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.AddScoped(ctx, tc.s)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addScopedSubscriptionDBQ, userID, mock.Anything).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.AddScoped(ctx, &hub.ScopedSubscription{
+					OrganizationName: "org1",
+					EventKind:        hub.NewRelease,
+				})
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addScopedSubscriptionDBQ, userID, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.AddScoped(ctx, &hub.ScopedSubscription{
+			RepositoryID: repositoryID,
+			EventKind:    hub.NewPackage,
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
 
@@ -191,7 +438,7 @@ func TestDelete(t *testing.T) {
 				"invalid event kind",
 				&hub.Subscription{
 					PackageID: packageID,
-					EventKind: hub.EventKind(5),
+					EventKind: hub.RepositoryTrackingErrors,
 				},
 			},
 		}
@@ -238,25 +485,49 @@ func TestDelete(t *testing.T) {
 	})
 }
 
-func TestDeleteOptOut(t *testing.T) {
+func TestDeleteBulk(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(nil)
 		assert.Panics(t, func() {
-			_ = m.DeleteOptOut(context.Background(), optOutID)
+			_ = m.DeleteBulk(context.Background(), &hub.BulkSubscriptionInput{})
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
-			errMsg   string
-			optOutID string
+			errMsg string
+			input  *hub.BulkSubscriptionInput
 		}{
 			{
-				"invalid opt out id",
-				"invalid",
+				"one of repository id, organization name or user alias must be provided",
+				&hub.BulkSubscriptionInput{
+					EventKind: hub.NewRelease,
+				},
+			},
+			{
+				"one of repository id, organization name or user alias must be provided",
+				&hub.BulkSubscriptionInput{
+					OrganizationName: "org1",
+					UserAlias:        "user1",
+					EventKind:        hub.NewRelease,
+				},
+			},
+			{
+				"invalid repository id",
+				&hub.BulkSubscriptionInput{
+					RepositoryID: "invalid",
+					EventKind:    hub.NewRelease,
+				},
+			},
+			{
+				"invalid event kind",
+				&hub.BulkSubscriptionInput{
+					OrganizationName: "org1",
+					EventKind:        hub.RepositoryTrackingErrors,
+				},
 			},
 		}
 		for _, tc := range testCases {
@@ -264,7 +535,7 @@ func TestDeleteOptOut(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(nil)
-				err := m.DeleteOptOut(ctx, tc.optOutID)
+				err := m.DeleteBulk(ctx, tc.input)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
@@ -274,10 +545,13 @@ func TestDeleteOptOut(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, deleteOptOutDBQ, userID, optOutID).Return(tests.ErrFakeDB)
+		db.On("Exec", ctx, deleteBulkSubscriptionDBQ, userID, mock.Anything).Return(tests.ErrFakeDB)
 		m := NewManager(db)
 
-		err := m.DeleteOptOut(ctx, optOutID)
+		err := m.DeleteBulk(ctx, &hub.BulkSubscriptionInput{
+			RepositoryID: repositoryID,
+			EventKind:    hub.NewRelease,
+		})
 		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
@@ -285,86 +559,267 @@ func TestDeleteOptOut(t *testing.T) {
 	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, deleteOptOutDBQ, userID, optOutID).Return(nil)
+		db.On("Exec", ctx, deleteBulkSubscriptionDBQ, userID, mock.Anything).Return(nil)
 		m := NewManager(db)
 
-		err := m.DeleteOptOut(ctx, optOutID)
+		err := m.DeleteBulk(ctx, &hub.BulkSubscriptionInput{
+			UserAlias: "user1",
+			EventKind: hub.SecurityAlert,
+		})
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetByPackageJSON(t *testing.T) {
+func TestDeleteOptOut(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(nil)
 		assert.Panics(t, func() {
-			_, _ = m.GetByPackageJSON(context.Background(), "")
+			_ = m.DeleteOptOut(context.Background(), optOutID)
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
-		t.Parallel()
-		m := NewManager(nil)
-		_, err := m.GetByPackageJSON(ctx, "")
-		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
-		assert.Contains(t, err.Error(), "invalid package id")
+		testCases := []struct {
+			errMsg   string
+			optOutID string
+		}{
+			{
+				"invalid opt out id",
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.DeleteOptOut(ctx, tc.optOutID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
+	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserPkgSubscriptionsDBQ, userID, packageID).Return([]byte("dataJSON"), nil)
+		db.On("Exec", ctx, deleteOptOutDBQ, userID, optOutID).Return(tests.ErrFakeDB)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetByPackageJSON(ctx, packageID)
-		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		err := m.DeleteOptOut(ctx, optOutID)
+		assert.Equal(t, tests.ErrFakeDB, err)
 		db.AssertExpectations(t)
 	})
 
-	t.Run("database error", func(t *testing.T) {
+	t.Run("database query succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserPkgSubscriptionsDBQ, userID, packageID).Return(nil, tests.ErrFakeDB)
+		db.On("Exec", ctx, deleteOptOutDBQ, userID, optOutID).Return(nil)
 		m := NewManager(db)
 
-		dataJSON, err := m.GetByPackageJSON(ctx, packageID)
-		assert.Equal(t, tests.ErrFakeDB, err)
-		assert.Nil(t, dataJSON)
+		err := m.DeleteOptOut(ctx, optOutID)
+		assert.NoError(t, err)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestGetByUserJSON(t *testing.T) {
+func TestDeleteScoped(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(nil)
 		assert.Panics(t, func() {
-			_, _ = m.GetByUserJSON(context.Background())
+			_ = m.DeleteScoped(context.Background(), &hub.ScopedSubscription{})
 		})
 	})
 
-	t.Run("database query succeeded", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserSubscriptionsDBQ, userID).Return([]byte("dataJSON"), nil)
-		m := NewManager(db)
-
-		dataJSON, err := m.GetByUserJSON(ctx)
-		assert.NoError(t, err)
-		assert.Equal(t, []byte("dataJSON"), dataJSON)
-		db.AssertExpectations(t)
-	})
-
-	t.Run("database error", func(t *testing.T) {
-		t.Parallel()
-		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, getUserSubscriptionsDBQ, userID).Return(nil, tests.ErrFakeDB)
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			s      *hub.ScopedSubscription
+		}{
+			{
+				"one of repository id, organization name or user alias must be provided",
+				&hub.ScopedSubscription{
+					EventKind: hub.NewRelease,
+				},
+			},
+			{
+				"one of repository id, organization name or user alias must be provided",
+				&hub.ScopedSubscription{
+					RepositoryID: repositoryID,
+					UserAlias:    "user1",
+					EventKind:    hub.NewRelease,
+				},
+			},
+			{
+				"invalid repository id",
+				&hub.ScopedSubscription{
+					RepositoryID: "invalid",
+					EventKind:    hub.NewRelease,
+				},
+			},
+			{
+				"invalid event kind",
+				&hub.ScopedSubscription{
+					UserAlias: "user1",
+					EventKind: hub.RepositoryScanningErrors,
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.DeleteScoped(ctx, tc.s)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteScopedSubscriptionDBQ, userID, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.DeleteScoped(ctx, &hub.ScopedSubscription{
+			UserAlias: "user1",
+			EventKind: hub.NewRelease,
+		})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteScopedSubscriptionDBQ, userID, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.DeleteScoped(ctx, &hub.ScopedSubscription{
+			UserAlias: "user1",
+			EventKind: hub.NewRelease,
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestExportJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.ExportJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, exportSubscriptionsDBQ, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.ExportJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, exportSubscriptionsDBQ, userID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.ExportJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetByPackageJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByPackageJSON(context.Background(), "")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetByPackageJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid package id")
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserPkgSubscriptionsDBQ, userID, packageID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetByPackageJSON(ctx, packageID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserPkgSubscriptionsDBQ, userID, packageID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetByPackageJSON(ctx, packageID)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetByUserJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByUserJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserSubscriptionsDBQ, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetByUserJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserSubscriptionsDBQ, userID).Return(nil, tests.ErrFakeDB)
 		m := NewManager(db)
 
 		dataJSON, err := m.GetByUserJSON(ctx)
@@ -410,6 +865,42 @@ func TestGetOptOutListJSON(t *testing.T) {
 	})
 }
 
+func TestGetScopedByUserJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetScopedByUserJSON(context.Background())
+		})
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserScopedSubscriptionsDBQ, userID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetScopedByUserJSON(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserScopedSubscriptionsDBQ, userID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetScopedByUserJSON(ctx)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
 func TestGetSubscriptors(t *testing.T) {
 	ctx := context.Background()
 	pkgNewReleaseEvent := &hub.Event{
@@ -474,6 +965,109 @@ func TestGetSubscriptors(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("database query succeeded (security alert event)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.SecurityAlert).
+			Return([]byte(`
+		[
+			{
+				"user_id": "00000000-0000-0000-0000-000000000001"
+			},
+			{
+				"user_id": "00000000-0000-0000-0000-000000000002",
+				"min_severity": "critical"
+			},
+			{
+				"user_id": "00000000-0000-0000-0000-000000000003",
+				"min_severity": "high"
+			}
+		]
+		`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			PackageID: packageID,
+			EventKind: hub.SecurityAlert,
+			Data: map[string]interface{}{
+				"vulnerabilities": []interface{}{
+					map[string]interface{}{"id": "CVE-2021-0001", "severity": "MEDIUM"},
+					map[string]interface{}{"id": "CVE-2021-0002", "severity": "HIGH"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{
+			{UserID: "00000000-0000-0000-0000-000000000001"},
+			{UserID: "00000000-0000-0000-0000-000000000003"},
+		}, subscriptors)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (security alert event without vulnerabilities)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.SecurityAlert).
+			Return([]byte(`[{"user_id": "00000000-0000-0000-0000-000000000001", "min_severity": "critical"}]`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			PackageID: packageID,
+			EventKind: hub.SecurityAlert,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (pkg deprecated event)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.PackageDeprecated).
+			Return([]byte(`[{"user_id": "00000000-0000-0000-0000-000000000001"}]`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			PackageID: packageID,
+			EventKind: hub.PackageDeprecated,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (new package event)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.NewPackage).
+			Return([]byte(`[{"user_id": "00000000-0000-0000-0000-000000000001"}]`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			PackageID: packageID,
+			EventKind: hub.NewPackage,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded (security alert event)", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getPkgSubscriptorsDBQ, packageID, hub.SecurityAlert).
+			Return([]byte(`[{"user_id": "00000000-0000-0000-0000-000000000001"}]`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			PackageID: packageID,
+			EventKind: hub.SecurityAlert,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("database query succeeded (repo tracking errors event)", func(t *testing.T) {
 		t.Parallel()
 		expectedSubscriptors := []*hub.User{
@@ -524,4 +1118,376 @@ func TestGetSubscriptors(t *testing.T) {
 		assert.Equal(t, expectedSubscriptors, subscriptors)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("database query succeeded (repo verified publisher change event)", func(t *testing.T) {
+		t.Parallel()
+		e := &hub.Event{
+			RepositoryID: repositoryID,
+			EventKind:    hub.RepositoryVerifiedPublisherChange,
+		}
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getRepoSubscriptorsDBQ, repositoryID, e.EventKind).
+			Return([]byte(`[{"user_id": "00000000-0000-0000-0000-000000000001"}]`), nil)
+		m := NewManager(db)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), e)
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("subscriptors included in event data (webhook suspended event)", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			EventKind: hub.WebhookSuspended,
+			Data: map[string]interface{}{
+				"subscriptors": []map[string]string{
+					{"user_id": "00000000-0000-0000-0000-000000000001"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+	})
+
+	t.Run("subscriptors included in event data (saved search new matches event)", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			EventKind: hub.SavedSearchNewMatches,
+			Data: map[string]interface{}{
+				"subscriptors": []map[string]string{
+					{"user_id": "00000000-0000-0000-0000-000000000001"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+	})
+
+	t.Run("subscriptors included in event data (package new question event)", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			PackageID: packageID,
+			EventKind: hub.PackageNewQuestion,
+			Data: map[string]interface{}{
+				"subscriptors": []map[string]string{
+					{"user_id": "00000000-0000-0000-0000-000000000001"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000001"}}, subscriptors)
+	})
+
+	t.Run("subscriptors included in event data (repository ownership transfer event)", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+
+		subscriptors, err := m.GetSubscriptors(context.Background(), &hub.Event{
+			RepositoryID: repositoryID,
+			EventKind:    hub.RepositoryOwnershipTransfer,
+			Data: map[string]interface{}{
+				"subscriptors": []map[string]string{
+					{"user_id": "00000000-0000-0000-0000-000000000002"},
+				},
+				"previous_owner": map[string]string{
+					"organization_name": "org1",
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []*hub.User{{UserID: "00000000-0000-0000-0000-000000000002"}}, subscriptors)
+	})
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+	entries := []*hub.SubscriptionsExportEntry{
+		{
+			RepositoryName: "repo1",
+			PackageName:    "pkg1",
+			EventKinds:     []hub.EventKind{hub.NewRelease, hub.SecurityAlert},
+		},
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Import(context.Background(), entries)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			entries []*hub.SubscriptionsExportEntry
+		}{
+			{
+				"no subscriptions provided",
+				nil,
+			},
+			{
+				"too many subscriptions",
+				make([]*hub.SubscriptionsExportEntry, maxImportEntries+1),
+			},
+			{
+				"repository name not provided",
+				[]*hub.SubscriptionsExportEntry{
+					{
+						PackageName: "pkg1",
+						EventKinds:  []hub.EventKind{hub.NewRelease},
+					},
+				},
+			},
+			{
+				"package name not provided",
+				[]*hub.SubscriptionsExportEntry{
+					{
+						RepositoryName: "repo1",
+						EventKinds:     []hub.EventKind{hub.NewRelease},
+					},
+				},
+			},
+			{
+				"no event kinds provided",
+				[]*hub.SubscriptionsExportEntry{
+					{
+						RepositoryName: "repo1",
+						PackageName:    "pkg1",
+					},
+				},
+			},
+			{
+				"invalid event kind",
+				[]*hub.SubscriptionsExportEntry{
+					{
+						RepositoryName: "repo1",
+						PackageName:    "pkg1",
+						EventKinds:     []hub.EventKind{hub.RepositoryScanningErrors},
+					},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Import(ctx, tc.entries)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, importSubscriptionsDBQ, userID, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.Import(ctx, entries)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, importSubscriptionsDBQ, userID, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.Import(ctx, entries)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestSearchJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, userID)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.SearchJSON(context.Background(), &hub.SearchSubscriptionsInput{})
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.SearchSubscriptionsInput
+		}{
+			{
+				"invalid limit",
+				&hub.SearchSubscriptionsInput{Limit: 0},
+			},
+			{
+				"invalid limit",
+				&hub.SearchSubscriptionsInput{Limit: maxLimit + 1},
+			},
+			{
+				"invalid offset",
+				&hub.SearchSubscriptionsInput{Limit: 10, Offset: -1},
+			},
+			{
+				"offset and cursor cannot be used together",
+				&hub.SearchSubscriptionsInput{Limit: 10, Offset: 10, Cursor: "eyJuYW1lIjogInBrZzEifQ"},
+			},
+			{
+				"invalid cursor",
+				&hub.SearchSubscriptionsInput{Limit: 10, Cursor: "invalid"},
+			},
+			{
+				"invalid event kind",
+				&hub.SearchSubscriptionsInput{
+					Limit:      10,
+					EventKinds: []hub.EventKind{hub.WebhookSuspended},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.SearchJSON(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		input := &hub.SearchSubscriptionsInput{
+			Limit:      10,
+			EventKinds: []hub.EventKind{hub.NewRelease},
+		}
+		inputJSON, _ := json.Marshal(input)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchSubscriptionsDBQ, userID, inputJSON).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.SearchJSON(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchSubscriptionsDBQ, userID, mock.Anything).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db)
+
+		dataJSON, err := m.SearchJSON(ctx, &hub.SearchSubscriptionsInput{Limit: 10})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("cursor rejected by the database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, searchSubscriptionsDBQ, userID, mock.Anything).
+			Return(nil, &pgconn.PgError{Code: "22023", Message: "invalid cursor"})
+		m := NewManager(db)
+
+		dataJSON, err := m.SearchJSON(ctx, &hub.SearchSubscriptionsInput{Limit: 10, Cursor: "eyJuYW1lIjogInJlcG8xIn0"})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid cursor")
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUnsubscribe(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			input  *hub.UnsubscribeInput
+		}{
+			{
+				"invalid user id",
+				&hub.UnsubscribeInput{
+					UserID: "invalid",
+				},
+			},
+			{
+				"invalid package id",
+				&hub.UnsubscribeInput{
+					UserID:    userID,
+					EventKind: hub.NewRelease,
+					PackageID: "invalid",
+				},
+			},
+			{
+				"invalid repository id",
+				&hub.UnsubscribeInput{
+					UserID:       userID,
+					EventKind:    hub.RepositoryTrackingErrors,
+					RepositoryID: "invalid",
+				},
+			},
+			{
+				"invalid event kind",
+				&hub.UnsubscribeInput{
+					UserID:       userID,
+					EventKind:    hub.RepositoryOwnershipClaim,
+					RepositoryID: repositoryID,
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.Unsubscribe(ctx, tc.input)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unsubscribeDBQ, mock.Anything).Return(tests.ErrFakeDB)
+		m := NewManager(db)
+
+		err := m.Unsubscribe(ctx, &hub.UnsubscribeInput{
+			UserID:    userID,
+			EventKind: hub.NewRelease,
+			PackageID: packageID,
+		})
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, unsubscribeDBQ, mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.Unsubscribe(ctx, &hub.UnsubscribeInput{
+			UserID:       userID,
+			EventKind:    hub.RepositoryScanningErrors,
+			RepositoryID: repositoryID,
+		})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
 }
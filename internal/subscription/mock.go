@@ -18,24 +18,55 @@ func (m *ManagerMock) Add(ctx context.Context, s *hub.Subscription) error {
 	return args.Error(0)
 }
 
+// AddBulk implements the SubscriptionManager interface.
+func (m *ManagerMock) AddBulk(ctx context.Context, input *hub.BulkSubscriptionInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
 // AddOptOut implements the SubscriptionManager interface.
 func (m *ManagerMock) AddOptOut(ctx context.Context, o *hub.OptOut) error {
 	args := m.Called(ctx, o)
 	return args.Error(0)
 }
 
+// AddScoped implements the SubscriptionManager interface.
+func (m *ManagerMock) AddScoped(ctx context.Context, s *hub.ScopedSubscription) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
 // Delete implements the SubscriptionManager interface.
 func (m *ManagerMock) Delete(ctx context.Context, s *hub.Subscription) error {
 	args := m.Called(ctx, s)
 	return args.Error(0)
 }
 
+// DeleteBulk implements the SubscriptionManager interface.
+func (m *ManagerMock) DeleteBulk(ctx context.Context, input *hub.BulkSubscriptionInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
 // DeleteOptOut implements the SubscriptionManager interface.
 func (m *ManagerMock) DeleteOptOut(ctx context.Context, optOutID string) error {
 	args := m.Called(ctx, optOutID)
 	return args.Error(0)
 }
 
+// DeleteScoped implements the SubscriptionManager interface.
+func (m *ManagerMock) DeleteScoped(ctx context.Context, s *hub.ScopedSubscription) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+// ExportJSON implements the SubscriptionManager interface.
+func (m *ManagerMock) ExportJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetByPackageJSON implements the SubscriptionManager interface.
 func (m *ManagerMock) GetByPackageJSON(ctx context.Context, packageID string) ([]byte, error) {
 	args := m.Called(ctx, packageID)
@@ -57,9 +88,35 @@ func (m *ManagerMock) GetOptOutListJSON(ctx context.Context) ([]byte, error) {
 	return data, args.Error(1)
 }
 
+// GetScopedByUserJSON implements the SubscriptionManager interface.
+func (m *ManagerMock) GetScopedByUserJSON(ctx context.Context) ([]byte, error) {
+	args := m.Called(ctx)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
 // GetSubscriptors implements the SubscriptionManager interface.
 func (m *ManagerMock) GetSubscriptors(ctx context.Context, e *hub.Event) ([]*hub.User, error) {
 	args := m.Called(ctx, e)
 	data, _ := args.Get(0).([]*hub.User)
 	return data, args.Error(1)
 }
+
+// Import implements the SubscriptionManager interface.
+func (m *ManagerMock) Import(ctx context.Context, entries []*hub.SubscriptionsExportEntry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+// SearchJSON implements the SubscriptionManager interface.
+func (m *ManagerMock) SearchJSON(ctx context.Context, input *hub.SearchSubscriptionsInput) ([]byte, error) {
+	args := m.Called(ctx, input)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Unsubscribe implements the SubscriptionManager interface.
+func (m *ManagerMock) Unsubscribe(ctx context.Context, input *hub.UnsubscribeInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
@@ -0,0 +1,55 @@
+package subscription
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// errInvalidUnsubscribeToken indicates that the unsubscribe token provided is
+// malformed or its signature is not valid.
+var errInvalidUnsubscribeToken = fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid unsubscribe token")
+
+// NewUnsubscribeToken creates a token signed with the key provided that
+// allows a user to stop receiving the notifications described by the input
+// given without having to log in.
+func NewUnsubscribeToken(key string, input *hub.UnsubscribeInput) string {
+	inputJSON, _ := json.Marshal(input)
+	payload := base64.RawURLEncoding.EncodeToString(inputJSON)
+	return payload + "." + signUnsubscribeTokenPayload(key, payload)
+}
+
+// ParseUnsubscribeToken checks the signature of the token provided using the
+// key given and returns the unsubscribe input encoded in it.
+func ParseUnsubscribeToken(key, token string) (*hub.UnsubscribeInput, error) {
+	parts := strings.Split(token, ".")
+	if key == "" || len(parts) != 2 {
+		return nil, errInvalidUnsubscribeToken
+	}
+	payload, signature := parts[0], parts[1]
+	if !hmac.Equal([]byte(signature), []byte(signUnsubscribeTokenPayload(key, payload))) {
+		return nil, errInvalidUnsubscribeToken
+	}
+	inputJSON, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, errInvalidUnsubscribeToken
+	}
+	input := &hub.UnsubscribeInput{}
+	if err := json.Unmarshal(inputJSON, input); err != nil {
+		return nil, errInvalidUnsubscribeToken
+	}
+	return input, nil
+}
+
+// signUnsubscribeTokenPayload returns the signature of the unsubscribe token
+// payload provided.
+func signUnsubscribeTokenPayload(key, payload string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
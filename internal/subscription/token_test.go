@@ -0,0 +1,51 @@
+package subscription
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	input := &hub.UnsubscribeInput{
+		UserID:    userID,
+		EventKind: hub.NewRelease,
+		PackageID: packageID,
+	}
+	token := NewUnsubscribeToken("key", input)
+
+	t.Run("valid token", func(t *testing.T) {
+		t.Parallel()
+		parsedInput, err := ParseUnsubscribeToken("key", token)
+		require.NoError(t, err)
+		assert.Equal(t, input, parsedInput)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		payload := strings.Split(token, ".")[0]
+		testCases := []struct {
+			description string
+			key         string
+			token       string
+		}{
+			{"no key provided", "", token},
+			{"malformed token", "key", "invalid"},
+			{"different key", "other-key", token},
+			{"tampered payload", "key", "e30" + token[len(payload):]},
+			{"invalid payload", "key", "-." + signUnsubscribeTokenPayload("key", "-")},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				parsedInput, err := ParseUnsubscribeToken(tc.key, tc.token)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Nil(t, parsedInput)
+			})
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/util"
+)
+
+const (
+	// Database queries
+	addTeamDBQ          = `select add_team($1::uuid, $2::text, $3::jsonb)`
+	addTeamMemberDBQ    = `select add_team_member($1::uuid, $2::text, $3::text, $4::text)`
+	deleteRepoPermsDBQ  = `select delete_team_repository_permissions($1::uuid, $2::text, $3::text, $4::text)`
+	deleteTeamDBQ       = `select delete_team($1::uuid, $2::text, $3::text)`
+	deleteTeamMemberDBQ = `select delete_team_member($1::uuid, $2::text, $3::text, $4::text)`
+	getOrgTeamsDBQ      = `select get_org_teams($1::uuid, $2::text)`
+	updateRepoPermsDBQ  = `select update_team_repository_permissions($1::uuid, $2::text, $3::text, $4::text, $5::text[])`
+	updateTeamDBQ       = `select update_team($1::uuid, $2::text, $3::text, $4::jsonb)`
+)
+
+var (
+	// ErrNameNotAvailable indicates that the team name provided is already
+	// being used in the organization.
+	ErrNameNotAvailable = errors.New("team name not available")
+
+	// ErrUserNotMember indicates that the user provided is not a member of the
+	// organization the team belongs to.
+	ErrUserNotMember = errors.New("user is not a member of the organization")
+
+	// errNameNotAvailableDB represents the error returned from the database
+	// when the team name provided is not available.
+	errNameNotAvailableDB = errors.New("ERROR: team name not available (SQLSTATE P0001)")
+
+	// errUserNotMemberDB represents the error returned from the database when
+	// the user provided is not a member of the organization.
+	errUserNotMemberDB = errors.New("ERROR: user is not a member of the organization (SQLSTATE P0001)")
+
+	// teamNameRE is a regexp used to validate a team name.
+	teamNameRE = regexp.MustCompile(`^[a-z0-9-]+$`)
+)
+
+// Manager provides an API to manage the teams of an organization.
+type Manager struct {
+	db hub.DB
+	az hub.Authorizer
+}
+
+// NewManager creates a new Manager instance.
+func NewManager(db hub.DB, az hub.Authorizer) *Manager {
+	return &Manager{
+		db: db,
+		az: az,
+	}
+}
+
+// Add adds the provided team to the organization given.
+func (m *Manager) Add(ctx context.Context, orgName string, t *hub.Team) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if t.Name == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "name not provided")
+	}
+	if !teamNameRE.MatchString(t.Name) {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid name")
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName); err != nil {
+		return err
+	}
+
+	// Add team to database
+	tJSON, _ := json.Marshal(t)
+	_, err := m.db.Exec(ctx, addTeamDBQ, userID, orgName, tJSON)
+	return translateDBError(err)
+}
+
+// AddMember adds a member of the organization to the provided team.
+func (m *Manager) AddMember(ctx context.Context, orgName, teamName, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateOrgAndTeam(orgName, teamName); err != nil {
+		return err
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName); err != nil {
+		return err
+	}
+
+	// Add team member to database
+	_, err := m.db.Exec(ctx, addTeamMemberDBQ, userID, orgName, teamName, userAlias)
+	return translateDBError(err)
+}
+
+// Delete deletes the provided team from the organization given.
+func (m *Manager) Delete(ctx context.Context, orgName, teamName string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateOrgAndTeam(orgName, teamName); err != nil {
+		return err
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName); err != nil {
+		return err
+	}
+
+	// Delete team from database
+	_, err := m.db.Exec(ctx, deleteTeamDBQ, userID, orgName, teamName)
+	return translateDBError(err)
+}
+
+// DeleteMember deletes a member from the provided team.
+func (m *Manager) DeleteMember(ctx context.Context, orgName, teamName, userAlias string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateOrgAndTeam(orgName, teamName); err != nil {
+		return err
+	}
+	if userAlias == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user alias not provided")
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName); err != nil {
+		return err
+	}
+
+	// Delete team member from database
+	_, err := m.db.Exec(ctx, deleteTeamMemberDBQ, userID, orgName, teamName, userAlias)
+	return translateDBError(err)
+}
+
+// DeleteRepositoryPermissions revokes the permissions granted to the provided
+// team on the repository given.
+func (m *Manager) DeleteRepositoryPermissions(ctx context.Context, orgName, teamName, repoName string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateOrgAndTeam(orgName, teamName); err != nil {
+		return err
+	}
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName); err != nil {
+		return err
+	}
+
+	// Delete team repository permissions from database
+	_, err := m.db.Exec(ctx, deleteRepoPermsDBQ, userID, orgName, teamName, repoName)
+	return translateDBError(err)
+}
+
+// GetByOrgJSON returns the teams of the provided organization as a json array.
+// The user doing the request must belong to the organization.
+func (m *Manager) GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if orgName == "" {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+
+	// Get organization teams from database
+	dataJSON, err := util.DBQueryJSON(ctx, m.db, getOrgTeamsDBQ, userID, orgName)
+	if err != nil {
+		return nil, translateDBError(err)
+	}
+	return dataJSON, nil
+}
+
+// Update updates the provided team in the database.
+func (m *Manager) Update(ctx context.Context, orgName, teamName string, t *hub.Team) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateOrgAndTeam(orgName, teamName); err != nil {
+		return err
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName); err != nil {
+		return err
+	}
+
+	// Update team in database
+	tJSON, _ := json.Marshal(t)
+	_, err := m.db.Exec(ctx, updateTeamDBQ, userID, orgName, teamName, tJSON)
+	return translateDBError(err)
+}
+
+// UpdateRepositoryPermissions sets the actions the members of the provided
+// team are allowed to perform on the repository given. Only repository actions
+// can be granted to teams.
+func (m *Manager) UpdateRepositoryPermissions(
+	ctx context.Context,
+	orgName,
+	teamName,
+	repoName string,
+	actions []hub.Action,
+) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if err := validateOrgAndTeam(orgName, teamName); err != nil {
+		return err
+	}
+	if repoName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "repository name not provided")
+	}
+	if len(actions) == 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "actions not provided")
+	}
+	for _, action := range actions {
+		if !authz.IsRepositoryAction(action) {
+			return fmt.Errorf("%w: %s: %s", hub.ErrInvalidInput, "invalid action", action)
+		}
+	}
+
+	// Authorize action
+	if err := m.authorize(ctx, orgName); err != nil {
+		return err
+	}
+
+	// Update team repository permissions in database
+	_, err := m.db.Exec(ctx, updateRepoPermsDBQ, userID, orgName, teamName, repoName, actions)
+	return translateDBError(err)
+}
+
+// authorize checks if the user doing the request is allowed to manage the
+// teams of the provided organization.
+func (m *Manager) authorize(ctx context.Context, orgName string) error {
+	return m.az.Authorize(ctx, &hub.AuthorizeInput{
+		OrganizationName: orgName,
+		UserID:           ctx.Value(hub.UserIDKey).(string),
+		Action:           hub.ManageOrganizationTeams,
+	})
+}
+
+// validateOrgAndTeam checks if the organization and team names provided are
+// valid.
+func validateOrgAndTeam(orgName, teamName string) error {
+	if orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "organization name not provided")
+	}
+	if teamName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "team name not provided")
+	}
+	return nil
+}
+
+// translateDBError translates the errors returned by the database into the
+// errors returned by the manager.
+func translateDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err.Error() {
+	case util.ErrDBInsufficientPrivilege.Error():
+		return hub.ErrInsufficientPrivilege
+	case util.ErrDBNotFound.Error():
+		return hub.ErrNotFound
+	case errNameNotAvailableDB.Error():
+		return ErrNameNotAvailable
+	case errUserNotMemberDB.Error():
+		return ErrUserNotMember
+	}
+	return err
+}
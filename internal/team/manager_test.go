@@ -0,0 +1,606 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/authz"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var azInput = &hub.AuthorizeInput{
+	OrganizationName: "org1",
+	UserID:           "userID",
+	Action:           hub.ManageOrganizationTeams,
+}
+
+func TestAdd(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	team := &hub.Team{Name: "team1"}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Add(context.Background(), "org1", team)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			orgName string
+			team    *hub.Team
+		}{
+			{
+				"organization name not provided",
+				"",
+				team,
+			},
+			{
+				"name not provided",
+				"org1",
+				&hub.Team{},
+			},
+			{
+				"invalid name",
+				"org1",
+				&hub.Team{Name: "_invalid.name"},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.Add(ctx, tc.orgName, tc.team)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.Add(ctx, "org1", team)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				errNameNotAvailableDB,
+				ErrNameNotAvailable,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addTeamDBQ, "userID", "org1", mock.Anything).Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, azInput).Return(nil)
+				m := NewManager(db, az)
+
+				err := m.Add(ctx, "org1", team)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addTeamDBQ, "userID", "org1", mock.Anything).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.Add(ctx, "org1", team)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestAddMember(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.AddMember(context.Background(), "org1", "team1", "user1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg    string
+			orgName   string
+			teamName  string
+			userAlias string
+		}{
+			{
+				"organization name not provided",
+				"",
+				"team1",
+				"user1",
+			},
+			{
+				"team name not provided",
+				"org1",
+				"",
+				"user1",
+			},
+			{
+				"user alias not provided",
+				"org1",
+				"team1",
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.AddMember(ctx, tc.orgName, tc.teamName, tc.userAlias)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.AddMember(ctx, "org1", "team1", "user1")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+			{
+				errUserNotMemberDB,
+				ErrUserNotMember,
+			},
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addTeamMemberDBQ, "userID", "org1", "team1", "user1").Return(tc.dbErr)
+				az := &authz.AuthorizerMock{}
+				az.On("Authorize", ctx, azInput).Return(nil)
+				m := NewManager(db, az)
+
+				err := m.AddMember(ctx, "org1", "team1", "user1")
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+				az.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addTeamMemberDBQ, "userID", "org1", "team1", "user1").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.AddMember(ctx, "org1", "team1", "user1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), "org1", "team1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		err := m.Delete(ctx, "org1", "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "team name not provided")
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.Delete(ctx, "org1", "team1")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteTeamDBQ, "userID", "org1", "team1").Return(util.ErrDBNotFound)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.Delete(ctx, "org1", "team1")
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteTeamDBQ, "userID", "org1", "team1").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.Delete(ctx, "org1", "team1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestDeleteMember(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteMember(context.Background(), "org1", "team1", "user1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		err := m.DeleteMember(ctx, "org1", "team1", "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "user alias not provided")
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.DeleteMember(ctx, "org1", "team1", "user1")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteTeamMemberDBQ, "userID", "org1", "team1", "user1").Return(tests.ErrFakeDB)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.DeleteMember(ctx, "org1", "team1", "user1")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteTeamMemberDBQ, "userID", "org1", "team1", "user1").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.DeleteMember(ctx, "org1", "team1", "user1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestDeleteRepositoryPermissions(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteRepositoryPermissions(context.Background(), "org1", "team1", "repo1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		err := m.DeleteRepositoryPermissions(ctx, "org1", "team1", "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "repository name not provided")
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.DeleteRepositoryPermissions(ctx, "org1", "team1", "repo1")
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteRepoPermsDBQ, "userID", "org1", "team1", "repo1").Return(util.ErrDBNotFound)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.DeleteRepositoryPermissions(ctx, "org1", "team1", "repo1")
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteRepoPermsDBQ, "userID", "org1", "team1", "repo1").Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.DeleteRepositoryPermissions(ctx, "org1", "team1", "repo1")
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestGetByOrgJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetByOrgJSON(context.Background(), "org1")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.GetByOrgJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgTeamsDBQ, "userID", "org1").Return(nil, util.ErrDBInsufficientPrivilege)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetByOrgJSON(ctx, "org1")
+		assert.Equal(t, hub.ErrInsufficientPrivilege, err)
+		assert.Nil(t, dataJSON)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getOrgTeamsDBQ, "userID", "org1").Return([]byte("dataJSON"), nil)
+		m := NewManager(db, nil)
+
+		dataJSON, err := m.GetByOrgJSON(ctx, "org1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	team := &hub.Team{DisplayName: "Team 1"}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.Update(context.Background(), "org1", "team1", team)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		err := m.Update(ctx, "", "team1", team)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "organization name not provided")
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.Update(ctx, "org1", "team1", team)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateTeamDBQ, "userID", "org1", "team1", mock.Anything).Return(util.ErrDBNotFound)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.Update(ctx, "org1", "team1", team)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateTeamDBQ, "userID", "org1", "team1", mock.Anything).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.Update(ctx, "org1", "team1", team)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
+
+func TestUpdateRepositoryPermissions(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	actions := []hub.Action{hub.UpdateOrganizationRepository}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.UpdateRepositoryPermissions(context.Background(), "org1", "team1", "repo1", actions)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg   string
+			repoName string
+			actions  []hub.Action
+		}{
+			{
+				"repository name not provided",
+				"",
+				actions,
+			},
+			{
+				"actions not provided",
+				"repo1",
+				nil,
+			},
+			{
+				"invalid action",
+				"repo1",
+				[]hub.Action{hub.UpdateOrganization},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				err := m.UpdateRepositoryPermissions(ctx, "org1", "team1", tc.repoName, tc.actions)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("authorization failed", func(t *testing.T) {
+		t.Parallel()
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(tests.ErrFake)
+		m := NewManager(nil, az)
+
+		err := m.UpdateRepositoryPermissions(ctx, "org1", "team1", "repo1", actions)
+		assert.Equal(t, tests.ErrFake, err)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateRepoPermsDBQ, "userID", "org1", "team1", "repo1", actions).
+			Return(util.ErrDBNotFound)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.UpdateRepositoryPermissions(ctx, "org1", "team1", "repo1", actions)
+		assert.Equal(t, hub.ErrNotFound, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+
+	t.Run("database query succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateRepoPermsDBQ, "userID", "org1", "team1", "repo1", actions).Return(nil)
+		az := &authz.AuthorizerMock{}
+		az.On("Authorize", ctx, azInput).Return(nil)
+		m := NewManager(db, az)
+
+		err := m.UpdateRepositoryPermissions(ctx, "org1", "team1", "repo1", actions)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		az.AssertExpectations(t)
+	})
+}
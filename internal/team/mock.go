@@ -0,0 +1,68 @@
+package team
+
+import (
+	"context"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/stretchr/testify/mock"
+)
+
+// ManagerMock is a mock implementation of the TeamManager interface.
+type ManagerMock struct {
+	mock.Mock
+}
+
+// Add implements the TeamManager interface.
+func (m *ManagerMock) Add(ctx context.Context, orgName string, t *hub.Team) error {
+	args := m.Called(ctx, orgName, t)
+	return args.Error(0)
+}
+
+// AddMember implements the TeamManager interface.
+func (m *ManagerMock) AddMember(ctx context.Context, orgName, teamName, userAlias string) error {
+	args := m.Called(ctx, orgName, teamName, userAlias)
+	return args.Error(0)
+}
+
+// Delete implements the TeamManager interface.
+func (m *ManagerMock) Delete(ctx context.Context, orgName, teamName string) error {
+	args := m.Called(ctx, orgName, teamName)
+	return args.Error(0)
+}
+
+// DeleteMember implements the TeamManager interface.
+func (m *ManagerMock) DeleteMember(ctx context.Context, orgName, teamName, userAlias string) error {
+	args := m.Called(ctx, orgName, teamName, userAlias)
+	return args.Error(0)
+}
+
+// DeleteRepositoryPermissions implements the TeamManager interface.
+func (m *ManagerMock) DeleteRepositoryPermissions(ctx context.Context, orgName, teamName, repoName string) error {
+	args := m.Called(ctx, orgName, teamName, repoName)
+	return args.Error(0)
+}
+
+// GetByOrgJSON implements the TeamManager interface.
+func (m *ManagerMock) GetByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
+	args := m.Called(ctx, orgName)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// Update implements the TeamManager interface.
+func (m *ManagerMock) Update(ctx context.Context, orgName, teamName string, t *hub.Team) error {
+	args := m.Called(ctx, orgName, teamName, t)
+	return args.Error(0)
+}
+
+// UpdateRepositoryPermissions implements the TeamManager interface.
+func (m *ManagerMock) UpdateRepositoryPermissions(
+	ctx context.Context,
+	orgName,
+	teamName,
+	repoName string,
+	actions []hub.Action,
+) error {
+	args := m.Called(ctx, orgName, teamName, repoName, actions)
+	return args.Error(0)
+}
@@ -0,0 +1,195 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/img"
+	"github.com/rs/zerolog/log"
+)
+
+// dryRunTimeout represents the maximum amount of time a repository can be
+// processed in dry-run mode. It must be lower than the write timeout of the
+// http server, as dry-runs are usually triggered from the API.
+const dryRunTimeout = 25 * time.Second
+
+// errDryRunTimeout indicates that the repository could not be processed in
+// dry-run mode in the time allowed.
+var errDryRunTimeout = errors.New("repository dry-run timed out")
+
+// DryRunner is a hub.TrackerDryRunner implementation that processes
+// repositories using the tracker without persisting anything. Packages are not
+// registered, images are downloaded but not stored and the errors found are
+// returned instead of being saved in the database.
+type DryRunner struct {
+	svc *hub.TrackerServices
+}
+
+// NewDryRunner creates a new DryRunner instance.
+func NewDryRunner(svc *hub.TrackerServices) *DryRunner {
+	return &DryRunner{
+		svc: svc,
+	}
+}
+
+// DryRun implements the hub.TrackerDryRunner interface.
+func (dr *DryRunner) DryRun(ctx context.Context, r *hub.Repository) (*hub.TrackerDryRunResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+	defer cancel()
+
+	// Setup tracker services for this dry-run
+	ec := &dryRunErrorsCollector{}
+	svc := *dr.svc
+	svc.Ctx = ctx
+	svc.Ec = ec
+	svc.Is = &dryRunImageStore{svc: &svc}
+	svc.Sv = &dryRunSignatureVerifier{}
+
+	// Process repository
+	logger := log.With().Str("repo", r.Name).Str("kind", hub.GetKindName(r.Kind)).Bool("dryRun", true).Logger()
+	t := New(&svc, r, logger)
+	packages, err := t.dryRun()
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = errDryRunTimeout
+		}
+		ec.Append(r.RepositoryID, &hub.RepositoryError{
+			Class:   hub.RepositoryErrorClassRepository,
+			Message: err.Error(),
+		})
+	}
+
+	return &hub.TrackerDryRunResult{
+		Packages: packages,
+		Errors:   ec.get(),
+	}, nil
+}
+
+// dryRun processes the repository like a regular tracking run does, returning
+// the packages that would be registered instead of registering them.
+func (t *Tracker) dryRun() ([]*hub.TrackerDryRunPackage, error) {
+	// Clone repository when applicable and get its metadata
+	tmpDir, packagesPath, err := t.cloneRepository()
+	if err != nil {
+		return nil, fmt.Errorf("error cloning repository: %w", err)
+	}
+	if tmpDir != "" {
+		defer os.RemoveAll(tmpDir)
+	}
+	t.basePath = filepath.Join(tmpDir, packagesPath)
+	t.md = t.getRepositoryMetadata()
+
+	// Get packages available in repository
+	packagesAvailable, err := t.getPackagesAvailable()
+	if err != nil {
+		return nil, fmt.Errorf("error getting packages available: %w", err)
+	}
+	pruneVersions(t.r.RetentionPolicy, t.md, packagesAvailable, time.Now())
+
+	// Prepare the list of packages that would be registered
+	packages := make([]*hub.TrackerDryRunPackage, 0, len(packagesAvailable))
+	for _, p := range packagesAvailable {
+		if shouldIgnorePackage(t.md, p.Name, p.Version) {
+			continue
+		}
+		packages = append(packages, &hub.TrackerDryRunPackage{
+			Name:        p.Name,
+			Version:     p.Version,
+			DisplayName: p.DisplayName,
+			Description: p.Description,
+		})
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name != packages[j].Name {
+			return packages[i].Name < packages[j].Name
+		}
+		return packages[i].Version < packages[j].Version
+	})
+
+	return packages, nil
+}
+
+// dryRunErrorsCollector is a hub.ErrorsCollector implementation that keeps
+// the errors collected in memory, so that they can be returned to the caller
+// of the dry-run.
+type dryRunErrorsCollector struct {
+	mu     sync.Mutex
+	errors []*hub.RepositoryError
+}
+
+// Append implements the hub.ErrorsCollector interface.
+func (c *dryRunErrorsCollector) Append(repositoryID string, err *hub.RepositoryError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err.TS == 0 {
+		err.TS = time.Now().Unix()
+	}
+	c.errors = append(c.errors, err)
+}
+
+// Flush implements the hub.ErrorsCollector interface.
+func (c *dryRunErrorsCollector) Flush() {}
+
+// Init implements the hub.ErrorsCollector interface.
+func (c *dryRunErrorsCollector) Init(repositoryID string) {}
+
+// get returns the errors collected sorted by message.
+func (c *dryRunErrorsCollector) get() []*hub.RepositoryError {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	errs := make([]*hub.RepositoryError, len(c.errors))
+	copy(errs, c.errors)
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Message < errs[j].Message
+	})
+	return errs
+}
+
+// dryRunImageStore is an img.Store implementation used in dry-run mode. Images
+// are downloaded to check that they are available, but they are not stored.
+type dryRunImageStore struct {
+	svc *hub.TrackerServices
+}
+
+// DownloadAndSaveImage implements the img.Store interface.
+func (s *dryRunImageStore) DownloadAndSaveImage(ctx context.Context, imageURL string) (string, error) {
+	githubToken := s.svc.Cfg.GetString("creds.githubToken")
+	if _, err := img.Download(ctx, s.svc.Hc, githubToken, s.svc.GithubRL, imageURL); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// GetImage implements the img.Store interface.
+func (s *dryRunImageStore) GetImage(ctx context.Context, imageID, version string) ([]byte, error) {
+	return nil, errors.New("images cannot be retrieved in dry-run mode")
+}
+
+// SaveImage implements the img.Store interface.
+func (s *dryRunImageStore) SaveImage(ctx context.Context, data []byte) (string, error) {
+	return "", nil
+}
+
+// dryRunSignatureVerifier is a hub.OCISignatureVerifier implementation used in
+// dry-run mode. Signatures and provenance attestations are not part of the
+// dry-run results, so they are not verified.
+type dryRunSignatureVerifier struct{}
+
+// Verify implements the hub.OCISignatureVerifier interface.
+func (v *dryRunSignatureVerifier) Verify(ctx context.Context, r *hub.Repository, version string) ([]*hub.Signature, error) {
+	return nil, nil
+}
+
+// VerifyProvenance implements the hub.OCISignatureVerifier interface.
+func (v *dryRunSignatureVerifier) VerifyProvenance(ctx context.Context, r *hub.Repository, version string) (*hub.Provenance, error) {
+	return nil, nil
+}
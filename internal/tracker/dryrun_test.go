@@ -0,0 +1,157 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	r1 := &hub.Repository{
+		Kind: hub.Helm,
+		URL:  "https://repo.url",
+	}
+	p1v1 := &hub.Package{
+		Name:        "pkg1",
+		Version:     "1.0.0",
+		DisplayName: "Package 1",
+		Description: "description",
+		Repository:  r1,
+	}
+	p1v2 := &hub.Package{
+		Name:       "pkg1",
+		Version:    "2.0.0",
+		Repository: r1,
+	}
+	p2v1 := &hub.Package{
+		Name:       "pkg2",
+		Version:    "1.0.0",
+		Repository: r1,
+	}
+
+	t.Run("error cloning repository", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		r := &hub.Repository{
+			Kind: hub.OPA,
+			URL:  "https://github.com/org1/repo1",
+		}
+		sw := newServicesWrapper()
+		sw.rc.On("CloneRepository", mock.Anything, r).Return("", "", tests.ErrFake)
+
+		// Run test and check expectations
+		result, err := NewDryRunner(sw.svc).DryRun(ctx, r)
+		require.NoError(t, err)
+		assert.Empty(t, result.Packages)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, hub.RepositoryErrorClassRepository, result.Errors[0].Class)
+		assert.Equal(t, "error cloning repository: fake error for tests", result.Errors[0].Message)
+		assert.NotZero(t, result.Errors[0].TS)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("error getting packages available", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.src.On("GetPackagesAvailable").Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		result, err := NewDryRunner(sw.svc).DryRun(ctx, r1)
+		require.NoError(t, err)
+		assert.Empty(t, result.Packages)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "error getting packages available: fake error for tests", result.Errors[0].Message)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("packages and errors returned, nothing persisted", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(&hub.RepositoryMetadata{
+			Ignore: []*hub.RepositoryIgnoreEntry{
+				{
+					Name:    p1v2.Name,
+					Version: p1v2.Version,
+				},
+			},
+		}, nil)
+		var i *hub.TrackerSourceInput
+		sw.svc.SetupTrackerSource = func(input *hub.TrackerSourceInput) hub.TrackerSource {
+			i = input
+			return sw.src
+		}
+		sw.src.On("GetPackagesAvailable").
+			Run(func(args mock.Arguments) {
+				i.Svc.Ec.Append(i.Repository.RepositoryID, &hub.RepositoryError{
+					Class:   hub.RepositoryErrorClassPackage,
+					Message: "error preparing package",
+				})
+				imageID, err := i.Svc.Is.SaveImage(i.Svc.Ctx, []byte("image"))
+				assert.Empty(t, imageID)
+				assert.NoError(t, err)
+			}).
+			Return(map[string]*hub.Package{
+				pkg.BuildKey(p2v1): p2v1,
+				pkg.BuildKey(p1v2): p1v2,
+				pkg.BuildKey(p1v1): p1v1,
+			}, nil)
+
+		// Run test and check expectations
+		result, err := NewDryRunner(sw.svc).DryRun(ctx, r1)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.TrackerDryRunPackage{
+			{
+				Name:        "pkg1",
+				Version:     "1.0.0",
+				DisplayName: "Package 1",
+				Description: "description",
+			},
+			{
+				Name:    "pkg2",
+				Version: "1.0.0",
+			},
+		}, result.Packages)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, hub.RepositoryErrorClassPackage, result.Errors[0].Class)
+		assert.Equal(t, "error preparing package", result.Errors[0].Message)
+		sw.assertExpectations(t)
+	})
+}
+
+func TestDryRunImageStore(t *testing.T) {
+	t.Run("error downloading image", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		sw.hc.On("Do", mock.Anything).Return(nil, tests.ErrFake)
+		s := &dryRunImageStore{svc: sw.svc}
+
+		imageID, err := s.DownloadAndSaveImage(context.Background(), "https://image.url")
+		assert.Equal(t, tests.ErrFake, err)
+		assert.Empty(t, imageID)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("image in data url, nothing stored", func(t *testing.T) {
+		t.Parallel()
+		sw := newServicesWrapper()
+		s := &dryRunImageStore{svc: sw.svc}
+
+		imageID, err := s.DownloadAndSaveImage(context.Background(), "data:image/png;base64,aW1hZ2U=")
+		assert.NoError(t, err)
+		assert.Empty(t, imageID)
+		sw.assertExpectations(t)
+	})
+}
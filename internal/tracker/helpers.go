@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tracker/source/crossplane"
 	"github.com/artifacthub/hub/internal/tracker/source/falco"
 	"github.com/artifacthub/hub/internal/tracker/source/generic"
 	"github.com/artifacthub/hub/internal/tracker/source/helm"
 	"github.com/artifacthub/hub/internal/tracker/source/helmplugin"
 	"github.com/artifacthub/hub/internal/tracker/source/krew"
+	"github.com/artifacthub/hub/internal/tracker/source/oci"
 	"github.com/artifacthub/hub/internal/tracker/source/olm"
 	"github.com/artifacthub/hub/internal/tracker/source/tekton"
+	"github.com/artifacthub/hub/internal/tracker/source/wasm"
 	"github.com/spf13/viper"
 )
 
@@ -29,7 +34,9 @@ const (
 //   kinds will be returned.
 // - Otherwise, all the repositories will be returned.
 //
-// NOTE: disabled repositories will be filtered out.
+// NOTE: disabled repositories, as well as the ones whose tracking has been
+// paused or is not due yet according to their tracking schedule, will be
+// filtered out.
 func GetRepositories(
 	ctx context.Context,
 	cfg *viper.Viper,
@@ -42,11 +49,11 @@ func GetRepositories(
 	switch {
 	case len(reposNames) > 0:
 		for _, name := range reposNames {
-			repo, err := rm.GetByName(ctx, name, true)
+			r, err := rm.GetByName(ctx, name, true)
 			if err != nil {
 				return nil, fmt.Errorf("error getting repository %s: %w", name, err)
 			}
-			repos = append(repos, repo)
+			repos = append(repos, r)
 		}
 	case len(reposKinds) > 0:
 		for _, kindName := range reposKinds {
@@ -68,11 +75,12 @@ func GetRepositories(
 		}
 	}
 
-	// Filter out disabled repositories
+	// Filter out disabled, paused and not due repositories
+	now := time.Now()
 	var reposFiltered []*hub.Repository
-	for _, repo := range repos {
-		if !repo.Disabled {
-			reposFiltered = append(reposFiltered, repo)
+	for _, r := range repos {
+		if !r.Disabled && r.TrackingEnabled && repo.IsTrackingDue(r, now) {
+			reposFiltered = append(reposFiltered, r)
 		}
 	}
 
@@ -84,6 +92,8 @@ func GetRepositories(
 func SetupSource(i *hub.TrackerSourceInput) hub.TrackerSource {
 	var source hub.TrackerSource
 	switch i.Repository.Kind {
+	case hub.CrossplaneConfiguration:
+		source = crossplane.NewTrackerSource(i)
 	case hub.Falco:
 		// Temporary solution to maintain backwards compatibility with
 		// the only Falco rules repository registered at the moment in
@@ -106,6 +116,10 @@ func SetupSource(i *hub.TrackerSourceInput) hub.TrackerSource {
 		source = generic.NewTrackerSource(i)
 	case hub.TektonTask:
 		source = tekton.NewTrackerSource(i)
+	case hub.OCIArtifact:
+		source = oci.NewTrackerSource(i)
+	case hub.Wasm:
+		source = oci.NewTrackerSource(i, oci.WithMetadataExtractor(&wasm.MetadataExtractor{}))
 	}
 	return source
 }
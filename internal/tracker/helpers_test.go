@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/repo"
@@ -17,17 +18,32 @@ import (
 func TestGetRepositories(t *testing.T) {
 	ctx := context.Background()
 	repo1 := &hub.Repository{
-		Name: "repo1",
-		Kind: hub.Helm,
+		Name:            "repo1",
+		Kind:            hub.Helm,
+		TrackingEnabled: true,
 	}
 	repo2 := &hub.Repository{
-		Name: "repo2",
-		Kind: hub.OLM,
+		Name:            "repo2",
+		Kind:            hub.OLM,
+		TrackingEnabled: true,
 	}
 	repo3 := &hub.Repository{
-		Name:     "repo3",
-		Kind:     hub.OPA,
-		Disabled: true,
+		Name:            "repo3",
+		Kind:            hub.OPA,
+		Disabled:        true,
+		TrackingEnabled: true,
+	}
+	repo4 := &hub.Repository{
+		Name:            "repo4",
+		Kind:            hub.Helm,
+		TrackingEnabled: false,
+	}
+	repo5 := &hub.Repository{
+		Name:             "repo5",
+		Kind:             hub.Helm,
+		TrackingEnabled:  true,
+		TrackingSchedule: "@daily",
+		LastTrackingTS:   time.Now().Unix(),
 	}
 
 	t.Run("error getting repository by name", func(t *testing.T) {
@@ -143,7 +159,7 @@ func TestGetRepositories(t *testing.T) {
 
 		// Setup expectations
 		rm := &repo.ManagerMock{}
-		rm.On("GetAll", ctx, true).Return([]*hub.Repository{repo1, repo2, repo3}, nil)
+		rm.On("GetAll", ctx, true).Return([]*hub.Repository{repo1, repo2, repo3, repo4, repo5}, nil)
 
 		// Run test and check expectations
 		cfg := viper.New()
@@ -214,6 +230,24 @@ func TestSetupSource(t *testing.T) {
 			},
 			"*tekton.TrackerSource",
 		},
+		{
+			&hub.Repository{
+				Kind: hub.Wasm,
+			},
+			"*oci.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.OCIArtifact,
+			},
+			"*oci.TrackerSource",
+		},
+		{
+			&hub.Repository{
+				Kind: hub.CrossplaneConfiguration,
+			},
+			"*crossplane.TrackerSource",
+		},
 	}
 	for i, tc := range testCases {
 		tc := tc
@@ -0,0 +1,66 @@
+package tracker
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+)
+
+// pruneVersions removes from the packages available provided the versions
+// that should not be indexed according to the retention policy, returning the
+// versions removed. Ignored versions are not taken into account. The latest
+// version of each package is always kept, as well as the versions that are not
+// valid semver. Versions whose creation time is unknown are never pruned by
+// age.
+func pruneVersions(
+	policy *hub.RetentionPolicy,
+	md *hub.RepositoryMetadata,
+	packagesAvailable map[string]*hub.Package,
+	now time.Time,
+) []*hub.Package {
+	if policy == nil || (policy.MaxVersions <= 0 && policy.MaxAgeDays <= 0) {
+		return nil
+	}
+
+	// Group packages versions by name
+	type version struct {
+		p  *hub.Package
+		sv *semver.Version
+	}
+	versionsByName := make(map[string][]*version)
+	for _, p := range packagesAvailable {
+		if shouldIgnorePackage(md, p.Name, p.Version) {
+			continue
+		}
+		sv, err := semver.NewVersion(p.Version)
+		if err != nil {
+			continue
+		}
+		versionsByName[p.Name] = append(versionsByName[p.Name], &version{p: p, sv: sv})
+	}
+
+	// Prune versions not allowed by the policy
+	var minTS int64
+	if policy.MaxAgeDays > 0 {
+		minTS = now.AddDate(0, 0, -policy.MaxAgeDays).Unix()
+	}
+	var pruned []*hub.Package
+	for _, versions := range versionsByName {
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i].sv.GreaterThan(versions[j].sv)
+		})
+		for i, v := range versions[1:] {
+			tooMany := policy.MaxVersions > 0 && i+1 >= policy.MaxVersions
+			tooOld := minTS > 0 && v.p.TS > 0 && v.p.TS < minTS
+			if tooMany || tooOld {
+				delete(packagesAvailable, pkg.BuildKey(v.p))
+				pruned = append(pruned, v.p)
+			}
+		}
+	}
+
+	return pruned
+}
@@ -0,0 +1,104 @@
+package tracker
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneVersions(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 {
+		return now.AddDate(0, 0, -days).Unix()
+	}
+
+	testCases := []struct {
+		desc             string
+		policy           *hub.RetentionPolicy
+		md               *hub.RepositoryMetadata
+		packages         []*hub.Package
+		expectedVersions []string
+	}{
+		{
+			"no policy",
+			nil,
+			nil,
+			[]*hub.Package{
+				{Name: "pkg1", Version: "1.0.0"},
+				{Name: "pkg1", Version: "2.0.0"},
+			},
+			[]string{"pkg1@1.0.0", "pkg1@2.0.0"},
+		},
+		{
+			"keep last versions of each package",
+			&hub.RetentionPolicy{MaxVersions: 2},
+			nil,
+			[]*hub.Package{
+				{Name: "pkg1", Version: "1.0.0"},
+				{Name: "pkg1", Version: "1.10.0"},
+				{Name: "pkg1", Version: "1.9.0"},
+				{Name: "pkg2", Version: "0.1.0"},
+			},
+			[]string{"pkg1@1.10.0", "pkg1@1.9.0", "pkg2@0.1.0"},
+		},
+		{
+			"drop versions older than max age",
+			&hub.RetentionPolicy{MaxAgeDays: 30},
+			nil,
+			[]*hub.Package{
+				{Name: "pkg1", Version: "1.0.0", TS: daysAgo(60)},
+				{Name: "pkg1", Version: "1.1.0", TS: daysAgo(10)},
+				{Name: "pkg1", Version: "1.2.0"},
+			},
+			[]string{"pkg1@1.1.0", "pkg1@1.2.0"},
+		},
+		{
+			"latest version is always kept",
+			&hub.RetentionPolicy{MaxAgeDays: 30},
+			nil,
+			[]*hub.Package{
+				{Name: "pkg1", Version: "1.0.0", TS: daysAgo(90)},
+				{Name: "pkg1", Version: "1.1.0", TS: daysAgo(60)},
+			},
+			[]string{"pkg1@1.1.0"},
+		},
+		{
+			"ignored and invalid versions are not taken into account",
+			&hub.RetentionPolicy{MaxVersions: 1},
+			&hub.RepositoryMetadata{
+				Ignore: []*hub.RepositoryIgnoreEntry{
+					{Name: "pkg1", Version: "2.0.0"},
+				},
+			},
+			[]*hub.Package{
+				{Name: "pkg1", Version: "1.0.0"},
+				{Name: "pkg1", Version: "1.1.0"},
+				{Name: "pkg1", Version: "2.0.0"},
+				{Name: "pkg1", Version: "latest"},
+			},
+			[]string{"pkg1@1.1.0", "pkg1@2.0.0", "pkg1@latest"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			packages := make(map[string]*hub.Package, len(tc.packages))
+			for _, p := range tc.packages {
+				packages[pkg.BuildKey(p)] = p
+			}
+			pruned := pruneVersions(tc.policy, tc.md, packages, now)
+			versions := make([]string, 0, len(packages))
+			for key := range packages {
+				versions = append(versions, key)
+			}
+			sort.Strings(versions)
+			assert.Equal(t, tc.expectedVersions, versions)
+			assert.Len(t, pruned, len(tc.packages)-len(tc.expectedVersions))
+		})
+	}
+}
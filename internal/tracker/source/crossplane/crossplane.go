@@ -0,0 +1,400 @@
+package crossplane
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/ghodss/yaml"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	concurrency = 10
+
+	// packageFile represents the name of the file that holds the package
+	// metadata (crossplane.yaml) and its objects in the package image.
+	packageFile = "package.yaml"
+
+	descriptionAnnotation  = "meta.crossplane.io/description"
+	friendlyNameAnnotation = "friendly-name.meta.crossplane.io"
+	iconURIAnnotation      = "meta.crossplane.io/iconURI"
+	licenseAnnotation      = "meta.crossplane.io/license"
+	maintainerAnnotation   = "meta.crossplane.io/maintainer"
+	readmeAnnotation       = "meta.crossplane.io/readme"
+	sourceAnnotation       = "meta.crossplane.io/source"
+
+	configurationKind = "Configuration"
+	compositionKind   = "Composition"
+	crdKind           = "CustomResourceDefinition"
+	xrdKind           = "CompositeResourceDefinition"
+)
+
+// examplesPathRE matches the paths of the examples files in the package image.
+var examplesPathRE = regexp.MustCompile(`^(\.up/)?examples/.+\.ya?ml$`)
+
+// TrackerSource is a hub.TrackerSource implementation for repositories of
+// Crossplane configuration packages stored in OCI registries. Each repository
+// holds a single package, and each of the tags that are valid semver versions
+// is considered a version of it.
+type TrackerSource struct {
+	i  *hub.TrackerSourceInput
+	tg hub.OCITagsGetter
+	mg hub.OCIManifestGetter
+	fg hub.OCIFilesGetter
+	sg hub.OCISBOMGetter
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.tg == nil {
+		s.tg = &repo.OCITagsGetter{}
+	}
+	if s.mg == nil {
+		s.mg = &repo.OCIManifestGetter{}
+	}
+	if s.fg == nil {
+		s.fg = &repo.OCIFilesGetter{}
+	}
+	if s.sg == nil {
+		s.sg = &repo.OCISBOMGetter{}
+	}
+	return s
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	var mu sync.Mutex
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get versions (tags) available in the repository
+	versions, err := s.tg.Tags(s.i.Svc.Ctx, s.i.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository available versions: %w", err)
+	}
+
+	// Prepare and store package versions
+	name := path.Base(s.i.Repository.URL)
+	limiter := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, version := range versions {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			wg.Wait()
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func(version string) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			p, err := s.preparePackage(name, version)
+			if err != nil {
+				s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error preparing package: %w", err))
+				return
+			}
+			mu.Lock()
+			packagesAvailable[pkg.BuildKey(p)] = p
+			mu.Unlock()
+		}(version)
+	}
+	wg.Wait()
+
+	return packagesAvailable, nil
+}
+
+// preparePackage prepares a package version using the content of the package
+// image tagged with the version provided.
+func (s *TrackerSource) preparePackage(name, version string) (*hub.Package, error) {
+	// Parse package version
+	sv, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package version: %w", err)
+	}
+
+	// Get image digest
+	_, digest, err := s.mg.Manifest(s.i.Svc.Ctx, s.i.Repository, version)
+	if err != nil {
+		return nil, fmt.Errorf("error getting manifest: %w", err)
+	}
+
+	// Prepare package version
+	p := &hub.Package{
+		Name:       name,
+		Version:    sv.String(),
+		Digest:     digest,
+		ContentURL: s.i.Repository.URL + ":" + version,
+		Repository: s.i.Repository,
+	}
+
+	// If the package version is not registered yet or if it needs to be
+	// registered again, we need to enrich the package with the information
+	// available in the package image. Otherwise, the minimal version of the
+	// package prepared above is enough.
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
+	registeredDigest, ok := s.i.PackagesRegistered[pkg.BuildKey(p)]
+	if ok && registeredDigest == digest && !bypassDigestCheck {
+		return p, nil
+	}
+	files, err := s.fg.Files(s.i.Svc.Ctx, s.i.Repository, version, func(name string) bool {
+		return name == packageFile || examplesPathRE.MatchString(name)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting package files: %w", err)
+	}
+	packageData, ok := files[packageFile]
+	if !ok {
+		return nil, errors.New("package file not found")
+	}
+	objects, err := splitYAMLDocuments(packageData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package file: %w", err)
+	}
+	if err := enrichPackageFromObjects(p, objects); err != nil {
+		return nil, err
+	}
+	delete(files, packageFile)
+	p.CRDsExamples, err = getExamples(files)
+	if err != nil {
+		return nil, fmt.Errorf("invalid examples: %w", err)
+	}
+
+	// Store logo when available
+	if p.LogoURL != "" {
+		logoImageID, err := s.i.Svc.Is.DownloadAndSaveImage(s.i.Svc.Ctx, p.LogoURL)
+		if err == nil {
+			p.LogoImageID = logoImageID
+		} else {
+			s.warn(hub.RepositoryErrorClassImage, name, version, fmt.Errorf("error getting logo image %s: %w", p.LogoURL, err))
+			p.LogoURL = ""
+		}
+	}
+
+	// Verify signatures
+	if err := source.VerifySignatures(s.i, p, version); err != nil {
+		s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error verifying signatures: %w", err))
+	}
+
+	// Verify SLSA provenance attestation
+	if err := source.VerifyProvenance(s.i, p, version); err != nil {
+		s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error verifying provenance: %w", err))
+	}
+
+	// Get SBOMs attached to the artifact
+	sboms, err := s.sg.SBOMs(s.i.Svc.Ctx, s.i.Repository, version)
+	if err == nil {
+		p.SBOMs = sboms
+	} else {
+		s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error getting sboms: %w", err))
+	}
+
+	return p, nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
+	err = fmt.Errorf("%w (package: %s version: %s)", err, pkgName, pkgVersion)
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
+}
+
+// object represents a Kubernetes object defined in the package file. Only the
+// fields used to prepare the package are included.
+type object struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		// Configuration
+		Crossplane struct {
+			Version string `json:"version"`
+		} `json:"crossplane"`
+		DependsOn []map[string]interface{} `json:"dependsOn"`
+
+		// CRD and XRD
+		Names struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Versions []struct {
+			Name string `json:"name"`
+		} `json:"versions"`
+
+		// Composition
+		CompositeTypeRef struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		} `json:"compositeTypeRef"`
+	} `json:"spec"`
+}
+
+// enrichPackageFromObjects adds some extra information to the package from the
+// objects defined in the package file: the configuration metadata, the CRDs
+// and XRDs provided and the compositions available.
+func enrichPackageFromObjects(p *hub.Package, objects [][]byte) error {
+	var cfg *object
+	var compositions []interface{}
+	for _, data := range objects {
+		var o *object
+		if err := yaml.Unmarshal(data, &o); err != nil {
+			return fmt.Errorf("invalid object: %w", err)
+		}
+		if o == nil {
+			continue
+		}
+		switch o.Kind {
+		case configurationKind:
+			if strings.HasPrefix(o.APIVersion, "meta.pkg.crossplane.io/") {
+				cfg = o
+			}
+		case crdKind, xrdKind:
+			var version string
+			if len(o.Spec.Versions) > 0 {
+				version = o.Spec.Versions[0].Name
+			}
+			p.CRDs = append(p.CRDs, map[string]interface{}{
+				"kind":        o.Spec.Names.Kind,
+				"version":     version,
+				"name":        o.Metadata.Name,
+				"displayName": o.Spec.Names.Kind,
+				"description": o.Metadata.Annotations[descriptionAnnotation],
+			})
+		case compositionKind:
+			compositions = append(compositions, map[string]interface{}{
+				"name":             o.Metadata.Name,
+				"compositeTypeRef": o.Spec.CompositeTypeRef.APIVersion + "/" + o.Spec.CompositeTypeRef.Kind,
+			})
+		}
+	}
+	if cfg == nil {
+		return errors.New("configuration metadata not found")
+	}
+
+	// Configuration metadata
+	annotations := cfg.Metadata.Annotations
+	p.DisplayName = annotations[friendlyNameAnnotation]
+	if p.DisplayName == "" {
+		p.DisplayName = cfg.Metadata.Name
+	}
+	p.Description = strings.TrimSpace(annotations[descriptionAnnotation])
+	p.Readme = annotations[readmeAnnotation]
+	p.License = annotations[licenseAnnotation]
+	p.LogoURL = annotations[iconURIAnnotation]
+	if v := annotations[sourceAnnotation]; v != "" {
+		if !strings.Contains(v, "://") {
+			v = "https://" + v
+		}
+		p.Links = append(p.Links, &hub.Link{Name: "source", URL: v})
+	}
+	for _, maintainer := range strings.Split(annotations[maintainerAnnotation], ",") {
+		maintainer = strings.TrimSpace(maintainer)
+		if maintainer == "" {
+			continue
+		}
+		if addr, err := mail.ParseAddress(maintainer); err == nil {
+			p.Maintainers = append(p.Maintainers, &hub.Maintainer{
+				Name:  addr.Name,
+				Email: addr.Address,
+			})
+		}
+	}
+
+	// Kind specific data
+	p.Data = map[string]interface{}{}
+	if cfg.Spec.Crossplane.Version != "" {
+		p.Data["crossplaneVersion"] = cfg.Spec.Crossplane.Version
+	}
+	if len(cfg.Spec.DependsOn) > 0 {
+		dependsOn := make([]interface{}, 0, len(cfg.Spec.DependsOn))
+		for _, dep := range cfg.Spec.DependsOn {
+			dependsOn = append(dependsOn, dep)
+		}
+		p.Data["dependsOn"] = dependsOn
+	}
+	if len(compositions) > 0 {
+		p.Data["compositions"] = compositions
+	}
+
+	return nil
+}
+
+// getExamples returns the objects defined in the examples files provided,
+// sorted by file name.
+func getExamples(files map[string][]byte) ([]interface{}, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var examples []interface{}
+	for _, name := range names {
+		objects, err := splitYAMLDocuments(files[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		for _, data := range objects {
+			var example interface{}
+			if err := yaml.Unmarshal(data, &example); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if example != nil {
+				examples = append(examples, example)
+			}
+		}
+	}
+	return examples, nil
+}
+
+// splitYAMLDocuments splits the YAML stream provided into its documents.
+func splitYAMLDocuments(data []byte) ([][]byte, error) {
+	var docs [][]byte
+	dec := yamlv3.NewDecoder(bytes.NewReader(data))
+	for {
+		var node yamlv3.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		doc, err := yamlv3.Marshal(&node)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("no documents found")
+	}
+	return docs, nil
+}
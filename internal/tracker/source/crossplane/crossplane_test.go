@@ -0,0 +1,314 @@
+package crossplane
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	repoURL = "oci://registry.io/org/getting-started"
+	digest  = "sha256:ea8a2a4fd2e7b9f6b25bf95ad3f3bc1b0c0a1e4c3ccc1ec5e5b2d6a3d4b9e1f0"
+
+	packageData = `---
+apiVersion: meta.pkg.crossplane.io/v1
+kind: Configuration
+metadata:
+  name: getting-started
+  annotations:
+    friendly-name.meta.crossplane.io: Getting started
+    meta.crossplane.io/maintainer: Jane <jane@email.com>
+    meta.crossplane.io/source: github.com/org/getting-started
+    meta.crossplane.io/license: Apache-2.0
+    meta.crossplane.io/description: |
+      An introductory example to Crossplane and Composition.
+    meta.crossplane.io/readme: "# Getting started"
+    meta.crossplane.io/iconURI: https://icon.url
+spec:
+  crossplane:
+    version: ">=v1.0.0-0"
+  dependsOn:
+    - provider: crossplane/provider-aws
+      version: ">=v0.14.0"
+---
+apiVersion: apiextensions.crossplane.io/v1
+kind: CompositeResourceDefinition
+metadata:
+  name: compositepostgresqlinstances.database.example.org
+spec:
+  group: database.example.org
+  names:
+    kind: CompositePostgreSQLInstance
+  versions:
+    - name: v1alpha1
+---
+apiVersion: apiextensions.crossplane.io/v1
+kind: Composition
+metadata:
+  name: compositepostgresqlinstances.aws.database.example.org
+spec:
+  compositeTypeRef:
+    apiVersion: database.example.org/v1alpha1
+    kind: CompositePostgreSQLInstance
+`
+	exampleData = `
+apiVersion: database.example.org/v1alpha1
+kind: PostgreSQLInstance
+metadata:
+  name: my-db
+`
+)
+
+func TestTrackerSource(t *testing.T) {
+	t.Run("error getting repository versions", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: repoURL},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+	})
+
+	t.Run("error preparing package", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			files       map[string][]byte
+			filesErr    error
+			expectedErr string
+		}{
+			{
+				nil,
+				tests.ErrFake,
+				"error preparing package: error getting package files: fake error for tests (package: getting-started version: 1.0.0)",
+			},
+			{
+				map[string][]byte{},
+				nil,
+				"error preparing package: package file not found (package: getting-started version: 1.0.0)",
+			},
+			{
+				map[string][]byte{packageFile: []byte("kind: Provider")},
+				nil,
+				"error preparing package: configuration metadata not found (package: getting-started version: 1.0.0)",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedErr, func(t *testing.T) {
+				t.Parallel()
+
+				// Setup services and expectations
+				sw := source.NewTestsServicesWrapper()
+				i := &hub.TrackerSourceInput{
+					Repository: &hub.Repository{URL: repoURL},
+					Svc:        sw.Svc,
+				}
+				tg := &repo.OCITagsGetterMock{}
+				tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+				mg := &repo.OCIManifestGetterMock{}
+				mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return(nil, digest, nil)
+				fg := &repo.OCIFilesGetterMock{}
+				fg.On("Files", i.Svc.Ctx, i.Repository, "1.0.0").Return(tc.files, tc.filesErr)
+				sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+					PackageName:    "getting-started",
+					PackageVersion: "1.0.0",
+					Class:          hub.RepositoryErrorClassPackage,
+					Message:        tc.expectedErr,
+				}).Return()
+
+				// Run test and check expectations
+				s := NewTrackerSource(i, withOCITagsGetter(tg), withOCIManifestGetter(mg), withOCIFilesGetter(fg))
+				packages, err := s.GetPackagesAvailable()
+				assert.Equal(t, map[string]*hub.Package{}, packages)
+				assert.NoError(t, err)
+				sw.AssertExpectations(t)
+				tg.AssertExpectations(t)
+				mg.AssertExpectations(t)
+				fg.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("package already registered", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository:         &hub.Repository{URL: repoURL},
+			PackagesRegistered: map[string]string{"getting-started@1.0.0": digest},
+			Svc:                sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		mg := &repo.OCIManifestGetterMock{}
+		mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return(nil, digest, nil)
+		fg := &repo.OCIFilesGetterMock{}
+
+		// Run test and check expectations
+		s := NewTrackerSource(i, withOCITagsGetter(tg), withOCIManifestGetter(mg), withOCIFilesGetter(fg))
+		packages, err := s.GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			"getting-started@1.0.0": {
+				Name:       "getting-started",
+				Version:    "1.0.0",
+				Digest:     digest,
+				ContentURL: repoURL + ":1.0.0",
+				Repository: i.Repository,
+			},
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+		mg.AssertExpectations(t)
+		fg.AssertExpectations(t)
+	})
+
+	t.Run("one package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: repoURL},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		mg := &repo.OCIManifestGetterMock{}
+		mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return(nil, digest, nil)
+		fg := &repo.OCIFilesGetterMock{}
+		fg.On("Files", i.Svc.Ctx, i.Repository, "1.0.0").Return(map[string][]byte{
+			packageFile:                 []byte(packageData),
+			".up/examples/example.yaml": []byte(exampleData),
+		}, nil)
+		sw.Is.On("DownloadAndSaveImage", i.Svc.Ctx, "https://icon.url").Return("logoImageID", nil)
+		sw.Sv.On("Verify", i.Svc.Ctx, i.Repository, "1.0.0").Return(nil, nil)
+		sw.Sv.On("VerifyProvenance", i.Svc.Ctx, i.Repository, "1.0.0").Return(nil, nil)
+		sg := &repo.OCISBOMGetterMock{}
+		sg.On("SBOMs", i.Svc.Ctx, i.Repository, "1.0.0").Return(nil, tests.ErrFake)
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "getting-started",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassPackage,
+			Message:        "error getting sboms: fake error for tests (package: getting-started version: 1.0.0)",
+		}).Return()
+
+		// Run test and check expectations
+		s := NewTrackerSource(i, withOCITagsGetter(tg), withOCIManifestGetter(mg), withOCIFilesGetter(fg), withOCISBOMGetter(sg))
+		packages, err := s.GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			"getting-started@1.0.0": {
+				Name:        "getting-started",
+				LogoURL:     "https://icon.url",
+				LogoImageID: "logoImageID",
+				DisplayName: "Getting started",
+				Description: "An introductory example to Crossplane and Composition.",
+				Readme:      "# Getting started",
+				Links: []*hub.Link{
+					{Name: "source", URL: "https://github.com/org/getting-started"},
+				},
+				CRDs: []interface{}{
+					map[string]interface{}{
+						"kind":        "CompositePostgreSQLInstance",
+						"version":     "v1alpha1",
+						"name":        "compositepostgresqlinstances.database.example.org",
+						"displayName": "CompositePostgreSQLInstance",
+						"description": "",
+					},
+				},
+				CRDsExamples: []interface{}{
+					map[string]interface{}{
+						"apiVersion": "database.example.org/v1alpha1",
+						"kind":       "PostgreSQLInstance",
+						"metadata": map[string]interface{}{
+							"name": "my-db",
+						},
+					},
+				},
+				Data: map[string]interface{}{
+					"crossplaneVersion": ">=v1.0.0-0",
+					"dependsOn": []interface{}{
+						map[string]interface{}{
+							"provider": "crossplane/provider-aws",
+							"version":  ">=v0.14.0",
+						},
+					},
+					"compositions": []interface{}{
+						map[string]interface{}{
+							"name":             "compositepostgresqlinstances.aws.database.example.org",
+							"compositeTypeRef": "database.example.org/v1alpha1/CompositePostgreSQLInstance",
+						},
+					},
+				},
+				Version:    "1.0.0",
+				Digest:     digest,
+				License:    "Apache-2.0",
+				ContentURL: repoURL + ":1.0.0",
+				Maintainers: []*hub.Maintainer{
+					{Name: "Jane", Email: "jane@email.com"},
+				},
+				Repository: i.Repository,
+			},
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+		mg.AssertExpectations(t)
+		fg.AssertExpectations(t)
+		sg.AssertExpectations(t)
+	})
+}
+
+func TestSplitYAMLDocuments(t *testing.T) {
+	t.Parallel()
+
+	docs, err := splitYAMLDocuments([]byte("---\nkind: A\n---\nkind: B\n"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("kind: A\n"), []byte("kind: B\n")}, docs)
+
+	_, err = splitYAMLDocuments([]byte(""))
+	assert.EqualError(t, err, "no documents found")
+}
+
+func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.tg = tg
+	}
+}
+
+func withOCIManifestGetter(mg hub.OCIManifestGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.mg = mg
+	}
+}
+
+func withOCIFilesGetter(fg hub.OCIFilesGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.fg = fg
+	}
+}
+
+func withOCISBOMGetter(sg hub.OCISBOMGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.sg = sg
+	}
+}
@@ -51,12 +51,12 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		// Read and parse rules metadata file
 		data, err := ioutil.ReadFile(pkgPath)
 		if err != nil {
-			s.warn(fmt.Errorf("error reading rules metadata file: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error reading rules metadata file: %w", err))
 			return nil
 		}
 		var md *RulesMetadata
 		if err = yaml.Unmarshal(data, &md); err != nil || md == nil {
-			s.warn(fmt.Errorf("error unmarshaling rules metadata file: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error unmarshaling rules metadata file: %w", err))
 			return nil
 		}
 
@@ -68,7 +68,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		// Prepare and store package version
 		p, err := s.preparePackage(s.i.Repository, md, strings.TrimPrefix(pkgPath, s.i.BasePath))
 		if err != nil {
-			s.warn(fmt.Errorf("error preparing package: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, md.Name, md.Version, fmt.Errorf("error preparing package: %w", err))
 			return nil
 		}
 		packagesAvailable[pkg.BuildKey(p)] = p
@@ -92,15 +92,13 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *RulesMetadata, pkg
 	version := sv.String()
 
 	// Prepare source link url
-	var repoBaseURL, pkgsPath, provider string
+	var repoBaseURL, provider string
 	matches := repo.GitRepoURLRE.FindStringSubmatch(r.URL)
 	if len(matches) >= 3 {
 		repoBaseURL = matches[1]
 		provider = matches[2]
 	}
-	if len(matches) == 4 {
-		pkgsPath = strings.TrimSuffix(matches[3], "/")
-	}
+	pkgsPath := repo.GetPackagesPath(r)
 	var blobPath string
 	switch provider {
 	case "github":
@@ -137,7 +135,7 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *RulesMetadata, pkg
 			p.LogoURL = md.Icon
 			p.LogoImageID = logoImageID
 		} else {
-			s.warn(fmt.Errorf("error getting package %s version %s logo image: %w", p.Name, p.Version, err))
+			s.warn(hub.RepositoryErrorClassImage, p.Name, p.Version, fmt.Errorf("error getting package %s version %s logo image: %w", p.Name, p.Version, err))
 		}
 	}
 
@@ -146,9 +144,14 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *RulesMetadata, pkg
 
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (s *TrackerSource) warn(err error) {
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
 	s.i.Svc.Logger.Warn().Err(err).Send()
-	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
 }
 
 // RulesMetadata represents some metadata for a Falco rules package.
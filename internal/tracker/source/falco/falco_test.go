@@ -56,7 +56,10 @@ func TestTrackerSource(t *testing.T) {
 			Svc:        sw.Svc,
 		}
 		expectedErr := "error unmarshaling rules metadata file: yaml: line 3: found unexpected end of stream"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			Class:   hub.RepositoryErrorClassPackage,
+			Message: expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -76,7 +79,12 @@ func TestTrackerSource(t *testing.T) {
 			Svc:        sw.Svc,
 		}
 		expectedErr := "error preparing package: invalid package (test) version (invalid): Invalid Semantic Version"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "test",
+			PackageVersion: "invalid",
+			Class:          hub.RepositoryErrorClassPackage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -100,7 +108,12 @@ func TestTrackerSource(t *testing.T) {
 		}
 		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("", tests.ErrFake)
 		expectedErr := "error getting package test version 0.1.0 logo image: fake error for tests"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "test",
+			PackageVersion: "0.1.0",
+			Class:          hub.RepositoryErrorClassImage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		p := source.ClonePackage(basePkg)
@@ -146,4 +159,36 @@ func TestTrackerSource(t *testing.T) {
 		assert.NoError(t, err)
 		sw.AssertExpectations(t)
 	})
+
+	t.Run("one package returned, packages path set independently from url", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL:    "https://github.com/org1/repo1",
+				Branch: "main",
+				Path:   "path/to/packages",
+			},
+			BasePath: "testdata/path4",
+			Svc:      sw.Svc,
+		}
+		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("logoImageID", nil)
+
+		// Run test and check expectations
+		p := source.ClonePackage(basePkg)
+		p.Repository = i.Repository
+		p.LogoURL = logoImageURL
+		p.LogoImageID = "logoImageID"
+		p.Data = map[string]interface{}{
+			"rules": []*Rule{{Raw: "Falco rules in YAML"}},
+		}
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			pkg.BuildKey(p): p,
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+	})
 }
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -48,7 +50,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		md, err := pkg.GetPackageMetadata(filepath.Join(pkgPath, hub.PackageMetadataFile))
 		if err != nil {
 			if !errors.Is(err, os.ErrNotExist) {
-				s.warn(err)
+				s.warn(hub.RepositoryErrorClassPackage, "", "", err)
 			}
 			return nil
 		}
@@ -56,7 +58,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		// Prepare and store package version
 		p, err := s.preparePackage(s.i.Repository, md, pkgPath)
 		if err != nil {
-			s.warn(fmt.Errorf("error preparing package: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, md.Name, md.Version, fmt.Errorf("error preparing package: %w", err))
 			return nil
 		}
 		packagesAvailable[pkg.BuildKey(p)] = p
@@ -98,11 +100,11 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *hub.PackageMetadat
 	if md.LogoPath != "" {
 		data, err := ioutil.ReadFile(filepath.Join(pkgPath, md.LogoPath))
 		if err != nil {
-			s.warn(fmt.Errorf("error reading package %s version %s logo: %w", md.Name, md.Version, err))
+			s.warn(hub.RepositoryErrorClassImage, md.Name, md.Version, fmt.Errorf("error reading package %s version %s logo: %w", md.Name, md.Version, err))
 		} else {
 			p.LogoImageID, err = s.i.Svc.Is.SaveImage(s.i.Svc.Ctx, data)
 			if err != nil && !errors.Is(err, image.ErrFormat) {
-				s.warn(fmt.Errorf("error saving package %s version %s logo: %w", md.Name, md.Version, err))
+				s.warn(hub.RepositoryErrorClassImage, md.Name, md.Version, fmt.Errorf("error saving package %s version %s logo: %w", md.Name, md.Version, err))
 			}
 		}
 	} else if md.LogoURL != "" {
@@ -111,18 +113,59 @@ func (s *TrackerSource) preparePackage(r *hub.Repository, md *hub.PackageMetadat
 			p.LogoURL = md.LogoURL
 			p.LogoImageID = logoImageID
 		} else {
-			s.warn(fmt.Errorf("error getting package %s version %s logo: %w", md.Name, md.Version, err))
+			s.warn(hub.RepositoryErrorClassImage, md.Name, md.Version, fmt.Errorf("error getting package %s version %s logo: %w", md.Name, md.Version, err))
+		}
+	}
+
+	// Get SBOM when available
+	if md.SBOMPath != "" || md.SBOMURL != "" {
+		sbom, err := s.getSBOM(md, pkgPath)
+		if err == nil {
+			p.SBOMs = []*hub.SBOM{sbom}
+		} else {
+			s.warn(hub.RepositoryErrorClassPackage, md.Name, md.Version, fmt.Errorf("error getting package %s version %s sbom: %w", md.Name, md.Version, err))
 		}
 	}
 
 	return p, nil
 }
 
+// getSBOM reads the SBOM referenced in the package metadata provided, which
+// can be located in the package path or at a remote url.
+func (s *TrackerSource) getSBOM(md *hub.PackageMetadata, pkgPath string) (*hub.SBOM, error) {
+	if md.SBOMPath != "" {
+		data, err := ioutil.ReadFile(filepath.Join(pkgPath, md.SBOMPath))
+		if err != nil {
+			return nil, err
+		}
+		return pkg.ParseSBOM(data, "")
+	}
+	req, _ := http.NewRequestWithContext(s.i.Svc.Ctx, "GET", md.SBOMURL, nil)
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, pkg.MaxSBOMSize+1))
+	if err != nil {
+		return nil, err
+	}
+	return pkg.ParseSBOM(data, resp.Header.Get("Content-Type"))
+}
+
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (s *TrackerSource) warn(err error) {
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
 	s.i.Svc.Logger.Warn().Err(err).Send()
-	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
 }
 
 // prepareFalcoData reads and formats Falco specific data available in the path
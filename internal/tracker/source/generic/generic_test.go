@@ -88,7 +88,10 @@ func TestTrackerSource(t *testing.T) {
 			Svc:        sw.Svc,
 		}
 		expectedErr := "error unmarshaling package metadata file: yaml: line 2: did not find expected node content"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			Class:   hub.RepositoryErrorClassPackage,
+			Message: expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -108,7 +111,10 @@ func TestTrackerSource(t *testing.T) {
 			Svc:        sw.Svc,
 		}
 		expectedErr := "error validating package metadata file: invalid metadata: invalid version (semver expected): Invalid Semantic Version"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			Class:   hub.RepositoryErrorClassPackage,
+			Message: expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -135,7 +141,12 @@ func TestTrackerSource(t *testing.T) {
 					Svc:        sw.Svc,
 				}
 				expectedErr := "error preparing package: error preparing package pkg1 version 1.0.0 data: no files found"
-				sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+				sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+					PackageName:    "pkg1",
+					PackageVersion: "1.0.0",
+					Class:          hub.RepositoryErrorClassPackage,
+					Message:        expectedErr,
+				}).Return()
 
 				// Run test and check expectations
 				packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -159,7 +170,12 @@ func TestTrackerSource(t *testing.T) {
 			Svc:      sw.Svc,
 		}
 		expectedErr := "error reading package pkg1 version 1.0.0 logo: open testdata/path4/red-dot.png: no such file or directory"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg1",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassImage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		p := source.ClonePackage(basePkg)
@@ -186,7 +202,12 @@ func TestTrackerSource(t *testing.T) {
 		}
 		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("", tests.ErrFake)
 		expectedErr := "error saving package pkg1 version 1.0.0 logo: fake error for tests"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg1",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassImage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		p := source.ClonePackage(basePkg)
@@ -222,6 +243,9 @@ func TestTrackerSource(t *testing.T) {
 				"policy1.rego": "policy content\n",
 			},
 		}
+		p.SBOMs = []*hub.SBOM{
+			{Format: pkg.SBOMFormatSPDXJSON, Content: "{\"spdxVersion\": \"SPDX-2.2\", \"name\": \"pkg1\"}\n"},
+		}
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
 		assert.Equal(t, map[string]*hub.Package{
 			pkg.BuildKey(p): p,
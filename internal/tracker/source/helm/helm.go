@@ -17,6 +17,7 @@ import (
 	"github.com/artifacthub/hub/internal/license"
 	"github.com/artifacthub/hub/internal/pkg"
 	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tracker/source"
 	"github.com/containerd/containerd/remotes/docker"
 	"github.com/deislabs/oras/pkg/content"
 	ctxo "github.com/deislabs/oras/pkg/context"
@@ -52,6 +53,8 @@ type TrackerSource struct {
 	i  *hub.TrackerSourceInput
 	il hub.HelmIndexLoader
 	tg hub.OCITagsGetter
+	sg hub.OCISBOMGetter
+	hc hub.HTTPClient
 }
 
 // NewTrackerSource creates a new TrackerSource instance.
@@ -66,6 +69,9 @@ func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource))
 	if s.tg == nil {
 		s.tg = &repo.OCITagsGetter{}
 	}
+	if s.sg == nil {
+		s.sg = &repo.OCISBOMGetter{}
+	}
 	return s
 }
 
@@ -74,6 +80,14 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 	var mu sync.Mutex
 	packagesAvailable := make(map[string]*hub.Package)
 
+	// Setup http client used to download charts, honoring the repository TLS
+	// and proxy options when present
+	hc, err := repo.HTTPClient(s.i.Repository, s.i.Svc.Hc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transport options: %w", err)
+	}
+	s.hc = hc
+
 	// Iterate over charts versions available in the repository
 	charts, err := s.getCharts()
 	if err != nil {
@@ -101,7 +115,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 				}()
 				p, err := s.preparePackage(chartVersion)
 				if err != nil {
-					s.warn(chartVersion.Metadata, fmt.Errorf("error preparing package: %w", err))
+					s.warn(chartVersion.Metadata, hub.RepositoryErrorClassPackage, fmt.Errorf("error preparing package: %w", err))
 					return
 				}
 				mu.Lock()
@@ -220,7 +234,7 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 				p.LogoURL = md.Icon
 				p.LogoImageID = logoImageID
 			} else {
-				s.warn(md, fmt.Errorf("error getting logo image %s: %w", md.Icon, err))
+				s.warn(md, hub.RepositoryErrorClassImage, fmt.Errorf("error getting logo image %s: %w", md.Icon, err))
 			}
 		}
 
@@ -233,6 +247,23 @@ func (s *TrackerSource) preparePackage(chartVersion *helmrepo.ChartVersion) (*hu
 			p.Signed = hasProvenanceFile
 		}
 
+		// Verify cosign signatures and SLSA provenance and get the SBOMs
+		// attached when the chart is stored in an OCI registry
+		if chartURL.Scheme == "oci" {
+			if err := source.VerifySignatures(s.i, p, md.Version); err != nil {
+				s.warn(md, hub.RepositoryErrorClassPackage, fmt.Errorf("error verifying signatures: %w", err))
+			}
+			if err := source.VerifyProvenance(s.i, p, md.Version); err != nil {
+				s.warn(md, hub.RepositoryErrorClassPackage, fmt.Errorf("error verifying provenance: %w", err))
+			}
+			sboms, err := s.sg.SBOMs(s.i.Svc.Ctx, s.i.Repository, md.Version)
+			if err == nil {
+				p.SBOMs = sboms
+			} else {
+				s.warn(md, hub.RepositoryErrorClassPackage, fmt.Errorf("error getting sboms: %w", err))
+			}
+		}
+
 		// Enrich package from data available in chart archive
 		if err := enrichPackageFromArchive(p, chart); err != nil {
 			return nil, fmt.Errorf("error enriching package from archive: %w", err)
@@ -264,7 +295,7 @@ func (s *TrackerSource) loadChartArchive(u *url.URL) (*chart.Chart, error) {
 		if s.i.Repository.AuthUser != "" || s.i.Repository.AuthPass != "" {
 			req.SetBasicAuth(s.i.Repository.AuthUser, s.i.Repository.AuthPass)
 		}
-		resp, err := s.i.Svc.Hc.Do(req)
+		resp, err := s.hc.Do(req)
 		if err != nil {
 			return nil, err
 		}
@@ -277,6 +308,13 @@ func (s *TrackerSource) loadChartArchive(u *url.URL) (*chart.Chart, error) {
 		// Pull reference layers from OCI registry
 		ref := strings.TrimPrefix(u.String(), hub.RepositoryOCIPrefix)
 		resolverOptions := docker.ResolverOptions{}
+		transport, err := repo.HTTPTransport(s.i.Repository)
+		if err != nil {
+			return nil, err
+		}
+		if transport != nil {
+			resolverOptions.Client = &http.Client{Transport: transport}
+		}
 		if s.i.Repository.AuthUser != "" || s.i.Repository.AuthPass != "" {
 			resolverOptions.Authorizer = docker.NewDockerAuthorizer(
 				docker.WithAuthCreds(func(string) (string, string, error) {
@@ -329,7 +367,7 @@ func (s *TrackerSource) chartHasProvenanceFile(u string) (bool, error) {
 	if s.i.Repository.AuthUser != "" || s.i.Repository.AuthPass != "" {
 		req.SetBasicAuth(s.i.Repository.AuthUser, s.i.Repository.AuthPass)
 	}
-	resp, err := s.i.Svc.Hc.Do(req)
+	resp, err := s.hc.Do(req)
 	if err != nil {
 		return false, err
 	}
@@ -342,11 +380,16 @@ func (s *TrackerSource) chartHasProvenanceFile(u string) (bool, error) {
 
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (s *TrackerSource) warn(md *chart.Metadata, err error) {
+func (s *TrackerSource) warn(md *chart.Metadata, class hub.RepositoryErrorClass, err error) {
 	err = fmt.Errorf("%w (package: %s version: %s)", err, md.Name, md.Version)
 	s.i.Svc.Logger.Warn().Err(err).Send()
 	if !md.Deprecated {
-		s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+		s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    md.Name,
+			PackageVersion: md.Version,
+			Class:          class,
+			Message:        err.Error(),
+		})
 	}
 }
 
@@ -360,6 +403,9 @@ func enrichPackageFromArchive(p *hub.Package, chart *chart.Chart) error {
 	p.AppVersion = md.AppVersion
 	p.Deprecated = md.Deprecated
 	p.ValuesSchema = chart.Schema
+	if values := getRawFile(chart, "values.yaml"); values != nil {
+		p.DefaultValues = string(values.Data)
+	}
 	p.Data = map[string]interface{}{}
 
 	// API version
@@ -557,6 +603,18 @@ func enrichPackageFromAnnotations(p *hub.Package, annotations map[string]string)
 	return nil
 }
 
+// getRawFile returns the file requested from the provided chart's raw files.
+// Unlike the chart files, the raw ones include the chart special files, like
+// the default values file.
+func getRawFile(chart *chart.Chart, name string) *chart.File {
+	for _, file := range chart.Raw {
+		if file.Name == name {
+			return file
+		}
+	}
+	return nil
+}
+
 // getFile returns the file requested from the provided chart.
 func getFile(chart *chart.Chart, name string) *chart.File {
 	for _, file := range chart.Files {
@@ -99,6 +99,28 @@ func TestTrackerSource(t *testing.T) {
 	}
 	logoImageURL := "http://icon.url"
 
+	t.Run("invalid repository TLS options", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{
+				URL: "https://repo.url",
+				TLS: &hub.RepositoryTLSConfig{
+					CACert: "invalid",
+				},
+			},
+			Svc: sw.Svc,
+		}
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.EqualError(t, err, "invalid transport options: invalid CA certificate")
+		sw.AssertExpectations(t)
+	})
+
 	t.Run("error loading repository index file", func(t *testing.T) {
 		t.Parallel()
 
@@ -167,7 +189,12 @@ func TestTrackerSource(t *testing.T) {
 			},
 		}, "", nil)
 		expectedErr := "error preparing package: invalid package version: Invalid Semantic Version (package: pkg1 version: invalid)"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg1",
+			PackageVersion: "invalid",
+			Class:          hub.RepositoryErrorClassPackage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i, withIndexLoader(il)).GetPackagesAvailable()
@@ -207,7 +234,12 @@ func TestTrackerSource(t *testing.T) {
 		req, _ := http.NewRequest("GET", "https://repo.url/pkg1-1.0.0.tgz", nil)
 		sw.Hc.On("Do", req).Return(nil, tests.ErrFake)
 		expectedErr := "error preparing package: error loading chart (https://repo.url/pkg1-1.0.0.tgz): fake error for tests (package: pkg1 version: 1.0.0)"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg1",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassPackage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i, withIndexLoader(il)).GetPackagesAvailable()
@@ -258,7 +290,12 @@ func TestTrackerSource(t *testing.T) {
 		}, nil)
 		sw.Is.On("DownloadAndSaveImage", sw.Svc.Ctx, logoImageURL).Return("", tests.ErrFake)
 		expectedErr := "error getting logo image http://icon.url: fake error for tests (package: pkg1 version: 1.0.0)"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg1",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassImage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		p := source.ClonePackage(basePkg)
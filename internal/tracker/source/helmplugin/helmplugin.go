@@ -56,20 +56,20 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		data, err := ioutil.ReadFile(filepath.Join(pkgPath, plugin.PluginFileName))
 		if err != nil {
 			if !errors.Is(err, os.ErrNotExist) {
-				s.warn(fmt.Errorf("error reading plugin metadata file: %w", err))
+				s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error reading plugin metadata file: %w", err))
 			}
 			return nil
 		}
 		var md *plugin.Metadata
 		if err = yaml.Unmarshal(data, &md); err != nil || md == nil {
-			s.warn(fmt.Errorf("error unmarshaling plugin metadata file: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error unmarshaling plugin metadata file: %w", err))
 			return nil
 		}
 
 		// Prepare and store package version
 		p, err := preparePackage(s.i.Repository, md, pkgPath)
 		if err != nil {
-			s.warn(fmt.Errorf("error preparing package: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, md.Name, md.Version, fmt.Errorf("error preparing package: %w", err))
 			return nil
 		}
 		packagesAvailable[pkg.BuildKey(p)] = p
@@ -85,9 +85,14 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (s *TrackerSource) warn(err error) {
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
 	s.i.Svc.Logger.Warn().Err(err).Send()
-	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
 }
 
 // preparePackage prepares a package version using the plugin metadata and the
@@ -39,7 +39,10 @@ func TestTrackerSource(t *testing.T) {
 			Svc:        sw.Svc,
 		}
 		expectedErr := "error unmarshaling plugin metadata file: error converting YAML to JSON: yaml: line 3: found unexpected end of stream"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			Class:   hub.RepositoryErrorClassPackage,
+			Message: expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -59,7 +62,12 @@ func TestTrackerSource(t *testing.T) {
 			Svc:        sw.Svc,
 		}
 		expectedErr := "error preparing package: invalid package (test-plugin) version (invalid): Invalid Semantic Version"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "test-plugin",
+			PackageVersion: "invalid",
+			Class:          hub.RepositoryErrorClassPackage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
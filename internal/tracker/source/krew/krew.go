@@ -67,19 +67,19 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		// Read and parse plugin manifest file
 		manifestRaw, err := ioutil.ReadFile(filepath.Join(pluginsPath, file.Name()))
 		if err != nil {
-			s.warn(fmt.Errorf("error reading plugin manifest file: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error reading plugin manifest file: %w", err))
 			continue
 		}
 		var manifest *index.Plugin
 		if err = yaml.Unmarshal(manifestRaw, &manifest); err != nil || manifest == nil {
-			s.warn(fmt.Errorf("error unmarshaling plugin manifest file: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error unmarshaling plugin manifest file: %w", err))
 			continue
 		}
 
 		// Prepare and store package version
 		p, err := preparePackage(s.i.Repository, manifest, manifestRaw)
 		if err != nil {
-			s.warn(fmt.Errorf("error preparing package: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, manifest.Name, manifest.Spec.Version, fmt.Errorf("error preparing package: %w", err))
 			continue
 		}
 		packagesAvailable[pkg.BuildKey(p)] = p
@@ -90,9 +90,14 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (s *TrackerSource) warn(err error) {
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
 	s.i.Svc.Logger.Warn().Err(err).Send()
-	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
 }
 
 // preparePackage prepares a package version using the plugin manifest provided.
@@ -0,0 +1,391 @@
+package oci
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/mail"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/pkg"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/ghodss/yaml"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+const (
+	concurrency = 10
+
+	// maxReadmeSize represents the maximum size of the readme files fetched.
+	maxReadmeSize = 1 << 20 // 1 MiB
+
+	// Standard OCI annotations
+	authorsAnnotation       = "org.opencontainers.image.authors"
+	createdAnnotation       = "org.opencontainers.image.created"
+	descriptionAnnotation   = "org.opencontainers.image.description"
+	documentationAnnotation = "org.opencontainers.image.documentation"
+	licensesAnnotation      = "org.opencontainers.image.licenses"
+	sourceAnnotation        = "org.opencontainers.image.source"
+	titleAnnotation         = "org.opencontainers.image.title"
+	urlAnnotation           = "org.opencontainers.image.url"
+	vendorAnnotation        = "org.opencontainers.image.vendor"
+
+	// Artifact Hub specific annotations
+	changesAnnotation         = "io.artifacthub.package.changes"
+	keywordsAnnotation        = "io.artifacthub.package.keywords"
+	licenseAnnotation         = "io.artifacthub.package.license"
+	linksAnnotation           = "io.artifacthub.package.links"
+	logoURLAnnotation         = "io.artifacthub.package.logo-url"
+	maintainersAnnotation     = "io.artifacthub.package.maintainers"
+	prereleaseAnnotation      = "io.artifacthub.package.prerelease"
+	readmeURLAnnotation       = "io.artifacthub.package.readme-url"
+	recommendationsAnnotation = "io.artifacthub.package.recommendations"
+	securityUpdatesAnnotation = "io.artifacthub.package.contains-security-updates"
+)
+
+// MetadataExtractor is the interface that wraps the Extract method, used to
+// enrich the packages prepared by the TrackerSource with the metadata specific
+// to a given kind of OCI artifacts.
+type MetadataExtractor interface {
+	// Extract enriches the package provided with the metadata available in
+	// the artifact manifest. It's called once the package has been enriched
+	// from the manifest annotations, and it should return an error when the
+	// artifact is not of the expected kind.
+	Extract(p *hub.Package, manifest *v1.Manifest) error
+}
+
+// TrackerSource is a hub.TrackerSource implementation for repositories of
+// artifacts stored in OCI registries. Each repository holds a single package,
+// and each of the tags that are valid semver versions is considered a version
+// of it. Packages metadata is read from the artifact manifest annotations, and
+// a MetadataExtractor can be provided to extract kind specific metadata.
+type TrackerSource struct {
+	i  *hub.TrackerSourceInput
+	tg hub.OCITagsGetter
+	mg hub.OCIManifestGetter
+	sg hub.OCISBOMGetter
+	e  MetadataExtractor
+}
+
+// NewTrackerSource creates a new TrackerSource instance.
+func NewTrackerSource(i *hub.TrackerSourceInput, opts ...func(s *TrackerSource)) *TrackerSource {
+	s := &TrackerSource{i: i}
+	for _, o := range opts {
+		o(s)
+	}
+	if s.tg == nil {
+		s.tg = &repo.OCITagsGetter{}
+	}
+	if s.mg == nil {
+		s.mg = &repo.OCIManifestGetter{}
+	}
+	if s.sg == nil {
+		s.sg = &repo.OCISBOMGetter{}
+	}
+	if s.e == nil {
+		s.e = &genericExtractor{}
+	}
+	return s
+}
+
+// WithMetadataExtractor allows providing the MetadataExtractor used to get the
+// kind specific metadata of the artifacts.
+func WithMetadataExtractor(e MetadataExtractor) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.e = e
+	}
+}
+
+// GetPackagesAvailable implements the TrackerSource interface.
+func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error) {
+	var mu sync.Mutex
+	packagesAvailable := make(map[string]*hub.Package)
+
+	// Get versions (tags) available in the repository
+	versions, err := s.tg.Tags(s.i.Svc.Ctx, s.i.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("error getting repository available versions: %w", err)
+	}
+
+	// Prepare and store package versions
+	name := path.Base(s.i.Repository.URL)
+	limiter := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, version := range versions {
+		// Return ASAP if context is cancelled
+		select {
+		case <-s.i.Svc.Ctx.Done():
+			wg.Wait()
+			return nil, s.i.Svc.Ctx.Err()
+		default:
+		}
+
+		limiter <- struct{}{}
+		wg.Add(1)
+		go func(version string) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			p, err := s.preparePackage(name, version)
+			if err != nil {
+				s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error preparing package: %w", err))
+				return
+			}
+			mu.Lock()
+			packagesAvailable[pkg.BuildKey(p)] = p
+			mu.Unlock()
+		}(version)
+	}
+	wg.Wait()
+
+	return packagesAvailable, nil
+}
+
+// preparePackage prepares a package version using the manifest of the OCI
+// artifact tagged with the version provided.
+func (s *TrackerSource) preparePackage(name, version string) (*hub.Package, error) {
+	// Parse package version
+	sv, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package version: %w", err)
+	}
+
+	// Get artifact manifest
+	manifestData, digest, err := s.mg.Manifest(s.i.Svc.Ctx, s.i.Repository, version)
+	if err != nil {
+		return nil, fmt.Errorf("error getting manifest: %w", err)
+	}
+
+	// Prepare package version
+	p := &hub.Package{
+		Name:       name,
+		Version:    sv.String(),
+		Digest:     digest,
+		ContentURL: s.i.Repository.URL + ":" + version,
+		Repository: s.i.Repository,
+	}
+
+	// If the package version is not registered yet or if it needs to be
+	// registered again, we need to enrich the package with the information
+	// available in the artifact manifest. Otherwise, the minimal version of
+	// the package prepared above is enough.
+	bypassDigestCheck := s.i.Svc.Cfg.GetBool("tracker.bypassDigestCheck")
+	registeredDigest, ok := s.i.PackagesRegistered[pkg.BuildKey(p)]
+	if ok && registeredDigest == digest && !bypassDigestCheck {
+		return p, nil
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(manifestData))
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := enrichPackageFromAnnotations(p, manifest.Annotations); err != nil {
+		return nil, fmt.Errorf("error enriching package from annotations: %w", err)
+	}
+	if err := s.e.Extract(p, manifest); err != nil {
+		return nil, err
+	}
+
+	// Store logo when available
+	if logoURL := manifest.Annotations[logoURLAnnotation]; logoURL != "" {
+		logoImageID, err := s.i.Svc.Is.DownloadAndSaveImage(s.i.Svc.Ctx, logoURL)
+		if err == nil {
+			p.LogoURL = logoURL
+			p.LogoImageID = logoImageID
+		} else {
+			s.warn(hub.RepositoryErrorClassImage, name, version, fmt.Errorf("error getting logo image %s: %w", logoURL, err))
+		}
+	}
+
+	// Get readme file when available
+	if readmeURL := manifest.Annotations[readmeURLAnnotation]; readmeURL != "" {
+		readme, err := s.getReadme(readmeURL)
+		if err == nil {
+			p.Readme = readme
+		} else {
+			s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error getting readme file %s: %w", readmeURL, err))
+		}
+	}
+
+	// Verify signatures
+	if err := source.VerifySignatures(s.i, p, version); err != nil {
+		s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error verifying signatures: %w", err))
+	}
+
+	// Verify SLSA provenance attestation
+	if err := source.VerifyProvenance(s.i, p, version); err != nil {
+		s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error verifying provenance: %w", err))
+	}
+
+	// Get SBOMs attached to the artifact
+	sboms, err := s.sg.SBOMs(s.i.Svc.Ctx, s.i.Repository, version)
+	if err == nil {
+		p.SBOMs = sboms
+	} else {
+		s.warn(hub.RepositoryErrorClassPackage, name, version, fmt.Errorf("error getting sboms: %w", err))
+	}
+
+	return p, nil
+}
+
+// getReadme fetches the readme file located at the url provided.
+func (s *TrackerSource) getReadme(u string) (string, error) {
+	req, _ := http.NewRequestWithContext(s.i.Svc.Ctx, "GET", u, nil)
+	resp, err := s.i.Svc.Hc.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxReadmeSize))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// warn is a helper that sends the error provided to the errors collector and
+// logs it as a warning.
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
+	err = fmt.Errorf("%w (package: %s version: %s)", err, pkgName, pkgVersion)
+	s.i.Svc.Logger.Warn().Err(err).Send()
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
+}
+
+// genericExtractor is the MetadataExtractor used when none is provided. It
+// only stores the media types of the artifact's config and layers, which are
+// used to identify the artifact type.
+type genericExtractor struct{}
+
+// Extract implements the MetadataExtractor interface.
+func (e *genericExtractor) Extract(p *hub.Package, manifest *v1.Manifest) error {
+	layersMediaTypes := make([]interface{}, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		layersMediaTypes = append(layersMediaTypes, string(layer.MediaType))
+	}
+	p.Data = map[string]interface{}{
+		"configMediaType":  string(manifest.Config.MediaType),
+		"layersMediaTypes": layersMediaTypes,
+	}
+	return nil
+}
+
+// enrichPackageFromAnnotations adds some extra information to the package from
+// the annotations of the artifact manifest.
+func enrichPackageFromAnnotations(p *hub.Package, annotations map[string]string) error {
+	p.DisplayName = annotations[titleAnnotation]
+	p.Description = annotations[descriptionAnnotation]
+	p.HomeURL = annotations[urlAnnotation]
+	p.License = annotations[licensesAnnotation]
+	if v := annotations[licenseAnnotation]; v != "" {
+		p.License = v
+	}
+	p.Provider = annotations[vendorAnnotation]
+
+	// Changes
+	if v := annotations[changesAnnotation]; v != "" {
+		var changes []string
+		if err := yaml.Unmarshal([]byte(v), &changes); err != nil {
+			return fmt.Errorf("invalid changes value: %s", v)
+		}
+		p.Changes = changes
+	}
+
+	// Created
+	if v := annotations[createdAnnotation]; v != "" {
+		created, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("invalid created value: %s", v)
+		}
+		p.TS = created.Unix()
+	}
+
+	// Keywords
+	for _, keyword := range strings.Split(annotations[keywordsAnnotation], ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			p.Keywords = append(p.Keywords, keyword)
+		}
+	}
+
+	// Links
+	if v := annotations[sourceAnnotation]; v != "" {
+		p.Links = append(p.Links, &hub.Link{Name: "source", URL: v})
+	}
+	if v := annotations[documentationAnnotation]; v != "" {
+		p.Links = append(p.Links, &hub.Link{Name: "documentation", URL: v})
+	}
+	if v := annotations[linksAnnotation]; v != "" {
+		var links []*hub.Link
+		if err := yaml.Unmarshal([]byte(v), &links); err != nil {
+			return fmt.Errorf("invalid links value: %s", v)
+		}
+		p.Links = append(p.Links, links...)
+	}
+
+	// Maintainers
+	if v := annotations[maintainersAnnotation]; v != "" {
+		var maintainers []*hub.Maintainer
+		if err := yaml.Unmarshal([]byte(v), &maintainers); err != nil {
+			return fmt.Errorf("invalid maintainers value: %s", v)
+		}
+		p.Maintainers = maintainers
+	} else if v := annotations[authorsAnnotation]; v != "" {
+		for _, author := range strings.Split(v, ",") {
+			author = strings.TrimSpace(author)
+			if author == "" {
+				continue
+			}
+			if addr, err := mail.ParseAddress(author); err == nil {
+				p.Maintainers = append(p.Maintainers, &hub.Maintainer{
+					Name:  addr.Name,
+					Email: addr.Address,
+				})
+			}
+		}
+	}
+
+	// Prerelease
+	if v := annotations[prereleaseAnnotation]; v != "" {
+		prerelease, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid prerelease value: %s", v)
+		}
+		p.Prerelease = prerelease
+	}
+
+	// Recommendations
+	if v := annotations[recommendationsAnnotation]; v != "" {
+		var recommendations []*hub.Recommendation
+		if err := yaml.Unmarshal([]byte(v), &recommendations); err != nil {
+			return fmt.Errorf("invalid recommendations value: %s", v)
+		}
+		p.Recommendations = recommendations
+	}
+
+	// Security updates
+	if v := annotations[securityUpdatesAnnotation]; v != "" {
+		containsSecurityUpdates, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid containsSecurityUpdates value: %s", v)
+		}
+		p.ContainsSecurityUpdates = containsSecurityUpdates
+	}
+
+	return nil
+}
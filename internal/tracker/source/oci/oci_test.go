@@ -0,0 +1,493 @@
+package oci
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/repo"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/artifacthub/hub/internal/tracker/source"
+	"github.com/artifacthub/hub/internal/tracker/source/wasm"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	repoURL      = "oci://registry.io/org/echo"
+	manifestData = `{
+		"schemaVersion": 2,
+		"config": {
+			"mediaType": "application/vnd.wasmcloud.actor.archive.config",
+			"size": 2,
+			"digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+		},
+		"layers": [
+			{
+				"mediaType": "application/vnd.module.wasm.content.layer.v1+wasm",
+				"size": 1024,
+				"digest": "sha256:0f6fc5d0ec3c1b5c1f6d6b2b6b6e2b7c6f3a7f1c3c0e6d5b4a3f2e1d0c9b8a7f"
+			}
+		],
+		"annotations": {
+			"org.opencontainers.image.title": "Echo",
+			"org.opencontainers.image.description": "Echo actor",
+			"org.opencontainers.image.created": "2021-03-01T10:00:00Z",
+			"org.opencontainers.image.authors": "Jane <jane@email.com>, invalid",
+			"org.opencontainers.image.licenses": "Apache-2.0",
+			"org.opencontainers.image.url": "https://echo.url",
+			"org.opencontainers.image.source": "https://github.com/org/echo",
+			"org.opencontainers.image.vendor": "org",
+			"io.artifacthub.package.keywords": "wasm, wasmcloud",
+			"io.artifacthub.package.logo-url": "https://echo.url/logo.png",
+			"io.artifacthub.package.readme-url": "https://echo.url/README.md",
+			"io.artifacthub.package.prerelease": "true"
+		}
+	}`
+	manifestDigest = "sha256:ea8a2a4fd2e7b9f6b25bf95ad3f3bc1b0c0a1e4c3ccc1ec5e5b2d6a3d4b9e1f0"
+)
+
+func TestTrackerSource(t *testing.T) {
+	t.Run("error getting repository versions", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: repoURL},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return(nil, tests.ErrFake)
+
+		// Run test and check expectations
+		packages, err := NewTrackerSource(i, withOCITagsGetter(tg)).GetPackagesAvailable()
+		assert.Nil(t, packages)
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+	})
+
+	t.Run("error preparing package", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			manifest    string
+			manifestErr error
+			expectedErr string
+		}{
+			{
+				"",
+				tests.ErrFake,
+				"error preparing package: error getting manifest: fake error for tests (package: echo version: 1.0.0)",
+			},
+			{
+				"invalid",
+				nil,
+				"error preparing package: invalid manifest: invalid character 'i' looking for beginning of value (package: echo version: 1.0.0)",
+			},
+			{
+				manifestData,
+				nil,
+				"error preparing package: fake error for tests (package: echo version: 1.0.0)",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.expectedErr, func(t *testing.T) {
+				t.Parallel()
+
+				// Setup services and expectations
+				sw := source.NewTestsServicesWrapper()
+				i := &hub.TrackerSourceInput{
+					Repository: &hub.Repository{URL: repoURL},
+					Svc:        sw.Svc,
+				}
+				tg := &repo.OCITagsGetterMock{}
+				tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+				mg := &repo.OCIManifestGetterMock{}
+				mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return([]byte(tc.manifest), manifestDigest, tc.manifestErr)
+				sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+					PackageName:    "echo",
+					PackageVersion: "1.0.0",
+					Class:          hub.RepositoryErrorClassPackage,
+					Message:        tc.expectedErr,
+				}).Return()
+
+				// Run test and check expectations
+				s := NewTrackerSource(
+					i,
+					withOCITagsGetter(tg),
+					withOCIManifestGetter(mg),
+					WithMetadataExtractor(&failingExtractor{}),
+				)
+				packages, err := s.GetPackagesAvailable()
+				assert.Equal(t, map[string]*hub.Package{}, packages)
+				assert.NoError(t, err)
+				sw.AssertExpectations(t)
+				tg.AssertExpectations(t)
+				mg.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("package already registered", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository:         &hub.Repository{URL: repoURL},
+			PackagesRegistered: map[string]string{"echo@1.0.0": manifestDigest},
+			Svc:                sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		mg := &repo.OCIManifestGetterMock{}
+		mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return([]byte(manifestData), manifestDigest, nil)
+
+		// Run test and check expectations
+		s := NewTrackerSource(i, withOCITagsGetter(tg), withOCIManifestGetter(mg))
+		packages, err := s.GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			"echo@1.0.0": {
+				Name:       "echo",
+				Version:    "1.0.0",
+				Digest:     manifestDigest,
+				ContentURL: repoURL + ":1.0.0",
+				Repository: i.Repository,
+			},
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+		mg.AssertExpectations(t)
+	})
+
+	t.Run("one package returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: repoURL},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		mg := &repo.OCIManifestGetterMock{}
+		mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return([]byte(manifestData), manifestDigest, nil)
+		sw.Is.On("DownloadAndSaveImage", i.Svc.Ctx, "https://echo.url/logo.png").Return("logoImageID", nil)
+		sw.Hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://echo.url/README.md"
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("# Echo"))),
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.Sv.On("Verify", i.Svc.Ctx, i.Repository, "1.0.0").Return([]*hub.Signature{
+			{Kind: repo.CosignKeylessSignature, Identity: "jane@email.com", Issuer: "https://github.com/login/oauth", Verified: true},
+		}, nil)
+		sw.Sv.On("VerifyProvenance", i.Svc.Ctx, i.Repository, "1.0.0").Return(&hub.Provenance{
+			PredicateType: "https://slsa.dev/provenance/v0.2",
+			BuilderID:     "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.2.0",
+			Level:         repo.SLSALevelTrustedBuilder,
+			Verified:      true,
+		}, nil)
+		sg := &repo.OCISBOMGetterMock{}
+		sg.On("SBOMs", i.Svc.Ctx, i.Repository, "1.0.0").Return([]*hub.SBOM{
+			{Format: "spdx", Content: "SPDXVersion: SPDX-2.2"},
+		}, nil)
+
+		// Run test and check expectations
+		s := NewTrackerSource(i, withOCITagsGetter(tg), withOCIManifestGetter(mg), withOCISBOMGetter(sg))
+		packages, err := s.GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			"echo@1.0.0": {
+				Name:        "echo",
+				DisplayName: "Echo",
+				Description: "Echo actor",
+				Keywords:    []string{"wasm", "wasmcloud"},
+				HomeURL:     "https://echo.url",
+				Readme:      "# Echo",
+				LogoURL:     "https://echo.url/logo.png",
+				LogoImageID: "logoImageID",
+				Links: []*hub.Link{
+					{Name: "source", URL: "https://github.com/org/echo"},
+				},
+				Data: map[string]interface{}{
+					"configMediaType": "application/vnd.wasmcloud.actor.archive.config",
+					"layersMediaTypes": []interface{}{
+						"application/vnd.module.wasm.content.layer.v1+wasm",
+					},
+				},
+				Version: "1.0.0",
+				Digest:  manifestDigest,
+				License: "Apache-2.0",
+				Signed:  true,
+				Signatures: []*hub.Signature{
+					{Kind: repo.CosignKeylessSignature, Identity: "jane@email.com", Issuer: "https://github.com/login/oauth", Verified: true},
+				},
+				Provenance: &hub.Provenance{
+					PredicateType: "https://slsa.dev/provenance/v0.2",
+					BuilderID:     "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.2.0",
+					Level:         repo.SLSALevelTrustedBuilder,
+					Verified:      true,
+				},
+				ContentURL: repoURL + ":1.0.0",
+				SBOMs: []*hub.SBOM{
+					{Format: "spdx", Content: "SPDXVersion: SPDX-2.2"},
+				},
+				Provider:   "org",
+				Prerelease: true,
+				Maintainers: []*hub.Maintainer{
+					{Name: "Jane", Email: "jane@email.com"},
+				},
+				Repository: i.Repository,
+				TS:         1614592800,
+			},
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+		mg.AssertExpectations(t)
+		sg.AssertExpectations(t)
+	})
+}
+
+func TestEnrichPackageFromAnnotations(t *testing.T) {
+	testCases := []struct {
+		annotations map[string]string
+		expectedErr string
+	}{
+		{
+			map[string]string{createdAnnotation: "invalid"},
+			"invalid created value: invalid",
+		},
+		{
+			map[string]string{prereleaseAnnotation: "invalid"},
+			"invalid prerelease value: invalid",
+		},
+		{
+			map[string]string{securityUpdatesAnnotation: "invalid"},
+			"invalid containsSecurityUpdates value: invalid",
+		},
+		{
+			map[string]string{changesAnnotation: "{"},
+			"invalid changes value: {",
+		},
+		{
+			map[string]string{linksAnnotation: "{"},
+			"invalid links value: {",
+		},
+		{
+			map[string]string{maintainersAnnotation: "{"},
+			"invalid maintainers value: {",
+		},
+		{
+			map[string]string{recommendationsAnnotation: "{"},
+			"invalid recommendations value: {",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.expectedErr, func(t *testing.T) {
+			t.Parallel()
+			err := enrichPackageFromAnnotations(&hub.Package{}, tc.annotations)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestEnrichPackageFromArtifactHubAnnotations(t *testing.T) {
+	t.Parallel()
+
+	p := &hub.Package{}
+	err := enrichPackageFromAnnotations(p, map[string]string{
+		authorsAnnotation:         "Jane <jane@email.com>",
+		changesAnnotation:         "- Added feature\n- Fixed bug",
+		licenseAnnotation:         "MIT",
+		licensesAnnotation:        "Apache-2.0",
+		linksAnnotation:           "- name: link1\n  url: https://link1.url",
+		maintainersAnnotation:     "- name: John\n  email: john@email.com",
+		recommendationsAnnotation: "- url: https://artifacthub.io/packages/helm/artifact-hub/artifact-hub",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &hub.Package{
+		License: "MIT",
+		Changes: []string{"Added feature", "Fixed bug"},
+		Links: []*hub.Link{
+			{Name: "link1", URL: "https://link1.url"},
+		},
+		Maintainers: []*hub.Maintainer{
+			{Name: "John", Email: "john@email.com"},
+		},
+		Recommendations: []*hub.Recommendation{
+			{URL: "https://artifacthub.io/packages/helm/artifact-hub/artifact-hub"},
+		},
+	}, p)
+}
+
+func TestTrackerSourceWasmModules(t *testing.T) {
+	t.Run("wasm layer not found", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: repoURL},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		mg := &repo.OCIManifestGetterMock{}
+		mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return(
+			[]byte(`{"schemaVersion": 2, "layers": [{"mediaType": "application/tar+gzip"}]}`),
+			manifestDigest,
+			nil,
+		)
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "echo",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassPackage,
+			Message:        "error preparing package: wasm layer not found (package: echo version: 1.0.0)",
+		}).Return()
+
+		// Run test and check expectations
+		s := NewTrackerSource(
+			i,
+			withOCITagsGetter(tg),
+			withOCIManifestGetter(mg),
+			WithMetadataExtractor(&wasm.MetadataExtractor{}),
+		)
+		packages, err := s.GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+		mg.AssertExpectations(t)
+	})
+
+	t.Run("one wasm module returned, no errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := source.NewTestsServicesWrapper()
+		i := &hub.TrackerSourceInput{
+			Repository: &hub.Repository{URL: repoURL},
+			Svc:        sw.Svc,
+		}
+		tg := &repo.OCITagsGetterMock{}
+		tg.On("Tags", i.Svc.Ctx, i.Repository).Return([]string{"1.0.0"}, nil)
+		mg := &repo.OCIManifestGetterMock{}
+		mg.On("Manifest", i.Svc.Ctx, i.Repository, "1.0.0").Return([]byte(manifestData), manifestDigest, nil)
+		sw.Is.On("DownloadAndSaveImage", i.Svc.Ctx, "https://echo.url/logo.png").Return("logoImageID", nil)
+		sw.Hc.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://echo.url/README.md"
+		})).Return(&http.Response{
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("# Echo"))),
+			StatusCode: http.StatusOK,
+		}, nil)
+		sw.Sv.On("Verify", i.Svc.Ctx, i.Repository, "1.0.0").Return([]*hub.Signature{
+			{Kind: repo.CosignKeylessSignature, Identity: "jane@email.com", Issuer: "https://github.com/login/oauth", Verified: true},
+		}, nil)
+		sw.Sv.On("VerifyProvenance", i.Svc.Ctx, i.Repository, "1.0.0").Return(&hub.Provenance{
+			PredicateType: "https://slsa.dev/provenance/v0.2",
+			BuilderID:     "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.2.0",
+			Level:         repo.SLSALevelTrustedBuilder,
+			Verified:      true,
+		}, nil)
+		sg := &repo.OCISBOMGetterMock{}
+		sg.On("SBOMs", i.Svc.Ctx, i.Repository, "1.0.0").Return([]*hub.SBOM{
+			{Format: "spdx", Content: "SPDXVersion: SPDX-2.2"},
+		}, nil)
+
+		// Run test and check expectations
+		s := NewTrackerSource(
+			i,
+			withOCITagsGetter(tg),
+			withOCIManifestGetter(mg),
+			withOCISBOMGetter(sg),
+			WithMetadataExtractor(&wasm.MetadataExtractor{}),
+		)
+		packages, err := s.GetPackagesAvailable()
+		assert.Equal(t, map[string]*hub.Package{
+			"echo@1.0.0": {
+				Name:        "echo",
+				DisplayName: "Echo",
+				Description: "Echo actor",
+				Keywords:    []string{"wasm", "wasmcloud"},
+				HomeURL:     "https://echo.url",
+				Readme:      "# Echo",
+				LogoURL:     "https://echo.url/logo.png",
+				LogoImageID: "logoImageID",
+				Links: []*hub.Link{
+					{Name: "source", URL: "https://github.com/org/echo"},
+				},
+				Data: map[string]interface{}{
+					"configMediaType": "application/vnd.wasmcloud.actor.archive.config",
+					"layerMediaType":  "application/vnd.module.wasm.content.layer.v1+wasm",
+					"layerDigest":     "sha256:0f6fc5d0ec3c1b5c1f6d6b2b6b6e2b7c6f3a7f1c3c0e6d5b4a3f2e1d0c9b8a7f",
+					"layerSize":       int64(1024),
+				},
+				Version: "1.0.0",
+				Digest:  manifestDigest,
+				License: "Apache-2.0",
+				Signed:  true,
+				Signatures: []*hub.Signature{
+					{Kind: repo.CosignKeylessSignature, Identity: "jane@email.com", Issuer: "https://github.com/login/oauth", Verified: true},
+				},
+				Provenance: &hub.Provenance{
+					PredicateType: "https://slsa.dev/provenance/v0.2",
+					BuilderID:     "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.2.0",
+					Level:         repo.SLSALevelTrustedBuilder,
+					Verified:      true,
+				},
+				ContentURL: repoURL + ":1.0.0",
+				SBOMs: []*hub.SBOM{
+					{Format: "spdx", Content: "SPDXVersion: SPDX-2.2"},
+				},
+				Provider:   "org",
+				Prerelease: true,
+				Maintainers: []*hub.Maintainer{
+					{Name: "Jane", Email: "jane@email.com"},
+				},
+				Repository: i.Repository,
+				TS:         1614592800,
+			},
+		}, packages)
+		assert.NoError(t, err)
+		sw.AssertExpectations(t)
+		tg.AssertExpectations(t)
+		mg.AssertExpectations(t)
+		sg.AssertExpectations(t)
+	})
+}
+
+type failingExtractor struct{}
+
+func (e *failingExtractor) Extract(p *hub.Package, manifest *v1.Manifest) error {
+	return tests.ErrFake
+}
+
+func withOCITagsGetter(tg hub.OCITagsGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.tg = tg
+	}
+}
+
+func withOCIManifestGetter(mg hub.OCIManifestGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.mg = mg
+	}
+}
+
+func withOCISBOMGetter(sg hub.OCISBOMGetter) func(s *TrackerSource) {
+	return func(s *TrackerSource) {
+		s.sg = sg
+	}
+}
@@ -69,7 +69,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		// Get package manifest
 		manifest, err := getManifest(pkgPath)
 		if err != nil {
-			s.warn(fmt.Errorf("error getting package manifest: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error getting package manifest: %w", err))
 			return nil
 		}
 		if manifest == nil {
@@ -81,7 +81,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		pkgName := manifest.PackageName
 		versionsUnfiltered, err := ioutil.ReadDir(pkgPath)
 		if err != nil {
-			s.warn(fmt.Errorf("error reading package %s versions: %w", pkgName, err))
+			s.warn(hub.RepositoryErrorClassPackage, pkgName, "", fmt.Errorf("error reading package %s versions: %w", pkgName, err))
 			return nil
 		}
 		var versions []os.FileInfo
@@ -90,7 +90,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 				continue
 			}
 			if _, err := semver.StrictNewVersion(entryV.Name()); err != nil {
-				s.warn(fmt.Errorf("invalid package %s version (%s): %w", pkgName, entryV.Name(), err))
+				s.warn(hub.RepositoryErrorClassPackage, pkgName, entryV.Name(), fmt.Errorf("invalid package %s version (%s): %w", pkgName, entryV.Name(), err))
 				continue
 			} else {
 				versions = append(versions, entryV)
@@ -109,7 +109,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 			pkgVersionPath := filepath.Join(pkgPath, version)
 			csv, csvData, err := getCSV(pkgVersionPath)
 			if err != nil {
-				s.warn(fmt.Errorf("error getting package %s version %s csv: %w", pkgName, version, err))
+				s.warn(hub.RepositoryErrorClassPackage, pkgName, version, fmt.Errorf("error getting package %s version %s csv: %w", pkgName, version, err))
 				continue
 			}
 
@@ -191,11 +191,11 @@ func (s *TrackerSource) preparePackage(
 	if len(csv.Spec.Icon) > 0 && csv.Spec.Icon[0].Data != "" {
 		data, err := base64.StdEncoding.DecodeString(csv.Spec.Icon[0].Data)
 		if err != nil {
-			s.warn(fmt.Errorf("error decoding package %s logo image: %w", p.Name, err))
+			s.warn(hub.RepositoryErrorClassImage, p.Name, p.Version, fmt.Errorf("error decoding package %s logo image: %w", p.Name, err))
 		} else {
 			p.LogoImageID, err = s.i.Svc.Is.SaveImage(s.i.Svc.Ctx, data)
 			if err != nil {
-				s.warn(fmt.Errorf("error saving package %s image: %w", p.Name, err))
+				s.warn(hub.RepositoryErrorClassImage, p.Name, p.Version, fmt.Errorf("error saving package %s image: %w", p.Name, err))
 			}
 		}
 	}
@@ -276,9 +276,14 @@ func (s *TrackerSource) preparePackage(
 
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (s *TrackerSource) warn(err error) {
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
 	s.i.Svc.Logger.Warn().Err(err).Send()
-	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
 }
 
 // getManifest reads and parses the package manifest.
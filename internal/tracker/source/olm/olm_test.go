@@ -123,7 +123,12 @@ func TestTrackerSource(t *testing.T) {
 			Svc:        sw.Svc,
 		}
 		expectedErr := "error getting package test-operator version 0.1.0 csv: csv file not found"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "test-operator",
+			PackageVersion: "0.1.0",
+			Class:          hub.RepositoryErrorClassPackage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		packages, err := NewTrackerSource(i).GetPackagesAvailable()
@@ -144,7 +149,12 @@ func TestTrackerSource(t *testing.T) {
 		}
 		sw.Is.On("SaveImage", sw.Svc.Ctx, imageData).Return("", tests.ErrFake)
 		expectedErr := "error saving package test-operator image: fake error for tests"
-		sw.Ec.On("Append", i.Repository.RepositoryID, expectedErr).Return()
+		sw.Ec.On("Append", i.Repository.RepositoryID, &hub.RepositoryError{
+			PackageName:    "test-operator",
+			PackageVersion: "0.1.0",
+			Class:          hub.RepositoryErrorClassImage,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		p := source.ClonePackage(basePkg)
@@ -0,0 +1,35 @@
+package source
+
+import (
+	"github.com/artifacthub/hub/internal/hub"
+)
+
+// VerifySignatures verifies the signatures of the provided package version,
+// stored in the OCI registry of the repository given. The package signatures
+// are set and it's marked as signed when at least one of them is verified.
+func VerifySignatures(i *hub.TrackerSourceInput, p *hub.Package, version string) error {
+	signatures, err := i.Svc.Sv.Verify(i.Svc.Ctx, i.Repository, version)
+	if err != nil {
+		return err
+	}
+	p.Signatures = signatures
+	for _, s := range signatures {
+		if s.Verified {
+			p.Signed = true
+			break
+		}
+	}
+	return nil
+}
+
+// VerifyProvenance verifies the SLSA provenance attestation of the provided
+// package version, stored in the OCI registry of the repository given, and
+// sets it in the package when available.
+func VerifyProvenance(i *hub.TrackerSourceInput, p *hub.Package, version string) error {
+	provenance, err := i.Svc.Sv.VerifyProvenance(i.Svc.Ctx, i.Repository, version)
+	if err != nil {
+		return err
+	}
+	p.Provenance = provenance
+	return nil
+}
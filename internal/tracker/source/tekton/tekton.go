@@ -56,7 +56,7 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		// Get package manifest
 		manifest, manifestRaw, err := getManifest(pkgPath)
 		if err != nil {
-			s.warn(fmt.Errorf("error getting package manifest: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, "", "", fmt.Errorf("error getting package manifest: %w", err))
 			return nil
 		}
 		if manifest == nil {
@@ -68,14 +68,14 @@ func (s *TrackerSource) GetPackagesAvailable() (map[string]*hub.Package, error)
 		versionLabel := manifest.Labels["app.kubernetes.io/version"]
 		sv, err := semver.NewVersion(versionLabel)
 		if err != nil {
-			s.warn(fmt.Errorf("invalid package (%s) version (%s): %w", manifest.Name, versionLabel, err))
+			s.warn(hub.RepositoryErrorClassPackage, manifest.Name, versionLabel, fmt.Errorf("invalid package (%s) version (%s): %w", manifest.Name, versionLabel, err))
 			return nil
 		}
 
 		// Prepare and store package version
 		p, err := s.preparePackage(s.i.Repository, manifest, manifestRaw, pkgPath, sv.String())
 		if err != nil {
-			s.warn(fmt.Errorf("error preparing package: %w", err))
+			s.warn(hub.RepositoryErrorClassPackage, manifest.Name, versionLabel, fmt.Errorf("error preparing package: %w", err))
 			return nil
 		}
 		packagesAvailable[pkg.BuildKey(p)] = p
@@ -99,15 +99,13 @@ func (s *TrackerSource) preparePackage(
 	version string,
 ) (*hub.Package, error) {
 	// Prepare content and source urls
-	var repoBaseURL, pkgsPath, provider string
+	var repoBaseURL, provider string
 	matches := repo.GitRepoURLRE.FindStringSubmatch(r.URL)
 	if len(matches) >= 3 {
 		repoBaseURL = matches[1]
 		provider = matches[2]
 	}
-	if len(matches) == 4 {
-		pkgsPath = strings.TrimSuffix(matches[3], "/")
-	}
+	pkgsPath := repo.GetPackagesPath(r)
 	var blobPath, rawPath string
 	switch provider {
 	case "github":
@@ -171,9 +169,14 @@ func (s *TrackerSource) preparePackage(
 
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (s *TrackerSource) warn(err error) {
+func (s *TrackerSource) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
 	s.i.Svc.Logger.Warn().Err(err).Send()
-	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, err.Error())
+	s.i.Svc.Ec.Append(s.i.Repository.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
 }
 
 // getManifest reads and parses the package manifest.
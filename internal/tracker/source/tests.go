@@ -20,6 +20,7 @@ type TestsServicesWrapper struct {
 	Ec  *repo.ErrorsCollectorMock
 	Hc  *tests.HTTPClientMock
 	Is  *img.StoreMock
+	Sv  *repo.OCISignatureVerifierMock
 	Svc *hub.TrackerSourceServices
 }
 
@@ -29,6 +30,7 @@ func NewTestsServicesWrapper() *TestsServicesWrapper {
 	ec := &repo.ErrorsCollectorMock{}
 	hc := &tests.HTTPClientMock{}
 	is := &img.StoreMock{}
+	sv := &repo.OCISignatureVerifierMock{}
 
 	// Setup tracker source services using mocks
 	svc := &hub.TrackerSourceServices{
@@ -37,6 +39,7 @@ func NewTestsServicesWrapper() *TestsServicesWrapper {
 		Ec:       ec,
 		Hc:       hc,
 		Is:       is,
+		Sv:       sv,
 		Logger:   zerolog.Nop(),
 		GithubRL: rate.NewLimiter(rate.Inf, 0),
 	}
@@ -46,6 +49,7 @@ func NewTestsServicesWrapper() *TestsServicesWrapper {
 		Ec:  ec,
 		Hc:  hc,
 		Is:  is,
+		Sv:  sv,
 		Svc: svc,
 	}
 }
@@ -56,6 +60,7 @@ func (sw *TestsServicesWrapper) AssertExpectations(t *testing.T) {
 	sw.Ec.AssertExpectations(t)
 	sw.Hc.AssertExpectations(t)
 	sw.Is.AssertExpectations(t)
+	sw.Sv.AssertExpectations(t)
 }
 
 // ClonePackage clones the provided package returning a new one.
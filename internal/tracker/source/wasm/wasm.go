@@ -0,0 +1,49 @@
+package wasm
+
+import (
+	"errors"
+
+	"github.com/artifacthub/hub/internal/hub"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// wasmLayerMediaTypes represents the media types used by the layers holding
+// the WASM module in the artifacts supported: modules pushed with wasm-to-oci
+// or wash (wasmCloud actors), WASM OCI artifacts and WASM components.
+var wasmLayerMediaTypes = []string{
+	"application/vnd.module.wasm.content.layer.v1+wasm",
+	"application/vnd.wasm.content.layer.v1+wasm",
+	"application/wasm",
+}
+
+// MetadataExtractor is an oci.MetadataExtractor implementation for WASM
+// modules stored in OCI registries.
+type MetadataExtractor struct{}
+
+// Extract implements the oci.MetadataExtractor interface.
+func (e *MetadataExtractor) Extract(p *hub.Package, manifest *v1.Manifest) error {
+	layer, err := getWasmLayer(manifest)
+	if err != nil {
+		return err
+	}
+	p.Data = map[string]interface{}{
+		"configMediaType": string(manifest.Config.MediaType),
+		"layerMediaType":  string(layer.MediaType),
+		"layerDigest":     layer.Digest.String(),
+		"layerSize":       layer.Size,
+	}
+	return nil
+}
+
+// getWasmLayer returns the layer holding the WASM module in the manifest
+// provided.
+func getWasmLayer(manifest *v1.Manifest) (*v1.Descriptor, error) {
+	for i, layer := range manifest.Layers {
+		for _, mediaType := range wasmLayerMediaTypes {
+			if string(layer.MediaType) == mediaType {
+				return &manifest.Layers[i], nil
+			}
+		}
+	}
+	return nil, errors.New("wasm layer not found")
+}
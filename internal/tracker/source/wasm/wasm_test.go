@@ -0,0 +1,50 @@
+package wasm
+
+import (
+	"testing"
+
+	"github.com/artifacthub/hub/internal/hub"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataExtractor(t *testing.T) {
+	t.Run("wasm layer not found", func(t *testing.T) {
+		t.Parallel()
+		manifest := &v1.Manifest{
+			Layers: []v1.Descriptor{
+				{MediaType: "application/tar+gzip"},
+			},
+		}
+		err := (&MetadataExtractor{}).Extract(&hub.Package{}, manifest)
+		assert.EqualError(t, err, "wasm layer not found")
+	})
+
+	t.Run("wasm layer found", func(t *testing.T) {
+		t.Parallel()
+		layerDigest, _ := v1.NewHash("sha256:0f6fc5d0ec3c1b5c1f6d6b2b6b6e2b7c6f3a7f1c3c0e6d5b4a3f2e1d0c9b8a7f")
+		manifest := &v1.Manifest{
+			Config: v1.Descriptor{
+				MediaType: "application/vnd.wasmcloud.actor.archive.config",
+			},
+			Layers: []v1.Descriptor{
+				{MediaType: "application/tar+gzip"},
+				{
+					MediaType: "application/vnd.module.wasm.content.layer.v1+wasm",
+					Digest:    layerDigest,
+					Size:      1024,
+				},
+			},
+		}
+		p := &hub.Package{}
+		err := (&MetadataExtractor{}).Extract(p, manifest)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"configMediaType": "application/vnd.wasmcloud.actor.archive.config",
+			"layerMediaType":  "application/vnd.module.wasm.content.layer.v1+wasm",
+			"layerDigest":     "sha256:0f6fc5d0ec3c1b5c1f6d6b2b6b6e2b7c6f3a7f1c3c0e6d5b4a3f2e1d0c9b8a7f",
+			"layerSize":       int64(1024),
+		}, p.Data)
+	})
+}
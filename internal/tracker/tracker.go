@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/pkg"
@@ -14,6 +15,10 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// trackingRunProgressInterval represents the minimum interval between two
+// consecutive updates of the progress of a tracking run in the database.
+const trackingRunProgressInterval = 5 * time.Second
+
 // Tracker is in charge of tracking the packages available in the repository
 // provided, registering and unregistering them as needed.
 type Tracker struct {
@@ -23,19 +28,58 @@ type Tracker struct {
 	packagesRegistered map[string]string
 	basePath           string
 	logger             zerolog.Logger
+	run                *hub.TrackingRun
+	runErrs            []string
+	runUpdatedAt       time.Time
 }
 
 // New creates a new Tracker instance.
-func New(svc *hub.TrackerServices, r *hub.Repository, logger zerolog.Logger) *Tracker {
-	return &Tracker{
+func New(
+	svc *hub.TrackerServices,
+	r *hub.Repository,
+	logger zerolog.Logger,
+	opts ...func(t *Tracker),
+) *Tracker {
+	t := &Tracker{
 		svc:    svc,
 		r:      r,
 		logger: logger,
 	}
+	for _, o := range opts {
+		o(t)
+	}
+	return t
+}
+
+// WithTrackingRun allows providing the id of the tracking run registered for
+// the repository, so that its status and progress are kept updated.
+func WithTrackingRun(trackingRunID string) func(t *Tracker) {
+	return func(t *Tracker) {
+		t.run = &hub.TrackingRun{
+			TrackingRunID: trackingRunID,
+			Status:        hub.TrackingRunQueued,
+		}
+	}
 }
 
 // Run initializes the tracking of the repository provided.
-func (t *Tracker) Run() error {
+func (t *Tracker) Run() (err error) {
+	// Keep tracking run status and progress updated
+	t.updateRun(hub.TrackingRunRunning, true)
+	defer func() {
+		if err != nil {
+			t.runErrs = append(t.runErrs, err.Error())
+		}
+		t.updateRun(hub.TrackingRunCompleted, true)
+	}()
+
+	// Resolve repository credentials when they reference an external secret
+	if t.r.AuthPassRef != "" {
+		if err := t.svc.Rm.ResolveCredentials(t.svc.Ctx, t.r); err != nil {
+			return fmt.Errorf("error resolving repository credentials: %w", err)
+		}
+	}
+
 	// Check if repository has been updated since last time it was processed
 	remoteDigest, err := t.svc.Rm.GetRemoteDigest(t.svc.Ctx, t.r)
 	if err != nil {
@@ -72,6 +116,13 @@ func (t *Tracker) Run() error {
 	if err != nil {
 		return fmt.Errorf("error getting packages available: %w", err)
 	}
+	for _, p := range pruneVersions(t.r.RetentionPolicy, t.md, packagesAvailable, time.Now()) {
+		t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("version pruned by retention policy")
+	}
+	if t.run != nil {
+		t.run.PackagesTotal = len(packagesAvailable)
+		t.updateRun(hub.TrackingRunRunning, true)
+	}
 
 	// Register available packages when needed
 	for _, p := range packagesAvailable {
@@ -82,21 +133,11 @@ func (t *Tracker) Run() error {
 		default:
 		}
 
-		// Check if this package version is already registered
-		digest, ok := t.packagesRegistered[pkg.BuildKey(p)]
-		if ok && p.Digest == digest && !bypassDigestCheck {
-			continue
-		}
-
-		// Check if this package should be ignored
-		if shouldIgnorePackage(t.md, p.Name, p.Version) {
-			continue
-		}
-
-		// Register package
-		t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("registering package")
-		if err := t.svc.Pm.Register(t.svc.Ctx, p); err != nil {
-			t.warn(fmt.Errorf("error registering package %s version %s: %w", p.Name, p.Version, err))
+		// Register package if needed and update tracking run progress
+		t.registerPackage(p, bypassDigestCheck)
+		if t.run != nil {
+			t.run.PackagesProcessed++
+			t.updateRun(hub.TrackingRunRunning, false)
 		}
 	}
 
@@ -110,7 +151,8 @@ func (t *Tracker) Run() error {
 			default:
 			}
 
-			// Unregister pkg if it's not available anymore or if it's ignored
+			// Unregister pkg if it's not available anymore (or it has been
+			// pruned by the retention policy) or if it's ignored
 			name, version := pkg.ParseKey(key)
 			_, ok := packagesAvailable[key]
 			if !ok || shouldIgnorePackage(t.md, name, version) {
@@ -121,7 +163,7 @@ func (t *Tracker) Run() error {
 					Repository: t.r,
 				}
 				if err := t.svc.Pm.Unregister(t.svc.Ctx, p); err != nil {
-					t.warn(fmt.Errorf("error unregistering package %s version %s: %w", name, version, err))
+					t.warn(hub.RepositoryErrorClassRegistration, name, version, fmt.Errorf("error unregistering package %s version %s: %w", name, version, err))
 				}
 			}
 		}
@@ -129,7 +171,7 @@ func (t *Tracker) Run() error {
 
 	// Set verified publisher flag if needed
 	if err := setVerifiedPublisherFlag(t.svc.Ctx, t.svc.Rm, t.r, t.md); err != nil {
-		t.warn(fmt.Errorf("error setting verified publisher flag: %w", err))
+		t.warn(hub.RepositoryErrorClassRepository, "", "", fmt.Errorf("error setting verified publisher flag: %w", err))
 	}
 
 	// Update repository digest if needed
@@ -142,6 +184,27 @@ func (t *Tracker) Run() error {
 	return nil
 }
 
+// registerPackage registers the package provided, unless it's already
+// registered and hasn't changed or it should be ignored.
+func (t *Tracker) registerPackage(p *hub.Package, bypassDigestCheck bool) {
+	// Check if this package version is already registered
+	digest, ok := t.packagesRegistered[pkg.BuildKey(p)]
+	if ok && p.Digest == digest && !bypassDigestCheck {
+		return
+	}
+
+	// Check if this package should be ignored
+	if shouldIgnorePackage(t.md, p.Name, p.Version) {
+		return
+	}
+
+	// Register package
+	t.logger.Debug().Str("name", p.Name).Str("v", p.Version).Msg("registering package")
+	if err := t.svc.Pm.Register(t.svc.Ctx, p); err != nil {
+		t.warn(hub.RepositoryErrorClassRegistration, p.Name, p.Version, fmt.Errorf("error registering package %s version %s: %w", p.Name, p.Version, err))
+	}
+}
+
 // cloneRepository creates a local cope of the repository provided to the
 // tracker instance when applicable to the repository kind.
 func (t *Tracker) cloneRepository() (string, string, error) {
@@ -149,8 +212,9 @@ func (t *Tracker) cloneRepository() (string, string, error) {
 	var err error
 
 	switch t.r.Kind {
-	case hub.Helm:
-		// Helm repositories are not cloned
+	case hub.CrossplaneConfiguration, hub.Helm, hub.OCIArtifact, hub.Wasm:
+		// Repositories stored in OCI registries or Helm repositories are not
+		// cloned
 	case hub.OLM:
 		if strings.HasPrefix(t.r.URL, hub.RepositoryOCIPrefix) {
 			tmpDir, err = t.svc.Oe.ExportRepository(t.svc.Ctx, t.r)
@@ -196,6 +260,7 @@ func (t *Tracker) getPackagesAvailable() (map[string]*hub.Package, error) {
 			Ec:       t.svc.Ec,
 			Hc:       t.svc.Hc,
 			Is:       t.svc.Is,
+			Sv:       t.svc.Sv,
 			Logger:   t.logger,
 			GithubRL: t.svc.GithubRL,
 		},
@@ -204,9 +269,33 @@ func (t *Tracker) getPackagesAvailable() (map[string]*hub.Package, error) {
 	return source.GetPackagesAvailable()
 }
 
+// updateRun updates the status and progress of the tracking run in the
+// database, when one has been provided. Progress updates are throttled unless
+// forced, to avoid hitting the database once per package processed.
+func (t *Tracker) updateRun(status hub.TrackingRunStatus, force bool) {
+	if t.run == nil {
+		return
+	}
+	if !force && time.Since(t.runUpdatedAt) < trackingRunProgressInterval {
+		return
+	}
+	t.run.Status = status
+	t.run.Errors = strings.Join(t.runErrs, "\n")
+	if err := t.svc.Rm.UpdateTrackingRun(t.svc.Ctx, t.run); err != nil {
+		t.logger.Warn().Err(fmt.Errorf("error updating tracking run: %w", err)).Send()
+	}
+	t.runUpdatedAt = time.Now()
+}
+
 // warn is a helper that sends the error provided to the errors collector and
 // logs it as a warning.
-func (t *Tracker) warn(err error) {
+func (t *Tracker) warn(class hub.RepositoryErrorClass, pkgName, pkgVersion string, err error) {
 	t.logger.Warn().Err(err).Send()
-	t.svc.Ec.Append(t.r.RepositoryID, err.Error())
+	t.svc.Ec.Append(t.r.RepositoryID, &hub.RepositoryError{
+		PackageName:    pkgName,
+		PackageVersion: pkgVersion,
+		Class:          class,
+		Message:        err.Error(),
+	})
+	t.runErrs = append(t.runErrs, err.Error())
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
 )
 
@@ -41,6 +43,22 @@ func TestTracker(t *testing.T) {
 		Repository: r1,
 	}
 
+	t.Run("error resolving repository credentials", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		r := &hub.Repository{
+			AuthPassRef: "kubernetes:repo1-creds#password",
+		}
+		sw := newServicesWrapper()
+		sw.rm.On("ResolveCredentials", sw.svc.Ctx, r).Return(tests.ErrFake)
+
+		// Run test and check expectations
+		err := New(sw.svc, r, zerolog.Nop()).Run()
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		sw.assertExpectations(t)
+	})
+
 	t.Run("error getting repository remote digest", func(t *testing.T) {
 		t.Parallel()
 
@@ -200,7 +218,12 @@ func TestTracker(t *testing.T) {
 		}, nil)
 		sw.pm.On("Register", sw.svc.Ctx, p1v1).Return(tests.ErrFake)
 		expectedErr := "error registering package pkg1 version 1.0.0: fake error for tests"
-		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.ec.On("Append", r1.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg1",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassRegistration,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -335,7 +358,12 @@ func TestTracker(t *testing.T) {
 		}, nil)
 		sw.pm.On("Unregister", sw.svc.Ctx, p1v1).Return(tests.ErrFake)
 		expectedErr := "error unregistering package pkg1 version 1.0.0: fake error for tests"
-		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.ec.On("Append", r1.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg1",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassRegistration,
+			Message:        expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -417,6 +445,40 @@ func TestTracker(t *testing.T) {
 		sw.assertExpectations(t)
 	})
 
+	t.Run("package version pruned by retention policy unregistered successfully", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		r := &hub.Repository{
+			RepositoryID:    "repo1",
+			Kind:            hub.Helm,
+			URL:             "https://repo.url",
+			RetentionPolicy: &hub.RetentionPolicy{MaxVersions: 1},
+		}
+		sw := newServicesWrapper()
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r).Return("", nil)
+		sw.ec.On("Init", r.RepositoryID)
+		sw.rm.On("GetMetadata", r.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r.RepositoryID).Return(map[string]string{
+			pkg.BuildKey(p1v1): "",
+			pkg.BuildKey(p1v2): "",
+		}, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p1v1): p1v1,
+			pkg.BuildKey(p1v2): p1v2,
+		}, nil)
+		sw.pm.On("Unregister", sw.svc.Ctx, &hub.Package{
+			Name:       p1v1.Name,
+			Version:    p1v1.Version,
+			Repository: r,
+		}).Return(nil)
+
+		// Run test and check expectations
+		err := New(sw.svc, r, zerolog.Nop()).Run()
+		assert.Nil(t, err)
+		sw.assertExpectations(t)
+	})
+
 	t.Run("error setting verified publisher flag", func(t *testing.T) {
 		t.Parallel()
 
@@ -431,7 +493,10 @@ func TestTracker(t *testing.T) {
 		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{}, nil)
 		sw.rm.On("SetVerifiedPublisher", sw.svc.Ctx, r1.RepositoryID, true).Return(tests.ErrFake)
 		expectedErr := "error setting verified publisher flag: error setting verified publisher flag: fake error for tests"
-		sw.ec.On("Append", r1.RepositoryID, expectedErr).Return()
+		sw.ec.On("Append", r1.RepositoryID, &hub.RepositoryError{
+			Class:   hub.RepositoryErrorClassRepository,
+			Message: expectedErr,
+		}).Return()
 
 		// Run test and check expectations
 		err := New(sw.svc, r1, zerolog.Nop()).Run()
@@ -456,6 +521,85 @@ func TestTracker(t *testing.T) {
 		assert.Nil(t, err)
 		sw.assertExpectations(t)
 	})
+
+	t.Run("tracking run completed with errors", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		var runs []hub.TrackingRun
+		sw.rm.On("UpdateTrackingRun", sw.svc.Ctx, mock.Anything).Run(func(args mock.Arguments) {
+			runs = append(runs, *args.Get(1).(*hub.TrackingRun))
+		}).Return(nil)
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", tests.ErrFake)
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop(), WithTrackingRun("run1")).Run()
+		assert.True(t, errors.Is(err, tests.ErrFake))
+		assert.Equal(t, []hub.TrackingRun{
+			{
+				TrackingRunID: "run1",
+				Status:        hub.TrackingRunRunning,
+			},
+			{
+				TrackingRunID: "run1",
+				Status:        hub.TrackingRunCompleted,
+				Errors:        "error getting repository remote digest: fake error for tests",
+			},
+		}, runs)
+		sw.assertExpectations(t)
+	})
+
+	t.Run("tracking run status and progress updated", func(t *testing.T) {
+		t.Parallel()
+
+		// Setup services and expectations
+		sw := newServicesWrapper()
+		var runs []hub.TrackingRun
+		sw.rm.On("UpdateTrackingRun", sw.svc.Ctx, mock.Anything).Run(func(args mock.Arguments) {
+			runs = append(runs, *args.Get(1).(*hub.TrackingRun))
+		}).Return(nil)
+		sw.rm.On("GetRemoteDigest", sw.svc.Ctx, r1).Return("", nil)
+		sw.ec.On("Init", r1.RepositoryID)
+		sw.rm.On("GetMetadata", r1.URL+"/"+hub.RepositoryMetadataFile).Return(nil, nil)
+		sw.rm.On("GetPackagesDigest", sw.svc.Ctx, r1.RepositoryID).Return(map[string]string{
+			pkg.BuildKey(p1v1): "",
+		}, nil)
+		sw.src.On("GetPackagesAvailable").Return(map[string]*hub.Package{
+			pkg.BuildKey(p1v1): p1v1,
+			pkg.BuildKey(p2v1): p2v1,
+		}, nil)
+		sw.pm.On("Register", sw.svc.Ctx, p2v1).Return(tests.ErrFake)
+		expectedErr := "error registering package pkg2 version 1.0.0: fake error for tests"
+		sw.ec.On("Append", r1.RepositoryID, &hub.RepositoryError{
+			PackageName:    "pkg2",
+			PackageVersion: "1.0.0",
+			Class:          hub.RepositoryErrorClassRegistration,
+			Message:        expectedErr,
+		}).Return()
+
+		// Run test and check expectations
+		err := New(sw.svc, r1, zerolog.Nop(), WithTrackingRun("run1")).Run()
+		assert.Nil(t, err)
+		require.Len(t, runs, 3)
+		assert.Equal(t, hub.TrackingRun{
+			TrackingRunID: "run1",
+			Status:        hub.TrackingRunRunning,
+		}, runs[0])
+		assert.Equal(t, hub.TrackingRun{
+			TrackingRunID: "run1",
+			Status:        hub.TrackingRunRunning,
+			PackagesTotal: 2,
+		}, runs[1])
+		assert.Equal(t, hub.TrackingRun{
+			TrackingRunID:     "run1",
+			Status:            hub.TrackingRunCompleted,
+			PackagesTotal:     2,
+			PackagesProcessed: 2,
+			Errors:            expectedErr,
+		}, runs[2])
+		sw.assertExpectations(t)
+	})
 }
 
 type servicesWrapper struct {
@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// Database queries
+	deleteScheduledUsersDBQ = `select delete_scheduled_users()`
+
+	// deleterInterval represents how often the deleter checks if there are
+	// users whose deletion grace period has elapsed.
+	deleterInterval = 1 * time.Hour
+)
+
+// Deleter is in charge of deleting the users whose deletion was scheduled once
+// their deletion grace period has elapsed.
+type Deleter struct {
+	db hub.DB
+}
+
+// NewDeleter creates a new Deleter instance.
+func NewDeleter(db hub.DB) *Deleter {
+	return &Deleter{
+		db: db,
+	}
+}
+
+// Run runs the deleter periodically until it's asked to stop via the context
+// provided.
+func (d *Deleter) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(deleterInterval)
+	defer ticker.Stop()
+	for {
+		if n, err := d.DeleteScheduled(ctx); err != nil {
+			if ctx.Err() == nil {
+				log.Error().Err(err).Msg("error deleting scheduled users")
+			}
+		} else if n > 0 {
+			log.Info().Int64("users", n).Msg("scheduled users deleted")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteScheduled deletes the users whose deletion grace period has elapsed,
+// returning the number of users deleted.
+func (d *Deleter) DeleteScheduled(ctx context.Context) (int64, error) {
+	var n int64
+	err := d.db.QueryRow(ctx, deleteScheduledUsersDBQ).Scan(&n)
+	return n, err
+}
@@ -3,8 +3,10 @@ package user
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/sha512"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,31 +17,85 @@ import (
 	"github.com/artifacthub/hub/internal/email"
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/jackc/pgx/v4"
+	"github.com/rs/zerolog/log"
 	"github.com/satori/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/text/language"
 )
 
 const (
 	// Database queries
+	changeUserEmailDBQ           = `select change_user_email($1::uuid, $2::bytea, $3::boolean)`
+	checkTokenAPIKeyDBQ          = `select exists (select 1 from api_key ak join "user" u using (user_id) where ak.api_key_id = $1::uuid and ak.user_id = $2::uuid and u.deletion_scheduled_at is null and u.disabled = false)`
+	checkTokenSessionDBQ         = `select exists (select 1 from session s join "user" u using (user_id) where s.session_id = $1::bytea and s.user_id = $2::uuid and s.created_at > current_timestamp - make_interval(secs => $3::int) and u.deletion_scheduled_at is null and u.disabled = false)`
 	checkUserAliasAvailDBQ       = `select check_user_alias_availability($1::text)`
-	checkUserCredsDBQ            = `select user_id, password from "user" where email = $1 and password is not null and email_verified = true`
+	checkUserCredsDBQ            = `select user_id, password from "user" where email = $1 and password is not null and email_verified = true and disabled = false`
+	deleteFailedLoginsDBQ        = `select delete_failed_login_attempts($1::text)`
 	deleteSessionDBQ             = `delete from session where session_id = $1`
-	getAPIKeyInfoDBQ             = `select user_id, secret from api_key where api_key_id = $1`
-	getSessionDBQ                = `select user_id, floor(extract(epoch from created_at)) from session where session_id = $1`
+	getAPIKeyInfoDBQ             = `select ak.user_id, ak.secret, coalesce(ak.scopes, '{}') from api_key ak join "user" u using (user_id) where ak.api_key_id = $1 and u.deletion_scheduled_at is null and u.disabled = false`
+	getLoginThrottleDBQ          = `select locked, retry_after from get_login_throttle($1::text, nullif($2, '')::inet, $3::integer, $4::interval)`
+	getPasswordHistoryDBQ        = `select coalesce(array_agg(password), '{}') from (select password from password_history where user_id = $1::uuid order by created_at desc limit $2::integer) ph`
+	getResetCodePwdHistoryDBQ    = `select coalesce(array_agg(password), '{}') from (select password from password_history where user_id = (select user_id from password_reset_code where password_reset_code_id = sha512($1::bytea)) order by created_at desc limit $2::integer) ph`
+	getSessionDBQ                = `select s.user_id, floor(extract(epoch from s.created_at)) from session s join "user" u using (user_id) where s.session_id = $1 and u.disabled = false`
 	getUserIDDBQ                 = `select user_id from "user" where email = $1`
 	getUserPasswordDBQ           = `select password from "user" where user_id = $1 and password is not null`
 	getUserProfileDBQ            = `select get_user_profile($1::uuid)`
+	isPasswordExpiredDBQ         = `select coalesce(password_changed_at < current_timestamp - $2::interval, false) from "user" where user_id = $1::uuid`
+	registerDeleteUserCodeDBQ    = `select register_delete_user_code($1::uuid)`
+	registerEmailChangeCodeDBQ   = `select register_email_change_code($1::uuid, $2::text)`
+	registerFailedLoginDBQ       = `select register_failed_login_attempt($1::text, nullif($2, '')::inet, $3::integer, $4::interval)`
 	registerPasswordResetCodeDBQ = `select register_password_reset_code($1::text)`
 	registerSessionDBQ           = `select register_session($1::jsonb)`
+	registerSessionDeviceDBQ     = `select register_session_device($1::bytea, $2::bytea)`
 	registerUserDBQ              = `select register_user($1::jsonb)`
 	resetUserPasswordDBQ         = `select reset_user_password($1::bytea, $2::text)`
+	revokeSessionDBQ             = `select revoke_session($1::bytea)`
+	scheduleUserDeletionDBQ      = `select schedule_user_deletion($1::uuid, $2::bytea, $3::interval)`
 	updateUserPasswordDBQ        = `select update_user_password($1::uuid, $2::text, $3::text)`
 	updateUserProfileDBQ         = `select update_user_profile($1::uuid, $2::jsonb)`
 	verifyEmailDBQ               = `select verify_email($1::uuid)`
 	verifyPasswordResetCodeDBQ   = `select verify_password_reset_code($1::bytea)`
+
+	// DefaultDeletionGracePeriod represents the default period of time that
+	// must elapse since a user requests the deletion of the account until it
+	// is actually deleted.
+	DefaultDeletionGracePeriod = 30 * 24 * time.Hour
+
+	// DefaultLoginMaxFailedAttempts represents the default number of failed
+	// login attempts allowed within the lockout period before the account is
+	// temporarily locked.
+	DefaultLoginMaxFailedAttempts = 10
+
+	// DefaultLoginLockoutDuration represents the default period of time an
+	// account remains locked after too many failed login attempts.
+	DefaultLoginLockoutDuration = 15 * time.Minute
 )
 
 var (
+	// ErrEmailNotAvailable indicates that the email provided is already being
+	// used by another account.
+	ErrEmailNotAvailable = errors.New("email not available")
+
+	// errEmailNotAvailableDB represents the error returned from the database
+	// when the email provided is already being used by another account.
+	errEmailNotAvailableDB = errors.New("ERROR: email not available (SQLSTATE P0001)")
+
+	// ErrInvalidDeleteUserCode indicates that the delete user code provided
+	// is not valid.
+	ErrInvalidDeleteUserCode = errors.New("invalid delete user code")
+
+	// errInvalidDeleteUserCodeDB represents the error returned from the
+	// database when the delete user code is not valid.
+	errInvalidDeleteUserCodeDB = errors.New("ERROR: invalid delete user code (SQLSTATE P0001)")
+
+	// ErrInvalidEmailChangeCode indicates that the email change code provided
+	// is not valid.
+	ErrInvalidEmailChangeCode = errors.New("invalid email change code")
+
+	// errInvalidEmailChangeCodeDB represents the error returned from the
+	// database when the email change code is not valid.
+	errInvalidEmailChangeCodeDB = errors.New("ERROR: invalid email change code (SQLSTATE P0001)")
+
 	// ErrInvalidPassword indicates that the password provided is not valid.
 	ErrInvalidPassword = errors.New("invalid password")
 
@@ -51,24 +107,125 @@ var (
 	// database when the password reset code is not valid.
 	errInvalidPasswordResetCodeDB = errors.New("ERROR: invalid password reset code (SQLSTATE P0001)")
 
+	// ErrInvalidSessionRevocationCode indicates that the session revocation
+	// code provided is not valid.
+	ErrInvalidSessionRevocationCode = errors.New("invalid session revocation code")
+
+	// errInvalidSessionRevocationCodeDB represents the error returned from the
+	// database when the session revocation code is not valid.
+	errInvalidSessionRevocationCodeDB = errors.New("ERROR: invalid session revocation code (SQLSTATE P0001)")
+
 	// ErrNotFound indicates that the user does not exist.
 	ErrNotFound = errors.New("user not found")
 )
 
 // Manager provides an API to manage users.
 type Manager struct {
-	db hub.DB
-	es hub.EmailSender
+	db                     hub.DB
+	es                     hub.EmailSender
+	deletionGracePeriod    time.Duration
+	loginMaxFailedAttempts int
+	loginLockoutDuration   time.Duration
+	passwordPolicy         PasswordPolicy
+	hc                     hub.HTTPClient
 }
 
 // NewManager creates a new Manager instance.
-func NewManager(db hub.DB, es hub.EmailSender) *Manager {
-	return &Manager{
-		db: db,
-		es: es,
+func NewManager(db hub.DB, es hub.EmailSender, opts ...func(m *Manager)) *Manager {
+	m := &Manager{
+		db:                     db,
+		es:                     es,
+		deletionGracePeriod:    DefaultDeletionGracePeriod,
+		loginMaxFailedAttempts: DefaultLoginMaxFailedAttempts,
+		loginLockoutDuration:   DefaultLoginLockoutDuration,
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// WithDeletionGracePeriod allows providing the period of time that must
+// elapse since a user requests the deletion of the account until it is
+// actually deleted.
+func WithDeletionGracePeriod(d time.Duration) func(m *Manager) {
+	return func(m *Manager) {
+		if d > 0 {
+			m.deletionGracePeriod = d
+		}
+	}
+}
+
+// WithLoginLockout allows providing the number of failed login attempts
+// allowed within the lockout period before an account is temporarily locked,
+// as well as the duration of the lockout.
+func WithLoginLockout(maxFailedAttempts int, duration time.Duration) func(m *Manager) {
+	return func(m *Manager) {
+		if maxFailedAttempts > 0 {
+			m.loginMaxFailedAttempts = maxFailedAttempts
+		}
+		if duration > 0 {
+			m.loginLockoutDuration = duration
+		}
 	}
 }
 
+// ChangeEmail updates the email of the user doing the request to the new
+// email associated to the code provided, once it has been verified. The
+// previous address will be notified about the change. When requested, the user
+// sessions will be invalidated.
+func (m *Manager) ChangeEmail(ctx context.Context, codeB64 string, invalidateSessions bool) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if codeB64 == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "code not provided")
+	}
+	code, err := base64.URLEncoding.DecodeString(codeB64)
+	if err != nil {
+		return ErrInvalidEmailChangeCode
+	}
+
+	// Change user email in database
+	var oldEmail string
+	err = m.db.QueryRow(ctx, changeUserEmailDBQ, userID, code, invalidateSessions).Scan(&oldEmail)
+	if err != nil {
+		switch err.Error() {
+		case errInvalidEmailChangeCodeDB.Error():
+			return ErrInvalidEmailChangeCode
+		case errEmailNotAvailableDB.Error():
+			return ErrEmailNotAvailable
+		default:
+			return err
+		}
+	}
+
+	// Notify the previous address about the change
+	if m.es != nil {
+		u, err := m.GetProfile(ctx)
+		if err != nil {
+			return err
+		}
+		templateData := map[string]string{
+			"newEmail": u.Email,
+		}
+		var emailBody bytes.Buffer
+		if err := emailChangedTmpl.Execute(&emailBody, templateData); err != nil {
+			return err
+		}
+		emailData := &email.Data{
+			To:      oldEmail,
+			Subject: "Your email address has been changed",
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // CheckAPIKey checks if the api key provided is valid.
 func (m *Manager) CheckAPIKey(ctx context.Context, apiKeyID, apiKeySecret string) (*hub.CheckAPIKeyOutput, error) {
 	// Validate input
@@ -76,9 +233,10 @@ func (m *Manager) CheckAPIKey(ctx context.Context, apiKeyID, apiKeySecret string
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "api key id or secret not provided")
 	}
 
-	// Get key's user id and secret from database
+	// Get key's user id, secret and scopes from database
 	var userID, apiKeySecretHashed string
-	err := m.db.QueryRow(ctx, getAPIKeyInfoDBQ, apiKeyID).Scan(&userID, &apiKeySecretHashed)
+	var scopes []string
+	err := m.db.QueryRow(ctx, getAPIKeyInfoDBQ, apiKeyID).Scan(&userID, &apiKeySecretHashed, &scopes)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return &hub.CheckAPIKeyOutput{Valid: false}, nil
@@ -101,10 +259,14 @@ func (m *Manager) CheckAPIKey(ctx context.Context, apiKeyID, apiKeySecret string
 		}
 	}
 
-	return &hub.CheckAPIKeyOutput{
+	output := &hub.CheckAPIKeyOutput{
 		Valid:  true,
 		UserID: userID,
-	}, nil
+	}
+	for _, scope := range scopes {
+		output.Scopes = append(output.Scopes, hub.APIKeyScope(scope))
+	}
+	return output, nil
 }
 
 // CheckAvailability checks the availability of a given value for the provided
@@ -142,11 +304,14 @@ func (m *Manager) CheckAvailability(ctx context.Context, resourceKind, value str
 	return available, err
 }
 
-// CheckCredentials checks if the credentials provided are valid.
+// CheckCredentials checks if the credentials provided are valid. Failed
+// attempts are tracked per email and ip, applying progressive delays between
+// attempts and locking the account temporarily when too many of them fail.
 func (m *Manager) CheckCredentials(
 	ctx context.Context,
 	email,
-	password string,
+	password,
+	ip string,
 ) (*hub.CheckCredentialsOutput, error) {
 	// Validate input
 	if email == "" {
@@ -156,26 +321,101 @@ func (m *Manager) CheckCredentials(
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "password not provided")
 	}
 
+	// Check if login attempts are being throttled for the email or ip provided
+	lockout := fmt.Sprintf("%d seconds", int64(m.loginLockoutDuration.Seconds()))
+	var locked bool
+	var retryAfter int64
+	err := m.db.QueryRow(ctx, getLoginThrottleDBQ, email, ip, m.loginMaxFailedAttempts, lockout).
+		Scan(&locked, &retryAfter)
+	if err != nil {
+		return nil, err
+	}
+	if retryAfter > 0 {
+		return &hub.CheckCredentialsOutput{
+			Valid:      false,
+			Locked:     locked,
+			RetryAfter: time.Duration(retryAfter) * time.Second,
+		}, nil
+	}
+
 	// Get password for email provided from database
 	var userID, hashedPassword string
-	err := m.db.QueryRow(ctx, checkUserCredsDBQ, email).Scan(&userID, &hashedPassword)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return &hub.CheckCredentialsOutput{Valid: false}, nil
-		}
+	err = m.db.QueryRow(ctx, checkUserCredsDBQ, email).Scan(&userID, &hashedPassword)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return nil, err
 	}
 
 	// Check if the password provided is valid
-	err = bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	userFound := err == nil
+	if !userFound || bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password)) != nil {
+		return m.registerFailedLoginAttempt(ctx, email, ip, lockout, userFound)
+	}
+
+	// Reset failed login attempts
+	if _, err := m.db.Exec(ctx, deleteFailedLoginsDBQ, email); err != nil {
+		return nil, err
+	}
+
+	// Check if the password has expired
+	var passwordExpired bool
+	if m.passwordPolicy.MaxAge > 0 {
+		maxAge := fmt.Sprintf("%d seconds", int64(m.passwordPolicy.MaxAge.Seconds()))
+		if err := m.db.QueryRow(ctx, isPasswordExpiredDBQ, userID, maxAge).Scan(&passwordExpired); err != nil {
+			return nil, err
+		}
+	}
+
+	return &hub.CheckCredentialsOutput{
+		Valid:           true,
+		UserID:          userID,
+		PasswordExpired: passwordExpired,
+	}, nil
+}
+
+// registerFailedLoginAttempt registers a failed login attempt for the email and
+// ip provided. When the attempt causes the account of an existing user to be
+// locked, the user is notified by email.
+func (m *Manager) registerFailedLoginAttempt(
+	ctx context.Context,
+	userEmail,
+	ip,
+	lockout string,
+	userFound bool,
+) (*hub.CheckCredentialsOutput, error) {
+	var locked bool
+	err := m.db.QueryRow(ctx, registerFailedLoginDBQ, userEmail, ip, m.loginMaxFailedAttempts, lockout).
+		Scan(&locked)
 	if err != nil {
+		return nil, err
+	}
+	if !locked {
 		return &hub.CheckCredentialsOutput{Valid: false}, nil
 	}
 
+	// Notify user that the account has been locked
+	if userFound && m.es != nil {
+		templateData := map[string]interface{}{
+			"attempts": m.loginMaxFailedAttempts,
+			"lockout":  m.loginLockoutDuration.String(),
+		}
+		var emailBody bytes.Buffer
+		if err := accountLockedTmpl.Execute(&emailBody, templateData); err != nil {
+			return nil, err
+		}
+		emailData := &email.Data{
+			To:      userEmail,
+			Subject: "Your account has been temporarily locked",
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return nil, err
+		}
+	}
 	return &hub.CheckCredentialsOutput{
-		Valid:  true,
-		UserID: userID,
-	}, err
+		Valid:      false,
+		Locked:     true,
+		RetryAfter: m.loginLockoutDuration,
+	}, nil
 }
 
 // CheckSession checks if the user session provided is valid.
@@ -214,6 +454,50 @@ func (m *Manager) CheckSession(
 	}, nil
 }
 
+// CheckTokenBinding checks if the credential the user's token is bound to is
+// still valid. Tokens bound to API keys or sessions that have been deleted,
+// sessions that have expired (using the session duration provided), or that
+// belong to users who are disabled, scheduled for deletion or no longer
+// exist, are not valid.
+func (m *Manager) CheckTokenBinding(
+	ctx context.Context,
+	userID string,
+	b *hub.TokenBinding,
+	sessionDuration time.Duration,
+) (bool, error) {
+	// Validate input
+	if _, err := uuid.FromString(userID); err != nil {
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid user id")
+	}
+	var query string
+	var args []interface{}
+	switch {
+	case b.APIKeyID != "":
+		if _, err := uuid.FromString(b.APIKeyID); err != nil {
+			return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid api key id")
+		}
+		query, args = checkTokenAPIKeyDBQ, []interface{}{b.APIKeyID, userID}
+	case b.SessionIDHash != "":
+		if _, err := hex.DecodeString(b.SessionIDHash); err != nil {
+			return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid session id hash")
+		}
+		if sessionDuration == 0 {
+			return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "session duration not provided")
+		}
+		query = checkTokenSessionDBQ
+		args = []interface{}{"\\x" + b.SessionIDHash, userID, int(sessionDuration.Seconds())}
+	default:
+		return false, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "token binding not provided")
+	}
+
+	// Check credential in database
+	var valid bool
+	if err := m.db.QueryRow(ctx, query, args...).Scan(&valid); err != nil {
+		return false, err
+	}
+	return valid, nil
+}
+
 // DeleteSession deletes a user session from the database.
 func (m *Manager) DeleteSession(ctx context.Context, sessionID []byte) error {
 	// Validate input
@@ -226,6 +510,67 @@ func (m *Manager) DeleteSession(ctx context.Context, sessionID []byte) error {
 	return err
 }
 
+// DeleteUser schedules the deletion of the user doing the request, provided
+// that the delete user code is valid. The user will be deleted once the
+// deletion grace period elapses, unless the user logs in again before that.
+func (m *Manager) DeleteUser(ctx context.Context, codeB64, baseURL string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if codeB64 == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "code not provided")
+	}
+	if m.es != nil {
+		u, err := url.Parse(baseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid base url")
+		}
+	}
+	code, err := base64.URLEncoding.DecodeString(codeB64)
+	if err != nil {
+		return ErrInvalidDeleteUserCode
+	}
+
+	// Get user profile, as it won't be available once the user sessions are
+	// invalidated
+	u, err := m.GetProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Schedule user deletion in database
+	gracePeriod := fmt.Sprintf("%d seconds", int64(m.deletionGracePeriod.Seconds()))
+	_, err = m.db.Exec(ctx, scheduleUserDeletionDBQ, userID, code, gracePeriod)
+	if err != nil {
+		if err.Error() == errInvalidDeleteUserCodeDB.Error() {
+			return ErrInvalidDeleteUserCode
+		}
+		return err
+	}
+
+	// Send user deletion scheduled email
+	if m.es != nil {
+		templateData := map[string]string{
+			"baseURL":      baseURL,
+			"deletionDate": time.Now().Add(m.deletionGracePeriod).UTC().Format("January 2, 2006"),
+		}
+		var emailBody bytes.Buffer
+		if err := userDeletionScheduledTmpl.Execute(&emailBody, templateData); err != nil {
+			return err
+		}
+		emailData := &email.Data{
+			To:      u.Email,
+			Subject: "Your account will be deleted",
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetProfile returns the profile of the user doing the request.
 func (m *Manager) GetProfile(ctx context.Context) (*hub.User, error) {
 	dataJSON, err := m.GetProfileJSON(ctx)
@@ -267,6 +612,91 @@ func (m *Manager) GetUserID(ctx context.Context, email string) (string, error) {
 	return userID, nil
 }
 
+// RegisterDeleteUserCode registers a code that allows the user doing the
+// request to confirm the deletion of the account. The code will be emailed to
+// the user.
+func (m *Manager) RegisterDeleteUserCode(ctx context.Context, baseURL string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Get user profile
+	u, err := m.GetProfile(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Register delete user code in database
+	var code []byte
+	err = m.db.QueryRow(ctx, registerDeleteUserCodeDBQ, userID).Scan(&code)
+	if err != nil {
+		return err
+	}
+
+	// Send delete user code email
+	if m.es != nil {
+		templateData := map[string]string{
+			"code": base64.URLEncoding.EncodeToString(code),
+		}
+		var emailBody bytes.Buffer
+		if err := deleteUserCodeTmpl.Execute(&emailBody, templateData); err != nil {
+			return err
+		}
+		emailData := &email.Data{
+			To:      u.Email,
+			Subject: "Confirm your account deletion",
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterEmailChangeCode registers a code that allows the user doing the
+// request to confirm the change of the account email to the new email
+// provided. The code will be emailed to the new address, so that it can be
+// verified before the change takes place.
+func (m *Manager) RegisterEmailChangeCode(ctx context.Context, newEmail string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if newEmail == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "new email not provided")
+	}
+
+	// Register email change code in database
+	var code []byte
+	err := m.db.QueryRow(ctx, registerEmailChangeCodeDBQ, userID, newEmail).Scan(&code)
+	if err != nil {
+		if err.Error() == errEmailNotAvailableDB.Error() {
+			return ErrEmailNotAvailable
+		}
+		return err
+	}
+
+	// Send email change code to the new address
+	if m.es != nil {
+		templateData := map[string]string{
+			"code": base64.URLEncoding.EncodeToString(code),
+		}
+		var emailBody bytes.Buffer
+		if err := emailChangeCodeTmpl.Execute(&emailBody, templateData); err != nil {
+			return err
+		}
+		emailData := &email.Data{
+			To:      newEmail,
+			Subject: "Confirm your new email address",
+			Body:    emailBody.Bytes(),
+		}
+		if err := m.es.SendEmail(emailData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RegisterPasswordResetCode registers a code that allows the user identified
 // by the email provided to reset the password. A link containing the code will
 // be email to the user to initiate the password reset process.
@@ -312,8 +742,11 @@ func (m *Manager) RegisterPasswordResetCode(ctx context.Context, userEmail, base
 	return nil
 }
 
-// RegisterSession registers a user session in the database.
-func (m *Manager) RegisterSession(ctx context.Context, session *hub.Session) ([]byte, error) {
+// RegisterSession registers a user session in the database. When the session
+// is created from a device not seen before for the user, a notification email
+// including a link to revoke the session is sent to the user. The base url
+// provided will be used to build the revocation link.
+func (m *Manager) RegisterSession(ctx context.Context, session *hub.Session, baseURL string) ([]byte, error) {
 	// Validate input
 	if session.UserID == "" {
 		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "user id not provided")
@@ -326,7 +759,60 @@ func (m *Manager) RegisterSession(ctx context.Context, session *hub.Session) ([]
 	sessionJSON, _ := json.Marshal(session)
 	var sessionID []byte
 	err := m.db.QueryRow(ctx, registerSessionDBQ, sessionJSON).Scan(&sessionID)
-	return sessionID, err
+	if err != nil {
+		return nil, err
+	}
+
+	// Notify user if the session was created from a new device. Errors are
+	// logged but they do not prevent the user from signing in.
+	if m.es != nil {
+		if err := m.notifyNewDevice(ctx, sessionID, session, baseURL); err != nil {
+			log.Error().Err(err).Str("userID", session.UserID).Msg("error notifying new device sign-in")
+		}
+	}
+
+	return sessionID, nil
+}
+
+// notifyNewDevice registers the device used to create the session provided,
+// sending a notification email to the user when it had not been seen before.
+func (m *Manager) notifyNewDevice(
+	ctx context.Context,
+	sessionID []byte,
+	session *hub.Session,
+	baseURL string,
+) error {
+	// Register session device in database
+	code := make([]byte, 32)
+	if _, err := rand.Read(code); err != nil {
+		return err
+	}
+	var userEmail *string
+	err := m.db.QueryRow(ctx, registerSessionDeviceDBQ, sessionID, code).Scan(&userEmail)
+	if err != nil {
+		return err
+	}
+	if userEmail == nil {
+		return nil
+	}
+
+	// Send new sign-in email
+	templateData := map[string]string{
+		"ip":        session.IP,
+		"userAgent": session.UserAgent,
+		"time":      time.Now().UTC().Format(time.RFC1123),
+		"link":      fmt.Sprintf("%s/revoke-session?code=%s", baseURL, base64.URLEncoding.EncodeToString(code)),
+	}
+	var emailBody bytes.Buffer
+	if err := newSignInTmpl.Execute(&emailBody, templateData); err != nil {
+		return err
+	}
+	emailData := &email.Data{
+		To:      *userEmail,
+		Subject: "New sign-in to your account",
+		Body:    emailBody.Bytes(),
+	}
+	return m.es.SendEmail(emailData)
 }
 
 // RegisterUser registers the user provided in the database. When the user is
@@ -353,9 +839,19 @@ func (m *Manager) RegisterUser(ctx context.Context, user *hub.User, baseURL stri
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid profile image id")
 		}
 	}
+	if user.Locale != "" {
+		tag, err := language.Parse(user.Locale)
+		if err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid locale")
+		}
+		user.Locale = tag.String()
+	}
 
-	// Hash password
+	// Validate and hash password
 	if user.Password != "" {
+		if err := m.validatePassword(ctx, user.Password, nil); err != nil {
+			return err
+		}
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 		if err != nil {
 			return err
@@ -409,6 +905,24 @@ func (m *Manager) ResetPassword(ctx context.Context, codeB64, newPassword, baseU
 		}
 	}
 
+	code, err := base64.URLEncoding.DecodeString(codeB64)
+	if err != nil {
+		return ErrInvalidPasswordResetCode
+	}
+
+	// Validate new password
+	var previousHashes []string
+	if m.passwordPolicy.HistorySize > 0 {
+		err := m.db.QueryRow(ctx, getResetCodePwdHistoryDBQ, code, m.passwordPolicy.HistorySize).
+			Scan(&previousHashes)
+		if err != nil {
+			return err
+		}
+	}
+	if err := m.validatePassword(ctx, newPassword, previousHashes); err != nil {
+		return err
+	}
+
 	// Hash new password
 	newHashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
@@ -416,10 +930,6 @@ func (m *Manager) ResetPassword(ctx context.Context, codeB64, newPassword, baseU
 	}
 
 	// Reset user password in database
-	code, err := base64.URLEncoding.DecodeString(codeB64)
-	if err != nil {
-		return ErrInvalidPasswordResetCode
-	}
 	var userEmail string
 	err = m.db.QueryRow(ctx, resetUserPasswordDBQ, code, string(newHashed)).Scan(&userEmail)
 	if err != nil {
@@ -451,6 +961,26 @@ func (m *Manager) ResetPassword(ctx context.Context, codeB64, newPassword, baseU
 	return nil
 }
 
+// RevokeSession revokes the session associated to the revocation code
+// provided, which is sent to users when they sign in from a new device.
+func (m *Manager) RevokeSession(ctx context.Context, codeB64 string) error {
+	// Validate input
+	if codeB64 == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "code not provided")
+	}
+	code, err := base64.URLEncoding.DecodeString(codeB64)
+	if err != nil {
+		return ErrInvalidSessionRevocationCode
+	}
+
+	// Revoke session in database
+	_, err = m.db.Exec(ctx, revokeSessionDBQ, code)
+	if err != nil && err.Error() == errInvalidSessionRevocationCodeDB.Error() {
+		return ErrInvalidSessionRevocationCode
+	}
+	return err
+}
+
 // UpdatePassword updates the user password in the database.
 func (m *Manager) UpdatePassword(ctx context.Context, old, new string) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -474,6 +1004,19 @@ func (m *Manager) UpdatePassword(ctx context.Context, old, new string) error {
 		return ErrInvalidPassword
 	}
 
+	// Validate new password
+	var previousHashes []string
+	if m.passwordPolicy.HistorySize > 0 {
+		err := m.db.QueryRow(ctx, getPasswordHistoryDBQ, userID, m.passwordPolicy.HistorySize).
+			Scan(&previousHashes)
+		if err != nil {
+			return err
+		}
+	}
+	if err := m.validatePassword(ctx, new, previousHashes); err != nil {
+		return err
+	}
+
 	// Hash new password
 	newHashed, err := bcrypt.GenerateFromPassword([]byte(new), bcrypt.DefaultCost)
 	if err != nil {
@@ -498,6 +1041,43 @@ func (m *Manager) UpdateProfile(ctx context.Context, user *hub.User) error {
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid profile image id")
 		}
 	}
+	if user.Locale != "" {
+		tag, err := language.Parse(user.Locale)
+		if err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid locale")
+		}
+		user.Locale = tag.String()
+	}
+	if user.NotificationsPreferences != nil {
+		for _, kind := range user.NotificationsPreferences.EmailDisabledEventKinds {
+			switch kind {
+			case hub.NewRelease,
+				hub.NewPackage,
+				hub.SecurityAlert,
+				hub.RepositoryTrackingErrors,
+				hub.RepositoryOwnershipClaim,
+				hub.RepositoryOwnershipTransfer,
+				hub.RepositoryScanningErrors,
+				hub.PackageDeprecated,
+				hub.SavedSearchNewMatches,
+				hub.PackageNewQuestion,
+				hub.RepositoryVerifiedPublisherChange:
+			default:
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid event kind in notifications preferences")
+			}
+		}
+		if q := user.NotificationsPreferences.QuietHours; q != nil {
+			if _, err := time.Parse("15:04", q.Start); err != nil {
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid quiet hours start time")
+			}
+			if _, err := time.Parse("15:04", q.End); err != nil {
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid quiet hours end time")
+			}
+			if _, err := time.LoadLocation(q.TimeZone); err != nil || q.TimeZone == "" {
+				return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid quiet hours time zone")
+			}
+		}
+	}
 
 	// Update user profile in database
 	userJSON, _ := json.Marshal(user)
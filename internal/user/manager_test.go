@@ -1,11 +1,13 @@
 package user
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha512"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +20,120 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+func TestChangeEmail(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	code := []byte("code")
+	codeB64 := base64.URLEncoding.EncodeToString(code)
+	profileJSON := []byte(`{"alias": "alias", "email": "new@email.com"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.ChangeEmail(context.Background(), codeB64, false)
+		})
+	})
+
+	t.Run("code not provided", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+
+		err := m.ChangeEmail(ctx, "", false)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "code not provided")
+	})
+
+	t.Run("invalid code encoding", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+
+		err := m.ChangeEmail(ctx, "!invalid!", false)
+		assert.Equal(t, ErrInvalidEmailChangeCode, err)
+	})
+
+	t.Run("database error changing user email", func(t *testing.T) {
+		testCases := []struct {
+			dbErr       error
+			expectedErr error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				errInvalidEmailChangeCodeDB,
+				ErrInvalidEmailChangeCode,
+			},
+			{
+				errEmailNotAvailableDB,
+				ErrEmailNotAvailable,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, changeUserEmailDBQ, "userID", code, true).Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.ChangeEmail(ctx, codeB64, true)
+				assert.Equal(t, tc.expectedErr, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("database error getting user profile", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, changeUserEmailDBQ, "userID", code, false).Return("old@email.com", nil)
+		db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		es := &email.SenderMock{}
+		m := NewManager(db, es)
+
+		err := m.ChangeEmail(ctx, codeB64, false)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("email changed successfully", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"email changed notification sent successfully",
+				nil,
+			},
+			{
+				"error sending email changed notification",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, changeUserEmailDBQ, "userID", code, false).Return("old@email.com", nil)
+				db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(profileJSON, nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+					return data.To == "old@email.com" && bytes.Contains(data.Body, []byte("new@email.com"))
+				})).Return(tc.emailSenderResponse)
+				m := NewManager(db, es)
+
+				err := m.ChangeEmail(ctx, codeB64, false)
+				assert.Equal(t, tc.emailSenderResponse, err)
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestCheckAPIKey(t *testing.T) {
 	ctx := context.Background()
 
@@ -79,7 +195,8 @@ func TestCheckAPIKey(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		secretHashed, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.DefaultCost)
-		db.On("QueryRow", ctx, getAPIKeyInfoDBQ, "keyID").Return([]interface{}{"userID", string(secretHashed)}, nil)
+		db.On("QueryRow", ctx, getAPIKeyInfoDBQ, "keyID").
+			Return([]interface{}{"userID", string(secretHashed), []string{}}, nil)
 		m := NewManager(db, nil)
 
 		output, err := m.CheckAPIKey(ctx, "keyID", "secret")
@@ -93,13 +210,30 @@ func TestCheckAPIKey(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
 		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
-		db.On("QueryRow", ctx, getAPIKeyInfoDBQ, "keyID").Return([]interface{}{"userID", secretHashed}, nil)
+		db.On("QueryRow", ctx, getAPIKeyInfoDBQ, "keyID").Return([]interface{}{"userID", secretHashed, []string{}}, nil)
 		m := NewManager(db, nil)
 
 		output, err := m.CheckAPIKey(ctx, "keyID", "secret")
 		assert.NoError(t, err)
 		assert.True(t, output.Valid)
 		assert.Equal(t, "userID", output.UserID)
+		assert.Empty(t, output.Scopes)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("valid key with some scopes", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		secretHashed := fmt.Sprintf("%x", sha512.Sum512([]byte("secret")))
+		db.On("QueryRow", ctx, getAPIKeyInfoDBQ, "keyID").
+			Return([]interface{}{"userID", secretHashed, []string{"read-only", "webhooks:manage"}}, nil)
+		m := NewManager(db, nil)
+
+		output, err := m.CheckAPIKey(ctx, "keyID", "secret")
+		assert.NoError(t, err)
+		assert.True(t, output.Valid)
+		assert.Equal(t, "userID", output.UserID)
+		assert.Equal(t, []hub.APIKeyScope{hub.APIKeyScopeReadOnly, hub.APIKeyScopeWebhooksManage}, output.Scopes)
 		db.AssertExpectations(t)
 	})
 }
@@ -181,6 +315,7 @@ func TestCheckAvailability(t *testing.T) {
 
 func TestCheckCredentials(t *testing.T) {
 	ctx := context.Background()
+	lockout := "900 seconds"
 
 	t.Run("invalid input", func(t *testing.T) {
 		testCases := []struct {
@@ -204,33 +339,93 @@ func TestCheckCredentials(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(nil, nil)
-				_, err := m.CheckCredentials(ctx, tc.email, tc.password)
+				_, err := m.CheckCredentials(ctx, tc.email, tc.password, "ip")
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
 		}
 	})
 
+	t.Run("error checking login throttle in database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, output)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("login attempt throttled", func(t *testing.T) {
+		testCases := []struct {
+			locked bool
+		}{
+			{false},
+			{true},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(fmt.Sprintf("locked: %t", tc.locked), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+					Return([]interface{}{tc.locked, int64(30)}, nil)
+				m := NewManager(db, nil)
+
+				output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
+				assert.NoError(t, err)
+				assert.False(t, output.Valid)
+				assert.Equal(t, tc.locked, output.Locked)
+				assert.Equal(t, 30*time.Second, output.RetryAfter)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("error getting credentials from database", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
+		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, output)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("credentials provided not found in database", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
 		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, registerFailedLoginDBQ, "email", "ip", 10, lockout).Return(false, nil)
 		m := NewManager(db, nil)
 
-		output, err := m.CheckCredentials(ctx, "email", "pass")
+		output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
 		assert.NoError(t, err)
 		assert.False(t, output.Valid)
 		assert.Empty(t, output.UserID)
+		assert.False(t, output.Locked)
 		db.AssertExpectations(t)
 	})
 
-	t.Run("error getting credentials from database", func(t *testing.T) {
+	t.Run("error registering failed login attempt", func(t *testing.T) {
 		t.Parallel()
+		pw, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
 		db := &tests.DBMock{}
-		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return(nil, tests.ErrFakeDB)
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
+		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return([]interface{}{"userID", string(pw)}, nil)
+		db.On("QueryRow", ctx, registerFailedLoginDBQ, "email", "ip", 10, lockout).Return(nil, tests.ErrFakeDB)
 		m := NewManager(db, nil)
 
-		output, err := m.CheckCredentials(ctx, "email", "pass")
+		output, err := m.CheckCredentials(ctx, "email", "pass2", "ip")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, output)
 		db.AssertExpectations(t)
@@ -240,13 +435,97 @@ func TestCheckCredentials(t *testing.T) {
 		t.Parallel()
 		pw, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
 		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return([]interface{}{"userID", string(pw)}, nil)
+		db.On("QueryRow", ctx, registerFailedLoginDBQ, "email", "ip", 10, lockout).Return(false, nil)
 		m := NewManager(db, nil)
 
-		output, err := m.CheckCredentials(ctx, "email", "pass2")
+		output, err := m.CheckCredentials(ctx, "email", "pass2", "ip")
 		assert.NoError(t, err)
 		assert.False(t, output.Valid)
 		assert.Empty(t, output.UserID)
+		assert.False(t, output.Locked)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("invalid credentials provided, account locked", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+			expectedErr         error
+		}{
+			{
+				"account locked email sent successfully",
+				nil,
+				nil,
+			},
+			{
+				"error sending account locked email",
+				email.ErrFakeSenderFailure,
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				pw, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 3, "60 seconds").
+					Return([]interface{}{false, int64(0)}, nil)
+				db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return([]interface{}{"userID", string(pw)}, nil)
+				db.On("QueryRow", ctx, registerFailedLoginDBQ, "email", "ip", 3, "60 seconds").Return(true, nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+					return data.To == "email"
+				})).Return(tc.emailSenderResponse)
+				m := NewManager(db, es, WithLoginLockout(3, 1*time.Minute))
+
+				output, err := m.CheckCredentials(ctx, "email", "pass2", "ip")
+				assert.Equal(t, tc.expectedErr, err)
+				if tc.expectedErr == nil {
+					assert.False(t, output.Valid)
+					assert.True(t, output.Locked)
+					assert.Equal(t, 1*time.Minute, output.RetryAfter)
+				}
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("account locked but user not found, no email sent", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
+		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return(nil, pgx.ErrNoRows)
+		db.On("QueryRow", ctx, registerFailedLoginDBQ, "email", "ip", 10, lockout).Return(true, nil)
+		es := &email.SenderMock{}
+		m := NewManager(db, es)
+
+		output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
+		assert.NoError(t, err)
+		assert.False(t, output.Valid)
+		assert.True(t, output.Locked)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("error deleting failed login attempts", func(t *testing.T) {
+		t.Parallel()
+		pw, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
+		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return([]interface{}{"userID", string(pw)}, nil)
+		db.On("Exec", ctx, deleteFailedLoginsDBQ, "email").Return(tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.Nil(t, output)
 		db.AssertExpectations(t)
 	})
 
@@ -254,13 +533,35 @@ func TestCheckCredentials(t *testing.T) {
 		t.Parallel()
 		pw, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
 		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
 		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return([]interface{}{"userID", string(pw)}, nil)
+		db.On("Exec", ctx, deleteFailedLoginsDBQ, "email").Return(nil)
 		m := NewManager(db, nil)
 
-		output, err := m.CheckCredentials(ctx, "email", "pass")
+		output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
 		assert.NoError(t, err)
 		assert.True(t, output.Valid)
 		assert.Equal(t, "userID", output.UserID)
+		assert.False(t, output.PasswordExpired)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("valid credentials provided, password expired", func(t *testing.T) {
+		t.Parallel()
+		pw, _ := bcrypt.GenerateFromPassword([]byte("pass"), bcrypt.DefaultCost)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getLoginThrottleDBQ, "email", "ip", 10, lockout).
+			Return([]interface{}{false, int64(0)}, nil)
+		db.On("QueryRow", ctx, checkUserCredsDBQ, "email").Return([]interface{}{"userID", string(pw)}, nil)
+		db.On("Exec", ctx, deleteFailedLoginsDBQ, "email").Return(nil)
+		db.On("QueryRow", ctx, isPasswordExpiredDBQ, "userID", "86400 seconds").Return(true, nil)
+		m := NewManager(db, nil, WithPasswordPolicy(PasswordPolicy{MaxAge: 24 * time.Hour}))
+
+		output, err := m.CheckCredentials(ctx, "email", "pass", "ip")
+		assert.NoError(t, err)
+		assert.True(t, output.Valid)
+		assert.True(t, output.PasswordExpired)
 		db.AssertExpectations(t)
 	})
 }
@@ -358,6 +659,109 @@ func TestCheckSession(t *testing.T) {
 	})
 }
 
+func TestCheckTokenBinding(t *testing.T) {
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	apiKeyID := "00000000-0000-0000-0000-000000000002"
+	sessionIDHash := fmt.Sprintf("%x", sha512.Sum512([]byte("sessionID")))
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			userID  string
+			binding *hub.TokenBinding
+		}{
+			{
+				"invalid user id",
+				"invalid",
+				&hub.TokenBinding{APIKeyID: apiKeyID},
+			},
+			{
+				"invalid api key id",
+				userID,
+				&hub.TokenBinding{APIKeyID: "invalid"},
+			},
+			{
+				"invalid session id hash",
+				userID,
+				&hub.TokenBinding{SessionIDHash: "invalid"},
+			},
+			{
+				"token binding not provided",
+				userID,
+				&hub.TokenBinding{},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil, nil)
+				_, err := m.CheckTokenBinding(ctx, tc.userID, tc.binding, time.Hour)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("session duration not provided", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		_, err := m.CheckTokenBinding(ctx, userID, &hub.TokenBinding{SessionIDHash: sessionIDHash}, 0)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "session duration not provided")
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, checkTokenAPIKeyDBQ, apiKeyID, userID).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		valid, err := m.CheckTokenBinding(ctx, userID, &hub.TokenBinding{APIKeyID: apiKeyID}, time.Hour)
+		assert.Equal(t, tests.ErrFakeDB, err)
+		assert.False(t, valid)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("token bound to an api key", func(t *testing.T) {
+		testCases := []bool{true, false}
+		for _, exists := range testCases {
+			exists := exists
+			t.Run(fmt.Sprintf("api key exists: %t", exists), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, checkTokenAPIKeyDBQ, apiKeyID, userID).Return(exists, nil)
+				m := NewManager(db, nil)
+
+				valid, err := m.CheckTokenBinding(ctx, userID, &hub.TokenBinding{APIKeyID: apiKeyID}, time.Hour)
+				assert.NoError(t, err)
+				assert.Equal(t, exists, valid)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("token bound to a session", func(t *testing.T) {
+		testCases := []bool{true, false}
+		for _, exists := range testCases {
+			exists := exists
+			t.Run(fmt.Sprintf("session exists: %t", exists), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, checkTokenSessionDBQ, hashSessionID([]byte("sessionID")), userID, 3600).
+					Return(exists, nil)
+				m := NewManager(db, nil)
+
+				valid, err := m.CheckTokenBinding(ctx, userID, &hub.TokenBinding{SessionIDHash: sessionIDHash}, time.Hour)
+				assert.NoError(t, err)
+				assert.Equal(t, exists, valid)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
 func TestDeleteSession(t *testing.T) {
 	ctx := context.Background()
 	hashedSessionID := hashSessionID([]byte("sessionID"))
@@ -410,12 +814,142 @@ func TestDeleteSession(t *testing.T) {
 			t.Run(tc.description, func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("Exec", ctx, deleteSessionDBQ, hashedSessionID).Return(tc.dbResponse)
-				m := NewManager(db, nil)
+				db.On("Exec", ctx, deleteSessionDBQ, hashedSessionID).Return(tc.dbResponse)
+				m := NewManager(db, nil)
+
+				err := m.DeleteSession(ctx, []byte("sessionID"))
+				assert.Equal(t, tc.dbResponse, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	code := []byte("code")
+	codeB64 := base64.URLEncoding.EncodeToString(code)
+	profileJSON := []byte(`{"alias": "alias", "email": "email"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.DeleteUser(context.Background(), codeB64, "http://baseurl.com")
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg  string
+			codeB64 string
+			baseURL string
+		}{
+			{
+				"code not provided",
+				"",
+				"http://baseurl.com",
+			},
+			{
+				"invalid base url",
+				codeB64,
+				"invalid",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				es := &email.SenderMock{}
+				m := NewManager(nil, es)
+
+				err := m.DeleteUser(ctx, tc.codeB64, tc.baseURL)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("invalid code encoding", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+
+		err := m.DeleteUser(ctx, "!invalid!", "http://baseurl.com")
+		assert.Equal(t, ErrInvalidDeleteUserCode, err)
+	})
+
+	t.Run("database error getting user profile", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		err := m.DeleteUser(ctx, codeB64, "http://baseurl.com")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error scheduling user deletion", func(t *testing.T) {
+		testCases := []struct {
+			dbErr       error
+			expectedErr error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				errInvalidDeleteUserCodeDB,
+				ErrInvalidDeleteUserCode,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(profileJSON, nil)
+				db.On("Exec", ctx, scheduleUserDeletionDBQ, "userID", code, "2592000 seconds").Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.DeleteUser(ctx, codeB64, "http://baseurl.com")
+				assert.Equal(t, tc.expectedErr, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("user deletion scheduled successfully", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"user deletion scheduled email sent successfully",
+				nil,
+			},
+			{
+				"error sending user deletion scheduled email",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(profileJSON, nil)
+				db.On("Exec", ctx, scheduleUserDeletionDBQ, "userID", code, "3600 seconds").Return(nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+					return data.To == "email"
+				})).Return(tc.emailSenderResponse)
+				m := NewManager(db, es, WithDeletionGracePeriod(1*time.Hour))
 
-				err := m.DeleteSession(ctx, []byte("sessionID"))
-				assert.Equal(t, tc.dbResponse, err)
+				err := m.DeleteUser(ctx, codeB64, "http://baseurl.com")
+				assert.Equal(t, tc.emailSenderResponse, err)
 				db.AssertExpectations(t)
+				es.AssertExpectations(t)
 			})
 		}
 	})
@@ -452,6 +986,7 @@ func TestGetProfile(t *testing.T) {
 			LastName:       "last_name",
 			Email:          "email",
 			ProfileImageID: "profile_image_id",
+			Locale:         "es",
 		}
 
 		db := &tests.DBMock{}
@@ -461,7 +996,8 @@ func TestGetProfile(t *testing.T) {
 			"first_name": "first_name",
 			"last_name": "last_name",
 			"email": "email",
-			"profile_image_id": "profile_image_id"
+			"profile_image_id": "profile_image_id",
+			"locale": "es"
 		}
 		`), nil)
 		m := NewManager(db, nil)
@@ -573,7 +1109,7 @@ func TestRegisterSession(t *testing.T) {
 				t.Parallel()
 				m := NewManager(nil, nil)
 				s := &hub.Session{UserID: tc.userID}
-				_, err := m.RegisterSession(ctx, s)
+				_, err := m.RegisterSession(ctx, s, "http://baseurl.com")
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
@@ -586,7 +1122,7 @@ func TestRegisterSession(t *testing.T) {
 		db.On("QueryRow", ctx, registerSessionDBQ, mock.Anything).Return([]byte("sessionID"), nil)
 		m := NewManager(db, nil)
 
-		sessionID, err := m.RegisterSession(ctx, s)
+		sessionID, err := m.RegisterSession(ctx, s, "http://baseurl.com")
 		assert.NoError(t, err)
 		assert.Equal(t, []byte("sessionID"), sessionID)
 		db.AssertExpectations(t)
@@ -598,11 +1134,285 @@ func TestRegisterSession(t *testing.T) {
 		db.On("QueryRow", ctx, registerSessionDBQ, mock.Anything).Return(nil, tests.ErrFakeDB)
 		m := NewManager(db, nil)
 
-		sessionID, err := m.RegisterSession(ctx, s)
+		sessionID, err := m.RegisterSession(ctx, s, "http://baseurl.com")
 		assert.Equal(t, tests.ErrFakeDB, err)
 		assert.Nil(t, sessionID)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("session registered from a known device, no email sent", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerSessionDBQ, mock.Anything).Return([]byte("sessionID"), nil)
+		db.On("QueryRow", ctx, registerSessionDeviceDBQ, []byte("sessionID"), mock.Anything).Return(nil, nil)
+		es := &email.SenderMock{}
+		m := NewManager(db, es)
+
+		sessionID, err := m.RegisterSession(ctx, s, "http://baseurl.com")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("sessionID"), sessionID)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("session registered from a new device, email sent", func(t *testing.T) {
+		t.Parallel()
+		userEmail := "user1@email.com"
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerSessionDBQ, mock.Anything).Return([]byte("sessionID"), nil)
+		db.On("QueryRow", ctx, registerSessionDeviceDBQ, []byte("sessionID"), mock.Anything).
+			Return(&userEmail, nil)
+		es := &email.SenderMock{}
+		es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+			body := string(data.Body)
+			return data.To == userEmail &&
+				strings.Contains(body, "http://baseurl.com/revoke-session?code=") &&
+				strings.Contains(body, s.IP) &&
+				strings.Contains(body, s.UserAgent)
+		})).Return(nil)
+		m := NewManager(db, es)
+
+		sessionID, err := m.RegisterSession(ctx, s, "http://baseurl.com")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("sessionID"), sessionID)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+
+	t.Run("error sending new device email does not prevent signing in", func(t *testing.T) {
+		t.Parallel()
+		userEmail := "user1@email.com"
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, registerSessionDBQ, mock.Anything).Return([]byte("sessionID"), nil)
+		db.On("QueryRow", ctx, registerSessionDeviceDBQ, []byte("sessionID"), mock.Anything).
+			Return(&userEmail, nil)
+		es := &email.SenderMock{}
+		es.On("SendEmail", mock.Anything).Return(email.ErrFakeSenderFailure)
+		m := NewManager(db, es)
+
+		sessionID, err := m.RegisterSession(ctx, s, "http://baseurl.com")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("sessionID"), sessionID)
+		db.AssertExpectations(t)
+		es.AssertExpectations(t)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	ctx := context.Background()
+	code := []byte("code")
+	codeB64 := base64.URLEncoding.EncodeToString(code)
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+
+		err := m.RevokeSession(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("invalid code encoding", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+
+		err := m.RevokeSession(ctx, "!")
+		assert.Equal(t, ErrInvalidSessionRevocationCode, err)
+	})
+
+	t.Run("database error revoking session", func(t *testing.T) {
+		testCases := []struct {
+			dbErr       error
+			expectedErr error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				errInvalidSessionRevocationCodeDB,
+				ErrInvalidSessionRevocationCode,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, revokeSessionDBQ, code).Return(tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.RevokeSession(ctx, codeB64)
+				assert.Equal(t, tc.expectedErr, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("session revoked successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, revokeSessionDBQ, code).Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.RevokeSession(ctx, codeB64)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRegisterDeleteUserCode(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	profileJSON := []byte(`{"alias": "alias", "email": "email"}`)
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RegisterDeleteUserCode(context.Background(), "http://baseurl.com")
+		})
+	})
+
+	t.Run("database error getting user profile", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		err := m.RegisterDeleteUserCode(ctx, "http://baseurl.com")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("database error registering delete user code", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(profileJSON, nil)
+		db.On("QueryRow", ctx, registerDeleteUserCodeDBQ, "userID").Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil)
+
+		err := m.RegisterDeleteUserCode(ctx, "http://baseurl.com")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("successful delete user code registration in database", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"delete user code sent successfully",
+				nil,
+			},
+			{
+				"error sending delete user code",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getUserProfileDBQ, "userID").Return(profileJSON, nil)
+				db.On("QueryRow", ctx, registerDeleteUserCodeDBQ, "userID").Return([]byte("code"), nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+					return data.To == "email"
+				})).Return(tc.emailSenderResponse)
+				m := NewManager(db, es)
+
+				err := m.RegisterDeleteUserCode(ctx, "http://baseurl.com")
+				assert.Equal(t, tc.emailSenderResponse, err)
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
+}
+
+func TestRegisterEmailChangeCode(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+		assert.Panics(t, func() {
+			_ = m.RegisterEmailChangeCode(context.Background(), "new@email.com")
+		})
+	})
+
+	t.Run("new email not provided", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil)
+
+		err := m.RegisterEmailChangeCode(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "new email not provided")
+	})
+
+	t.Run("database error registering email change code", func(t *testing.T) {
+		testCases := []struct {
+			dbErr       error
+			expectedErr error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				errEmailNotAvailableDB,
+				ErrEmailNotAvailable,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, registerEmailChangeCodeDBQ, "userID", "new@email.com").Return(nil, tc.dbErr)
+				m := NewManager(db, nil)
+
+				err := m.RegisterEmailChangeCode(ctx, "new@email.com")
+				assert.Equal(t, tc.expectedErr, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("successful email change code registration in database", func(t *testing.T) {
+		testCases := []struct {
+			description         string
+			emailSenderResponse error
+		}{
+			{
+				"email change code sent successfully",
+				nil,
+			},
+			{
+				"error sending email change code",
+				email.ErrFakeSenderFailure,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, registerEmailChangeCodeDBQ, "userID", "new@email.com").Return([]byte("code"), nil)
+				es := &email.SenderMock{}
+				es.On("SendEmail", mock.MatchedBy(func(data *email.Data) bool {
+					return data.To == "new@email.com"
+				})).Return(tc.emailSenderResponse)
+				m := NewManager(db, es)
+
+				err := m.RegisterEmailChangeCode(ctx, "new@email.com")
+				assert.Equal(t, tc.emailSenderResponse, err)
+				db.AssertExpectations(t)
+				es.AssertExpectations(t)
+			})
+		}
+	})
 }
 
 func TestRegisterPasswordResetCode(t *testing.T) {
@@ -712,6 +1522,11 @@ func TestRegisterUser(t *testing.T) {
 				&hub.User{Alias: "user1", Email: "email", ProfileImageID: "invalid"},
 				"http://baseurl.com",
 			},
+			{
+				"invalid locale",
+				&hub.User{Alias: "user1", Email: "email", Locale: "invalid_locale"},
+				"http://baseurl.com",
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -769,6 +1584,19 @@ func TestRegisterUser(t *testing.T) {
 		}
 	})
 
+	t.Run("password does not comply with policy", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, WithPasswordPolicy(PasswordPolicy{MinLength: 10}))
+
+		u := &hub.User{
+			Alias:    "alias",
+			Email:    "email@email.com",
+			Password: "password",
+		}
+		err := m.RegisterUser(ctx, u, "http://baseurl.com")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
 	t.Run("database error registering user", func(t *testing.T) {
 		t.Parallel()
 		code := ""
@@ -830,6 +1658,18 @@ func TestResetPassword(t *testing.T) {
 		}
 	})
 
+	t.Run("new password used recently", func(t *testing.T) {
+		t.Parallel()
+		hash, _ := bcrypt.GenerateFromPassword([]byte("newPassword"), bcrypt.MinCost)
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getResetCodePwdHistoryDBQ, code, 3).Return([]string{string(hash)}, nil)
+		m := NewManager(db, nil, WithPasswordPolicy(PasswordPolicy{HistorySize: 3}))
+
+		err := m.ResetPassword(ctx, codeB64, "newPassword", "http://baseurl.com")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
 	t.Run("database error resetting password", func(t *testing.T) {
 		testCases := []struct {
 			dbErr       error
@@ -955,6 +1795,30 @@ func TestUpdatePassword(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("database error getting passwords history", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserPasswordDBQ, "userID").Return(string(oldHashed), nil)
+		db.On("QueryRow", ctx, getPasswordHistoryDBQ, "userID", 3).Return(nil, tests.ErrFakeDB)
+		m := NewManager(db, nil, WithPasswordPolicy(PasswordPolicy{HistorySize: 3}))
+
+		err := m.UpdatePassword(ctx, "old", "new")
+		assert.Equal(t, tests.ErrFakeDB, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("new password used recently", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getUserPasswordDBQ, "userID").Return(string(oldHashed), nil)
+		db.On("QueryRow", ctx, getPasswordHistoryDBQ, "userID", 3).Return([]string{string(oldHashed)}, nil)
+		m := NewManager(db, nil, WithPasswordPolicy(PasswordPolicy{HistorySize: 3}))
+
+		err := m.UpdatePassword(ctx, "old", "old")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		db.AssertExpectations(t)
+	})
+
 	t.Run("database error updating password", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
@@ -1005,6 +1869,50 @@ func TestUpdateProfile(t *testing.T) {
 				"invalid profile image id",
 				&hub.User{Alias: "user1", Email: "email", ProfileImageID: "invalid"},
 			},
+			{
+				"invalid locale",
+				&hub.User{Alias: "user1", Email: "email", Locale: "invalid_locale"},
+			},
+			{
+				"invalid event kind in notifications preferences",
+				&hub.User{
+					Alias: "user1",
+					Email: "email",
+					NotificationsPreferences: &hub.NotificationsPreferences{
+						EmailDisabledEventKinds: []hub.EventKind{hub.NewRelease, hub.EventKind(9)},
+					},
+				},
+			},
+			{
+				"invalid quiet hours start time",
+				&hub.User{
+					Alias: "user1",
+					Email: "email",
+					NotificationsPreferences: &hub.NotificationsPreferences{
+						QuietHours: &hub.QuietHours{Start: "25:00", End: "07:00", TimeZone: "UTC"},
+					},
+				},
+			},
+			{
+				"invalid quiet hours end time",
+				&hub.User{
+					Alias: "user1",
+					Email: "email",
+					NotificationsPreferences: &hub.NotificationsPreferences{
+						QuietHours: &hub.QuietHours{Start: "22:00", End: "7am", TimeZone: "UTC"},
+					},
+				},
+			},
+			{
+				"invalid quiet hours time zone",
+				&hub.User{
+					Alias: "user1",
+					Email: "email",
+					NotificationsPreferences: &hub.NotificationsPreferences{
+						QuietHours: &hub.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Invalid/Zone"},
+					},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -1029,6 +1937,17 @@ func TestUpdateProfile(t *testing.T) {
 		db.AssertExpectations(t)
 	})
 
+	t.Run("locale provided is normalized", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateUserProfileDBQ, "userID", []byte(`{"user_id":"","alias":"user1","first_name":"","last_name":"","email":"","email_verified":false,"password":"","profile_image_id":"","locale":"pt-BR"}`)).Return(nil)
+		m := NewManager(db, nil)
+
+		err := m.UpdateProfile(ctx, &hub.User{Alias: "user1", Locale: "pt_br"})
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
 	t.Run("database error", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
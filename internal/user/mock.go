@@ -13,6 +13,12 @@ type ManagerMock struct {
 	mock.Mock
 }
 
+// ChangeEmail implements the UserManager interface.
+func (m *ManagerMock) ChangeEmail(ctx context.Context, code string, invalidateSessions bool) error {
+	args := m.Called(ctx, code, invalidateSessions)
+	return args.Error(0)
+}
+
 // CheckAPIKey implements the UserManager interface.
 func (m *ManagerMock) CheckAPIKey(ctx context.Context, apiKeyID, apiKeySecret string) (*hub.CheckAPIKeyOutput, error) {
 	args := m.Called(ctx, apiKeyID, apiKeySecret)
@@ -30,13 +36,25 @@ func (m *ManagerMock) CheckAvailability(ctx context.Context, resourceKind, value
 func (m *ManagerMock) CheckCredentials(
 	ctx context.Context,
 	email,
-	password string,
+	password,
+	ip string,
 ) (*hub.CheckCredentialsOutput, error) {
-	args := m.Called(ctx, email, password)
+	args := m.Called(ctx, email, password, ip)
 	data, _ := args.Get(0).(*hub.CheckCredentialsOutput)
 	return data, args.Error(1)
 }
 
+// CheckTokenBinding implements the UserManager interface.
+func (m *ManagerMock) CheckTokenBinding(
+	ctx context.Context,
+	userID string,
+	b *hub.TokenBinding,
+	sessionDuration time.Duration,
+) (bool, error) {
+	args := m.Called(ctx, userID, b, sessionDuration)
+	return args.Bool(0), args.Error(1)
+}
+
 // CheckSession implements the UserManager interface.
 func (m *ManagerMock) CheckSession(
 	ctx context.Context,
@@ -54,6 +72,12 @@ func (m *ManagerMock) DeleteSession(ctx context.Context, sessionID []byte) error
 	return args.Error(0)
 }
 
+// DeleteUser implements the UserManager interface.
+func (m *ManagerMock) DeleteUser(ctx context.Context, code, baseURL string) error {
+	args := m.Called(ctx, code, baseURL)
+	return args.Error(0)
+}
+
 // GetProfile implements the UserManager interface.
 func (m *ManagerMock) GetProfile(ctx context.Context) (*hub.User, error) {
 	args := m.Called(ctx)
@@ -74,6 +98,18 @@ func (m *ManagerMock) GetUserID(ctx context.Context, email string) (string, erro
 	return args.String(0), args.Error(1)
 }
 
+// RegisterDeleteUserCode implements the UserManager interface.
+func (m *ManagerMock) RegisterDeleteUserCode(ctx context.Context, baseURL string) error {
+	args := m.Called(ctx, baseURL)
+	return args.Error(0)
+}
+
+// RegisterEmailChangeCode implements the UserManager interface.
+func (m *ManagerMock) RegisterEmailChangeCode(ctx context.Context, newEmail string) error {
+	args := m.Called(ctx, newEmail)
+	return args.Error(0)
+}
+
 // RegisterPasswordResetCode implements the UserManager interface.
 func (m *ManagerMock) RegisterPasswordResetCode(ctx context.Context, userEmail, baseURL string) error {
 	args := m.Called(ctx, userEmail, baseURL)
@@ -81,8 +117,8 @@ func (m *ManagerMock) RegisterPasswordResetCode(ctx context.Context, userEmail,
 }
 
 // RegisterSession implements the UserManager interface.
-func (m *ManagerMock) RegisterSession(ctx context.Context, session *hub.Session) ([]byte, error) {
-	args := m.Called(ctx, session)
+func (m *ManagerMock) RegisterSession(ctx context.Context, session *hub.Session, baseURL string) ([]byte, error) {
+	args := m.Called(ctx, session, baseURL)
 	data, _ := args.Get(0).([]byte)
 	return data, args.Error(1)
 }
@@ -99,6 +135,12 @@ func (m *ManagerMock) ResetPassword(ctx context.Context, code, newPassword, base
 	return args.Error(0)
 }
 
+// RevokeSession implements the UserManager interface.
+func (m *ManagerMock) RevokeSession(ctx context.Context, code string) error {
+	args := m.Called(ctx, code)
+	return args.Error(0)
+}
+
 // UpdatePassword implements the UserManager interface.
 func (m *ManagerMock) UpdatePassword(ctx context.Context, old, new string) error {
 	args := m.Called(ctx, old, new)
@@ -0,0 +1,136 @@
+package user
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" // #nosec
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// MaxPasswordHistorySize represents the maximum number of previous
+	// passwords that can be checked to prevent users from reusing them.
+	MaxPasswordHistorySize = 24
+
+	// pwnedPasswordsRangeURL represents the url of the Have I Been Pwned
+	// range API, used to check if a password has been exposed in a breach
+	// without sending it (only the first 5 chars of its SHA-1 are sent).
+	pwnedPasswordsRangeURL = "https://api.pwnedpasswords.com/range/"
+)
+
+// PasswordPolicy represents the policy enforced on the passwords set by the
+// users. The zero value does not enforce any restriction.
+type PasswordPolicy struct {
+	// MinLength represents the minimum number of characters passwords must
+	// have.
+	MinLength int
+
+	// CheckBreached indicates if passwords that have been exposed in data
+	// breaches must be rejected.
+	CheckBreached bool
+
+	// HistorySize represents the number of previous passwords that users
+	// are not allowed to reuse.
+	HistorySize int
+
+	// MaxAge represents the time after which passwords expire. Users whose
+	// password has expired will need to reset it to be able to log in again.
+	MaxAge time.Duration
+}
+
+// WithPasswordPolicy allows providing the policy enforced on the passwords
+// set by the users.
+func WithPasswordPolicy(p PasswordPolicy) func(m *Manager) {
+	return func(m *Manager) {
+		if p.HistorySize > MaxPasswordHistorySize {
+			p.HistorySize = MaxPasswordHistorySize
+		}
+		m.passwordPolicy = p
+	}
+}
+
+// WithHTTPClient allows providing the http client used to check if passwords
+// have been exposed in data breaches.
+func WithHTTPClient(hc hub.HTTPClient) func(m *Manager) {
+	return func(m *Manager) {
+		m.hc = hc
+	}
+}
+
+// validatePassword checks if the password provided complies with the
+// passwords policy. The hashes of the passwords previously used by the user
+// are used to check that the password is not being reused.
+func (m *Manager) validatePassword(ctx context.Context, password string, previousHashes []string) error {
+	p := m.passwordPolicy
+
+	// Check length
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf(
+			"%w: password must be at least %d characters long", hub.ErrInvalidInput, p.MinLength,
+		)
+	}
+
+	// Check password has not been used recently
+	for _, hash := range previousHashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return fmt.Errorf(
+				"%w: password has been used recently, please choose a different one", hub.ErrInvalidInput,
+			)
+		}
+	}
+
+	// Check password has not been exposed in a data breach
+	if p.CheckBreached && m.hc != nil {
+		breached, err := m.isPasswordBreached(ctx, password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			return fmt.Errorf(
+				"%w: password has been exposed in a data breach, please choose a different one",
+				hub.ErrInvalidInput,
+			)
+		}
+	}
+
+	return nil
+}
+
+// isPasswordBreached checks if the password provided has been exposed in a
+// data breach using the Have I Been Pwned range API.
+func (m *Manager) isPasswordBreached(ctx context.Context, password string) (bool, error) {
+	hash := fmt.Sprintf("%X", sha1.Sum([]byte(password))) // #nosec
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, "GET", pwnedPasswordsRangeURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Add-Padding", "true")
+	resp, err := m.hc.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code received: %d", resp.StatusCode)
+	}
+
+	// Each line contains the suffix of a breached password hash and the
+	// number of times it has been seen (padding entries have a count of 0)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		parts := strings.SplitN(strings.TrimSpace(scanner.Text()), ":", 2)
+		if len(parts) == 2 && parts[0] == suffix && parts[1] != "0" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,120 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/artifacthub/hub/internal/hub"
+	"github.com/artifacthub/hub/internal/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestWithPasswordPolicy(t *testing.T) {
+	t.Parallel()
+	p := PasswordPolicy{
+		MinLength:     8,
+		CheckBreached: true,
+		HistorySize:   100,
+		MaxAge:        24 * time.Hour,
+	}
+	m := NewManager(nil, nil, WithPasswordPolicy(p))
+
+	assert.Equal(t, 8, m.passwordPolicy.MinLength)
+	assert.True(t, m.passwordPolicy.CheckBreached)
+	assert.Equal(t, MaxPasswordHistorySize, m.passwordPolicy.HistorySize)
+	assert.Equal(t, 24*time.Hour, m.passwordPolicy.MaxAge)
+}
+
+func TestValidatePassword(t *testing.T) {
+	ctx := context.Background()
+
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	isRangeRequest := mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.String() == pwnedPasswordsRangeURL+"5BAA6"
+	})
+	newRangeResponse := func(body string) *http.Response {
+		return &http.Response{
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			StatusCode: http.StatusOK,
+		}
+	}
+
+	t.Run("password too short", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil, nil, WithPasswordPolicy(PasswordPolicy{MinLength: 10}))
+
+		err := m.validatePassword(ctx, "password", nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "at least 10 characters")
+	})
+
+	t.Run("password used recently", func(t *testing.T) {
+		t.Parallel()
+		hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+		m := NewManager(nil, nil, WithPasswordPolicy(PasswordPolicy{HistorySize: 1}))
+
+		err := m.validatePassword(ctx, "password", []string{string(hash)})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "used recently")
+	})
+
+	t.Run("error checking if password has been breached", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isRangeRequest).Return(nil, tests.ErrFake)
+		m := NewManager(nil, nil, WithPasswordPolicy(PasswordPolicy{CheckBreached: true}), WithHTTPClient(hc))
+
+		err := m.validatePassword(ctx, "password", nil)
+		assert.Equal(t, tests.ErrFake, err)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("password has been breached", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isRangeRequest).Return(newRangeResponse(
+			"003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n",
+		), nil)
+		m := NewManager(nil, nil, WithPasswordPolicy(PasswordPolicy{CheckBreached: true}), WithHTTPClient(hc))
+
+		err := m.validatePassword(ctx, "password", nil)
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "data breach")
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("password only found in padding entries", func(t *testing.T) {
+		t.Parallel()
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isRangeRequest).Return(newRangeResponse(
+			"1E4C9B93F3F0682250B6CF8331B7EE68FD8:0\r\n",
+		), nil)
+		m := NewManager(nil, nil, WithPasswordPolicy(PasswordPolicy{CheckBreached: true}), WithHTTPClient(hc))
+
+		err := m.validatePassword(ctx, "password", nil)
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+
+	t.Run("password complies with policy", func(t *testing.T) {
+		t.Parallel()
+		hash, _ := bcrypt.GenerateFromPassword([]byte("other"), bcrypt.MinCost)
+		hc := &tests.HTTPClientMock{}
+		hc.On("Do", isRangeRequest).Return(newRangeResponse(
+			"003D68EB55068C33ACE09247EE4C639306B:3\r\n",
+		), nil)
+		p := PasswordPolicy{MinLength: 8, CheckBreached: true, HistorySize: 1}
+		m := NewManager(nil, nil, WithPasswordPolicy(p), WithHTTPClient(hc))
+
+		err := m.validatePassword(ctx, "password", []string{string(hash)})
+		assert.NoError(t, err)
+		hc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"errors"
+
+	"github.com/artifacthub/hub/internal/img/objstore"
+	"github.com/spf13/viper"
+)
+
+// SetupChartMirrorBucket creates the bucket used by the chart mirror to store
+// the charts archives based on the configuration provided. Archives can be
+// stored in a local directory or in the object storage bucket configured for
+// the images.
+func SetupChartMirrorBucket(cfg *viper.Viper) (objstore.Bucket, error) {
+	cfg.SetDefault("chartMirror.store", "fs")
+	switch cfg.GetString("chartMirror.store") {
+	case "fs":
+		bucket, err := objstore.NewFSBucket(cfg.GetString("chartMirror.fs.path"))
+		if err != nil {
+			return nil, err
+		}
+		return bucket, nil
+	case "objstore":
+		return SetupImageStoreBucket(cfg)
+	default:
+		return nil, errors.New("invalid chart mirror store")
+	}
+}
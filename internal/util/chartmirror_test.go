@@ -0,0 +1,50 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupChartMirrorBucket(t *testing.T) {
+	t.Parallel()
+
+	// Check a valid store must be provided
+	cfg := viper.New()
+	cfg.Set("chartMirror.store", "invalid")
+	bucket, err := SetupChartMirrorBucket(cfg)
+	require.Error(t, err)
+	require.Nil(t, bucket)
+
+	// Check the directory is required when using the local filesystem
+	cfg = viper.New()
+	bucket, err = SetupChartMirrorBucket(cfg)
+	require.Error(t, err)
+	require.Nil(t, bucket)
+
+	// Check local filesystem bucket was setup successfully
+	cfg = viper.New()
+	cfg.Set("chartMirror.fs.path", t.TempDir())
+	bucket, err = SetupChartMirrorBucket(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, bucket)
+
+	// Check the images object storage bucket must be configured
+	cfg = viper.New()
+	cfg.Set("chartMirror.store", "objstore")
+	cfg.Set("images.store", "pg")
+	bucket, err = SetupChartMirrorBucket(cfg)
+	require.Error(t, err)
+	require.Nil(t, bucket)
+
+	// Check images object storage bucket was setup successfully
+	cfg = viper.New()
+	cfg.Set("chartMirror.store", "objstore")
+	cfg.Set("images.store", "s3")
+	cfg.Set("images.s3.bucket", "bucket")
+	cfg.Set("images.s3.region", "us-east-1")
+	bucket, err = SetupChartMirrorBucket(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, bucket)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/artifacthub/hub/internal/hub"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/log/zerologadapter"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -15,10 +16,17 @@ import (
 	"github.com/spf13/viper"
 )
 
+// invalidParameterValueCode represents the error code returned by the
+// database when some of the values provided to a function are not valid.
+const invalidParameterValueCode = "22023"
+
 var (
 	// ErrDBInsufficientPrivilege indicates that the user does not have the
 	// required privilege to perform the operation.
 	ErrDBInsufficientPrivilege = errors.New("ERROR: insufficient_privilege (SQLSTATE 42501)")
+
+	// ErrDBNotFound indicates that the requested item was not found.
+	ErrDBNotFound = errors.New("ERROR: no_data_found (SQLSTATE P0002)")
 )
 
 // SetupDB creates a database connection pool using the configuration provided.
@@ -84,6 +92,10 @@ func DBQueryJSON(ctx context.Context, db hub.DB, query string, args ...interface
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, hub.ErrNotFound
 		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == invalidParameterValueCode {
+			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, pgErr.Message)
+		}
 		return nil, err
 	}
 	return dataJSON, nil
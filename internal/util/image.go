@@ -1,9 +1,11 @@
 package util
 
 import (
+	"context"
 	"errors"
 
 	"github.com/artifacthub/hub/internal/img"
+	"github.com/artifacthub/hub/internal/img/objstore"
 	"github.com/artifacthub/hub/internal/img/pg"
 	"github.com/spf13/viper"
 	"golang.org/x/time/rate"
@@ -16,11 +18,33 @@ func SetupImageStore(
 	hc img.HTTPClient,
 	githubRL *rate.Limiter,
 ) (img.Store, error) {
+	cfg.SetDefault("images.store", "pg")
 	imageStore := cfg.GetString("images.store")
 	switch imageStore {
 	case "pg":
 		return pg.NewImageStore(cfg, db, hc, githubRL), nil
+	case "s3", "gcs", "azure":
+		bucket, err := SetupImageStoreBucket(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return objstore.NewImageStore(cfg, bucket, hc, githubRL), nil
 	default:
 		return nil, errors.New("invalid image store")
 	}
 }
+
+// SetupImageStoreBucket creates a new object storage bucket to be used by the
+// image store based on the configuration provided.
+func SetupImageStoreBucket(cfg *viper.Viper) (objstore.Bucket, error) {
+	switch cfg.GetString("images.store") {
+	case "s3":
+		return objstore.NewS3Bucket(cfg)
+	case "gcs":
+		return objstore.NewGCSBucket(context.Background(), cfg)
+	case "azure":
+		return objstore.NewAzureBucket(cfg)
+	default:
+		return nil, errors.New("invalid image store bucket")
+	}
+}
@@ -23,4 +23,41 @@ func TestSetupImageStore(t *testing.T) {
 	imageStore, err = SetupImageStore(cfg, nil, nil, nil)
 	require.NoError(t, err)
 	require.NotNil(t, imageStore)
+
+	// Check object storage bucket settings are required
+	cfg = viper.New()
+	cfg.Set("images.store", "s3")
+	imageStore, err = SetupImageStore(cfg, nil, nil, nil)
+	require.Error(t, err)
+	require.Nil(t, imageStore)
+
+	// Check object storage based image store was setup successfully
+	cfg = viper.New()
+	cfg.Set("images.store", "s3")
+	cfg.Set("images.s3.bucket", "bucket")
+	cfg.Set("images.s3.region", "us-east-1")
+	imageStore, err = SetupImageStore(cfg, nil, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, imageStore)
+}
+
+func TestSetupImageStoreBucket(t *testing.T) {
+	t.Parallel()
+
+	// Check a valid bucket provider must be provided
+	cfg := viper.New()
+	cfg.Set("images.store", "pg")
+	bucket, err := SetupImageStoreBucket(cfg)
+	require.Error(t, err)
+	require.Nil(t, bucket)
+
+	// Check azure bucket was setup successfully
+	cfg = viper.New()
+	cfg.Set("images.store", "azure")
+	cfg.Set("images.azure.accountName", "account")
+	cfg.Set("images.azure.accountKey", "a2V5")
+	cfg.Set("images.azure.container", "images")
+	bucket, err = SetupImageStoreBucket(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, bucket)
 }
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor indicates that the pagination cursor provided is not valid.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ValidateCursor checks if the pagination cursor provided is valid. Cursors
+// are opaque to clients, but they are built by the database by encoding a json
+// object using url safe base64 without padding.
+func ValidateCursor(cursor string) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(data, &v); err != nil || v == nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCursor(t *testing.T) {
+	testCases := []struct {
+		cursor        string
+		expectedError error
+	}{
+		{"eyJuYW1lIjogInJlcG8xIn0", nil},
+		{"eyJuYW1lIjogIn5-fiJ9", nil},
+		{"", ErrInvalidCursor},
+		{"eyJuYW1lIjogInJlcG8xIn0=", ErrInvalidCursor},
+		{"not a cursor", ErrInvalidCursor},
+		{"WzEsMl0", ErrInvalidCursor},
+		{"bnVsbA", ErrInvalidCursor},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.cursor, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expectedError, ValidateCursor(tc.cursor))
+		})
+	}
+}
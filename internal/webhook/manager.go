@@ -3,9 +3,12 @@ package webhook
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/artifacthub/hub/internal/hub"
 	"github.com/artifacthub/hub/internal/util"
@@ -15,14 +18,42 @@ import (
 const (
 	// Database queries
 	addWebhookDBQ                 = `select add_webhook($1::uuid, $2::text, $3::jsonb)`
+	addWebhookDeliveryDBQ         = `select add_webhook_delivery($1::uuid, $2::jsonb)`
 	deleteWebhookDBQ              = `select delete_webhook($1::uuid, $2::uuid)`
+	enableWebhookDBQ              = `select enable_webhook($1::uuid, $2::uuid)`
+	getDeliveriesDBQ              = `select get_webhook_deliveries($1::uuid, $2::uuid)`
+	getDeliveryDBQ                = `select get_webhook_delivery($1::uuid, $2::uuid, $3::uuid)`
+	getFailedDeliveriesDBQ        = `select get_webhook_failed_deliveries($1::uuid, $2::uuid)`
+	getFailedDeliveryDBQ          = `select get_webhook_failed_delivery($1::uuid, $2::uuid, $3::uuid)`
+	getHeadersDBQ                 = `select get_webhook_headers($1::uuid, $2::uuid)`
 	getWebhooksSubscribedToPkgDBQ = `select get_webhooks_subscribed_to_package($1::int, $2::uuid)`
 	getOrgWebhooksDBQ             = `select get_org_webhooks($1::uuid, $2::text)`
 	getUserWebhooksDBQ            = `select get_user_webhooks($1::uuid)`
 	getWebhookDBQ                 = `select get_webhook($1::uuid, $2::uuid)`
+	requeueFailedDeliveryDBQ      = `select requeue_webhook_failed_delivery($1::uuid, $2::uuid, $3::uuid)`
 	updateWebhookDBQ              = `select update_webhook($1::uuid, $2::jsonb)`
 )
 
+var (
+	// errAllPackagesDB represents the error returned from the database when a
+	// webhook not owned by an organization is set to cover all packages.
+	errAllPackagesDB = errors.New("ERROR: only organization webhooks can cover all packages (SQLSTATE P0001)")
+
+	// headerNameRE is a regexp used to validate the names of the custom
+	// headers of a webhook (token as defined in RFC 7230).
+	headerNameRE = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+	// reservedHeaders represents the headers that are set by Artifact Hub
+	// when delivering notifications and cannot be overridden.
+	reservedHeaders = []string{
+		"Content-Type",
+		"Content-Length",
+		"Host",
+		"X-ArtifactHub-Delivery",
+		"X-ArtifactHub-Secret",
+	}
+)
+
 // Manager provides an API to manage webhooks.
 type Manager struct {
 	db hub.DB
@@ -53,21 +84,60 @@ func (m *Manager) Add(ctx context.Context, orgName string, wh *hub.Webhook) erro
 	if _, err := template.New("").Parse(wh.Template); err != nil {
 		return fmt.Errorf("%w: %s %s", hub.ErrInvalidInput, "invalid template", err)
 	}
+	if err := validateFormat(wh); err != nil {
+		return err
+	}
+	if wh.Batch && (wh.Format != "" || wh.Template != "") {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "batch deliveries require the default payload")
+	}
+	if wh.RateLimit < 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid rate limit")
+	}
+	if err := validateHeaders(wh, false); err != nil {
+		return err
+	}
 	if len(wh.EventKinds) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
 	}
-	if len(wh.Packages) == 0 {
-		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no packages provided")
+	if wh.AllPackages && orgName == "" {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only organization webhooks can cover all packages")
 	}
-	for _, p := range wh.Packages {
-		if _, err := uuid.FromString(p.PackageID); err != nil {
-			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
-		}
+	if err := validatePackages(wh); err != nil {
+		return err
 	}
 
 	// Add webhook to the database
 	whJSON, _ := json.Marshal(wh)
 	_, err = m.db.Exec(ctx, addWebhookDBQ, userID, orgName, whJSON)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errAllPackagesDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only organization webhooks can cover all packages")
+		}
+	}
+	return err
+}
+
+// AddDelivery registers the provided webhook delivery attempt, performed on
+// behalf of the user making the request.
+func (m *Manager) AddDelivery(ctx context.Context, d *hub.WebhookDelivery) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(d.WebhookID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if d.NotificationID != "" {
+		if _, err := uuid.FromString(d.NotificationID); err != nil {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+		}
+	}
+
+	// Register delivery in database
+	dJSON, _ := json.Marshal(d)
+	_, err := m.db.Exec(ctx, addWebhookDeliveryDBQ, userID, dJSON)
 	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
 		return hub.ErrInsufficientPrivilege
 	}
@@ -91,6 +161,105 @@ func (m *Manager) Delete(ctx context.Context, webhookID string) error {
 	return err
 }
 
+// Enable enables the provided webhook. This allows resuming the deliveries to
+// webhooks that were suspended due to repeated failed deliveries.
+func (m *Manager) Enable(ctx context.Context, webhookID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+
+	// Enable webhook in database
+	_, err := m.db.Exec(ctx, enableWebhookDBQ, userID, webhookID)
+	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
+		return hub.ErrInsufficientPrivilege
+	}
+	return err
+}
+
+// GetDeliveriesJSON returns the latest delivery attempts of the provided
+// webhook as a json array.
+func (m *Manager) GetDeliveriesJSON(ctx context.Context, webhookID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+
+	// Get deliveries from database
+	return util.DBQueryJSON(ctx, m.db, getDeliveriesDBQ, userID, webhookID)
+}
+
+// GetDeliveryJSON returns the requested delivery attempt of the provided
+// webhook, including the payload delivered, as a json object.
+func (m *Manager) GetDeliveryJSON(ctx context.Context, webhookID, deliveryID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if _, err := uuid.FromString(deliveryID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid delivery id")
+	}
+
+	// Get delivery from database
+	return util.DBQueryJSON(ctx, m.db, getDeliveryDBQ, userID, webhookID, deliveryID)
+}
+
+// GetFailedDeliveriesJSON returns the notifications of the provided webhook
+// that could not be delivered after exhausting all attempts as a json array.
+func (m *Manager) GetFailedDeliveriesJSON(ctx context.Context, webhookID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+
+	// Get failed deliveries from database
+	return util.DBQueryJSON(ctx, m.db, getFailedDeliveriesDBQ, userID, webhookID)
+}
+
+// GetFailedDeliveryJSON returns the requested failed delivery of the provided
+// webhook, including the payload that could not be delivered, as a json
+// object.
+func (m *Manager) GetFailedDeliveryJSON(ctx context.Context, webhookID, notificationID string) ([]byte, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if _, err := uuid.FromString(notificationID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+	}
+
+	// Get failed delivery from database
+	return util.DBQueryJSON(ctx, m.db, getFailedDeliveryDBQ, userID, webhookID, notificationID)
+}
+
+// GetHeaders returns the custom headers of the provided webhook, including
+// the values of the secret ones.
+func (m *Manager) GetHeaders(ctx context.Context, webhookID string) ([]*hub.WebhookHeader, error) {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+
+	// Get headers from database
+	var headers []*hub.WebhookHeader
+	if err := util.DBQueryUnmarshal(ctx, m.db, &headers, getHeadersDBQ, userID, webhookID); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
 // GetJSON returns the requested webhook as a json object.
 func (m *Manager) GetJSON(ctx context.Context, webhookID string) ([]byte, error) {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -139,7 +308,7 @@ func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.Web
 	var dataJSON []byte
 	var err error
 	switch e.EventKind {
-	case hub.NewRelease:
+	case hub.NewRelease, hub.NewPackage, hub.PackageDeprecated, hub.SecurityAlert:
 		if _, err := uuid.FromString(e.PackageID); err != nil {
 			return nil, fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
 		}
@@ -157,6 +326,32 @@ func (m *Manager) GetSubscribedTo(ctx context.Context, e *hub.Event) ([]*hub.Web
 	return webhooks, err
 }
 
+// RequeueFailedDelivery removes the provided notification from the webhook's
+// dead-letter queue and enqueues it again so that its delivery is retried.
+func (m *Manager) RequeueFailedDelivery(ctx context.Context, webhookID, notificationID string) error {
+	userID := ctx.Value(hub.UserIDKey).(string)
+
+	// Validate input
+	if _, err := uuid.FromString(webhookID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid webhook id")
+	}
+	if _, err := uuid.FromString(notificationID); err != nil {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid notification id")
+	}
+
+	// Requeue failed delivery in database
+	_, err := m.db.Exec(ctx, requeueFailedDeliveryDBQ, userID, webhookID, notificationID)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case util.ErrDBNotFound.Error():
+			return hub.ErrNotFound
+		}
+	}
+	return err
+}
+
 // Update updates the provided webhook in the database.
 func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	userID := ctx.Value(hub.UserIDKey).(string)
@@ -178,9 +373,84 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 	if _, err := template.New("").Parse(wh.Template); err != nil {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid template")
 	}
+	if err := validateFormat(wh); err != nil {
+		return err
+	}
+	if wh.Batch && (wh.Format != "" || wh.Template != "") {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "batch deliveries require the default payload")
+	}
+	if wh.RateLimit < 0 {
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid rate limit")
+	}
+	if err := validateHeaders(wh, true); err != nil {
+		return err
+	}
 	if len(wh.EventKinds) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no event kinds provided")
 	}
+	if err := validatePackages(wh); err != nil {
+		return err
+	}
+
+	// Update webhook in database
+	whJSON, _ := json.Marshal(wh)
+	_, err = m.db.Exec(ctx, updateWebhookDBQ, userID, whJSON)
+	if err != nil {
+		switch err.Error() {
+		case util.ErrDBInsufficientPrivilege.Error():
+			return hub.ErrInsufficientPrivilege
+		case errAllPackagesDB.Error():
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "only organization webhooks can cover all packages")
+		}
+	}
+	return err
+}
+
+// validateFormat checks if the format of the webhook provided is valid.
+func validateFormat(wh *hub.Webhook) error {
+	switch wh.Format {
+	case "":
+		return nil
+	case hub.WebhookFormatSlack, hub.WebhookFormatTeams, hub.WebhookFormatDiscord:
+		if wh.Template != "" {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "custom templates cannot be used with the format selected")
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid format")
+	}
+}
+
+// validateHeaders checks if the custom headers of the webhook provided are
+// valid. Secret headers are allowed to be provided without a value on
+// updates, in which case the value currently stored will be kept.
+func validateHeaders(wh *hub.Webhook, update bool) error {
+	for _, h := range wh.Headers {
+		if h == nil || !headerNameRE.MatchString(h.Name) {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid header name")
+		}
+		for _, reserved := range reservedHeaders {
+			if strings.EqualFold(h.Name, reserved) {
+				return fmt.Errorf("%w: %s %s", hub.ErrInvalidInput, "reserved header", h.Name)
+			}
+		}
+		if h.Value == "" && !(update && h.Secret) {
+			return fmt.Errorf("%w: %s %s", hub.ErrInvalidInput, "value not provided for header", h.Name)
+		}
+	}
+	return nil
+}
+
+// validatePackages checks if the packages of the webhook provided are valid.
+// Webhooks covering all packages must not provide a list of packages, as they
+// are subscribed to all the packages of the organization's repositories.
+func validatePackages(wh *hub.Webhook) error {
+	if wh.AllPackages {
+		if len(wh.Packages) > 0 {
+			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "packages cannot be provided when covering all packages")
+		}
+		return nil
+	}
 	if len(wh.Packages) == 0 {
 		return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "no packages provided")
 	}
@@ -189,12 +459,5 @@ func (m *Manager) Update(ctx context.Context, wh *hub.Webhook) error {
 			return fmt.Errorf("%w: %s", hub.ErrInvalidInput, "invalid package id")
 		}
 	}
-
-	// Update webhook in database
-	whJSON, _ := json.Marshal(wh)
-	_, err = m.db.Exec(ctx, updateWebhookDBQ, userID, whJSON)
-	if err != nil && err.Error() == util.ErrDBInsufficientPrivilege.Error() {
-		return hub.ErrInsufficientPrivilege
-	}
-	return err
+	return nil
 }
@@ -73,6 +73,80 @@ func TestAdd(t *testing.T) {
 					Template: "{{ .",
 				},
 			},
+			{
+				"invalid format",
+				"org1",
+				&hub.Webhook{
+					Name:   "webhook",
+					URL:    "http://webhook1.url",
+					Format: "invalid",
+				},
+			},
+			{
+				"invalid header name",
+				"org1",
+				&hub.Webhook{
+					Name:    "webhook",
+					URL:     "http://webhook1.url",
+					Headers: []*hub.WebhookHeader{{Name: "Invalid Name", Value: "value"}},
+				},
+			},
+			{
+				"reserved header",
+				"org1",
+				&hub.Webhook{
+					Name:    "webhook",
+					URL:     "http://webhook1.url",
+					Headers: []*hub.WebhookHeader{{Name: "x-artifacthub-secret", Value: "value"}},
+				},
+			},
+			{
+				"reserved header",
+				"org1",
+				&hub.Webhook{
+					Name:    "webhook",
+					URL:     "http://webhook1.url",
+					Headers: []*hub.WebhookHeader{{Name: "X-ArtifactHub-Delivery", Value: "value"}},
+				},
+			},
+			{
+				"value not provided for header",
+				"org1",
+				&hub.Webhook{
+					Name:    "webhook",
+					URL:     "http://webhook1.url",
+					Headers: []*hub.WebhookHeader{{Name: "Authorization", Secret: true}},
+				},
+			},
+			{
+				"invalid rate limit",
+				"org1",
+				&hub.Webhook{
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					RateLimit: -1,
+				},
+			},
+			{
+				"custom templates cannot be used with the format selected",
+				"org1",
+				&hub.Webhook{
+					Name:     "webhook",
+					URL:      "http://webhook1.url",
+					Template: "custom",
+					Format:   hub.WebhookFormatTeams,
+				},
+			},
+			{
+				"batch deliveries require the default payload",
+				"org1",
+				&hub.Webhook{
+					Name:     "webhook",
+					URL:      "http://webhook1.url",
+					Template: "custom",
+					Batch:    true,
+				},
+			},
 			{
 				"no event kinds provided",
 				"org1",
@@ -82,25 +156,559 @@ func TestAdd(t *testing.T) {
 				},
 			},
 			{
-				"no packages provided",
-				"org1",
-				&hub.Webhook{
-					Name:       "webhook",
-					URL:        "http://webhook1.url",
-					EventKinds: []hub.EventKind{hub.NewRelease},
-				},
+				"no packages provided",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.NewRelease},
+				},
+			},
+			{
+				"invalid package id",
+				"org1",
+				&hub.Webhook{
+					Name:       "webhook",
+					URL:        "http://webhook1.url",
+					EventKinds: []hub.EventKind{hub.NewRelease},
+					Packages: []*hub.Package{
+						{PackageID: ""},
+					},
+				},
+			},
+			{
+				"only organization webhooks can cover all packages",
+				"",
+				&hub.Webhook{
+					Name:        "webhook",
+					URL:         "http://webhook1.url",
+					EventKinds:  []hub.EventKind{hub.NewRelease},
+					AllPackages: true,
+				},
+			},
+			{
+				"packages cannot be provided when covering all packages",
+				"org1",
+				&hub.Webhook{
+					Name:        "webhook",
+					URL:         "http://webhook1.url",
+					EventKinds:  []hub.EventKind{hub.NewRelease},
+					AllPackages: true,
+					Packages: []*hub.Package{
+						{PackageID: validUUID},
+					},
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+
+				err := m.Add(ctx, tc.orgName, tc.wh)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Add(ctx, "orgName", wh)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("add webhook succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.Add(ctx, "orgName", wh)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("add organization webhook covering all packages succeeded", func(t *testing.T) {
+		t.Parallel()
+		orgWh := &hub.Webhook{
+			Name:        "webhook1",
+			URL:         "http://webhook1.url",
+			EventKinds:  []hub.EventKind{hub.NewRelease},
+			AllPackages: true,
+		}
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.Add(ctx, "orgName", orgWh)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestAddDelivery(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+	d := &hub.WebhookDelivery{
+		WebhookID:  validUUID,
+		Payload:    "payload",
+		Latency:    100,
+		StatusCode: 200,
+	}
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.AddDelivery(context.Background(), d)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg string
+			d      *hub.WebhookDelivery
+		}{
+			{
+				"invalid webhook id",
+				&hub.WebhookDelivery{
+					WebhookID: "",
+				},
+			},
+			{
+				"invalid notification id",
+				&hub.WebhookDelivery{
+					WebhookID:      validUUID,
+					NotificationID: "invalid",
+				},
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.AddDelivery(ctx, tc.d)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, addWebhookDeliveryDBQ, "userID", mock.Anything).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.AddDelivery(ctx, d)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("add delivery succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, addWebhookDeliveryDBQ, "userID", mock.Anything).Return(nil)
+		m := NewManager(db)
+
+		err := m.AddDelivery(ctx, d)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Delete(context.Background(), validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Delete(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, deleteWebhookDBQ, "userID", validUUID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Delete(ctx, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("delete webhook succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, deleteWebhookDBQ, "userID", validUUID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Delete(ctx, validUUID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestEnable(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.Enable(context.Background(), validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		err := m.Enable(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, enableWebhookDBQ, "userID", validUUID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.Enable(ctx, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("enable webhook succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, enableWebhookDBQ, "userID", validUUID).Return(nil)
+		m := NewManager(db)
+
+		err := m.Enable(ctx, validUUID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetDeliveriesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetDeliveriesJSON(context.Background(), validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetDeliveriesJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getDeliveriesDBQ, "userID", validUUID).Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.GetDeliveriesJSON(ctx, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("deliveries data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDeliveriesDBQ, "userID", validUUID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetDeliveriesJSON(ctx, validUUID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetDeliveryJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetDeliveryJSON(context.Background(), validUUID, validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg     string
+			webhookID  string
+			deliveryID string
+		}{
+			{
+				"invalid webhook id",
+				"",
+				validUUID,
+			},
+			{
+				"invalid delivery id",
+				validUUID,
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				_, err := m.GetDeliveryJSON(ctx, tc.webhookID, tc.deliveryID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getDeliveryDBQ, "userID", validUUID, validUUID).Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.GetDeliveryJSON(ctx, validUUID, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("delivery data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getDeliveryDBQ, "userID", validUUID, validUUID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetDeliveryJSON(ctx, validUUID, validUUID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetFailedDeliveriesJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetFailedDeliveriesJSON(context.Background(), validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		_, err := m.GetFailedDeliveriesJSON(ctx, "")
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("QueryRow", ctx, getFailedDeliveriesDBQ, "userID", validUUID).Return(nil, tc.dbErr)
+				m := NewManager(db)
+
+				dataJSON, err := m.GetFailedDeliveriesJSON(ctx, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("failed deliveries data returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getFailedDeliveriesDBQ, "userID", validUUID).Return([]byte("dataJSON"), nil)
+		m := NewManager(db)
+
+		dataJSON, err := m.GetFailedDeliveriesJSON(ctx, validUUID)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestGetFailedDeliveryJSON(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_, _ = m.GetFailedDeliveryJSON(context.Background(), validUUID, validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg         string
+			webhookID      string
+			notificationID string
+		}{
+			{
+				"invalid webhook id",
+				"",
+				validUUID,
 			},
 			{
-				"invalid package id",
-				"org1",
-				&hub.Webhook{
-					Name:       "webhook",
-					URL:        "http://webhook1.url",
-					EventKinds: []hub.EventKind{hub.NewRelease},
-					Packages: []*hub.Package{
-						{PackageID: ""},
-					},
-				},
+				"invalid notification id",
+				validUUID,
+				"",
 			},
 		}
 		for _, tc := range testCases {
@@ -108,8 +716,7 @@ func TestAdd(t *testing.T) {
 			t.Run(tc.errMsg, func(t *testing.T) {
 				t.Parallel()
 				m := NewManager(nil)
-
-				err := m.Add(ctx, tc.orgName, tc.wh)
+				_, err := m.GetFailedDeliveryJSON(ctx, tc.webhookID, tc.notificationID)
 				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
 				assert.Contains(t, err.Error(), tc.errMsg)
 			})
@@ -135,44 +742,47 @@ func TestAdd(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(tc.dbErr)
+				db.On("QueryRow", ctx, getFailedDeliveryDBQ, "userID", validUUID, validUUID).Return(nil, tc.dbErr)
 				m := NewManager(db)
 
-				err := m.Add(ctx, "orgName", wh)
+				dataJSON, err := m.GetFailedDeliveryJSON(ctx, validUUID, validUUID)
 				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, dataJSON)
 				db.AssertExpectations(t)
 			})
 		}
 	})
 
-	t.Run("add webhook succeeded", func(t *testing.T) {
+	t.Run("failed delivery data returned successfully", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, addWebhookDBQ, "userID", "orgName", mock.Anything).Return(nil)
+		db.On("QueryRow", ctx, getFailedDeliveryDBQ, "userID", validUUID, validUUID).Return([]byte("dataJSON"), nil)
 		m := NewManager(db)
 
-		err := m.Add(ctx, "orgName", wh)
+		dataJSON, err := m.GetFailedDeliveryJSON(ctx, validUUID, validUUID)
 		assert.NoError(t, err)
+		assert.Equal(t, []byte("dataJSON"), dataJSON)
 		db.AssertExpectations(t)
 	})
 }
 
-func TestDelete(t *testing.T) {
+func TestGetHeaders(t *testing.T) {
 	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
 
 	t.Run("user id not found in ctx", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(nil)
 		assert.Panics(t, func() {
-			_ = m.Delete(context.Background(), validUUID)
+			_, _ = m.GetHeaders(context.Background(), validUUID)
 		})
 	})
 
 	t.Run("invalid input", func(t *testing.T) {
 		t.Parallel()
 		m := NewManager(nil)
-		err := m.Delete(ctx, "")
+		_, err := m.GetHeaders(ctx, "invalid")
 		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "invalid webhook id")
 	})
 
 	t.Run("database error", func(t *testing.T) {
@@ -194,24 +804,34 @@ func TestDelete(t *testing.T) {
 			t.Run(tc.dbErr.Error(), func(t *testing.T) {
 				t.Parallel()
 				db := &tests.DBMock{}
-				db.On("Exec", ctx, deleteWebhookDBQ, "userID", validUUID).Return(tc.dbErr)
+				db.On("QueryRow", ctx, getHeadersDBQ, "userID", validUUID).Return(nil, tc.dbErr)
 				m := NewManager(db)
 
-				err := m.Delete(ctx, validUUID)
+				headers, err := m.GetHeaders(ctx, validUUID)
 				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, headers)
 				db.AssertExpectations(t)
 			})
 		}
 	})
 
-	t.Run("delete webhook succeeded", func(t *testing.T) {
+	t.Run("headers returned successfully", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
-		db.On("Exec", ctx, deleteWebhookDBQ, "userID", validUUID).Return(nil)
+		db.On("QueryRow", ctx, getHeadersDBQ, "userID", validUUID).Return([]byte(`
+		[{
+			"name": "Authorization",
+			"value": "Bearer token",
+			"secret": true
+		}]
+		`), nil)
 		m := NewManager(db)
 
-		err := m.Delete(ctx, validUUID)
-		assert.NoError(t, err)
+		headers, err := m.GetHeaders(ctx, validUUID)
+		require.NoError(t, err)
+		assert.Equal(t, []*hub.WebhookHeader{
+			{Name: "Authorization", Value: "Bearer token", Secret: true},
+		}, headers)
 		db.AssertExpectations(t)
 	})
 }
@@ -429,6 +1049,157 @@ func TestGetSubscribedTo(t *testing.T) {
 		assert.Equal(t, "http://webhook2.url", w[1].URL)
 		db.AssertExpectations(t)
 	})
+
+	t.Run("webhooks subscribed to package deprecated event returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhooksSubscribedToPkgDBQ, hub.PackageDeprecated, validUUID).Return([]byte(`
+		[{
+			"webhook_id": "00000000-0000-0000-0000-000000000001",
+			"name": "webhook1",
+			"url": "http://webhook1.url"
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		w, err := m.GetSubscribedTo(ctx, &hub.Event{
+			EventKind: hub.PackageDeprecated,
+			PackageID: validUUID,
+		})
+		require.NoError(t, err)
+		require.Len(t, w, 1)
+		assert.Equal(t, "webhook1", w[0].Name)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("webhooks subscribed to new package event returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhooksSubscribedToPkgDBQ, hub.NewPackage, validUUID).Return([]byte(`
+		[{
+			"webhook_id": "00000000-0000-0000-0000-000000000001",
+			"name": "webhook1",
+			"url": "http://webhook1.url"
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		w, err := m.GetSubscribedTo(ctx, &hub.Event{
+			EventKind: hub.NewPackage,
+			PackageID: validUUID,
+		})
+		require.NoError(t, err)
+		require.Len(t, w, 1)
+		assert.Equal(t, "webhook1", w[0].Name)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("webhooks subscribed to security alert event returned successfully", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("QueryRow", ctx, getWebhooksSubscribedToPkgDBQ, hub.SecurityAlert, validUUID).Return([]byte(`
+		[{
+			"webhook_id": "00000000-0000-0000-0000-000000000001",
+			"name": "webhook1",
+			"url": "http://webhook1.url"
+		}]
+		`), nil)
+		m := NewManager(db)
+
+		w, err := m.GetSubscribedTo(ctx, &hub.Event{
+			EventKind: hub.SecurityAlert,
+			PackageID: validUUID,
+		})
+		require.NoError(t, err)
+		require.Len(t, w, 1)
+		assert.Equal(t, "webhook1", w[0].Name)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestRequeueFailedDelivery(t *testing.T) {
+	ctx := context.WithValue(context.Background(), hub.UserIDKey, "userID")
+
+	t.Run("user id not found in ctx", func(t *testing.T) {
+		t.Parallel()
+		m := NewManager(nil)
+		assert.Panics(t, func() {
+			_ = m.RequeueFailedDelivery(context.Background(), validUUID, validUUID)
+		})
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		testCases := []struct {
+			errMsg         string
+			webhookID      string
+			notificationID string
+		}{
+			{
+				"invalid webhook id",
+				"",
+				validUUID,
+			},
+			{
+				"invalid notification id",
+				validUUID,
+				"",
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.errMsg, func(t *testing.T) {
+				t.Parallel()
+				m := NewManager(nil)
+				err := m.RequeueFailedDelivery(ctx, tc.webhookID, tc.notificationID)
+				assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+				assert.Contains(t, err.Error(), tc.errMsg)
+			})
+		}
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		testCases := []struct {
+			dbErr         error
+			expectedError error
+		}{
+			{
+				tests.ErrFakeDB,
+				tests.ErrFakeDB,
+			},
+			{
+				util.ErrDBInsufficientPrivilege,
+				hub.ErrInsufficientPrivilege,
+			},
+			{
+				util.ErrDBNotFound,
+				hub.ErrNotFound,
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.dbErr.Error(), func(t *testing.T) {
+				t.Parallel()
+				db := &tests.DBMock{}
+				db.On("Exec", ctx, requeueFailedDeliveryDBQ, "userID", validUUID, validUUID).Return(tc.dbErr)
+				m := NewManager(db)
+
+				err := m.RequeueFailedDelivery(ctx, validUUID, validUUID)
+				assert.Equal(t, tc.expectedError, err)
+				db.AssertExpectations(t)
+			})
+		}
+	})
+
+	t.Run("requeue failed delivery succeeded", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, requeueFailedDeliveryDBQ, "userID", validUUID, validUUID).Return(nil)
+		m := NewManager(db)
+
+		err := m.RequeueFailedDelivery(ctx, validUUID, validUUID)
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -495,6 +1266,80 @@ func TestUpdate(t *testing.T) {
 					Template:  "{{ .",
 				},
 			},
+			{
+				"invalid format",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Format:    "invalid",
+				},
+			},
+			{
+				"invalid header name",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Headers:   []*hub.WebhookHeader{{Name: "Invalid Name", Value: "value"}},
+				},
+			},
+			{
+				"reserved header",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Headers:   []*hub.WebhookHeader{{Name: "x-artifacthub-secret", Value: "value"}},
+				},
+			},
+			{
+				"reserved header",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Headers:   []*hub.WebhookHeader{{Name: "X-ArtifactHub-Delivery", Value: "value"}},
+				},
+			},
+			{
+				"value not provided for header",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Headers:   []*hub.WebhookHeader{{Name: "Authorization"}},
+				},
+			},
+			{
+				"invalid rate limit",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					RateLimit: -1,
+				},
+			},
+			{
+				"custom templates cannot be used with the format selected",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Template:  "custom",
+					Format:    hub.WebhookFormatDiscord,
+				},
+			},
+			{
+				"batch deliveries require the default payload",
+				&hub.Webhook{
+					WebhookID: validUUID,
+					Name:      "webhook",
+					URL:       "http://webhook1.url",
+					Format:    hub.WebhookFormatSlack,
+					Batch:     true,
+				},
+			},
 			{
 				"no event kinds provided",
 				&hub.Webhook{
@@ -524,6 +1369,19 @@ func TestUpdate(t *testing.T) {
 					},
 				},
 			},
+			{
+				"packages cannot be provided when covering all packages",
+				&hub.Webhook{
+					WebhookID:   validUUID,
+					Name:        "webhook",
+					URL:         "http://webhook1.url",
+					EventKinds:  []hub.EventKind{hub.NewRelease},
+					AllPackages: true,
+					Packages: []*hub.Package{
+						{PackageID: validUUID},
+					},
+				},
+			},
 		}
 		for _, tc := range testCases {
 			tc := tc
@@ -567,6 +1425,24 @@ func TestUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("user webhook cannot cover all packages", func(t *testing.T) {
+		t.Parallel()
+		db := &tests.DBMock{}
+		db.On("Exec", ctx, updateWebhookDBQ, "userID", mock.Anything).Return(errAllPackagesDB)
+		m := NewManager(db)
+
+		err := m.Update(ctx, &hub.Webhook{
+			WebhookID:   validUUID,
+			Name:        "webhook1",
+			URL:         "http://webhook1.url",
+			EventKinds:  []hub.EventKind{hub.NewRelease},
+			AllPackages: true,
+		})
+		assert.True(t, errors.Is(err, hub.ErrInvalidInput))
+		assert.Contains(t, err.Error(), "only organization webhooks can cover all packages")
+		db.AssertExpectations(t)
+	})
+
 	t.Run("update webhook succeeded", func(t *testing.T) {
 		t.Parallel()
 		db := &tests.DBMock{}
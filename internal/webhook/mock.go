@@ -18,12 +18,59 @@ func (m *ManagerMock) Add(ctx context.Context, orgName string, wh *hub.Webhook)
 	return args.Error(0)
 }
 
+// AddDelivery implements the WebhookManager interface.
+func (m *ManagerMock) AddDelivery(ctx context.Context, d *hub.WebhookDelivery) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
 // Delete implements the WebhookManager interface.
 func (m *ManagerMock) Delete(ctx context.Context, webhookID string) error {
 	args := m.Called(ctx, webhookID)
 	return args.Error(0)
 }
 
+// Enable implements the WebhookManager interface.
+func (m *ManagerMock) Enable(ctx context.Context, webhookID string) error {
+	args := m.Called(ctx, webhookID)
+	return args.Error(0)
+}
+
+// GetDeliveriesJSON implements the WebhookManager interface.
+func (m *ManagerMock) GetDeliveriesJSON(ctx context.Context, webhookID string) ([]byte, error) {
+	args := m.Called(ctx, webhookID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetDeliveryJSON implements the WebhookManager interface.
+func (m *ManagerMock) GetDeliveryJSON(ctx context.Context, webhookID, deliveryID string) ([]byte, error) {
+	args := m.Called(ctx, webhookID, deliveryID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetFailedDeliveriesJSON implements the WebhookManager interface.
+func (m *ManagerMock) GetFailedDeliveriesJSON(ctx context.Context, webhookID string) ([]byte, error) {
+	args := m.Called(ctx, webhookID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetFailedDeliveryJSON implements the WebhookManager interface.
+func (m *ManagerMock) GetFailedDeliveryJSON(ctx context.Context, webhookID, notificationID string) ([]byte, error) {
+	args := m.Called(ctx, webhookID, notificationID)
+	data, _ := args.Get(0).([]byte)
+	return data, args.Error(1)
+}
+
+// GetHeaders implements the WebhookManager interface.
+func (m *ManagerMock) GetHeaders(ctx context.Context, webhookID string) ([]*hub.WebhookHeader, error) {
+	args := m.Called(ctx, webhookID)
+	data, _ := args.Get(0).([]*hub.WebhookHeader)
+	return data, args.Error(1)
+}
+
 // GetOwnedByOrgJSON implements the WebhookManager interface.
 func (m *ManagerMock) GetOwnedByOrgJSON(ctx context.Context, orgName string) ([]byte, error) {
 	args := m.Called(ctx, orgName)
@@ -52,6 +99,12 @@ func (m *ManagerMock) GetJSON(ctx context.Context, webhookID string) ([]byte, er
 	return data, args.Error(1)
 }
 
+// RequeueFailedDelivery implements the WebhookManager interface.
+func (m *ManagerMock) RequeueFailedDelivery(ctx context.Context, webhookID, notificationID string) error {
+	args := m.Called(ctx, webhookID, notificationID)
+	return args.Error(0)
+}
+
 // Update implements the WebhookManager interface.
 func (m *ManagerMock) Update(ctx context.Context, wh *hub.Webhook) error {
 	args := m.Called(ctx, wh)
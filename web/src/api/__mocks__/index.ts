@@ -8,6 +8,7 @@ export const API = {
   getCSRFToken: jest.fn(),
   register: jest.fn(),
   verifyEmail: jest.fn(),
+  revokeSession: jest.fn(),
   login: jest.fn(),
   logout: jest.fn(),
   getUserProfile: jest.fn(),
@@ -27,10 +28,15 @@ export const API = {
   getOrganizationMembers: jest.fn(),
   addOrganizationMember: jest.fn(),
   deleteOrganizationMember: jest.fn(),
+  updateOrganizationMemberRole: jest.fn(),
   confirmOrganizationMembership: jest.fn(),
   getStarredByUser: jest.fn(),
   updateUserProfile: jest.fn(),
   updatePassword: jest.fn(),
+  registerDeleteUserCode: jest.fn(),
+  deleteUser: jest.fn(),
+  registerEmailChangeCode: jest.fn(),
+  changeEmail: jest.fn(),
   saveImage: jest.fn(),
   getPackageSubscriptions: jest.fn(),
   addSubscription: jest.fn(),
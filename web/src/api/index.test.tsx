@@ -881,11 +881,35 @@ describe('index API', () => {
           status: 204,
         });
 
-        const response = await methods.API.deleteOrganization('org1');
+        const response = await methods.API.deleteOrganization('org1', {
+          confirmation: 'org1',
+          transferRepositoriesToUser: true,
+        });
 
         expect(fetchMock).toHaveBeenCalledTimes(1);
         expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/orgs/org1');
         expect(fetchMock.mock.calls[0][1]!.method).toBe('DELETE');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(
+          JSON.stringify({ confirmation: 'org1', transfer_repositories_to_user: true })
+        );
+        expect(response).toBe('');
+      });
+    });
+
+    describe('cancelOrganizationDeletion', () => {
+      it('success', async () => {
+        fetchMock.mockResponse('', {
+          headers: {
+            'content-type': 'text/plain; charset=utf-8',
+          },
+          status: 204,
+        });
+
+        const response = await methods.API.cancelOrganizationDeletion('org1');
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/orgs/org1/cancel-deletion');
+        expect(fetchMock.mock.calls[0][1]!.method).toBe('PUT');
         expect(response).toBe('');
       });
     });
@@ -1031,6 +1055,47 @@ describe('index API', () => {
       });
     });
 
+    describe('registerDeleteUserCode', () => {
+      it('success', async () => {
+        fetchMock.mockResponse('', {
+          headers: {
+            'content-type': 'text/plain; charset=utf-8',
+          },
+          status: 201,
+        });
+
+        const response = await methods.API.registerDeleteUserCode();
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/users/delete-user-code');
+        expect(fetchMock.mock.calls[0][1]!.method).toBe('POST');
+        expect(response).toBe('');
+      });
+    });
+
+    describe('deleteUser', () => {
+      it('success', async () => {
+        fetchMock.mockResponse('', {
+          headers: {
+            'content-type': 'text/plain; charset=utf-8',
+          },
+          status: 204,
+        });
+
+        const response = await methods.API.deleteUser('code');
+
+        expect(fetchMock).toHaveBeenCalledTimes(1);
+        expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/users');
+        expect(fetchMock.mock.calls[0][1]!.method).toBe('DELETE');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(
+          JSON.stringify({
+            code: 'code',
+          })
+        );
+        expect(response).toBe('');
+      });
+    });
+
     describe('saveImage', () => {
       it('success', async () => {
         const img =
@@ -1358,11 +1423,11 @@ describe('index API', () => {
     describe('triggerWebhookTest', () => {
       it('success', async () => {
         const webhook: TestWebhook = getData('28') as TestWebhook;
-        fetchMock.mockResponse('', {
+        fetchMock.mockResponse(JSON.stringify({ status_code: 200, latency: 120, response_body: 'ok' }), {
           headers: {
-            'content-type': 'text/plain; charset=utf-8',
+            'content-type': 'application/json',
           },
-          status: 204,
+          status: 200,
         });
 
         const response = await methods.API.triggerWebhookTest(webhook);
@@ -1374,7 +1439,7 @@ describe('index API', () => {
         expect(fetchMock.mock.calls[0][1]!.body).toBe(
           JSON.stringify(renameKeysInObject(webhook, { contentType: 'content_type', eventKinds: 'event_kinds' }))
         );
-        expect(response).toBe('');
+        expect(response).toEqual({ statusCode: 200, latency: 120, responseBody: 'ok' });
       });
     });
 
@@ -1423,11 +1488,12 @@ describe('index API', () => {
           status: 204,
         });
 
-        const response = await methods.API.addAPIKey('test');
+        const response = await methods.API.addAPIKey('test', ['read-only']);
 
         expect(fetchMock).toHaveBeenCalledTimes(1);
         expect(fetchMock.mock.calls[0][0]).toEqual('/api/v1/api-keys');
         expect(fetchMock.mock.calls[0][1]!.method).toBe('POST');
+        expect(fetchMock.mock.calls[0][1]!.body).toBe(JSON.stringify({ name: 'test', scopes: ['read-only'] }));
         expect(response.key).toEqual('123abc');
       });
     });
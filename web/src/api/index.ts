@@ -13,6 +13,7 @@ import {
   ChangeLog,
   ChartTemplatesData,
   CheckAvailabilityProps,
+  DeleteOrganizationInput,
   Error,
   ErrorKind,
   EventKind,
@@ -20,6 +21,7 @@ import {
   OptOutItem,
   Organization,
   OrganizationPolicy,
+  OrganizationRole,
   Package,
   PackageStars,
   Profile,
@@ -37,6 +39,7 @@ import {
   UserFullName,
   UserLogin,
   Webhook,
+  WebhookTestResult,
 } from '../types';
 import { TS_QUERY } from '../utils/data';
 import getHubBaseURL from '../utils/getHubBaseURL';
@@ -69,6 +72,7 @@ interface FetchOptions {
 
 const EXCEPTIONS = ['policies', 'rules', 'policyData', 'roles', 'crds', 'crdsExamples'];
 const CSRF_HEADER = 'X-Csrf-Token';
+const CAPTCHA_HEADER = 'X-Captcha-Response';
 let csrfToken: string | null = null;
 
 export const toCamelCase = (r: any): Result => {
@@ -306,12 +310,13 @@ export const API = {
     });
   },
 
-  register: (user: User): Promise<null | string> => {
+  register: (user: User, captchaResponse?: string | null): Promise<null | string> => {
     const newUser = renameKeysInObject(user, { firstName: 'first_name', lastName: 'last_name' });
     return apiFetch(`${API_BASE_URL}/users`, {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
+        ...(captchaResponse ? { [CAPTCHA_HEADER]: captchaResponse } : {}),
       },
       body: JSON.stringify(newUser),
     });
@@ -329,6 +334,18 @@ export const API = {
     });
   },
 
+  revokeSession: (code: string): Promise<null> => {
+    return apiFetch(`${API_BASE_URL}/users/revoke-session`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({
+        code: code,
+      }),
+    });
+  },
+
   login: (user: UserLogin): Promise<null | string> => {
     return apiFetch(`${API_BASE_URL}/users/login`, {
       method: 'POST',
@@ -473,9 +490,23 @@ export const API = {
     });
   },
 
-  deleteOrganization: (orgName: string): Promise<null | string> => {
+  deleteOrganization: (orgName: string, input: DeleteOrganizationInput): Promise<null | string> => {
+    const data = renameKeysInObject(input, {
+      transferRepositoriesToOrg: 'transfer_repositories_to_org',
+      transferRepositoriesToUser: 'transfer_repositories_to_user',
+    });
     return apiFetch(`${API_BASE_URL}/orgs/${orgName}`, {
       method: 'DELETE',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify(data),
+    });
+  },
+
+  cancelOrganizationDeletion: (orgName: string): Promise<null | string> => {
+    return apiFetch(`${API_BASE_URL}/orgs/${orgName}/cancel-deletion`, {
+      method: 'PUT',
     });
   },
 
@@ -495,6 +526,20 @@ export const API = {
     });
   },
 
+  updateOrganizationMemberRole: (
+    organizationName: string,
+    alias: string,
+    role: OrganizationRole
+  ): Promise<null | string> => {
+    return apiFetch(`${API_BASE_URL}/orgs/${organizationName}/member/${encodeURI(alias)}/role`, {
+      method: 'PUT',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({ role: role }),
+    });
+  },
+
   confirmOrganizationMembership: (organizationName: string): Promise<null> => {
     return apiFetch(`${API_BASE_URL}/orgs/${organizationName}/accept-invitation`);
   },
@@ -531,6 +576,49 @@ export const API = {
     });
   },
 
+  registerDeleteUserCode: (): Promise<null | string> => {
+    return apiFetch(`${API_BASE_URL}/users/delete-user-code`, {
+      method: 'POST',
+    });
+  },
+
+  deleteUser: (code: string): Promise<null | string> => {
+    return apiFetch(`${API_BASE_URL}/users`, {
+      method: 'DELETE',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({
+        code: code,
+      }),
+    });
+  },
+
+  registerEmailChangeCode: (email: string): Promise<null | string> => {
+    return apiFetch(`${API_BASE_URL}/users/email-change-code`, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({
+        email: email,
+      }),
+    });
+  },
+
+  changeEmail: (code: string, invalidateSessions: boolean): Promise<null | string> => {
+    return apiFetch(`${API_BASE_URL}/users/email`, {
+      method: 'PUT',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({
+        code: code,
+        invalidate_sessions: invalidateSessions,
+      }),
+    });
+  },
+
   saveImage: (data: string | ArrayBuffer): Promise<LogoImage> => {
     return apiFetch(`${API_BASE_URL}/images`, {
       method: 'POST',
@@ -610,7 +698,7 @@ export const API = {
     });
   },
 
-  triggerWebhookTest: (webhook: TestWebhook): Promise<string | null> => {
+  triggerWebhookTest: (webhook: TestWebhook): Promise<WebhookTestResult> => {
     const formattedWebhook = renameKeysInObject(webhook, { contentType: 'content_type', eventKinds: 'event_kinds' });
 
     return apiFetch(`${API_BASE_URL}/webhooks/test`, {
@@ -630,7 +718,7 @@ export const API = {
     return apiFetch(`${API_BASE_URL}/api-keys/${apiKeyId}`);
   },
 
-  addAPIKey: (name: string): Promise<APIKeyCode> => {
+  addAPIKey: (name: string, scopes?: string[]): Promise<APIKeyCode> => {
     return apiFetch(`${API_BASE_URL}/api-keys`, {
       method: 'POST',
       headers: {
@@ -638,6 +726,7 @@ export const API = {
       },
       body: JSON.stringify({
         name: name,
+        scopes: scopes,
       }),
     });
   },
@@ -726,11 +815,12 @@ export const API = {
   },
 
   // Reset password
-  requestPasswordResetCode: (email: string): Promise<string | null> => {
+  requestPasswordResetCode: (email: string, captchaResponse?: string | null): Promise<string | null> => {
     return apiFetch(`${API_BASE_URL}/users/password-reset-code`, {
       method: 'POST',
       headers: {
         'Content-Type': 'application/json',
+        ...(captchaResponse ? { [CAPTCHA_HEADER]: captchaResponse } : {}),
       },
       body: JSON.stringify({
         email: email,
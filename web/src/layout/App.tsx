@@ -62,7 +62,15 @@ export default function App() {
           <UserNotificationsController />
           <Switch>
             <Route
-              path={['/', '/verify-email', '/login', '/accept-invitation', '/oauth-failed', '/reset-password']}
+              path={[
+                '/',
+                '/verify-email',
+                '/login',
+                '/accept-invitation',
+                '/oauth-failed',
+                '/reset-password',
+                '/revoke-session',
+              ]}
               exact
               render={({ location }) => (
                 <div className="d-flex flex-column flex-grow-1">
@@ -80,6 +88,9 @@ export default function App() {
                     resetPwdCode={
                       location.pathname === '/reset-password' ? getQueryParam(location.search, 'code') : undefined
                     }
+                    revokeSessionCode={
+                      location.pathname === '/revoke-session' ? getQueryParam(location.search, 'code') : undefined
+                    }
                     orgToConfirm={
                       location.pathname === '/accept-invitation' ? getQueryParam(location.search, 'org') : undefined
                     }
@@ -0,0 +1,44 @@
+import { render, waitFor } from '@testing-library/react';
+import React from 'react';
+
+import Captcha from './Captcha';
+
+const addMetaTag = (name: string, content: string) => {
+  const meta = document.createElement('meta');
+  meta.name = `artifacthub:${name}`;
+  meta.content = content;
+  document.head.appendChild(meta);
+};
+
+describe('Captcha', () => {
+  afterEach(() => {
+    document.head.innerHTML = '';
+    delete (window as any).turnstile;
+  });
+
+  it('does not render anything when no provider has been configured', () => {
+    const { queryByTestId } = render(<Captcha onChange={jest.fn()} />);
+
+    expect(queryByTestId('captcha')).toBeNull();
+  });
+
+  it('renders the provider widget and reports the response', async () => {
+    addMetaTag('captchaProvider', 'turnstile');
+    addMetaTag('captchaSiteKey', 'siteKey');
+    const renderMock = jest.fn();
+    (window as any).turnstile = { render: renderMock };
+    const onChangeMock = jest.fn();
+
+    const { getByTestId } = render(<Captcha onChange={onChangeMock} />);
+
+    const container = getByTestId('captcha');
+    await waitFor(() => {
+      expect(renderMock).toHaveBeenCalledTimes(1);
+    });
+    expect(renderMock.mock.calls[0][0]).toBe(container);
+    expect(renderMock.mock.calls[0][1].sitekey).toBe('siteKey');
+
+    renderMock.mock.calls[0][1].callback('response');
+    expect(onChangeMock).toHaveBeenCalledWith('response');
+  });
+});
@@ -0,0 +1,75 @@
+import React, { useEffect, useRef } from 'react';
+
+interface Props {
+  onChange: (response: string | null) => void;
+}
+
+interface CaptchaProvider {
+  scriptURL: string;
+  global: string;
+}
+
+const CALLBACK_NAME = 'onCaptchaLoaded';
+
+const PROVIDERS: { [key: string]: CaptchaProvider } = {
+  hcaptcha: { scriptURL: 'https://js.hcaptcha.com/1/api.js', global: 'hcaptcha' },
+  recaptcha: { scriptURL: 'https://www.google.com/recaptcha/api.js', global: 'grecaptcha' },
+  turnstile: { scriptURL: 'https://challenges.cloudflare.com/turnstile/v0/api.js', global: 'turnstile' },
+};
+
+const getMetaTag = (name: string): string | null => {
+  const meta = document.querySelector(`meta[name='artifacthub:${name}']`);
+  return meta ? meta.getAttribute('content') : null;
+};
+
+export const getCaptchaConfig = (): { provider: CaptchaProvider; siteKey: string } | null => {
+  const provider = PROVIDERS[getMetaTag('captchaProvider') || ''];
+  const siteKey = getMetaTag('captchaSiteKey');
+  if (!provider || !siteKey) return null;
+  return { provider, siteKey };
+};
+
+// The provider's script is only loaded once, even when several widgets are rendered
+let scriptLoaded: Promise<void> | null = null;
+
+const loadScript = (provider: CaptchaProvider): Promise<void> => {
+  if ((window as any)[provider.global]) return Promise.resolve();
+  if (!scriptLoaded) {
+    scriptLoaded = new Promise((resolve) => {
+      (window as any)[CALLBACK_NAME] = () => resolve();
+      const script = document.createElement('script');
+      script.src = `${provider.scriptURL}?onload=${CALLBACK_NAME}&render=explicit`;
+      script.async = true;
+      document.head.appendChild(script);
+    });
+  }
+  return scriptLoaded;
+};
+
+const Captcha = (props: Props) => {
+  const container = useRef<HTMLDivElement>(null);
+  const config = getCaptchaConfig();
+  const { onChange } = props;
+
+  useEffect(() => {
+    if (!config) return;
+    let unmounted = false;
+    loadScript(config.provider).then(() => {
+      if (unmounted || !container.current) return;
+      (window as any)[config.provider.global].render(container.current, {
+        sitekey: config.siteKey,
+        callback: (response: string) => onChange(response),
+        'expired-callback': () => onChange(null),
+      });
+    });
+    return () => {
+      unmounted = true;
+    };
+  }, []); /* eslint-disable-line react-hooks/exhaustive-deps */
+
+  if (!config) return null;
+
+  return <div data-testid="captcha" ref={container} className="my-3" />;
+};
+
+export default Captcha;
@@ -28,6 +28,17 @@ describe('Image', () => {
     );
   });
 
+  it('renders resized image when size is defined', () => {
+    const { getByAltText } = render(<Image {...defaultProps} size={50} />);
+    const image = getByAltText(defaultProps.alt);
+    expect(image).toBeInTheDocument();
+    expect(image).toHaveProperty('src', `http://localhost/image/${defaultProps.imageId}?width=50&height=50`);
+    expect(image).toHaveProperty(
+      'srcset',
+      `/image/${defaultProps.imageId}?width=50&height=50 1x, /image/${defaultProps.imageId}?width=100&height=100 2x, /image/${defaultProps.imageId}?width=150&height=150 3x, /image/${defaultProps.imageId}?width=200&height=200 4x`
+    );
+  });
+
   it('renders placeholder image when imageId is not defined', () => {
     const props = {
       ...defaultProps,
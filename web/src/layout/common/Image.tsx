@@ -11,9 +11,11 @@ interface Props {
   className?: string;
   placeholderIcon?: JSX.Element;
   kind?: RepositoryKind;
+  size?: number;
 }
 
 const PLACEHOLDER_SRC = '/static/media/package_placeholder.svg';
+const PIXEL_DENSITIES = [1, 2, 3, 4];
 
 const Image = (props: Props) => {
   const [error, setError] = useState(false);
@@ -22,6 +24,18 @@ const Image = (props: Props) => {
     return `${getHubBaseURL()}/image/${props.imageId}`;
   };
 
+  const getSizedSrc = (size: number): string => {
+    return `${getSrc()}?width=${size}&height=${size}`;
+  };
+
+  const getSrcSet = (): string => {
+    const size = props.size;
+    if (isUndefined(size)) {
+      return PIXEL_DENSITIES.map((density: number) => `${getSrc()}@${density}x ${density}x`).join(', ');
+    }
+    return PIXEL_DENSITIES.map((density: number) => `${getSizedSrc(size * density)} ${density}x`).join(', ');
+  };
+
   const getPlaceholder = (): string => {
     if (isUndefined(props.kind)) {
       return PLACEHOLDER_SRC;
@@ -63,8 +77,8 @@ const Image = (props: Props) => {
       ) : (
         <img
           alt={props.alt}
-          srcSet={`${getSrc()}@1x 1x, ${getSrc()}@2x 2x, ${getSrc()}@3x 3x, ${getSrc()}@4x 4x`}
-          src={getSrc()}
+          srcSet={getSrcSet()}
+          src={isUndefined(props.size) ? getSrc() : getSizedSrc(props.size)}
           className={props.className}
           onError={() => setError(true)}
         />
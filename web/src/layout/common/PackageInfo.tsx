@@ -52,6 +52,7 @@ const PackageInfo = (props: Props) => {
         alt={`Logo ${props.package.displayName || props.package.name}`}
         className={styles.image}
         kind={props.package.repository.kind}
+        size={80}
       />
     </div>
   );
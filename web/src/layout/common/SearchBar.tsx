@@ -293,6 +293,7 @@ const SearchBar = (props: Props) => {
                             alt={`Logo ${pkg.displayName || pkg.name}`}
                             className={styles.image}
                             kind={pkg.repository.kind}
+                            size={50}
                           />
                         </div>
 
@@ -47,6 +47,25 @@ describe('SignedBadge', () => {
     });
   });
 
+  it('renders cosign signature identity', async () => {
+    const { getByTestId, getByText, getByRole } = render(
+      <SignedBadge
+        repositoryKind={11}
+        signed
+        signatures={[{ kind: 'cosign-keyless', identity: 'jane@email.com', verified: true }]}
+      />
+    );
+    expect(getByText('Signed')).toBeInTheDocument();
+
+    const badge = getByTestId('elementWithTooltip');
+    fireEvent.mouseEnter(badge);
+
+    await waitFor(() => {
+      expect(getByText('Signed with cosign by jane@email.com')).toBeInTheDocument();
+      expect(getByRole('tooltip')).toBeInTheDocument();
+    });
+  });
+
   it('does not render label', () => {
     const { container } = render(<SignedBadge repositoryKind={0} signed={false} />);
     expect(container).toBeEmptyDOMElement();
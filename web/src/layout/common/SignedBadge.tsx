@@ -1,25 +1,40 @@
+import isNull from 'lodash/isNull';
 import isUndefined from 'lodash/isUndefined';
 import React from 'react';
 import { FaAward } from 'react-icons/fa';
 
-import { RepositoryKind } from '../../types';
+import { RepositoryKind, Signature } from '../../types';
 import ElementWithTooltip from './ElementWithTooltip';
 import Label from './Label';
 
 interface Props {
   signed: null | boolean;
+  signatures?: Signature[] | null;
   className?: string;
   repositoryKind?: RepositoryKind;
 }
 
-const SignedBadge = (props: Props) => (
-  <ElementWithTooltip
-    active={props.signed}
-    className={props.className}
-    element={<Label text="Signed" icon={<FaAward />} labelStyle="success" />}
-    tooltipMessage="This chart has a provenance file"
-    visibleTooltip={!isUndefined(props.repositoryKind) && props.repositoryKind === RepositoryKind.Helm}
-  />
-);
+const getTooltipMessage = (props: Props): string | null => {
+  if (!isUndefined(props.repositoryKind) && props.repositoryKind === RepositoryKind.Helm && !props.signatures) {
+    return 'This chart has a provenance file';
+  }
+  const signature = (props.signatures || []).find((s: Signature) => s.verified);
+  if (isUndefined(signature)) return null;
+  return signature.identity ? `Signed with cosign by ${signature.identity}` : 'Signed with cosign';
+};
+
+const SignedBadge = (props: Props) => {
+  const tooltipMessage = getTooltipMessage(props);
+
+  return (
+    <ElementWithTooltip
+      active={props.signed}
+      className={props.className}
+      element={<Label text="Signed" icon={<FaAward />} labelStyle="success" />}
+      tooltipMessage={tooltipMessage || ''}
+      visibleTooltip={!isNull(tooltipMessage)}
+    />
+  );
+};
 
 export default SignedBadge;
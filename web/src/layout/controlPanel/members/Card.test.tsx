@@ -1,9 +1,10 @@
 import { fireEvent, render, waitFor } from '@testing-library/react';
 import React from 'react';
+import { mocked } from 'ts-jest/utils';
 
 import { API } from '../../../api';
 import { AppCtx } from '../../../context/AppCtx';
-import { Member } from '../../../types';
+import { Member, OrganizationRole } from '../../../types';
 import Card from './Card';
 jest.mock('../../../api');
 
@@ -146,5 +147,37 @@ describe('Member Card - members section', () => {
         );
       });
     });
+
+    it('calls updateOrganizationMemberRole to change member role', async () => {
+      mocked(API).updateOrganizationMemberRole.mockResolvedValue(null);
+      const props = {
+        ...defaultProps,
+        member: {
+          ...memberMock,
+          role: OrganizationRole.Member,
+        },
+      };
+      const { getByText, getByTestId, queryByTestId } = render(
+        <AppCtx.Provider value={{ ctx: mockCtx, dispatch: jest.fn() }}>
+          <Card {...props} />
+        </AppCtx.Provider>
+      );
+
+      expect(getByText('member')).toBeInTheDocument();
+      expect(queryByTestId('memberRoleBtn')).toBeNull();
+
+      const btn = getByTestId('adminRoleBtn');
+      fireEvent.click(btn);
+
+      await waitFor(() => {
+        expect(API.updateOrganizationMemberRole).toHaveBeenCalledTimes(1);
+        expect(API.updateOrganizationMemberRole).toHaveBeenCalledWith(
+          mockCtx.prefs.controlPanel.selectedOrg,
+          memberMock.alias,
+          OrganizationRole.Admin
+        );
+        expect(props.onSuccess).toHaveBeenCalledTimes(1);
+      });
+    });
   });
 });
@@ -2,13 +2,13 @@ import classnames from 'classnames';
 import isUndefined from 'lodash/isUndefined';
 import React, { useContext, useRef, useState } from 'react';
 import { BsThreeDotsVertical } from 'react-icons/bs';
-import { FaSignOutAlt, FaUser, FaUserMinus } from 'react-icons/fa';
+import { FaSignOutAlt, FaUser, FaUserCog, FaUserMinus } from 'react-icons/fa';
 import { IoMdCloseCircle } from 'react-icons/io';
 
 import { API } from '../../../api';
 import { AppCtx, unselectOrg } from '../../../context/AppCtx';
 import useOutsideClick from '../../../hooks/useOutsideClick';
-import { AuthorizerAction, ErrorKind, Member } from '../../../types';
+import { AuthorizerAction, ErrorKind, Member, OrganizationRole } from '../../../types';
 import alertDispatcher from '../../../utils/alertDispatcher';
 import Modal from '../../common/Modal';
 import ActionBtn from '../ActionBtn';
@@ -61,6 +61,26 @@ const MemberCard = (props: Props) => {
     }
   }
 
+  async function updateRole(role: OrganizationRole) {
+    try {
+      await API.updateOrganizationMemberRole(ctx.prefs.controlPanel.selectedOrg!, props.member.alias, role);
+      props.onSuccess();
+    } catch (err) {
+      if (err.kind !== ErrorKind.Unauthorized) {
+        let errorMessage = 'An error occurred updating the member role, please try again later.';
+        if (err.kind === ErrorKind.Forbidden) {
+          errorMessage = 'You do not have permissions to update the role of the members of the organization.';
+        }
+        alertDispatcher.postAlert({
+          type: 'danger',
+          message: errorMessage,
+        });
+      } else {
+        props.onAuthError();
+      }
+    }
+  }
+
   const isUser = props.member.alias === ctx.user!.alias;
 
   const getFullName = (): string => {
@@ -90,6 +110,11 @@ const MemberCard = (props: Props) => {
                 <div className="h5 mb-1">
                   {props.member.firstName || props.member.lastName ? getFullName() : props.member.alias}
                 </div>
+                {props.member.role && (
+                  <div className="ml-3">
+                    <span className="badge badge-secondary text-uppercase">{props.member.role}</span>
+                  </div>
+                )}
                 {!isUndefined(props.member.confirmed) && !props.member.confirmed && (
                   <div className={classnames('ml-3', { 'mr-3': props.membersNumber > 1 })}>
                     <span className="badge badge-warning">Invitation not accepted yet</span>
@@ -204,6 +229,29 @@ const MemberCard = (props: Props) => {
                         </>
                       </ActionBtn>
                     )}
+
+                    {!isUser &&
+                      props.member.role &&
+                      Object.values(OrganizationRole)
+                        .filter((role: OrganizationRole) => role !== props.member.role)
+                        .map((role: OrganizationRole) => (
+                          <ActionBtn
+                            key={`role_${role}`}
+                            testId={`${role}RoleBtn`}
+                            className="dropdown-item btn btn-sm rounded-0 text-secondary"
+                            onClick={(e: React.MouseEvent<HTMLButtonElement>) => {
+                              e.preventDefault();
+                              closeDropdown();
+                              updateRole(role);
+                            }}
+                            action={AuthorizerAction.UpdateOrganizationMemberRole}
+                          >
+                            <>
+                              <FaUserCog className={`mr-2 ${styles.btnIcon}`} />
+                              <span>Make {role}</span>
+                            </>
+                          </ActionBtn>
+                        ))}
                   </div>
 
                   <button
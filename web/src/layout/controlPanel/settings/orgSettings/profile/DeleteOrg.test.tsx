@@ -138,7 +138,7 @@ describe('DeleteOrg', () => {
 
         await waitFor(() => {
           expect(API.deleteOrganization).toHaveBeenCalledTimes(1);
-          expect(API.deleteOrganization).toHaveBeenCalledWith('orgTest');
+          expect(API.deleteOrganization).toHaveBeenCalledWith('orgTest', { confirmation: 'orgTest' });
         });
 
         expect(mockDispatch).toHaveBeenCalledTimes(1);
@@ -29,7 +29,7 @@ const DeleteOrganization = (props: Props) => {
   async function deleteOrganization() {
     try {
       setIsDeleting(true);
-      await API.deleteOrganization(props.organization.name);
+      await API.deleteOrganization(props.organization.name, { confirmation: props.organization.name });
       dispatch(unselectOrg());
       window.scrollTo(0, 0); // Scroll to top when org is deleted
       setIsDeleting(false);
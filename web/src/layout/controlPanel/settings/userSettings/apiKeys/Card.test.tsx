@@ -47,6 +47,27 @@ describe('API key Card - API keys section', () => {
       expect(getByTestId('deleteAPIKeyModalBtn')).toBeInTheDocument();
     });
 
+    it('renders API key usage', () => {
+      const { getByText } = render(
+        <Card
+          {...defaultProps}
+          apiKey={{ ...APIKeyMock, lastUsedAt: 1592498139, lastUsedIp: '192.168.1.1', requestsLast30Days: 25 }}
+        />
+      );
+
+      expect(getByText('Last used:')).toBeInTheDocument();
+      expect(getByText('2020/06/18 16:35:39 (+00:00) from 192.168.1.1')).toBeInTheDocument();
+      expect(getByText('Requests (last 30 days):')).toBeInTheDocument();
+      expect(getByText('25')).toBeInTheDocument();
+    });
+
+    it('renders never used API key', () => {
+      const { getByText, queryByText } = render(<Card {...defaultProps} />);
+
+      expect(getByText('Never')).toBeInTheDocument();
+      expect(queryByText('Requests (last 30 days):')).toBeNull();
+    });
+
     it('calls deleteAPIKey when leave button in dropdown is clicked', async () => {
       const { getByTestId, getByText } = render(<Card {...defaultProps} />);
 
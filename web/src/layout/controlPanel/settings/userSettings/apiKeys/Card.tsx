@@ -183,6 +183,29 @@ const APIKeyCard = (props: Props) => {
             <small className="text-muted text-uppercase mr-1">Created at: </small>
             <small>{moment(props.apiKey.createdAt! * 1000).format('YYYY/MM/DD HH:mm:ss (Z)')}</small>
           </div>
+          <div className="text-truncate">
+            <small className="text-muted text-uppercase mr-1">Last used: </small>
+            {props.apiKey.lastUsedAt ? (
+              <small>
+                {moment(props.apiKey.lastUsedAt * 1000).format('YYYY/MM/DD HH:mm:ss (Z)')}
+                {props.apiKey.lastUsedIp && ` from ${props.apiKey.lastUsedIp}`}
+              </small>
+            ) : (
+              <small>Never</small>
+            )}
+          </div>
+          {props.apiKey.lastUsedAt && (
+            <div className="text-truncate">
+              <small className="text-muted text-uppercase mr-1">Requests (last 30 days): </small>
+              <small>{props.apiKey.requestsLast30Days || 0}</small>
+            </div>
+          )}
+          {props.apiKey.scopes && props.apiKey.scopes.length > 0 && (
+            <div className="text-truncate">
+              <small className="text-muted text-uppercase mr-1">Scopes: </small>
+              <small>{props.apiKey.scopes.join(', ')}</small>
+            </div>
+          )}
         </div>
       </div>
     </div>
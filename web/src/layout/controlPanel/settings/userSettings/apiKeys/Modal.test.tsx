@@ -70,7 +70,7 @@ describe('APIKeyModal - API keys section', () => {
 
       await waitFor(() => {
         expect(API.addAPIKey).toHaveBeenCalledTimes(1);
-        expect(API.addAPIKey).toHaveBeenCalledWith('test');
+        expect(API.addAPIKey).toHaveBeenCalledWith('test', []);
       });
 
       expect(onSuccessMock).toHaveBeenCalledTimes(1);
@@ -92,6 +92,27 @@ describe('APIKeyModal - API keys section', () => {
       expect(btns[3]).toHaveAttribute('href', 'https://artifacthub.github.io/hub/api');
     });
 
+    it('calls add API key with the scopes selected', async () => {
+      mocked(API).addAPIKey.mockResolvedValue({
+        secret: '1276576',
+        apiKeyId: 'id',
+      });
+      const { getByTestId, getByText } = render(<Modal {...defaultProps} />);
+
+      fireEvent.change(getByTestId('nameInput'), { target: { value: 'test' } });
+      fireEvent.click(getByTestId('scope_read-only'));
+      fireEvent.click(getByTestId('scope_webhooks:manage'));
+      fireEvent.click(getByTestId('apiKeyFormBtn'));
+
+      await waitFor(() => {
+        expect(API.addAPIKey).toHaveBeenCalledTimes(1);
+        expect(API.addAPIKey).toHaveBeenCalledWith('test', ['read-only', 'webhooks:manage']);
+      });
+
+      expect(getByText('read-only, webhooks:manage')).toBeInTheDocument();
+      expect(getByText(/can be used to perform the operations allowed by its scopes/i)).toBeInTheDocument();
+    });
+
     it('displays default Api error', async () => {
       mocked(API).addAPIKey.mockRejectedValue({
         kind: ErrorKind.Other,
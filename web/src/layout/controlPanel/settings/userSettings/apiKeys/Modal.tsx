@@ -9,6 +9,7 @@ import { docco } from 'react-syntax-highlighter/dist/cjs/styles/hljs';
 
 import { API } from '../../../../../api';
 import { APIKey, APIKeyCode, ErrorKind, RefInputField } from '../../../../../types';
+import { API_KEY_SCOPES } from '../../../../../utils/data';
 import ButtonCopyToClipboard from '../../../../common/ButtonCopyToClipboard';
 import ExternalLink from '../../../../common/ExternalLink';
 import InputField from '../../../../common/InputField';
@@ -37,6 +38,7 @@ const APIKeyModal = (props: Props) => {
   const [apiKey, setApiKey] = useState<APIKey | undefined>(props.apiKey);
   const [apiError, setApiError] = useState<string | null>(null);
   const [apiKeyCode, setApiKeyCode] = useState<APIKeyCode | undefined>(undefined);
+  const [scopes, setScopes] = useState<string[]>([]);
 
   // Clean API error when form is focused after validation
   const cleanApiError = () => {
@@ -48,17 +50,18 @@ const APIKeyModal = (props: Props) => {
   const onCloseModal = () => {
     setApiKey(undefined);
     setApiKeyCode(undefined);
+    setScopes([]);
     setIsValidated(false);
     setApiError(null);
     props.onClose();
   };
 
-  async function handleAPIKey(name: string) {
+  async function handleAPIKey(name: string, scopes: string[]) {
     try {
       if (props.apiKey) {
         await API.updateAPIKey(props.apiKey.apiKeyId!, name);
       } else {
-        setApiKeyCode(await API.addAPIKey(name));
+        setApiKeyCode(await API.addAPIKey(name, scopes));
       }
       if (props.onSuccess) {
         props.onSuccess();
@@ -87,7 +90,7 @@ const APIKeyModal = (props: Props) => {
     if (form.current) {
       const { isValid, apiKey } = validateForm(form.current);
       if (isValid && apiKey) {
-        handleAPIKey(apiKey.name);
+        handleAPIKey(apiKey.name, apiKey.scopes || []);
       } else {
         setIsSending(false);
       }
@@ -102,7 +105,9 @@ const APIKeyModal = (props: Props) => {
     if (isValid) {
       apiKey = {
         name: formData.get('name') as string,
+        scopes: formData.getAll('scopes') as string[],
       };
+      setScopes(apiKey.scopes!);
     }
 
     setIsValidated(true);
@@ -229,9 +234,20 @@ const APIKeyModal = (props: Props) => {
             </small>
 
             <div className={`alert alert-warning mt-4 mb-2 ${styles.alert}`}>
-              <span className="font-weight-bold text-uppercase">Important:</span> the API key you've just generated can
-              be used to perform <u className="font-weight-bold">ANY</u> operation you can, so please store it safely
-              and don't share it with others.
+              {scopes.length === 0 ? (
+                <>
+                  <span className="font-weight-bold text-uppercase">Important:</span> the API key you've just generated
+                  can be used to perform <u className="font-weight-bold">ANY</u> operation you can, so please store it
+                  safely and don't share it with others.
+                </>
+              ) : (
+                <>
+                  <span className="font-weight-bold text-uppercase">Important:</span> the API key you've just generated
+                  can be used to perform the operations allowed by its scopes (
+                  <span className="font-weight-bold">{scopes.join(', ')}</span>), so please store it safely and don't
+                  share it with others.
+                </>
+              )}
             </div>
           </>
         ) : (
@@ -257,6 +273,32 @@ const APIKeyModal = (props: Props) => {
               onKeyDown={handleOnReturnKeyDown}
               required
             />
+
+            {isUndefined(props.apiKey) && (
+              <div className="form-group mb-4">
+                <label className="font-weight-bold">Scopes</label>
+                <small className="form-text text-muted mt-0 mb-2">
+                  Restrict the operations this API key can perform. When no scopes are selected, the API key can be
+                  used to perform any operation you can.
+                </small>
+                {API_KEY_SCOPES.map((scope) => (
+                  <div className="custom-control custom-checkbox mb-2" key={`scope_${scope.name}`}>
+                    <input
+                      data-testid={`scope_${scope.name}`}
+                      id={`scope_${scope.name}`}
+                      className="custom-control-input"
+                      type="checkbox"
+                      name="scopes"
+                      value={scope.name}
+                    />
+                    <label className="custom-control-label" htmlFor={`scope_${scope.name}`}>
+                      <span className="font-weight-bold">{scope.name}</span>
+                      <small className="text-muted ml-2">{scope.description}</small>
+                    </label>
+                  </div>
+                ))}
+              </div>
+            )}
           </form>
         )}
       </div>
@@ -0,0 +1,111 @@
+import { fireEvent, render, waitFor } from '@testing-library/react';
+import React from 'react';
+import { BrowserRouter as Router } from 'react-router-dom';
+import { mocked } from 'ts-jest/utils';
+
+import { API } from '../../../../../api';
+import { AppCtx } from '../../../../../context/AppCtx';
+import { ErrorKind } from '../../../../../types';
+import alertDispatcher from '../../../../../utils/alertDispatcher';
+import DeleteAccount from './DeleteAccount';
+jest.mock('../../../../../api');
+jest.mock('../../../../../utils/alertDispatcher');
+
+const onAuthErrorMock = jest.fn();
+const mockDispatch = jest.fn();
+
+const mockCtx = {
+  user: { alias: 'userAlias', email: 'jsmith@email.com' },
+  prefs: {
+    controlPanel: {},
+    search: { limit: 60 },
+    theme: {
+      configured: 'light',
+      effective: 'light',
+    },
+    notifications: {
+      lastDisplayedTime: null,
+      enabled: true,
+      displayed: [],
+    },
+  },
+};
+
+const renderComponent = () =>
+  render(
+    <AppCtx.Provider value={{ ctx: mockCtx, dispatch: mockDispatch }}>
+      <Router>
+        <DeleteAccount onAuthError={onAuthErrorMock} />
+      </Router>
+    </AppCtx.Provider>
+  );
+
+describe('Delete account - user settings', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  it('renders component', () => {
+    const { getByTestId, queryByTestId } = renderComponent();
+
+    expect(getByTestId('deleteAccount')).toBeInTheDocument();
+    expect(getByTestId('requestDeleteUserCodeBtn')).toBeInTheDocument();
+    expect(queryByTestId('deleteUserCodeInput')).toBeNull();
+  });
+
+  it('requests delete user code and deletes account', async () => {
+    mocked(API).registerDeleteUserCode.mockResolvedValue(null);
+    mocked(API).deleteUser.mockResolvedValue(null);
+
+    const { getByTestId, findByTestId } = renderComponent();
+    fireEvent.click(getByTestId('requestDeleteUserCodeBtn'));
+
+    await waitFor(() => {
+      expect(API.registerDeleteUserCode).toHaveBeenCalledTimes(1);
+    });
+
+    const input = await findByTestId('deleteUserCodeInput');
+    const btn = getByTestId('deleteUserBtn');
+    expect(btn).toBeDisabled();
+    fireEvent.change(input, { target: { value: 'code' } });
+    fireEvent.click(btn);
+
+    await waitFor(() => {
+      expect(API.deleteUser).toHaveBeenCalledTimes(1);
+      expect(API.deleteUser).toHaveBeenCalledWith('code');
+      expect(mockDispatch).toHaveBeenCalledWith({ type: 'signOut' });
+    });
+  });
+
+  describe('when registerDeleteUserCode fails', () => {
+    it('UnauthorizedError', async () => {
+      mocked(API).registerDeleteUserCode.mockRejectedValue({
+        kind: ErrorKind.Unauthorized,
+      });
+
+      const { getByTestId } = renderComponent();
+      fireEvent.click(getByTestId('requestDeleteUserCodeBtn'));
+
+      await waitFor(() => {
+        expect(onAuthErrorMock).toHaveBeenCalledTimes(1);
+      });
+    });
+
+    it('default error', async () => {
+      mocked(API).registerDeleteUserCode.mockRejectedValue({
+        kind: ErrorKind.Other,
+      });
+
+      const { getByTestId } = renderComponent();
+      fireEvent.click(getByTestId('requestDeleteUserCodeBtn'));
+
+      await waitFor(() => {
+        expect(alertDispatcher.postAlert).toHaveBeenCalledTimes(1);
+        expect(alertDispatcher.postAlert).toHaveBeenCalledWith({
+          type: 'danger',
+          message: 'An error occurred requesting the deletion of your account, please try again later.',
+        });
+      });
+    });
+  });
+});
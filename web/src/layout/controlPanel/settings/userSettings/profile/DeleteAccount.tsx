@@ -0,0 +1,131 @@
+import React, { useContext, useState } from 'react';
+import { useHistory } from 'react-router-dom';
+
+import { API } from '../../../../../api';
+import { AppCtx, signOut } from '../../../../../context/AppCtx';
+import { ErrorKind } from '../../../../../types';
+import alertDispatcher from '../../../../../utils/alertDispatcher';
+import compoundErrorMessage from '../../../../../utils/compoundErrorMessage';
+
+interface Props {
+  onAuthError: () => void;
+}
+
+const DeleteAccount = (props: Props) => {
+  const { dispatch } = useContext(AppCtx);
+  const history = useHistory();
+  const [isSending, setIsSending] = useState(false);
+  const [codeRequested, setCodeRequested] = useState(false);
+  const [code, setCode] = useState('');
+
+  const onCodeChange = (e: React.ChangeEvent<HTMLInputElement>) => {
+    setCode(e.target.value.trim());
+  };
+
+  const handleError = (err: any, message: string) => {
+    setIsSending(false);
+    if (err.kind !== ErrorKind.Unauthorized) {
+      alertDispatcher.postAlert({
+        type: 'danger',
+        message: compoundErrorMessage(err, message),
+      });
+    } else {
+      props.onAuthError();
+    }
+  };
+
+  async function registerDeleteUserCode() {
+    try {
+      setIsSending(true);
+      await API.registerDeleteUserCode();
+      setIsSending(false);
+      setCodeRequested(true);
+    } catch (err) {
+      handleError(err, 'An error occurred requesting the deletion of your account');
+    }
+  }
+
+  async function deleteUser() {
+    try {
+      setIsSending(true);
+      await API.deleteUser(code);
+      setIsSending(false);
+      dispatch(signOut());
+      history.push('/');
+    } catch (err) {
+      handleError(err, 'An error occurred deleting your account');
+    }
+  }
+
+  return (
+    <div data-testid="deleteAccount">
+      <p className="mb-4">
+        Once your account is deleted, all the repositories, subscriptions, webhooks and API keys that belong to it will
+        be removed as well. Your account will be kept for a grace period before being deleted, during which you can
+        cancel the deletion just by logging in again.
+      </p>
+
+      {codeRequested ? (
+        <>
+          <p className="mb-3">
+            We have sent you an email with a code to confirm the deletion of your account. Please paste it below.
+          </p>
+          <div className="form-group">
+            <label className="font-weight-bold" htmlFor="deleteUserCode">
+              Confirmation code
+            </label>
+            <input
+              data-testid="deleteUserCodeInput"
+              id="deleteUserCode"
+              className="form-control"
+              type="text"
+              value={code}
+              onChange={onCodeChange}
+              autoComplete="off"
+              spellCheck="false"
+            />
+          </div>
+          <div className="mt-4 mb-2">
+            <button
+              data-testid="deleteUserBtn"
+              className="btn btn-sm btn-danger"
+              type="button"
+              disabled={isSending || code === ''}
+              onClick={deleteUser}
+            >
+              {isSending ? (
+                <>
+                  <span className="spinner-grow spinner-grow-sm" role="status" aria-hidden="true" />
+                  <span className="ml-2">Deleting account</span>
+                </>
+              ) : (
+                <div className="text-uppercase">Delete account</div>
+              )}
+            </button>
+          </div>
+        </>
+      ) : (
+        <div className="mt-4 mb-2">
+          <button
+            data-testid="requestDeleteUserCodeBtn"
+            className="btn btn-sm btn-danger"
+            type="button"
+            disabled={isSending}
+            onClick={registerDeleteUserCode}
+          >
+            {isSending ? (
+              <>
+                <span className="spinner-grow spinner-grow-sm" role="status" aria-hidden="true" />
+                <span className="ml-2">Requesting deletion</span>
+              </>
+            ) : (
+              <div className="text-uppercase">Delete account</div>
+            )}
+          </button>
+        </div>
+      )}
+    </div>
+  );
+};
+
+export default DeleteAccount;
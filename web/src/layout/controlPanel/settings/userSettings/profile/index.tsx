@@ -3,6 +3,7 @@ import React, { useContext, useEffect, useState } from 'react';
 import { API } from '../../../../../api';
 import { AppCtx } from '../../../../../context/AppCtx';
 import { ErrorKind, Profile } from '../../../../../types';
+import DeleteAccount from './DeleteAccount';
 import styles from './ProfileSection.module.css';
 import UpdatePassword from './UpdatePassword';
 import UpdateProfile from './UpdateProfile';
@@ -49,6 +50,14 @@ const ProfileSection = (props: Props) => {
           <UpdatePassword />
         </div>
       </div>
+
+      <div className="mt-5">
+        <div className={`h3 mb-4 pb-2 border-bottom ${styles.title}`}>Delete account</div>
+
+        <div className={`mt-4 mt-md-5 ${styles.formWrapper}`}>
+          <DeleteAccount onAuthError={props.onAuthError} />
+        </div>
+      </div>
     </main>
   );
 };
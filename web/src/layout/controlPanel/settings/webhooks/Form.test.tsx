@@ -629,6 +629,33 @@ describe('WebhookForm', () => {
       expect(getByTestId('testWebhookTick')).toBeInTheDocument();
     });
 
+    it('displays error when test delivery fails', async () => {
+      mocked(API).triggerWebhookTest.mockResolvedValue({
+        statusCode: 404,
+        latency: 10,
+        responseBody: 'not found',
+        error: 'unexpected status code: 404',
+      });
+      const mockWebhook = getMockWebhook('9');
+
+      const { getByTestId, getByText, queryByTestId } = render(
+        <AppCtx.Provider value={{ ctx: mockUserCtx, dispatch: jest.fn() }}>
+          <Router>
+            <WebhookForm {...defaultProps} webhook={{ ...mockWebhook, contentType: null, template: null }} />
+          </Router>
+        </AppCtx.Provider>
+      );
+
+      fireEvent.click(getByTestId('testWebhookBtn'));
+
+      await waitFor(() => {
+        expect(API.triggerWebhookTest).toHaveBeenCalledTimes(1);
+      });
+
+      expect(getByText('An error occurred testing the webhook: unexpected status code: 404')).toBeInTheDocument();
+      expect(queryByTestId('testWebhookTick')).toBeNull();
+    });
+
     it('triggers test on webhook addition', async () => {
       mocked(API).triggerWebhookTest.mockResolvedValue(null);
 
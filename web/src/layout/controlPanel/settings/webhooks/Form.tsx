@@ -142,9 +142,13 @@ const WebhookForm = (props: Props) => {
     try {
       setIsSendingTest(true);
       setIsTestSent(false);
-      await API.triggerWebhookTest(webhook);
-      setIsTestSent(true);
+      const result = await API.triggerWebhookTest(webhook);
       setIsSendingTest(false);
+      if (result && result.error) {
+        setApiError(`An error occurred testing the webhook: ${result.error}`);
+      } else {
+        setIsTestSent(true);
+      }
     } catch (err) {
       setIsSendingTest(false);
       if (err.kind !== ErrorKind.Unauthorized) {
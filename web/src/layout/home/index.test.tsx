@@ -57,6 +57,22 @@ describe('Home index', () => {
       });
     });
 
+    it('revokes session when a revocation code is provided', async () => {
+      mocked(API).getStats.mockResolvedValue(getMockStats('2'));
+      mocked(API).revokeSession.mockResolvedValue(null);
+
+      render(
+        <Router>
+          <HomeView {...defaultProps} revokeSessionCode="code" />
+        </Router>
+      );
+
+      await waitFor(() => {
+        expect(API.revokeSession).toHaveBeenCalledTimes(1);
+        expect(API.revokeSession).toHaveBeenCalledWith('code');
+      });
+    });
+
     it('renders dash symbol when results are 0', async () => {
       const mockStats = getMockStats('4');
       mocked(API).getStats.mockResolvedValue(mockStats);
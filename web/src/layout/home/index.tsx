@@ -6,6 +6,7 @@ import { Link, useHistory } from 'react-router-dom';
 import { API } from '../../api';
 import { RepositoryKind, Stats } from '../../types';
 import alertDispatcher from '../../utils/alertDispatcher';
+import compoundErrorMessage from '../../utils/compoundErrorMessage';
 import ExternalLink from '../common/ExternalLink';
 import RepositoryIcon from '../common/RepositoryIcon';
 import SampleQueries from '../common/SampleQueries';
@@ -22,6 +23,7 @@ interface Props {
   isSearching: boolean;
   emailCode?: string;
   resetPwdCode?: string;
+  revokeSessionCode?: string;
   orgToConfirm?: string;
   onOauthFailed: boolean;
 }
@@ -47,18 +49,48 @@ const HomeView = (props: Props) => {
 
   useEffect(() => {
     if (props.onOauthFailed) {
+      const reason = new URLSearchParams(history.location.search).get('reason');
       history.replace({
         pathname: '/',
         search: '',
       });
       alertDispatcher.postAlert({
         type: 'danger',
-        message: 'Authentication process failed. Please try again later.',
+        message:
+          reason === 'domain_not_allowed'
+            ? 'The domain of your email is not allowed to sign in.'
+            : 'Authentication process failed. Please try again later.',
         autoClose: false,
       });
     }
   }, [props.onOauthFailed, history]);
 
+  useEffect(() => {
+    async function revokeSession(code: string) {
+      try {
+        await API.revokeSession(code);
+        alertDispatcher.postAlert({
+          type: 'success',
+          message: 'The session has been revoked. Please reset your password if you did not sign in.',
+          autoClose: false,
+        });
+      } catch (err) {
+        alertDispatcher.postAlert({
+          type: 'danger',
+          message: compoundErrorMessage(err, 'An error occurred revoking the session'),
+        });
+      }
+    }
+
+    if (props.revokeSessionCode) {
+      history.replace({
+        pathname: '/',
+        search: '',
+      });
+      revokeSession(props.revokeSessionCode);
+    }
+  }, [props.revokeSessionCode, history]);
+
   return (
     <div className={`d-flex flex-column flex-grow-1 ${styles.home} home`}>
       <div className={`jumbotron mb-0 text-center ${styles.jumbotron}`}>
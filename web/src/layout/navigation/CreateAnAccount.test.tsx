@@ -72,13 +72,16 @@ describe('CreateAnAccount', () => {
 
       await waitFor(() => {
         expect(API.register).toHaveBeenCalledTimes(1);
-        expect(API.register).toHaveBeenCalledWith({
-          alias: 'userAlias',
-          firstName: 'John',
-          lastName: 'Smith',
-          email: 'test@email.com',
-          password: '123qwe',
-        });
+        expect(API.register).toHaveBeenCalledWith(
+          {
+            alias: 'userAlias',
+            firstName: 'John',
+            lastName: 'Smith',
+            email: 'test@email.com',
+            password: '123qwe',
+          },
+          null
+        );
       });
     });
 
@@ -100,13 +103,16 @@ describe('CreateAnAccount', () => {
 
       await waitFor(() => {
         expect(API.register).toHaveBeenCalledTimes(1);
-        expect(API.register).toHaveBeenCalledWith({
-          alias: 'userAlias',
-          firstName: 'John',
-          lastName: 'Smith',
-          email: 'test@email.com',
-          password: '123qwe*$',
-        });
+        expect(API.register).toHaveBeenCalledWith(
+          {
+            alias: 'userAlias',
+            firstName: 'John',
+            lastName: 'Smith',
+            email: 'test@email.com',
+            password: '123qwe*$',
+          },
+          null
+        );
       });
     });
 
@@ -7,6 +7,7 @@ import { MdDone } from 'react-icons/md';
 import { API } from '../../api';
 import { RefInputField, ResourceKind, User } from '../../types';
 import compoundErrorMessage from '../../utils/compoundErrorMessage';
+import Captcha from '../common/Captcha';
 import InputField from '../common/InputField';
 
 interface Loading {
@@ -40,6 +41,8 @@ const CreateAnAccount = React.forwardRef<HTMLFormElement, Props>((props, ref) =>
   const [isValidated, setIsValidated] = useState(false);
   const [isValidatingField, setIsValidatingField] = useState(false);
   const [password, setPassword] = useState<Password>({ value: '', isValid: false });
+  const [captchaResponse, setCaptchaResponse] = useState<string | null>(null);
+  const [captchaKey, setCaptchaKey] = useState(0);
 
   const onPasswordChange = (e: React.ChangeEvent<HTMLInputElement>) => {
     setPassword({ value: e.target.value, isValid: e.currentTarget.checkValidity() });
@@ -54,13 +57,16 @@ const CreateAnAccount = React.forwardRef<HTMLFormElement, Props>((props, ref) =>
 
   async function registerUser(user: User) {
     try {
-      await API.register(user);
+      await API.register(user, captchaResponse);
       props.setSuccess(true);
       props.setIsLoading({ status: false });
     } catch (err) {
       let error = compoundErrorMessage(err, 'An error occurred registering the user');
       props.setApiError(error);
       props.setIsLoading({ status: false });
+      // Captcha responses can only be verified once, so a new challenge is needed
+      setCaptchaResponse(null);
+      setCaptchaKey(captchaKey + 1);
     }
   }
 
@@ -229,6 +235,8 @@ const CreateAnAccount = React.forwardRef<HTMLFormElement, Props>((props, ref) =>
                 required
               />
             </div>
+
+            <Captcha key={captchaKey} onChange={setCaptchaResponse} />
           </form>
         </>
       )}
@@ -54,5 +54,32 @@ describe('Footer', () => {
       const footer = getByRole('contentinfo');
       expect(footer).toHaveClass('invisibleFooter');
     });
+
+    it('renders custom footer links', () => {
+      Object.defineProperty(document, 'querySelector', {
+        value: (selector: any) => {
+          switch (selector) {
+            case `meta[name='artifacthub:footerLinks']`:
+              return {
+                getAttribute: () => '[{"title": "Terms of service", "url": "https://hub.test/terms"}]',
+              };
+            default:
+              return false;
+          }
+        },
+        writable: true,
+      });
+
+      const { getByText, getAllByRole } = render(
+        <Router>
+          <Footer />
+        </Router>
+      );
+
+      expect(getByText('Links')).toBeInTheDocument();
+      const links = getAllByRole('button');
+      expect(links).toHaveLength(7);
+      expect(links[5]).toHaveTextContent('Terms of service');
+    });
   });
 });
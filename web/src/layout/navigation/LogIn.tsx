@@ -35,6 +35,9 @@ interface Props {
 }
 
 const LogIn = (props: Props) => {
+  const isPasswordAuth = document.querySelector(`meta[name='artifacthub:passwordAuth']`)
+    ? document.querySelector(`meta[name='artifacthub:passwordAuth']`)!.getAttribute('content') !== 'false'
+    : true;
   const { dispatch } = useContext(AppCtx);
   const history = useHistory();
   const loginForm = useRef<HTMLFormElement>(null);
@@ -162,75 +165,77 @@ const LogIn = (props: Props) => {
         </div>
       ) : (
         <div className="my-auto">
-          <form
-            ref={loginForm}
-            data-testid="loginForm"
-            className={classnames('w-100', { 'needs-validation': !isValidated }, { 'was-validated': isValidated })}
-            onFocus={cleanApiError}
-            autoComplete="on"
-            noValidate
-          >
-            <InputField
-              ref={emailInput}
-              type="email"
-              label="Email"
-              name="email"
-              value=""
-              invalidText={{
-                default: 'This field is required',
-                typeMismatch: 'Please enter a valid email address',
-              }}
-              autoComplete="email"
-              onChange={onEmailChange}
-              validateOnBlur={email !== ''}
-              required
-            />
-
-            <InputField
-              ref={passwordInput}
-              type="password"
-              label="Password"
-              name="password"
-              value=""
-              invalidText={{
-                default: 'This field is required',
-              }}
-              validateOnBlur
-              onKeyDown={handleOnReturnKeyDown}
-              autoComplete="current-password"
-              required
-            />
-
-            <div className="d-flex flex-row align-items-row justify-content-between">
-              <button
-                data-testid="resetPasswordTabBtn"
-                className="btn btn-sm btn-link pl-0 text-no-decoration"
-                type="button"
-                onClick={() => setVisibleResetPassword(true)}
-              >
-                Forgot password?
-              </button>
-
-              <button
-                data-testid="logInBtn"
-                className="btn btn-secondary"
-                type="button"
-                disabled={isLoading.status}
-                onClick={submitForm}
-              >
-                {!isUndefined(isLoading.type) && isLoading.type === 'log' ? (
-                  <>
-                    <span className="spinner-grow spinner-grow-sm" role="status" aria-hidden="true" />
-                    <span className="ml-2">Singing in...</span>
-                  </>
-                ) : (
-                  <>Sign in</>
-                )}
-              </button>
-            </div>
-          </form>
-
-          <OAuth isLoading={isLoading} setIsLoading={setIsLoading} />
+          {isPasswordAuth && (
+            <form
+              ref={loginForm}
+              data-testid="loginForm"
+              className={classnames('w-100', { 'needs-validation': !isValidated }, { 'was-validated': isValidated })}
+              onFocus={cleanApiError}
+              autoComplete="on"
+              noValidate
+            >
+              <InputField
+                ref={emailInput}
+                type="email"
+                label="Email"
+                name="email"
+                value=""
+                invalidText={{
+                  default: 'This field is required',
+                  typeMismatch: 'Please enter a valid email address',
+                }}
+                autoComplete="email"
+                onChange={onEmailChange}
+                validateOnBlur={email !== ''}
+                required
+              />
+
+              <InputField
+                ref={passwordInput}
+                type="password"
+                label="Password"
+                name="password"
+                value=""
+                invalidText={{
+                  default: 'This field is required',
+                }}
+                validateOnBlur
+                onKeyDown={handleOnReturnKeyDown}
+                autoComplete="current-password"
+                required
+              />
+
+              <div className="d-flex flex-row align-items-row justify-content-between">
+                <button
+                  data-testid="resetPasswordTabBtn"
+                  className="btn btn-sm btn-link pl-0 text-no-decoration"
+                  type="button"
+                  onClick={() => setVisibleResetPassword(true)}
+                >
+                  Forgot password?
+                </button>
+
+                <button
+                  data-testid="logInBtn"
+                  className="btn btn-secondary"
+                  type="button"
+                  disabled={isLoading.status}
+                  onClick={submitForm}
+                >
+                  {!isUndefined(isLoading.type) && isLoading.type === 'log' ? (
+                    <>
+                      <span className="spinner-grow spinner-grow-sm" role="status" aria-hidden="true" />
+                      <span className="ml-2">Singing in...</span>
+                    </>
+                  ) : (
+                    <>Sign in</>
+                  )}
+                </button>
+              </div>
+            </form>
+          )}
+
+          <OAuth isLoading={isLoading} setIsLoading={setIsLoading} hideSeparator={!isPasswordAuth} />
         </div>
       )}
     </Modal>
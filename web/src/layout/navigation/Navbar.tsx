@@ -23,8 +23,14 @@ interface Props {
   visibleModal?: string;
 }
 
+const getMetaTagContent = (name: string): string | null => {
+  const tag = document.querySelector(`meta[name='artifacthub:${name}']`);
+  return tag ? tag.getAttribute('content') : null;
+};
+
 const Navbar = (props: Props) => {
   const { ctx } = useContext(AppCtx);
+  const websiteLogo = getMetaTagContent('websiteLogo');
   const openLogInModal =
     (!isUndefined(props.redirect) && isNull(ctx.user)) ||
     (!isUndefined(props.visibleModal) && props.visibleModal === 'login');
@@ -46,18 +52,24 @@ const Navbar = (props: Props) => {
         <div className="container-lg px-sm-4 px-lg-0">
           <div className={`d-flex flex-row ${styles.mobileWrapper}`}>
             <Link data-testid="brandLink" className="navbar-brand d-flex align-items-center" to="/">
-              <FiHexagon className="mr-2" />
-              <div className="d-flex align-items-start">
-                <div className="d-flex align-items-baseline">
-                  <span className="mr-1">Artifact</span>
-                  <span className={styles.brand}>HUB</span>
-                </div>
-                <span
-                  className={`text-uppercase badge badge-pill badge-secondary d-flex align-items-center ${styles.badge}`}
-                >
-                  Beta
-                </span>
-              </div>
+              {websiteLogo ? (
+                <img className={styles.logo} src={websiteLogo} alt={getMetaTagContent('siteName') || 'Logo'} />
+              ) : (
+                <>
+                  <FiHexagon className="mr-2" />
+                  <div className="d-flex align-items-start">
+                    <div className="d-flex align-items-baseline">
+                      <span className="mr-1">Artifact</span>
+                      <span className={styles.brand}>HUB</span>
+                    </div>
+                    <span
+                      className={`text-uppercase badge badge-pill badge-secondary d-flex align-items-center ${styles.badge}`}
+                    >
+                      Beta
+                    </span>
+                  </div>
+                </>
+              )}
             </Link>
 
             <MobileSettings
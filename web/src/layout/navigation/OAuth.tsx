@@ -10,6 +10,7 @@ interface Loading {
 
 interface Props {
   separatorClassName?: string;
+  hideSeparator?: boolean;
   isLoading: Loading;
   setIsLoading: React.Dispatch<React.SetStateAction<Loading>>;
 }
@@ -41,10 +42,12 @@ const OAuth = (props: Props) => {
 
   return (
     <>
-      <div className={`${props.separatorClassName} my-4 position-relative text-center ${styles.separator}`}>
-        <span className={`p-3 m-auto ${styles.separatorContent}`}>or sign in with</span>
-        <div className={styles.divider} />
-      </div>
+      {!props.hideSeparator && (
+        <div className={`${props.separatorClassName} my-4 position-relative text-center ${styles.separator}`}>
+          <span className={`p-3 m-auto ${styles.separatorContent}`}>or sign in with</span>
+          <div className={styles.divider} />
+        </div>
+      )}
 
       <div>
         <div className="d-flex flex-column mb-1">
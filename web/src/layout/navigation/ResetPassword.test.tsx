@@ -46,7 +46,7 @@ describe('ResetPassword visibleTitle', () => {
 
       await waitFor(() => {
         expect(API.requestPasswordResetCode).toHaveBeenCalledTimes(1);
-        expect(API.requestPasswordResetCode).toHaveBeenCalledWith('test@email.com');
+        expect(API.requestPasswordResetCode).toHaveBeenCalledWith('test@email.com', null);
       });
 
       waitFor(() => {
@@ -5,6 +5,7 @@ import { MdDone } from 'react-icons/md';
 
 import { API } from '../../api';
 import { RefInputField } from '../../types';
+import Captcha from '../common/Captcha';
 import InputField from '../common/InputField';
 
 interface FormValidation {
@@ -25,6 +26,8 @@ const ResetPassword = (props: Props) => {
   const [resetPwdEmail, setResetPwdEmail] = useState('');
   const [isSending, setIsSending] = useState<boolean>(false);
   const [isSuccess, setIsSuccess] = useState<boolean>(false);
+  const [captchaResponse, setCaptchaResponse] = useState<string | null>(null);
+  const [captchaKey, setCaptchaKey] = useState(0);
 
   const onResetPwdEmailChange = (e: React.ChangeEvent<HTMLInputElement>) => {
     setResetPwdEmail(e.target.value);
@@ -32,11 +35,14 @@ const ResetPassword = (props: Props) => {
 
   async function requestPasswordResetCode(email: string) {
     try {
-      await API.requestPasswordResetCode(email);
+      await API.requestPasswordResetCode(email, captchaResponse);
       setIsSuccess(true);
       setIsSending(false);
     } catch (err) {
       setIsSending(false);
+      // Captcha responses can only be verified once, so a new challenge is needed
+      setCaptchaResponse(null);
+      setCaptchaKey(captchaKey + 1);
     } finally {
       if (props.onFinish) {
         props.onFinish();
@@ -110,6 +116,8 @@ const ResetPassword = (props: Props) => {
               required
             />
 
+            <Captcha key={captchaKey} onChange={setCaptchaResponse} />
+
             <div className="text-right">
               <button
                 data-testid="resetPasswordBtn"
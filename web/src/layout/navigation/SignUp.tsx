@@ -19,6 +19,9 @@ interface Props {
 }
 
 const SignUp = (props: Props) => {
+  const isPasswordAuth = document.querySelector(`meta[name='artifacthub:passwordAuth']`)
+    ? document.querySelector(`meta[name='artifacthub:passwordAuth']`)!.getAttribute('content') !== 'false'
+    : true;
   const form = useRef<HTMLFormElement>(null);
   const [apiError, setApiError] = useState<string | null>(null);
   const [activeSignUp, setActiveSignUp] = useState(false);
@@ -77,22 +80,33 @@ const SignUp = (props: Props) => {
         />
       ) : (
         <div className="my-auto">
-          <div className="h5 mb-5 text-center">Create your account using your email</div>
+          {isPasswordAuth ? (
+            <>
+              <div className="h5 mb-5 text-center">Create your account using your email</div>
 
-          <button
-            data-testid="signUpBtn"
-            type="button"
-            onClick={() => setActiveSignUp(true)}
-            className="btn btn-outline-secondary btn-block"
-            disabled={isLoading.status}
-          >
-            <div className="d-flex align-items-center">
-              <FaEnvelope />
-              <div className="flex-grow-1 text-center">Sign up</div>
-            </div>
-          </button>
+              <button
+                data-testid="signUpBtn"
+                type="button"
+                onClick={() => setActiveSignUp(true)}
+                className="btn btn-outline-secondary btn-block"
+                disabled={isLoading.status}
+              >
+                <div className="d-flex align-items-center">
+                  <FaEnvelope />
+                  <div className="flex-grow-1 text-center">Sign up</div>
+                </div>
+              </button>
+            </>
+          ) : (
+            <div className="h5 mb-5 text-center">Create your account using one of the available providers</div>
+          )}
 
-          <OAuth separatorClassName="my-5" isLoading={isLoading} setIsLoading={setIsLoading} />
+          <OAuth
+            separatorClassName="my-5"
+            isLoading={isLoading}
+            setIsLoading={setIsLoading}
+            hideSeparator={!isPasswordAuth}
+          />
         </div>
       )}
     </Modal>
@@ -15,6 +15,7 @@ import License from './License';
 import Links from './Links';
 import Maintainers from './Maintainers';
 import Platforms from './Platforms';
+import Provenance from './Provenance';
 import SecurityReport from './securityReport';
 import Version from './Version';
 import VersionInRow from './VersionInRow';
@@ -243,6 +244,8 @@ const Details = (props: Props) => {
         </>
       )}
 
+      <Provenance provenance={props.package.provenance} />
+
       <ContainersImages containers={props.package.containersImages} packageId={props.package.packageId} />
 
       {props.package.repository.kind === RepositoryKind.Helm &&
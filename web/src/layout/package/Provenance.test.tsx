@@ -0,0 +1,42 @@
+import { render } from '@testing-library/react';
+import React from 'react';
+
+import Provenance from './Provenance';
+
+const defaultProps = {
+  provenance: {
+    predicateType: 'https://slsa.dev/provenance/v0.2',
+    builderId: 'https://github.com/slsa-framework/slsa-github-generator',
+    level: 3,
+    verified: true,
+  },
+};
+
+describe('Provenance', () => {
+  afterEach(() => {
+    jest.resetAllMocks();
+  });
+
+  describe('Render', () => {
+    it('renders component', () => {
+      const { getByText, getByTestId } = render(<Provenance {...defaultProps} />);
+
+      expect(getByText('Provenance')).toBeInTheDocument();
+      expect(getByTestId('slsaLevelBadge')).toHaveTextContent('SLSA level 3');
+      expect(getByTestId('slsaLevelBadge')).toHaveAttribute('title', 'Provenance attestation verified');
+      expect(getByText('https://github.com/slsa-framework/slsa-github-generator')).toBeInTheDocument();
+    });
+
+    it('renders not verified provenance', () => {
+      const { getByTestId } = render(<Provenance provenance={{ ...defaultProps.provenance, level: 1, verified: false }} />);
+
+      expect(getByTestId('slsaLevelBadge')).toHaveTextContent('SLSA level 1');
+      expect(getByTestId('slsaLevelBadge')).toHaveAttribute('title', 'Provenance attestation not verified');
+    });
+
+    it('does not render component if provenance is null', () => {
+      const { container } = render(<Provenance provenance={null} />);
+      expect(container).toBeEmptyDOMElement();
+    });
+  });
+});
@@ -0,0 +1,37 @@
+import isNull from 'lodash/isNull';
+import isUndefined from 'lodash/isUndefined';
+import React from 'react';
+
+import { Provenance as ProvenanceData } from '../../types';
+import SmallTitle from '../common/SmallTitle';
+import styles from './Provenance.module.css';
+
+interface Props {
+  provenance?: ProvenanceData | null;
+}
+
+const Provenance = (props: Props) => {
+  if (isUndefined(props.provenance) || isNull(props.provenance)) return null;
+
+  return (
+    <>
+      <SmallTitle text="Provenance" />
+      <div className="mb-3">
+        <div
+          data-testid="slsaLevelBadge"
+          className={`d-inline badge font-weight-normal mr-2 mb-1 ${styles.badge}`}
+          title={props.provenance.verified ? 'Provenance attestation verified' : 'Provenance attestation not verified'}
+        >
+          SLSA level {props.provenance.level}
+        </div>
+        {props.provenance.builderId !== '' && (
+          <div className={`text-truncate text-muted ${styles.text}`} title={props.provenance.builderId}>
+            {props.provenance.builderId}
+          </div>
+        )}
+      </div>
+    </>
+  );
+};
+
+export default Provenance;
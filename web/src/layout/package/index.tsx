@@ -288,9 +288,18 @@ const PackageView = (props: Props) => {
           className={`d-inline mr-3 ${extraStyle}`}
         />
       )}
+      {detail!.yanked && (
+        <Label
+          text="Yanked"
+          icon={<AiOutlineStop />}
+          labelStyle="warning"
+          className={`d-inline mr-3 ${extraStyle}`}
+        />
+      )}
       <SignedBadge
         repositoryKind={detail!.repository.kind}
         signed={detail!.signed}
+        signatures={detail!.signatures}
         className={`d-inline ${extraStyle}`}
       />
     </>
@@ -785,6 +794,12 @@ const PackageView = (props: Props) => {
                   {!isNull(detail) && (
                     <>
                       <div className={styles.mainContent}>
+                        {detail.yanked && (
+                          <div className="alert alert-warning mb-4" role="alert" data-testid="yankedAlert">
+                            <span className="font-weight-bold">This version has been yanked by its publisher</span>
+                            {detail.yankedReason ? `: ${detail.yankedReason}` : '.'} It's not recommended to install it.
+                          </div>
+                        )}
                         {isNull(detail.readme) || isUndefined(detail.readme) ? (
                           <div className={styles.noReadmeWrapper}>
                             <NoData>No README file available for this package</NoData>
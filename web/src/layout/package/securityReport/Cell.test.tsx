@@ -186,6 +186,43 @@ describe('SecuritySummary', () => {
       expect(cell).toHaveTextContent('-');
     });
 
+    it('renders suppressed vulnerability', () => {
+      const props = {
+        ...defaultProps,
+        vulnerability: {
+          ...defaultProps.vulnerability,
+          Suppressed: true,
+          SuppressionJustification: 'Vulnerable code not used',
+        },
+        isExpanded: true,
+      };
+
+      const { getByTestId, getByText } = render(
+        <table>
+          <tbody>
+            <SecurityCell {...props} />
+          </tbody>
+        </table>
+      );
+
+      expect(getByTestId('suppressedBadge')).toBeInTheDocument();
+      expect(getByTestId('suppressionJustification')).toBeInTheDocument();
+      expect(getByText('Vulnerable code not used')).toBeInTheDocument();
+    });
+
+    it('does not render suppressed badge when vulnerability is not suppressed', () => {
+      const { queryByTestId } = render(
+        <table>
+          <tbody>
+            <SecurityCell {...defaultProps} isExpanded />
+          </tbody>
+        </table>
+      );
+
+      expect(queryByTestId('suppressedBadge')).toBeNull();
+      expect(queryByTestId('suppressionJustification')).toBeNull();
+    });
+
     it('opens vulnerability detail', () => {
       const { queryByTestId, getByTestId, rerender } = render(
         <table>
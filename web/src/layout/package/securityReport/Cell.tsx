@@ -61,6 +61,15 @@ const SecurityCell = (props: Props) => {
         <td className="align-middle text-nowrap">
           {props.vulnerability.VulnerabilityID}
           {getMainReference()}
+          {props.vulnerability.Suppressed && (
+            <span
+              data-testid="suppressedBadge"
+              className={`badge badge-pill border text-muted ml-2 ${styles.suppressedBadge}`}
+              title="Marked as not applicable by the publisher"
+            >
+              Suppressed
+            </span>
+          )}
         </td>
         <td className="align-middle text-nowrap text-uppercase">
           <div className="d-flex flex-row align-items-center">
@@ -91,6 +100,14 @@ const SecurityCell = (props: Props) => {
         <tr data-testid="vulnerabilityDetail" className={styles.noClickableCell}>
           <td colSpan={6}>
             <div className="m-3">
+              {props.vulnerability.Suppressed && props.vulnerability.SuppressionJustification && (
+                <div className="alert alert-light border mb-3" role="alert" data-testid="suppressionJustification">
+                  <small>
+                    <span className="font-weight-bold">Suppressed by the publisher:</span>{' '}
+                    {props.vulnerability.SuppressionJustification}
+                  </small>
+                </div>
+              )}
               {isUndefined(props.vulnerability.title) && isUndefined(props.vulnerability.Description) ? (
                 <div className="font-italic">Any information about this vulnerability</div>
               ) : (
@@ -73,8 +73,12 @@ export interface Package {
   keywords?: string[];
   maintainers?: Maintainer[];
   deprecated: boolean | null;
+  yanked?: boolean;
+  yankedReason?: string;
   isOperator?: boolean | null;
   signed: boolean | null;
+  signatures?: Signature[] | null;
+  provenance?: Provenance | null;
   links?: PackageLink[];
   stars?: number | null;
   eventKinds?: EventKind[];
@@ -98,6 +102,21 @@ export interface Package {
   official?: boolean;
 }
 
+export interface Signature {
+  kind: string;
+  identity?: string;
+  issuer?: string;
+  verified: boolean;
+}
+
+export interface Provenance {
+  predicateType: string;
+  builderId: string;
+  level: number;
+  identity?: string;
+  verified: boolean;
+}
+
 export interface ContainerImage {
   image: string;
   name?: string;
@@ -107,6 +126,7 @@ export interface Version {
   version: string;
   containsSecurityUpdates: boolean;
   prerelease: boolean;
+  yanked?: boolean;
   ts: number;
 }
 
@@ -252,11 +272,19 @@ export interface User extends UserLogin {
   lastName?: string;
 }
 
+export enum OrganizationRole {
+  Owner = 'owner',
+  Admin = 'admin',
+  Member = 'member',
+  Viewer = 'viewer',
+}
+
 export interface Member {
   alias: string;
   firstName?: string;
   lastName?: string;
   confirmed?: boolean;
+  role?: OrganizationRole;
 }
 
 export interface UserAuth {
@@ -284,6 +312,13 @@ export interface Organization {
   description?: string;
   membersCount?: number | null;
   confirmed?: boolean | null;
+  deletionScheduledAt?: number;
+}
+
+export interface DeleteOrganizationInput {
+  confirmation: string;
+  transferRepositoriesToOrg?: string;
+  transferRepositoriesToUser?: boolean;
 }
 
 export interface RefInputField {
@@ -367,6 +402,13 @@ export interface TestWebhook {
   eventKinds: EventKind[];
 }
 
+export interface WebhookTestResult {
+  statusCode: number;
+  latency: number;
+  responseBody: string;
+  error?: string;
+}
+
 export interface Webhook extends TestWebhook {
   webhookId?: string;
   name: string;
@@ -408,7 +450,11 @@ export interface NavSection {
 export interface APIKey {
   apiKeyId?: string;
   name: string;
+  scopes?: string[];
   createdAt?: number;
+  lastUsedAt?: number;
+  lastUsedIp?: string;
+  requestsLast30Days?: number;
 }
 
 export interface APIKeyCode {
@@ -447,10 +493,12 @@ export enum AuthorizerAction {
   DeleteOrganization = 'deleteOrganization',
   DeleteOrganizationMember = 'deleteOrganizationMember',
   DeleteOrganizationRepository = 'deleteOrganizationRepository',
+  GetAuditLog = 'getAuditLog',
   GetAuthorizationPolicy = 'getAuthorizationPolicy',
   TransferOrganizationRepository = 'transferOrganizationRepository',
   UpdateAuthorizationPolicy = 'updateAuthorizationPolicy',
   UpdateOrganization = 'updateOrganization',
+  UpdateOrganizationMemberRole = 'updateOrganizationMemberRole',
   UpdateOrganizationRepository = 'updateOrganizationRepository',
 }
 
@@ -30,6 +30,11 @@ export interface SubscriptionItem {
   enabled: boolean;
 }
 
+export interface APIKeyScopeItem {
+  name: string;
+  description: string;
+}
+
 export interface PayloadKindsItem {
   kind: PayloadKind;
   name: string;
@@ -162,6 +167,25 @@ export const REPOSITORY_KINDS: RepoKindDef[] = [
   },
 ];
 
+export const API_KEY_SCOPES: APIKeyScopeItem[] = [
+  {
+    name: 'read-only',
+    description: 'Read any resource you have access to',
+  },
+  {
+    name: 'packages:read',
+    description: 'Read packages information',
+  },
+  {
+    name: 'repos:write',
+    description: 'Manage repositories',
+  },
+  {
+    name: 'webhooks:manage',
+    description: 'Manage webhooks',
+  },
+];
+
 export const PAYLOAD_KINDS_LIST: PayloadKindsItem[] = [
   {
     kind: PayloadKind.default,
@@ -323,6 +347,7 @@ user_roles[role] {
             AuthorizerAction.DeleteOrganization,
             AuthorizerAction.DeleteOrganizationMember,
             AuthorizerAction.DeleteOrganizationRepository,
+            AuthorizerAction.GetAuditLog,
             AuthorizerAction.GetAuthorizationPolicy,
             AuthorizerAction.TransferOrganizationRepository,
             AuthorizerAction.UpdateAuthorizationPolicy,